@@ -0,0 +1,418 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// secret returns the value of the environment variable key, or the first line of stdin
+func (a *app) secret(key, name string) (string, error) {
+	if value := a.getenv(key); value != "" {
+		return value, nil
+	}
+	fmt.Fprintf(a.stderr, "%s: ", name)
+	line, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", fmt.Errorf("no %s, set %s or write it to stdin", name, key)
+	}
+	return line, nil
+}
+
+func (a *app) newLoginCommand(g *globals) *cobra.Command {
+	var tenantID, account, mfaCode string
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in and keep the tokens in the token file",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := required(map[string]string{"tenant": tenantID, "account": account}); err != nil {
+				return err
+			}
+			return a.login(cmd.Context(), g, tenantID, account, mfaCode)
+		},
+	}
+	cmd.Flags().StringVar(&tenantID, "tenant", a.getenv("ERPCTL_TENANT"), "id of the tenant of the user (ERPCTL_TENANT)")
+	cmd.Flags().StringVar(&account, "account", a.getenv("ERPCTL_ACCOUNT"), "email or username of the user (ERPCTL_ACCOUNT)")
+	cmd.Flags().StringVar(&mfaCode, "mfa-code", "", "TOTP or recovery code of the users with MFA enabled")
+	return cmd
+}
+
+func (a *app) login(ctx context.Context, g *globals, tenantID, account, mfaCode string) error {
+	password, err := a.secret("ERPCTL_PASSWORD", "password")
+	if err != nil {
+		return err
+	}
+	c, err := a.connect(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	tokens, err := c.Auth().Login(ctx, &client.LoginRequest{TenantID: tenantID, Account: account, Password: password, MFACode: mfaCode})
+	if err != nil {
+		return err
+	}
+	if err := writeTokens(g.tokenFile, tokens); err != nil {
+		return err
+	}
+	identity, err := c.Identity()
+	if err != nil {
+		return err
+	}
+	expiresAt := tokens.RefreshTokenExpiry.UTC().Format(time.RFC3339)
+	return a.printer(g).print(
+		map[string]string{"tenant_id": identity.TenantID, "user_id": identity.UserID, "username": identity.Username, "session_expires_at": expiresAt},
+		[]string{"TENANT", "USER", "USERNAME", "SESSION EXPIRES"},
+		[][]string{{identity.TenantID, identity.UserID, identity.Username, expiresAt}},
+	)
+}
+
+func (a *app) newLogoutCommand(g *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Revoke the tokens of the token file and remove it",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return a.logout(cmd.Context(), g)
+		},
+	}
+}
+
+func (a *app) logout(ctx context.Context, g *globals) error {
+	c, done, err := a.session(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if err := c.Logout(ctx); err != nil {
+		return err
+	}
+	if g.token == "" {
+		if err := os.Remove(g.tokenFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return a.printer(g).print(map[string]bool{"logged_out": true}, []string{"LOGGED OUT"}, [][]string{{"true"}})
+}
+
+// tenantOptions are the flags of tenant create
+type tenantOptions struct {
+	name, slug, domain, email, status string
+}
+
+func (a *app) newTenantCreateCommand(g *globals) *cobra.Command {
+	var opts tenantOptions
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a tenant",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := required(map[string]string{"name": opts.name, "email": opts.email}); err != nil {
+				return err
+			}
+			return a.tenantCreate(cmd.Context(), g, &opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.name, "name", "", "name of the tenant")
+	cmd.Flags().StringVar(&opts.slug, "slug", "", "URL name of the tenant")
+	cmd.Flags().StringVar(&opts.domain, "domain", "", "email domain of the tenant")
+	cmd.Flags().StringVar(&opts.email, "email", "", "contact email of the tenant")
+	cmd.Flags().StringVar(&opts.status, "status", "active", "status of the tenant: active or trial")
+	return cmd
+}
+
+func (a *app) tenantCreate(ctx context.Context, g *globals, opts *tenantOptions) error {
+	tenantStatus, ok := authv1.TenantStatus_value["TENANT_STATUS_"+strings.ToUpper(opts.status)]
+	if !ok {
+		return usageErrorf("invalid status %q", opts.status)
+	}
+	c, done, err := a.session(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer done()
+	identity, err := c.Identity()
+	if err != nil {
+		return err
+	}
+	tenantID, err := c.Tenants().Create(ctx, &client.Tenant{
+		Name:      opts.name,
+		Slug:      opts.slug,
+		Domain:    opts.domain,
+		Status:    authv1.TenantStatus(tenantStatus),
+		Contact:   &authv1.ContactInfo{Email: opts.email},
+		CreatedBy: identity.UserID,
+	})
+	if err != nil {
+		return err
+	}
+	return a.printer(g).print(
+		map[string]string{"tenant_id": tenantID, "name": opts.name},
+		[]string{"ID", "NAME"},
+		[][]string{{tenantID, opts.name}},
+	)
+}
+
+// userOptions are the flags of user create
+type userOptions struct {
+	email, username, firstName, lastName, roleID string
+}
+
+func (a *app) newUserCreateCommand(g *globals) *cobra.Command {
+	var opts userOptions
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user of the tenant of the session",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.email == "" && opts.username == "" {
+				return usageErrorf("--email or --username is required")
+			}
+			return a.userCreate(cmd.Context(), g, &opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.email, "email", "", "email of the user")
+	cmd.Flags().StringVar(&opts.username, "username", "", "username of the user")
+	cmd.Flags().StringVar(&opts.firstName, "first-name", "", "first name of the user")
+	cmd.Flags().StringVar(&opts.lastName, "last-name", "", "last name of the user")
+	cmd.Flags().StringVar(&opts.roleID, "role", "", "id of a role assigned to the user")
+	return cmd
+}
+
+func (a *app) userCreate(ctx context.Context, g *globals, opts *userOptions) error {
+	password, err := a.secret("ERPCTL_USER_PASSWORD", "password of the new user")
+	if err != nil {
+		return err
+	}
+	c, done, err := a.session(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer done()
+	identity, err := c.Identity()
+	if err != nil {
+		return err
+	}
+	userID, err := c.Users().Create(ctx, &client.User{
+		TenantId: identity.TenantID,
+		Email:    opts.email,
+		Username: opts.username,
+		Status:   authv1.UserStatus_USER_STATUS_ACTIVE,
+		Profile:  &authv1.UserProfile{FirstName: opts.firstName, LastName: opts.lastName},
+	}, password)
+	if err != nil {
+		return err
+	}
+	if opts.roleID != "" {
+		if err := c.Users().AssignRole(ctx, userID, opts.roleID, time.Time{}); err != nil {
+			return fmt.Errorf("user %s created, failed to assign role %s: %w", userID, opts.roleID, err)
+		}
+	}
+	return a.printer(g).print(
+		map[string]string{"user_id": userID, "tenant_id": identity.TenantID, "email": opts.email, "username": opts.username},
+		[]string{"ID", "TENANT", "EMAIL", "USERNAME"},
+		[][]string{{userID, identity.TenantID, opts.email, opts.username}},
+	)
+}
+
+func (a *app) newRoleAssignCommand(g *globals) *cobra.Command {
+	var userID, roleID string
+	var expiresIn time.Duration
+	cmd := &cobra.Command{
+		Use:   "assign",
+		Short: "Assign a role to a user",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := required(map[string]string{"user": userID, "role": roleID}); err != nil {
+				return err
+			}
+			return a.roleAssign(cmd.Context(), g, userID, roleID, expiresIn)
+		},
+	}
+	cmd.Flags().StringVar(&userID, "user", "", "id of the user")
+	cmd.Flags().StringVar(&roleID, "role", "", "id of the role")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "how long the user holds the role, for good when 0")
+	return cmd
+}
+
+func (a *app) roleAssign(ctx context.Context, g *globals, userID, roleID string, expiresIn time.Duration) error {
+	var expiresAt time.Time
+	if expiresIn > 0 {
+		expiresAt = time.Now().Add(expiresIn)
+	}
+	c, done, err := a.session(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if err := c.Users().AssignRole(ctx, userID, roleID, expiresAt); err != nil {
+		return err
+	}
+	expires := ""
+	if !expiresAt.IsZero() {
+		expires = expiresAt.UTC().Format(time.RFC3339)
+	}
+	return a.printer(g).print(
+		map[string]string{"user_id": userID, "role_id": roleID, "expires_at": expires},
+		[]string{"USER", "ROLE", "EXPIRES"},
+		[][]string{{userID, roleID, expires}},
+	)
+}
+
+func (a *app) newPermissionListCommand(g *globals) *cobra.Command {
+	var tenantID string
+	var effective bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the permissions of a tenant, or those of the session user",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return a.permissionList(cmd.Context(), g, tenantID, effective)
+		},
+	}
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "id of the tenant, the tenant of the session when empty")
+	cmd.Flags().BoolVar(&effective, "effective", false, "list the permissions held by the user of the session instead")
+	return cmd
+}
+
+func (a *app) permissionList(ctx context.Context, g *globals, tenantID string, effective bool) error {
+	c, done, err := a.session(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if effective {
+		permissions, err := c.RBAC().Permissions(ctx)
+		if err != nil {
+			return err
+		}
+		rows := [][]string{}
+		for _, permission := range sortedKeys(permissions) {
+			rows = append(rows, []string{permission, strconv.FormatBool(permissions[permission])})
+		}
+		return a.printer(g).print(permissions, []string{"PERMISSION", "GRANTED"}, rows)
+	}
+	permissions, err := c.RBAC().ListPermissions(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{}
+	for _, permission := range permissions {
+		rows = append(rows, []string{
+			permission.GetPermissionString(),
+			permission.GetDisplayName(),
+			permission.GetCategory(),
+			strconv.FormatBool(permission.GetIsDangerous()),
+		})
+	}
+	return a.printer(g).print(protoListJSON(permissions), []string{"PERMISSION", "NAME", "CATEGORY", "DANGEROUS"}, rows)
+}
+
+func (a *app) newSessionRevokeCommand(g *globals) *cobra.Command {
+	var tenantID, userID, deviceID string
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke the sessions of a tenant or of a device of a user",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			byDevice := userID != "" || deviceID != ""
+			if byDevice == (tenantID != "") {
+				return usageErrorf("either --tenant or --user and --device is required")
+			}
+			if byDevice {
+				if err := required(map[string]string{"user": userID, "device": deviceID}); err != nil {
+					return err
+				}
+			}
+			return a.sessionRevoke(cmd.Context(), g, tenantID, userID, deviceID)
+		},
+	}
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "id of the tenant whose sessions are all revoked")
+	cmd.Flags().StringVar(&userID, "user", "", "id of the user whose device session is revoked, with --device")
+	cmd.Flags().StringVar(&deviceID, "device", "", "id of the device of the user")
+	return cmd
+}
+
+func (a *app) sessionRevoke(ctx context.Context, g *globals, tenantID, userID, deviceID string) error {
+	c, done, err := a.session(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer done()
+	if tenantID == "" {
+		revoked, err := c.Users().RevokeDevice(ctx, userID, deviceID)
+		if err != nil {
+			return err
+		}
+		return a.printer(g).print(
+			map[string]any{"user_id": userID, "device_id": deviceID, "session_revoked": revoked},
+			[]string{"USER", "DEVICE", "SESSION REVOKED"},
+			[][]string{{userID, deviceID, strconv.FormatBool(revoked)}},
+		)
+	}
+	accessTokens, refreshTokens, err := c.Auth().RevokeTenantSessions(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	return a.printer(g).print(
+		map[string]any{"tenant_id": tenantID, "access_tokens_revoked": accessTokens, "refresh_tokens_revoked": refreshTokens},
+		[]string{"TENANT", "ACCESS TOKENS REVOKED", "REFRESH TOKENS REVOKED"},
+		[][]string{{tenantID, strconv.Itoa(int(accessTokens)), strconv.Itoa(int(refreshTokens))}},
+	)
+}
+
+func (a *app) newAuditTailCommand(g *globals) *cobra.Command {
+	var filter client.AuditFilter
+	var since time.Duration
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Stream the audit log of a tenant until interrupted",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if since > 0 {
+				filter.Since = time.Now().Add(-since)
+			}
+			return a.auditTail(cmd.Context(), g, filter)
+		},
+	}
+	cmd.Flags().StringVar(&filter.TargetTenantID, "tenant", "", "id of the tenant, the tenant of the session when empty")
+	cmd.Flags().StringVar(&filter.ActorID, "actor", "", "id of the user or API key that did the actions")
+	cmd.Flags().StringVar(&filter.ResourceType, "resource", "", "type of the resource of the actions, e.g. user, role or token")
+	cmd.Flags().DurationVar(&since, "since", 0, "age of the oldest event streamed, every stored event when 0")
+	cmd.Flags().StringVar(&filter.Cursor, "cursor", "", "cursor of the last event received, the stream resumes after it")
+	return cmd
+}
+
+func (a *app) auditTail(ctx context.Context, g *globals, filter client.AuditFilter) error {
+	c, done, err := a.session(ctx, g)
+	if err != nil {
+		return err
+	}
+	defer done()
+	p := a.printer(g)
+	err = c.Audit().Tail(ctx, filter, func(log *client.AuditLog, cursor string) error {
+		return p.printLine(
+			map[string]any{"cursor": cursor, "log": protoJSON(log)},
+			[]string{formatTime(log.GetTimestamp()), log.GetSeverity(), log.GetAction(), "actor=" + log.GetActorId(), log.GetTargetType() + "=" + log.GetTargetId()},
+		)
+	})
+	if ctx.Err() != nil {
+		// Interrupted
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,215 @@
+// Command erpctl administers the ERP through the gRPC APIs of the auth service, see pkg/client:
+//
+//	erpctl login --tenant <id> --account admin@acme.com     # the password is read from ERPCTL_PASSWORD or stdin
+//	erpctl tenant create --name Acme --email ops@acme.com
+//	erpctl user create --email jane@acme.com --role <id>    # the password is read from ERPCTL_USER_PASSWORD or stdin
+//	erpctl role assign --user <id> --role <id>
+//	erpctl permission list [--effective]
+//	erpctl session revoke --tenant <id> | --user <id> --device <id>
+//	erpctl audit tail [--actor <id>] [--resource user] [--since 1h]
+//	erpctl logout
+//
+// The tokens of login are kept in the token file, refreshed by the following commands and revoked by logout.
+// --token (ERPCTL_TOKEN) replaces them with an access token obtained elsewhere. Every command prints a table, or
+// JSON with --output json
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"erp.localhost/internal/infra/buildinfo"
+	"erp.localhost/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultAddress = "localhost:5000"
+	outputTable    = "table"
+	outputJSON     = "json"
+)
+
+// usageError is returned for invalid arguments, the command exits with 2 and points to its help
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// app is the environment of the commands
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+	// connect opens the client of the auth service, replaced by the tests
+	connect func(ctx context.Context, g *globals) (*client.Client, error)
+}
+
+// globals are the persistent flags shared by every command
+type globals struct {
+	address   string
+	insecure  bool
+	certsDir  string
+	token     string
+	tokenFile string
+	output    string
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv, connect: connect}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// run runs the command of args and returns the exit code: 2 for invalid arguments and 1 for failed commands
+func (a *app) run(ctx context.Context, args []string) int {
+	root := a.newRootCommand()
+	root.SetArgs(args)
+	cmd, err := root.ExecuteContextC(ctx)
+	if err == nil {
+		return 0
+	}
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintf(a.stderr, "Error: %v\nRun '%s --help' for usage.\n", err, cmd.CommandPath())
+		return 2
+	}
+	fmt.Fprintf(a.stderr, "%s: %v\n", cmd.CommandPath(), err)
+	return 1
+}
+
+// newRootCommand returns the command tree of erpctl, its persistent flags are bound to the globals of the commands
+func (a *app) newRootCommand() *cobra.Command {
+	g := &globals{}
+	root := &cobra.Command{
+		Use:           "erpctl",
+		Short:         "Administer the ERP through the APIs of the auth service",
+		Version:       buildinfo.Get().Version,
+		Args:          noArgs,
+		RunE:          showHelp,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			if g.output != outputTable && g.output != outputJSON {
+				return usageErrorf("invalid output %q, table or json", g.output)
+			}
+			return nil
+		},
+	}
+	root.SetIn(a.stdin)
+	root.SetOut(a.stdout)
+	root.SetErr(a.stderr)
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&g.address, "address", a.env("ERPCTL_ADDRESS", defaultAddress), "address of the auth service (ERPCTL_ADDRESS)")
+	flags.BoolVar(&g.insecure, "insecure", a.getenv("ERPCTL_INSECURE") == "true", "connect without TLS (ERPCTL_INSECURE)")
+	flags.StringVar(&g.certsDir, "certs-dir", a.getenv("ERPCTL_CERTS_DIR"), "directory of the CA, certificate and key of the mTLS connection (ERPCTL_CERTS_DIR)")
+	flags.StringVar(&g.token, "token", a.getenv("ERPCTL_TOKEN"), "access token used instead of the tokens of login (ERPCTL_TOKEN)")
+	flags.StringVar(&g.tokenFile, "token-file", a.env("ERPCTL_TOKEN_FILE", defaultTokenFile()), "file keeping the tokens of login (ERPCTL_TOKEN_FILE)")
+	flags.StringVarP(&g.output, "output", "o", outputTable, "output format: table or json")
+
+	root.AddCommand(
+		a.newLoginCommand(g),
+		a.newLogoutCommand(g),
+		group("tenant", "Manage the tenants", a.newTenantCreateCommand(g)),
+		group("user", "Manage the users of the tenant of the session", a.newUserCreateCommand(g)),
+		group("role", "Manage the roles of the users", a.newRoleAssignCommand(g)),
+		group("permission", "Inspect the permissions", a.newPermissionListCommand(g)),
+		group("session", "Manage the sessions of the users", a.newSessionRevokeCommand(g)),
+		group("audit", "Read the audit log", a.newAuditTailCommand(g)),
+	)
+	return root
+}
+
+// group returns a command grouping the subcommands of a resource, it only prints its help
+func group(name, short string, subcommands ...*cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{Use: name, Short: short, Args: noArgs, RunE: showHelp}
+	cmd.AddCommand(subcommands...)
+	return cmd
+}
+
+func showHelp(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
+
+// noArgs refuses the positional arguments, unknown subcommands included
+func noArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.NoArgs(cmd, args); err != nil {
+		return &usageError{err: err}
+	}
+	return nil
+}
+
+// required reports the first empty flag of values, by name
+func required(values map[string]string) error {
+	for _, name := range sortedKeys(values) {
+		if values[name] == "" {
+			return usageErrorf("--%s is required", name)
+		}
+	}
+	return nil
+}
+
+func (a *app) env(key, fallback string) string {
+	if value := a.getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// connect opens the client of the auth service at the address of g
+func connect(ctx context.Context, g *globals) (*client.Client, error) {
+	// The output of the commands is kept apart from the logs of the client
+	opts := []client.Option{client.WithLogger(stderrLogger{w: os.Stderr})}
+	if g.insecure {
+		opts = append(opts, client.WithInsecure())
+	}
+	if g.certsDir != "" {
+		opts = append(opts, client.WithCertsDir(g.certsDir))
+	}
+	return client.New(ctx, g.address, opts...)
+}
+
+// stderrLogger writes the warnings and errors of the client to w, as the operator has no use for its other logs
+type stderrLogger struct {
+	w io.Writer
+}
+
+func (l stderrLogger) Trace(string, ...any) {}
+func (l stderrLogger) Debug(string, ...any) {}
+func (l stderrLogger) Info(string, ...any)  {}
+func (l stderrLogger) Warn(msg string, fields ...any) {
+	fmt.Fprintln(l.w, append([]any{"warning:", msg}, fields...)...)
+}
+func (l stderrLogger) Error(msg string, fields ...any) {
+	fmt.Fprintln(l.w, append([]any{"error:", msg}, fields...)...)
+}
+func (l stderrLogger) Fatal(msg string, fields ...any) {
+	l.Error(msg, fields...)
+	os.Exit(1)
+}
+func (l stderrLogger) WithContext(context.Context) client.Logger { return l }
+
+func defaultTokenFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".erpctl-tokens.json"
+	}
+	return filepath.Join(dir, "erpctl", "tokens.json")
+}
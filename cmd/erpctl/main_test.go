@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/pkg/client"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeConn answers the calls of the commands with the responses by method and records their requests
+type fakeConn struct {
+	responses map[string]proto.Message
+	requests  map[string]any
+}
+
+func (f *fakeConn) Invoke(_ context.Context, method string, args, reply any, _ ...grpc.CallOption) error {
+	f.requests[method] = args
+	res, ok := f.responses[method]
+	if !ok {
+		return status.Error(codes.Unimplemented, method)
+	}
+	proto.Merge(reply.(proto.Message), res)
+	return nil
+}
+
+func (f *fakeConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streams")
+}
+
+// newTestApp returns an app over conn, logged in as user-1 of tenant-1 through its token file
+func newTestApp(t *testing.T, conn *fakeConn) (*app, *bytes.Buffer, *bytes.Buffer, string) {
+	t.Helper()
+	access, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &token.JWTAccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		TenantID:         "tenant-1",
+		UserID:           "user-1",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	tokenFile := filepath.Join(t.TempDir(), "erpctl", "tokens.json")
+	require.NoError(t, writeTokens(tokenFile, &client.Tokens{AccessToken: access, RefreshToken: "refresh-1"}))
+
+	var stdout, stderr bytes.Buffer
+	env := map[string]string{"ERPCTL_TOKEN_FILE": tokenFile}
+	a := &app{
+		stdin:  strings.NewReader(""),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string { return env[key] },
+		connect: func(context.Context, *globals) (*client.Client, error) {
+			return client.NewFromConn(conn, client.WithLogger(stderrLogger{w: &stderr})), nil
+		},
+	}
+	return a, &stdout, &stderr, tokenFile
+}
+
+func TestRun_Usage(t *testing.T) {
+	a, stdout, stderr, _ := newTestApp(t, &fakeConn{})
+	assert.Equal(t, 0, a.run(context.Background(), nil))
+	assert.Contains(t, stdout.String(), "session")
+	stdout.Reset()
+	assert.Equal(t, 0, a.run(context.Background(), []string{"session", "--help"}))
+	assert.Contains(t, stdout.String(), "revoke")
+	assert.Equal(t, 2, a.run(context.Background(), []string{"tenant", "delete"}))
+	assert.Contains(t, stderr.String(), `unknown command "delete" for "erpctl tenant"`)
+	assert.Equal(t, 2, a.run(context.Background(), []string{"role", "assign", "--user", "user-2"}))
+	assert.Contains(t, stderr.String(), "--role is required")
+	assert.Equal(t, 2, a.run(context.Background(), []string{"role", "assign", "--unknown"}))
+	assert.Equal(t, 2, a.run(context.Background(), []string{"session", "revoke", "--tenant", "t", "--user", "u"}))
+	assert.Equal(t, 2, a.run(context.Background(), []string{"permission", "list", "-o", "yaml"}))
+}
+
+func TestRun_TokenFlag(t *testing.T) {
+	conn := &fakeConn{
+		requests: map[string]any{},
+		responses: map[string]proto.Message{
+			authv1.PermissionService_ListPermissions_FullMethodName: &authv1.ListPermissionsResponse{},
+		},
+	}
+	a, _, stderr, tokenFile := newTestApp(t, conn)
+	tokens, err := readTokens(tokenFile)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(tokenFile))
+
+	// The access token of --token is used without a token file, which is left alone
+	require.Equal(t, 0, a.run(context.Background(), []string{"--token", tokens.AccessToken, "permission", "list"}), stderr.String())
+	assert.Equal(t, "tenant-1", conn.requests[authv1.PermissionService_ListPermissions_FullMethodName].(*authv1.ListPermissionsRequest).GetTargetTenantId())
+	assert.NoFileExists(t, tokenFile)
+}
+
+func TestRun_NotLoggedIn(t *testing.T) {
+	a, _, stderr, tokenFile := newTestApp(t, &fakeConn{})
+	require.NoError(t, os.Remove(tokenFile))
+	assert.Equal(t, 1, a.run(context.Background(), []string{"permission", "list"}))
+	assert.Contains(t, stderr.String(), "not logged in")
+}
+
+func TestWriteTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "erpctl", "tokens.json")
+	tokens := &client.Tokens{AccessToken: "access", RefreshToken: "refresh", RefreshTokenExpiry: time.Unix(1700000000, 0).UTC()}
+	require.NoError(t, writeTokens(path, tokens))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	read, err := readTokens(path)
+	require.NoError(t, err)
+	assert.Equal(t, tokens, read)
+}
+
+func TestPermissionList(t *testing.T) {
+	conn := &fakeConn{
+		requests: map[string]any{},
+		responses: map[string]proto.Message{
+			authv1.PermissionService_ListPermissions_FullMethodName: &authv1.ListPermissionsResponse{Permissions: []*authv1.Permission{
+				{PermissionString: "orders:create", DisplayName: "Create orders", Category: "orders"},
+				{PermissionString: "users:delete", DisplayName: "Delete users", Category: "users", IsDangerous: true},
+			}},
+			authv1.VerificationService_GetUserPermissions_FullMethodName: &authv1.GetUserPermissionsResponse{
+				Permissions: map[string]bool{"users:read": true, "orders:create": true},
+			},
+		},
+	}
+	a, stdout, _, _ := newTestApp(t, conn)
+
+	require.Equal(t, 0, a.run(context.Background(), []string{"permission", "list"}))
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"PERMISSION", "NAME", "CATEGORY", "DANGEROUS"}, strings.Fields(lines[0])[:4])
+	assert.Contains(t, lines[2], "users:delete")
+	assert.Equal(t, "tenant-1", conn.requests[authv1.PermissionService_ListPermissions_FullMethodName].(*authv1.ListPermissionsRequest).GetTargetTenantId())
+
+	stdout.Reset()
+	require.Equal(t, 0, a.run(context.Background(), []string{"permission", "list", "--effective", "--output", "json"}))
+	var effective map[string]bool
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &effective))
+	assert.Equal(t, map[string]bool{"users:read": true, "orders:create": true}, effective)
+}
+
+func TestTenantCreate(t *testing.T) {
+	conn := &fakeConn{
+		requests: map[string]any{},
+		responses: map[string]proto.Message{
+			authv1.TenantService_CreateTenant_FullMethodName: &authv1.CreateTenantResponse{TenantId: "tenant-2"},
+		},
+	}
+	a, stdout, _, _ := newTestApp(t, conn)
+
+	require.Equal(t, 0, a.run(context.Background(), []string{"tenant", "create", "--name", "Acme", "--email", "ops@acme.com", "--status", "trial", "--output", "json"}))
+	var created map[string]string
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &created))
+	assert.Equal(t, "tenant-2", created["tenant_id"])
+
+	req := conn.requests[authv1.TenantService_CreateTenant_FullMethodName].(*authv1.CreateTenantRequest)
+	assert.Equal(t, "user-1", req.GetIdentifier().GetUserId())
+	assert.Equal(t, "Acme", req.GetTenant().GetName())
+	assert.Equal(t, authv1.TenantStatus_TENANT_STATUS_TRIAL, req.GetTenant().GetStatus())
+	assert.Equal(t, "user-1", req.GetTenant().GetCreatedBy())
+	assert.Equal(t, "ops@acme.com", req.GetTenant().GetContact().GetEmail())
+}
+
+func TestSessionRevoke_Tenant(t *testing.T) {
+	conn := &fakeConn{
+		requests: map[string]any{},
+		responses: map[string]proto.Message{
+			authv1.AuthService_RevokeAllTenantTokens_FullMethodName: &authv1.RevokeAllTenantTokensResponse{Revoked: true, AccessTokensRevoked: 4, RefreshTokensRevoked: 2},
+		},
+	}
+	a, stdout, _, _ := newTestApp(t, conn)
+
+	require.Equal(t, 0, a.run(context.Background(), []string{"session", "revoke", "--tenant", "tenant-2"}))
+	assert.Equal(t, []string{"tenant-2", "4", "2"}, strings.Fields(strings.Split(strings.TrimSpace(stdout.String()), "\n")[1]))
+	assert.Equal(t, "tenant-2", conn.requests[authv1.AuthService_RevokeAllTenantTokens_FullMethodName].(*authv1.RevokeAllTenantTokensRequest).GetTargetTenantId())
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// printer writes the results of a command as a table or as indented JSON
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (a *app) printer(g *globals) *printer {
+	return &printer{w: a.stdout, format: g.output}
+}
+
+// print writes rows under headers, or value as JSON
+func (p *printer) print(value any, headers []string, rows [][]string) error {
+	if p.format == outputJSON {
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(data))
+		return err
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printLine writes one row of a stream, without headers, or value as one line of JSON
+func (p *printer) printLine(value any, row []string) error {
+	if p.format == outputJSON {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(data))
+		return err
+	}
+	_, err := fmt.Fprintln(p.w, strings.Join(row, "  "))
+	return err
+}
+
+// protoJSON encodes m with its proto field names, for the JSON output
+func protoJSON(m proto.Message) json.RawMessage {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return data
+}
+
+// protoListJSON encodes messages as a JSON array
+func protoListJSON[M proto.Message](messages []M) []json.RawMessage {
+	list := make([]json.RawMessage, 0, len(messages))
+	for _, m := range messages {
+		list = append(list, protoJSON(m))
+	}
+	return list
+}
+
+// formatTime formats t in UTC for the tables, empty when it is not set
+func formatTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.AsTime().UTC().Format(time.RFC3339)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"erp.localhost/pkg/client"
+)
+
+// errNotLoggedIn is returned by the commands run without tokens
+var errNotLoggedIn = errors.New("not logged in, run erpctl login or set ERPCTL_TOKEN")
+
+// readTokens reads the tokens kept by login
+func readTokens(path string) (*client.Tokens, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tokens := &client.Tokens{}
+	if err := json.Unmarshal(data, tokens); err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %w", path, err)
+	}
+	return tokens, nil
+}
+
+// writeTokens keeps tokens in path, readable by the user only. The file is replaced at once so a failed write
+// leaves the previous tokens
+func writeTokens(path string, tokens *client.Tokens) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tokens-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// session opens the client and starts its session with ERPCTL_TOKEN or the tokens of the token file. The returned
+// function keeps the tokens refreshed by the command in the token file and closes the client
+func (a *app) session(ctx context.Context, g *globals) (*client.Client, func(), error) {
+	var tokens *client.Tokens
+	fromEnv := g.token != ""
+	if fromEnv {
+		tokens = &client.Tokens{AccessToken: g.token}
+	} else {
+		var err error
+		tokens, err = readTokens(g.tokenFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, errNotLoggedIn
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	c, err := a.connect(ctx, g)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.SetTokens(tokens); err != nil {
+		c.Close()
+		return nil, nil, fmt.Errorf("invalid access token: %w", err)
+	}
+	done := func() {
+		defer c.Close()
+		current := c.Tokens()
+		if fromEnv || current == nil || current.AccessToken == tokens.AccessToken {
+			return
+		}
+		if err := writeTokens(g.tokenFile, current); err != nil {
+			fmt.Fprintf(a.stderr, "failed to keep the refreshed tokens in %s: %v\n", g.tokenFile, err)
+		}
+	}
+	return c, done, nil
+}
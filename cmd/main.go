@@ -1,12 +1,175 @@
+// Command erp runs the services of the ERP system. Each service runs on its own for a microservice deployment, or
+// several run concurrently in one process:
+//
+//	erp serve auth              # the auth service
+//	erp serve auth core         # the auth and core services in one process
+//	erp serve all               # the init job, then every service
+//	erp init                    # seeds the system data and exits
+//	erp migrate                 # upgrades the stored documents to the latest schema versions and exits
+//	erp migrate status          # shows the schema versions applied to the collections
+//
+// The services read their settings from the environment, CONFIG_FILE and the flags following the service names,
+// see infra_config.Load. Services running in one process read the same environment and flags, so the settings
+// of a single service, e.g. SERVER_PORT, are only set when it runs alone.
+//
+// With --dev-inmemory the services keep their data in the memory of the process instead of Mongo and Redis, for
+// local development without the servers. `erp serve all --dev-inmemory` seeds and runs everything in one process
 package main
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
 	server_auth "erp.localhost/internal/auth/cmd"
+	server_config "erp.localhost/internal/config/cmd"
+	server_core "erp.localhost/internal/core/cmd"
+	server_gateway "erp.localhost/internal/gateway/cmd"
+	"erp.localhost/internal/infra/db/memory"
 	server_init "erp.localhost/internal/init/cmd"
 )
 
+// allServices selects every service, after the init job
+const allServices = "all"
+
+// devInMemoryFlag switches the process to the in-memory stores, see memory.Enable
+const devInMemoryFlag = "dev-inmemory"
+
+// service is the entry point of a service, main returns once the service stopped
+type service struct {
+	name string
+	main func()
+}
+
+// services are the services of the binary, in the order they start
+var services = []service{
+	{name: "auth", main: server_auth.Main},
+	{name: "config", main: server_config.Main},
+	{name: "core", main: server_core.Main},
+	{name: "gateway", main: server_gateway.Main},
+}
+
 func main() {
-	server_init.Main()
-	server_auth.Main()
-	// server_core.Main()
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	args, inMemory := removeFlag(args, devInMemoryFlag)
+	if inMemory {
+		memory.Enable()
+		fmt.Fprintln(stderr, "running in-memory, the data is lost when the process exits")
+	}
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	switch args[0] {
+	case "serve":
+		names, err := selectServices(args[1:])
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			usage(stderr)
+			return 2
+		}
+		if slices.Contains(args[1:], allServices) {
+			server_init.Main()
+		}
+		serve(names)
+	case "init":
+		server_init.Main()
+	case "migrate":
+		server_init.Migrate(args[1:])
+	case "help", "-h", "--help":
+		usage(stdout)
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	return 0
+}
+
+// removeFlag returns args without the boolean flag name, written with one or two dashes, and whether it was set.
+// The arguments after -- are left as they are
+func removeFlag(args []string, name string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	found := false
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if arg == "-"+name || arg == "--"+name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// selectServices returns the services named by the arguments before the first flag, in start order
+func selectServices(args []string) ([]string, error) {
+	selected := map[string]bool{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		if arg == allServices {
+			for _, s := range services {
+				selected[s.name] = true
+			}
+			continue
+		}
+		if !slices.ContainsFunc(services, func(s service) bool { return s.name == arg }) {
+			return nil, fmt.Errorf("unknown service %q", arg)
+		}
+		selected[arg] = true
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("no service to serve")
+	}
+	names := make([]string, 0, len(selected))
+	for _, s := range services {
+		if selected[s.name] {
+			names = append(names, s.name)
+		}
+	}
+	return names, nil
+}
+
+// serve runs the services concurrently until they all stopped, each stops on SIGINT or SIGTERM
+func serve(names []string) {
+	var wg sync.WaitGroup
+	for _, s := range services {
+		if !slices.Contains(names, s.name) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.main()
+		}()
+	}
+	wg.Wait()
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		names = append(names, s.name)
+	}
+	fmt.Fprintf(w, `Usage:
+  erp serve <service>... [flags]   run the services concurrently: %s or %s
+  erp init [flags]                 seed the system data and exit
+  erp migrate [status] [flags]     upgrade the stored documents to the latest schema versions and exit
+  erp help                         show this help
+
+Flags:
+  --%s                     keep the data in memory instead of Mongo and Redis
+`, strings.Join(names, ", "), allServices, devInMemoryFlag)
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectServices(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "single", args: []string{"core"}, expected: []string{"core"}},
+		{name: "start order", args: []string{"gateway", "auth", "auth"}, expected: []string{"auth", "gateway"}},
+		{name: "all", args: []string{"all"}, expected: []string{"auth", "config", "core", "gateway"}},
+		{name: "flags follow the services", args: []string{"auth", "--port", "6000"}, expected: []string{"auth"}},
+		{name: "unknown service", args: []string{"billing"}, wantErr: true},
+		{name: "no service", args: []string{"--port", "6000"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names, err := selectServices(tc.args)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestRemoveFlag(t *testing.T) {
+	args, found := removeFlag([]string{"serve", "--dev-inmemory", "auth", "--port", "6000"}, devInMemoryFlag)
+	assert.True(t, found)
+	assert.Equal(t, []string{"serve", "auth", "--port", "6000"}, args)
+
+	args, found = removeFlag([]string{"serve", "auth", "-dev-inmemory"}, devInMemoryFlag)
+	assert.True(t, found)
+	assert.Equal(t, []string{"serve", "auth"}, args)
+
+	args, found = removeFlag([]string{"serve", "auth", "--", "--dev-inmemory"}, devInMemoryFlag)
+	assert.False(t, found)
+	assert.Equal(t, []string{"serve", "auth", "--", "--dev-inmemory"}, args)
+}
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run(nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "erp serve")
+	assert.Equal(t, 2, run([]string{"deploy"}, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"serve", "billing"}, &stdout, &stderr))
+	assert.Equal(t, 0, run([]string{"help"}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "auth, config, core, gateway or all")
+}
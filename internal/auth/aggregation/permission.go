@@ -13,11 +13,13 @@ import (
 // PermissionAggregationHandler handles permission-specific aggregations
 type PermissionAggregationHandler struct {
 	*aggregation.BaseAggregationHandler[authv1.Permission]
+	// permissions aggregates the permissions collection, the pipelines of the embedded handler start from the roles
+	permissions *aggregation.BaseAggregationHandler[authv1.Permission]
 }
 
 // NewPermissionAggregationHandler creates a new permission aggregation handler
 func NewPermissionAggregationHandler(logger logger.Logger) (*PermissionAggregationHandler, error) {
-	aggregation, err := aggregation.NewBaseAggregationHandler[authv1.Permission](
+	roles, err := aggregation.NewBaseAggregationHandler[authv1.Permission](
 		model_mongo.AuthDB,
 		model_mongo.RolesCollection,
 		logger,
@@ -25,11 +27,25 @@ func NewPermissionAggregationHandler(logger logger.Logger) (*PermissionAggregati
 	if err != nil {
 		return nil, err
 	}
+	permissions, err := aggregation.NewBaseAggregationHandler[authv1.Permission](
+		model_mongo.AuthDB,
+		model_mongo.PermissionsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &PermissionAggregationHandler{
-		BaseAggregationHandler: aggregation,
+		BaseAggregationHandler: roles,
+		permissions:            permissions,
 	}, nil
 }
 
+// BatchGetByIDs retrieves the permissions of ids from the permissions collection
+func (h *PermissionAggregationHandler) BatchGetByIDs(ctx context.Context, tenantID string, ids []string, fields []string) ([]*authv1.Permission, error) {
+	return h.permissions.BatchGetByIDs(ctx, tenantID, ids, fields)
+}
+
 // GetUserPermissions retrieves all permissions for a user using aggregation
 // This replaces the N+1 query pattern (1 user + N roles + M permissions per role)
 func (h *PermissionAggregationHandler) GetUserPermissions(
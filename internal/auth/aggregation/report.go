@@ -0,0 +1,123 @@
+package aggregation
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/logging/logger"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Tenant scoped collections counted in the per tenant storage
+var reportStorageCollections = []model_mongo.Collection{
+	model_mongo.UsersCollection,
+	model_mongo.RolesCollection,
+	model_mongo.PermissionsCollection,
+	model_mongo.APIUsageCollection,
+}
+
+// TenantUserCounts is the result of the user count aggregation, grouped by tenant
+type TenantUserCounts struct {
+	TenantID           string `bson:"_id"`
+	Users              int64  `bson:"users"`
+	MonthlyActiveUsers int64  `bson:"monthly_active_users"`
+}
+
+// TenantStorage is the result of the storage aggregation of one collection, grouped by tenant
+type TenantStorage struct {
+	TenantID string `bson:"_id"`
+	Bytes    int64  `bson:"bytes"`
+}
+
+// ReportAggregationHandler computes the cross tenant figures of system reports
+type ReportAggregationHandler struct {
+	users   *aggregation.BaseAggregationHandler[TenantUserCounts]
+	storage map[model_mongo.Collection]*aggregation.BaseAggregationHandler[TenantStorage]
+	logger  logger.Logger
+}
+
+// NewReportAggregationHandler creates a new report aggregation handler
+func NewReportAggregationHandler(logger logger.Logger) (*ReportAggregationHandler, error) {
+	users, err := aggregation.NewBaseAggregationHandler[TenantUserCounts](
+		model_mongo.AuthDB,
+		model_mongo.UsersCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// The reports span every tenant, they are offloaded to the secondaries and tolerate their lag
+	users.SetReadPreference(mongo.ReadSecondaryPreferred)
+	storage := map[model_mongo.Collection]*aggregation.BaseAggregationHandler[TenantStorage]{}
+	for _, collection := range reportStorageCollections {
+		handler, err := aggregation.NewBaseAggregationHandler[TenantStorage](model_mongo.AuthDB, collection, logger)
+		if err != nil {
+			return nil, err
+		}
+		handler.SetReadPreference(mongo.ReadSecondaryPreferred)
+		storage[collection] = handler
+	}
+	return &ReportAggregationHandler{
+		users:   users,
+		storage: storage,
+		logger:  logger,
+	}, nil
+}
+
+// UserCounts returns the number of users per tenant and how many of them logged in successfully since activeSince
+func (h *ReportAggregationHandler) UserCounts(ctx context.Context, activeSince time.Time) (map[string]*TenantUserCounts, error) {
+	pipeline := []bson.M{
+		{"$group": bson.M{
+			"_id":   "$tenant_id",
+			"users": bson.M{"$sum": 1},
+			"monthly_active_users": bson.M{"$sum": bson.M{
+				"$cond": bson.A{
+					bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+						"input": bson.M{"$ifNull": bson.A{"$login_history", bson.A{}}},
+						"as":    "login",
+						"in": bson.M{"$and": bson.A{
+							"$$login.success",
+							bson.M{"$gte": bson.A{"$$login.timestamp", activeSince}},
+						}},
+					}}}},
+					1,
+					0,
+				},
+			}},
+		}},
+	}
+	results, err := h.users.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]*TenantUserCounts, len(results))
+	for _, result := range results {
+		counts[result.TenantID] = result
+	}
+	return counts, nil
+}
+
+// StorageBytes returns the BSON size of the documents of each tenant across the tenant scoped auth collections
+func (h *ReportAggregationHandler) StorageBytes(ctx context.Context) (map[string]int64, error) {
+	pipeline := []bson.M{
+		{"$group": bson.M{
+			"_id":   "$tenant_id",
+			"bytes": bson.M{"$sum": bson.M{"$bsonSize": "$$ROOT"}},
+		}},
+	}
+	storage := map[string]int64{}
+	for collection, handler := range h.storage {
+		results, err := handler.Aggregate(ctx, pipeline, nil)
+		if err != nil {
+			h.logger.Error("failed to aggregate tenant storage", "collection", collection, "error", err)
+			return nil, err
+		}
+		for _, result := range results {
+			storage[result.TenantID] += result.Bytes
+		}
+	}
+	return storage, nil
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/hash"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// lastUsedInterval limits how often last_used_at is written for a busy key
+const lastUsedInterval = time.Minute
+
+// APIKeyAPI manages API keys for machine to machine integrations.
+// A key acts on behalf of the user who created it, limited to its scopes.
+type APIKeyAPI struct {
+	logger        logger.Logger
+	apiKeyHandler *handler.APIKeyHandler
+	rbacAPI       *RBACAPI
+	// Limits the keys of the tenants, set by SetTenantQuotas
+	quotas *TenantQuotas
+}
+
+func NewAPIKeyAPI(rbacAPI *RBACAPI, logger logger.Logger) (*APIKeyAPI, error) {
+	apiKeyHandler, err := handler.NewAPIKeyHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new api key handler", "error", err)
+		return nil, err
+	}
+	return &APIKeyAPI{
+		logger:        logger,
+		apiKeyHandler: apiKeyHandler,
+		rbacAPI:       rbacAPI,
+	}, nil
+}
+
+// CreateAPIKey creates a key for the calling user and returns it with the plain key, which is not stored.
+// Every scope must be a catalog permission held by the caller.
+func (a *APIKeyAPI) CreateAPIKey(ctx context.Context, tenantID, userID, name string, scopes []string, expiresAt *timestamppb.Timestamp) (*authv1.APIKey, string, error) {
+	if tenantID == "" || userID == "" || name == "" || len(scopes) == 0 {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, name, scopes"))
+		a.logger.Error("failed to create api key", "error", err)
+		return nil, "", err
+	}
+	for _, scope := range scopes {
+		if !permissions.IsKnown(scope) {
+			err := infra_error.Validation(infra_error.ValidationInvalidValue, "scopes").WithDetails("scope", scope)
+			a.logger.Error("failed to create api key", "tenant_id", tenantID, "error", err)
+			return nil, "", err
+		}
+	}
+	if expiresAt != nil && !expiresAt.AsTime().After(time.Now()) {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue, "expires_at")
+		a.logger.Error("failed to create api key", "tenant_id", tenantID, "error", err)
+		return nil, "", err
+	}
+
+	// Keys cannot mint other keys, a leaked key must not outlive its revocation
+	if _, ok := model_auth.APIKeyIDFromPrincipal(userID); ok {
+		err := infra_error.Auth(infra_error.AuthPermissionDenied)
+		a.logger.Error("failed to create api key", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", err
+	}
+	if err := a.hasPermission(ctx, tenantID, userID, permissions.ApikeyCreate); err != nil {
+		a.logger.Error("failed to create api key", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", err
+	}
+	for _, scope := range scopes {
+		if err := a.hasPermission(ctx, tenantID, userID, scope); err != nil {
+			a.logger.Error("failed to create api key, scope not held by caller", "tenant_id", tenantID, "user_id", userID, "scope", scope, "error", err)
+			return nil, "", err
+		}
+	}
+	if err := a.quotas.Check(ctx, tenantID, model_auth.TenantResourceAPIKeys); err != nil {
+		a.logger.Error("failed to create api key", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", err
+	}
+
+	key, prefix, keyHash, err := hash.GenerateAPIKey()
+	if err != nil {
+		a.logger.Error("failed to generate api key", "tenant_id", tenantID, "error", err)
+		return nil, "", err
+	}
+	apiKey := &authv1.APIKey{
+		TenantId:  tenantID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		CreatedBy: userID,
+		ExpiresAt: expiresAt,
+	}
+	id, err := a.apiKeyHandler.CreateAPIKey(ctx, apiKey)
+	if err != nil {
+		a.logger.Error("failed to create api key", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", err
+	}
+	apiKey.Id = id
+	a.logger.Info("api key created", "tenant_id", tenantID, "user_id", userID, "key_id", id, "prefix", prefix)
+	return apiKey, key, nil
+}
+
+// RevokeAPIKey revokes a key of the caller tenant, revoking an already revoked key is a no-op
+func (a *APIKeyAPI) RevokeAPIKey(ctx context.Context, tenantID, userID, keyID string) error {
+	if tenantID == "" || userID == "" || keyID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, key_id"))
+		a.logger.Error("failed to revoke api key", "error", err)
+		return err
+	}
+	if err := a.hasPermission(ctx, tenantID, userID, permissions.ApikeyDelete); err != nil {
+		a.logger.Error("failed to revoke api key", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+
+	apiKey, err := a.apiKeyHandler.GetAPIKeyByID(ctx, tenantID, keyID)
+	if err != nil {
+		a.logger.Error("failed to get api key", "tenant_id", tenantID, "key_id", keyID, "error", err)
+		return err
+	}
+	if apiKey.GetRevokedAt() != nil {
+		return nil
+	}
+	apiKey.RevokedAt = timestamppb.Now()
+	apiKey.RevokedBy = userID
+	if err := a.apiKeyHandler.UpdateAPIKey(ctx, apiKey); err != nil {
+		a.logger.Error("failed to revoke api key", "tenant_id", tenantID, "key_id", keyID, "error", err)
+		return err
+	}
+	a.logger.Info("api key revoked", "tenant_id", tenantID, "user_id", userID, "key_id", keyID)
+	return nil
+}
+
+func (a *APIKeyAPI) ListAPIKeys(ctx context.Context, tenantID, userID string, includeRevoked bool) ([]*authv1.APIKey, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		a.logger.Error("failed to list api keys", "error", err)
+		return nil, err
+	}
+	if err := a.hasPermission(ctx, tenantID, userID, permissions.ApikeyRead); err != nil {
+		a.logger.Error("failed to list api keys", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	apiKeys, err := a.apiKeyHandler.GetAPIKeysByTenantID(ctx, tenantID)
+	if err != nil {
+		a.logger.Error("failed to list api keys", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	result := make([]*authv1.APIKey, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		if apiKey.GetRevokedAt() != nil && !includeRevoked {
+			continue
+		}
+		result = append(result, apiKey)
+	}
+	return result, nil
+}
+
+// AuthenticateAPIKey resolves a plain key to its tenant and the user id of its calls.
+// It implements interceptor.APIKeyAuthenticator.
+func (a *APIKeyAPI) AuthenticateAPIKey(ctx context.Context, key string) (string, string, error) {
+	prefix, err := hash.ParseAPIKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	apiKey, err := a.apiKeyHandler.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil || !hash.VerifyAPIKey(key, apiKey.GetKeyHash()) {
+		return "", "", infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	if err := validator_auth.ValidateActiveAPIKey(apiKey); err != nil {
+		return "", "", err
+	}
+
+	if lastUsed := apiKey.GetLastUsedAt(); lastUsed == nil || time.Since(lastUsed.AsTime()) > lastUsedInterval {
+		apiKey.LastUsedAt = timestamppb.Now()
+		if err := a.apiKeyHandler.UpdateAPIKey(ctx, apiKey); err != nil {
+			// Authentication does not depend on the usage timestamp
+			a.logger.Warn("failed to update api key last use", "tenant_id", apiKey.GetTenantId(), "key_id", apiKey.GetId(), "error", err)
+		}
+	}
+	return apiKey.GetTenantId(), model_auth.APIKeyPrincipal(apiKey.GetId()), nil
+}
+
+/* Helper functions */
+func (a *APIKeyAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return a.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permission, tenantID)
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	audit_collection "erp.localhost/internal/infra/event/audit_log/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+)
+
+// AuditStreamConfig holds the settings of the audit event streams, loaded with infra_config.Load
+type AuditStreamConfig struct {
+	// Interval between two reads of the new events of a stream
+	PollInterval time.Duration `yaml:"poll_interval" env:"AUDIT_STREAM_POLL_INTERVAL" default:"1s"`
+	// SettleDelay holds back the events younger than it. Events are stamped before they are stored, so an event
+	// stored after a later stamped one is still streamed as long as it is stored within the delay
+	SettleDelay time.Duration `yaml:"settle_delay" env:"AUDIT_STREAM_SETTLE_DELAY" default:"2s"`
+	// BatchSize caps the events read at once
+	BatchSize int `yaml:"batch_size" env:"AUDIT_STREAM_BATCH_SIZE" default:"500" validate:"positive"`
+}
+
+// auditLogTailer reads the audit logs of a tenant in tail order, see audit_collection.AuditLogsCollection
+type auditLogTailer interface {
+	TailAuditLogs(ctx context.Context, tenantID string, filter map[string]any, after *audit_collection.AuditLogPosition, until time.Time, limit int) ([]*eventv1.AuditLog, error)
+}
+
+// AuditAPI streams the audit events of the tenants to their security tooling, e.g. SIEM collectors
+type AuditAPI struct {
+	logger    logger.Logger
+	rbacAPI   *RBACAPI
+	auditLogs auditLogTailer
+	config    AuditStreamConfig
+}
+
+func NewAuditAPI(rbacAPI *RBACAPI, config *AuditStreamConfig, logger logger.Logger) (*AuditAPI, error) {
+	auditLogsHandler, err := collection.NewBaseCollectionHandler[eventv1.AuditLog](model_mongo.AuthDB, model_mongo.AuditLogsCollection, logger)
+	if err != nil {
+		logger.Error("failed to create audit logs collection handler", "error", err)
+		return nil, err
+	}
+	return &AuditAPI{
+		logger:    logger,
+		rbacAPI:   rbacAPI,
+		auditLogs: audit_collection.NewAuditLogsCollection(auditLogsHandler, logger),
+		config:    *config,
+	}, nil
+}
+
+// StreamAuditEvents sends the events of targetTenantID matching filter oldest first, then the new ones as they are
+// stored, until ctx is done. A stream started with the cursor of an event resumes right after it, so a collector
+// reconnecting with the cursor of the last event it received gets every later event once. Requires audit read
+// permission
+func (a *AuditAPI) StreamAuditEvents(ctx context.Context, tenantID, userID, targetTenantID string, filter *authv1.AuditEventFilter, cursor string, send func(*authv1.AuditEvent) error) error {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		return infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+	}
+	var after *audit_collection.AuditLogPosition
+	if cursor != "" {
+		position, err := decodeAuditCursor(cursor)
+		if err != nil {
+			return err
+		}
+		after = &position
+	}
+	if err := a.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permissions.AuditRead, targetTenantID); err != nil {
+		a.logger.Error("failed to stream audit events", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID, "error", err)
+		return err
+	}
+
+	a.logger.Info("audit event stream started", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID, "resumed", after != nil)
+	return a.streamAuditEvents(ctx, targetTenantID, auditEventMatch(filter, after == nil), after, send)
+}
+
+// streamAuditEvents sends the audit logs of targetTenantID matching match stored after position after, until ctx is
+// done
+func (a *AuditAPI) streamAuditEvents(ctx context.Context, targetTenantID string, match map[string]any, after *audit_collection.AuditLogPosition, send func(*authv1.AuditEvent) error) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		// A full batch is followed by the next one right away, the stream waits once it caught up
+		auditLogs, err := a.auditLogs.TailAuditLogs(ctx, targetTenantID, match, after, time.Now().Add(-a.config.SettleDelay), a.config.BatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			a.logger.Error("failed to read audit events", "target_tenant_id", targetTenantID, "error", err)
+			return err
+		}
+		for _, auditLog := range auditLogs {
+			position := audit_collection.PositionOf(auditLog)
+			if err := send(&authv1.AuditEvent{Log: auditLog, Cursor: encodeAuditCursor(position)}); err != nil {
+				return err
+			}
+			after = &position
+		}
+		if len(auditLogs) >= a.config.BatchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(a.config.PollInterval)
+		}
+	}
+}
+
+// auditEventMatch returns the audit log filter of filter, since is only applied to the streams not resumed from a
+// cursor
+func auditEventMatch(filter *authv1.AuditEventFilter, withSince bool) map[string]any {
+	match := map[string]any{}
+	if filter.GetActorId() != "" {
+		match["actor_id"] = filter.GetActorId()
+	}
+	if filter.GetResourceType() != "" {
+		match["target_type"] = filter.GetResourceType()
+	}
+	if withSince && filter.GetSince() != nil {
+		match["timestamp"] = map[string]any{"$gte": filter.GetSince().AsTime()}
+	}
+	return match
+}
+
+// encodeAuditCursor returns the opaque cursor of position, its millisecond timestamp and id
+func encodeAuditCursor(position audit_collection.AuditLogPosition) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(position.Timestamp.UnixMilli(), 10) + ":" + position.ID))
+}
+
+// decodeAuditCursor returns the position of cursor, a validation error when it is not a cursor of an event
+func decodeAuditCursor(cursor string) (audit_collection.AuditLogPosition, error) {
+	invalid := infra_error.Validation(infra_error.ValidationInvalidValue, "cursor")
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return audit_collection.AuditLogPosition{}, invalid.WithError(err)
+	}
+	millis, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return audit_collection.AuditLogPosition{}, invalid
+	}
+	timestamp, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return audit_collection.AuditLogPosition{}, invalid.WithError(err)
+	}
+	return audit_collection.AuditLogPosition{Timestamp: time.UnixMilli(timestamp).UTC(), ID: id}, nil
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	audit_collection "erp.localhost/internal/infra/event/audit_log/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// collectAuditEvents streams the audit events of tenant-1 until count of them are received
+func collectAuditEvents(t *testing.T, a *AuditAPI, match map[string]any, after *audit_collection.AuditLogPosition, count int) []*authv1.AuditEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var events []*authv1.AuditEvent
+	err := a.streamAuditEvents(ctx, "tenant-1", match, after, func(event *authv1.AuditEvent) error {
+		events = append(events, event)
+		if len(events) == count {
+			cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, events, count, "the stream timed out")
+	return events
+}
+
+func auditEventIDs(events []*authv1.AuditEvent) []string {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.GetLog().GetId())
+	}
+	return ids
+}
+
+func TestAuditAPI_StreamAuditEvents(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	handler, err := collection.NewBaseCollectionHandler[eventv1.AuditLog](model_mongo.AuthDB, model_mongo.AuditLogsCollection, log)
+	require.NoError(t, err)
+	a := &AuditAPI{
+		logger:    log,
+		auditLogs: audit_collection.NewAuditLogsCollection(handler, log),
+		config:    AuditStreamConfig{PollInterval: 10 * time.Millisecond, BatchSize: 2},
+	}
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute).Truncate(time.Millisecond)
+	store := func(id, tenantID, actorID, targetType string, timestamp time.Time) {
+		_, err := handler.Create(ctx, &eventv1.AuditLog{
+			Id:         id,
+			TenantId:   tenantID,
+			Timestamp:  timestamppb.New(timestamp),
+			Category:   "auth",
+			Action:     "login",
+			ActorId:    actorID,
+			TargetType: targetType,
+			Result:     "success",
+		})
+		require.NoError(t, err)
+	}
+	// The logs of the same millisecond are streamed by id
+	store("log-b", "tenant-1", "user-1", "session", start)
+	store("log-a", "tenant-1", "user-2", "user", start)
+	store("log-c", "tenant-1", "user-1", "token", start.Add(time.Second))
+	store("log-x", "tenant-2", "user-1", "session", start)
+
+	events := collectAuditEvents(t, a, nil, nil, 3)
+	assert.Equal(t, []string{"log-a", "log-b", "log-c"}, auditEventIDs(events))
+
+	// A stream resumed from a cursor starts right after its event, then gets the events stored later
+	position, err := decodeAuditCursor(events[0].GetCursor())
+	require.NoError(t, err)
+	store("log-d", "tenant-1", "user-1", "session", start.Add(2*time.Second))
+	resumed := collectAuditEvents(t, a, nil, &position, 3)
+	assert.Equal(t, []string{"log-b", "log-c", "log-d"}, auditEventIDs(resumed))
+
+	filtered := collectAuditEvents(t, a, auditEventMatch(&authv1.AuditEventFilter{
+		ActorId:      "user-1",
+		ResourceType: "session",
+		Since:        timestamppb.New(start.Add(time.Second)),
+	}, true), nil, 1)
+	assert.Equal(t, []string{"log-d"}, auditEventIDs(filtered))
+}
+
+func TestDecodeAuditCursor(t *testing.T) {
+	position := audit_collection.AuditLogPosition{Timestamp: time.UnixMilli(1700000000123).UTC(), ID: "log-1"}
+	decoded, err := decodeAuditCursor(encodeAuditCursor(position))
+	require.NoError(t, err)
+	assert.Equal(t, position, decoded)
+
+	for _, cursor := range []string{"not a cursor", "bG9nLTE", "MTIzOg"} {
+		_, err := decodeAuditCursor(cursor)
+		assert.Error(t, err, cursor)
+	}
+}
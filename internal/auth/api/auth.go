@@ -1,25 +1,52 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"time"
 
+	"erp.localhost/internal/auth/handler"
 	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/auth/oidc"
 	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/event/outbox"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/grpc/interceptor"
 	"erp.localhost/internal/infra/logging/logger"
 	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type AuthAPI struct {
-	logger       logger.Logger
-	rbacAPI      *RBACAPI
-	userAPI      *UserAPI
-	tokenManager *TokenAPI
+	logger           logger.Logger
+	rbacAPI          *RBACAPI
+	userAPI          *UserAPI
+	tokenManager     *TokenAPI
+	notifier         *notifier
+	oidcConfig       *OIDCConfig
+	oidcProviders    map[string]*oidc.Provider
+	oidcStateHandler *handler.OIDCStateHandler
+	// Tenant SAML settings and token policies are read through the config service,
+	// SAML login fails and the global token policy applies until the client is set
+	configClient         client.ConfigClient
+	tokenPolicies        *tenantTokenPolicies
+	samlConfig           *SAMLConfig
+	samlAssertionHandler *handler.SAMLAssertionHandler
+	outbox               *outbox.Outbox
+	// Lifetime of the impersonation tokens, see Impersonate
+	impersonationTTL time.Duration
+	// Login anomaly detection, see checkLoginRisk
+	loginRiskConfig LoginRiskConfig
+	knownDevices    knownDeviceStore
+	loginCodes      loginCodeStore
+	// Magic link login, see RequestLoginLink
+	loginLinkConfig LoginLinkConfig
+	loginLinks      loginLinkStore
 }
 
 func NewAuthAPI(rbacAPI *RBACAPI, userAPI *UserAPI, logger logger.Logger) (*AuthAPI, error) {
@@ -29,15 +56,66 @@ func NewAuthAPI(rbacAPI *RBACAPI, userAPI *UserAPI, logger logger.Logger) (*Auth
 		logger.Fatal("failed to create token manager", "error", err)
 		return nil, err
 	}
+	oidcStateHandler, err := handler.NewOIDCStateHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new oidc state handler", "error", err)
+		return nil, err
+	}
+	samlAssertionHandler, err := handler.NewSAMLAssertionHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new saml assertion handler", "error", err)
+		return nil, err
+	}
+	knownDevicesHandler, err := handler.NewKnownDevicesHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new known devices handler", "error", err)
+		return nil, err
+	}
+	mfaCodeHandler, err := handler.NewMFACodeHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new mfa code handler", "error", err)
+		return nil, err
+	}
+	loginLinkHandler, err := handler.NewLoginLinkHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new login link handler", "error", err)
+		return nil, err
+	}
+	// The self-service calls of the users are authenticated with their access tokens
+	userAPI.sessions = tokenManager
+	userAPI.knownDevices = knownDevicesHandler
+	userAPI.knownDeviceTTL = defaultLoginRiskConfig.KnownDeviceTTL
+	userAPI.deviceSessions = tokenManager
+	// The minimal access tokens are checked against the permissions the RBAC check resolves and caches
+	if rbacAPI != nil && rbacAPI.Verification != nil && rbacAPI.Verification.verificationManager != nil {
+		tokenManager.permissionsVersions = rbacAPI.Verification.verificationManager
+	}
+	oidcConfig := LoadOIDCConfig()
 	return &AuthAPI{
-		logger:       logger,
-		rbacAPI:      rbacAPI,
-		userAPI:      userAPI,
-		tokenManager: tokenManager,
+		logger:               logger,
+		rbacAPI:              rbacAPI,
+		userAPI:              userAPI,
+		tokenManager:         tokenManager,
+		oidcConfig:           oidcConfig,
+		oidcProviders:        newOIDCProviders(oidcConfig, logger),
+		oidcStateHandler:     oidcStateHandler,
+		samlConfig:           LoadSAMLConfig(),
+		samlAssertionHandler: samlAssertionHandler,
+		impersonationTTL:     DefaultImpersonationTokenTTL,
+		loginRiskConfig:      defaultLoginRiskConfig,
+		knownDevices:         knownDevicesHandler,
+		loginCodes:           mfaCodeHandler,
+		loginLinkConfig:      defaultLoginLinkConfig,
+		loginLinks:           loginLinkHandler,
 	}, nil
 }
 
-func (a *AuthAPI) Login(tenantID, email, username, password string) (*NewTokenResponse, error) {
+// SetOutbox records the failed logins in outbox
+func (a *AuthAPI) SetOutbox(outbox *outbox.Outbox) {
+	a.outbox = outbox
+}
+
+func (a *AuthAPI) Login(ctx context.Context, tenantID, email, username, password, mfaCode string) (*NewTokenResponse, error) {
 	if tenantID == "" || password == "" || (email == "" && username == "") {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, email/username, password"))
 		a.logger.Error("failed to login", "error", err)
@@ -52,110 +130,202 @@ func (a *AuthAPI) Login(tenantID, email, username, password string) (*NewTokenRe
 	} else {
 		filterType = filterTypeUnsupported
 	}
-	user, err := a.userAPI.getUser(tenantID, email, filterType)
+	account := email
+	if account == "" {
+		account = username
+	}
+	user, err := a.userAPI.getUser(ctx, tenantID, account, filterType)
 	if err != nil {
 		a.logger.Error("failed to find user", "error", err)
+		a.recordLogin(ctx, tenantID, account, nil, err)
 		return nil, err
 	}
 
-	tokens, err := a.Authenticate(user, password)
-	if user.LoginHistory == nil {
-		user.LoginHistory = make([]*authv1.LoginRecord, 0)
-	}
-	user.LoginHistory = append(user.LoginHistory, &authv1.LoginRecord{
+	tokens, err := a.Authenticate(ctx, user, password, mfaCode)
+	a.recordLogin(ctx, tenantID, account, user, err)
+	return tokens, err
+}
+
+// recordLogin stores the attempt on account in the login history, user is nil when the account is unknown. Failed
+// logins of users are recorded as events, successful ones update the user
+func (a *AuthAPI) recordLogin(ctx context.Context, tenantID, account string, user *authv1.User, loginErr error) {
+	success := loginErr == nil
+	ip, userAgent := interceptor.ClientFromContext(ctx)
+	record := &authv1.LoginRecord{
 		Timestamp: timestamppb.Now(),
-		Success:   tokens != nil,
+		IpAddress: ip,
+		UserAgent: userAgent,
+		Success:   success,
+		TenantId:  tenantID,
+		UserId:    user.GetId(),
+		Account:   account,
+	}
+	if !success {
+		record.FailureReason = infra_error.InternalUnexpectedError.Code
+		if appErr, ok := infra_error.AsAppError(loginErr); ok {
+			record.FailureReason = appErr.Code
+		}
+	}
+	if a.userAPI.loginHistoryHandler != nil {
+		if err := a.userAPI.loginHistoryHandler.RecordLogin(ctx, record); err != nil {
+			a.logger.Error("failed to record login", "tenant_id", tenantID, "user_id", user.GetId(), "error", err)
+		}
+	}
+	if user == nil {
+		return
+	}
+
+	// Authenticate may have upgraded the password hash, it is persisted with the last login
+	updateErr := a.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if !success {
+			return []*eventv1.DomainEvent{userEvent(model_event.EventLoginFailed, "", user)}, nil
+		}
+		user.LastLogin = record.GetTimestamp()
+		if user.GetDeletionScheduledAt() != nil {
+			// Logging in during the grace period keeps the account
+			a.logger.Info("account deletion cancelled by login", "tenant_id", tenantID, "user_id", user.GetId())
+			user.DeletionScheduledAt = nil
+		}
+		return nil, a.userAPI.userHandler.UpdateUser(ctx, user)
 	})
-	if updateErr := a.userAPI.userHandler.UpdateUser(user); updateErr != nil {
-		a.logger.Error("failed to update user login history", "error", updateErr)
+	if updateErr != nil {
+		a.logger.Error("failed to record user login", "error", updateErr)
 	}
-	return tokens, err
 }
 
-func (a *AuthAPI) Logout(tenantID, userID, accessToken, refreshToken, revokedBy string) (string, error) {
-	err := a.RevokeTokens(tenantID, userID, accessToken, refreshToken, revokedBy)
+func (a *AuthAPI) Logout(ctx context.Context, tenantID, userID, accessToken, refreshToken, revokedBy string) (string, error) {
+	err := a.RevokeTokens(ctx, tenantID, userID, accessToken, refreshToken, revokedBy)
 	if err != nil {
 		return "logout failed", err
 	}
 	return "logout successful", err
 }
 
-func (a *AuthAPI) Authenticate(user *authv1.User, password string) (*NewTokenResponse, error) {
+func (a *AuthAPI) Authenticate(ctx context.Context, user *authv1.User, password, mfaCode string) (*NewTokenResponse, error) {
 	if password == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, user_password, user_hash"))
 		a.logger.Error("Failed to authenticate user", "error", err)
 		return nil, err
 	}
 
-	if !hash.VerifyHash(password, user.GetPasswordHash()) {
+	valid, err := hash.Verify(ctx, password, user.GetPasswordHash())
+	if err != nil {
+		a.logger.Warn("password verification rejected", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return nil, err
+	}
+	if !valid {
 		return nil, infra_error.Auth(infra_error.AuthInvalidCredentials)
 	}
+	// Deprovisioned and suspended accounts keep their data but may not log in
+	if status := user.GetStatus(); status == authv1.UserStatus_USER_STATUS_SUSPENDED || status == authv1.UserStatus_USER_STATUS_INACTIVE {
+		return nil, infra_error.Auth(infra_error.AuthAccountDisabled)
+	}
+	if err := a.checkTenantLogin(ctx, user.GetTenantId()); err != nil {
+		return nil, err
+	}
+
+	// Hashes made with an older algorithm or weaker parameters are upgraded while the password is at hand,
+	// Login persists the user after authentication
+	if hash.NeedsRehash(user.GetPasswordHash()) {
+		if upgraded, err := hash.Hash(password); err == nil {
+			user.PasswordHash = upgraded
+			a.logger.Info("password hash upgraded", "tenant_id", user.GetTenantId(), "user_id", user.GetId())
+		} else {
+			a.logger.Warn("failed to upgrade password hash", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		}
+	}
+
+	// The generated passwords are replaced with ChangePassword, which takes the current password, before a login
+	if user.GetMustChangePassword() {
+		a.logger.Warn("password change required", "tenant_id", user.GetTenantId(), "user_id", user.GetId())
+		return nil, infra_error.Auth(infra_error.AuthPasswordChangeRequired)
+	}
+	if a.userAPI.IsPasswordExpired(ctx, user) {
+		a.logger.Warn("password expired", "tenant_id", user.GetTenantId(), "user_id", user.GetId())
+		return nil, infra_error.Auth(infra_error.AuthPasswordExpired)
+	}
+
+	if user.GetMfaEnabled() {
+		if err := a.verifyMFACode(ctx, user, mfaCode); err != nil {
+			a.logger.Warn("mfa verification failed", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+			return nil, err
+		}
+	}
+
+	risk, err := a.checkLoginRisk(ctx, user, mfaCode)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate tokens
-	return a.generateAndStoreTokens(user)
+	tokens, err := a.generateAndStoreTokens(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	a.rememberLogin(ctx, user, risk)
+	a.registerDevice(ctx, user, risk)
+	tokens.RiskScore, tokens.RiskReasons = risk.score, risk.reasons
+	return tokens, nil
 }
 
-func (a *AuthAPI) VerifyToken(token string) error {
+func (a *AuthAPI) VerifyToken(ctx context.Context, token string) error {
 	if token == "" {
-		return status.Error(codes.InvalidArgument, infra_error.Validation(infra_error.ValidationRequiredFields, "access_token").Error())
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "access_token")
 	}
-	_, err := a.tokenManager.VerifyAccessToken(token)
+	_, err := a.tokenManager.VerifyAccessToken(ctx, token)
 	return err
 }
 
-func (a *AuthAPI) RefreshToken(tenantID, userID, token string) (*NewTokenResponse, error) {
+func (a *AuthAPI) RefreshToken(ctx context.Context, tenantID, userID, token string) (*NewTokenResponse, error) {
 	if tenantID == "" || userID == "" || token == "" {
 		return nil, infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, refresh_token"))
 	}
 
 	// Verify the refresh token is valid
-	_, err := a.tokenManager.VerifyRefreshToken(tenantID, userID, token)
+	current, err := a.tokenManager.VerifyRefreshToken(ctx, tenantID, userID, token)
 	if err != nil {
 		a.logger.Error("Failed to verify refresh token", "error", err, "tenant_id", tenantID, "user_id", userID, "refresh_token", token)
 		return nil, err
 	}
+	if err := a.checkTenantLogin(ctx, tenantID); err != nil {
+		return nil, err
+	}
 
 	// Revoke old access tokens to prevent orphaned tokens
-	// Note: We only revoke access tokens, not refresh tokens, since the refresh token
-	// is still valid and will be revoked explicitly below
-	if err := a.tokenManager.RevokeAllAccessTokens(tenantID, userID, "system"); err != nil {
+	// Note: We only revoke access tokens, the refresh token is replaced by its rotation below
+	if err := a.tokenManager.RevokeAllAccessTokens(ctx, tenantID, userID, "system"); err != nil {
 		a.logger.Warn("Failed to revoke old access tokens before refresh", "error", err, "tenant_id", tenantID, "user_id", userID)
 		// Continue anyway - non-critical failure
 	}
-	user, err := a.userAPI.getUser(tenantID, userID, filterTypeID)
+	user, err := a.userAPI.getUser(ctx, tenantID, userID, filterTypeID)
 	if err != nil {
 		a.logger.Error("failed to find user", "error", err)
 		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
 	}
 
-	newTokenResponse, err := a.generateAndStoreTokens(user)
+	// The new refresh token joins the family of the old one and replaces it,
+	// the old token is kept in the family history to detect its reuse
+	newTokenResponse, err := a.rotateAndStoreTokens(ctx, user, current)
 	if err != nil {
 		a.logger.Error("Failed to generate and store tokens", "error", err, "tenant_id", tenantID, "user_id", userID)
 		return nil, err
 	}
-
-	// Revoke the old refresh token after successfully creating new tokens
-	err = a.tokenManager.RevokeRefreshToken(tenantID, userID, token, "system", true)
-	if err != nil {
-		a.logger.Error("Failed to revoke old refresh token", "error", err, "tenant_id", tenantID, "user_id", userID)
-		return nil, err
-	}
 	return newTokenResponse, nil
 }
 
-func (a *AuthAPI) RevokeTokens(tenantID, userID, accessToken, refreshToken, revokedBy string) error {
+func (a *AuthAPI) RevokeTokens(ctx context.Context, tenantID, userID, accessToken, refreshToken, revokedBy string) error {
 	if tenantID == "" || userID == "" || accessToken == "" || refreshToken == "" || revokedBy == "" {
 		return infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, access_token, refresh_token, revoked_by"))
 	}
 
 	if accessToken != "" {
-		err := a.tokenManager.RevokeAccessToken(accessToken, revokedBy)
+		err := a.tokenManager.RevokeAccessToken(ctx, accessToken, revokedBy)
 		if err != nil {
 			return err
 		}
 	}
 	if refreshToken != "" {
-		err := a.tokenManager.RevokeRefreshToken(tenantID, userID, refreshToken, revokedBy, false)
+		err := a.tokenManager.RevokeRefreshToken(ctx, tenantID, userID, refreshToken, revokedBy, false)
 		if err != nil {
 			return err
 		}
@@ -163,7 +333,7 @@ func (a *AuthAPI) RevokeTokens(tenantID, userID, accessToken, refreshToken, revo
 	return nil
 }
 
-func (a *AuthAPI) RevokeAllTenantTokens(tenantID, revokedBy, targetTenantID string) (int, int, error) {
+func (a *AuthAPI) RevokeAllTenantTokens(ctx context.Context, tenantID, revokedBy, targetTenantID string) (int, int, error) {
 	if tenantID == "" || revokedBy == "" || targetTenantID == "" {
 		return 0, 0, infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
 	}
@@ -171,39 +341,55 @@ func (a *AuthAPI) RevokeAllTenantTokens(tenantID, revokedBy, targetTenantID stri
 	a.logger.Warn("Revoking all tenant tokens", "tenant_id", targetTenantID, "revoked_by", revokedBy)
 
 	// This is a critical operation that should require elevated permissions
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeToken, model_auth.PermissionActionDelete)
-	if err != nil {
-		return 0, 0, err
-	}
-	err = a.rbacAPI.Verification.HasPermission(tenantID, revokedBy, permission, targetTenantID)
+	permission := permissions.TokenDelete
+	err := a.rbacAPI.Verification.HasPermission(ctx, tenantID, revokedBy, permission, targetTenantID)
 	if err != nil {
 		return 0, 0, err
 	}
 
 	// Revoke all tokens for this tenant
-	return a.tokenManager.RevokeAllTenantTokens(targetTenantID, revokedBy)
+	return a.tokenManager.RevokeAllTenantTokens(ctx, targetTenantID, revokedBy)
 }
 
-func (a *AuthAPI) generateAccessToken(user *authv1.User) (string, *authv1_cache.TokenMetadata, error) {
-	// Generate access token
-	userRoles := make([]string, len(user.GetRoles()))
-	for i, role := range user.GetRoles() {
-		userRoles[i] = role.RoleId
-	}
-	accessToken, claims, err := a.tokenManager.GenerateAccessToken(&GenerateAccessTokenInput{
+// generateAccessToken issues an access token of user, actor is set on the impersonation tokens and a positive ttl
+// shortens their lifetime. The minimal tokens carry the permissions version of user instead of its roles
+func (a *AuthAPI) generateAccessToken(ctx context.Context, user *authv1.User, actor *authv1.TokenActor, ttl time.Duration) (string, *authv1_cache.TokenMetadata, error) {
+	input := &GenerateAccessTokenInput{
 		UserId:   user.GetId(),
 		TenantId: user.GetTenantId(),
 		Username: user.GetUsername(),
 		Email:    user.GetEmail(),
-		Roles:    userRoles,
-	})
+		Actor:    actor,
+		TTL:      ttl,
+	}
+	if a.tokenManager.minimalClaims() {
+		version, err := a.tokenManager.permissionsVersions.PermissionsVersion(ctx, user.GetTenantId(), user.GetId())
+		if err != nil {
+			return "", nil, err
+		}
+		input.PermissionsVersion = version
+	} else {
+		// The expired role assignments are not in the claims
+		now := time.Now()
+		for _, role := range user.GetRoles() {
+			if model_auth.IsRoleAssignmentActive(role, now) {
+				input.Roles = append(input.Roles, role.RoleId)
+			}
+		}
+	}
+	accessToken, claims, err := a.tokenManager.GenerateAccessToken(input)
 	if err != nil {
-		return "", nil, status.Error(codes.Internal, err.Error())
+		return "", nil, err
 	}
 
+	// The impersonation tokens are stored apart from the token of the user
+	sessionID := claims.GetUserId()
+	if actor != nil {
+		sessionID = model_auth.ImpersonationSessionID(claims.GetUserId(), actor.GetUserId())
+	}
 	accessTokenMetadata := &authv1_cache.TokenMetadata{
 		Jti:       accessToken,
-		UserId:    claims.GetUserId(),
+		UserId:    sessionID,
 		TenantId:  claims.GetTenantId(),
 		IssuedAt:  claims.GetIssuedAt(),
 		ExpiresAt: claims.GetExpiresAt(),
@@ -218,32 +404,47 @@ func (a *AuthAPI) generateAccessToken(user *authv1.User) (string, *authv1_cache.
 	return accessToken, accessTokenMetadata, nil
 }
 
-func (a *AuthAPI) generateRefreshToken(tenantID string, userID string) (string, *authv1_cache.RefreshToken, error) {
+func (a *AuthAPI) generateRefreshToken(ctx context.Context, tenantID string, userID string, deviceID string, parent *authv1_cache.RefreshToken) (string, *authv1_cache.RefreshToken, error) {
 	issuedAt := time.Now()
 	// Generate refresh token
-	tokenString, refreshToken, err := a.tokenManager.GenerateRefreshToken(GenerateRefreshTokenInput{
+	tokenString, refreshToken, err := a.tokenManager.GenerateRefreshToken(ctx, GenerateRefreshTokenInput{
 		UserId:    userID,
 		TenantId:  tenantID,
 		CreatedAt: issuedAt,
+		DeviceId:  deviceID,
+		Parent:    parent,
 	})
 	if err != nil {
-		return "", nil, status.Error(codes.Internal, err.Error())
+		return "", nil, err
 	}
 	return tokenString, refreshToken, nil
 }
 
-func (a *AuthAPI) generateAndStoreTokens(user *authv1.User) (*NewTokenResponse, error) {
-	accessToken, accessTokenMetadata, err := a.generateAccessToken(user)
+// generateAndStoreTokens issues a token pair for a new login, starting a new refresh token family
+func (a *AuthAPI) generateAndStoreTokens(ctx context.Context, user *authv1.User) (*NewTokenResponse, error) {
+	return a.rotateAndStoreTokens(ctx, user, nil)
+}
+
+// rotateAndStoreTokens issues a token pair whose refresh token is rotated from parent
+func (a *AuthAPI) rotateAndStoreTokens(ctx context.Context, user *authv1.User, parent *authv1_cache.RefreshToken) (*NewTokenResponse, error) {
+	accessToken, accessTokenMetadata, err := a.generateAccessToken(ctx, user, nil, 0)
 	if err != nil {
 		return nil, err
 	}
-	refreshTokenString, refreshTokenModel, err := a.generateRefreshToken(user.GetTenantId(), user.GetId())
+	// The tokens are issued on the device of the login, the rotated ones stay on it so revoking the device revokes them
+	device := parent.GetDeviceId()
+	if device == "" {
+		_, userAgent := interceptor.ClientFromContext(ctx)
+		device = deviceID(userAgent)
+	}
+	accessTokenMetadata.DeviceId = device
+	refreshTokenString, refreshTokenModel, err := a.generateRefreshToken(ctx, user.GetTenantId(), user.GetId(), device, parent)
 	if err != nil {
 		return nil, err
 	}
 
 	// Store tokens (single token per user - automatically replaces existing)
-	err = a.tokenManager.StoreTokens(user.GetTenantId(), user.GetId(), accessTokenMetadata, refreshTokenModel)
+	err = a.tokenManager.StoreTokens(ctx, user.GetTenantId(), user.GetId(), accessTokenMetadata, refreshTokenModel)
 	if err != nil {
 		return nil, err
 	}
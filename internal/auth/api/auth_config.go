@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_shared "erp.localhost/internal/infra/model/shared"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Timeout of calls to the config service
+const configRequestTimeout = 5 * time.Second
+
+var errConfigClientMissing = infra_error.Internal(infra_error.InternalConfigError, errors.New("config service client is not configured"))
+
+// SetConfigClient sets the config service client the tenant auth settings are stored with,
+// tenant token policies are applied from then on
+func (a *AuthAPI) SetConfigClient(configClient client.ConfigClient) {
+	a.configClient = configClient
+	a.tokenPolicies = newTenantTokenPolicies(func(tenantID string) (*authv1.TokenPolicy, error) {
+		policy, _, err := a.storedTokenPolicy(tenantID)
+		return policy, err
+	}, tokenPolicyCacheTTL)
+	a.tokenManager.policies = a.tokenPolicies
+}
+
+// loadAuthConfig reads the message stored under key in the auth module config of the tenant into message,
+// message is left empty when the key is not set
+func (a *AuthAPI) loadAuthConfig(tenantID, key string, message proto.Message) (int32, error) {
+	if a.configClient == nil {
+		return 0, errConfigClientMissing
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), configRequestTimeout)
+	defer cancel()
+	moduleConfig, version, err := a.configClient.GetConfig(ctx, tenantID, string(model_shared.ModuleAuth))
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := moduleConfig.GetFields()[key]
+	if !ok {
+		return version, nil
+	}
+	data, err := protojson.Marshal(value)
+	if err != nil {
+		return 0, infra_error.Internal(infra_error.InternalConfigError, err)
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+		return 0, infra_error.Internal(infra_error.InternalConfigError, err)
+	}
+	return version, nil
+}
+
+// storeAuthConfig stores message under key in the auth module config of the tenant and returns the new version
+func (a *AuthAPI) storeAuthConfig(tenantID, userID, key string, message proto.Message) (int32, error) {
+	if a.configClient == nil {
+		return 0, errConfigClientMissing
+	}
+	value, err := authConfigValue(message)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), configRequestTimeout)
+	defer cancel()
+	// The key shares the auth module config with other keys, which are kept as they are
+	moduleConfig, _, err := a.configClient.GetConfig(ctx, tenantID, string(model_shared.ModuleAuth))
+	if err != nil {
+		return 0, err
+	}
+	if moduleConfig == nil || moduleConfig.Fields == nil {
+		moduleConfig = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	}
+	moduleConfig.Fields[key] = value
+	return a.configClient.SetConfig(ctx, tenantID, userID, string(model_shared.ModuleAuth), moduleConfig)
+}
+
+// authConfigValue converts message to the struct value stored in the config service
+func authConfigValue(message proto.Message) (*structpb.Value, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	value := &structpb.Value{}
+	if err := protojson.Unmarshal(data, value); err != nil {
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo/changestream"
+	"erp.localhost/internal/infra/event/outbox"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// changeEventNamespace derives the ids of the changed events from the changes, a change handled twice records the
+// same event id so the consumers drop the duplicate
+var changeEventNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("erp.localhost/auth/changes"))
+
+// ChangeFeedCollections are the collections whose changes are handled by the ChangeFeed
+var ChangeFeedCollections = []model_mongo.Collection{
+	model_mongo.UsersCollection,
+	model_mongo.TenantsCollection,
+	model_mongo.RolesCollection,
+	model_mongo.PermissionsCollection,
+}
+
+// ChangeFeed handles the changes of the users, tenants, roles and permissions read from their change stream. It
+// sees the changes of the API as well as the ones made around it, e.g. by the init job, a migration or another
+// replica of the service
+type ChangeFeed struct {
+	verification *VerificationAPI
+	tenants      *TenantCache
+	outbox       *outbox.Outbox
+	logger       logger.Logger
+}
+
+func NewChangeFeed(rbacAPI *RBACAPI, logger logger.Logger) *ChangeFeed {
+	return &ChangeFeed{
+		verification: rbacAPI.Verification,
+		logger:       logger,
+	}
+}
+
+// SetOutbox records the changes as auth.<aggregate>.changed events in events
+func (f *ChangeFeed) SetOutbox(events *outbox.Outbox) {
+	f.outbox = events
+}
+
+// SetTenantCache drops the changed tenants from tenants
+func (f *ChangeFeed) SetTenantCache(tenants *TenantCache) {
+	f.tenants = tenants
+}
+
+// InvalidateTenants drops the changed tenant from the tenant cache, so the status and limits changed by another
+// replica or around the API apply before the TTL of the cache
+func (f *ChangeFeed) InvalidateTenants(_ context.Context, change *changestream.Change) error {
+	if f.tenants != nil && model_mongo.Collection(change.Collection) == model_mongo.TenantsCollection {
+		f.tenants.Invalidate(change.DocumentID)
+	}
+	return nil
+}
+
+// InvalidatePermissions drops the permissions cached for the change: a user change drops the user, a tenant, role
+// or permission change drops every user of the tenant. The deleted users carry no tenant, their cached permissions
+// expire with the TTL of the cache
+func (f *ChangeFeed) InvalidatePermissions(ctx context.Context, change *changestream.Change) error {
+	switch model_mongo.Collection(change.Collection) {
+	case model_mongo.UsersCollection:
+		if change.TenantID != "" {
+			f.verification.InvalidateUserPermissions(ctx, change.TenantID, change.DocumentID)
+		}
+	case model_mongo.TenantsCollection:
+		f.verification.InvalidateTenantPermissions(ctx, change.DocumentID)
+	case model_mongo.RolesCollection, model_mongo.PermissionsCollection:
+		if change.TenantID != "" {
+			f.verification.InvalidateTenantPermissions(ctx, change.TenantID)
+		}
+	}
+	return nil
+}
+
+// Publish records the change as an auth.<aggregate>.changed event, the payload of a delete only carries the id of
+// the document
+func (f *ChangeFeed) Publish(ctx context.Context, change *changestream.Change) error {
+	if f.outbox == nil {
+		return nil
+	}
+	event, err := changedEvent(change)
+	if err != nil || event == nil {
+		return err
+	}
+	event.Id = uuid.NewSHA1(changeEventNamespace, []byte(change.ID)).String()
+	event.OccurredAt = timestamppb.New(change.Time)
+	return f.outbox.Add(ctx, event)
+}
+
+// changedEvent returns the event of change, nil for the collections without one
+func changedEvent(change *changestream.Change) (*eventv1.DomainEvent, error) {
+	deleted := change.Operation == changestream.OperationDelete || len(change.Document) == 0
+	switch model_mongo.Collection(change.Collection) {
+	case model_mongo.UsersCollection:
+		user := &authv1.User{Id: change.DocumentID, TenantId: change.TenantID}
+		if !deleted {
+			if err := change.Decode(user); err != nil {
+				return nil, err
+			}
+		}
+		return userEvent(model_event.EventUserChanged, "", user), nil
+	case model_mongo.TenantsCollection:
+		tenant := &authv1.Tenant{Id: change.DocumentID}
+		if !deleted {
+			if err := change.Decode(tenant); err != nil {
+				return nil, err
+			}
+		}
+		return tenantEvent(model_event.EventTenantChanged, "", tenant), nil
+	case model_mongo.RolesCollection:
+		role := &authv1.Role{Id: change.DocumentID, TenantId: change.TenantID}
+		if !deleted {
+			if err := change.Decode(role); err != nil {
+				return nil, err
+			}
+		}
+		return roleEvent(model_event.EventRoleChanged, "", role), nil
+	case model_mongo.PermissionsCollection:
+		permission := &authv1.Permission{Id: change.DocumentID, TenantId: change.TenantID}
+		if !deleted {
+			if err := change.Decode(permission); err != nil {
+				return nil, err
+			}
+		}
+		return permissionEvent(model_event.EventPermissionChanged, "", permission), nil
+	default:
+		return nil, nil
+	}
+}
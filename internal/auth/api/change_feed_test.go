@@ -0,0 +1,39 @@
+package api
+
+import (
+	"testing"
+
+	"erp.localhost/internal/infra/db/mongo/changestream"
+	model_event "erp.localhost/internal/infra/model/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestChangedEvent(t *testing.T) {
+	document, err := bson.Marshal(bson.M{"_id": "role-1", "tenant_id": "tenant-1", "name": "Admin", "permissions": []string{"user:read"}})
+	require.NoError(t, err)
+	event, err := changedEvent(&changestream.Change{
+		Operation:  changestream.OperationUpdate,
+		Collection: "roles",
+		DocumentID: "role-1",
+		TenantID:   "tenant-1",
+		Document:   document,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model_event.EventRoleChanged, event.Type)
+	assert.Equal(t, "tenant-1", event.TenantId)
+	require.NotNil(t, event.GetRole())
+	assert.Equal(t, "Admin", event.GetRole().Name)
+	assert.Equal(t, []string{"user:read"}, event.GetRole().Permissions)
+
+	// A delete only carries the id of the document
+	event, err = changedEvent(&changestream.Change{Operation: changestream.OperationDelete, Collection: "users", DocumentID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, model_event.EventUserChanged, event.Type)
+	assert.Equal(t, "user-1", event.GetUser().UserId)
+
+	event, err = changedEvent(&changestream.Change{Operation: changestream.OperationInsert, Collection: "api_keys"})
+	require.NoError(t, err)
+	assert.Nil(t, event)
+}
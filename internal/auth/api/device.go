@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// unknownDeviceName names the devices whose user agent tells no platform
+const unknownDeviceName = "Unknown device"
+
+// devicePlatforms are the platforms told by the user agents, the first match wins so the mobile platforms come
+// before the desktop ones their user agents mention
+var devicePlatforms = []struct{ token, platform string }{
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"CrOS", "ChromeOS"},
+	{"Windows", "Windows"},
+	{"Macintosh", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// deviceStore persists the devices users logged in from, see handler.DeviceHandler
+type deviceStore interface {
+	RegisterDevice(ctx context.Context, device *authv1.Device) (*authv1.Device, error)
+	ListDevices(ctx context.Context, tenantID, userID string) ([]*authv1.Device, error)
+	GetDevice(ctx context.Context, tenantID, userID, deviceID string) (*authv1.Device, error)
+	RenameDevice(ctx context.Context, tenantID, userID, deviceID, name string) (*authv1.Device, error)
+	DeleteDevice(ctx context.Context, tenantID, userID, deviceID string) error
+}
+
+// deviceSessionRevoker revokes the tokens issued on a device
+type deviceSessionRevoker interface {
+	RevokeDeviceTokens(ctx context.Context, tenantID, userID, deviceID, revokedBy string) (bool, error)
+}
+
+// ListDevices returns the devices accountID of targetTenantID logged in from, most recently seen first.
+// Users may always list their own devices, other accounts require user read permission
+func (u *UserAPI) ListDevices(ctx context.Context, tenantID, userID, targetTenantID, accountID string) ([]*authv1.Device, error) {
+	targetTenantID, accountID, err := u.deviceOwner(ctx, tenantID, userID, targetTenantID, accountID, permissions.UserRead)
+	if err != nil {
+		u.logger.Error("failed to list devices", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	return u.devices.ListDevices(ctx, targetTenantID, accountID)
+}
+
+// RenameDevice names the device deviceID of accountID of targetTenantID and returns it. Users may always rename
+// their own devices, other accounts require user update permission
+func (u *UserAPI) RenameDevice(ctx context.Context, tenantID, userID, targetTenantID, accountID, deviceID, name string) (*authv1.Device, error) {
+	name = strings.TrimSpace(name)
+	if deviceID == "" || name == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "device_id", "name")
+	}
+	targetTenantID, accountID, err := u.deviceOwner(ctx, tenantID, userID, targetTenantID, accountID, permissions.UserUpdate)
+	if err != nil {
+		u.logger.Error("failed to rename device", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	return u.devices.RenameDevice(ctx, targetTenantID, accountID, deviceID, name)
+}
+
+// RevokeDevice forgets the device deviceID of accountID of targetTenantID and revokes the tokens issued on it, and
+// reports whether there were any. The next login from the device registers it again as a new, untrusted device.
+// Users may always revoke their own devices, other accounts require user update permission
+func (u *UserAPI) RevokeDevice(ctx context.Context, tenantID, userID, targetTenantID, accountID, deviceID string) (bool, error) {
+	if deviceID == "" {
+		return false, infra_error.Validation(infra_error.ValidationRequiredFields, "device_id")
+	}
+	targetTenantID, accountID, err := u.deviceOwner(ctx, tenantID, userID, targetTenantID, accountID, permissions.UserUpdate)
+	if err != nil {
+		u.logger.Error("failed to revoke device", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, err
+	}
+	device, err := u.devices.GetDevice(ctx, targetTenantID, accountID, deviceID)
+	if err != nil {
+		return false, err
+	}
+
+	// The tokens are revoked first, a failure leaves the device listed so the revocation can be retried
+	tokensRevoked := false
+	if u.deviceSessions != nil {
+		tokensRevoked, err = u.deviceSessions.RevokeDeviceTokens(ctx, targetTenantID, accountID, device.GetFingerprint(), userID)
+		if err != nil {
+			u.logger.Error("failed to revoke device tokens", "target_tenant_id", targetTenantID, "account_id", accountID, "device_id", deviceID, "error", err)
+			return false, err
+		}
+	}
+	if u.knownDevices != nil {
+		// A login from the revoked device is scored as a new device again
+		if err := u.knownDevices.Forget(ctx, targetTenantID, accountID, device.GetFingerprint(), u.knownDeviceTTL); err != nil {
+			u.logger.Warn("failed to forget revoked device", "target_tenant_id", targetTenantID, "account_id", accountID, "device_id", deviceID, "error", err)
+		}
+	}
+	if err := u.devices.DeleteDevice(ctx, targetTenantID, accountID, deviceID); err != nil {
+		return false, err
+	}
+	u.logger.Info("device revoked", "target_tenant_id", targetTenantID, "account_id", accountID, "device_id", deviceID, "revoked_by", userID, "tokens_revoked", tokensRevoked)
+	return tokensRevoked, nil
+}
+
+// deviceOwner returns the tenant and account whose devices a call is on, the caller by default. The devices of the
+// other accounts require permission
+func (u *UserAPI) deviceOwner(ctx context.Context, tenantID, userID, targetTenantID, accountID, permission string) (string, string, error) {
+	if tenantID == "" || userID == "" {
+		return "", "", infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+	}
+	if u.devices == nil {
+		return "", "", infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("devices are not available"))
+	}
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+	if accountID == "" {
+		accountID = userID
+	}
+	if targetTenantID != tenantID || accountID != userID {
+		if err := u.hasPermission(ctx, tenantID, userID, permission, targetTenantID); err != nil {
+			return "", "", err
+		}
+	}
+	return targetTenantID, accountID, nil
+}
+
+// registerDevice records the device of a successful login of user, trusted when the login passed MFA, which every
+// login of the users with MFA does, or a step-up code. Failures do not fail the login, the device is registered on
+// the next one
+func (a *AuthAPI) registerDevice(ctx context.Context, user *authv1.User, risk *loginRisk) {
+	if a.userAPI.devices == nil || risk.deviceID == "" {
+		return
+	}
+	platform := devicePlatform(risk.userAgent)
+	name := platform
+	if name == "" {
+		name = unknownDeviceName
+	}
+	_, err := a.userAPI.devices.RegisterDevice(ctx, &authv1.Device{
+		TenantId:      user.GetTenantId(),
+		UserId:        user.GetId(),
+		Fingerprint:   risk.deviceID,
+		Name:          name,
+		Platform:      platform,
+		UserAgent:     risk.userAgent,
+		LastIpAddress: risk.ip,
+		Trusted:       user.GetMfaEnabled() || risk.steppedUp,
+	})
+	if err != nil {
+		a.logger.Warn("failed to register device", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+	}
+}
+
+// devicePlatform returns the platform userAgent tells, empty when it tells none
+func devicePlatform(userAgent string) string {
+	for _, candidate := range devicePlatforms {
+		if strings.Contains(userAgent, candidate.token) {
+			return candidate.platform
+		}
+	}
+	return ""
+}
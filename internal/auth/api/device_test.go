@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeviceStore struct {
+	devices map[string]*authv1.Device
+}
+
+func (f *fakeDeviceStore) RegisterDevice(_ context.Context, device *authv1.Device) (*authv1.Device, error) {
+	f.devices[device.GetFingerprint()] = device
+	return device, nil
+}
+
+func (f *fakeDeviceStore) ListDevices(_ context.Context, tenantID, userID string) ([]*authv1.Device, error) {
+	devices := []*authv1.Device{}
+	for _, device := range f.devices {
+		if device.GetTenantId() == tenantID && device.GetUserId() == userID {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+func (f *fakeDeviceStore) GetDevice(_ context.Context, tenantID, userID, deviceID string) (*authv1.Device, error) {
+	for _, device := range f.devices {
+		if device.GetTenantId() == tenantID && device.GetUserId() == userID && device.GetId() == deviceID {
+			return device, nil
+		}
+	}
+	return nil, infra_error.NotFound(infra_error.NotFoundResource, "device", deviceID)
+}
+
+func (f *fakeDeviceStore) RenameDevice(ctx context.Context, tenantID, userID, deviceID, name string) (*authv1.Device, error) {
+	device, err := f.GetDevice(ctx, tenantID, userID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	device.Name = name
+	return device, nil
+}
+
+func (f *fakeDeviceStore) DeleteDevice(_ context.Context, _, _, deviceID string) error {
+	for fingerprint, device := range f.devices {
+		if device.GetId() == deviceID {
+			delete(f.devices, fingerprint)
+		}
+	}
+	return nil
+}
+
+type fakeDeviceSessions struct {
+	revoked []string
+}
+
+func (f *fakeDeviceSessions) RevokeDeviceTokens(_ context.Context, _, _, deviceID, _ string) (bool, error) {
+	f.revoked = append(f.revoked, deviceID)
+	return true, nil
+}
+
+type fakeKnownDeviceEraser struct {
+	forgotten []string
+}
+
+func (f *fakeKnownDeviceEraser) Delete(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (f *fakeKnownDeviceEraser) Forget(_ context.Context, _, _, deviceID string, _ time.Duration) error {
+	f.forgotten = append(f.forgotten, deviceID)
+	return nil
+}
+
+func TestDevicePlatform(t *testing.T) {
+	testCases := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36":                "Windows",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15": "iOS",
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36":                 "Android",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15":        "macOS",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36":                          "Linux",
+		"grpc-go/1.60.0": "",
+	}
+	for userAgent, want := range testCases {
+		assert.Equal(t, want, devicePlatform(userAgent), userAgent)
+	}
+}
+
+func TestAuthAPI_RegisterDevice(t *testing.T) {
+	a, _ := newLoginRiskTestAuthAPI(defaultLoginRiskConfig)
+	devices := &fakeDeviceStore{devices: map[string]*authv1.Device{}}
+	a.userAPI.devices = devices
+	user := &authv1.User{Id: "user-1", TenantId: "tenant-1"}
+
+	a.registerDevice(context.Background(), user, &loginRisk{deviceID: "laptop", userAgent: "Mozilla/5.0 (Windows NT 10.0)", ip: "203.0.113.7"})
+	require.Contains(t, devices.devices, "laptop")
+	assert.Equal(t, "Windows", devices.devices["laptop"].GetName())
+	assert.False(t, devices.devices["laptop"].GetTrusted())
+
+	// A login confirmed with a step-up code trusts its device
+	a.registerDevice(context.Background(), user, &loginRisk{deviceID: "phone", steppedUp: true})
+	require.Contains(t, devices.devices, "phone")
+	assert.Equal(t, unknownDeviceName, devices.devices["phone"].GetName())
+	assert.True(t, devices.devices["phone"].GetTrusted())
+
+	// Clients without a user agent have no device
+	a.registerDevice(context.Background(), user, &loginRisk{})
+	assert.Len(t, devices.devices, 2)
+}
+
+func TestUserAPI_RevokeDevice(t *testing.T) {
+	sessions := &fakeDeviceSessions{}
+	known := &fakeKnownDeviceEraser{}
+	u := &UserAPI{
+		logger: logger.NewBaseLogger(shared.ModuleAuth),
+		devices: &fakeDeviceStore{devices: map[string]*authv1.Device{
+			"laptop": {Id: "device-1", TenantId: "tenant-1", UserId: "user-1", Fingerprint: "laptop"},
+		}},
+		deviceSessions: sessions,
+		knownDevices:   known,
+	}
+	ctx := context.Background()
+
+	tokensRevoked, err := u.RevokeDevice(ctx, "tenant-1", "user-1", "", "", "device-1")
+	require.NoError(t, err)
+	assert.True(t, tokensRevoked)
+	assert.Equal(t, []string{"laptop"}, sessions.revoked)
+	assert.Equal(t, []string{"laptop"}, known.forgotten)
+	devices, err := u.ListDevices(ctx, "tenant-1", "user-1", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+
+	_, err = u.RevokeDevice(ctx, "tenant-1", "user-1", "", "", "device-1")
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+	_, err = u.RevokeDevice(ctx, "tenant-1", "user-1", "", "", "")
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryValidation))
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EmailSender delivers outbound emails. Implementations are plugged in by the service entry point.
+type EmailSender interface {
+	SendEmail(to, subject, body string) error
+}
+
+// logEmailSender is used until a real sender is configured, it only logs that an email would be sent
+type logEmailSender struct {
+	logger logger.Logger
+}
+
+func (s *logEmailSender) SendEmail(to, subject, body string) error {
+	s.logger.Info("email sender not configured, email not delivered", "to", to, "subject", subject)
+	return nil
+}
+
+type EmailVerificationConfig struct {
+	TokenDuration time.Duration
+	// Link sent to the user, the token and tenant are appended as query parameters
+	VerificationURL string
+}
+
+// LoadEmailVerificationConfig loads email verification configuration from environment variables
+func LoadEmailVerificationConfig() *EmailVerificationConfig {
+	return &EmailVerificationConfig{
+		TokenDuration:   parseDuration(getEnv("EMAIL_VERIFICATION_DURATION", "24h"), 24*time.Hour),
+		VerificationURL: getEnv("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email"),
+	}
+}
+
+// SetEmailSender replaces the sender used for verification emails
+func (u *UserAPI) SetEmailSender(sender EmailSender) {
+	if sender != nil {
+		u.emailSender = sender
+	}
+}
+
+// SendEmailVerification issues a verification token for the account email and sends the verification link.
+// Users may request it for themselves, sending it for other accounts requires user update permission.
+func (u *UserAPI) SendEmailVerification(ctx context.Context, tenantID, userID, accountID string) error {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		u.logger.Error("failed to send email verification", "error", err)
+		return err
+	}
+	if accountID == "" {
+		accountID = userID
+	}
+	if accountID != userID {
+		if err := u.hasPermission(ctx, tenantID, userID, permissions.UserUpdate, tenantID); err != nil {
+			u.logger.Error("failed to send email verification", "tenant_id", tenantID, "user_id", userID, "error", err)
+			return err
+		}
+	}
+
+	user, err := u.getUser(ctx, tenantID, accountID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to send email verification", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	if user.GetEmail() == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "email")
+	}
+	if user.GetEmailVerified() {
+		return infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("email is already verified"))
+	}
+
+	token, err := generateVerificationToken()
+	if err != nil {
+		u.logger.Error("failed to generate email verification token", "tenant_id", tenantID, "user_id", accountID, "error", err)
+		return err
+	}
+	// Only the hash of the token is stored, the token itself is only in the link
+	tokenHash := u.codeHasher.Hash(tenantID, token)
+	now := time.Now()
+	verificationToken := &authv1_cache.EmailVerificationToken{
+		TokenHash: tokenHash,
+		UserId:    user.GetId(),
+		Email:     user.GetEmail(),
+		CreatedAt: timestamppb.New(now),
+		ExpiresAt: timestamppb.New(now.Add(u.emailVerificationConfig.TokenDuration)),
+	}
+	if err := u.emailVerificationHandler.Store(ctx, tenantID, verificationToken); err != nil {
+		return err
+	}
+
+	link := u.verificationLink(tenantID, token)
+	body := fmt.Sprintf("Please confirm your email address by opening the following link:\n\n%s\n\nThe link expires in %s.", link, u.emailVerificationConfig.TokenDuration)
+	if err := u.emailSender.SendEmail(user.GetEmail(), "Verify your email address", body); err != nil {
+		u.logger.Error("failed to deliver email verification", "tenant_id", tenantID, "user_id", accountID, "error", err)
+		if deleteErr := u.emailVerificationHandler.Delete(ctx, tenantID, tokenHash); deleteErr != nil {
+			u.logger.Warn("failed to delete undelivered email verification token", "tenant_id", tenantID, "user_id", accountID, "error", deleteErr)
+		}
+		return infra_error.Internal(infra_error.InternalExternalServiceError, err)
+	}
+
+	u.logger.Info("email verification sent", "tenant_id", tenantID, "user_id", accountID)
+	return nil
+}
+
+// ConfirmEmailVerification consumes a verification token and marks the email as verified. The token is invalidated
+// before the email is marked, so it verifies at most once, a failed confirmation needs a new link
+func (u *UserAPI) ConfirmEmailVerification(ctx context.Context, tenantID, token string) error {
+	if tenantID == "" || token == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, token"))
+		u.logger.Error("failed to confirm email verification", "error", err)
+		return err
+	}
+
+	tokenHash := u.codeHasher.Hash(tenantID, token)
+	verificationToken, err := u.emailVerificationHandler.Validate(ctx, tenantID, tokenHash)
+	if err != nil {
+		u.logger.Warn("invalid email verification token", "tenant_id", tenantID, "error", err)
+		return err
+	}
+	if err := u.emailVerificationHandler.Delete(ctx, tenantID, tokenHash); err != nil {
+		u.logger.Error("failed to delete used email verification token", "tenant_id", tenantID, "user_id", verificationToken.GetUserId(), "error", err)
+		return err
+	}
+
+	user, err := u.getUser(ctx, tenantID, verificationToken.GetUserId(), filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to confirm email verification", "tenant_id", tenantID, "user_id", verificationToken.GetUserId(), "error", err)
+		return err
+	}
+	// The email may have changed since the link was sent
+	if user.GetEmail() != verificationToken.GetEmail() {
+		return infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+
+	user.EmailVerified = true
+	if err := u.userHandler.UpdateUser(ctx, user); err != nil {
+		u.logger.Error("failed to mark email as verified", "tenant_id", tenantID, "user_id", user.GetId(), "error", err)
+		return err
+	}
+
+	u.logger.Info("email verified", "tenant_id", tenantID, "user_id", user.GetId())
+	return nil
+}
+
+func (u *UserAPI) verificationLink(tenantID, token string) string {
+	params := url.Values{}
+	params.Set("tenant_id", tenantID)
+	params.Set("token", token)
+	return fmt.Sprintf("%s?%s", u.emailVerificationConfig.VerificationURL, params.Encode())
+}
+
+func generateVerificationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return hex.EncodeToString(buf), nil
+}
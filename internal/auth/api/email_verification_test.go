@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGenerateVerificationToken(t *testing.T) {
+	first, err := generateVerificationToken()
+	require.NoError(t, err)
+	second, err := generateVerificationToken()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 64)
+	assert.NotEqual(t, first, second)
+}
+
+func TestVerificationLink(t *testing.T) {
+	userAPI := &UserAPI{
+		emailVerificationConfig: &EmailVerificationConfig{VerificationURL: "https://erp.localhost/verify-email"},
+	}
+
+	link := userAPI.verificationLink("tenant 1", "abc123")
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "erp.localhost", parsed.Host)
+	assert.Equal(t, "/verify-email", parsed.Path)
+	assert.Equal(t, "tenant 1", parsed.Query().Get("tenant_id"))
+	assert.Equal(t, "abc123", parsed.Query().Get("token"))
+}
+
+// failingEmailSender fails every delivery
+type failingEmailSender struct{}
+
+func (failingEmailSender) SendEmail(to, subject, body string) error {
+	return errors.New("smtp unavailable")
+}
+
+var emailedVerificationToken = regexp.MustCompile(`token=([0-9a-f]{64})`)
+
+func TestUserAPI_EmailVerification(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+	ctx := context.Background()
+	keys := memory.SharedKeys(string(model_redis.RedisKeyEmailVerify))
+	t.Cleanup(func() { _, _ = keys.DeleteByPattern(ctx, "tenant-1:*") })
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	userHandler, err := handler.NewUserHandler(log)
+	require.NoError(t, err)
+	emailVerificationHandler, err := handler.NewEmailVerificationHandler(log)
+	require.NoError(t, err)
+	sender := &recordingEmailSender{}
+	u := &UserAPI{
+		logger:                   log,
+		userHandler:              userHandler,
+		emailVerificationHandler: emailVerificationHandler,
+		emailVerificationConfig:  &EmailVerificationConfig{TokenDuration: time.Hour, VerificationURL: "https://erp.localhost/verify-email"},
+		emailSender:              failingEmailSender{},
+		codeHasher:               hash.NewCodeHasher("secret"),
+	}
+
+	userID, err := userHandler.CreateUser(ctx, &authv1.User{
+		TenantId:     "tenant-1",
+		Username:     "user",
+		Email:        "user@example.com",
+		PasswordHash: "hash",
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    "System",
+		CreatedAt:    timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	// sendToken sends a verification email and returns the token of its link
+	sendToken := func() string {
+		t.Helper()
+		require.NoError(t, u.SendEmailVerification(ctx, "tenant-1", userID, ""))
+		match := emailedVerificationToken.FindStringSubmatch(sender.bodies[len(sender.bodies)-1])
+		require.Len(t, match, 2)
+		return match[1]
+	}
+	assertVerified := func(verified bool) {
+		t.Helper()
+		user, err := userHandler.GetUserByID(ctx, "tenant-1", userID)
+		require.NoError(t, err)
+		assert.Equal(t, verified, user.GetEmailVerified())
+	}
+
+	// The token of an undelivered email is not kept
+	err = u.SendEmailVerification(ctx, "tenant-1", userID, "")
+	assert.True(t, infra_error.Internal(infra_error.InternalExternalServiceError, nil).Is(err))
+	stored, err := keys.Scan(ctx, "tenant-1:*", 10)
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+	u.SetEmailSender(sender)
+
+	err = u.ConfirmEmailVerification(ctx, "tenant-1", "wrong")
+	assert.True(t, infra_error.Auth(infra_error.AuthTokenInvalid).Is(err))
+
+	// The token is consumed before the user is updated, a failed update needs a new link
+	token := sendToken()
+	errWrite := errors.New("write failed")
+	documents.OnWrite(func(collectionName string, doc bson.M) error {
+		if collectionName == string(model_mongo.UsersCollection) {
+			return errWrite
+		}
+		return nil
+	})
+	err = u.ConfirmEmailVerification(ctx, "tenant-1", token)
+	require.ErrorIs(t, err, errWrite)
+	documents.OnWrite(nil)
+	err = u.ConfirmEmailVerification(ctx, "tenant-1", token)
+	assert.True(t, infra_error.Auth(infra_error.AuthTokenInvalid).Is(err))
+	assertVerified(false)
+
+	// Expired tokens are refused
+	token = sendToken()
+	tokenHash := u.codeHasher.Hash("tenant-1", token)
+	_, err = keys.Create(ctx, "tenant-1:"+tokenHash, &authv1_cache.EmailVerificationToken{
+		TokenHash: tokenHash,
+		UserId:    userID,
+		Email:     "user@example.com",
+		CreatedAt: timestamppb.New(time.Now().Add(-2 * time.Hour)),
+		ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour)),
+	})
+	require.NoError(t, err)
+	err = u.ConfirmEmailVerification(ctx, "tenant-1", token)
+	assert.True(t, infra_error.Auth(infra_error.AuthTokenExpired).Is(err))
+	assertVerified(false)
+
+	// A link is refused once the user changed their email
+	token = sendToken()
+	user, err := userHandler.GetUserByID(ctx, "tenant-1", userID)
+	require.NoError(t, err)
+	user.Email = "changed@example.com"
+	require.NoError(t, userHandler.UpdateUser(ctx, user))
+	err = u.ConfirmEmailVerification(ctx, "tenant-1", token)
+	assert.True(t, infra_error.Auth(infra_error.AuthTokenInvalid).Is(err))
+	assertVerified(false)
+
+	// A token verifies once
+	token = sendToken()
+	require.NoError(t, u.ConfirmEmailVerification(ctx, "tenant-1", token))
+	assertVerified(true)
+	err = u.ConfirmEmailVerification(ctx, "tenant-1", token)
+	assert.True(t, infra_error.Auth(infra_error.AuthTokenInvalid).Is(err))
+	err = u.SendEmailVerification(ctx, "tenant-1", userID, "")
+	assert.True(t, infra_error.Business(infra_error.BusinessInvalidOperation).Is(err))
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/auth/oidc"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// erasedName replaces the names of the erased users in their profile and audit logs
+	erasedName = "Erased user"
+	// erasedEmailDomain is the domain of the placeholder emails of the erased users, reserved so it never delivers
+	erasedEmailDomain = "erased.invalid"
+	// erasedPrefix starts the placeholder usernames and emails of the erased users
+	erasedPrefix = "erased-"
+)
+
+// auditLogAnonymizer writes the audit logs of the users and removes their personal data on erasure
+type auditLogAnonymizer interface {
+	auditLogWriter
+	AnonymizeAuditLogs(ctx context.Context, tenantID, userID, name string) (int, error)
+}
+
+// knownDeviceEraser forgets the devices and networks users logged in from, all of them on erasure and one on its
+// revocation
+type knownDeviceEraser interface {
+	Delete(ctx context.Context, tenantID, userID string) error
+	Forget(ctx context.Context, tenantID, userID, deviceID string, ttl time.Duration) error
+}
+
+// EraseUser anonymizes the personal data of accountID of targetTenantID: the user email, username, profile,
+// credentials and linked identities, the addresses of their login history and their names in the audit logs. The
+// user id is kept so the audit logs still refer to the user, the account is deactivated and its sessions revoked.
+// The returned receipt is also written to the audit log of the tenant
+func (u *UserAPI) EraseUser(ctx context.Context, tenantID, userID, targetTenantID, accountID, reason string) (*authv1.ErasureReceipt, error) {
+	if tenantID == "" || userID == "" || accountID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, account_id"))
+		u.logger.Error("failed to erase user", "error", err)
+		return nil, err
+	}
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserErase, targetTenantID); err != nil {
+		u.logger.Error("failed to erase user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	user, err := u.userHandler.GetUserByID(ctx, targetTenantID, accountID)
+	if err != nil {
+		u.logger.Error("failed to erase user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+		return nil, err
+	}
+	if isErasedUser(user) {
+		return nil, infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("user is already erased"))
+	}
+
+	receipt := &authv1.ErasureReceipt{
+		Id:       uuid.New().String(),
+		TenantId: targetTenantID,
+		UserId:   accountID,
+		ErasedBy: userID,
+		Reason:   reason,
+	}
+	// The related records are anonymized first, while the stored user still holds the accounts its login
+	// attempts were made with, so a failed erasure can be retried
+	if u.sessions != nil {
+		if err := u.sessions.RevokeAllTokens(ctx, targetTenantID, accountID, userID); err != nil {
+			u.logger.Error("failed to erase user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+			return nil, err
+		}
+		receipt.SessionsRevoked = true
+	}
+	loginRecords, err := u.loginHistoryHandler.AnonymizeLoginHistory(ctx, targetTenantID, accountID, []string{user.GetEmail(), user.GetUsername()})
+	if err != nil {
+		u.logger.Error("failed to erase user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+		return nil, err
+	}
+	receipt.LoginRecordsAnonymized = int32(loginRecords)
+	auditLogs, err := u.auditLogs.AnonymizeAuditLogs(ctx, targetTenantID, accountID, erasedName)
+	if err != nil {
+		u.logger.Error("failed to erase user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+		return nil, err
+	}
+	receipt.AuditLogsAnonymized = int32(auditLogs)
+	if u.knownDevices != nil {
+		if err := u.knownDevices.Delete(ctx, targetTenantID, accountID); err != nil {
+			u.logger.Error("failed to erase user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+			return nil, err
+		}
+	}
+
+	// A random password keeps password login impossible, the account is deactivated anyway
+	randomPassword, err := oidc.RandomValue()
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := hash.Hash(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+	receipt.ErasedFields = eraseUserData(user, passwordHash)
+	err = u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := u.userHandler.ReplaceUser(ctx, user); err != nil {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{userEvent(model_event.EventUserUpdated, userID, user)}, nil
+	})
+	if err != nil {
+		u.logger.Error("failed to erase user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+		return nil, err
+	}
+	u.rbacAPI.Verification.InvalidateUserPermissions(ctx, targetTenantID, accountID)
+
+	receipt.ErasedAt = timestamppb.Now()
+	u.auditErasure(ctx, receipt)
+	u.logger.Info("user erased", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID, "account_id", accountID, "receipt_id", receipt.GetId())
+	return receipt, nil
+}
+
+// eraseUserData replaces the personal data of user with placeholders, sets passwordHash as its password and
+// deactivates it. It returns the names of the erased fields
+func eraseUserData(user *authv1.User, passwordHash string) []string {
+	erased := []string{"email", "username", "profile"}
+	user.Email = erasedPrefix + user.GetId() + "@" + erasedEmailDomain
+	user.Username = erasedPrefix + user.GetId()
+	user.EmailVerified = false
+	user.PhoneVerified = false
+	user.Profile = &authv1.UserProfile{DisplayName: erasedName}
+
+	erased = append(erased, "password")
+	user.PasswordHash = passwordHash
+	user.PasswordHistory = nil
+	user.PasswordResetToken = ""
+	user.PasswordResetExpires = nil
+	if user.GetMfaEnabled() || user.GetMfaSecret() != "" {
+		erased = append(erased, "mfa")
+	}
+	user.MfaEnabled = false
+	user.MfaSecret = ""
+	user.MfaRecoveryCodes = nil
+	if len(user.GetExternalIdentities()) > 0 {
+		erased = append(erased, "external_identities")
+	}
+	user.ExternalIdentities = nil
+	if len(user.GetLoginHistory()) > 0 {
+		erased = append(erased, "login_history")
+	}
+	for _, record := range user.GetLoginHistory() {
+		record.IpAddress = ""
+		record.UserAgent = ""
+		record.Account = ""
+	}
+
+	user.Status = authv1.UserStatus_USER_STATUS_INACTIVE
+	user.DeletionScheduledAt = nil
+	return erased
+}
+
+// isErasedUser reports whether the personal data of user was erased
+func isErasedUser(user *authv1.User) bool {
+	return strings.HasSuffix(user.GetEmail(), "@"+erasedEmailDomain) && strings.HasPrefix(user.GetEmail(), erasedPrefix)
+}
+
+// auditErasure writes receipt to the audit log of the tenant of the erased user
+func (u *UserAPI) auditErasure(ctx context.Context, receipt *authv1.ErasureReceipt) {
+	erasedFields := make([]any, len(receipt.GetErasedFields()))
+	for i, field := range receipt.GetErasedFields() {
+		erasedFields[i] = field
+	}
+	metadata, err := structpb.NewStruct(map[string]any{
+		"reason":                   receipt.GetReason(),
+		"erased_fields":            erasedFields,
+		"login_records_anonymized": receipt.GetLoginRecordsAnonymized(),
+		"audit_logs_anonymized":    receipt.GetAuditLogsAnonymized(),
+		"sessions_revoked":         receipt.GetSessionsRevoked(),
+	})
+	if err != nil {
+		u.logger.Warn("Failed to build audit log metadata", "error", err)
+	}
+	auditLog := &eventv1.AuditLog{
+		Id:         receipt.GetId(),
+		Category:   model_event.CategoryDataAccess,
+		Action:     model_event.ActionRightToBeForgotten,
+		Severity:   model_event.SeverityWarning,
+		ActorId:    receipt.GetErasedBy(),
+		ActorType:  model_event.ActorTypeUser,
+		TargetId:   receipt.GetUserId(),
+		TargetType: model_event.TargetTypeUser,
+		Result:     model_event.ResultSuccess,
+		Message:    "user personal data erased",
+		Metadata:   metadata,
+	}
+	if err := u.auditLogs.CreateAuditLog(ctx, receipt.GetTenantId(), auditLog); err != nil {
+		u.logger.Error("Failed to write erasure receipt audit log", "error", err, "tenantID", receipt.GetTenantId(), "receiptID", receipt.GetId())
+	}
+}
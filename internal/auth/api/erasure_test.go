@@ -0,0 +1,56 @@
+package api
+
+import (
+	"testing"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEraseUserData(t *testing.T) {
+	user := &authv1.User{
+		Id:                  "9b2c7d4e-8f1a-4c3b-9d5e-6f7a8b9c0d1e",
+		TenantId:            "tenant-1",
+		Email:               "john@example.com",
+		Username:            "john",
+		PasswordHash:        "hash",
+		PasswordHistory:     []string{"old-hash"},
+		EmailVerified:       true,
+		MfaEnabled:          true,
+		MfaSecret:           "secret",
+		MfaRecoveryCodes:    []string{"code"},
+		Status:              authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:           "admin",
+		Profile:             &authv1.UserProfile{FirstName: "John", LastName: "Doe", Phone: "+15550100"},
+		Roles:               []*authv1.UserRole{{RoleId: "role-1", TenantId: "tenant-1", AssignedBy: "admin"}},
+		ExternalIdentities:  []*authv1.ExternalIdentity{{Provider: "google", Subject: "sub", Email: "john@example.com"}},
+		LoginHistory:        []*authv1.LoginRecord{{IpAddress: "10.0.0.1", UserAgent: "curl", Success: true}},
+		DeletionScheduledAt: timestamppb.Now(),
+	}
+	require.False(t, isErasedUser(user))
+
+	erased := eraseUserData(user, "new-hash")
+	assert.Equal(t, []string{"email", "username", "profile", "password", "mfa", "external_identities", "login_history"}, erased)
+	assert.True(t, isErasedUser(user))
+
+	assert.Equal(t, "9b2c7d4e-8f1a-4c3b-9d5e-6f7a8b9c0d1e", user.GetId())
+	assert.NotContains(t, user.GetEmail(), "john")
+	assert.NotContains(t, user.GetUsername(), "john")
+	assert.Equal(t, &authv1.UserProfile{DisplayName: erasedName}, user.GetProfile())
+	assert.Equal(t, "new-hash", user.GetPasswordHash())
+	assert.Empty(t, user.GetPasswordHistory())
+	assert.Empty(t, user.GetMfaSecret())
+	assert.Empty(t, user.GetMfaRecoveryCodes())
+	assert.Empty(t, user.GetExternalIdentities())
+	assert.Empty(t, user.GetLoginHistory()[0].GetIpAddress())
+	assert.True(t, user.GetLoginHistory()[0].GetSuccess())
+	assert.Equal(t, authv1.UserStatus_USER_STATUS_INACTIVE, user.GetStatus())
+	assert.Nil(t, user.GetDeletionScheduledAt())
+	// Role assignments are not personal data and are kept
+	assert.Len(t, user.GetRoles(), 1)
+	// The erased user is still stored, it must stay valid
+	require.NoError(t, validator_auth.ValidateUser(user, false))
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"erp.localhost/internal/infra/event/outbox"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+)
+
+/* Domain events of the auth entities, written to the outbox with their change */
+
+func userEvent(eventType, actorID string, user *authv1.User) *eventv1.DomainEvent {
+	event := outbox.NewEvent(eventType, user.GetTenantId(), actorID)
+	roleIDs := make([]string, 0, len(user.GetRoles()))
+	for _, role := range user.GetRoles() {
+		roleIDs = append(roleIDs, role.GetRoleId())
+	}
+	event.Payload = &eventv1.DomainEvent_User{User: &eventv1.UserEvent{
+		UserId:   user.GetId(),
+		Email:    user.GetEmail(),
+		Username: user.GetUsername(),
+		Status:   user.GetStatus().String(),
+		RoleIds:  roleIDs,
+	}}
+	return event
+}
+
+func loginEvent(eventType string, user *authv1.User, risk *loginRisk) *eventv1.DomainEvent {
+	event := outbox.NewEvent(eventType, user.GetTenantId(), user.GetId())
+	event.Payload = &eventv1.DomainEvent_Login{Login: &eventv1.LoginEvent{
+		UserId:      user.GetId(),
+		IpAddress:   risk.ip,
+		UserAgent:   risk.userAgent,
+		RiskScore:   risk.score,
+		RiskReasons: risk.reasons,
+	}}
+	return event
+}
+
+func tenantEvent(eventType, actorID string, tenant *authv1.Tenant) *eventv1.DomainEvent {
+	event := outbox.NewEvent(eventType, tenant.GetId(), actorID)
+	event.Payload = &eventv1.DomainEvent_Tenant{Tenant: &eventv1.TenantEvent{
+		TenantId: tenant.GetId(),
+		Name:     tenant.GetName(),
+		Slug:     tenant.GetSlug(),
+		Status:   tenant.GetStatus().String(),
+	}}
+	return event
+}
+
+func roleEvent(eventType, actorID string, role *authv1.Role) *eventv1.DomainEvent {
+	event := outbox.NewEvent(eventType, role.GetTenantId(), actorID)
+	event.Payload = &eventv1.DomainEvent_Role{Role: &eventv1.RoleEvent{
+		RoleId:      role.GetId(),
+		Name:        role.GetName(),
+		Status:      role.GetStatus().String(),
+		Permissions: role.GetPermissions(),
+	}}
+	return event
+}
+
+func permissionEvent(eventType, actorID string, permission *authv1.Permission) *eventv1.DomainEvent {
+	event := outbox.NewEvent(eventType, permission.GetTenantId(), actorID)
+	event.Payload = &eventv1.DomainEvent_Permission{Permission: &eventv1.PermissionEvent{
+		PermissionId:     permission.GetId(),
+		PermissionString: permission.GetPermissionString(),
+		Resource:         permission.GetResource(),
+		Action:           permission.GetAction(),
+	}}
+	return event
+}
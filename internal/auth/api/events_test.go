@@ -0,0 +1,41 @@
+package api
+
+import (
+	"testing"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserEvent(t *testing.T) {
+	user := &authv1.User{
+		Id:       "user-1",
+		TenantId: "tenant-1",
+		Email:    "jane@example.com",
+		Username: "jane",
+		Status:   authv1.UserStatus_USER_STATUS_ACTIVE,
+		Roles:    []*authv1.UserRole{{RoleId: "role-1"}, {RoleId: "role-2"}},
+	}
+	event := userEvent(model_event.EventUserUpdated, "admin-1", user)
+
+	assert.NotEmpty(t, event.Id)
+	assert.Equal(t, model_event.EventUserUpdated, event.Type)
+	assert.Equal(t, "tenant-1", event.TenantId)
+	assert.Equal(t, "admin-1", event.ActorId)
+	require.NotNil(t, event.GetUser())
+	assert.Equal(t, "user-1", event.GetUser().UserId)
+	assert.Equal(t, "USER_STATUS_ACTIVE", event.GetUser().Status)
+	assert.Equal(t, []string{"role-1", "role-2"}, event.GetUser().RoleIds)
+}
+
+func TestCreatedTenantEvent(t *testing.T) {
+	event := createdTenantEvent("admin-1", "tenant-2", &authv1.Tenant{Name: "Acme", Slug: "acme"})
+
+	assert.Equal(t, model_event.EventTenantCreated, event.Type)
+	assert.Equal(t, "tenant-2", event.TenantId)
+	require.NotNil(t, event.GetTenant())
+	assert.Equal(t, "tenant-2", event.GetTenant().TenantId)
+	assert.Equal(t, "Acme", event.GetTenant().Name)
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/auth/oidc"
+	infra_error "erp.localhost/internal/infra/error"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// oidcCreatedByPrefix marks users created on their first OIDC login, followed by the provider name
+	oidcCreatedByPrefix = "oidc:"
+	// samlCreatedByPrefix marks users created on their first SAML login, followed by the IdP entity id
+	samlCreatedByPrefix = "saml:"
+)
+
+// federatedIdentity is a user verified by an external identity provider (OIDC or SAML)
+type federatedIdentity struct {
+	// Provider identifies the provider in linked identities, e.g. "google" or "saml"
+	Provider string
+	Issuer   string
+	Subject  string
+	Email    string
+	// EmailVerified is set when the provider vouches that the email belongs to the user
+	EmailVerified bool
+	DisplayName   string
+	FirstName     string
+	LastName      string
+	// CreatedBy is recorded on provisioned users and their default roles
+	CreatedBy string
+}
+
+// federationPolicy is the tenant policy for external identities that are not linked to a user yet
+type federationPolicy struct {
+	AutoProvision  bool
+	DefaultRoleIDs []string
+	// LinkByEmail links the identity to the user with the same email when the email is verified
+	LinkByEmail    bool
+	AllowedDomains []string
+}
+
+// federatedUser resolves the user of a verified external identity following the tenant policy
+func (a *AuthAPI) federatedUser(ctx context.Context, tenantID string, identity *federatedIdentity, policy *federationPolicy) (*authv1.User, error) {
+	userHandler := a.userAPI.userHandler
+	user, err := userHandler.GetUserByExternalIdentity(ctx, tenantID, identity.Provider, identity.Subject)
+	if err != nil || user != nil {
+		return user, err
+	}
+
+	var existing *authv1.User
+	if identity.Email != "" {
+		if existing, err = userHandler.FindUserByEmail(ctx, tenantID, identity.Email); err != nil {
+			return nil, err
+		}
+	}
+	// Only an email verified by the provider proves the account belongs to the existing user
+	if existing != nil && policy.LinkByEmail && identity.EmailVerified {
+		if _, err := a.linkExternalIdentity(ctx, existing, identity); err != nil {
+			return nil, err
+		}
+		a.logger.Info("external identity linked by verified email", "tenant_id", tenantID, "user_id", existing.GetId(), "provider", identity.Provider)
+		return existing, nil
+	}
+	if !policy.AutoProvision {
+		return nil, infra_error.Auth(infra_error.AuthExternalIdentityNotLinked)
+	}
+	if existing != nil {
+		return nil, infra_error.Conflict(infra_error.ConflictDuplicateEmail)
+	}
+	return a.provisionUser(ctx, tenantID, identity, policy)
+}
+
+// completeFederatedLogin checks the account state and MFA of a user resolved by federatedUser or a login link and
+// issues their tokens
+func (a *AuthAPI) completeFederatedLogin(ctx context.Context, user *authv1.User, mfaCode string) (*NewTokenResponse, error) {
+	if status := user.GetStatus(); status == authv1.UserStatus_USER_STATUS_SUSPENDED || status == authv1.UserStatus_USER_STATUS_INACTIVE {
+		err := infra_error.Auth(infra_error.AuthAccountDisabled)
+		a.recordLogin(ctx, user.GetTenantId(), user.GetEmail(), user, err)
+		return nil, err
+	}
+	if err := a.checkTenantLogin(ctx, user.GetTenantId()); err != nil {
+		a.recordLogin(ctx, user.GetTenantId(), user.GetEmail(), user, err)
+		return nil, err
+	}
+	if user.GetMfaEnabled() {
+		if err := a.verifyMFACode(ctx, user, mfaCode); err != nil {
+			a.logger.Warn("mfa verification failed", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+			a.recordLogin(ctx, user.GetTenantId(), user.GetEmail(), user, err)
+			return nil, err
+		}
+	}
+
+	risk, err := a.checkLoginRisk(ctx, user, mfaCode)
+	if err != nil {
+		a.recordLogin(ctx, user.GetTenantId(), user.GetEmail(), user, err)
+		return nil, err
+	}
+
+	tokens, err := a.generateAndStoreTokens(ctx, user)
+	a.recordLogin(ctx, user.GetTenantId(), user.GetEmail(), user, err)
+	if err != nil {
+		return nil, err
+	}
+	a.rememberLogin(ctx, user, risk)
+	a.registerDevice(ctx, user, risk)
+	tokens.RiskScore, tokens.RiskReasons = risk.score, risk.reasons
+	return tokens, nil
+}
+
+// provisionUser creates the account of an external user on their first login
+func (a *AuthAPI) provisionUser(ctx context.Context, tenantID string, identity *federatedIdentity, policy *federationPolicy) (*authv1.User, error) {
+	if identity.Email == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "email")
+	}
+	if allowed := policy.AllowedDomains; len(allowed) > 0 {
+		domain := identity.Email[strings.LastIndex(identity.Email, "@")+1:]
+		if !slices.ContainsFunc(allowed, func(d string) bool { return strings.EqualFold(d, domain) }) {
+			return nil, infra_error.Auth(infra_error.AuthExternalIdentityNotLinked).WithError(errors.New("email domain is not allowed to be provisioned"))
+		}
+	}
+	if err := a.userAPI.quotas.Check(ctx, tenantID, model_auth.TenantResourceUsers); err != nil {
+		return nil, err
+	}
+
+	// The user has no password, a random one keeps password login impossible until they set one
+	randomPassword, err := oidc.RandomValue()
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := hash.Hash(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	now := timestamppb.Now()
+	roles := make([]*authv1.UserRole, 0, len(policy.DefaultRoleIDs))
+	for _, roleID := range policy.DefaultRoleIDs {
+		roles = append(roles, &authv1.UserRole{
+			RoleId:     roleID,
+			TenantId:   tenantID,
+			AssignedAt: now,
+			AssignedBy: identity.CreatedBy,
+		})
+	}
+	user := &authv1.User{
+		TenantId:      tenantID,
+		Email:         identity.Email,
+		Username:      identity.Email,
+		PasswordHash:  passwordHash,
+		Status:        authv1.UserStatus_USER_STATUS_ACTIVE,
+		EmailVerified: identity.EmailVerified,
+		Roles:         roles,
+		CreatedBy:     identity.CreatedBy,
+		Profile: &authv1.UserProfile{
+			FirstName:   identity.FirstName,
+			LastName:    identity.LastName,
+			DisplayName: identity.DisplayName,
+		},
+		ExternalIdentities: []*authv1.ExternalIdentity{externalIdentity(identity)},
+	}
+	userID, err := a.userAPI.userHandler.CreateUser(ctx, user)
+	if err != nil {
+		a.logger.Error("failed to provision federated user", "tenant_id", tenantID, "provider", identity.Provider, "error", err)
+		return nil, err
+	}
+	user.Id = userID
+	a.logger.Info("user provisioned from federated login", "tenant_id", tenantID, "user_id", userID, "provider", identity.Provider)
+	return user, nil
+}
+
+// linkExternalIdentity stores the identity on the user, replacing an earlier account of the same provider
+func (a *AuthAPI) linkExternalIdentity(ctx context.Context, user *authv1.User, identity *federatedIdentity) (*authv1.ExternalIdentity, error) {
+	linked := externalIdentity(identity)
+	user.ExternalIdentities = slices.DeleteFunc(user.GetExternalIdentities(), func(existing *authv1.ExternalIdentity) bool {
+		return existing.GetProvider() == identity.Provider
+	})
+	user.ExternalIdentities = append(user.ExternalIdentities, linked)
+	if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+		a.logger.Error("failed to link external identity", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return nil, err
+	}
+	return linked, nil
+}
+
+func externalIdentity(identity *federatedIdentity) *authv1.ExternalIdentity {
+	return &authv1.ExternalIdentity{
+		Provider: identity.Provider,
+		Issuer:   identity.Issuer,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		LinkedAt: timestamppb.Now(),
+	}
+}
+
+// hasPassword reports whether the user can log in with a password, provisioned users get one only when they set it
+func hasPassword(user *authv1.User) bool {
+	provisioned := strings.HasPrefix(user.GetCreatedBy(), oidcCreatedByPrefix) || strings.HasPrefix(user.GetCreatedBy(), samlCreatedByPrefix)
+	return !provisioned || user.GetLastPasswordChange() != nil
+}
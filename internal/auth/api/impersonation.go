@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultImpersonationTokenTTL is the lifetime of the impersonation tokens until SetImpersonationConfig is called
+const DefaultImpersonationTokenTTL = 15 * time.Minute
+
+// ImpersonationConfig holds the settings of the impersonation sessions, loaded with infra_config.Load
+type ImpersonationConfig struct {
+	// TokenTTL bounds the lifetime of the impersonation tokens, they are not refreshed
+	TokenTTL time.Duration `yaml:"token_ttl" env:"IMPERSONATION_TOKEN_TTL" default:"15m"`
+}
+
+// SetImpersonationConfig applies the settings of the impersonation sessions
+func (a *AuthAPI) SetImpersonationConfig(config *ImpersonationConfig) {
+	a.impersonationTTL = config.TokenTTL
+}
+
+// Impersonate issues a short-lived access token of accountID, a user of the tenant of userID, whose act claim names
+// userID. The user needs the user:impersonate permission and every permission of the impersonated user, support
+// cannot act as an admin. The session is not refreshed and ends when the token expires or with StopImpersonation
+func (a *AuthAPI) Impersonate(ctx context.Context, tenantID, userID, accountID, reason string) (*NewTokenResponse, error) {
+	if tenantID == "" || userID == "" || accountID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, account_id"))
+	}
+	if userID == accountID {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "account_id").WithError(errors.New("users cannot impersonate themselves"))
+	}
+	if _, ok := model_auth.APIKeyIDFromPrincipal(userID); ok {
+		return nil, infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+	if err := a.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permissions.UserImpersonate, tenantID); err != nil {
+		a.logger.Warn("permission denied for Impersonate", "tenant_id", tenantID, "user_id", userID, "account_id", accountID)
+		return nil, err
+	}
+
+	user, err := a.userAPI.getUser(ctx, tenantID, accountID, filterTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if user.GetStatus() != authv1.UserStatus_USER_STATUS_ACTIVE {
+		return nil, infra_error.Auth(infra_error.AuthAccountDisabled)
+	}
+	if err := a.userAPI.checkGrantable(ctx, tenantID, userID, nil, user); err != nil {
+		return nil, err
+	}
+
+	actor := &authv1.TokenActor{UserId: userID, TenantId: tenantID}
+	accessToken, accessTokenMetadata, err := a.generateAccessToken(ctx, user, actor, a.impersonationTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.tokenManager.StoreImpersonationToken(ctx, tenantID, accessTokenMetadata); err != nil {
+		return nil, err
+	}
+
+	expiresAt := accessTokenMetadata.GetExpiresAt().AsTime()
+	a.logger.Info("impersonation started", "tenant_id", tenantID, "user_id", userID, "account_id", accountID, "expires_at", expiresAt)
+	a.auditImpersonation(ctx, model_event.ActionImpersonationStarted, "impersonation started", tenantID, userID, accountID, map[string]any{
+		"reason":     reason,
+		"expires_at": expiresAt.Format(time.RFC3339),
+	})
+	return &NewTokenResponse{
+		UserId:         user.GetId(),
+		TenantId:       tenantID,
+		Token:          accessToken,
+		TokenExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
+// StopImpersonation revokes the impersonation token of userID acting as accountID before it expires
+func (a *AuthAPI) StopImpersonation(ctx context.Context, tenantID, userID, accountID string) error {
+	if tenantID == "" || userID == "" || accountID == "" {
+		return infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, account_id"))
+	}
+	if err := a.tokenManager.RevokeImpersonationToken(ctx, tenantID, accountID, userID, userID); err != nil {
+		return err
+	}
+
+	a.logger.Info("impersonation stopped", "tenant_id", tenantID, "user_id", userID, "account_id", accountID)
+	a.auditImpersonation(ctx, model_event.ActionImpersonationStopped, "impersonation stopped", tenantID, userID, accountID, map[string]any{})
+	return nil
+}
+
+// auditImpersonation writes a change of the impersonation session of actorID acting as accountID to the audit log
+func (a *AuthAPI) auditImpersonation(ctx context.Context, action, message, tenantID, actorID, accountID string, details map[string]any) {
+	if a.userAPI.auditLogs == nil {
+		return
+	}
+	metadata, err := structpb.NewStruct(details)
+	if err != nil {
+		a.logger.Warn("Failed to build audit log metadata", "error", err)
+	}
+	auditLog := &eventv1.AuditLog{
+		Id:         uuid.New().String(),
+		Category:   model_event.CategorySecurity,
+		Action:     action,
+		Severity:   model_event.SeverityWarning,
+		ActorId:    actorID,
+		ActorType:  model_event.ActorTypeUser,
+		TargetId:   accountID,
+		TargetType: model_event.TargetTypeUser,
+		Result:     model_event.ResultSuccess,
+		Message:    message,
+		Metadata:   metadata,
+	}
+	if err := a.userAPI.auditLogs.CreateAuditLog(ctx, tenantID, auditLog); err != nil {
+		a.logger.Error("Failed to write impersonation audit log", "error", err, "tenantID", tenantID, "userID", actorID)
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mock_token "erp.localhost/internal/auth/handler/mock"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTokenManager_ImpersonationToken(t *testing.T) {
+	tm := newPolicyTestTokenAPI(nil)
+	sessionID := model_auth.ImpersonationSessionID("user-1", "support-1")
+	assert.Equal(t, "user-1~support-1", sessionID)
+
+	// The impersonation token is validated against its session, not the token of the user
+	ctrl := gomock.NewController(t)
+	accessMock := mock_token.NewMockTokenHandler[authv1_cache.TokenMetadata](ctrl)
+	accessMock.EXPECT().Validate(gomock.Any(), "tenant-1", sessionID).Return(&authv1_cache.TokenMetadata{
+		UserId:    sessionID,
+		TenantId:  "tenant-1",
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}, nil).Times(1)
+	tm.accessTokenHandler = accessMock
+
+	tokenString, claims, err := tm.GenerateAccessToken(&GenerateAccessTokenInput{
+		UserId:   "user-1",
+		TenantId: "tenant-1",
+		Email:    "user@example.com",
+		Username: "user",
+		Roles:    []string{"role-1"},
+		Actor:    &authv1.TokenActor{UserId: "support-1", TenantId: "tenant-1"},
+		TTL:      5 * time.Minute,
+	})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.GetExpiresAt().AsTime(), 5*time.Second)
+	assert.Equal(t, "support-1", claims.GetAct().GetUserId())
+
+	verified, err := tm.VerifyAccessToken(context.Background(), tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", verified.GetUserId())
+	assert.Equal(t, "support-1", verified.GetAct().GetUserId())
+	assert.Equal(t, "tenant-1", verified.GetAct().GetTenantId())
+}
+
+func TestTokenManager_ImpersonationTokenTTL(t *testing.T) {
+	tm := newPolicyTestTokenAPI(nil)
+
+	// A TTL longer than the one of the policy does not extend the token
+	_, claims, err := tm.GenerateAccessToken(&GenerateAccessTokenInput{
+		UserId:   "user-1",
+		TenantId: "tenant-1",
+		Email:    "user@example.com",
+		Username: "user",
+		Roles:    []string{"role-1"},
+		TTL:      1000 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.True(t, claims.GetExpiresAt().AsTime().Before(time.Now().Add(1000*time.Hour)))
+	assert.Nil(t, claims.GetAct())
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/notification"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// loginLinkConfigKey is the key of the login link settings in the auth module config of a tenant
+	loginLinkConfigKey = "login_link"
+
+	// loginLinkRequestedMessage is the response of every link request, whether or not a link was sent
+	loginLinkRequestedMessage = "if the email belongs to an account, a sign-in link was sent to it"
+)
+
+// LoginLinkConfig holds the settings of the magic link login, loaded with infra_config.Load. Tenants turn the login
+// on in their login_link settings
+type LoginLinkConfig struct {
+	// TTL bounds how long a link logs in, it is used once
+	TTL time.Duration `yaml:"ttl" env:"LOGIN_LINK_TTL" default:"15m"`
+	// Link sent to the user, the token and tenant are appended as query parameters
+	URL string `yaml:"url" env:"LOGIN_LINK_URL" default:"http://localhost:3000/login-link"`
+}
+
+// defaultLoginLinkConfig applies until SetLoginLinkConfig is called
+var defaultLoginLinkConfig = LoginLinkConfig{
+	TTL: 15 * time.Minute,
+	URL: "http://localhost:3000/login-link",
+}
+
+// loginLinkStore keeps the pending login links by the hash of their token
+type loginLinkStore interface {
+	Store(ctx context.Context, tenantID string, linkToken *authv1_cache.LoginLinkToken) error
+	Consume(ctx context.Context, tenantID, tokenHash string) (*authv1_cache.LoginLinkToken, error)
+	Delete(ctx context.Context, tenantID, tokenHash string) error
+}
+
+// SetLoginLinkConfig applies the settings of the magic link login
+func (a *AuthAPI) SetLoginLinkConfig(config *LoginLinkConfig) {
+	a.loginLinkConfig = *config
+}
+
+// RequestLoginLink sends a single use login link to the account of email. The response is the same whether or not
+// the email belongs to an active account, so it does not tell which emails have one
+func (a *AuthAPI) RequestLoginLink(ctx context.Context, tenantID, email string) (string, error) {
+	if tenantID == "" || email == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, email"))
+		a.logger.Error("failed to request login link", "error", err)
+		return "", err
+	}
+	if err := a.checkLoginLinkEnabled(tenantID); err != nil {
+		return "", err
+	}
+
+	user, err := a.userAPI.userHandler.FindUserByEmail(ctx, tenantID, email)
+	if err != nil {
+		a.logger.Error("failed to find user for login link", "tenant_id", tenantID, "error", err)
+		return "", err
+	}
+	if user == nil {
+		a.logger.Info("login link requested for unknown email", "tenant_id", tenantID)
+		return loginLinkRequestedMessage, nil
+	}
+	if status := user.GetStatus(); status == authv1.UserStatus_USER_STATUS_SUSPENDED || status == authv1.UserStatus_USER_STATUS_INACTIVE {
+		a.logger.Info("login link requested for disabled account", "tenant_id", tenantID, "user_id", user.GetId())
+		return loginLinkRequestedMessage, nil
+	}
+
+	if err := a.sendLoginLink(ctx, user); err != nil {
+		return "", err
+	}
+	a.logger.Info("login link sent", "tenant_id", tenantID, "user_id", user.GetId())
+	return loginLinkRequestedMessage, nil
+}
+
+// CompleteLoginLink consumes the token of a login link and issues the tokens of its user. The token is invalidated
+// before the login, so a link logs in at most once and a failed login needs a new link
+func (a *AuthAPI) CompleteLoginLink(ctx context.Context, tenantID, token, mfaCode string) (*NewTokenResponse, error) {
+	if tenantID == "" || token == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, token"))
+		a.logger.Error("failed to complete login link", "error", err)
+		return nil, err
+	}
+	if err := a.checkLoginLinkEnabled(tenantID); err != nil {
+		return nil, err
+	}
+
+	linkToken, err := a.loginLinks.Consume(ctx, tenantID, a.userAPI.codeHasher.Hash(tenantID, token))
+	if err != nil {
+		a.logger.Warn("login link rejected", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	user, err := a.userAPI.getUser(ctx, tenantID, linkToken.GetUserId(), filterTypeID)
+	if err != nil {
+		a.logger.Error("failed to find user of login link", "tenant_id", tenantID, "user_id", linkToken.GetUserId(), "error", err)
+		return nil, err
+	}
+	// The email may have changed since the link was sent
+	if user.GetEmail() != linkToken.GetEmail() {
+		err := infra_error.Auth(infra_error.AuthTokenInvalid)
+		a.recordLogin(ctx, tenantID, linkToken.GetEmail(), user, err)
+		return nil, err
+	}
+	return a.completeFederatedLogin(ctx, user, mfaCode)
+}
+
+// sendLoginLink stores a new login link token of user and sends them its link. The token is deleted when the link
+// could not be sent
+func (a *AuthAPI) sendLoginLink(ctx context.Context, user *authv1.User) error {
+	tenantID := user.GetTenantId()
+	token, err := generateVerificationToken()
+	if err != nil {
+		a.logger.Error("failed to generate login link token", "tenant_id", tenantID, "user_id", user.GetId(), "error", err)
+		return err
+	}
+	// Only the hash of the token is stored, the token itself is only in the link
+	tokenHash := a.userAPI.codeHasher.Hash(tenantID, token)
+	now := time.Now()
+	ttl := a.loginLinkConfig.TTL
+	if err := a.loginLinks.Store(ctx, tenantID, &authv1_cache.LoginLinkToken{
+		TokenHash: tokenHash,
+		UserId:    user.GetId(),
+		Email:     user.GetEmail(),
+		CreatedAt: timestamppb.New(now),
+		ExpiresAt: timestamppb.New(now.Add(ttl)),
+	}); err != nil {
+		return err
+	}
+
+	data := map[string]any{"Link": a.loginLink(tenantID, token), "ExpiresIn": ttl.String()}
+	if err := a.notifier.send(user, notification.EventLoginLink, data); err != nil {
+		a.logger.Error("failed to deliver login link", "tenant_id", tenantID, "user_id", user.GetId(), "error", err)
+		if deleteErr := a.loginLinks.Delete(ctx, tenantID, tokenHash); deleteErr != nil {
+			a.logger.Warn("failed to delete undelivered login link token", "tenant_id", tenantID, "user_id", user.GetId(), "error", deleteErr)
+		}
+		return infra_error.Internal(infra_error.InternalExternalServiceError, err)
+	}
+	return nil
+}
+
+// checkLoginLinkEnabled returns a feature disabled error unless the tenant turned the magic link login on
+func (a *AuthAPI) checkLoginLinkEnabled(tenantID string) error {
+	settings := &authv1.LoginLinkSettings{}
+	if _, err := a.loadAuthConfig(tenantID, loginLinkConfigKey, settings); err != nil {
+		a.logger.Error("failed to get login link settings", "tenant_id", tenantID, "error", err)
+		return err
+	}
+	if !settings.GetEnabled() {
+		return infra_error.Business(infra_error.BusinessFeatureDisabled).WithError(errors.New("login link is not enabled for the tenant"))
+	}
+	return nil
+}
+
+func (a *AuthAPI) loginLink(tenantID, token string) string {
+	params := url.Values{}
+	params.Set("tenant_id", tenantID)
+	params.Set("token", token)
+	return fmt.Sprintf("%s?%s", a.loginLinkConfig.URL, params.Encode())
+}
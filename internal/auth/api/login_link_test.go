@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"erp.localhost/internal/infra/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAuthConfigClient serves the auth module config of every tenant from config
+type fakeAuthConfigClient struct {
+	client.ConfigClient
+	config map[string]any
+}
+
+func (f *fakeAuthConfigClient) GetConfig(_ context.Context, _, _ string) (*structpb.Struct, int32, error) {
+	config, err := structpb.NewStruct(f.config)
+	return config, 1, err
+}
+
+type fakeLoginLinkStore struct {
+	links map[string]*authv1_cache.LoginLinkToken
+}
+
+func (f *fakeLoginLinkStore) Store(_ context.Context, tenantID string, linkToken *authv1_cache.LoginLinkToken) error {
+	f.links[tenantID+":"+linkToken.GetTokenHash()] = linkToken
+	return nil
+}
+
+func (f *fakeLoginLinkStore) Consume(_ context.Context, tenantID, tokenHash string) (*authv1_cache.LoginLinkToken, error) {
+	linkToken, ok := f.links[tenantID+":"+tokenHash]
+	if !ok {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	delete(f.links, tenantID+":"+tokenHash)
+	return linkToken, nil
+}
+
+func (f *fakeLoginLinkStore) Delete(_ context.Context, tenantID, tokenHash string) error {
+	delete(f.links, tenantID+":"+tokenHash)
+	return nil
+}
+
+type recordingEmailNotifier struct {
+	messages []*notification.Message
+}
+
+func (r *recordingEmailNotifier) Channel() notification.Channel {
+	return notification.ChannelEmail
+}
+
+func (r *recordingEmailNotifier) Send(msg *notification.Message) error {
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func TestAuthAPI_LoginLink(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	userHandler, err := handler.NewUserHandler(log)
+	require.NoError(t, err)
+	configClient := &fakeAuthConfigClient{config: map[string]any{}}
+	links := &fakeLoginLinkStore{links: map[string]*authv1_cache.LoginLinkToken{}}
+	emails := &recordingEmailNotifier{}
+	dispatcher := notification.NewDispatcher(nil, log)
+	dispatcher.Register(emails)
+	a := &AuthAPI{
+		logger:          log,
+		userAPI:         &UserAPI{logger: log, userHandler: userHandler, codeHasher: hash.NewCodeHasher("secret")},
+		configClient:    configClient,
+		loginLinkConfig: defaultLoginLinkConfig,
+		loginLinks:      links,
+	}
+	a.SetNotificationDispatcher(dispatcher)
+
+	ctx := context.Background()
+	userID, err := userHandler.CreateUser(ctx, &authv1.User{
+		TenantId:     "tenant-1",
+		Username:     "user",
+		Email:        "user@example.com",
+		PasswordHash: "hash",
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    "System",
+		CreatedAt:    timestamppb.Now(),
+	})
+	require.NoError(t, err)
+
+	// The login is off until the tenant turns it on
+	_, err = a.RequestLoginLink(ctx, "tenant-1", "user@example.com")
+	assert.True(t, infra_error.Business(infra_error.BusinessFeatureDisabled).Is(err))
+	_, err = a.CompleteLoginLink(ctx, "tenant-1", "token", "")
+	assert.True(t, infra_error.Business(infra_error.BusinessFeatureDisabled).Is(err))
+	configClient.config[loginLinkConfigKey] = map[string]any{"enabled": true}
+
+	// Unknown emails get the same response without an email
+	message, err := a.RequestLoginLink(ctx, "tenant-1", "nobody@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, loginLinkRequestedMessage, message)
+	assert.Empty(t, emails.messages)
+
+	message, err = a.RequestLoginLink(ctx, "tenant-1", "user@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, loginLinkRequestedMessage, message)
+	require.Len(t, emails.messages, 1)
+	assert.Equal(t, "user@example.com", emails.messages[0].To)
+	assert.Equal(t, notification.EventLoginLink, emails.messages[0].Event)
+
+	// Only the hash of the token is stored
+	token := emailedLoginLinkToken(t, emails.messages[0].Body)
+	require.Len(t, links.links, 1)
+	for _, linkToken := range links.links {
+		assert.NotEqual(t, token, linkToken.GetTokenHash())
+		assert.Equal(t, userID, linkToken.GetUserId())
+	}
+
+	_, err = a.CompleteLoginLink(ctx, "tenant-1", "wrong", "")
+	assert.True(t, infra_error.Auth(infra_error.AuthTokenInvalid).Is(err))
+
+	// A link is refused once its user changed their email, and is used up by the attempt
+	user, err := userHandler.GetUserByID(ctx, "tenant-1", userID)
+	require.NoError(t, err)
+	user.Email = "changed@example.com"
+	require.NoError(t, userHandler.UpdateUser(ctx, user))
+	_, err = a.CompleteLoginLink(ctx, "tenant-1", token, "")
+	assert.True(t, infra_error.Auth(infra_error.AuthTokenInvalid).Is(err))
+	assert.Empty(t, links.links)
+
+	// Disabled accounts get the same response without an email
+	user.Status = authv1.UserStatus_USER_STATUS_SUSPENDED
+	require.NoError(t, userHandler.UpdateUser(ctx, user))
+	message, err = a.RequestLoginLink(ctx, "tenant-1", "changed@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, loginLinkRequestedMessage, message)
+	assert.Len(t, emails.messages, 1)
+}
+
+var loginLinkPattern = regexp.MustCompile(`https?://\S+`)
+
+// emailedLoginLinkToken returns the token of the login link in body
+func emailedLoginLinkToken(t *testing.T, body string) string {
+	t.Helper()
+	link, err := url.Parse(loginLinkPattern.FindString(body))
+	require.NoError(t, err)
+	token := link.Query().Get("token")
+	require.NotEmpty(t, token)
+	assert.Equal(t, "tenant-1", link.Query().Get("tenant_id"))
+	return token
+}
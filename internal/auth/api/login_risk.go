@@ -0,0 +1,296 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/notification"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Reasons of a login risk
+	RiskReasonNewDevice  = "new_device"
+	RiskReasonNewNetwork = "new_network"
+
+	newDeviceRisk  = 50
+	newNetworkRisk = 50
+
+	// loginCodeDigits is the length of the codes emailed for a step-up, loginCodeMaxAttempts the wrong codes
+	// accepted before a new one has to be sent
+	loginCodeDigits      = 6
+	loginCodeMaxAttempts = 5
+	loginCodeMethodEmail = "email"
+)
+
+// LoginRiskConfig holds the settings of the login anomaly detection, loaded with infra_config.Load
+type LoginRiskConfig struct {
+	// Enabled scores the logins against the devices and networks each user logged in from before
+	Enabled bool `yaml:"enabled" env:"LOGIN_RISK_ENABLED" default:"true"`
+	// Logins scoring SuspiciousScore or more are suspicious, the user is notified and a webhook event is emitted
+	SuspiciousScore int32 `yaml:"suspicious_score" env:"LOGIN_RISK_SUSPICIOUS_SCORE" default:"50"`
+	// RequireStepUp makes suspicious logins of users without MFA confirm a code emailed to them, valid for
+	// StepUpCodeTTL. Users with MFA always give their code, users without an email are not stepped up
+	RequireStepUp bool          `yaml:"require_step_up" env:"LOGIN_RISK_REQUIRE_STEP_UP" default:"false"`
+	StepUpCodeTTL time.Duration `yaml:"step_up_code_ttl" env:"LOGIN_RISK_STEP_UP_CODE_TTL" default:"10m"`
+	// MaxKnownDevices caps the devices remembered per user, the least recently seen are forgotten first. The
+	// devices of a user are forgotten after KnownDeviceTTL without login
+	MaxKnownDevices int           `yaml:"max_known_devices" env:"LOGIN_RISK_MAX_KNOWN_DEVICES" default:"10"`
+	KnownDeviceTTL  time.Duration `yaml:"known_device_ttl" env:"LOGIN_RISK_KNOWN_DEVICE_TTL" default:"2160h"`
+}
+
+// defaultLoginRiskConfig applies until SetLoginRiskConfig is called
+var defaultLoginRiskConfig = LoginRiskConfig{
+	Enabled:         true,
+	SuspiciousScore: 50,
+	StepUpCodeTTL:   10 * time.Minute,
+	MaxKnownDevices: 10,
+	KnownDeviceTTL:  90 * 24 * time.Hour,
+}
+
+// knownDeviceStore keeps the devices users logged in from
+type knownDeviceStore interface {
+	Get(ctx context.Context, tenantID, userID string) (*authv1_cache.KnownDevices, error)
+	Store(ctx context.Context, tenantID string, devices *authv1_cache.KnownDevices, ttl time.Duration) error
+}
+
+// loginCodeStore keeps the pending step-up code of each user
+type loginCodeStore interface {
+	Store(ctx context.Context, tenantID string, code *authv1_cache.MFACode) error
+	Get(ctx context.Context, tenantID, userID string) (*authv1_cache.MFACode, error)
+	Delete(ctx context.Context, tenantID, userID string) error
+}
+
+// SetLoginRiskConfig applies the settings of the login anomaly detection
+func (a *AuthAPI) SetLoginRiskConfig(config *LoginRiskConfig) {
+	a.loginRiskConfig = *config
+	a.userAPI.knownDeviceTTL = config.KnownDeviceTTL
+}
+
+// loginRisk is a login scored against the known devices of the user
+type loginRisk struct {
+	score     int32
+	reasons   []string
+	ip        string
+	userAgent string
+	deviceID  string
+	network   string
+	devices   *authv1_cache.KnownDevices
+	// steppedUp is set when the login confirmed a step-up code
+	steppedUp bool
+}
+
+// checkLoginRisk scores the login of user and, when required, makes suspicious logins of users without MFA
+// confirm a code emailed to them. Failures to read the known devices do not fail the login, it scores 0
+func (a *AuthAPI) checkLoginRisk(ctx context.Context, user *authv1.User, code string) (*loginRisk, error) {
+	ip, userAgent := interceptor.ClientFromContext(ctx)
+	risk := &loginRisk{
+		ip:        ip,
+		userAgent: userAgent,
+		deviceID:  deviceID(userAgent),
+		network:   networkOf(ip),
+	}
+	if !a.loginRiskConfig.Enabled || a.knownDevices == nil || (risk.deviceID == "" && risk.network == "") {
+		return risk, nil
+	}
+
+	devices, err := a.knownDevices.Get(ctx, user.GetTenantId(), user.GetId())
+	if err != nil {
+		a.logger.Warn("failed to get known devices, login not scored", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return risk, nil
+	}
+	risk.devices = devices
+	risk.score, risk.reasons = scoreLogin(devices, risk.deviceID, risk.network)
+
+	if a.suspicious(risk) && a.loginRiskConfig.RequireStepUp && !user.GetMfaEnabled() && user.GetEmail() != "" {
+		if err := a.verifyLoginCode(ctx, user, code); err != nil {
+			a.logger.Warn("login step-up required", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "score", risk.score, "reasons", risk.reasons)
+			return nil, err
+		}
+		risk.steppedUp = true
+	}
+	return risk, nil
+}
+
+// rememberLogin adds the device of a successful login to the known devices of user and notifies them, of a
+// suspicious login with a webhook event as well
+func (a *AuthAPI) rememberLogin(ctx context.Context, user *authv1.User, risk *loginRisk) {
+	data := map[string]any{"IPAddress": risk.ip}
+	if !a.suspicious(risk) {
+		a.notifier.notify(user, notification.EventNewLogin, data)
+	} else {
+		a.logger.Warn("suspicious login", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "score", risk.score, "reasons", risk.reasons, "ip", risk.ip)
+		a.notifier.notify(user, notification.EventSuspiciousLogin, data)
+		event := loginEvent(model_event.EventLoginSuspicious, user, risk)
+		if err := a.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+			return []*eventv1.DomainEvent{event}, nil
+		}); err != nil {
+			a.logger.Error("failed to record suspicious login", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		}
+	}
+	if !a.loginRiskConfig.Enabled || a.knownDevices == nil || (risk.deviceID == "" && risk.network == "") {
+		return
+	}
+	devices := rememberDevice(risk.devices, user.GetId(), risk, a.loginRiskConfig.MaxKnownDevices, time.Now())
+	if err := a.knownDevices.Store(ctx, user.GetTenantId(), devices, a.loginRiskConfig.KnownDeviceTTL); err != nil {
+		a.logger.Warn("failed to remember device", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+	}
+}
+
+// suspicious reports whether risk reaches the suspicious score
+func (a *AuthAPI) suspicious(risk *loginRisk) bool {
+	return risk.score > 0 && risk.score >= a.loginRiskConfig.SuspiciousScore
+}
+
+// verifyLoginCode checks code against the hash of the step-up code emailed to user in constant time, the code is
+// invalidated once it was used. Without code, or once the pending code was guessed wrong too often, a new code is
+// emailed and an MFA required error returned
+func (a *AuthAPI) verifyLoginCode(ctx context.Context, user *authv1.User, code string) error {
+	if a.loginCodes == nil {
+		return infra_error.Auth(infra_error.AuthMFARequired)
+	}
+	tenantID, userID := user.GetTenantId(), user.GetId()
+	if code != "" {
+		pending, err := a.loginCodes.Get(ctx, tenantID, userID)
+		if err != nil {
+			return err
+		}
+		if pending != nil && pending.GetAttempts() < loginCodeMaxAttempts {
+			if a.userAPI.codeHasher.Verify(tenantID, code, pending.GetCodeHash()) {
+				// A code is used once, it is rejected when it cannot be invalidated
+				if err := a.loginCodes.Delete(ctx, tenantID, userID); err != nil {
+					a.logger.Error("failed to delete used login code", "tenant_id", tenantID, "user_id", userID, "error", err)
+					return err
+				}
+				return nil
+			}
+			pending.Attempts++
+			if err := a.loginCodes.Store(ctx, tenantID, pending); err != nil {
+				return err
+			}
+			return infra_error.Auth(infra_error.AuthMFAInvalidCode)
+		}
+	}
+	if err := a.sendLoginCode(ctx, user); err != nil {
+		return err
+	}
+	return infra_error.Auth(infra_error.AuthMFARequired).WithDetails("method", loginCodeMethodEmail)
+}
+
+// sendLoginCode emails a new step-up code to user, replacing the pending one
+func (a *AuthAPI) sendLoginCode(ctx context.Context, user *authv1.User) error {
+	code, err := randomDigits(loginCodeDigits)
+	if err != nil {
+		return infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	now := time.Now()
+	ttl := a.loginRiskConfig.StepUpCodeTTL
+	if err := a.loginCodes.Store(ctx, user.GetTenantId(), &authv1_cache.MFACode{
+		UserId:    user.GetId(),
+		CodeHash:  a.userAPI.codeHasher.Hash(user.GetTenantId(), code),
+		Method:    loginCodeMethodEmail,
+		CreatedAt: timestamppb.New(now),
+		ExpiresAt: timestamppb.New(now.Add(ttl)),
+	}); err != nil {
+		return err
+	}
+	body := fmt.Sprintf("A sign-in to your account from a new device needs confirmation. Your code is %s, it expires in %s.", code, ttl)
+	if err := a.userAPI.emailSender.SendEmail(user.GetEmail(), "Your sign-in code", body); err != nil {
+		a.logger.Error("failed to deliver login code", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return nil
+}
+
+// scoreLogin scores a login from deviceID on network against devices. The first login of a user scores 0
+func scoreLogin(devices *authv1_cache.KnownDevices, deviceID, network string) (int32, []string) {
+	if len(devices.GetDevices()) == 0 {
+		return 0, nil
+	}
+	var score int32
+	var reasons []string
+	if deviceID != "" && !slices.ContainsFunc(devices.GetDevices(), func(d *authv1_cache.KnownDevice) bool { return d.GetDeviceId() == deviceID }) {
+		score += newDeviceRisk
+		reasons = append(reasons, RiskReasonNewDevice)
+	}
+	if network != "" && !slices.ContainsFunc(devices.GetDevices(), func(d *authv1_cache.KnownDevice) bool { return d.GetNetwork() == network }) {
+		score += newNetworkRisk
+		reasons = append(reasons, RiskReasonNewNetwork)
+	}
+	return min(score, 100), reasons
+}
+
+// rememberDevice moves the device and network of risk to the front of devices, keeping at most max of them
+func rememberDevice(devices *authv1_cache.KnownDevices, userID string, risk *loginRisk, max int, now time.Time) *authv1_cache.KnownDevices {
+	seen := timestamppb.New(now)
+	device := &authv1_cache.KnownDevice{
+		DeviceId:  risk.deviceID,
+		Network:   risk.network,
+		UserAgent: risk.userAgent,
+		FirstSeen: seen,
+		LastSeen:  seen,
+	}
+	remembered := []*authv1_cache.KnownDevice{device}
+	for _, known := range devices.GetDevices() {
+		if known.GetDeviceId() == device.GetDeviceId() && known.GetNetwork() == device.GetNetwork() {
+			device.FirstSeen = known.GetFirstSeen()
+			continue
+		}
+		remembered = append(remembered, known)
+	}
+	if max > 0 && len(remembered) > max {
+		remembered = remembered[:max]
+	}
+	return &authv1_cache.KnownDevices{
+		UserId:    userID,
+		Devices:   remembered,
+		UpdatedAt: seen,
+	}
+}
+
+// deviceID identifies a device by the hash of its user agent
+func deviceID(userAgent string) string {
+	if userAgent == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(sum[:16])
+}
+
+// networkOf returns the IP range of ip, /24 for IPv4 and /48 for IPv6 addresses
+func networkOf(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: parsed.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
+
+// randomDigits returns a random numeric code of n digits
+func randomDigits(n int) (string, error) {
+	code := make([]byte, n)
+	for i := range code {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + digit.Int64())
+	}
+	return string(code), nil
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/hash"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+type fakeKnownDeviceStore struct {
+	devices map[string]*authv1_cache.KnownDevices
+}
+
+func (f *fakeKnownDeviceStore) Get(_ context.Context, tenantID, userID string) (*authv1_cache.KnownDevices, error) {
+	return f.devices[tenantID+":"+userID], nil
+}
+
+func (f *fakeKnownDeviceStore) Store(_ context.Context, tenantID string, devices *authv1_cache.KnownDevices, _ time.Duration) error {
+	f.devices[tenantID+":"+devices.GetUserId()] = devices
+	return nil
+}
+
+type fakeLoginCodeStore struct {
+	codes map[string]*authv1_cache.MFACode
+}
+
+func (f *fakeLoginCodeStore) Store(_ context.Context, tenantID string, code *authv1_cache.MFACode) error {
+	f.codes[tenantID+":"+code.GetUserId()] = code
+	return nil
+}
+
+func (f *fakeLoginCodeStore) Get(_ context.Context, tenantID, userID string) (*authv1_cache.MFACode, error) {
+	return f.codes[tenantID+":"+userID], nil
+}
+
+func (f *fakeLoginCodeStore) Delete(_ context.Context, tenantID, userID string) error {
+	delete(f.codes, tenantID+":"+userID)
+	return nil
+}
+
+type recordingEmailSender struct {
+	sent   []string
+	bodies []string
+}
+
+func (r *recordingEmailSender) SendEmail(to, subject, body string) error {
+	r.sent = append(r.sent, to)
+	r.bodies = append(r.bodies, body)
+	return nil
+}
+
+var emailedCode = regexp.MustCompile(`\b\d{6}\b`)
+
+// lastCode returns the code of the last email sent
+func (r *recordingEmailSender) lastCode(t *testing.T) string {
+	t.Helper()
+	require.NotEmpty(t, r.bodies)
+	code := emailedCode.FindString(r.bodies[len(r.bodies)-1])
+	require.NotEmpty(t, code)
+	return code
+}
+
+func newLoginRiskTestAuthAPI(config LoginRiskConfig) (*AuthAPI, *recordingEmailSender) {
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	sender := &recordingEmailSender{}
+	return &AuthAPI{
+		logger:          log,
+		userAPI:         &UserAPI{logger: log, emailSender: sender, codeHasher: hash.NewCodeHasher("secret")},
+		loginRiskConfig: config,
+		knownDevices:    &fakeKnownDeviceStore{devices: map[string]*authv1_cache.KnownDevices{}},
+		loginCodes:      &fakeLoginCodeStore{codes: map[string]*authv1_cache.MFACode{}},
+	}, sender
+}
+
+func clientContext(ip, userAgent string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(interceptor.ClientIPHeader, ip, interceptor.ClientUserAgentHeader, userAgent))
+}
+
+func TestScoreLogin(t *testing.T) {
+	known := &authv1_cache.KnownDevices{Devices: []*authv1_cache.KnownDevice{
+		{DeviceId: "laptop", Network: "203.0.113.0/24"},
+	}}
+
+	testCases := []struct {
+		name        string
+		devices     *authv1_cache.KnownDevices
+		deviceID    string
+		network     string
+		wantScore   int32
+		wantReasons []string
+	}{
+		{name: "first login", devices: nil, deviceID: "laptop", network: "198.51.100.0/24"},
+		{name: "known device and network", devices: known, deviceID: "laptop", network: "203.0.113.0/24"},
+		{name: "new network", devices: known, deviceID: "laptop", network: "198.51.100.0/24", wantScore: 50, wantReasons: []string{RiskReasonNewNetwork}},
+		{name: "new device", devices: known, deviceID: "phone", network: "203.0.113.0/24", wantScore: 50, wantReasons: []string{RiskReasonNewDevice}},
+		{name: "new device and network", devices: known, deviceID: "phone", network: "198.51.100.0/24", wantScore: 100, wantReasons: []string{RiskReasonNewDevice, RiskReasonNewNetwork}},
+		{name: "unknown client", devices: known},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score, reasons := scoreLogin(tc.devices, tc.deviceID, tc.network)
+			assert.Equal(t, tc.wantScore, score)
+			assert.Equal(t, tc.wantReasons, reasons)
+		})
+	}
+}
+
+func TestNetworkOf(t *testing.T) {
+	assert.Equal(t, "203.0.113.0/24", networkOf("203.0.113.77"))
+	assert.Equal(t, "2001:db8:1234::/48", networkOf("2001:db8:1234:5678::1"))
+	assert.Equal(t, "", networkOf("not an ip"))
+}
+
+func TestRememberDevice(t *testing.T) {
+	firstSeen := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := firstSeen.Add(24 * time.Hour)
+	devices := rememberDevice(nil, "user-1", &loginRisk{deviceID: "laptop", network: "net-1"}, 2, firstSeen)
+	devices = rememberDevice(devices, "user-1", &loginRisk{deviceID: "phone", network: "net-2"}, 2, now)
+	devices = rememberDevice(devices, "user-1", &loginRisk{deviceID: "laptop", network: "net-1"}, 2, now)
+
+	require.Len(t, devices.GetDevices(), 2)
+	assert.Equal(t, "laptop", devices.GetDevices()[0].GetDeviceId())
+	assert.Equal(t, firstSeen, devices.GetDevices()[0].GetFirstSeen().AsTime())
+	assert.Equal(t, now, devices.GetDevices()[0].GetLastSeen().AsTime())
+	assert.Equal(t, "phone", devices.GetDevices()[1].GetDeviceId())
+
+	// The least recently seen device is forgotten past the cap
+	devices = rememberDevice(devices, "user-1", &loginRisk{deviceID: "tablet", network: "net-3"}, 2, now)
+	require.Len(t, devices.GetDevices(), 2)
+	assert.Equal(t, "tablet", devices.GetDevices()[0].GetDeviceId())
+	assert.Equal(t, "laptop", devices.GetDevices()[1].GetDeviceId())
+}
+
+func TestAuthAPI_LoginRiskStepUp(t *testing.T) {
+	config := defaultLoginRiskConfig
+	config.RequireStepUp = true
+	a, sender := newLoginRiskTestAuthAPI(config)
+	user := &authv1.User{Id: "user-1", TenantId: "tenant-1", Email: "user@example.com"}
+
+	// The first login is learned
+	risk, err := a.checkLoginRisk(clientContext("203.0.113.7", "laptop"), user, "")
+	require.NoError(t, err)
+	assert.Zero(t, risk.score)
+	a.rememberLogin(context.Background(), user, risk)
+
+	// A login from the known device does not need a step-up
+	risk, err = a.checkLoginRisk(clientContext("203.0.113.8", "laptop"), user, "")
+	require.NoError(t, err)
+	assert.Zero(t, risk.score)
+
+	// A login from a new device needs the emailed code
+	ctx := clientContext("198.51.100.1", "phone")
+	_, err = a.checkLoginRisk(ctx, user, "")
+	require.Error(t, err)
+	assert.True(t, infra_error.Auth(infra_error.AuthMFARequired).Is(err))
+	assert.Equal(t, []string{"user@example.com"}, sender.sent)
+
+	_, err = a.checkLoginRisk(ctx, user, "wrong")
+	require.Error(t, err)
+	assert.True(t, infra_error.Auth(infra_error.AuthMFAInvalidCode).Is(err))
+
+	// Only the hash of the code is stored
+	code := sender.lastCode(t)
+	assert.NotEqual(t, code, a.loginCodes.(*fakeLoginCodeStore).codes["tenant-1:user-1"].GetCodeHash())
+	risk, err = a.checkLoginRisk(ctx, user, code)
+	require.NoError(t, err)
+	assert.Equal(t, int32(100), risk.score)
+	assert.Empty(t, a.loginCodes.(*fakeLoginCodeStore).codes)
+
+	// A used code is invalidated, giving it again sends a new one
+	_, err = a.checkLoginRisk(ctx, user, code)
+	require.Error(t, err)
+	assert.True(t, infra_error.Auth(infra_error.AuthMFARequired).Is(err))
+	assert.Len(t, sender.sent, 2)
+
+	// Users with MFA give their TOTP code instead
+	user.MfaEnabled = true
+	_, err = a.checkLoginRisk(ctx, user, "")
+	require.NoError(t, err)
+	assert.Len(t, sender.sent, 2)
+}
+
+func TestAuthAPI_LoginCodeMaxAttempts(t *testing.T) {
+	config := defaultLoginRiskConfig
+	config.RequireStepUp = true
+	a, sender := newLoginRiskTestAuthAPI(config)
+	user := &authv1.User{Id: "user-1", TenantId: "tenant-1", Email: "user@example.com"}
+
+	err := a.verifyLoginCode(context.Background(), user, "")
+	require.Error(t, err)
+	code := sender.lastCode(t)
+
+	for range loginCodeMaxAttempts {
+		err = a.verifyLoginCode(context.Background(), user, "wrong")
+		assert.True(t, infra_error.Auth(infra_error.AuthMFAInvalidCode).Is(err))
+	}
+
+	// Past the attempts the right code is rejected as well and a new code is sent
+	err = a.verifyLoginCode(context.Background(), user, code)
+	require.Error(t, err)
+	assert.True(t, infra_error.Auth(infra_error.AuthMFARequired).Is(err))
+	assert.Len(t, sender.sent, 2)
+	require.NoError(t, a.verifyLoginCode(context.Background(), user, sender.lastCode(t)))
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/auth/mfa"
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/notification"
+)
+
+// EnrollMFA generates a new TOTP secret for the user. MFA stays disabled until the
+// enrollment is confirmed with a valid code via VerifyMFAEnrollment.
+func (a *AuthAPI) EnrollMFA(ctx context.Context, tenantID, userID string) (string, string, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		a.logger.Error("failed to enroll mfa", "error", err)
+		return "", "", err
+	}
+
+	user, err := a.userAPI.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		a.logger.Error("failed to enroll mfa", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return "", "", err
+	}
+	if user.GetMfaEnabled() {
+		return "", "", infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("mfa is already enabled"))
+	}
+
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		a.logger.Error("failed to generate mfa secret", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return "", "", err
+	}
+	user.MfaSecret = secret
+	if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+		a.logger.Error("failed to store mfa secret", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return "", "", err
+	}
+
+	accountName := user.GetEmail()
+	if accountName == "" {
+		accountName = user.GetUsername()
+	}
+	a.logger.Info("mfa enrollment started", "tenant_id", tenantID, "user_id", userID)
+	return secret, mfa.ProvisioningURI(Issuer, accountName, secret), nil
+}
+
+// VerifyMFAEnrollment confirms a pending enrollment, enables MFA and returns fresh recovery codes
+func (a *AuthAPI) VerifyMFAEnrollment(ctx context.Context, tenantID, userID, code string) ([]string, error) {
+	if tenantID == "" || userID == "" || code == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, code"))
+		a.logger.Error("failed to verify mfa enrollment", "error", err)
+		return nil, err
+	}
+
+	user, err := a.userAPI.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		a.logger.Error("failed to verify mfa enrollment", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	if user.GetMfaEnabled() || user.GetMfaSecret() == "" {
+		return nil, infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("no pending mfa enrollment"))
+	}
+	if !acceptTOTPCode(user, code) {
+		return nil, infra_error.Auth(infra_error.AuthMFAInvalidCode)
+	}
+
+	recoveryCodes, hashes, err := mfa.GenerateRecoveryCodes(mfa.RecoveryCodesCount)
+	if err != nil {
+		a.logger.Error("failed to generate recovery codes", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	user.MfaEnabled = true
+	user.MfaRecoveryCodes = hashes
+	if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+		a.logger.Error("failed to enable mfa", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("mfa enabled", "tenant_id", tenantID, "user_id", userID)
+	a.notifier.notify(user, notification.EventMFAEnabled, nil)
+	return recoveryCodes, nil
+}
+
+// DisableMFA turns MFA off after verifying a TOTP or recovery code
+func (a *AuthAPI) DisableMFA(ctx context.Context, tenantID, userID, code string) error {
+	if tenantID == "" || userID == "" || code == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, code"))
+		a.logger.Error("failed to disable mfa", "error", err)
+		return err
+	}
+
+	user, err := a.userAPI.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		a.logger.Error("failed to disable mfa", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	if !user.GetMfaEnabled() {
+		return infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("mfa is not enabled"))
+	}
+	if err := a.verifyMFACode(ctx, user, code); err != nil {
+		return err
+	}
+
+	user.MfaEnabled = false
+	user.MfaSecret = ""
+	user.MfaRecoveryCodes = nil
+	if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+		a.logger.Error("failed to disable mfa", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+
+	a.logger.Info("mfa disabled", "tenant_id", tenantID, "user_id", userID)
+	a.notifier.notify(user, notification.EventMFADisabled, nil)
+	return nil
+}
+
+// acceptTOTPCode reports whether code is a TOTP code of the user of a time step after the last accepted one, and
+// records its step on user. The caller stores user
+func acceptTOTPCode(user *authv1.User, code string) bool {
+	counter, ok := mfa.MatchCode(user.GetMfaSecret(), code, time.Now())
+	if !ok || counter <= user.GetMfaLastCounter() {
+		return false
+	}
+	user.MfaLastCounter = counter
+	return true
+}
+
+// verifyMFACode accepts either a TOTP code or an unused recovery code.
+// A matching recovery code is consumed.
+func (a *AuthAPI) verifyMFACode(ctx context.Context, user *authv1.User, code string) error {
+	if code == "" {
+		return infra_error.Auth(infra_error.AuthMFARequired)
+	}
+	if acceptTOTPCode(user, code) {
+		// The accepted time step is stored so the code cannot be replayed, a concurrent replay fails on the version
+		if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+			a.logger.Error("failed to record mfa code", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+			return err
+		}
+		return nil
+	}
+
+	idx := mfa.MatchRecoveryCode(code, user.GetMfaRecoveryCodes())
+	if idx < 0 {
+		return infra_error.Auth(infra_error.AuthMFAInvalidCode)
+	}
+	user.MfaRecoveryCodes = append(user.MfaRecoveryCodes[:idx], user.MfaRecoveryCodes[idx+1:]...)
+	if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+		a.logger.Error("failed to consume recovery code", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+	a.logger.Warn("recovery code used", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "remaining", len(user.MfaRecoveryCodes))
+	return nil
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/mfa"
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAuthAPI_MFACodeReplay(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	userHandler, err := handler.NewUserHandler(log)
+	require.NoError(t, err)
+	a := &AuthAPI{logger: log, userAPI: &UserAPI{logger: log, userHandler: userHandler}}
+
+	ctx := context.Background()
+	userID, err := userHandler.CreateUser(ctx, &authv1.User{
+		TenantId:     "tenant-1",
+		Username:     "user",
+		Email:        "user@example.com",
+		PasswordHash: "hash",
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    "System",
+		CreatedAt:    timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	secret, _, err := a.EnrollMFA(ctx, "tenant-1", userID)
+	require.NoError(t, err)
+
+	code, err := mfa.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	_, err = a.VerifyMFAEnrollment(ctx, "tenant-1", userID, code)
+	require.NoError(t, err)
+
+	// The code accepted by the enrollment is refused afterwards, though it is still within its window
+	err = a.DisableMFA(ctx, "tenant-1", userID, code)
+	assert.True(t, infra_error.Auth(infra_error.AuthMFAInvalidCode).Is(err))
+	user, err := userHandler.GetUserByID(ctx, "tenant-1", userID)
+	require.NoError(t, err)
+	assert.True(t, user.GetMfaEnabled())
+
+	// The code of the next time step is accepted once
+	next, err := mfa.GenerateCode(secret, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	require.NoError(t, a.verifyMFACode(ctx, user, next))
+	user, err = userHandler.GetUserByID(ctx, "tenant-1", userID)
+	require.NoError(t, err)
+	err = a.verifyMFACode(ctx, user, next)
+	assert.True(t, infra_error.Auth(infra_error.AuthMFAInvalidCode).Is(err))
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/notification"
+)
+
+// notifier sends auth event notifications to users, a nil dispatcher disables notifications
+type notifier struct {
+	dispatcher *notification.Dispatcher
+	logger     logger.Logger
+}
+
+// notify delivers the event in the background, delivery failures never fail the calling operation
+func (n *notifier) notify(user *authv1.User, event notification.Event, data map[string]any) {
+	if n == nil || n.dispatcher == nil || user == nil {
+		return
+	}
+	recipient := notification.RecipientFromUser(user)
+	go func() {
+		if err := n.dispatcher.Notify(recipient, event, data); err != nil {
+			n.logger.Warn("failed to deliver notification", "tenant_id", recipient.TenantID, "user_id", recipient.UserID, "event", event, "error", err)
+		}
+	}()
+}
+
+// send delivers the event and waits for it, for the notifications the operation fails without
+func (n *notifier) send(user *authv1.User, event notification.Event, data map[string]any) error {
+	if n == nil || n.dispatcher == nil {
+		return errors.New("notifications are not configured")
+	}
+	return n.dispatcher.Notify(notification.RecipientFromUser(user), event, data)
+}
+
+// SetNotificationDispatcher enables auth event notifications (new login, MFA changes)
+func (a *AuthAPI) SetNotificationDispatcher(dispatcher *notification.Dispatcher) {
+	a.notifier = &notifier{dispatcher: dispatcher, logger: a.logger}
+}
+
+// SetNotificationDispatcher enables user event notifications (password changed).
+// The dispatcher email notifier is also used for verification emails when it can send plain emails.
+func (u *UserAPI) SetNotificationDispatcher(dispatcher *notification.Dispatcher) {
+	u.notifier = &notifier{dispatcher: dispatcher, logger: u.logger}
+	if dispatcher == nil {
+		return
+	}
+	if emailNotifier, ok := dispatcher.Notifier(notification.ChannelEmail); ok {
+		if sender, ok := emailNotifier.(EmailSender); ok {
+			u.SetEmailSender(sender)
+		}
+	}
+}
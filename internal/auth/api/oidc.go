@@ -0,0 +1,280 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/auth/oidc"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type OIDCConfig struct {
+	// Time the user has to complete the login at the provider
+	StateDuration time.Duration
+	// Timeout of calls to the providers
+	HTTPTimeout time.Duration
+	Providers   []oidc.Config
+}
+
+// LoadOIDCConfig loads the OIDC providers from environment variables.
+// OIDC_PROVIDERS lists the provider names, each provider is configured with
+// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL
+// and optionally OIDC_<NAME>_SCOPES (space separated).
+func LoadOIDCConfig() *OIDCConfig {
+	config := &OIDCConfig{
+		StateDuration: parseDuration(getEnv("OIDC_STATE_DURATION", "10m"), 10*time.Minute),
+		HTTPTimeout:   parseDuration(getEnv("OIDC_HTTP_TIMEOUT", "10s"), 10*time.Second),
+	}
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		config.Providers = append(config.Providers, oidc.Config{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "")),
+		})
+	}
+	return config
+}
+
+// newOIDCProviders creates the configured providers, providers with an incomplete configuration are skipped
+func newOIDCProviders(config *OIDCConfig, logger logger.Logger) map[string]*oidc.Provider {
+	httpClient := &http.Client{Timeout: config.HTTPTimeout}
+	providers := make(map[string]*oidc.Provider, len(config.Providers))
+	for _, providerConfig := range config.Providers {
+		provider, err := oidc.NewProvider(providerConfig, httpClient)
+		if err != nil {
+			logger.Warn("skipping oidc provider with invalid configuration", "provider", providerConfig.Name, "error", err)
+			continue
+		}
+		providers[provider.Name()] = provider
+	}
+	return providers
+}
+
+// GetOIDCAuthURL starts a login at the provider and returns the URL the user is sent to.
+// When userID is set the provider account is linked to that user by LinkExternalIdentity instead.
+func (a *AuthAPI) GetOIDCAuthURL(ctx context.Context, tenantID, providerName, userID string) (string, string, error) {
+	if tenantID == "" || providerName == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, provider"))
+		a.logger.Error("failed to get oidc auth url", "error", err)
+		return "", "", err
+	}
+	provider, _, err := a.oidcProvider(ctx, tenantID, providerName)
+	if err != nil {
+		a.logger.Error("failed to get oidc auth url", "tenant_id", tenantID, "provider", providerName, "error", err)
+		return "", "", err
+	}
+	if userID != "" {
+		if _, err := a.userAPI.getUser(ctx, tenantID, userID, filterTypeID); err != nil {
+			a.logger.Error("failed to find user", "tenant_id", tenantID, "user_id", userID, "error", err)
+			return "", "", err
+		}
+	}
+
+	state, err := oidc.RandomValue()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oidc.RandomValue()
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := oidc.RandomValue()
+	if err != nil {
+		return "", "", err
+	}
+	authURL, err := provider.AuthCodeURL(context.Background(), state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		a.logger.Error("failed to build oidc auth url", "tenant_id", tenantID, "provider", providerName, "error", err)
+		return "", "", err
+	}
+
+	now := time.Now()
+	pending := &authv1_cache.OIDCState{
+		State:        state,
+		TenantId:     tenantID,
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		UserId:       userID,
+		CreatedAt:    timestamppb.New(now),
+		ExpiresAt:    timestamppb.New(now.Add(a.oidcConfig.StateDuration)),
+	}
+	if err := a.oidcStateHandler.Store(ctx, pending); err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// LoginWithOIDC completes a login started by GetOIDCAuthURL and issues tokens for the user linked to the provider account.
+// Depending on the tenant settings, unknown accounts are linked to the user with the same verified email or provisioned.
+func (a *AuthAPI) LoginWithOIDC(ctx context.Context, tenantID, state, code, mfaCode string) (*NewTokenResponse, error) {
+	if tenantID == "" || state == "" || code == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, state, code"))
+		a.logger.Error("failed to login with oidc", "error", err)
+		return nil, err
+	}
+
+	pending, err := a.oidcStateHandler.Consume(ctx, tenantID, state)
+	if err != nil {
+		a.logger.Warn("oidc login with unknown state", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if pending.GetUserId() != "" {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("state was issued to link an identity"))
+	}
+	provider, settings, err := a.oidcProvider(ctx, tenantID, pending.GetProvider())
+	if err != nil {
+		a.logger.Error("failed to login with oidc", "tenant_id", tenantID, "provider", pending.GetProvider(), "error", err)
+		return nil, err
+	}
+	identity, err := provider.Exchange(context.Background(), code, pending.GetCodeVerifier(), pending.GetNonce())
+	if err != nil {
+		a.logger.Warn("oidc code exchange failed", "tenant_id", tenantID, "provider", provider.Name(), "error", err)
+		return nil, err
+	}
+
+	user, err := a.federatedUser(ctx, tenantID, oidcIdentity(identity), oidcPolicy(settings))
+	if err != nil {
+		a.logger.Warn("oidc login rejected", "tenant_id", tenantID, "provider", provider.Name(), "error", err)
+		return nil, err
+	}
+	return a.completeFederatedLogin(ctx, user, mfaCode)
+}
+
+// LinkExternalIdentity completes a flow started by GetOIDCAuthURL for the user and links the provider account to them
+func (a *AuthAPI) LinkExternalIdentity(ctx context.Context, tenantID, userID, state, code string) (*authv1.ExternalIdentity, error) {
+	if tenantID == "" || userID == "" || state == "" || code == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, state, code"))
+		a.logger.Error("failed to link external identity", "error", err)
+		return nil, err
+	}
+
+	pending, err := a.oidcStateHandler.Consume(ctx, tenantID, state)
+	if err != nil {
+		a.logger.Warn("external identity link with unknown state", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	if pending.GetUserId() != userID {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("state was not issued to link an identity for this user"))
+	}
+	provider, _, err := a.oidcProvider(ctx, tenantID, pending.GetProvider())
+	if err != nil {
+		a.logger.Error("failed to link external identity", "tenant_id", tenantID, "provider", pending.GetProvider(), "error", err)
+		return nil, err
+	}
+	identity, err := provider.Exchange(context.Background(), code, pending.GetCodeVerifier(), pending.GetNonce())
+	if err != nil {
+		a.logger.Warn("oidc code exchange failed", "tenant_id", tenantID, "provider", provider.Name(), "error", err)
+		return nil, err
+	}
+
+	linked, err := a.userAPI.userHandler.GetUserByExternalIdentity(ctx, tenantID, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if linked != nil && linked.GetId() != userID {
+		return nil, infra_error.Conflict(infra_error.ConflictExternalIdentityLinked)
+	}
+	user, err := a.userAPI.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		a.logger.Error("failed to find user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	externalIdentity, err := a.linkExternalIdentity(ctx, user, oidcIdentity(identity))
+	if err != nil {
+		return nil, err
+	}
+	a.logger.Info("external identity linked", "tenant_id", tenantID, "user_id", userID, "provider", identity.Provider)
+	return externalIdentity, nil
+}
+
+// UnlinkExternalIdentity removes the link between the user and their account at the provider.
+// The last identity of a provisioned user without a password can't be removed, it is their only way to log in.
+func (a *AuthAPI) UnlinkExternalIdentity(ctx context.Context, tenantID, userID, providerName string) error {
+	if tenantID == "" || userID == "" || providerName == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, provider"))
+		a.logger.Error("failed to unlink external identity", "error", err)
+		return err
+	}
+
+	user, err := a.userAPI.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		a.logger.Error("failed to find user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	identities := user.GetExternalIdentities()
+	index := slices.IndexFunc(identities, func(identity *authv1.ExternalIdentity) bool {
+		return identity.GetProvider() == providerName
+	})
+	if index < 0 {
+		return infra_error.NotFound(infra_error.NotFoundResource, "external identity", providerName)
+	}
+	if len(identities) == 1 && !hasPassword(user) {
+		return infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("set a password before unlinking the last external identity"))
+	}
+
+	user.ExternalIdentities = slices.Delete(identities, index, index+1)
+	if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+		a.logger.Error("failed to unlink external identity", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	a.logger.Info("external identity unlinked", "tenant_id", tenantID, "user_id", userID, "provider", providerName)
+	return nil
+}
+
+// oidcProvider returns the provider if it is configured and enabled for the tenant, with the tenant OIDC settings
+func (a *AuthAPI) oidcProvider(ctx context.Context, tenantID, providerName string) (*oidc.Provider, *authv1.OIDCSettings, error) {
+	provider, ok := a.oidcProviders[providerName]
+	if !ok {
+		return nil, nil, infra_error.Validation(infra_error.ValidationInvalidValue, "provider")
+	}
+	tenant, err := a.userAPI.tenantHandler.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	settings := tenant.GetSettings().GetOidc()
+	if !slices.Contains(settings.GetProviders(), providerName) {
+		return nil, nil, infra_error.Business(infra_error.BusinessFeatureDisabled).WithError(errors.New("provider is not enabled for the tenant"))
+	}
+	return provider, settings, nil
+}
+
+// oidcIdentity converts a verified provider identity for federatedUser
+func oidcIdentity(identity *oidc.Identity) *federatedIdentity {
+	return &federatedIdentity{
+		Provider:      identity.Provider,
+		Issuer:        identity.Issuer,
+		Subject:       identity.Subject,
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+		DisplayName:   identity.Name,
+		FirstName:     identity.GivenName,
+		LastName:      identity.FamilyName,
+		CreatedBy:     oidcCreatedByPrefix + identity.Provider,
+	}
+}
+
+func oidcPolicy(settings *authv1.OIDCSettings) *federationPolicy {
+	return &federationPolicy{
+		AutoProvision:  settings.GetAutoProvision(),
+		DefaultRoleIDs: settings.GetDefaultRoleIds(),
+		LinkByEmail:    settings.GetLinkByVerifiedEmail(),
+		AllowedDomains: settings.GetAllowedDomains(),
+	}
+}
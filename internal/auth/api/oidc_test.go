@@ -0,0 +1,82 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/oidc"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	shared "erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLoadOIDCConfig(t *testing.T) {
+	t.Setenv("OIDC_PROVIDERS", "google, Azure-AD,")
+	t.Setenv("OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+	t.Setenv("OIDC_GOOGLE_CLIENT_ID", "google-client")
+	t.Setenv("OIDC_GOOGLE_CLIENT_SECRET", "google-secret")
+	t.Setenv("OIDC_GOOGLE_REDIRECT_URL", "https://erp.localhost/oidc/callback")
+	t.Setenv("OIDC_AZURE_AD_ISSUER", "https://login.microsoftonline.com/tenant/v2.0")
+	t.Setenv("OIDC_AZURE_AD_SCOPES", "openid email")
+	t.Setenv("OIDC_STATE_DURATION", "5m")
+
+	config := LoadOIDCConfig()
+
+	assert.Equal(t, 5*time.Minute, config.StateDuration)
+	assert.Equal(t, []oidc.Config{
+		{
+			Name:         "google",
+			Issuer:       "https://accounts.google.com",
+			ClientID:     "google-client",
+			ClientSecret: "google-secret",
+			RedirectURL:  "https://erp.localhost/oidc/callback",
+			Scopes:       []string{},
+		},
+		{
+			Name:   "azure-ad",
+			Issuer: "https://login.microsoftonline.com/tenant/v2.0",
+			Scopes: []string{"openid", "email"},
+		},
+	}, config.Providers)
+
+	// The azure-ad provider has no client id and is skipped
+	providers := newOIDCProviders(config, logger.NewBaseLogger(shared.ModuleAuth))
+	assert.Len(t, providers, 1)
+	assert.Contains(t, providers, "google")
+}
+
+func TestHasPassword(t *testing.T) {
+	testCases := []struct {
+		name string
+		user *authv1.User
+		want bool
+	}{
+		{
+			name: "user created by an admin",
+			user: &authv1.User{CreatedBy: "admin-1"},
+			want: true,
+		},
+		{
+			name: "provisioned user",
+			user: &authv1.User{CreatedBy: "oidc:google"},
+			want: false,
+		},
+		{
+			name: "saml provisioned user",
+			user: &authv1.User{CreatedBy: "saml:https://idp.example.com"},
+			want: false,
+		},
+		{
+			name: "provisioned user that set a password",
+			user: &authv1.User{CreatedBy: "oidc:google", LastPasswordChange: timestamppb.Now()},
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, hasPassword(tc.user))
+		})
+	}
+}
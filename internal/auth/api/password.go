@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/auth/hash"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/notification"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ChangePassword changes the password of the calling user after verifying the current one
+func (u *UserAPI) ChangePassword(ctx context.Context, tenantID, userID, currentPassword, newPassword string) error {
+	if tenantID == "" || userID == "" || currentPassword == "" || newPassword == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, current_password, new_password"))
+		u.logger.Error("failed to change password", "error", err)
+		return err
+	}
+
+	user, err := u.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to change password", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	if err := u.verifyCurrentPassword(ctx, user, currentPassword); err != nil {
+		return err
+	}
+
+	return u.setPassword(ctx, user, newPassword)
+}
+
+// ResetPassword sets a new password for another user of the tenant without the current one
+func (u *UserAPI) ResetPassword(ctx context.Context, tenantID, userID, accountID, newPassword string) error {
+	if tenantID == "" || userID == "" || accountID == "" || newPassword == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, account_id, new_password"))
+		u.logger.Error("failed to reset password", "error", err)
+		return err
+	}
+
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserUpdate, tenantID); err != nil {
+		u.logger.Error("failed to reset password", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+
+	user, err := u.getUser(ctx, tenantID, accountID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to reset password", "tenant_id", tenantID, "user_id", userID, "account_id", accountID, "error", err)
+		return err
+	}
+
+	return u.setPassword(ctx, user, newPassword)
+}
+
+// IsPasswordExpired reports whether the user password is older than the tenant policy allows
+func (u *UserAPI) IsPasswordExpired(ctx context.Context, user *authv1.User) bool {
+	lastChange := user.GetLastPasswordChange()
+	if lastChange == nil {
+		return false
+	}
+	return hash.IsPasswordExpired(u.passwordPolicy(ctx, user.GetTenantId()), lastChange.AsTime(), time.Now())
+}
+
+// setPassword validates the new password against the tenant policy and history, then stores its hash
+func (u *UserAPI) setPassword(ctx context.Context, user *authv1.User, newPassword string) error {
+	policy := u.passwordPolicy(ctx, user.GetTenantId())
+	if err := hash.ValidatePassword(policy, newPassword, user.GetUsername(), user.GetEmail()); err != nil {
+		u.logger.Error("failed to set password", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+	if err := hash.CheckPasswordReuse(ctx, policy, newPassword, user.GetPasswordHash(), user.GetPasswordHistory()); err != nil {
+		u.logger.Error("failed to set password", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+	passwordHash, err := hash.Hash(newPassword)
+	if err != nil {
+		u.logger.Error("failed to set password", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+
+	user.PasswordHistory = hash.PushPasswordHistory(policy, user.GetPasswordHistory(), user.GetPasswordHash())
+	user.PasswordHash = passwordHash
+	user.LastPasswordChange = timestamppb.Now()
+	user.MustChangePassword = false
+	if _, err := u.updateUser(ctx, user); err != nil {
+		return err
+	}
+	u.notifier.notify(user, notification.EventPasswordChanged, nil)
+	return nil
+}
+
+// rotatePassword replaces the password of user with a generated one that must be changed on the next login and
+// returns it, it is not kept anywhere else. The sessions of user are ended first, a failure leaves the password
+func (u *UserAPI) rotatePassword(ctx context.Context, user *authv1.User, revokedBy string) (string, error) {
+	if u.sessions != nil {
+		if err := u.sessions.RevokeAllTokens(ctx, user.GetTenantId(), user.GetId(), revokedBy); err != nil {
+			u.logger.Error("failed to rotate password", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+			return "", err
+		}
+	}
+	password, err := hash.GeneratePassword()
+	if err != nil {
+		return "", err
+	}
+	passwordHash, err := hash.Hash(password)
+	if err != nil {
+		u.logger.Error("failed to rotate password", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return "", err
+	}
+
+	policy := u.passwordPolicy(ctx, user.GetTenantId())
+	user.PasswordHistory = hash.PushPasswordHistory(policy, user.GetPasswordHistory(), user.GetPasswordHash())
+	user.PasswordHash = passwordHash
+	user.LastPasswordChange = timestamppb.Now()
+	user.MustChangePassword = true
+	if _, err := u.updateUser(ctx, user); err != nil {
+		return "", err
+	}
+	u.notifier.notify(user, notification.EventPasswordChanged, nil)
+	return password, nil
+}
+
+func (u *UserAPI) hashPassword(ctx context.Context, user *authv1.User, password string) (string, error) {
+	return hash.HashPasswordWithPolicy(u.passwordPolicy(ctx, user.GetTenantId()), password, user.GetUsername(), user.GetEmail())
+}
+
+// passwordPolicy returns the tenant password policy, falling back to the default one when the tenant can not be loaded
+func (u *UserAPI) passwordPolicy(ctx context.Context, tenantID string) *authv1.PasswordPolicy {
+	tenant, err := u.tenantHandler.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		u.logger.Warn("failed to load tenant password policy, using default", "tenant_id", tenantID, "error", err)
+		return hash.DefaultPasswordPolicy()
+	}
+	return hash.EffectivePasswordPolicy(tenant)
+}
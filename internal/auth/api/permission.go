@@ -1,119 +1,195 @@
 package api
 
 import (
+	"context"
+
 	"erp.localhost/internal/auth/handler"
 	"erp.localhost/internal/auth/rbac"
+	"erp.localhost/internal/infra/event/outbox"
 	"erp.localhost/internal/infra/logging/logger"
-	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
 )
 
 // PermissionAPI provides permission management with authorization enforcement
 type PermissionAPI struct {
-	permissionHandler   *handler.PermissionHandler
-	verificationManager *rbac.VerificationManager
-	logger              logger.Logger
+	permissionHandler    *handler.PermissionHandler
+	permissionSetHandler *handler.PermissionSetHandler
+	roleHandler          *handler.RoleHandler
+	verificationManager  *rbac.VerificationManager
+	outbox               *outbox.Outbox
+	logger               logger.Logger
 }
 
 // NewPermissionAPI creates a new PermissionAPI instance
 func NewPermissionAPI(
 	permissionHandler *handler.PermissionHandler,
+	permissionSetHandler *handler.PermissionSetHandler,
+	roleHandler *handler.RoleHandler,
 	verificationManager *rbac.VerificationManager,
 	logger logger.Logger,
 ) *PermissionAPI {
 	return &PermissionAPI{
-		permissionHandler:   permissionHandler,
-		verificationManager: verificationManager,
-		logger:              logger,
+		permissionHandler:    permissionHandler,
+		permissionSetHandler: permissionSetHandler,
+		roleHandler:          roleHandler,
+		verificationManager:  verificationManager,
+		logger:               logger,
 	}
 }
 
 // CreatePermission creates a new permission with authorization check
-func (pa *PermissionAPI) CreatePermission(tenantID, requestorUserID string, permission *authv1.Permission, targetTenantID string) (string, error) {
-	permissionStr, err := model_auth.CreatePermissionString(model_auth.ResourceTypePermission, model_auth.PermissionActionCreate)
-	if err != nil {
-		return "", err
-	}
+func (pa *PermissionAPI) CreatePermission(ctx context.Context, tenantID, requestorUserID string, permission *authv1.Permission, targetTenantID string) (string, error) {
+	permissionStr := permissions.PermissionCreate
 
-	if err := pa.verificationManager.HasPermission(tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
 		pa.logger.Warn("Permission denied for CreatePermission", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
 		return "", err
 	}
+	if err := pa.checkProtected(ctx, tenantID, requestorUserID, permission); err != nil {
+		return "", err
+	}
+	if err := rbac.ValidateCondition(permission.GetCondition()); err != nil {
+		return "", err
+	}
 
-	return pa.permissionHandler.CreatePermission(permission)
+	var id string
+	err := pa.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		var err error
+		id, err = pa.permissionHandler.CreatePermission(ctx, permission)
+		if err != nil {
+			return nil, err
+		}
+		event := permissionEvent(model_event.EventPermissionCreated, requestorUserID, permission)
+		event.GetPermission().PermissionId = id
+		return []*eventv1.DomainEvent{event}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
 }
 
 // UpdatePermission updates an existing permission with authorization check
-func (pa *PermissionAPI) UpdatePermission(tenantID, requestorUserID string, permission *authv1.Permission, targetTenantID string) error {
-	permissionStr, err := model_auth.CreatePermissionString(model_auth.ResourceTypePermission, model_auth.PermissionActionUpdate)
+func (pa *PermissionAPI) UpdatePermission(ctx context.Context, tenantID, requestorUserID string, permission *authv1.Permission, targetTenantID string) error {
+	permissionStr := permissions.PermissionUpdate
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for UpdatePermission", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+		return err
+	}
+	current, err := pa.permissionHandler.GetPermissionByID(ctx, permission.GetTenantId(), permission.GetId())
 	if err != nil {
 		return err
 	}
-
-	if err := pa.verificationManager.HasPermission(tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
-		pa.logger.Warn("Permission denied for UpdatePermission", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+	if err := pa.checkProtected(ctx, tenantID, requestorUserID, current, permission); err != nil {
 		return err
 	}
+	if err := rbac.ValidateCondition(permission.GetCondition()); err != nil {
+		return err
+	}
+	// The holders of the permission are granted what it becomes
+	if permission.GetPermissionString() != current.GetPermissionString() || permission.GetCondition() != current.GetCondition() {
+		if err := pa.verificationManager.CheckGrantable(ctx, tenantID, requestorUserID, targetTenantID, permission); err != nil {
+			return err
+		}
+	}
 
-	return pa.permissionHandler.UpdatePermission(permission)
+	err = pa.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := pa.permissionHandler.UpdatePermission(ctx, permission); err != nil {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{permissionEvent(model_event.EventPermissionUpdated, requestorUserID, permission)}, nil
+	})
+	if err != nil {
+		return err
+	}
+	// The permissions of every user holding the permission change with it
+	pa.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	return nil
 }
 
 // GetPermissionByID retrieves a permission by ID with authorization check
-func (pa *PermissionAPI) GetPermissionByID(tenantID, requestorUserID, permissionID string, targetTenantID string) (*authv1.Permission, error) {
-	permissionStr, err := model_auth.CreatePermissionString(model_auth.ResourceTypePermission, model_auth.PermissionActionRead)
-	if err != nil {
-		return nil, err
-	}
+func (pa *PermissionAPI) GetPermissionByID(ctx context.Context, tenantID, requestorUserID, permissionID string, targetTenantID string) (*authv1.Permission, error) {
+	permissionStr := permissions.PermissionRead
 
-	if err := pa.verificationManager.HasPermission(tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
 		pa.logger.Warn("Permission denied for GetPermissionByID", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
 		return nil, err
 	}
 
-	return pa.permissionHandler.GetPermissionByID(targetTenantID, permissionID)
+	return pa.permissionHandler.GetPermissionByID(ctx, targetTenantID, permissionID)
 }
 
 // ListPermissions retrieves all permissions for a tenant with authorization check
-func (pa *PermissionAPI) ListPermissions(tenantID, requestorUserID string, targetTenantID string) ([]*authv1.Permission, error) {
-	permissionStr, err := model_auth.CreatePermissionString(model_auth.ResourceTypePermission, model_auth.PermissionActionRead)
-	if err != nil {
-		return nil, err
-	}
+func (pa *PermissionAPI) ListPermissions(ctx context.Context, tenantID, requestorUserID string, targetTenantID string) ([]*authv1.Permission, error) {
+	permissionStr := permissions.PermissionRead
 
-	if err := pa.verificationManager.HasPermission(tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
 		pa.logger.Warn("Permission denied for ListPermissions", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
 		return nil, err
 	}
 
-	return pa.permissionHandler.GetPermissionsByTenantID(targetTenantID)
+	return pa.permissionHandler.GetPermissionsByTenantID(ctx, targetTenantID)
 }
 
 // DeletePermission deletes a permission with authorization check
-func (pa *PermissionAPI) DeletePermission(tenantID, requestorUserID, permissionID string, targetTenantID string) error {
-	permissionStr, err := model_auth.CreatePermissionString(model_auth.ResourceTypePermission, model_auth.PermissionActionDelete)
+func (pa *PermissionAPI) DeletePermission(ctx context.Context, tenantID, requestorUserID, permissionID string, targetTenantID string) error {
+	permissionStr := permissions.PermissionDelete
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for DeletePermission", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+		return err
+	}
+	current, err := pa.permissionHandler.GetPermissionByID(ctx, targetTenantID, permissionID)
 	if err != nil {
 		return err
 	}
-
-	if err := pa.verificationManager.HasPermission(tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
-		pa.logger.Warn("Permission denied for DeletePermission", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+	if err := pa.checkProtected(ctx, tenantID, requestorUserID, current); err != nil {
 		return err
 	}
 
-	return pa.permissionHandler.DeletePermission(targetTenantID, permissionID)
+	err = pa.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := pa.permissionHandler.DeletePermission(ctx, targetTenantID, permissionID); err != nil {
+			return nil, err
+		}
+		deleted := &authv1.Permission{Id: permissionID, TenantId: targetTenantID}
+		return []*eventv1.DomainEvent{permissionEvent(model_event.EventPermissionDeleted, requestorUserID, deleted)}, nil
+	})
+	if err != nil {
+		return err
+	}
+	pa.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	return nil
 }
 
 // DeletePermission deletes a permission with authorization check
-func (pa *PermissionAPI) DeleteTenantPermissions(tenantID, requestorUserID, targetTenantID string) error {
-	permissionStr, err := model_auth.CreatePermissionString(model_auth.ResourceTypePermission, model_auth.PermissionActionDelete)
-	if err != nil {
+func (pa *PermissionAPI) DeleteTenantPermissions(ctx context.Context, tenantID, requestorUserID, targetTenantID string) error {
+	permissionStr := permissions.PermissionDelete
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for DeleteTenantPermissions", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
 		return err
 	}
 
-	if err := pa.verificationManager.HasPermission(tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
-		pa.logger.Warn("Permission denied for DeleteTenantPermissions", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+	if err := pa.permissionHandler.DeleteTenantPermissions(ctx, targetTenantID); err != nil {
+		return err
+	}
+	if err := pa.permissionSetHandler.DeleteTenantPermissionSets(ctx, targetTenantID); err != nil {
 		return err
 	}
+	pa.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	return nil
+}
 
-	return pa.permissionHandler.DeleteTenantPermissions(targetTenantID)
+// checkProtected requires a system admin when any of perms is protected
+func (pa *PermissionAPI) checkProtected(ctx context.Context, tenantID, requestorUserID string, perms ...*authv1.Permission) error {
+	for _, permission := range perms {
+		if permission.GetProtected() {
+			return pa.verificationManager.CheckProtected(ctx, tenantID, requestorUserID)
+		}
+	}
+	return nil
 }
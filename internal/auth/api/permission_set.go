@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"slices"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// CreatePermissionSet creates a new permission set with authorization check
+func (pa *PermissionAPI) CreatePermissionSet(ctx context.Context, tenantID, requestorUserID string, set *authv1.PermissionSet, targetTenantID string) (string, error) {
+	permissionStr := permissions.PermissionCreate
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for CreatePermissionSet", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+		return "", err
+	}
+	if err := pa.validatePermissionSetMembers(ctx, set); err != nil {
+		return "", err
+	}
+
+	return pa.permissionSetHandler.CreatePermissionSet(ctx, set)
+}
+
+// UpdatePermissionSet updates an existing permission set with authorization check
+func (pa *PermissionAPI) UpdatePermissionSet(ctx context.Context, tenantID, requestorUserID string, set *authv1.PermissionSet, targetTenantID string) error {
+	permissionStr := permissions.PermissionUpdate
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for UpdatePermissionSet", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+		return err
+	}
+	if err := pa.validatePermissionSetMembers(ctx, set); err != nil {
+		return err
+	}
+	current, err := pa.permissionSetHandler.GetPermissionSetByID(ctx, set.GetTenantId(), set.GetId())
+	if err != nil {
+		return err
+	}
+	// The roles the set is attached to are granted the permissions added to it
+	added := slices.DeleteFunc(slices.Clone(set.GetPermissions()), func(id string) bool {
+		return slices.Contains(current.GetPermissions(), id)
+	})
+	if err := pa.verificationManager.CheckGrantablePermissions(ctx, tenantID, requestorUserID, targetTenantID, added); err != nil {
+		return err
+	}
+
+	if err := pa.permissionSetHandler.UpdatePermissionSet(ctx, set); err != nil {
+		return err
+	}
+	// The permissions of every role the set is attached to change with it
+	pa.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	return nil
+}
+
+// GetPermissionSetByID retrieves a permission set by ID with authorization check
+func (pa *PermissionAPI) GetPermissionSetByID(ctx context.Context, tenantID, requestorUserID, setID string, targetTenantID string) (*authv1.PermissionSet, error) {
+	permissionStr := permissions.PermissionRead
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for GetPermissionSetByID", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+		return nil, err
+	}
+
+	return pa.permissionSetHandler.GetPermissionSetByID(ctx, targetTenantID, setID)
+}
+
+// ListPermissionSets retrieves all permission sets for a tenant with authorization check
+func (pa *PermissionAPI) ListPermissionSets(ctx context.Context, tenantID, requestorUserID string, targetTenantID string) ([]*authv1.PermissionSet, error) {
+	permissionStr := permissions.PermissionRead
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for ListPermissionSets", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+		return nil, err
+	}
+
+	return pa.permissionSetHandler.GetPermissionSetsByTenantID(ctx, targetTenantID)
+}
+
+// DeletePermissionSet deletes a permission set and detaches it from the roles holding it, with authorization check
+func (pa *PermissionAPI) DeletePermissionSet(ctx context.Context, tenantID, requestorUserID, setID string, targetTenantID string) error {
+	permissionStr := permissions.PermissionDelete
+
+	if err := pa.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permissionStr, targetTenantID); err != nil {
+		pa.logger.Warn("Permission denied for DeletePermissionSet", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permissionStr)
+		return err
+	}
+
+	// The set is detached from its roles before it is deleted, a failure leaves it attached to the remaining roles
+	// and existing, never referenced by a role once deleted
+	roles, err := pa.roleHandler.GetRolesByPermissionSetID(ctx, targetTenantID, setID)
+	if err != nil {
+		pa.logger.Error("failed to get the roles of the permission set", "tenant_id", targetTenantID, "permission_set_id", setID, "error", err)
+		return err
+	}
+	roleIDs := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.GetId())
+	}
+	// The permissions of the roles change with every detached set, even when a later step fails
+	defer pa.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	if err := pa.roleHandler.DetachPermissionSet(ctx, targetTenantID, setID, roleIDs); err != nil {
+		pa.logger.Error("failed to detach the permission set", "tenant_id", targetTenantID, "permission_set_id", setID, "error", err)
+		return err
+	}
+	return pa.permissionSetHandler.DeletePermissionSet(ctx, targetTenantID, setID)
+}
+
+// validatePermissionSetMembers checks the permissions of set exist in its tenant
+func (pa *PermissionAPI) validatePermissionSetMembers(ctx context.Context, set *authv1.PermissionSet) error {
+	for _, permissionID := range set.GetPermissions() {
+		if _, err := pa.permissionHandler.GetPermissionByID(ctx, set.GetTenantId(), permissionID); err != nil {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "Permissions").WithError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/rbac"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPermissionAPI_PermissionSetChanges(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	userHandler, err := handler.NewUserHandler(log)
+	require.NoError(t, err)
+	roleHandler, err := handler.NewRoleHandler(log)
+	require.NoError(t, err)
+	permissionHandler, err := handler.NewPermissionHandler(log)
+	require.NoError(t, err)
+	permissionSetHandler, err := handler.NewPermissionSetHandler(log)
+	require.NoError(t, err)
+	tenantHandler, err := handler.NewTenantHandler(log)
+	require.NoError(t, err)
+	apiKeyHandler, err := handler.NewAPIKeyHandler(log)
+	require.NoError(t, err)
+	vm := rbac.NewVerificationManager(userHandler, roleHandler, permissionHandler, permissionSetHandler, tenantHandler, apiKeyHandler, log)
+	cache, err := rbac.NewRedisPermissionCache(&rbac.PermissionCacheConfig{Enabled: true, TTL: time.Hour}, log)
+	require.NoError(t, err)
+	vm.SetPermissionCache(cache)
+	pa := NewPermissionAPI(permissionHandler, permissionSetHandler, roleHandler, vm, log)
+
+	ctx := context.Background()
+	const tenantID = "tenant-permission-sets"
+	t.Cleanup(func() { cache.InvalidateTenant(ctx, tenantID) })
+	createPermission := func(permission, resource, action string) string {
+		id, err := permissionHandler.CreatePermission(ctx, &authv1.Permission{
+			TenantId:         tenantID,
+			Resource:         resource,
+			Action:           action,
+			PermissionString: permission,
+			DisplayName:      permission,
+			Status:           authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE,
+			CreatedBy:        "System",
+		})
+		require.NoError(t, err)
+		return id
+	}
+	createRole := func(name string, permissionIDs, setIDs []string) string {
+		id, err := roleHandler.CreateRole(ctx, &authv1.Role{
+			TenantId:       tenantID,
+			Name:           name,
+			Permissions:    permissionIDs,
+			PermissionSets: setIDs,
+			Status:         authv1.RoleStatus_ROLE_STATUS_ACTIVE,
+			CreatedBy:      "System",
+			CreatedAt:      timestamppb.Now(),
+		})
+		require.NoError(t, err)
+		return id
+	}
+	createUser := func(username, roleID string) string {
+		id, err := userHandler.CreateUser(ctx, &authv1.User{
+			TenantId:     tenantID,
+			Username:     username,
+			Email:        username + "@example.com",
+			PasswordHash: "hash",
+			Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+			Roles:        []*authv1.UserRole{{RoleId: roleID, TenantId: tenantID, AssignedBy: "System", AssignedAt: timestamppb.Now()}},
+			CreatedBy:    "System",
+			CreatedAt:    timestamppb.Now(),
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	usersRead := createPermission("users:read", "users", "read")
+	usersCreate := createPermission("users:create", "users", "create")
+	ordersRead := createPermission("orders:read", "orders", "read")
+	adminID := createUser("admin", createRole(model_auth.RoleTenantAdmin, []string{"*"}, nil))
+	setID, err := pa.CreatePermissionSet(ctx, tenantID, adminID, &authv1.PermissionSet{
+		TenantId:    tenantID,
+		Name:        "user-readers",
+		Permissions: []string{usersRead},
+		CreatedBy:   adminID,
+	}, tenantID)
+	require.NoError(t, err)
+	roleID := createRole("operator", []string{ordersRead}, []string{setID})
+	userID := createUser("operator", roleID)
+
+	granted, err := vm.GetUserPermissions(ctx, tenantID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"orders:read": true, "users:read": true}, granted)
+
+	// The cached permissions of the users holding the set follow its update
+	set, err := permissionSetHandler.GetPermissionSetByID(ctx, tenantID, setID)
+	require.NoError(t, err)
+	set.Permissions = []string{usersCreate}
+	require.NoError(t, pa.UpdatePermissionSet(ctx, tenantID, adminID, set, tenantID))
+	granted, err = vm.GetUserPermissions(ctx, tenantID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"orders:read": true, "users:create": true}, granted)
+
+	// The deleted set is detached from its roles and no longer grants its permissions
+	require.NoError(t, pa.DeletePermissionSet(ctx, tenantID, adminID, setID, tenantID))
+	role, err := roleHandler.GetRoleByID(ctx, tenantID, roleID)
+	require.NoError(t, err)
+	assert.Empty(t, role.GetPermissionSets())
+	_, err = permissionSetHandler.GetPermissionSetByID(ctx, tenantID, setID)
+	assert.Error(t, err)
+	granted, err = vm.GetUserPermissions(ctx, tenantID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"orders:read": true}, granted)
+}
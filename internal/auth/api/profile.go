@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// GetProfileCompletion reports which eventually required profile fields a user has not completed yet.
+// Users may always query their own profile, other accounts require user read permission.
+func (u *UserAPI) GetProfileCompletion(ctx context.Context, tenantID, userID, targetTenantID, accountID string) (*authv1.ProfileCompletion, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		u.logger.Error("failed to get profile completion", "error", err)
+		return nil, err
+	}
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+	if accountID == "" {
+		accountID = userID
+	}
+
+	if targetTenantID != tenantID || accountID != userID {
+		if err := u.hasPermission(ctx, tenantID, userID, permissions.UserRead, targetTenantID); err != nil {
+			u.logger.Error("failed to get profile completion", "tenant_id", tenantID, "user_id", userID, "error", err)
+			return nil, err
+		}
+	}
+
+	user, err := u.getUser(ctx, targetTenantID, accountID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to get profile completion", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	if user == nil {
+		return nil, infra_error.NotFound(infra_error.NotFoundUser, "user", accountID)
+	}
+
+	return profileCompletion(user, u.eventuallyRequiredProfileFields(ctx, targetTenantID)), nil
+}
+
+// GetProfileCompletionStats aggregates profile completion for all users of a tenant
+func (u *UserAPI) GetProfileCompletionStats(ctx context.Context, tenantID, userID, targetTenantID string) (*authv1.ProfileCompletionStats, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		u.logger.Error("failed to get profile completion stats", "error", err)
+		return nil, err
+	}
+
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserRead, targetTenantID); err != nil {
+		u.logger.Error("failed to get profile completion stats", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	users, err := u.userHandler.GetUsersByTenantID(ctx, targetTenantID)
+	if err != nil {
+		u.logger.Error("failed to get profile completion stats", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	fields := u.eventuallyRequiredProfileFields(ctx, targetTenantID)
+	stats := &authv1.ProfileCompletionStats{
+		TotalUsers:               int32(len(users)),
+		MissingByField:           make(map[string]int32, len(fields)),
+		EventuallyRequiredFields: fields,
+	}
+	for _, field := range fields {
+		stats.MissingByField[field] = 0
+	}
+	for _, user := range users {
+		completion := profileCompletion(user, fields)
+		if len(completion.MissingFields) == 0 {
+			stats.CompletedUsers++
+		}
+		for _, field := range completion.MissingFields {
+			stats.MissingByField[field]++
+		}
+	}
+	return stats, nil
+}
+
+// eventuallyRequiredProfileFields returns the tenant configured fields, falling back to the defaults
+func (u *UserAPI) eventuallyRequiredProfileFields(ctx context.Context, tenantID string) []string {
+	tenant, err := u.tenantHandler.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		u.logger.Warn("failed to get tenant profile settings, using defaults", "tenant_id", tenantID, "error", err)
+	}
+	fields := tenant.GetSettings().GetEventuallyRequiredProfileFields()
+	if len(fields) == 0 {
+		fields = model_auth.DefaultEventuallyRequiredProfileFields
+	}
+	result := make([]string, len(fields))
+	copy(result, fields)
+	return result
+}
+
+func profileCompletion(user *authv1.User, fields []string) *authv1.ProfileCompletion {
+	completion := &authv1.ProfileCompletion{
+		UserId:            user.GetId(),
+		CompletedFields:   []string{},
+		MissingFields:     []string{},
+		CompletionPercent: 100,
+	}
+	for _, field := range fields {
+		if isProfileFieldCompleted(user, field) {
+			completion.CompletedFields = append(completion.CompletedFields, field)
+		} else {
+			completion.MissingFields = append(completion.MissingFields, field)
+		}
+	}
+	if len(fields) > 0 {
+		completion.CompletionPercent = int32(len(completion.CompletedFields) * 100 / len(fields))
+	}
+	return completion
+}
+
+func isProfileFieldCompleted(user *authv1.User, field string) bool {
+	switch field {
+	case model_auth.ProfileFieldPhone:
+		return user.GetProfile().GetPhone() != ""
+	case model_auth.ProfileFieldTitle:
+		return user.GetProfile().GetTitle() != ""
+	case model_auth.ProfileFieldDepartment:
+		return user.GetProfile().GetDepartment() != ""
+	case model_auth.ProfileFieldMFA:
+		return user.GetMfaEnabled()
+	default:
+		return false
+	}
+}
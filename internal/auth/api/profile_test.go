@@ -0,0 +1,64 @@
+package api
+
+import (
+	"testing"
+
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileCompletion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		user            *authv1.User
+		fields          []string
+		expectedMissing []string
+		expectedPercent int32
+	}{
+		{
+			name:            "empty profile misses every field",
+			user:            &authv1.User{Id: "user-1"},
+			fields:          model_auth.DefaultEventuallyRequiredProfileFields,
+			expectedMissing: model_auth.DefaultEventuallyRequiredProfileFields,
+			expectedPercent: 0,
+		},
+		{
+			name: "partially completed profile",
+			user: &authv1.User{
+				Id:      "user-1",
+				Profile: &authv1.UserProfile{Phone: "+972500000000", Title: "Engineer"},
+			},
+			fields:          model_auth.DefaultEventuallyRequiredProfileFields,
+			expectedMissing: []string{model_auth.ProfileFieldDepartment, model_auth.ProfileFieldMFA},
+			expectedPercent: 50,
+		},
+		{
+			name: "completed profile",
+			user: &authv1.User{
+				Id:         "user-1",
+				MfaEnabled: true,
+				Profile:    &authv1.UserProfile{Phone: "+972500000000", Title: "Engineer", Department: "R&D"},
+			},
+			fields:          model_auth.DefaultEventuallyRequiredProfileFields,
+			expectedMissing: []string{},
+			expectedPercent: 100,
+		},
+		{
+			name:            "only tenant configured fields are checked",
+			user:            &authv1.User{Id: "user-1", MfaEnabled: true},
+			fields:          []string{model_auth.ProfileFieldMFA},
+			expectedMissing: []string{},
+			expectedPercent: 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			completion := profileCompletion(tc.user, tc.fields)
+			assert.Equal(t, tc.user.GetId(), completion.GetUserId())
+			assert.Equal(t, tc.expectedMissing, completion.GetMissingFields())
+			assert.Equal(t, tc.expectedPercent, completion.GetCompletionPercent())
+		})
+	}
+}
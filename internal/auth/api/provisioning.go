@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// DeprovisionUser deactivates accountID of targetTenantID and revokes its sessions. Unlike DeleteUser the user, its
+// roles and its history are kept, so an identity provider provisioning the user again reactivates the same account.
+// Requires user update permission
+func (u *UserAPI) DeprovisionUser(ctx context.Context, tenantID, userID, targetTenantID, accountID string) error {
+	if tenantID == "" || userID == "" || targetTenantID == "" || accountID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id, account_id"))
+		u.logger.Error("failed to deprovision user", "error", err)
+		return err
+	}
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserUpdate, targetTenantID); err != nil {
+		u.logger.Error("failed to deprovision user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	user, err := u.getUser(ctx, targetTenantID, accountID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to deprovision user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+		return err
+	}
+
+	if user.GetStatus() != authv1.UserStatus_USER_STATUS_INACTIVE {
+		deactivated := &authv1.User{
+			Id:       accountID,
+			TenantId: targetTenantID,
+			Version:  user.GetVersion(),
+			Status:   authv1.UserStatus_USER_STATUS_INACTIVE,
+		}
+		if _, err := u.UpdateUser(ctx, tenantID, userID, deactivated, &fieldmaskpb.FieldMask{Paths: []string{"status"}}); err != nil {
+			return err
+		}
+	}
+	// Sessions are revoked on every call, a deprovisioning retried after a failed revocation completes it
+	if u.sessions != nil {
+		if err := u.sessions.RevokeAllTokens(ctx, targetTenantID, accountID, userID); err != nil {
+			u.logger.Error("failed to deprovision user", "target_tenant_id", targetTenantID, "account_id", accountID, "error", err)
+			return err
+		}
+	}
+	u.logger.Info("user deprovisioned", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID, "account_id", accountID)
+	return nil
+}
@@ -1,8 +1,12 @@
 package api
 
 import (
+	"context"
+
 	"erp.localhost/internal/auth/handler"
 	"erp.localhost/internal/auth/rbac"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/event/outbox"
 	"erp.localhost/internal/infra/logging/logger"
 )
 
@@ -17,16 +21,23 @@ type RBACAPI struct {
 func NewRBACAPI(
 	roleHandler *handler.RoleHandler,
 	permissionHandler *handler.PermissionHandler,
+	permissionSetHandler *handler.PermissionSetHandler,
 	verificationManager *rbac.VerificationManager,
 	logger logger.Logger,
 ) *RBACAPI {
 	return &RBACAPI{
-		Roles:        NewRoleAPI(roleHandler, verificationManager, logger),
-		Permissions:  NewPermissionAPI(permissionHandler, verificationManager, logger),
+		Roles:        NewRoleAPI(roleHandler, permissionSetHandler, verificationManager, logger),
+		Permissions:  NewPermissionAPI(permissionHandler, permissionSetHandler, roleHandler, verificationManager, logger),
 		Verification: NewVerificationAPI(verificationManager, logger),
 	}
 }
 
+// SetOutbox records the domain events of the role and permission changes in outbox
+func (r *RBACAPI) SetOutbox(outbox *outbox.Outbox) {
+	r.Roles.outbox = outbox
+	r.Permissions.outbox = outbox
+}
+
 // VerificationAPI provides permission verification operations (no authorization needed)
 type VerificationAPI struct {
 	verificationManager *rbac.VerificationManager
@@ -44,32 +55,83 @@ func NewVerificationAPI(
 	}
 }
 
+// InvalidateUserPermissions drops the cached permissions of a user after it changes
+func (va *VerificationAPI) InvalidateUserPermissions(ctx context.Context, tenantID, userID string) {
+	va.verificationManager.InvalidateUserPermissions(ctx, tenantID, userID)
+}
+
+// InvalidateTenantPermissions drops the cached permissions of the users of a tenant after a role or permission changes
+func (va *VerificationAPI) InvalidateTenantPermissions(ctx context.Context, tenantID string) {
+	va.verificationManager.InvalidateTenantPermissions(ctx, tenantID)
+}
+
 // GetUserPermissions retrieves all permissions for a user
-func (va *VerificationAPI) GetUserPermissionsIDs(tenantID, userID string) (map[string]bool, error) {
-	return va.verificationManager.GetUserPermissionsIDs(tenantID, userID)
+func (va *VerificationAPI) GetUserPermissionsIDs(ctx context.Context, tenantID, userID string) (map[string]bool, error) {
+	return va.verificationManager.GetUserPermissionsIDs(ctx, tenantID, userID)
 }
 
 // GetUserPermissions retrieves all permissions for a user
-func (va *VerificationAPI) GetUserPermissions(tenantID, userID string) (map[string]bool, error) {
-	return va.verificationManager.GetUserPermissions(tenantID, userID)
+func (va *VerificationAPI) GetUserPermissions(ctx context.Context, tenantID, userID string) (map[string]bool, error) {
+	return va.verificationManager.GetUserPermissions(ctx, tenantID, userID)
 }
 
 // GetUserRoles retrieves all role IDs for a user
-func (va *VerificationAPI) GetUserRoles(tenantID, userID string) ([]string, error) {
-	return va.verificationManager.GetUserRoles(tenantID, userID)
+func (va *VerificationAPI) GetUserRoles(ctx context.Context, tenantID, userID string) ([]string, error) {
+	return va.verificationManager.GetUserRoles(ctx, tenantID, userID)
 }
 
 // CheckPermissions checks if a user has specific permissions
-func (va *VerificationAPI) CheckPermissions(tenantID, userID string, permissions []string) (map[string]bool, error) {
-	return va.verificationManager.CheckPermissions(tenantID, userID, permissions)
+func (va *VerificationAPI) CheckPermissions(ctx context.Context, tenantID, userID string, permissions []string) (map[string]bool, error) {
+	return va.verificationManager.CheckPermissions(ctx, tenantID, userID, permissions)
 }
 
 // HasPermission checks if a user has a specific permission (with cross-tenant support)
-func (va *VerificationAPI) HasPermission(tenantID, userID, permission string, targetTenantID string) error {
-	return va.verificationManager.HasPermission(tenantID, userID, permission, targetTenantID)
+func (va *VerificationAPI) HasPermission(ctx context.Context, tenantID, userID, permission string, targetTenantID string) error {
+	return va.verificationManager.HasPermission(ctx, tenantID, userID, permission, targetTenantID)
+}
+
+// HasPermissionWithAttributes checks if a user has a specific permission for a request with attributes, the
+// conditions of the conditional permissions are evaluated against them
+func (va *VerificationAPI) HasPermissionWithAttributes(ctx context.Context, tenantID, userID, permission string, targetTenantID string, attributes map[string]string) error {
+	return va.verificationManager.HasPermissionWithAttributes(ctx, tenantID, userID, permission, targetTenantID, attributes)
+}
+
+// VerifyPermissions checks several permissions and role names of a user on targetTenantID in one call
+func (va *VerificationAPI) VerifyPermissions(ctx context.Context, tenantID, userID string, permissions, roleNames []string, targetTenantID string, attributes map[string]string) (map[string]bool, map[string]bool, error) {
+	return va.verificationManager.VerifyPermissions(ctx, tenantID, userID, permissions, roleNames, targetTenantID, attributes)
+}
+
+// RequirePermissions returns a permission denied error unless the user holds every permission on targetTenantID
+func (va *VerificationAPI) RequirePermissions(ctx context.Context, tenantID, userID, targetTenantID string, permissions ...string) error {
+	granted, _, err := va.verificationManager.VerifyPermissions(ctx, tenantID, userID, permissions, nil, targetTenantID, nil)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !granted[permission] {
+			va.logger.Warn("permission denied", "user_id", userID, "tenant_id", tenantID, "target_tenant_id", targetTenantID, "permission", permission)
+			return infra_error.Auth(infra_error.AuthPermissionDenied)
+		}
+	}
+	return nil
 }
 
 // IsSystemTenantUser checks if a user belongs to the system tenant
 func (va *VerificationAPI) IsSystemTenantUser(tenantID string) bool {
 	return va.verificationManager.IsSystemTenantUser(tenantID)
 }
+
+// IsSystemAdmin checks if a user is an admin of the system tenant
+func (va *VerificationAPI) IsSystemAdmin(ctx context.Context, tenantID, userID string) error {
+	return va.verificationManager.IsSystemAdmin(ctx, tenantID, userID)
+}
+
+// CheckGrantableRoles returns a privilege escalation error unless the user holds every permission the roles grant
+func (va *VerificationAPI) CheckGrantableRoles(ctx context.Context, tenantID, userID, targetTenantID string, roleIDs []string) error {
+	return va.verificationManager.CheckGrantableRoles(ctx, tenantID, userID, targetTenantID, roleIDs)
+}
+
+// CheckGrantablePermissions returns a privilege escalation error unless the user holds every permission granted
+func (va *VerificationAPI) CheckGrantablePermissions(ctx context.Context, tenantID, userID, targetTenantID string, permissionIDs []string) error {
+	return va.verificationManager.CheckGrantablePermissions(ctx, tenantID, userID, targetTenantID, permissionIDs)
+}
@@ -1,123 +1,212 @@
 package api
 
 import (
+	"context"
+
 	"erp.localhost/internal/auth/handler"
 	"erp.localhost/internal/auth/rbac"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/event/outbox"
 	"erp.localhost/internal/infra/logging/logger"
 	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
 )
 
 // RoleAPI provides role management with authorization enforcement
 type RoleAPI struct {
-	roleHandler         *handler.RoleHandler
-	verificationManager *rbac.VerificationManager
-	logger              logger.Logger
+	roleHandler          *handler.RoleHandler
+	permissionSetHandler *handler.PermissionSetHandler
+	verificationManager  *rbac.VerificationManager
+	outbox               *outbox.Outbox
+	quotas               *TenantQuotas
+	logger               logger.Logger
 }
 
 // NewRoleAPI creates a new RoleAPI instance
 func NewRoleAPI(
 	roleHandler *handler.RoleHandler,
+	permissionSetHandler *handler.PermissionSetHandler,
 	verificationManager *rbac.VerificationManager,
 	logger logger.Logger,
 ) *RoleAPI {
 	return &RoleAPI{
-		roleHandler:         roleHandler,
-		verificationManager: verificationManager,
-		logger:              logger,
+		roleHandler:          roleHandler,
+		permissionSetHandler: permissionSetHandler,
+		verificationManager:  verificationManager,
+		logger:               logger,
 	}
 }
 
 // CreateRole creates a new role with authorization check
-func (ra *RoleAPI) CreateRole(tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) (string, error) {
+func (ra *RoleAPI) CreateRole(ctx context.Context, tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) (string, error) {
 	// 1. Check permission (with cross-tenant support)
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeRole, model_auth.PermissionActionCreate)
-	if err != nil {
-		return "", err
-	}
+	permission := permissions.RoleCreate
 
 	// targetTenantID is the tenant where the role will be created
 	// If requestor is system tenant user, they can create roles in any tenant
 	// If requestor is tenant admin, they can create roles in their own tenant
-	if err := ra.verificationManager.HasPermission(tenantID, requestorUserID, permission, targetTenantID); err != nil {
+	if err := ra.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permission, targetTenantID); err != nil {
 		ra.logger.Warn("Permission denied for CreateRole", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
 		return "", err
 	}
+	if err := ra.checkProtected(ctx, tenantID, requestorUserID, role); err != nil {
+		return "", err
+	}
+	if err := ra.validatePermissionSets(ctx, role); err != nil {
+		return "", err
+	}
+	if err := ra.verificationManager.CheckGrantableRole(ctx, tenantID, requestorUserID, targetTenantID, role, nil); err != nil {
+		return "", err
+	}
+	// The system roles seeded with the tenant are not limited
+	if role.GetType() != authv1.RoleType_ROLE_TYPE_SYSTEM {
+		if err := ra.quotas.Check(ctx, targetTenantID, model_auth.TenantResourceRoles); err != nil {
+			return "", err
+		}
+	}
 
 	// 2. Call business logic
-	return ra.roleHandler.CreateRole(role)
+	var id string
+	err := ra.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		var err error
+		id, err = ra.roleHandler.CreateRole(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		event := roleEvent(model_event.EventRoleCreated, requestorUserID, role)
+		event.GetRole().RoleId = id
+		return []*eventv1.DomainEvent{event}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
 }
 
 // UpdateRole updates an existing role with authorization check
-func (ra *RoleAPI) UpdateRole(tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) error {
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeRole, model_auth.PermissionActionUpdate)
+func (ra *RoleAPI) UpdateRole(ctx context.Context, tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) error {
+	permission := permissions.RoleUpdate
+
+	if err := ra.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permission, targetTenantID); err != nil {
+		ra.logger.Warn("Permission denied for UpdateRole", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
+		return err
+	}
+	current, err := ra.roleHandler.GetRoleByID(ctx, role.GetTenantId(), role.GetId())
 	if err != nil {
 		return err
 	}
-
-	if err := ra.verificationManager.HasPermission(tenantID, requestorUserID, permission, targetTenantID); err != nil {
-		ra.logger.Warn("Permission denied for UpdateRole", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
+	if err := ra.checkProtected(ctx, tenantID, requestorUserID, current, role); err != nil {
+		return err
+	}
+	if err := ra.validatePermissionSets(ctx, role); err != nil {
+		return err
+	}
+	if err := ra.verificationManager.CheckGrantableRole(ctx, tenantID, requestorUserID, targetTenantID, role, current); err != nil {
 		return err
 	}
 
-	return ra.roleHandler.UpdateRole(role)
+	err = ra.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := ra.roleHandler.UpdateRole(ctx, role); err != nil {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{roleEvent(model_event.EventRoleUpdated, requestorUserID, role)}, nil
+	})
+	if err != nil {
+		return err
+	}
+	// The permissions of every user holding the role change with it
+	ra.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	return nil
 }
 
 // GetRoleByID retrieves a role by ID with authorization check
-func (ra *RoleAPI) GetRoleByID(tenantID, requestorUserID, roleID string, targetTenantID string) (*authv1.Role, error) {
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeRole, model_auth.PermissionActionRead)
-	if err != nil {
-		return nil, err
-	}
+func (ra *RoleAPI) GetRoleByID(ctx context.Context, tenantID, requestorUserID, roleID string, targetTenantID string) (*authv1.Role, error) {
+	permission := permissions.RoleRead
 
-	if err := ra.verificationManager.HasPermission(tenantID, requestorUserID, permission, targetTenantID); err != nil {
+	if err := ra.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permission, targetTenantID); err != nil {
 		ra.logger.Warn("Permission denied for GetRoleByID", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
 		return nil, err
 	}
 
-	return ra.roleHandler.GetRoleByID(targetTenantID, roleID)
+	return ra.roleHandler.GetRoleByID(ctx, targetTenantID, roleID)
 }
 
 // ListRoles retrieves all roles for a tenant with authorization check
-func (ra *RoleAPI) ListRoles(tenantID, requestorUserID string, targetTenantID string) ([]*authv1.Role, error) {
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeRole, model_auth.PermissionActionRead)
-	if err != nil {
-		return nil, err
-	}
+func (ra *RoleAPI) ListRoles(ctx context.Context, tenantID, requestorUserID string, targetTenantID string) ([]*authv1.Role, error) {
+	permission := permissions.RoleRead
 
-	if err := ra.verificationManager.HasPermission(tenantID, requestorUserID, permission, targetTenantID); err != nil {
+	if err := ra.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permission, targetTenantID); err != nil {
 		ra.logger.Warn("Permission denied for ListRoles", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
 		return nil, err
 	}
 
-	return ra.roleHandler.GetRolesByTenantID(targetTenantID)
+	return ra.roleHandler.GetRolesByTenantID(ctx, targetTenantID)
 }
 
 // DeleteRole deletes a role with authorization check
-func (ra *RoleAPI) DeleteRole(tenantID, requestorUserID, roleID string, targetTenantID string) error {
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeRole, model_auth.PermissionActionDelete)
+func (ra *RoleAPI) DeleteRole(ctx context.Context, tenantID, requestorUserID, roleID string, targetTenantID string) error {
+	permission := permissions.RoleDelete
+
+	if err := ra.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permission, targetTenantID); err != nil {
+		ra.logger.Warn("Permission denied for DeleteRole", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
+		return err
+	}
+	current, err := ra.roleHandler.GetRoleByID(ctx, targetTenantID, roleID)
 	if err != nil {
 		return err
 	}
-
-	if err := ra.verificationManager.HasPermission(tenantID, requestorUserID, permission, targetTenantID); err != nil {
-		ra.logger.Warn("Permission denied for DeleteRole", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
+	if err := ra.checkProtected(ctx, tenantID, requestorUserID, current); err != nil {
 		return err
 	}
 
-	return ra.roleHandler.DeleteRole(targetTenantID, roleID)
-}
-
-func (ra *RoleAPI) DeleteTenantRoles(tenantID, requestorUserID, targetTenantID string) error {
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeRole, model_auth.PermissionActionDelete)
+	err = ra.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := ra.roleHandler.DeleteRole(ctx, targetTenantID, roleID); err != nil {
+			return nil, err
+		}
+		deleted := &authv1.Role{Id: roleID, TenantId: targetTenantID}
+		return []*eventv1.DomainEvent{roleEvent(model_event.EventRoleDeleted, requestorUserID, deleted)}, nil
+	})
 	if err != nil {
 		return err
 	}
+	ra.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	return nil
+}
 
-	if err := ra.verificationManager.HasPermission(tenantID, requestorUserID, permission, targetTenantID); err != nil {
+func (ra *RoleAPI) DeleteTenantRoles(ctx context.Context, tenantID, requestorUserID, targetTenantID string) error {
+	permission := permissions.RoleDelete
+
+	if err := ra.verificationManager.HasPermission(ctx, tenantID, requestorUserID, permission, targetTenantID); err != nil {
 		ra.logger.Warn("Permission denied for DeleteRole", "tenant_id", tenantID, "user_id", requestorUserID, "permission", permission)
 		return err
 	}
 
-	return ra.roleHandler.DeleteTenantRoles(targetTenantID)
+	if err := ra.roleHandler.DeleteTenantRoles(ctx, targetTenantID); err != nil {
+		return err
+	}
+	ra.verificationManager.InvalidateTenantPermissions(ctx, targetTenantID)
+	return nil
+}
+
+// checkProtected requires a system admin when any of roles is protected
+func (ra *RoleAPI) checkProtected(ctx context.Context, tenantID, requestorUserID string, roles ...*authv1.Role) error {
+	for _, role := range roles {
+		if role.GetProtected() {
+			return ra.verificationManager.CheckProtected(ctx, tenantID, requestorUserID)
+		}
+	}
+	return nil
+}
+
+// validatePermissionSets checks the permission sets attached to role exist in its tenant
+func (ra *RoleAPI) validatePermissionSets(ctx context.Context, role *authv1.Role) error {
+	for _, setID := range role.GetPermissionSets() {
+		if _, err := ra.permissionSetHandler.GetPermissionSetByID(ctx, role.GetTenantId(), setID); err != nil {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "PermissionSets").WithError(err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/metrics"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// roleAssignmentActor is the actor of the changes made by the expiry sweeps
+const roleAssignmentActor = "system"
+
+var expiredRoleAssignments = metrics.NewCounter("role_assignments_expired_total",
+	"Total number of expired role assignments removed by the sweeps.")
+
+// RoleAssignmentConfig holds the settings of the temporary role assignments, loaded with infra_config.Load
+type RoleAssignmentConfig struct {
+	// Interval between two sweeps of the expired assignments. Expired assignments grant nothing before they
+	// are swept, the sweeps remove them from the users and audit the removal
+	SweepInterval time.Duration `yaml:"sweep_interval" env:"ROLE_ASSIGNMENT_SWEEP_INTERVAL" default:"1m"`
+}
+
+// ExtendRoleAssignment moves the expiry of the temporary assignment of roleID to accountID to expiresAt, later
+// than the current one. Assignments without expiry are permanent and cannot be extended
+func (u *UserAPI) ExtendRoleAssignment(ctx context.Context, tenantID, userID, targetTenantID, accountID, roleID string, expiresAt time.Time) error {
+	if tenantID == "" || userID == "" || targetTenantID == "" || accountID == "" || roleID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id, account_id, role_id"))
+		u.logger.Error("failed to extend role assignment", "error", err)
+		return err
+	}
+	if !expiresAt.After(time.Now()) {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "ExpiresAt").WithError(errors.New("expires_at must be in the future"))
+	}
+
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserModifyRole, targetTenantID); err != nil {
+		u.logger.Error("failed to extend role assignment", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+
+	user, err := u.userHandler.GetUserByID(ctx, targetTenantID, accountID)
+	if err != nil {
+		u.logger.Error("failed to extend role assignment", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	var assignment *authv1.UserRole
+	for _, userRole := range user.GetRoles() {
+		if userRole.GetRoleId() == roleID {
+			assignment = userRole
+			break
+		}
+	}
+	if assignment == nil {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "RoleId").WithError(errors.New("role is not assigned to the user"))
+	}
+	if assignment.GetExpiresAt() == nil {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "RoleId").WithError(errors.New("role assignment is permanent"))
+	}
+	if !expiresAt.After(assignment.GetExpiresAt().AsTime()) {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "ExpiresAt").WithError(errors.New("expires_at must be later than the current expiry"))
+	}
+	previous := assignment.GetExpiresAt().AsTime()
+	assignment.ExpiresAt = timestamppb.New(expiresAt)
+
+	err = u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := u.userHandler.UpdateUser(ctx, user); err != nil {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{userEvent(model_event.EventUserUpdated, userID, user)}, nil
+	})
+	if err != nil {
+		u.logger.Error("failed to extend role assignment", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	// An assignment expired but not swept yet is active again
+	u.rbacAPI.Verification.InvalidateUserPermissions(ctx, targetTenantID, accountID)
+	u.auditRoleAssignment(ctx, model_event.ActionRoleAssignmentExtended, "role assignment extended", userID, model_event.ActorTypeUser, user, assignment, map[string]any{
+		"previous_expires_at": previous.Format(time.RFC3339),
+		"expires_at":          expiresAt.Format(time.RFC3339),
+	})
+	return nil
+}
+
+// RunRoleAssignmentSweeps removes the expired role assignments every interval until quit is closed
+func (u *UserAPI) RunRoleAssignmentSweeps(interval time.Duration, quit <-chan struct{}) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	// Sweeps run in the background, not on behalf of a request
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = u.SweepExpiredRoleAssignments(ctx)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// SweepExpiredRoleAssignments removes the role assignments of every tenant expired by now, writes an audit log
+// per removed assignment and returns how many were removed
+func (u *UserAPI) SweepExpiredRoleAssignments(ctx context.Context) (int, error) {
+	now := time.Now()
+	users, err := u.userHandler.GetUsersWithExpiredRoles(ctx, now)
+	if err != nil {
+		u.logger.Error("failed to read the expired role assignments", "error", err)
+		return 0, err
+	}
+
+	removed := 0
+	for _, user := range users {
+		var active, expired []*authv1.UserRole
+		for _, userRole := range user.GetRoles() {
+			if model_auth.IsRoleAssignmentActive(userRole, now) {
+				active = append(active, userRole)
+			} else {
+				expired = append(expired, userRole)
+			}
+		}
+		if len(expired) == 0 {
+			continue
+		}
+		user.Roles = active
+		err := u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+			if err := u.userHandler.UpdateUser(ctx, user); err != nil {
+				return nil, err
+			}
+			return []*eventv1.DomainEvent{userEvent(model_event.EventUserUpdated, roleAssignmentActor, user)}, nil
+		})
+		if err != nil {
+			// Retried on the next sweep, the expired assignments grant nothing meanwhile
+			u.logger.Error("failed to remove expired role assignments", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+			continue
+		}
+		u.rbacAPI.Verification.InvalidateUserPermissions(ctx, user.GetTenantId(), user.GetId())
+		for _, userRole := range expired {
+			u.auditRoleAssignment(ctx, model_event.ActionRoleAssignmentExpired, "expired role assignment removed", roleAssignmentActor, model_event.ActorTypeSystem, user, userRole, map[string]any{
+				"expires_at": userRole.GetExpiresAt().AsTime().Format(time.RFC3339),
+			})
+		}
+		removed += len(expired)
+		expiredRoleAssignments.Add(float64(len(expired)))
+	}
+	if removed > 0 {
+		u.logger.Info("removed expired role assignments", "count", removed)
+	}
+	return removed, nil
+}
+
+// auditRoleAssignment writes a change of the role assignment of user to the audit log
+func (u *UserAPI) auditRoleAssignment(ctx context.Context, action, message, actorID, actorType string, user *authv1.User, userRole *authv1.UserRole, details map[string]any) {
+	if u.auditLogs == nil {
+		return
+	}
+	details["user_id"] = user.GetId()
+	details["role_id"] = userRole.GetRoleId()
+	metadata, err := structpb.NewStruct(details)
+	if err != nil {
+		u.logger.Warn("Failed to build audit log metadata", "error", err)
+	}
+	auditLog := &eventv1.AuditLog{
+		Id:         uuid.New().String(),
+		Category:   model_event.CategoryRoleMgmt,
+		Action:     action,
+		Severity:   model_event.SeverityInfo,
+		ActorId:    actorID,
+		ActorType:  actorType,
+		TargetId:   user.GetId(),
+		TargetType: model_event.TargetTypeUser,
+		Result:     model_event.ResultSuccess,
+		Message:    message,
+		Metadata:   metadata,
+	}
+	if err := u.auditLogs.CreateAuditLog(ctx, user.GetTenantId(), auditLog); err != nil {
+		u.logger.Error("Failed to write role assignment audit log", "error", err, "tenantID", user.GetTenantId(), "userID", user.GetId())
+	}
+}
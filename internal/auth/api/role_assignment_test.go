@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/rbac"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	audit_collection "erp.localhost/internal/infra/event/audit_log/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const roleAssignmentTenantID = "tenant-role-assignments"
+
+type roleAssignmentTest struct {
+	userAPI     *UserAPI
+	userHandler *handler.UserHandler
+	auditLogs   *audit_collection.AuditLogsCollection
+	documents   *memory.Documents
+	adminID     string
+	roleID      string
+}
+
+func newRoleAssignmentTest(t *testing.T) *roleAssignmentTest {
+	t.Helper()
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	userHandler, err := handler.NewUserHandler(log)
+	require.NoError(t, err)
+	roleHandler, err := handler.NewRoleHandler(log)
+	require.NoError(t, err)
+	permissionHandler, err := handler.NewPermissionHandler(log)
+	require.NoError(t, err)
+	permissionSetHandler, err := handler.NewPermissionSetHandler(log)
+	require.NoError(t, err)
+	tenantHandler, err := handler.NewTenantHandler(log)
+	require.NoError(t, err)
+	apiKeyHandler, err := handler.NewAPIKeyHandler(log)
+	require.NoError(t, err)
+	auditLogsHandler, err := collection.NewBaseCollectionHandler[eventv1.AuditLog](model_mongo.AuthDB, model_mongo.AuditLogsCollection, log)
+	require.NoError(t, err)
+	vm := rbac.NewVerificationManager(userHandler, roleHandler, permissionHandler, permissionSetHandler, tenantHandler, apiKeyHandler, log)
+	auditLogs := audit_collection.NewAuditLogsCollection(auditLogsHandler, log)
+
+	ctx := context.Background()
+	createRole := func(name string, permissionIDs []string) string {
+		id, err := roleHandler.CreateRole(ctx, &authv1.Role{
+			TenantId:    roleAssignmentTenantID,
+			Name:        name,
+			Permissions: permissionIDs,
+			Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
+			CreatedBy:   "System",
+			CreatedAt:   timestamppb.Now(),
+		})
+		require.NoError(t, err)
+		return id
+	}
+	test := &roleAssignmentTest{
+		userAPI: &UserAPI{
+			logger:      log,
+			userHandler: userHandler,
+			rbacAPI:     NewRBACAPI(roleHandler, permissionHandler, permissionSetHandler, vm, log),
+			auditLogs:   auditLogs,
+		},
+		userHandler: userHandler,
+		auditLogs:   auditLogs,
+		documents:   documents,
+		roleID:      createRole("operator", []string{"orders:read"}),
+	}
+	adminRoleID := createRole(model_auth.RoleTenantAdmin, []string{"*"})
+	test.adminID = test.createUser(t, "admin", &authv1.UserRole{RoleId: adminRoleID})
+	return test
+}
+
+// createUser creates a user of the test tenant holding roles
+func (r *roleAssignmentTest) createUser(t *testing.T, username string, roles ...*authv1.UserRole) string {
+	t.Helper()
+	for _, role := range roles {
+		role.TenantId = roleAssignmentTenantID
+		role.AssignedBy = "System"
+		role.AssignedAt = timestamppb.Now()
+	}
+	id, err := r.userHandler.CreateUser(context.Background(), &authv1.User{
+		TenantId:     roleAssignmentTenantID,
+		Username:     username,
+		Email:        username + "@example.com",
+		PasswordHash: "hash",
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		Roles:        roles,
+		CreatedBy:    "System",
+		CreatedAt:    timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	return id
+}
+
+// roles returns the role assignments of userID by role id
+func (r *roleAssignmentTest) roles(t *testing.T, userID string) map[string]*authv1.UserRole {
+	t.Helper()
+	user, err := r.userHandler.GetUserByID(context.Background(), roleAssignmentTenantID, userID)
+	require.NoError(t, err)
+	roles := map[string]*authv1.UserRole{}
+	for _, userRole := range user.GetRoles() {
+		roles[userRole.GetRoleId()] = userRole
+	}
+	return roles
+}
+
+// auditActions returns the actions of the audit logs of the test tenant
+func (r *roleAssignmentTest) auditActions(t *testing.T) []string {
+	t.Helper()
+	logs, err := r.auditLogs.GetAuditLogsByFilter(context.Background(), roleAssignmentTenantID, map[string]any{})
+	require.NoError(t, err)
+	actions := make([]string, 0, len(logs))
+	for _, log := range logs {
+		actions = append(actions, log.GetAction())
+	}
+	return actions
+}
+
+// failUserWrites fails the writes of the user documents of username
+func (r *roleAssignmentTest) failUserWrites(username string, err error) {
+	r.documents.OnWrite(func(collectionName string, doc bson.M) error {
+		if collectionName == string(model_mongo.UsersCollection) && doc["username"] == username {
+			return err
+		}
+		return nil
+	})
+}
+
+func TestUserAPI_ExtendRoleAssignment(t *testing.T) {
+	ctx := context.Background()
+	test := newRoleAssignmentTest(t)
+	permanentRoleID := test.roleID + "-permanent"
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	userID := test.createUser(t, "operator",
+		&authv1.UserRole{RoleId: test.roleID, ExpiresAt: timestamppb.New(expiresAt)},
+		&authv1.UserRole{RoleId: permanentRoleID},
+	)
+	later := expiresAt.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		userID  string
+		roleID  string
+		expires time.Time
+		wantErr *infra_error.AppError
+	}{
+		{name: "missing role", userID: test.adminID, expires: later, wantErr: infra_error.Validation(infra_error.ValidationInvalidValue)},
+		{name: "expiry in the past", userID: test.adminID, roleID: test.roleID, expires: time.Now().Add(-time.Minute), wantErr: infra_error.Validation(infra_error.ValidationInvalidValue)},
+		{name: "without permission", userID: userID, roleID: test.roleID, expires: later, wantErr: infra_error.Auth(infra_error.AuthPermissionDenied)},
+		{name: "role not assigned", userID: test.adminID, roleID: "unassigned", expires: later, wantErr: infra_error.Validation(infra_error.ValidationInvalidValue)},
+		{name: "permanent assignment", userID: test.adminID, roleID: permanentRoleID, expires: later, wantErr: infra_error.Validation(infra_error.ValidationInvalidValue)},
+		{name: "earlier expiry", userID: test.adminID, roleID: test.roleID, expires: expiresAt.Add(-time.Minute), wantErr: infra_error.Validation(infra_error.ValidationInvalidValue)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := test.userAPI.ExtendRoleAssignment(ctx, roleAssignmentTenantID, tt.userID, roleAssignmentTenantID, userID, tt.roleID, tt.expires)
+			assert.True(t, tt.wantErr.Is(err), "got %v", err)
+			assert.True(t, expiresAt.Equal(test.roles(t, userID)[test.roleID].GetExpiresAt().AsTime()))
+		})
+	}
+	assert.Empty(t, test.auditActions(t))
+
+	// A failed update keeps the expiry and is not audited
+	errWrite := errors.New("write failed")
+	test.failUserWrites("operator", errWrite)
+	err := test.userAPI.ExtendRoleAssignment(ctx, roleAssignmentTenantID, test.adminID, roleAssignmentTenantID, userID, test.roleID, later)
+	require.ErrorIs(t, err, errWrite)
+	test.documents.OnWrite(nil)
+	assert.True(t, expiresAt.Equal(test.roles(t, userID)[test.roleID].GetExpiresAt().AsTime()))
+	assert.Empty(t, test.auditActions(t))
+
+	require.NoError(t, test.userAPI.ExtendRoleAssignment(ctx, roleAssignmentTenantID, test.adminID, roleAssignmentTenantID, userID, test.roleID, later))
+	assert.True(t, later.Equal(test.roles(t, userID)[test.roleID].GetExpiresAt().AsTime()))
+	assert.Equal(t, []string{model_event.ActionRoleAssignmentExtended}, test.auditActions(t))
+}
+
+func TestUserAPI_SweepExpiredRoleAssignments(t *testing.T) {
+	ctx := context.Background()
+	test := newRoleAssignmentTest(t)
+	expired := func() *authv1.UserRole {
+		return &authv1.UserRole{RoleId: test.roleID, ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute))}
+	}
+	activeRoleID := test.roleID + "-active"
+	firstID := test.createUser(t, "first", expired(), &authv1.UserRole{RoleId: activeRoleID, ExpiresAt: timestamppb.New(time.Now().Add(time.Hour))})
+	secondID := test.createUser(t, "second", expired())
+
+	// A failed removal is retried on the next sweep and does not stop the sweep of the other users
+	errWrite := errors.New("write failed")
+	test.failUserWrites("second", errWrite)
+	removed, err := test.userAPI.SweepExpiredRoleAssignments(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, []string{activeRoleID}, roleIDs(test.roles(t, firstID)))
+	assert.Equal(t, []string{test.roleID}, roleIDs(test.roles(t, secondID)))
+	assert.Equal(t, []string{model_event.ActionRoleAssignmentExpired}, test.auditActions(t))
+
+	test.documents.OnWrite(nil)
+	removed, err = test.userAPI.SweepExpiredRoleAssignments(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Empty(t, test.roles(t, secondID))
+	assert.Len(t, test.auditActions(t), 2)
+
+	// Nothing is left to remove
+	removed, err = test.userAPI.SweepExpiredRoleAssignments(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
+
+func roleIDs(roles map[string]*authv1.UserRole) []string {
+	ids := make([]string, 0, len(roles))
+	for id := range roles {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/auth/saml"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// samlProvider is the provider of SAML external identities, a tenant has a single IdP
+	samlProvider = "saml"
+	// samlConfigKey is the key of the SAML settings in the auth module config of a tenant
+	samlConfigKey = "saml"
+	// samlRoleMappingAssignedBy marks roles granted by the tenant role mappings, they are synced on every SAML login
+	samlRoleMappingAssignedBy = "saml:role_mapping"
+)
+
+type SAMLConfig struct {
+	// BaseURL is the public URL of the ERP, the SP entity id and ACS URL of each tenant are built from it
+	BaseURL string
+	// Allowed clock difference to the IdPs
+	ClockSkew time.Duration
+}
+
+// LoadSAMLConfig loads the service provider configuration from SAML_SP_BASE_URL and SAML_CLOCK_SKEW
+func LoadSAMLConfig() *SAMLConfig {
+	return &SAMLConfig{
+		BaseURL:   strings.TrimSuffix(getEnv("SAML_SP_BASE_URL", "https://erp.localhost"), "/"),
+		ClockSkew: parseDuration(getEnv("SAML_CLOCK_SKEW", "1m"), time.Minute),
+	}
+}
+
+// serviceProvider returns the service provider of the tenant, every tenant is a separate audience
+func (c *SAMLConfig) serviceProvider(tenantID string) *saml.ServiceProvider {
+	entityID := c.BaseURL + "/saml/" + tenantID
+	return &saml.ServiceProvider{
+		EntityID:  entityID,
+		ACSURL:    entityID + "/acs",
+		ClockSkew: c.ClockSkew,
+	}
+}
+
+// GetSAMLConfig returns the SAML settings of the target tenant and the values to register at the IdP
+func (a *AuthAPI) GetSAMLConfig(ctx context.Context, tenantID, userID, targetTenantID string) (*authv1.SAMLConfigResponse, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		a.logger.Error("failed to get saml config", "error", err)
+		return nil, err
+	}
+	if err := a.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permissions.TenantRead, targetTenantID); err != nil {
+		return nil, err
+	}
+
+	settings, version, err := a.samlSettings(targetTenantID)
+	if err != nil {
+		a.logger.Error("failed to get saml config", "tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+	return a.samlConfigResponse(targetTenantID, settings, version), nil
+}
+
+// SetSAMLConfig validates and stores the SAML settings of the target tenant
+func (a *AuthAPI) SetSAMLConfig(ctx context.Context, tenantID, userID, targetTenantID string, settings *authv1.SAMLSettings) (*authv1.SAMLConfigResponse, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" || settings == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id, settings"))
+		a.logger.Error("failed to set saml config", "error", err)
+		return nil, err
+	}
+	if err := a.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permissions.TenantUpdate, targetTenantID); err != nil {
+		return nil, err
+	}
+	// Disabled settings may be incomplete, they are validated once SAML is enabled
+	if settings.GetEnabled() {
+		if _, err := samlIdentityProvider(settings); err != nil {
+			a.logger.Error("invalid saml settings", "tenant_id", targetTenantID, "error", err)
+			return nil, err
+		}
+	}
+	version, err := a.storeAuthConfig(targetTenantID, userID, samlConfigKey, settings)
+	if err != nil {
+		a.logger.Error("failed to set saml config", "tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("saml config updated", "tenant_id", targetTenantID, "enabled", settings.GetEnabled(), "updated_by", userID)
+	return a.samlConfigResponse(targetTenantID, settings, version), nil
+}
+
+// LoginWithSAML verifies a SAML response posted by the tenant IdP and issues tokens for the user of its assertion.
+// Depending on the tenant settings, unknown subjects are linked to the user with the same email or provisioned.
+func (a *AuthAPI) LoginWithSAML(ctx context.Context, tenantID, samlResponse, mfaCode string) (*NewTokenResponse, error) {
+	if tenantID == "" || samlResponse == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, saml_response"))
+		a.logger.Error("failed to login with saml", "error", err)
+		return nil, err
+	}
+
+	settings, _, err := a.samlSettings(tenantID)
+	if err != nil {
+		a.logger.Error("failed to get saml config", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if !settings.GetEnabled() {
+		return nil, infra_error.Business(infra_error.BusinessFeatureDisabled).WithError(errors.New("saml is not enabled for the tenant"))
+	}
+	idp, err := samlIdentityProvider(settings)
+	if err != nil {
+		a.logger.Error("invalid saml settings", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	assertion, err := a.samlConfig.serviceProvider(tenantID).ParseResponse(samlResponse, idp)
+	if err != nil {
+		a.logger.Warn("saml response rejected", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	// Bearer assertions are single use, a captured response can't be posted again
+	if err := a.samlAssertionHandler.Consume(ctx, &authv1_cache.SAMLAssertion{
+		AssertionId: assertion.ID,
+		TenantId:    tenantID,
+		Issuer:      assertion.Issuer,
+		ConsumedAt:  timestamppb.Now(),
+		ExpiresAt:   timestamppb.New(assertion.ExpiresAt),
+	}); err != nil {
+		a.logger.Warn("saml assertion rejected", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+
+	user, err := a.federatedUser(ctx, tenantID, samlIdentity(assertion, settings), samlPolicy(settings))
+	if err != nil {
+		a.logger.Warn("saml login rejected", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := a.syncSAMLRoles(ctx, user, assertion, settings); err != nil {
+		return nil, err
+	}
+	return a.completeFederatedLogin(ctx, user, mfaCode)
+}
+
+// syncSAMLRoles grants the roles mapped from the assertion and removes mapped roles the user no longer has at the IdP.
+// Roles assigned in the ERP are never changed.
+func (a *AuthAPI) syncSAMLRoles(ctx context.Context, user *authv1.User, assertion *saml.Assertion, settings *authv1.SAMLSettings) error {
+	mapped := mappedSAMLRoles(assertion, settings)
+	changed := false
+	roles := make([]*authv1.UserRole, 0, len(user.GetRoles())+len(mapped))
+	for _, role := range user.GetRoles() {
+		if role.GetAssignedBy() == samlRoleMappingAssignedBy && !slices.Contains(mapped, role.GetRoleId()) {
+			changed = true
+			continue
+		}
+		roles = append(roles, role)
+	}
+	now := timestamppb.Now()
+	for _, roleID := range mapped {
+		if slices.ContainsFunc(roles, func(role *authv1.UserRole) bool { return role.GetRoleId() == roleID }) {
+			continue
+		}
+		changed = true
+		roles = append(roles, &authv1.UserRole{
+			RoleId:     roleID,
+			TenantId:   user.GetTenantId(),
+			AssignedAt: now,
+			AssignedBy: samlRoleMappingAssignedBy,
+		})
+	}
+	if !changed {
+		return nil
+	}
+
+	user.Roles = roles
+	if err := a.userAPI.userHandler.UpdateUser(ctx, user); err != nil {
+		a.logger.Error("failed to sync saml roles", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+	a.logger.Info("saml roles synced", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "roles", mapped)
+	return nil
+}
+
+// samlSettings loads the SAML settings of the tenant from the config service, empty settings when none are stored
+func (a *AuthAPI) samlSettings(tenantID string) (*authv1.SAMLSettings, int32, error) {
+	settings := &authv1.SAMLSettings{}
+	version, err := a.loadAuthConfig(tenantID, samlConfigKey, settings)
+	if err != nil {
+		return nil, 0, err
+	}
+	return settings, version, nil
+}
+
+func (a *AuthAPI) samlConfigResponse(tenantID string, settings *authv1.SAMLSettings, version int32) *authv1.SAMLConfigResponse {
+	sp := a.samlConfig.serviceProvider(tenantID)
+	return &authv1.SAMLConfigResponse{
+		Settings:   settings,
+		Version:    version,
+		SpEntityId: sp.EntityID,
+		AcsUrl:     sp.ACSURL,
+	}
+}
+
+// samlIdentityProvider returns the trusted IdP of the settings, read from the metadata XML when it is set
+func samlIdentityProvider(settings *authv1.SAMLSettings) (*saml.IdentityProvider, error) {
+	if metadata := settings.GetIdpMetadataXml(); metadata != "" {
+		return saml.ParseIdPMetadata([]byte(metadata))
+	}
+	idp := &saml.IdentityProvider{
+		EntityID: settings.GetIdpEntityId(),
+		SSOURL:   settings.GetIdpSsoUrl(),
+	}
+	for _, encoded := range settings.GetIdpCertificates() {
+		certificate, err := saml.ParseCertificate(encoded)
+		if err != nil {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "idp_certificates").WithError(err)
+		}
+		idp.Certificates = append(idp.Certificates, certificate)
+	}
+	if err := idp.Validate(); err != nil {
+		return nil, err
+	}
+	return idp, nil
+}
+
+// samlIdentity reads the user of an assertion, the email falls back to an email NameID
+func samlIdentity(assertion *saml.Assertion, settings *authv1.SAMLSettings) *federatedIdentity {
+	attributes := settings.GetAttributes()
+	email := ""
+	if name := attributes.GetEmail(); name != "" {
+		email = assertion.Attribute(name)
+	} else if assertion.NameIDFormat == saml.NameIDFormatEmail {
+		email = assertion.NameID
+	}
+	return &federatedIdentity{
+		Provider: samlProvider,
+		Issuer:   assertion.Issuer,
+		Subject:  assertion.NameID,
+		Email:    strings.ToLower(strings.TrimSpace(email)),
+		// The tenant trusts its IdP for the email, linking by email is opt in through link_by_email
+		EmailVerified: email != "",
+		DisplayName:   samlAttribute(assertion, attributes.GetDisplayName()),
+		FirstName:     samlAttribute(assertion, attributes.GetFirstName()),
+		LastName:      samlAttribute(assertion, attributes.GetLastName()),
+		CreatedBy:     samlCreatedByPrefix + assertion.Issuer,
+	}
+}
+
+func samlPolicy(settings *authv1.SAMLSettings) *federationPolicy {
+	return &federationPolicy{
+		AutoProvision:  settings.GetAutoProvision(),
+		DefaultRoleIDs: settings.GetDefaultRoleIds(),
+		LinkByEmail:    settings.GetLinkByEmail(),
+		AllowedDomains: settings.GetAllowedDomains(),
+	}
+}
+
+// mappedSAMLRoles returns the role ids granted by the role mappings matching the roles attribute of the assertion
+func mappedSAMLRoles(assertion *saml.Assertion, settings *authv1.SAMLSettings) []string {
+	roleIDs := []string{}
+	name := settings.GetAttributes().GetRoles()
+	if name == "" {
+		return roleIDs
+	}
+	values := assertion.Attributes[name]
+	for _, mapping := range settings.GetRoleMappings() {
+		if !slices.Contains(values, mapping.GetValue()) {
+			continue
+		}
+		for _, roleID := range mapping.GetRoleIds() {
+			if !slices.Contains(roleIDs, roleID) {
+				roleIDs = append(roleIDs, roleID)
+			}
+		}
+	}
+	return roleIDs
+}
+
+func samlAttribute(assertion *saml.Assertion, name string) string {
+	if name == "" {
+		return ""
+	}
+	return assertion.Attribute(name)
+}
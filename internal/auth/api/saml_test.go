@@ -0,0 +1,112 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/saml"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSAMLConfig(t *testing.T) {
+	t.Setenv("SAML_SP_BASE_URL", "https://erp.example.com/")
+	t.Setenv("SAML_CLOCK_SKEW", "30s")
+
+	config := LoadSAMLConfig()
+	assert.Equal(t, 30*time.Second, config.ClockSkew)
+
+	sp := config.serviceProvider("tenant-1")
+	assert.Equal(t, "https://erp.example.com/saml/tenant-1", sp.EntityID)
+	assert.Equal(t, "https://erp.example.com/saml/tenant-1/acs", sp.ACSURL)
+}
+
+func TestAuthConfigValue(t *testing.T) {
+	settings := &authv1.SAMLSettings{
+		Enabled:      true,
+		IdpEntityId:  "https://idp.example.com",
+		Attributes:   &authv1.SAMLAttributeMapping{Email: "mail", Roles: "groups"},
+		RoleMappings: []*authv1.SAMLRoleMapping{{Value: "admins", RoleIds: []string{"role-admin"}}},
+	}
+
+	value, err := authConfigValue(settings)
+	require.NoError(t, err)
+	fields := value.GetStructValue().GetFields()
+	assert.Equal(t, "https://idp.example.com", fields["idp_entity_id"].GetStringValue())
+	assert.Equal(t, "groups", fields["attributes"].GetStructValue().GetFields()["roles"].GetStringValue())
+}
+
+func TestSAMLIdentity(t *testing.T) {
+	assertion := &saml.Assertion{
+		Issuer:       "https://idp.example.com",
+		NameID:       "Jane@Example.com",
+		NameIDFormat: saml.NameIDFormatEmail,
+		Attributes: map[string][]string{
+			"mail":      {"jane.doe@example.com"},
+			"givenName": {"Jane"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		mapping   *authv1.SAMLAttributeMapping
+		wantEmail string
+		wantFirst string
+	}{
+		{
+			name:      "email from the name id",
+			wantEmail: "jane@example.com",
+		},
+		{
+			name:      "email from an attribute",
+			mapping:   &authv1.SAMLAttributeMapping{Email: "mail", FirstName: "givenName"},
+			wantEmail: "jane.doe@example.com",
+			wantFirst: "Jane",
+		},
+		{
+			name:    "missing email attribute",
+			mapping: &authv1.SAMLAttributeMapping{Email: "email"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity := samlIdentity(assertion, &authv1.SAMLSettings{Attributes: tc.mapping})
+			assert.Equal(t, samlProvider, identity.Provider)
+			assert.Equal(t, "Jane@Example.com", identity.Subject)
+			assert.Equal(t, tc.wantEmail, identity.Email)
+			assert.Equal(t, tc.wantEmail != "", identity.EmailVerified)
+			assert.Equal(t, tc.wantFirst, identity.FirstName)
+			assert.Equal(t, "saml:https://idp.example.com", identity.CreatedBy)
+		})
+	}
+}
+
+func TestMappedSAMLRoles(t *testing.T) {
+	assertion := &saml.Assertion{Attributes: map[string][]string{"groups": {"admins", "sales"}}}
+	mappings := []*authv1.SAMLRoleMapping{
+		{Value: "admins", RoleIds: []string{"role-admin", "role-user"}},
+		{Value: "sales", RoleIds: []string{"role-user", "role-sales"}},
+		{Value: "support", RoleIds: []string{"role-support"}},
+	}
+
+	roles := mappedSAMLRoles(assertion, &authv1.SAMLSettings{
+		Attributes:   &authv1.SAMLAttributeMapping{Roles: "groups"},
+		RoleMappings: mappings,
+	})
+	assert.Equal(t, []string{"role-admin", "role-user", "role-sales"}, roles)
+
+	// Without a roles attribute nothing is mapped, so synced roles are removed
+	assert.Empty(t, mappedSAMLRoles(assertion, &authv1.SAMLSettings{RoleMappings: mappings}))
+}
+
+func TestSAMLIdentityProvider(t *testing.T) {
+	_, err := samlIdentityProvider(&authv1.SAMLSettings{IdpEntityId: "https://idp.example.com"})
+	require.Error(t, err)
+
+	_, err = samlIdentityProvider(&authv1.SAMLSettings{IdpEntityId: "https://idp.example.com", IdpCertificates: []string{"not a certificate"}})
+	require.Error(t, err)
+
+	_, err = samlIdentityProvider(&authv1.SAMLSettings{IdpMetadataXml: "<EntityDescriptor/>"})
+	require.Error(t, err)
+}
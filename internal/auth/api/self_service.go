@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/auth/hash"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	"erp.localhost/internal/infra/metrics"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/notification"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// accountDeletionActor is the actor of the deletions made by the account deletion sweeps
+const accountDeletionActor = "system"
+
+var deletedAccounts = metrics.NewCounter("accounts_deleted_total",
+	"Total number of accounts deleted by the sweeps at the end of their deletion grace period.")
+
+// AccountDeletionConfig holds the settings of the self-service account deletions, loaded with infra_config.Load
+type AccountDeletionConfig struct {
+	// Time between the request of a user to delete their account and its deletion, logging in meanwhile cancels it
+	GracePeriod time.Duration `yaml:"grace_period" env:"ACCOUNT_DELETION_GRACE_PERIOD" default:"720h"`
+	// Interval between two sweeps of the accounts due for deletion
+	SweepInterval time.Duration `yaml:"sweep_interval" env:"ACCOUNT_DELETION_SWEEP_INTERVAL" default:"1h"`
+}
+
+var defaultAccountDeletionConfig = AccountDeletionConfig{
+	GracePeriod:   30 * 24 * time.Hour,
+	SweepInterval: time.Hour,
+}
+
+// sessionStore verifies the access tokens of the self-service calls and ends the sessions of the deleted accounts
+type sessionStore interface {
+	VerifyAccessToken(ctx context.Context, token string) (*authv1.AccessTokenClaims, error)
+	RevokeAllTokens(ctx context.Context, tenantID, userID, revokedBy string) error
+}
+
+// SetAccountDeletionConfig applies the settings of the self-service account deletions
+func (u *UserAPI) SetAccountDeletionConfig(config *AccountDeletionConfig) {
+	u.accountDeletionConfig = *config
+}
+
+// UpdateMyProfile replaces the profile of the calling user
+func (u *UserAPI) UpdateMyProfile(ctx context.Context, tenantID, userID string, profile *authv1.UserProfile) (*authv1.User, error) {
+	if err := u.authenticateSelf(ctx, tenantID, userID); err != nil {
+		u.logger.Warn("failed to update own profile", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	if profile == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "profile")
+	}
+	user, err := u.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to update own profile", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	user.Profile = profile
+	if err := u.updateSelf(ctx, user); err != nil {
+		u.logger.Error("failed to update own profile", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdateMyPreferences replaces the preferences of the calling user
+func (u *UserAPI) UpdateMyPreferences(ctx context.Context, tenantID, userID string, preferences *authv1.UserPreferences) (*authv1.UserPreferences, error) {
+	if err := u.authenticateSelf(ctx, tenantID, userID); err != nil {
+		u.logger.Warn("failed to update own preferences", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	if preferences == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "preferences")
+	}
+	user, err := u.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to update own preferences", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	user.Preferences = preferences
+	if err := u.updateSelf(ctx, user); err != nil {
+		u.logger.Error("failed to update own preferences", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	return user.GetPreferences(), nil
+}
+
+// ChangeMyEmail moves the calling user to newEmail after verifying their password. The new address is unverified
+// until the emailed verification link is opened, it reports whether the link was sent
+func (u *UserAPI) ChangeMyEmail(ctx context.Context, tenantID, userID, newEmail, currentPassword string) (bool, error) {
+	if err := u.authenticateSelf(ctx, tenantID, userID); err != nil {
+		u.logger.Warn("failed to change own email", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, err
+	}
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+	if newEmail == "" || currentPassword == "" {
+		return false, infra_error.Validation(infra_error.ValidationRequiredFields, "new_email", "current_password")
+	}
+	if !validator_auth.IsValidEmail(newEmail) {
+		return false, infra_error.Validation(infra_error.ValidationInvalidValue, "new_email")
+	}
+	user, err := u.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to change own email", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, err
+	}
+	if err := u.verifyCurrentPassword(ctx, user, currentPassword); err != nil {
+		return false, err
+	}
+	if newEmail == user.GetEmail() {
+		return false, infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("email is unchanged"))
+	}
+	existing, err := u.userHandler.FindUserByEmail(ctx, tenantID, newEmail)
+	if err != nil {
+		u.logger.Error("failed to change own email", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, err
+	}
+	if existing != nil {
+		return false, infra_error.Conflict(infra_error.ConflictDuplicateEmail)
+	}
+
+	user.Email = newEmail
+	user.EmailVerified = false
+	if err := u.updateSelf(ctx, user); err != nil {
+		u.logger.Error("failed to change own email", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, err
+	}
+	// The change stands when the link cannot be sent, the user can ask for it again
+	if err := u.SendEmailVerification(ctx, tenantID, userID, userID); err != nil {
+		u.logger.Warn("failed to send verification of the new email", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ChangeMyPassword changes the password of the calling user after verifying the current one
+func (u *UserAPI) ChangeMyPassword(ctx context.Context, tenantID, userID, currentPassword, newPassword string) error {
+	if err := u.authenticateSelf(ctx, tenantID, userID); err != nil {
+		u.logger.Warn("failed to change own password", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	return u.ChangePassword(ctx, tenantID, userID, currentPassword, newPassword)
+}
+
+// DeleteMyAccount schedules the deletion of the calling user at the end of the grace period and ends their
+// sessions. Logging in before the deletion cancels it, asking again keeps the first schedule
+func (u *UserAPI) DeleteMyAccount(ctx context.Context, tenantID, userID, currentPassword string) (time.Time, error) {
+	if err := u.authenticateSelf(ctx, tenantID, userID); err != nil {
+		u.logger.Warn("failed to delete own account", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return time.Time{}, err
+	}
+	if currentPassword == "" {
+		return time.Time{}, infra_error.Validation(infra_error.ValidationRequiredFields, "current_password")
+	}
+	user, err := u.getUser(ctx, tenantID, userID, filterTypeID)
+	if err != nil {
+		u.logger.Error("failed to delete own account", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return time.Time{}, err
+	}
+	if err := u.verifyCurrentPassword(ctx, user, currentPassword); err != nil {
+		return time.Time{}, err
+	}
+	if scheduled := user.GetDeletionScheduledAt(); scheduled != nil {
+		return scheduled.AsTime(), nil
+	}
+
+	scheduledAt := time.Now().Add(u.accountDeletionConfig.GracePeriod).UTC()
+	user.DeletionScheduledAt = timestamppb.New(scheduledAt)
+	if err := u.updateSelf(ctx, user); err != nil {
+		u.logger.Error("failed to delete own account", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return time.Time{}, err
+	}
+	if err := u.sessions.RevokeAllTokens(ctx, tenantID, userID, userID); err != nil {
+		// The sessions expire on their own, the deletion stays scheduled
+		u.logger.Warn("failed to revoke the sessions of the account scheduled for deletion", "tenant_id", tenantID, "user_id", userID, "error", err)
+	}
+	u.notifier.notify(user, notification.EventAccountDeletionScheduled, map[string]any{
+		"DeletionDate": scheduledAt.Format(time.DateOnly),
+	})
+	u.logger.Info("account deletion scheduled", "tenant_id", tenantID, "user_id", userID, "deletion_scheduled_at", scheduledAt)
+	return scheduledAt, nil
+}
+
+// RunAccountDeletionSweeps deletes the accounts at the end of their deletion grace period every interval until
+// quit is closed
+func (u *UserAPI) RunAccountDeletionSweeps(interval time.Duration, quit <-chan struct{}) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	// Sweeps run in the background, not on behalf of a request
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = u.SweepAccountDeletions(ctx)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// SweepAccountDeletions deletes the accounts of every tenant whose deletion is due by now and returns how many
+// were deleted
+func (u *UserAPI) SweepAccountDeletions(ctx context.Context) (int, error) {
+	users, err := u.userHandler.GetUsersDueForDeletion(ctx, time.Now())
+	if err != nil {
+		u.logger.Error("failed to read the accounts due for deletion", "error", err)
+		return 0, err
+	}
+	deleted := 0
+	for _, user := range users {
+		err := u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+			if err := u.userHandler.DeleteUser(ctx, user.GetTenantId(), user.GetId()); err != nil {
+				return nil, err
+			}
+			return []*eventv1.DomainEvent{userEvent(model_event.EventUserDeleted, accountDeletionActor, user)}, nil
+		})
+		if err != nil {
+			// Retried on the next sweep
+			u.logger.Error("failed to delete account", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+			continue
+		}
+		u.rbacAPI.Verification.InvalidateUserPermissions(ctx, user.GetTenantId(), user.GetId())
+		deleted++
+		deletedAccounts.Inc()
+	}
+	if deleted > 0 {
+		u.logger.Info("deleted accounts at the end of their grace period", "count", deleted)
+	}
+	return deleted, nil
+}
+
+// authenticateSelf verifies that the bearer token of the call was issued to userID of tenantID. The self-service
+// calls skip the RBAC checks, impersonation tokens are refused so that support cannot act as the account owner
+func (u *UserAPI) authenticateSelf(ctx context.Context, tenantID, userID string) error {
+	if tenantID == "" || userID == "" {
+		return infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+	}
+	if u.sessions == nil {
+		return infra_error.Auth(infra_error.AuthUnauthenticated)
+	}
+	token := interceptor.BearerTokenFromContext(ctx)
+	if token == "" {
+		return infra_error.Auth(infra_error.AuthTokenMissing)
+	}
+	claims, err := u.sessions.VerifyAccessToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if claims.GetTenantId() != tenantID || claims.GetUserId() != userID {
+		u.logger.Warn("self-service call for another user", "tenant_id", tenantID, "user_id", userID, "token_tenant_id", claims.GetTenantId(), "token_user_id", claims.GetUserId())
+		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+	if claims.GetAct() != nil {
+		u.logger.Warn("self-service call with an impersonation token", "tenant_id", tenantID, "user_id", userID, "actor_id", claims.GetAct().GetUserId())
+		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+	return nil
+}
+
+// verifyCurrentPassword returns an invalid credentials error when password is not the password of user
+func (u *UserAPI) verifyCurrentPassword(ctx context.Context, user *authv1.User, password string) error {
+	valid, err := hash.Verify(ctx, password, user.GetPasswordHash())
+	if err != nil {
+		u.logger.Warn("password verification rejected", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+	if !valid {
+		return infra_error.Auth(infra_error.AuthInvalidCredentials)
+	}
+	return nil
+}
+
+// updateSelf stores a change the user made to their own account
+func (u *UserAPI) updateSelf(ctx context.Context, user *authv1.User) error {
+	return u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := u.userHandler.UpdateUser(ctx, user); err != nil {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{userEvent(model_event.EventUserUpdated, user.GetId(), user)}, nil
+	})
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+type fakeSessionStore struct {
+	claims map[string]*authv1.AccessTokenClaims
+}
+
+func (f *fakeSessionStore) VerifyAccessToken(_ context.Context, token string) (*authv1.AccessTokenClaims, error) {
+	claims, ok := f.claims[token]
+	if !ok {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	return claims, nil
+}
+
+func (f *fakeSessionStore) RevokeAllTokens(_ context.Context, _, _, _ string) error {
+	return nil
+}
+
+func bearerContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(interceptor.AuthorizationHeader, "Bearer "+token))
+}
+
+func TestUserAPI_AuthenticateSelf(t *testing.T) {
+	u := &UserAPI{
+		logger: logger.NewBaseLogger(shared.ModuleAuth),
+		sessions: &fakeSessionStore{claims: map[string]*authv1.AccessTokenClaims{
+			"user-token":          {TenantId: "tenant-1", UserId: "user-1"},
+			"impersonation-token": {TenantId: "tenant-1", UserId: "user-1", Act: &authv1.TokenActor{TenantId: "tenant-1", UserId: "support-1"}},
+		}},
+	}
+
+	testCases := []struct {
+		name    string
+		ctx     context.Context
+		userID  string
+		wantErr *infra_error.AppError
+	}{
+		{name: "own token", ctx: bearerContext("user-token"), userID: "user-1"},
+		{name: "no token", ctx: context.Background(), userID: "user-1", wantErr: infra_error.Auth(infra_error.AuthTokenMissing)},
+		{name: "invalid token", ctx: bearerContext("forged"), userID: "user-1", wantErr: infra_error.Auth(infra_error.AuthTokenInvalid)},
+		{name: "token of another user", ctx: bearerContext("user-token"), userID: "user-2", wantErr: infra_error.Auth(infra_error.AuthPermissionDenied)},
+		{name: "impersonation token", ctx: bearerContext("impersonation-token"), userID: "user-1", wantErr: infra_error.Auth(infra_error.AuthPermissionDenied)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := u.authenticateSelf(tc.ctx, "tenant-1", tc.userID)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, tc.wantErr.Is(err))
+		})
+	}
+}
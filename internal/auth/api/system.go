@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/status"
+)
+
+// SystemAPI reports the operational status of the service to system admins and manages the system admin account
+type SystemAPI struct {
+	logger        logger.Logger
+	rbacAPI       *RBACAPI
+	userAPI       *UserAPI
+	aggregator    *status.Aggregator
+	tenantHandler *handler.TenantHandler
+	reportHandler systemReportStore
+	reportConfig  *SystemReportConfig
+	reportJob     *reportJob
+}
+
+// NewSystemAPI connects its own Mongo and Redis clients so probes do not compete with request traffic
+func NewSystemAPI(rbacAPI *RBACAPI, userAPI *UserAPI, logger logger.Logger) (*SystemAPI, error) {
+	aggregator := status.NewAggregator(logger)
+	if err := addStoreProbes(aggregator, logger); err != nil {
+		return nil, err
+	}
+
+	tenantHandler, err := handler.NewTenantHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new tenant handler", "error", err)
+		return nil, err
+	}
+	reportHandler, err := handler.NewSystemReportHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new system report handler", "error", err)
+		return nil, err
+	}
+
+	job := &reportJob{}
+	aggregator.AddJob("system_reports", job.state)
+
+	return &SystemAPI{
+		logger:        logger,
+		rbacAPI:       rbacAPI,
+		userAPI:       userAPI,
+		aggregator:    aggregator,
+		tenantHandler: tenantHandler,
+		reportHandler: reportHandler,
+		reportConfig:  LoadSystemReportConfig(),
+		reportJob:     job,
+	}, nil
+}
+
+// addStoreProbes registers the probes and cache stats of Mongo and Redis, none in the in-memory mode where the
+// process has no servers to probe
+func addStoreProbes(aggregator *status.Aggregator, logger logger.Logger) error {
+	if memory.Enabled() {
+		logger.Info("in-memory mode, mongo and redis are not probed")
+		return nil
+	}
+	mongoManager, err := mongo.NewMongoDBManagerWithAppName(model_mongo.AuthDB, "status-probes", logger)
+	if err != nil {
+		logger.Error("failed to create mongo manager for status probes", "error", err)
+		return err
+	}
+	redisConfig := redis.LoadRedisConfig()
+	redisConfig.ClientName = "status-probes"
+	redisHandler, err := redis.NewBaseRedisHandlerWithConfig(redisConfig, "", logger)
+	if err != nil {
+		logger.Error("failed to create redis handler for status probes", "error", err)
+		return err
+	}
+	aggregator.AddProbe("mongo", mongoManager.Ping)
+	aggregator.AddProbe("redis", redisHandler.Ping)
+	aggregator.AddCache("redis", redisHandler.CacheStats)
+	aggregator.AddCache("redis_fallback", redis.FallbackCacheStats)
+	return nil
+}
+
+// Aggregator is used by the entry point to register the queues, jobs and modules it runs
+func (s *SystemAPI) Aggregator() *status.Aggregator {
+	return s.aggregator
+}
+
+func (s *SystemAPI) GetSystemStatus(ctx context.Context, tenantID, userID string) (*authv1.SystemStatus, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		s.logger.Error("failed to get system status", "error", err)
+		return nil, err
+	}
+	if err := s.rbacAPI.Verification.IsSystemAdmin(ctx, tenantID, userID); err != nil {
+		s.logger.Warn("system status denied", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	return s.aggregator.Status(), nil
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db"
+)
+
+// RotateSystemAdmin replaces the password of the system admin with a generated one and ends their sessions, e.g.
+// after the password given out by the init job was exposed. The caller must be a system admin, the tenant of the
+// system admins is the system tenant. The generated password is returned once and must be changed on the next login
+func (s *SystemAPI) RotateSystemAdmin(ctx context.Context, tenantID, userID string) (string, string, error) {
+	if err := s.authorizeSystemAdmin(ctx, tenantID, userID, "rotate system admin"); err != nil {
+		return "", "", err
+	}
+	admin, err := s.userAPI.getUser(ctx, tenantID, db.SystemAdminEmail, filterTypeEmail)
+	if err != nil {
+		s.logger.Error("failed to rotate system admin", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return "", "", err
+	}
+	password, err := s.userAPI.rotatePassword(ctx, admin, userID)
+	if err != nil {
+		return "", "", err
+	}
+	s.logger.Warn("system admin password rotated", "tenant_id", tenantID, "admin_id", admin.GetId(), "rotated_by", userID)
+	return admin.GetId(), password, nil
+}
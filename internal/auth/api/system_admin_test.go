@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type recordingSessionStore struct {
+	fakeSessionStore
+	revoked []string
+}
+
+func (r *recordingSessionStore) RevokeAllTokens(_ context.Context, tenantID, userID, _ string) error {
+	r.revoked = append(r.revoked, tenantID+":"+userID)
+	return nil
+}
+
+func TestUserAPI_RotatePassword(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	userHandler, err := handler.NewUserHandler(log)
+	require.NoError(t, err)
+	tenantHandler, err := handler.NewTenantHandler(log)
+	require.NoError(t, err)
+	sessions := &recordingSessionStore{}
+	u := &UserAPI{logger: log, userHandler: userHandler, tenantHandler: tenantHandler, sessions: sessions}
+
+	ctx := context.Background()
+	oldHash, err := hash.Hash("Old-Admin-Password-1")
+	require.NoError(t, err)
+	adminID, err := userHandler.CreateUser(ctx, &authv1.User{
+		TenantId:     "system",
+		Username:     "system_admin",
+		Email:        "system@system.com",
+		PasswordHash: oldHash,
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    "System",
+		CreatedAt:    timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	admin, err := userHandler.GetUserByID(ctx, "system", adminID)
+	require.NoError(t, err)
+
+	password, err := u.rotatePassword(ctx, admin, "operator-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"system:" + adminID}, sessions.revoked)
+
+	rotated, err := userHandler.GetUserByID(ctx, "system", adminID)
+	require.NoError(t, err)
+	assert.True(t, rotated.GetMustChangePassword())
+	assert.Equal(t, []string{oldHash}, rotated.GetPasswordHistory())
+	valid, err := hash.Verify(ctx, password, rotated.GetPasswordHash())
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// Changing the generated password clears the flag
+	require.NoError(t, u.ChangePassword(ctx, "system", adminID, password, "New-Admin-Password-2"))
+	changed, err := userHandler.GetUserByID(ctx, "system", adminID)
+	require.NoError(t, err)
+	assert.False(t, changed.GetMustChangePassword())
+}
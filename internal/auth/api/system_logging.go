@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+)
+
+// GetLogLevels returns the log levels of the process
+func (s *SystemAPI) GetLogLevels(ctx context.Context, tenantID, userID string) (*authv1.LogLevels, error) {
+	if err := s.authorizeSystemAdmin(ctx, tenantID, userID, "get log levels"); err != nil {
+		return nil, err
+	}
+	return currentLogLevels(), nil
+}
+
+// SetLogLevel overrides the log level of a module, or of the modules without an override when module is empty,
+// reset removes the override of module. Levels are not persisted and apply until the process restarts
+func (s *SystemAPI) SetLogLevel(ctx context.Context, tenantID, userID, module, level string, reset bool) (*authv1.LogLevels, error) {
+	if err := s.authorizeSystemAdmin(ctx, tenantID, userID, "set log level"); err != nil {
+		return nil, err
+	}
+
+	var target shared.Module
+	if module != "" {
+		parsed, ok := shared.ParseModule(module)
+		if !ok {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "module").WithError(fmt.Errorf("unknown module %q", module))
+		}
+		target = parsed
+	}
+
+	if reset {
+		if target == "" {
+			return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "module")
+		}
+		logger.ResetLevel(target)
+	} else {
+		parsed, err := logger.ParseLevel(level)
+		if err != nil {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "level").WithError(err)
+		}
+		logger.SetLevel(target, parsed)
+	}
+	s.logger.Warn("log level changed", "tenant_id", tenantID, "user_id", userID, "module", target, "level", level, "reset", reset)
+	return currentLogLevels(), nil
+}
+
+func (s *SystemAPI) authorizeSystemAdmin(ctx context.Context, tenantID, userID, action string) error {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		s.logger.Error("failed to "+action, "error", err)
+		return err
+	}
+	if err := s.rbacAPI.Verification.IsSystemAdmin(ctx, tenantID, userID); err != nil {
+		s.logger.Warn(action+" denied", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func currentLogLevels() *authv1.LogLevels {
+	global, modules := logger.Levels()
+	levels := &authv1.LogLevels{
+		Level:   global.String(),
+		Modules: make(map[string]string, len(modules)),
+	}
+	for module, level := range modules {
+		levels.Modules[string(module)] = level.String()
+	}
+	return levels
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	aggregation_auth "erp.localhost/internal/auth/aggregation"
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Window in which a successful login makes a user monthly active
+	monthlyActiveWindow = 30 * 24 * time.Hour
+)
+
+// SystemReportConfig holds the system report schedule
+type SystemReportConfig struct {
+	Interval time.Duration
+	// Number of reports kept in the system_reports collection
+	Retention int
+}
+
+// LoadSystemReportConfig loads the system report schedule from environment variables
+func LoadSystemReportConfig() *SystemReportConfig {
+	retention, err := strconv.Atoi(getEnv("SYSTEM_REPORT_RETENTION", "30"))
+	if err != nil || retention <= 0 {
+		retention = 30
+	}
+	return &SystemReportConfig{
+		Interval:  parseDuration(getEnv("SYSTEM_REPORT_INTERVAL", "24h"), 24*time.Hour),
+		Retention: retention,
+	}
+}
+
+// systemReportStore keeps the generated reports and computes their figures, see handler.SystemReportHandler
+type systemReportStore interface {
+	SaveReport(ctx context.Context, report *authv1.SystemReport) (string, error)
+	LatestReport(ctx context.Context) (*authv1.SystemReport, error)
+	PruneReports(ctx context.Context, keep int) error
+	UserCounts(activeSince time.Time) (map[string]*aggregation_auth.TenantUserCounts, error)
+	StorageBytes() (map[string]int64, error)
+}
+
+// reportJob tracks the scheduled report generation for system status
+type reportJob struct {
+	mu      sync.Mutex
+	running bool
+	lastRun time.Time
+	lastErr error
+}
+
+func (j *reportJob) state() status.JobState {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return status.JobState{Running: j.running, LastRun: j.lastRun, LastError: j.lastErr}
+}
+
+func (j *reportJob) setRunning(running bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.running = running
+}
+
+func (j *reportJob) finished(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.lastRun = time.Now()
+	j.lastErr = err
+}
+
+// GetSystemReport returns the latest scheduled report, or generates one when refresh is set or none exists yet
+func (s *SystemAPI) GetSystemReport(ctx context.Context, tenantID, userID string, refresh bool) (*authv1.SystemReport, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		s.logger.Error("failed to get system report", "error", err)
+		return nil, err
+	}
+	if err := s.rbacAPI.Verification.IsSystemAdmin(ctx, tenantID, userID); err != nil {
+		s.logger.Warn("system report denied", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	return s.systemReport(ctx, refresh)
+}
+
+// ExportSystemReport renders the latest report as CSV, one line per tenant
+func (s *SystemAPI) ExportSystemReport(ctx context.Context, tenantID, userID string) (*authv1.ExportSystemReportResponse, error) {
+	report, err := s.GetSystemReport(ctx, tenantID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := SystemReportCSV(report)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return &authv1.ExportSystemReportResponse{
+		FileName:    fmt.Sprintf("system_report_%s.csv", report.GetGeneratedAt().AsTime().UTC().Format("20060102T150405Z")),
+		ContentType: "text/csv",
+		Data:        data,
+	}, nil
+}
+
+// GenerateSystemReport computes a report across all tenants, stores it and prunes reports past the retention
+func (s *SystemAPI) GenerateSystemReport(ctx context.Context) (*authv1.SystemReport, error) {
+	now := time.Now().UTC()
+	tenants, err := s.tenantHandler.GetTenants(ctx)
+	if err != nil {
+		s.logger.Error("failed to generate system report", "error", err)
+		return nil, err
+	}
+	users, err := s.reportHandler.UserCounts(now.Add(-monthlyActiveWindow))
+	if err != nil {
+		s.logger.Error("failed to generate system report", "error", err)
+		return nil, err
+	}
+	storage, err := s.reportHandler.StorageBytes()
+	if err != nil {
+		s.logger.Error("failed to generate system report", "error", err)
+		return nil, err
+	}
+
+	report := BuildSystemReport(tenants, users, storage, now)
+	if report.Id, err = s.reportHandler.SaveReport(ctx, report); err != nil {
+		s.logger.Error("failed to save system report", "error", err)
+		return nil, err
+	}
+	if err := s.reportHandler.PruneReports(ctx, s.reportConfig.Retention); err != nil {
+		s.logger.Warn("failed to prune system reports", "error", err)
+	}
+	s.logger.Info("system report generated", "tenants", report.GetTotalTenants(), "users", report.GetTotalUsers())
+	return report, nil
+}
+
+// RunReports generates a report every configured interval until quit is closed
+func (s *SystemAPI) RunReports(quit <-chan struct{}) {
+	s.reportJob.setRunning(true)
+	defer s.reportJob.setRunning(false)
+
+	// Reports are generated in the background, not on behalf of a request
+	ctx := context.Background()
+	ticker := time.NewTicker(s.reportConfig.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, err := s.GenerateSystemReport(ctx)
+			s.reportJob.finished(err)
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (s *SystemAPI) systemReport(ctx context.Context, refresh bool) (*authv1.SystemReport, error) {
+	if !refresh {
+		report, err := s.reportHandler.LatestReport(ctx)
+		if err != nil {
+			s.logger.Error("failed to get latest system report", "error", err)
+			return nil, err
+		}
+		if report != nil {
+			return report, nil
+		}
+	}
+	report, err := s.GenerateSystemReport(ctx)
+	s.reportJob.finished(err)
+	return report, err
+}
+
+// BuildSystemReport combines the tenants with their aggregated users and storage, tenants are sorted by storage
+func BuildSystemReport(tenants []*authv1.Tenant, users map[string]*aggregation_auth.TenantUserCounts, storage map[string]int64, now time.Time) *authv1.SystemReport {
+	report := &authv1.SystemReport{
+		GeneratedAt:  timestamppb.New(now),
+		TotalTenants: int64(len(tenants)),
+	}
+
+	byStatus := map[authv1.TenantStatus]int64{}
+	for _, tenant := range tenants {
+		byStatus[tenant.GetStatus()]++
+		tenantReport := &authv1.TenantReport{
+			TenantId:     tenant.GetId(),
+			Name:         tenant.GetName(),
+			Status:       tenant.GetStatus(),
+			StorageBytes: storage[tenant.GetId()],
+		}
+		if counts, ok := users[tenant.GetId()]; ok {
+			tenantReport.Users = counts.Users
+			tenantReport.MonthlyActiveUsers = counts.MonthlyActiveUsers
+		}
+		report.TotalUsers += tenantReport.GetUsers()
+		report.MonthlyActiveUsers += tenantReport.GetMonthlyActiveUsers()
+		report.StorageBytes += tenantReport.GetStorageBytes()
+		report.Tenants = append(report.Tenants, tenantReport)
+	}
+	sort.SliceStable(report.Tenants, func(i, j int) bool {
+		return report.Tenants[i].GetStorageBytes() > report.Tenants[j].GetStorageBytes()
+	})
+
+	for tenantStatus, count := range byStatus {
+		report.TenantsByStatus = append(report.TenantsByStatus, &authv1.TenantStatusCount{Status: tenantStatus, Count: count})
+	}
+	sort.Slice(report.TenantsByStatus, func(i, j int) bool {
+		return report.TenantsByStatus[i].GetStatus() < report.TenantsByStatus[j].GetStatus()
+	})
+	return report
+}
+
+// SystemReportCSV renders the tenants of a report as CSV with a header row
+func SystemReportCSV(report *authv1.SystemReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"tenant_id", "name", "status", "users", "monthly_active_users", "storage_bytes"}); err != nil {
+		return nil, err
+	}
+	for _, tenant := range report.GetTenants() {
+		record := []string{
+			tenant.GetTenantId(),
+			tenant.GetName(),
+			tenant.GetStatus().String(),
+			strconv.FormatInt(tenant.GetUsers(), 10),
+			strconv.FormatInt(tenant.GetMonthlyActiveUsers(), 10),
+			strconv.FormatInt(tenant.GetStorageBytes(), 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write system report csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	aggregation_auth "erp.localhost/internal/auth/aggregation"
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSystemReport(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tenants := []*authv1.Tenant{
+		{Id: "t1", Name: "acme", Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE},
+		{Id: "t2", Name: "globex", Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE},
+		{Id: "t3", Name: "initech", Status: authv1.TenantStatus_TENANT_STATUS_TRIAL},
+	}
+	users := map[string]*aggregation_auth.TenantUserCounts{
+		"t1": {TenantID: "t1", Users: 10, MonthlyActiveUsers: 4},
+		"t2": {TenantID: "t2", Users: 3, MonthlyActiveUsers: 3},
+	}
+	storage := map[string]int64{"t1": 100, "t2": 500, "t3": 50}
+
+	report := BuildSystemReport(tenants, users, storage, now)
+	assert.Equal(t, now, report.GetGeneratedAt().AsTime())
+	assert.Equal(t, int64(3), report.GetTotalTenants())
+	assert.Equal(t, int64(13), report.GetTotalUsers())
+	assert.Equal(t, int64(7), report.GetMonthlyActiveUsers())
+	assert.Equal(t, int64(650), report.GetStorageBytes())
+
+	require.Len(t, report.GetTenantsByStatus(), 2)
+	assert.Equal(t, authv1.TenantStatus_TENANT_STATUS_ACTIVE, report.GetTenantsByStatus()[0].GetStatus())
+	assert.Equal(t, int64(2), report.GetTenantsByStatus()[0].GetCount())
+	assert.Equal(t, authv1.TenantStatus_TENANT_STATUS_TRIAL, report.GetTenantsByStatus()[1].GetStatus())
+	assert.Equal(t, int64(1), report.GetTenantsByStatus()[1].GetCount())
+
+	// Sorted by storage, tenants without users report zero
+	require.Len(t, report.GetTenants(), 3)
+	assert.Equal(t, "t2", report.GetTenants()[0].GetTenantId())
+	assert.Equal(t, "t1", report.GetTenants()[1].GetTenantId())
+	assert.Equal(t, "t3", report.GetTenants()[2].GetTenantId())
+	assert.Zero(t, report.GetTenants()[2].GetUsers())
+}
+
+func TestSystemReportCSV(t *testing.T) {
+	report := &authv1.SystemReport{
+		Tenants: []*authv1.TenantReport{
+			{TenantId: "t1", Name: "acme, inc", Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE, Users: 10, MonthlyActiveUsers: 4, StorageBytes: 100},
+		},
+	}
+	data, err := SystemReportCSV(report)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "tenant_id,name,status,users,monthly_active_users,storage_bytes", lines[0])
+	assert.Equal(t, `t1,"acme, inc",TENANT_STATUS_ACTIVE,10,4,100`, lines[1])
+}
+
+// fakeSystemReportStore keeps the reports in memory and fails the calls set in errs, by method name. The figures are
+// the ones of tenantID
+type fakeSystemReportStore struct {
+	tenantID string
+	reports  []*authv1.SystemReport
+	pruned   []int
+	errs     map[string]error
+}
+
+func (f *fakeSystemReportStore) SaveReport(_ context.Context, report *authv1.SystemReport) (string, error) {
+	if err := f.errs["SaveReport"]; err != nil {
+		return "", err
+	}
+	f.reports = append(f.reports, report)
+	return fmt.Sprintf("report-%d", len(f.reports)), nil
+}
+
+func (f *fakeSystemReportStore) LatestReport(_ context.Context) (*authv1.SystemReport, error) {
+	if err := f.errs["LatestReport"]; err != nil || len(f.reports) == 0 {
+		return nil, err
+	}
+	return f.reports[len(f.reports)-1], nil
+}
+
+func (f *fakeSystemReportStore) PruneReports(_ context.Context, keep int) error {
+	f.pruned = append(f.pruned, keep)
+	return f.errs["PruneReports"]
+}
+
+func (f *fakeSystemReportStore) UserCounts(_ time.Time) (map[string]*aggregation_auth.TenantUserCounts, error) {
+	if err := f.errs["UserCounts"]; err != nil {
+		return nil, err
+	}
+	return map[string]*aggregation_auth.TenantUserCounts{f.tenantID: {TenantID: f.tenantID, Users: 2, MonthlyActiveUsers: 1}}, nil
+}
+
+func (f *fakeSystemReportStore) StorageBytes() (map[string]int64, error) {
+	if err := f.errs["StorageBytes"]; err != nil {
+		return nil, err
+	}
+	return map[string]int64{f.tenantID: 100}, nil
+}
+
+func newReportTestSystemAPI(t *testing.T, store *fakeSystemReportStore) *SystemAPI {
+	t.Helper()
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	tenantHandler, err := handler.NewTenantHandler(log)
+	require.NoError(t, err)
+	store.tenantID, err = tenantHandler.CreateTenant(context.Background(), &authv1.Tenant{
+		Name:      "acme",
+		Status:    authv1.TenantStatus_TENANT_STATUS_ACTIVE,
+		Contact:   &authv1.ContactInfo{Email: "admin@acme.com"},
+		CreatedBy: "System",
+	})
+	require.NoError(t, err)
+	return &SystemAPI{
+		logger:        log,
+		tenantHandler: tenantHandler,
+		reportHandler: store,
+		reportConfig:  &SystemReportConfig{Interval: time.Hour, Retention: 3},
+		reportJob:     &reportJob{},
+	}
+}
+
+func TestSystemAPI_GenerateSystemReport(t *testing.T) {
+	errStore := errors.New("store failed")
+	testCases := []struct {
+		name string
+		errs map[string]error
+		// Whether a report is saved and returned
+		wantReport bool
+		wantErr    error
+	}{
+		{name: "generated", wantReport: true},
+		{name: "user counts fail", errs: map[string]error{"UserCounts": errStore}, wantErr: errStore},
+		{name: "storage fails", errs: map[string]error{"StorageBytes": errStore}, wantErr: errStore},
+		{name: "save fails", errs: map[string]error{"SaveReport": errStore}, wantErr: errStore},
+		// The pruning is retried on the next report, the saved one is still returned
+		{name: "prune fails", errs: map[string]error{"PruneReports": errStore}, wantReport: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fakeSystemReportStore{errs: tc.errs}
+			s := newReportTestSystemAPI(t, store)
+
+			report, err := s.GenerateSystemReport(context.Background())
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, report)
+				assert.Empty(t, store.reports)
+				assert.Empty(t, store.pruned)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, store.reports, 1)
+			assert.Equal(t, "report-1", report.GetId())
+			assert.Equal(t, int64(1), report.GetTotalTenants())
+			assert.Equal(t, int64(2), report.GetTotalUsers())
+			assert.Equal(t, int64(100), report.GetStorageBytes())
+			assert.Equal(t, []int{3}, store.pruned)
+		})
+	}
+}
+
+func TestSystemAPI_SystemReport(t *testing.T) {
+	ctx := context.Background()
+	errStore := errors.New("store failed")
+
+	// A failed generation is reported as the last error of the job
+	store := &fakeSystemReportStore{errs: map[string]error{"StorageBytes": errStore}}
+	s := newReportTestSystemAPI(t, store)
+	_, err := s.GetSystemReport(ctx, "", "user-1", false)
+	assert.True(t, infra_error.Validation(infra_error.ValidationInvalidValue).Is(err))
+	_, err = s.systemReport(ctx, false)
+	require.ErrorIs(t, err, errStore)
+	assert.ErrorIs(t, s.reportJob.state().LastError, errStore)
+
+	// The latest report is returned without generating another one
+	store.errs = nil
+	generated, err := s.systemReport(ctx, false)
+	require.NoError(t, err)
+	assert.Nil(t, s.reportJob.state().LastError)
+	latest, err := s.systemReport(ctx, false)
+	require.NoError(t, err)
+	assert.Same(t, generated, latest)
+	assert.Len(t, store.reports, 1)
+
+	refreshed, err := s.systemReport(ctx, true)
+	require.NoError(t, err)
+	assert.NotSame(t, generated, refreshed)
+	assert.Len(t, store.reports, 2)
+
+	// The latest report is not replaced by a new one when it cannot be read
+	store.errs = map[string]error{"LatestReport": errStore}
+	_, err = s.systemReport(ctx, false)
+	require.ErrorIs(t, err, errStore)
+	assert.Len(t, store.reports, 2)
+}
@@ -4,16 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"erp.localhost/internal/auth/handler"
-	"erp.localhost/internal/auth/hash"
-	"erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
+	audit_collection "erp.localhost/internal/infra/event/audit_log/collection"
+	"erp.localhost/internal/infra/event/outbox"
 	"erp.localhost/internal/infra/logging/logger"
 	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	validator_auth "erp.localhost/internal/infra/model/auth/validator"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/model/fieldmask"
+	"erp.localhost/internal/infra/usage"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type TenantDefaults struct {
@@ -28,6 +38,18 @@ type TenantAPI struct {
 	authAPI       *AuthAPI
 	rbacAPI       *RBACAPI
 	userAPI       *UserAPI
+	seeder        *TenantSeeder
+	usageHandler  *handler.UsageHandler
+	usageTracker  *usage.Tracker
+	outbox        *outbox.Outbox
+	// Trial period and sweeps, see tenant_lifecycle.go
+	lifecycleConfig TenantLifecycleConfig
+	// Limits of the users, roles and API keys, see tenant_quota.go
+	quotas *TenantQuotas
+	// Written on exports and read by them, see tenant_export.go
+	auditLogs auditLogStore
+	// Tenants read on every call, see tenant_cache.go
+	tenantCache *TenantCache
 }
 
 func NewTenantAPI(authAPI *AuthAPI, rbacAPI *RBACAPI, userAPI *UserAPI, logger logger.Logger) (*TenantAPI, error) {
@@ -36,16 +58,43 @@ func NewTenantAPI(authAPI *AuthAPI, rbacAPI *RBACAPI, userAPI *UserAPI, logger l
 		logger.Error("failed to create new user handler", "error", err)
 		return nil, err
 	}
-	return &TenantAPI{
-		logger:        logger,
-		tenantHandler: tenantHandler,
-		authAPI:       authAPI,
-		rbacAPI:       rbacAPI,
-		userAPI:       userAPI,
-	}, nil
+	usageHandler, err := handler.NewUsageHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new usage handler", "error", err)
+		return nil, err
+	}
+	auditLogsHandler, err := collection.NewBaseCollectionHandler[eventv1.AuditLog](model_mongo.AuthDB, model_mongo.AuditLogsCollection, logger)
+	if err != nil {
+		logger.Error("failed to create audit logs collection handler", "error", err)
+		return nil, err
+	}
+	tenantAPI := &TenantAPI{
+		logger:          logger,
+		tenantHandler:   tenantHandler,
+		authAPI:         authAPI,
+		rbacAPI:         rbacAPI,
+		userAPI:         userAPI,
+		seeder:          NewTenantSeeder(rbacAPI.Permissions, rbacAPI.Roles, userAPI.userHandler, tenantHandler, logger),
+		usageHandler:    usageHandler,
+		auditLogs:       audit_collection.NewAuditLogsCollection(auditLogsHandler, logger),
+		lifecycleConfig: defaultTenantLifecycleConfig,
+		tenantCache:     NewTenantCache(tenantHandler, defaultTenantCacheConfig.TTL),
+	}
+	tenantAPI.usageTracker = usage.NewTracker(usageHandler, tenantAPI.monthlyAPICap, logger)
+	tenantAPI.quotas = NewTenantQuotas(tenantAPI.tenantCache, logger)
+	tenantAPI.quotas.SetCounter(model_auth.TenantResourceUsers, userAPI.countUsers)
+	tenantAPI.quotas.SetCounter(model_auth.TenantResourceRoles, rbacAPI.Roles.countRoles)
+	userAPI.quotas = tenantAPI.quotas
+	rbacAPI.Roles.quotas = tenantAPI.quotas
+	return tenantAPI, nil
 }
 
-func (t *TenantAPI) CreateTenant(tenantID, userID string, newTenant *authv1.Tenant) (string, error) {
+// SetOutbox records the domain events of the tenant changes in outbox
+func (t *TenantAPI) SetOutbox(outbox *outbox.Outbox) {
+	t.outbox = outbox
+}
+
+func (t *TenantAPI) CreateTenant(ctx context.Context, tenantID, userID string, newTenant *authv1.Tenant) (string, error) {
 	// Step 1: validate input
 	if tenantID == "" || userID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
@@ -58,49 +107,103 @@ func (t *TenantAPI) CreateTenant(tenantID, userID string, newTenant *authv1.Tena
 	}
 
 	// Step 2: Check RBAC permission
-	if err := t.checkPermission(tenantID, userID, model_auth.ResourceTypeTenant, model_auth.PermissionActionCreate); err != nil {
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantCreate); err != nil {
 		return "", err
 	}
+	t.startTrial(newTenant, time.Now())
 	// Step 3: Check for duplication
-	tenant, err := t.tenantHandler.GetTenantByName(newTenant.Name)
+	tenant, err := t.tenantHandler.GetTenantByName(ctx, newTenant.Name)
 	if err != nil {
 		t.logger.Error("failed to get temamt for verification", "tenant_id", tenantID, "error", err)
 		return "", err
 	}
 	if tenant != nil {
-		err := infra_error.Validation(infra_error.ConflictDuplicateEmail)
+		err := infra_error.Conflict(infra_error.ConflictDuplicateEmail)
 		t.logger.Error("failed to create new tenant", "tenantID", tenantID, "error", err.Error())
 		return "", err
 	}
 
 	adminEmail := newTenant.GetContact().GetEmail()
 
-	// Step 4: Create tenant in MongoDB
-	newTenantID, err := t.tenantHandler.CreateTenant(newTenant)
+	// Step 4: Create tenant in MongoDB and seed its defaults (permission, role, admin user) in one transaction
+	newTenantID, defaults, err := t.createTenantInTransaction(ctx, tenantID, userID, newTenant)
+	if errors.Is(err, mongo.ErrTransactionsUnsupported) {
+		t.logger.Warn("mongo deployment has no transactions, seeding the tenant with rollback on failure", "tenant_id", tenantID)
+		newTenantID, defaults, err = t.createTenantWithRollback(ctx, tenantID, userID, newTenant)
+	}
 	if err != nil {
-		t.logger.Error("failed to create tenant", "error", err)
 		return "", err
 	}
-	t.logger.Info("tenant created in database", "tenant_id", tenantID)
+	t.logger.Info("tenant defaults seeded", "tenant_id", newTenantID, "admin_email", adminEmail, "permission_id", defaults.PermissionID, "role_id", defaults.RoleId, "user_id", defaults.UserId)
 
-	// Step 5: Seed defaults (permission, role, admin user)
-	defaults, err := t.seedDefaults(tenantID, adminEmail, userID)
-	if err != nil {
-		t.logger.Error("failed to seed tenant defaults", "tenant_id", tenantID, "error", err)
+	return newTenantID, nil
+}
 
-		// Rollback: Delete tenant
-		if deleteErr := t.tenantHandler.DeleteTenant(tenantID); deleteErr != nil {
-			t.logger.Error("failed to rollback tenant creation", "tenant_id", tenantID, "error", deleteErr)
+// createTenantInTransaction creates the tenant and its defaults atomically, nothing is left behind when seeding fails
+func (t *TenantAPI) createTenantInTransaction(ctx context.Context, tenantID, userID string, newTenant *authv1.Tenant) (string, *TenantDefaults, error) {
+	var newTenantID string
+	var defaults *TenantDefaults
+	err := t.tenantHandler.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		newTenantID, err = t.tenantHandler.CreateTenant(ctx, newTenant)
+		if err != nil {
+			t.logger.Error("failed to create tenant", "error", err)
+			return err
+		}
+		defaults, err = t.seeder.Seed(ctx, tenantID, userID, newTenantID)
+		if err != nil {
+			t.logger.Error("failed to seed tenant defaults, aborting transaction", "tenant_id", newTenantID, "error", err)
+			return err
+		}
+		return t.outbox.Add(ctx, createdTenantEvent(userID, newTenantID, newTenant))
+	})
+	if err != nil {
+		var seedErr *SeedError
+		if errors.As(err, &seedErr) {
+			seedErr.Rollback = abortedReport(newTenantID, defaults)
 		}
+		return "", nil, err
+	}
+	t.logger.Info("tenant created in database", "tenant_id", newTenantID)
+	return newTenantID, defaults, nil
+}
 
-		return "", err
+// createTenantWithRollback creates the tenant and its defaults, deleting them again when seeding fails
+func (t *TenantAPI) createTenantWithRollback(ctx context.Context, tenantID, userID string, newTenant *authv1.Tenant) (string, *TenantDefaults, error) {
+	newTenantID, err := t.tenantHandler.CreateTenant(ctx, newTenant)
+	if err != nil {
+		t.logger.Error("failed to create tenant", "error", err)
+		return "", nil, err
 	}
-	t.logger.Info("tenant defaults seeded", "tenant_id", tenantID, "permission_id", defaults.PermissionID, "role_id", defaults.RoleId, "user_id", defaults.UserId)
+	t.logger.Info("tenant created in database", "tenant_id", newTenantID)
 
-	return newTenantID, nil
+	defaults, err := t.seeder.Seed(ctx, tenantID, userID, newTenantID)
+	if err != nil {
+		t.logger.Error("failed to seed tenant defaults", "tenant_id", newTenantID, "error", err)
+
+		// Rollback: Delete seeded defaults and the tenant
+		report := t.seeder.Rollback(ctx, tenantID, userID, newTenantID, defaults, true)
+		var seedErr *SeedError
+		if errors.As(err, &seedErr) {
+			seedErr.Rollback = report
+			return "", nil, seedErr
+		}
+		return "", nil, &SeedError{Err: err, Rollback: report}
+	}
+	// Without a transaction the tenant is committed already, a failed event write is only logged
+	_ = t.outbox.Add(ctx, createdTenantEvent(userID, newTenantID, newTenant))
+	return newTenantID, defaults, nil
+}
+
+// createdTenantEvent is the event of the creation of newTenant with the id newTenantID
+func createdTenantEvent(userID, newTenantID string, newTenant *authv1.Tenant) *eventv1.DomainEvent {
+	event := tenantEvent(model_event.EventTenantCreated, userID, newTenant)
+	event.TenantId = newTenantID
+	event.GetTenant().TenantId = newTenantID
+	return event
 }
 
-func (t *TenantAPI) GetTenant(tenantID, userID, targetTenantID, targetTenantName string) (*authv1.Tenant, error) {
+func (t *TenantAPI) GetTenant(ctx context.Context, tenantID, userID, targetTenantID, targetTenantName string) (*authv1.Tenant, error) {
 
 	if tenantID == "" || userID == "" || (targetTenantID == "" && targetTenantName == "") {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id, target_tenant_name"))
@@ -108,20 +211,20 @@ func (t *TenantAPI) GetTenant(tenantID, userID, targetTenantID, targetTenantName
 		return nil, err
 	}
 
-	if err := t.checkPermission(tenantID, userID, model_auth.ResourceTypeTenant, model_auth.PermissionActionRead); err != nil {
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantRead); err != nil {
 		return nil, err
 	}
 
 	if targetTenantID != "" {
 		t.logger.Debug("getting tenant by id", "tenant_id", targetTenantID)
-		return t.tenantHandler.GetTenantByID(targetTenantID)
+		return t.tenantHandler.GetTenantByID(ctx, targetTenantID)
 	} else {
 		t.logger.Debug("getting tenant by name", "name", targetTenantName)
-		return t.tenantHandler.GetTenantByName(targetTenantName)
+		return t.tenantHandler.GetTenantByName(ctx, targetTenantName)
 	}
 }
 
-func (t *TenantAPI) ListTenants(tenantID, userID, status string) ([]*authv1.Tenant, error) {
+func (t *TenantAPI) ListTenants(ctx context.Context, tenantID, userID, status string) ([]*authv1.Tenant, error) {
 	// Step 1: validate input
 	if tenantID == "" || userID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
@@ -130,21 +233,26 @@ func (t *TenantAPI) ListTenants(tenantID, userID, status string) ([]*authv1.Tena
 	}
 
 	// Step 2: Check RBAC permission
-	if err := t.checkPermission(tenantID, userID, model_auth.ResourceTypeTenant, model_auth.PermissionActionRead); err != nil {
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantRead); err != nil {
 		return nil, err
 	}
 
 	if status != "" {
 		t.logger.Debug("getting tenants by status", "status", status)
-		return t.tenantHandler.GetTenantsByStatus(status)
+		return t.tenantHandler.GetTenantsByStatus(ctx, status)
 	} else {
 		t.logger.Debug("getting all tenants")
-		return t.tenantHandler.GetTenants()
+		return t.tenantHandler.GetTenants(ctx)
 	}
 
 }
 
-func (t *TenantAPI) UpdateTenant(tenantID, userID string, tenant *authv1.Tenant) error {
+// tenantUpdateMaskFields are the fields of a tenant an update mask may name
+var tenantUpdateMaskFields = []string{"name", "slug", "domain", "subscription", "settings", "contact", "branding", "metadata", "limits"}
+
+// UpdateTenant replaces the stored tenant with tenant, or when mask names fields only changes those fields, tenant
+// then only needs its id, version and the named fields
+func (t *TenantAPI) UpdateTenant(ctx context.Context, tenantID, userID string, tenant *authv1.Tenant, mask *fieldmaskpb.FieldMask) error {
 	// Step 1: validate input
 	if tenantID == "" || userID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
@@ -152,30 +260,73 @@ func (t *TenantAPI) UpdateTenant(tenantID, userID string, tenant *authv1.Tenant)
 		return err
 	}
 
-	if err := validator_auth.ValidateTenant(tenant, false); err != nil {
+	// Partial updates are validated once merged with the stored tenant
+	if fieldmask.IsEmpty(mask) {
+		if err := validator_auth.ValidateTenant(tenant, false); err != nil {
+			t.logger.Error("failed to update tenant", "error", err)
+			return err
+		}
+	} else if err := fieldmask.CheckPaths(mask, tenantUpdateMaskFields...); err != nil {
 		t.logger.Error("failed to update tenant", "error", err)
 		return err
 	}
 
 	// Step 2: Check RBAC permission
-	if err := t.checkPermission(tenantID, userID, model_auth.ResourceTypeTenant, model_auth.PermissionActionUpdate); err != nil {
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantUpdate); err != nil {
 		return err
 	}
 
 	t.logger.Info("updating tenant", "tenant_id", tenant, "requested_by", userID, "target_tenant_id", tenant.GetId())
 
 	// Step 4: Get existing tenant
-	existingTenant, err := t.tenantHandler.GetTenantByID(tenant.GetId())
+	existingTenant, err := t.tenantHandler.GetTenantByID(ctx, tenant.GetId())
 	if err != nil || existingTenant == nil {
 		t.logger.Error("failed to get existing tenant", "tenant_id", tenant.Id, "error", err)
 		return err
 	}
+	if !fieldmask.IsEmpty(mask) {
+		tenant, err = mergeTenantUpdate(existingTenant, tenant, mask)
+		if err != nil {
+			t.logger.Error("failed to update tenant", "tenant_id", existingTenant.GetId(), "error", err)
+			return err
+		}
+	}
+	if err := checkLifecycleFields(existingTenant, tenant); err != nil {
+		t.logger.Error("failed to update tenant", "tenant_id", tenant.Id, "error", err)
+		return err
+	}
+	// Tenants cannot raise their own limits
+	if !proto.Equal(tenant.GetLimits(), existingTenant.GetLimits()) && !t.rbacAPI.Verification.IsSystemTenantUser(tenantID) {
+		err := infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields, "limits")
+		t.logger.Error("failed to update tenant", "tenant_id", tenant.Id, "error", err)
+		return err
+	}
 
 	//TODO: Do diff and validate
-	return t.tenantHandler.UpdateTenant(tenant)
+	err = t.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := t.tenantHandler.UpdateTenant(ctx, tenant); err != nil {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{tenantEvent(model_event.EventTenantUpdated, userID, tenant)}, nil
+	})
+	t.tenantCache.Invalidate(tenant.GetId())
+	return err
 }
 
-func (t *TenantAPI) DeleteTenant(tenantID, userID, targetTenantID string) error {
+// mergeTenantUpdate returns a copy of stored with the fields of update named by mask, at the version update was read at
+func mergeTenantUpdate(stored, update *authv1.Tenant, mask *fieldmaskpb.FieldMask) (*authv1.Tenant, error) {
+	merged := proto.Clone(stored).(*authv1.Tenant)
+	if err := fieldmask.Apply(merged, update, mask); err != nil {
+		return nil, err
+	}
+	merged.Version = update.GetVersion()
+	if err := validator_auth.ValidateTenant(merged, false); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func (t *TenantAPI) DeleteTenant(ctx context.Context, tenantID, userID, targetTenantID string) error {
 	// Step 1: validate input
 	if tenantID == "" || userID == "" || targetTenantID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
@@ -184,7 +335,7 @@ func (t *TenantAPI) DeleteTenant(tenantID, userID, targetTenantID string) error
 	}
 
 	// Step 2: Verify tenant exists
-	_, err := t.tenantHandler.GetTenantByID(targetTenantID)
+	_, err := t.tenantHandler.GetTenantByID(ctx, targetTenantID)
 	if err != nil {
 		t.logger.Error("tenant not found", "target_tenant_id", targetTenantID, "error", err)
 		return err
@@ -192,7 +343,7 @@ func (t *TenantAPI) DeleteTenant(tenantID, userID, targetTenantID string) error
 
 	// Step 3: Revoke all tenant users tokens
 	t.logger.Info("starting tenant deletion cascade", "tenant_id", tenantID, "requested_by", userID, "target_tenant_id", targetTenantID)
-	if _, _, err := t.authAPI.RevokeAllTenantTokens(tenantID, userID, targetTenantID); err != nil {
+	if _, _, err := t.authAPI.RevokeAllTenantTokens(ctx, tenantID, userID, targetTenantID); err != nil {
 		t.logger.Error("failed to revoke tokens for tenant", "tenant_id", tenantID, "error", err)
 		// Continue with deletion even if this fails
 	} else {
@@ -202,7 +353,7 @@ func (t *TenantAPI) DeleteTenant(tenantID, userID, targetTenantID string) error
 	// STEP 4: Delete ALL users for this tenant (bulk operation)
 	// This deletes all user documents with matching tenant_id in one operation
 	t.logger.Info("deleting all users for tenant", "target_tenant_id", targetTenantID)
-	if err := t.userAPI.DeleteTenantUsers(tenantID, userID, targetTenantID); err != nil {
+	if err := t.userAPI.DeleteTenantUsers(ctx, tenantID, userID, targetTenantID); err != nil {
 		t.logger.Error("failed to delete roles for tenant", "target_tenant_id", targetTenantID, "error", err)
 		return err
 	} else {
@@ -212,7 +363,7 @@ func (t *TenantAPI) DeleteTenant(tenantID, userID, targetTenantID string) error
 	// STEP 5: Delete ALL roles for this tenant (bulk operation)
 	// This deletes all role documents with matching tenant_id in one operation
 	t.logger.Info("deleting all roles for tenant", "target_tenant_id", targetTenantID)
-	if err := t.rbacAPI.Roles.DeleteTenantRoles(tenantID, userID, targetTenantID); err != nil {
+	if err := t.rbacAPI.Roles.DeleteTenantRoles(ctx, tenantID, userID, targetTenantID); err != nil {
 		t.logger.Error("failed to delete roles for tenant", "target_tenant_id", targetTenantID, "error", err)
 		// Continue with deletion even if this fails
 	} else {
@@ -222,7 +373,7 @@ func (t *TenantAPI) DeleteTenant(tenantID, userID, targetTenantID string) error
 	// STEP 6: Delete ALL permissions for this tenant (bulk operation)
 	// This deletes all permission documents with matching tenant_id in one operation
 	t.logger.Info("deleting all permissions for tenant", "target_tenant_id", targetTenantID)
-	if err := t.rbacAPI.Permissions.DeleteTenantPermissions(tenantID, userID, targetTenantID); err != nil {
+	if err := t.rbacAPI.Permissions.DeleteTenantPermissions(ctx, tenantID, userID, targetTenantID); err != nil {
 		t.logger.Error("failed to delete permissions for tenant", "target_tenant_id", targetTenantID, "error", err)
 		// Continue with deletion even if this fails
 	} else {
@@ -231,165 +382,41 @@ func (t *TenantAPI) DeleteTenant(tenantID, userID, targetTenantID string) error
 
 	// STEP 7 Delete the tenant itself
 	t.logger.Info("deleting tenant", "target_tenant_id", targetTenantID)
-	return t.tenantHandler.DeleteTenant(targetTenantID)
+	err = t.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := t.tenantHandler.DeleteTenant(ctx, targetTenantID); err != nil {
+			return nil, err
+		}
+		deleted := &authv1.Tenant{Id: targetTenantID}
+		return []*eventv1.DomainEvent{tenantEvent(model_event.EventTenantDeleted, userID, deleted)}, nil
+	})
+	t.tenantCache.Invalidate(targetTenantID)
+	return err
 }
 
 /* Helper functions */
 
-// checkPermission verifies if a user has the required permission
-func (t *TenantAPI) checkPermission(tenantID, userID, resource, action string) error {
-	// Create permission string using helper
-	permString, err := model_auth.CreatePermissionString(resource, action)
-	if err != nil {
-		t.logger.Error("invalid permission format", "resource", resource, "action", action, "error", err)
-		return err
-	}
-
-	permissions := []string{permString}
-	res, err := t.rbacAPI.Verification.CheckPermissions(tenantID, userID, permissions)
-	if err != nil {
+// checkPermission verifies if a user has all the required permissions, checked in one call
+func (t *TenantAPI) checkPermission(ctx context.Context, tenantID, userID string, permStrings ...string) error {
+	if err := t.rbacAPI.Verification.RequirePermissions(ctx, tenantID, userID, tenantID, permStrings...); err != nil {
 		return err
 	}
-	// Check result
-	if !res[permString] {
-		t.logger.Warn("permission denied", "user_id", userID, "tenant_id", tenantID, "permission", permString)
-		return infra_error.Auth(infra_error.AuthPermissionDenied)
-	}
 
-	t.logger.Debug("permission check passed", "user_id", userID, "permission", permString)
+	t.logger.Debug("permission check passed", "user_id", userID, "permissions", permStrings)
 	return nil
 }
 
 /* Seeding functions */
 
-// SeedDefaults creates default permission, role, and admin user for a new tenant
-func (t *TenantAPI) seedDefaults(tenantID, adminEmail, createdBy string) (*TenantDefaults, error) {
-	t.logger.Info("Seeding defaults for new tenant", "tenant_id", tenantID)
-
-	defaults := &TenantDefaults{}
-
-	// Step 1: Create "*:*" permission
-	permissionID, err := t.createWildcardPermission(tenantID, createdBy)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create wildcard permission: %w", err)
-	}
-	defaults.PermissionID = permissionID
-	t.logger.Info("Wildcard permission created", "tenant_id", tenantID, "permission_id", permissionID)
-
-	// Step 2: Create TenantAdmin role
-	roleID, err := t.createTenantAdminRole(tenantID, permissionID, createdBy)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create TenantAdmin role: %w", err)
-	}
-	defaults.RoleId = roleID
-	t.logger.Info("TenantAdmin role created", "tenant_id", tenantID, "role_id", roleID)
-
-	// Step 3: Create initial admin user in Core
-	userID, err := t.createAdminUser(tenantID, db.TenantAdminUser, db.TenantAdminPassword, roleID, createdBy)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create admin user: %w", err)
-	}
-	defaults.UserId = userID
-	t.logger.Info("Admin user created", "tenant_id", tenantID, "user_id", userID, "email", adminEmail)
-
-	t.logger.Info("Tenant defaults seeded successfully", "tenant_id", tenantID)
-	return defaults, nil
-}
-
-func (t *TenantAPI) createWildcardPermission(tenantID, createdBy string) (string, error) {
-
-	permission := &authv1.Permission{
-		TenantId:         tenantID,
-		DisplayName:      "Full Access",
-		PermissionString: db.SystemAdminPermissionID,
-		Description:      "Grants full access to all resources and actions",
-		Resource:         model_auth.ResourceTypeAll,     // "*"
-		Action:           model_auth.PermissionActionAll, // "*"
-		Status:           authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE,
-		CreatedBy:        createdBy,
-		IsDangerous:      true,
-	}
-
-	return t.rbacAPI.Permissions.CreatePermission(tenantID, createdBy, permission, tenantID)
-}
-
-func (t *TenantAPI) createTenantAdminRole(tenantID, permissionID, createdBy string) (string, error) {
-	role := &authv1.Role{
-		TenantId:    tenantID,
-		Name:        model_auth.RoleTenantAdmin,
-		Description: "Tenant administrator with full access to all tenant resources",
-		Type:        authv1.RoleType_ROLE_TYPE_SYSTEM,
-		Permissions: []string{permissionID}, // Assign "*:*" permission
-		Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
-		CreatedBy:   createdBy,
-	}
-
-	return t.rbacAPI.Roles.CreateRole(tenantID, createdBy, role, tenantID)
-}
-
-func (t *TenantAPI) createAdminUser(tenantID, username, plainPassword, roleID, createdBy string) (string, error) {
-	// Hash password
-	hashedPassword, err := hash.HashPassword(plainPassword)
-	if err != nil {
-		return "", err
-	}
-
-	user := &authv1.User{
-		TenantId:     tenantID,
-		Username:     username,
-		PasswordHash: hashedPassword,
-		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
-		CreatedBy:    createdBy,
-		Roles: []*authv1.UserRole{
-			{
-				TenantId:   tenantID,
-				RoleId:     roleID,
-				AssignedAt: timestamppb.Now(),
-				AssignedBy: createdBy,
-			},
-		},
-	}
-
-	// Validate user
-	if err := validator_auth.ValidateUser(user, true); err != nil {
-		return "", err
-	}
-
-	// Create user via collection
-	return t.userAPI.userHandler.CreateUser(user)
+// Seeder returns the seeder used for new tenants, mainly to inject failures in tests
+func (t *TenantAPI) Seeder() *TenantSeeder {
+	return t.seeder
 }
 
 // RollbackDefaults deletes all seeded defaults (used when tenant creation fails)
 func (t *TenantAPI) RollbackDefaults(ctx context.Context, tenantID string, defaults *TenantDefaults) error {
-	t.logger.Warn("Rolling back tenant defaults", "tenant_id", tenantID)
-
-	var rollbackErrors []error
-
-	// Delete admin user (local collection)
-	if defaults.UserId != "" {
-		if err := t.userAPI.userHandler.DeleteUser(tenantID, defaults.UserId); err != nil {
-			rollbackErrors = append(rollbackErrors, fmt.Errorf("failed to delete admin user: %w", err))
-		}
-	}
-
-	// Delete role (via Auth gRPC)
-	if defaults.RoleId != "" {
-		if err := t.rbacAPI.Roles.DeleteRole(tenantID, defaults.UserId, defaults.RoleId, tenantID); err != nil {
-			rollbackErrors = append(rollbackErrors, fmt.Errorf("failed to delete role via gRPC: %w", err))
-		}
+	report := t.seeder.Rollback(ctx, tenantID, defaults.UserId, tenantID, defaults, false)
+	if !report.Complete() {
+		return fmt.Errorf("rollback partially failed: %s", report)
 	}
-
-	// Delete permission (via Auth gRPC)
-	if defaults.PermissionID != "" {
-		if err := t.rbacAPI.Permissions.DeletePermission(tenantID, defaults.UserId, defaults.PermissionID, tenantID); err != nil {
-			rollbackErrors = append(rollbackErrors, fmt.Errorf("failed to delete permission via gRPC: %w", err))
-		}
-	}
-
-	if len(rollbackErrors) > 0 {
-		return fmt.Errorf("rollback partially failed: %v", rollbackErrors)
-	}
-
-	t.logger.Info("Tenant defaults rolled back successfully", "tenant_id", tenantID)
 	return nil
 }
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/infra/metrics"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var tenantCacheRequests = metrics.NewCounter("tenant_cache_requests_total",
+	"Total number of tenant lookups served by the tenant cache, by result (hit or miss).", "result")
+
+// TenantCacheConfig holds the settings of the tenant cache, loaded with infra_config.Load
+type TenantCacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"TENANT_CACHE_ENABLED" default:"true"`
+	// TTL bounds how long a tenant change missed by the invalidation is served
+	TTL time.Duration `yaml:"ttl" env:"TENANT_CACHE_TTL" default:"30s"`
+}
+
+var defaultTenantCacheConfig = TenantCacheConfig{
+	Enabled: true,
+	TTL:     30 * time.Second,
+}
+
+type cachedTenant struct {
+	tenant    *authv1.Tenant
+	fetchedAt time.Time
+}
+
+// TenantCache keeps the tenants read on every call, for their status, limits and subscription, in memory. Entries
+// expire after the TTL and are dropped when the tenant changes, through the API or, with the change stream, around
+// it. A cache with a zero TTL reads every tenant from its source
+type TenantCache struct {
+	source tenantGetter
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	tenants map[string]cachedTenant
+}
+
+func NewTenantCache(source tenantGetter, ttl time.Duration) *TenantCache {
+	return &TenantCache{
+		source:  source,
+		ttl:     ttl,
+		tenants: map[string]cachedTenant{},
+	}
+}
+
+// GetTenantByID returns a copy of the cached tenant, reading it from the source when missing or stale
+func (c *TenantCache) GetTenantByID(ctx context.Context, tenantID string) (*authv1.Tenant, error) {
+	c.mu.Lock()
+	cached, ok := c.tenants[tenantID]
+	ttl := c.ttl
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < ttl {
+		tenantCacheRequests.Inc("hit")
+		return proto.Clone(cached.tenant).(*authv1.Tenant), nil
+	}
+
+	tenantCacheRequests.Inc("miss")
+	tenant, err := c.source.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 && tenant != nil {
+		c.mu.Lock()
+		c.tenants[tenantID] = cachedTenant{tenant: proto.Clone(tenant).(*authv1.Tenant), fetchedAt: time.Now()}
+		c.mu.Unlock()
+	}
+	return tenant, nil
+}
+
+// Invalidate drops the cached tenant, the next lookup reads the stored tenant
+func (c *TenantCache) Invalidate(tenantID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.tenants, tenantID)
+}
+
+// setTTL changes how long the tenants are cached, a zero TTL disables the cache and drops the cached tenants
+func (c *TenantCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+	if ttl <= 0 {
+		c.tenants = map[string]cachedTenant{}
+	}
+}
+
+// SetTenantCacheConfig applies the settings of the tenant cache
+func (t *TenantAPI) SetTenantCacheConfig(config *TenantCacheConfig) {
+	if !config.Enabled {
+		t.tenantCache.setTTL(0)
+		return
+	}
+	t.tenantCache.setTTL(config.TTL)
+}
+
+// TenantCache returns the cache of the tenants, the change feed drops the tenants changed around the API from it
+func (t *TenantAPI) TenantCache() *TenantCache {
+	return t.tenantCache
+}
+
+// CheckTenantStatus returns the error the calls of the users of tenantID fail with, see model_auth.TenantLoginError.
+// Tenants that cannot be read are left to the called method, which reads or authorizes against them itself
+func (t *TenantAPI) CheckTenantStatus(ctx context.Context, tenantID string) error {
+	tenant, err := t.tenantCache.GetTenantByID(ctx, tenantID)
+	if err != nil || tenant == nil {
+		t.logger.Debug("tenant status unknown, leaving the call to its method", "tenant_id", tenantID, "error", err)
+		return nil
+	}
+	return model_auth.TenantLoginError(tenant, time.Now())
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// countingTenantGetter counts the tenant reads reaching the source of a cache
+type countingTenantGetter struct {
+	fakeTenantGetter
+	reads int
+}
+
+func (c *countingTenantGetter) GetTenantByID(ctx context.Context, tenantID string) (*authv1.Tenant, error) {
+	c.reads++
+	return c.fakeTenantGetter.GetTenantByID(ctx, tenantID)
+}
+
+func TestTenantCache(t *testing.T) {
+	source := &countingTenantGetter{fakeTenantGetter: fakeTenantGetter{tenants: map[string]*authv1.Tenant{
+		"tenant-1": {Id: "tenant-1", Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE},
+	}}}
+	cache := NewTenantCache(source, time.Minute)
+	ctx := context.Background()
+
+	tenant, err := cache.GetTenantByID(ctx, "tenant-1")
+	require.NoError(t, err)
+	tenant.Status = authv1.TenantStatus_TENANT_STATUS_INACTIVE
+	tenant, err = cache.GetTenantByID(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, authv1.TenantStatus_TENANT_STATUS_ACTIVE, tenant.GetStatus(), "the cached tenant is not shared with the callers")
+	assert.Equal(t, 1, source.reads)
+
+	source.tenants["tenant-1"].Status = authv1.TenantStatus_TENANT_STATUS_SUSPENDED
+	cache.Invalidate("tenant-1")
+	tenant, err = cache.GetTenantByID(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, authv1.TenantStatus_TENANT_STATUS_SUSPENDED, tenant.GetStatus())
+	assert.Equal(t, 2, source.reads)
+
+	// Missing tenants are not cached
+	_, err = cache.GetTenantByID(ctx, "tenant-2")
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+	source.tenants["tenant-2"] = &authv1.Tenant{Id: "tenant-2"}
+	_, err = cache.GetTenantByID(ctx, "tenant-2")
+	require.NoError(t, err)
+
+	cache.setTTL(0)
+	_, _ = cache.GetTenantByID(ctx, "tenant-1")
+	_, _ = cache.GetTenantByID(ctx, "tenant-1")
+	assert.Equal(t, 6, source.reads)
+}
+
+func TestTenantAPI_CheckTenantStatus(t *testing.T) {
+	source := &fakeTenantGetter{tenants: map[string]*authv1.Tenant{
+		"active":    {Id: "active", Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE},
+		"suspended": {Id: "suspended", Status: authv1.TenantStatus_TENANT_STATUS_SUSPENDED},
+		"inactive":  {Id: "inactive", Status: authv1.TenantStatus_TENANT_STATUS_INACTIVE},
+		"expired":   {Id: "expired", Status: authv1.TenantStatus_TENANT_STATUS_TRIAL, TrialEnd: timestamppb.New(time.Now().Add(-time.Hour))},
+	}}
+	tenantAPI := &TenantAPI{logger: logger.NewBaseLogger(shared.ModuleAuth), tenantCache: NewTenantCache(source, time.Minute)}
+	ctx := context.Background()
+
+	require.NoError(t, tenantAPI.CheckTenantStatus(ctx, "active"))
+	assert.True(t, infra_error.Auth(infra_error.AuthTenantSuspended).Is(tenantAPI.CheckTenantStatus(ctx, "suspended")))
+	assert.True(t, infra_error.Auth(infra_error.AuthTenantInactive).Is(tenantAPI.CheckTenantStatus(ctx, "inactive")))
+	assert.True(t, infra_error.Auth(infra_error.AuthTenantSuspended).Is(tenantAPI.CheckTenantStatus(ctx, "expired")))
+	// Unknown tenants are left to the called method
+	require.NoError(t, tenantAPI.CheckTenantStatus(ctx, "unknown"))
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"erp.localhost/internal/infra/db/mongo/codec"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// exportChunkSize is the number of documents sent per chunk of a tenant export
+const exportChunkSize = 500
+
+// auditLogStore writes and reads the audit logs of the tenants
+type auditLogStore interface {
+	auditLogWriter
+	GetAuditLogsByFilter(ctx context.Context, tenantID string, filter map[string]any) ([]*eventv1.AuditLog, error)
+}
+
+// exportSource reads the documents of one collection of a tenant. The documents are read at once, the handlers do
+// not page their reads
+type exportSource struct {
+	collection model_mongo.Collection
+	documents  func(ctx context.Context, tenantID string) ([]proto.Message, error)
+}
+
+// ExportTenantData sends the documents of targetTenantID collection by collection to send, in chunks of
+// exportChunkSize documents. The users are sent without their password hashes and secrets
+func (t *TenantAPI) ExportTenantData(ctx context.Context, tenantID, userID, targetTenantID string, format authv1.TenantExportFormat, send func(*authv1.ExportTenantDataChunk) error) error {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		t.logger.Error("failed to export tenant data", "error", err)
+		return err
+	}
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantExport); err != nil {
+		return err
+	}
+	if format == authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_UNSPECIFIED {
+		format = authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_NDJSON
+	}
+
+	t.logger.Info("exporting tenant data", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID, "format", format.String())
+	exported, err := exportTenantData(ctx, targetTenantID, t.exportSources(), format, send)
+	if err != nil {
+		t.logger.Error("failed to export tenant data", "target_tenant_id", targetTenantID, "documents_exported", exported, "error", err)
+		return err
+	}
+	t.logger.Info("tenant data exported", "target_tenant_id", targetTenantID, "documents_exported", exported)
+	t.auditExport(ctx, targetTenantID, userID, format, exported)
+	return nil
+}
+
+// exportSources lists the tenant collections in export order
+func (t *TenantAPI) exportSources() []exportSource {
+	return []exportSource{
+		{collection: model_mongo.TenantsCollection, documents: func(ctx context.Context, tenantID string) ([]proto.Message, error) {
+			tenant, err := t.tenantHandler.GetTenantByID(ctx, tenantID)
+			if err != nil {
+				return nil, err
+			}
+			return []proto.Message{tenant}, nil
+		}},
+		{collection: model_mongo.UsersCollection, documents: func(ctx context.Context, tenantID string) ([]proto.Message, error) {
+			users, err := t.userAPI.userHandler.GetUsersByTenantID(ctx, tenantID)
+			for _, user := range users {
+				RedactUser(user)
+			}
+			return asMessages(users, err)
+		}},
+		{collection: model_mongo.RolesCollection, documents: func(ctx context.Context, tenantID string) ([]proto.Message, error) {
+			return asMessages(t.rbacAPI.Roles.roleHandler.GetRolesByTenantID(ctx, tenantID))
+		}},
+		{collection: model_mongo.PermissionsCollection, documents: func(ctx context.Context, tenantID string) ([]proto.Message, error) {
+			return asMessages(t.rbacAPI.Permissions.permissionHandler.GetPermissionsByTenantID(ctx, tenantID))
+		}},
+		{collection: model_mongo.PermissionSetsCollection, documents: func(ctx context.Context, tenantID string) ([]proto.Message, error) {
+			return asMessages(t.rbacAPI.Permissions.permissionSetHandler.GetPermissionSetsByTenantID(ctx, tenantID))
+		}},
+		{collection: model_mongo.AuditLogsCollection, documents: func(ctx context.Context, tenantID string) ([]proto.Message, error) {
+			return asMessages(t.auditLogs.GetAuditLogsByFilter(ctx, tenantID, nil))
+		}},
+	}
+}
+
+// exportTenantData sends the documents of sources in chunks and returns how many documents were sent. Every
+// collection sends at least one chunk, possibly empty, so its completion is reported
+func exportTenantData(ctx context.Context, tenantID string, sources []exportSource, format authv1.TenantExportFormat, send func(*authv1.ExportTenantDataChunk) error) (int64, error) {
+	var exported int64
+	for i, source := range sources {
+		documents, err := source.documents(ctx, tenantID)
+		if err != nil {
+			return exported, err
+		}
+		for start := 0; ; start += exportChunkSize {
+			end := min(start+exportChunkSize, len(documents))
+			data, err := encodeExportDocuments(format, documents[start:end])
+			if err != nil {
+				return exported, err
+			}
+			exported += int64(end - start)
+			last := end == len(documents)
+			progress := &authv1.ExportProgress{
+				CollectionsDone:   int32(i),
+				CollectionsTotal:  int32(len(sources)),
+				DocumentsExported: exported,
+			}
+			if last {
+				progress.CollectionsDone++
+				progress.Done = i == len(sources)-1
+			}
+			err = send(&authv1.ExportTenantDataChunk{
+				Collection:    string(source.collection),
+				Format:        format,
+				Data:          data,
+				DocumentCount: int32(end - start),
+				Progress:      progress,
+			})
+			if err != nil {
+				return exported, err
+			}
+			if last {
+				break
+			}
+		}
+	}
+	return exported, nil
+}
+
+// encodeExportDocuments encodes documents as newline-delimited JSON or concatenated BSON
+func encodeExportDocuments(format authv1.TenantExportFormat, documents []proto.Message) ([]byte, error) {
+	var data []byte
+	for _, document := range documents {
+		var encoded []byte
+		var err error
+		switch format {
+		case authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_BSON:
+			encoded, err = bson.MarshalWithRegistry(codec.GetRegistry(), document)
+		default:
+			encoded, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(document)
+			encoded = append(encoded, '\n')
+		}
+		if err != nil {
+			return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+		}
+		data = append(data, encoded...)
+	}
+	return data, nil
+}
+
+// asMessages returns items as proto messages, passing err through
+func asMessages[T proto.Message](items []T, err error) ([]proto.Message, error) {
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]proto.Message, len(items))
+	for i, item := range items {
+		messages[i] = item
+	}
+	return messages, nil
+}
+
+// auditExport writes an export of tenantID to the audit log of the tenant
+func (t *TenantAPI) auditExport(ctx context.Context, tenantID, userID string, format authv1.TenantExportFormat, exported int64) {
+	metadata, err := structpb.NewStruct(map[string]any{
+		"format":             format.String(),
+		"documents_exported": exported,
+	})
+	if err != nil {
+		t.logger.Warn("Failed to build audit log metadata", "error", err)
+	}
+	auditLog := &eventv1.AuditLog{
+		Id:         uuid.New().String(),
+		Category:   model_event.CategoryDataAccess,
+		Action:     model_event.ActionGDPRDataExport,
+		Severity:   model_event.SeverityWarning,
+		ActorId:    userID,
+		ActorType:  model_event.ActorTypeUser,
+		TargetId:   tenantID,
+		TargetType: model_event.TargetTypeTenant,
+		Result:     model_event.ResultSuccess,
+		Message:    "tenant data exported",
+		Metadata:   metadata,
+	}
+	if err := t.auditLogs.CreateAuditLog(ctx, tenantID, auditLog); err != nil {
+		t.logger.Error("Failed to write tenant export audit log", "error", err, "tenantID", tenantID)
+	}
+}
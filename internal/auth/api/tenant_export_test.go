@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"google.golang.org/protobuf/proto"
+)
+
+func fixedSource(collection model_mongo.Collection, count int) exportSource {
+	return exportSource{collection: collection, documents: func(_ context.Context, tenantID string) ([]proto.Message, error) {
+		documents := make([]proto.Message, count)
+		for i := range documents {
+			documents[i] = &authv1.Role{TenantId: tenantID, Name: "role"}
+		}
+		return documents, nil
+	}}
+}
+
+func TestExportTenantData_Chunks(t *testing.T) {
+	sources := []exportSource{
+		fixedSource(model_mongo.RolesCollection, exportChunkSize+1),
+		fixedSource(model_mongo.PermissionsCollection, 0),
+	}
+	var chunks []*authv1.ExportTenantDataChunk
+	exported, err := exportTenantData(context.Background(), "tenant-1", sources, authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_NDJSON, func(chunk *authv1.ExportTenantDataChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(exportChunkSize+1), exported)
+	require.Len(t, chunks, 3)
+
+	assert.Equal(t, "roles", chunks[0].GetCollection())
+	assert.Equal(t, int32(exportChunkSize), chunks[0].GetDocumentCount())
+	assert.Equal(t, exportChunkSize, bytes.Count(chunks[0].GetData(), []byte("\n")))
+	assert.Equal(t, int32(0), chunks[0].GetProgress().GetCollectionsDone())
+
+	assert.Equal(t, int32(1), chunks[1].GetDocumentCount())
+	assert.Equal(t, int32(1), chunks[1].GetProgress().GetCollectionsDone())
+	assert.False(t, chunks[1].GetProgress().GetDone())
+
+	// Empty collections report their completion
+	assert.Equal(t, "permissions", chunks[2].GetCollection())
+	assert.Zero(t, chunks[2].GetDocumentCount())
+	assert.Equal(t, int32(2), chunks[2].GetProgress().GetCollectionsDone())
+	assert.Equal(t, int64(exportChunkSize+1), chunks[2].GetProgress().GetDocumentsExported())
+	assert.True(t, chunks[2].GetProgress().GetDone())
+}
+
+func TestEncodeExportDocuments_BSON(t *testing.T) {
+	data, err := encodeExportDocuments(authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_BSON, []proto.Message{
+		&authv1.Role{TenantId: "tenant-1", Name: "viewer"},
+		&authv1.Role{TenantId: "tenant-1", Name: "editor"},
+	})
+	require.NoError(t, err)
+
+	first, rest, ok := bsoncore.ReadDocument(data)
+	require.True(t, ok)
+	second, rest, ok := bsoncore.ReadDocument(rest)
+	require.True(t, ok)
+	assert.Empty(t, rest)
+	assert.Equal(t, "viewer", first.Lookup("name").StringValue())
+	assert.Equal(t, "editor", second.Lookup("name").StringValue())
+}
+
+func TestRedactUser(t *testing.T) {
+	user := &authv1.User{
+		Email:            "user@example.com",
+		PasswordHash:     "hash",
+		PasswordHistory:  []string{"old-hash"},
+		MfaSecret:        "secret",
+		MfaRecoveryCodes: []string{"code"},
+	}
+	RedactUser(user)
+
+	data, err := encodeExportDocuments(authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_NDJSON, []proto.Message{user})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "user@example.com")
+	assert.NotContains(t, string(data), "hash")
+	assert.NotContains(t, string(data), "secret")
+	assert.NotContains(t, string(data), "code")
+}
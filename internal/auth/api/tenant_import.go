@@ -0,0 +1,448 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/codec"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Reasons of the import conflicts
+const (
+	importConflictExists  = "already exists in the tenant"
+	importConflictUnknown = "references a document missing from the export"
+)
+
+// tenantArchive holds the documents of a tenant, read back from the chunks of an export or from the tenant an
+// import writes to
+type tenantArchive struct {
+	tenant         *authv1.Tenant
+	permissions    []*authv1.Permission
+	permissionSets []*authv1.PermissionSet
+	roles          []*authv1.Role
+	users          []*authv1.User
+}
+
+// tenantImport is the plan of an import, the ids of the export are mapped onto the ids of the tenant while the
+// documents are inserted
+type tenantImport struct {
+	tenantID      string
+	archive       *tenantArchive
+	permissionIDs map[string]string
+	setIDs        map[string]string
+	roleIDs       map[string]string
+	// Documents of the export mapped onto the matching documents of the tenant instead of inserted
+	reusedPermissions map[string]bool
+	reusedRoles       map[string]bool
+	conflicts         []*authv1.ImportConflict
+}
+
+// ImportTenantData writes the documents of an export into targetTenantID, or into a new tenant created from the
+// exported tenant when targetTenantID is empty. The documents get new ids and are written in one transaction,
+// nothing is written on a dry run or when conflicts are found
+func (t *TenantAPI) ImportTenantData(ctx context.Context, tenantID, userID, targetTenantID string, dryRun bool, chunks []*authv1.ExportTenantDataChunk) (*authv1.ImportTenantDataResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		t.logger.Error("failed to import tenant data", "error", err)
+		return nil, err
+	}
+	required := []string{permissions.TenantImport}
+	if targetTenantID == "" {
+		required = append(required, permissions.TenantCreate)
+	}
+	if err := t.checkPermission(ctx, tenantID, userID, required...); err != nil {
+		return nil, err
+	}
+
+	archive, err := readTenantArchive(chunks)
+	if err != nil {
+		t.logger.Error("failed to read tenant import", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	existing, tenants, err := t.importTarget(ctx, targetTenantID)
+	if err != nil {
+		t.logger.Error("failed to read tenant of import", "target_tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+	plan, err := planImport(targetTenantID, archive, existing, tenants)
+	if err != nil {
+		t.logger.Error("failed to plan tenant import", "target_tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+
+	response := &authv1.ImportTenantDataResponse{
+		TenantId:    targetTenantID,
+		DryRun:      dryRun,
+		Collections: plan.results(),
+		Conflicts:   plan.conflicts,
+	}
+	if dryRun || len(plan.conflicts) > 0 {
+		t.logger.Info("tenant import not applied", "target_tenant_id", targetTenantID, "dry_run", dryRun, "conflicts", len(plan.conflicts))
+		return response, nil
+	}
+
+	err = t.tenantHandler.WithTransaction(ctx, func(ctx context.Context) error {
+		return t.applyImport(ctx, userID, plan)
+	})
+	if errors.Is(err, mongo.ErrTransactionsUnsupported) {
+		err = infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("imports need a mongo deployment with transactions"))
+	}
+	if err != nil {
+		t.logger.Error("failed to import tenant data", "target_tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+	response.TenantId = plan.tenantID
+	response.Applied = true
+	t.logger.Info("tenant data imported", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", plan.tenantID)
+	t.auditImport(ctx, plan.tenantID, userID, response.GetCollections())
+	return response, nil
+}
+
+// importTarget reads the documents of targetTenantID the import is checked against, or the tenants a new tenant
+// must not clash with when targetTenantID is empty
+func (t *TenantAPI) importTarget(ctx context.Context, targetTenantID string) (*tenantArchive, []*authv1.Tenant, error) {
+	existing := &tenantArchive{}
+	if targetTenantID == "" {
+		tenants, err := t.tenantHandler.GetTenants(ctx)
+		return existing, tenants, err
+	}
+	var err error
+	if existing.tenant, err = t.tenantHandler.GetTenantByID(ctx, targetTenantID); err != nil {
+		return nil, nil, err
+	}
+	if existing.permissions, err = t.rbacAPI.Permissions.permissionHandler.GetPermissionsByTenantID(ctx, targetTenantID); err != nil {
+		return nil, nil, err
+	}
+	if existing.permissionSets, err = t.rbacAPI.Permissions.permissionSetHandler.GetPermissionSetsByTenantID(ctx, targetTenantID); err != nil {
+		return nil, nil, err
+	}
+	if existing.roles, err = t.rbacAPI.Roles.roleHandler.GetRolesByTenantID(ctx, targetTenantID); err != nil {
+		return nil, nil, err
+	}
+	if existing.users, err = t.userAPI.userHandler.GetUsersByTenantID(ctx, targetTenantID); err != nil {
+		return nil, nil, err
+	}
+	return existing, nil, nil
+}
+
+// applyImport inserts the documents of plan with one bulk write per collection, the ids of the export are replaced
+// by the ids of the new documents
+func (t *TenantAPI) applyImport(ctx context.Context, userID string, plan *tenantImport) error {
+	archive := plan.archive
+	if plan.tenantID == "" {
+		newTenant := archive.tenant
+		newTenant.Id = ""
+		id, err := t.tenantHandler.CreateTenant(ctx, newTenant)
+		if err != nil {
+			return err
+		}
+		plan.tenantID = id
+		if err := t.outbox.Add(ctx, createdTenantEvent(userID, id, newTenant)); err != nil {
+			return err
+		}
+	}
+
+	var importedPermissions []*authv1.Permission
+	var permissionSources []string
+	for _, permission := range archive.permissions {
+		if plan.reusedPermissions[permission.GetId()] {
+			continue
+		}
+		permissionSources = append(permissionSources, permission.GetId())
+		permission.Id, permission.TenantId = "", plan.tenantID
+		importedPermissions = append(importedPermissions, permission)
+	}
+	ids, err := t.rbacAPI.Permissions.permissionHandler.CreatePermissions(ctx, importedPermissions)
+	if err != nil {
+		return err
+	}
+	mapImportedIDs(plan.permissionIDs, permissionSources, ids)
+
+	setSources := make([]string, 0, len(archive.permissionSets))
+	for _, set := range archive.permissionSets {
+		setSources = append(setSources, set.GetId())
+		set.Id, set.TenantId = "", plan.tenantID
+		set.Permissions = remapIDs(set.GetPermissions(), plan.permissionIDs)
+	}
+	if ids, err = t.rbacAPI.Permissions.permissionSetHandler.CreatePermissionSets(ctx, archive.permissionSets); err != nil {
+		return err
+	}
+	mapImportedIDs(plan.setIDs, setSources, ids)
+
+	var importedRoles []*authv1.Role
+	var roleSources []string
+	for _, role := range archive.roles {
+		if plan.reusedRoles[role.GetId()] {
+			continue
+		}
+		roleSources = append(roleSources, role.GetId())
+		role.Id, role.TenantId = "", plan.tenantID
+		role.Permissions = remapIDs(role.GetPermissions(), plan.permissionIDs)
+		role.PermissionSets = remapIDs(role.GetPermissionSets(), plan.setIDs)
+		importedRoles = append(importedRoles, role)
+	}
+	if ids, err = t.rbacAPI.Roles.roleHandler.CreateRoles(ctx, importedRoles); err != nil {
+		return err
+	}
+	mapImportedIDs(plan.roleIDs, roleSources, ids)
+
+	for _, user := range archive.users {
+		user.Id, user.TenantId = "", plan.tenantID
+		for _, userRole := range user.GetRoles() {
+			userRole.RoleId, userRole.TenantId = plan.roleIDs[userRole.GetRoleId()], plan.tenantID
+		}
+	}
+	_, err = t.userAPI.userHandler.CreateUsers(ctx, archive.users)
+	return err
+}
+
+// mapImportedIDs maps the ids of the export, sourceIDs, onto the ids of the documents created from them by index
+func mapImportedIDs(mapping map[string]string, sourceIDs, ids []string) {
+	for i, id := range ids {
+		mapping[sourceIDs[i]] = id
+	}
+}
+
+// auditImport writes an import into tenantID to the audit log of the tenant
+func (t *TenantAPI) auditImport(ctx context.Context, tenantID, userID string, collections []*authv1.ImportCollectionResult) {
+	imported := map[string]any{}
+	for _, collection := range collections {
+		imported[collection.GetCollection()] = collection.GetImported()
+	}
+	metadata, err := structpb.NewStruct(map[string]any{"imported": imported})
+	if err != nil {
+		t.logger.Warn("Failed to build audit log metadata", "error", err)
+	}
+	auditLog := &eventv1.AuditLog{
+		Id:         uuid.New().String(),
+		Category:   model_event.CategoryTenant,
+		Action:     model_event.ActionTenantImported,
+		Severity:   model_event.SeverityWarning,
+		ActorId:    userID,
+		ActorType:  model_event.ActorTypeUser,
+		TargetId:   tenantID,
+		TargetType: model_event.TargetTypeTenant,
+		Result:     model_event.ResultSuccess,
+		Message:    "tenant data imported",
+		Metadata:   metadata,
+	}
+	if err := t.auditLogs.CreateAuditLog(ctx, tenantID, auditLog); err != nil {
+		t.logger.Error("Failed to write tenant import audit log", "error", err, "tenantID", tenantID)
+	}
+}
+
+// planImport checks archive against the documents of the tenant it is imported into. Permissions matching a
+// permission of the tenant and system roles matching a role of the tenant are reused, other documents clashing
+// with the tenant are conflicts. tenants are the tenants a new tenant, when tenantID is empty, must not clash with
+func planImport(tenantID string, archive, existing *tenantArchive, tenants []*authv1.Tenant) (*tenantImport, error) {
+	if tenantID == "" && archive.tenant == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, string(model_mongo.TenantsCollection))
+	}
+	plan := &tenantImport{
+		tenantID:          tenantID,
+		archive:           archive,
+		permissionIDs:     make(map[string]string),
+		setIDs:            make(map[string]string),
+		roleIDs:           make(map[string]string),
+		reusedPermissions: make(map[string]bool),
+		reusedRoles:       make(map[string]bool),
+	}
+	if tenantID == "" {
+		for _, tenant := range tenants {
+			if strings.EqualFold(tenant.GetName(), archive.tenant.GetName()) {
+				plan.conflict(model_mongo.TenantsCollection, archive.tenant.GetId(), "name", archive.tenant.GetName(), importConflictExists)
+			}
+		}
+	}
+
+	existingPermissions := make(map[string]string)
+	for _, permission := range existing.permissions {
+		existingPermissions[permissionKey(permission)] = permission.GetId()
+	}
+	sourcePermissions := make(map[string]bool)
+	for _, permission := range archive.permissions {
+		sourcePermissions[permission.GetId()] = true
+		if id, ok := existingPermissions[permissionKey(permission)]; ok {
+			plan.permissionIDs[permission.GetId()] = id
+			plan.reusedPermissions[permission.GetId()] = true
+		}
+	}
+
+	existingSets := make(map[string]bool)
+	for _, set := range existing.permissionSets {
+		existingSets[strings.ToLower(set.GetName())] = true
+	}
+	sourceSets := make(map[string]bool)
+	for _, set := range archive.permissionSets {
+		sourceSets[set.GetId()] = true
+		if existingSets[strings.ToLower(set.GetName())] {
+			plan.conflict(model_mongo.PermissionSetsCollection, set.GetId(), "name", set.GetName(), importConflictExists)
+		}
+		plan.checkReferences(model_mongo.PermissionSetsCollection, set.GetId(), "permissions", set.GetPermissions(), sourcePermissions)
+	}
+
+	existingRoles := make(map[string]string)
+	for _, role := range existing.roles {
+		existingRoles[strings.ToLower(role.GetName())] = role.GetId()
+	}
+	sourceRoles := make(map[string]bool)
+	for _, role := range archive.roles {
+		sourceRoles[role.GetId()] = true
+		if id, ok := existingRoles[strings.ToLower(role.GetName())]; ok {
+			if role.GetType() == authv1.RoleType_ROLE_TYPE_SYSTEM {
+				plan.roleIDs[role.GetId()] = id
+				plan.reusedRoles[role.GetId()] = true
+				continue
+			}
+			plan.conflict(model_mongo.RolesCollection, role.GetId(), "name", role.GetName(), importConflictExists)
+		}
+		plan.checkReferences(model_mongo.RolesCollection, role.GetId(), "permissions", role.GetPermissions(), sourcePermissions)
+		plan.checkReferences(model_mongo.RolesCollection, role.GetId(), "permission_sets", role.GetPermissionSets(), sourceSets)
+	}
+
+	existingUsers := make(map[string]bool)
+	for _, user := range existing.users {
+		existingUsers["email:"+strings.ToLower(user.GetEmail())] = true
+		existingUsers["username:"+strings.ToLower(user.GetUsername())] = true
+	}
+	for _, user := range archive.users {
+		if existingUsers["email:"+strings.ToLower(user.GetEmail())] {
+			plan.conflict(model_mongo.UsersCollection, user.GetId(), "email", user.GetEmail(), importConflictExists)
+		} else if user.GetUsername() != "" && existingUsers["username:"+strings.ToLower(user.GetUsername())] {
+			plan.conflict(model_mongo.UsersCollection, user.GetId(), "username", user.GetUsername(), importConflictExists)
+		}
+		for _, userRole := range user.GetRoles() {
+			plan.checkReferences(model_mongo.UsersCollection, user.GetId(), "roles", []string{userRole.GetRoleId()}, sourceRoles)
+		}
+	}
+	return plan, nil
+}
+
+// conflict records a conflict of the document sourceID
+func (p *tenantImport) conflict(collection model_mongo.Collection, sourceID, field, value, reason string) {
+	p.conflicts = append(p.conflicts, &authv1.ImportConflict{
+		Collection: string(collection),
+		SourceId:   sourceID,
+		Field:      field,
+		Value:      value,
+		Reason:     reason,
+	})
+}
+
+// checkReferences records a conflict for each of ids missing from known
+func (p *tenantImport) checkReferences(collection model_mongo.Collection, sourceID, field string, ids []string, known map[string]bool) {
+	for _, id := range ids {
+		if !known[id] {
+			p.conflict(collection, sourceID, field, id, importConflictUnknown)
+		}
+	}
+}
+
+// results counts the documents of the plan per collection
+func (p *tenantImport) results() []*authv1.ImportCollectionResult {
+	result := func(collection model_mongo.Collection, total, reused int) *authv1.ImportCollectionResult {
+		return &authv1.ImportCollectionResult{Collection: string(collection), Imported: int32(total - reused), Reused: int32(reused)}
+	}
+	tenants := 0
+	if p.tenantID == "" {
+		tenants = 1
+	}
+	return []*authv1.ImportCollectionResult{
+		result(model_mongo.TenantsCollection, tenants, 0),
+		result(model_mongo.PermissionsCollection, len(p.archive.permissions), len(p.reusedPermissions)),
+		result(model_mongo.PermissionSetsCollection, len(p.archive.permissionSets), 0),
+		result(model_mongo.RolesCollection, len(p.archive.roles), len(p.reusedRoles)),
+		result(model_mongo.UsersCollection, len(p.archive.users), 0),
+	}
+}
+
+// permissionKey identifies the permissions granting the same access
+func permissionKey(permission *authv1.Permission) string {
+	return strings.ToLower(permission.GetPermissionString()) + "|" + permission.GetCondition()
+}
+
+// remapIDs replaces the ids of the export by the ids of the imported documents
+func remapIDs(ids []string, mapping map[string]string) []string {
+	remapped := make([]string, 0, len(ids))
+	for _, id := range ids {
+		remapped = append(remapped, mapping[id])
+	}
+	return remapped
+}
+
+// readTenantArchive decodes the chunks of an export, the collections that are not imported, e.g. the audit logs,
+// are skipped
+func readTenantArchive(chunks []*authv1.ExportTenantDataChunk) (*tenantArchive, error) {
+	archive := &tenantArchive{}
+	for _, chunk := range chunks {
+		var err error
+		switch model_mongo.Collection(chunk.GetCollection()) {
+		case model_mongo.TenantsCollection:
+			var tenants []*authv1.Tenant
+			if tenants, err = appendImportDocuments(tenants, chunk); err == nil && len(tenants) > 0 {
+				archive.tenant = tenants[0]
+			}
+		case model_mongo.PermissionsCollection:
+			archive.permissions, err = appendImportDocuments(archive.permissions, chunk)
+		case model_mongo.PermissionSetsCollection:
+			archive.permissionSets, err = appendImportDocuments(archive.permissionSets, chunk)
+		case model_mongo.RolesCollection:
+			archive.roles, err = appendImportDocuments(archive.roles, chunk)
+		case model_mongo.UsersCollection:
+			archive.users, err = appendImportDocuments(archive.users, chunk)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+// appendImportDocuments appends the newline-delimited JSON or concatenated BSON documents of chunk to documents
+func appendImportDocuments[T any, PT interface {
+	*T
+	proto.Message
+}](documents []PT, chunk *authv1.ExportTenantDataChunk) ([]PT, error) {
+	data := chunk.GetData()
+	for len(data) > 0 {
+		document := PT(new(T))
+		var err error
+		switch chunk.GetFormat() {
+		case authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_BSON:
+			raw, rest, ok := bsoncore.ReadDocument(data)
+			if !ok {
+				err = errors.New("truncated bson document")
+				break
+			}
+			data = rest
+			err = bson.UnmarshalWithRegistry(codec.GetRegistry(), raw, document)
+		default:
+			var line []byte
+			line, data, _ = bytes.Cut(data, []byte("\n"))
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(line, document)
+		}
+		if err != nil {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "chunk").WithDetails("collection", chunk.GetCollection()).WithError(err)
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
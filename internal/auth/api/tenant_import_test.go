@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func exportedArchive() *tenantArchive {
+	return &tenantArchive{
+		tenant: &authv1.Tenant{Id: "source", Name: "Acme"},
+		permissions: []*authv1.Permission{
+			{Id: "perm-all", PermissionString: "*:*"},
+			{Id: "perm-read", PermissionString: "user:read"},
+		},
+		permissionSets: []*authv1.PermissionSet{{Id: "set-1", Name: "readers", Permissions: []string{"perm-read"}}},
+		roles: []*authv1.Role{
+			{Id: "role-admin", Name: "tenant_admin", Type: authv1.RoleType_ROLE_TYPE_SYSTEM, Permissions: []string{"perm-all"}},
+			{Id: "role-viewer", Name: "viewer", Type: authv1.RoleType_ROLE_TYPE_CUSTOM, Permissions: []string{"perm-read"}, PermissionSets: []string{"set-1"}},
+		},
+		users: []*authv1.User{
+			{Id: "user-1", Email: "admin@acme.com", Username: "admin", Roles: []*authv1.UserRole{{RoleId: "role-admin"}}},
+			{Id: "user-2", Email: "viewer@acme.com", Username: "viewer", Roles: []*authv1.UserRole{{RoleId: "role-viewer"}}},
+		},
+	}
+}
+
+func archiveChunks(t *testing.T, archive *tenantArchive, format authv1.TenantExportFormat) []*authv1.ExportTenantDataChunk {
+	sources := []exportSource{
+		{collection: model_mongo.TenantsCollection, documents: func(context.Context, string) ([]proto.Message, error) {
+			return []proto.Message{archive.tenant}, nil
+		}},
+		{collection: model_mongo.PermissionsCollection, documents: func(context.Context, string) ([]proto.Message, error) {
+			return asMessages(archive.permissions, nil)
+		}},
+		{collection: model_mongo.PermissionSetsCollection, documents: func(context.Context, string) ([]proto.Message, error) {
+			return asMessages(archive.permissionSets, nil)
+		}},
+		{collection: model_mongo.RolesCollection, documents: func(context.Context, string) ([]proto.Message, error) {
+			return asMessages(archive.roles, nil)
+		}},
+		{collection: model_mongo.UsersCollection, documents: func(context.Context, string) ([]proto.Message, error) {
+			return asMessages(archive.users, nil)
+		}},
+		{collection: model_mongo.AuditLogsCollection, documents: func(context.Context, string) ([]proto.Message, error) {
+			return nil, nil
+		}},
+	}
+	var chunks []*authv1.ExportTenantDataChunk
+	_, err := exportTenantData(context.Background(), "source", sources, format, func(chunk *authv1.ExportTenantDataChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	require.NoError(t, err)
+	return chunks
+}
+
+func TestReadTenantArchive(t *testing.T) {
+	for _, format := range []authv1.TenantExportFormat{authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_NDJSON, authv1.TenantExportFormat_TENANT_EXPORT_FORMAT_BSON} {
+		t.Run(format.String(), func(t *testing.T) {
+			source := exportedArchive()
+			archive, err := readTenantArchive(archiveChunks(t, source, format))
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(source.tenant, archive.tenant))
+			require.Len(t, archive.roles, 2)
+			assert.Equal(t, []string{"set-1"}, archive.roles[1].GetPermissionSets())
+			require.Len(t, archive.users, 2)
+			assert.Equal(t, "role-viewer", archive.users[1].GetRoles()[0].GetRoleId())
+		})
+	}
+
+	_, err := readTenantArchive([]*authv1.ExportTenantDataChunk{{Collection: "roles", Data: []byte("{not json\n")}})
+	assert.True(t, infra_error.Validation(infra_error.ValidationInvalidValue).Is(err))
+}
+
+func TestPlanImport(t *testing.T) {
+	// A seeded tenant has its own *:* permission and tenant_admin role
+	seeded := &tenantArchive{
+		permissions: []*authv1.Permission{{Id: "target-perm-all", PermissionString: "*:*"}},
+		roles:       []*authv1.Role{{Id: "target-role-admin", Name: "tenant_admin", Type: authv1.RoleType_ROLE_TYPE_SYSTEM}},
+		users:       []*authv1.User{{Id: "target-user", Email: "owner@acme.com", Username: "owner"}},
+	}
+
+	plan, err := planImport("target", exportedArchive(), seeded, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plan.conflicts)
+	assert.Equal(t, "target-perm-all", plan.permissionIDs["perm-all"])
+	assert.Equal(t, "target-role-admin", plan.roleIDs["role-admin"])
+	results := plan.results()
+	assert.Equal(t, &authv1.ImportCollectionResult{Collection: "permissions", Imported: 1, Reused: 1}, results[1])
+	assert.Equal(t, &authv1.ImportCollectionResult{Collection: "roles", Imported: 1, Reused: 1}, results[3])
+	assert.Equal(t, int32(2), results[4].GetImported())
+
+	// Clashing names, emails and dangling references are reported
+	seeded.roles = append(seeded.roles, &authv1.Role{Id: "target-role-viewer", Name: "Viewer"})
+	seeded.users = append(seeded.users, &authv1.User{Id: "target-user-2", Email: "VIEWER@acme.com"})
+	archive := exportedArchive()
+	archive.users[0].Roles = append(archive.users[0].Roles, &authv1.UserRole{RoleId: "role-missing"})
+	plan, err = planImport("target", archive, seeded, nil)
+	require.NoError(t, err)
+	require.Len(t, plan.conflicts, 3)
+	assert.Equal(t, "roles", plan.conflicts[0].GetCollection())
+	assert.Equal(t, "name", plan.conflicts[0].GetField())
+	assert.Equal(t, "role-missing", plan.conflicts[1].GetValue())
+	assert.Equal(t, importConflictUnknown, plan.conflicts[1].GetReason())
+	assert.Equal(t, "email", plan.conflicts[2].GetField())
+
+	// A new tenant must not clash with the existing tenants
+	plan, err = planImport("", exportedArchive(), &tenantArchive{}, []*authv1.Tenant{{Id: "other", Name: "acme"}})
+	require.NoError(t, err)
+	require.Len(t, plan.conflicts, 1)
+	assert.Equal(t, "tenants", plan.conflicts[0].GetCollection())
+
+	_, err = planImport("", &tenantArchive{}, &tenantArchive{}, nil)
+	assert.True(t, infra_error.Validation(infra_error.ValidationRequiredFields).Is(err))
+}
+
+func TestRemapIDs(t *testing.T) {
+	assert.Equal(t, []string{"new-1", "new-2"}, remapIDs([]string{"old-1", "old-2"}, map[string]string{"old-1": "new-1", "old-2": "new-2"}))
+	assert.Empty(t, remapIDs(nil, nil))
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/metrics"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// tenantLifecycleActor is the actor of the status changes made by the trial sweeps
+const tenantLifecycleActor = "system"
+
+// trialExpiredReason is the status reason of the tenants suspended at the end of their trial
+const trialExpiredReason = "trial expired"
+
+var expiredTrials = metrics.NewCounter("tenant_trials_expired_total",
+	"Total number of tenants suspended by the sweeps at the end of their trial.")
+
+// TenantLifecycleConfig holds the settings of the tenant trials, loaded with infra_config.Load
+type TenantLifecycleConfig struct {
+	// Length of the trial of the tenants created in trial without a trial end
+	TrialPeriod time.Duration `yaml:"trial_period" env:"TENANT_TRIAL_PERIOD" default:"336h"`
+	// Interval between two sweeps of the expired trials. Users of expired trials cannot log in before the sweep,
+	// the sweeps suspend the tenants and end the sessions of their users
+	TrialSweepInterval time.Duration `yaml:"trial_sweep_interval" env:"TENANT_TRIAL_SWEEP_INTERVAL" default:"1h"`
+}
+
+var defaultTenantLifecycleConfig = TenantLifecycleConfig{
+	TrialPeriod:        14 * 24 * time.Hour,
+	TrialSweepInterval: time.Hour,
+}
+
+// SetLifecycleConfig applies the settings of the tenant trials
+func (t *TenantAPI) SetLifecycleConfig(config *TenantLifecycleConfig) {
+	t.lifecycleConfig = *config
+}
+
+// ChangeTenantStatus moves targetTenantID to status along the transitions allowed by model_auth.CanChangeTenantStatus
+// and returns the previous status. Suspending or deactivating a tenant ends the sessions of its users
+func (t *TenantAPI) ChangeTenantStatus(ctx context.Context, tenantID, userID, targetTenantID string, status authv1.TenantStatus, reason string) (authv1.TenantStatus, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		t.logger.Error("failed to change tenant status", "error", err)
+		return authv1.TenantStatus_TENANT_STATUS_UNSPECIFIED, err
+	}
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantUpdate); err != nil {
+		return authv1.TenantStatus_TENANT_STATUS_UNSPECIFIED, err
+	}
+
+	tenant, err := t.tenantHandler.GetTenantByID(ctx, targetTenantID)
+	if err != nil {
+		t.logger.Error("failed to change tenant status", "tenant_id", tenantID, "target_tenant_id", targetTenantID, "error", err)
+		return authv1.TenantStatus_TENANT_STATUS_UNSPECIFIED, err
+	}
+	previous := tenant.GetStatus()
+	if !model_auth.CanChangeTenantStatus(previous, status) {
+		return previous, infra_error.Business(infra_error.BusinessInvalidTenantStatus).WithError(errors.New("cannot change tenant status from " + previous.String() + " to " + status.String()))
+	}
+	if err := t.changeTenantStatus(ctx, tenant, status, reason, userID, model_event.ActorTypeUser); err != nil {
+		t.logger.Error("failed to change tenant status", "tenant_id", tenantID, "target_tenant_id", targetTenantID, "error", err)
+		return previous, err
+	}
+	return previous, nil
+}
+
+// RunTrialSweeps suspends the tenants at the end of their trial every interval until quit is closed
+func (t *TenantAPI) RunTrialSweeps(interval time.Duration, quit <-chan struct{}) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	// Sweeps run in the background, not on behalf of a request
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = t.SweepExpiredTrials(ctx)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// SweepExpiredTrials suspends the tenants whose trial ended by now and returns how many were suspended
+func (t *TenantAPI) SweepExpiredTrials(ctx context.Context) (int, error) {
+	tenants, err := t.tenantHandler.GetTenantsWithExpiredTrial(ctx, time.Now())
+	if err != nil {
+		t.logger.Error("failed to read the expired trials", "error", err)
+		return 0, err
+	}
+	suspended := 0
+	for _, tenant := range tenants {
+		err := t.changeTenantStatus(ctx, tenant, authv1.TenantStatus_TENANT_STATUS_SUSPENDED, trialExpiredReason, tenantLifecycleActor, model_event.ActorTypeSystem)
+		if err != nil {
+			// Retried on the next sweep, the logins are refused meanwhile
+			t.logger.Error("failed to suspend expired trial", "tenant_id", tenant.GetId(), "error", err)
+			continue
+		}
+		suspended++
+		expiredTrials.Inc()
+	}
+	if suspended > 0 {
+		t.logger.Info("suspended tenants at the end of their trial", "count", suspended)
+	}
+	return suspended, nil
+}
+
+// startTrial sets the trial period of a tenant created in trial, an explicit trial end is kept
+func (t *TenantAPI) startTrial(tenant *authv1.Tenant, now time.Time) {
+	tenant.StatusChangedAt = timestamppb.New(now)
+	if tenant.GetStatus() != authv1.TenantStatus_TENANT_STATUS_TRIAL {
+		return
+	}
+	if tenant.GetTrialStart() == nil {
+		tenant.TrialStart = timestamppb.New(now)
+	}
+	if tenant.GetTrialEnd() == nil {
+		tenant.TrialEnd = timestamppb.New(tenant.GetTrialStart().AsTime().Add(t.lifecycleConfig.TrialPeriod))
+	}
+}
+
+// checkLifecycleFields returns a restricted fields error when an update of existing changes its status or trial,
+// they change through ChangeTenantStatus. The status change time and reason are kept
+func checkLifecycleFields(existing, tenant *authv1.Tenant) error {
+	if tenant.GetStatus() != existing.GetStatus() ||
+		!proto.Equal(tenant.GetTrialStart(), existing.GetTrialStart()) ||
+		!proto.Equal(tenant.GetTrialEnd(), existing.GetTrialEnd()) {
+		return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields, "status", "trial_start", "trial_end")
+	}
+	tenant.StatusChangedAt = existing.GetStatusChangedAt()
+	tenant.StatusReason = existing.GetStatusReason()
+	return nil
+}
+
+// changeTenantStatus stores the new status of tenant, audits the change and ends the sessions of the users of
+// tenants that are no longer usable
+func (t *TenantAPI) changeTenantStatus(ctx context.Context, tenant *authv1.Tenant, status authv1.TenantStatus, reason, actorID, actorType string) error {
+	previous := tenant.GetStatus()
+	tenant.Status = status
+	tenant.StatusChangedAt = timestamppb.Now()
+	tenant.StatusReason = reason
+	err := t.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := t.tenantHandler.UpdateTenant(ctx, tenant); err != nil {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{tenantEvent(model_event.EventTenantStatusChanged, actorID, tenant)}, nil
+	})
+	// Dropped on failure too, the write may have been applied before the error
+	t.tenantCache.Invalidate(tenant.GetId())
+	if err != nil {
+		return err
+	}
+	t.logger.Info("tenant status changed", "tenant_id", tenant.GetId(), "previous_status", previous.String(), "status", status.String(), "actor_id", actorID, "reason", reason)
+
+	if model_auth.TenantLoginError(tenant, time.Now()) != nil && t.authAPI != nil {
+		if _, _, err := t.authAPI.tokenManager.RevokeAllTenantTokens(ctx, tenant.GetId(), actorID); err != nil {
+			// The logins and refreshes of the users are refused, their access tokens expire on their own
+			t.logger.Warn("failed to revoke the tokens of the tenant", "tenant_id", tenant.GetId(), "error", err)
+		}
+	}
+	t.auditTenantStatus(ctx, tenant, previous, actorID, actorType)
+	return nil
+}
+
+// auditTenantStatus writes a status change of tenant to the audit log of the tenant
+func (t *TenantAPI) auditTenantStatus(ctx context.Context, tenant *authv1.Tenant, previous authv1.TenantStatus, actorID, actorType string) {
+	if t.userAPI == nil || t.userAPI.auditLogs == nil {
+		return
+	}
+	action := model_event.ActionTenantActivated
+	switch tenant.GetStatus() {
+	case authv1.TenantStatus_TENANT_STATUS_SUSPENDED:
+		action = model_event.ActionTenantSuspended
+	case authv1.TenantStatus_TENANT_STATUS_INACTIVE:
+		action = model_event.ActionTenantDeactivated
+	}
+	metadata, err := structpb.NewStruct(map[string]any{
+		"previous_status": previous.String(),
+		"status":          tenant.GetStatus().String(),
+		"reason":          tenant.GetStatusReason(),
+	})
+	if err != nil {
+		t.logger.Warn("Failed to build audit log metadata", "error", err)
+	}
+	auditLog := &eventv1.AuditLog{
+		Id:         uuid.New().String(),
+		Category:   model_event.CategoryTenant,
+		Action:     action,
+		Severity:   model_event.SeverityWarning,
+		ActorId:    actorID,
+		ActorType:  actorType,
+		TargetId:   tenant.GetId(),
+		TargetType: model_event.TargetTypeTenant,
+		Result:     model_event.ResultSuccess,
+		Message:    "tenant status changed",
+		Metadata:   metadata,
+	}
+	if err := t.userAPI.auditLogs.CreateAuditLog(ctx, tenant.GetId(), auditLog); err != nil {
+		t.logger.Error("Failed to write tenant status audit log", "error", err, "tenantID", tenant.GetId())
+	}
+}
+
+// checkTenantLogin returns the error the logins and token refreshes of the users of tenantID fail with, see
+// model_auth.TenantLoginError
+func (a *AuthAPI) checkTenantLogin(ctx context.Context, tenantID string) error {
+	tenant, err := a.userAPI.tenantHandler.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		a.logger.Error("failed to get tenant of login", "tenant_id", tenantID, "error", err)
+		return err
+	}
+	if err := model_auth.TenantLoginError(tenant, time.Now()); err != nil {
+		a.logger.Warn("login refused for tenant", "tenant_id", tenantID, "status", tenant.GetStatus().String(), "error", err)
+		return err
+	}
+	return nil
+}
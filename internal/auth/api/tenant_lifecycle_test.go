@@ -0,0 +1,57 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTenantAPI_StartTrial(t *testing.T) {
+	tenantAPI := &TenantAPI{lifecycleConfig: defaultTenantLifecycleConfig}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	trial := &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_TRIAL}
+	tenantAPI.startTrial(trial, now)
+	assert.Equal(t, now, trial.GetTrialStart().AsTime())
+	assert.Equal(t, now.Add(14*24*time.Hour), trial.GetTrialEnd().AsTime())
+	assert.Equal(t, now, trial.GetStatusChangedAt().AsTime())
+
+	// An explicit trial end is kept
+	trialEnd := now.Add(30 * 24 * time.Hour)
+	extended := &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_TRIAL, TrialEnd: timestamppb.New(trialEnd)}
+	tenantAPI.startTrial(extended, now)
+	assert.Equal(t, trialEnd, extended.GetTrialEnd().AsTime())
+
+	active := &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE}
+	tenantAPI.startTrial(active, now)
+	assert.Nil(t, active.GetTrialStart())
+	assert.Nil(t, active.GetTrialEnd())
+}
+
+func TestCheckLifecycleFields(t *testing.T) {
+	changedAt := timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	existing := &authv1.Tenant{
+		Status:          authv1.TenantStatus_TENANT_STATUS_TRIAL,
+		TrialEnd:        timestamppb.New(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)),
+		StatusChangedAt: changedAt,
+		StatusReason:    "signup",
+	}
+
+	update := &authv1.Tenant{Status: existing.GetStatus(), TrialEnd: timestamppb.New(existing.GetTrialEnd().AsTime())}
+	require.NoError(t, checkLifecycleFields(existing, update))
+	assert.Equal(t, changedAt, update.GetStatusChangedAt())
+	assert.Equal(t, "signup", update.GetStatusReason())
+
+	activated := &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE, TrialEnd: existing.GetTrialEnd()}
+	err := checkLifecycleFields(existing, activated)
+	assert.True(t, infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields).Is(err))
+
+	extended := &authv1.Tenant{Status: existing.GetStatus(), TrialEnd: timestamppb.New(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))}
+	err = checkLifecycleFields(existing, extended)
+	assert.True(t, infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields).Is(err))
+}
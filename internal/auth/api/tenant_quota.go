@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// tenantGetter reads the tenants whose limits are checked
+type tenantGetter interface {
+	GetTenantByID(ctx context.Context, tenantID string) (*authv1.Tenant, error)
+}
+
+// tenantResourceCounter counts the resources of a tenant limited by its quotas
+type tenantResourceCounter func(ctx context.Context, tenantID string) (int, error)
+
+// TenantQuotas checks the creations of users, roles and API keys against the limits of their tenant.
+// The count and the creation are not atomic, concurrent creations may go past a limit by a few
+type TenantQuotas struct {
+	logger   logger.Logger
+	tenants  tenantGetter
+	counters map[string]tenantResourceCounter
+}
+
+func NewTenantQuotas(tenants tenantGetter, logger logger.Logger) *TenantQuotas {
+	return &TenantQuotas{
+		logger:   logger,
+		tenants:  tenants,
+		counters: make(map[string]tenantResourceCounter),
+	}
+}
+
+// SetCounter registers the counter of resource, set by the APIs creating the resource
+func (q *TenantQuotas) SetCounter(resource string, counter tenantResourceCounter) {
+	q.counters[resource] = counter
+}
+
+// Check returns a tenant limit error when tenantID already has as many resources as its limit allows. Resources
+// without a counter and tenants without limits are not checked
+func (q *TenantQuotas) Check(ctx context.Context, tenantID, resource string) error {
+	if q == nil || q.counters[resource] == nil {
+		return nil
+	}
+	tenant, err := q.tenants.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		q.logger.Error("failed to get tenant limits", "tenant_id", tenantID, "error", err)
+		return err
+	}
+	limit := model_auth.TenantLimit(tenant.GetLimits(), resource)
+	if limit <= 0 {
+		return nil
+	}
+	count, err := q.counters[resource](ctx, tenantID)
+	if err != nil {
+		q.logger.Error("failed to count tenant resources", "tenant_id", tenantID, "resource", resource, "error", err)
+		return err
+	}
+	if err := model_auth.CheckTenantLimit(resource, limit, count); err != nil {
+		q.logger.Warn("tenant limit reached", "tenant_id", tenantID, "resource", resource, "limit", limit, "count", count)
+		return err
+	}
+	return nil
+}
+
+// Usage returns the resource counts of tenant with its limits
+func (q *TenantQuotas) Usage(ctx context.Context, tenant *authv1.Tenant) (*authv1.TenantUsage, error) {
+	usage := &authv1.TenantUsage{TenantId: tenant.GetId()}
+	for resource, target := range map[string]**authv1.ResourceUsage{
+		model_auth.TenantResourceUsers:   &usage.Users,
+		model_auth.TenantResourceRoles:   &usage.Roles,
+		model_auth.TenantResourceAPIKeys: &usage.ApiKeys,
+	} {
+		resourceUsage := &authv1.ResourceUsage{Limit: model_auth.TenantLimit(tenant.GetLimits(), resource)}
+		if counter := q.counters[resource]; counter != nil {
+			count, err := counter(ctx, tenant.GetId())
+			if err != nil {
+				q.logger.Error("failed to count tenant resources", "tenant_id", tenant.GetId(), "resource", resource, "error", err)
+				return nil, err
+			}
+			resourceUsage.Count = int32(count)
+		}
+		*target = resourceUsage
+	}
+	return usage, nil
+}
+
+// Quotas returns the quotas checked by the user, role and API key creations
+func (t *TenantAPI) Quotas() *TenantQuotas {
+	return t.quotas
+}
+
+// GetTenantUsage returns the user, role and API key counts of a tenant with its limits
+func (t *TenantAPI) GetTenantUsage(ctx context.Context, tenantID, userID, targetTenantID string) (*authv1.TenantUsage, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		t.logger.Error("failed to get tenant usage", "error", err)
+		return nil, err
+	}
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantRead); err != nil {
+		return nil, err
+	}
+	tenant, err := t.tenantHandler.GetTenantByID(ctx, targetTenantID)
+	if err != nil {
+		t.logger.Error("failed to get tenant usage", "tenant_id", tenantID, "target_tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+	return t.quotas.Usage(ctx, tenant)
+}
+
+// countUsers is the tenantResourceCounter of the users
+func (u *UserAPI) countUsers(ctx context.Context, tenantID string) (int, error) {
+	count, err := u.userHandler.CountUsersByTenantID(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// countRoles is the tenantResourceCounter of the roles, the system roles seeded with the tenant are not counted
+func (ra *RoleAPI) countRoles(ctx context.Context, tenantID string) (int, error) {
+	roles, err := ra.roleHandler.GetRolesByTenantID(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, role := range roles {
+		if role.GetType() != authv1.RoleType_ROLE_TYPE_SYSTEM {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// countAPIKeys is the tenantResourceCounter of the API keys, revoked and expired keys are not counted
+func (a *APIKeyAPI) countAPIKeys(ctx context.Context, tenantID string) (int, error) {
+	keys, err := a.apiKeyHandler.GetAPIKeysByTenantID(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	count := 0
+	for _, key := range keys {
+		if key.GetRevokedAt() == nil && (key.GetExpiresAt() == nil || key.GetExpiresAt().AsTime().After(now)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// SetTenantQuotas checks the API key creations against the limits of their tenant
+func (a *APIKeyAPI) SetTenantQuotas(quotas *TenantQuotas) {
+	quotas.SetCounter(model_auth.TenantResourceAPIKeys, a.countAPIKeys)
+	a.quotas = quotas
+}
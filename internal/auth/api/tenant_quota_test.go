@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTenantGetter struct {
+	tenants map[string]*authv1.Tenant
+}
+
+func (f *fakeTenantGetter) GetTenantByID(_ context.Context, tenantID string) (*authv1.Tenant, error) {
+	tenant, ok := f.tenants[tenantID]
+	if !ok {
+		return nil, infra_error.NotFound(infra_error.NotFoundResource, "tenant", tenantID)
+	}
+	return tenant, nil
+}
+
+func fixedCounter(count int) tenantResourceCounter {
+	return func(context.Context, string) (int, error) {
+		return count, nil
+	}
+}
+
+func newTestTenantQuotas() *TenantQuotas {
+	quotas := NewTenantQuotas(&fakeTenantGetter{tenants: map[string]*authv1.Tenant{
+		"limited":   {Id: "limited", Limits: &authv1.TenantLimits{MaxUsers: 3, MaxRoles: 2}},
+		"unlimited": {Id: "unlimited"},
+	}}, logger.NewBaseLogger(shared.ModuleAuth))
+	quotas.SetCounter(model_auth.TenantResourceUsers, fixedCounter(2))
+	quotas.SetCounter(model_auth.TenantResourceRoles, fixedCounter(2))
+	return quotas
+}
+
+func TestTenantQuotas_Check(t *testing.T) {
+	quotas := newTestTenantQuotas()
+	ctx := context.Background()
+
+	require.NoError(t, quotas.Check(ctx, "limited", model_auth.TenantResourceUsers))
+	err := quotas.Check(ctx, "limited", model_auth.TenantResourceRoles)
+	assert.True(t, infra_error.Business(infra_error.BusinessTenantLimitReached).Is(err))
+	require.NoError(t, quotas.Check(ctx, "unlimited", model_auth.TenantResourceRoles))
+	// Resources without a counter are not checked
+	require.NoError(t, quotas.Check(ctx, "limited", model_auth.TenantResourceAPIKeys))
+
+	var unset *TenantQuotas
+	require.NoError(t, unset.Check(ctx, "limited", model_auth.TenantResourceRoles))
+}
+
+func TestTenantQuotas_Usage(t *testing.T) {
+	quotas := newTestTenantQuotas()
+
+	usage, err := quotas.Usage(context.Background(), &authv1.Tenant{Id: "limited", Limits: &authv1.TenantLimits{MaxUsers: 3, MaxRoles: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, "limited", usage.GetTenantId())
+	assert.Equal(t, int32(2), usage.GetUsers().GetCount())
+	assert.Equal(t, int32(3), usage.GetUsers().GetLimit())
+	assert.Equal(t, int32(2), usage.GetRoles().GetCount())
+	assert.Equal(t, int32(2), usage.GetRoles().GetLimit())
+	assert.Zero(t, usage.GetApiKeys().GetCount())
+	assert.Zero(t, usage.GetApiKeys().GetLimit())
+}
@@ -0,0 +1,295 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Seams used by the TenantSeeder, satisfied by PermissionAPI, RoleAPI, UserHandler and TenantHandler
+type PermissionSeedStore interface {
+	CreatePermission(ctx context.Context, tenantID, requestorUserID string, permission *authv1.Permission, targetTenantID string) (string, error)
+	DeletePermission(ctx context.Context, tenantID, requestorUserID, permissionID string, targetTenantID string) error
+}
+
+type RoleSeedStore interface {
+	CreateRole(ctx context.Context, tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) (string, error)
+	DeleteRole(ctx context.Context, tenantID, requestorUserID, roleID string, targetTenantID string) error
+}
+
+type UserSeedStore interface {
+	CreateUser(ctx context.Context, user *authv1.User) (string, error)
+	DeleteUser(ctx context.Context, tenantID, userID string) error
+}
+
+type TenantSeedStore interface {
+	DeleteTenant(ctx context.Context, tenantID string) error
+}
+
+// SeedStep identifies a resource created while seeding a tenant
+type SeedStep string
+
+const (
+	SeedStepPermission SeedStep = "permission"
+	SeedStepRole       SeedStep = "role"
+	SeedStepAdminUser  SeedStep = "admin_user"
+	SeedStepTenant     SeedStep = "tenant"
+)
+
+// RollbackReport describes the outcome of rolling back a failed tenant seeding
+type RollbackReport struct {
+	TenantID string
+	// Steps whose resources were deleted
+	RolledBack []SeedStep
+	// Steps whose resources could not be deleted and are left behind
+	NotRolledBack map[SeedStep]error
+	// Steps that never created a resource, nothing to roll back
+	Skipped []SeedStep
+}
+
+// Complete reports whether every created resource was removed
+func (r *RollbackReport) Complete() bool {
+	return len(r.NotRolledBack) == 0
+}
+
+func (r *RollbackReport) String() string {
+	return fmt.Sprintf("rolled back: %v, not rolled back: %v, skipped: %v", r.RolledBack, r.NotRolledBack, r.Skipped)
+}
+
+// SeedError is returned when seeding a tenant failed, it carries the rollback report
+type SeedError struct {
+	Step     SeedStep
+	Err      error
+	Rollback *RollbackReport
+}
+
+func (e *SeedError) Error() string {
+	if e.Rollback != nil && !e.Rollback.Complete() {
+		return fmt.Sprintf("failed to seed %s: %v (rollback incomplete: %s)", e.Step, e.Err, e.Rollback)
+	}
+	return fmt.Sprintf("failed to seed %s: %v", e.Step, e.Err)
+}
+
+func (e *SeedError) Unwrap() error {
+	return e.Err
+}
+
+// TenantSeeder creates the default permission, role and admin user of a new tenant and removes them on failure
+type TenantSeeder struct {
+	logger      logger.Logger
+	permissions PermissionSeedStore
+	roles       RoleSeedStore
+	users       UserSeedStore
+	tenants     TenantSeedStore
+	failures    *FailureInjector
+}
+
+func NewTenantSeeder(permissions PermissionSeedStore, roles RoleSeedStore, users UserSeedStore, tenants TenantSeedStore, logger logger.Logger) *TenantSeeder {
+	return &TenantSeeder{
+		logger:      logger,
+		permissions: permissions,
+		roles:       roles,
+		users:       users,
+		tenants:     tenants,
+	}
+}
+
+// SetFailureInjector makes the seeder fail on the configured steps, nil disables injection
+func (s *TenantSeeder) SetFailureInjector(failures *FailureInjector) {
+	s.failures = failures
+}
+
+// Seed creates the defaults of targetTenantID on behalf of requestorUserID from tenantID.
+// On failure the defaults created so far are returned together with a *SeedError.
+func (s *TenantSeeder) Seed(ctx context.Context, tenantID, requestorUserID, targetTenantID string) (*TenantDefaults, error) {
+	s.logger.Info("Seeding defaults for new tenant", "tenant_id", targetTenantID)
+
+	defaults := &TenantDefaults{}
+
+	// Step 1: Create "*:*" permission
+	permissionID, err := s.createWildcardPermission(ctx, tenantID, requestorUserID, targetTenantID)
+	if err != nil {
+		return defaults, &SeedError{Step: SeedStepPermission, Err: err}
+	}
+	defaults.PermissionID = permissionID
+	s.logger.Info("Wildcard permission created", "tenant_id", targetTenantID, "permission_id", permissionID)
+
+	// Step 2: Create TenantAdmin role
+	roleID, err := s.createTenantAdminRole(ctx, tenantID, requestorUserID, targetTenantID, permissionID)
+	if err != nil {
+		return defaults, &SeedError{Step: SeedStepRole, Err: err}
+	}
+	defaults.RoleId = roleID
+	s.logger.Info("TenantAdmin role created", "tenant_id", targetTenantID, "role_id", roleID)
+
+	// Step 3: Create initial admin user
+	userID, err := s.createAdminUser(ctx, targetTenantID, db.TenantAdminUser, db.TenantAdminPassword, roleID, requestorUserID)
+	if err != nil {
+		return defaults, &SeedError{Step: SeedStepAdminUser, Err: err}
+	}
+	defaults.UserId = userID
+	s.logger.Info("Admin user created", "tenant_id", targetTenantID, "user_id", userID)
+
+	s.logger.Info("Tenant defaults seeded successfully", "tenant_id", targetTenantID)
+	return defaults, nil
+}
+
+// Rollback deletes the seeded defaults in reverse creation order and, when deleteTenant is set, the tenant itself.
+// Every step is attempted even if a previous one failed.
+func (s *TenantSeeder) Rollback(ctx context.Context, tenantID, requestorUserID, targetTenantID string, defaults *TenantDefaults, deleteTenant bool) *RollbackReport {
+	s.logger.Warn("Rolling back tenant defaults", "tenant_id", targetTenantID)
+
+	if defaults == nil {
+		defaults = &TenantDefaults{}
+	}
+	report := &RollbackReport{
+		TenantID:      targetTenantID,
+		NotRolledBack: map[SeedStep]error{},
+	}
+	rollback := func(step SeedStep, created bool, remove func() error) {
+		if !created {
+			report.Skipped = append(report.Skipped, step)
+			return
+		}
+		err := s.failures.check(step, SeedPhaseRollback)
+		if err == nil {
+			err = remove()
+		}
+		if err != nil {
+			s.logger.Error("failed to roll back tenant default", "tenant_id", targetTenantID, "step", step, "error", err)
+			report.NotRolledBack[step] = err
+			return
+		}
+		report.RolledBack = append(report.RolledBack, step)
+	}
+
+	rollback(SeedStepAdminUser, defaults.UserId != "", func() error {
+		return s.users.DeleteUser(ctx, targetTenantID, defaults.UserId)
+	})
+	rollback(SeedStepRole, defaults.RoleId != "", func() error {
+		return s.roles.DeleteRole(ctx, tenantID, requestorUserID, defaults.RoleId, targetTenantID)
+	})
+	rollback(SeedStepPermission, defaults.PermissionID != "", func() error {
+		return s.permissions.DeletePermission(ctx, tenantID, requestorUserID, defaults.PermissionID, targetTenantID)
+	})
+	rollback(SeedStepTenant, deleteTenant, func() error {
+		return s.tenants.DeleteTenant(ctx, targetTenantID)
+	})
+
+	if report.Complete() {
+		s.logger.Info("Tenant defaults rolled back successfully", "tenant_id", targetTenantID, "report", report.String())
+	} else {
+		s.logger.Error("Tenant defaults rollback incomplete", "tenant_id", targetTenantID, "report", report.String())
+	}
+	return report
+}
+
+// abortedReport is the report of a seeding done in an aborted transaction, nothing it created was committed
+func abortedReport(targetTenantID string, defaults *TenantDefaults) *RollbackReport {
+	if defaults == nil {
+		defaults = &TenantDefaults{}
+	}
+	report := &RollbackReport{
+		TenantID:      targetTenantID,
+		NotRolledBack: map[SeedStep]error{},
+	}
+	for _, step := range []struct {
+		step    SeedStep
+		created bool
+	}{
+		{SeedStepAdminUser, defaults.UserId != ""},
+		{SeedStepRole, defaults.RoleId != ""},
+		{SeedStepPermission, defaults.PermissionID != ""},
+		{SeedStepTenant, true},
+	} {
+		if step.created {
+			report.RolledBack = append(report.RolledBack, step.step)
+		} else {
+			report.Skipped = append(report.Skipped, step.step)
+		}
+	}
+	return report
+}
+
+func (s *TenantSeeder) createWildcardPermission(ctx context.Context, tenantID, requestorUserID, targetTenantID string) (string, error) {
+	if err := s.failures.check(SeedStepPermission, SeedPhaseCreate); err != nil {
+		return "", err
+	}
+
+	permission := &authv1.Permission{
+		TenantId:         targetTenantID,
+		DisplayName:      "Full Access",
+		PermissionString: permissions.Wildcard,
+		Description:      "Grants full access to all resources and actions",
+		Resource:         model_auth.ResourceTypeAll,     // "*"
+		Action:           model_auth.PermissionActionAll, // "*"
+		Status:           authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE,
+		CreatedBy:        requestorUserID,
+		IsDangerous:      true,
+		Protected:        true,
+	}
+
+	return s.permissions.CreatePermission(ctx, tenantID, requestorUserID, permission, targetTenantID)
+}
+
+func (s *TenantSeeder) createTenantAdminRole(ctx context.Context, tenantID, requestorUserID, targetTenantID, permissionID string) (string, error) {
+	if err := s.failures.check(SeedStepRole, SeedPhaseCreate); err != nil {
+		return "", err
+	}
+
+	role := &authv1.Role{
+		TenantId:    targetTenantID,
+		Name:        model_auth.RoleTenantAdmin,
+		Description: "Tenant administrator with full access to all tenant resources",
+		Type:        authv1.RoleType_ROLE_TYPE_SYSTEM,
+		Permissions: []string{permissionID}, // Assign "*:*" permission
+		Protected:   true,
+		Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
+		CreatedBy:   requestorUserID,
+	}
+
+	return s.roles.CreateRole(ctx, tenantID, requestorUserID, role, targetTenantID)
+}
+
+func (s *TenantSeeder) createAdminUser(ctx context.Context, tenantID, username, plainPassword, roleID, createdBy string) (string, error) {
+	if err := s.failures.check(SeedStepAdminUser, SeedPhaseCreate); err != nil {
+		return "", err
+	}
+
+	// Hash password
+	hashedPassword, err := hash.HashPassword(plainPassword)
+	if err != nil {
+		return "", err
+	}
+
+	user := &authv1.User{
+		TenantId:     tenantID,
+		Username:     username,
+		PasswordHash: hashedPassword,
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    createdBy,
+		Roles: []*authv1.UserRole{
+			{
+				TenantId:   tenantID,
+				RoleId:     roleID,
+				AssignedAt: timestamppb.Now(),
+				AssignedBy: createdBy,
+			},
+		},
+	}
+
+	// Validate user
+	if err := validator_auth.ValidateUser(user, true); err != nil {
+		return "", err
+	}
+
+	return s.users.CreateUser(ctx, user)
+}
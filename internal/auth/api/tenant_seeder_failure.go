@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SeedPhase tells whether a step is being created or rolled back
+type SeedPhase string
+
+const (
+	SeedPhaseCreate   SeedPhase = "create"
+	SeedPhaseRollback SeedPhase = "rollback"
+)
+
+// ErrInjectedFailure is the default error returned by a FailureInjector
+var ErrInjectedFailure = errors.New("injected failure")
+
+// FailureInjector makes a TenantSeeder fail on chosen steps so rollback paths can be exercised in tests.
+// A nil FailureInjector never fails.
+type FailureInjector struct {
+	mu       sync.Mutex
+	failures map[string]error
+}
+
+func NewFailureInjector() *FailureInjector {
+	return &FailureInjector{
+		failures: map[string]error{},
+	}
+}
+
+// FailOn makes the given step fail in the given phase, a nil err uses ErrInjectedFailure
+func (f *FailureInjector) FailOn(step SeedStep, phase SeedPhase, err error) *FailureInjector {
+	if err == nil {
+		err = ErrInjectedFailure
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[failureKey(step, phase)] = err
+	return f
+}
+
+// Reset removes every configured failure
+func (f *FailureInjector) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = map[string]error{}
+}
+
+func (f *FailureInjector) check(step SeedStep, phase SeedPhase) error {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err, ok := f.failures[failureKey(step, phase)]; ok {
+		return fmt.Errorf("%s %s: %w", phase, step, err)
+	}
+	return nil
+}
+
+func failureKey(step SeedStep, phase SeedPhase) string {
+	return fmt.Sprintf("%s:%s", phase, step)
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	shared "erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSeedStore records created resources in memory and implements every seeder seam
+type fakeSeedStore struct {
+	nextID      int
+	permissions map[string]bool
+	roles       map[string]bool
+	users       map[string]bool
+	tenants     map[string]bool
+}
+
+func newFakeSeedStore(tenantID string) *fakeSeedStore {
+	return &fakeSeedStore{
+		permissions: map[string]bool{},
+		roles:       map[string]bool{},
+		users:       map[string]bool{},
+		tenants:     map[string]bool{tenantID: true},
+	}
+}
+
+func (f *fakeSeedStore) id(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+func (f *fakeSeedStore) CreatePermission(_ context.Context, tenantID, requestorUserID string, permission *authv1.Permission, targetTenantID string) (string, error) {
+	id := f.id("permission")
+	f.permissions[id] = true
+	return id, nil
+}
+
+func (f *fakeSeedStore) DeletePermission(_ context.Context, tenantID, requestorUserID, permissionID string, targetTenantID string) error {
+	delete(f.permissions, permissionID)
+	return nil
+}
+
+func (f *fakeSeedStore) CreateRole(_ context.Context, tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) (string, error) {
+	id := f.id("role")
+	f.roles[id] = true
+	return id, nil
+}
+
+func (f *fakeSeedStore) DeleteRole(_ context.Context, tenantID, requestorUserID, roleID string, targetTenantID string) error {
+	delete(f.roles, roleID)
+	return nil
+}
+
+func (f *fakeSeedStore) CreateUser(_ context.Context, user *authv1.User) (string, error) {
+	id := f.id("user")
+	f.users[id] = true
+	return id, nil
+}
+
+func (f *fakeSeedStore) DeleteUser(_ context.Context, tenantID, userID string) error {
+	delete(f.users, userID)
+	return nil
+}
+
+func (f *fakeSeedStore) DeleteTenant(_ context.Context, tenantID string) error {
+	delete(f.tenants, tenantID)
+	return nil
+}
+
+func TestTenantSeeder_SeedAndRollback(t *testing.T) {
+	const (
+		tenantID       = "tenant-1"
+		userID         = "user-1"
+		targetTenantID = "new-tenant"
+	)
+
+	testCases := []struct {
+		name                  string
+		failures              *FailureInjector
+		expectedFailedStep    SeedStep
+		expectedRolledBack    []SeedStep
+		expectedNotRolledBack []SeedStep
+		expectedSkipped       []SeedStep
+		expectTenantRemaining bool
+		expectRolesRemaining  int
+	}{
+		{
+			name:               "permission creation fails",
+			failures:           NewFailureInjector().FailOn(SeedStepPermission, SeedPhaseCreate, nil),
+			expectedFailedStep: SeedStepPermission,
+			expectedRolledBack: []SeedStep{SeedStepTenant},
+			expectedSkipped:    []SeedStep{SeedStepAdminUser, SeedStepRole, SeedStepPermission},
+		},
+		{
+			name:               "admin user creation fails",
+			failures:           NewFailureInjector().FailOn(SeedStepAdminUser, SeedPhaseCreate, nil),
+			expectedFailedStep: SeedStepAdminUser,
+			expectedRolledBack: []SeedStep{SeedStepRole, SeedStepPermission, SeedStepTenant},
+			expectedSkipped:    []SeedStep{SeedStepAdminUser},
+		},
+		{
+			name: "role rollback fails",
+			failures: NewFailureInjector().
+				FailOn(SeedStepAdminUser, SeedPhaseCreate, nil).
+				FailOn(SeedStepRole, SeedPhaseRollback, nil),
+			expectedFailedStep:    SeedStepAdminUser,
+			expectedRolledBack:    []SeedStep{SeedStepPermission, SeedStepTenant},
+			expectedNotRolledBack: []SeedStep{SeedStepRole},
+			expectedSkipped:       []SeedStep{SeedStepAdminUser},
+			expectRolesRemaining:  1,
+		},
+		{
+			name: "tenant rollback fails",
+			failures: NewFailureInjector().
+				FailOn(SeedStepRole, SeedPhaseCreate, nil).
+				FailOn(SeedStepTenant, SeedPhaseRollback, nil),
+			expectedFailedStep:    SeedStepRole,
+			expectedRolledBack:    []SeedStep{SeedStepPermission},
+			expectedNotRolledBack: []SeedStep{SeedStepTenant},
+			expectedSkipped:       []SeedStep{SeedStepAdminUser, SeedStepRole},
+			expectTenantRemaining: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newFakeSeedStore(targetTenantID)
+			seeder := NewTenantSeeder(store, store, store, store, logger.NewBaseLogger(shared.ModuleAuth))
+			seeder.SetFailureInjector(tc.failures)
+
+			defaults, err := seeder.Seed(context.Background(), tenantID, userID, targetTenantID)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInjectedFailure)
+
+			var seedErr *SeedError
+			require.True(t, errors.As(err, &seedErr))
+			assert.Equal(t, tc.expectedFailedStep, seedErr.Step)
+
+			report := seeder.Rollback(context.Background(), tenantID, userID, targetTenantID, defaults, true)
+			assert.Equal(t, tc.expectedRolledBack, report.RolledBack)
+			assert.Equal(t, tc.expectedSkipped, report.Skipped)
+			assert.Len(t, report.NotRolledBack, len(tc.expectedNotRolledBack))
+			for _, step := range tc.expectedNotRolledBack {
+				assert.ErrorIs(t, report.NotRolledBack[step], ErrInjectedFailure)
+			}
+			assert.Equal(t, len(tc.expectedNotRolledBack) == 0, report.Complete())
+
+			assert.Empty(t, store.users)
+			assert.Empty(t, store.permissions)
+			assert.Len(t, store.roles, tc.expectRolesRemaining)
+			assert.Equal(t, tc.expectTenantRemaining, store.tenants[targetTenantID])
+		})
+	}
+}
+
+func TestTenantSeeder_SeedWithoutFailures(t *testing.T) {
+	store := newFakeSeedStore("new-tenant")
+	seeder := NewTenantSeeder(store, store, store, store, logger.NewBaseLogger(shared.ModuleAuth))
+
+	defaults, err := seeder.Seed(context.Background(), "tenant-1", "user-1", "new-tenant")
+	require.NoError(t, err)
+	assert.NotEmpty(t, defaults.PermissionID)
+	assert.NotEmpty(t, defaults.RoleId)
+	assert.NotEmpty(t, defaults.UserId)
+	assert.Len(t, store.users, 1)
+}
+
+func TestTenantSeeder_AbortedReport(t *testing.T) {
+	store := newFakeSeedStore("new-tenant")
+	seeder := NewTenantSeeder(store, store, store, store, logger.NewBaseLogger(shared.ModuleAuth))
+	seeder.SetFailureInjector(NewFailureInjector().FailOn(SeedStepAdminUser, SeedPhaseCreate, nil))
+
+	defaults, err := seeder.Seed(context.Background(), "tenant-1", "user-1", "new-tenant")
+	require.Error(t, err)
+	assert.Len(t, store.permissions, 1)
+	assert.Len(t, store.roles, 1)
+
+	report := abortedReport("new-tenant", defaults)
+	assert.True(t, report.Complete())
+	assert.Equal(t, []SeedStep{SeedStepRole, SeedStepPermission, SeedStepTenant}, report.RolledBack)
+	assert.Equal(t, []SeedStep{SeedStepAdminUser}, report.Skipped)
+}
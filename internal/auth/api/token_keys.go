@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/secret"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// rotatedKey is a signing key replaced by a rotation, the tokens it signed verify until expiresAt
+type rotatedKey struct {
+	key       []byte
+	expiresAt time.Time
+}
+
+// signingKey returns the key new tokens are signed with
+func (tm *TokenAPI) signingKey() []byte {
+	tm.keyMu.RLock()
+	defer tm.keyMu.RUnlock()
+	return []byte(tm.secretKey)
+}
+
+// verificationKeys returns the keys accepted on verification, the current key and the rotated keys whose tokens
+// may not have expired yet
+func (tm *TokenAPI) verificationKeys() any {
+	tm.keyMu.RLock()
+	defer tm.keyMu.RUnlock()
+	now := time.Now()
+	keys := []jwt.VerificationKey{[]byte(tm.secretKey)}
+	for _, previous := range tm.previousKeys {
+		if now.Before(previous.expiresAt) {
+			keys = append(keys, previous.key)
+		}
+	}
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	return jwt.VerificationKeySet{Keys: keys}
+}
+
+// RotateSecretKey signs new tokens with key. Tokens signed with the previous key keep verifying for the default
+// access token lifetime, tenants with a longer token policy have their older tokens rejected
+func (tm *TokenAPI) RotateSecretKey(key string) {
+	tm.keyMu.Lock()
+	defer tm.keyMu.Unlock()
+	if key == "" || key == tm.secretKey {
+		return
+	}
+	now := time.Now()
+	kept := tm.previousKeys[:0]
+	for _, previous := range tm.previousKeys {
+		if now.Before(previous.expiresAt) {
+			kept = append(kept, previous)
+		}
+	}
+	tm.previousKeys = append(kept, rotatedKey{key: []byte(tm.secretKey), expiresAt: now.Add(tm.tokenDuration)})
+	tm.secretKey = key
+	tm.logger.Info("token signing key rotated", "previous_keys", len(tm.previousKeys))
+}
+
+// UseSecretProvider reads the signing key from provider and follows its rotations. The key of the environment
+// stays in use when the provider does not have one
+func (tm *TokenAPI) UseSecretProvider(ctx context.Context, provider secret.SecretProvider) {
+	if key, ok := secret.Lookup(ctx, provider, secret.JWTSecretKey, tm.logger); ok {
+		// The key of the environment never signed a token of this process, it is not kept for verification
+		tm.keyMu.Lock()
+		tm.secretKey = key
+		tm.keyMu.Unlock()
+	}
+	provider.OnRotate(secret.JWTSecretKey, tm.RotateSecretKey)
+}
+
+// SetSecretProvider reads the token signing key from provider and follows its rotations
+func (a *AuthAPI) SetSecretProvider(ctx context.Context, provider secret.SecretProvider) {
+	a.tokenManager.UseSecretProvider(ctx, provider)
+}
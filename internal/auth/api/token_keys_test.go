@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mock_token "erp.localhost/internal/auth/handler/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/secret"
+	mock_secret "erp.localhost/internal/infra/secret/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func generateTestAccessToken(t *testing.T, tm *TokenAPI) string {
+	t.Helper()
+	tokenString, _, err := tm.GenerateAccessToken(&GenerateAccessTokenInput{
+		UserId:   "user-1",
+		TenantId: "tenant-1",
+		Email:    "user@example.com",
+		Username: "user",
+		Roles:    []string{"admin"},
+	})
+	require.NoError(t, err)
+	return tokenString
+}
+
+func TestTokenAPI_RotateSecretKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	accessMock := mock_token.NewMockTokenHandler[authv1_cache.TokenMetadata](ctrl)
+	accessMock.EXPECT().GetOne(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.TokenMetadata{}, nil).AnyTimes()
+
+	tm := newPolicyTestTokenAPI(nil)
+	tm.accessTokenHandler = accessMock
+	oldToken := generateTestAccessToken(t, tm)
+
+	tm.RotateSecretKey("rotated-secret")
+	newToken := generateTestAccessToken(t, tm)
+	assert.Equal(t, []byte("rotated-secret"), tm.signingKey())
+
+	// Tokens of both keys verify during the grace period
+	for _, tokenString := range []string{oldToken, newToken} {
+		_, err := tm.GetTokenMetadata(context.Background(), tokenString)
+		assert.NoError(t, err)
+	}
+
+	// Rotating to the same key keeps the previous keys as they are
+	tm.RotateSecretKey("rotated-secret")
+	tm.RotateSecretKey("")
+	require.Len(t, tm.previousKeys, 1)
+
+	// Once the grace period ended only the current key verifies
+	tm.previousKeys[0].expiresAt = time.Now().Add(-time.Second)
+	_, err := tm.GetTokenMetadata(context.Background(), oldToken)
+	assert.Error(t, err)
+	_, err = tm.GetTokenMetadata(context.Background(), newToken)
+	assert.NoError(t, err)
+}
+
+func TestTokenAPI_UseSecretProvider(t *testing.T) {
+	testCases := []struct {
+		name       string
+		value      string
+		err        error
+		wantKey    string
+		wantRotate string
+	}{
+		{
+			name:       "key from the provider",
+			value:      "vault-secret",
+			wantKey:    "vault-secret",
+			wantRotate: "next-secret",
+		},
+		{
+			name:       "provider without the key keeps the environment key",
+			err:        infra_error.NotFound(infra_error.NotFoundResource, "secret", secret.JWTSecretKey),
+			wantKey:    "test-secret",
+			wantRotate: "next-secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			provider := mock_secret.NewMockSecretProvider(ctrl)
+			var onRotate func(string)
+			provider.EXPECT().Get(gomock.Any(), secret.JWTSecretKey).Return(tc.value, tc.err)
+			provider.EXPECT().OnRotate(secret.JWTSecretKey, gomock.Any()).Do(func(_ string, fn func(string)) {
+				onRotate = fn
+			})
+
+			tm := newPolicyTestTokenAPI(nil)
+			tm.UseSecretProvider(context.Background(), provider)
+			assert.Equal(t, []byte(tc.wantKey), tm.signingKey())
+			// The key replaced at startup never signed a token, it is not kept
+			assert.Empty(t, tm.previousKeys)
+
+			require.NotNil(t, onRotate)
+			onRotate(tc.wantRotate)
+			assert.Equal(t, []byte(tc.wantRotate), tm.signingKey())
+			assert.Len(t, tm.previousKeys, 1)
+		})
+	}
+}
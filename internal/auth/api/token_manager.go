@@ -1,23 +1,38 @@
 package api
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"erp.localhost/internal/auth/handler"
 	"erp.localhost/internal/auth/hash"
 	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/db/redis"
 	infra_error "erp.localhost/internal/infra/error"
+	audit_collection "erp.localhost/internal/infra/event/audit_log/collection"
 	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	model_auth "erp.localhost/internal/infra/model/auth"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
 	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/tracing"
+	"erp.localhost/internal/infra/validation"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -28,13 +43,36 @@ const (
 	TokenTypeRefresh = "refresh"
 
 	Issuer = "erp.localhost"
+
+	// AccessTokenClaimsFull issues access tokens carrying the roles of their user
+	AccessTokenClaimsFull = "full"
+	// AccessTokenClaimsMinimal issues access tokens carrying only the permissions version of their user, see
+	// TokenAPI.VerifyAccessToken
+	AccessTokenClaimsMinimal = "minimal"
+
+	// maxRotatedRefreshTokens caps the rotation history kept per family, a family refreshed more
+	// often than this within its lifetime forgets its oldest tokens
+	maxRotatedRefreshTokens = 100
+)
+
+var (
+	tokensIssued = metrics.NewCounter("auth_tokens_issued_total",
+		"Total number of tokens issued, by token type.", "type")
+	tokensRevoked = metrics.NewCounter("auth_tokens_revoked_total",
+		"Total number of token revocations, by token type.", "type")
 )
 
-// TokenConfig holds configuration for token management
+// TokenConfig holds configuration for token management, the global defaults of the tenant token policies
 type TokenConfig struct {
 	SecretKey            string
 	TokenDuration        time.Duration
 	RefreshTokenDuration time.Duration
+	Issuer               string
+	Audience             []string
+	// ClaimsMode is AccessTokenClaimsFull or AccessTokenClaimsMinimal
+	ClaimsMode string
+	// Redis is the connection of the token stores
+	Redis *redis.RedisConfig
 }
 
 // LoadTokenConfig loads token configuration from environment variables with defaults
@@ -43,7 +81,22 @@ func LoadTokenConfig() *TokenConfig {
 		SecretKey:            getEnv("JWT_SECRET_KEY", "secret"),
 		TokenDuration:        parseDuration(getEnv("ACCESS_TOKEN_DURATION", "1h"), 1*time.Hour),
 		RefreshTokenDuration: parseDuration(getEnv("REFRESH_TOKEN_DURATION", "168h"), 7*24*time.Hour),
+		Issuer:               getEnv("JWT_ISSUER", Issuer),
+		Audience:             splitList(getEnv("JWT_AUDIENCE", "")),
+		ClaimsMode:           getEnv("ACCESS_TOKEN_CLAIMS", AccessTokenClaimsFull),
+		Redis:                redis.LoadRedisConfig(),
+	}
+}
+
+// splitList splits a comma separated environment value, ignoring empty entries
+func splitList(value string) []string {
+	values := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
 	}
+	return values
 }
 
 // getEnv gets an environment variable or returns a default value
@@ -80,25 +133,63 @@ type NewTokenResponse struct {
 	TokenExpiresAt        int64  `json:"token_expires_at"`
 	RefreshToken          string `json:"refresh_token"`
 	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at"`
+	// Risk of the login the tokens were issued for, see checkLoginRisk
+	RiskScore   int32    `json:"risk_score,omitempty"`
+	RiskReasons []string `json:"risk_reasons,omitempty"`
+}
+
+// refreshTokenFamilyStore keeps the rotation history of refresh token families
+type refreshTokenFamilyStore interface {
+	Store(ctx context.Context, family *authv1_cache.RefreshTokenFamily) error
+	GetOne(ctx context.Context, tenantID string, familyID string) (*authv1_cache.RefreshTokenFamily, error)
+	Delete(ctx context.Context, tenantID string, familyID string) error
+}
+
+// auditLogWriter records security events in the audit log
+type auditLogWriter interface {
+	CreateAuditLog(ctx context.Context, tenantID string, auditLog *eventv1.AuditLog) error
+}
+
+// permissionsVersionSource hashes the effective permissions of the users, see rbac.VerificationManager
+type permissionsVersionSource interface {
+	PermissionsVersion(ctx context.Context, tenantID, userID string) (string, error)
 }
 
 // TokenAPI coordinates all token operations including JWT generation/verification and Redis storage
 type TokenAPI struct {
-	secretKey            string
-	tokenDuration        time.Duration
-	refreshTokenDuration time.Duration
-	accessTokenHandler   handler.TokenHandler[authv1_cache.TokenMetadata]
-	refreshTokenHandler  handler.TokenHandler[authv1_cache.RefreshToken]
-	logger               logger.Logger
+	// secretKey signs new tokens, the rotated keys in previousKeys still verify the tokens they signed
+	keyMu                     sync.RWMutex
+	secretKey                 string
+	previousKeys              []rotatedKey
+	tokenDuration             time.Duration
+	refreshTokenDuration      time.Duration
+	accessTokenHandler        handler.TokenHandler[authv1_cache.TokenMetadata]
+	refreshTokenHandler       handler.TokenHandler[authv1_cache.RefreshToken]
+	refreshTokenFamilyHandler refreshTokenFamilyStore
+	auditLogs                 auditLogWriter
+	issuer                    string
+	audience                  []string
+	// Tenant token policies override the durations, issuer and audience above, nil uses them for every tenant
+	policies tokenPolicySource
+	// claimsMode selects the claims of the access tokens, the minimal ones are checked against permissionsVersions
+	claimsMode          string
+	permissionsVersions permissionsVersionSource
+	logger              logger.Logger
 }
 
 // GenerateAccessTokenInput input for generating access tokens
 type GenerateAccessTokenInput struct {
-	UserId   string
-	TenantId string
-	Email    string
-	Username string
-	Roles    []string
+	UserId   string `validate:"required"`
+	TenantId string `validate:"required"`
+	Email    string `validate:"required"`
+	Username string `validate:"required"`
+	// Roles are required unless PermissionsVersion is set, the minimal tokens carry no roles
+	Roles              []string
+	PermissionsVersion string
+	// Actor is the user impersonating UserId, set on the impersonation tokens
+	Actor *authv1.TokenActor
+	// TTL shortens the lifetime of the token below the one of the tenant policy, 0 keeps the policy
+	TTL time.Duration
 }
 
 // GenerateRefreshTokenInput input for generating refresh tokens
@@ -108,24 +199,18 @@ type GenerateRefreshTokenInput struct {
 	IPAddress string
 	UserAgent string
 	CreatedAt time.Time
+	// DeviceId is the fingerprint of the device of the login, see authv1.Device
+	DeviceId string
+	// Parent is the token being rotated, the new token joins its family. A nil parent starts a new family
+	Parent *authv1_cache.RefreshToken
 }
 
 func (i *GenerateAccessTokenInput) Validate() error {
-	missingFields := []string{}
-	if i.UserId == "" {
-		missingFields = append(missingFields, "UserId")
-	}
-	if i.TenantId == "" {
-		missingFields = append(missingFields, "TenantId")
-	}
-	if i.Email == "" || i.Username == "" {
-		missingFields = append(missingFields, "Email", "Username")
-	}
-	if len(i.Roles) == 0 {
-		missingFields = append(missingFields, "Roles")
+	if err := validation.Struct(i, false); err != nil {
+		return err
 	}
-	if len(missingFields) > 0 {
-		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	if len(i.Roles) == 0 && i.PermissionsVersion == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "Roles")
 	}
 	return nil
 }
@@ -139,29 +224,53 @@ func NewTokenAPI(logger logger.Logger) (*TokenAPI, error) {
 		logger.Fatal("failed to create token manager", "error", err)
 		return nil, err
 	}
+	if config.ClaimsMode != AccessTokenClaimsFull && config.ClaimsMode != AccessTokenClaimsMinimal {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(fmt.Errorf("access token claims must be %s or %s, got %q", AccessTokenClaimsFull, AccessTokenClaimsMinimal, config.ClaimsMode))
+		logger.Fatal("failed to create token manager", "error", err)
+		return nil, err
+	}
 	logger.Info("Token configuration loaded",
 		"access_token_duration", config.TokenDuration.String(),
-		"refresh_token_duration", config.RefreshTokenDuration.String())
+		"refresh_token_duration", config.RefreshTokenDuration.String(),
+		"issuer", config.Issuer,
+		"claims", config.ClaimsMode)
 
-	accessTokenHandler, err := handler.NewAccessTokenHandler(logger)
+	accessTokenHandler, err := handler.NewAccessTokenHandler(config.Redis, logger)
 	if err != nil {
 		logger.Fatal("failed to create access token handler")
 		return nil, err
 	}
 
-	refreshTokenHandler, err := handler.NewRefreshTokenHandler(logger)
+	refreshTokenHandler, err := handler.NewRefreshTokenHandler(config.Redis, logger)
 	if err != nil {
 		logger.Fatal("failed to create refresh token handler")
 		return nil, err
 	}
 
+	refreshTokenFamilyHandler, err := handler.NewRefreshTokenFamilyHandler(config.Redis, logger)
+	if err != nil {
+		logger.Fatal("failed to create refresh token family handler")
+		return nil, err
+	}
+
+	auditLogsHandler, err := collection.NewBaseCollectionHandler[eventv1.AuditLog](model_mongo.AuthDB, model_mongo.AuditLogsCollection, logger)
+	if err != nil {
+		logger.Fatal("failed to create audit logs collection handler")
+		return nil, err
+	}
+
 	return &TokenAPI{
-		secretKey:            config.SecretKey,
-		tokenDuration:        config.TokenDuration,
-		refreshTokenDuration: config.RefreshTokenDuration,
-		accessTokenHandler:   accessTokenHandler,
-		refreshTokenHandler:  refreshTokenHandler,
-		logger:               logger,
+		secretKey:                 config.SecretKey,
+		tokenDuration:             config.TokenDuration,
+		refreshTokenDuration:      config.RefreshTokenDuration,
+		accessTokenHandler:        accessTokenHandler,
+		refreshTokenHandler:       refreshTokenHandler,
+		refreshTokenFamilyHandler: refreshTokenFamilyHandler,
+		auditLogs:                 audit_collection.NewAuditLogsCollection(auditLogsHandler, logger),
+		issuer:                    config.Issuer,
+		audience:                  config.Audience,
+		claimsMode:                config.ClaimsMode,
+		logger:                    logger,
 	}, nil
 }
 
@@ -175,27 +284,35 @@ func (tm *TokenAPI) GenerateAccessToken(input *GenerateAccessTokenInput) (string
 		return "", nil, err
 	}
 
+	policy := tm.tokenPolicy(input.TenantId)
 	now := time.Now()
-	expiresAt := now.Add(tm.tokenDuration)
+	ttl := time.Duration(policy.GetAccessTokenTtlSeconds()) * time.Second
+	if input.TTL > 0 && input.TTL < ttl {
+		ttl = input.TTL
+	}
+	expiresAt := now.Add(ttl)
 
 	// Create JWT claims with generated jti
 	jwtClaims := &token.JWTAccessClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        uuid.New().String(), // Generate jti (not persisted)
-			Issuer:    Issuer,
+			Issuer:    policy.GetIssuer(),
+			Audience:  policy.GetAudience(),
 			Subject:   input.UserId,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
-		UserID:   input.UserId,
-		TenantID: input.TenantId,
-		Email:    input.Email,
-		Roles:    input.Roles,
+		UserID:             input.UserId,
+		TenantID:           input.TenantId,
+		Email:              input.Email,
+		Roles:              input.Roles,
+		PermissionsVersion: input.PermissionsVersion,
+		Act:                token.NewActorClaims(input.Actor),
 	}
 
 	// Sign the JWT
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
-	tokenString, err := token.SignedString([]byte(tm.secretKey))
+	tokenString, err := token.SignedString(tm.signingKey())
 	if err != nil {
 		return "", nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
 	}
@@ -203,17 +320,21 @@ func (tm *TokenAPI) GenerateAccessToken(input *GenerateAccessTokenInput) (string
 	// Convert to proto claims (jti not included)
 	protoClaims := jwtClaims.ToProtoClaims()
 
+	tokensIssued.Inc(TokenTypeAccess)
 	return tokenString, protoClaims, nil
 }
 
 // Full verification flow
-func (tm *TokenAPI) VerifyAccessToken(tokenString string) (*authv1.AccessTokenClaims, error) {
+func (tm *TokenAPI) VerifyAccessToken(ctx context.Context, tokenString string) (_ *authv1.AccessTokenClaims, err error) {
+	ctx, span := tracing.Start(ctx, "TokenAPI.VerifyAccessToken")
+	defer func() { tracing.End(span, err) }()
+
 	// 1. Parse and verify JWT signature
 	jwtToken, err := jwt.ParseWithClaims(tokenString, &token.JWTAccessClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("unexpected signing method: %v", token.Header["alg"]))
 		}
-		return []byte(tm.secretKey), nil
+		return tm.verificationKeys(), nil
 	})
 
 	if err != nil {
@@ -229,9 +350,17 @@ func (tm *TokenAPI) VerifyAccessToken(tokenString string) (*authv1.AccessTokenCl
 	if !ok {
 		return nil, infra_error.Auth(infra_error.AuthTokenInvalid)
 	}
+	if err := tm.verifyIssuerAndAudience(jwtClaims); err != nil {
+		return nil, err
+	}
 
 	// 3. Verify against Redis storage (CRITICAL!)
-	storedMetadata, err := tm.accessTokenHandler.Validate(jwtClaims.TenantID, jwtClaims.UserID)
+	// The impersonation tokens are stored apart from the token of the user
+	sessionID := jwtClaims.UserID
+	if jwtClaims.Act != nil {
+		sessionID = model_auth.ImpersonationSessionID(jwtClaims.UserID, jwtClaims.Act.Subject)
+	}
+	storedMetadata, err := tm.accessTokenHandler.Validate(ctx, jwtClaims.TenantID, sessionID)
 	if err != nil {
 		tm.logger.Warn("Access token validation failed",
 			"tenantID", jwtClaims.TenantID,
@@ -256,7 +385,16 @@ func (tm *TokenAPI) VerifyAccessToken(tokenString string) (*authv1.AccessTokenCl
 		return nil, infra_error.Auth(infra_error.AuthTokenExpired)
 	}
 
-	// 6. All checks passed - return the claims
+	// 6. Verify the permissions of the user did not change since a minimal token was issued
+	if err := tm.verifyPermissionsVersion(ctx, jwtClaims); err != nil {
+		tm.logger.Info("Access token permissions are outdated",
+			"tenantID", jwtClaims.TenantID,
+			"userID", jwtClaims.UserID,
+			"error", err)
+		return nil, err
+	}
+
+	// 7. All checks passed - return the claims
 	tm.logger.Debug("Access token verified successfully",
 		"tenantID", jwtClaims.TenantID,
 		"userID", jwtClaims.UserID)
@@ -264,8 +402,53 @@ func (tm *TokenAPI) VerifyAccessToken(tokenString string) (*authv1.AccessTokenCl
 	return jwtClaims.ToProtoClaims(), nil
 }
 
+// minimalClaims reports whether the access tokens carry the permissions version of their user instead of the roles
+func (tm *TokenAPI) minimalClaims() bool {
+	return tm.claimsMode == AccessTokenClaimsMinimal && tm.permissionsVersions != nil
+}
+
+// verifyPermissionsVersion rejects the minimal tokens issued before the permissions of their user changed, e.g. by a
+// role edit. The tokens carrying roles are not checked, the services verify their permissions on every call
+func (tm *TokenAPI) verifyPermissionsVersion(ctx context.Context, claims *token.JWTAccessClaims) error {
+	if claims.PermissionsVersion == "" {
+		return nil
+	}
+	if tm.permissionsVersions == nil {
+		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("permissions versions are not available"))
+	}
+	current, err := tm.permissionsVersions.PermissionsVersion(ctx, claims.TenantID, claims.UserID)
+	if err != nil {
+		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
+	}
+	if current != claims.PermissionsVersion {
+		return infra_error.Auth(infra_error.AuthTokenRevoked).WithError(errors.New("permissions changed since the token was issued"))
+	}
+	return nil
+}
+
+// verifyIssuerAndAudience checks the token was issued under the current policy of its tenant,
+// tokens issued before the tenant changed its issuer or audience are rejected
+func (tm *TokenAPI) verifyIssuerAndAudience(claims *token.JWTAccessClaims) error {
+	policy := tm.tokenPolicy(claims.TenantID)
+	if claims.Issuer != policy.GetIssuer() {
+		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("unexpected token issuer"))
+	}
+	if len(policy.GetAudience()) == 0 {
+		return nil
+	}
+	for _, audience := range claims.Audience {
+		if slices.Contains(policy.GetAudience(), audience) {
+			return nil
+		}
+	}
+	return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("unexpected token audience"))
+}
+
 // GenerateRefreshToken generates a new refresh token for the given user
-func (tm *TokenAPI) GenerateRefreshToken(input GenerateRefreshTokenInput) (string, *authv1_cache.RefreshToken, error) {
+func (tm *TokenAPI) GenerateRefreshToken(ctx context.Context, input GenerateRefreshTokenInput) (_ string, _ *authv1_cache.RefreshToken, err error) {
+	ctx, span := tracing.Start(ctx, "TokenAPI.GenerateRefreshToken")
+	defer func() { tracing.End(span, err) }()
+
 	if input.UserId == "" {
 		return "", nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("user_id is required"))
 	}
@@ -275,7 +458,7 @@ func (tm *TokenAPI) GenerateRefreshToken(input GenerateRefreshTokenInput) (strin
 		input.CreatedAt = time.Now()
 	}
 	now := input.CreatedAt
-	expiresAt := now.Add(tm.refreshTokenDuration)
+	expiresAt := now.Add(time.Duration(tm.tokenPolicy(input.TenantId).GetRefreshTokenTtlSeconds()) * time.Second)
 
 	// Generate cryptographically secure random token
 	// 32 bytes = 256 bits of entropy (very secure)
@@ -284,8 +467,9 @@ func (tm *TokenAPI) GenerateRefreshToken(input GenerateRefreshTokenInput) (strin
 		return "", nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
 	}
 
-	// Encode to base64 URL-safe string (no padding)
-	tokenString := base64.RawURLEncoding.EncodeToString(tokenBytes)
+	// Encode to base64 URL-safe string (no padding), prefixed with the token id: <token_id>.<secret>
+	tokenID := uuid.New().String()
+	tokenString := tokenID + "." + base64.RawURLEncoding.EncodeToString(tokenBytes)
 	tokenHash, err := hash.Hash(tokenString)
 	if err != nil {
 		return "", nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
@@ -298,6 +482,13 @@ func (tm *TokenAPI) GenerateRefreshToken(input GenerateRefreshTokenInput) (strin
 		ExpiresAt: timestamppb.New(expiresAt),
 		CreatedAt: timestamppb.New(now),
 		Revoked:   false,
+		TokenId:   tokenID,
+		FamilyId:  uuid.New().String(),
+		DeviceId:  input.DeviceId,
+	}
+	if input.Parent.GetFamilyId() != "" {
+		refreshToken.FamilyId = input.Parent.GetFamilyId()
+		refreshToken.ParentId = input.Parent.GetTokenId()
 	}
 
 	// Validate before storing
@@ -305,15 +496,24 @@ func (tm *TokenAPI) GenerateRefreshToken(input GenerateRefreshTokenInput) (strin
 		return "", nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
 	}
 
-	// Store refresh token in Redis (use tokenString as tokenID)
-	if err := tm.refreshTokenHandler.Store(input.TenantId, input.UserId, refreshToken); err != nil {
+	// The family is recorded first, a token is never handed out without the history needed to detect its reuse
+	if err := tm.storeRefreshTokenFamily(ctx, refreshToken, input.Parent); err != nil {
+		return "", nil, err
+	}
+
+	// Store refresh token in Redis (replaces the rotated token)
+	if err := tm.refreshTokenHandler.Store(ctx, input.TenantId, input.UserId, refreshToken); err != nil {
 		return "", nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
 	}
+	tokensIssued.Inc(TokenTypeRefresh)
 	return tokenString, refreshToken, nil
 }
 
 // VerifyRefreshToken verifies if the given refresh token is valid
-func (tm *TokenAPI) VerifyRefreshToken(tenantID string, userID string, tokenString string) (*authv1_cache.RefreshToken, error) {
+func (tm *TokenAPI) VerifyRefreshToken(ctx context.Context, tenantID string, userID string, tokenString string) (_ *authv1_cache.RefreshToken, err error) {
+	ctx, span := tracing.Start(ctx, "TokenAPI.VerifyRefreshToken")
+	defer func() { tracing.End(span, err) }()
+
 	if tenantID == "" {
 		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("tenantID is required"))
 	}
@@ -324,22 +524,29 @@ func (tm *TokenAPI) VerifyRefreshToken(tenantID string, userID string, tokenStri
 		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("userID is required"))
 	}
 
-	tm.logger.Debug("Verifying refresh token", "tenantID", tenantID, "userID", userID, "token", tokenString)
+	tm.logger.Debug("Verifying refresh token", "tenantID", tenantID, "userID", userID)
 
 	// Validate the token (this also retrieves it)
-	refreshToken, err := tm.refreshTokenHandler.Validate(tenantID, userID)
+	refreshToken, err := tm.refreshTokenHandler.Validate(ctx, tenantID, userID)
 	if err != nil {
 		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
 	}
 
-	// SECURITY: Verify the stored token matches the provided token
-	// This is critical for detecting stolen/old tokens
+	// SECURITY: A token of the current family that was already rotated means the family leaked
+	if presentedID := refreshTokenID(tokenString); refreshToken.GetTokenId() != "" && presentedID != refreshToken.GetTokenId() {
+		return nil, tm.checkRefreshTokenReuse(ctx, refreshToken, tokenString)
+	}
 
-	if valid := hash.VerifyHash(tokenString, refreshToken.TokenHash); !valid {
+	// Verify the stored token matches the provided token
+	valid, err := hash.Verify(ctx, tokenString, refreshToken.TokenHash)
+	if err != nil {
+		// The pool is saturated or the caller gave up, this says nothing about the token so nothing is revoked
+		return nil, err
+	}
+	if !valid {
+		// Nothing is revoked, otherwise anyone knowing a user id could end that user's session
 		tm.logger.Warn("Attempted use of invalid refresh token", "tenantID", tenantID, "userID", userID)
-		// Revoke the current valid token (security measure)
-		_ = tm.RevokeAllTokens(tenantID, userID, "system")
-		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("token mismatch - possible theft detected"))
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("token mismatch"))
 	}
 
 	// Basic validation
@@ -355,30 +562,15 @@ func (tm *TokenAPI) VerifyRefreshToken(tenantID string, userID string, tokenStri
 	// Check if expired
 	if validator_auth.IsExpired(refreshToken) {
 		// Auto-cleanup expired token
-		if err := tm.refreshTokenHandler.Delete(tenantID, userID); err != nil {
+		if err := tm.refreshTokenHandler.Delete(ctx, tenantID, userID); err != nil {
 			return nil, infra_error.Internal(infra_error.InternalDatabaseError, err)
 		}
 		return nil, infra_error.Auth(infra_error.AuthRefreshTokenExpired).WithError(errors.New("token has expired"))
 	}
 
-	// SECURITY: Check for suspicious activity
-	// 1. Check if token is being reused (already used recently)
-	if !refreshToken.LastUsedAt.AsTime().IsZero() {
-		timeSinceLastUse := time.Since(refreshToken.LastUsedAt.AsTime())
-		if timeSinceLastUse < 1*time.Minute {
-			// Token used twice within 1 minute - possible token theft
-			// Revoke all user tokens as security measure
-			tm.logger.Warn("Suspicious: Token reused within 1 minute", "tenantID", tenantID, "userID", userID)
-			if err := tm.RevokeAllTokens(tenantID, refreshToken.UserId, "system"); err != nil {
-				return nil, infra_error.Internal(infra_error.InternalDatabaseError, err)
-			}
-			return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("suspicious activity detected - all sessions terminated"))
-		}
-	}
-
 	// Update last used timestamp with safe type assertion
 	if refreshTokenHandler, ok := tm.refreshTokenHandler.(*handler.RefreshTokenHandler); ok {
-		if err := refreshTokenHandler.UpdateLastUsed(tenantID, userID, tokenString); err != nil {
+		if err := refreshTokenHandler.UpdateLastUsed(ctx, tenantID, userID, tokenString); err != nil {
 			tm.logger.Warn("Failed to update last used timestamp", "error", err)
 		}
 	} else {
@@ -388,6 +580,121 @@ func (tm *TokenAPI) VerifyRefreshToken(tenantID string, userID string, tokenStri
 	return refreshToken, nil
 }
 
+// refreshTokenID returns the token id part of a refresh token, tokens issued before families have none
+func refreshTokenID(tokenString string) string {
+	tokenID, _, found := strings.Cut(tokenString, ".")
+	if !found {
+		return ""
+	}
+	return tokenID
+}
+
+// storeRefreshTokenFamily records refreshToken as the current token of its family,
+// parent, the token it was rotated from, moves to the rotated history
+func (tm *TokenAPI) storeRefreshTokenFamily(ctx context.Context, refreshToken *authv1_cache.RefreshToken, parent *authv1_cache.RefreshToken) error {
+	family := &authv1_cache.RefreshTokenFamily{
+		FamilyId:       refreshToken.GetFamilyId(),
+		TenantId:       refreshToken.GetTenantId(),
+		UserId:         refreshToken.GetUserId(),
+		CurrentTokenId: refreshToken.GetTokenId(),
+		CreatedAt:      refreshToken.GetCreatedAt(),
+		ExpiresAt:      refreshToken.GetExpiresAt(),
+	}
+	if refreshToken.GetParentId() != "" {
+		if existing, err := tm.refreshTokenFamilyHandler.GetOne(ctx, family.TenantId, family.FamilyId); err == nil {
+			family.CreatedAt = existing.GetCreatedAt()
+			family.RotatedTokens = existing.GetRotatedTokens()
+		}
+		family.RotatedTokens = append(family.RotatedTokens, &authv1_cache.RotatedRefreshToken{
+			TokenId:   parent.GetTokenId(),
+			TokenHash: parent.GetTokenHash(),
+		})
+		if len(family.RotatedTokens) > maxRotatedRefreshTokens {
+			family.RotatedTokens = family.RotatedTokens[len(family.RotatedTokens)-maxRotatedRefreshTokens:]
+		}
+	}
+
+	if err := tm.refreshTokenFamilyHandler.Store(ctx, family); err != nil {
+		tm.logger.Error("Failed to store refresh token family", "error", err, "tenantID", family.TenantId, "userID", family.UserId)
+		return infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+	return nil
+}
+
+// checkRefreshTokenReuse handles a refresh token that is not the current token of the user.
+// A token rotated out of the current family revokes the family and the session, anything else is just invalid.
+// The presented token must match the hash of the rotated one, a token id alone never revokes a session
+func (tm *TokenAPI) checkRefreshTokenReuse(ctx context.Context, current *authv1_cache.RefreshToken, tokenString string) error {
+	tenantID, userID := current.GetTenantId(), current.GetUserId()
+	presentedID := refreshTokenID(tokenString)
+	family, err := tm.refreshTokenFamilyHandler.GetOne(ctx, tenantID, current.GetFamilyId())
+	var rotated *authv1_cache.RotatedRefreshToken
+	if err == nil && presentedID != "" {
+		for _, token := range family.GetRotatedTokens() {
+			if token.GetTokenId() == presentedID {
+				rotated = token
+				break
+			}
+		}
+	}
+	if rotated == nil {
+		tm.logger.Warn("Attempted use of unknown refresh token", "tenantID", tenantID, "userID", userID)
+		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("token mismatch"))
+	}
+	valid, err := hash.Verify(ctx, tokenString, rotated.GetTokenHash())
+	if err != nil {
+		// The pool is saturated or the caller gave up, this says nothing about the token so nothing is revoked
+		return err
+	}
+	if !valid {
+		tm.logger.Warn("Attempted use of invalid refresh token", "tenantID", tenantID, "userID", userID)
+		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("token mismatch"))
+	}
+
+	tm.logger.Warn("Refresh token reuse detected, revoking token family", "tenantID", tenantID, "userID", userID, "familyID", family.GetFamilyId(), "tokenID", presentedID)
+	if err := tm.RevokeAllTokens(ctx, tenantID, userID, "system"); err != nil {
+		return infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+	if err := tm.refreshTokenFamilyHandler.Delete(ctx, tenantID, family.GetFamilyId()); err != nil {
+		tm.logger.Warn("Failed to delete revoked refresh token family", "error", err, "tenantID", tenantID, "familyID", family.GetFamilyId())
+	}
+	tm.auditRefreshTokenReuse(ctx, family, presentedID)
+
+	return infra_error.Auth(infra_error.AuthTokenRevoked).WithError(errors.New("refresh token reuse detected - session revoked"))
+}
+
+// auditRefreshTokenReuse writes the revocation of a reused token family to the audit log
+func (tm *TokenAPI) auditRefreshTokenReuse(ctx context.Context, family *authv1_cache.RefreshTokenFamily, reusedTokenID string) {
+	if tm.auditLogs == nil {
+		return
+	}
+	metadata, err := structpb.NewStruct(map[string]any{
+		"user_id":          family.GetUserId(),
+		"reused_token_id":  reusedTokenID,
+		"current_token_id": family.GetCurrentTokenId(),
+		"rotated_tokens":   len(family.GetRotatedTokens()),
+	})
+	if err != nil {
+		tm.logger.Warn("Failed to build audit log metadata", "error", err)
+	}
+	auditLog := &eventv1.AuditLog{
+		Id:         uuid.New().String(),
+		Category:   model_event.CategorySecurity,
+		Action:     model_event.ActionTokenTheftSuspected,
+		Severity:   model_event.SeverityCritical,
+		ActorId:    "system",
+		ActorType:  model_event.ActorTypeSystem,
+		TargetId:   family.GetFamilyId(),
+		TargetType: model_event.TargetTypeToken,
+		Result:     model_event.ResultSuccess,
+		Message:    "rotated refresh token presented again, token family and session revoked",
+		Metadata:   metadata,
+	}
+	if err := tm.auditLogs.CreateAuditLog(ctx, family.GetTenantId(), auditLog); err != nil {
+		tm.logger.Error("Failed to write token reuse audit log", "error", err, "tenantID", family.GetTenantId(), "familyID", family.GetFamilyId())
+	}
+}
+
 // ============================================================================
 // REDIS TOKEN STORAGE OPERATIONS
 // ============================================================================
@@ -395,20 +702,20 @@ func (tm *TokenAPI) VerifyRefreshToken(tenantID string, userID string, tokenStri
 // StoreTokens stores both access and refresh tokens in Redis
 // This is typically called after successful authentication
 // Single token per user - automatically replaces any existing tokens
-func (tm *TokenAPI) StoreTokens(tenantID string, userID string, accessTokenMetadata *authv1_cache.TokenMetadata, refreshToken *authv1_cache.RefreshToken) error {
+func (tm *TokenAPI) StoreTokens(ctx context.Context, tenantID string, userID string, accessTokenMetadata *authv1_cache.TokenMetadata, refreshToken *authv1_cache.RefreshToken) error {
 	tm.logger.Info("Storing token pair (single token per user - replaces existing)", "tenantID", tenantID, "userID", userID)
 
 	// Store access token (automatically replaces existing)
-	if err := tm.accessTokenHandler.Store(tenantID, userID, accessTokenMetadata); err != nil {
+	if err := tm.accessTokenHandler.Store(ctx, tenantID, userID, accessTokenMetadata); err != nil {
 		tm.logger.Error("Failed to store access token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
 	}
 
 	// Store refresh token (automatically replaces existing)
-	if err := tm.refreshTokenHandler.Store(tenantID, userID, refreshToken); err != nil {
+	if err := tm.refreshTokenHandler.Store(ctx, tenantID, userID, refreshToken); err != nil {
 		// If refresh token storage fails, try to clean up access token
 		tm.logger.Error("Failed to store refresh token", "error", err, "tenantID", tenantID, "userID", userID)
-		_ = tm.accessTokenHandler.Delete(tenantID, userID)
+		_ = tm.accessTokenHandler.Delete(ctx, tenantID, userID)
 		return err
 	}
 
@@ -416,14 +723,35 @@ func (tm *TokenAPI) StoreTokens(tenantID string, userID string, accessTokenMetad
 	return nil
 }
 
+// StoreImpersonationToken stores the access token of an impersonation session under its session id, the token of
+// the impersonated user is kept and no refresh token is issued
+func (tm *TokenAPI) StoreImpersonationToken(ctx context.Context, tenantID string, accessTokenMetadata *authv1_cache.TokenMetadata) error {
+	if err := tm.accessTokenHandler.Store(ctx, tenantID, accessTokenMetadata.GetUserId(), accessTokenMetadata); err != nil {
+		tm.logger.Error("Failed to store impersonation token", "error", err, "tenantID", tenantID, "sessionID", accessTokenMetadata.GetUserId())
+		return err
+	}
+	return nil
+}
+
+// RevokeImpersonationToken revokes the access token of actorID impersonating userID
+func (tm *TokenAPI) RevokeImpersonationToken(ctx context.Context, tenantID, userID, actorID, revokedBy string) error {
+	sessionID := model_auth.ImpersonationSessionID(userID, actorID)
+	if err := tm.accessTokenHandler.Revoke(ctx, tenantID, sessionID, revokedBy); err != nil {
+		tm.logger.Error("Failed to revoke impersonation token", "error", err, "tenantID", tenantID, "sessionID", sessionID)
+		return err
+	}
+	tokensRevoked.Inc(TokenTypeAccess)
+	return nil
+}
+
 // ValidateAccessTokenFromRedis validates an access token from Redis
-func (tm *TokenAPI) ValidateAccessTokenFromRedis(tenantID string, userID string) (*authv1_cache.TokenMetadata, error) {
-	return tm.accessTokenHandler.Validate(tenantID, userID)
+func (tm *TokenAPI) ValidateAccessTokenFromRedis(ctx context.Context, tenantID string, userID string) (*authv1_cache.TokenMetadata, error) {
+	return tm.accessTokenHandler.Validate(ctx, tenantID, userID)
 }
 
 // ValidateRefreshTokenFromRedis validates a refresh token from Redis
-func (tm *TokenAPI) ValidateRefreshTokenFromRedis(tenantID string, userID string) (*authv1_cache.RefreshToken, error) {
-	return tm.refreshTokenHandler.Validate(tenantID, userID)
+func (tm *TokenAPI) ValidateRefreshTokenFromRedis(ctx context.Context, tenantID string, userID string) (*authv1_cache.RefreshToken, error) {
+	return tm.refreshTokenHandler.Validate(ctx, tenantID, userID)
 }
 
 // // RevokeAccessTokenFromRedis revokes a single access token in Redis
@@ -438,11 +766,12 @@ func (tm *TokenAPI) ValidateRefreshTokenFromRedis(tenantID string, userID string
 
 // RevokeAllAccessTokens revokes the access token for a user (but not refresh token)
 // This is typically called during token refresh to prevent orphaned access tokens
-func (tm *TokenAPI) RevokeAllAccessTokens(tenantID string, userID string, revokedBy string) error {
-	if err := tm.accessTokenHandler.Revoke(tenantID, userID, revokedBy); err != nil {
+func (tm *TokenAPI) RevokeAllAccessTokens(ctx context.Context, tenantID string, userID string, revokedBy string) error {
+	if err := tm.accessTokenHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
 		tm.logger.Error("Failed to revoke access token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
 	}
+	tokensRevoked.Inc(TokenTypeAccess)
 
 	tm.logger.Debug("Access token revoked", "tenantID", tenantID, "userID", userID, "revokedBy", revokedBy)
 	return nil
@@ -450,18 +779,24 @@ func (tm *TokenAPI) RevokeAllAccessTokens(tenantID string, userID string, revoke
 
 // RevokeAllTokens revokes all tokens (both access and refresh) for a user
 // This is typically called on logout or security incidents
-func (tm *TokenAPI) RevokeAllTokens(tenantID string, userID string, revokedBy string) error {
+func (tm *TokenAPI) RevokeAllTokens(ctx context.Context, tenantID string, userID string, revokedBy string) (err error) {
+	ctx, span := tracing.Start(ctx, "TokenAPI.RevokeAllTokens")
+	defer func() { tracing.End(span, err) }()
+
 	// Revoke access token
-	if err := tm.accessTokenHandler.Revoke(tenantID, userID, revokedBy); err != nil {
+	if err := tm.accessTokenHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
 		tm.logger.Error("Failed to revoke access token", "error", err, "tenantID", tenantID, "userID", userID)
 		// Continue with refresh token even if access token fails
+	} else {
+		tokensRevoked.Inc(TokenTypeAccess)
 	}
 
 	// Revoke refresh token
-	if err := tm.refreshTokenHandler.Revoke(tenantID, userID, revokedBy); err != nil {
+	if err := tm.refreshTokenHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
 		tm.logger.Error("Failed to revoke refresh token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
 	}
+	tokensRevoked.Inc(TokenTypeRefresh)
 
 	tm.logger.Debug("All tokens revoked", "tenantID", tenantID, "userID", userID, "revokedBy", revokedBy)
 	return nil
@@ -488,30 +823,30 @@ func (tm *TokenAPI) RevokeAllTokens(tenantID string, userID string, revokedBy st
 // }
 
 // UpdateRefreshTokenLastUsed updates the last used timestamp for a refresh token
-func (tm *TokenAPI) UpdateRefreshTokenLastUsed(tenantID string, userID string, tokenString string) error {
+func (tm *TokenAPI) UpdateRefreshTokenLastUsed(ctx context.Context, tenantID string, userID string, tokenString string) error {
 	if refreshTokenHandler, ok := tm.refreshTokenHandler.(*handler.RefreshTokenHandler); ok {
-		return refreshTokenHandler.UpdateLastUsed(tenantID, userID, tokenString)
+		return refreshTokenHandler.UpdateLastUsed(ctx, tenantID, userID, tokenString)
 	}
 	tm.logger.Debug("UpdateLastUsed not available for this token handler implementation")
 	return nil
 }
 
 // DeleteAccessTokenFromRedis permanently deletes an access token from Redis
-func (tm *TokenAPI) DeleteAccessTokenFromRedis(tenantID string, userID string) error {
-	return tm.accessTokenHandler.Delete(tenantID, userID)
+func (tm *TokenAPI) DeleteAccessTokenFromRedis(ctx context.Context, tenantID string, userID string) error {
+	return tm.accessTokenHandler.Delete(ctx, tenantID, userID)
 }
 
 // DeleteRefreshTokenFromRedis permanently deletes a refresh token from Redis
-func (tm *TokenAPI) DeleteRefreshTokenFromRedis(tenantID string, userID string) error {
-	return tm.refreshTokenHandler.Delete(tenantID, userID)
+func (tm *TokenAPI) DeleteRefreshTokenFromRedis(ctx context.Context, tenantID string, userID string) error {
+	return tm.refreshTokenHandler.Delete(ctx, tenantID, userID)
 }
 
 // RevokeAccessToken revokes a JWT access token (legacy method for compatibility)
-func (tm *TokenAPI) RevokeAccessToken(tokenString string, revokedBy string) error {
+func (tm *TokenAPI) RevokeAccessToken(ctx context.Context, tokenString string, revokedBy string) error {
 	if tokenString == "" {
 		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("token is required"))
 	}
-	metadata, err := tm.GetTokenMetadata(tokenString)
+	metadata, err := tm.GetTokenMetadata(ctx, tokenString)
 	if err != nil {
 		return err
 	}
@@ -524,43 +859,74 @@ func (tm *TokenAPI) RevokeAccessToken(tokenString string, revokedBy string) erro
 	if metadata.RevokedAt != nil && metadata.RevokedAt.AsTime().Before(time.Now()) {
 		return infra_error.Auth(infra_error.AuthTokenRevoked).WithError(errors.New("access token has been revoked"))
 	}
-	if err := tm.accessTokenHandler.Revoke(metadata.TenantId, metadata.UserId, revokedBy); err != nil {
+	if err := tm.accessTokenHandler.Revoke(ctx, metadata.TenantId, metadata.UserId, revokedBy); err != nil {
 		return err
 	}
+	tokensRevoked.Inc(TokenTypeAccess)
 	return nil
 }
 
 // RevokeRefreshToken revokes a refresh token (legacy method for compatibility)
-func (tm *TokenAPI) RevokeRefreshToken(tenantID string, userID string, tokenString string, revokedBy string, skipVerification bool) error {
+func (tm *TokenAPI) RevokeRefreshToken(ctx context.Context, tenantID string, userID string, tokenString string, revokedBy string, skipVerification bool) error {
 	if tokenString == "" || tenantID == "" || userID == "" {
 		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("token, tenantID, and userID are required"))
 	}
 	if !skipVerification {
 		// Verify token exists and is valid
-		_, err := tm.VerifyRefreshToken(tenantID, userID, tokenString)
+		_, err := tm.VerifyRefreshToken(ctx, tenantID, userID, tokenString)
 		if err != nil {
 			tm.logger.Error("Failed to verify refresh token", "error", err, "tenantID", tenantID, "userID", userID, "token", tokenString)
 			return err
 		}
 	}
 	// Revoke the token
-	if err := tm.refreshTokenHandler.Revoke(tenantID, userID, revokedBy); err != nil {
+	if err := tm.refreshTokenHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
 		tm.logger.Error("Failed to revoke refresh token", "error", err, "tenantID", tenantID, "userID", userID, "token", tokenString, "requestBy", revokedBy)
 		return infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
 	}
+	tokensRevoked.Inc(TokenTypeRefresh)
 	tm.logger.Info("Refresh token revoked", "tenantID", tenantID, "userID", userID, "token", tokenString, "requestBy", revokedBy)
 	return nil
 }
 
+// RevokeDeviceTokens revokes the access and refresh tokens of a user issued on the device of fingerprint deviceID
+// and reports whether any was. The tokens of the other devices of the user are kept
+func (tm *TokenAPI) RevokeDeviceTokens(ctx context.Context, tenantID, userID, deviceID, revokedBy string) (bool, error) {
+	if tenantID == "" || userID == "" || deviceID == "" {
+		return false, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId", "DeviceId")
+	}
+	revoked := false
+	// A missing token has nothing to revoke
+	if accessToken, err := tm.accessTokenHandler.GetOne(ctx, tenantID, userID); err == nil && accessToken.GetDeviceId() == deviceID {
+		if err := tm.accessTokenHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
+			tm.logger.Error("Failed to revoke device access token", "error", err, "tenantID", tenantID, "userID", userID, "deviceID", deviceID)
+			return false, err
+		}
+		tokensRevoked.Inc(TokenTypeAccess)
+		revoked = true
+	}
+	if refreshToken, err := tm.refreshTokenHandler.GetOne(ctx, tenantID, userID); err == nil && refreshToken.GetDeviceId() == deviceID {
+		if err := tm.refreshTokenHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
+			tm.logger.Error("Failed to revoke device refresh token", "error", err, "tenantID", tenantID, "userID", userID, "deviceID", deviceID)
+			return revoked, err
+		}
+		tokensRevoked.Inc(TokenTypeRefresh)
+		revoked = true
+	}
+	tm.logger.Debug("Device tokens revoked", "tenantID", tenantID, "userID", userID, "deviceID", deviceID, "revoked", revoked, "revokedBy", revokedBy)
+	return revoked, nil
+}
+
 // RevokeAllUserRefreshTokens revokes all refresh tokens for a user (legacy method for compatibility)
-func (tm *TokenAPI) RevokeAllUserRefreshTokens(tenantID string, userID string, requestBy string) error {
+func (tm *TokenAPI) RevokeAllUserRefreshTokens(ctx context.Context, tenantID string, userID string, requestBy string) error {
 	if userID == "" || tenantID == "" {
 		return errors.New("user_id and tenant_id are required")
 	}
 
-	if err := tm.refreshTokenHandler.Revoke(tenantID, userID, requestBy); err != nil {
+	if err := tm.refreshTokenHandler.Revoke(ctx, tenantID, userID, requestBy); err != nil {
 		return err
 	}
+	tokensRevoked.Inc(TokenTypeRefresh)
 
 	return nil
 }
@@ -568,7 +934,10 @@ func (tm *TokenAPI) RevokeAllUserRefreshTokens(tenantID string, userID string, r
 // RevokeAllTenantTokens revokes all tokens for ALL users in a tenant
 // This is used for tenant suspension or security incidents
 // Returns the number of access and refresh tokens revoked
-func (tm *TokenAPI) RevokeAllTenantTokens(tenantID string, revokedBy string) (int, int, error) {
+func (tm *TokenAPI) RevokeAllTenantTokens(ctx context.Context, tenantID string, revokedBy string) (_ int, _ int, err error) {
+	ctx, span := tracing.Start(ctx, "TokenAPI.RevokeAllTenantTokens")
+	defer func() { tracing.End(span, err) }()
+
 	if tenantID == "" {
 		return 0, 0, infra_error.Validation(infra_error.ValidationRequiredFields, "tenantID")
 	}
@@ -592,7 +961,7 @@ func (tm *TokenAPI) RevokeAllTenantTokens(tenantID string, revokedBy string) (in
 	}
 
 	// Scan all access token keys for this tenant
-	accessKeys, err := accessHandler.ScanKeys(tenantID)
+	accessKeys, err := accessHandler.ScanKeys(ctx, tenantID)
 	if err != nil {
 		tm.logger.Error("Failed to scan access tokens", "error", err, "tenantID", tenantID)
 		// Continue with refresh tokens even if this fails
@@ -604,7 +973,7 @@ func (tm *TokenAPI) RevokeAllTenantTokens(tenantID string, revokedBy string) (in
 			parts := parseRedisKey(key)
 			if len(parts) >= 2 {
 				userID := parts[len(parts)-1]
-				if err := accessHandler.Revoke(tenantID, userID, revokedBy); err != nil {
+				if err := accessHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
 					tm.logger.Warn("Failed to revoke access token", "error", err, "tenantID", tenantID, "userID", userID)
 				} else {
 					accessTokensRevoked++
@@ -614,7 +983,7 @@ func (tm *TokenAPI) RevokeAllTenantTokens(tenantID string, revokedBy string) (in
 	}
 
 	// Scan all refresh token keys for this tenant
-	refreshKeys, err := refreshHandler.ScanKeys(tenantID)
+	refreshKeys, err := refreshHandler.ScanKeys(ctx, tenantID)
 	if err != nil {
 		tm.logger.Error("Failed to scan refresh tokens", "error", err, "tenantID", tenantID)
 		return accessTokensRevoked, refreshTokensRevoked, err
@@ -626,7 +995,7 @@ func (tm *TokenAPI) RevokeAllTenantTokens(tenantID string, revokedBy string) (in
 		parts := parseRedisKey(key)
 		if len(parts) >= 2 {
 			userID := parts[len(parts)-1]
-			if err := refreshHandler.Revoke(tenantID, userID, revokedBy); err != nil {
+			if err := refreshHandler.Revoke(ctx, tenantID, userID, revokedBy); err != nil {
 				tm.logger.Warn("Failed to revoke refresh token", "error", err, "tenantID", tenantID, "userID", userID)
 			} else {
 				refreshTokensRevoked++
@@ -634,6 +1003,8 @@ func (tm *TokenAPI) RevokeAllTenantTokens(tenantID string, revokedBy string) (in
 		}
 	}
 
+	tokensRevoked.Add(float64(accessTokensRevoked), TokenTypeAccess)
+	tokensRevoked.Add(float64(refreshTokensRevoked), TokenTypeRefresh)
 	tm.logger.Info("All tenant tokens revoked", "tenantID", tenantID, "accessTokensRevoked", accessTokensRevoked, "refreshTokensRevoked", refreshTokensRevoked)
 	return accessTokensRevoked, refreshTokensRevoked, nil
 }
@@ -641,7 +1012,7 @@ func (tm *TokenAPI) RevokeAllTenantTokens(tenantID string, revokedBy string) (in
 // DeleteAllTenantTokens permanently deletes all tokens for ALL users in a tenant
 // This is used for tenant deletion (cascade cleanup)
 // Returns the number of access and refresh tokens deleted
-func (tm *TokenAPI) DeleteAllTenantTokens(tenantID string) (int, int, error) {
+func (tm *TokenAPI) DeleteAllTenantTokens(ctx context.Context, tenantID string) (int, int, error) {
 	if tenantID == "" {
 		return 0, 0, infra_error.Validation(infra_error.ValidationRequiredFields, "tenantID")
 	}
@@ -660,14 +1031,14 @@ func (tm *TokenAPI) DeleteAllTenantTokens(tenantID string) (int, int, error) {
 	}
 
 	// Delete all access tokens using pattern
-	accessCount, err := accessHandler.DeleteByPattern(tenantID, "")
+	accessCount, err := accessHandler.DeleteByPattern(ctx, tenantID, "")
 	if err != nil {
 		tm.logger.Error("Failed to delete access tokens by pattern", "error", err, "tenantID", tenantID)
 		// Continue with refresh tokens
 	}
 
 	// Delete all refresh tokens using pattern
-	refreshCount, err := refreshHandler.DeleteByPattern(tenantID, "")
+	refreshCount, err := refreshHandler.DeleteByPattern(ctx, tenantID, "")
 	if err != nil {
 		tm.logger.Error("Failed to delete refresh tokens by pattern", "error", err, "tenantID", tenantID)
 		return accessCount, refreshCount, err
@@ -699,7 +1070,7 @@ func parseRedisKey(key string) []string {
 	return result
 }
 
-func (tm *TokenAPI) GetTokenMetadata(accessTokenString string) (*authv1_cache.TokenMetadata, error) {
+func (tm *TokenAPI) GetTokenMetadata(ctx context.Context, accessTokenString string) (*authv1_cache.TokenMetadata, error) {
 	if accessTokenString == "" {
 		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("empty access token"))
 	}
@@ -709,7 +1080,7 @@ func (tm *TokenAPI) GetTokenMetadata(accessTokenString string) (*authv1_cache.To
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("invalid signing method"))
 		}
-		return []byte(tm.secretKey), nil
+		return tm.verificationKeys(), nil
 	})
 	if err != nil {
 		return nil, err
@@ -732,7 +1103,7 @@ func (tm *TokenAPI) GetTokenMetadata(accessTokenString string) (*authv1_cache.To
 		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("tenant_id is required"))
 	}
 	// Get the single access token for this user
-	accessTokenMetadata, err := tm.accessTokenHandler.GetOne(claims.TenantId, claims.UserId)
+	accessTokenMetadata, err := tm.accessTokenHandler.GetOne(ctx, claims.TenantId, claims.UserId)
 	if err != nil {
 		return nil, err
 	}
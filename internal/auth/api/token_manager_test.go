@@ -1,14 +1,18 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
 	mock_token "erp.localhost/internal/auth/handler/mock"
+	"erp.localhost/internal/auth/hash"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
 	"erp.localhost/internal/infra/model/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,20 +109,20 @@ func TestTokenManager_StoreTokens(t *testing.T) {
 
 			if tc.expectedAccessStoreCalls > 0 {
 				accessMock.EXPECT().
-					Store(tc.tenantID, tc.userID, tc.accessTokenMetadata).
+					Store(gomock.Any(), tc.tenantID, tc.userID, tc.accessTokenMetadata).
 					Return(tc.accessStoreError).
 					Times(tc.expectedAccessStoreCalls)
 			}
 
 			if tc.expectedRefreshStoreCalls > 0 {
 				refreshMock.EXPECT().
-					Store(tc.tenantID, tc.userID, tc.refreshToken).
+					Store(gomock.Any(), tc.tenantID, tc.userID, tc.refreshToken).
 					Return(tc.refreshStoreError).
 					Times(tc.expectedRefreshStoreCalls)
 			}
 			if tc.expectedDeleteCalls > 0 {
 				accessMock.EXPECT().
-					Delete(tc.tenantID, tc.userID).
+					Delete(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.deleteError).
 					Times(tc.expectedDeleteCalls)
 			}
@@ -129,7 +133,7 @@ func TestTokenManager_StoreTokens(t *testing.T) {
 				logger:              logger.NewBaseLogger(shared.ModuleAuth),
 			}
 
-			err := tm.StoreTokens(
+			err := tm.StoreTokens(context.Background(),
 				tc.tenantID, tc.userID,
 				tc.accessTokenMetadata, tc.refreshToken,
 			)
@@ -186,7 +190,7 @@ func TestTokenManager_ValidateAccessToken(t *testing.T) {
 			mock := mock_token.NewMockTokenHandler[authv1_cache.TokenMetadata](ctrl)
 			if tc.expectedValidateCallTimes > 0 {
 				mock.EXPECT().
-					Validate(tc.tenantID, tc.userID).
+					Validate(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.returnMetadata, tc.returnError).
 					Times(tc.expectedValidateCallTimes)
 			}
@@ -196,7 +200,7 @@ func TestTokenManager_ValidateAccessToken(t *testing.T) {
 				logger:             logger.NewBaseLogger(shared.ModuleAuth),
 			}
 
-			metadata, err := tm.ValidateAccessTokenFromRedis(tc.tenantID, tc.userID)
+			metadata, err := tm.ValidateAccessTokenFromRedis(context.Background(), tc.tenantID, tc.userID)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -252,7 +256,7 @@ func TestTokenManager_ValidateRefreshToken(t *testing.T) {
 			mock := mock_token.NewMockTokenHandler[authv1_cache.RefreshToken](ctrl)
 			if tc.expectedValidateCallTimes > 0 {
 				mock.EXPECT().
-					Validate(tc.tenantID, tc.userID).
+					Validate(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.returnToken, tc.returnError).
 					Times(tc.expectedValidateCallTimes)
 			}
@@ -262,7 +266,7 @@ func TestTokenManager_ValidateRefreshToken(t *testing.T) {
 				logger:              logger.NewBaseLogger(shared.ModuleAuth),
 			}
 
-			token, err := tm.ValidateRefreshTokenFromRedis(tc.tenantID, tc.userID)
+			token, err := tm.ValidateRefreshTokenFromRedis(context.Background(), tc.tenantID, tc.userID)
 
 			if tc.wantErr {
 				require.Error(t, err)
@@ -275,6 +279,196 @@ func TestTokenManager_ValidateRefreshToken(t *testing.T) {
 	}
 }
 
+// fakeRefreshTokenFamilyStore keeps refresh token families in memory
+type fakeRefreshTokenFamilyStore struct {
+	families map[string]*authv1_cache.RefreshTokenFamily
+}
+
+func newFakeRefreshTokenFamilyStore(families ...*authv1_cache.RefreshTokenFamily) *fakeRefreshTokenFamilyStore {
+	store := &fakeRefreshTokenFamilyStore{families: map[string]*authv1_cache.RefreshTokenFamily{}}
+	for _, family := range families {
+		store.families[family.GetFamilyId()] = family
+	}
+	return store
+}
+
+func (s *fakeRefreshTokenFamilyStore) Store(_ context.Context, family *authv1_cache.RefreshTokenFamily) error {
+	s.families[family.GetFamilyId()] = family
+	return nil
+}
+
+func (s *fakeRefreshTokenFamilyStore) GetOne(_ context.Context, _ string, familyID string) (*authv1_cache.RefreshTokenFamily, error) {
+	family, ok := s.families[familyID]
+	if !ok {
+		return nil, infra_error.NotFound(infra_error.NotFoundResource, "refresh_token_family", familyID)
+	}
+	return family, nil
+}
+
+func (s *fakeRefreshTokenFamilyStore) Delete(_ context.Context, _ string, familyID string) error {
+	delete(s.families, familyID)
+	return nil
+}
+
+type fakeAuditLogWriter struct {
+	logs []*eventv1.AuditLog
+}
+
+func (w *fakeAuditLogWriter) CreateAuditLog(_ context.Context, tenantID string, auditLog *eventv1.AuditLog) error {
+	auditLog.TenantId = tenantID
+	w.logs = append(w.logs, auditLog)
+	return nil
+}
+
+func TestTokenManager_GenerateRefreshToken_Rotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	refreshMock := mock_token.NewMockTokenHandler[authv1_cache.RefreshToken](ctrl)
+	refreshMock.EXPECT().Store(gomock.Any(), "tenant-1", "user-1", gomock.Any()).Return(nil).Times(2)
+	families := newFakeRefreshTokenFamilyStore()
+
+	tm := &TokenAPI{
+		refreshTokenDuration:      time.Hour,
+		refreshTokenHandler:       refreshMock,
+		refreshTokenFamilyHandler: families,
+		logger:                    logger.NewBaseLogger(shared.ModuleAuth),
+	}
+
+	firstString, first, err := tm.GenerateRefreshToken(context.Background(), GenerateRefreshTokenInput{UserId: "user-1", TenantId: "tenant-1"})
+	require.NoError(t, err)
+	assert.Equal(t, first.GetTokenId(), refreshTokenID(firstString))
+	assert.Empty(t, first.GetParentId())
+	require.Contains(t, families.families, first.GetFamilyId())
+	assert.Equal(t, first.GetTokenId(), families.families[first.GetFamilyId()].GetCurrentTokenId())
+
+	_, second, err := tm.GenerateRefreshToken(context.Background(), GenerateRefreshTokenInput{UserId: "user-1", TenantId: "tenant-1", Parent: first})
+	require.NoError(t, err)
+	assert.Equal(t, first.GetFamilyId(), second.GetFamilyId())
+	assert.Equal(t, first.GetTokenId(), second.GetParentId())
+	assert.NotEqual(t, first.GetTokenId(), second.GetTokenId())
+
+	family := families.families[first.GetFamilyId()]
+	assert.Equal(t, second.GetTokenId(), family.GetCurrentTokenId())
+	require.Len(t, family.GetRotatedTokens(), 1)
+	assert.Equal(t, first.GetTokenId(), family.GetRotatedTokens()[0].GetTokenId())
+	assert.Equal(t, first.GetTokenHash(), family.GetRotatedTokens()[0].GetTokenHash())
+}
+
+func TestTokenManager_VerifyRefreshToken_Reuse(t *testing.T) {
+	const (
+		currentTokenID = "token-3"
+		currentString  = currentTokenID + ".secret"
+	)
+	currentHash, err := hash.Hash(currentString)
+	require.NoError(t, err)
+	rotated := make([]*authv1_cache.RotatedRefreshToken, 0, 2)
+	for _, tokenID := range []string{"token-1", "token-2"} {
+		tokenHash, err := hash.Hash(tokenID + ".secret")
+		require.NoError(t, err)
+		rotated = append(rotated, &authv1_cache.RotatedRefreshToken{TokenId: tokenID, TokenHash: tokenHash})
+	}
+
+	testCases := []struct {
+		name        string
+		token       string
+		wantErrCode string
+		wantRevoked bool
+	}{
+		{
+			name:  "current token",
+			token: currentString,
+		},
+		{
+			name:        "rotated token presented again",
+			token:       "token-1.secret",
+			wantErrCode: infra_error.AuthTokenRevoked.Code,
+			wantRevoked: true,
+		},
+		{
+			name:        "rotated token id with wrong secret",
+			token:       "token-1.guessed",
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+		{
+			name:        "unknown token",
+			token:       "token-9.secret",
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+		{
+			name:        "current token id with wrong secret",
+			token:       currentTokenID + ".other",
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+		{
+			name:        "token without id",
+			token:       "secret",
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			accessMock := mock_token.NewMockTokenHandler[authv1_cache.TokenMetadata](ctrl)
+			refreshMock := mock_token.NewMockTokenHandler[authv1_cache.RefreshToken](ctrl)
+			refreshMock.EXPECT().Validate(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.RefreshToken{
+				UserId:    "user-1",
+				TenantId:  "tenant-1",
+				TokenHash: currentHash,
+				TokenId:   currentTokenID,
+				FamilyId:  "family-1",
+				ParentId:  "token-2",
+				CreatedAt: timestamppb.Now(),
+				ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+			}, nil)
+			if tc.wantRevoked {
+				accessMock.EXPECT().Revoke(gomock.Any(), "tenant-1", "user-1", "system").Return(nil)
+				refreshMock.EXPECT().Revoke(gomock.Any(), "tenant-1", "user-1", "system").Return(nil)
+			}
+			families := newFakeRefreshTokenFamilyStore(&authv1_cache.RefreshTokenFamily{
+				FamilyId:       "family-1",
+				TenantId:       "tenant-1",
+				UserId:         "user-1",
+				CurrentTokenId: currentTokenID,
+				RotatedTokens:  rotated,
+				ExpiresAt:      timestamppb.New(time.Now().Add(time.Hour)),
+			})
+			auditLogs := &fakeAuditLogWriter{}
+
+			tm := &TokenAPI{
+				accessTokenHandler:        accessMock,
+				refreshTokenHandler:       refreshMock,
+				refreshTokenFamilyHandler: families,
+				auditLogs:                 auditLogs,
+				logger:                    logger.NewBaseLogger(shared.ModuleAuth),
+			}
+
+			token, err := tm.VerifyRefreshToken(context.Background(), "tenant-1", "user-1", tc.token)
+			if tc.wantErrCode == "" {
+				require.NoError(t, err)
+				assert.Equal(t, currentTokenID, token.GetTokenId())
+				assert.Empty(t, auditLogs.logs)
+				return
+			}
+			require.Error(t, err)
+			appErr, ok := err.(*infra_error.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tc.wantErrCode, appErr.Code)
+
+			if !tc.wantRevoked {
+				assert.Contains(t, families.families, "family-1")
+				assert.Empty(t, auditLogs.logs)
+				return
+			}
+			assert.NotContains(t, families.families, "family-1")
+			require.Len(t, auditLogs.logs, 1)
+			assert.Equal(t, "tenant-1", auditLogs.logs[0].GetTenantId())
+			assert.Equal(t, model_event.ActionTokenTheftSuspected, auditLogs.logs[0].GetAction())
+			assert.Equal(t, model_event.SeverityCritical, auditLogs.logs[0].GetSeverity())
+			assert.Equal(t, "family-1", auditLogs.logs[0].GetTargetId())
+		})
+	}
+}
+
 /*func TestTokenManager_RevokeAllTokens(t *testing.T) {
 	testCases := []struct {
 		name                       string
@@ -349,3 +543,106 @@ func TestTokenManager_ValidateRefreshToken(t *testing.T) {
 		})
 	}
 }*/
+
+func TestTokenManager_RevokeDeviceTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	accessMock := mock_token.NewMockTokenHandler[authv1_cache.TokenMetadata](ctrl)
+	refreshMock := mock_token.NewMockTokenHandler[authv1_cache.RefreshToken](ctrl)
+	tm := &TokenAPI{
+		accessTokenHandler:  accessMock,
+		refreshTokenHandler: refreshMock,
+		logger:              logger.NewBaseLogger(shared.ModuleAuth),
+	}
+	ctx := context.Background()
+
+	// The session of another device is kept
+	accessMock.EXPECT().GetOne(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.TokenMetadata{DeviceId: "phone"}, nil)
+	refreshMock.EXPECT().GetOne(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.RefreshToken{DeviceId: "phone"}, nil)
+	revoked, err := tm.RevokeDeviceTokens(ctx, "tenant-1", "user-1", "laptop", "admin-1")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	accessMock.EXPECT().GetOne(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.TokenMetadata{DeviceId: "laptop"}, nil)
+	accessMock.EXPECT().Revoke(gomock.Any(), "tenant-1", "user-1", "admin-1").Return(nil)
+	refreshMock.EXPECT().GetOne(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.RefreshToken{DeviceId: "laptop"}, nil)
+	refreshMock.EXPECT().Revoke(gomock.Any(), "tenant-1", "user-1", "admin-1").Return(nil)
+	revoked, err = tm.RevokeDeviceTokens(ctx, "tenant-1", "user-1", "laptop", "admin-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+// fakePermissionsVersions returns the permissions version of every user, or err
+type fakePermissionsVersions struct {
+	version string
+	err     error
+}
+
+func (f *fakePermissionsVersions) PermissionsVersion(_ context.Context, _, _ string) (string, error) {
+	return f.version, f.err
+}
+
+func TestTokenManager_MinimalClaims(t *testing.T) {
+	testCases := []struct {
+		name        string
+		current     string
+		currentErr  error
+		wantErrCode string
+	}{
+		{name: "permissions unchanged", current: "version-1"},
+		{name: "permissions changed", current: "version-2", wantErrCode: infra_error.AuthTokenRevoked.Code},
+		{name: "permissions unavailable", currentErr: errors.New("rbac unavailable"), wantErrCode: infra_error.AuthTokenInvalid.Code},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			accessMock := mock_token.NewMockTokenHandler[authv1_cache.TokenMetadata](ctrl)
+			accessMock.EXPECT().Validate(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.TokenMetadata{
+				UserId:    "user-1",
+				TenantId:  "tenant-1",
+				ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+			}, nil).Times(1)
+			versions := &fakePermissionsVersions{version: "version-1"}
+			tm := newPolicyTestTokenAPI(nil)
+			tm.accessTokenHandler = accessMock
+			tm.claimsMode = AccessTokenClaimsMinimal
+			tm.permissionsVersions = versions
+			require.True(t, tm.minimalClaims())
+
+			// The minimal tokens carry no roles
+			tokenString, claims, err := tm.GenerateAccessToken(&GenerateAccessTokenInput{
+				UserId:             "user-1",
+				TenantId:           "tenant-1",
+				Email:              "user@example.com",
+				Username:           "user",
+				PermissionsVersion: "version-1",
+			})
+			require.NoError(t, err)
+			assert.Empty(t, claims.GetRoles())
+			assert.Equal(t, "version-1", claims.GetPermissionsVersion())
+
+			versions.version, versions.err = tc.current, tc.currentErr
+			verified, err := tm.VerifyAccessToken(context.Background(), tokenString)
+			if tc.wantErrCode == "" {
+				require.NoError(t, err)
+				assert.Equal(t, "version-1", verified.GetPermissionsVersion())
+				return
+			}
+			require.Error(t, err)
+			appErr, ok := err.(*infra_error.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tc.wantErrCode, appErr.Code)
+		})
+	}
+}
+
+func TestGenerateAccessTokenInput_Validate(t *testing.T) {
+	input := &GenerateAccessTokenInput{UserId: "user-1", TenantId: "tenant-1", Email: "user@example.com", Username: "user"}
+	assert.Error(t, input.Validate(), "an access token needs roles or a permissions version")
+
+	input.Roles = []string{"role-1"}
+	assert.NoError(t, input.Validate())
+
+	input.Roles, input.PermissionsVersion = nil, "version-1"
+	assert.NoError(t, input.Validate())
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+const (
+	// tokenPolicyConfigKey is the key of the token policy in the auth module config of a tenant
+	tokenPolicyConfigKey = "token_policy"
+	// tokenPolicyCacheTTL is how long a tenant policy is used before it is read again from the config service
+	tokenPolicyCacheTTL = time.Minute
+)
+
+// Bounds of the token lifetimes a tenant may configure
+const (
+	minAccessTokenTTL  = time.Minute
+	maxAccessTokenTTL  = 24 * time.Hour
+	minRefreshTokenTTL = time.Hour
+	maxRefreshTokenTTL = 90 * 24 * time.Hour
+)
+
+// tokenPolicySource returns the token policy stored for a tenant, an empty policy when the tenant has none
+type tokenPolicySource interface {
+	TokenPolicy(tenantID string) (*authv1.TokenPolicy, error)
+}
+
+type cachedTokenPolicy struct {
+	policy    *authv1.TokenPolicy
+	fetchedAt time.Time
+}
+
+// tenantTokenPolicies caches the tenant token policies, tokens are issued on every login and refresh
+// so the config service is asked at most once per tenant and cache TTL
+type tenantTokenPolicies struct {
+	load func(tenantID string) (*authv1.TokenPolicy, error)
+	ttl  time.Duration
+
+	mu       sync.Mutex
+	policies map[string]cachedTokenPolicy
+}
+
+func newTenantTokenPolicies(load func(tenantID string) (*authv1.TokenPolicy, error), ttl time.Duration) *tenantTokenPolicies {
+	return &tenantTokenPolicies{
+		load:     load,
+		ttl:      ttl,
+		policies: map[string]cachedTokenPolicy{},
+	}
+}
+
+// TokenPolicy returns the cached policy of the tenant, loading it when missing or stale
+func (p *tenantTokenPolicies) TokenPolicy(tenantID string) (*authv1.TokenPolicy, error) {
+	p.mu.Lock()
+	cached, ok := p.policies[tenantID]
+	p.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < p.ttl {
+		return cached.policy, nil
+	}
+
+	policy, err := p.load(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.policies[tenantID] = cachedTokenPolicy{policy: policy, fetchedAt: time.Now()}
+	p.mu.Unlock()
+	return policy, nil
+}
+
+// invalidate drops the cached policy of the tenant, the next token reads the stored policy
+func (p *tenantTokenPolicies) invalidate(tenantID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.policies, tenantID)
+}
+
+// GetTokenPolicy returns the token policy stored for the target tenant and the policy applied to its tokens
+func (a *AuthAPI) GetTokenPolicy(ctx context.Context, tenantID, userID, targetTenantID string) (*authv1.TokenPolicyResponse, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		a.logger.Error("failed to get token policy", "error", err)
+		return nil, err
+	}
+	if err := a.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permissions.TenantRead, targetTenantID); err != nil {
+		return nil, err
+	}
+
+	policy, version, err := a.storedTokenPolicy(targetTenantID)
+	if err != nil {
+		a.logger.Error("failed to get token policy", "tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+	return &authv1.TokenPolicyResponse{
+		Policy:          policy,
+		EffectivePolicy: a.tokenManager.mergeTokenPolicy(policy),
+		Version:         version,
+	}, nil
+}
+
+// SetTokenPolicy validates and stores the token policy of the target tenant, it applies to tokens issued afterwards
+func (a *AuthAPI) SetTokenPolicy(ctx context.Context, tenantID, userID, targetTenantID string, policy *authv1.TokenPolicy) (*authv1.TokenPolicyResponse, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" || policy == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id, policy"))
+		a.logger.Error("failed to set token policy", "error", err)
+		return nil, err
+	}
+	if err := a.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permissions.TenantUpdate, targetTenantID); err != nil {
+		return nil, err
+	}
+	if err := validateTokenPolicy(policy); err != nil {
+		a.logger.Error("invalid token policy", "tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+
+	version, err := a.storeAuthConfig(targetTenantID, userID, tokenPolicyConfigKey, policy)
+	if err != nil {
+		a.logger.Error("failed to set token policy", "tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+	if a.tokenPolicies != nil {
+		a.tokenPolicies.invalidate(targetTenantID)
+	}
+
+	a.logger.Info("token policy updated", "tenant_id", targetTenantID, "updated_by", userID)
+	return &authv1.TokenPolicyResponse{
+		Policy:          policy,
+		EffectivePolicy: a.tokenManager.mergeTokenPolicy(policy),
+		Version:         version,
+	}, nil
+}
+
+// storedTokenPolicy loads the token policy of the tenant from the config service, an empty policy when none is stored
+func (a *AuthAPI) storedTokenPolicy(tenantID string) (*authv1.TokenPolicy, int32, error) {
+	policy := &authv1.TokenPolicy{}
+	version, err := a.loadAuthConfig(tenantID, tokenPolicyConfigKey, policy)
+	if err != nil {
+		return nil, 0, err
+	}
+	return policy, version, nil
+}
+
+// validateTokenPolicy checks the values set in policy, unset values use the global defaults
+func validateTokenPolicy(policy *authv1.TokenPolicy) error {
+	invalid := []string{}
+	accessTTL := time.Duration(policy.GetAccessTokenTtlSeconds()) * time.Second
+	refreshTTL := time.Duration(policy.GetRefreshTokenTtlSeconds()) * time.Second
+	if accessTTL != 0 && (accessTTL < minAccessTokenTTL || accessTTL > maxAccessTokenTTL) {
+		invalid = append(invalid, fmt.Sprintf("access_token_ttl_seconds must be between %d and %d", int64(minAccessTokenTTL.Seconds()), int64(maxAccessTokenTTL.Seconds())))
+	}
+	if refreshTTL != 0 && (refreshTTL < minRefreshTokenTTL || refreshTTL > maxRefreshTokenTTL) {
+		invalid = append(invalid, fmt.Sprintf("refresh_token_ttl_seconds must be between %d and %d", int64(minRefreshTokenTTL.Seconds()), int64(maxRefreshTokenTTL.Seconds())))
+	}
+	if accessTTL != 0 && refreshTTL != 0 && accessTTL >= refreshTTL {
+		invalid = append(invalid, "access_token_ttl_seconds must be shorter than refresh_token_ttl_seconds")
+	}
+	if policy.GetIssuer() != strings.TrimSpace(policy.GetIssuer()) {
+		invalid = append(invalid, "issuer must not have surrounding spaces")
+	}
+	for _, audience := range policy.GetAudience() {
+		if strings.TrimSpace(audience) == "" {
+			invalid = append(invalid, "audience must not have empty values")
+			break
+		}
+	}
+	if len(invalid) > 0 {
+		return infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New(strings.Join(invalid, ", ")))
+	}
+	return nil
+}
+
+// defaultTokenPolicy returns the global token policy of the service
+func (tm *TokenAPI) defaultTokenPolicy() *authv1.TokenPolicy {
+	return &authv1.TokenPolicy{
+		AccessTokenTtlSeconds:  int64(tm.tokenDuration.Seconds()),
+		RefreshTokenTtlSeconds: int64(tm.refreshTokenDuration.Seconds()),
+		Issuer:                 tm.issuer,
+		Audience:               tm.audience,
+	}
+}
+
+// mergeTokenPolicy returns the global defaults overridden by the values set in policy
+func (tm *TokenAPI) mergeTokenPolicy(policy *authv1.TokenPolicy) *authv1.TokenPolicy {
+	merged := tm.defaultTokenPolicy()
+	if policy.GetAccessTokenTtlSeconds() > 0 {
+		merged.AccessTokenTtlSeconds = policy.GetAccessTokenTtlSeconds()
+	}
+	if policy.GetRefreshTokenTtlSeconds() > 0 {
+		merged.RefreshTokenTtlSeconds = policy.GetRefreshTokenTtlSeconds()
+	}
+	if policy.GetIssuer() != "" {
+		merged.Issuer = policy.GetIssuer()
+	}
+	if len(policy.GetAudience()) > 0 {
+		merged.Audience = policy.GetAudience()
+	}
+	return merged
+}
+
+// tokenPolicy returns the policy applied to the tokens of the tenant. The global defaults are used
+// when the tenant policy can't be read, a config service outage must not block logins
+func (tm *TokenAPI) tokenPolicy(tenantID string) *authv1.TokenPolicy {
+	if tm.policies == nil || tenantID == "" {
+		return tm.defaultTokenPolicy()
+	}
+	policy, err := tm.policies.TokenPolicy(tenantID)
+	if err != nil {
+		tm.logger.Warn("Failed to load tenant token policy, using defaults", "tenantID", tenantID, "error", err)
+		return tm.defaultTokenPolicy()
+	}
+	return tm.mergeTokenPolicy(policy)
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock_token "erp.localhost/internal/auth/handler/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newPolicyTestTokenAPI(policies tokenPolicySource) *TokenAPI {
+	return &TokenAPI{
+		secretKey:            "test-secret",
+		tokenDuration:        time.Hour,
+		refreshTokenDuration: 7 * 24 * time.Hour,
+		issuer:               Issuer,
+		audience:             []string{},
+		policies:             policies,
+		logger:               logger.NewBaseLogger(shared.ModuleAuth),
+	}
+}
+
+type staticTokenPolicies map[string]*authv1.TokenPolicy
+
+func (p staticTokenPolicies) TokenPolicy(tenantID string) (*authv1.TokenPolicy, error) {
+	policy, ok := p[tenantID]
+	if !ok {
+		return nil, errors.New("config service unavailable")
+	}
+	return policy, nil
+}
+
+func TestValidateTokenPolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  *authv1.TokenPolicy
+		wantErr bool
+	}{
+		{
+			name:   "empty policy uses the defaults",
+			policy: &authv1.TokenPolicy{},
+		},
+		{
+			name: "valid policy",
+			policy: &authv1.TokenPolicy{
+				AccessTokenTtlSeconds:  900,
+				RefreshTokenTtlSeconds: 86400,
+				Issuer:                 "https://erp.example.com",
+				Audience:               []string{"erp-web"},
+			},
+		},
+		{
+			name:    "access ttl too short",
+			policy:  &authv1.TokenPolicy{AccessTokenTtlSeconds: 10},
+			wantErr: true,
+		},
+		{
+			name:    "refresh ttl too long",
+			policy:  &authv1.TokenPolicy{RefreshTokenTtlSeconds: int64((365 * 24 * time.Hour).Seconds())},
+			wantErr: true,
+		},
+		{
+			name:    "access ttl not shorter than refresh ttl",
+			policy:  &authv1.TokenPolicy{AccessTokenTtlSeconds: 7200, RefreshTokenTtlSeconds: 3600},
+			wantErr: true,
+		},
+		{
+			name:    "empty audience value",
+			policy:  &authv1.TokenPolicy{Audience: []string{"erp-web", " "}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTokenPolicy(tc.policy)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTokenManager_TokenPolicy(t *testing.T) {
+	tm := newPolicyTestTokenAPI(staticTokenPolicies{
+		"tenant-1": {AccessTokenTtlSeconds: 900, Issuer: "https://tenant-1.example.com"},
+		"tenant-2": {},
+	})
+
+	policy := tm.tokenPolicy("tenant-1")
+	assert.Equal(t, int64(900), policy.GetAccessTokenTtlSeconds())
+	assert.Equal(t, int64((7 * 24 * time.Hour).Seconds()), policy.GetRefreshTokenTtlSeconds())
+	assert.Equal(t, "https://tenant-1.example.com", policy.GetIssuer())
+
+	// Tenants without a policy and unreadable policies use the global defaults
+	for _, tenantID := range []string{"tenant-2", "tenant-3"} {
+		policy = tm.tokenPolicy(tenantID)
+		assert.Equal(t, int64(3600), policy.GetAccessTokenTtlSeconds())
+		assert.Equal(t, Issuer, policy.GetIssuer())
+	}
+}
+
+func TestTenantTokenPolicies_Cache(t *testing.T) {
+	loads := 0
+	policies := newTenantTokenPolicies(func(tenantID string) (*authv1.TokenPolicy, error) {
+		loads++
+		return &authv1.TokenPolicy{AccessTokenTtlSeconds: int64(loads * 60)}, nil
+	}, time.Minute)
+
+	first, err := policies.TokenPolicy("tenant-1")
+	require.NoError(t, err)
+	second, err := policies.TokenPolicy("tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, loads)
+
+	policies.invalidate("tenant-1")
+	third, err := policies.TokenPolicy("tenant-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(120), third.GetAccessTokenTtlSeconds())
+	assert.Equal(t, 2, loads)
+}
+
+func TestTokenManager_AccessTokenTenantPolicy(t *testing.T) {
+	policies := staticTokenPolicies{
+		"tenant-1": {AccessTokenTtlSeconds: 900, Issuer: "https://tenant-1.example.com", Audience: []string{"erp-web"}},
+	}
+	tm := newPolicyTestTokenAPI(policies)
+	ctrl := gomock.NewController(t)
+	accessMock := mock_token.NewMockTokenHandler[authv1_cache.TokenMetadata](ctrl)
+	accessMock.EXPECT().Validate(gomock.Any(), "tenant-1", "user-1").Return(&authv1_cache.TokenMetadata{
+		UserId:    "user-1",
+		TenantId:  "tenant-1",
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}, nil).AnyTimes()
+	tm.accessTokenHandler = accessMock
+
+	tokenString, claims, err := tm.GenerateAccessToken(&GenerateAccessTokenInput{
+		UserId:   "user-1",
+		TenantId: "tenant-1",
+		Email:    "user@example.com",
+		Username: "user",
+		Roles:    []string{"role-1"},
+	})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.GetExpiresAt().AsTime(), 5*time.Second)
+
+	_, err = tm.VerifyAccessToken(context.Background(), tokenString)
+	require.NoError(t, err)
+
+	// Changing the issuer invalidates tokens issued under the previous policy
+	policies["tenant-1"] = &authv1.TokenPolicy{Issuer: "https://other.example.com"}
+	_, err = tm.VerifyAccessToken(context.Background(), tokenString)
+	require.Error(t, err)
+	appErr, ok := err.(*infra_error.AppError)
+	require.True(t, ok)
+	assert.Equal(t, infra_error.AuthTokenInvalid.Code, appErr.Code)
+
+	// A tenant audience rejects tokens without it
+	policies["tenant-1"] = &authv1.TokenPolicy{Issuer: "https://tenant-1.example.com", Audience: []string{"erp-mobile"}}
+	_, err = tm.VerifyAccessToken(context.Background(), tokenString)
+	require.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestMergeUserUpdate(t *testing.T) {
+	stored := &authv1.User{
+		Id:           "user-1",
+		TenantId:     "tenant-1",
+		Email:        "john@example.com",
+		Username:     "john",
+		PasswordHash: "hash",
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    "admin",
+		Profile:      &authv1.UserProfile{FirstName: "John", LastName: "Doe", Phone: "+15550100"},
+		Version:      4,
+	}
+	update := &authv1.User{Id: "user-1", TenantId: "tenant-1", Profile: &authv1.UserProfile{FirstName: "Johnny"}, Version: 4}
+
+	merged, err := mergeUserUpdate(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"profile.first_name", "profile.phone"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Johnny", merged.GetProfile().GetFirstName())
+	assert.Equal(t, "Doe", merged.GetProfile().GetLastName())
+	assert.Empty(t, merged.GetProfile().GetPhone())
+	assert.Equal(t, "john@example.com", merged.GetEmail())
+	assert.Equal(t, int64(4), merged.GetVersion())
+	// The stored user is unchanged
+	assert.Equal(t, "John", stored.GetProfile().GetFirstName())
+
+	// The merged user must stay valid
+	_, err = mergeUserUpdate(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"status"}})
+	assert.Error(t, err)
+}
+
+func TestMergeTenantUpdate(t *testing.T) {
+	stored := &authv1.Tenant{
+		Id:        "tenant-1",
+		Name:      "Acme",
+		Domain:    "acme.com",
+		Status:    authv1.TenantStatus_TENANT_STATUS_ACTIVE,
+		CreatedBy: "admin",
+		Contact:   &authv1.ContactInfo{Email: "admin@acme.com"},
+		Version:   2,
+	}
+	update := &authv1.Tenant{Id: "tenant-1", Name: "Acme Inc", Version: 1}
+
+	merged, err := mergeTenantUpdate(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"name", "domain"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Inc", merged.GetName())
+	assert.Empty(t, merged.GetDomain())
+	assert.Equal(t, "admin@acme.com", merged.GetContact().GetEmail())
+	// A stale version is kept so the update is rejected when stored
+	assert.Equal(t, int64(1), merged.GetVersion())
+
+	_, err = mergeTenantUpdate(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"contact"}})
+	assert.True(t, infra_error.Validation(infra_error.ValidationRequiredFields).Is(err))
+}
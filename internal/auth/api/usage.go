@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/usage"
+)
+
+// UsageTracker returns the tracker fed by the usage interceptor
+func (t *TenantAPI) UsageTracker() *usage.Tracker {
+	return t.usageTracker
+}
+
+// GetUsage returns the daily API usage rollups of a tenant, by default for the current month
+func (t *TenantAPI) GetUsage(ctx context.Context, tenantID, userID, targetTenantID, fromDate, toDate string) (*authv1.UsageReport, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		t.logger.Error("failed to get usage", "error", err)
+		return nil, err
+	}
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantRead); err != nil {
+		return nil, err
+	}
+
+	monthStart, monthEnd := usage.MonthRange(time.Now().UTC())
+	if fromDate == "" {
+		fromDate = monthStart
+	}
+	if toDate == "" {
+		toDate = monthEnd
+	}
+	if fromDate > toDate {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidDate, "from_date", "to_date")
+	}
+
+	// Pending calls are written before reading so the report is up to date
+	if err := t.usageTracker.Flush(ctx); err != nil {
+		t.logger.Warn("failed to flush usage before report", "tenant_id", targetTenantID, "error", err)
+	}
+	rollups, err := t.usageHandler.Rollups(ctx, targetTenantID, fromDate, toDate)
+	if err != nil {
+		t.logger.Error("failed to get usage", "tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+
+	report := &authv1.UsageReport{
+		TenantId: targetTenantID,
+		Rollups:  rollups,
+	}
+	for _, rollup := range rollups {
+		report.TotalCalls += rollup.GetCalls()
+	}
+	if report.MonthToDateCalls, err = t.usageTracker.MonthToDate(ctx, targetTenantID); err != nil {
+		t.logger.Warn("failed to get month to date usage", "tenant_id", targetTenantID, "error", err)
+	}
+	if report.MonthlyCap, err = t.usageTracker.MonthlyCap(ctx, targetTenantID); err != nil {
+		t.logger.Warn("failed to get tenant api cap", "tenant_id", targetTenantID, "error", err)
+	}
+	return report, nil
+}
+
+// ExportUsage exports a month of usage, aggregated per method, for invoicing. Defaults to the previous month as CSV.
+func (t *TenantAPI) ExportUsage(ctx context.Context, tenantID, userID, targetTenantID, month string, format authv1.UsageExportFormat) (*authv1.ExportUsageResponse, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		t.logger.Error("failed to export usage", "error", err)
+		return nil, err
+	}
+	if err := t.checkPermission(ctx, tenantID, userID, permissions.TenantRead); err != nil {
+		return nil, err
+	}
+
+	var monthStart time.Time
+	if month == "" {
+		now := time.Now().UTC()
+		monthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+		month = monthStart.Format(usage.MonthFormat)
+	} else {
+		parsed, err := time.Parse(usage.MonthFormat, month)
+		if err != nil {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidDate, "month")
+		}
+		monthStart = parsed
+	}
+
+	fromDate, toDate := usage.MonthRange(monthStart)
+	rollups, err := t.usageHandler.Rollups(ctx, targetTenantID, fromDate, toDate)
+	if err != nil {
+		t.logger.Error("failed to export usage", "tenant_id", targetTenantID, "error", err)
+		return nil, err
+	}
+	lines := usage.BillingLines(targetTenantID, month, rollups)
+
+	response := &authv1.ExportUsageResponse{}
+	switch format {
+	case authv1.UsageExportFormat_USAGE_EXPORT_FORMAT_JSON:
+		response.ContentType = "application/json"
+		response.FileName = fmt.Sprintf("usage_%s_%s.json", targetTenantID, month)
+		response.Data, err = usage.ExportJSON(targetTenantID, month, lines)
+	default:
+		response.ContentType = "text/csv"
+		response.FileName = fmt.Sprintf("usage_%s_%s.csv", targetTenantID, month)
+		response.Data, err = usage.ExportCSV(lines)
+	}
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return response, nil
+}
+
+// monthlyAPICap is the usage.CapProvider backed by the tenant subscription limits
+func (t *TenantAPI) monthlyAPICap(ctx context.Context, tenantID string) (int64, error) {
+	tenant, err := t.tenantCache.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return tenant.GetSubscription().GetLimits().GetMaxApiCallsPerMonth(), nil
+}
@@ -1,15 +1,32 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"slices"
+	"time"
 
 	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
+	audit_collection "erp.localhost/internal/infra/event/audit_log/collection"
+	"erp.localhost/internal/infra/event/outbox"
 	"erp.localhost/internal/infra/logging/logger"
 	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/model/fieldmask"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"erp.localhost/internal/infra/notification"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type FilterType int
@@ -22,188 +39,429 @@ const (
 )
 
 type UserAPI struct {
-	logger      logger.Logger
-	userHandler *handler.UserHandler
-	rbacAPI     *RBACAPI
+	logger                   logger.Logger
+	userHandler              *handler.UserHandler
+	tenantHandler            *handler.TenantHandler
+	emailVerificationHandler *handler.EmailVerificationHandler
+	loginHistoryHandler      *handler.LoginHistoryHandler
+	devices                  deviceStore
+	emailVerificationConfig  *EmailVerificationConfig
+	emailSender              EmailSender
+	codeHasher               *hash.CodeHasher
+	notifier                 *notifier
+	rbacAPI                  *RBACAPI
+	outbox                   *outbox.Outbox
+	auditLogs                auditLogAnonymizer
+	quotas                   *TenantQuotas
+	// Verifies the tokens of the self-service calls, set by NewAuthAPI
+	sessions sessionStore
+	// Forgotten on erasure and device revocation, set by NewAuthAPI
+	knownDevices   knownDeviceEraser
+	knownDeviceTTL time.Duration
+	// Revokes the tokens of the revoked devices, set by NewAuthAPI
+	deviceSessions        deviceSessionRevoker
+	accountDeletionConfig AccountDeletionConfig
 }
 
-func NewUserAPI(rbacAPI *RBACAPI, logger logger.Logger) (*UserAPI, error) {
+// NewUserAPI returns the API of the users, the verification codes and tokens are hashed with codeHasher
+func NewUserAPI(rbacAPI *RBACAPI, codeHasher *hash.CodeHasher, logger logger.Logger) (*UserAPI, error) {
 	userHander, err := handler.NewUserHandler(logger)
 	if err != nil {
 		logger.Error("failed to create new user handler", "error", err)
 		return nil, err
 	}
+	tenantHandler, err := handler.NewTenantHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new tenant handler", "error", err)
+		return nil, err
+	}
+	emailVerificationHandler, err := handler.NewEmailVerificationHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new email verification handler", "error", err)
+		return nil, err
+	}
+	loginHistoryHandler, err := handler.NewLoginHistoryHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new login history handler", "error", err)
+		return nil, err
+	}
+	deviceHandler, err := handler.NewDeviceHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new device handler", "error", err)
+		return nil, err
+	}
+	auditLogsHandler, err := collection.NewBaseCollectionHandler[eventv1.AuditLog](model_mongo.AuthDB, model_mongo.AuditLogsCollection, logger)
+	if err != nil {
+		logger.Error("failed to create audit logs collection handler", "error", err)
+		return nil, err
+	}
 	return &UserAPI{
-		rbacAPI:     rbacAPI,
-		userHandler: userHander,
-		logger:      logger,
+		rbacAPI:                  rbacAPI,
+		userHandler:              userHander,
+		tenantHandler:            tenantHandler,
+		emailVerificationHandler: emailVerificationHandler,
+		loginHistoryHandler:      loginHistoryHandler,
+		devices:                  deviceHandler,
+		emailVerificationConfig:  LoadEmailVerificationConfig(),
+		emailSender:              &logEmailSender{logger: logger},
+		codeHasher:               codeHasher,
+		auditLogs:                audit_collection.NewAuditLogsCollection(auditLogsHandler, logger),
+		accountDeletionConfig:    defaultAccountDeletionConfig,
+		logger:                   logger,
 	}, nil
 }
 
-func (u *UserAPI) CreateUser(tenantID, userID string, newUser *authv1.User) (string, error) {
+// SetOutbox records the domain events of the user changes in outbox
+func (u *UserAPI) SetOutbox(outbox *outbox.Outbox) {
+	u.outbox = outbox
+}
+
+// CreateUser creates a new user, when password is set it is validated against the tenant policy and hashed
+func (u *UserAPI) CreateUser(ctx context.Context, tenantID, userID string, newUser *authv1.User, password string) (string, error) {
 	if tenantID == "" || userID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
 		u.logger.Error("failed to create user", "error", err)
 		return "", err
 	}
+	if password != "" {
+		passwordHash, err := u.hashPassword(ctx, newUser, password)
+		if err != nil {
+			u.logger.Error("failed to create user", "tenant_id", tenantID, "error", err)
+			return "", err
+		}
+		newUser.PasswordHash = passwordHash
+		newUser.LastPasswordChange = timestamppb.Now()
+	}
 	if err := validator_auth.ValidateUser(newUser, true); err != nil {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
 		u.logger.Error("failed to create user", "error", err)
 		return "", err
 	}
 
-	if err := u.hasPermission(tenantID, userID, model_auth.PermissionActionCreate, tenantID); err != nil {
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserCreate, tenantID); err != nil {
+		u.logger.Error("failed to create user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return "", err
+	}
+	if err := u.checkGrantable(ctx, tenantID, userID, nil, newUser); err != nil {
+		u.logger.Error("failed to create user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return "", err
+	}
+	if err := u.quotas.Check(ctx, tenantID, model_auth.TenantResourceUsers); err != nil {
 		u.logger.Error("failed to create user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return "", err
 	}
 
-	user, err := u.getUser(tenantID, newUser.Email, filterTypeEmail)
+	user, err := u.getUser(ctx, tenantID, newUser.Email, filterTypeEmail)
 	if err != nil {
 		u.logger.Error("failed to get user for verification", "tenant_id", tenantID, "error", err)
 		return "", err
 	}
 	if user != nil {
-		err := infra_error.Validation(infra_error.ConflictDuplicateEmail)
+		err := infra_error.Conflict(infra_error.ConflictDuplicateEmail)
 		u.logger.Error("failed to create new account", "tenantID", tenantID, "error", err.Error())
 		return "", err
 	}
 
 	// convert from proto user to model user
-	return u.userHandler.CreateUser(newUser)
+	var id string
+	err = u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		var err error
+		id, err = u.userHandler.CreateUser(ctx, newUser)
+		if err != nil {
+			return nil, err
+		}
+		event := userEvent(model_event.EventUserCreated, userID, newUser)
+		event.GetUser().UserId = id
+		return []*eventv1.DomainEvent{event}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
 }
 
-func (u *UserAPI) GetUser(tenantID, userID, targetTenantID, accountID string) (*authv1.User, error) {
+func (u *UserAPI) GetUser(ctx context.Context, tenantID, userID, targetTenantID, accountID string) (*authv1.User, error) {
 	if tenantID == "" || userID == "" || targetTenantID == "" || accountID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id, account_id"))
 		u.logger.Error("failed to get user", "error", err)
 		return nil, err
 	}
 
-	if err := u.hasPermission(tenantID, userID, model_auth.PermissionActionRead, targetTenantID); err != nil {
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserRead, targetTenantID); err != nil {
 		u.logger.Error("failed to get user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return nil, err
 	}
-	return u.getUser(tenantID, accountID, filterTypeID)
+	return u.getUser(ctx, tenantID, accountID, filterTypeID)
+}
+
+func (u *UserAPI) GetUsers(ctx context.Context, tenantID, userID, targetTenantID, roleID string) ([]*authv1.User, error) {
+	users, _, err := u.ListUsers(ctx, tenantID, userID, targetTenantID, roleID, mongo.ListOptions{})
+	return users, err
 }
 
-func (u *UserAPI) GetUsers(tenantID, userID, targetTenantID, roleID string) ([]*authv1.User, error) {
+// ListUsers returns the users of the target tenant, or of its role when roleID is set, ordered, projected and
+// limited by opts, with the pagination of the first page of opts.Limit users
+func (u *UserAPI) ListUsers(ctx context.Context, tenantID, userID, targetTenantID, roleID string, opts mongo.ListOptions) ([]*authv1.User, *infrav1.PaginationResponse, error) {
 	if tenantID == "" || userID == "" || targetTenantID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
 		u.logger.Error("failed to get users", "error", err)
-		return nil, err
+		return nil, nil, err
 	}
-	if err := u.hasPermission(tenantID, userID, model_auth.PermissionActionRead, targetTenantID); err != nil {
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserRead, targetTenantID); err != nil {
 		u.logger.Error("failed to get users", "tenant_id", tenantID, "user_id", userID, "error", err)
-		return nil, err
+		return nil, nil, err
 	}
 
+	var users []*authv1.User
+	var err error
 	if roleID != "" {
-		return u.userHandler.GetUsersByRoleID(targetTenantID, roleID)
+		users, err = u.userHandler.GetUsersByRoleID(ctx, targetTenantID, roleID, opts)
+	} else {
+		users, err = u.userHandler.GetUsersByTenantID(ctx, targetTenantID, opts)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	total := int64(len(users))
+	// Only a full page may have left users out
+	if opts.Limit > 0 && total == opts.Limit {
+		if roleID != "" {
+			total, err = u.userHandler.CountUsersByRoleID(ctx, targetTenantID, roleID)
+		} else {
+			total, err = u.userHandler.CountUsersByTenantID(ctx, targetTenantID)
+		}
+		if err != nil {
+			u.logger.Error("failed to count users", "tenant_id", tenantID, "target_tenant_id", targetTenantID, "error", err)
+			return nil, nil, err
+		}
+	}
+	return users, listPagination(opts.Limit, total), nil
+}
+
+// listPagination returns the pagination of the first page of a list limited to limit items out of total, a single
+// page when the list is not limited
+func listPagination(limit, total int64) *infrav1.PaginationResponse {
+	pageSize := limit
+	if pageSize <= 0 {
+		pageSize = total
+	}
+	var totalPages int64
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return &infrav1.PaginationResponse{
+		Page:       1,
+		PageSize:   int32(pageSize),
+		TotalItems: total,
+		TotalPages: int32(totalPages),
+		HasNext:    totalPages > 1,
+	}
+}
+
+// SearchUsers returns a page of the target tenant users matching search
+func (u *UserAPI) SearchUsers(ctx context.Context, tenantID, userID, targetTenantID string, search *authv1.UserSearch, pagination *infrav1.PaginationRequest) ([]*authv1.User, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" || targetTenantID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
+		u.logger.Error("failed to search users", "error", err)
+		return nil, nil, err
+	}
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserRead, targetTenantID); err != nil {
+		u.logger.Error("failed to search users", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
 	}
-	return u.userHandler.GetUsersByTenantID(targetTenantID)
+
+	return u.userHandler.SearchUsers(ctx, targetTenantID, search, pagination)
+}
+
+// GetLoginHistory returns a page of the login attempts of a user in [from, to), newest first.
+// Users may always query their own history, other accounts require user read permission.
+func (u *UserAPI) GetLoginHistory(ctx context.Context, tenantID, userID, targetTenantID, accountID string, from, to *timestamppb.Timestamp, pagination *infrav1.PaginationRequest) ([]*authv1.LoginRecord, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		u.logger.Error("failed to get login history", "error", err)
+		return nil, nil, err
+	}
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+	if accountID == "" {
+		accountID = userID
+	}
+
+	if targetTenantID != tenantID || accountID != userID {
+		if err := u.hasPermission(ctx, tenantID, userID, permissions.UserRead, targetTenantID); err != nil {
+			u.logger.Error("failed to get login history", "tenant_id", tenantID, "user_id", userID, "error", err)
+			return nil, nil, err
+		}
+	}
+
+	return u.loginHistoryHandler.GetLoginHistory(ctx, targetTenantID, accountID, from, to, pagination)
 }
 
 // TODO: finish logic
-func (u *UserAPI) UpdateUser(tenantID, userID string, newUserData *authv1.User) (bool, error) {
+// userUpdateMaskFields are the fields of a user an update mask may name
+var userUpdateMaskFields = []string{"profile", "roles", "additional_permissions", "revoked_permissions", "status", "preferences", "external_id"}
+
+// UpdateUser replaces the stored user with newUserData, or when mask names fields only changes those fields, newUserData
+// then only needs its id, tenant id, version and the named fields
+func (u *UserAPI) UpdateUser(ctx context.Context, tenantID, userID string, newUserData *authv1.User, mask *fieldmaskpb.FieldMask) (bool, error) {
 	if tenantID == "" || userID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
 		u.logger.Error("failed to update user", "error", err)
 		return false, err
 	}
-	if err := validator_auth.ValidateUser(newUserData, true); err != nil {
-		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
-		u.logger.Error("Failed to update user", "error", err)
+	// Partial updates are validated once merged with the stored user
+	if fieldmask.IsEmpty(mask) {
+		if err := validator_auth.ValidateUser(newUserData, true); err != nil {
+			err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+			u.logger.Error("Failed to update user", "error", err)
+			return false, err
+		}
+	} else if err := fieldmask.CheckPaths(mask, userUpdateMaskFields...); err != nil {
+		u.logger.Error("failed to update user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return false, err
 	}
 
 	targetTenantID := newUserData.TenantId
 
-	if err := u.hasPermission(tenantID, userID, model_auth.PermissionActionUpdate, targetTenantID); err != nil {
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserUpdate, targetTenantID); err != nil {
 		u.logger.Error("failed to update user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return false, err
 	}
 
-	oldUserData, err := u.getUser(tenantID, newUserData.Id, filterTypeID)
+	oldUserData, err := u.getUser(ctx, tenantID, newUserData.Id, filterTypeID)
 	if err != nil {
 		u.logger.Error("failed to update user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return false, err
 	}
+	if !fieldmask.IsEmpty(mask) {
+		newUserData, err = mergeUserUpdate(oldUserData, newUserData, mask)
+		if err != nil {
+			u.logger.Error("failed to update user", "tenant_id", tenantID, "user_id", userID, "error", err)
+			return false, err
+		}
+	}
 
 	// Do diff and validate
-	err = u.validateUserUpdateData(tenantID, userID, oldUserData, newUserData)
+	err = u.validateUserUpdateData(ctx, tenantID, userID, oldUserData, newUserData)
 	if err != nil {
 		u.logger.Error("failed to update user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return false, err
 	}
-	return u.updateUser(newUserData)
+	var updated bool
+	err = u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		var err error
+		updated, err = u.updateUser(ctx, newUserData)
+		if err != nil || !updated {
+			return nil, err
+		}
+		return []*eventv1.DomainEvent{userEvent(model_event.EventUserUpdated, userID, newUserData)}, nil
+	})
+	if err == nil && updated {
+		// Roles, additional and revoked permissions of the user may have changed
+		u.rbacAPI.Verification.InvalidateUserPermissions(ctx, targetTenantID, newUserData.GetId())
+	}
+	if err == nil && updated && oldUserData.GetPasswordHash() != newUserData.GetPasswordHash() {
+		u.notifier.notify(newUserData, notification.EventPasswordChanged, nil)
+	}
+	return updated, err
+}
+
+// mergeUserUpdate returns a copy of stored with the fields of update named by mask, at the version update was read at
+func mergeUserUpdate(stored, update *authv1.User, mask *fieldmaskpb.FieldMask) (*authv1.User, error) {
+	merged := proto.Clone(stored).(*authv1.User)
+	if err := fieldmask.Apply(merged, update, mask); err != nil {
+		return nil, err
+	}
+	merged.Version = update.GetVersion()
+	if err := validator_auth.ValidateUser(merged, true); err != nil {
+		return nil, err
+	}
+	return merged, nil
 }
 
-func (u *UserAPI) DeleteUser(tenantID, userID, targetTenantID, accountID string) error {
+func (u *UserAPI) DeleteUser(ctx context.Context, tenantID, userID, targetTenantID, accountID string) error {
 	if tenantID == "" || userID == "" || targetTenantID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
 		u.logger.Error("failed to delete user", "error", err)
 		return err
 	}
 
-	if err := u.hasPermission(tenantID, userID, model_auth.PermissionActionDelete, targetTenantID); err != nil {
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserDelete, targetTenantID); err != nil {
 		u.logger.Error("failed to delete user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return err
 	}
 
-	if err := u.userHandler.DeleteUser(targetTenantID, accountID); err != nil {
+	err := u.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		if err := u.userHandler.DeleteUser(ctx, targetTenantID, accountID); err != nil {
+			return nil, err
+		}
+		deleted := &authv1.User{Id: accountID, TenantId: targetTenantID}
+		return []*eventv1.DomainEvent{userEvent(model_event.EventUserDeleted, userID, deleted)}, nil
+	})
+	if err != nil {
 		u.logger.Error("failed to delete user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return err
 	}
+	u.rbacAPI.Verification.InvalidateUserPermissions(ctx, targetTenantID, accountID)
 	u.logger.Debug("user deleted successfuly", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID)
 	return nil
 }
 
-func (u *UserAPI) DeleteTenantUsers(tenantID, userID, targetTenantID string) error {
+func (u *UserAPI) DeleteTenantUsers(ctx context.Context, tenantID, userID, targetTenantID string) error {
 	if tenantID == "" || userID == "" || targetTenantID == "" {
 		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, target_tenant_id"))
 		u.logger.Error("failed to delete tenant users", "error", err)
 		return err
 	}
 
-	if err := u.hasPermission(tenantID, userID, model_auth.PermissionActionDelete, targetTenantID); err != nil {
+	if err := u.hasPermission(ctx, tenantID, userID, permissions.UserDelete, targetTenantID); err != nil {
 		u.logger.Error("failed to delete tenant users", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return err
 	}
 
-	if err := u.userHandler.DeleteTenantUsers(targetTenantID); err != nil {
+	if err := u.userHandler.DeleteTenantUsers(ctx, targetTenantID); err != nil {
 		u.logger.Error("failed to delete tenant users", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return err
 	}
+	u.rbacAPI.Verification.InvalidateTenantPermissions(ctx, targetTenantID)
 	u.logger.Debug("tenant users deleted successfuly", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID)
 	return nil
 }
 
 /* Helper functions */
-func (u *UserAPI) hasPermission(tenantID, userID, action, targetTenantID string) error {
-	permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeUser, action)
-	if err != nil {
-		return err
-	}
-	return u.rbacAPI.Verification.HasPermission(tenantID, userID, permission, targetTenantID)
+// RedactUser clears the password hashes and the MFA secrets of user, which never leave the service
+func RedactUser(user *authv1.User) {
+	user.PasswordHash = ""
+	user.PasswordHistory = nil
+	user.PasswordResetToken = ""
+	user.PasswordResetExpires = nil
+	user.MfaSecret = ""
+	user.MfaRecoveryCodes = nil
+	user.MfaLastCounter = 0
+}
+
+func (u *UserAPI) hasPermission(ctx context.Context, tenantID, userID, permission, targetTenantID string) error {
+	return u.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permission, targetTenantID)
 }
 
-func (u *UserAPI) getUser(tenantID string, accountID string, filterType FilterType) (*authv1.User, error) {
+func (u *UserAPI) getUser(ctx context.Context, tenantID string, accountID string, filterType FilterType) (*authv1.User, error) {
 	switch filterType {
 	case filterTypeID:
-		return u.userHandler.GetUserByID(tenantID, accountID)
+		return u.userHandler.GetUserByID(ctx, tenantID, accountID)
 	case filterTypeEmail:
-		return u.userHandler.GetUserByEmail(tenantID, accountID)
+		return u.userHandler.GetUserByEmail(ctx, tenantID, accountID)
 	case filterTypeUsername:
-		return u.userHandler.GetUserByUsername(tenantID, accountID)
+		return u.userHandler.GetUserByUsername(ctx, tenantID, accountID)
 	default:
 		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "account identifier")
 	}
 }
 
-func (u *UserAPI) updateUser(user *authv1.User) (bool, error) {
+func (u *UserAPI) updateUser(ctx context.Context, user *authv1.User) (bool, error) {
 	tenantID := user.GetTenantId()
 	userID := user.GetId()
-	err := u.userHandler.UpdateUser(user)
+	err := u.userHandler.UpdateUser(ctx, user)
 	success := err == nil
 	if success {
 		u.logger.Debug("user updated successfuly", "tenant_id", tenantID, "user_id", userID, "target_tenant_id")
@@ -213,38 +471,55 @@ func (u *UserAPI) updateUser(user *authv1.User) (bool, error) {
 	return success, err
 }
 
-func (u *UserAPI) validateUserUpdateData(tenantID, userID string, old *authv1.User, new *authv1.User) error {
+func (u *UserAPI) validateUserUpdateData(ctx context.Context, tenantID, userID string, old *authv1.User, new *authv1.User) error {
 	if old.TenantId != new.TenantId ||
 		old.Username != new.Username ||
 		old.Email != new.Email ||
 		old.CreatedBy != new.CreatedBy ||
-		old.CreatedAt != new.CreatedAt {
+		!proto.Equal(old.CreatedAt, new.CreatedAt) {
 		return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields)
 	}
 
+	required := []string{}
 	equal := slices.EqualFunc(old.Roles, new.Roles, func(a, b *authv1.UserRole) bool {
 		return a.TenantId == b.TenantId &&
-			a.RoleId == b.RoleId
+			a.RoleId == b.RoleId &&
+			proto.Equal(a.ExpiresAt, b.ExpiresAt)
 	})
 	if !equal {
-		permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeUser, model_auth.PermissionActionModifyRole)
-		if err != nil {
-			return err
-		}
-		if err := u.rbacAPI.Verification.HasPermission(tenantID, userID, permission, new.TenantId); err != nil {
-			return err
-		}
+		required = append(required, permissions.UserModifyRole)
 	}
 
 	if !slices.Equal(old.AdditionalPermissions, new.AdditionalPermissions) || !slices.Equal(old.RevokedPermissions, new.RevokedPermissions) {
-		permission, err := model_auth.CreatePermissionString(model_auth.ResourceTypeUser, model_auth.PermissionActionModifyPermission)
-		if err != nil {
-			return err
-		}
-		if err := u.rbacAPI.Verification.HasPermission(tenantID, userID, permission, new.TenantId); err != nil {
-			return err
-		}
+		required = append(required, permissions.UserModifyPermission)
 	}
 
-	return nil
+	if len(required) == 0 {
+		return nil
+	}
+	if err := u.rbacAPI.Verification.RequirePermissions(ctx, tenantID, userID, new.TenantId, required...); err != nil {
+		return err
+	}
+	return u.checkGrantable(ctx, tenantID, userID, old, new)
+}
+
+// checkGrantable returns a privilege escalation error when the roles or additional permissions added to a user grant
+// permissions the requestor does not hold, old is nil for a new user
+func (u *UserAPI) checkGrantable(ctx context.Context, tenantID, userID string, old *authv1.User, new *authv1.User) error {
+	var roleIDs []string
+	for _, userRole := range new.GetRoles() {
+		assigned := slices.ContainsFunc(old.GetRoles(), func(r *authv1.UserRole) bool {
+			return r.GetRoleId() == userRole.GetRoleId()
+		})
+		if !assigned {
+			roleIDs = append(roleIDs, userRole.GetRoleId())
+		}
+	}
+	if err := u.rbacAPI.Verification.CheckGrantableRoles(ctx, tenantID, userID, new.GetTenantId(), roleIDs); err != nil {
+		return err
+	}
+	added := slices.DeleteFunc(slices.Clone(new.GetAdditionalPermissions()), func(id string) bool {
+		return slices.Contains(old.GetAdditionalPermissions(), id)
+	})
+	return u.rbacAPI.Verification.CheckGrantablePermissions(ctx, tenantID, userID, new.GetTenantId(), added)
 }
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/auth/handler"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// webhookSecretPrefix marks the generated secrets, which are 32 random bytes in hex
+	webhookSecretPrefix = "whsec_"
+	// minWebhookSecretLength is the length a secret chosen by the tenant must have
+	minWebhookSecretLength = 16
+	// webhookAllEvents subscribes a webhook to every event
+	webhookAllEvents = "*"
+)
+
+// webhookEvents are the events a webhook can subscribe to, the domain event types without their module
+var webhookEvents = []string{
+	model_event.WebhookEvent(model_event.EventUserCreated),
+	model_event.WebhookEvent(model_event.EventUserUpdated),
+	model_event.WebhookEvent(model_event.EventUserDeleted),
+	model_event.WebhookEvent(model_event.EventTenantUpdated),
+	model_event.WebhookEvent(model_event.EventTenantStatusChanged),
+	model_event.WebhookEvent(model_event.EventRoleCreated),
+	model_event.WebhookEvent(model_event.EventRoleUpdated),
+	model_event.WebhookEvent(model_event.EventRoleDeleted),
+	model_event.WebhookEvent(model_event.EventPermissionCreated),
+	model_event.WebhookEvent(model_event.EventPermissionUpdated),
+	model_event.WebhookEvent(model_event.EventPermissionDeleted),
+	model_event.WebhookEvent(model_event.EventLoginFailed),
+	model_event.WebhookEvent(model_event.EventLoginSuspicious),
+}
+
+// WebhookConfig holds the delivery settings of the tenant webhooks, loaded with infra_config.Load
+type WebhookConfig struct {
+	// Interval between two polls of the pending deliveries
+	DeliveryInterval time.Duration `yaml:"delivery_interval" env:"WEBHOOK_DELIVERY_INTERVAL" default:"1s"`
+	// BatchSize caps the deliveries sent by a poll
+	BatchSize int `yaml:"batch_size" env:"WEBHOOK_BATCH_SIZE" default:"100"`
+	// Timeout of a call to an endpoint
+	Timeout time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT" default:"10s"`
+	// Failed deliveries are retried with an exponential backoff from RetryBackoff up to MaxBackoff, a delivery
+	// is given up after MaxAttempts and can then be replayed
+	MaxAttempts  int           `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" default:"8"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env:"WEBHOOK_RETRY_BACKOFF" default:"10s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env:"WEBHOOK_MAX_BACKOFF" default:"1h"`
+}
+
+// WebhookAPI manages the webhooks tenant admins register to receive the auth events of their tenant.
+// Events are queued as deliveries in the transaction of their change and sent by RunDeliveries.
+type WebhookAPI struct {
+	logger         logger.Logger
+	webhookHandler *handler.WebhookHandler
+	rbacAPI        *RBACAPI
+	sender         *webhookSender
+	config         WebhookConfig
+
+	mu      sync.Mutex
+	running bool
+	lastRun time.Time
+	lastErr error
+}
+
+func NewWebhookAPI(rbacAPI *RBACAPI, config *WebhookConfig, logger logger.Logger) (*WebhookAPI, error) {
+	webhookHandler, err := handler.NewWebhookHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new webhook handler", "error", err)
+		return nil, err
+	}
+	return &WebhookAPI{
+		logger:         logger,
+		webhookHandler: webhookHandler,
+		rbacAPI:        rbacAPI,
+		sender:         newWebhookSender(config.Timeout),
+		config:         *config,
+	}, nil
+}
+
+// CreateWebhook registers an endpoint of the tenant and returns it with its secret, which is not returned again.
+// A secret is generated when none is given.
+func (w *WebhookAPI) CreateWebhook(ctx context.Context, tenantID, userID, url string, events []string, secret string) (*authv1.Webhook, string, error) {
+	if tenantID == "" || userID == "" || url == "" || len(events) == 0 {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, url, events"))
+		w.logger.Error("failed to create webhook", "error", err)
+		return nil, "", err
+	}
+	if err := validateWebhookSettings(url, events, secret); err != nil {
+		w.logger.Error("failed to create webhook", "tenant_id", tenantID, "error", err)
+		return nil, "", err
+	}
+	if err := w.hasPermission(ctx, tenantID, userID, permissions.WebhookCreate); err != nil {
+		w.logger.Error("failed to create webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", err
+	}
+
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			w.logger.Error("failed to generate webhook secret", "tenant_id", tenantID, "error", err)
+			return nil, "", err
+		}
+		secret = generated
+	}
+	webhook := &authv1.Webhook{
+		TenantId:  tenantID,
+		Url:       url,
+		Secret:    secret,
+		Events:    events,
+		Active:    true,
+		CreatedBy: userID,
+	}
+	id, err := w.webhookHandler.CreateWebhook(ctx, webhook)
+	if err != nil {
+		w.logger.Error("failed to create webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", err
+	}
+	webhook.Id = id
+	w.logger.Info("webhook created", "tenant_id", tenantID, "user_id", userID, "webhook_id", id, "url", url)
+	return redactWebhook(webhook), secret, nil
+}
+
+func (w *WebhookAPI) GetWebhook(ctx context.Context, tenantID, userID, webhookID string) (*authv1.Webhook, error) {
+	if tenantID == "" || userID == "" || webhookID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, webhook_id"))
+		w.logger.Error("failed to get webhook", "error", err)
+		return nil, err
+	}
+	if err := w.hasPermission(ctx, tenantID, userID, permissions.WebhookRead); err != nil {
+		w.logger.Error("failed to get webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	webhook, err := w.webhookHandler.GetWebhookByID(ctx, tenantID, webhookID)
+	if err != nil {
+		w.logger.Error("failed to get webhook", "tenant_id", tenantID, "webhook_id", webhookID, "error", err)
+		return nil, err
+	}
+	return redactWebhook(webhook), nil
+}
+
+func (w *WebhookAPI) ListWebhooks(ctx context.Context, tenantID, userID string) ([]*authv1.Webhook, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		w.logger.Error("failed to list webhooks", "error", err)
+		return nil, err
+	}
+	if err := w.hasPermission(ctx, tenantID, userID, permissions.WebhookRead); err != nil {
+		w.logger.Error("failed to list webhooks", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	webhooks, err := w.webhookHandler.GetWebhooksByTenantID(ctx, tenantID)
+	if err != nil {
+		w.logger.Error("failed to list webhooks", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		redactWebhook(webhook)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook changes the settings given and returns the webhook, with the new secret when rotateSecret is set.
+// Unset url and active and empty events keep their values.
+func (w *WebhookAPI) UpdateWebhook(ctx context.Context, tenantID, userID, webhookID string, url *string, events []string, active *bool, rotateSecret bool) (*authv1.Webhook, string, error) {
+	if tenantID == "" || userID == "" || webhookID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, webhook_id"))
+		w.logger.Error("failed to update webhook", "error", err)
+		return nil, "", err
+	}
+	if err := w.hasPermission(ctx, tenantID, userID, permissions.WebhookUpdate); err != nil {
+		w.logger.Error("failed to update webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", err
+	}
+
+	webhook, err := w.webhookHandler.GetWebhookByID(ctx, tenantID, webhookID)
+	if err != nil {
+		w.logger.Error("failed to get webhook", "tenant_id", tenantID, "webhook_id", webhookID, "error", err)
+		return nil, "", err
+	}
+	if url != nil {
+		webhook.Url = *url
+	}
+	if len(events) > 0 {
+		webhook.Events = events
+	}
+	if active != nil {
+		webhook.Active = *active
+	}
+	if err := validateWebhookSettings(webhook.GetUrl(), webhook.GetEvents(), ""); err != nil {
+		w.logger.Error("failed to update webhook", "tenant_id", tenantID, "webhook_id", webhookID, "error", err)
+		return nil, "", err
+	}
+	secret := ""
+	if rotateSecret {
+		if secret, err = generateWebhookSecret(); err != nil {
+			w.logger.Error("failed to generate webhook secret", "tenant_id", tenantID, "error", err)
+			return nil, "", err
+		}
+		webhook.Secret = secret
+	}
+	if err := w.webhookHandler.UpdateWebhook(ctx, webhook); err != nil {
+		w.logger.Error("failed to update webhook", "tenant_id", tenantID, "webhook_id", webhookID, "error", err)
+		return nil, "", err
+	}
+	w.logger.Info("webhook updated", "tenant_id", tenantID, "user_id", userID, "webhook_id", webhookID, "secret_rotated", rotateSecret)
+	return redactWebhook(webhook), secret, nil
+}
+
+// DeleteWebhook removes a webhook, its pending deliveries are given up and its delivery log expires with time
+func (w *WebhookAPI) DeleteWebhook(ctx context.Context, tenantID, userID, webhookID string) error {
+	if tenantID == "" || userID == "" || webhookID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, webhook_id"))
+		w.logger.Error("failed to delete webhook", "error", err)
+		return err
+	}
+	if err := w.hasPermission(ctx, tenantID, userID, permissions.WebhookDelete); err != nil {
+		w.logger.Error("failed to delete webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+
+	if err := w.webhookHandler.DeleteWebhook(ctx, tenantID, webhookID); err != nil {
+		w.logger.Error("failed to delete webhook", "tenant_id", tenantID, "webhook_id", webhookID, "error", err)
+		return err
+	}
+	w.logger.Info("webhook deleted", "tenant_id", tenantID, "user_id", userID, "webhook_id", webhookID)
+	return nil
+}
+
+// ListDeliveries returns the delivery log of a webhook newest first, of every status when status is empty
+func (w *WebhookAPI) ListDeliveries(ctx context.Context, tenantID, userID, webhookID, status string) ([]*authv1.WebhookDelivery, error) {
+	if tenantID == "" || userID == "" || webhookID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, webhook_id"))
+		w.logger.Error("failed to list webhook deliveries", "error", err)
+		return nil, err
+	}
+	if err := w.hasPermission(ctx, tenantID, userID, permissions.WebhookRead); err != nil {
+		w.logger.Error("failed to list webhook deliveries", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	deliveries, err := w.webhookHandler.GetDeliveries(ctx, tenantID, webhookID, status)
+	if err != nil {
+		w.logger.Error("failed to list webhook deliveries", "tenant_id", tenantID, "webhook_id", webhookID, "error", err)
+		return nil, err
+	}
+	slices.SortStableFunc(deliveries, func(a, b *authv1.WebhookDelivery) int {
+		return b.GetCreatedAt().AsTime().Compare(a.GetCreatedAt().AsTime())
+	})
+	return deliveries, nil
+}
+
+// ReplayDelivery queues the payload of a delivery again as a new delivery to its webhook, the replayed delivery
+// is kept in the log
+func (w *WebhookAPI) ReplayDelivery(ctx context.Context, tenantID, userID, deliveryID string) (*authv1.WebhookDelivery, error) {
+	if tenantID == "" || userID == "" || deliveryID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, delivery_id"))
+		w.logger.Error("failed to replay webhook delivery", "error", err)
+		return nil, err
+	}
+	if err := w.hasPermission(ctx, tenantID, userID, permissions.WebhookUpdate); err != nil {
+		w.logger.Error("failed to replay webhook delivery", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	original, err := w.webhookHandler.GetDeliveryByID(ctx, tenantID, deliveryID)
+	if err != nil {
+		w.logger.Error("failed to get webhook delivery", "tenant_id", tenantID, "delivery_id", deliveryID, "error", err)
+		return nil, err
+	}
+	// The webhook must still exist, deliveries of deleted webhooks are only kept as a log
+	if _, err := w.webhookHandler.GetWebhookByID(ctx, tenantID, original.GetWebhookId()); err != nil {
+		w.logger.Error("failed to get webhook", "tenant_id", tenantID, "webhook_id", original.GetWebhookId(), "error", err)
+		return nil, err
+	}
+	delivery := newWebhookDelivery(tenantID, original.GetWebhookId(), original.GetEventId(), original.GetEvent(), original.GetPayload())
+	delivery.ReplayOf = original.GetId()
+	id, err := w.webhookHandler.CreateDelivery(ctx, delivery)
+	if err != nil {
+		w.logger.Error("failed to replay webhook delivery", "tenant_id", tenantID, "delivery_id", deliveryID, "error", err)
+		return nil, err
+	}
+	delivery.Id = id
+	w.logger.Info("webhook delivery replayed", "tenant_id", tenantID, "user_id", userID, "delivery_id", deliveryID, "replay_id", id)
+	return delivery, nil
+}
+
+// Enqueue queues a delivery of event to every active webhook of its tenant subscribed to it.
+// It is an outbox.Subscriber, the deliveries are written in the transaction of the change.
+func (w *WebhookAPI) Enqueue(ctx context.Context, event *eventv1.DomainEvent) error {
+	if event.GetTenantId() == "" {
+		return nil
+	}
+	name := model_event.WebhookEvent(event.GetType())
+	if !slices.Contains(webhookEvents, name) {
+		return nil
+	}
+	webhooks, err := w.webhookHandler.GetActiveWebhooks(ctx, event.GetTenantId())
+	if err != nil {
+		return err
+	}
+	var payload string
+	for _, webhook := range webhooks {
+		if !matchesWebhookEvent(webhook.GetEvents(), name) {
+			continue
+		}
+		if payload == "" {
+			if payload, err = webhookPayload(event); err != nil {
+				return err
+			}
+		}
+		delivery := newWebhookDelivery(event.GetTenantId(), webhook.GetId(), event.GetId(), name, payload)
+		if _, err := w.webhookHandler.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* Helper functions */
+func (w *WebhookAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return w.rbacAPI.Verification.HasPermission(ctx, tenantID, userID, permission, tenantID)
+}
+
+func newWebhookDelivery(tenantID, webhookID, eventID, event, payload string) *authv1.WebhookDelivery {
+	now := timestamppb.Now()
+	return &authv1.WebhookDelivery{
+		TenantId:      tenantID,
+		WebhookId:     webhookID,
+		EventId:       eventID,
+		Event:         event,
+		Payload:       payload,
+		Status:        model_event.WebhookDeliveryPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+}
+
+// redactWebhook clears the secret of a webhook returned to a caller
+func redactWebhook(webhook *authv1.Webhook) *authv1.Webhook {
+	webhook.Secret = ""
+	return webhook
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+// validateWebhookSettings validates the url and event filter of a webhook, and its secret when one is chosen
+func validateWebhookSettings(url string, events []string, secret string) error {
+	if err := validator_auth.ValidateWebhookURL(url); err != nil {
+		return err
+	}
+	for _, filter := range events {
+		if !isValidWebhookFilter(filter) {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "events").WithDetails("event", filter)
+		}
+	}
+	if secret != "" && len(secret) < minWebhookSecretLength {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "secret")
+	}
+	return nil
+}
+
+// isValidWebhookFilter reports whether filter is *, a webhook event or <aggregate>.* of one
+func isValidWebhookFilter(filter string) bool {
+	if filter == webhookAllEvents {
+		return true
+	}
+	return slices.ContainsFunc(webhookEvents, func(event string) bool {
+		return matchesWebhookEvent([]string{filter}, event)
+	})
+}
+
+// matchesWebhookEvent reports whether one of the filters of a webhook selects event
+func matchesWebhookEvent(filters []string, event string) bool {
+	for _, filter := range filters {
+		if filter == webhookAllEvents || filter == event {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(filter, ".*"); ok && strings.HasPrefix(event, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookPayload returns the JSON body of the deliveries of event
+func webhookPayload(event *eventv1.DomainEvent) (string, error) {
+	body := struct {
+		ID         string          `json:"id"`
+		Event      string          `json:"event"`
+		TenantID   string          `json:"tenant_id"`
+		ActorID    string          `json:"actor_id,omitempty"`
+		OccurredAt time.Time       `json:"occurred_at"`
+		Data       json.RawMessage `json:"data,omitempty"`
+	}{
+		ID:         event.GetId(),
+		Event:      model_event.WebhookEvent(event.GetType()),
+		TenantID:   event.GetTenantId(),
+		ActorID:    event.GetActorId(),
+		OccurredAt: event.GetOccurredAt().AsTime().UTC(),
+	}
+	message := event.ProtoReflect()
+	if field := message.WhichOneof(message.Descriptor().Oneofs().ByName("payload")); field != nil {
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message.Get(field).Message().Interface())
+		if err != nil {
+			return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+		}
+		body.Data = data
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return string(payload), nil
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"erp.localhost/internal/infra/metrics"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_event "erp.localhost/internal/infra/model/event"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Headers of the webhook deliveries. The signature is t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>"> with
+// the webhook secret, receivers reject old timestamps to stop replays
+const (
+	WebhookSignatureHeader = "X-ERP-Signature"
+	WebhookEventHeader     = "X-ERP-Event"
+	WebhookDeliveryHeader  = "X-ERP-Delivery"
+)
+
+// Delivery results of webhookDeliveries
+const (
+	webhookResultDelivered = "delivered"
+	webhookResultRetried   = "retried"
+	webhookResultFailed    = "failed"
+)
+
+var webhookDeliveries = metrics.NewCounter("webhook_deliveries_total",
+	"Total number of webhook delivery attempts, by event and result.", "event", "result")
+
+// webhookSender posts signed payloads to the webhook endpoints
+type webhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func newWebhookSender(timeout time.Duration) *webhookSender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &webhookSender{
+		client: &http.Client{Timeout: timeout},
+		now:    time.Now,
+	}
+}
+
+// send posts the payload of delivery to the webhook and returns the status code of the endpoint, 0 when it could
+// not be reached. Codes other than 2xx are returned with an error
+func (s *webhookSender) send(ctx context.Context, webhook *authv1.Webhook, delivery *authv1.WebhookDelivery) (int, error) {
+	body := []byte(delivery.GetPayload())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.GetUrl(), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ERP-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.GetEvent())
+	req.Header.Set(WebhookDeliveryHeader, delivery.GetId())
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload(webhook.GetSecret(), s.now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain a bounded part of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// signWebhookPayload returns the signature header of body sent at timestamp
+func signWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RunDeliveries sends the pending deliveries every DeliveryInterval until quit is closed
+func (w *WebhookAPI) RunDeliveries(quit <-chan struct{}) {
+	interval := w.config.DeliveryInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	w.setRunning(true)
+	defer w.setRunning(false)
+	// Deliveries are sent in the background, not on behalf of a request
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = w.DeliverPending(ctx)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// DeliverPending sends the deliveries due for an attempt, oldest first, and returns how many were delivered
+func (w *WebhookAPI) DeliverPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := w.webhookHandler.GetPendingDeliveries(ctx, now)
+	w.finishRun(now, err)
+	if err != nil {
+		w.logger.Error("failed to read the pending webhook deliveries", "error", err)
+		return 0, err
+	}
+	slices.SortStableFunc(deliveries, func(a, b *authv1.WebhookDelivery) int {
+		return a.GetCreatedAt().AsTime().Compare(b.GetCreatedAt().AsTime())
+	})
+	if w.config.BatchSize > 0 && len(deliveries) > w.config.BatchSize {
+		deliveries = deliveries[:w.config.BatchSize]
+	}
+
+	delivered := 0
+	webhooks := map[string]*authv1.Webhook{}
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.GetWebhookId()]
+		if !ok {
+			// A missing webhook was deleted, its deliveries are given up below
+			webhook, _ = w.webhookHandler.GetWebhookByID(ctx, delivery.GetTenantId(), delivery.GetWebhookId())
+			webhooks[delivery.GetWebhookId()] = webhook
+		}
+		if w.deliver(ctx, webhook, delivery) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliver makes one attempt of delivery and stores its outcome, it reports whether the delivery succeeded
+func (w *WebhookAPI) deliver(ctx context.Context, webhook *authv1.Webhook, delivery *authv1.WebhookDelivery) bool {
+	if webhook == nil || !webhook.GetActive() {
+		delivery.Status = model_event.WebhookDeliveryFailed
+		delivery.LastError = "webhook deleted or disabled"
+		webhookDeliveries.Inc(delivery.GetEvent(), webhookResultFailed)
+		w.updateDelivery(ctx, delivery)
+		return false
+	}
+
+	delivery.Attempts++
+	code, err := w.sender.send(ctx, webhook, delivery)
+	delivery.ResponseCode = int32(code)
+	if err == nil {
+		delivery.Status = model_event.WebhookDeliveryDelivered
+		delivery.DeliveredAt = timestamppb.Now()
+		delivery.LastError = ""
+		webhookDeliveries.Inc(delivery.GetEvent(), webhookResultDelivered)
+		w.updateDelivery(ctx, delivery)
+		return true
+	}
+
+	delivery.LastError = err.Error()
+	if w.config.MaxAttempts > 0 && int(delivery.Attempts) >= w.config.MaxAttempts {
+		delivery.Status = model_event.WebhookDeliveryFailed
+		webhookDeliveries.Inc(delivery.GetEvent(), webhookResultFailed)
+		w.logger.Warn("giving up webhook delivery", "tenant_id", delivery.GetTenantId(), "webhook_id", delivery.GetWebhookId(), "delivery_id", delivery.GetId(), "attempts", delivery.Attempts, "error", err)
+	} else {
+		delivery.NextAttemptAt = timestamppb.New(time.Now().Add(w.backoff(int(delivery.Attempts))))
+		webhookDeliveries.Inc(delivery.GetEvent(), webhookResultRetried)
+		w.logger.Debug("webhook delivery failed", "tenant_id", delivery.GetTenantId(), "webhook_id", delivery.GetWebhookId(), "delivery_id", delivery.GetId(), "attempts", delivery.Attempts, "error", err)
+	}
+	w.updateDelivery(ctx, delivery)
+	return false
+}
+
+func (w *WebhookAPI) updateDelivery(ctx context.Context, delivery *authv1.WebhookDelivery) {
+	if err := w.webhookHandler.UpdateDelivery(ctx, delivery); err != nil {
+		// A delivered payload is sent again on the next poll, receivers drop the duplicate by event id
+		w.logger.Error("failed to store webhook delivery attempt", "tenant_id", delivery.GetTenantId(), "delivery_id", delivery.GetId(), "error", err)
+	}
+}
+
+// backoff returns the wait before the attempt following attempt, attempts count from 1
+func (w *WebhookAPI) backoff(attempt int) time.Duration {
+	backoff := w.config.RetryBackoff
+	for i := 1; i < attempt && backoff < w.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if w.config.MaxBackoff > 0 {
+		backoff = min(backoff, w.config.MaxBackoff)
+	}
+	return backoff
+}
+
+// DeliveryStatus returns whether deliveries are sent, when the pending deliveries were last polled and the error
+// of that poll
+func (w *WebhookAPI) DeliveryStatus() (bool, time.Time, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.running, w.lastRun, w.lastErr
+}
+
+func (w *WebhookAPI) setRunning(running bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.running = running
+}
+
+func (w *WebhookAPI) finishRun(at time.Time, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastRun = at
+	w.lastErr = err
+}
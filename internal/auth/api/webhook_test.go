@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMatchesWebhookEvent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filters  []string
+		event    string
+		expected bool
+	}{
+		{name: "every event", filters: []string{"*"}, event: "login.failed", expected: true},
+		{name: "exact event", filters: []string{"user.created"}, event: "user.created", expected: true},
+		{name: "other event", filters: []string{"user.created"}, event: "user.deleted", expected: false},
+		{name: "aggregate prefix", filters: []string{"role.*"}, event: "role.updated", expected: true},
+		{name: "prefix of another aggregate", filters: []string{"user.*"}, event: "role.updated", expected: false},
+		{name: "prefix is not a substring", filters: []string{"use.*"}, event: "user.created", expected: false},
+		{name: "one of the filters", filters: []string{"user.created", "login.*"}, event: "login.failed", expected: true},
+		{name: "no filters", filters: nil, event: "user.created", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchesWebhookEvent(tc.filters, tc.event))
+		})
+	}
+}
+
+func TestValidateWebhookSettings(t *testing.T) {
+	testCases := []struct {
+		name    string
+		url     string
+		events  []string
+		secret  string
+		wantErr bool
+	}{
+		{name: "valid", url: "https://hooks.example.com/erp", events: []string{"user.created", "role.*"}},
+		{name: "every event", url: "http://localhost:8080/hook", events: []string{"*"}},
+		{name: "chosen secret", url: "https://hooks.example.com/erp", events: []string{"*"}, secret: "0123456789abcdef"},
+		{name: "relative url", url: "/hook", events: []string{"*"}, wantErr: true},
+		{name: "unsupported scheme", url: "ftp://hooks.example.com", events: []string{"*"}, wantErr: true},
+		{name: "unknown event", url: "https://hooks.example.com", events: []string{"user.renamed"}, wantErr: true},
+		{name: "unknown aggregate", url: "https://hooks.example.com", events: []string{"order.*"}, wantErr: true},
+		{name: "short secret", url: "https://hooks.example.com", events: []string{"*"}, secret: "short", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateWebhookSettings(tc.url, tc.events, tc.secret)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookPayload(t *testing.T) {
+	event := roleEvent(model_event.EventRoleUpdated, "admin-1", &authv1.Role{
+		Id:          "role-1",
+		TenantId:    "tenant-1",
+		Name:        "Sales",
+		Permissions: []string{"order:read"},
+	})
+
+	payload, err := webhookPayload(event)
+	require.NoError(t, err)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(payload), &body))
+	assert.Equal(t, event.Id, body["id"])
+	assert.Equal(t, "role.updated", body["event"])
+	assert.Equal(t, "tenant-1", body["tenant_id"])
+	assert.Equal(t, "admin-1", body["actor_id"])
+	data, ok := body["data"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "role-1", data["role_id"])
+	assert.Equal(t, []any{"order:read"}, data["permissions"])
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"event-1"}`)
+
+	signature := signWebhookPayload("secret", at, body)
+	assert.Regexp(t, `^t=1700000000,v1=[0-9a-f]{64}$`, signature)
+	assert.Equal(t, signature, signWebhookPayload("secret", at, body))
+	assert.NotEqual(t, signature, signWebhookPayload("other", at, body))
+	assert.NotEqual(t, signature, signWebhookPayload("secret", at.Add(time.Second), body))
+}
+
+func TestWebhookSender_Send(t *testing.T) {
+	var received *http.Request
+	var receivedBody []byte
+	code := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	at := time.Unix(1700000000, 0)
+	sender := newWebhookSender(time.Second)
+	sender.now = func() time.Time { return at }
+	webhook := &authv1.Webhook{Url: server.URL, Secret: "secret"}
+	delivery := &authv1.WebhookDelivery{Id: "delivery-1", Event: "user.created", Payload: `{"id":"event-1"}`}
+
+	status, err := sender.send(context.Background(), webhook, delivery)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, delivery.Payload, string(receivedBody))
+	assert.Equal(t, "user.created", received.Header.Get(WebhookEventHeader))
+	assert.Equal(t, "delivery-1", received.Header.Get(WebhookDeliveryHeader))
+	assert.Equal(t, signWebhookPayload("secret", at, receivedBody), received.Header.Get(WebhookSignatureHeader))
+
+	// Codes other than 2xx are retried
+	code = http.StatusServiceUnavailable
+	status, err = sender.send(context.Background(), webhook, delivery)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestWebhookAPI_Backoff(t *testing.T) {
+	w := &WebhookAPI{config: WebhookConfig{RetryBackoff: 10 * time.Second, MaxBackoff: time.Minute}}
+	assert.Equal(t, 10*time.Second, w.backoff(1))
+	assert.Equal(t, 20*time.Second, w.backoff(2))
+	assert.Equal(t, 40*time.Second, w.backoff(3))
+	assert.Equal(t, time.Minute, w.backoff(4))
+	assert.Equal(t, time.Minute, w.backoff(10))
+}
+
+func newWebhookTestAPI(t *testing.T) (*WebhookAPI, *memory.Documents) {
+	t.Helper()
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	webhookHandler, err := handler.NewWebhookHandler(log)
+	require.NoError(t, err)
+	return &WebhookAPI{
+		logger:         log,
+		webhookHandler: webhookHandler,
+		sender:         newWebhookSender(time.Second),
+		config:         WebhookConfig{MaxAttempts: 3, RetryBackoff: time.Minute, MaxBackoff: time.Hour},
+	}, documents
+}
+
+// createTestWebhook stores a webhook of tenant-1 subscribed to events
+func createTestWebhook(t *testing.T, w *WebhookAPI, url string, active bool, events ...string) string {
+	t.Helper()
+	id, err := w.webhookHandler.CreateWebhook(context.Background(), &authv1.Webhook{
+		TenantId:  "tenant-1",
+		Url:       url,
+		Events:    events,
+		Secret:    "0123456789abcdef",
+		Active:    active,
+		CreatedBy: "admin-1",
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func TestWebhookAPI_DeliverPending(t *testing.T) {
+	errWrite := errors.New("write failed")
+	testCases := []struct {
+		name string
+		// Status code of the endpoint, the endpoint is unreachable when 0
+		code int
+		// State of the webhook of the delivery, active, disabled or deleted
+		webhook  string
+		attempts int32
+		// failWrites fails the writes of the deliveries
+		failWrites    bool
+		wantDelivered int
+		wantRequests  int
+		wantStatus    string
+		wantAttempts  int32
+		wantCode      int32
+		wantError     string
+	}{
+		{name: "endpoint error is retried", code: http.StatusServiceUnavailable, webhook: "active", wantRequests: 1, wantStatus: model_event.WebhookDeliveryPending, wantAttempts: 1, wantCode: http.StatusServiceUnavailable, wantError: "webhook returned status 503"},
+		{name: "unreachable endpoint is retried", webhook: "active", wantStatus: model_event.WebhookDeliveryPending, wantAttempts: 1, wantError: "failed to call webhook"},
+		{name: "last attempt is given up", code: http.StatusInternalServerError, webhook: "active", attempts: 2, wantRequests: 1, wantStatus: model_event.WebhookDeliveryFailed, wantAttempts: 3, wantCode: http.StatusInternalServerError, wantError: "webhook returned status 500"},
+		{name: "disabled webhook is given up", code: http.StatusNoContent, webhook: "disabled", wantStatus: model_event.WebhookDeliveryFailed, wantError: "webhook deleted or disabled"},
+		{name: "deleted webhook is given up", code: http.StatusNoContent, webhook: "deleted", wantStatus: model_event.WebhookDeliveryFailed, wantError: "webhook deleted or disabled"},
+		// The delivery is sent again on the next poll, receivers drop the duplicate by event id
+		{name: "delivery not stored", code: http.StatusNoContent, webhook: "active", failWrites: true, wantDelivered: 1, wantRequests: 1, wantStatus: model_event.WebhookDeliveryPending},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, documents := newWebhookTestAPI(t)
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				requests++
+				rw.WriteHeader(tc.code)
+			}))
+			defer server.Close()
+			url := server.URL
+			if tc.code == 0 {
+				server.Close()
+			}
+
+			ctx := context.Background()
+			webhookID := "deleted-webhook"
+			if tc.webhook != "deleted" {
+				webhookID = createTestWebhook(t, w, url, tc.webhook == "active", "*")
+			}
+			delivery := newWebhookDelivery("tenant-1", webhookID, "event-1", "user.created", `{"id":"event-1"}`)
+			delivery.Attempts = tc.attempts
+			deliveryID, err := w.webhookHandler.CreateDelivery(ctx, delivery)
+			require.NoError(t, err)
+			if tc.failWrites {
+				documents.OnWrite(func(collectionName string, _ bson.M) error {
+					if collectionName == string(model_mongo.WebhookDeliveriesCollection) {
+						return errWrite
+					}
+					return nil
+				})
+			}
+
+			delivered, err := w.DeliverPending(ctx)
+			require.NoError(t, err)
+			documents.OnWrite(nil)
+			assert.Equal(t, tc.wantDelivered, delivered)
+			assert.Equal(t, tc.wantRequests, requests)
+
+			stored, err := w.webhookHandler.GetDeliveryByID(ctx, "tenant-1", deliveryID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, stored.GetStatus())
+			assert.Equal(t, tc.wantAttempts, stored.GetAttempts())
+			assert.Equal(t, tc.wantCode, stored.GetResponseCode())
+			assert.Contains(t, stored.GetLastError(), tc.wantError)
+			if tc.wantStatus == model_event.WebhookDeliveryPending && tc.wantAttempts > 0 {
+				assert.True(t, stored.GetNextAttemptAt().AsTime().After(time.Now()), "retried after the backoff")
+			}
+		})
+	}
+}
+
+func TestWebhookAPI_Enqueue(t *testing.T) {
+	ctx := context.Background()
+	w, documents := newWebhookTestAPI(t)
+	subscribedID := createTestWebhook(t, w, "https://hooks.example.com/users", true, "user.*")
+	createTestWebhook(t, w, "https://hooks.example.com/roles", true, "role.*")
+	disabledID := createTestWebhook(t, w, "https://hooks.example.com/disabled", false, "*")
+	user := &authv1.User{Id: "user-1", TenantId: "tenant-1", Email: "user@example.com"}
+
+	// A failed delivery fails the change it is written with
+	errWrite := errors.New("write failed")
+	documents.OnWrite(func(collectionName string, _ bson.M) error {
+		if collectionName == string(model_mongo.WebhookDeliveriesCollection) {
+			return errWrite
+		}
+		return nil
+	})
+	require.ErrorIs(t, w.Enqueue(ctx, userEvent(model_event.EventUserCreated, "admin-1", user)), errWrite)
+	documents.OnWrite(nil)
+
+	require.NoError(t, w.Enqueue(ctx, userEvent(model_event.EventUserCreated, "admin-1", user)))
+	deliveries, err := w.webhookHandler.GetDeliveries(ctx, "tenant-1", subscribedID, "")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "user.created", deliveries[0].GetEvent())
+	assert.Equal(t, model_event.WebhookDeliveryPending, deliveries[0].GetStatus())
+	disabled, err := w.webhookHandler.GetDeliveries(ctx, "tenant-1", disabledID, "")
+	require.NoError(t, err)
+	assert.Empty(t, disabled)
+
+	// Events without tenant are not delivered
+	require.NoError(t, w.Enqueue(ctx, userEvent(model_event.EventUserCreated, "admin-1", &authv1.User{Id: "user-2"})))
+	pending, err := w.webhookHandler.GetPendingDeliveries(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+}
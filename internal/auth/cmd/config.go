@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"time"
+
+	"erp.localhost/internal/auth/api"
+	"erp.localhost/internal/auth/rbac"
+	"erp.localhost/internal/auth/scim"
+	"erp.localhost/internal/auth/token"
+	infra_config "erp.localhost/internal/infra/config"
+	"erp.localhost/internal/infra/db/mongo/changestream"
+	"erp.localhost/internal/infra/event/outbox"
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/secret"
+	"erp.localhost/internal/infra/shutdown"
+	"erp.localhost/internal/infra/usage"
+)
+
+// Config holds the settings of the auth service, see infra_config.Load for how they are resolved
+type Config struct {
+	Server  infra_config.Server  `yaml:"server"`
+	Metrics infra_config.Metrics `yaml:"metrics"`
+	Mongo   infra_config.Mongo   `yaml:"mongo"`
+	TLS     infra_config.TLS     `yaml:"tls"`
+	Client  infra_config.Client  `yaml:"client"`
+	Secrets secret.Config        `yaml:"secrets"`
+	// How the addresses of the other services are found
+	Discovery discovery.Config `yaml:"discovery"`
+	// Draining of the calls and goroutines and closing of the dependencies at shutdown
+	Shutdown shutdown.Config `yaml:"shutdown"`
+	// Resolved user permissions, cached in Redis between the checks
+	PermissionCache rbac.PermissionCacheConfig `yaml:"permission_cache"`
+	// Domain events of the users, tenants, roles and permissions, published to the broker through the outbox
+	Events outbox.Config `yaml:"events"`
+	// Delivery of the auth events to the webhooks registered by the tenants
+	Webhooks api.WebhookConfig `yaml:"webhooks"`
+	// Streams of the audit events to the security tooling of the tenants
+	AuditStream api.AuditStreamConfig `yaml:"audit_stream"`
+	// SCIM endpoint the identity providers of the tenants provision their users and groups through
+	SCIM scim.Config `yaml:"scim"`
+	// Sweeps of the expired temporary role assignments
+	RoleAssignments api.RoleAssignmentConfig `yaml:"role_assignments"`
+	// Short-lived access tokens of the users acting as other users of their tenant
+	Impersonation api.ImpersonationConfig `yaml:"impersonation"`
+	// Scoring of the logins against the devices and networks the users logged in from before
+	LoginRisk api.LoginRiskConfig `yaml:"login_risk"`
+	// Lifetime and URL of the magic links logging the users in without their password
+	LoginLink api.LoginLinkConfig `yaml:"login_link"`
+	// Grace period and sweeps of the accounts the users asked to delete
+	AccountDeletion api.AccountDeletionConfig `yaml:"account_deletion"`
+	// Trial period of the new tenants and sweeps of the expired trials
+	TenantLifecycle api.TenantLifecycleConfig `yaml:"tenant_lifecycle"`
+	// Tenants read by the status check of every call and the usage and quota checks, cached in memory
+	TenantCache api.TenantCacheConfig `yaml:"tenant_cache"`
+	// Change stream of the users, tenants, roles and permissions, see api.ChangeFeed
+	ChangeStream changestream.Config `yaml:"change_stream"`
+	// Sweeps of the expired and revoked tokens left in Redis
+	TokenCleanup token.CleanupConfig `yaml:"token_cleanup"`
+	// Address of the config service, tenant SAML settings are stored in it. Found by the discovery when empty
+	ConfigServiceAddress string `yaml:"config_service_address" env:"CONFIG_SERVICE_ADDRESS" flag:"config-service-address"`
+	// Key of the hashes of the verification codes and tokens, the value of the secret store takes precedence.
+	// Required, the service does not start without it
+	VerificationCodeSecret string `yaml:"verification_code_secret" env:"VERIFICATION_CODE_SECRET"`
+	// Interval between two flushes of the API usage rollups
+	UsageFlushInterval time.Duration `yaml:"usage_flush_interval" env:"USAGE_FLUSH_INTERVAL" flag:"usage-flush-interval" validate:"positive"`
+}
+
+// loadConfig loads the settings of the service from its defaults, CONFIG_FILE, the environment and args
+func loadConfig(args []string) (*Config, error) {
+	config := &Config{
+		Server:             infra_config.NewServer(5000),
+		Metrics:            infra_config.Metrics{Port: 9000},
+		UsageFlushInterval: usage.DefaultFlushInterval,
+	}
+	if err := infra_config.Load(config, infra_config.WithArgs(args)); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -1,63 +1,96 @@
 package cmd
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"os"
-	"os/signal"
-	"sync"
-	"syscall"
 	"time"
 
 	"erp.localhost/internal/auth/api"
 	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/hash"
 	"erp.localhost/internal/auth/rbac"
+	"erp.localhost/internal/auth/scim"
 	"erp.localhost/internal/auth/service"
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/buildinfo"
+	db_mongo "erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/changestream"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/db/redis"
 	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/event/broker"
+	"erp.localhost/internal/infra/event/outbox"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/grpc/interceptor"
 	"erp.localhost/internal/infra/grpc/server"
 	grpc_server "erp.localhost/internal/infra/grpc/server"
 	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
 	model_shared "erp.localhost/internal/infra/model/shared"
-)
-
-const (
-	ServerPort = 5000
+	"erp.localhost/internal/infra/notification"
+	"erp.localhost/internal/infra/secret"
+	"erp.localhost/internal/infra/shutdown"
+	"erp.localhost/internal/infra/status"
+	"erp.localhost/internal/infra/tracing"
+	"google.golang.org/grpc"
 )
 
 // TODO: when breaking to microservices, this will be the entry point for the auth service
 func Main() {
 	logger := logger.NewBaseLogger(model_shared.ModuleAuth)
 	defer logger.Close()
-	logger.Info("Starting service...")
-	// Channel to listen for OS signals for graceful shutdown
-	stopChan := make(chan os.Signal, 1)
-	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
-
-	// Channel to signal the gRPC server goroutine to stop
-	quit := make(chan struct{})
-
-	insecure := false
-	certs := model_shared.NewCerts()
-	if certs == nil {
-		logger.Warn("configuring insecure")
-		insecure = true
+	logger.Info("Starting service...", buildinfo.Get().LogArgs()...)
+	config, err := loadConfig(os.Args[1:])
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		return
 	}
-
-	// Create server
-	logger.Info("Creating gRPC server...")
-	srv, err := grpc_server.NewGRPCServer(&server.Config{
-		Port:             ServerPort,
-		Module:           model_shared.ModuleAuth,
-		Insecure:         insecure, // Set to false for production with certs
-		Certs:            certs,
-		EnableReflection: true,
-		KeepAliveTime:    30 * time.Second,
-		KeepAliveTimeout: 10 * time.Second,
-	}, logger)
+	// Stops the server and the background goroutines, then closes the dependencies once main returns
+	coordinator := shutdown.New(&config.Shutdown, logger)
+	defer coordinator.Shutdown()
+	// The database clients are shared by the handlers and the services of the process, they close last
+	coordinator.OnCloseShared("mongo", db_mongo.CloseClients)
+	coordinator.OnCloseShared("redis", func(context.Context) error { return redis.CloseClients() })
+	// Credentials are read from the secret store selected by SECRETS_PROVIDER
+	secrets, err := secret.New(&config.Secrets, logger)
 	if err != nil {
-		logger.Error(infra_error.Internal(infra_error.InternalGRPCError, err).Error())
+		logger.Error("failed to create secret provider", "error", err)
+		return
+	}
+	coordinator.OnClose("secrets", func(context.Context) error { return secrets.Close() })
+	if uri, ok := secret.Lookup(context.Background(), secrets, secret.MongoURI, logger); ok {
+		config.Mongo.URI = uri
+	}
+	db_mongo.SetURI(config.Mongo.URI)
+	if value, ok := secret.Lookup(context.Background(), secrets, secret.VerificationCodeSecret, logger); ok {
+		config.VerificationCodeSecret = value
+	}
+	if config.VerificationCodeSecret == "" {
+		logger.Error("invalid configuration", "error", infra_error.Validation(infra_error.ValidationRequiredFields, secret.VerificationCodeSecret))
 		return
 	}
+	db_mongo.SetReadPreference(db_mongo.ReadPreference(config.Mongo.ReadPreference))
+	// Distributed tracing, spans are exported when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.LoadConfig(model_shared.ModuleAuth), logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	coordinator.OnClose("tracing", shutdownTracing)
+
+	// Connections between the services use mTLS, the service does not start without its certificates unless
+	// TLS_INSECURE is set
+	certs := model_shared.NewCerts()
+	if config.TLS.CertsDir != "" {
+		certs = model_shared.NewCertsFromDir(config.TLS.CertsDir)
+	}
 
 	roleHanlder := createRoleHandler(logger)
 	if roleHanlder == nil {
@@ -69,15 +102,189 @@ func Main() {
 		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to create permission manager")).Error())
 		return
 	}
+	permSetHandler := createPermissionSetHandler(logger)
+	if permSetHandler == nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to create permission set manager")).Error())
+		return
+	}
 	verificationManager := createVerificationManager(logger)
 	if verificationManager == nil {
 		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to create verification manager")).Error())
 		return
 	}
-	rbacAPI := api.NewRBACAPI(roleHanlder, permHandler, verificationManager, logger)
-	userAPI, err := api.NewUserAPI(rbacAPI, logger)
+	if config.PermissionCache.Enabled {
+		permissionCache, err := rbac.NewRedisPermissionCache(&config.PermissionCache, logger)
+		if err != nil {
+			// Permissions are resolved from Mongo on every check without the cache
+			logger.Warn("failed to create permission cache, continuing without it", "error", err)
+		} else {
+			verificationManager.SetPermissionCache(permissionCache)
+		}
+	}
+	rbacAPI := api.NewRBACAPI(roleHanlder, permHandler, permSetHandler, verificationManager, logger)
+	userAPI, err := api.NewUserAPI(rbacAPI, hash.NewCodeHasher(config.VerificationCodeSecret), logger)
 	authAPI, err := api.NewAuthAPI(rbacAPI, userAPI, logger)
 	tenantAPI, err := api.NewTenantAPI(authAPI, rbacAPI, userAPI, logger)
+	systemAPI, err := api.NewSystemAPI(rbacAPI, userAPI, logger)
+	apiKeyAPI, err := api.NewAPIKeyAPI(rbacAPI, logger)
+	webhookAPI, err := api.NewWebhookAPI(rbacAPI, &config.Webhooks, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	auditAPI, err := api.NewAuditAPI(rbacAPI, &config.AuditStream, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+
+	// Notifications for auth events, channels are enabled by their environment configuration
+	dispatcher := notification.NewDispatcherFromEnv(logger)
+	authAPI.SetNotificationDispatcher(dispatcher)
+	// The token signing key follows the rotations of the secret store
+	authAPI.SetSecretProvider(context.Background(), secrets)
+	authAPI.SetImpersonationConfig(&config.Impersonation)
+	authAPI.SetLoginRiskConfig(&config.LoginRisk)
+	authAPI.SetLoginLinkConfig(&config.LoginLink)
+	userAPI.SetNotificationDispatcher(dispatcher)
+	userAPI.SetAccountDeletionConfig(&config.AccountDeletion)
+	tenantAPI.SetLifecycleConfig(&config.TenantLifecycle)
+	tenantAPI.SetTenantCacheConfig(&config.TenantCache)
+	apiKeyAPI.SetTenantQuotas(tenantAPI.Quotas())
+
+	// Changes of users, tenants, roles and permissions write their events to the outbox, the publisher sends them
+	// to the broker selected by EVENTS_BROKER
+	eventBroker, err := broker.New(&config.Events.Broker, logger)
+	if err != nil {
+		logger.Error("failed to create events broker", "error", err)
+		return
+	}
+	coordinator.OnClose("events_broker", func(context.Context) error { return eventBroker.Close() })
+	events := createOutbox(logger)
+	if events == nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to create outbox")).Error())
+		return
+	}
+	userAPI.SetOutbox(events)
+	tenantAPI.SetOutbox(events)
+	rbacAPI.SetOutbox(events)
+	authAPI.SetOutbox(events)
+	// Tenant webhooks receive the events of their tenant, deliveries are queued with the change
+	events.Subscribe(webhookAPI.Enqueue)
+	publisher := outbox.NewPublisher(events, eventBroker, &config.Events, logger)
+
+	// Clients of the other services share their connections
+	retry := interceptor.DefaultRetryPolicy()
+	retry.MaxAttempts = config.Client.RetryAttempts
+	clients := client.NewFactory(&client.Config{
+		Module:              model_shared.ModuleAuth,
+		Insecure:            config.TLS.Insecure,
+		Certs:               certs,
+		CertReloadInterval:  config.TLS.ReloadInterval,
+		ConnectTimeout:      config.Client.ConnectTimeout,
+		RequestTimeout:      config.Client.RequestTimeout,
+		KeepAliveTime:       config.Client.KeepAliveTime,
+		KeepAliveTimeout:    config.Client.KeepAliveTimeout,
+		LoadBalancingPolicy: config.Client.LoadBalancingPolicy,
+		Retry:               retry,
+	}, logger)
+	coordinator.OnClose("grpc_clients", func(context.Context) error { return clients.Close() })
+	// Services without a configured address are found by the discovery, see DISCOVERY_PROVIDER
+	resolver, err := discovery.New(&config.Discovery)
+	if err != nil {
+		logger.Error("invalid service discovery", "error", err)
+		return
+	}
+	clients.SetResolver(resolver)
+
+	// Tenant SAML settings are stored in the config service
+	// Changes of the auth collections, made by the API or around it, invalidate the permission and tenant caches and
+	// are recorded as auth.<aggregate>.changed events. Needs a replica set, like the transactions
+	var changeWatcher *changestream.Watcher
+	if config.ChangeStream.Enabled {
+		changeWatcher, err = changestream.NewMongoWatcher(model_mongo.AuthDB, "auth", api.ChangeFeedCollections, &config.ChangeStream, logger)
+		if err != nil {
+			logger.Warn("failed to create change stream watcher, continuing without it", "error", err)
+		} else {
+			changeFeed := api.NewChangeFeed(rbacAPI, logger)
+			changeFeed.SetOutbox(events)
+			changeFeed.SetTenantCache(tenantAPI.TenantCache())
+			changeWatcher.Subscribe(changeFeed.InvalidatePermissions)
+			changeWatcher.Subscribe(changeFeed.InvalidateTenants)
+			changeWatcher.Subscribe(changeFeed.Publish)
+		}
+	}
+	configClient, err := clients.ConfigClient(context.Background(), config.ConfigServiceAddress)
+	if err != nil {
+		logger.Warn("config service client unavailable, saml login is disabled", "error", err)
+	} else {
+		coordinator.OnClose("config_client", func(context.Context) error { return configClient.Close() })
+		authAPI.SetConfigClient(configClient)
+	}
+
+	// Queues, jobs and modules of this binary reported by GetSystemStatus
+	usageTracker := tenantAPI.UsageTracker()
+	statusAggregator := systemAPI.Aggregator()
+	statusAggregator.AddModule(model_shared.ModuleAuth)
+	statusAggregator.AddQueue("usage_rollups", func() (int64, int64) {
+		return int64(usageTracker.Pending()), 0
+	})
+	verifyPool := hash.DefaultVerifyPool()
+	statusAggregator.AddQueue("hash_verify", func() (int64, int64) {
+		_, waiting, _ := verifyPool.Stats()
+		return waiting, 0
+	})
+	statusAggregator.AddJob("usage_flush", func() status.JobState {
+		running, lastRun, lastErr := usageTracker.Status()
+		return status.JobState{Running: running, LastRun: lastRun, LastError: lastErr}
+	})
+	statusAggregator.AddJob("outbox_publish", func() status.JobState {
+		running, lastRun, lastErr := publisher.Status()
+		return status.JobState{Running: running, LastRun: lastRun, LastError: lastErr}
+	})
+	statusAggregator.AddJob("webhook_delivery", func() status.JobState {
+		running, lastRun, lastErr := webhookAPI.DeliveryStatus()
+		return status.JobState{Running: running, LastRun: lastRun, LastError: lastErr}
+	})
+
+	// Create server
+	logger.Info("Creating gRPC server...")
+	srv, err := grpc_server.NewGRPCServer(&server.Config{
+		Port:                config.Server.Port,
+		Module:              model_shared.ModuleAuth,
+		Insecure:            config.TLS.Insecure,
+		Certs:               certs,
+		AllowedClients:      config.TLS.AllowedClients,
+		CertReloadInterval:  config.TLS.ReloadInterval,
+		Environment:         config.Server.Environment,
+		EnableReflection:    config.Server.EnableReflection,
+		EnableDebugServices: config.Server.EnableDebugServices,
+		VerboseErrors:       config.Server.VerboseErrors,
+		KeepAliveTime:       config.Server.KeepAliveTime,
+		KeepAliveTimeout:    config.Server.KeepAliveTimeout,
+		ShutdownTimeout:     config.Shutdown.Timeout,
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			// API keys are resolved first so usage is recorded for the key tenant
+			interceptor.ServerAPIKeyInterceptor(apiKeyAPI, logger),
+			// Calls of suspended and inactive tenants are refused before their usage is recorded
+			interceptor.ServerTenantStatusInterceptor(tenantAPI, logger),
+			interceptor.ServerUsageInterceptor(tenantAPI.UsageTracker(), logger),
+		},
+	}, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalGRPCError, err).Error())
+		return
+	}
+	// Readiness follows the status probes and the config service
+	statusAggregator.EachProbe(srv.Health().AddDependency)
+	if configClient != nil {
+		srv.Health().AddDependency("config_service", configClient.Probe)
+	}
+
+	// Prometheus metrics of the process, see internal/infra/metrics
+	metricsServer := metrics.NewServer(config.Metrics.Port, logger)
+	metricsServer.Start()
+	coordinator.OnClose("metrics_server", metricsServer.Shutdown)
 
 	/* Register services */
 	logger.Info("Registering gRPC services...")
@@ -99,29 +306,70 @@ func Main() {
 	// Tenant service
 	tenantService := service.NewTenantService(tenantAPI, logger)
 	srv.RegisterService(&authv1.TenantService_ServiceDesc, tenantService)
+	// System service
+	// Deletes the token keys Redis did not expire
+	tokenCleaner, err := token.NewRedisCleaner(redis.LoadRedisConfig(), &config.TokenCleanup, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	systemService := service.NewSystemService(systemAPI, logger)
+	srv.RegisterService(&authv1.SystemService_ServiceDesc, systemService)
+	// API key service
+	apiKeyService := service.NewAPIKeyService(apiKeyAPI, logger)
+	srv.RegisterService(&authv1.APIKeyService_ServiceDesc, apiKeyService)
+	// Webhook service
+	webhookService := service.NewWebhookService(webhookAPI, logger)
+	srv.RegisterService(&authv1.WebhookService_ServiceDesc, webhookService)
+	// Audit service
+	auditService := service.NewAuditService(auditAPI, logger)
+	srv.RegisterService(&authv1.AuditService_ServiceDesc, auditService)
 
-	// WaitGroup to wait for the gRPC server goroutine to finish
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		// Run gRPC Server
-		if err := srv.ListenAndServe(quit); err != nil {
-			logger.Warn("gRPC server stopped", "error", err)
-			return
+	coordinator.Go("usage_flush", func(quit <-chan struct{}) {
+		// Flush API usage rollups until shutdown
+		tenantAPI.UsageTracker().Run(config.UsageFlushInterval, quit)
+	})
+	// Publish the domain events of the outbox until shutdown
+	coordinator.Go("outbox_publish", publisher.Run)
+	// Send the tenant webhook deliveries until shutdown
+	coordinator.Go("webhook_delivery", webhookAPI.RunDeliveries)
+	// Generate cross tenant system reports until shutdown
+	coordinator.Go("system_reports", systemAPI.RunReports)
+	coordinator.Go("role_assignment_sweeps", func(quit <-chan struct{}) {
+		// Remove the expired role assignments until shutdown
+		userAPI.RunRoleAssignmentSweeps(config.RoleAssignments.SweepInterval, quit)
+	})
+	coordinator.Go("account_deletion_sweeps", func(quit <-chan struct{}) {
+		// Delete the accounts at the end of their deletion grace period until shutdown
+		userAPI.RunAccountDeletionSweeps(config.AccountDeletion.SweepInterval, quit)
+	})
+	coordinator.Go("trial_sweeps", func(quit <-chan struct{}) {
+		// Suspend the tenants at the end of their trial until shutdown
+		tenantAPI.RunTrialSweeps(config.TenantLifecycle.TrialSweepInterval, quit)
+	})
+	if changeWatcher != nil {
+		// Handle the changes of the auth collections until shutdown
+		coordinator.Go("change_stream", changeWatcher.Run)
+	}
+	// Delete the expired and revoked tokens left in Redis until shutdown
+	coordinator.Go("token_cleanup", tokenCleaner.Run)
+	if config.SCIM.Enabled {
+		// Provisioning of the users and roles by the identity providers of the tenants, see internal/auth/scim
+		scimServer := &http.Server{
+			Addr:              fmt.Sprintf(":%d", config.SCIM.Port),
+			Handler:           scim.NewHandler(apiKeyAPI, userAPI, rbacAPI.Roles, &config.SCIM, logger),
+			ReadHeaderTimeout: 10 * time.Second,
 		}
-	}()
-
-	// Wait for OS signal
-	<-stopChan
-
-	logger.Warn("gRPC server shutdown...")
-	// Signal the gRPC server to stop
-	close(quit)
-
-	// Wait for the gRPC server goroutine to finish
-	wg.Wait()
-	logger.Warn("gRPC server stopped")
+		coordinator.OnDrain("scim_server", scimServer.Shutdown)
+		coordinator.Go("scim_server", func(<-chan struct{}) {
+			logger.Info("SCIM endpoint listening", "port", config.SCIM.Port, "path", scim.BasePath)
+			if err := scimServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("SCIM endpoint stopped", "error", err)
+				coordinator.Stop()
+			}
+		})
+	}
+	coordinator.ServeGRPC(srv)
 }
 
 func createRoleHandler(logger logger.Logger) *handler.RoleHandler {
@@ -139,6 +387,13 @@ func createPermissionHandler(logger logger.Logger) *handler.PermissionHandler {
 	}
 	return hanlder
 }
+func createPermissionSetHandler(logger logger.Logger) *handler.PermissionSetHandler {
+	hanlder, err := handler.NewPermissionSetHandler(logger)
+	if err != nil {
+		logger.Fatal("failed to init permission set handler", "error", err)
+	}
+	return hanlder
+}
 func createUserManager(logger logger.Logger) *handler.UserHandler {
 	hanlder, err := handler.NewUserHandler(logger)
 	if err != nil {
@@ -153,17 +408,35 @@ func createTenantManager(logger logger.Logger) *handler.TenantHandler {
 	}
 	return hanlder
 }
+func createAPIKeyHandler(logger logger.Logger) *handler.APIKeyHandler {
+	hanlder, err := handler.NewAPIKeyHandler(logger)
+	if err != nil {
+		logger.Fatal("failed to init api key handler", "error", err)
+	}
+	return hanlder
+}
+
+func createOutbox(logger logger.Logger) *outbox.Outbox {
+	outboxCollection, err := collection.NewBaseCollectionHandler[eventv1.OutboxEvent](model_mongo.AuthDB, model_mongo.OutboxCollection, logger)
+	if err != nil {
+		logger.Fatal("failed to init outbox collection", "error", err)
+		return nil
+	}
+	return outbox.NewOutbox(outboxCollection, logger)
+}
 
 func createVerificationManager(logger logger.Logger) *rbac.VerificationManager {
 	uh := createUserManager(logger)
 	rh := createRoleHandler(logger)
 	ph := createPermissionHandler(logger)
+	psh := createPermissionSetHandler(logger)
 	th := createTenantManager(logger)
+	ah := createAPIKeyHandler(logger)
 
-	if rh == nil || ph == nil || uh == nil || th == nil {
+	if rh == nil || ph == nil || psh == nil || uh == nil || th == nil || ah == nil {
 		return nil
 	}
 
-	return rbac.NewVerificationManager(uh, rh, ph, th, logger)
+	return rbac.NewVerificationManager(uh, rh, ph, psh, th, ah, logger)
 
 }
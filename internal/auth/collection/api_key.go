@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type APIKeyCollection struct {
+	*collection.BaseCollectionHandler[authv1.APIKey]
+}
+
+func NewAPIKeyCollection(logger logger.Logger) (*APIKeyCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.APIKey](
+		model_mongo.AuthDB,
+		model_mongo.APIKeysCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &APIKeyCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
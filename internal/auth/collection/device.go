@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type DeviceCollection struct {
+	*collection.BaseCollectionHandler[authv1.Device]
+}
+
+func NewDeviceCollection(logger logger.Logger) (*DeviceCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.Device](
+		model_mongo.AuthDB,
+		model_mongo.DevicesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &DeviceCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
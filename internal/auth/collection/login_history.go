@@ -0,0 +1,112 @@
+package collection
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type LoginHistoryCollection struct {
+	*collection.BaseCollectionHandler[authv1.LoginRecord]
+	history *aggregation.BaseAggregationHandler[loginHistoryPage]
+	logger  logger.Logger
+}
+
+// loginHistoryPage is the single document the history pipeline returns, a page of records and the total match count
+type loginHistoryPage struct {
+	Records []*authv1.LoginRecord `bson:"records"`
+	Total   []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+func NewLoginHistoryCollection(logger logger.Logger) (*LoginHistoryCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.LoginRecord](
+		model_mongo.AuthDB,
+		model_mongo.LoginHistoryCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	history, err := aggregation.NewBaseAggregationHandler[loginHistoryPage](
+		model_mongo.AuthDB,
+		model_mongo.LoginHistoryCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginHistoryCollection{
+		BaseCollectionHandler: collection,
+		history:               history,
+		logger:                logger,
+	}, nil
+}
+
+// History returns the page of the login attempts of a user between from and to, newest first, and the pagination
+// of the results
+func (l *LoginHistoryCollection) History(ctx context.Context, tenantID, userID string, from, to *timestamppb.Timestamp, pagination *infrav1.PaginationRequest) ([]*authv1.LoginRecord, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	pipeline, err := buildLoginHistoryPipeline(tenantID, userID, from, to, page, pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := l.history.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		l.logger.Error("failed to get login history", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+
+	records := []*authv1.LoginRecord{}
+	var total int64
+	if len(results) > 0 {
+		records = results[0].Records
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+	return records, paginationResponse(page, pageSize, total), nil
+}
+
+// buildLoginHistoryPipeline matches the login attempts of a user in [from, to) and returns one page of them, newest
+// first, with the total count
+func buildLoginHistoryPipeline(tenantID, userID string, from, to *timestamppb.Timestamp, page, pageSize int32) ([]bson.M, error) {
+	match := bson.M{"tenant_id": tenantID, "user_id": userID}
+
+	timestamp := bson.M{}
+	if from != nil {
+		timestamp["$gte"] = from.AsTime()
+	}
+	if to != nil {
+		timestamp["$lt"] = to.AsTime()
+	}
+	if from != nil && to != nil && !from.AsTime().Before(to.AsTime()) {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "from", "to")
+	}
+	if len(timestamp) > 0 {
+		match["timestamp"] = timestamp
+	}
+
+	return []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"records": bson.A{
+				bson.M{"$sort": bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}},
+				bson.M{"$skip": int64(page-1) * int64(pageSize)},
+				bson.M{"$limit": pageSize},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+		}},
+	}, nil
+}
@@ -0,0 +1,64 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBuildLoginHistoryPipeline(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		from      *timestamppb.Timestamp
+		to        *timestamppb.Timestamp
+		wantMatch bson.M
+		wantErr   bool
+	}{
+		{
+			name:      "no range",
+			wantMatch: bson.M{"tenant_id": "tenant-1", "user_id": "user-1"},
+		},
+		{
+			name:      "from only",
+			from:      timestamppb.New(from),
+			wantMatch: bson.M{"tenant_id": "tenant-1", "user_id": "user-1", "timestamp": bson.M{"$gte": from}},
+		},
+		{
+			name:      "range",
+			from:      timestamppb.New(from),
+			to:        timestamppb.New(to),
+			wantMatch: bson.M{"tenant_id": "tenant-1", "user_id": "user-1", "timestamp": bson.M{"$gte": from, "$lt": to}},
+		},
+		{
+			name:    "empty range",
+			from:    timestamppb.New(to),
+			to:      timestamppb.New(from),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pipeline, err := buildLoginHistoryPipeline("tenant-1", "user-1", tc.from, tc.to, 2, 25)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, pipeline, 2)
+			assert.Equal(t, tc.wantMatch, pipeline[0]["$match"])
+
+			page := pipeline[1]["$facet"].(bson.M)["records"].(bson.A)
+			assert.Equal(t, bson.M{"$sort": bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}}, page[0])
+			assert.Equal(t, bson.M{"$skip": int64(25)}, page[1])
+			assert.Equal(t, bson.M{"$limit": int32(25)}, page[2])
+		})
+	}
+}
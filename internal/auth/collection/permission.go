@@ -1,7 +1,11 @@
 package collection
 
 import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo"
 	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	model_mongo "erp.localhost/internal/infra/model/db/mongo"
@@ -24,3 +28,48 @@ func NewPermissionCollection(logger logger.Logger) (*PermissionCollection, error
 		BaseCollectionHandler: collection,
 	}, nil
 }
+
+// Permission strings are unique per tenant
+var permissionUniqueness = uniqueness[authv1.Permission]{
+	constraints: []uniqueConstraint[authv1.Permission]{
+		{
+			index: "idx_tenant_permission_string_unique",
+			err:   infra_error.ConflictDuplicatePermission,
+			filter: func(permission *authv1.Permission) map[string]any {
+				return map[string]any{"tenant_id": permission.GetTenantId(), "permission_string": permission.GetPermissionString()}
+			},
+		},
+	},
+	id: (*authv1.Permission).GetId,
+}
+
+func (c *PermissionCollection) Create(ctx context.Context, permission *authv1.Permission) (string, error) {
+	if err := permissionUniqueness.check(ctx, c.BaseCollectionHandler, permission, false); err != nil {
+		return "", err
+	}
+	id, err := c.BaseCollectionHandler.Create(ctx, permission)
+	return id, permissionUniqueness.writeError(err)
+}
+
+// CreateMany creates permissions in one bulk write, see collection.BaseCollectionHandler.CreateMany
+func (c *PermissionCollection) CreateMany(ctx context.Context, permissions []*authv1.Permission, opts mongo.BulkOptions) ([]string, error) {
+	if err := permissionUniqueness.checkMany(ctx, c.BaseCollectionHandler, permissions); err != nil {
+		return nil, err
+	}
+	ids, err := c.BaseCollectionHandler.CreateMany(ctx, permissions, opts)
+	return ids, permissionUniqueness.bulkWriteError(err)
+}
+
+func (c *PermissionCollection) Update(ctx context.Context, filter map[string]any, permission *authv1.Permission) error {
+	if err := permissionUniqueness.check(ctx, c.BaseCollectionHandler, permission, true); err != nil {
+		return err
+	}
+	return permissionUniqueness.writeError(c.BaseCollectionHandler.Update(ctx, filter, permission))
+}
+
+func (c *PermissionCollection) UpdateVersioned(ctx context.Context, filter map[string]any, permission *authv1.Permission, expectedVersion int64) error {
+	if err := permissionUniqueness.check(ctx, c.BaseCollectionHandler, permission, true); err != nil {
+		return err
+	}
+	return permissionUniqueness.writeError(c.BaseCollectionHandler.UpdateVersioned(ctx, filter, permission, expectedVersion))
+}
@@ -0,0 +1,68 @@
+package collection
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type PermissionSetCollection struct {
+	*collection.BaseCollectionHandler[authv1.PermissionSet]
+}
+
+func NewPermissionSetCollection(logger logger.Logger) (*PermissionSetCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.PermissionSet](
+		model_mongo.AuthDB,
+		model_mongo.PermissionSetsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &PermissionSetCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
+
+// Permission set names are unique per tenant
+var permissionSetUniqueness = uniqueness[authv1.PermissionSet]{
+	constraints: []uniqueConstraint[authv1.PermissionSet]{
+		{
+			index: "idx_tenant_name_unique",
+			err:   infra_error.ConflictDuplicatePermissionSet,
+			filter: func(set *authv1.PermissionSet) map[string]any {
+				return map[string]any{"tenant_id": set.GetTenantId(), "name": set.GetName()}
+			},
+		},
+	},
+	id: (*authv1.PermissionSet).GetId,
+}
+
+func (c *PermissionSetCollection) Create(ctx context.Context, set *authv1.PermissionSet) (string, error) {
+	if err := permissionSetUniqueness.check(ctx, c.BaseCollectionHandler, set, false); err != nil {
+		return "", err
+	}
+	id, err := c.BaseCollectionHandler.Create(ctx, set)
+	return id, permissionSetUniqueness.writeError(err)
+}
+
+// CreateMany creates sets in one bulk write, see collection.BaseCollectionHandler.CreateMany
+func (c *PermissionSetCollection) CreateMany(ctx context.Context, sets []*authv1.PermissionSet, opts mongo.BulkOptions) ([]string, error) {
+	if err := permissionSetUniqueness.checkMany(ctx, c.BaseCollectionHandler, sets); err != nil {
+		return nil, err
+	}
+	ids, err := c.BaseCollectionHandler.CreateMany(ctx, sets, opts)
+	return ids, permissionSetUniqueness.bulkWriteError(err)
+}
+
+func (c *PermissionSetCollection) Update(ctx context.Context, filter map[string]any, set *authv1.PermissionSet) error {
+	if err := permissionSetUniqueness.check(ctx, c.BaseCollectionHandler, set, true); err != nil {
+		return err
+	}
+	return permissionSetUniqueness.writeError(c.BaseCollectionHandler.Update(ctx, filter, set))
+}
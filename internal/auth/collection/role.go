@@ -1,7 +1,11 @@
 package collection
 
 import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo"
 	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	model_mongo "erp.localhost/internal/infra/model/db/mongo"
@@ -24,3 +28,41 @@ func NewRoleCollection(logger logger.Logger) (*RoleCollection, error) {
 		BaseCollectionHandler: collection,
 	}, nil
 }
+
+// Role names are unique per tenant
+var roleUniqueness = uniqueness[authv1.Role]{
+	constraints: []uniqueConstraint[authv1.Role]{
+		{
+			index: "idx_tenant_name_unique",
+			err:   infra_error.ConflictDuplicateRole,
+			filter: func(role *authv1.Role) map[string]any {
+				return map[string]any{"tenant_id": role.GetTenantId(), "name": role.GetName()}
+			},
+		},
+	},
+	id: (*authv1.Role).GetId,
+}
+
+func (c *RoleCollection) Create(ctx context.Context, role *authv1.Role) (string, error) {
+	if err := roleUniqueness.check(ctx, c.BaseCollectionHandler, role, false); err != nil {
+		return "", err
+	}
+	id, err := c.BaseCollectionHandler.Create(ctx, role)
+	return id, roleUniqueness.writeError(err)
+}
+
+// CreateMany creates roles in one bulk write, see collection.BaseCollectionHandler.CreateMany
+func (c *RoleCollection) CreateMany(ctx context.Context, roles []*authv1.Role, opts mongo.BulkOptions) ([]string, error) {
+	if err := roleUniqueness.checkMany(ctx, c.BaseCollectionHandler, roles); err != nil {
+		return nil, err
+	}
+	ids, err := c.BaseCollectionHandler.CreateMany(ctx, roles, opts)
+	return ids, roleUniqueness.bulkWriteError(err)
+}
+
+func (c *RoleCollection) Update(ctx context.Context, filter map[string]any, role *authv1.Role) error {
+	if err := roleUniqueness.check(ctx, c.BaseCollectionHandler, role, true); err != nil {
+		return err
+	}
+	return roleUniqueness.writeError(c.BaseCollectionHandler.Update(ctx, filter, role))
+}
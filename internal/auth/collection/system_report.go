@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type SystemReportCollection struct {
+	*collection.BaseCollectionHandler[authv1.SystemReport]
+}
+
+func NewSystemReportCollection(logger logger.Logger) (*SystemReportCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.SystemReport](
+		model_mongo.AuthDB,
+		model_mongo.SystemReportsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &SystemReportCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
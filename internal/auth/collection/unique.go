@@ -0,0 +1,111 @@
+package collection
+
+import (
+	"context"
+	"errors"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// uniqueConstraint is a combination of fields that must be unique within a tenant.
+// It is checked before writes for a clear error and enforced by a unique index against concurrent writes.
+type uniqueConstraint[T any] struct {
+	// index is the unique index backing the constraint, see model_mongo indexes
+	index string
+	err   infra_error.ErrorDef
+	// filter selects the documents item collides with, nil when item doesn't set the fields
+	filter func(item *T) map[string]any
+}
+
+// uniqueness enforces the unique constraints of a collection
+type uniqueness[T any] struct {
+	constraints []uniqueConstraint[T]
+	id          func(item *T) string
+}
+
+// check returns a conflict when another document already holds the unique fields of item.
+// On update item may collide with itself, writes of items without an id are left to the indexes.
+// Creates only check that a colliding document exists, updates read the colliding documents to skip item.
+func (u uniqueness[T]) check(ctx context.Context, handler collection.CollectionHandler[T], item *T, update bool) error {
+	if update && u.id(item) == "" {
+		return nil
+	}
+	for _, constraint := range u.constraints {
+		filter := constraint.filter(item)
+		if filter == nil {
+			continue
+		}
+		if !update {
+			exists, err := handler.Exists(ctx, filter)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return infra_error.Conflict(constraint.err)
+			}
+			continue
+		}
+		existing, err := handler.FindAll(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, document := range existing {
+			if u.id(document) != u.id(item) {
+				return infra_error.Conflict(constraint.err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkMany returns a conflict when a document already holds the unique fields of one of the created items, with
+// one query per constraint. Items colliding with each other are left to the indexes
+func (u uniqueness[T]) checkMany(ctx context.Context, handler collection.CollectionHandler[T], items []*T) error {
+	for _, constraint := range u.constraints {
+		filters := []any{}
+		for _, item := range items {
+			if filter := constraint.filter(item); filter != nil {
+				filters = append(filters, filter)
+			}
+		}
+		if len(filters) == 0 {
+			continue
+		}
+		exists, err := handler.Exists(ctx, map[string]any{"$or": filters})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return infra_error.Conflict(constraint.err)
+		}
+	}
+	return nil
+}
+
+// bulkWriteError converts the operations of a bulk write rejected by the index of a constraint as writeError does
+func (u uniqueness[T]) bulkWriteError(err error) error {
+	var bulkErr *mongo.BulkWriteError
+	if !errors.As(err, &bulkErr) {
+		return u.writeError(err)
+	}
+	for i, failure := range bulkErr.Failures {
+		bulkErr.Failures[i].Err = u.writeError(failure.Err)
+	}
+	return bulkErr
+}
+
+// writeError converts a write rejected by the index of a constraint to the error of the constraint
+func (u uniqueness[T]) writeError(err error) error {
+	index, ok := collection.DuplicateKeyIndex(err)
+	if !ok {
+		return err
+	}
+	for _, constraint := range u.constraints {
+		if constraint.index == index {
+			return infra_error.Conflict(constraint.err).WithError(err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock_collection "erp.localhost/internal/infra/db/mongo/collection/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUniqueness_Check(t *testing.T) {
+	emailFilter := map[string]any{"tenant_id": "tenant-1", "email": "a@example.com"}
+	usernameFilter := map[string]any{"tenant_id": "tenant-1", "username": "alice"}
+
+	testCases := []struct {
+		name      string
+		user      *authv1.User
+		update    bool
+		setupMock func(handler *mock_collection.MockCollectionHandler[authv1.User])
+		wantErr   *infra_error.ErrorDef
+	}{
+		{
+			name: "no conflicts",
+			user: &authv1.User{TenantId: "tenant-1", Email: "a@example.com", Username: "alice"},
+			setupMock: func(handler *mock_collection.MockCollectionHandler[authv1.User]) {
+				handler.EXPECT().Exists(gomock.Any(), emailFilter).Return(false, nil)
+				handler.EXPECT().Exists(gomock.Any(), usernameFilter).Return(false, nil)
+			},
+		},
+		{
+			name: "duplicate email",
+			user: &authv1.User{TenantId: "tenant-1", Email: "a@example.com", Username: "alice"},
+			setupMock: func(handler *mock_collection.MockCollectionHandler[authv1.User]) {
+				handler.EXPECT().Exists(gomock.Any(), emailFilter).Return(true, nil)
+			},
+			wantErr: &infra_error.ConflictDuplicateEmail,
+		},
+		{
+			name: "duplicate username",
+			user: &authv1.User{TenantId: "tenant-1", Email: "a@example.com", Username: "alice"},
+			setupMock: func(handler *mock_collection.MockCollectionHandler[authv1.User]) {
+				handler.EXPECT().Exists(gomock.Any(), emailFilter).Return(false, nil)
+				handler.EXPECT().Exists(gomock.Any(), usernameFilter).Return(true, nil)
+			},
+			wantErr: &infra_error.ConflictDuplicateUsername,
+		},
+		{
+			name: "empty fields are not checked",
+			user: &authv1.User{TenantId: "tenant-1", Email: "a@example.com"},
+			setupMock: func(handler *mock_collection.MockCollectionHandler[authv1.User]) {
+				handler.EXPECT().Exists(gomock.Any(), emailFilter).Return(false, nil)
+			},
+		},
+		{
+			name:   "update keeping its own email",
+			user:   &authv1.User{Id: "user-1", TenantId: "tenant-1", Email: "a@example.com"},
+			update: true,
+			setupMock: func(handler *mock_collection.MockCollectionHandler[authv1.User]) {
+				handler.EXPECT().FindAll(gomock.Any(), emailFilter).Return([]*authv1.User{{Id: "user-1"}}, nil)
+			},
+		},
+		{
+			name:   "update taking another user's email",
+			user:   &authv1.User{Id: "user-1", TenantId: "tenant-1", Email: "a@example.com"},
+			update: true,
+			setupMock: func(handler *mock_collection.MockCollectionHandler[authv1.User]) {
+				handler.EXPECT().FindAll(gomock.Any(), emailFilter).Return([]*authv1.User{{Id: "user-2"}}, nil)
+			},
+			wantErr: &infra_error.ConflictDuplicateEmail,
+		},
+		{
+			name:      "update without id is left to the indexes",
+			user:      &authv1.User{TenantId: "tenant-1", Email: "a@example.com"},
+			update:    true,
+			setupMock: func(handler *mock_collection.MockCollectionHandler[authv1.User]) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			handler := mock_collection.NewMockCollectionHandler[authv1.User](ctrl)
+			tc.setupMock(handler)
+
+			err := userUniqueness.check(context.Background(), handler, tc.user, tc.update)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			var appErr *infra_error.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, infra_error.CategoryConflict, appErr.Category)
+			assert.Equal(t, tc.wantErr.Code, appErr.Code)
+		})
+	}
+}
+
+func TestUniqueness_CheckMany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := mock_collection.NewMockCollectionHandler[authv1.User](ctrl)
+	users := []*authv1.User{
+		{TenantId: "tenant-1", Email: "a@example.com", Username: "alice"},
+		{TenantId: "tenant-1", Email: "b@example.com"},
+	}
+	handler.EXPECT().Exists(gomock.Any(), map[string]any{"$or": []any{
+		map[string]any{"tenant_id": "tenant-1", "email": "a@example.com"},
+		map[string]any{"tenant_id": "tenant-1", "email": "b@example.com"},
+	}}).Return(false, nil)
+	handler.EXPECT().Exists(gomock.Any(), map[string]any{"$or": []any{
+		map[string]any{"tenant_id": "tenant-1", "username": "alice"},
+	}}).Return(true, nil)
+
+	err := userUniqueness.checkMany(context.Background(), handler, users)
+	var appErr *infra_error.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, infra_error.ConflictDuplicateUsername.Code, appErr.Code)
+}
+
+func TestUniqueness_WriteError(t *testing.T) {
+	duplicate := func(index string) error {
+		return driver_mongo.WriteException{WriteErrors: []driver_mongo.WriteError{{
+			Code:    11000,
+			Message: "E11000 duplicate key error collection: auth_db.roles index: " + index + " dup key: { tenant_id: \"tenant-1\", name: \"admin\" }",
+		}}}
+	}
+
+	err := roleUniqueness.writeError(duplicate("idx_tenant_name_unique"))
+	var appErr *infra_error.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, infra_error.ConflictDuplicateRole.Code, appErr.Code)
+
+	// Indexes that back no constraint and other errors pass through
+	err = roleUniqueness.writeError(duplicate("idx_other_unique"))
+	assert.False(t, errors.As(err, &appErr))
+	plain := errors.New("database connection failed")
+	assert.Equal(t, plain, roleUniqueness.writeError(plain))
+	assert.NoError(t, roleUniqueness.writeError(nil))
+}
@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type UsageCollection struct {
+	*collection.BaseCollectionHandler[authv1.UsageRollup]
+}
+
+func NewUsageCollection(logger logger.Logger) (*UsageCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.UsageRollup](
+		model_mongo.AuthDB,
+		model_mongo.APIUsageCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &UsageCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
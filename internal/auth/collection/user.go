@@ -1,14 +1,29 @@
 package collection
 
 import (
+	"context"
+	"expvar"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/aggregation"
 	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
 	model_mongo "erp.localhost/internal/infra/model/db/mongo"
 )
 
+var (
+	// Per field counters of user writes that were near or over the embedded array limits
+	userArraysNearLimit = expvar.NewMap("auth_user_arrays_near_limit")
+	userArraysTrimmed   = expvar.NewMap("auth_user_arrays_trimmed")
+)
+
 type UserCollection struct {
 	*collection.BaseCollectionHandler[authv1.User]
+	search *aggregation.BaseAggregationHandler[userSearchPage]
+	logger logger.Logger
 }
 
 func NewUserCollection(logger logger.Logger) (*UserCollection, error) {
@@ -20,7 +35,93 @@ func NewUserCollection(logger logger.Logger) (*UserCollection, error) {
 	if err != nil {
 		return nil, err
 	}
+	search, err := newUserSearchAggregation(logger)
+	if err != nil {
+		return nil, err
+	}
 	return &UserCollection{
 		BaseCollectionHandler: collection,
+		search:                search,
+		logger:                logger,
 	}, nil
 }
+
+// Emails and usernames are unique per tenant
+var userUniqueness = uniqueness[authv1.User]{
+	constraints: []uniqueConstraint[authv1.User]{
+		{
+			index: "idx_tenant_email_unique",
+			err:   infra_error.ConflictDuplicateEmail,
+			filter: func(user *authv1.User) map[string]any {
+				if user.GetEmail() == "" {
+					return nil
+				}
+				return map[string]any{"tenant_id": user.GetTenantId(), "email": user.GetEmail()}
+			},
+		},
+		{
+			index: "idx_tenant_username_unique",
+			err:   infra_error.ConflictDuplicateUsername,
+			filter: func(user *authv1.User) map[string]any {
+				if user.GetUsername() == "" {
+					return nil
+				}
+				return map[string]any{"tenant_id": user.GetTenantId(), "username": user.GetUsername()}
+			},
+		},
+	},
+	id: (*authv1.User).GetId,
+}
+
+func (u *UserCollection) Create(ctx context.Context, user *authv1.User) (string, error) {
+	u.enforceArrayLimits(user)
+	if err := userUniqueness.check(ctx, u.BaseCollectionHandler, user, false); err != nil {
+		u.logger.Warn("user conflicts with an existing user", "tenant_id", user.GetTenantId(), "error", err)
+		return "", err
+	}
+	id, err := u.BaseCollectionHandler.Create(ctx, user)
+	return id, userUniqueness.writeError(err)
+}
+
+// CreateMany creates users in one bulk write, see collection.BaseCollectionHandler.CreateMany
+func (u *UserCollection) CreateMany(ctx context.Context, users []*authv1.User, opts mongo.BulkOptions) ([]string, error) {
+	for _, user := range users {
+		u.enforceArrayLimits(user)
+	}
+	if err := userUniqueness.checkMany(ctx, u.BaseCollectionHandler, users); err != nil {
+		u.logger.Warn("users conflict with an existing user", "error", err)
+		return nil, err
+	}
+	ids, err := u.BaseCollectionHandler.CreateMany(ctx, users, opts)
+	return ids, userUniqueness.bulkWriteError(err)
+}
+
+func (u *UserCollection) Update(ctx context.Context, filter map[string]any, user *authv1.User) error {
+	u.enforceArrayLimits(user)
+	if err := userUniqueness.check(ctx, u.BaseCollectionHandler, user, true); err != nil {
+		u.logger.Warn("user conflicts with an existing user", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+	return userUniqueness.writeError(u.BaseCollectionHandler.Update(ctx, filter, user))
+}
+
+func (u *UserCollection) UpdateVersioned(ctx context.Context, filter map[string]any, user *authv1.User, expectedVersion int64) error {
+	u.enforceArrayLimits(user)
+	if err := userUniqueness.check(ctx, u.BaseCollectionHandler, user, true); err != nil {
+		u.logger.Warn("user conflicts with an existing user", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "error", err)
+		return err
+	}
+	return userUniqueness.writeError(u.BaseCollectionHandler.UpdateVersioned(ctx, filter, user, expectedVersion))
+}
+
+// enforceArrayLimits trims embedded arrays before they are written and records near-limit documents
+func (u *UserCollection) enforceArrayLimits(user *authv1.User) {
+	for _, field := range validator_auth.EnforceUserArrayLimits(user) {
+		userArraysTrimmed.Add(field, 1)
+		u.logger.Debug("trimmed user array to its limit", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "field", field)
+	}
+	for _, field := range validator_auth.NearLimitUserArrays(user) {
+		userArraysNearLimit.Add(field, 1)
+		u.logger.Warn("user array is near its limit", "tenant_id", user.GetTenantId(), "user_id", user.GetId(), "field", field)
+	}
+}
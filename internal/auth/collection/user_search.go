@@ -0,0 +1,176 @@
+package collection
+
+import (
+	"context"
+	"regexp"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// Fields the free text query of a user search is matched against
+var userSearchTextFields = []string{
+	"email",
+	"username",
+	"profile.first_name",
+	"profile.last_name",
+	"profile.display_name",
+	"profile.title",
+	"profile.department",
+}
+
+var userSortFields = map[authv1.UserSortField]string{
+	authv1.UserSortField_USER_SORT_FIELD_UNSPECIFIED: "created_at",
+	authv1.UserSortField_USER_SORT_FIELD_CREATED_AT:  "created_at",
+	authv1.UserSortField_USER_SORT_FIELD_EMAIL:       "email",
+	authv1.UserSortField_USER_SORT_FIELD_USERNAME:    "username",
+	authv1.UserSortField_USER_SORT_FIELD_LAST_NAME:   "profile.last_name",
+	authv1.UserSortField_USER_SORT_FIELD_LAST_LOGIN:  "last_login",
+}
+
+// userSearchPage is the single document the search pipeline returns, a page of users and the total match count
+type userSearchPage struct {
+	Users []*authv1.User `bson:"users"`
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+func newUserSearchAggregation(logger logger.Logger) (*aggregation.BaseAggregationHandler[userSearchPage], error) {
+	return aggregation.NewBaseAggregationHandler[userSearchPage](
+		model_mongo.AuthDB,
+		model_mongo.UsersCollection,
+		logger,
+	)
+}
+
+// Search returns the page of the tenant users matching search and the pagination of the results
+func (u *UserCollection) Search(ctx context.Context, tenantID string, search *authv1.UserSearch, pagination *infrav1.PaginationRequest) ([]*authv1.User, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	pipeline, err := buildUserSearchPipeline(tenantID, search, page, pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := u.search.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		u.logger.Error("failed to search users", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+
+	users := []*authv1.User{}
+	var total int64
+	if len(results) > 0 {
+		users = results[0].Users
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+	return users, paginationResponse(page, pageSize, total), nil
+}
+
+// paginationResponse returns the pagination of page out of total items
+func paginationResponse(page, pageSize int32, total int64) *infrav1.PaginationResponse {
+	totalPages := int32((total + int64(pageSize) - 1) / int64(pageSize))
+	return &infrav1.PaginationResponse{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+// searchPage returns the requested page, from 1, and page size with defaults applied and the size capped
+func searchPage(pagination *infrav1.PaginationRequest) (int32, int32) {
+	page, pageSize := pagination.GetPage(), pagination.GetPageSize()
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+	return page, pageSize
+}
+
+// buildUserSearchPipeline matches the tenant users against search and returns one page of them with the total count
+func buildUserSearchPipeline(tenantID string, search *authv1.UserSearch, page, pageSize int32) ([]bson.M, error) {
+	if search == nil {
+		search = &authv1.UserSearch{}
+	}
+	match := bson.M{"tenant_id": tenantID}
+
+	if statuses := search.GetStatuses(); len(statuses) > 0 {
+		values := make(bson.A, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, int32(status))
+		}
+		match["status"] = bson.M{"$in": values}
+	}
+	if search.RoleId != nil {
+		match["roles.role_id"] = search.GetRoleId()
+	}
+	if search.EmailDomain != nil {
+		match["email"] = bson.M{"$regex": "@" + regexp.QuoteMeta(search.GetEmailDomain()) + "$", "$options": "i"}
+	}
+
+	created := bson.M{}
+	if search.GetCreatedAfter() != nil {
+		created["$gte"] = search.GetCreatedAfter().AsTime()
+	}
+	if search.GetCreatedBefore() != nil {
+		created["$lt"] = search.GetCreatedBefore().AsTime()
+	}
+	if search.GetCreatedAfter() != nil && search.GetCreatedBefore() != nil &&
+		!search.GetCreatedAfter().AsTime().Before(search.GetCreatedBefore().AsTime()) {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "created_after", "created_before")
+	}
+	if len(created) > 0 {
+		match["created_at"] = created
+	}
+
+	if query := search.GetQuery(); query != "" {
+		pattern := regexp.QuoteMeta(query)
+		or := make(bson.A, 0, len(userSearchTextFields))
+		for _, field := range userSearchTextFields {
+			or = append(or, bson.M{field: bson.M{"$regex": pattern, "$options": "i"}})
+		}
+		match["$or"] = or
+	}
+
+	sortField, ok := userSortFields[search.GetSortBy()]
+	if !ok {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "sort_by")
+	}
+	direction := 1
+	if search.GetDescending() {
+		direction = -1
+	}
+
+	return []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"users": bson.A{
+				bson.M{"$sort": bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}}},
+				bson.M{"$skip": int64(page-1) * int64(pageSize)},
+				bson.M{"$limit": pageSize},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+		}},
+	}, nil
+}
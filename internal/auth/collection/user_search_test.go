@@ -0,0 +1,120 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBuildUserSearchPipeline(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		search    *authv1.UserSearch
+		wantMatch bson.M
+		wantSort  bson.D
+		wantErr   bool
+	}{
+		{
+			name:      "no criteria",
+			search:    nil,
+			wantMatch: bson.M{"tenant_id": "tenant-1"},
+			wantSort:  bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name: "all filters",
+			search: &authv1.UserSearch{
+				Statuses:      []authv1.UserStatus{authv1.UserStatus_USER_STATUS_ACTIVE, authv1.UserStatus_USER_STATUS_INVITED},
+				RoleId:        proto.String("role-1"),
+				EmailDomain:   proto.String("example.com"),
+				CreatedAfter:  timestamppb.New(after),
+				CreatedBefore: timestamppb.New(before),
+				SortBy:        authv1.UserSortField_USER_SORT_FIELD_LAST_NAME,
+				Descending:    true,
+			},
+			wantMatch: bson.M{
+				"tenant_id":     "tenant-1",
+				"status":        bson.M{"$in": bson.A{int32(1), int32(4)}},
+				"roles.role_id": "role-1",
+				"email":         bson.M{"$regex": `@example\.com$`, "$options": "i"},
+				"created_at":    bson.M{"$gte": after, "$lt": before},
+			},
+			wantSort: bson.D{{Key: "profile.last_name", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name:   "free text is escaped",
+			search: &authv1.UserSearch{Query: "a.b+"},
+			wantMatch: bson.M{
+				"tenant_id": "tenant-1",
+				"$or": bson.A{
+					bson.M{"email": bson.M{"$regex": `a\.b\+`, "$options": "i"}},
+					bson.M{"username": bson.M{"$regex": `a\.b\+`, "$options": "i"}},
+					bson.M{"profile.first_name": bson.M{"$regex": `a\.b\+`, "$options": "i"}},
+					bson.M{"profile.last_name": bson.M{"$regex": `a\.b\+`, "$options": "i"}},
+					bson.M{"profile.display_name": bson.M{"$regex": `a\.b\+`, "$options": "i"}},
+					bson.M{"profile.title": bson.M{"$regex": `a\.b\+`, "$options": "i"}},
+					bson.M{"profile.department": bson.M{"$regex": `a\.b\+`, "$options": "i"}},
+				},
+			},
+			wantSort: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name:    "empty created range",
+			search:  &authv1.UserSearch{CreatedAfter: timestamppb.New(before), CreatedBefore: timestamppb.New(after)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort field",
+			search:  &authv1.UserSearch{SortBy: authv1.UserSortField(99)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pipeline, err := buildUserSearchPipeline("tenant-1", tc.search, 3, 10)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, pipeline, 2)
+			assert.Equal(t, tc.wantMatch, pipeline[0]["$match"])
+
+			page := pipeline[1]["$facet"].(bson.M)["users"].(bson.A)
+			assert.Equal(t, bson.M{"$sort": tc.wantSort}, page[0])
+			assert.Equal(t, bson.M{"$skip": int64(20)}, page[1])
+			assert.Equal(t, bson.M{"$limit": int32(10)}, page[2])
+		})
+	}
+}
+
+func TestSearchPage(t *testing.T) {
+	testCases := []struct {
+		name         string
+		pagination   *infrav1.PaginationRequest
+		wantPage     int32
+		wantPageSize int32
+	}{
+		{name: "defaults", pagination: nil, wantPage: 1, wantPageSize: defaultSearchPageSize},
+		{name: "requested", pagination: &infrav1.PaginationRequest{Page: 4, PageSize: 50}, wantPage: 4, wantPageSize: 50},
+		{name: "page size capped", pagination: &infrav1.PaginationRequest{Page: 1, PageSize: 1000}, wantPage: 1, wantPageSize: maxSearchPageSize},
+		{name: "negative values", pagination: &infrav1.PaginationRequest{Page: -1, PageSize: -5}, wantPage: 1, wantPageSize: defaultSearchPageSize},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, pageSize := searchPage(tc.pagination)
+			assert.Equal(t, tc.wantPage, page)
+			assert.Equal(t, tc.wantPageSize, pageSize)
+		})
+	}
+}
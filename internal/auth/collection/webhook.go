@@ -0,0 +1,44 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type WebhookCollection struct {
+	*collection.BaseCollectionHandler[authv1.Webhook]
+}
+
+func NewWebhookCollection(logger logger.Logger) (*WebhookCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.Webhook](
+		model_mongo.AuthDB,
+		model_mongo.WebhooksCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
+
+type WebhookDeliveryCollection struct {
+	*collection.BaseCollectionHandler[authv1.WebhookDelivery]
+}
+
+func NewWebhookDeliveryCollection(logger logger.Logger) (*WebhookDeliveryCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[authv1.WebhookDelivery](
+		model_mongo.AuthDB,
+		model_mongo.WebhookDeliveriesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookDeliveryCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
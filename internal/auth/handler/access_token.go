@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"time"
 
 	"erp.localhost/internal/auth/token"
@@ -9,26 +10,27 @@ import (
 	"erp.localhost/internal/infra/logging/logger"
 	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
 	validator_auth_cache "erp.localhost/internal/infra/model/auth/validator/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
 )
 
 //go:generate mockgen -destination=mock/mock_token_handler.go -package=mock erp.localhost/internal/auth/handler TokenHandler
 type TokenHandler[T any] interface {
 	// Store stores a single token for a user (replaces existing if present)
-	Store(tenantID string, userID string, value *T) error
+	Store(ctx context.Context, tenantID string, userID string, value *T) error
 	// GetOne retrieves the single token for a user
-	GetOne(tenantID string, userID string) (*T, error)
+	GetOne(ctx context.Context, tenantID string, userID string) (*T, error)
 	// Validate checks if the token is valid (exists, not revoked, not expired)
-	Validate(tenantID string, userID string) (*T, error)
+	Validate(ctx context.Context, tenantID string, userID string) (*T, error)
 	// Revoke revokes the single token for a user
-	Revoke(tenantID string, userID string, revokedBy string) error
+	Revoke(ctx context.Context, tenantID string, userID string, revokedBy string) error
 	// // RevokeAll revokes all the tokens that are related to a pattern
 	// RevokeAll(pattern string, revokedBy string) error
 	// ScanKeys finds all the keys that are related to a tenant
-	ScanKeys(tenantID string) ([]string, error)
+	ScanKeys(ctx context.Context, tenantID string) ([]string, error)
 	// Delete permanently deletes the single token for a user
-	Delete(tenantID string, userID string) error
+	Delete(ctx context.Context, tenantID string, userID string) error
 	// Delete permanently deletes the tokens that match the pattern
-	DeleteByPattern(tenantID string, pattern string) (int, error)
+	DeleteByPattern(ctx context.Context, tenantID string, pattern string) (int, error)
 }
 
 // AccessTokenHandler handles access token operations in Redis
@@ -39,13 +41,13 @@ type AccessTokenHandler struct {
 	logger  logger.Logger
 }
 
-func NewAccessTokenHandler(logger logger.Logger) (*AccessTokenHandler, error) {
-	handler, err := token.NewAccessTokenKeyHandler(logger)
+func NewAccessTokenHandler(config *redis.RedisConfig, logger logger.Logger) (*AccessTokenHandler, error) {
+	handler, err := token.NewAccessTokenKeyHandler(config, logger)
 	if err != nil {
 		return nil, err
 	}
 	return &AccessTokenHandler{
-		handler: handler,
+		handler: redis.WithFallback[authv1_cache.TokenMetadata](handler, model_redis.RedisKeyToken, config, logger),
 		logger:  logger,
 	}, nil
 }
@@ -53,7 +55,7 @@ func NewAccessTokenHandler(logger logger.Logger) (*AccessTokenHandler, error) {
 // Store stores an access token in Redis (replaces existing token if present)
 // Key: tokens:{tenant_id}:{user_id}
 // Single token per user - automatically replaces any existing token
-func (h *AccessTokenHandler) Store(tenantID string, userID string, metadata *authv1_cache.TokenMetadata) error {
+func (h *AccessTokenHandler) Store(ctx context.Context, tenantID string, userID string, metadata *authv1_cache.TokenMetadata) error {
 	if err := validator_auth_cache.ValidateTokenMetaData(metadata); err != nil {
 		h.logger.Error("Failed to validate token", "error", err)
 		return err
@@ -69,7 +71,7 @@ func (h *AccessTokenHandler) Store(tenantID string, userID string, metadata *aut
 	opts := map[string]any{"ttl": ttl}
 
 	// Store token using userID as key (automatically replaces old token)
-	err := h.handler.Set(tenantID, userID, metadata, opts)
+	err := h.handler.Set(ctx, tenantID, userID, metadata, opts)
 	if err != nil {
 		h.logger.Error("Failed to store access token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
@@ -80,8 +82,8 @@ func (h *AccessTokenHandler) Store(tenantID string, userID string, metadata *aut
 }
 
 // GetOne retrieves the single access token for a user from Redis
-func (h *AccessTokenHandler) GetOne(tenantID string, userID string) (*authv1_cache.TokenMetadata, error) {
-	token, err := h.handler.GetOne(tenantID, userID)
+func (h *AccessTokenHandler) GetOne(ctx context.Context, tenantID string, userID string) (*authv1_cache.TokenMetadata, error) {
+	token, err := h.handler.GetOne(ctx, tenantID, userID)
 	if err != nil {
 		h.logger.Debug("Access token not found", "tenantID", tenantID, "userID", userID)
 		return nil, err
@@ -91,8 +93,8 @@ func (h *AccessTokenHandler) GetOne(tenantID string, userID string) (*authv1_cac
 }
 
 // Validate checks if a token is valid (exists, not revoked, not expired)
-func (h *AccessTokenHandler) Validate(tenantID string, userID string) (*authv1_cache.TokenMetadata, error) {
-	metadata, err := h.handler.GetOne(tenantID, userID)
+func (h *AccessTokenHandler) Validate(ctx context.Context, tenantID string, userID string) (*authv1_cache.TokenMetadata, error) {
+	metadata, err := h.handler.GetOne(ctx, tenantID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -111,8 +113,8 @@ func (h *AccessTokenHandler) Validate(tenantID string, userID string) (*authv1_c
 }
 
 // Revoke revokes the single access token for a user
-func (h *AccessTokenHandler) Revoke(tenantID string, userID string, revokedBy string) error {
-	metadata, err := h.GetOne(tenantID, userID)
+func (h *AccessTokenHandler) Revoke(ctx context.Context, tenantID string, userID string, revokedBy string) error {
+	metadata, err := h.GetOne(ctx, tenantID, userID)
 	if err != nil || metadata == nil {
 		// No token to revoke
 		h.logger.Debug("No access token to revoke", "tenantID", tenantID, "userID", userID)
@@ -124,7 +126,7 @@ func (h *AccessTokenHandler) Revoke(tenantID string, userID string, revokedBy st
 	// metadata.RevokedBy = revokedBy
 
 	// err = h.keyHandler.Update(tenantID, userID, metadata)
-	err = h.Delete(tenantID, userID)
+	err = h.Delete(ctx, tenantID, userID)
 	if err != nil {
 		h.logger.Error("Failed to revoke access token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
@@ -135,8 +137,8 @@ func (h *AccessTokenHandler) Revoke(tenantID string, userID string, revokedBy st
 }
 
 // Delete permanently removes the access token from Redis (hard delete)
-func (h *AccessTokenHandler) Delete(tenantID string, userID string) error {
-	err := h.handler.Delete(tenantID, userID)
+func (h *AccessTokenHandler) Delete(ctx context.Context, tenantID string, userID string) error {
+	err := h.handler.Delete(ctx, tenantID, userID)
 	if err != nil {
 		h.logger.Error("Failed to delete access token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
@@ -148,9 +150,9 @@ func (h *AccessTokenHandler) Delete(tenantID string, userID string) error {
 
 // ScanKeys returns all access token keys for a tenant
 // Used for tenant-level token management (revoke/delete all tokens for a tenant)
-func (h *AccessTokenHandler) ScanKeys(tenantID string) ([]string, error) {
+func (h *AccessTokenHandler) ScanKeys(ctx context.Context, tenantID string) ([]string, error) {
 	// Pattern: all user IDs in this tenant (tenantID:*)
-	keys, err := h.handler.ScanKeys(tenantID, "*")
+	keys, err := h.handler.ScanKeys(ctx, tenantID, "*")
 	if err != nil {
 		h.logger.Error("Failed to scan access token keys", "error", err, "tenantID", tenantID)
 		return nil, err
@@ -162,9 +164,9 @@ func (h *AccessTokenHandler) ScanKeys(tenantID string) ([]string, error) {
 
 // DeleteByPattern deletes all access tokens for a tenant
 // Returns the number of tokens deleted
-func (h *AccessTokenHandler) DeleteByPattern(tenantID string, pattern string) (int, error) {
+func (h *AccessTokenHandler) DeleteByPattern(ctx context.Context, tenantID string, pattern string) (int, error) {
 	// Pattern: all user IDs in this tenant (tenantID:*)
-	count, err := h.handler.DeleteByPattern(tenantID, pattern)
+	count, err := h.handler.DeleteByPattern(ctx, tenantID, pattern)
 	if err != nil {
 		h.logger.Error("Failed to delete access tokens by pattern", "error", err, "tenantID", tenantID)
 		return 0, err
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -126,14 +127,14 @@ func TestAccessTokenKeyHandler_Store(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.TokenMetadata](ctrl)
 			if tc.expectedSetCallTimes > 0 {
 				mockHandler.EXPECT().
-					Set(tc.expectedTenantID, tc.expectedUserID, tc.metadata, gomock.Any()).
+					Set(gomock.Any(), tc.expectedTenantID, tc.expectedUserID, tc.metadata, gomock.Any()).
 					Return(tc.returnSetError).
 					Times(tc.expectedSetCallTimes)
 			}
 
 			handler := createNewAccessTokenHandler(mockHandler)
 
-			err := handler.Store(tc.tenantID, tc.userID, tc.metadata)
+			err := handler.Store(context.Background(), tc.tenantID, tc.userID, tc.metadata)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -210,14 +211,14 @@ func TestAccessTokenKeyHandler_GetOne(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.TokenMetadata](ctrl)
 			if tc.expectedGetOneCallTimes > 0 {
 				mockHandler.EXPECT().
-					GetOne(tc.expectedTenantID, tc.expectedUserID).
+					GetOne(gomock.Any(), tc.expectedTenantID, tc.expectedUserID).
 					Return(tc.returnMetadata, tc.returnError).
 					Times(tc.expectedGetOneCallTimes)
 			}
 
 			handler := createNewAccessTokenHandler(mockHandler)
 
-			result, err := handler.GetOne(tc.tenantID, tc.userID)
+			result, err := handler.GetOne(context.Background(), tc.tenantID, tc.userID)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Nil(t, result)
@@ -318,14 +319,14 @@ func TestAccessTokenKeyHandler_Validate(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.TokenMetadata](ctrl)
 			if tc.expectedGetOneCallTimes > 0 {
 				mockHandler.EXPECT().
-					GetOne(tc.expectedTenantID, tc.expectedUserID).
+					GetOne(gomock.Any(), tc.expectedTenantID, tc.expectedUserID).
 					Return(tc.returnMetadata, tc.returnError).
 					Times(tc.expectedGetOneCallTimes)
 			}
 
 			handler := createNewAccessTokenHandler(mockHandler)
 
-			result, err := handler.Validate(tc.tenantID, tc.userID)
+			result, err := handler.Validate(context.Background(), tc.tenantID, tc.userID)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Nil(t, result)
@@ -421,7 +422,7 @@ func TestAccessTokenKeyHandler_Revoke(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.TokenMetadata](ctrl)
 			if tc.expectedGetOneCallTimes > 0 {
 				mockHandler.EXPECT().
-					GetOne(tc.expectedGetTenantID, tc.expectedGetUserID).
+					GetOne(gomock.Any(), tc.expectedGetTenantID, tc.expectedGetUserID).
 					Return(tc.returnGetMetadata, tc.returnGetError).
 					Times(tc.expectedGetOneCallTimes)
 			}
@@ -431,14 +432,14 @@ func TestAccessTokenKeyHandler_Revoke(t *testing.T) {
 				expectedMetadata.Revoked = true
 				expectedMetadata.RevokedBy = tc.revokedBy
 				mockHandler.EXPECT().
-					Delete(tc.expectedDeleteTenantID, tc.expectedDeleteUserID).
+					Delete(gomock.Any(), tc.expectedDeleteTenantID, tc.expectedDeleteUserID).
 					Return(tc.returnDeleteError).
 					Times(tc.expectedDeleteCallTimes)
 			}
 
 			handler := createNewAccessTokenHandler(mockHandler)
 
-			err := handler.Revoke(tc.tenantID, tc.userID, tc.revokedBy)
+			err := handler.Revoke(context.Background(), tc.tenantID, tc.userID, tc.revokedBy)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -489,14 +490,14 @@ func TestAccessTokenKeyHandler_Delete(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.TokenMetadata](ctrl)
 			if tc.expectedDeleteCallTimes > 0 {
 				mockHandler.EXPECT().
-					Delete(tc.expectedDeleteTenantID, tc.expectedDeleteUserID).
+					Delete(gomock.Any(), tc.expectedDeleteTenantID, tc.expectedDeleteUserID).
 					Return(tc.returnDeleteError).
 					Times(tc.expectedDeleteCallTimes)
 			}
 
 			handler := createNewAccessTokenHandler(mockHandler)
 
-			err := handler.Delete(tc.tenantID, tc.userID)
+			err := handler.Delete(context.Background(), tc.tenantID, tc.userID)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
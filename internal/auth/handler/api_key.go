@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type APIKeyHandler struct {
+	collection collection_mongo.CollectionHandler[authv1.APIKey]
+	logger     logger.Logger
+}
+
+func NewAPIKeyHandler(logger logger.Logger) (*APIKeyHandler, error) {
+	collection, err := collection_auth.NewAPIKeyCollection(logger)
+	if err != nil {
+		logger.Error("failed to create api key collection handler", "error", err)
+		return nil, err
+	}
+	return &APIKeyHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+func (a *APIKeyHandler) CreateAPIKey(ctx context.Context, key *authv1.APIKey) (string, error) {
+	if err := validator_auth.ValidateAPIKey(key, true); err != nil {
+		return "", err
+	}
+	key.CreatedAt = timestamppb.Now()
+	a.logger.Debug("Creating api key", "tenant_id", key.GetTenantId(), "prefix", key.GetPrefix())
+	return a.collection.Create(ctx, key)
+}
+
+func (a *APIKeyHandler) GetAPIKeyByID(ctx context.Context, tenantID, keyID string) (*authv1.APIKey, error) {
+	if tenantID == "" || keyID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "KeyId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       keyID,
+	}
+	a.logger.Debug("Getting api key by id", "filter", filter)
+	return a.collection.FindOne(ctx, filter)
+}
+
+// GetAPIKeyByPrefix looks up a key across tenants, the prefix identifies the key before its tenant is known
+func (a *APIKeyHandler) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*authv1.APIKey, error) {
+	if prefix == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "Prefix")
+	}
+	filter := map[string]any{
+		"prefix": prefix,
+	}
+	a.logger.Debug("Getting api key by prefix", "filter", filter)
+	return a.collection.FindOne(ctx, filter)
+}
+
+func (a *APIKeyHandler) GetAPIKeysByTenantID(ctx context.Context, tenantID string) ([]*authv1.APIKey, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	a.logger.Debug("Getting api keys by tenant id", "filter", filter)
+	return a.collection.FindAll(ctx, filter)
+}
+
+func (a *APIKeyHandler) UpdateAPIKey(ctx context.Context, key *authv1.APIKey) error {
+	if err := validator_auth.ValidateAPIKey(key, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": key.TenantId,
+		"_id":       key.Id,
+	}
+	a.logger.Debug("Updating api key", "filter", filter)
+	return a.collection.Update(ctx, filter, key)
+}
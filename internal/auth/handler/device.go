@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeviceHandler persists the devices users logged in from, keyed by the fingerprint of their user agent
+type DeviceHandler struct {
+	collection *collection_auth.DeviceCollection
+	logger     logger.Logger
+}
+
+func NewDeviceHandler(logger logger.Logger) (*DeviceHandler, error) {
+	collection, err := collection_auth.NewDeviceCollection(logger)
+	if err != nil {
+		logger.Error("failed to create device collection handler", "error", err)
+		return nil, err
+	}
+	return &DeviceHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+// RegisterDevice records a login from device: the device of the same fingerprint is seen again, a new one is
+// inserted with the name and platform of device. A trusted device stays trusted, an untrusted one becomes trusted
+// when device is
+func (d *DeviceHandler) RegisterDevice(ctx context.Context, device *authv1.Device) (*authv1.Device, error) {
+	if device.GetTenantId() == "" || device.GetUserId() == "" || device.GetFingerprint() == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId", "Fingerprint")
+	}
+	now := time.Now().UTC()
+	filter := map[string]any{
+		"tenant_id":   device.GetTenantId(),
+		"user_id":     device.GetUserId(),
+		"fingerprint": device.GetFingerprint(),
+	}
+	set := map[string]any{
+		"last_seen":       now,
+		"last_ip_address": device.GetLastIpAddress(),
+		"user_agent":      device.GetUserAgent(),
+	}
+	insert := map[string]any{
+		"name":       device.GetName(),
+		"platform":   device.GetPlatform(),
+		"first_seen": now,
+	}
+	if device.GetTrusted() {
+		set["trusted"] = true
+	} else {
+		insert["trusted"] = false
+	}
+	update := map[string]any{"$set": set, "$setOnInsert": insert}
+
+	// The first logins from a device may race on the unique fingerprint index, the loser updates the device the
+	// winner inserted
+	registered, err := d.collection.FindOneAndUpdate(ctx, filter, update, true)
+	if infra_error.IsCategory(err, infra_error.CategoryConflict) {
+		registered, err = d.collection.FindOneAndUpdate(ctx, filter, update, true)
+	}
+	if err != nil {
+		d.logger.Error("failed to register device", "tenant_id", device.GetTenantId(), "user_id", device.GetUserId(), "error", err)
+		return nil, err
+	}
+	return registered, nil
+}
+
+// ListDevices returns the devices of a user, most recently seen first
+func (d *DeviceHandler) ListDevices(ctx context.Context, tenantID, userID string) ([]*authv1.Device, error) {
+	if tenantID == "" || userID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId")
+	}
+	devices, err := d.collection.FindAll(ctx, map[string]any{"tenant_id": tenantID, "user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	// The ids break the ties of the logins in the same millisecond, the later registered first
+	slices.SortFunc(devices, func(a, b *authv1.Device) int {
+		if c := b.GetLastSeen().AsTime().Compare(a.GetLastSeen().AsTime()); c != 0 {
+			return c
+		}
+		return strings.Compare(b.GetId(), a.GetId())
+	})
+	return devices, nil
+}
+
+// GetDevice returns the device of a user, a not found error when the user has no such device
+func (d *DeviceHandler) GetDevice(ctx context.Context, tenantID, userID, deviceID string) (*authv1.Device, error) {
+	if tenantID == "" || userID == "" || deviceID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId", "DeviceId")
+	}
+	device, err := d.collection.FindOne(ctx, deviceFilter(tenantID, userID, deviceID))
+	if err != nil {
+		return nil, deviceError(err, deviceID)
+	}
+	return device, nil
+}
+
+// RenameDevice sets the name of the device of a user and returns the renamed device
+func (d *DeviceHandler) RenameDevice(ctx context.Context, tenantID, userID, deviceID, name string) (*authv1.Device, error) {
+	if tenantID == "" || userID == "" || deviceID == "" || name == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId", "DeviceId", "Name")
+	}
+	update := map[string]any{"$set": map[string]any{"name": name}}
+	device, err := d.collection.FindOneAndUpdate(ctx, deviceFilter(tenantID, userID, deviceID), update, false)
+	if err != nil {
+		return nil, deviceError(err, deviceID)
+	}
+	return device, nil
+}
+
+// DeleteDevice forgets the device of a user, it is registered again on the next login from it
+func (d *DeviceHandler) DeleteDevice(ctx context.Context, tenantID, userID, deviceID string) error {
+	if tenantID == "" || userID == "" || deviceID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId", "DeviceId")
+	}
+	return d.collection.Delete(ctx, deviceFilter(tenantID, userID, deviceID))
+}
+
+func deviceFilter(tenantID, userID, deviceID string) map[string]any {
+	return map[string]any{
+		"tenant_id": tenantID,
+		"user_id":   userID,
+		"_id":       deviceID,
+	}
+}
+
+// deviceError returns a not found error for the missing devices, err otherwise
+func deviceError(err error, deviceID string) error {
+	if errors.Is(err, driver_mongo.ErrNoDocuments) {
+		return infra_error.NotFound(infra_error.NotFoundResource, "device", deviceID)
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceHandler_RegisterDevice(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	h, err := NewDeviceHandler(logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+	ctx := context.Background()
+	login := func(fingerprint, ip string, trusted bool) *authv1.Device {
+		device, err := h.RegisterDevice(ctx, &authv1.Device{
+			TenantId:      "tenant-1",
+			UserId:        "user-1",
+			Fingerprint:   fingerprint,
+			Name:          "Windows",
+			Platform:      "Windows",
+			LastIpAddress: ip,
+			Trusted:       trusted,
+		})
+		require.NoError(t, err)
+		return device
+	}
+
+	laptop := login("laptop", "203.0.113.7", false)
+	require.NotEmpty(t, laptop.GetId())
+	assert.False(t, laptop.GetTrusted())
+
+	// Renamed devices keep their name when seen again, and become trusted once a login passes MFA
+	_, err = h.RenameDevice(ctx, "tenant-1", "user-1", laptop.GetId(), "Work laptop")
+	require.NoError(t, err)
+	again := login("laptop", "198.51.100.9", true)
+	assert.Equal(t, laptop.GetId(), again.GetId())
+	assert.Equal(t, "Work laptop", again.GetName())
+	assert.Equal(t, "198.51.100.9", again.GetLastIpAddress())
+	assert.True(t, again.GetTrusted())
+	assert.True(t, login("laptop", "198.51.100.9", false).GetTrusted())
+
+	phone := login("phone", "203.0.113.7", false)
+	devices, err := h.ListDevices(ctx, "tenant-1", "user-1")
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, phone.GetId(), devices[0].GetId())
+
+	require.NoError(t, h.DeleteDevice(ctx, "tenant-1", "user-1", phone.GetId()))
+	_, err = h.GetDevice(ctx, "tenant-1", "user-1", phone.GetId())
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+	_, err = h.RenameDevice(ctx, "tenant-1", "user-2", laptop.GetId(), "Not mine")
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+}
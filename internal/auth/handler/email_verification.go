@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	validator_auth_cache "erp.localhost/internal/infra/model/auth/validator/cache"
+)
+
+// EmailVerificationHandler handles email verification tokens in Redis, stored by the hash of the token
+// Key pattern: email_verify:{tenant_id}:{token_hash}
+type EmailVerificationHandler struct {
+	handler redis.KeyHandler[authv1_cache.EmailVerificationToken]
+	logger  logger.Logger
+}
+
+func NewEmailVerificationHandler(logger logger.Logger) (*EmailVerificationHandler, error) {
+	handler, err := token.NewEmailVerificationKeyHandler(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &EmailVerificationHandler{
+		handler: handler,
+		logger:  logger,
+	}, nil
+}
+
+// Store stores a verification token until it expires
+func (h *EmailVerificationHandler) Store(ctx context.Context, tenantID string, verificationToken *authv1_cache.EmailVerificationToken) error {
+	if err := validator_auth_cache.ValidateEmailVerificationToken(verificationToken); err != nil {
+		h.logger.Error("Failed to validate email verification token", "error", err)
+		return err
+	}
+
+	ttl := time.Until(verificationToken.GetExpiresAt().AsTime())
+	opts := map[string]any{"ttl": ttl}
+	if err := h.handler.Set(ctx, tenantID, verificationToken.GetTokenHash(), verificationToken, opts); err != nil {
+		h.logger.Error("Failed to store email verification token", "error", err, "tenantID", tenantID, "userID", verificationToken.GetUserId())
+		return err
+	}
+
+	h.logger.Debug("Email verification token stored", "tenantID", tenantID, "userID", verificationToken.GetUserId())
+	return nil
+}
+
+// Validate returns the verification token of tokenHash if it exists and has not expired
+func (h *EmailVerificationHandler) Validate(ctx context.Context, tenantID string, tokenHash string) (*authv1_cache.EmailVerificationToken, error) {
+	stored, err := h.handler.GetOne(ctx, tenantID, tokenHash)
+	if err != nil {
+		h.logger.Debug("Email verification token not found", "tenantID", tenantID)
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	if stored.GetVerified() {
+		return nil, infra_error.Auth(infra_error.AuthTokenRevoked)
+	}
+	if time.Now().After(stored.GetExpiresAt().AsTime()) {
+		return nil, infra_error.Auth(infra_error.AuthTokenExpired)
+	}
+	return stored, nil
+}
+
+// Delete removes the verification token of tokenHash, used once it was consumed
+func (h *EmailVerificationHandler) Delete(ctx context.Context, tenantID string, tokenHash string) error {
+	if err := h.handler.Delete(ctx, tenantID, tokenHash); err != nil {
+		h.logger.Error("Failed to delete email verification token", "error", err, "tenantID", tenantID)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+)
+
+// KnownDevicesHandler handles the devices users logged in from in Redis
+// Key pattern: known_devices:{tenant_id}:{user_id}
+type KnownDevicesHandler struct {
+	handler redis.KeyHandler[authv1_cache.KnownDevices]
+	logger  logger.Logger
+}
+
+func NewKnownDevicesHandler(logger logger.Logger) (*KnownDevicesHandler, error) {
+	handler, err := token.NewKnownDevicesKeyHandler(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &KnownDevicesHandler{
+		handler: handler,
+		logger:  logger,
+	}, nil
+}
+
+// Get returns the known devices of a user, nil when the user has none
+func (h *KnownDevicesHandler) Get(ctx context.Context, tenantID, userID string) (*authv1_cache.KnownDevices, error) {
+	devices, err := h.handler.GetOne(ctx, tenantID, userID)
+	if err != nil {
+		if redis.IsNotFound(err) {
+			return nil, nil
+		}
+		h.logger.Error("Failed to get known devices", "error", err, "tenantID", tenantID, "userID", userID)
+		return nil, err
+	}
+	return devices, nil
+}
+
+// Delete forgets the known devices of a user
+func (h *KnownDevicesHandler) Delete(ctx context.Context, tenantID, userID string) error {
+	if err := h.handler.Delete(ctx, tenantID, userID); err != nil {
+		h.logger.Error("Failed to delete known devices", "error", err, "tenantID", tenantID, "userID", userID)
+		return err
+	}
+	return nil
+}
+
+// Forget drops deviceID from the known devices of a user, the remaining devices are forgotten after ttl without login
+func (h *KnownDevicesHandler) Forget(ctx context.Context, tenantID, userID, deviceID string, ttl time.Duration) error {
+	devices, err := h.Get(ctx, tenantID, userID)
+	if err != nil || devices == nil {
+		return err
+	}
+	known := len(devices.GetDevices())
+	remaining := slices.DeleteFunc(devices.GetDevices(), func(d *authv1_cache.KnownDevice) bool { return d.GetDeviceId() == deviceID })
+	if len(remaining) == known {
+		return nil
+	}
+	if len(remaining) == 0 {
+		return h.Delete(ctx, tenantID, userID)
+	}
+	devices.Devices = remaining
+	return h.Store(ctx, tenantID, devices, ttl)
+}
+
+// Store replaces the known devices of a user, they are forgotten after ttl without login
+func (h *KnownDevicesHandler) Store(ctx context.Context, tenantID string, devices *authv1_cache.KnownDevices, ttl time.Duration) error {
+	if tenantID == "" || devices.GetUserId() == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId")
+	}
+	opts := map[string]any{"ttl": ttl}
+	if err := h.handler.Set(ctx, tenantID, devices.GetUserId(), devices, opts); err != nil {
+		h.logger.Error("Failed to store known devices", "error", err, "tenantID", tenantID, "userID", devices.GetUserId())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LoginHistoryHandler persists the login attempts, they expire after model_mongo.LoginHistoryRetention
+type LoginHistoryHandler struct {
+	collection *collection_auth.LoginHistoryCollection
+	logger     logger.Logger
+}
+
+func NewLoginHistoryHandler(logger logger.Logger) (*LoginHistoryHandler, error) {
+	collection, err := collection_auth.NewLoginHistoryCollection(logger)
+	if err != nil {
+		logger.Error("failed to create login history collection handler", "error", err)
+		return nil, err
+	}
+	return &LoginHistoryHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+// RecordLogin stores a login attempt, the timestamp defaults to now
+func (l *LoginHistoryHandler) RecordLogin(ctx context.Context, record *authv1.LoginRecord) error {
+	if record.GetTenantId() == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	if record.GetTimestamp() == nil {
+		record.Timestamp = timestamppb.Now()
+	}
+	_, err := l.collection.Create(ctx, record)
+	return err
+}
+
+// AnonymizeLoginHistory clears the addresses, user agents and accounts of the login attempts of a user, and of the
+// attempts made with one of accounts before the user existed, and returns how many were anonymized
+func (l *LoginHistoryHandler) AnonymizeLoginHistory(ctx context.Context, tenantID, userID string, accounts []string) (int, error) {
+	if tenantID == "" || userID == "" {
+		return 0, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId")
+	}
+	match := []any{map[string]any{"user_id": userID}}
+	for _, account := range accounts {
+		if account != "" {
+			match = append(match, map[string]any{"account": account})
+		}
+	}
+	records, err := l.collection.FindAll(ctx, map[string]any{"tenant_id": tenantID, "$or": match})
+	if err != nil {
+		return 0, err
+	}
+	anonymized := 0
+	for _, record := range records {
+		record.IpAddress = ""
+		record.UserAgent = ""
+		record.Account = ""
+		if err := l.collection.Update(ctx, map[string]any{"tenant_id": tenantID, "_id": record.GetId()}, record); err != nil {
+			l.logger.Error("failed to anonymize login record", "tenant_id", tenantID, "user_id", userID, "record_id", record.GetId(), "error", err)
+			return anonymized, err
+		}
+		anonymized++
+	}
+	return anonymized, nil
+}
+
+// GetLoginHistory returns a page of the login attempts of a user in [from, to), newest first. Nil bounds are open
+func (l *LoginHistoryHandler) GetLoginHistory(ctx context.Context, tenantID, userID string, from, to *timestamppb.Timestamp, pagination *infrav1.PaginationRequest) ([]*authv1.LoginRecord, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId")
+	}
+	l.logger.Debug("Getting login history", "tenant_id", tenantID, "user_id", userID)
+	return l.collection.History(ctx, tenantID, userID, from, to, pagination)
+}
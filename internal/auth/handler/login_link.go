@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	validator_auth_cache "erp.localhost/internal/infra/model/auth/validator/cache"
+)
+
+// LoginLinkHandler handles pending magic link logins in Redis, stored by the hash of the token
+// Key pattern: login_link:{tenant_id}:{token_hash}
+type LoginLinkHandler struct {
+	handler redis.KeyHandler[authv1_cache.LoginLinkToken]
+	logger  logger.Logger
+}
+
+func NewLoginLinkHandler(logger logger.Logger) (*LoginLinkHandler, error) {
+	handler, err := token.NewLoginLinkKeyHandler(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginLinkHandler{
+		handler: handler,
+		logger:  logger,
+	}, nil
+}
+
+// Store stores a login link token until it expires
+func (h *LoginLinkHandler) Store(ctx context.Context, tenantID string, linkToken *authv1_cache.LoginLinkToken) error {
+	if err := validator_auth_cache.ValidateLoginLinkToken(linkToken); err != nil {
+		h.logger.Error("Failed to validate login link token", "error", err)
+		return err
+	}
+
+	ttl := time.Until(linkToken.GetExpiresAt().AsTime())
+	opts := map[string]any{"ttl": ttl}
+	if err := h.handler.Set(ctx, tenantID, linkToken.GetTokenHash(), linkToken, opts); err != nil {
+		h.logger.Error("Failed to store login link token", "error", err, "tenantID", tenantID, "userID", linkToken.GetUserId())
+		return err
+	}
+
+	h.logger.Debug("Login link token stored", "tenantID", tenantID, "userID", linkToken.GetUserId())
+	return nil
+}
+
+// Consume returns the login link token of tokenHash and deletes it, so a link logs in at most once
+func (h *LoginLinkHandler) Consume(ctx context.Context, tenantID, tokenHash string) (*authv1_cache.LoginLinkToken, error) {
+	stored, err := h.handler.GetOne(ctx, tenantID, tokenHash)
+	if err != nil {
+		h.logger.Debug("Login link token not found", "tenantID", tenantID)
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	if err := h.handler.Delete(ctx, tenantID, tokenHash); err != nil {
+		h.logger.Error("Failed to delete login link token", "error", err, "tenantID", tenantID)
+		return nil, err
+	}
+	if time.Now().After(stored.GetExpiresAt().AsTime()) {
+		return nil, infra_error.Auth(infra_error.AuthTokenExpired)
+	}
+	return stored, nil
+}
+
+// Delete removes the login link token of tokenHash, used when its link could not be sent
+func (h *LoginLinkHandler) Delete(ctx context.Context, tenantID, tokenHash string) error {
+	if err := h.handler.Delete(ctx, tenantID, tokenHash); err != nil {
+		h.logger.Error("Failed to delete login link token", "error", err, "tenantID", tenantID)
+		return err
+	}
+	return nil
+}
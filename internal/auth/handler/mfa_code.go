@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+)
+
+// MFACodeHandler handles the one-time codes sent to users in Redis, a user has at most one pending code
+// Key pattern: mfa_code:{tenant_id}:{user_id}
+type MFACodeHandler struct {
+	handler redis.KeyHandler[authv1_cache.MFACode]
+	logger  logger.Logger
+}
+
+func NewMFACodeHandler(logger logger.Logger) (*MFACodeHandler, error) {
+	handler, err := token.NewMFACodeKeyHandler(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &MFACodeHandler{
+		handler: handler,
+		logger:  logger,
+	}, nil
+}
+
+// Store stores a code until it expires, replacing the pending code of the user
+func (h *MFACodeHandler) Store(ctx context.Context, tenantID string, code *authv1_cache.MFACode) error {
+	if tenantID == "" || code.GetUserId() == "" || code.GetCodeHash() == "" || code.GetExpiresAt() == nil {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId", "CodeHash", "ExpiresAt")
+	}
+	opts := map[string]any{"ttl": time.Until(code.GetExpiresAt().AsTime())}
+	if err := h.handler.Set(ctx, tenantID, code.GetUserId(), code, opts); err != nil {
+		h.logger.Error("Failed to store mfa code", "error", err, "tenantID", tenantID, "userID", code.GetUserId())
+		return err
+	}
+	return nil
+}
+
+// Get returns the pending code of a user, nil when the user has none
+func (h *MFACodeHandler) Get(ctx context.Context, tenantID, userID string) (*authv1_cache.MFACode, error) {
+	code, err := h.handler.GetOne(ctx, tenantID, userID)
+	if err != nil {
+		if redis.IsNotFound(err) {
+			return nil, nil
+		}
+		h.logger.Error("Failed to get mfa code", "error", err, "tenantID", tenantID, "userID", userID)
+		return nil, err
+	}
+	if time.Now().After(code.GetExpiresAt().AsTime()) {
+		return nil, nil
+	}
+	return code, nil
+}
+
+// Delete removes the pending code of a user
+func (h *MFACodeHandler) Delete(ctx context.Context, tenantID, userID string) error {
+	if err := h.handler.Delete(ctx, tenantID, userID); err != nil {
+		h.logger.Error("Failed to delete mfa code", "error", err, "tenantID", tenantID, "userID", userID)
+		return err
+	}
+	return nil
+}
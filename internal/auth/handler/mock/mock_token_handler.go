@@ -10,6 +10,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,103 +41,103 @@ func (m *MockTokenHandler[T]) EXPECT() *MockTokenHandlerMockRecorder[T] {
 }
 
 // Delete mocks base method.
-func (m *MockTokenHandler[T]) Delete(tenantID, userID string) error {
+func (m *MockTokenHandler[T]) Delete(ctx context.Context, tenantID, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", tenantID, userID)
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTokenHandlerMockRecorder[T]) Delete(tenantID, userID any) *gomock.Call {
+func (mr *MockTokenHandlerMockRecorder[T]) Delete(ctx, tenantID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTokenHandler[T])(nil).Delete), tenantID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTokenHandler[T])(nil).Delete), ctx, tenantID, userID)
 }
 
 // DeleteByPattern mocks base method.
-func (m *MockTokenHandler[T]) DeleteByPattern(tenantID, pattern string) (int, error) {
+func (m *MockTokenHandler[T]) DeleteByPattern(ctx context.Context, tenantID, pattern string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByPattern", tenantID, pattern)
+	ret := m.ctrl.Call(m, "DeleteByPattern", ctx, tenantID, pattern)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteByPattern indicates an expected call of DeleteByPattern.
-func (mr *MockTokenHandlerMockRecorder[T]) DeleteByPattern(tenantID, pattern any) *gomock.Call {
+func (mr *MockTokenHandlerMockRecorder[T]) DeleteByPattern(ctx, tenantID, pattern any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPattern", reflect.TypeOf((*MockTokenHandler[T])(nil).DeleteByPattern), tenantID, pattern)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPattern", reflect.TypeOf((*MockTokenHandler[T])(nil).DeleteByPattern), ctx, tenantID, pattern)
 }
 
 // GetOne mocks base method.
-func (m *MockTokenHandler[T]) GetOne(tenantID, userID string) (*T, error) {
+func (m *MockTokenHandler[T]) GetOne(ctx context.Context, tenantID, userID string) (*T, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOne", tenantID, userID)
+	ret := m.ctrl.Call(m, "GetOne", ctx, tenantID, userID)
 	ret0, _ := ret[0].(*T)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOne indicates an expected call of GetOne.
-func (mr *MockTokenHandlerMockRecorder[T]) GetOne(tenantID, userID any) *gomock.Call {
+func (mr *MockTokenHandlerMockRecorder[T]) GetOne(ctx, tenantID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockTokenHandler[T])(nil).GetOne), tenantID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockTokenHandler[T])(nil).GetOne), ctx, tenantID, userID)
 }
 
 // Revoke mocks base method.
-func (m *MockTokenHandler[T]) Revoke(tenantID, userID, revokedBy string) error {
+func (m *MockTokenHandler[T]) Revoke(ctx context.Context, tenantID, userID, revokedBy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Revoke", tenantID, userID, revokedBy)
+	ret := m.ctrl.Call(m, "Revoke", ctx, tenantID, userID, revokedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Revoke indicates an expected call of Revoke.
-func (mr *MockTokenHandlerMockRecorder[T]) Revoke(tenantID, userID, revokedBy any) *gomock.Call {
+func (mr *MockTokenHandlerMockRecorder[T]) Revoke(ctx, tenantID, userID, revokedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTokenHandler[T])(nil).Revoke), tenantID, userID, revokedBy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTokenHandler[T])(nil).Revoke), ctx, tenantID, userID, revokedBy)
 }
 
 // ScanKeys mocks base method.
-func (m *MockTokenHandler[T]) ScanKeys(tenantID string) ([]string, error) {
+func (m *MockTokenHandler[T]) ScanKeys(ctx context.Context, tenantID string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ScanKeys", tenantID)
+	ret := m.ctrl.Call(m, "ScanKeys", ctx, tenantID)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ScanKeys indicates an expected call of ScanKeys.
-func (mr *MockTokenHandlerMockRecorder[T]) ScanKeys(tenantID any) *gomock.Call {
+func (mr *MockTokenHandlerMockRecorder[T]) ScanKeys(ctx, tenantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanKeys", reflect.TypeOf((*MockTokenHandler[T])(nil).ScanKeys), tenantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanKeys", reflect.TypeOf((*MockTokenHandler[T])(nil).ScanKeys), ctx, tenantID)
 }
 
 // Store mocks base method.
-func (m *MockTokenHandler[T]) Store(tenantID, userID string, value *T) error {
+func (m *MockTokenHandler[T]) Store(ctx context.Context, tenantID, userID string, value *T) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Store", tenantID, userID, value)
+	ret := m.ctrl.Call(m, "Store", ctx, tenantID, userID, value)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Store indicates an expected call of Store.
-func (mr *MockTokenHandlerMockRecorder[T]) Store(tenantID, userID, value any) *gomock.Call {
+func (mr *MockTokenHandlerMockRecorder[T]) Store(ctx, tenantID, userID, value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockTokenHandler[T])(nil).Store), tenantID, userID, value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockTokenHandler[T])(nil).Store), ctx, tenantID, userID, value)
 }
 
 // Validate mocks base method.
-func (m *MockTokenHandler[T]) Validate(tenantID, userID string) (*T, error) {
+func (m *MockTokenHandler[T]) Validate(ctx context.Context, tenantID, userID string) (*T, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Validate", tenantID, userID)
+	ret := m.ctrl.Call(m, "Validate", ctx, tenantID, userID)
 	ret0, _ := ret[0].(*T)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Validate indicates an expected call of Validate.
-func (mr *MockTokenHandlerMockRecorder[T]) Validate(tenantID, userID any) *gomock.Call {
+func (mr *MockTokenHandlerMockRecorder[T]) Validate(ctx, tenantID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTokenHandler[T])(nil).Validate), tenantID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockTokenHandler[T])(nil).Validate), ctx, tenantID, userID)
 }
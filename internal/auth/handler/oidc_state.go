@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	validator_auth_cache "erp.localhost/internal/infra/model/auth/validator/cache"
+)
+
+// OIDCStateHandler handles pending OIDC authorization requests in Redis
+// Key pattern: oidc_state:{tenant_id}:{state}
+type OIDCStateHandler struct {
+	handler redis.KeyHandler[authv1_cache.OIDCState]
+	logger  logger.Logger
+}
+
+func NewOIDCStateHandler(logger logger.Logger) (*OIDCStateHandler, error) {
+	handler, err := token.NewOIDCStateKeyHandler(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCStateHandler{
+		handler: handler,
+		logger:  logger,
+	}, nil
+}
+
+// Store stores an authorization request until it expires
+func (h *OIDCStateHandler) Store(ctx context.Context, state *authv1_cache.OIDCState) error {
+	if err := validator_auth_cache.ValidateOIDCState(state); err != nil {
+		h.logger.Error("Failed to validate oidc state", "error", err)
+		return err
+	}
+
+	ttl := time.Until(state.GetExpiresAt().AsTime())
+	opts := map[string]any{"ttl": ttl}
+	if err := h.handler.Set(ctx, state.GetTenantId(), state.GetState(), state, opts); err != nil {
+		h.logger.Error("Failed to store oidc state", "error", err, "tenantID", state.GetTenantId(), "provider", state.GetProvider())
+		return err
+	}
+
+	h.logger.Debug("OIDC state stored", "tenantID", state.GetTenantId(), "provider", state.GetProvider())
+	return nil
+}
+
+// Consume returns the authorization request and deletes it, so a provider response can only be redeemed once
+func (h *OIDCStateHandler) Consume(ctx context.Context, tenantID, state string) (*authv1_cache.OIDCState, error) {
+	stored, err := h.handler.GetOne(ctx, tenantID, state)
+	if err != nil {
+		h.logger.Debug("OIDC state not found", "tenantID", tenantID)
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	if err := h.handler.Delete(ctx, tenantID, state); err != nil {
+		h.logger.Error("Failed to delete oidc state", "error", err, "tenantID", tenantID)
+		return nil, err
+	}
+	if time.Now().After(stored.GetExpiresAt().AsTime()) {
+		return nil, infra_error.Auth(infra_error.AuthTokenExpired)
+	}
+	return stored, nil
+}
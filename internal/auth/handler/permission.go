@@ -7,6 +7,7 @@ import (
 
 	aggregation_auth "erp.localhost/internal/auth/aggregation"
 	collection_auth "erp.localhost/internal/auth/collection"
+	mongo "erp.localhost/internal/infra/db/mongo"
 	aggregation_mongo "erp.localhost/internal/infra/db/mongo/aggregation"
 	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
@@ -40,73 +41,93 @@ func NewPermissionHandler(logger logger.Logger) (*PermissionHandler, error) {
 	}, nil
 }
 
-func (p *PermissionHandler) CreatePermission(permission *authv1.Permission) (string, error) {
-	if err := validator_auth.ValidatePermission(permission, true); err != nil {
+func (p *PermissionHandler) CreatePermission(ctx context.Context, permission *authv1.Permission) (string, error) {
+	if err := preparePermission(permission); err != nil {
 		return "", err
 	}
+	p.logger.Debug("Creating permission", "permission", permission)
+	return p.collection.Create(ctx, permission)
+}
+
+// CreatePermissions creates permissions in one ordered bulk write and returns their ids by index, the permissions
+// after a failed one are not created, see collection.BaseCollectionHandler.CreateMany
+func (p *PermissionHandler) CreatePermissions(ctx context.Context, permissions []*authv1.Permission) ([]string, error) {
+	for _, permission := range permissions {
+		if err := preparePermission(permission); err != nil {
+			return nil, err
+		}
+	}
+	p.logger.Debug("Creating permissions", "count", len(permissions))
+	return p.collection.CreateMany(ctx, permissions, mongo.BulkOptions{})
+}
+
+// preparePermission validates a new permission and sets its timestamps and lower case name and permission string
+func preparePermission(permission *authv1.Permission) error {
+	if err := validator_auth.ValidatePermission(permission, true); err != nil {
+		return err
+	}
 	permission.CreatedAt = timestamppb.Now()
 	permission.UpdatedAt = timestamppb.Now()
-	p.logger.Debug("Creating permission", "permission", permission)
 	permission.DisplayName = strings.ToLower(permission.DisplayName)
 	permission.PermissionString = strings.ToLower(permission.PermissionString)
-	return p.collection.Create(permission)
+	return nil
 }
 
-func (p *PermissionHandler) GetPermissionByID(tenantID, permissionID string) (*authv1.Permission, error) {
+func (p *PermissionHandler) GetPermissionByID(ctx context.Context, tenantID, permissionID string) (*authv1.Permission, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 		"_id":       permissionID,
 	}
 	p.logger.Debug("Getting permission by id", "filter", filter)
-	return p.findPermissionByFilter(filter)
+	return p.findPermissionByFilter(ctx, filter)
 }
 
-func (p *PermissionHandler) GetPermissionByName(tenantID, name string) (*authv1.Permission, error) {
+func (p *PermissionHandler) GetPermissionByName(ctx context.Context, tenantID, name string) (*authv1.Permission, error) {
 	filter := map[string]any{
 		"tenant_id":         tenantID,
 		"permission_string": name,
 	}
 	p.logger.Debug("Getting permission by name", "filter", filter)
-	return p.findPermissionByFilter(filter)
+	return p.findPermissionByFilter(ctx, filter)
 }
 
-func (p *PermissionHandler) GetPermissionsByTenantID(tenantID string) ([]*authv1.Permission, error) {
+func (p *PermissionHandler) GetPermissionsByTenantID(ctx context.Context, tenantID string) ([]*authv1.Permission, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 	}
 	p.logger.Debug("Getting permissions by tenant id", "filter", filter)
-	return p.findPermissionsByFilter(filter)
+	return p.findPermissionsByFilter(ctx, filter)
 }
 
-func (p *PermissionHandler) GetPermissionsByResource(tenantID, resource string) ([]*authv1.Permission, error) {
+func (p *PermissionHandler) GetPermissionsByResource(ctx context.Context, tenantID, resource string) ([]*authv1.Permission, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 		"resource":  resource,
 	}
 	p.logger.Debug("Getting permissions by resource", "filter", filter)
-	return p.findPermissionsByFilter(filter)
+	return p.findPermissionsByFilter(ctx, filter)
 }
 
-func (p *PermissionHandler) GetPermissionsByAction(tenantID, action string) ([]*authv1.Permission, error) {
+func (p *PermissionHandler) GetPermissionsByAction(ctx context.Context, tenantID, action string) ([]*authv1.Permission, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 		"action":    action,
 	}
 	p.logger.Debug("Getting permissions by action", "filter", filter)
-	return p.findPermissionsByFilter(filter)
+	return p.findPermissionsByFilter(ctx, filter)
 }
 
-func (p *PermissionHandler) GetPermissionsByResourceAndAction(tenantID, resource, action string) ([]*authv1.Permission, error) {
+func (p *PermissionHandler) GetPermissionsByResourceAndAction(ctx context.Context, tenantID, resource, action string) ([]*authv1.Permission, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 		"resource":  resource,
 		"action":    action,
 	}
 	p.logger.Debug("Getting permissions by resource and action", "filter", filter)
-	return p.findPermissionsByFilter(filter)
+	return p.findPermissionsByFilter(ctx, filter)
 }
 
-func (p *PermissionHandler) UpdatePermission(permission *authv1.Permission) error {
+func (p *PermissionHandler) UpdatePermission(ctx context.Context, permission *authv1.Permission) error {
 	if err := validator_auth.ValidatePermission(permission, false); err != nil {
 		return err
 	}
@@ -115,7 +136,7 @@ func (p *PermissionHandler) UpdatePermission(permission *authv1.Permission) erro
 		"_id":       permission.Id,
 	}
 	p.logger.Debug("Updating permission", "permission", permission)
-	currentPermission, err := p.GetPermissionByID(permission.TenantId, permission.Id)
+	currentPermission, err := p.GetPermissionByID(ctx, permission.TenantId, permission.Id)
 	if err != nil {
 		return err
 	}
@@ -123,10 +144,14 @@ func (p *PermissionHandler) UpdatePermission(permission *authv1.Permission) erro
 		return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields, "CreatedAt")
 	}
 	permission.UpdatedAt = timestamppb.Now()
-	return p.collection.Update(filter, permission)
+	if err := p.collection.UpdateVersioned(ctx, filter, permission, permission.GetVersion()); err != nil {
+		return err
+	}
+	permission.Version++
+	return nil
 }
 
-func (p *PermissionHandler) DeletePermission(tenantID, permissionID string) error {
+func (p *PermissionHandler) DeletePermission(ctx context.Context, tenantID, permissionID string) error {
 	if tenantID == "" || permissionID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "PermissionID")
 	}
@@ -135,10 +160,10 @@ func (p *PermissionHandler) DeletePermission(tenantID, permissionID string) erro
 		"_id":       permissionID,
 	}
 	p.logger.Debug("Deleting permission", "filter", filter)
-	return p.collection.Delete(filter)
+	return p.collection.Delete(ctx, filter)
 }
 
-func (p *PermissionHandler) DeleteTenantPermissions(tenantID string) error {
+func (p *PermissionHandler) DeleteTenantPermissions(ctx context.Context, tenantID string) error {
 	if tenantID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
 	}
@@ -146,25 +171,25 @@ func (p *PermissionHandler) DeleteTenantPermissions(tenantID string) error {
 		"tenant_id": tenantID,
 	}
 	p.logger.Debug("Deleting permission", "filter", filter)
-	return p.collection.Delete(filter)
+	return p.collection.Delete(ctx, filter)
 }
 
-func (p *PermissionHandler) findPermissionByFilter(filter map[string]any) (*authv1.Permission, error) {
+func (p *PermissionHandler) findPermissionByFilter(ctx context.Context, filter map[string]any) (*authv1.Permission, error) {
 	if tenant_id, ok := filter["tenant_id"]; !ok || tenant_id == nil {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
 	}
-	permission, err := p.collection.FindOne(filter)
+	permission, err := p.collection.FindOne(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 	return permission, nil
 }
 
-func (p *PermissionHandler) findPermissionsByFilter(filter map[string]any) ([]*authv1.Permission, error) {
+func (p *PermissionHandler) findPermissionsByFilter(ctx context.Context, filter map[string]any) ([]*authv1.Permission, error) {
 	if tenant_id, ok := filter["tenant_id"]; !ok || tenant_id == nil {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
 	}
-	permissions, err := p.collection.FindAll(filter)
+	permissions, err := p.collection.FindAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +202,7 @@ func (p *PermissionHandler) findPermissionsByFilter(filter map[string]any) ([]*a
 
 // GetPermissionsByIDsAggregation retrieves multiple permissions by IDs using aggregation
 // This replaces N sequential queries with a single batch query using $in operator
-func (p *PermissionHandler) GetPermissionsByIDsAggregation(
+func (p *PermissionHandler) GetPermissionsByIDsAggregation(ctx context.Context,
 	tenantID string,
 	permissionIDs []string,
 	fields []string,
@@ -187,7 +212,7 @@ func (p *PermissionHandler) GetPermissionsByIDsAggregation(
 		// Fallback to sequential queries if aggregation handler not available
 		permissions := make([]*authv1.Permission, 0, len(permissionIDs))
 		for _, id := range permissionIDs {
-			perm, err := p.GetPermissionByID(tenantID, id)
+			perm, err := p.GetPermissionByID(ctx, tenantID, id)
 			if err != nil {
 				p.logger.Debug("permission not found", "id", id)
 				continue
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	mongo "erp.localhost/internal/infra/db/mongo"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PermissionSetHandler struct {
+	collection collection_mongo.CollectionHandler[authv1.PermissionSet]
+	logger     logger.Logger
+}
+
+func NewPermissionSetHandler(logger logger.Logger) (*PermissionSetHandler, error) {
+	collection, err := collection_auth.NewPermissionSetCollection(logger)
+	if err != nil {
+		logger.Error("failed to create permission set collection handler", "error", err)
+		return nil, err
+	}
+	return &PermissionSetHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+func (p *PermissionSetHandler) CreatePermissionSet(ctx context.Context, set *authv1.PermissionSet) (string, error) {
+	if err := preparePermissionSet(set); err != nil {
+		return "", err
+	}
+	p.logger.Debug("Creating permission set", "tenant_id", set.GetTenantId(), "name", set.GetName())
+	return p.collection.Create(ctx, set)
+}
+
+// CreatePermissionSets creates sets in one ordered bulk write and returns their ids by index, the sets after a
+// failed one are not created, see collection.BaseCollectionHandler.CreateMany
+func (p *PermissionSetHandler) CreatePermissionSets(ctx context.Context, sets []*authv1.PermissionSet) ([]string, error) {
+	for _, set := range sets {
+		if err := preparePermissionSet(set); err != nil {
+			return nil, err
+		}
+	}
+	p.logger.Debug("Creating permission sets", "count", len(sets))
+	return p.collection.CreateMany(ctx, sets, mongo.BulkOptions{})
+}
+
+// preparePermissionSet validates a new permission set and sets its timestamps and lower case name
+func preparePermissionSet(set *authv1.PermissionSet) error {
+	if err := validator_auth.ValidatePermissionSet(set, true); err != nil {
+		return err
+	}
+	set.CreatedAt = timestamppb.Now()
+	set.UpdatedAt = timestamppb.Now()
+	set.Name = strings.ToLower(set.Name)
+	return nil
+}
+
+func (p *PermissionSetHandler) GetPermissionSetByID(ctx context.Context, tenantID, setID string) (*authv1.PermissionSet, error) {
+	if tenantID == "" || setID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "PermissionSetId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       setID,
+	}
+	p.logger.Debug("Getting permission set by id", "filter", filter)
+	return p.collection.FindOne(ctx, filter)
+}
+
+// GetPermissionSetsByIDs returns the permission sets of setIDs, the missing ones are skipped and the other
+// errors returned
+func (p *PermissionSetHandler) GetPermissionSetsByIDs(ctx context.Context, tenantID string, setIDs []string) ([]*authv1.PermissionSet, error) {
+	sets := make([]*authv1.PermissionSet, 0, len(setIDs))
+	for _, setID := range setIDs {
+		set, err := p.GetPermissionSetByID(ctx, tenantID, setID)
+		if errors.Is(err, driver_mongo.ErrNoDocuments) {
+			p.logger.Debug("permission set not found", "tenant_id", tenantID, "id", setID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+func (p *PermissionSetHandler) GetPermissionSetsByTenantID(ctx context.Context, tenantID string) ([]*authv1.PermissionSet, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	p.logger.Debug("Getting permission sets by tenant id", "filter", filter)
+	return p.collection.FindAll(ctx, filter)
+}
+
+func (p *PermissionSetHandler) UpdatePermissionSet(ctx context.Context, set *authv1.PermissionSet) error {
+	if err := validator_auth.ValidatePermissionSet(set, false); err != nil {
+		return err
+	}
+	current, err := p.GetPermissionSetByID(ctx, set.TenantId, set.Id)
+	if err != nil {
+		return err
+	}
+	if set.CreatedBy != current.CreatedBy {
+		return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields, "CreatedBy")
+	}
+	filter := map[string]any{
+		"tenant_id": set.TenantId,
+		"_id":       set.Id,
+	}
+	set.CreatedAt = current.CreatedAt
+	set.UpdatedAt = timestamppb.Now()
+	set.Name = strings.ToLower(set.Name)
+	p.logger.Debug("Updating permission set", "filter", filter)
+	return p.collection.Update(ctx, filter, set)
+}
+
+func (p *PermissionSetHandler) DeletePermissionSet(ctx context.Context, tenantID, setID string) error {
+	if tenantID == "" || setID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "PermissionSetId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       setID,
+	}
+	p.logger.Debug("Deleting permission set", "filter", filter)
+	return p.collection.Delete(ctx, filter)
+}
+
+func (p *PermissionSetHandler) DeleteTenantPermissionSets(ctx context.Context, tenantID string) error {
+	if tenantID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	p.logger.Debug("Deleting permission sets", "filter", filter)
+	return p.collection.Delete(ctx, filter)
+}
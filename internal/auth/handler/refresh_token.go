@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"time"
 
 	"erp.localhost/internal/auth/token"
@@ -9,6 +10,7 @@ import (
 	"erp.localhost/internal/infra/logging/logger"
 	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
 	"erp.localhost/internal/infra/model/auth/validator"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -17,13 +19,13 @@ type RefreshTokenHandler struct {
 	logger  logger.Logger
 }
 
-func NewRefreshTokenHandler(logger logger.Logger) (*RefreshTokenHandler, error) {
-	handler, err := token.NewRefreshTokenKeyHandler(logger)
+func NewRefreshTokenHandler(config *redis.RedisConfig, logger logger.Logger) (*RefreshTokenHandler, error) {
+	handler, err := token.NewRefreshTokenKeyHandler(config, logger)
 	if err != nil {
 		return nil, err
 	}
 	return &RefreshTokenHandler{
-		handler: handler,
+		handler: redis.WithFallback[authv1_cache.RefreshToken](handler, model_redis.RedisKeyRefreshToken, config, logger),
 		logger:  logger,
 	}, nil
 }
@@ -31,7 +33,7 @@ func NewRefreshTokenHandler(logger logger.Logger) (*RefreshTokenHandler, error)
 // Store stores a refresh token in Redis (replaces existing token if present)
 // Key: refresh_tokens:{tenant_id}:{user_id}
 // Single token per user - automatically replaces any existing token
-func (h *RefreshTokenHandler) Store(tenantID string, userID string, refreshToken *authv1_cache.RefreshToken) error {
+func (h *RefreshTokenHandler) Store(ctx context.Context, tenantID string, userID string, refreshToken *authv1_cache.RefreshToken) error {
 	if err := validator.ValidateRefreshToken(refreshToken); err != nil {
 		h.logger.Error("Failed to validate refresh token", "error", err)
 		return err
@@ -47,7 +49,7 @@ func (h *RefreshTokenHandler) Store(tenantID string, userID string, refreshToken
 	opts := map[string]any{"ttl": ttl}
 
 	// Store token using userID as key (automatically replaces old token)
-	err := h.handler.Set(tenantID, userID, refreshToken, opts)
+	err := h.handler.Set(ctx, tenantID, userID, refreshToken, opts)
 	if err != nil {
 		h.logger.Error("Failed to store refresh token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
@@ -58,8 +60,8 @@ func (h *RefreshTokenHandler) Store(tenantID string, userID string, refreshToken
 }
 
 // GetOne retrieves the single refresh token for a user from Redis
-func (h *RefreshTokenHandler) GetOne(tenantID string, userID string) (*authv1_cache.RefreshToken, error) {
-	token, err := h.handler.GetOne(tenantID, userID)
+func (h *RefreshTokenHandler) GetOne(ctx context.Context, tenantID string, userID string) (*authv1_cache.RefreshToken, error) {
+	token, err := h.handler.GetOne(ctx, tenantID, userID)
 	if err != nil {
 		h.logger.Debug("Refresh token not found", "tenantID", tenantID, "userID", userID)
 		return nil, err
@@ -68,8 +70,8 @@ func (h *RefreshTokenHandler) GetOne(tenantID string, userID string) (*authv1_ca
 }
 
 // Validate checks if a refresh token is valid (exists, not revoked, not expired)
-func (h *RefreshTokenHandler) Validate(tenantID string, userID string) (*authv1_cache.RefreshToken, error) {
-	token, err := h.GetOne(tenantID, userID)
+func (h *RefreshTokenHandler) Validate(ctx context.Context, tenantID string, userID string) (*authv1_cache.RefreshToken, error) {
+	token, err := h.GetOne(ctx, tenantID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -88,15 +90,15 @@ func (h *RefreshTokenHandler) Validate(tenantID string, userID string) (*authv1_
 }
 
 // Revoke revokes the single refresh token for a user
-func (h *RefreshTokenHandler) Revoke(tenantID string, userID string, revokedBy string) error {
-	token, err := h.GetOne(tenantID, userID)
+func (h *RefreshTokenHandler) Revoke(ctx context.Context, tenantID string, userID string, revokedBy string) error {
+	token, err := h.GetOne(ctx, tenantID, userID)
 	if err != nil || token == nil {
 		// No token to revoke
 		h.logger.Debug("No refresh token to revoke", "tenantID", tenantID, "userID", userID)
 		return nil
 	}
 
-	err = h.Delete(tenantID, userID)
+	err = h.Delete(ctx, tenantID, userID)
 	if err != nil {
 		h.logger.Error("Failed to revoke refresh token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
@@ -107,15 +109,15 @@ func (h *RefreshTokenHandler) Revoke(tenantID string, userID string, revokedBy s
 }
 
 // UpdateLastUsed updates the LastUsedAt timestamp for a refresh token
-func (h *RefreshTokenHandler) UpdateLastUsed(tenantID string, userID string, tokenString string) error {
-	token, err := h.GetOne(tenantID, userID)
+func (h *RefreshTokenHandler) UpdateLastUsed(ctx context.Context, tenantID string, userID string, tokenString string) error {
+	token, err := h.GetOne(ctx, tenantID, userID)
 	if err != nil {
 		return err
 	}
 
 	token.LastUsedAt = timestamppb.Now()
 
-	err = h.handler.Update(tenantID, userID, token)
+	err = h.handler.Update(ctx, tenantID, userID, token)
 	if err != nil {
 		h.logger.Error("Failed to update refresh token last used", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
@@ -125,8 +127,8 @@ func (h *RefreshTokenHandler) UpdateLastUsed(tenantID string, userID string, tok
 }
 
 // Delete permanently removes the refresh token from Redis (hard delete)
-func (h *RefreshTokenHandler) Delete(tenantID string, userID string) error {
-	err := h.handler.Delete(tenantID, userID)
+func (h *RefreshTokenHandler) Delete(ctx context.Context, tenantID string, userID string) error {
+	err := h.handler.Delete(ctx, tenantID, userID)
 	if err != nil {
 		h.logger.Error("Failed to delete refresh token", "error", err, "tenantID", tenantID, "userID", userID)
 		return err
@@ -138,9 +140,9 @@ func (h *RefreshTokenHandler) Delete(tenantID string, userID string) error {
 
 // ScanKeys returns all refresh token keys for a tenant
 // Used for tenant-level token management (revoke/delete all tokens for a tenant)
-func (h *RefreshTokenHandler) ScanKeys(tenantID string) ([]string, error) {
+func (h *RefreshTokenHandler) ScanKeys(ctx context.Context, tenantID string) ([]string, error) {
 	// Pattern: all user IDs in this tenant (tenantID:*)
-	keys, err := h.handler.ScanKeys(tenantID, "*")
+	keys, err := h.handler.ScanKeys(ctx, tenantID, "*")
 	if err != nil {
 		h.logger.Error("Failed to scan refresh token keys", "error", err, "tenantID", tenantID)
 		return nil, err
@@ -152,9 +154,9 @@ func (h *RefreshTokenHandler) ScanKeys(tenantID string) ([]string, error) {
 
 // DeleteByPattern deletes all refresh tokens for a tenant
 // Returns the number of tokens deleted
-func (h *RefreshTokenHandler) DeleteByPattern(tenantID, pattern string) (int, error) {
+func (h *RefreshTokenHandler) DeleteByPattern(ctx context.Context, tenantID, pattern string) (int, error) {
 	// Pattern: all user IDs in this tenant (tenantID:*)
-	count, err := h.handler.DeleteByPattern(tenantID, pattern)
+	count, err := h.handler.DeleteByPattern(ctx, tenantID, pattern)
 	if err != nil {
 		h.logger.Error("Failed to delete refresh tokens by pattern", "error", err, "tenantID", tenantID)
 		return 0, err
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/model/auth/validator"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// RefreshTokenFamilyHandler stores the rotation history of refresh tokens issued from the same login
+// Key pattern: refresh_token_family:{tenant_id}:{family_id}
+type RefreshTokenFamilyHandler struct {
+	handler redis.KeyHandler[authv1_cache.RefreshTokenFamily]
+	logger  logger.Logger
+}
+
+func NewRefreshTokenFamilyHandler(config *redis.RedisConfig, logger logger.Logger) (*RefreshTokenFamilyHandler, error) {
+	handler, err := token.NewRefreshTokenFamilyKeyHandler(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &RefreshTokenFamilyHandler{
+		handler: redis.WithFallback[authv1_cache.RefreshTokenFamily](handler, model_redis.RedisKeyRefreshTokenFamily, config, logger),
+		logger:  logger,
+	}, nil
+}
+
+// Store stores the family until its newest token expires, replacing the previous state
+func (h *RefreshTokenFamilyHandler) Store(ctx context.Context, family *authv1_cache.RefreshTokenFamily) error {
+	if err := validator.ValidateRefreshTokenFamily(family); err != nil {
+		h.logger.Error("Failed to validate refresh token family", "error", err)
+		return err
+	}
+
+	ttl := time.Until(family.GetExpiresAt().AsTime())
+	if ttl <= 0 {
+		return infra_error.Auth(infra_error.AuthRefreshTokenExpired)
+	}
+	opts := map[string]any{"ttl": ttl}
+	if err := h.handler.Set(ctx, family.GetTenantId(), family.GetFamilyId(), family, opts); err != nil {
+		h.logger.Error("Failed to store refresh token family", "error", err, "tenantID", family.GetTenantId(), "familyID", family.GetFamilyId())
+		return err
+	}
+
+	h.logger.Debug("Refresh token family stored", "tenantID", family.GetTenantId(), "familyID", family.GetFamilyId())
+	return nil
+}
+
+// GetOne retrieves a refresh token family from Redis
+func (h *RefreshTokenFamilyHandler) GetOne(ctx context.Context, tenantID string, familyID string) (*authv1_cache.RefreshTokenFamily, error) {
+	family, err := h.handler.GetOne(ctx, tenantID, familyID)
+	if err != nil {
+		h.logger.Debug("Refresh token family not found", "tenantID", tenantID, "familyID", familyID)
+		return nil, err
+	}
+	return family, nil
+}
+
+// Delete removes a refresh token family from Redis
+func (h *RefreshTokenFamilyHandler) Delete(ctx context.Context, tenantID string, familyID string) error {
+	if err := h.handler.Delete(ctx, tenantID, familyID); err != nil {
+		h.logger.Error("Failed to delete refresh token family", "error", err, "tenantID", tenantID, "familyID", familyID)
+		return err
+	}
+
+	h.logger.Debug("Refresh token family deleted", "tenantID", tenantID, "familyID", familyID)
+	return nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -120,14 +121,14 @@ func TestRefreshTokenKeyHandler_Store(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.RefreshToken](ctrl)
 			if tc.expectedSetCallTimes > 0 {
 				mockHandler.EXPECT().
-					Set(tc.tenantID, tc.userID, tc.refreshToken, gomock.Any()).
+					Set(gomock.Any(), tc.tenantID, tc.userID, tc.refreshToken, gomock.Any()).
 					Return(tc.returnSetError).
 					Times(tc.expectedSetCallTimes)
 			}
 
 			handler := createNewRefreshTokenHandler(mockHandler)
 
-			err := handler.Store(tc.tenantID, tc.userID, tc.refreshToken)
+			err := handler.Store(context.Background(), tc.tenantID, tc.userID, tc.refreshToken)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -197,14 +198,14 @@ func TestRefreshTokenKeyHandler_GetOne(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.RefreshToken](ctrl)
 			if tc.expectedGetOneCallTimes > 0 {
 				mockHandler.EXPECT().
-					GetOne(tc.tenantID, tc.userID).
+					GetOne(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.returnToken, tc.returnError).
 					Times(tc.expectedGetOneCallTimes)
 			}
 
 			handler := createNewRefreshTokenHandler(mockHandler)
 
-			result, err := handler.GetOne(tc.tenantID, tc.userID)
+			result, err := handler.GetOne(context.Background(), tc.tenantID, tc.userID)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Nil(t, result)
@@ -299,14 +300,14 @@ func TestRefreshTokenKeyHandler_Validate(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.RefreshToken](ctrl)
 			if tc.expectedGetOneCallTimes > 0 {
 				mockHandler.EXPECT().
-					GetOne(tc.tenantID, tc.userID).
+					GetOne(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.returnToken, tc.returnError).
 					Times(tc.expectedGetOneCallTimes)
 			}
 
 			handler := createNewRefreshTokenHandler(mockHandler)
 
-			result, err := handler.Validate(tc.tenantID, tc.userID)
+			result, err := handler.Validate(context.Background(), tc.tenantID, tc.userID)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Nil(t, result)
@@ -382,7 +383,7 @@ func TestRefreshTokenKeyHandler_Revoke(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.RefreshToken](ctrl)
 			if tc.expectedGetOneCallTimes > 0 {
 				mockHandler.EXPECT().
-					GetOne(tc.tenantID, tc.userID).
+					GetOne(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.returnGetToken, tc.returnGetError).
 					Times(tc.expectedGetOneCallTimes)
 			}
@@ -391,14 +392,14 @@ func TestRefreshTokenKeyHandler_Revoke(t *testing.T) {
 				expectedToken := tc.returnGetToken
 				expectedToken.Revoked = true
 				mockHandler.EXPECT().
-					Delete(tc.tenantID, tc.userID).
+					Delete(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.returnDeleteError).
 					Times(tc.expectedDeleteCallTimes)
 			}
 
 			handler := createNewRefreshTokenHandler(mockHandler)
 
-			err := handler.Revoke(tc.tenantID, tc.userID, "system")
+			err := handler.Revoke(context.Background(), tc.tenantID, tc.userID, "system")
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -443,14 +444,14 @@ func TestRefreshTokenKeyHandler_Delete(t *testing.T) {
 			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.RefreshToken](ctrl)
 			if tc.expectedDeleteCallTimes > 0 {
 				mockHandler.EXPECT().
-					Delete(tc.tenantID, tc.userID).
+					Delete(gomock.Any(), tc.tenantID, tc.userID).
 					Return(tc.returnDeleteError).
 					Times(tc.expectedDeleteCallTimes)
 			}
 
 			handler := createNewRefreshTokenHandler(mockHandler)
 
-			err := handler.Delete(tc.tenantID, tc.userID)
+			err := handler.Delete(context.Background(), tc.tenantID, tc.userID)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -7,6 +7,7 @@ import (
 
 	aggregation_auth "erp.localhost/internal/auth/aggregation"
 	collection_auth "erp.localhost/internal/auth/collection"
+	mongo "erp.localhost/internal/infra/db/mongo"
 	aggregation_mongo "erp.localhost/internal/infra/db/mongo/aggregation"
 	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
@@ -40,44 +41,64 @@ func NewRoleHandler(logger logger.Logger) (*RoleHandler, error) {
 	}, nil
 }
 
-func (r *RoleHandler) CreateRole(role *authv1.Role) (string, error) {
-	if err := validator_auth.ValidateRole(role, true); err != nil {
+func (r *RoleHandler) CreateRole(ctx context.Context, role *authv1.Role) (string, error) {
+	if err := prepareRole(role); err != nil {
 		return "", err
 	}
+	r.logger.Debug("Creating role", "role", role)
+	return r.collection.Create(ctx, role)
+}
+
+// CreateRoles creates roles in one ordered bulk write and returns their ids by index, the roles after a failed one
+// are not created, see collection.BaseCollectionHandler.CreateMany
+func (r *RoleHandler) CreateRoles(ctx context.Context, roles []*authv1.Role) ([]string, error) {
+	for _, role := range roles {
+		if err := prepareRole(role); err != nil {
+			return nil, err
+		}
+	}
+	r.logger.Debug("Creating roles", "count", len(roles))
+	return r.collection.CreateMany(ctx, roles, mongo.BulkOptions{})
+}
+
+// prepareRole validates a new role and sets its timestamps and lower case name
+func prepareRole(role *authv1.Role) error {
+	if err := validator_auth.ValidateRole(role, true); err != nil {
+		return err
+	}
 	role.CreatedAt = timestamppb.Now()
 	role.UpdatedAt = timestamppb.Now()
-	r.logger.Debug("Creating role", "role", role)
 	role.Name = strings.ToLower(role.Name)
-	return r.collection.Create(role)
+	return nil
 }
 
-func (r *RoleHandler) GetRoleByID(tenantID, roleID string) (*authv1.Role, error) {
+func (r *RoleHandler) GetRoleByID(ctx context.Context, tenantID, roleID string) (*authv1.Role, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 		"_id":       roleID,
 	}
 	r.logger.Debug("Getting role by id", "filter", filter)
-	return r.findRoleByFilter(filter)
+	return r.findRoleByFilter(ctx, filter)
 }
 
-func (r *RoleHandler) GetRoleByName(tenantID, name string) (*authv1.Role, error) {
+func (r *RoleHandler) GetRoleByName(ctx context.Context, tenantID, name string) (*authv1.Role, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 		"name":      name,
 	}
 	r.logger.Debug("Getting role by name", "filter", filter)
-	return r.findRoleByFilter(filter)
+	return r.findRoleByFilter(ctx, filter)
 }
 
-func (r *RoleHandler) GetRolesByTenantID(tenantID string) ([]*authv1.Role, error) {
+func (r *RoleHandler) GetRolesByTenantID(ctx context.Context, tenantID string) ([]*authv1.Role, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 	}
 	r.logger.Debug("Getting roles by tenant id", "filter", filter)
-	return r.findRolesByFilter(filter)
+	return r.findRolesByFilter(ctx, filter)
 }
 
-func (r *RoleHandler) GetRolesByPermissionsIDs(tenantID string, permissionsIDs []string) ([]*authv1.Role, error) {
+func (r *RoleHandler) GetRolesByPermissionsIDs(ctx context.Context, tenantID string, permissionsIDs []string) ([]*authv1.Role, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 		"permissions": map[string]any{
@@ -85,10 +106,39 @@ func (r *RoleHandler) GetRolesByPermissionsIDs(tenantID string, permissionsIDs [
 		},
 	}
 	r.logger.Debug("Getting roles by permissions ids", "filter", filter)
-	return r.findRolesByFilter(filter)
+	return r.findRolesByFilter(ctx, filter)
+}
+
+// GetRolesByPermissionSetID returns the roles the permission set is attached to
+func (r *RoleHandler) GetRolesByPermissionSetID(ctx context.Context, tenantID, setID string) ([]*authv1.Role, error) {
+	filter := map[string]any{
+		"tenant_id":       tenantID,
+		"permission_sets": setID,
+	}
+	r.logger.Debug("Getting roles by permission set id", "filter", filter)
+	return r.findRolesByFilter(ctx, filter)
+}
+
+// DetachPermissionSet pulls the permission set from the roles of roleIDs in one ordered bulk write, the roles
+// before a failed write are detached
+func (r *RoleHandler) DetachPermissionSet(ctx context.Context, tenantID, setID string, roleIDs []string) error {
+	if tenantID == "" || setID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "PermissionSetId")
+	}
+	ops := make([]mongo.BulkOperation, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		filter := map[string]any{
+			"tenant_id": tenantID,
+			"_id":       roleID,
+		}
+		ops = append(ops, mongo.UpdateOperation(filter, map[string]any{"$pull": map[string]any{"permission_sets": setID}}, false))
+	}
+	r.logger.Debug("Detaching permission set", "tenant_id", tenantID, "permission_set_id", setID, "roles", len(roleIDs))
+	_, err := r.collection.BulkWrite(ctx, ops, mongo.BulkOptions{})
+	return err
 }
 
-func (r *RoleHandler) UpdateRole(role *authv1.Role) error {
+func (r *RoleHandler) UpdateRole(ctx context.Context, role *authv1.Role) error {
 	if err := validator_auth.ValidateRole(role, false); err != nil {
 		return err
 	}
@@ -97,7 +147,7 @@ func (r *RoleHandler) UpdateRole(role *authv1.Role) error {
 		"_id":       role.Id,
 	}
 	r.logger.Debug("Updating role", "role", role)
-	currentRole, err := r.GetRoleByID(role.TenantId, role.Id)
+	currentRole, err := r.GetRoleByID(ctx, role.TenantId, role.Id)
 	if err != nil {
 		return err
 	}
@@ -105,10 +155,10 @@ func (r *RoleHandler) UpdateRole(role *authv1.Role) error {
 		return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields, "CreatedAt")
 	}
 	role.UpdatedAt = timestamppb.Now()
-	return r.collection.Update(filter, role)
+	return r.collection.Update(ctx, filter, role)
 }
 
-func (r *RoleHandler) DeleteRole(tenantID, roleID string) error {
+func (r *RoleHandler) DeleteRole(ctx context.Context, tenantID, roleID string) error {
 	if tenantID == "" || roleID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "RoleId")
 	}
@@ -117,10 +167,10 @@ func (r *RoleHandler) DeleteRole(tenantID, roleID string) error {
 		"_id":       roleID,
 	}
 	r.logger.Debug("Deleting role", "filter", filter)
-	return r.collection.Delete(filter)
+	return r.collection.Delete(ctx, filter)
 }
 
-func (r *RoleHandler) DeleteTenantRoles(tenantID string) error {
+func (r *RoleHandler) DeleteTenantRoles(ctx context.Context, tenantID string) error {
 	if tenantID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "RoleId")
 	}
@@ -128,25 +178,25 @@ func (r *RoleHandler) DeleteTenantRoles(tenantID string) error {
 		"tenant_id": tenantID,
 	}
 	r.logger.Debug("Deleting role", "filter", filter)
-	return r.collection.Delete(filter)
+	return r.collection.Delete(ctx, filter)
 }
 
-func (r *RoleHandler) findRoleByFilter(filter map[string]any) (*authv1.Role, error) {
+func (r *RoleHandler) findRoleByFilter(ctx context.Context, filter map[string]any) (*authv1.Role, error) {
 	if tenant_id, ok := filter["tenant_id"]; !ok || tenant_id == nil {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
 	}
-	role, err := r.collection.FindOne(filter)
+	role, err := r.collection.FindOne(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 	return role, nil
 }
 
-func (r *RoleHandler) findRolesByFilter(filter map[string]any) ([]*authv1.Role, error) {
+func (r *RoleHandler) findRolesByFilter(ctx context.Context, filter map[string]any) ([]*authv1.Role, error) {
 	if tenant_id, ok := filter["tenant_id"]; !ok || tenant_id == nil {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
 	}
-	roles, err := r.collection.FindAll(filter)
+	roles, err := r.collection.FindAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +209,7 @@ func (r *RoleHandler) findRolesByFilter(filter map[string]any) ([]*authv1.Role,
 
 // GetRolesByIDsAggregation retrieves multiple roles by IDs using aggregation
 // This replaces N sequential queries with a single batch query using $in operator
-func (r *RoleHandler) GetRolesByIDsAggregation(
+func (r *RoleHandler) GetRolesByIDsAggregation(ctx context.Context,
 	tenantID string,
 	roleIDs []string,
 	fields []string,
@@ -168,7 +218,7 @@ func (r *RoleHandler) GetRolesByIDsAggregation(
 		r.logger.Warn("aggregation handler not initialized, falling back to sequential queries")
 		roles := make([]*authv1.Role, 0, len(roleIDs))
 		for _, id := range roleIDs {
-			role, err := r.GetRoleByID(tenantID, id)
+			role, err := r.GetRoleByID(ctx, tenantID, id)
 			if err != nil {
 				r.logger.Debug("role not found", "id", id)
 				continue
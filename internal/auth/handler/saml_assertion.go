@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	validator_auth_cache "erp.localhost/internal/infra/model/auth/validator/cache"
+)
+
+// SAMLAssertionHandler remembers consumed SAML assertions in Redis
+// Key pattern: saml_assertion:{tenant_id}:{assertion_id}
+type SAMLAssertionHandler struct {
+	handler redis.KeyHandler[authv1_cache.SAMLAssertion]
+	logger  logger.Logger
+}
+
+func NewSAMLAssertionHandler(logger logger.Logger) (*SAMLAssertionHandler, error) {
+	handler, err := token.NewSAMLAssertionKeyHandler(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &SAMLAssertionHandler{
+		handler: handler,
+		logger:  logger,
+	}, nil
+}
+
+// Consume marks the assertion as used until it expires, an assertion seen before is rejected as a replay
+func (h *SAMLAssertionHandler) Consume(ctx context.Context, assertion *authv1_cache.SAMLAssertion) error {
+	if err := validator_auth_cache.ValidateSAMLAssertion(assertion); err != nil {
+		h.logger.Error("Failed to validate saml assertion", "error", err)
+		return err
+	}
+
+	if _, err := h.handler.GetOne(ctx, assertion.GetTenantId(), assertion.GetAssertionId()); err == nil {
+		h.logger.Warn("SAML assertion replayed", "tenantID", assertion.GetTenantId(), "issuer", assertion.GetIssuer())
+		return infra_error.Auth(infra_error.AuthSSOResponseInvalid).WithError(errors.New("saml assertion was already used"))
+	}
+
+	ttl := time.Until(assertion.GetExpiresAt().AsTime())
+	if ttl <= 0 {
+		return infra_error.Auth(infra_error.AuthSSOResponseInvalid).WithError(errors.New("saml assertion is expired"))
+	}
+	opts := map[string]any{"ttl": ttl}
+	if err := h.handler.Set(ctx, assertion.GetTenantId(), assertion.GetAssertionId(), assertion, opts); err != nil {
+		h.logger.Error("Failed to store saml assertion", "error", err, "tenantID", assertion.GetTenantId())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	aggregation_auth "erp.localhost/internal/auth/aggregation"
+	collection_auth "erp.localhost/internal/auth/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// SystemReportHandler stores generated system reports and computes their cross tenant figures
+type SystemReportHandler struct {
+	collection  collection_mongo.CollectionHandler[authv1.SystemReport]
+	aggregation *aggregation_auth.ReportAggregationHandler
+	logger      logger.Logger
+}
+
+func NewSystemReportHandler(logger logger.Logger) (*SystemReportHandler, error) {
+	collection, err := collection_auth.NewSystemReportCollection(logger)
+	if err != nil {
+		logger.Error("failed to create system report collection handler", "error", err)
+		return nil, err
+	}
+	aggregation, err := aggregation_auth.NewReportAggregationHandler(logger)
+	if err != nil {
+		logger.Error("failed to create report aggregation handler", "error", err)
+		return nil, err
+	}
+	return &SystemReportHandler{
+		collection:  collection,
+		aggregation: aggregation,
+		logger:      logger,
+	}, nil
+}
+
+func (s *SystemReportHandler) SaveReport(ctx context.Context, report *authv1.SystemReport) (string, error) {
+	if report.GetGeneratedAt() == nil {
+		return "", infra_error.Validation(infra_error.ValidationRequiredFields, "GeneratedAt")
+	}
+	s.logger.Debug("Saving system report", "generated_at", report.GetGeneratedAt().AsTime())
+	return s.collection.Create(ctx, report)
+}
+
+// LatestReport returns the most recent report, or nil when none was generated yet
+func (s *SystemReportHandler) LatestReport(ctx context.Context) (*authv1.SystemReport, error) {
+	reports, err := s.reports(ctx)
+	if err != nil || len(reports) == 0 {
+		return nil, err
+	}
+	return reports[0], nil
+}
+
+// PruneReports deletes all but the keep most recent reports
+func (s *SystemReportHandler) PruneReports(ctx context.Context, keep int) error {
+	reports, err := s.reports(ctx)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports[min(keep, len(reports)):] {
+		if err := s.collection.Delete(ctx, map[string]any{"_id": report.GetId()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UserCounts returns the users and monthly active users of every tenant
+func (s *SystemReportHandler) UserCounts(activeSince time.Time) (map[string]*aggregation_auth.TenantUserCounts, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return s.aggregation.UserCounts(ctx, activeSince)
+}
+
+// StorageBytes returns the storage used by every tenant in the auth database
+func (s *SystemReportHandler) StorageBytes() (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return s.aggregation.StorageBytes(ctx)
+}
+
+// reports returns all stored reports, newest first
+func (s *SystemReportHandler) reports(ctx context.Context) ([]*authv1.SystemReport, error) {
+	reports, err := s.collection.FindAll(ctx, map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].GetGeneratedAt().AsTime().After(reports[j].GetGeneratedAt().AsTime())
+	})
+	return reports, nil
+}
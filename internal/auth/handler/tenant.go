@@ -1,16 +1,20 @@
 package handler
 
 import (
+	"context"
 	"strings"
+	"time"
 
 	aggregation_auth "erp.localhost/internal/auth/aggregation"
 	collection_auth "erp.localhost/internal/auth/collection"
+	mongo "erp.localhost/internal/infra/db/mongo"
 	aggregation_mongo "erp.localhost/internal/infra/db/mongo/aggregation"
 	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -38,7 +42,17 @@ func NewTenantHandler(logger logger.Logger) (*TenantHandler, error) {
 	}, nil
 }
 
-func (t TenantHandler) CreateTenant(tenant *authv1.Tenant) (string, error) {
+// WithTransaction runs fn in a transaction, handler calls given its ctx run inside it.
+// mongo.ErrTransactionsUnsupported is returned without running fn when the deployment has no transactions.
+func (t TenantHandler) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	transactor, ok := t.collection.(mongo.Transactor)
+	if !ok {
+		return mongo.ErrTransactionsUnsupported
+	}
+	return transactor.WithTransaction(ctx, fn)
+}
+
+func (t TenantHandler) CreateTenant(ctx context.Context, tenant *authv1.Tenant) (string, error) {
 	if err := validator_auth.ValidateTenant(tenant, true); err != nil {
 		return "", err
 	}
@@ -46,10 +60,10 @@ func (t TenantHandler) CreateTenant(tenant *authv1.Tenant) (string, error) {
 	tenant.UpdatedAt = timestamppb.Now()
 	t.logger.Debug("Creating tenant", "tenant", tenant)
 	tenant.Name = strings.ToLower(tenant.Name)
-	return t.collection.Create(tenant)
+	return t.collection.Create(ctx, tenant)
 }
 
-func (t TenantHandler) GetTenantByID(tenantID string) (*authv1.Tenant, error) {
+func (t TenantHandler) GetTenantByID(ctx context.Context, tenantID string) (*authv1.Tenant, error) {
 	if tenantID == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
 	}
@@ -57,10 +71,10 @@ func (t TenantHandler) GetTenantByID(tenantID string) (*authv1.Tenant, error) {
 		"_id": tenantID,
 	}
 	t.logger.Debug("Getting tenant by id", "filter", filter)
-	return t.findTenantByFilter(filter)
+	return t.findTenantByFilter(ctx, filter)
 }
 
-func (t TenantHandler) GetTenantByName(name string) (*authv1.Tenant, error) {
+func (t TenantHandler) GetTenantByName(ctx context.Context, name string) (*authv1.Tenant, error) {
 	if name == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
 	}
@@ -68,15 +82,15 @@ func (t TenantHandler) GetTenantByName(name string) (*authv1.Tenant, error) {
 		"name": strings.ToLower(name),
 	}
 	t.logger.Debug("Getting tenant by id", "filter", filter)
-	return t.findTenantByFilter(filter)
+	return t.findTenantByFilter(ctx, filter)
 }
 
-func (t TenantHandler) GetTenants() ([]*authv1.Tenant, error) {
+func (t TenantHandler) GetTenants(ctx context.Context) ([]*authv1.Tenant, error) {
 	t.logger.Debug("Getting all tenants")
-	return t.findTenantsByFilter(nil)
+	return t.findTenantsByFilter(ctx, nil)
 }
 
-func (t TenantHandler) GetTenantsByStatus(status string) ([]*authv1.Tenant, error) {
+func (t TenantHandler) GetTenantsByStatus(ctx context.Context, status string) ([]*authv1.Tenant, error) {
 	if status == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "status")
 	}
@@ -84,10 +98,20 @@ func (t TenantHandler) GetTenantsByStatus(status string) ([]*authv1.Tenant, erro
 		"status": status,
 	}
 	t.logger.Debug("Getting all tenants by status")
-	return t.findTenantsByFilter(filter)
+	return t.findTenantsByFilter(ctx, filter)
+}
+
+// GetTenantsWithExpiredTrial returns the tenants still in trial whose trial period ended at now
+func (t TenantHandler) GetTenantsWithExpiredTrial(ctx context.Context, now time.Time) ([]*authv1.Tenant, error) {
+	filter := map[string]any{
+		"status":    int32(authv1.TenantStatus_TENANT_STATUS_TRIAL),
+		"trial_end": map[string]any{"$lte": now},
+	}
+	t.logger.Debug("Getting tenants with expired trial", "filter", filter)
+	return t.findTenantsByFilter(ctx, filter)
 }
 
-func (t TenantHandler) UpdateTenant(tenant *authv1.Tenant) error {
+func (t TenantHandler) UpdateTenant(ctx context.Context, tenant *authv1.Tenant) error {
 	if err := validator_auth.ValidateTenant(tenant, false); err != nil {
 		return err
 	}
@@ -95,21 +119,25 @@ func (t TenantHandler) UpdateTenant(tenant *authv1.Tenant) error {
 		"_id": tenant.Id,
 	}
 	t.logger.Debug("Updating tenant", "tenant", tenant)
-	currentTenant, err := t.GetTenantByID(tenant.Id)
+	currentTenant, err := t.GetTenantByID(ctx, tenant.Id)
 	if err != nil {
 		return err
 	}
 	if tenant.Id != currentTenant.Id ||
 		tenant.Name != currentTenant.Name ||
-		tenant.CreatedAt != currentTenant.CreatedAt ||
+		!proto.Equal(tenant.CreatedAt, currentTenant.CreatedAt) ||
 		tenant.CreatedBy != currentTenant.CreatedBy {
 		return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields)
 	}
 	tenant.UpdatedAt = timestamppb.Now()
-	return t.collection.Update(filter, tenant)
+	if err := t.collection.UpdateVersioned(ctx, filter, tenant, tenant.GetVersion()); err != nil {
+		return err
+	}
+	tenant.Version++
+	return nil
 }
 
-func (t TenantHandler) DeleteTenant(tenantID string) error {
+func (t TenantHandler) DeleteTenant(ctx context.Context, tenantID string) error {
 	if tenantID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
 	}
@@ -117,21 +145,21 @@ func (t TenantHandler) DeleteTenant(tenantID string) error {
 		"_id": tenantID,
 	}
 	t.logger.Debug("Deleting tenant", "filter", filter)
-	return t.collection.Delete(filter)
+	return t.collection.Delete(ctx, filter)
 }
 
-func (t TenantHandler) findTenantByFilter(filter map[string]any) (*authv1.Tenant, error) {
+func (t TenantHandler) findTenantByFilter(ctx context.Context, filter map[string]any) (*authv1.Tenant, error) {
 	if len(filter) == 0 {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "filter")
 	}
-	tenant, err := t.collection.FindOne(filter)
+	tenant, err := t.collection.FindOne(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 	return tenant, nil
 }
-func (t TenantHandler) findTenantsByFilter(filter map[string]any) ([]*authv1.Tenant, error) {
-	tenants, err := t.collection.FindAll(filter)
+func (t TenantHandler) findTenantsByFilter(ctx context.Context, filter map[string]any) ([]*authv1.Tenant, error) {
+	tenants, err := t.collection.FindAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/usage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UsageHandler persists API usage daily rollups, it implements usage.Store
+type UsageHandler struct {
+	collection collection_mongo.CollectionHandler[authv1.UsageRollup]
+	logger     logger.Logger
+}
+
+func NewUsageHandler(logger logger.Logger) (*UsageHandler, error) {
+	collection, err := collection_auth.NewUsageCollection(logger)
+	if err != nil {
+		logger.Error("failed to create usage collection handler", "error", err)
+		return nil, err
+	}
+	return &UsageHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+// Increment adds calls and errors to a daily rollup.
+// Rollups are flushed by a single tracker per service instance, the unique index rejects concurrent duplicates.
+func (u *UsageHandler) Increment(ctx context.Context, tenantID, date, service, method string, calls, errors int64) error {
+	if tenantID == "" || date == "" || method == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "Date", "Method")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"date":      date,
+		"service":   service,
+		"method":    method,
+	}
+	existing, err := u.collection.FindAll(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		_, err := u.collection.Create(ctx, &authv1.UsageRollup{
+			TenantId:  tenantID,
+			Date:      date,
+			Service:   service,
+			Method:    method,
+			Calls:     calls,
+			Errors:    errors,
+			UpdatedAt: timestamppb.Now(),
+		})
+		return err
+	}
+
+	rollup := existing[0]
+	rollup.Calls += calls
+	rollup.Errors += errors
+	rollup.UpdatedAt = timestamppb.Now()
+	return u.collection.Update(ctx, filter, rollup)
+}
+
+// Rollups returns the rollups of a tenant between two days (YYYY-MM-DD), inclusive
+func (u *UsageHandler) Rollups(ctx context.Context, tenantID, fromDate, toDate string) ([]*authv1.UsageRollup, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	if _, err := time.Parse(usage.DateFormat, fromDate); err != nil {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidDate, "from_date")
+	}
+	if _, err := time.Parse(usage.DateFormat, toDate); err != nil {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidDate, "to_date")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		// YYYY-MM-DD strings sort chronologically
+		"date": map[string]any{"$gte": fromDate, "$lte": toDate},
+	}
+	u.logger.Debug("Getting usage rollups", "filter", filter)
+	return u.collection.FindAll(ctx, filter)
+}
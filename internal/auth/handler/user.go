@@ -1,21 +1,31 @@
 package handler
 
 import (
+	"context"
 	"strings"
+	"time"
 
 	aggregation_auth "erp.localhost/internal/auth/aggregation"
 	collection_auth "erp.localhost/internal/auth/collection"
+	mongo "erp.localhost/internal/infra/db/mongo"
 	aggregation_mongo "erp.localhost/internal/infra/db/mongo/aggregation"
 	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// userSearcher runs paginated user searches, implemented by UserCollection
+type userSearcher interface {
+	Search(ctx context.Context, tenantID string, search *authv1.UserSearch, pagination *infrav1.PaginationRequest) ([]*authv1.User, *infrav1.PaginationResponse, error)
+}
+
 type UserHandler struct {
 	collection  collection_mongo.CollectionHandler[authv1.User]
+	search      userSearcher
 	aggregation aggregation_mongo.AggregationHandler[authv1.User]
 	logger      logger.Logger
 }
@@ -33,28 +43,49 @@ func NewUserHandler(logger logger.Logger) (*UserHandler, error) {
 	}
 	return &UserHandler{
 		collection:  collection,
+		search:      collection,
 		aggregation: aggregation,
 		logger:      logger,
 	}, nil
 }
 
-func (u *UserHandler) CreateUser(user *authv1.User) (string, error) {
-	if err := validator_auth.ValidateUser(user, true); err != nil {
+func (u *UserHandler) CreateUser(ctx context.Context, user *authv1.User) (string, error) {
+	if err := prepareUser(user); err != nil {
 		return "", err
 	}
+	u.logger.Debug("Creating user", "user", user)
+	return u.collection.Create(ctx, user)
+}
+
+// CreateUsers creates users in one ordered bulk write and returns their ids by index, the users after a failed one
+// are not created, see collection.BaseCollectionHandler.CreateMany
+func (u *UserHandler) CreateUsers(ctx context.Context, users []*authv1.User) ([]string, error) {
+	for _, user := range users {
+		if err := prepareUser(user); err != nil {
+			return nil, err
+		}
+	}
+	u.logger.Debug("Creating users", "count", len(users))
+	return u.collection.CreateMany(ctx, users, mongo.BulkOptions{})
+}
+
+// prepareUser validates a new user and sets its timestamps and lower case username and email
+func prepareUser(user *authv1.User) error {
+	if err := validator_auth.ValidateUser(user, true); err != nil {
+		return err
+	}
 	user.CreatedAt = timestamppb.Now()
 	user.UpdatedAt = timestamppb.Now()
-	u.logger.Debug("Creating user", "user", user)
 	if user.GetUsername() != "" {
 		user.Username = strings.ToLower(user.Username)
 	}
 	if user.GetEmail() != "" {
 		user.Email = strings.ToLower(user.Email)
 	}
-	return u.collection.Create(user)
+	return nil
 }
 
-func (u *UserHandler) GetUserByID(tenantID, userID string) (*authv1.User, error) {
+func (u *UserHandler) GetUserByID(ctx context.Context, tenantID, userID string) (*authv1.User, error) {
 	if userID == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "userID")
 	}
@@ -63,10 +94,10 @@ func (u *UserHandler) GetUserByID(tenantID, userID string) (*authv1.User, error)
 		"_id":       userID,
 	}
 	u.logger.Debug("Getting user by id", "filter", filter)
-	return u.findUserByFilter(filter)
+	return u.findUserByFilter(ctx, filter)
 }
 
-func (u *UserHandler) GetUserByEmail(tenantID, email string) (*authv1.User, error) {
+func (u *UserHandler) GetUserByEmail(ctx context.Context, tenantID, email string) (*authv1.User, error) {
 	if email == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "email")
 	}
@@ -75,10 +106,10 @@ func (u *UserHandler) GetUserByEmail(tenantID, email string) (*authv1.User, erro
 		"email":     strings.ToLower(email),
 	}
 	u.logger.Debug("Getting user by email", "filter", filter)
-	return u.findUserByFilter(filter)
+	return u.findUserByFilter(ctx, filter)
 }
 
-func (u *UserHandler) GetUserByUsername(tenantID, username string) (*authv1.User, error) {
+func (u *UserHandler) GetUserByUsername(ctx context.Context, tenantID, username string) (*authv1.User, error) {
 	if username == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "username")
 	}
@@ -87,18 +118,82 @@ func (u *UserHandler) GetUserByUsername(tenantID, username string) (*authv1.User
 		"username":  strings.ToLower(username),
 	}
 	u.logger.Debug("Getting user by username", "filter", filter)
-	return u.findUserByFilter(filter)
+	return u.findUserByFilter(ctx, filter)
 }
 
-func (u *UserHandler) GetUsersByTenantID(tenantID string) ([]*authv1.User, error) {
+// GetUserByExternalIdentity returns the user linked to the provider account, nil when no user is linked
+func (u *UserHandler) GetUserByExternalIdentity(ctx context.Context, tenantID, provider, subject string) (*authv1.User, error) {
+	if provider == "" || subject == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "provider", "subject")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"external_identities": map[string]any{
+			"$elemMatch": map[string]any{
+				"provider": provider,
+				"subject":  subject,
+			},
+		},
+	}
+	u.logger.Debug("Getting user by external identity", "filter", filter)
+	return u.findOptionalUserByFilter(ctx, filter)
+}
+
+// FindUserByEmail returns the user with the email, nil when there is none
+func (u *UserHandler) FindUserByEmail(ctx context.Context, tenantID, email string) (*authv1.User, error) {
+	if email == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "email")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"email":     strings.ToLower(email),
+	}
+	u.logger.Debug("Finding user by email", "filter", filter)
+	return u.findOptionalUserByFilter(ctx, filter)
+}
+
+// GetUsersByTenantID returns the users of the tenant, ordered, projected and limited by opts when given
+func (u *UserHandler) GetUsersByTenantID(ctx context.Context, tenantID string, opts ...mongo.ListOptions) ([]*authv1.User, error) {
 	filter := map[string]any{
 		"tenant_id": tenantID,
 	}
 	u.logger.Debug("Getting users by tenant id", "filter", filter)
-	return u.findUsersByFilter(filter)
+	return u.findUsersByFilter(ctx, filter, opts...)
+}
+
+// CountUsersByTenantID returns the number of users of the tenant
+func (u *UserHandler) CountUsersByTenantID(ctx context.Context, tenantID string) (int64, error) {
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	u.logger.Debug("Counting users by tenant id", "filter", filter)
+	return u.collection.Count(ctx, filter)
 }
 
-func (u *UserHandler) GetUsersByRoleID(tenantID, roleID string) ([]*authv1.User, error) {
+// CountUsersByRoleID returns the number of tenant users holding the role
+func (u *UserHandler) CountUsersByRoleID(ctx context.Context, tenantID, roleID string) (int64, error) {
+	if roleID == "" {
+		return 0, infra_error.Validation(infra_error.ValidationRequiredFields, "roleID")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"role_id":   roleID,
+	}
+	u.logger.Debug("Counting users by role id", "filter", filter)
+	return u.collection.Count(ctx, filter)
+}
+
+// SearchUsers returns a page of the tenant users matching search
+func (u *UserHandler) SearchUsers(ctx context.Context, tenantID string, search *authv1.UserSearch, pagination *infrav1.PaginationRequest) ([]*authv1.User, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
+	}
+	u.logger.Debug("Searching users", "tenant_id", tenantID, "search", search, "pagination", pagination)
+	return u.search.Search(ctx, tenantID, search, pagination)
+}
+
+// GetUsersByRoleID returns the tenant users holding the role, ordered, projected and limited by opts when given
+func (u *UserHandler) GetUsersByRoleID(ctx context.Context, tenantID, roleID string, opts ...mongo.ListOptions) ([]*authv1.User, error) {
 	if roleID == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "roleID")
 	}
@@ -107,10 +202,28 @@ func (u *UserHandler) GetUsersByRoleID(tenantID, roleID string) ([]*authv1.User,
 		"role_id":   roleID,
 	}
 	u.logger.Debug("Getting users by role id", "filter", filter)
-	return u.findUsersByFilter(filter)
+	return u.findUsersByFilter(ctx, filter, opts...)
+}
+
+// GetUsersWithExpiredRoles returns the users of every tenant holding a role assignment expired at now
+func (u *UserHandler) GetUsersWithExpiredRoles(ctx context.Context, now time.Time) ([]*authv1.User, error) {
+	filter := map[string]any{
+		"roles.expires_at": map[string]any{"$lte": now},
+	}
+	u.logger.Debug("Getting users with expired roles", "filter", filter)
+	return u.collection.FindAll(ctx, filter)
+}
+
+// GetUsersDueForDeletion returns the users of every tenant whose account deletion is scheduled at or before now
+func (u *UserHandler) GetUsersDueForDeletion(ctx context.Context, now time.Time) ([]*authv1.User, error) {
+	filter := map[string]any{
+		"deletion_scheduled_at": map[string]any{"$lte": now},
+	}
+	u.logger.Debug("Getting users due for deletion", "filter", filter)
+	return u.collection.FindAll(ctx, filter)
 }
 
-func (u *UserHandler) UpdateUser(user *authv1.User) error {
+func (u *UserHandler) UpdateUser(ctx context.Context, user *authv1.User) error {
 	if err := validator_auth.ValidateUser(user, false); err != nil {
 		return err
 	}
@@ -122,10 +235,32 @@ func (u *UserHandler) UpdateUser(user *authv1.User) error {
 	user.UpdatedAt = timestamppb.Now()
 	user.Username = strings.ToLower(user.Username)
 	user.Email = strings.ToLower(user.Email)
-	return u.collection.Update(filter, user)
+	if err := u.collection.UpdateVersioned(ctx, filter, user, user.GetVersion()); err != nil {
+		return err
+	}
+	user.Version++
+	return nil
 }
 
-func (u *UserHandler) DeleteUser(tenantID, userID string) error {
+// ReplaceUser stores user in place of the stored document with the same id. Unlike UpdateUser the fields cleared
+// on user are removed, updates skip the empty omitempty fields. Run it in a transaction, it deletes then creates
+func (u *UserHandler) ReplaceUser(ctx context.Context, user *authv1.User) error {
+	if err := validator_auth.ValidateUser(user, false); err != nil {
+		return err
+	}
+	u.logger.Debug("Replacing user", "tenant_id", user.GetTenantId(), "user_id", user.GetId())
+	if err := u.DeleteUser(ctx, user.GetTenantId(), user.GetId()); err != nil {
+		return err
+	}
+	user.UpdatedAt = timestamppb.Now()
+	user.Username = strings.ToLower(user.Username)
+	user.Email = strings.ToLower(user.Email)
+	user.Version++
+	_, err := u.collection.Create(ctx, user)
+	return err
+}
+
+func (u *UserHandler) DeleteUser(ctx context.Context, tenantID, userID string) error {
 	if tenantID == "" || userID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId")
 	}
@@ -134,10 +269,10 @@ func (u *UserHandler) DeleteUser(tenantID, userID string) error {
 		"_id":       userID,
 	}
 	u.logger.Debug("Deleting user", "filter", filter)
-	return u.collection.Delete(filter)
+	return u.collection.Delete(ctx, filter)
 }
 
-func (u *UserHandler) DeleteTenantUsers(tenantID string) error {
+func (u *UserHandler) DeleteTenantUsers(ctx context.Context, tenantID string) error {
 	if tenantID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "UserId")
 	}
@@ -145,25 +280,34 @@ func (u *UserHandler) DeleteTenantUsers(tenantID string) error {
 		"tenant_id": tenantID,
 	}
 	u.logger.Debug("Deleting user", "filter", filter)
-	return u.collection.Delete(filter)
+	return u.collection.Delete(ctx, filter)
 }
 
-func (u *UserHandler) findUserByFilter(filter map[string]any) (*authv1.User, error) {
+func (u *UserHandler) findUserByFilter(ctx context.Context, filter map[string]any) (*authv1.User, error) {
 	if _, ok := filter["tenant_id"]; !ok {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
 	}
-	user, err := u.collection.FindOne(filter)
+	user, err := u.collection.FindOne(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
-func (u *UserHandler) findUsersByFilter(filter map[string]any) ([]*authv1.User, error) {
+// findOptionalUserByFilter is findUserByFilter for lookups where no match is expected, it returns nil instead of an error
+func (u *UserHandler) findOptionalUserByFilter(ctx context.Context, filter map[string]any) (*authv1.User, error) {
+	users, err := u.findUsersByFilter(ctx, filter)
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+	return users[0], nil
+}
+
+func (u *UserHandler) findUsersByFilter(ctx context.Context, filter map[string]any, opts ...mongo.ListOptions) ([]*authv1.User, error) {
 	if _, ok := filter["tenant_id"]; !ok {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
 	}
-	users, err := u.collection.FindAll(filter)
+	users, err := u.collection.FindAll(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	model_event "erp.localhost/internal/infra/model/event"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WebhookHandler struct {
+	collection         collection_mongo.CollectionHandler[authv1.Webhook]
+	deliveryCollection collection_mongo.CollectionHandler[authv1.WebhookDelivery]
+	logger             logger.Logger
+}
+
+func NewWebhookHandler(logger logger.Logger) (*WebhookHandler, error) {
+	collection, err := collection_auth.NewWebhookCollection(logger)
+	if err != nil {
+		logger.Error("failed to create webhook collection handler", "error", err)
+		return nil, err
+	}
+	deliveryCollection, err := collection_auth.NewWebhookDeliveryCollection(logger)
+	if err != nil {
+		logger.Error("failed to create webhook delivery collection handler", "error", err)
+		return nil, err
+	}
+	return &WebhookHandler{
+		collection:         collection,
+		deliveryCollection: deliveryCollection,
+		logger:             logger,
+	}, nil
+}
+
+func (w *WebhookHandler) CreateWebhook(ctx context.Context, webhook *authv1.Webhook) (string, error) {
+	if err := validator_auth.ValidateWebhook(webhook, true); err != nil {
+		return "", err
+	}
+	webhook.CreatedAt = timestamppb.Now()
+	w.logger.Debug("Creating webhook", "tenant_id", webhook.GetTenantId(), "url", webhook.GetUrl())
+	return w.collection.Create(ctx, webhook)
+}
+
+func (w *WebhookHandler) GetWebhookByID(ctx context.Context, tenantID, webhookID string) (*authv1.Webhook, error) {
+	if tenantID == "" || webhookID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "WebhookId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       webhookID,
+	}
+	w.logger.Debug("Getting webhook by id", "filter", filter)
+	return w.collection.FindOne(ctx, filter)
+}
+
+func (w *WebhookHandler) GetWebhooksByTenantID(ctx context.Context, tenantID string) ([]*authv1.Webhook, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	w.logger.Debug("Getting webhooks by tenant id", "filter", filter)
+	return w.collection.FindAll(ctx, filter)
+}
+
+// GetActiveWebhooks returns the webhooks of the tenant receiving events
+func (w *WebhookHandler) GetActiveWebhooks(ctx context.Context, tenantID string) ([]*authv1.Webhook, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"active":    true,
+	}
+	w.logger.Debug("Getting active webhooks", "filter", filter)
+	return w.collection.FindAll(ctx, filter)
+}
+
+func (w *WebhookHandler) UpdateWebhook(ctx context.Context, webhook *authv1.Webhook) error {
+	if err := validator_auth.ValidateWebhook(webhook, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": webhook.TenantId,
+		"_id":       webhook.Id,
+	}
+	webhook.UpdatedAt = timestamppb.Now()
+	w.logger.Debug("Updating webhook", "filter", filter)
+	return w.collection.Update(ctx, filter, webhook)
+}
+
+func (w *WebhookHandler) DeleteWebhook(ctx context.Context, tenantID, webhookID string) error {
+	if tenantID == "" || webhookID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "WebhookId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       webhookID,
+	}
+	w.logger.Debug("Deleting webhook", "filter", filter)
+	return w.collection.Delete(ctx, filter)
+}
+
+/* Deliveries */
+
+func (w *WebhookHandler) CreateDelivery(ctx context.Context, delivery *authv1.WebhookDelivery) (string, error) {
+	if err := validator_auth.ValidateWebhookDelivery(delivery, true); err != nil {
+		return "", err
+	}
+	w.logger.Debug("Creating webhook delivery", "tenant_id", delivery.GetTenantId(), "webhook_id", delivery.GetWebhookId(), "event_id", delivery.GetEventId())
+	return w.deliveryCollection.Create(ctx, delivery)
+}
+
+func (w *WebhookHandler) GetDeliveryByID(ctx context.Context, tenantID, deliveryID string) (*authv1.WebhookDelivery, error) {
+	if tenantID == "" || deliveryID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "DeliveryId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       deliveryID,
+	}
+	w.logger.Debug("Getting webhook delivery by id", "filter", filter)
+	return w.deliveryCollection.FindOne(ctx, filter)
+}
+
+// GetDeliveries returns the delivery log of a webhook, of every status when status is empty
+func (w *WebhookHandler) GetDeliveries(ctx context.Context, tenantID, webhookID, status string) ([]*authv1.WebhookDelivery, error) {
+	if tenantID == "" || webhookID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "WebhookId")
+	}
+	filter := map[string]any{
+		"tenant_id":  tenantID,
+		"webhook_id": webhookID,
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	w.logger.Debug("Getting webhook deliveries", "filter", filter)
+	return w.deliveryCollection.FindAll(ctx, filter)
+}
+
+// GetPendingDeliveries returns the pending deliveries of every tenant due for an attempt at now
+func (w *WebhookHandler) GetPendingDeliveries(ctx context.Context, now time.Time) ([]*authv1.WebhookDelivery, error) {
+	filter := map[string]any{
+		"status":          model_event.WebhookDeliveryPending,
+		"next_attempt_at": map[string]any{"$lte": now},
+	}
+	return w.deliveryCollection.FindAll(ctx, filter)
+}
+
+func (w *WebhookHandler) UpdateDelivery(ctx context.Context, delivery *authv1.WebhookDelivery) error {
+	if err := validator_auth.ValidateWebhookDelivery(delivery, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": delivery.TenantId,
+		"_id":       delivery.Id,
+	}
+	w.logger.Debug("Updating webhook delivery", "filter", filter)
+	return w.deliveryCollection.Update(ctx, filter, delivery)
+}
@@ -0,0 +1,65 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+const (
+	// APIKeyPrefix marks ERP API keys so they can be recognised by secret scanners
+	APIKeyPrefix = "erp"
+
+	apiKeyLookupBytes = 6
+	apiKeySecretBytes = 32
+)
+
+// ErrMalformedAPIKey is returned for keys not in the erp_<lookup>_<secret> format
+var ErrMalformedAPIKey = errors.New("malformed api key")
+
+// GenerateAPIKey returns a new key in the erp_<lookup>_<secret> format, its lookup prefix and its hash.
+// Only the prefix and the hash should be persisted, the key is shown to the user once.
+func GenerateAPIKey() (key, prefix, keyHash string, err error) {
+	lookup := make([]byte, apiKeyLookupBytes)
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(lookup); err != nil {
+		return "", "", "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	prefix = APIKeyPrefix + "_" + hex.EncodeToString(lookup)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// ParseAPIKey returns the lookup prefix of key
+func ParseAPIKey(key string) (string, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) < 3 || parts[0] != APIKeyPrefix || len(parts[1]) != hex.EncodedLen(apiKeyLookupBytes) {
+		return "", infra_error.Auth(infra_error.AuthTokenInvalid).WithError(ErrMalformedAPIKey)
+	}
+	// The secret is base64url encoded and may itself contain '_'
+	if len(key)-len(parts[0])-len(parts[1])-2 != base64.RawURLEncoding.EncodedLen(apiKeySecretBytes) {
+		return "", infra_error.Auth(infra_error.AuthTokenInvalid).WithError(ErrMalformedAPIKey)
+	}
+	return parts[0] + "_" + parts[1], nil
+}
+
+// HashAPIKey returns the SHA-256 of key. API keys carry 256 bits of entropy and are checked
+// on every call, so they don't need the slow password hashes.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAPIKey reports whether key matches keyHash in constant time
+func VerifyAPIKey(key, keyHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(keyHash)) == 1
+}
@@ -0,0 +1,51 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, keyHash, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	assert.NotContains(t, keyHash, key)
+	assert.True(t, VerifyAPIKey(key, keyHash))
+	assert.False(t, VerifyAPIKey(key+"x", keyHash))
+
+	parsed, err := ParseAPIKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, prefix, parsed)
+
+	other, otherPrefix, _, err := GenerateAPIKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+	assert.NotEqual(t, prefix, otherPrefix)
+}
+
+func TestParseAPIKey_Malformed(t *testing.T) {
+	key, _, _, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		key  string
+	}{
+		{name: "empty", key: ""},
+		{name: "wrong prefix", key: "xyz" + strings.TrimPrefix(key, APIKeyPrefix)},
+		{name: "missing secret", key: key[:strings.LastIndex(key, "_")]},
+		{name: "short secret", key: key[:len(key)-1]},
+		{name: "short lookup", key: APIKeyPrefix + "_abc_" + key[len(key)-43:]},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseAPIKey(tc.key)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrMalformedAPIKey)
+		})
+	}
+}
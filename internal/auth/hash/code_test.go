@@ -0,0 +1,22 @@
+package hash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeHasher(t *testing.T) {
+	hasher := NewCodeHasher("secret")
+
+	codeHash := hasher.Hash("tenant-1", "123456")
+	assert.NotContains(t, codeHash, "123456")
+	assert.Equal(t, codeHash, hasher.Hash("tenant-1", "123456"))
+
+	assert.True(t, hasher.Verify("tenant-1", "123456", codeHash))
+	assert.False(t, hasher.Verify("tenant-1", "123457", codeHash))
+	assert.False(t, hasher.Verify("tenant-2", "123456", codeHash), "the pepper is per tenant")
+	assert.False(t, NewCodeHasher("other").Verify("tenant-1", "123456", codeHash), "the pepper derives from the secret")
+	assert.False(t, hasher.Verify("tenant-1", "", codeHash))
+	assert.False(t, hasher.Verify("tenant-1", "123456", ""))
+}
@@ -0,0 +1,58 @@
+package hash
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// GeneratedPasswordLength is the length of the generated passwords, about 150 bits of entropy
+const GeneratedPasswordLength = 24
+
+// The character classes of the generated passwords, a password has one of each so it passes every policy.
+// Characters easily mistaken for one another are left out
+var generatedPasswordClasses = []string{
+	"abcdefghijkmnopqrstuvwxyz",
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"23456789",
+	"!#$%&*+-=?@^_",
+}
+
+// GeneratePassword returns a random password of GeneratedPasswordLength characters with a lowercase and an
+// uppercase letter, a digit and a symbol, for the accounts created without a password of their user
+func GeneratePassword() (string, error) {
+	alphabet := ""
+	for _, class := range generatedPasswordClasses {
+		alphabet += class
+	}
+	password := make([]byte, GeneratedPasswordLength)
+	for i := range password {
+		charset := alphabet
+		if i < len(generatedPasswordClasses) {
+			charset = generatedPasswordClasses[i]
+		}
+		c, err := randomIndex(len(charset))
+		if err != nil {
+			return "", err
+		}
+		password[i] = charset[c]
+	}
+	// The characters of the classes are moved to random positions
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,26 @@
+package hash
+
+import (
+	"testing"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	strict := DefaultPasswordPolicy()
+	strict.RequireSymbol = true
+	strict.MinLength = GeneratedPasswordLength
+
+	seen := map[string]bool{}
+	for range 20 {
+		password, err := GeneratePassword()
+		require.NoError(t, err)
+		assert.Len(t, password, GeneratedPasswordLength)
+		assert.NoError(t, ValidatePassword(strict, password, "system_admin", "system@system.com"))
+		assert.NoError(t, ValidatePassword(&authv1.PasswordPolicy{}, password))
+		assert.False(t, seen[password])
+		seen[password] = true
+	}
+}
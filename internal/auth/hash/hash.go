@@ -1,8 +1,12 @@
 package hash
 
 import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
 	infra_error "erp.localhost/internal/infra/error"
-	passwordvalidator "github.com/wagslane/go-password-validator"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -10,22 +14,102 @@ const (
 	minEntropyBits = 60.0
 )
 
-func HashPassword(password string) (string, error) {
-	err := passwordvalidator.Validate(password, minEntropyBits)
-	if err != nil {
-		return "", infra_error.Validation(infra_error.ValidationPasswordTooWeak)
+// Hash algorithms, the algorithm of a stored hash is detected from its prefix
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// Config selects the algorithm used for new hashes
+type Config struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+// LoadConfig reads HASH_ALGORITHM, HASH_BCRYPT_COST, HASH_ARGON2_TIME, HASH_ARGON2_MEMORY_KIB and HASH_ARGON2_THREADS.
+// Argon2id is used unless bcrypt is selected.
+func LoadConfig() Config {
+	cfg := Config{
+		Algorithm:  AlgorithmArgon2id,
+		BcryptCost: bcrypt.DefaultCost,
+		Argon2:     DefaultArgon2Params(),
 	}
-	return Hash(password)
+	if strings.EqualFold(os.Getenv("HASH_ALGORITHM"), AlgorithmBcrypt) {
+		cfg.Algorithm = AlgorithmBcrypt
+	}
+	if cost, err := strconv.Atoi(os.Getenv("HASH_BCRYPT_COST")); err == nil && cost >= bcrypt.MinCost && cost <= bcrypt.MaxCost {
+		cfg.BcryptCost = cost
+	}
+	if t, err := strconv.ParseUint(os.Getenv("HASH_ARGON2_TIME"), 10, 32); err == nil && t > 0 {
+		cfg.Argon2.Time = uint32(t)
+	}
+	if m, err := strconv.ParseUint(os.Getenv("HASH_ARGON2_MEMORY_KIB"), 10, 32); err == nil && m > 0 {
+		cfg.Argon2.Memory = uint32(m)
+	}
+	if p, err := strconv.ParseUint(os.Getenv("HASH_ARGON2_THREADS"), 10, 8); err == nil && p > 0 {
+		cfg.Argon2.Threads = uint8(p)
+	}
+	return cfg
+}
+
+var config atomic.Pointer[Config]
+
+func init() {
+	cfg := LoadConfig()
+	config.Store(&cfg)
+}
+
+// SetConfig replaces the configuration used for new hashes
+func SetConfig(cfg Config) {
+	config.Store(&cfg)
+}
+
+// GetConfig returns the configuration used for new hashes
+func GetConfig() Config {
+	return *config.Load()
+}
+
+// HashPassword validates the password against the default policy and hashes it
+func HashPassword(password string) (string, error) {
+	return HashPasswordWithPolicy(DefaultPasswordPolicy(), password)
 }
 
+// VerifyHash compares obj with a hash of any supported algorithm
 func VerifyHash(obj, hash string) bool {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return verifyArgon2id(obj, hash)
+	}
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(obj)) == nil
 }
 
+// Hash hashes obj with the configured algorithm
 func Hash(obj string) (string, error) {
-	hashedObj, err := bcrypt.GenerateFromPassword([]byte(obj), bcrypt.DefaultCost)
+	cfg := GetConfig()
+	if cfg.Algorithm == AlgorithmArgon2id {
+		return hashArgon2id(obj, cfg.Argon2)
+	}
+	hashedObj, err := bcrypt.GenerateFromPassword([]byte(obj), cfg.BcryptCost)
 	if err != nil {
 		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
 	}
 	return string(hashedObj), nil
 }
+
+// NeedsRehash reports whether a stored hash was made with another algorithm or weaker parameters than configured.
+// It is checked after a successful verification, when the plain text is available to hash again.
+func NeedsRehash(hash string) bool {
+	cfg := GetConfig()
+	if cfg.Algorithm == AlgorithmArgon2id {
+		params, _, _, err := decodeArgon2id(hash)
+		if err != nil {
+			return true
+		}
+		return params.Time < cfg.Argon2.Time || params.Memory < cfg.Argon2.Memory || params.Threads < cfg.Argon2.Threads
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return cost < cfg.BcryptCost
+}
@@ -1,6 +1,7 @@
 package hash
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,3 +50,56 @@ func TestVerifyPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestHash_Algorithms(t *testing.T) {
+	defer SetConfig(GetConfig())
+
+	testCases := []struct {
+		name       string
+		algorithm  string
+		wantPrefix string
+	}{
+		{name: "argon2id", algorithm: AlgorithmArgon2id, wantPrefix: "$argon2id$v=19$m=19456,t=2,p=1$"},
+		{name: "bcrypt", algorithm: AlgorithmBcrypt, wantPrefix: "$2a$10$"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetConfig(Config{Algorithm: tc.algorithm, BcryptCost: 10, Argon2: DefaultArgon2Params()})
+			hash, err := Hash("1aAm!&25@*zgTY$pwL")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hash, tc.wantPrefix), hash)
+			assert.True(t, VerifyHash("1aAm!&25@*zgTY$pwL", hash))
+			assert.False(t, VerifyHash("wrong", hash))
+			assert.False(t, NeedsRehash(hash))
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	defer SetConfig(GetConfig())
+	bcryptHash := "$2a$10$YxNnIaPMWRFglNffZjPEv.mJoa63BZWObp2yjHC7P6/aG61C.mJyC"
+
+	SetConfig(Config{Algorithm: AlgorithmArgon2id, BcryptCost: 10, Argon2: DefaultArgon2Params()})
+	weak, err := hashArgon2id("password", Argon2Params{Time: 1, Memory: 8 * 1024, Threads: 1, KeyLen: 32, SaltLen: 16})
+	require.NoError(t, err)
+	assert.True(t, VerifyHash("password", weak))
+	assert.True(t, NeedsRehash(bcryptHash))
+	assert.True(t, NeedsRehash(weak))
+	assert.True(t, NeedsRehash("not a hash"))
+
+	SetConfig(Config{Algorithm: AlgorithmBcrypt, BcryptCost: 12, Argon2: DefaultArgon2Params()})
+	assert.True(t, NeedsRehash(bcryptHash))
+	assert.True(t, NeedsRehash(weak))
+}
+
+func TestVerifyHash_MalformedArgon2(t *testing.T) {
+	testCases := []string{
+		"$argon2id$",
+		"$argon2id$v=18$m=19456,t=2,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=2,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=19456,t=2,p=1$!!$a2V5",
+	}
+	for _, hash := range testCases {
+		assert.False(t, VerifyHash("password", hash), hash)
+	}
+}
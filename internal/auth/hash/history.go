@@ -0,0 +1,52 @@
+package hash
+
+import (
+	"context"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// CheckPasswordReuse rejects a password matching the current hash or one of the remembered previous hashes.
+// history is ordered newest first, only the first history depth entries of the policy are checked.
+// A policy without history depth disables the check.
+func CheckPasswordReuse(ctx context.Context, policy *authv1.PasswordPolicy, password, currentHash string, history []string) error {
+	depth := int(policy.GetHistoryDepth())
+	if depth <= 0 {
+		return nil
+	}
+
+	hashes := make([]string, 0, depth+1)
+	if currentHash != "" {
+		hashes = append(hashes, currentHash)
+	}
+	hashes = append(hashes, history[:min(depth, len(history))]...)
+
+	for _, previous := range hashes {
+		reused, err := Verify(ctx, password, previous)
+		if err != nil {
+			return err
+		}
+		if reused {
+			return infra_error.Validation(infra_error.ValidationPasswordReused, "password").
+				WithDetails("history_depth", depth)
+		}
+	}
+	return nil
+}
+
+// PushPasswordHistory records the replaced password hash, newest first, keeping at most history depth entries
+func PushPasswordHistory(policy *authv1.PasswordPolicy, history []string, replacedHash string) []string {
+	depth := int(policy.GetHistoryDepth())
+	if depth <= 0 {
+		return nil
+	}
+	if replacedHash == "" {
+		return history[:min(depth, len(history))]
+	}
+
+	updated := make([]string, 0, depth)
+	updated = append(updated, replacedHash)
+	updated = append(updated, history[:min(depth-1, len(history))]...)
+	return updated
+}
@@ -0,0 +1,71 @@
+package hash
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPasswordReuse(t *testing.T) {
+	current, err := Hash("Current-Pass-1")
+	require.NoError(t, err)
+	previous, err := Hash("Previous-Pass-1")
+	require.NoError(t, err)
+	oldest, err := Hash("Oldest-Pass-1")
+	require.NoError(t, err)
+	history := []string{previous, oldest}
+
+	testCases := []struct {
+		name     string
+		depth    int32
+		password string
+		wantErr  bool
+	}{
+		{name: "history disabled", depth: 0, password: "Current-Pass-1", wantErr: false},
+		{name: "current password", depth: 1, password: "Current-Pass-1", wantErr: true},
+		{name: "previous password within depth", depth: 1, password: "Previous-Pass-1", wantErr: true},
+		{name: "oldest password outside depth", depth: 1, password: "Oldest-Pass-1", wantErr: false},
+		{name: "oldest password within depth", depth: 2, password: "Oldest-Pass-1", wantErr: true},
+		{name: "new password", depth: 5, password: "Brand-New-Pass-1", wantErr: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &authv1.PasswordPolicy{HistoryDepth: tc.depth}
+			err := CheckPasswordReuse(context.Background(), policy, tc.password, current, history)
+			if !tc.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			appErr, ok := infra_error.AsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, infra_error.ValidationPasswordReused.Code, appErr.Code)
+		})
+	}
+}
+
+func TestPushPasswordHistory(t *testing.T) {
+	testCases := []struct {
+		name     string
+		depth    int32
+		history  []string
+		replaced string
+		want     []string
+	}{
+		{name: "history disabled", depth: 0, history: []string{"a"}, replaced: "b", want: nil},
+		{name: "empty history", depth: 3, history: nil, replaced: "a", want: []string{"a"}},
+		{name: "newest first", depth: 3, history: []string{"b", "c"}, replaced: "a", want: []string{"a", "b", "c"}},
+		{name: "trimmed to depth", depth: 2, history: []string{"b", "c", "d"}, replaced: "a", want: []string{"a", "b"}},
+		{name: "no replaced hash", depth: 2, history: []string{"b", "c", "d"}, replaced: "", want: []string{"b", "c"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &authv1.PasswordPolicy{HistoryDepth: tc.depth}
+			assert.Equal(t, tc.want, PushPasswordHistory(policy, tc.history, tc.replaced))
+		})
+	}
+}
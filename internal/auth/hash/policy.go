@@ -0,0 +1,160 @@
+package hash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	passwordvalidator "github.com/wagslane/go-password-validator"
+)
+
+const (
+	// Passwords shorter than this are rejected even if a tenant policy allows them
+	MinPasswordLength = 8
+	MaxPasswordLength = 128
+
+	defaultHistoryDepth = 5
+)
+
+// Rejected by every policy
+var commonPasswords = []string{
+	"password",
+	"password1",
+	"password123",
+	"12345678",
+	"123456789",
+	"qwerty123",
+	"iloveyou",
+	"admin123",
+	"letmein1",
+	"welcome1",
+	"changeme",
+}
+
+// DefaultPasswordPolicy is used for tenants without their own policy
+func DefaultPasswordPolicy() *authv1.PasswordPolicy {
+	return &authv1.PasswordPolicy{
+		MinLength:        MinPasswordLength,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		HistoryDepth:     defaultHistoryDepth,
+		MinEntropyBits:   minEntropyBits,
+	}
+}
+
+// EffectivePasswordPolicy returns the tenant policy, or the default one when the tenant has none
+func EffectivePasswordPolicy(tenant *authv1.Tenant) *authv1.PasswordPolicy {
+	if policy := tenant.GetSettings().GetPasswordPolicy(); policy != nil {
+		return policy
+	}
+	return DefaultPasswordPolicy()
+}
+
+// ValidatePassword checks a plain text password against the policy.
+// identity holds values the password must not contain, such as the username and email.
+// All violations are reported in the error details.
+func ValidatePassword(policy *authv1.PasswordPolicy, password string, identity ...string) error {
+	if policy == nil {
+		policy = DefaultPasswordPolicy()
+	}
+
+	violations := []string{}
+	minLength := max(int(policy.GetMinLength()), MinPasswordLength)
+	length := len([]rune(password))
+	if length < minLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", minLength))
+	}
+	if length > MaxPasswordLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters long", MaxPasswordLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if policy.GetRequireUppercase() && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if policy.GetRequireLowercase() && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if policy.GetRequireDigit() && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if policy.GetRequireSymbol() && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if isBannedPassword(policy, password) {
+		violations = append(violations, "is too common")
+	}
+	lowered := strings.ToLower(password)
+	for _, value := range identity {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if local, _, found := strings.Cut(value, "@"); found {
+			value = local
+		}
+		if len(value) >= 3 && strings.Contains(lowered, value) {
+			violations = append(violations, "must not contain the username or email")
+			break
+		}
+	}
+
+	if policy.GetMinEntropyBits() > 0 && len(violations) == 0 {
+		if err := passwordvalidator.Validate(password, policy.GetMinEntropyBits()); err != nil {
+			violations = append(violations, "is too easy to guess")
+		}
+	}
+
+	if len(violations) > 0 {
+		return infra_error.Validation(infra_error.ValidationPasswordTooWeak, "password").
+			WithDetails("violations", violations).
+			WithError(errors.New("password " + strings.Join(violations, ", ")))
+	}
+	return nil
+}
+
+// IsPasswordExpired reports whether a password changed at lastChange is older than the policy max age
+func IsPasswordExpired(policy *authv1.PasswordPolicy, lastChange time.Time, now time.Time) bool {
+	if policy.GetMaxAgeDays() <= 0 || lastChange.IsZero() {
+		return false
+	}
+	return now.After(lastChange.AddDate(0, 0, int(policy.GetMaxAgeDays())))
+}
+
+// HashPasswordWithPolicy validates the password against the policy and hashes it
+func HashPasswordWithPolicy(policy *authv1.PasswordPolicy, password string, identity ...string) (string, error) {
+	if err := ValidatePassword(policy, password, identity...); err != nil {
+		return "", err
+	}
+	return Hash(password)
+}
+
+func isBannedPassword(policy *authv1.PasswordPolicy, password string) bool {
+	lowered := strings.ToLower(password)
+	for _, banned := range commonPasswords {
+		if lowered == banned {
+			return true
+		}
+	}
+	for _, banned := range policy.GetBannedPasswords() {
+		if lowered == strings.ToLower(banned) {
+			return true
+		}
+	}
+	return false
+}
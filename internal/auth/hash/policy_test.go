@@ -0,0 +1,75 @@
+package hash
+
+import (
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePassword(t *testing.T) {
+	testCases := []struct {
+		name           string
+		policy         *authv1.PasswordPolicy
+		password       string
+		identity       []string
+		wantViolations []string
+	}{
+		{name: "default policy strong password", policy: nil, password: "1aAm!&25@*zgTY$pwL"},
+		{name: "default policy too short", policy: nil, password: "aB1!", wantViolations: []string{"must be at least 8 characters long"}},
+		{name: "default policy missing classes", policy: nil, password: "abcdefghijkl", wantViolations: []string{"must contain an uppercase letter", "must contain a digit"}},
+		{name: "common password", policy: &authv1.PasswordPolicy{}, password: "Password123", wantViolations: []string{"is too common"}},
+		{name: "tenant banned password", policy: &authv1.PasswordPolicy{BannedPasswords: []string{"Acme-Corp-2024"}}, password: "acme-corp-2024", wantViolations: []string{"is too common"}},
+		{name: "min length floored", policy: &authv1.PasswordPolicy{MinLength: 4}, password: "xY7#q", wantViolations: []string{"must be at least 8 characters long"}},
+		{name: "symbol required", policy: &authv1.PasswordPolicy{RequireSymbol: true}, password: "NoSymbols123", wantViolations: []string{"must contain a symbol"}},
+		{name: "contains username", policy: &authv1.PasswordPolicy{}, password: "johnsmith!Q9z", identity: []string{"JohnSmith", "john.smith@example.com"}, wantViolations: []string{"must not contain the username or email"}},
+		{name: "low entropy", policy: &authv1.PasswordPolicy{MinEntropyBits: 80}, password: "aaaaaaaaaa", wantViolations: []string{"is too easy to guess"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePassword(tc.policy, tc.password, tc.identity...)
+			if len(tc.wantViolations) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			appErr, ok := infra_error.AsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, infra_error.ValidationPasswordTooWeak.Code, appErr.Code)
+			assert.Equal(t, tc.wantViolations, appErr.Details["violations"])
+		})
+	}
+}
+
+func TestEffectivePasswordPolicy(t *testing.T) {
+	assert.Equal(t, DefaultPasswordPolicy(), EffectivePasswordPolicy(nil))
+	assert.Equal(t, DefaultPasswordPolicy(), EffectivePasswordPolicy(&authv1.Tenant{}))
+
+	policy := &authv1.PasswordPolicy{MinLength: 12, RequireSymbol: true}
+	tenant := &authv1.Tenant{Settings: &authv1.TenantSettings{PasswordPolicy: policy}}
+	assert.Same(t, policy, EffectivePasswordPolicy(tenant))
+}
+
+func TestIsPasswordExpired(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name       string
+		policy     *authv1.PasswordPolicy
+		lastChange time.Time
+		want       bool
+	}{
+		{name: "no max age", policy: &authv1.PasswordPolicy{}, lastChange: now.AddDate(-1, 0, 0), want: false},
+		{name: "nil policy", policy: nil, lastChange: now.AddDate(-1, 0, 0), want: false},
+		{name: "never changed", policy: &authv1.PasswordPolicy{MaxAgeDays: 30}, lastChange: time.Time{}, want: false},
+		{name: "within max age", policy: &authv1.PasswordPolicy{MaxAgeDays: 30}, lastChange: now.AddDate(0, 0, -10), want: false},
+		{name: "past max age", policy: &authv1.PasswordPolicy{MaxAgeDays: 30}, lastChange: now.AddDate(0, 0, -31), want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsPasswordExpired(tc.policy, tc.lastChange, now))
+		})
+	}
+}
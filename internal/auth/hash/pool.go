@@ -0,0 +1,114 @@
+package hash
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+const (
+	// How long a verification waits for a free worker before it is rejected
+	DefaultVerifyQueueTimeout = 2 * time.Second
+)
+
+var ErrVerifyQueueTimeout = errors.New("timed out waiting for a hash verification worker")
+
+// VerifyPool bounds the number of concurrent hash verifications.
+// bcrypt is CPU bound, so under a login storm callers queue for a worker and are rejected
+// after the queue timeout instead of starving every other RPC of CPU.
+type VerifyPool struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+	verify       func(obj, hash string) bool
+
+	waiting  atomic.Int64
+	rejected atomic.Int64
+}
+
+// NewVerifyPool creates a pool, concurrency defaults to the number of CPUs and queueTimeout to DefaultVerifyQueueTimeout
+func NewVerifyPool(concurrency int, queueTimeout time.Duration) *VerifyPool {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	if queueTimeout <= 0 {
+		queueTimeout = DefaultVerifyQueueTimeout
+	}
+	return &VerifyPool{
+		slots:        make(chan struct{}, concurrency),
+		queueTimeout: queueTimeout,
+		verify:       VerifyHash,
+	}
+}
+
+// NewVerifyPoolFromEnv reads HASH_VERIFY_CONCURRENCY and HASH_VERIFY_QUEUE_TIMEOUT
+func NewVerifyPoolFromEnv() *VerifyPool {
+	concurrency, _ := strconv.Atoi(os.Getenv("HASH_VERIFY_CONCURRENCY"))
+	queueTimeout, _ := time.ParseDuration(os.Getenv("HASH_VERIFY_QUEUE_TIMEOUT"))
+	return NewVerifyPool(concurrency, queueTimeout)
+}
+
+// Verify compares obj with hash on a pool worker.
+// Returns an unavailable error when no worker frees up within the queue timeout, and a timeout error when ctx is
+// done first, the caller gave up so the wait is not counted as rejected.
+func (p *VerifyPool) Verify(ctx context.Context, obj, hash string) (bool, error) {
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		p.waiting.Add(1)
+		timer := time.NewTimer(p.queueTimeout)
+		select {
+		case p.slots <- struct{}{}:
+			timer.Stop()
+			p.waiting.Add(-1)
+		case <-timer.C:
+			p.waiting.Add(-1)
+			p.rejected.Add(1)
+			return false, infra_error.Internal(infra_error.InternalServiceUnavailable, ErrVerifyQueueTimeout)
+		case <-ctx.Done():
+			timer.Stop()
+			p.waiting.Add(-1)
+			return false, infra_error.Internal(infra_error.InternalTimeout, ctx.Err())
+		}
+	}
+	defer func() { <-p.slots }()
+	return p.verify(obj, hash), nil
+}
+
+// Stats returns the number of verifications in progress, waiting for a worker and rejected so far
+func (p *VerifyPool) Stats() (int64, int64, int64) {
+	return int64(len(p.slots)), p.waiting.Load(), p.rejected.Load()
+}
+
+// Capacity returns the maximum number of concurrent verifications
+func (p *VerifyPool) Capacity() int {
+	return cap(p.slots)
+}
+
+var defaultVerifyPool atomic.Pointer[VerifyPool]
+
+func init() {
+	defaultVerifyPool.Store(NewVerifyPoolFromEnv())
+}
+
+// DefaultVerifyPool returns the pool used by Verify
+func DefaultVerifyPool() *VerifyPool {
+	return defaultVerifyPool.Load()
+}
+
+// SetDefaultVerifyPool replaces the pool used by Verify
+func SetDefaultVerifyPool(pool *VerifyPool) {
+	if pool != nil {
+		defaultVerifyPool.Store(pool)
+	}
+}
+
+// Verify compares obj with hash on the default pool
+func Verify(ctx context.Context, obj, hash string) (bool, error) {
+	return DefaultVerifyPool().Verify(ctx, obj, hash)
+}
@@ -0,0 +1,114 @@
+package hash
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPool_Verify(t *testing.T) {
+	pool := NewVerifyPool(2, time.Second)
+	valid, err := pool.Verify(context.Background(), "password", "$2a$10$YxNnIaPMWRFglNffZjPEv.mJoa63BZWObp2yjHC7P6/aG61C.mJyC")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = pool.Verify(context.Background(), "invalid", "$2a$10$YxNnIaPMWRFglNffZjPEv.mJoa63BZWObp2yjHC7P6/aG61C.mJyC")
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	inFlight, waiting, rejected := pool.Stats()
+	assert.Zero(t, inFlight)
+	assert.Zero(t, waiting)
+	assert.Zero(t, rejected)
+}
+
+func TestVerifyPool_QueueTimeout(t *testing.T) {
+	pool := NewVerifyPool(1, 20*time.Millisecond)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pool.verify = func(obj, hash string) bool {
+		if obj == "slow" {
+			close(started)
+			<-release
+		}
+		return true
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = pool.Verify(context.Background(), "slow", "")
+	}()
+	<-started
+
+	_, err := pool.Verify(context.Background(), "fast", "")
+	require.Error(t, err)
+	appErr, ok := infra_error.AsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, infra_error.InternalServiceUnavailable.Code, appErr.Code)
+	assert.ErrorIs(t, err, ErrVerifyQueueTimeout)
+
+	close(release)
+	wg.Wait()
+
+	valid, err := pool.Verify(context.Background(), "fast", "")
+	require.NoError(t, err)
+	assert.True(t, valid)
+	_, _, rejected := pool.Stats()
+	assert.Equal(t, int64(1), rejected)
+}
+
+func TestVerifyPool_ContextDone(t *testing.T) {
+	pool := NewVerifyPool(1, time.Minute)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pool.verify = func(obj, hash string) bool {
+		if obj == "slow" {
+			close(started)
+			<-release
+		}
+		return true
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = pool.Verify(context.Background(), "slow", "")
+	}()
+	<-started
+
+	// The caller giving up ends the wait long before the queue timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := pool.Verify(ctx, "fast", "")
+	require.Error(t, err)
+	appErr, ok := infra_error.AsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, infra_error.InternalTimeout.Code, appErr.Code)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	_, err = pool.Verify(canceled, "fast", "")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, waiting, rejected := pool.Stats()
+	assert.Zero(t, waiting)
+	assert.Zero(t, rejected, "a caller giving up is not rejected")
+
+	close(release)
+	wg.Wait()
+}
+
+func TestNewVerifyPool_Defaults(t *testing.T) {
+	pool := NewVerifyPool(0, 0)
+	assert.Positive(t, pool.Capacity())
+	assert.Equal(t, DefaultVerifyQueueTimeout, pool.queueTimeout)
+}
@@ -0,0 +1,69 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"strings"
+
+	"erp.localhost/internal/auth/hash"
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+const (
+	RecoveryCodesCount = 10
+	recoveryCodeLength = 10
+	// Unambiguous characters only, recovery codes are typed in by hand
+	recoveryCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// GenerateRecoveryCodes returns plain recovery codes and their hashes.
+// Only the hashes should be persisted, the plain codes are shown to the user once.
+func GenerateRecoveryCodes(count int) ([]string, []string, error) {
+	codes := make([]string, 0, count)
+	hashes := make([]string, 0, count)
+	for range count {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		hashed, err := hash.Hash(normalizeRecoveryCode(code))
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashed)
+	}
+	return codes, hashes, nil
+}
+
+// MatchRecoveryCode returns the index of the hash matching code, or -1 if none does
+func MatchRecoveryCode(code string, hashes []string) int {
+	code = normalizeRecoveryCode(code)
+	if code == "" {
+		return -1
+	}
+	for i, hashed := range hashes {
+		if hash.VerifyHash(code, hashed) {
+			return i
+		}
+	}
+	return -1
+}
+
+func randomRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	var sb strings.Builder
+	for i, b := range buf {
+		if i == recoveryCodeLength/2 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+	}
+	return sb.String(), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
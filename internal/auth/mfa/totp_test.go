@@ -0,0 +1,94 @@
+package mfa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B test vectors (SHA1, secret "12345678901234567890"), truncated to 6 digits
+func TestGenerateCode_RFCVectors(t *testing.T) {
+	secret := encoding.EncodeToString([]byte("12345678901234567890"))
+	testCases := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := GenerateCode(secret, time.Unix(tc.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, code)
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Now()
+	current, err := GenerateCode(secret, now)
+	require.NoError(t, err)
+	previous, err := GenerateCode(secret, now.Add(-period))
+	require.NoError(t, err)
+	stale, err := GenerateCode(secret, now.Add(-5*period))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		secret   string
+		code     string
+		expected bool
+	}{
+		{name: "current code", secret: secret, code: current, expected: true},
+		{name: "previous period within skew", secret: secret, code: previous, expected: true},
+		{name: "stale code", secret: secret, code: stale, expected: false},
+		{name: "wrong length", secret: secret, code: "12345", expected: false},
+		{name: "invalid secret", secret: "not-base32!", code: current, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ValidateCode(tc.secret, tc.code, now))
+		})
+	}
+}
+
+func TestMatchCode(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	previous, err := GenerateCode(secret, now.Add(-period))
+	require.NoError(t, err)
+
+	counter, ok := MatchCode(secret, previous, now)
+	require.True(t, ok)
+	assert.Equal(t, now.Unix()/int64(period.Seconds())-1, counter)
+	_, ok = MatchCode(secret, previous, now.Add(3*period))
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("erp.localhost", "john@acme.com", "JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "otpauth://totp/erp.localhost:john@acme.com?")
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=erp.localhost")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes(3)
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+	require.Len(t, hashes, 3)
+
+	assert.Equal(t, 1, MatchRecoveryCode(codes[1], hashes))
+	assert.Equal(t, 2, MatchRecoveryCode(" "+codes[2]+" ", hashes))
+	assert.Equal(t, -1, MatchRecoveryCode("AAAAA-AAAAA", hashes))
+	assert.Equal(t, -1, MatchRecoveryCode("", hashes))
+}
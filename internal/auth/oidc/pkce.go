@@ -0,0 +1,26 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+const randomValueBytes = 32
+
+// RandomValue returns a URL safe random string, used for state, nonce and PKCE verifiers
+func RandomValue() (string, error) {
+	buf := make([]byte, randomValueBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CodeChallenge returns the S256 PKCE challenge of verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,300 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/validation"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// Allowed clock skew between the provider and this service when checking id token times
+	clockSkew = time.Minute
+	// Provider responses larger than this are rejected
+	maxResponseBytes = 1 << 20
+)
+
+// idTokenAlgorithms are the id token signing algorithms accepted from providers
+var idTokenAlgorithms = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+var (
+	// ErrNonceMismatch is returned when the id token was not issued for the login attempt being completed
+	ErrNonceMismatch = errors.New("id token nonce does not match")
+	// ErrMissingIDToken is returned when the token endpoint response has no id token
+	ErrMissingIDToken = errors.New("token response has no id_token")
+)
+
+// Config is the client registration of this service at an OIDC provider
+type Config struct {
+	// Name identifies the provider in tenant settings and linked identities, e.g. "google"
+	Name         string `validate:"required"`
+	Issuer       string `validate:"required"`
+	ClientID     string `validate:"required"`
+	ClientSecret string
+	RedirectURL  string `validate:"required"`
+	Scopes       []string
+}
+
+// Identity is the verified end user returned by a provider
+type Identity struct {
+	Provider      string
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+}
+
+// metadata is the subset of the provider discovery document used by the client
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OIDC relying party for a single provider.
+// Discovery runs on first use so the service starts even when a provider is unreachable.
+type Provider struct {
+	config     Config
+	httpClient *http.Client
+	keys       *keySet
+
+	mu       sync.Mutex
+	metadata *metadata
+}
+
+// NewProvider creates a provider client, http.DefaultClient is used when httpClient is nil
+func NewProvider(config Config, httpClient *http.Client) (*Provider, error) {
+	if err := validation.Struct(config, false); err != nil {
+		return nil, err
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Provider{
+		config:     config,
+		httpClient: httpClient,
+		keys:       newKeySet(httpClient),
+	}, nil
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthCodeURL returns the provider URL the user is sent to for login.
+// codeChallenge is the S256 PKCE challenge of the verifier later passed to Exchange.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return meta.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange redeems an authorization code at the token endpoint and returns the identity of its verified id token
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	if code == "" || codeVerifier == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "code", "code_verifier")
+	}
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalExternalServiceError, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	var tokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := doJSON(p.httpClient, req, &tokenResponse)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		// The code was rejected, e.g. expired or already redeemed
+		if tokenResponse.Error != "" {
+			return nil, infra_error.Auth(infra_error.AuthInvalidCredentials).
+				WithError(fmt.Errorf("token endpoint: %s %s", tokenResponse.Error, tokenResponse.ErrorDescription))
+		}
+		return nil, infra_error.Internal(infra_error.InternalExternalServiceError, fmt.Errorf("token endpoint returned status %d", status))
+	}
+	if tokenResponse.IDToken == "" {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(ErrMissingIDToken)
+	}
+	return p.VerifyIDToken(ctx, tokenResponse.IDToken, nonce)
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of an id token
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Identity, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &idTokenClaims{}
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(idTokenAlgorithms),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.key(ctx, meta.JWKSURI, kid)
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, infra_error.Auth(infra_error.AuthTokenExpired).WithError(err)
+		}
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(err)
+	}
+	// With several audiences the token must have been issued to this client
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("id token azp does not match the client"))
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(ErrNonceMismatch)
+	}
+	if claims.Subject == "" {
+		return nil, infra_error.Auth(infra_error.AuthTokenInvalid).WithError(errors.New("id token has no subject"))
+	}
+
+	return &Identity{
+		Provider:      p.config.Name,
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+	}, nil
+}
+
+// discover loads the provider metadata once, failed attempts are retried on the next call
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.config.Issuer, "/")+discoveryPath, nil)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalExternalServiceError, err)
+	}
+	meta := &metadata{}
+	status, err := doJSON(p.httpClient, req, meta)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, infra_error.Internal(infra_error.InternalExternalServiceError, fmt.Errorf("discovery returned status %d", status))
+	}
+	// The issuer must match exactly, otherwise tokens from another issuer could be accepted
+	if meta.Issuer != p.config.Issuer {
+		return nil, infra_error.Internal(infra_error.InternalConfigError, fmt.Errorf("discovery issuer %q does not match configured issuer %q", meta.Issuer, p.config.Issuer))
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, infra_error.Internal(infra_error.InternalExternalServiceError, errors.New("discovery document is missing endpoints"))
+	}
+	p.metadata = meta
+	return meta, nil
+}
+
+// doJSON sends req and decodes the JSON body into out, the status is returned for the caller to check
+func doJSON(client *http.Client, req *http.Request, out any) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, infra_error.Internal(infra_error.InternalExternalServiceError, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return 0, infra_error.Internal(infra_error.InternalExternalServiceError, err)
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, out); err != nil && resp.StatusCode == http.StatusOK {
+			return 0, infra_error.Internal(infra_error.InternalExternalServiceError, err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce           string    `json:"nonce"`
+	AuthorizedParty string    `json:"azp"`
+	Email           string    `json:"email"`
+	EmailVerified   boolClaim `json:"email_verified"`
+	Name            string    `json:"name"`
+	GivenName       string    `json:"given_name"`
+	FamilyName      string    `json:"family_name"`
+}
+
+// boolClaim accepts both JSON booleans and the "true"/"false" strings some providers send
+type boolClaim bool
+
+func (b *boolClaim) UnmarshalJSON(data []byte) error {
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*b = boolClaim(value)
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*b = boolClaim(strings.EqualFold(text, "true"))
+	return nil
+}
@@ -0,0 +1,302 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "erp-client"
+	testClientSecret = "erp-secret"
+	testRedirectURL  = "https://erp.localhost/oidc/callback"
+	testKeyID        = "key-1"
+)
+
+// fakeIssuer is a minimal OIDC provider serving discovery, jwks and a token endpoint
+type fakeIssuer struct {
+	server     *httptest.Server
+	key        *rsa.PrivateKey
+	keyID      string
+	idToken    string
+	tokenCalls int
+	lastForm   url.Values
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer := &fakeIssuer{key: key, keyID: testKeyID}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": issuer.keyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(issuer.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(issuer.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		issuer.tokenCalls++
+		_ = r.ParseForm()
+		issuer.lastForm = r.PostForm
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != testClientID || clientSecret != testClientSecret || r.PostForm.Get("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": issuer.idToken, "access_token": "provider-token"})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (f *fakeIssuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = f.keyID
+	signed, err := token.SignedString(f.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (f *fakeIssuer) claims(nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            f.server.URL,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "Jane@Example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+		"given_name":     "Jane",
+		"family_name":    "Doe",
+	}
+}
+
+func newTestProvider(t *testing.T, issuer *fakeIssuer) *Provider {
+	t.Helper()
+	provider, err := NewProvider(Config{
+		Name:         "test",
+		Issuer:       issuer.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+	}, issuer.server.Client())
+	require.NoError(t, err)
+	return provider
+}
+
+func TestNewProvider(t *testing.T) {
+	_, err := NewProvider(Config{Name: "test"}, nil)
+	require.Error(t, err)
+
+	provider, err := NewProvider(Config{Name: "test", Issuer: "https://issuer", ClientID: "client", RedirectURL: testRedirectURL}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "test", provider.Name())
+	assert.Equal(t, []string{"openid", "email", "profile"}, provider.config.Scopes)
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	provider := newTestProvider(t, issuer)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", CodeChallenge("verifier"))
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, issuer.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	query := parsed.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testClientID, query.Get("client_id"))
+	assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "state-1", query.Get("state"))
+	assert.Equal(t, "nonce-1", query.Get("nonce"))
+	assert.Equal(t, CodeChallenge("verifier"), query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		nonce       string
+		claims      func(issuer *fakeIssuer, claims jwt.MapClaims)
+		wantErrCode string
+	}{
+		{
+			name:  "valid code",
+			code:  "valid-code",
+			nonce: "nonce-1",
+		},
+		{
+			name:        "rejected code",
+			code:        "used-code",
+			nonce:       "nonce-1",
+			wantErrCode: infra_error.AuthInvalidCredentials.Code,
+		},
+		{
+			name:        "nonce mismatch",
+			code:        "valid-code",
+			nonce:       "other-nonce",
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+		{
+			name:  "other audience",
+			code:  "valid-code",
+			nonce: "nonce-1",
+			claims: func(_ *fakeIssuer, claims jwt.MapClaims) {
+				claims["aud"] = "other-client"
+			},
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+		{
+			name:  "other issuer",
+			code:  "valid-code",
+			nonce: "nonce-1",
+			claims: func(_ *fakeIssuer, claims jwt.MapClaims) {
+				claims["iss"] = "https://evil.example.com"
+			},
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+		{
+			name:  "expired token",
+			code:  "valid-code",
+			nonce: "nonce-1",
+			claims: func(_ *fakeIssuer, claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			},
+			wantErrCode: infra_error.AuthTokenExpired.Code,
+		},
+		{
+			name:  "multiple audiences without azp",
+			code:  "valid-code",
+			nonce: "nonce-1",
+			claims: func(_ *fakeIssuer, claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "other-client"}
+			},
+			wantErrCode: infra_error.AuthTokenInvalid.Code,
+		},
+		{
+			name:  "email verified as string",
+			code:  "valid-code",
+			nonce: "nonce-1",
+			claims: func(_ *fakeIssuer, claims jwt.MapClaims) {
+				claims["email_verified"] = "true"
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issuer := newFakeIssuer(t)
+			provider := newTestProvider(t, issuer)
+			claims := issuer.claims("nonce-1")
+			if tc.claims != nil {
+				tc.claims(issuer, claims)
+			}
+			issuer.idToken = issuer.sign(t, claims)
+
+			identity, err := provider.Exchange(context.Background(), tc.code, "verifier-1", tc.nonce)
+			if tc.wantErrCode != "" {
+				require.Error(t, err)
+				appErr, ok := err.(*infra_error.AppError)
+				require.True(t, ok)
+				assert.Equal(t, tc.wantErrCode, appErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "verifier-1", issuer.lastForm.Get("code_verifier"))
+			assert.Equal(t, testRedirectURL, issuer.lastForm.Get("redirect_uri"))
+			assert.Equal(t, &Identity{
+				Provider:      "test",
+				Issuer:        issuer.server.URL,
+				Subject:       "subject-1",
+				Email:         "jane@example.com",
+				EmailVerified: true,
+				Name:          "Jane Doe",
+				GivenName:     "Jane",
+				FamilyName:    "Doe",
+			}, identity)
+		})
+	}
+}
+
+func TestProvider_VerifyIDToken_KeyRotation(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	provider := newTestProvider(t, issuer)
+	ctx := context.Background()
+
+	_, err := provider.VerifyIDToken(ctx, issuer.sign(t, issuer.claims("nonce-1")), "nonce-1")
+	require.NoError(t, err)
+
+	// A new key id is fetched once the refresh interval passed
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer.key, issuer.keyID = rotated, "key-2"
+	token := issuer.sign(t, issuer.claims("nonce-1"))
+
+	_, err = provider.VerifyIDToken(ctx, token, "nonce-1")
+	require.Error(t, err)
+
+	provider.keys.fetchedAt = time.Now().Add(-2 * jwksRefreshInterval)
+	_, err = provider.VerifyIDToken(ctx, token, "nonce-1")
+	require.NoError(t, err)
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	provider, err := NewProvider(Config{
+		Name:        "test",
+		Issuer:      issuer.server.URL + "/",
+		ClientID:    testClientID,
+		RedirectURL: testRedirectURL,
+	}, issuer.server.Client())
+	require.NoError(t, err)
+
+	_, err = provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	require.Error(t, err)
+	assert.Equal(t, 0, issuer.tokenCalls)
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// base64url encoded SHA-256 without padding
+	assert.Equal(t, "XDes6Zk92nj3vEJS0qh4KIo0xRykQAxbglu7QyAZeM4", CodeChallenge("dBjftJeZ4CVP-mB92K7uVDUoWyjJaJEeWzvWD4Wb-xw"))
+
+	first, err := RandomValue()
+	require.NoError(t, err)
+	second, err := RandomValue()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, first, base64.RawURLEncoding.EncodedLen(randomValueBytes))
+}
@@ -0,0 +1,394 @@
+package rbac
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// MaxConditionLength bounds the length of a permission condition
+const MaxConditionLength = 512
+
+// Attributes of the subject set by the verification, the attributes of a request cannot override them
+const (
+	SubjectUserIDAttribute   = "subject.user_id"
+	SubjectTenantIDAttribute = "subject.tenant_id"
+)
+
+// Condition is a parsed condition expression of a permission. The expressions compare attributes of the request
+// and literals with ==, !=, <, <=, > and >=, and combine the comparisons with &&, || and !, e.g.
+//
+//	owner == subject.user_id
+//	amount < 1000 && (status == 'draft' || status == "pending")
+//
+// Attributes are names of letters, digits, _ and ., literals are quoted strings, numbers, true and false. Values
+// are compared as numbers when both sides are numbers, as strings otherwise, only numbers are ordered
+type Condition struct {
+	expression string
+	root       conditionNode
+}
+
+// ParseCondition parses a condition expression, an empty expression has no condition and returns nil
+func ParseCondition(expression string) (*Condition, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, nil
+	}
+	if len(expression) > MaxConditionLength {
+		return nil, conditionError(fmt.Errorf("condition is longer than %d characters", MaxConditionLength))
+	}
+	tokens, err := tokenizeCondition(expression)
+	if err != nil {
+		return nil, conditionError(err)
+	}
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, conditionError(err)
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, conditionError(fmt.Errorf("unexpected %q at %d", p.peek().text, p.peek().pos))
+	}
+	return &Condition{expression: expression, root: root}, nil
+}
+
+// ValidateCondition returns a validation error when expression is not a valid condition
+func ValidateCondition(expression string) error {
+	_, err := ParseCondition(expression)
+	return err
+}
+
+// String returns the expression of the condition
+func (c *Condition) String() string {
+	return c.expression
+}
+
+// Evaluate evaluates the condition with attributes, a nil condition holds. A missing attribute fails the
+// evaluation, as does ordering values other than numbers
+func (c *Condition) Evaluate(attributes map[string]string) (bool, error) {
+	if c == nil {
+		return true, nil
+	}
+	return c.root.eval(attributes)
+}
+
+func conditionError(err error) error {
+	return infra_error.Validation(infra_error.ValidationInvalidValue, "condition").WithError(err)
+}
+
+/* Tokenizer */
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type conditionToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// conditionOperators are the operators of the expressions, the two characters ones first
+var conditionOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!"}
+
+func tokenizeCondition(expression string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	for i := 0; i < len(expression); {
+		c := rune(expression[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, conditionToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, conditionToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(expression[i+1:], expression[i])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenString, text: expression[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(expression) && unicode.IsDigit(rune(expression[i+1]))):
+			start := i
+			i++
+			for i < len(expression) && (unicode.IsDigit(rune(expression[i])) || expression[i] == '.') {
+				i++
+			}
+			if _, err := strconv.ParseFloat(expression[start:i], 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q at %d", expression[start:i], start)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenNumber, text: expression[start:i], pos: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(expression) && isAttributeChar(rune(expression[i])) {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenIdent, text: expression[start:i], pos: start})
+		default:
+			operator := ""
+			for _, op := range conditionOperators {
+				if strings.HasPrefix(expression[i:], op) {
+					operator = op
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected %q at %d", expression[i], i)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenOperator, text: operator, pos: i})
+			i += len(operator)
+		}
+	}
+	return append(tokens, conditionToken{kind: tokenEOF, pos: len(expression)}), nil
+}
+
+func isAttributeChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.'
+}
+
+/* Parser */
+
+// conditionParser is a recursive descent parser of
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand ]
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() conditionToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+func (p *conditionParser) isOperator(ops ...string) bool {
+	token := p.peek()
+	if token.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if token.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	if p.isOperator("!") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, fmt.Errorf("missing ) at %d", p.peek().pos)
+		}
+		p.next()
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("==", "!=", "<", "<=", ">", ">=") {
+		return &truthNode{operand: left}, nil
+	}
+	op := p.next().text
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return &comparisonNode{op: op, left: left, right: right}, nil
+}
+
+func (p *conditionParser) parseOperand() (conditionOperand, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenString, tokenNumber:
+		return conditionOperand{value: token.text}, nil
+	case tokenIdent:
+		if token.text == "true" || token.text == "false" {
+			return conditionOperand{value: token.text}, nil
+		}
+		return conditionOperand{attribute: token.text}, nil
+	case tokenEOF:
+		return conditionOperand{}, fmt.Errorf("unexpected end of condition")
+	default:
+		return conditionOperand{}, fmt.Errorf("unexpected %q at %d", token.text, token.pos)
+	}
+}
+
+/* Evaluation */
+
+type conditionNode interface {
+	eval(attributes map[string]string) (bool, error)
+}
+
+// conditionOperand is an attribute when attribute is set, a literal value otherwise
+type conditionOperand struct {
+	attribute string
+	value     string
+}
+
+func (o conditionOperand) resolve(attributes map[string]string) (string, error) {
+	if o.attribute == "" {
+		return o.value, nil
+	}
+	value, ok := attributes[o.attribute]
+	if !ok {
+		return "", fmt.Errorf("missing attribute %q", o.attribute)
+	}
+	return value, nil
+}
+
+type logicalNode struct {
+	or          bool
+	left, right conditionNode
+}
+
+func (n *logicalNode) eval(attributes map[string]string) (bool, error) {
+	left, err := n.left.eval(attributes)
+	if err != nil {
+		return false, err
+	}
+	if left == n.or {
+		// true || ... and false && ... are decided by the left side
+		return left, nil
+	}
+	return n.right.eval(attributes)
+}
+
+type notNode struct {
+	operand conditionNode
+}
+
+func (n *notNode) eval(attributes map[string]string) (bool, error) {
+	value, err := n.operand.eval(attributes)
+	return !value && err == nil, err
+}
+
+// truthNode holds when its operand is true
+type truthNode struct {
+	operand conditionOperand
+}
+
+func (n *truthNode) eval(attributes map[string]string) (bool, error) {
+	value, err := n.operand.resolve(attributes)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+type comparisonNode struct {
+	op          string
+	left, right conditionOperand
+}
+
+func (n *comparisonNode) eval(attributes map[string]string) (bool, error) {
+	left, err := n.left.resolve(attributes)
+	if err != nil {
+		return false, err
+	}
+	right, err := n.right.resolve(attributes)
+	if err != nil {
+		return false, err
+	}
+	leftNumber, leftErr := strconv.ParseFloat(left, 64)
+	rightNumber, rightErr := strconv.ParseFloat(right, 64)
+	numbers := leftErr == nil && rightErr == nil
+	switch n.op {
+	case "==":
+		if numbers {
+			return leftNumber == rightNumber, nil
+		}
+		return left == right, nil
+	case "!=":
+		if numbers {
+			return leftNumber != rightNumber, nil
+		}
+		return left != right, nil
+	}
+	if !numbers {
+		return false, fmt.Errorf("%q and %q are not both numbers", left, right)
+	}
+	switch n.op {
+	case "<":
+		return leftNumber < rightNumber, nil
+	case "<=":
+		return leftNumber <= rightNumber, nil
+	case ">":
+		return leftNumber > rightNumber, nil
+	default:
+		return leftNumber >= rightNumber, nil
+	}
+}
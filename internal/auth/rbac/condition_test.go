@@ -0,0 +1,105 @@
+package rbac
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCondition(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{name: "empty", expression: "  "},
+		{name: "comparison", expression: "owner == subject.user_id"},
+		{name: "number", expression: "amount < 1000.5"},
+		{name: "negative number", expression: "balance >= -10"},
+		{name: "logical", expression: "amount < 1000 && (status == 'draft' || status == \"pending\")"},
+		{name: "negation", expression: "!archived"},
+		{name: "missing operand", expression: "owner ==", wantErr: true},
+		{name: "missing paren", expression: "(owner == subject.user_id", wantErr: true},
+		{name: "unterminated string", expression: "status == 'draft", wantErr: true},
+		{name: "unknown operator", expression: "amount = 10", wantErr: true},
+		{name: "trailing tokens", expression: "owner == subject.user_id status", wantErr: true},
+		{name: "too long", expression: "a == '" + strings.Repeat("x", MaxConditionLength) + "'", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCondition(tc.expression)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCondition_Evaluate(t *testing.T) {
+	attributes := map[string]string{
+		SubjectUserIDAttribute: "user-123",
+		"owner":                "user-123",
+		"amount":               "250",
+		"status":               "draft",
+		"archived":             "false",
+	}
+
+	testCases := []struct {
+		name       string
+		expression string
+		expected   bool
+		wantErr    bool
+	}{
+		{name: "owner", expression: "owner == subject.user_id", expected: true},
+		{name: "not owner", expression: "owner != subject.user_id", expected: false},
+		{name: "below limit", expression: "amount < 1000", expected: true},
+		{name: "above limit", expression: "amount > 1000", expected: false},
+		{name: "numbers compared as numbers", expression: "amount == 250.0", expected: true},
+		{name: "and", expression: "amount <= 250 && status == 'draft'", expected: true},
+		{name: "or", expression: "status == 'sent' || owner == subject.user_id", expected: true},
+		{name: "precedence", expression: "status == 'sent' && amount > 0 || archived == false", expected: true},
+		{name: "grouping", expression: "status == 'sent' && (amount > 0 || archived == false)", expected: false},
+		{name: "negation", expression: "!archived", expected: true},
+		{name: "short circuit skips missing attribute", expression: "owner == subject.user_id || region == 'eu'", expected: true},
+		{name: "missing attribute", expression: "region == 'eu'", wantErr: true},
+		{name: "ordering strings", expression: "status < 'sent'", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition, err := ParseCondition(tc.expression)
+			require.NoError(t, err)
+			held, err := condition.Evaluate(attributes)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.False(t, held)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, held)
+		})
+	}
+}
+
+func TestCondition_EvaluateNil(t *testing.T) {
+	condition, err := ParseCondition("")
+	require.NoError(t, err)
+	held, err := condition.Evaluate(nil)
+	require.NoError(t, err)
+	assert.True(t, held)
+}
+
+func TestSubjectAttributes(t *testing.T) {
+	attributes := subjectAttributes("tenant-123", "user-123", map[string]string{
+		"owner":                "user-456",
+		SubjectUserIDAttribute: "user-456",
+	})
+	assert.Equal(t, "user-456", attributes["owner"])
+	assert.Equal(t, "user-123", attributes[SubjectUserIDAttribute])
+	assert.Equal(t, "tenant-123", attributes[SubjectTenantIDAttribute])
+}
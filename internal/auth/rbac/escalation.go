@@ -0,0 +1,131 @@
+package rbac
+
+import (
+	"context"
+
+	infra_error "erp.localhost/internal/infra/error"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// CheckProtected returns a protected resource error unless the user is a system admin, only the system admins
+// change the protected roles and permissions
+func (vm *VerificationManager) CheckProtected(ctx context.Context, tenantID, userID string) error {
+	if err := vm.IsSystemAdmin(ctx, tenantID, userID); err != nil {
+		vm.logger.Warn("protected resource change denied", "tenant_id", tenantID, "user_id", userID)
+		return infra_error.Auth(infra_error.AuthProtectedResource)
+	}
+	return nil
+}
+
+// CheckGrantablePermissions returns a privilege escalation error unless the user holds every permission of
+// permissionIDs on targetTenantID, users cannot grant permissions they do not hold. API keys grant nothing
+func (vm *VerificationManager) CheckGrantablePermissions(ctx context.Context, tenantID, userID, targetTenantID string, permissionIDs []string) error {
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+	granted, err := vm.permissionHandler.GetPermissionsByIDsAggregation(ctx, targetTenantID, permissionIDs, nil)
+	if err != nil {
+		return err
+	}
+	return vm.CheckGrantable(ctx, tenantID, userID, targetTenantID, granted...)
+}
+
+// CheckGrantable returns a privilege escalation error unless the user holds every permission of granted on
+// targetTenantID
+func (vm *VerificationManager) CheckGrantable(ctx context.Context, tenantID, userID, targetTenantID string, granted ...*authv1.Permission) error {
+	if len(granted) == 0 {
+		return nil
+	}
+	access, err := vm.grantingAccess(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range granted {
+		if !vm.holdsPermission(access, tenantID, targetTenantID, permission) {
+			vm.logger.Warn("privilege escalation denied", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID, "permission", permission.GetPermissionString())
+			return infra_error.Auth(infra_error.AuthPrivilegeEscalation)
+		}
+	}
+	return nil
+}
+
+// CheckGrantableRole returns a privilege escalation error unless the user holds every permission role grants
+// beyond previous, the version of the role being updated or nil. The admin roles grant every permission
+func (vm *VerificationManager) CheckGrantableRole(ctx context.Context, tenantID, userID, targetTenantID string, role, previous *authv1.Role) error {
+	if isAdminRole(role.GetName()) && (previous == nil || !isAdminRole(previous.GetName())) {
+		wildcard := &authv1.Permission{PermissionString: permissions.Wildcard}
+		if err := vm.CheckGrantable(ctx, tenantID, userID, targetTenantID, wildcard); err != nil {
+			return err
+		}
+	}
+	added, err := vm.GetRolePermissionIDs(ctx, role)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		previousIDs, err := vm.GetRolePermissionIDs(ctx, previous)
+		if err != nil {
+			return err
+		}
+		held := make(map[string]bool)
+		for _, permissionID := range previousIDs {
+			held[permissionID] = true
+		}
+		var newIDs []string
+		for _, permissionID := range added {
+			if !held[permissionID] {
+				newIDs = append(newIDs, permissionID)
+			}
+		}
+		added = newIDs
+	}
+	return vm.CheckGrantablePermissions(ctx, tenantID, userID, targetTenantID, added)
+}
+
+// CheckGrantableRoles returns a privilege escalation error unless the user holds every permission the roles of
+// roleIDs grant on targetTenantID
+func (vm *VerificationManager) CheckGrantableRoles(ctx context.Context, tenantID, userID, targetTenantID string, roleIDs []string) error {
+	for _, roleID := range roleIDs {
+		role, err := vm.roleHandler.GetRoleByID(ctx, targetTenantID, roleID)
+		if err != nil {
+			return err
+		}
+		if err := vm.CheckGrantableRole(ctx, tenantID, userID, targetTenantID, role, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantingAccess returns the access a principal grants permissions with
+func (vm *VerificationManager) grantingAccess(ctx context.Context, tenantID, userID string) (*userAccess, error) {
+	if _, ok := model_auth.APIKeyIDFromPrincipal(userID); ok {
+		vm.logger.Warn("privilege escalation denied for api key", "tenant_id", tenantID, "principal", userID)
+		return nil, infra_error.Auth(infra_error.AuthPrivilegeEscalation)
+	}
+	return vm.getUserAccess(ctx, tenantID, userID)
+}
+
+// holdsPermission reports whether access of a user of tenantID holds permission on targetTenantID. The wildcard
+// holds every permission in the tenant of the user, or in every tenant for the system tenant users. A conditional
+// permission only holds the same permission under the same condition
+func (vm *VerificationManager) holdsPermission(access *userAccess, tenantID, targetTenantID string, permission *authv1.Permission) bool {
+	if tenantID != targetTenantID && !vm.IsSystemTenantUser(tenantID) {
+		return false
+	}
+	if hasWildcard(access.permissions) {
+		return true
+	}
+	if !access.permissions[permission.GetPermissionString()] {
+		return false
+	}
+	condition, ok := access.conditions[permission.GetPermissionString()]
+	return !ok || condition == permission.GetCondition()
+}
+
+// isAdminRole reports whether the role named name grants every permission of its tenant
+func isAdminRole(name string) bool {
+	return name == model_auth.RoleTenantAdmin || name == model_auth.RoleSystemAdmin
+}
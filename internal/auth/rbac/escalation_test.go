@@ -0,0 +1,109 @@
+package rbac
+
+import (
+	"testing"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationManager_holdsPermission(t *testing.T) {
+	vm := &VerificationManager{systemTenantID: "system", logger: logger.NewBaseLogger(shared.ModuleAuth)}
+	userRead := &authv1.Permission{PermissionString: "user:read"}
+	ownUserRead := &authv1.Permission{PermissionString: "user:read", Condition: "owner == subject.user_id"}
+	userDelete := &authv1.Permission{PermissionString: "user:delete"}
+	wildcard := &userAccess{permissions: map[string]bool{permissions.Wildcard: true}}
+
+	testCases := []struct {
+		name           string
+		access         *userAccess
+		tenantID       string
+		targetTenantID string
+		permission     *authv1.Permission
+		expected       bool
+	}{
+		{
+			name:           "held",
+			access:         &userAccess{permissions: map[string]bool{"user:read": true}},
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-123",
+			permission:     userRead,
+			expected:       true,
+		},
+		{
+			name:           "not held",
+			access:         &userAccess{permissions: map[string]bool{"user:read": true}},
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-123",
+			permission:     userDelete,
+		},
+		{
+			name:           "revoked",
+			access:         &userAccess{permissions: map[string]bool{"user:delete": false}},
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-123",
+			permission:     userDelete,
+		},
+		{
+			name:           "unconditional held as conditional",
+			access:         &userAccess{permissions: map[string]bool{"user:read": true}},
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-123",
+			permission:     ownUserRead,
+			expected:       true,
+		},
+		{
+			name: "conditional held under the same condition",
+			access: &userAccess{
+				permissions: map[string]bool{"user:read": true},
+				conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+			},
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-123",
+			permission:     ownUserRead,
+			expected:       true,
+		},
+		{
+			name: "conditional not held as unconditional",
+			access: &userAccess{
+				permissions: map[string]bool{"user:read": true},
+				conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+			},
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-123",
+			permission:     userRead,
+		},
+		{
+			name:           "wildcard in own tenant",
+			access:         wildcard,
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-123",
+			permission:     userDelete,
+			expected:       true,
+		},
+		{
+			name:           "wildcard in another tenant",
+			access:         wildcard,
+			tenantID:       "tenant-123",
+			targetTenantID: "tenant-456",
+			permission:     userDelete,
+		},
+		{
+			name:           "system tenant wildcard in another tenant",
+			access:         wildcard,
+			tenantID:       "system",
+			targetTenantID: "tenant-456",
+			permission:     &authv1.Permission{PermissionString: permissions.Wildcard},
+			expected:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, vm.holdsPermission(tc.access, tc.tenantID, tc.targetTenantID, tc.permission))
+		})
+	}
+}
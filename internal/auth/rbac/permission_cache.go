@@ -0,0 +1,123 @@
+package rbac
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	permissionCacheRequests = metrics.NewCounter("rbac_permission_cache_requests_total",
+		"Total number of user permission lookups served by the permission cache, by result (hit or miss).", "result")
+	permissionCacheInvalidations = metrics.NewCounter("rbac_permission_cache_invalidations_total",
+		"Total number of permission cache invalidations, by scope (user or tenant).", "scope")
+)
+
+// PermissionCacheConfig holds the settings of the permission cache, loaded with infra_config.Load
+type PermissionCacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"PERMISSION_CACHE_ENABLED" default:"true"`
+	// TTL bounds how long a change missed by the invalidation is served
+	TTL time.Duration `yaml:"ttl" env:"PERMISSION_CACHE_TTL" default:"30s"`
+}
+
+// PermissionCache keeps the resolved permissions of the users in Redis. Entries expire after the TTL and are
+// removed when a user, role or permission they depend on changes. A nil PermissionCache caches nothing
+// Key pattern: permissions:{tenant_id}:{user_id}
+type PermissionCache struct {
+	handler redis.KeyHandler[authv1_cache.UserPermissionsCache]
+	ttl     time.Duration
+	logger  logger.Logger
+}
+
+func NewPermissionCache(handler redis.KeyHandler[authv1_cache.UserPermissionsCache], ttl time.Duration, logger logger.Logger) *PermissionCache {
+	return &PermissionCache{
+		handler: handler,
+		ttl:     ttl,
+		logger:  logger,
+	}
+}
+
+// NewRedisPermissionCache creates a permission cache connected with the Redis configuration from the environment
+func NewRedisPermissionCache(config *PermissionCacheConfig, logger logger.Logger) (*PermissionCache, error) {
+	handler, err := redis.NewBaseKeyHandler[authv1_cache.UserPermissionsCache](model_redis.RedisKeyUserPermissions, logger)
+	if err != nil {
+		return nil, err
+	}
+	return NewPermissionCache(handler, config.TTL, logger), nil
+}
+
+// Get returns the cached access of the user, ok is false on a miss
+func (c *PermissionCache) Get(ctx context.Context, tenantID, userID string) (*userAccess, bool) {
+	if c == nil {
+		return nil, false
+	}
+	cached, err := c.handler.GetOne(ctx, tenantID, userID)
+	if err != nil || cached == nil {
+		permissionCacheRequests.Inc("miss")
+		return nil, false
+	}
+	permissionCacheRequests.Inc("hit")
+	access := &userAccess{
+		permissions: make(map[string]bool, len(cached.GetPermissions())+len(cached.GetDeniedPermissions())),
+		conditions:  cached.GetConditions(),
+	}
+	for _, permission := range cached.GetPermissions() {
+		access.permissions[permission] = true
+	}
+	for _, permission := range cached.GetDeniedPermissions() {
+		access.permissions[permission] = false
+	}
+	return access, true
+}
+
+// Set caches the resolved access of the user, a failure only costs the next lookup
+func (c *PermissionCache) Set(ctx context.Context, tenantID, userID string, access *userAccess) {
+	if c == nil {
+		return
+	}
+	cached := &authv1_cache.UserPermissionsCache{
+		UserId:     userID,
+		TenantId:   tenantID,
+		CachedAt:   timestamppb.Now(),
+		Conditions: access.conditions,
+	}
+	for permission, granted := range access.permissions {
+		if granted {
+			cached.Permissions = append(cached.Permissions, permission)
+		} else {
+			cached.DeniedPermissions = append(cached.DeniedPermissions, permission)
+		}
+	}
+	if err := c.handler.Set(ctx, tenantID, userID, cached, map[string]any{"ttl": c.ttl}); err != nil {
+		c.logger.Warn("failed to cache user permissions", "tenant_id", tenantID, "user_id", userID, "error", err)
+	}
+}
+
+// InvalidateUser removes the cached permissions of the user
+func (c *PermissionCache) InvalidateUser(ctx context.Context, tenantID, userID string) {
+	if c == nil {
+		return
+	}
+	permissionCacheInvalidations.Inc("user")
+	if err := c.handler.Delete(ctx, tenantID, userID); err != nil {
+		c.logger.Warn("failed to invalidate user permissions", "tenant_id", tenantID, "user_id", userID, "error", err)
+	}
+}
+
+// InvalidateTenant removes the cached permissions of every user of the tenant, a role or permission change
+// affects all the users holding it
+func (c *PermissionCache) InvalidateTenant(ctx context.Context, tenantID string) {
+	if c == nil || tenantID == "" {
+		return
+	}
+	permissionCacheInvalidations.Inc("tenant")
+	if _, err := c.handler.DeleteByPattern(ctx, tenantID, ""); err != nil {
+		c.logger.Warn("failed to invalidate tenant permissions", "tenant_id", tenantID, "error", err)
+	}
+}
@@ -0,0 +1,107 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock_redis "erp.localhost/internal/infra/db/redis/mock"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPermissionCache_Get(t *testing.T) {
+	testCases := []struct {
+		name           string
+		cached         *authv1_cache.UserPermissionsCache
+		returnGetError error
+		expected       *userAccess
+		expectedOK     bool
+	}{
+		{
+			name: "hit",
+			cached: &authv1_cache.UserPermissionsCache{
+				UserId:            "user-123",
+				TenantId:          "tenant-123",
+				Permissions:       []string{"user:read", "role:read"},
+				DeniedPermissions: []string{"user:delete"},
+				Conditions:        map[string]string{"user:read": "owner == subject.user_id"},
+			},
+			expected: &userAccess{
+				permissions: map[string]bool{"user:read": true, "role:read": true, "user:delete": false},
+				conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+			},
+			expectedOK: true,
+		},
+		{
+			name:           "miss",
+			returnGetError: errors.New("key not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.UserPermissionsCache](ctrl)
+			mockHandler.EXPECT().GetOne(gomock.Any(), "tenant-123", "user-123").Return(tc.cached, tc.returnGetError).Times(1)
+			cache := NewPermissionCache(mockHandler, time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+
+			access, ok := cache.Get(context.Background(), "tenant-123", "user-123")
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expected, access)
+		})
+	}
+}
+
+func TestPermissionCache_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.UserPermissionsCache](ctrl)
+	var stored *authv1_cache.UserPermissionsCache
+	mockHandler.EXPECT().Set(gomock.Any(), "tenant-123", "user-123", gomock.Any(), map[string]any{"ttl": 30 * time.Second}).
+		DoAndReturn(func(_ context.Context, _, _ string, value *authv1_cache.UserPermissionsCache, _ ...map[string]any) error {
+			stored = value
+			return nil
+		}).Times(1)
+	cache := NewPermissionCache(mockHandler, 30*time.Second, logger.NewBaseLogger(shared.ModuleAuth))
+
+	cache.Set(context.Background(), "tenant-123", "user-123", &userAccess{
+		permissions: map[string]bool{"user:read": true, "user:delete": false},
+		conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+	})
+	assert.Equal(t, "user-123", stored.GetUserId())
+	assert.Equal(t, "tenant-123", stored.GetTenantId())
+	assert.Equal(t, []string{"user:read"}, stored.GetPermissions())
+	assert.Equal(t, []string{"user:delete"}, stored.GetDeniedPermissions())
+	assert.Equal(t, map[string]string{"user:read": "owner == subject.user_id"}, stored.GetConditions())
+	assert.NotNil(t, stored.GetCachedAt())
+}
+
+func TestPermissionCache_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockHandler := mock_redis.NewMockKeyHandler[authv1_cache.UserPermissionsCache](ctrl)
+	mockHandler.EXPECT().Delete(gomock.Any(), "tenant-123", "user-123").Return(nil).Times(1)
+	mockHandler.EXPECT().DeleteByPattern(gomock.Any(), "tenant-123", "").Return(3, nil).Times(1)
+	cache := NewPermissionCache(mockHandler, time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+
+	cache.InvalidateUser(context.Background(), "tenant-123", "user-123")
+	cache.InvalidateTenant(context.Background(), "tenant-123")
+	// An empty tenant would match the keys of every tenant
+	cache.InvalidateTenant(context.Background(), "")
+}
+
+func TestPermissionCache_Nil(t *testing.T) {
+	var cache *PermissionCache
+	access, ok := cache.Get(context.Background(), "tenant-123", "user-123")
+	assert.False(t, ok)
+	assert.Nil(t, access)
+	cache.Set(context.Background(), "tenant-123", "user-123", &userAccess{permissions: map[string]bool{"user:read": true}})
+	cache.InvalidateUser(context.Background(), "tenant-123", "user-123")
+	cache.InvalidateTenant(context.Background(), "tenant-123")
+}
@@ -0,0 +1,41 @@
+package rbac
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+)
+
+// permissionsVersionLength is the number of hex characters of the permissions versions, 128 bits of the hash
+const permissionsVersionLength = 32
+
+// PermissionsVersion returns the hash of the effective permissions of a user, served from the permission cache when
+// set. It changes whenever a role, permission set or permission edit changes what the user holds
+func (vm *VerificationManager) PermissionsVersion(ctx context.Context, tenantID, userID string) (string, error) {
+	access, err := vm.getUserAccess(ctx, tenantID, userID)
+	if err != nil {
+		return "", err
+	}
+	return access.version(), nil
+}
+
+// version hashes the granted permissions and their conditions, in a stable order
+func (a *userAccess) version() string {
+	granted := make([]string, 0, len(a.permissions))
+	for permission, ok := range a.permissions {
+		if ok {
+			granted = append(granted, permission)
+		}
+	}
+	slices.Sort(granted)
+
+	hash := sha256.New()
+	for _, permission := range granted {
+		hash.Write([]byte(permission))
+		hash.Write([]byte{0})
+		hash.Write([]byte(a.conditions[permission]))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:permissionsVersionLength]
+}
@@ -0,0 +1,69 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAccess_Version(t *testing.T) {
+	base := &userAccess{
+		permissions: map[string]bool{"user:read": true, "role:read": true, "user:delete": false},
+		conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+	}
+	version := base.version()
+	assert.Len(t, version, permissionsVersionLength)
+
+	testCases := []struct {
+		name      string
+		access    *userAccess
+		unchanged bool
+	}{
+		{
+			name: "same permissions",
+			access: &userAccess{
+				permissions: map[string]bool{"role:read": true, "user:read": true, "user:delete": false},
+				conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+			},
+			unchanged: true,
+		},
+		{
+			name: "denied permission dropped",
+			access: &userAccess{
+				permissions: map[string]bool{"user:read": true, "role:read": true},
+				conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+			},
+			unchanged: true,
+		},
+		{
+			name: "permission granted",
+			access: &userAccess{
+				permissions: map[string]bool{"user:read": true, "role:read": true, "user:delete": true},
+				conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+			},
+		},
+		{
+			name: "permission revoked",
+			access: &userAccess{
+				permissions: map[string]bool{"user:read": true, "role:read": false, "user:delete": false},
+				conditions:  map[string]string{"user:read": "owner == subject.user_id"},
+			},
+		},
+		{
+			name: "condition changed",
+			access: &userAccess{
+				permissions: map[string]bool{"user:read": true, "role:read": true, "user:delete": false},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.unchanged {
+				assert.Equal(t, version, tc.access.version())
+			} else {
+				assert.NotEqual(t, version, tc.access.version())
+			}
+		})
+	}
+}
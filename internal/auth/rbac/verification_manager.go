@@ -1,21 +1,30 @@
 package rbac
 
 import (
+	"context"
+	"slices"
+	"time"
+
 	"erp.localhost/internal/auth/handler"
 	"erp.localhost/internal/infra/db"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
 )
 
 type VerificationManager struct {
-	userHandler       *handler.UserHandler
-	roleHandler       *handler.RoleHandler
-	permissionHandler *handler.PermissionHandler
-	tenantHandler     *handler.TenantHandler
-	systemTenantID    string // System tenant ID (from config or constant)
-	logger            logger.Logger
+	userHandler          *handler.UserHandler
+	roleHandler          *handler.RoleHandler
+	permissionHandler    *handler.PermissionHandler
+	permissionSetHandler *handler.PermissionSetHandler
+	tenantHandler        *handler.TenantHandler
+	apiKeyHandler        *handler.APIKeyHandler
+	permissionCache      *PermissionCache
+	systemTenantID       string // System tenant ID (from config or constant)
+	logger               logger.Logger
 }
 
 // NewVerificationManager creates a new VerificationManager instance
@@ -23,43 +32,67 @@ func NewVerificationManager(
 	userHandler *handler.UserHandler,
 	roleHandler *handler.RoleHandler,
 	permissionHandler *handler.PermissionHandler,
+	permissionSetHandler *handler.PermissionSetHandler,
 	tenantHandler *handler.TenantHandler,
+	apiKeyHandler *handler.APIKeyHandler,
 	logger logger.Logger,
 ) *VerificationManager {
 	return &VerificationManager{
-		userHandler:       userHandler,
-		roleHandler:       roleHandler,
-		permissionHandler: permissionHandler,
-		tenantHandler:     tenantHandler,
-		systemTenantID:    db.SystemTenantID,
-		logger:            logger,
+		userHandler:          userHandler,
+		roleHandler:          roleHandler,
+		permissionHandler:    permissionHandler,
+		permissionSetHandler: permissionSetHandler,
+		tenantHandler:        tenantHandler,
+		apiKeyHandler:        apiKeyHandler,
+		systemTenantID:       db.SystemTenantID,
+		logger:               logger,
 	}
 }
 
+// SetPermissionCache caches the resolved permissions of the users in cache
+func (vm *VerificationManager) SetPermissionCache(cache *PermissionCache) {
+	vm.permissionCache = cache
+}
+
+// InvalidateUserPermissions drops the cached permissions of a user, called after the user changes
+func (vm *VerificationManager) InvalidateUserPermissions(ctx context.Context, tenantID, userID string) {
+	vm.permissionCache.InvalidateUser(ctx, tenantID, userID)
+}
+
+// InvalidateTenantPermissions drops the cached permissions of the users of a tenant, called after a role or
+// permission of the tenant changes
+func (vm *VerificationManager) InvalidateTenantPermissions(ctx context.Context, tenantID string) {
+	vm.permissionCache.InvalidateTenant(ctx, tenantID)
+}
+
 // GetUserPermissionsIDs retrieves all the users permissions in a map with the format <id> -> <has permission (true/false)>
-func (vm *VerificationManager) GetUserPermissionsIDs(tenantID, userID string) (map[string]bool, error) {
+func (vm *VerificationManager) GetUserPermissionsIDs(ctx context.Context, tenantID, userID string) (map[string]bool, error) {
 	// 1. Get user from UserCollection
-	user, err := vm.userHandler.GetUserByID(tenantID, userID)
+	user, err := vm.userHandler.GetUserByID(ctx, tenantID, userID)
 	if err != nil {
 		vm.logger.Error(err.Error())
 		return nil, err
 	}
 
-	if vm.isTenantAdmin(user) {
+	if vm.isTenantAdmin(ctx, user) {
 		// Return all permission IDs from database
-		return vm.getAllPermissionIDs(tenantID), nil
+		return vm.getAllPermissionIDs(ctx, tenantID), nil
 	}
 
-	// 3. Resolve permissions from user.Roles
+	// 3. Resolve permissions from the active user.Roles
 	userPermissions := make(map[string]bool)
-	for _, userRole := range user.Roles {
-		role, err := vm.roleHandler.GetRoleByID(tenantID, userRole.RoleId)
+	for _, userRole := range activeRoles(user) {
+		role, err := vm.roleHandler.GetRoleByID(ctx, tenantID, userRole.RoleId)
 		if err != nil {
 			vm.logger.Error(err.Error())
 			return nil, err
 		}
-		for _, permission := range role.Permissions {
-			perm, err := vm.permissionHandler.GetPermissionByID(tenantID, permission)
+		permissionIDs, err := vm.GetRolePermissionIDs(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, permission := range permissionIDs {
+			perm, err := vm.permissionHandler.GetPermissionByID(ctx, tenantID, permission)
 			if err != nil {
 				continue
 			}
@@ -74,7 +107,7 @@ func (vm *VerificationManager) GetUserPermissionsIDs(tenantID, userID string) (m
 
 	// 4. Apply user.AdditionalPermissions
 	for _, permission := range user.AdditionalPermissions {
-		perm, err := vm.permissionHandler.GetPermissionByID(tenantID, permission)
+		perm, err := vm.permissionHandler.GetPermissionByID(ctx, tenantID, permission)
 		if err != nil {
 			continue
 		}
@@ -94,21 +127,106 @@ func (vm *VerificationManager) GetUserPermissionsIDs(tenantID, userID string) (m
 	return userPermissions, nil
 }
 
-// Returns permission strings (for RBAC checks like "users:read")
+// GetRolePermissionIDs returns the distinct IDs of the permissions of a role, its own permissions followed by the
+// permissions of its permission sets. A role whose sets cannot be read has no permissions resolved, rather than
+// only part of them
+func (vm *VerificationManager) GetRolePermissionIDs(ctx context.Context, role *authv1.Role) ([]string, error) {
+	setPermissionIDs, err := vm.getPermissionSetsPermissionIDs(ctx, role.GetTenantId(), role.GetPermissionSets())
+	if err != nil {
+		return nil, err
+	}
+	return distinctIDs(role.GetPermissions(), setPermissionIDs), nil
+}
+
+// getPermissionSetsPermissionIDs flattens the permission sets of setIDs into their distinct permission IDs, the
+// missing sets are skipped
+func (vm *VerificationManager) getPermissionSetsPermissionIDs(ctx context.Context, tenantID string, setIDs []string) ([]string, error) {
+	if len(setIDs) == 0 || vm.permissionSetHandler == nil {
+		return nil, nil
+	}
+	sets, err := vm.permissionSetHandler.GetPermissionSetsByIDs(ctx, tenantID, setIDs)
+	if err != nil {
+		vm.logger.Error("failed to get permission sets", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	members := make([][]string, 0, len(sets))
+	for _, set := range sets {
+		members = append(members, set.GetPermissions())
+	}
+	return distinctIDs(members...), nil
+}
+
+// distinctIDs returns the IDs of lists without their duplicates, in order
+func distinctIDs(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, list := range lists {
+		for _, id := range list {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// userAccess is the resolved access of a user, the permissions it holds and the conditions of the conditional ones
+type userAccess struct {
+	permissions map[string]bool
+	conditions  map[string]string
+}
+
+// grant sets whether the user holds perm and keeps its condition
+func (a *userAccess) grant(perm *authv1.Permission, granted bool) {
+	a.permissions[perm.PermissionString] = granted
+	if granted && perm.GetCondition() != "" {
+		if a.conditions == nil {
+			a.conditions = make(map[string]string)
+		}
+		a.conditions[perm.PermissionString] = perm.GetCondition()
+	} else {
+		delete(a.conditions, perm.PermissionString)
+	}
+}
+
+// Returns permission strings (for RBAC checks like "users:read"), served from the permission cache when set
+func (vm *VerificationManager) GetUserPermissions(ctx context.Context, tenantID, userID string) (map[string]bool, error) {
+	access, err := vm.getUserAccess(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return access.permissions, nil
+}
+
+// getUserAccess returns the resolved access of a user, served from the permission cache when set
+func (vm *VerificationManager) getUserAccess(ctx context.Context, tenantID, userID string) (*userAccess, error) {
+	if cached, ok := vm.permissionCache.Get(ctx, tenantID, userID); ok {
+		return cached, nil
+	}
+	access, err := vm.resolveUserPermissions(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	vm.permissionCache.Set(ctx, tenantID, userID, access)
+	return access, nil
+}
+
+// resolveUserPermissions resolves the permissions of a user from its roles
 // OPTIMIZED: Uses MongoDB aggregation to replace 70+ queries with 1-2 queries
-func (vm *VerificationManager) GetUserPermissions(tenantID, userID string) (map[string]bool, error) {
+func (vm *VerificationManager) resolveUserPermissions(ctx context.Context, tenantID, userID string) (*userAccess, error) {
 	// OPTIMIZATION: Check admin status using aggregation (1 query instead of N)
-	roles, err := vm.roleHandler.GetUserRolesAggregation(tenantID, userID, []string{"name"})
+	roles, err := vm.roleHandler.GetUserRolesAggregation(tenantID, userID, []string{"name", "permission_sets"})
 	if err != nil {
 		// Fallback to original method if aggregation fails
 		vm.logger.Warn("role aggregation failed, falling back to original method", "error", err)
-		return vm.getUserPermissionsLegacy(tenantID, userID)
+		return vm.getUserPermissionsLegacy(ctx, tenantID, userID)
 	}
 
 	// Check if user has admin role
 	for _, role := range roles {
-		if role.Name == model_auth.RoleTenantAdmin || role.Name == model_auth.RoleSystemAdmin {
-			return vm.getAllPermissions(), nil
+		if isAdminRole(role.Name) {
+			return &userAccess{permissions: vm.getAllPermissions()}, nil
 		}
 	}
 
@@ -116,97 +234,118 @@ func (vm *VerificationManager) GetUserPermissions(tenantID, userID string) (map[
 	permissions, err := vm.permissionHandler.GetUserPermissionsAggregation(tenantID, userID, nil)
 	if err != nil {
 		vm.logger.Warn("permission aggregation failed, falling back to original method", "error", err)
-		return vm.getUserPermissionsLegacy(tenantID, userID)
+		return vm.getUserPermissionsLegacy(ctx, tenantID, userID)
 	}
 
 	// Process results into permission map
-	userPermissions := make(map[string]bool)
+	access := &userAccess{permissions: make(map[string]bool)}
 	for _, perm := range permissions {
 		if perm.Status == authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE {
-			userPermissions[perm.PermissionString] = true
+			access.grant(perm, true)
+		}
+	}
+
+	// Flatten the permission sets of the roles
+	var setIDs []string
+	for _, role := range roles {
+		setIDs = append(setIDs, role.PermissionSets...)
+	}
+	setPermissionIDs, err := vm.getPermissionSetsPermissionIDs(ctx, tenantID, setIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(setPermissionIDs) > 0 {
+		setPermissions, err := vm.permissionHandler.GetPermissionsByIDsAggregation(ctx, tenantID, setPermissionIDs, nil)
+		if err != nil {
+			vm.logger.Warn("permission set aggregation failed, falling back to original method", "error", err)
+			return vm.getUserPermissionsLegacy(ctx, tenantID, userID)
+		}
+		for _, perm := range setPermissions {
+			if perm.Status == authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE {
+				access.grant(perm, true)
+			}
 		}
 	}
 
 	// Handle additional and revoked permissions
 	// These are much smaller sets, so individual queries are acceptable
-	user, err := vm.userHandler.GetUserByID(tenantID, userID)
+	user, err := vm.userHandler.GetUserByID(ctx, tenantID, userID)
 	if err == nil {
 		// Apply additional permissions
 		for _, permissionID := range user.AdditionalPermissions {
-			perm, err := vm.permissionHandler.GetPermissionByID(tenantID, permissionID)
+			perm, err := vm.permissionHandler.GetPermissionByID(ctx, tenantID, permissionID)
 			if err != nil {
 				continue
 			}
 			if perm.Status == authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE {
-				userPermissions[perm.PermissionString] = true
+				access.grant(perm, true)
 			}
 		}
 
 		// Apply revoked permissions
 		for _, permissionID := range user.RevokedPermissions {
-			perm, err := vm.permissionHandler.GetPermissionByID(tenantID, permissionID)
+			perm, err := vm.permissionHandler.GetPermissionByID(ctx, tenantID, permissionID)
 			if err != nil {
 				continue
 			}
-			userPermissions[perm.PermissionString] = false
+			access.grant(perm, false)
 		}
 	}
 
-	return userPermissions, nil
+	return access, nil
 }
 
 // getUserPermissionsLegacy is the original implementation kept as fallback
-func (vm *VerificationManager) getUserPermissionsLegacy(tenantID, userID string) (map[string]bool, error) {
-	user, err := vm.userHandler.GetUserByID(tenantID, userID)
+func (vm *VerificationManager) getUserPermissionsLegacy(ctx context.Context, tenantID, userID string) (*userAccess, error) {
+	user, err := vm.userHandler.GetUserByID(ctx, tenantID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if vm.isTenantAdmin(user) {
-		return vm.getAllPermissions(), nil
+	if vm.isTenantAdmin(ctx, user) {
+		return &userAccess{permissions: vm.getAllPermissions()}, nil
 	}
 
-	userPermissions := make(map[string]bool)
+	access := &userAccess{permissions: make(map[string]bool)}
 
-	// Resolve from roles
-	for _, userRole := range user.Roles {
-		role, err := vm.roleHandler.GetRoleByID(tenantID, userRole.RoleId)
+	// Resolve from the active roles
+	for _, userRole := range activeRoles(user) {
+		role, err := vm.roleHandler.GetRoleByID(ctx, tenantID, userRole.RoleId)
 		if err != nil {
 			continue
 		}
-		for _, permissionID := range role.Permissions {
-			perm, err := vm.permissionHandler.GetPermissionByID(tenantID, permissionID)
+		permissionIDs, err := vm.GetRolePermissionIDs(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, permissionID := range permissionIDs {
+			perm, err := vm.permissionHandler.GetPermissionByID(ctx, tenantID, permissionID)
 			if err != nil {
 				continue
 			}
-			userPermissions[perm.PermissionString] = true
+			access.grant(perm, true)
 		}
 	}
 
 	// Apply additional permissions
 	for _, permissionID := range user.AdditionalPermissions {
-		perm, err := vm.permissionHandler.GetPermissionByID(tenantID, permissionID)
+		perm, err := vm.permissionHandler.GetPermissionByID(ctx, tenantID, permissionID)
 		if err != nil {
 			continue
 		}
-		switch perm.Status {
-		case authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE:
-			userPermissions[perm.PermissionString] = true
-		default:
-			userPermissions[perm.PermissionString] = false
-		}
+		access.grant(perm, perm.Status == authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE)
 	}
 
 	// Apply revoked permissions
 	for _, permissionID := range user.RevokedPermissions {
-		perm, err := vm.permissionHandler.GetPermissionByID(tenantID, permissionID)
+		perm, err := vm.permissionHandler.GetPermissionByID(ctx, tenantID, permissionID)
 		if err != nil {
 			continue
 		}
-		userPermissions[perm.PermissionString] = false
+		access.grant(perm, false)
 	}
 
-	return userPermissions, nil
+	return access, nil
 }
 
 // Check if user belongs to system tenant
@@ -214,18 +353,33 @@ func (vm *VerificationManager) IsSystemTenantUser(tenantID string) bool {
 	return tenantID == vm.systemTenantID
 }
 
+// IsSystemAdmin returns an error unless the user is an admin of the system tenant
+func (vm *VerificationManager) IsSystemAdmin(ctx context.Context, tenantID, userID string) error {
+	if _, ok := model_auth.APIKeyIDFromPrincipal(userID); ok || !vm.IsSystemTenantUser(tenantID) {
+		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+	user, err := vm.userHandler.GetUserByID(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if !vm.isTenantAdmin(ctx, user) {
+		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+	return nil
+}
+
 // Check if user has tenant admin role
 // OPTIMIZED: Uses MongoDB aggregation to replace N queries with 1 query
-func (vm *VerificationManager) isTenantAdmin(user *authv1.User) bool {
+func (vm *VerificationManager) isTenantAdmin(ctx context.Context, user *authv1.User) bool {
 	roles, err := vm.roleHandler.GetUserRolesAggregation(user.TenantId, user.Id, []string{"name"})
 	if err != nil {
 		// Fallback to original method if aggregation fails
 		vm.logger.Warn("role aggregation failed in isTenantAdmin, falling back", "error", err)
-		return vm.isTenantAdminLegacy(user)
+		return vm.isTenantAdminLegacy(ctx, user)
 	}
 
 	for _, role := range roles {
-		if role.Name == model_auth.RoleTenantAdmin || role.Name == model_auth.RoleSystemAdmin {
+		if isAdminRole(role.Name) {
 			return true
 		}
 	}
@@ -233,13 +387,13 @@ func (vm *VerificationManager) isTenantAdmin(user *authv1.User) bool {
 }
 
 // isTenantAdminLegacy is the original implementation kept as fallback
-func (vm *VerificationManager) isTenantAdminLegacy(user *authv1.User) bool {
-	for _, userRole := range user.Roles {
-		role, err := vm.roleHandler.GetRoleByID(user.TenantId, userRole.RoleId)
+func (vm *VerificationManager) isTenantAdminLegacy(ctx context.Context, user *authv1.User) bool {
+	for _, userRole := range activeRoles(user) {
+		role, err := vm.roleHandler.GetRoleByID(ctx, user.TenantId, userRole.RoleId)
 		if err != nil {
 			continue
 		}
-		if role.Name == model_auth.RoleTenantAdmin || role.Name == model_auth.RoleSystemAdmin {
+		if isAdminRole(role.Name) {
 			return true
 		}
 	}
@@ -247,9 +401,9 @@ func (vm *VerificationManager) isTenantAdminLegacy(user *authv1.User) bool {
 }
 
 // Get all permission IDs (for tenant admin)
-func (vm *VerificationManager) getAllPermissionIDs(tenantID string) map[string]bool {
+func (vm *VerificationManager) getAllPermissionIDs(ctx context.Context, tenantID string) map[string]bool {
 	// Query all permissions from database
-	permissions, err := vm.permissionHandler.GetPermissionsByTenantID(tenantID)
+	permissions, err := vm.permissionHandler.GetPermissionsByTenantID(ctx, tenantID)
 	if err != nil {
 		vm.logger.Error("failed to get all permissions", "error", err)
 		return map[string]bool{}
@@ -265,19 +419,35 @@ func (vm *VerificationManager) getAllPermissionIDs(tenantID string) map[string]b
 
 // Get all possible permissions (for tenant admin)
 func (vm *VerificationManager) getAllPermissions() map[string]bool {
-	wildCard, _ := model_auth.CreatePermissionString(model_auth.ResourceTypeAll, model_auth.PermissionActionAll)
 	// Query all permissions from PermissionsCollection
 	// Or return a predefined set of all possible permissions
 	return map[string]bool{
 		// All possible permissions are granted
-		wildCard: true, // Wildcard permission
+		permissions.Wildcard: true, // Wildcard permission
 	}
 }
 
-// GetUserRoles returns all role IDs assigned to a user
-func (vm *VerificationManager) GetUserRoles(tenantID, userID string) ([]string, error) {
+// hasWildcard reports whether the resolved permissions grant every permission, as those of a tenant admin do
+func hasWildcard(userPermissions map[string]bool) bool {
+	return userPermissions[permissions.Wildcard]
+}
+
+// activeRoles returns the role assignments of user not expired yet
+func activeRoles(user *authv1.User) []*authv1.UserRole {
+	now := time.Now()
+	roles := make([]*authv1.UserRole, 0, len(user.GetRoles()))
+	for _, userRole := range user.GetRoles() {
+		if model_auth.IsRoleAssignmentActive(userRole, now) {
+			roles = append(roles, userRole)
+		}
+	}
+	return roles
+}
+
+// GetUserRoles returns the IDs of the roles assigned to a user, the expired assignments are skipped
+func (vm *VerificationManager) GetUserRoles(ctx context.Context, tenantID, userID string) ([]string, error) {
 	// Get user from UserCollection
-	user, err := vm.userHandler.GetUserByID(tenantID, userID)
+	user, err := vm.userHandler.GetUserByID(ctx, tenantID, userID)
 	if err != nil {
 		vm.logger.Error(err.Error())
 		return nil, err
@@ -285,7 +455,7 @@ func (vm *VerificationManager) GetUserRoles(tenantID, userID string) ([]string,
 
 	// Extract role IDs
 	roleIDs := make([]string, 0, len(user.Roles))
-	for _, userRole := range user.Roles {
+	for _, userRole := range activeRoles(user) {
 		roleIDs = append(roleIDs, userRole.RoleId)
 	}
 
@@ -293,81 +463,159 @@ func (vm *VerificationManager) GetUserRoles(tenantID, userID string) ([]string,
 }
 
 // CheckPermissions with system tenant and tenant admin logic
-func (vm *VerificationManager) CheckPermissions(tenantID, userID string, permissions []string) (map[string]bool, error) {
-	// 1. Get user
-	user, err := vm.userHandler.GetUserByID(tenantID, userID)
-	if err != nil {
-		vm.logger.Error(err.Error())
-		return nil, err
-	}
-	// 2. Check if tenant admin → grant all
-	if vm.isTenantAdmin(user) {
+func (vm *VerificationManager) CheckPermissions(ctx context.Context, tenantID, userID string, permissions []string) (map[string]bool, error) {
+	if keyID, ok := model_auth.APIKeyIDFromPrincipal(userID); ok {
 		result := make(map[string]bool)
 		for _, perm := range permissions {
-			result[perm] = true
+			result[perm] = vm.hasAPIKeyPermission(ctx, tenantID, keyID, perm, tenantID, nil) == nil
 		}
 		return result, nil
 	}
-
-	// 3. Get user permissions
-	userPermissions, err := vm.GetUserPermissions(tenantID, userID)
+	// 1. Get user permissions
+	access, err := vm.getUserAccess(ctx, tenantID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. Check each permission
+	// 2. Check each permission, tenant admins hold the wildcard and are granted all
 	result := make(map[string]bool)
 	for _, perm := range permissions {
-		userPerm, ok := userPermissions[perm]
-		if !ok {
-			userPerm = false
-		}
-		result[perm] = userPerm
+		result[perm] = vm.grantsPermission(access, tenantID, userID, perm, tenantID, nil)
 	}
 
 	return result, nil
 }
 
 // HasPermission with cross-tenant check for system tenant users
-func (vm *VerificationManager) HasPermission(tenantID, userID, permission string, targetTenantID string) error {
-	// 1. Get user
-	user, err := vm.userHandler.GetUserByID(tenantID, userID)
+func (vm *VerificationManager) HasPermission(ctx context.Context, tenantID, userID, permission string, targetTenantID string) error {
+	return vm.HasPermissionWithAttributes(ctx, tenantID, userID, permission, targetTenantID, nil)
+}
+
+// HasPermissionWithAttributes is HasPermission evaluating the condition of a conditional permission against the
+// attributes of the request, see Condition
+func (vm *VerificationManager) HasPermissionWithAttributes(ctx context.Context, tenantID, userID, permission string, targetTenantID string, attributes map[string]string) error {
+	if keyID, ok := model_auth.APIKeyIDFromPrincipal(userID); ok {
+		return vm.hasAPIKeyPermission(ctx, tenantID, keyID, permission, targetTenantID, attributes)
+	}
+
+	// 1. Get user permissions
+	access, err := vm.getUserAccess(ctx, tenantID, userID)
 	if err != nil {
 		return err
 	}
 
-	// 2. Check if tenant admin (for same tenant operations)
-	if tenantID == targetTenantID && vm.isTenantAdmin(user) {
-		return nil // Tenant admin has all permissions in their tenant
+	if !vm.grantsPermission(access, tenantID, userID, permission, targetTenantID, attributes) {
+		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+
+	return nil
+}
+
+// grantsPermission reports whether the resolved access of a user of tenantID grants permission on targetTenantID
+// for a request with attributes
+func (vm *VerificationManager) grantsPermission(access *userAccess, tenantID, userID, permission, targetTenantID string, attributes map[string]string) bool {
+	// 1. Check if tenant admin (for same tenant operations)
+	if tenantID == targetTenantID && hasWildcard(access.permissions) {
+		return true // Tenant admin has all permissions in their tenant
+	}
+
+	// 2. System tenant users can operate on all tenants (no tenant restriction), other users only on their own
+	if tenantID != targetTenantID && !vm.IsSystemTenantUser(tenantID) {
+		return false
+	}
+
+	if !access.permissions[permission] {
+		return false
+	}
+
+	// 3. Conditional permissions are held when their condition holds for the request
+	expression, ok := access.conditions[permission]
+	if !ok {
+		return true
+	}
+	condition, err := ParseCondition(expression)
+	if err != nil {
+		vm.logger.Error("invalid permission condition", "tenant_id", tenantID, "permission", permission, "condition", expression, "error", err)
+		return false
+	}
+	held, err := condition.Evaluate(subjectAttributes(tenantID, userID, attributes))
+	if err != nil {
+		vm.logger.Debug("permission condition not evaluated", "tenant_id", tenantID, "user_id", userID, "permission", permission, "error", err)
+		return false
+	}
+	return held
+}
+
+// subjectAttributes returns attributes with the attributes of the subject, those of the request cannot override them
+func subjectAttributes(tenantID, userID string, attributes map[string]string) map[string]string {
+	result := make(map[string]string, len(attributes)+2)
+	for name, value := range attributes {
+		result[name] = value
+	}
+	result[SubjectUserIDAttribute] = userID
+	result[SubjectTenantIDAttribute] = tenantID
+	return result
+}
+
+// VerifyPermissions checks several permissions and role names of a user on targetTenantID with one resolution of
+// its permissions and roles, the conditions of the permissions are evaluated against attributes. The results map
+// every permission and role name checked to whether the user holds it
+func (vm *VerificationManager) VerifyPermissions(ctx context.Context, tenantID, userID string, permissionList, roleNames []string, targetTenantID string, attributes map[string]string) (map[string]bool, map[string]bool, error) {
+	permissionResults := make(map[string]bool, len(permissionList))
+	roleResults := make(map[string]bool, len(roleNames))
+	for _, roleName := range roleNames {
+		roleResults[roleName] = false
 	}
 
-	// 3. Check if system tenant user (cross-tenant operations)
-	if vm.IsSystemTenantUser(tenantID) {
-		// System tenant users can operate on all tenants
-		// Just check if they have the permission (no tenant restriction)
-		userPermissions, err := vm.GetUserPermissions(tenantID, userID)
+	if keyID, ok := model_auth.APIKeyIDFromPrincipal(userID); ok {
+		// API keys hold scopes, not roles
+		for _, perm := range permissionList {
+			permissionResults[perm] = vm.hasAPIKeyPermission(ctx, tenantID, keyID, perm, targetTenantID, attributes) == nil
+		}
+		return permissionResults, roleResults, nil
+	}
+
+	if len(permissionList) > 0 {
+		access, err := vm.getUserAccess(ctx, tenantID, userID)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		if userPermissions[permission] {
-			return nil // System user has permission for cross-tenant operation
+		for _, perm := range permissionList {
+			permissionResults[perm] = vm.grantsPermission(access, tenantID, userID, perm, targetTenantID, attributes)
 		}
-		return infra_error.Auth(infra_error.AuthPermissionDenied)
 	}
 
-	// 4. Regular permission check (same tenant only)
-	if tenantID != targetTenantID {
-		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	if len(roleNames) > 0 {
+		roles, err := vm.roleHandler.GetUserRolesAggregation(tenantID, userID, []string{"name"})
+		if err != nil {
+			vm.logger.Error("failed to get user roles", "tenant_id", tenantID, "user_id", userID, "error", err)
+			return nil, nil, err
+		}
+		for _, role := range roles {
+			if _, ok := roleResults[role.Name]; ok {
+				roleResults[role.Name] = true
+			}
+		}
 	}
 
-	userPermissions, err := vm.GetUserPermissions(tenantID, userID)
+	return permissionResults, roleResults, nil
+}
+
+// hasAPIKeyPermission checks a call authenticated with an API key.
+// The permission must be in the key scopes and still be held by the user who created the key.
+func (vm *VerificationManager) hasAPIKeyPermission(ctx context.Context, tenantID, keyID, permission string, targetTenantID string, attributes map[string]string) error {
+	if vm.apiKeyHandler == nil {
+		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+	key, err := vm.apiKeyHandler.GetAPIKeyByID(ctx, tenantID, keyID)
 	if err != nil {
 		return err
 	}
-
-	if !userPermissions[permission] {
+	if err := validator_auth.ValidateActiveAPIKey(key); err != nil {
+		return err
+	}
+	if !slices.Contains(key.GetScopes(), permission) && !slices.Contains(key.GetScopes(), permissions.Wildcard) {
 		return infra_error.Auth(infra_error.AuthPermissionDenied)
 	}
-
-	return nil
+	return vm.HasPermissionWithAttributes(ctx, tenantID, key.GetCreatedBy(), permission, targetTenantID, attributes)
 }
@@ -0,0 +1,201 @@
+package rbac
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testRBAC is a verification manager over the in-memory auth database with its handlers
+type testRBAC struct {
+	vm             *VerificationManager
+	users          *handler.UserHandler
+	roles          *handler.RoleHandler
+	permissions    *handler.PermissionHandler
+	permissionSets *handler.PermissionSetHandler
+}
+
+func newTestRBAC(t *testing.T) *testRBAC {
+	t.Helper()
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	r := &testRBAC{}
+	var err error
+	r.users, err = handler.NewUserHandler(log)
+	require.NoError(t, err)
+	r.roles, err = handler.NewRoleHandler(log)
+	require.NoError(t, err)
+	r.permissions, err = handler.NewPermissionHandler(log)
+	require.NoError(t, err)
+	r.permissionSets, err = handler.NewPermissionSetHandler(log)
+	require.NoError(t, err)
+	tenants, err := handler.NewTenantHandler(log)
+	require.NoError(t, err)
+	apiKeys, err := handler.NewAPIKeyHandler(log)
+	require.NoError(t, err)
+	r.vm = NewVerificationManager(r.users, r.roles, r.permissions, r.permissionSets, tenants, apiKeys, log)
+	return r
+}
+
+func (r *testRBAC) createPermission(t *testing.T, tenantID, permission string) string {
+	t.Helper()
+	resource, action, _ := strings.Cut(permission, ":")
+	id, err := r.permissions.CreatePermission(context.Background(), &authv1.Permission{
+		TenantId:         tenantID,
+		Resource:         resource,
+		Action:           action,
+		PermissionString: permission,
+		DisplayName:      permission,
+		Status:           authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE,
+		CreatedBy:        "System",
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func (r *testRBAC) createPermissionSet(t *testing.T, tenantID, name string, permissionIDs ...string) string {
+	t.Helper()
+	id, err := r.permissionSets.CreatePermissionSet(context.Background(), &authv1.PermissionSet{
+		TenantId:    tenantID,
+		Name:        name,
+		Permissions: permissionIDs,
+		CreatedBy:   "System",
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func (r *testRBAC) createRole(t *testing.T, role *authv1.Role) string {
+	t.Helper()
+	role.Status = authv1.RoleStatus_ROLE_STATUS_ACTIVE
+	role.CreatedBy = "System"
+	role.CreatedAt = timestamppb.Now()
+	id, err := r.roles.CreateRole(context.Background(), role)
+	require.NoError(t, err)
+	return id
+}
+
+func (r *testRBAC) createUser(t *testing.T, tenantID, username string, roleIDs ...string) string {
+	t.Helper()
+	user := &authv1.User{
+		TenantId:     tenantID,
+		Username:     username,
+		Email:        username + "@example.com",
+		PasswordHash: "hash",
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    "System",
+		CreatedAt:    timestamppb.Now(),
+	}
+	for _, roleID := range roleIDs {
+		user.Roles = append(user.Roles, &authv1.UserRole{RoleId: roleID, TenantId: tenantID, AssignedBy: "System", AssignedAt: timestamppb.Now()})
+	}
+	id, err := r.users.CreateUser(context.Background(), user)
+	require.NoError(t, err)
+	return id
+}
+
+func TestVerificationManager_RolePermissionsWithSets(t *testing.T) {
+	r := newTestRBAC(t)
+	ctx := context.Background()
+	usersRead := r.createPermission(t, "tenant-1", "users:read")
+	usersCreate := r.createPermission(t, "tenant-1", "users:create")
+	ordersRead := r.createPermission(t, "tenant-1", "orders:read")
+	users := r.createPermissionSet(t, "tenant-1", "users", usersRead, usersCreate)
+	orders := r.createPermissionSet(t, "tenant-1", "orders", usersCreate, ordersRead)
+	roleID := r.createRole(t, &authv1.Role{
+		TenantId:       "tenant-1",
+		Name:           "operator",
+		Permissions:    []string{usersRead},
+		PermissionSets: []string{users, orders, "missing-set"},
+	})
+	role, err := r.roles.GetRoleByID(ctx, "tenant-1", roleID)
+	require.NoError(t, err)
+
+	// The members of the sets follow the own permissions of the role, once each, the missing set is skipped
+	permissionIDs, err := r.vm.GetRolePermissionIDs(ctx, role)
+	require.NoError(t, err)
+	assert.Equal(t, []string{usersRead, usersCreate, ordersRead}, permissionIDs)
+
+	userID := r.createUser(t, "tenant-1", "operator", roleID)
+	granted, err := r.vm.GetUserPermissions(ctx, "tenant-1", userID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"users:read": true, "users:create": true, "orders:read": true}, granted)
+	require.NoError(t, r.vm.HasPermission(ctx, "tenant-1", userID, "orders:read", "tenant-1"))
+}
+
+func TestVerificationManager_RolePermissionsWithSets_Error(t *testing.T) {
+	r := newTestRBAC(t)
+
+	// The sets of a role without tenant cannot be read, the role resolves to an error rather than to its own
+	// permissions only
+	permissionIDs, err := r.vm.GetRolePermissionIDs(context.Background(), &authv1.Role{
+		Permissions:    []string{"permission-1"},
+		PermissionSets: []string{"set-1"},
+	})
+	require.Error(t, err)
+	assert.Nil(t, permissionIDs)
+}
+
+func TestVerificationManager_CheckGrantableRole_PermissionSets(t *testing.T) {
+	r := newTestRBAC(t)
+	ctx := context.Background()
+	usersRead := r.createPermission(t, "tenant-1", "users:read")
+	usersDelete := r.createPermission(t, "tenant-1", "users:delete")
+	readers := r.createPermissionSet(t, "tenant-1", "readers", usersRead)
+	managers := r.createPermissionSet(t, "tenant-1", "managers", usersRead, usersDelete)
+	callerRole := r.createRole(t, &authv1.Role{TenantId: "tenant-1", Name: "reader", Permissions: []string{usersRead}})
+	callerID := r.createUser(t, "tenant-1", "caller", callerRole)
+
+	testCases := []struct {
+		name        string
+		role        *authv1.Role
+		previous    *authv1.Role
+		expectedErr error
+	}{
+		{
+			name: "set of held permissions",
+			role: &authv1.Role{TenantId: "tenant-1", Name: "viewer", PermissionSets: []string{readers}},
+		},
+		{
+			name:        "set granting a permission the caller lacks",
+			role:        &authv1.Role{TenantId: "tenant-1", Name: "manager", PermissionSets: []string{managers}},
+			expectedErr: infra_error.Auth(infra_error.AuthPrivilegeEscalation),
+		},
+		{
+			name:        "set attached to an existing role",
+			role:        &authv1.Role{TenantId: "tenant-1", Name: "viewer", Permissions: []string{usersRead}, PermissionSets: []string{managers}},
+			previous:    &authv1.Role{TenantId: "tenant-1", Name: "viewer", Permissions: []string{usersRead}},
+			expectedErr: infra_error.Auth(infra_error.AuthPrivilegeEscalation),
+		},
+		{
+			name:     "set already attached",
+			role:     &authv1.Role{TenantId: "tenant-1", Name: "manager", PermissionSets: []string{managers}},
+			previous: &authv1.Role{TenantId: "tenant-1", Name: "manager", PermissionSets: []string{managers}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := r.vm.CheckGrantableRole(ctx, "tenant-1", callerID, "tenant-1", tc.role, tc.previous)
+			if tc.expectedErr != nil {
+				assert.True(t, infra_error.Auth(infra_error.AuthPrivilegeEscalation).Is(err), err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package saml
+
+import (
+	"sort"
+	"strings"
+)
+
+// canonicalizer renders an element subtree with Exclusive XML Canonicalization 1.0 without comments
+// (https://www.w3.org/TR/xml-exc-c14n/), the only canonicalization accepted for signed SAML content
+type canonicalizer struct {
+	// prefixes of the InclusiveNamespaces PrefixList, "" is the default namespace
+	inclusive map[string]bool
+	// excluded is left out of the output, used for the enveloped signature transform
+	excluded *element
+}
+
+func newCanonicalizer(prefixList string, excluded *element) *canonicalizer {
+	inclusive := map[string]bool{}
+	for _, prefix := range strings.Fields(prefixList) {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		inclusive[prefix] = true
+	}
+	return &canonicalizer{inclusive: inclusive, excluded: excluded}
+}
+
+// canonicalize returns the canonical form of the subtree rooted at el
+func (c *canonicalizer) canonicalize(el *element) []byte {
+	var builder strings.Builder
+	// Outside of the subtree the default namespace is empty and nothing else is rendered
+	c.writeElement(&builder, el, map[string]string{"": ""})
+	return []byte(builder.String())
+}
+
+func (c *canonicalizer) writeElement(builder *strings.Builder, el *element, rendered map[string]string) {
+	if el == c.excluded {
+		return
+	}
+
+	// A namespace is output where it is visibly utilized and not already rendered by an output ancestor
+	used := map[string]bool{el.prefix: true}
+	for _, attr := range el.attrs {
+		if attr.prefix != "" {
+			used[attr.prefix] = true
+		}
+	}
+	for prefix := range c.inclusive {
+		if _, ok := el.lookupNamespace(prefix); ok {
+			used[prefix] = true
+		}
+	}
+
+	scope := rendered
+	declarations := []string{}
+	for prefix := range used {
+		if prefix == "xml" {
+			continue
+		}
+		uri, _ := el.lookupNamespace(prefix)
+		if current, ok := rendered[prefix]; ok && current == uri {
+			continue
+		}
+		// Prefixes other than the default can not be undeclared
+		if prefix != "" && uri == "" {
+			continue
+		}
+		if len(declarations) == 0 {
+			scope = make(map[string]string, len(rendered)+len(used))
+			for key, value := range rendered {
+				scope[key] = value
+			}
+		}
+		scope[prefix] = uri
+		declarations = append(declarations, prefix)
+	}
+	// The default namespace sorts first since it has no local name
+	sort.Strings(declarations)
+
+	attrs := make([]attribute, len(el.attrs))
+	copy(attrs, el.attrs)
+	sort.SliceStable(attrs, func(i, j int) bool {
+		iNamespace, jNamespace := attrNamespace(el, attrs[i]), attrNamespace(el, attrs[j])
+		if iNamespace != jNamespace {
+			return iNamespace < jNamespace
+		}
+		return attrs[i].local < attrs[j].local
+	})
+
+	builder.WriteByte('<')
+	builder.WriteString(qualifiedName(el.prefix, el.local))
+	for _, prefix := range declarations {
+		if prefix == "" {
+			builder.WriteString(` xmlns="`)
+		} else {
+			builder.WriteString(` xmlns:` + prefix + `="`)
+		}
+		builder.WriteString(escapeAttr(scope[prefix]))
+		builder.WriteByte('"')
+	}
+	for _, attr := range attrs {
+		builder.WriteByte(' ')
+		builder.WriteString(qualifiedName(attr.prefix, attr.local))
+		builder.WriteString(`="`)
+		builder.WriteString(escapeAttr(attr.value))
+		builder.WriteByte('"')
+	}
+	builder.WriteByte('>')
+
+	for _, child := range el.children {
+		switch v := child.(type) {
+		case *element:
+			c.writeElement(builder, v, scope)
+		case text:
+			builder.WriteString(escapeText(string(v)))
+		}
+	}
+
+	builder.WriteString("</" + qualifiedName(el.prefix, el.local) + ">")
+}
+
+func attrNamespace(el *element, attr attribute) string {
+	if attr.prefix == "" {
+		return ""
+	}
+	uri, _ := el.lookupNamespace(attr.prefix)
+	return uri
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+
+var attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+func escapeAttr(value string) string {
+	return attrEscaper.Replace(value)
+}
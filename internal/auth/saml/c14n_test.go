@@ -0,0 +1,111 @@
+package saml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		document   string
+		path       []string
+		prefixList string
+		exclude    string
+		want       string
+	}{
+		{
+			name:     "sorts namespaces and attributes",
+			document: `<a:root xmlns:b="urn:b" xmlns:a="urn:a" z="1" b:y="2" a="3"><child/></a:root>`,
+			want:     `<a:root xmlns:a="urn:a" xmlns:b="urn:b" a="3" z="1" b:y="2"><child></child></a:root>`,
+		},
+		{
+			name:     "renders only visibly utilized namespaces of the subtree",
+			document: `<r xmlns="urn:d" xmlns:x="urn:x" xmlns:unused="urn:u"><x:child attr="v"><inner/></x:child></r>`,
+			path:     []string{"child"},
+			want:     `<x:child xmlns:x="urn:x" attr="v"><inner xmlns="urn:d"></inner></x:child>`,
+		},
+		{
+			name:     "escapes text and attribute values",
+			document: `<r a="&lt;&quot;&#9;&amp;&gt;">&lt;&amp;&gt;"'</r>`,
+			want:     `<r a="&lt;&quot;&#x9;&amp;>">&lt;&amp;&gt;"'</r>`,
+		},
+		{
+			name:     "undeclares the default namespace",
+			document: `<r xmlns="urn:d"><c xmlns=""><g/></c></r>`,
+			want:     `<r xmlns="urn:d"><c xmlns=""><g></g></c></r>`,
+		},
+		{
+			name:     "omits an empty default namespace at the apex",
+			document: `<r xmlns="urn:d"><c xmlns=""><g/></c></r>`,
+			path:     []string{"c"},
+			want:     `<c><g></g></c>`,
+		},
+		{
+			name:       "renders inclusive namespace prefixes",
+			document:   `<r xmlns:p="urn:p" xmlns:q="urn:q"><c/></r>`,
+			path:       []string{"c"},
+			prefixList: "p",
+			want:       `<c xmlns:p="urn:p"></c>`,
+		},
+		{
+			name:     "skips redundant declarations and keeps whitespace",
+			document: "<a:r xmlns:a=\"urn:a\">\n  <a:c xmlns:a=\"urn:a\"/>\n  <a:d xmlns:a=\"urn:other\"/>\n</a:r>",
+			want:     "<a:r xmlns:a=\"urn:a\">\n  <a:c></a:c>\n  <a:d xmlns:a=\"urn:other\"></a:d>\n</a:r>",
+		},
+		{
+			name:     "drops comments and the excluded element",
+			document: `<r><!-- comment --><keep/><Signature><SignedInfo/></Signature></r>`,
+			exclude:  "Signature",
+			want:     `<r><keep></keep></r>`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := parseDocument([]byte(tc.document))
+			require.NoError(t, err)
+			el := root
+			for _, local := range tc.path {
+				el = childByLocal(t, el, local)
+			}
+			var excluded *element
+			if tc.exclude != "" {
+				excluded = childByLocal(t, el, tc.exclude)
+			}
+			assert.Equal(t, tc.want, string(newCanonicalizer(tc.prefixList, excluded).canonicalize(el)))
+		})
+	}
+}
+
+func TestParseDocument(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+	}{
+		{name: "dtd", document: `<!DOCTYPE r [<!ENTITY e "x">]><r>&e;</r>`},
+		{name: "undefined entity", document: `<r>&e;</r>`},
+		{name: "mismatched end tag", document: `<r><a></b></r>`},
+		{name: "undeclared prefix", document: `<p:r/>`},
+		{name: "two roots", document: `<r/><r/>`},
+		{name: "empty", document: ``},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseDocument([]byte(tc.document))
+			require.Error(t, err)
+		})
+	}
+}
+
+func childByLocal(t *testing.T, el *element, local string) *element {
+	t.Helper()
+	for _, child := range el.childElements() {
+		if child.local == local {
+			return child
+		}
+	}
+	t.Fatalf("%s has no child %s", el.local, local)
+	return nil
+}
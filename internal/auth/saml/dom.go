@@ -0,0 +1,227 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	namespaceXML   = "http://www.w3.org/XML/1998/namespace"
+	namespaceXMLNS = "http://www.w3.org/2000/xmlns/"
+)
+
+// ErrDTDNotAllowed is returned for documents with a DTD, which could define entities or change ID attributes
+var ErrDTDNotAllowed = errors.New("xml documents with a DTD are not allowed")
+
+// element is a parsed XML element which keeps the prefixes and namespace declarations
+// of the source document, both are needed to canonicalize signed content
+type element struct {
+	parent *element
+	prefix string
+	local  string
+	// namespaces declared on the element by prefix, "" is the default namespace
+	namespaces map[string]string
+	attrs      []attribute
+	children   []node
+}
+
+type attribute struct {
+	prefix string
+	local  string
+	value  string
+}
+
+// node is a child of an element, either *element or text
+type node any
+
+type text string
+
+// parseDocument parses data into an element tree rooted at the document element.
+// Comments and processing instructions are dropped, they are never part of canonical signed content.
+func parseDocument(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if root != nil && current == nil {
+				return nil, errors.New("xml document has more than one root element")
+			}
+			el := newElement(current, t)
+			if current == nil {
+				root = el
+			} else {
+				current.children = append(current.children, el)
+			}
+			current = el
+		case xml.EndElement:
+			// RawToken does not check that start and end tags match
+			if current == nil || current.prefix != t.Name.Space || current.local != t.Name.Local {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current == nil {
+				if len(bytes.TrimSpace(t)) > 0 {
+					return nil, errors.New("text outside of the document element")
+				}
+				continue
+			}
+			current.children = append(current.children, text(t))
+		case xml.Directive:
+			return nil, ErrDTDNotAllowed
+		}
+	}
+	if root == nil {
+		return nil, errors.New("empty xml document")
+	}
+	if current != nil {
+		return nil, errors.New("xml document is not closed")
+	}
+	if err := root.checkNamespaces(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func newElement(parent *element, start xml.StartElement) *element {
+	el := &element{
+		parent:     parent,
+		prefix:     start.Name.Space,
+		local:      start.Name.Local,
+		namespaces: map[string]string{},
+	}
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			el.namespaces[""] = attr.Value
+		case attr.Name.Space == "xmlns":
+			el.namespaces[attr.Name.Local] = attr.Value
+		default:
+			el.attrs = append(el.attrs, attribute{prefix: attr.Name.Space, local: attr.Name.Local, value: attr.Value})
+		}
+	}
+	return el
+}
+
+// checkNamespaces verifies every prefix used in the tree is declared
+func (e *element) checkNamespaces() error {
+	if _, ok := e.lookupNamespace(e.prefix); !ok {
+		return fmt.Errorf("undeclared namespace prefix %q", e.prefix)
+	}
+	for _, attr := range e.attrs {
+		if attr.prefix == "" {
+			continue
+		}
+		if _, ok := e.lookupNamespace(attr.prefix); !ok {
+			return fmt.Errorf("undeclared namespace prefix %q", attr.prefix)
+		}
+	}
+	for _, child := range e.childElements() {
+		if err := child.checkNamespaces(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupNamespace resolves prefix in the scope of the element
+func (e *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return namespaceXML, true
+	}
+	for el := e; el != nil; el = el.parent {
+		if uri, ok := el.namespaces[prefix]; ok {
+			return uri, true
+		}
+	}
+	// Without a declaration the default namespace is empty
+	return "", prefix == ""
+}
+
+func (e *element) namespace() string {
+	uri, _ := e.lookupNamespace(e.prefix)
+	return uri
+}
+
+// is reports whether the element has the namespace and local name
+func (e *element) is(namespace, local string) bool {
+	return e.local == local && e.namespace() == namespace
+}
+
+// attr returns the value of the unqualified attribute name
+func (e *element) attr(name string) string {
+	for _, attr := range e.attrs {
+		if attr.prefix == "" && attr.local == name {
+			return attr.value
+		}
+	}
+	return ""
+}
+
+func (e *element) childElements() []*element {
+	elements := []*element{}
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok {
+			elements = append(elements, el)
+		}
+	}
+	return elements
+}
+
+// find returns the child elements with the namespace and local name
+func (e *element) find(namespace, local string) []*element {
+	elements := []*element{}
+	for _, child := range e.childElements() {
+		if child.is(namespace, local) {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+// findOne returns the single child element with the namespace and local name,
+// nil when there is none and an error when there are several
+func (e *element) findOne(namespace, local string) (*element, error) {
+	elements := e.find(namespace, local)
+	switch len(elements) {
+	case 0:
+		return nil, nil
+	case 1:
+		return elements[0], nil
+	default:
+		return nil, fmt.Errorf("%s has more than one %s", e.local, local)
+	}
+}
+
+// text returns the trimmed text content of the element
+func (e *element) text() string {
+	var builder strings.Builder
+	for _, child := range e.children {
+		if t, ok := child.(text); ok {
+			builder.WriteString(string(t))
+		}
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// walk calls fn for the element and all its descendants in document order
+func (e *element) walk(fn func(*element)) {
+	fn(e)
+	for _, child := range e.childElements() {
+		child.walk(fn)
+	}
+}
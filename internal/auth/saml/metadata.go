@@ -0,0 +1,104 @@
+package saml
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+const (
+	namespaceMetadata = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+)
+
+// IdentityProvider is the trusted configuration of a tenant IdP
+type IdentityProvider struct {
+	EntityID string
+	// SSOURL is where users are sent to start a login, preferring the HTTP-Redirect binding
+	SSOURL string
+	// Certificates sign responses or assertions, several are kept during key rollover
+	Certificates []*x509.Certificate
+}
+
+// ParseIdPMetadata reads the entity id, single sign-on URL and signing certificates from IdP metadata XML
+func ParseIdPMetadata(data []byte) (*IdentityProvider, error) {
+	root, err := parseDocument(data)
+	if err != nil {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "idp_metadata").WithError(err)
+	}
+	if !root.is(namespaceMetadata, "EntityDescriptor") {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "idp_metadata").
+			WithError(fmt.Errorf("expected EntityDescriptor, got %s", root.local))
+	}
+	descriptor, err := root.findOne(namespaceMetadata, "IDPSSODescriptor")
+	if err != nil || descriptor == nil {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "idp_metadata").
+			WithError(errors.New("metadata has no single IDPSSODescriptor"))
+	}
+
+	idp := &IdentityProvider{EntityID: root.attr("entityID")}
+	for _, service := range descriptor.find(namespaceMetadata, "SingleSignOnService") {
+		switch service.attr("Binding") {
+		case BindingHTTPRedirect:
+			idp.SSOURL = service.attr("Location")
+		case BindingHTTPPost:
+			if idp.SSOURL == "" {
+				idp.SSOURL = service.attr("Location")
+			}
+		}
+	}
+	for _, key := range descriptor.find(namespaceMetadata, "KeyDescriptor") {
+		if use := key.attr("use"); use != "" && use != "signing" {
+			continue
+		}
+		keyInfo, err := key.findOne(namespaceDSig, "KeyInfo")
+		if err != nil || keyInfo == nil {
+			continue
+		}
+		for _, x509Data := range keyInfo.find(namespaceDSig, "X509Data") {
+			for _, encoded := range x509Data.find(namespaceDSig, "X509Certificate") {
+				certificate, err := ParseCertificate(encoded.text())
+				if err != nil {
+					return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "idp_metadata").WithError(err)
+				}
+				idp.Certificates = append(idp.Certificates, certificate)
+			}
+		}
+	}
+	if err := idp.Validate(); err != nil {
+		return nil, err
+	}
+	return idp, nil
+}
+
+// Validate checks that the IdP has everything needed to verify responses
+func (idp *IdentityProvider) Validate() error {
+	missingFields := []string{}
+	if idp.EntityID == "" {
+		missingFields = append(missingFields, "EntityID")
+	}
+	if len(idp.Certificates) == 0 {
+		missingFields = append(missingFields, "Certificates")
+	}
+	if len(missingFields) > 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	}
+	return nil
+}
+
+// ParseCertificate parses a base64 DER certificate, PEM armor is accepted and stripped
+func ParseCertificate(encoded string) (*x509.Certificate, error) {
+	encoded = strings.TrimSpace(encoded)
+	encoded = strings.TrimPrefix(encoded, "-----BEGIN CERTIFICATE-----")
+	encoded = strings.TrimSuffix(encoded, "-----END CERTIFICATE-----")
+	der, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,316 @@
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+const (
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	statusSuccess       = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmationBearer  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	NameIDFormatEmail   = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	defaultClockSkew    = time.Minute
+	maxResponseBytes    = 1 << 20
+	samlTimestampLayout = time.RFC3339Nano
+)
+
+var (
+	// ErrEncryptedAssertion is returned for responses with encrypted assertions, the SP has no decryption key
+	ErrEncryptedAssertion = errors.New("encrypted assertions are not supported")
+	// ErrAssertionExpired is returned when the assertion conditions are no longer valid
+	ErrAssertionExpired = errors.New("saml assertion is expired or not yet valid")
+)
+
+// ServiceProvider is the ERP side of a SAML integration, it validates responses posted by IdPs
+type ServiceProvider struct {
+	// EntityID is the audience IdPs issue assertions to
+	EntityID string
+	// ACSURL is the assertion consumer service URL responses are posted to
+	ACSURL string
+	// ClockSkew is the allowed clock difference to the IdP, a minute when zero
+	ClockSkew time.Duration
+	// Now returns the current time, time.Now when nil
+	Now func() time.Time
+}
+
+// Assertion is the verified content of a SAML assertion
+type Assertion struct {
+	ID           string
+	Issuer       string
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	// Attributes are the attribute values by attribute name
+	Attributes map[string][]string
+	// ExpiresAt is the end of the assertion validity, the assertion id must be remembered until then to block replays
+	ExpiresAt time.Time
+}
+
+// Attribute returns the first value of the attribute name, or ""
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ParseResponse decodes a base64 SAMLResponse and returns its assertion after verifying
+// the signature against idp, the issuer, the validity window, the audience and the recipient
+func (sp *ServiceProvider) ParseResponse(encoded string, idp *IdentityProvider) (*Assertion, error) {
+	if err := idp.Validate(); err != nil {
+		return nil, err
+	}
+	data, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	if len(data) > maxResponseBytes {
+		return nil, invalidResponse(errors.New("saml response is too large"))
+	}
+	response, err := parseDocument(data)
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	if !response.is(namespaceProtocol, "Response") {
+		return nil, invalidResponse(fmt.Errorf("expected Response, got %s", response.local))
+	}
+	if err := checkUniqueIDs(response); err != nil {
+		return nil, invalidResponse(err)
+	}
+
+	if destination := response.attr("Destination"); destination != "" && destination != sp.ACSURL {
+		return nil, invalidResponse(fmt.Errorf("response destination %q is not the ACS URL", destination))
+	}
+	if err := checkStatus(response); err != nil {
+		return nil, invalidResponse(err)
+	}
+	if err := checkIssuer(response, idp.EntityID, false); err != nil {
+		return nil, invalidResponse(err)
+	}
+	if len(response.find(namespaceAssertion, "EncryptedAssertion")) > 0 {
+		return nil, invalidResponse(ErrEncryptedAssertion)
+	}
+	assertion, err := response.findOne(namespaceAssertion, "Assertion")
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	if assertion == nil {
+		return nil, invalidResponse(errors.New("response has no assertion"))
+	}
+
+	// Either the response or the assertion must be signed, every present signature must verify
+	signed := false
+	for _, el := range []*element{response, assertion} {
+		signature, err := signatureOf(el)
+		if err != nil {
+			return nil, invalidResponse(err)
+		}
+		if signature == nil {
+			continue
+		}
+		if err := verifySignature(el, signature, idp.Certificates); err != nil {
+			return nil, invalidResponse(err)
+		}
+		signed = true
+	}
+	if !signed {
+		return nil, invalidResponse(ErrSignatureMissing)
+	}
+
+	return sp.readAssertion(assertion, idp)
+}
+
+func (sp *ServiceProvider) readAssertion(assertion *element, idp *IdentityProvider) (*Assertion, error) {
+	now := sp.now()
+	skew := sp.clockSkew()
+
+	if err := checkIssuer(assertion, idp.EntityID, true); err != nil {
+		return nil, invalidResponse(err)
+	}
+	result := &Assertion{
+		ID:         assertion.attr("ID"),
+		Issuer:     idp.EntityID,
+		Attributes: map[string][]string{},
+	}
+	if result.ID == "" {
+		return nil, invalidResponse(errors.New("assertion has no ID"))
+	}
+
+	conditions, err := assertion.findOne(namespaceAssertion, "Conditions")
+	if err != nil || conditions == nil {
+		return nil, invalidResponse(errors.New("assertion has no single Conditions"))
+	}
+	notBefore, err := parseTime(conditions.attr("NotBefore"))
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	notOnOrAfter, err := parseTime(conditions.attr("NotOnOrAfter"))
+	if err != nil {
+		return nil, invalidResponse(err)
+	}
+	if notOnOrAfter.IsZero() {
+		return nil, invalidResponse(errors.New("assertion conditions have no NotOnOrAfter"))
+	}
+	if (!notBefore.IsZero() && now.Add(skew).Before(notBefore)) || !now.Add(-skew).Before(notOnOrAfter) {
+		return nil, infra_error.Auth(infra_error.AuthSSOResponseInvalid).WithError(ErrAssertionExpired)
+	}
+	result.ExpiresAt = notOnOrAfter
+	// Every audience restriction must name this service provider
+	for _, restriction := range conditions.find(namespaceAssertion, "AudienceRestriction") {
+		found := false
+		for _, audience := range restriction.find(namespaceAssertion, "Audience") {
+			if audience.text() == sp.EntityID {
+				found = true
+			}
+		}
+		if !found {
+			return nil, invalidResponse(errors.New("assertion audience does not include the service provider"))
+		}
+	}
+	if len(conditions.find(namespaceAssertion, "AudienceRestriction")) == 0 {
+		return nil, invalidResponse(errors.New("assertion has no audience restriction"))
+	}
+
+	expiresAt, err := sp.readSubject(assertion, result, now, skew)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Before(result.ExpiresAt) {
+		result.ExpiresAt = expiresAt
+	}
+
+	if statement, err := assertion.findOne(namespaceAssertion, "AuthnStatement"); err == nil && statement != nil {
+		result.SessionIndex = statement.attr("SessionIndex")
+	}
+	for _, statement := range assertion.find(namespaceAssertion, "AttributeStatement") {
+		for _, attribute := range statement.find(namespaceAssertion, "Attribute") {
+			name := attribute.attr("Name")
+			for _, value := range attribute.find(namespaceAssertion, "AttributeValue") {
+				result.Attributes[name] = append(result.Attributes[name], value.text())
+			}
+		}
+	}
+	return result, nil
+}
+
+// readSubject reads the name id and checks the bearer confirmation, it returns the confirmation expiry
+func (sp *ServiceProvider) readSubject(assertion *element, result *Assertion, now time.Time, skew time.Duration) (time.Time, error) {
+	subject, err := assertion.findOne(namespaceAssertion, "Subject")
+	if err != nil || subject == nil {
+		return time.Time{}, invalidResponse(errors.New("assertion has no single Subject"))
+	}
+	nameID, err := subject.findOne(namespaceAssertion, "NameID")
+	if err != nil || nameID == nil || nameID.text() == "" {
+		return time.Time{}, invalidResponse(errors.New("assertion subject has no NameID"))
+	}
+	result.NameID = nameID.text()
+	result.NameIDFormat = nameID.attr("Format")
+
+	for _, confirmation := range subject.find(namespaceAssertion, "SubjectConfirmation") {
+		if confirmation.attr("Method") != confirmationBearer {
+			continue
+		}
+		data, err := confirmation.findOne(namespaceAssertion, "SubjectConfirmationData")
+		if err != nil || data == nil {
+			continue
+		}
+		if data.attr("Recipient") != sp.ACSURL {
+			continue
+		}
+		expiresAt, err := parseTime(data.attr("NotOnOrAfter"))
+		if err != nil || expiresAt.IsZero() || !now.Add(-skew).Before(expiresAt) {
+			continue
+		}
+		return expiresAt, nil
+	}
+	return time.Time{}, invalidResponse(errors.New("assertion has no valid bearer confirmation for the ACS URL"))
+}
+
+func (sp *ServiceProvider) now() time.Time {
+	if sp.Now != nil {
+		return sp.Now()
+	}
+	return time.Now()
+}
+
+func (sp *ServiceProvider) clockSkew() time.Duration {
+	if sp.ClockSkew > 0 {
+		return sp.ClockSkew
+	}
+	return defaultClockSkew
+}
+
+// checkUniqueIDs rejects documents where several elements share an ID, signature wrapping attacks rely on them
+func checkUniqueIDs(root *element) error {
+	seen := map[string]bool{}
+	var duplicate string
+	root.walk(func(el *element) {
+		id := el.attr("ID")
+		if id == "" {
+			return
+		}
+		if seen[id] {
+			duplicate = id
+		}
+		seen[id] = true
+	})
+	if duplicate != "" {
+		return fmt.Errorf("duplicate ID %q", duplicate)
+	}
+	return nil
+}
+
+func checkStatus(response *element) error {
+	status, err := response.findOne(namespaceProtocol, "Status")
+	if err != nil || status == nil {
+		return errors.New("response has no single Status")
+	}
+	code, err := status.findOne(namespaceProtocol, "StatusCode")
+	if err != nil || code == nil {
+		return errors.New("response status has no StatusCode")
+	}
+	if value := code.attr("Value"); value != statusSuccess {
+		return fmt.Errorf("response status is %q", value)
+	}
+	return nil
+}
+
+// checkIssuer compares the Issuer child of el with entityID, the issuer is optional on responses
+func checkIssuer(el *element, entityID string, required bool) error {
+	issuer, err := el.findOne(namespaceAssertion, "Issuer")
+	if err != nil {
+		return err
+	}
+	if issuer == nil {
+		if required {
+			return fmt.Errorf("%s has no Issuer", el.local)
+		}
+		return nil
+	}
+	if issuer.text() != entityID {
+		return fmt.Errorf("%s issuer %q is not the IdP", el.local, issuer.text())
+	}
+	return nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(samlTimestampLayout, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return parsed, nil
+}
+
+func invalidResponse(err error) error {
+	return infra_error.Auth(infra_error.AuthSSOResponseInvalid).WithError(err)
+}
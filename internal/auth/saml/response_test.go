@@ -0,0 +1,316 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIdPEntityID = "https://idp.example.com"
+	testSPEntityID  = "https://erp.localhost/saml"
+	testACSURL      = "https://erp.localhost/saml/acs"
+	signaturePlace  = "{{signature}}"
+)
+
+var testNow = time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+type testIdP struct {
+	key         *rsa.PrivateKey
+	certificate *x509.Certificate
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    testNow.Add(-time.Hour),
+		NotAfter:     testNow.Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testIdP{key: key, certificate: certificate}
+}
+
+func (i *testIdP) provider() *IdentityProvider {
+	return &IdentityProvider{EntityID: testIdPEntityID, Certificates: []*x509.Certificate{i.certificate}}
+}
+
+// sign places an enveloped signature over the element with id at the signature placeholder of document
+func (i *testIdP) sign(t *testing.T, document, id, digestAlgorithm string) string {
+	t.Helper()
+	unsigned, err := parseDocument([]byte(strings.Replace(document, signaturePlace, "", 1)))
+	require.NoError(t, err)
+	hash := digestAlgorithms[digestAlgorithm]
+	if hash == 0 {
+		hash = crypto.SHA256
+	}
+	digest := hashBytes(hash, newCanonicalizer("", nil).canonicalize(elementByID(t, unsigned, id)))
+
+	signature := fmt.Sprintf(`<ds:Signature xmlns:ds="%s"><ds:SignedInfo>`+
+		`<ds:CanonicalizationMethod Algorithm="%s"/><ds:SignatureMethod Algorithm="%s"/>`+
+		`<ds:Reference URI="#%s"><ds:Transforms><ds:Transform Algorithm="%s"/><ds:Transform Algorithm="%s"/></ds:Transforms>`+
+		`<ds:DigestMethod Algorithm="%s"/><ds:DigestValue>%s</ds:DigestValue></ds:Reference>`+
+		`</ds:SignedInfo><ds:SignatureValue>%s</ds:SignatureValue></ds:Signature>`,
+		namespaceDSig, algorithmExcC14N, algorithmRSASHA256, id, algorithmEnvelopedSignature, algorithmExcC14N,
+		digestAlgorithm, base64.StdEncoding.EncodeToString(digest), "{{value}}")
+	withSignature := strings.Replace(document, signaturePlace, signature, 1)
+
+	parsed, err := parseDocument([]byte(strings.Replace(withSignature, "{{value}}", "", 1)))
+	require.NoError(t, err)
+	signedInfo := childByLocal(t, childByLocal(t, elementByID(t, parsed, id), "Signature"), "SignedInfo")
+	signedDigest := hashBytes(crypto.SHA256, newCanonicalizer("", nil).canonicalize(signedInfo))
+	value, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, signedDigest)
+	require.NoError(t, err)
+	return strings.Replace(withSignature, "{{value}}", base64.StdEncoding.EncodeToString(value), 1)
+}
+
+func elementByID(t *testing.T, root *element, id string) *element {
+	t.Helper()
+	var found *element
+	root.walk(func(el *element) {
+		if found == nil && el.attr("ID") == id {
+			found = el
+		}
+	})
+	require.NotNil(t, found)
+	return found
+}
+
+type testResponse struct {
+	issuer        string
+	audience      string
+	recipient     string
+	status        string
+	notOnOrAfter  time.Time
+	responseSig   string
+	assertionSig  string
+	extra         string
+	assertionBody string
+}
+
+func defaultTestResponse() testResponse {
+	return testResponse{
+		issuer:       testIdPEntityID,
+		audience:     testSPEntityID,
+		recipient:    testACSURL,
+		status:       statusSuccess,
+		notOnOrAfter: testNow.Add(5 * time.Minute),
+		assertionSig: signaturePlace,
+	}
+}
+
+func (r testResponse) document() string {
+	expiry := r.notOnOrAfter.Format(time.RFC3339)
+	return `<samlp:Response xmlns:samlp="` + namespaceProtocol + `" xmlns:saml="` + namespaceAssertion + `" ID="_response" Version="2.0" Destination="` + testACSURL + `">` +
+		`<saml:Issuer>` + r.issuer + `</saml:Issuer>` + r.responseSig +
+		`<samlp:Status><samlp:StatusCode Value="` + r.status + `"/></samlp:Status>` +
+		`<saml:Assertion ID="_assertion" Version="2.0">` +
+		`<saml:Issuer>` + r.issuer + `</saml:Issuer>` + r.assertionSig +
+		`<saml:Subject><saml:NameID Format="` + NameIDFormatEmail + `">jane@example.com</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="` + confirmationBearer + `"><saml:SubjectConfirmationData Recipient="` + r.recipient + `" NotOnOrAfter="` + expiry + `"/></saml:SubjectConfirmation>` +
+		`</saml:Subject>` +
+		`<saml:Conditions NotBefore="` + testNow.Add(-time.Minute).Format(time.RFC3339) + `" NotOnOrAfter="` + expiry + `">` +
+		`<saml:AudienceRestriction><saml:Audience>` + r.audience + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>` +
+		`<saml:AuthnStatement SessionIndex="_session"/>` +
+		`<saml:AttributeStatement>` +
+		`<saml:Attribute Name="email"><saml:AttributeValue>jane@example.com</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="groups"><saml:AttributeValue>admins</saml:AttributeValue><saml:AttributeValue>sales</saml:AttributeValue></saml:Attribute>` +
+		`</saml:AttributeStatement>` + r.assertionBody +
+		`</saml:Assertion>` + r.extra +
+		`</samlp:Response>`
+}
+
+func TestServiceProvider_ParseResponse(t *testing.T) {
+	idp := newTestIdP(t)
+	otherIdP := newTestIdP(t)
+
+	testCases := []struct {
+		name     string
+		response func(t *testing.T) string
+		idp      *IdentityProvider
+		wantErr  bool
+	}{
+		{
+			name: "signed assertion",
+			response: func(t *testing.T) string {
+				return idp.sign(t, defaultTestResponse().document(), "_assertion", algorithmSHA256)
+			},
+		},
+		{
+			name: "signed response",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.assertionSig, r.responseSig = "", signaturePlace
+				return idp.sign(t, r.document(), "_response", algorithmSHA512)
+			},
+		},
+		{
+			name: "unsigned",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.assertionSig = ""
+				return r.document()
+			},
+			wantErr: true,
+		},
+		{
+			name: "sha1 digest",
+			response: func(t *testing.T) string {
+				return idp.sign(t, defaultTestResponse().document(), "_assertion", "http://www.w3.org/2000/09/xmldsig#sha1")
+			},
+			wantErr: true,
+		},
+		{
+			name: "tampered after signing",
+			response: func(t *testing.T) string {
+				signed := idp.sign(t, defaultTestResponse().document(), "_assertion", algorithmSHA256)
+				return strings.Replace(signed, "<saml:AttributeValue>sales", "<saml:AttributeValue>owners", 1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "signed by another key",
+			response: func(t *testing.T) string {
+				return otherIdP.sign(t, defaultTestResponse().document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrapped assertion with duplicate id",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.extra = `<saml:Assertion ID="_assertion"/>`
+				return idp.sign(t, r.document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "encrypted assertion",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.extra = `<saml:EncryptedAssertion/>`
+				return idp.sign(t, r.document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "other audience",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.audience = "https://other.example.com"
+				return idp.sign(t, r.document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "other recipient",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.recipient = "https://other.example.com/acs"
+				return idp.sign(t, r.document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "other issuer",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.issuer = "https://evil.example.com"
+				return idp.sign(t, r.document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.notOnOrAfter = testNow.Add(-5 * time.Minute)
+				return idp.sign(t, r.document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed status",
+			response: func(t *testing.T) string {
+				r := defaultTestResponse()
+				r.status = "urn:oasis:names:tc:SAML:2.0:status:Requester"
+				return idp.sign(t, r.document(), "_assertion", algorithmSHA256)
+			},
+			wantErr: true,
+		},
+		{
+			name: "idp without certificates",
+			response: func(t *testing.T) string {
+				return idp.sign(t, defaultTestResponse().document(), "_assertion", algorithmSHA256)
+			},
+			idp:     &IdentityProvider{EntityID: testIdPEntityID},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sp := &ServiceProvider{EntityID: testSPEntityID, ACSURL: testACSURL, Now: func() time.Time { return testNow }}
+			provider := tc.idp
+			if provider == nil {
+				provider = idp.provider()
+			}
+
+			assertion, err := sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte(tc.response(t))), provider)
+			if tc.wantErr {
+				require.Error(t, err)
+				_, ok := err.(*infra_error.AppError)
+				assert.True(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "_assertion", assertion.ID)
+			assert.Equal(t, testIdPEntityID, assertion.Issuer)
+			assert.Equal(t, "jane@example.com", assertion.NameID)
+			assert.Equal(t, NameIDFormatEmail, assertion.NameIDFormat)
+			assert.Equal(t, "_session", assertion.SessionIndex)
+			assert.Equal(t, "jane@example.com", assertion.Attribute("email"))
+			assert.Equal(t, []string{"admins", "sales"}, assertion.Attributes["groups"])
+			assert.True(t, assertion.ExpiresAt.Equal(testNow.Add(5*time.Minute)))
+		})
+	}
+}
+
+func TestParseIdPMetadata(t *testing.T) {
+	idp := newTestIdP(t)
+	certificate := base64.StdEncoding.EncodeToString(idp.certificate.Raw)
+	metadata := `<md:EntityDescriptor xmlns:md="` + namespaceMetadata + `" xmlns:ds="` + namespaceDSig + `" entityID="` + testIdPEntityID + `">` +
+		`<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">` +
+		`<md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>invalid</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+		`<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + "\n" + certificate + "\n" + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+		`<md:SingleSignOnService Binding="` + BindingHTTPPost + `" Location="https://idp.example.com/sso/post"/>` +
+		`<md:SingleSignOnService Binding="` + BindingHTTPRedirect + `" Location="https://idp.example.com/sso/redirect"/>` +
+		`</md:IDPSSODescriptor></md:EntityDescriptor>`
+
+	parsed, err := ParseIdPMetadata([]byte(metadata))
+	require.NoError(t, err)
+	assert.Equal(t, testIdPEntityID, parsed.EntityID)
+	assert.Equal(t, "https://idp.example.com/sso/redirect", parsed.SSOURL)
+	require.Len(t, parsed.Certificates, 1)
+	assert.True(t, parsed.Certificates[0].Equal(idp.certificate))
+
+	_, err = ParseIdPMetadata([]byte(`<md:EntityDescriptor xmlns:md="` + namespaceMetadata + `" entityID="x"/>`))
+	require.Error(t, err)
+}
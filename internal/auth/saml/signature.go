@@ -0,0 +1,202 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	namespaceDSig = "http://www.w3.org/2000/09/xmldsig#"
+
+	algorithmExcC14N            = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algorithmEnvelopedSignature = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	algorithmSHA256             = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algorithmSHA512             = "http://www.w3.org/2001/04/xmlenc#sha512"
+	algorithmRSASHA256          = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algorithmRSASHA512          = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algorithmECDSASHA256        = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	algorithmECDSASHA512        = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+)
+
+var (
+	// ErrSignatureMissing is returned when neither the response nor the assertion is signed
+	ErrSignatureMissing = errors.New("saml response is not signed")
+	// ErrSignatureInvalid is returned when a signature does not verify against any IdP certificate
+	ErrSignatureInvalid = errors.New("saml signature is invalid")
+)
+
+// digestAlgorithms are the accepted reference digests, SHA-1 is rejected
+var digestAlgorithms = map[string]crypto.Hash{
+	algorithmSHA256: crypto.SHA256,
+	algorithmSHA512: crypto.SHA512,
+}
+
+// signatureAlgorithms are the accepted SignedInfo signature methods
+var signatureAlgorithms = map[string]crypto.Hash{
+	algorithmRSASHA256:   crypto.SHA256,
+	algorithmRSASHA512:   crypto.SHA512,
+	algorithmECDSASHA256: crypto.SHA256,
+	algorithmECDSASHA512: crypto.SHA512,
+}
+
+// signatureOf returns the enveloped signature of el, nil when el is not signed
+func signatureOf(el *element) (*element, error) {
+	return el.findOne(namespaceDSig, "Signature")
+}
+
+// verifySignature checks that signature is an enveloped signature over el made by one of certificates.
+// Only el itself is trusted afterwards, the caller must not look up signed content by ID elsewhere in the document.
+func verifySignature(el, signature *element, certificates []*x509.Certificate) error {
+	signedInfo, err := signature.findOne(namespaceDSig, "SignedInfo")
+	if err != nil || signedInfo == nil {
+		return fmt.Errorf("%w: missing SignedInfo", ErrSignatureInvalid)
+	}
+
+	canonicalization, err := signedInfo.findOne(namespaceDSig, "CanonicalizationMethod")
+	if err != nil || canonicalization == nil || canonicalization.attr("Algorithm") != algorithmExcC14N {
+		return fmt.Errorf("%w: unsupported canonicalization method", ErrSignatureInvalid)
+	}
+	signatureMethod, err := signedInfo.findOne(namespaceDSig, "SignatureMethod")
+	if err != nil || signatureMethod == nil {
+		return fmt.Errorf("%w: missing signature method", ErrSignatureInvalid)
+	}
+	algorithm := signatureMethod.attr("Algorithm")
+	hash, ok := signatureAlgorithms[algorithm]
+	if !ok {
+		return fmt.Errorf("%w: unsupported signature method %q", ErrSignatureInvalid, algorithm)
+	}
+
+	// The signature must cover exactly the element it is enveloped in
+	references := signedInfo.find(namespaceDSig, "Reference")
+	if len(references) != 1 {
+		return fmt.Errorf("%w: expected a single reference", ErrSignatureInvalid)
+	}
+	id := el.attr("ID")
+	if id == "" || references[0].attr("URI") != "#"+id {
+		return fmt.Errorf("%w: reference does not point to the signed element", ErrSignatureInvalid)
+	}
+	if err := verifyReference(el, signature, references[0]); err != nil {
+		return err
+	}
+
+	signatureValue, err := signature.findOne(namespaceDSig, "SignatureValue")
+	if err != nil || signatureValue == nil {
+		return fmt.Errorf("%w: missing signature value", ErrSignatureInvalid)
+	}
+	signatureBytes, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+	}
+
+	canonical := newCanonicalizer(inclusivePrefixes(canonicalization), nil).canonicalize(signedInfo)
+	digest := hashBytes(hash, canonical)
+	for _, certificate := range certificates {
+		if verifyDigest(certificate.PublicKey, hash, digest, signatureBytes) {
+			return nil
+		}
+	}
+	return ErrSignatureInvalid
+}
+
+// verifyReference checks the transforms and the digest of the reference to el
+func verifyReference(el, signature, reference *element) error {
+	// SAML signatures use the enveloped signature transform followed by exclusive canonicalization
+	transforms, err := reference.findOne(namespaceDSig, "Transforms")
+	if err != nil || transforms == nil {
+		return fmt.Errorf("%w: missing transforms", ErrSignatureInvalid)
+	}
+	enveloped, canonical, prefixList := false, false, ""
+	for _, transform := range transforms.childElements() {
+		switch {
+		case !transform.is(namespaceDSig, "Transform"):
+			return fmt.Errorf("%w: unexpected %s in transforms", ErrSignatureInvalid, transform.local)
+		case transform.attr("Algorithm") == algorithmEnvelopedSignature && !enveloped:
+			enveloped = true
+		case transform.attr("Algorithm") == algorithmExcC14N && !canonical:
+			canonical = true
+			prefixList = inclusivePrefixes(transform)
+		default:
+			return fmt.Errorf("%w: unsupported transform %q", ErrSignatureInvalid, transform.attr("Algorithm"))
+		}
+	}
+	if !enveloped || !canonical {
+		return fmt.Errorf("%w: unsupported transforms", ErrSignatureInvalid)
+	}
+
+	digestMethod, err := reference.findOne(namespaceDSig, "DigestMethod")
+	if err != nil || digestMethod == nil {
+		return fmt.Errorf("%w: missing digest method", ErrSignatureInvalid)
+	}
+	hash, ok := digestAlgorithms[digestMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("%w: unsupported digest method %q", ErrSignatureInvalid, digestMethod.attr("Algorithm"))
+	}
+	digestValue, err := reference.findOne(namespaceDSig, "DigestValue")
+	if err != nil || digestValue == nil {
+		return fmt.Errorf("%w: missing digest value", ErrSignatureInvalid)
+	}
+	expected, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+	}
+
+	actual := hashBytes(hash, newCanonicalizer(prefixList, signature).canonicalize(el))
+	if subtle.ConstantTimeCompare(expected, actual) != 1 {
+		return fmt.Errorf("%w: digest mismatch", ErrSignatureInvalid)
+	}
+	return nil
+}
+
+// inclusivePrefixes returns the PrefixList of the InclusiveNamespaces child of a canonicalization method
+func inclusivePrefixes(method *element) string {
+	for _, child := range method.childElements() {
+		if child.is(algorithmExcC14N, "InclusiveNamespaces") {
+			return child.attr("PrefixList")
+		}
+	}
+	return ""
+}
+
+func verifyDigest(publicKey any, hash crypto.Hash, digest, signature []byte) bool {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// XML signatures carry ECDSA values as the concatenation of r and s
+		if len(signature) == 0 || len(signature)%2 != 0 {
+			return false
+		}
+		half := len(signature) / 2
+		r := new(big.Int).SetBytes(signature[:half])
+		s := new(big.Int).SetBytes(signature[half:])
+		return ecdsa.Verify(key, digest, r, s)
+	default:
+		return false
+	}
+}
+
+func hashBytes(hash crypto.Hash, data []byte) []byte {
+	switch hash {
+	case crypto.SHA512:
+		sum := sha512.Sum512(data)
+		return sum[:]
+	default:
+		sum := sha256.Sum256(data)
+		return sum[:]
+	}
+}
+
+// decodeBase64 decodes base64 content of XML elements, which may be wrapped over several lines
+func decodeBase64(value string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+}
@@ -0,0 +1,441 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// Filter is a parsed filter of a list call, e.g. userName eq "jane@example.com" and active eq true
+type Filter interface {
+	// Matches reports whether resource, the JSON object of a resource, passes the filter
+	Matches(resource map[string]any) bool
+}
+
+// caseExactAttributes are compared case sensitively, the other string attributes are not
+var caseExactAttributes = map[string]bool{"id": true, "externalid": true}
+
+// comparisonOperators are the operators comparing an attribute with a value
+var comparisonOperators = map[string]bool{"eq": true, "ne": true, "co": true, "sw": true, "ew": true, "gt": true, "ge": true, "lt": true, "le": true}
+
+// ParseFilter parses a filter of RFC 7644 section 3.4.2.2: the comparison and presence operators, and, or, not,
+// grouping and the value filters of the multi-valued attributes, e.g. emails[type eq "work" and value co "@example.com"].
+// Multi-valued attributes match when any of their values does
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, invalidFilter(err)
+	}
+	p := &parser{tokens: tokens}
+	parsed, err := p.parseOr()
+	if err != nil {
+		return nil, invalidFilter(err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, invalidFilter(fmt.Errorf("unexpected %q", p.tokens[p.pos].text))
+	}
+	return parsed, nil
+}
+
+func invalidFilter(err error) error {
+	return infra_error.Validation(infra_error.ValidationInvalidFormat, "filter").WithDetails(scimTypeDetail, "invalidFilter").WithError(err)
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpen
+	tokenClose
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits filter into words, quoted strings, parentheses and brackets
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; c {
+		case ' ', '\t':
+			i++
+		case '(':
+			tokens = append(tokens, token{kind: tokenOpen, text: "("})
+			i++
+		case ')':
+			tokens = append(tokens, token{kind: tokenClose, text: ")"})
+			i++
+		case '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, text: "["})
+			i++
+		case ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, text: "]"})
+			i++
+		case '"':
+			end := i + 1
+			for end < len(filter) && filter[end] != '"' {
+				if filter[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(filter) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &value); err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %w", i, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && !strings.ContainsRune(" \t()[]\"", rune(filter[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: filter[i:end]})
+			i = end
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser of the tokens of a filter, or binds looser than and
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) next() (token, error) {
+	if p.pos >= len(p.tokens) {
+		return token{}, fmt.Errorf("unexpected end of filter")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != kind {
+		return fmt.Errorf("expected %q, got %q", text, t.text)
+	}
+	return nil
+}
+
+// peekWord reports whether the next token is the keyword word
+func (p *parser) peekWord(word string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenWord && strings.EqualFold(p.tokens[p.pos].text, word)
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right, or: true}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("and") {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseFactor() (Filter, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case t.kind == tokenOpen:
+		return p.parseGroup(tokenClose, ")")
+	case t.kind == tokenWord && strings.EqualFold(t.text, "not"):
+		if err := p.expect(tokenOpen, "("); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseGroup(tokenClose, ")")
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: inner}, nil
+	case t.kind != tokenWord:
+		return nil, fmt.Errorf("expected an attribute, got %q", t.text)
+	}
+
+	path := parsePath(t.text)
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOpenBracket {
+		p.pos++
+		inner, err := p.parseGroup(tokenCloseBracket, "]")
+		if err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{path: path, filter: inner}, nil
+	}
+	operator, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToLower(operator.text)
+	if operator.kind == tokenWord && op == "pr" {
+		return &presentFilter{path: path}, nil
+	}
+	if operator.kind != tokenWord || !comparisonOperators[op] {
+		return nil, fmt.Errorf("unknown operator %q", operator.text)
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &comparisonFilter{path: path, op: op, value: value, caseExact: len(path) == 1 && caseExactAttributes[path[0]]}, nil
+}
+
+// parseGroup parses the filter up to the closing token of a group
+func (p *parser) parseGroup(closing tokenKind, text string) (Filter, error) {
+	inner, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(closing, text); err != nil {
+		return nil, err
+	}
+	return inner, nil
+}
+
+// parseValue parses a comparison value: a string, true, false, null or a number
+func (p *parser) parseValue() (any, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if t.kind == tokenString {
+		return t.text, nil
+	}
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected a value, got %q", t.text)
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q", t.text)
+	}
+	return number, nil
+}
+
+// parsePath returns the lower cased names of an attribute path. The attributes of an extension are named by the
+// schema URN, e.g. urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department
+func parsePath(attribute string) []string {
+	attribute = strings.ToLower(attribute)
+	if strings.HasPrefix(attribute, "urn:") {
+		if i := strings.LastIndex(attribute, ":"); i > 0 {
+			return append([]string{attribute[:i]}, strings.Split(attribute[i+1:], ".")...)
+		}
+	}
+	return strings.Split(attribute, ".")
+}
+
+// resolve returns the values at path in value, the values of every item of the multi-valued attributes
+func resolve(value any, path []string) []any {
+	if len(path) == 0 {
+		if items, ok := value.([]any); ok {
+			return items
+		}
+		return []any{value}
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if strings.EqualFold(key, path[0]) {
+				return resolve(child, path[1:])
+			}
+		}
+	case []any:
+		var values []any
+		for _, item := range v {
+			values = append(values, resolve(item, path)...)
+		}
+		return values
+	}
+	return nil
+}
+
+type logicalFilter struct {
+	left, right Filter
+	or          bool
+}
+
+func (f *logicalFilter) Matches(resource map[string]any) bool {
+	if f.or {
+		return f.left.Matches(resource) || f.right.Matches(resource)
+	}
+	return f.left.Matches(resource) && f.right.Matches(resource)
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f *notFilter) Matches(resource map[string]any) bool {
+	return !f.filter.Matches(resource)
+}
+
+type presentFilter struct {
+	path []string
+}
+
+func (f *presentFilter) Matches(resource map[string]any) bool {
+	for _, value := range resolve(resource, f.path) {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v != "" {
+				return true
+			}
+		case map[string]any:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// valuePathFilter matches the resources with an item of a multi-valued attribute matching its filter
+type valuePathFilter struct {
+	path   []string
+	filter Filter
+}
+
+func (f *valuePathFilter) Matches(resource map[string]any) bool {
+	for _, value := range resolve(resource, f.path) {
+		if item, ok := value.(map[string]any); ok && f.filter.Matches(item) {
+			return true
+		}
+	}
+	return false
+}
+
+type comparisonFilter struct {
+	path      []string
+	op        string
+	value     any
+	caseExact bool
+}
+
+func (f *comparisonFilter) Matches(resource map[string]any) bool {
+	values := resolve(resource, f.path)
+	if f.op == "ne" {
+		eq := &comparisonFilter{path: f.path, op: "eq", value: f.value, caseExact: f.caseExact}
+		return !eq.Matches(resource)
+	}
+	if f.value == nil && f.op == "eq" {
+		return !(&presentFilter{path: f.path}).Matches(resource)
+	}
+	for _, value := range values {
+		if f.compare(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare compares actual with the value of the filter, the items of the complex multi-valued attributes by their
+// value sub-attribute
+func (f *comparisonFilter) compare(actual any) bool {
+	if item, ok := actual.(map[string]any); ok {
+		actual = item["value"]
+	}
+	switch expected := f.value.(type) {
+	case string:
+		s, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		if !f.caseExact {
+			s, expected = strings.ToLower(s), strings.ToLower(expected)
+		}
+		switch f.op {
+		case "co":
+			return strings.Contains(s, expected)
+		case "sw":
+			return strings.HasPrefix(s, expected)
+		case "ew":
+			return strings.HasSuffix(s, expected)
+		}
+		return compareOrdered(f.op, strings.Compare(s, expected))
+	case float64:
+		n, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case n < expected:
+			return compareOrdered(f.op, -1)
+		case n > expected:
+			return compareOrdered(f.op, 1)
+		}
+		return compareOrdered(f.op, 0)
+	case bool:
+		b, ok := actual.(bool)
+		return ok && f.op == "eq" && b == expected
+	}
+	return false
+}
+
+// compareOrdered applies the eq, gt, ge, lt and le operators to the result of a comparison
+func compareOrdered(op string, c int) bool {
+	switch op {
+	case "eq":
+		return c == 0
+	case "gt":
+		return c > 0
+	case "ge":
+		return c >= 0
+	case "lt":
+		return c < 0
+	case "le":
+		return c <= 0
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package scim
+
+import (
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	resource := map[string]any{
+		"id":         "user-1",
+		"externalId": "AbC",
+		"userName":   "Jane.Doe@Example.com",
+		"active":     true,
+		"name":       map[string]any{"givenName": "Jane", "familyName": "Doe"},
+		"emails": []any{
+			map[string]any{"value": "jane@example.com", "type": "work", "primary": true},
+			map[string]any{"value": "jane@home.example", "type": "home"},
+		},
+		"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": map[string]any{"department": "Sales"},
+		"meta": map[string]any{"created": "2026-01-02T03:04:05Z", "version": 3.0},
+	}
+
+	testCases := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: `userName eq "jane.doe@example.com"`, want: true},
+		{filter: `USERNAME Eq "jane.doe@example.com"`, want: true},
+		{filter: `userName ne "jane.doe@example.com"`, want: false},
+		{filter: `externalId eq "abc"`, want: false},
+		{filter: `externalId eq "AbC"`, want: true},
+		{filter: `id eq "user-1"`, want: true},
+		{filter: `userName sw "jane" and userName ew ".com"`, want: true},
+		{filter: `name.familyName co "o"`, want: true},
+		{filter: `title pr`, want: false},
+		{filter: `name pr`, want: true},
+		{filter: `active eq true`, want: true},
+		{filter: `active eq false or name.givenName eq "John"`, want: false},
+		{filter: `not (active eq false)`, want: true},
+		{filter: `emails eq "jane@home.example"`, want: true},
+		{filter: `emails.value ew "@home.example"`, want: true},
+		{filter: `emails[type eq "work" and value co "@example.com"]`, want: true},
+		{filter: `emails[type eq "work" and value co "@home"]`, want: false},
+		{filter: `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "sales"`, want: true},
+		{filter: `meta.created gt "2026-01-01T00:00:00Z" and meta.version ge 3`, want: true},
+		{filter: `(active eq false or userName eq "x") and id eq "user-1"`, want: false},
+		{filter: `active eq false or userName eq "x" and id eq "user-1" or id eq "user-1"`, want: true},
+		{filter: `title eq null`, want: true},
+		{filter: `userName eq "with \"quotes\""`, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			filter, err := ParseFilter(tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, filter.Matches(resource))
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, filter := range []string{
+		"",
+		`userName`,
+		`userName eq`,
+		`userName like "jane"`,
+		`userName eq "jane`,
+		`userName eq jane`,
+		`(userName eq "jane"`,
+		`userName eq "jane")`,
+		`emails[type eq "work"`,
+		`not userName eq "jane"`,
+		`userName eq "jane" and`,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			_, err := ParseFilter(filter)
+			require.Error(t, err)
+			appErr, ok := infra_error.AsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, "invalidFilter", appErr.Details[scimTypeDetail])
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rolesUpdateMask names the roles of a user, the group memberships change them
+var rolesUpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"roles"}}
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request, c caller) error {
+	filter, err := listFilter(r)
+	if err != nil {
+		return err
+	}
+	roles, err := h.roles.ListRoles(r.Context(), c.tenantID, c.principal, c.tenantID)
+	if err != nil {
+		return err
+	}
+	// The members are only read when returned or filtered on
+	withMembers := !excluded(r, "members")
+	var members map[string][]*authv1.User
+	if withMembers || filter != nil {
+		users, err := h.users.GetUsers(r.Context(), c.tenantID, c.principal, c.tenantID, "")
+		if err != nil {
+			return err
+		}
+		members = roleMembers(users)
+	}
+
+	slices.SortFunc(roles, func(a, b *authv1.Role) int { return strings.Compare(a.GetId(), b.GetId()) })
+	base := baseURL(r)
+	resources := []any{}
+	for _, role := range roles {
+		resource := toSCIMGroup(role, members[role.GetId()], base)
+		ok, err := matches(filter, resource)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if !withMembers {
+			resource.Members = nil
+		}
+		resources = append(resources, resource)
+	}
+	return h.writeList(w, r, resources)
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request, c caller) error {
+	return h.writeGroup(w, r, c, r.PathValue("id"), http.StatusOK)
+}
+
+// createGroup creates a role holding GroupPermissions named after the group, and assigns it to the members
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request, c caller) error {
+	var resource Group
+	if err := readJSON(r, &resource); err != nil {
+		return err
+	}
+	if resource.DisplayName == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "displayName")
+	}
+	role := &authv1.Role{
+		TenantId:    c.tenantID,
+		Name:        resource.DisplayName,
+		Description: "Group provisioned through SCIM",
+		Type:        authv1.RoleType_ROLE_TYPE_CUSTOM,
+		Permissions: slices.Clone(h.config.GroupPermissions),
+		Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
+		CreatedBy:   c.principal,
+	}
+	id, err := h.roles.CreateRole(r.Context(), c.tenantID, c.principal, role, c.tenantID)
+	if err != nil {
+		return err
+	}
+	if err := h.setMembers(r.Context(), c, id, nil, memberIDs(resource.Members)); err != nil {
+		return err
+	}
+	h.logger.Info("group provisioned through scim", "tenant_id", c.tenantID, "principal", c.principal, "role_id", id)
+	return h.writeGroup(w, r, c, id, http.StatusCreated)
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request, c caller) error {
+	var resource Group
+	if err := readJSON(r, &resource); err != nil {
+		return err
+	}
+	role, members, err := h.group(r.Context(), c, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.renameRole(r.Context(), c, role, resource.DisplayName); err != nil {
+		return err
+	}
+	if err := h.setMembers(r.Context(), c, role.GetId(), members, memberIDs(resource.Members)); err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, c, role.GetId(), http.StatusOK)
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request, c caller) error {
+	var patch PatchRequest
+	if err := readJSON(r, &patch); err != nil {
+		return err
+	}
+	role, members, err := h.group(r.Context(), c, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	patched := &groupPatch{name: role.GetName(), members: map[string]bool{}}
+	for _, member := range members {
+		patched.members[member.GetId()] = true
+	}
+	for _, operation := range patch.Operations {
+		if err := patched.apply(operation); err != nil {
+			return err
+		}
+	}
+
+	if err := h.renameRole(r.Context(), c, role, patched.name); err != nil {
+		return err
+	}
+	if err := h.setMembers(r.Context(), c, role.GetId(), members, patched.members); err != nil {
+		return err
+	}
+	return h.writeGroup(w, r, c, role.GetId(), http.StatusOK)
+}
+
+// deleteGroup deletes the role of the group
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request, c caller) error {
+	if err := h.roles.DeleteRole(r.Context(), c.tenantID, c.principal, r.PathValue("id"), c.tenantID); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// group returns the role of a group and the users it is assigned to
+func (h *Handler) group(ctx context.Context, c caller, roleID string) (*authv1.Role, []*authv1.User, error) {
+	role, err := h.roles.GetRoleByID(ctx, c.tenantID, c.principal, roleID, c.tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := h.users.GetUsers(ctx, c.tenantID, c.principal, c.tenantID, roleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return role, members, nil
+}
+
+func (h *Handler) writeGroup(w http.ResponseWriter, r *http.Request, c caller, roleID string, status int) error {
+	role, members, err := h.group(r.Context(), c, roleID)
+	if err != nil {
+		return err
+	}
+	resource := toSCIMGroup(role, members, baseURL(r))
+	if excluded(r, "members") {
+		resource.Members = nil
+	}
+	w.Header().Set("Location", resource.Meta.Location)
+	writeJSON(w, status, resource)
+	return nil
+}
+
+// renameRole names role name, unless it is already named so. The role names are stored lower cased
+func (h *Handler) renameRole(ctx context.Context, c caller, role *authv1.Role, name string) error {
+	if name == "" || strings.EqualFold(name, role.GetName()) {
+		return nil
+	}
+	role.Name = strings.ToLower(name)
+	return h.roles.UpdateRole(ctx, c.tenantID, c.principal, role, c.tenantID)
+}
+
+// setMembers assigns the role to the users of want it is not assigned to, and unassigns it from the users of
+// current missing from want
+func (h *Handler) setMembers(ctx context.Context, c caller, roleID string, current []*authv1.User, want map[string]bool) error {
+	assigned := map[string]bool{}
+	for _, user := range current {
+		assigned[user.GetId()] = true
+		if want[user.GetId()] {
+			continue
+		}
+		roles := slices.DeleteFunc(slices.Clone(user.GetRoles()), func(userRole *authv1.UserRole) bool {
+			return userRole.GetRoleId() == roleID
+		})
+		if err := h.setUserRoles(ctx, c, user, roles); err != nil {
+			return err
+		}
+	}
+
+	added := make([]string, 0, len(want))
+	for userID := range want {
+		if !assigned[userID] {
+			added = append(added, userID)
+		}
+	}
+	slices.Sort(added)
+	for _, userID := range added {
+		user, err := h.users.GetUser(ctx, c.tenantID, c.principal, c.tenantID, userID)
+		if err != nil {
+			return err
+		}
+		roles := append(slices.Clone(user.GetRoles()), &authv1.UserRole{
+			RoleId:     roleID,
+			TenantId:   c.tenantID,
+			AssignedAt: timestamppb.Now(),
+			AssignedBy: c.principal,
+		})
+		if err := h.setUserRoles(ctx, c, user, roles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) setUserRoles(ctx context.Context, c caller, user *authv1.User, roles []*authv1.UserRole) error {
+	update := &authv1.User{
+		Id:       user.GetId(),
+		TenantId: user.GetTenantId(),
+		Version:  user.GetVersion(),
+		Roles:    roles,
+	}
+	_, err := h.users.UpdateUser(ctx, c.tenantID, c.principal, update, rolesUpdateMask)
+	return err
+}
+
+// groupPatch is the name and the member ids of a group being patched
+type groupPatch struct {
+	name    string
+	members map[string]bool
+}
+
+// apply applies operation to the group
+func (g *groupPatch) apply(operation PatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	path := strings.ToLower(operation.Path)
+	switch {
+	case op != "add" && op != "replace" && op != "remove":
+		return invalidPatch("op", fmt.Errorf("unknown operation %q", operation.Op))
+	case path == "":
+		if op == "remove" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "path").WithDetails(scimTypeDetail, "noTarget")
+		}
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return invalidPatch("value", err)
+		}
+		for name, value := range attributes {
+			if err := g.apply(PatchOperation{Op: op, Path: name, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case path == "displayname":
+		if op == "remove" {
+			return immutable("displayName")
+		}
+		if err := json.Unmarshal(operation.Value, &g.name); err != nil {
+			return invalidPatch(operation.Path, err)
+		}
+		return nil
+	case path == "members":
+		var values []MultiValue
+		if len(operation.Value) > 0 {
+			if err := json.Unmarshal(operation.Value, &values); err != nil {
+				return invalidPatch(operation.Path, err)
+			}
+		}
+		switch {
+		case op == "replace" || (op == "remove" && len(values) == 0):
+			g.members = memberIDs(values)
+		case op == "add":
+			for _, value := range values {
+				g.members[value.Value] = true
+			}
+		default:
+			for _, value := range values {
+				delete(g.members, value.Value)
+			}
+		}
+		return nil
+	// The members removed by a value filter, e.g. members[value eq "id"]
+	case strings.HasPrefix(path, "members[") && strings.HasSuffix(path, "]") && op == "remove":
+		filter, err := ParseFilter(operation.Path[len("members[") : len(operation.Path)-1])
+		if err != nil {
+			return err
+		}
+		for userID := range g.members {
+			if filter.Matches(map[string]any{"value": userID}) {
+				delete(g.members, userID)
+			}
+		}
+		return nil
+	}
+	return invalidPatch(operation.Path, fmt.Errorf("unsupported attribute"))
+}
+
+// toSCIMGroup returns the SCIM resource of role, its members are the users it is assigned to
+func toSCIMGroup(role *authv1.Role, members []*authv1.User, base string) *Group {
+	resource := &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          role.GetId(),
+		DisplayName: role.GetName(),
+		Meta: &Meta{
+			ResourceType: resourceTypeGroup,
+			Created:      formatTime(role.GetCreatedAt()),
+			LastModified: formatTime(role.GetUpdatedAt()),
+			Location:     base + "/Groups/" + role.GetId(),
+		},
+	}
+	for _, user := range members {
+		display := user.GetProfile().GetDisplayName()
+		if display == "" {
+			display = userName(user)
+		}
+		resource.Members = append(resource.Members, MultiValue{Value: user.GetId(), Display: display, Ref: base + "/Users/" + user.GetId()})
+	}
+	slices.SortFunc(resource.Members, func(a, b MultiValue) int { return strings.Compare(a.Value, b.Value) })
+	return resource
+}
+
+// roleMembers returns the users of users holding each role, by role id
+func roleMembers(users []*authv1.User) map[string][]*authv1.User {
+	members := map[string][]*authv1.User{}
+	for _, user := range users {
+		for _, userRole := range user.GetRoles() {
+			members[userRole.GetRoleId()] = append(members[userRole.GetRoleId()], user)
+		}
+	}
+	return members
+}
+
+// memberIDs returns the user ids of members
+func memberIDs(members []MultiValue) map[string]bool {
+	ids := make(map[string]bool, len(members))
+	for _, member := range members {
+		if member.Value != "" {
+			ids[member.Value] = true
+		}
+	}
+	return ids
+}
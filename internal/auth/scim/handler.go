@@ -0,0 +1,291 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// maxBodyBytes caps the size of the request bodies
+	maxBodyBytes = 1 << 20
+	// scimTypeDetail is the detail of the errors holding their SCIM error type, e.g. invalidFilter
+	scimTypeDetail = "scim_type"
+)
+
+// tokenAuthenticator resolves the bearer tokens of the calls to their tenant and principal, see api.APIKeyAPI
+type tokenAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (string, string, error)
+}
+
+// userProvisioner is the part of api.UserAPI the users are provisioned through
+type userProvisioner interface {
+	CreateUser(ctx context.Context, tenantID, userID string, newUser *authv1.User, password string) (string, error)
+	GetUser(ctx context.Context, tenantID, userID, targetTenantID, accountID string) (*authv1.User, error)
+	GetUsers(ctx context.Context, tenantID, userID, targetTenantID, roleID string) ([]*authv1.User, error)
+	UpdateUser(ctx context.Context, tenantID, userID string, newUserData *authv1.User, mask *fieldmaskpb.FieldMask) (bool, error)
+	DeprovisionUser(ctx context.Context, tenantID, userID, targetTenantID, accountID string) error
+}
+
+// roleProvisioner is the part of api.RoleAPI the groups are provisioned through
+type roleProvisioner interface {
+	CreateRole(ctx context.Context, tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) (string, error)
+	UpdateRole(ctx context.Context, tenantID, requestorUserID string, role *authv1.Role, targetTenantID string) error
+	GetRoleByID(ctx context.Context, tenantID, requestorUserID, roleID string, targetTenantID string) (*authv1.Role, error)
+	ListRoles(ctx context.Context, tenantID, requestorUserID string, targetTenantID string) ([]*authv1.Role, error)
+	DeleteRole(ctx context.Context, tenantID, requestorUserID, roleID string, targetTenantID string) error
+}
+
+// caller is the tenant of the API key of a call and the principal it acts as
+type caller struct {
+	tenantID  string
+	principal string
+}
+
+// handlerFunc serves an authenticated call, the returned error is written as a SCIM error
+type handlerFunc func(w http.ResponseWriter, r *http.Request, c caller) error
+
+// Handler serves the SCIM endpoints under BasePath
+type Handler struct {
+	mux    *http.ServeMux
+	tokens tokenAuthenticator
+	users  userProvisioner
+	roles  roleProvisioner
+	config Config
+	logger logger.Logger
+}
+
+func NewHandler(tokens tokenAuthenticator, users userProvisioner, roles roleProvisioner, config *Config, logger logger.Logger) *Handler {
+	h := &Handler{
+		mux:    http.NewServeMux(),
+		tokens: tokens,
+		users:  users,
+		roles:  roles,
+		config: *config,
+		logger: logger,
+	}
+	// The discovery endpoints describe the service, they are not authenticated
+	h.mux.HandleFunc("GET "+BasePath+"/ServiceProviderConfig", h.serviceProviderConfig)
+	h.mux.HandleFunc("GET "+BasePath+"/ResourceTypes", h.resourceTypes)
+
+	h.mux.Handle("GET "+BasePath+"/Users", h.authenticated(h.listUsers))
+	h.mux.Handle("POST "+BasePath+"/Users", h.authenticated(h.createUser))
+	h.mux.Handle("GET "+BasePath+"/Users/{id}", h.authenticated(h.getUser))
+	h.mux.Handle("PUT "+BasePath+"/Users/{id}", h.authenticated(h.replaceUser))
+	h.mux.Handle("PATCH "+BasePath+"/Users/{id}", h.authenticated(h.patchUser))
+	h.mux.Handle("DELETE "+BasePath+"/Users/{id}", h.authenticated(h.deleteUser))
+
+	h.mux.Handle("GET "+BasePath+"/Groups", h.authenticated(h.listGroups))
+	h.mux.Handle("POST "+BasePath+"/Groups", h.authenticated(h.createGroup))
+	h.mux.Handle("GET "+BasePath+"/Groups/{id}", h.authenticated(h.getGroup))
+	h.mux.Handle("PUT "+BasePath+"/Groups/{id}", h.authenticated(h.replaceGroup))
+	h.mux.Handle("PATCH "+BasePath+"/Groups/{id}", h.authenticated(h.patchGroup))
+	h.mux.Handle("DELETE "+BasePath+"/Groups/{id}", h.authenticated(h.deleteGroup))
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// authenticated resolves the bearer token of the call to its caller before serving it with fn
+func (h *Handler) authenticated(fn handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		token = strings.TrimSpace(token)
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+			writeError(w, infra_error.Auth(infra_error.AuthUnauthenticated))
+			return
+		}
+		tenantID, principal, err := h.tokens.AuthenticateAPIKey(r.Context(), token)
+		if err != nil {
+			h.logger.Warn("scim token rejected", "method", r.Method, "path", r.URL.Path, "error", err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="scim", error="invalid_token"`)
+			writeError(w, err)
+			return
+		}
+		if err := fn(w, r, caller{tenantID: tenantID, principal: principal}); err != nil {
+			h.logger.Warn("scim call failed", "method", r.Method, "path", r.URL.Path, "tenant_id", tenantID, "principal", principal, "error", err)
+			writeError(w, err)
+		}
+	})
+}
+
+func (h *Handler) serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"schemas":        []string{SchemaServiceProviderConfig},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": h.config.MaxResults},
+		"changePassword": map[string]any{"supported": false},
+		"sort":           map[string]any{"supported": false},
+		"etag":           map[string]any{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "An API key of the tenant sent as a bearer token",
+			"primary":     true,
+		}},
+	})
+}
+
+func (h *Handler) resourceTypes(w http.ResponseWriter, r *http.Request) {
+	resourceType := func(name, endpoint, schema string, extensions ...string) map[string]any {
+		schemaExtensions := []map[string]any{}
+		for _, extension := range extensions {
+			schemaExtensions = append(schemaExtensions, map[string]any{"schema": extension, "required": false})
+		}
+		return map[string]any{
+			"schemas":          []string{SchemaResourceType},
+			"id":               name,
+			"name":             name,
+			"endpoint":         endpoint,
+			"schema":           schema,
+			"schemaExtensions": schemaExtensions,
+			"meta":             &Meta{ResourceType: "ResourceType", Location: baseURL(r) + "/ResourceTypes/" + name},
+		}
+	}
+	resources := []any{
+		resourceType(resourceTypeUser, "/Users", SchemaUser, SchemaEnterpriseUser),
+		resourceType(resourceTypeGroup, "/Groups", SchemaGroup),
+	}
+	writeJSON(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// listFilter returns the filter of a list call, nil when it sets none
+func listFilter(r *http.Request) (Filter, error) {
+	filter := strings.TrimSpace(r.URL.Query().Get("filter"))
+	if filter == "" {
+		return nil, nil
+	}
+	return ParseFilter(filter)
+}
+
+// matches reports whether the JSON object of resource passes filter, every resource passes a nil filter
+func matches(filter Filter, resource any) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return false, err
+	}
+	var object map[string]any
+	if err := json.Unmarshal(body, &object); err != nil {
+		return false, err
+	}
+	return filter.Matches(object), nil
+}
+
+// excluded reports whether the call excludes attribute from the returned resources
+func excluded(r *http.Request, attribute string) bool {
+	for _, name := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(name), attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeList writes the page of resources the startIndex and count of the call select. The index starts at 1, the
+// page holds up to MaxResults resources
+func (h *Handler) writeList(w http.ResponseWriter, r *http.Request, resources []any) error {
+	query := r.URL.Query()
+	startIndex, count := 1, h.config.MaxResults
+	if value := query.Get("startIndex"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "startIndex").WithError(err)
+		}
+		startIndex = max(parsed, 1)
+	}
+	if value := query.Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "count").WithError(err)
+		}
+		count = min(max(parsed, 0), h.config.MaxResults)
+	}
+
+	from := min(startIndex-1, len(resources))
+	to := min(from+count, len(resources))
+	writeJSON(w, http.StatusOK, &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: to - from,
+		Resources:    resources[from:to],
+	})
+	return nil
+}
+
+// readJSON decodes the body of the call into v
+func readJSON(r *http.Request, v any) error {
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes)).Decode(v); err != nil {
+		return infra_error.Validation(infra_error.ValidationInvalidFormat, "body").WithDetails(scimTypeDetail, "invalidSyntax").WithError(err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes err as a SCIM error with the HTTP status of its catalog code or category
+func writeError(w http.ResponseWriter, err error) {
+	appErr, ok := infra_error.AsAppError(err)
+	if !ok {
+		appErr = infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	status := infra_error.GetHTTPStatus(appErr)
+	writeJSON(w, status, &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType(appErr),
+		Detail:   appErr.RenderMessage(),
+	})
+}
+
+// scimType returns the SCIM error type of err, set by the SCIM layer or derived from its category
+func scimType(err *infra_error.AppError) string {
+	if scimType, ok := err.Details[scimTypeDetail].(string); ok {
+		return scimType
+	}
+	switch {
+	case err.Code == infra_error.ValidationTryToChangeRestrictedFields.Code:
+		return "mutability"
+	case err.Category == infra_error.CategoryConflict:
+		return "uniqueness"
+	case err.Category == infra_error.CategoryValidation:
+		return "invalidValue"
+	}
+	return ""
+}
+
+// baseURL returns the URL of BasePath on the host the call was made to
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	return scheme + "://" + r.Host + BasePath
+}
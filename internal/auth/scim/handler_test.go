@@ -0,0 +1,368 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/fieldmask"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testToken     = "erp_test-key"
+	testTenantID  = "tenant-1"
+	testPrincipal = "apikey:key-1"
+)
+
+type fakeTokens struct{}
+
+func (fakeTokens) AuthenticateAPIKey(_ context.Context, key string) (string, string, error) {
+	if key != testToken {
+		return "", "", infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	return testTenantID, testPrincipal, nil
+}
+
+type fakeUsers struct {
+	users         map[string]*authv1.User
+	created       int
+	deprovisioned []string
+}
+
+func (f *fakeUsers) CreateUser(_ context.Context, _, _ string, newUser *authv1.User, password string) (string, error) {
+	for _, user := range f.users {
+		if strings.EqualFold(user.GetUsername(), newUser.GetUsername()) {
+			return "", infra_error.Conflict(infra_error.ConflictDuplicateUsername)
+		}
+	}
+	f.created++
+	user := proto.Clone(newUser).(*authv1.User)
+	user.Id = fmt.Sprintf("user-%d", f.created)
+	user.Version = 1
+	user.CreatedAt = timestamppb.Now()
+	if password != "" {
+		user.PasswordHash = "hashed:" + password
+	}
+	f.users[user.Id] = user
+	return user.Id, nil
+}
+
+func (f *fakeUsers) GetUser(_ context.Context, _, _, _, accountID string) (*authv1.User, error) {
+	user, ok := f.users[accountID]
+	if !ok {
+		return nil, infra_error.NotFound(infra_error.NotFoundUser, "user", accountID)
+	}
+	return proto.Clone(user).(*authv1.User), nil
+}
+
+func (f *fakeUsers) GetUsers(_ context.Context, _, _, _, roleID string) ([]*authv1.User, error) {
+	users := []*authv1.User{}
+	for _, user := range f.users {
+		if roleID == "" || hasRole(user, roleID) {
+			users = append(users, proto.Clone(user).(*authv1.User))
+		}
+	}
+	return users, nil
+}
+
+func (f *fakeUsers) UpdateUser(_ context.Context, _, _ string, newUserData *authv1.User, mask *fieldmaskpb.FieldMask) (bool, error) {
+	user, ok := f.users[newUserData.GetId()]
+	if !ok {
+		return false, infra_error.NotFound(infra_error.NotFoundUser, "user", newUserData.GetId())
+	}
+	if user.GetVersion() != newUserData.GetVersion() {
+		return false, infra_error.Conflict(infra_error.ConflictResourceModified)
+	}
+	if err := fieldmask.Apply(user, newUserData, mask); err != nil {
+		return false, err
+	}
+	user.Version++
+	return true, nil
+}
+
+func (f *fakeUsers) DeprovisionUser(_ context.Context, _, _, _, accountID string) error {
+	user, ok := f.users[accountID]
+	if !ok {
+		return infra_error.NotFound(infra_error.NotFoundUser, "user", accountID)
+	}
+	user.Status = authv1.UserStatus_USER_STATUS_INACTIVE
+	user.Version++
+	f.deprovisioned = append(f.deprovisioned, accountID)
+	return nil
+}
+
+func hasRole(user *authv1.User, roleID string) bool {
+	for _, userRole := range user.GetRoles() {
+		if userRole.GetRoleId() == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeRoles struct {
+	roles   map[string]*authv1.Role
+	created int
+}
+
+func (f *fakeRoles) CreateRole(_ context.Context, _, _ string, role *authv1.Role, _ string) (string, error) {
+	for _, existing := range f.roles {
+		if existing.GetName() == strings.ToLower(role.GetName()) {
+			return "", infra_error.Conflict(infra_error.ConflictDuplicateRole)
+		}
+	}
+	f.created++
+	stored := proto.Clone(role).(*authv1.Role)
+	stored.Id = fmt.Sprintf("role-%d", f.created)
+	stored.Name = strings.ToLower(stored.Name)
+	f.roles[stored.Id] = stored
+	return stored.Id, nil
+}
+
+func (f *fakeRoles) UpdateRole(_ context.Context, _, _ string, role *authv1.Role, _ string) error {
+	if _, ok := f.roles[role.GetId()]; !ok {
+		return infra_error.NotFound(infra_error.NotFoundRole, "role", role.GetId())
+	}
+	f.roles[role.GetId()] = proto.Clone(role).(*authv1.Role)
+	return nil
+}
+
+func (f *fakeRoles) GetRoleByID(_ context.Context, _, _, roleID string, _ string) (*authv1.Role, error) {
+	role, ok := f.roles[roleID]
+	if !ok {
+		return nil, infra_error.NotFound(infra_error.NotFoundRole, "role", roleID)
+	}
+	return proto.Clone(role).(*authv1.Role), nil
+}
+
+func (f *fakeRoles) ListRoles(_ context.Context, _, _ string, _ string) ([]*authv1.Role, error) {
+	roles := []*authv1.Role{}
+	for _, role := range f.roles {
+		roles = append(roles, proto.Clone(role).(*authv1.Role))
+	}
+	return roles, nil
+}
+
+func (f *fakeRoles) DeleteRole(_ context.Context, _, _, roleID string, _ string) error {
+	if _, ok := f.roles[roleID]; !ok {
+		return infra_error.NotFound(infra_error.NotFoundRole, "role", roleID)
+	}
+	delete(f.roles, roleID)
+	return nil
+}
+
+func newTestHandler() (*Handler, *fakeUsers, *fakeRoles) {
+	users := &fakeUsers{users: map[string]*authv1.User{}}
+	roles := &fakeRoles{roles: map[string]*authv1.Role{}}
+	config := &Config{MaxResults: 50, GroupPermissions: []string{"user:read"}}
+	return NewHandler(fakeTokens{}, users, roles, config, logger.NewBaseLogger(shared.ModuleAuth)), users, roles
+}
+
+// call serves a call of the test API key with body as JSON
+func call(t *testing.T, h http.Handler, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, "http://erp.example"+BasePath+path, reader)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set("Content-Type", ContentType)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) *T {
+	t.Helper()
+	var v T
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &v), rec.Body.String())
+	return &v
+}
+
+func patchOp(op, path string, value any) PatchRequest {
+	raw, _ := json.Marshal(value)
+	return PatchRequest{Schemas: []string{SchemaPatchOp}, Operations: []PatchOperation{{Op: op, Path: path, Value: raw}}}
+}
+
+func TestHandler_Authentication(t *testing.T) {
+	h, _, _ := newTestHandler()
+
+	req := httptest.NewRequest(http.MethodGet, BasePath+"/Users", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, SchemaError, decode[Error](t, rec).Schemas[0])
+
+	req = httptest.NewRequest(http.MethodGet, BasePath+"/Users", nil)
+	req.Header.Set("Authorization", "Bearer not-a-key")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// The discovery endpoints are public
+	req = httptest.NewRequest(http.MethodGet, BasePath+"/ServiceProviderConfig", nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+}
+
+func TestHandler_Users(t *testing.T) {
+	h, users, _ := newTestHandler()
+
+	rec := call(t, h, http.MethodPost, "/Users", map[string]any{
+		"schemas":            []string{SchemaUser},
+		"userName":           "jane@example.com",
+		"externalId":         "idp-1",
+		"name":               map[string]any{"givenName": "Jane", "familyName": "Doe"},
+		SchemaEnterpriseUser: map[string]any{"department": "Sales"},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	created := decode[User](t, rec)
+	assert.Equal(t, "user-1", created.ID)
+	assert.Equal(t, "http://erp.example/scim/v2/Users/user-1", rec.Header().Get("Location"))
+	assert.True(t, *created.Active)
+	assert.Equal(t, "Sales", created.Enterprise.Department)
+	stored := users.users["user-1"]
+	assert.Equal(t, "jane@example.com", stored.GetEmail(), "the email defaults to an email username")
+	assert.Equal(t, "idp-1", stored.GetExternalId())
+	assert.Equal(t, testPrincipal, stored.GetCreatedBy())
+	assert.NotEmpty(t, stored.GetPasswordHash(), "the users without a password get a random one")
+
+	rec = call(t, h, http.MethodPost, "/Users", map[string]any{"userName": "JANE@example.com"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "uniqueness", decode[Error](t, rec).ScimType)
+	rec = call(t, h, http.MethodPost, "/Users", map[string]any{
+		"userName": "john",
+		"emails":   []map[string]any{{"value": "john@example.com", "primary": true}},
+		"password": "Secret-123",
+	})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "hashed:Secret-123", users.users["user-2"].GetPasswordHash())
+	assert.Empty(t, decode[User](t, rec).Password, "the password is never returned")
+
+	rec = call(t, h, http.MethodGet, `/Users?filter=userName+eq+"Jane@Example.com"`, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	list := decode[ListResponse](t, rec)
+	assert.Equal(t, 1, list.TotalResults)
+	rec = call(t, h, http.MethodGet, "/Users?startIndex=2&count=1", nil)
+	list = decode[ListResponse](t, rec)
+	assert.Equal(t, 2, list.TotalResults)
+	assert.Equal(t, 1, list.ItemsPerPage)
+	assert.Equal(t, "user-2", list.Resources[0].(map[string]any)["id"])
+	rec = call(t, h, http.MethodGet, `/Users?filter=userName+like+"jane"`, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "invalidFilter", decode[Error](t, rec).ScimType)
+
+	// Deactivating a user deprovisions it, activating it again reactivates the same account
+	rec = call(t, h, http.MethodPatch, "/Users/user-1", patchOp("Replace", "active", "False"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.False(t, *decode[User](t, rec).Active)
+	assert.Equal(t, []string{"user-1"}, users.deprovisioned)
+	rec = call(t, h, http.MethodPatch, "/Users/user-1", patchOp("replace", "", map[string]any{"active": true, "title": "Manager"}))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, authv1.UserStatus_USER_STATUS_ACTIVE, users.users["user-1"].GetStatus())
+	assert.Equal(t, "Manager", users.users["user-1"].GetProfile().GetTitle())
+	assert.Equal(t, "Jane", users.users["user-1"].GetProfile().GetFirstName(), "a patch keeps the attributes it does not name")
+
+	rec = call(t, h, http.MethodPut, "/Users/user-1", map[string]any{"userName": "jane@example.com", "displayName": "Jane D."})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "Jane D.", users.users["user-1"].GetProfile().GetDisplayName())
+	assert.Empty(t, users.users["user-1"].GetProfile().GetFirstName(), "a replace clears the attributes it does not set")
+	rec = call(t, h, http.MethodPut, "/Users/user-1", map[string]any{"userName": "someone-else"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "mutability", decode[Error](t, rec).ScimType)
+	rec = call(t, h, http.MethodPatch, "/Users/user-1", patchOp("replace", "nickName", "J"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "invalidPath", decode[Error](t, rec).ScimType)
+
+	// Deleted users are kept deactivated
+	rec = call(t, h, http.MethodDelete, "/Users/user-2", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	require.Contains(t, users.users, "user-2")
+	assert.Equal(t, authv1.UserStatus_USER_STATUS_INACTIVE, users.users["user-2"].GetStatus())
+	rec = call(t, h, http.MethodGet, "/Users/user-2", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, *decode[User](t, rec).Active)
+	rec = call(t, h, http.MethodGet, "/Users/user-9", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandler_Groups(t *testing.T) {
+	h, users, roles := newTestHandler()
+	for _, userName := range []string{"jane", "john", "joe"} {
+		rec := call(t, h, http.MethodPost, "/Users", map[string]any{"userName": userName})
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	rec := call(t, h, http.MethodPost, "/Groups", map[string]any{
+		"schemas":     []string{SchemaGroup},
+		"displayName": "Sales",
+		"members":     []map[string]any{{"value": "user-1"}, {"value": "user-2"}},
+	})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	group := decode[Group](t, rec)
+	assert.Equal(t, "role-1", group.ID)
+	assert.Equal(t, []string{"user-1", "user-2"}, []string{group.Members[0].Value, group.Members[1].Value})
+	assert.Equal(t, []string{"user:read"}, roles.roles["role-1"].GetPermissions())
+	assert.Equal(t, testPrincipal, users.users["user-1"].GetRoles()[0].GetAssignedBy())
+
+	rec = call(t, h, http.MethodGet, "/Users/user-1", nil)
+	groups := decode[User](t, rec).Groups
+	require.Len(t, groups, 1)
+	assert.Equal(t, MultiValue{Value: "role-1", Display: "sales", Ref: "http://erp.example/scim/v2/Groups/role-1"}, groups[0])
+
+	patch := PatchRequest{Schemas: []string{SchemaPatchOp}}
+	for _, operation := range []PatchRequest{
+		patchOp("remove", `members[value eq "user-1"]`, nil),
+		patchOp("add", "members", []map[string]any{{"value": "user-3"}}),
+		patchOp("replace", "displayName", "Sales EMEA"),
+	} {
+		patch.Operations = append(patch.Operations, operation.Operations...)
+	}
+	rec = call(t, h, http.MethodPatch, "/Groups/role-1", patch)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	group = decode[Group](t, rec)
+	assert.Equal(t, "sales emea", group.DisplayName)
+	assert.Equal(t, []string{"user-2", "user-3"}, []string{group.Members[0].Value, group.Members[1].Value})
+	assert.Empty(t, users.users["user-1"].GetRoles())
+
+	rec = call(t, h, http.MethodGet, `/Groups?filter=displayName+eq+"SALES+EMEA"&excludedAttributes=members`, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	list := decode[ListResponse](t, rec)
+	require.Equal(t, 1, list.TotalResults)
+	assert.NotContains(t, list.Resources[0], "members")
+	rec = call(t, h, http.MethodGet, `/Groups?filter=members.value+eq+"user-1"`, nil)
+	assert.Equal(t, 0, decode[ListResponse](t, rec).TotalResults)
+
+	rec = call(t, h, http.MethodPut, "/Groups/role-1", map[string]any{"displayName": "Sales EMEA", "members": []map[string]any{{"value": "user-1"}}})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Len(t, decode[Group](t, rec).Members, 1)
+	assert.Empty(t, users.users["user-2"].GetRoles())
+
+	rec = call(t, h, http.MethodPost, "/Groups", map[string]any{"displayName": "sales emea"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	rec = call(t, h, http.MethodDelete, "/Groups/role-1", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, roles.roles)
+}
@@ -0,0 +1,131 @@
+// Package scim serves the SCIM 2.0 (RFC 7643, RFC 7644) Users and Groups resources of the tenants, so the identity
+// providers of the enterprises provision their users. SCIM users are the users of the tenant and SCIM groups its
+// roles, the members of a group are the users the role is assigned to.
+//
+// The calls are authenticated with an API key of the tenant sent as a bearer token, they act with its scopes. A
+// deleted user is deprovisioned: it is deactivated and its sessions revoked, but kept so it can be reactivated.
+package scim
+
+import (
+	"encoding/json"
+)
+
+const (
+	// BasePath prefixes the paths of the endpoints
+	BasePath = "/scim/v2"
+	// ContentType of the requests and responses, plain application/json is accepted too
+	ContentType = "application/scim+json"
+
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	resourceTypeUser  = "User"
+	resourceTypeGroup = "Group"
+)
+
+// Config holds the settings of the SCIM endpoint, loaded with infra_config.Load
+type Config struct {
+	// Enabled serves the endpoint on Port
+	Enabled bool `yaml:"enabled" env:"SCIM_ENABLED"`
+	Port    int  `yaml:"port" env:"SCIM_PORT" default:"8000" validate:"port"`
+	// MaxResults caps the resources of a list response, it is also the page size when the call sets none
+	MaxResults int `yaml:"max_results" env:"SCIM_MAX_RESULTS" default:"200" validate:"positive"`
+	// GroupPermissions are held by the roles of the groups created through SCIM, a role holds at least one
+	// permission. The tenant admins then grant the roles what their groups need
+	GroupPermissions []string `yaml:"group_permissions" env:"SCIM_GROUP_PERMISSIONS" default:"user:read"`
+}
+
+// Meta holds the attributes of a resource set by the service
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// Name holds the components of the name of a user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// MultiValue is an item of a multi-valued attribute, e.g. an email of a user or a member of a group
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// EnterpriseUser holds the attributes of the enterprise user extension
+type EnterpriseUser struct {
+	Department string `json:"department,omitempty"`
+}
+
+// User is the SCIM representation of a user
+type User struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *Name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	// Active is true when unset
+	Active *bool `json:"active,omitempty"`
+	// Password is only read, it is never returned
+	Password     string          `json:"password,omitempty"`
+	Emails       []MultiValue    `json:"emails,omitempty"`
+	PhoneNumbers []MultiValue    `json:"phoneNumbers,omitempty"`
+	Groups       []MultiValue    `json:"groups,omitempty"`
+	Enterprise   *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta         *Meta           `json:"meta,omitempty"`
+}
+
+// Group is the SCIM representation of a role
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []MultiValue `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+// ListResponse is a page of the resources matching a query
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// PatchRequest changes the attributes of a resource named by its operations, in order
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation adds, replaces or removes the attribute at Path, or the attributes of Value when Path is empty
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Error is the body of the failed calls
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
@@ -0,0 +1,490 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/auth/oidc"
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_auth "erp.localhost/internal/infra/model/auth/validator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// userUpdateMask names the fields of a user SCIM changes, the username, email and credentials are not
+var userUpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"profile", "status", "external_id"}}
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request, c caller) error {
+	filter, err := listFilter(r)
+	if err != nil {
+		return err
+	}
+	users, err := h.users.GetUsers(r.Context(), c.tenantID, c.principal, c.tenantID, "")
+	if err != nil {
+		return err
+	}
+	roleNames, err := h.roleNames(r.Context(), c)
+	if err != nil {
+		return err
+	}
+
+	// Users are listed by id, so the pages of a listing do not overlap
+	slices.SortFunc(users, func(a, b *authv1.User) int { return strings.Compare(a.GetId(), b.GetId()) })
+	base := baseURL(r)
+	resources := []any{}
+	for _, user := range users {
+		resource := toSCIMUser(user, roleNames, base)
+		ok, err := matches(filter, resource)
+		if err != nil {
+			return err
+		}
+		if ok {
+			resources = append(resources, resource)
+		}
+	}
+	return h.writeList(w, r, resources)
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request, c caller) error {
+	user, err := h.users.GetUser(r.Context(), c.tenantID, c.principal, c.tenantID, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	return h.writeUser(w, r, c, user, http.StatusOK)
+}
+
+// createUser creates the user of the body. Users without a password get a random one, they log in through the single
+// sign-on of the identity provider
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request, c caller) error {
+	var resource User
+	if err := readJSON(r, &resource); err != nil {
+		return err
+	}
+	user, err := newUser(c, &resource)
+	if err != nil {
+		return err
+	}
+	id, err := h.users.CreateUser(r.Context(), c.tenantID, c.principal, user, resource.Password)
+	if err != nil {
+		return err
+	}
+	created, err := h.users.GetUser(r.Context(), c.tenantID, c.principal, c.tenantID, id)
+	if err != nil {
+		return err
+	}
+	h.logger.Info("user provisioned through scim", "tenant_id", c.tenantID, "principal", c.principal, "user_id", id)
+	return h.writeUser(w, r, c, created, http.StatusCreated)
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request, c caller) error {
+	var resource User
+	if err := readJSON(r, &resource); err != nil {
+		return err
+	}
+	stored, err := h.users.GetUser(r.Context(), c.tenantID, c.principal, c.tenantID, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	updated, err := h.updateUser(r.Context(), c, stored, &resource)
+	if err != nil {
+		return err
+	}
+	return h.writeUser(w, r, c, updated, http.StatusOK)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request, c caller) error {
+	var patch PatchRequest
+	if err := readJSON(r, &patch); err != nil {
+		return err
+	}
+	stored, err := h.users.GetUser(r.Context(), c.tenantID, c.principal, c.tenantID, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	resource := toSCIMUser(stored, nil, "")
+	for _, operation := range patch.Operations {
+		if err := patchUser(resource, operation); err != nil {
+			return err
+		}
+	}
+	updated, err := h.updateUser(r.Context(), c, stored, resource)
+	if err != nil {
+		return err
+	}
+	return h.writeUser(w, r, c, updated, http.StatusOK)
+}
+
+// deleteUser deprovisions the user, it is kept deactivated
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request, c caller) error {
+	if err := h.users.DeprovisionUser(r.Context(), c.tenantID, c.principal, c.tenantID, r.PathValue("id")); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// updateUser applies resource to stored and returns the updated user. A user becoming inactive is deprovisioned,
+// an inactive one becoming active is reactivated
+func (h *Handler) updateUser(ctx context.Context, c caller, stored *authv1.User, resource *User) (*authv1.User, error) {
+	if resource.UserName != "" && !strings.EqualFold(resource.UserName, userName(stored)) {
+		return nil, immutable("userName")
+	}
+	if email := primaryValue(resource.Emails); email != "" && !strings.EqualFold(email, stored.GetEmail()) {
+		return nil, immutable("emails")
+	}
+
+	update := &authv1.User{
+		Id:         stored.GetId(),
+		TenantId:   stored.GetTenantId(),
+		Version:    stored.GetVersion(),
+		Profile:    &authv1.UserProfile{},
+		Status:     stored.GetStatus(),
+		ExternalId: resource.ExternalID,
+	}
+	if stored.GetProfile() != nil {
+		update.Profile = proto.Clone(stored.GetProfile()).(*authv1.UserProfile)
+	}
+	setProfile(update.Profile, resource)
+	active := isActive(resource)
+	if active && !userActive(stored) {
+		update.Status = authv1.UserStatus_USER_STATUS_ACTIVE
+	}
+	if _, err := h.users.UpdateUser(ctx, c.tenantID, c.principal, update, userUpdateMask); err != nil {
+		return nil, err
+	}
+	if !active && userActive(stored) {
+		if err := h.users.DeprovisionUser(ctx, c.tenantID, c.principal, c.tenantID, stored.GetId()); err != nil {
+			return nil, err
+		}
+	}
+	return h.users.GetUser(ctx, c.tenantID, c.principal, c.tenantID, stored.GetId())
+}
+
+// writeUser writes user with the groups of its roles
+func (h *Handler) writeUser(w http.ResponseWriter, r *http.Request, c caller, user *authv1.User, status int) error {
+	var roleNames map[string]string
+	if len(user.GetRoles()) > 0 {
+		var err error
+		if roleNames, err = h.roleNames(r.Context(), c); err != nil {
+			return err
+		}
+	}
+	resource := toSCIMUser(user, roleNames, baseURL(r))
+	w.Header().Set("Location", resource.Meta.Location)
+	writeJSON(w, status, resource)
+	return nil
+}
+
+// roleNames returns the names of the roles of the tenant of the call by id
+func (h *Handler) roleNames(ctx context.Context, c caller) (map[string]string, error) {
+	roles, err := h.roles.ListRoles(ctx, c.tenantID, c.principal, c.tenantID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(roles))
+	for _, role := range roles {
+		names[role.GetId()] = role.GetName()
+	}
+	return names, nil
+}
+
+// newUser returns the user to create for resource
+func newUser(c caller, resource *User) (*authv1.User, error) {
+	if resource.UserName == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "userName")
+	}
+	user := &authv1.User{
+		TenantId:   c.tenantID,
+		Username:   resource.UserName,
+		Email:      primaryValue(resource.Emails),
+		Profile:    &authv1.UserProfile{},
+		Status:     authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:  c.principal,
+		ExternalId: resource.ExternalID,
+	}
+	if user.Email == "" && validator_auth.IsValidEmail(resource.UserName) {
+		user.Email = resource.UserName
+	}
+	if !isActive(resource) {
+		user.Status = authv1.UserStatus_USER_STATUS_INACTIVE
+	}
+	setProfile(user.Profile, resource)
+
+	// A random password keeps password login impossible until the user sets one
+	if resource.Password == "" {
+		randomPassword, err := oidc.RandomValue()
+		if err != nil {
+			return nil, err
+		}
+		if user.PasswordHash, err = hash.Hash(randomPassword); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// setProfile sets the profile attributes of resource on profile, the unset ones are cleared
+func setProfile(profile *authv1.UserProfile, resource *User) {
+	profile.FirstName, profile.LastName = "", ""
+	if resource.Name != nil {
+		profile.FirstName = resource.Name.GivenName
+		profile.LastName = resource.Name.FamilyName
+	}
+	profile.DisplayName = resource.DisplayName
+	if profile.DisplayName == "" && resource.Name != nil {
+		profile.DisplayName = resource.Name.Formatted
+	}
+	profile.Title = resource.Title
+	profile.Phone = primaryValue(resource.PhoneNumbers)
+	profile.Department = ""
+	if resource.Enterprise != nil {
+		profile.Department = resource.Enterprise.Department
+	}
+}
+
+// toSCIMUser returns the SCIM resource of user, its groups are the roles of roleNames it holds
+func toSCIMUser(user *authv1.User, roleNames map[string]string, base string) *User {
+	profile := user.GetProfile()
+	active := userActive(user)
+	resource := &User{
+		Schemas:     []string{SchemaUser},
+		ID:          user.GetId(),
+		ExternalID:  user.GetExternalId(),
+		UserName:    userName(user),
+		DisplayName: profile.GetDisplayName(),
+		Title:       profile.GetTitle(),
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      formatTime(user.GetCreatedAt()),
+			LastModified: formatTime(user.GetUpdatedAt()),
+			Location:     base + "/Users/" + user.GetId(),
+			Version:      `W/"` + strconv.FormatInt(user.GetVersion(), 10) + `"`,
+		},
+	}
+	if profile.GetFirstName() != "" || profile.GetLastName() != "" {
+		resource.Name = &Name{
+			GivenName:  profile.GetFirstName(),
+			FamilyName: profile.GetLastName(),
+			Formatted:  strings.TrimSpace(profile.GetFirstName() + " " + profile.GetLastName()),
+		}
+	}
+	if user.GetEmail() != "" {
+		resource.Emails = []MultiValue{{Value: user.GetEmail(), Type: "work", Primary: true}}
+	}
+	if profile.GetPhone() != "" {
+		resource.PhoneNumbers = []MultiValue{{Value: profile.GetPhone(), Type: "work", Primary: true}}
+	}
+	if profile.GetDepartment() != "" {
+		resource.Schemas = append(resource.Schemas, SchemaEnterpriseUser)
+		resource.Enterprise = &EnterpriseUser{Department: profile.GetDepartment()}
+	}
+	for _, userRole := range user.GetRoles() {
+		if name, ok := roleNames[userRole.GetRoleId()]; ok {
+			resource.Groups = append(resource.Groups, MultiValue{Value: userRole.GetRoleId(), Display: name, Ref: base + "/Groups/" + userRole.GetRoleId()})
+		}
+	}
+	return resource
+}
+
+// patchUser applies operation to resource
+func patchUser(resource *User, operation PatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "replace" && op != "remove" {
+		return invalidPatch("op", fmt.Errorf("unknown operation %q", operation.Op))
+	}
+	remove := op == "remove"
+	if operation.Path != "" {
+		return setUserAttribute(resource, operation.Path, operation.Value, remove)
+	}
+	if remove {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "path").WithDetails(scimTypeDetail, "noTarget")
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+		return invalidPatch("value", err)
+	}
+	for path, value := range attributes {
+		if err := setUserAttribute(resource, path, value, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setUserAttribute sets the attribute of resource at path to value, or clears it on remove
+func setUserAttribute(resource *User, path string, value json.RawMessage, remove bool) error {
+	lower := strings.ToLower(path)
+	enterprise := strings.ToLower(SchemaEnterpriseUser)
+	switch {
+	case lower == "active":
+		if remove {
+			resource.Active = nil
+			return nil
+		}
+		active, err := parseBool(value)
+		if err != nil {
+			return invalidPatch(path, err)
+		}
+		resource.Active = &active
+		return nil
+	case lower == "username":
+		return setString(&resource.UserName, path, value, remove)
+	case lower == "externalid":
+		return setString(&resource.ExternalID, path, value, remove)
+	case lower == "displayname":
+		return setString(&resource.DisplayName, path, value, remove)
+	case lower == "title":
+		return setString(&resource.Title, path, value, remove)
+	case lower == "name":
+		if remove {
+			resource.Name = nil
+			return nil
+		}
+		var name Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return invalidPatch(path, err)
+		}
+		resource.Name = &name
+		return nil
+	case strings.HasPrefix(lower, "name."):
+		if resource.Name == nil {
+			resource.Name = &Name{}
+		}
+		switch lower {
+		case "name.givenname":
+			return setString(&resource.Name.GivenName, path, value, remove)
+		case "name.familyname":
+			return setString(&resource.Name.FamilyName, path, value, remove)
+		case "name.formatted":
+			return setString(&resource.Name.Formatted, path, value, remove)
+		}
+	// Identity providers name the primary value of the multi-valued attributes with a value filter, e.g.
+	// emails[type eq "work"].value, each user has a single email and phone number
+	case strings.HasPrefix(lower, "emails"):
+		return setMultiValue(&resource.Emails, path, value, remove)
+	case strings.HasPrefix(lower, "phonenumbers"):
+		return setMultiValue(&resource.PhoneNumbers, path, value, remove)
+	case lower == enterprise:
+		if remove {
+			resource.Enterprise = nil
+			return nil
+		}
+		var extension EnterpriseUser
+		if err := json.Unmarshal(value, &extension); err != nil {
+			return invalidPatch(path, err)
+		}
+		resource.Enterprise = &extension
+		return nil
+	case lower == enterprise+":department":
+		if resource.Enterprise == nil {
+			resource.Enterprise = &EnterpriseUser{}
+		}
+		return setString(&resource.Enterprise.Department, path, value, remove)
+	}
+	return invalidPatch(path, fmt.Errorf("unsupported attribute"))
+}
+
+func setString(target *string, path string, value json.RawMessage, remove bool) error {
+	if remove {
+		*target = ""
+		return nil
+	}
+	if err := json.Unmarshal(value, target); err != nil {
+		return invalidPatch(path, err)
+	}
+	return nil
+}
+
+// setMultiValue sets target to value: a list of values, a single value or the plain string of the primary value
+func setMultiValue(target *[]MultiValue, path string, value json.RawMessage, remove bool) error {
+	if remove {
+		*target = nil
+		return nil
+	}
+	var values []MultiValue
+	if err := json.Unmarshal(value, &values); err == nil {
+		*target = values
+		return nil
+	}
+	var single MultiValue
+	if err := json.Unmarshal(value, &single); err == nil {
+		*target = []MultiValue{single}
+		return nil
+	}
+	var plain string
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return invalidPatch(path, err)
+	}
+	*target = []MultiValue{{Value: plain, Primary: true}}
+	return nil
+}
+
+// parseBool parses a boolean, also sent as a string by some identity providers, e.g. "False"
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(s)
+}
+
+func invalidPatch(path string, err error) error {
+	return infra_error.Validation(infra_error.ValidationInvalidValue, path).WithDetails(scimTypeDetail, "invalidPath").WithError(err)
+}
+
+func immutable(attribute string) error {
+	return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields, attribute)
+}
+
+// primaryValue returns the primary value of values, the first one when none is primary
+func primaryValue(values []MultiValue) string {
+	for _, value := range values {
+		if value.Primary {
+			return value.Value
+		}
+	}
+	if len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
+
+// isActive reports whether resource is active, the users are active unless set otherwise
+func isActive(resource *User) bool {
+	return resource.Active == nil || *resource.Active
+}
+
+// userActive reports whether user may log in, the invited users are active
+func userActive(user *authv1.User) bool {
+	status := user.GetStatus()
+	return status == authv1.UserStatus_USER_STATUS_ACTIVE || status == authv1.UserStatus_USER_STATUS_INVITED
+}
+
+// userName returns the username of user, its email when it has none
+func userName(user *authv1.User) string {
+	if user.GetUsername() != "" {
+		return user.GetUsername()
+	}
+	return user.GetEmail()
+}
+
+func formatTime(timestamp *timestamppb.Timestamp) string {
+	if timestamp == nil {
+		return ""
+	}
+	return timestamp.AsTime().UTC().Format(time.RFC3339)
+}
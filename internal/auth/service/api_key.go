@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/auth/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+type APIKeyService struct {
+	logger    logger.Logger
+	apiKeyAPI *api.APIKeyAPI
+	authv1.UnimplementedAPIKeyServiceServer
+}
+
+func NewAPIKeyService(apiKeyAPI *api.APIKeyAPI, logger logger.Logger) *APIKeyService {
+	return &APIKeyService{
+		logger:    logger,
+		apiKeyAPI: apiKeyAPI,
+	}
+}
+
+func (a *APIKeyService) CreateAPIKey(ctx context.Context, req *authv1.CreateAPIKeyRequest) (*authv1.CreateAPIKeyResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	apiKey, key, err := a.apiKeyAPI.CreateAPIKey(ctx, tenantID, userID, req.GetName(), req.GetScopes(), req.GetExpiresAt())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to create api key", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.CreateAPIKeyResponse{
+		ApiKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (a *APIKeyService) RevokeAPIKey(ctx context.Context, req *authv1.RevokeAPIKeyRequest) (*authv1.RevokeAPIKeyResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := a.apiKeyAPI.RevokeAPIKey(ctx, tenantID, userID, req.GetKeyId()); err != nil {
+		a.logger.WithContext(ctx).Error("failed to revoke api key", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.RevokeAPIKeyResponse{
+		Revoked: true,
+	}, nil
+}
+
+func (a *APIKeyService) ListAPIKeys(ctx context.Context, req *authv1.ListAPIKeysRequest) (*authv1.ListAPIKeysResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	apiKeys, err := a.apiKeyAPI.ListAPIKeys(ctx, tenantID, userID, req.GetIncludeRevoked())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to list api keys", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ListAPIKeysResponse{
+		ApiKeys: apiKeys,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"erp.localhost/internal/auth/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	validator_infra "erp.localhost/internal/infra/model/infra/validator"
+)
+
+type AuditService struct {
+	logger   logger.Logger
+	auditAPI *api.AuditAPI
+	authv1.UnimplementedAuditServiceServer
+}
+
+func NewAuditService(auditAPI *api.AuditAPI, logger logger.Logger) *AuditService {
+	return &AuditService{
+		logger:   logger,
+		auditAPI: auditAPI,
+	}
+}
+
+// StreamAuditEvents streams the audit events of the tenant until the client cancels the call
+func (a *AuditService) StreamAuditEvents(req *authv1.StreamAuditEventsRequest, stream authv1.AuditService_StreamAuditEventsServer) error {
+	ctx := stream.Context()
+	identifier := req.GetIdentifier()
+	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
+		a.logger.WithContext(ctx).Error("invalid identifier", "error", err)
+		return infra_error.ToGRPCError(err)
+	}
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	targetTenantID := req.GetTargetTenantId()
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+
+	if err := a.auditAPI.StreamAuditEvents(ctx, tenantID, userID, targetTenantID, req.GetFilter(), req.GetCursor(), stream.Send); err != nil {
+		a.logger.WithContext(ctx).Error("failed to stream audit events", "target_tenant_id", targetTenantID, "error", err)
+		return infra_error.ToGRPCError(err)
+	}
+	return nil
+}
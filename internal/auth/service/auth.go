@@ -9,7 +9,6 @@ import (
 	"erp.localhost/internal/infra/logging/logger"
 
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
-	validator_infra "erp.localhost/internal/infra/model/infra/validator"
 )
 
 type AuthService struct {
@@ -31,9 +30,9 @@ func (a *AuthService) Login(ctx context.Context, req *authv1.LoginRequest) (*aut
 	email := req.GetEmail()
 	username := req.GetUsername()
 
-	newTokenResponse, err := a.authAPI.Login(tenantID, email, username, userPassword)
+	newTokenResponse, err := a.authAPI.Login(ctx, tenantID, email, username, userPassword, req.GetMfaCode())
 	if err != nil {
-		a.logger.Error("failed to authenticate", "error", err.Error())
+		a.logger.WithContext(ctx).Error("failed to authenticate", "error", err.Error())
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -46,26 +45,24 @@ func (a *AuthService) Login(ctx context.Context, req *authv1.LoginRequest) (*aut
 			Token:        newTokenResponse.TokenExpiresAt,
 			RefreshToken: newTokenResponse.RefreshTokenExpiresAt,
 		},
+		Risk: &authv1.LoginRisk{
+			Score:   newTokenResponse.RiskScore,
+			Reasons: newTokenResponse.RiskReasons,
+		},
 	}, nil
 }
 
 func (a *AuthService) Logout(ctx context.Context, req *authv1.LogoutRequest) (*authv1.LogoutResponse, error) {
-	// Validate input
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		a.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	tokens := req.GetTokens()
 
-	message, err := a.authAPI.Logout(tenantID, userID, tokens.GetToken(), tokens.GetRefreshToken(), userID)
+	message, err := a.authAPI.Logout(ctx, tenantID, userID, tokens.GetToken(), tokens.GetRefreshToken(), userID)
 	if err != nil {
-		a.logger.Error("failed to logout", "tenantID", tenantID, "userID", userID, "error", err.Error())
+		a.logger.WithContext(ctx).Error("failed to logout", "tenantID", tenantID, "userID", userID, "error", err.Error())
 	} else {
-		a.logger.Info("logout successful", "tenantID", tenantID, "userID", userID)
+		a.logger.WithContext(ctx).Info("logout successful", "tenantID", tenantID, "userID", userID)
 	}
 
 	return &authv1.LogoutResponse{
@@ -74,35 +71,29 @@ func (a *AuthService) Logout(ctx context.Context, req *authv1.LogoutRequest) (*a
 }
 
 func (a *AuthService) VerifyToken(ctx context.Context, req *authv1.VerifyTokenRequest) (*authv1.VerifyTokenResponse, error) {
-	err := a.authAPI.VerifyToken(req.GetToken())
+	err := a.authAPI.VerifyToken(ctx, req.GetToken())
 	if err != nil {
-		a.logger.Error("failed to verify token", "error", err)
+		a.logger.WithContext(ctx).Error("failed to verify token", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
-	a.logger.Debug("token verified")
+	a.logger.WithContext(ctx).Debug("token verified")
 	return &authv1.VerifyTokenResponse{
 		Valid: true,
 	}, nil
 }
 
 func (a *AuthService) RefreshToken(ctx context.Context, req *authv1.RefreshTokenRequest) (*authv1.TokensResponse, error) {
-	// Validate input
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		a.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	token := req.GetRefreshToken()
 
-	newTokenResponse, err := a.authAPI.RefreshToken(tenantID, userID, token)
+	newTokenResponse, err := a.authAPI.RefreshToken(ctx, tenantID, userID, token)
 	if err != nil {
-		a.logger.Error("failed to refresh token", "tenantID", tenantID, "userID", userID, "error", err)
+		a.logger.WithContext(ctx).Error("failed to refresh token", "tenantID", tenantID, "userID", userID, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
-	a.logger.Debug("tokens refreshed successfuly", "tenantID", tenantID, "userID", userID)
+	a.logger.WithContext(ctx).Debug("tokens refreshed successfuly", "tenantID", tenantID, "userID", userID)
 	return &authv1.TokensResponse{
 		Tokens: &authv1.Tokens{
 			Token:        newTokenResponse.Token,
@@ -116,48 +107,34 @@ func (a *AuthService) RefreshToken(ctx context.Context, req *authv1.RefreshToken
 }
 
 func (a *AuthService) RevokeToken(ctx context.Context, req *authv1.RevokeTokenRequest) (*authv1.RevokeTokenResponse, error) {
-	// Validate input
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		a.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
 	tenantID := req.GetIdentifier().GetTenantId()
 	userID := req.GetIdentifier().GetUserId()
 	revokedBy := req.GetRevokedBy()
 	token := req.GetTokens().GetToken()
 	refreshToken := req.GetTokens().GetRefreshToken()
 
-	if err := a.authAPI.RevokeTokens(tenantID, userID, token, refreshToken, revokedBy); err != nil {
-		a.logger.Error("failed to revoke token", "tenantID", tenantID, "userID", userID, "revokedBy", revokedBy, "error", err)
+	if err := a.authAPI.RevokeTokens(ctx, tenantID, userID, token, refreshToken, revokedBy); err != nil {
+		a.logger.WithContext(ctx).Error("failed to revoke token", "tenantID", tenantID, "userID", userID, "revokedBy", revokedBy, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
-	a.logger.Debug("token revoked successfuly", "tenantID", tenantID, "userID", userID, "revokedBy", revokedBy)
+	a.logger.WithContext(ctx).Debug("token revoked successfuly", "tenantID", tenantID, "userID", userID, "revokedBy", revokedBy)
 	return &authv1.RevokeTokenResponse{
 		Revoked: true,
 	}, nil
 }
 
 func (a *AuthService) RevokeAllTenantTokens(ctx context.Context, req *authv1.RevokeAllTenantTokensRequest) (*authv1.RevokeAllTenantTokensResponse, error) {
-	// Validate input
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		a.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	// Validate input
 	tenantID := req.GetIdentifier().GetTenantId()
 	userID := req.GetIdentifier().GetUserId()
 	targetTenantID := req.GetTargetTenantId()
 
-	accessCount, refreshCount, err := a.authAPI.RevokeAllTenantTokens(tenantID, userID, targetTenantID)
+	accessCount, refreshCount, err := a.authAPI.RevokeAllTenantTokens(ctx, tenantID, userID, targetTenantID)
 	if err != nil {
-		a.logger.Error("Failed to revoke tenant tokens", "error", err, "tenant_id", tenantID)
+		a.logger.WithContext(ctx).Error("Failed to revoke tenant tokens", "error", err, "tenant_id", tenantID)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
-	a.logger.Info("All tenant tokens revoked", "tenant_id", tenantID, "access_tokens_revoked", accessCount, "refresh_tokens_revoked", refreshCount)
+	a.logger.WithContext(ctx).Info("All tenant tokens revoked", "tenant_id", tenantID, "access_tokens_revoked", accessCount, "refresh_tokens_revoked", refreshCount)
 
 	return &authv1.RevokeAllTenantTokensResponse{
 		Revoked:              true,
@@ -165,3 +142,276 @@ func (a *AuthService) RevokeAllTenantTokens(ctx context.Context, req *authv1.Rev
 		RefreshTokensRevoked: int32(refreshCount),
 	}, nil
 }
+
+func (a *AuthService) EnrollMFA(ctx context.Context, req *authv1.EnrollMFARequest) (*authv1.EnrollMFAResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	secret, uri, err := a.authAPI.EnrollMFA(ctx, tenantID, userID)
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to enroll mfa", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.EnrollMFAResponse{
+		Secret:          secret,
+		ProvisioningUri: uri,
+	}, nil
+}
+
+func (a *AuthService) VerifyMFAEnrollment(ctx context.Context, req *authv1.VerifyMFAEnrollmentRequest) (*authv1.VerifyMFAEnrollmentResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	recoveryCodes, err := a.authAPI.VerifyMFAEnrollment(ctx, tenantID, userID, req.GetCode())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to verify mfa enrollment", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.VerifyMFAEnrollmentResponse{
+		Enabled:       true,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (a *AuthService) DisableMFA(ctx context.Context, req *authv1.DisableMFARequest) (*authv1.DisableMFAResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := a.authAPI.DisableMFA(ctx, tenantID, userID, req.GetCode()); err != nil {
+		a.logger.WithContext(ctx).Error("failed to disable mfa", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.DisableMFAResponse{
+		Disabled: true,
+	}, nil
+}
+
+func (a *AuthService) GetOIDCAuthURL(ctx context.Context, req *authv1.GetOIDCAuthURLRequest) (*authv1.GetOIDCAuthURLResponse, error) {
+	tenantID := req.GetTenantId()
+	userID := ""
+	// The identifier is only set to link the provider account to a logged in user
+	if identifier := req.GetIdentifier(); identifier != nil {
+		if tenantID != "" && tenantID != identifier.GetTenantId() {
+			return nil, infra_error.ToGRPCError(infra_error.Auth(infra_error.AuthTenantAccessDenied))
+		}
+		tenantID = identifier.GetTenantId()
+		userID = identifier.GetUserId()
+	}
+
+	authURL, state, err := a.authAPI.GetOIDCAuthURL(ctx, tenantID, req.GetProvider(), userID)
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to get oidc auth url", "tenantID", tenantID, "provider", req.GetProvider(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.GetOIDCAuthURLResponse{
+		AuthUrl: authURL,
+		State:   state,
+	}, nil
+}
+
+func (a *AuthService) LoginWithOIDC(ctx context.Context, req *authv1.LoginWithOIDCRequest) (*authv1.TokensResponse, error) {
+	tenantID := req.GetTenantId()
+
+	newTokenResponse, err := a.authAPI.LoginWithOIDC(ctx, tenantID, req.GetState(), req.GetCode(), req.GetMfaCode())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to authenticate with oidc", "tenantID", tenantID, "error", err.Error())
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	return &authv1.TokensResponse{
+		Tokens: &authv1.Tokens{
+			Token:        newTokenResponse.Token,
+			RefreshToken: newTokenResponse.RefreshToken,
+		},
+		ExpiresIn: &authv1.ExpiresIn{
+			Token:        newTokenResponse.TokenExpiresAt,
+			RefreshToken: newTokenResponse.RefreshTokenExpiresAt,
+		},
+		Risk: &authv1.LoginRisk{
+			Score:   newTokenResponse.RiskScore,
+			Reasons: newTokenResponse.RiskReasons,
+		},
+	}, nil
+}
+
+func (a *AuthService) LinkExternalIdentity(ctx context.Context, req *authv1.LinkExternalIdentityRequest) (*authv1.LinkExternalIdentityResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	externalIdentity, err := a.authAPI.LinkExternalIdentity(ctx, tenantID, userID, req.GetState(), req.GetCode())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to link external identity", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.LinkExternalIdentityResponse{
+		ExternalIdentity: externalIdentity,
+	}, nil
+}
+
+func (a *AuthService) UnlinkExternalIdentity(ctx context.Context, req *authv1.UnlinkExternalIdentityRequest) (*authv1.UnlinkExternalIdentityResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := a.authAPI.UnlinkExternalIdentity(ctx, tenantID, userID, req.GetProvider()); err != nil {
+		a.logger.WithContext(ctx).Error("failed to unlink external identity", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.UnlinkExternalIdentityResponse{
+		Unlinked: true,
+	}, nil
+}
+
+func (a *AuthService) GetSAMLConfig(ctx context.Context, req *authv1.GetSAMLConfigRequest) (*authv1.SAMLConfigResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	res, err := a.authAPI.GetSAMLConfig(ctx, tenantID, userID, req.GetTargetTenantId())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to get saml config", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return res, nil
+}
+
+func (a *AuthService) SetSAMLConfig(ctx context.Context, req *authv1.SetSAMLConfigRequest) (*authv1.SAMLConfigResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	res, err := a.authAPI.SetSAMLConfig(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetSettings())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to set saml config", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return res, nil
+}
+
+func (a *AuthService) LoginWithSAML(ctx context.Context, req *authv1.LoginWithSAMLRequest) (*authv1.TokensResponse, error) {
+	tenantID := req.GetTenantId()
+
+	newTokenResponse, err := a.authAPI.LoginWithSAML(ctx, tenantID, req.GetSamlResponse(), req.GetMfaCode())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to authenticate with saml", "tenantID", tenantID, "error", err.Error())
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	return &authv1.TokensResponse{
+		Tokens: &authv1.Tokens{
+			Token:        newTokenResponse.Token,
+			RefreshToken: newTokenResponse.RefreshToken,
+		},
+		ExpiresIn: &authv1.ExpiresIn{
+			Token:        newTokenResponse.TokenExpiresAt,
+			RefreshToken: newTokenResponse.RefreshTokenExpiresAt,
+		},
+		Risk: &authv1.LoginRisk{
+			Score:   newTokenResponse.RiskScore,
+			Reasons: newTokenResponse.RiskReasons,
+		},
+	}, nil
+}
+
+func (a *AuthService) RequestLoginLink(ctx context.Context, req *authv1.RequestLoginLinkRequest) (*authv1.RequestLoginLinkResponse, error) {
+	tenantID := req.GetTenantId()
+
+	message, err := a.authAPI.RequestLoginLink(ctx, tenantID, req.GetEmail())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to request login link", "tenantID", tenantID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.RequestLoginLinkResponse{
+		Message: message,
+	}, nil
+}
+
+// CompleteLoginLink is called from the login link, the token itself authenticates the request
+func (a *AuthService) CompleteLoginLink(ctx context.Context, req *authv1.CompleteLoginLinkRequest) (*authv1.TokensResponse, error) {
+	tenantID := req.GetTenantId()
+
+	newTokenResponse, err := a.authAPI.CompleteLoginLink(ctx, tenantID, req.GetToken(), req.GetMfaCode())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to authenticate with login link", "tenantID", tenantID, "error", err.Error())
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	return &authv1.TokensResponse{
+		Tokens: &authv1.Tokens{
+			Token:        newTokenResponse.Token,
+			RefreshToken: newTokenResponse.RefreshToken,
+		},
+		ExpiresIn: &authv1.ExpiresIn{
+			Token:        newTokenResponse.TokenExpiresAt,
+			RefreshToken: newTokenResponse.RefreshTokenExpiresAt,
+		},
+		Risk: &authv1.LoginRisk{
+			Score:   newTokenResponse.RiskScore,
+			Reasons: newTokenResponse.RiskReasons,
+		},
+	}, nil
+}
+
+func (a *AuthService) GetTokenPolicy(ctx context.Context, req *authv1.GetTokenPolicyRequest) (*authv1.TokenPolicyResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	res, err := a.authAPI.GetTokenPolicy(ctx, tenantID, userID, req.GetTargetTenantId())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to get token policy", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return res, nil
+}
+
+func (a *AuthService) SetTokenPolicy(ctx context.Context, req *authv1.SetTokenPolicyRequest) (*authv1.TokenPolicyResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	res, err := a.authAPI.SetTokenPolicy(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetPolicy())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to set token policy", "tenantID", tenantID, "userID", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return res, nil
+}
+
+func (a *AuthService) Impersonate(ctx context.Context, req *authv1.ImpersonateRequest) (*authv1.TokensResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	newTokenResponse, err := a.authAPI.Impersonate(ctx, tenantID, userID, req.GetAccountId(), req.GetReason())
+	if err != nil {
+		a.logger.WithContext(ctx).Error("failed to impersonate", "tenantID", tenantID, "userID", userID, "accountID", req.GetAccountId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.TokensResponse{
+		Tokens: &authv1.Tokens{
+			Token: newTokenResponse.Token,
+		},
+		ExpiresIn: &authv1.ExpiresIn{
+			Token: newTokenResponse.TokenExpiresAt,
+		},
+	}, nil
+}
+
+func (a *AuthService) StopImpersonation(ctx context.Context, req *authv1.StopImpersonationRequest) (*authv1.StopImpersonationResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := a.authAPI.StopImpersonation(ctx, tenantID, userID, req.GetAccountId()); err != nil {
+		a.logger.WithContext(ctx).Error("failed to stop impersonation", "tenantID", tenantID, "userID", userID, "accountID", req.GetAccountId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.StopImpersonationResponse{
+		Stopped: true,
+	}, nil
+}
@@ -8,9 +8,6 @@ import (
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	infrav1 "erp.localhost/internal/infra/model/infra/v1"
-	validator_infra "erp.localhost/internal/infra/model/infra/validator"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // PermissionService implements the gRPC PermissionService
@@ -30,22 +27,16 @@ func NewPermissionService(permissionAPI *api.PermissionAPI, logger logger.Logger
 
 // CreatePermission creates a new permission
 func (ps *PermissionService) CreatePermission(ctx context.Context, req *authv1.CreatePermissionRequest) (*authv1.CreatePermissionResponse, error) {
-	ps.logger.Debug("gRPC CreatePermission called")
-
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		ps.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
+	ps.logger.WithContext(ctx).Debug("gRPC CreatePermission called")
 
 	tenantID := req.GetIdentifier().GetTenantId()
 	userID := req.GetIdentifier().GetUserId()
 	permission := req.GetPermission()
 	targetTenantID := req.GetPermission().GetTenantId()
 
-	permissionID, err := ps.permissionAPI.CreatePermission(tenantID, userID, permission, targetTenantID)
+	permissionID, err := ps.permissionAPI.CreatePermission(ctx, tenantID, userID, permission, targetTenantID)
 	if err != nil {
-		ps.logger.Error("Failed to create permission", "error", err)
+		ps.logger.WithContext(ctx).Error("Failed to create permission", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -54,30 +45,23 @@ func (ps *PermissionService) CreatePermission(ctx context.Context, req *authv1.C
 
 // UpdatePermission updates an existing permission
 func (ps *PermissionService) UpdatePermission(ctx context.Context, req *authv1.UpdatePermissionRequest) (*infrav1.Response, error) {
-	ps.logger.Debug("gRPC UpdatePermission called")
-
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		ps.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
+	ps.logger.WithContext(ctx).Debug("gRPC UpdatePermission called")
 
 	tenantID := req.GetIdentifier().GetTenantId()
 	userID := req.GetIdentifier().GetUserId()
 	permission := req.GetPermission()
 	targetTenantID := req.GetPermission().GetTenantId()
 
-	// 2. Get existing permission
-	existingPermission, err := ps.permissionAPI.GetPermissionByID(tenantID, userID, permission.GetId(), targetTenantID)
+	// 1. Get existing permission
+	existingPermission, err := ps.permissionAPI.GetPermissionByID(ctx, tenantID, userID, permission.GetId(), targetTenantID)
 	if err != nil || existingPermission == nil {
-		ps.logger.Error("Failed to get existing permission", "error", err)
+		ps.logger.WithContext(ctx).Error("Failed to get existing permission", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
-	// 4. Call API layer (with authorization)
-	if err := ps.permissionAPI.UpdatePermission(tenantID, userID, permission, targetTenantID); err != nil {
-		ps.logger.Error("Failed to update permission", "error", err)
+	// 2. Call API layer (with authorization)
+	if err := ps.permissionAPI.UpdatePermission(ctx, tenantID, userID, permission, targetTenantID); err != nil {
+		ps.logger.WithContext(ctx).Error("Failed to update permission", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -88,30 +72,17 @@ func (ps *PermissionService) UpdatePermission(ctx context.Context, req *authv1.U
 
 // GetPermission retrieves a permission by ID
 func (ps *PermissionService) GetPermission(ctx context.Context, req *authv1.GetPermissionRequest) (*authv1.Permission, error) {
-	ps.logger.Debug("gRPC GetPermission called")
-
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		ps.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	if req.GetPermissionId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "permission_id is required")
-	}
-	if req.GetTargetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_tenant_id is required")
-	}
+	ps.logger.WithContext(ctx).Debug("gRPC GetPermission called")
 
-	// 2. Call API layer (with authorization)
-	permission, err := ps.permissionAPI.GetPermissionByID(
+	// Call API layer (with authorization)
+	permission, err := ps.permissionAPI.GetPermissionByID(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetPermissionId(),
 		req.GetTargetTenantId(),
 	)
 	if err != nil {
-		ps.logger.Error("Failed to get permission", "error", err)
+		ps.logger.WithContext(ctx).Error("Failed to get permission", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 	return permission, nil
@@ -119,26 +90,16 @@ func (ps *PermissionService) GetPermission(ctx context.Context, req *authv1.GetP
 
 // ListPermissions retrieves all permissions for a tenant
 func (ps *PermissionService) ListPermissions(ctx context.Context, req *authv1.ListPermissionsRequest) (*authv1.ListPermissionsResponse, error) {
-	ps.logger.Debug("gRPC ListPermissions called")
-
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		ps.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	if req.GetTargetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_tenant_id is required")
-	}
+	ps.logger.WithContext(ctx).Debug("gRPC ListPermissions called")
 
-	// 2. Call API layer (with authorization)
-	permissions, err := ps.permissionAPI.ListPermissions(
+	// Call API layer (with authorization)
+	permissions, err := ps.permissionAPI.ListPermissions(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetTargetTenantId(),
 	)
 	if err != nil {
-		ps.logger.Error("Failed to list permissions", "error", err)
+		ps.logger.WithContext(ctx).Error("Failed to list permissions", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -150,29 +111,16 @@ func (ps *PermissionService) ListPermissions(ctx context.Context, req *authv1.Li
 
 // DeletePermission deletes a permission
 func (ps *PermissionService) DeletePermission(ctx context.Context, req *authv1.DeletePermissionRequest) (*infrav1.Response, error) {
-	ps.logger.Debug("gRPC DeletePermission called")
+	ps.logger.WithContext(ctx).Debug("gRPC DeletePermission called")
 
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		ps.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	if req.GetPermissionId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "permission_id is required")
-	}
-	if req.GetTargetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_tenant_id is required")
-	}
-
-	// 2. Call API layer (with authorization)
-	if err := ps.permissionAPI.DeletePermission(
+	// Call API layer (with authorization)
+	if err := ps.permissionAPI.DeletePermission(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetPermissionId(),
 		req.GetTargetTenantId(),
 	); err != nil {
-		ps.logger.Error("Failed to delete permission", "error", err)
+		ps.logger.WithContext(ctx).Error("Failed to delete permission", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
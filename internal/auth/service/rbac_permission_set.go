@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+)
+
+// CreatePermissionSet creates a new permission set
+func (ps *PermissionService) CreatePermissionSet(ctx context.Context, req *authv1.CreatePermissionSetRequest) (*authv1.CreatePermissionSetResponse, error) {
+	ps.logger.WithContext(ctx).Debug("gRPC CreatePermissionSet called")
+
+	identifier := req.GetIdentifier()
+
+	set := req.GetPermissionSet()
+	setID, err := ps.permissionAPI.CreatePermissionSet(ctx,
+		identifier.GetTenantId(),
+		identifier.GetUserId(),
+		set,
+		set.GetTenantId(),
+	)
+	if err != nil {
+		ps.logger.WithContext(ctx).Error("Failed to create permission set", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	return &authv1.CreatePermissionSetResponse{PermissionSetId: setID}, nil
+}
+
+// UpdatePermissionSet updates an existing permission set
+func (ps *PermissionService) UpdatePermissionSet(ctx context.Context, req *authv1.UpdatePermissionSetRequest) (*infrav1.Response, error) {
+	ps.logger.WithContext(ctx).Debug("gRPC UpdatePermissionSet called")
+
+	identifier := req.GetIdentifier()
+	if req.GetPermissionSet().GetId() == "" {
+		return nil, infra_error.ToGRPCError(infra_error.Validation(infra_error.ValidationRequiredFields, "permission_set.id"))
+	}
+
+	set := req.GetPermissionSet()
+	if err := ps.permissionAPI.UpdatePermissionSet(ctx,
+		identifier.GetTenantId(),
+		identifier.GetUserId(),
+		set,
+		set.GetTenantId(),
+	); err != nil {
+		ps.logger.WithContext(ctx).Error("Failed to update permission set", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	return &infrav1.Response{
+		Success: true,
+	}, nil
+}
+
+// GetPermissionSet retrieves a permission set by ID
+func (ps *PermissionService) GetPermissionSet(ctx context.Context, req *authv1.GetPermissionSetRequest) (*authv1.PermissionSet, error) {
+	ps.logger.WithContext(ctx).Debug("gRPC GetPermissionSet called")
+
+	identifier := req.GetIdentifier()
+
+	set, err := ps.permissionAPI.GetPermissionSetByID(ctx,
+		identifier.GetTenantId(),
+		identifier.GetUserId(),
+		req.GetPermissionSetId(),
+		req.GetTargetTenantId(),
+	)
+	if err != nil {
+		ps.logger.WithContext(ctx).Error("Failed to get permission set", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return set, nil
+}
+
+// ListPermissionSets retrieves all permission sets for a tenant
+func (ps *PermissionService) ListPermissionSets(ctx context.Context, req *authv1.ListPermissionSetsRequest) (*authv1.ListPermissionSetsResponse, error) {
+	ps.logger.WithContext(ctx).Debug("gRPC ListPermissionSets called")
+
+	identifier := req.GetIdentifier()
+
+	sets, err := ps.permissionAPI.ListPermissionSets(ctx,
+		identifier.GetTenantId(),
+		identifier.GetUserId(),
+		req.GetTargetTenantId(),
+	)
+	if err != nil {
+		ps.logger.WithContext(ctx).Error("Failed to list permission sets", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	return &authv1.ListPermissionSetsResponse{PermissionSets: sets}, nil
+}
+
+// DeletePermissionSet deletes a permission set and detaches it from its roles
+func (ps *PermissionService) DeletePermissionSet(ctx context.Context, req *authv1.DeletePermissionSetRequest) (*infrav1.Response, error) {
+	ps.logger.WithContext(ctx).Debug("gRPC DeletePermissionSet called")
+
+	identifier := req.GetIdentifier()
+
+	if err := ps.permissionAPI.DeletePermissionSet(ctx,
+		identifier.GetTenantId(),
+		identifier.GetUserId(),
+		req.GetPermissionSetId(),
+		req.GetTargetTenantId(),
+	); err != nil {
+		ps.logger.WithContext(ctx).Error("Failed to delete permission set", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	return &infrav1.Response{
+		Success: true,
+	}, nil
+}
@@ -8,9 +8,6 @@ import (
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	infrav1 "erp.localhost/internal/infra/model/infra/v1"
-	validator_infra "erp.localhost/internal/infra/model/infra/validator"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // RoleService implements the gRPC RoleService
@@ -30,22 +27,16 @@ func NewRoleService(roleAPI *api.RoleAPI, logger logger.Logger) *RoleService {
 
 // CreateRole creates a new role
 func (rs *RoleService) CreateRole(ctx context.Context, req *authv1.CreateRoleRequest) (*authv1.CreateRoleResponse, error) {
-	rs.logger.Debug("gRPC CreateRole called")
-
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		rs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
+	rs.logger.WithContext(ctx).Debug("gRPC CreateRole called")
 
 	tenantID := req.GetIdentifier().GetTenantId()
 	userID := req.GetIdentifier().GetUserId()
 	role := req.GetRole()
 	targetTenantID := req.GetRole().GetTenantId()
 
-	roleID, err := rs.roleAPI.CreateRole(tenantID, userID, role, targetTenantID)
+	roleID, err := rs.roleAPI.CreateRole(ctx, tenantID, userID, role, targetTenantID)
 	if err != nil {
-		rs.logger.Error("Failed to create role", "error", err)
+		rs.logger.WithContext(ctx).Error("Failed to create role", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -54,30 +45,23 @@ func (rs *RoleService) CreateRole(ctx context.Context, req *authv1.CreateRoleReq
 
 // UpdateRole updates an existing role
 func (rs *RoleService) UpdateRole(ctx context.Context, req *authv1.UpdateRoleRequest) (*infrav1.Response, error) {
-	rs.logger.Debug("gRPC UpdateRole called")
-
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		rs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
+	rs.logger.WithContext(ctx).Debug("gRPC UpdateRole called")
 
 	tenantID := req.GetIdentifier().GetTenantId()
 	userID := req.GetIdentifier().GetUserId()
 	role := req.GetRole()
 	targetTenantID := req.GetRole().GetTenantId()
 
-	// 2. Check if role exists
-	existingRole, err := rs.roleAPI.GetRoleByID(tenantID, userID, role.GetId(), targetTenantID)
+	// 1. Check if role exists
+	existingRole, err := rs.roleAPI.GetRoleByID(ctx, tenantID, userID, role.GetId(), targetTenantID)
 	if err != nil || existingRole == nil {
-		rs.logger.Error("Failed to get existing role", "error", err)
+		rs.logger.WithContext(ctx).Error("Failed to get existing role", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
-	// 4. Call API layer (with authorization)
-	if err := rs.roleAPI.UpdateRole(tenantID, userID, role, targetTenantID); err != nil {
-		rs.logger.Error("Failed to update role", "error", err)
+	// 2. Call API layer (with authorization)
+	if err := rs.roleAPI.UpdateRole(ctx, tenantID, userID, role, targetTenantID); err != nil {
+		rs.logger.WithContext(ctx).Error("Failed to update role", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -88,30 +72,17 @@ func (rs *RoleService) UpdateRole(ctx context.Context, req *authv1.UpdateRoleReq
 
 // GetRole retrieves a role by ID
 func (rs *RoleService) GetRole(ctx context.Context, req *authv1.GetRoleRequest) (*authv1.Role, error) {
-	rs.logger.Debug("gRPC GetRole called")
-
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		rs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	if req.GetRoleId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "role_id is required")
-	}
-	if req.GetTargetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_tenant_id is required")
-	}
+	rs.logger.WithContext(ctx).Debug("gRPC GetRole called")
 
-	// 2. Call API layer (with authorization)
-	role, err := rs.roleAPI.GetRoleByID(
+	// Call API layer (with authorization)
+	role, err := rs.roleAPI.GetRoleByID(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetRoleId(),
 		req.GetTargetTenantId(),
 	)
 	if err != nil {
-		rs.logger.Error("Failed to get role", "error", err)
+		rs.logger.WithContext(ctx).Error("Failed to get role", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 	return role, nil
@@ -119,26 +90,16 @@ func (rs *RoleService) GetRole(ctx context.Context, req *authv1.GetRoleRequest)
 
 // ListRoles retrieves all roles for a tenant
 func (rs *RoleService) ListRoles(ctx context.Context, req *authv1.ListRolesRequest) (*authv1.ListRolesResponse, error) {
-	rs.logger.Debug("gRPC ListRoles called")
-
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		rs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	if req.GetTargetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_tenant_id is required")
-	}
+	rs.logger.WithContext(ctx).Debug("gRPC ListRoles called")
 
-	// 2. Call API layer (with authorization)
-	roles, err := rs.roleAPI.ListRoles(
+	// Call API layer (with authorization)
+	roles, err := rs.roleAPI.ListRoles(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetTargetTenantId(),
 	)
 	if err != nil {
-		rs.logger.Error("Failed to list roles", "error", err)
+		rs.logger.WithContext(ctx).Error("Failed to list roles", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -150,29 +111,16 @@ func (rs *RoleService) ListRoles(ctx context.Context, req *authv1.ListRolesReque
 
 // DeleteRole deletes a role
 func (rs *RoleService) DeleteRole(ctx context.Context, req *authv1.DeleteRoleRequest) (*infrav1.Response, error) {
-	rs.logger.Debug("gRPC DeleteRole called")
+	rs.logger.WithContext(ctx).Debug("gRPC DeleteRole called")
 
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		rs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	if req.GetRoleId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "role_id is required")
-	}
-	if req.GetTargetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_tenant_id is required")
-	}
-
-	// 2. Call API layer (with authorization)
-	if err := rs.roleAPI.DeleteRole(
+	// Call API layer (with authorization)
+	if err := rs.roleAPI.DeleteRole(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetRoleId(),
 		req.GetTargetTenantId(),
 	); err != nil {
-		rs.logger.Error("Failed to delete role", "error", err)
+		rs.logger.WithContext(ctx).Error("Failed to delete role", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -6,10 +6,8 @@ import (
 	"erp.localhost/internal/auth/api"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
-	validator_infra "erp.localhost/internal/infra/model/infra/validator"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // VerificationService implements the gRPC VerificationService
@@ -29,26 +27,21 @@ func NewVerificationService(verificationAPI *api.VerificationAPI, logger logger.
 
 // CheckPermissions checks if a user has specific permissions
 func (vs *VerificationService) CheckPermissions(ctx context.Context, req *authv1.CheckPermissionsRequest) (*authv1.CheckPermissionsResponse, error) {
-	vs.logger.Debug("gRPC CheckPermissions called")
+	vs.logger.WithContext(ctx).Debug("gRPC CheckPermissions called")
 
 	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		vs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
 	if len(req.GetPermissions()) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "permissions list cannot be empty")
+		return nil, infra_error.ToGRPCError(infra_error.Validation(infra_error.ValidationRequiredFields, "permissions"))
 	}
 
 	// 2. Call API layer (no authorization needed - verification service)
-	permissions, err := vs.verificationAPI.CheckPermissions(
+	permissions, err := vs.verificationAPI.CheckPermissions(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetPermissions(),
 	)
 	if err != nil {
-		vs.logger.Error("Failed to check permissions", "error", err)
+		vs.logger.WithContext(ctx).Error("Failed to check permissions", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -57,30 +50,18 @@ func (vs *VerificationService) CheckPermissions(ctx context.Context, req *authv1
 
 // HasPermission checks if a user has a specific permission
 func (vs *VerificationService) HasPermission(ctx context.Context, req *authv1.HasPermissionRequest) (*authv1.HasPermissionResponse, error) {
-	vs.logger.Debug("gRPC HasPermission called")
+	vs.logger.WithContext(ctx).Debug("gRPC HasPermission called")
 
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		vs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-	if req.GetPermission() == "" {
-		return nil, status.Error(codes.InvalidArgument, "permission is required")
-	}
-	if req.GetTargetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "target_tenant_id is required")
-	}
-
-	// 2. Call API layer (no authorization needed - verification service)
-	err := vs.verificationAPI.HasPermission(
+	// 1. Call API layer (no authorization needed - verification service)
+	err := vs.verificationAPI.HasPermissionWithAttributes(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 		req.GetPermission(),
 		req.GetTargetTenantId(),
+		req.GetAttributes(),
 	)
 
-	// 3. Convert error to boolean response
+	// 2. Convert error to boolean response
 	hasPermission := err == nil
 
 	return &authv1.HasPermissionResponse{HasPermission: hasPermission}, nil
@@ -88,22 +69,15 @@ func (vs *VerificationService) HasPermission(ctx context.Context, req *authv1.Ha
 
 // GetUserPermissions retrieves all permissions for a user
 func (vs *VerificationService) GetUserPermissions(ctx context.Context, req *authv1.GetUserPermissionsRequest) (*authv1.GetUserPermissionsResponse, error) {
-	vs.logger.Debug("gRPC GetUserPermissions called")
+	vs.logger.WithContext(ctx).Debug("gRPC GetUserPermissions called")
 
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		vs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
-	// 2. Call API layer (no authorization needed - verification service)
-	permissions, err := vs.verificationAPI.GetUserPermissions(
+	// Call API layer (no authorization needed - verification service)
+	permissions, err := vs.verificationAPI.GetUserPermissions(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 	)
 	if err != nil {
-		vs.logger.Error("Failed to get user permissions", "error", err)
+		vs.logger.WithContext(ctx).Error("Failed to get user permissions", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -112,22 +86,15 @@ func (vs *VerificationService) GetUserPermissions(ctx context.Context, req *auth
 
 // GetUserRoles retrieves all role IDs for a user
 func (vs *VerificationService) GetUserRoles(ctx context.Context, req *authv1.GetUserRolesRequest) (*authv1.GetUserRolesResponse, error) {
-	vs.logger.Debug("gRPC GetUserRoles called")
+	vs.logger.WithContext(ctx).Debug("gRPC GetUserRoles called")
 
-	// 1. Validate request
-	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		vs.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
-	// 2. Call API layer (no authorization needed - verification service)
-	roleIDs, err := vs.verificationAPI.GetUserRoles(
+	// Call API layer (no authorization needed - verification service)
+	roleIDs, err := vs.verificationAPI.GetUserRoles(ctx,
 		req.GetIdentifier().GetTenantId(),
 		req.GetIdentifier().GetUserId(),
 	)
 	if err != nil {
-		vs.logger.Error("Failed to get user roles", "error", err)
+		vs.logger.WithContext(ctx).Error("Failed to get user roles", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -136,11 +103,11 @@ func (vs *VerificationService) GetUserRoles(ctx context.Context, req *authv1.Get
 
 // IsSystemTenantUser checks if a tenant is the system tenant
 func (vs *VerificationService) IsSystemTenantUser(ctx context.Context, req *authv1.IsSystemTenantUserRequest) (*authv1.IsSystemTenantUserResponse, error) {
-	vs.logger.Debug("gRPC IsSystemTenantUser called")
+	vs.logger.WithContext(ctx).Debug("gRPC IsSystemTenantUser called")
 
 	// 1. Validate request
 	if req.GetTenantId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
+		return nil, infra_error.ToGRPCError(infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id"))
 	}
 
 	// 2. Call API layer (no authorization needed - verification service)
@@ -148,3 +115,55 @@ func (vs *VerificationService) IsSystemTenantUser(ctx context.Context, req *auth
 
 	return &authv1.IsSystemTenantUserResponse{IsSystemTenant: isSystemTenant}, nil
 }
+
+// VerifyPermissions checks several permissions and roles of a user in one call
+func (vs *VerificationService) VerifyPermissions(ctx context.Context, req *authv1.VerifyPermissionsRequest) (*authv1.VerifyPermissionsResponse, error) {
+	vs.logger.WithContext(ctx).Debug("gRPC VerifyPermissions called")
+
+	// 1. Validate request
+	identifier := req.GetIdentifier()
+	if len(req.GetChecks()) == 0 && len(req.GetRoleNames()) == 0 {
+		return nil, infra_error.ToGRPCError(infra_error.Validation(infra_error.ValidationRequiredFields, "checks", "role_names"))
+	}
+	permissions := make([]string, 0, len(req.GetChecks()))
+	for _, check := range req.GetChecks() {
+		permission, err := model_auth.CreatePermissionString(check.GetResource(), check.GetAction())
+		if err != nil {
+			return nil, infra_error.ToGRPCError(err)
+		}
+		permissions = append(permissions, permission)
+	}
+	targetTenantID := req.GetTargetTenantId()
+	if targetTenantID == "" {
+		targetTenantID = identifier.GetTenantId()
+	}
+
+	// 2. Call API layer (no authorization needed - verification service)
+	permissionResults, roleResults, err := vs.verificationAPI.VerifyPermissions(ctx,
+		identifier.GetTenantId(),
+		identifier.GetUserId(),
+		permissions,
+		req.GetRoleNames(),
+		targetTenantID,
+		req.GetAttributes(),
+	)
+	if err != nil {
+		vs.logger.WithContext(ctx).Error("Failed to verify permissions", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	// 3. Allowed only when every permission and role is held
+	allowed := true
+	for _, granted := range permissionResults {
+		allowed = allowed && granted
+	}
+	for _, held := range roleResults {
+		allowed = allowed && held
+	}
+
+	return &authv1.VerifyPermissionsResponse{
+		Allowed:     allowed,
+		Permissions: permissionResults,
+		Roles:       roleResults,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/auth/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+type SystemService struct {
+	logger    logger.Logger
+	systemAPI *api.SystemAPI
+	authv1.UnimplementedSystemServiceServer
+}
+
+func NewSystemService(systemAPI *api.SystemAPI, logger logger.Logger) *SystemService {
+	return &SystemService{
+		logger:    logger,
+		systemAPI: systemAPI,
+	}
+}
+
+func (s *SystemService) GetSystemStatus(ctx context.Context, req *authv1.GetSystemStatusRequest) (*authv1.SystemStatus, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	systemStatus, err := s.systemAPI.GetSystemStatus(ctx, tenantID, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get system status", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return systemStatus, nil
+}
+
+func (s *SystemService) GetSystemReport(ctx context.Context, req *authv1.GetSystemReportRequest) (*authv1.SystemReport, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	report, err := s.systemAPI.GetSystemReport(ctx, tenantID, userID, req.GetRefresh())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get system report", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return report, nil
+}
+
+func (s *SystemService) ExportSystemReport(ctx context.Context, req *authv1.ExportSystemReportRequest) (*authv1.ExportSystemReportResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	export, err := s.systemAPI.ExportSystemReport(ctx, tenantID, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to export system report", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return export, nil
+}
+
+func (s *SystemService) GetLogLevels(ctx context.Context, req *authv1.GetLogLevelsRequest) (*authv1.LogLevels, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	levels, err := s.systemAPI.GetLogLevels(ctx, tenantID, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get log levels", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return levels, nil
+}
+
+func (s *SystemService) SetLogLevel(ctx context.Context, req *authv1.SetLogLevelRequest) (*authv1.LogLevels, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	levels, err := s.systemAPI.SetLogLevel(ctx, tenantID, userID, req.GetModule(), req.GetLevel(), req.GetReset_())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to set log level", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return levels, nil
+}
+
+func (s *SystemService) RotateSystemAdmin(ctx context.Context, req *authv1.RotateSystemAdminRequest) (*authv1.RotateSystemAdminResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	adminID, password, err := s.systemAPI.RotateSystemAdmin(ctx, tenantID, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to rotate system admin", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.RotateSystemAdminResponse{
+		UserId:   adminID,
+		Password: password,
+	}, nil
+}
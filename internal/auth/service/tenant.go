@@ -2,14 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"erp.localhost/internal/auth/api"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	validator_infra "erp.localhost/internal/infra/model/infra/validator"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type TenantService struct {
@@ -28,72 +28,60 @@ func NewTenantService(tenantAPI *api.TenantAPI, logger logger.Logger) *TenantSer
 
 func (t *TenantService) CreateTenant(ctx context.Context, req *authv1.CreateTenantRequest) (*authv1.CreateTenantResponse, error) {
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		t.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
 
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 
 	tenant := req.GetTenant()
 	if tenant == nil {
-		t.logger.Error("tenant data is required")
-		return nil, status.Error(codes.InvalidArgument, "tenant data is required")
+		t.logger.WithContext(ctx).Error("tenant data is required")
+		return nil, infra_error.ToGRPCError(infra_error.Validation(infra_error.ValidationRequiredFields, "tenant"))
 	}
 
-	t.logger.Info("creating tenant", "name", tenant.Name, "requested_by", identifier.UserId)
+	t.logger.WithContext(ctx).Info("creating tenant", "name", tenant.Name, "requested_by", identifier.UserId)
 
-	tenantID, err := t.tenantAPI.CreateTenant(tenantID, userID, tenant)
+	tenantID, err := t.tenantAPI.CreateTenant(ctx, tenantID, userID, tenant)
 	if err != nil {
-		t.logger.Error("failed to create tenant", "error", err)
+		t.logger.WithContext(ctx).Error("failed to create tenant", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
-	t.logger.Info("tenant created in database", "tenant_id", tenantID)
+	t.logger.WithContext(ctx).Info("tenant created in database", "tenant_id", tenantID)
 
 	return &authv1.CreateTenantResponse{TenantId: tenantID}, nil
 }
 
 func (t *TenantService) GetTenant(ctx context.Context, req *authv1.GetTenantRequest) (*authv1.Tenant, error) {
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		t.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
 
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	targetTenantID := req.GetTenantId()
 	targetTenantName := req.GetName()
 
-	tenant, err := t.tenantAPI.GetTenant(tenantID, userID, targetTenantID, targetTenantName)
+	tenant, err := t.tenantAPI.GetTenant(ctx, tenantID, userID, targetTenantID, targetTenantName)
 	if err != nil {
-		t.logger.Error("failed to get tenant", "error", err)
+		t.logger.WithContext(ctx).Error("failed to get tenant", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
-	t.logger.Info("tenant retrieved", "tenant_id", tenant.Id)
+	t.logger.WithContext(ctx).Info("tenant retrieved", "tenant_id", tenant.Id)
 	return tenant, nil
 }
 
 func (t *TenantService) ListTenants(ctx context.Context, req *authv1.ListTenantsRequest) (*authv1.ListTenantsResponse, error) {
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		t.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
 
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	status := req.GetStatus()
 
-	tenants, err := t.tenantAPI.ListTenants(tenantID, userID, status)
+	tenants, err := t.tenantAPI.ListTenants(ctx, tenantID, userID, status)
 	if err != nil {
-		t.logger.Error("failed to get tenants", "error", err)
+		t.logger.WithContext(ctx).Error("failed to get tenants", "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
-	t.logger.Info("tenants retrieved", "count", len(tenants))
+	t.logger.WithContext(ctx).Info("tenants retrieved", "count", len(tenants))
 	return &authv1.ListTenantsResponse{
 		Tenants: tenants,
 	}, nil
@@ -101,43 +89,169 @@ func (t *TenantService) ListTenants(ctx context.Context, req *authv1.ListTenants
 
 func (t *TenantService) UpdateTenant(ctx context.Context, req *authv1.UpdateTenantRequest) (*authv1.UpdateTenantResponse, error) {
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		t.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
 
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	tenant := req.GetTenant()
 
-	err := t.tenantAPI.UpdateTenant(tenantID, userID, tenant)
+	err := t.tenantAPI.UpdateTenant(ctx, tenantID, userID, tenant, req.GetUpdateMask())
 	if err != nil {
-		t.logger.Error("failed to update tenant", "tenant_id", tenant.Id, "error", err)
+		t.logger.WithContext(ctx).Error("failed to update tenant", "tenant_id", tenant.Id, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
-	t.logger.Info("tenant updated successfully", "tenant_id", tenant.Id)
+	t.logger.WithContext(ctx).Info("tenant updated successfully", "tenant_id", tenant.Id)
 	return &authv1.UpdateTenantResponse{Updated: true}, nil
 }
 
 func (t *TenantService) DeleteTenant(ctx context.Context, req *authv1.DeleteTenantRequest) (*authv1.DeleteTenantResponse, error) {
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		t.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
 
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	targetTenantID := req.GetTenantId()
 
 	// STEP 8: Delete the tenant itself
-	t.logger.Info("deleting tenant", "target_tenant_id", targetTenantID)
-	if err := t.tenantAPI.DeleteTenant(tenantID, userID, targetTenantID); err != nil {
-		t.logger.Error("failed to delete tenant", "target_tenant_id", targetTenantID, "error", err)
+	t.logger.WithContext(ctx).Info("deleting tenant", "target_tenant_id", targetTenantID)
+	if err := t.tenantAPI.DeleteTenant(ctx, tenantID, userID, targetTenantID); err != nil {
+		t.logger.WithContext(ctx).Error("failed to delete tenant", "target_tenant_id", targetTenantID, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
-	t.logger.Info("tenant deleted successfully", "target_tenant_id", targetTenantID)
+	t.logger.WithContext(ctx).Info("tenant deleted successfully", "target_tenant_id", targetTenantID)
 	return &authv1.DeleteTenantResponse{Deleted: true}, nil
 }
+
+func (t *TenantService) GetUsage(ctx context.Context, req *authv1.GetUsageRequest) (*authv1.UsageReport, error) {
+	identifier := req.GetIdentifier()
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	targetTenantID := req.GetTargetTenantId()
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+
+	report, err := t.tenantAPI.GetUsage(ctx, tenantID, userID, targetTenantID, req.GetFromDate(), req.GetToDate())
+	if err != nil {
+		t.logger.WithContext(ctx).Error("failed to get usage", "target_tenant_id", targetTenantID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return report, nil
+}
+
+func (t *TenantService) ExportUsage(ctx context.Context, req *authv1.ExportUsageRequest) (*authv1.ExportUsageResponse, error) {
+	identifier := req.GetIdentifier()
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	targetTenantID := req.GetTargetTenantId()
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+
+	export, err := t.tenantAPI.ExportUsage(ctx, tenantID, userID, targetTenantID, req.GetMonth(), req.GetFormat())
+	if err != nil {
+		t.logger.WithContext(ctx).Error("failed to export usage", "target_tenant_id", targetTenantID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return export, nil
+}
+
+func (t *TenantService) GetTenantUsage(ctx context.Context, req *authv1.GetTenantUsageRequest) (*authv1.TenantUsage, error) {
+	identifier := req.GetIdentifier()
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	targetTenantID := req.GetTargetTenantId()
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+
+	usage, err := t.tenantAPI.GetTenantUsage(ctx, tenantID, userID, targetTenantID)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("failed to get tenant usage", "target_tenant_id", targetTenantID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return usage, nil
+}
+
+func (t *TenantService) ExportTenantData(req *authv1.ExportTenantDataRequest, stream authv1.TenantService_ExportTenantDataServer) error {
+	ctx := stream.Context()
+	identifier := req.GetIdentifier()
+	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
+		t.logger.WithContext(ctx).Error("invalid identifier", "error", err)
+		return infra_error.ToGRPCError(err)
+	}
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	targetTenantID := req.GetTargetTenantId()
+	if targetTenantID == "" {
+		targetTenantID = tenantID
+	}
+
+	if err := t.tenantAPI.ExportTenantData(ctx, tenantID, userID, targetTenantID, req.GetFormat(), stream.Send); err != nil {
+		t.logger.WithContext(ctx).Error("failed to export tenant data", "target_tenant_id", targetTenantID, "error", err)
+		return infra_error.ToGRPCError(err)
+	}
+	return nil
+}
+
+func (t *TenantService) ImportTenantData(stream authv1.TenantService_ImportTenantDataServer) error {
+	ctx := stream.Context()
+	var first *authv1.ImportTenantDataRequest
+	var chunks []*authv1.ExportTenantDataChunk
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.logger.WithContext(ctx).Error("failed to receive tenant import", "error", err)
+			return err
+		}
+		if first == nil {
+			first = req
+		}
+		if req.GetChunk() != nil {
+			chunks = append(chunks, req.GetChunk())
+		}
+	}
+
+	identifier := first.GetIdentifier()
+	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
+		t.logger.WithContext(ctx).Error("invalid identifier", "error", err)
+		return infra_error.ToGRPCError(err)
+	}
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	// An empty target creates a new tenant
+	targetTenantID := first.GetTargetTenantId()
+
+	response, err := t.tenantAPI.ImportTenantData(ctx, tenantID, userID, targetTenantID, first.GetDryRun(), chunks)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("failed to import tenant data", "target_tenant_id", targetTenantID, "error", err)
+		return infra_error.ToGRPCError(err)
+	}
+	return stream.SendAndClose(response)
+}
+
+func (t *TenantService) ChangeTenantStatus(ctx context.Context, req *authv1.ChangeTenantStatusRequest) (*authv1.ChangeTenantStatusResponse, error) {
+	identifier := req.GetIdentifier()
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	targetTenantID := req.GetTargetTenantId()
+
+	previous, err := t.tenantAPI.ChangeTenantStatus(ctx, tenantID, userID, targetTenantID, req.GetStatus(), req.GetReason())
+	if err != nil {
+		t.logger.WithContext(ctx).Error("failed to change tenant status", "target_tenant_id", targetTenantID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ChangeTenantStatusResponse{
+		PreviousStatus: previous,
+		Status:         req.GetStatus(),
+	}, nil
+}
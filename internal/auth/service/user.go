@@ -4,10 +4,11 @@ import (
 	"context"
 
 	"erp.localhost/internal/auth/api"
+	"erp.localhost/internal/infra/db/mongo/query"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
-	validator_infra "erp.localhost/internal/infra/model/infra/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type UserService struct {
@@ -24,20 +25,14 @@ func NewUserService(userAPI *api.UserAPI, logger logger.Logger) *UserService {
 }
 
 func (u *UserService) CreateUser(ctx context.Context, req *authv1.CreateUserRequest) (*authv1.CreateUserResponse, error) {
-	// Validate input
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		u.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
 	tenantID := identifier.GetTenantId()
 	newUser := req.GetUser()
 
 	// convert from proto user to model user
-	id, err := u.userAPI.CreateUser(tenantID, identifier.GetUserId(), newUser)
+	id, err := u.userAPI.CreateUser(ctx, tenantID, identifier.GetUserId(), newUser, req.GetPassword())
 	if err != nil {
-		u.logger.Error("failed to create user", "tenant_id", tenantID, "error", err)
+		u.logger.WithContext(ctx).Error("failed to create user", "tenant_id", tenantID, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
@@ -47,68 +42,75 @@ func (u *UserService) CreateUser(ctx context.Context, req *authv1.CreateUserRequ
 }
 
 func (u *UserService) GetUser(ctx context.Context, req *authv1.GetUserRequest) (*authv1.User, error) {
-	// Validate input
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		u.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
 	accountID := req.GetAccountId()
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	targetTenantID := req.GetTargetTenantId()
 
 	// get user
-	user, err := u.userAPI.GetUser(tenantID, userID, targetTenantID, accountID)
+	user, err := u.userAPI.GetUser(ctx, tenantID, userID, targetTenantID, accountID)
 	if err != nil {
-		u.logger.Error("failed to get user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		u.logger.WithContext(ctx).Error("failed to get user", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
+	api.RedactUser(user)
 	return user, nil
 }
 
 func (u *UserService) ListUsers(ctx context.Context, req *authv1.ListUsersRequest) (*authv1.ListUsersResponse, error) {
-	// Validate input
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		u.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	targetTenantID := req.GetTargetTenantId()
 
-	users, err := u.userAPI.GetUsers(tenantID, userID, targetTenantID, req.GetRoleId())
+	opts, err := query.List[authv1.User](req.GetOrderBy(), req.GetReadMask().GetPaths(), req.GetLimit())
 	if err != nil {
-		u.logger.Error("failed to get users", "tenant_id", tenantID, "user_id", userID, "error", err)
+		u.logger.WithContext(ctx).Error("invalid list options", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	users, pagination, err := u.userAPI.ListUsers(ctx, tenantID, userID, targetTenantID, req.GetRoleId(), opts)
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to get users", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
 	return &authv1.ListUsersResponse{
-		Users: users,
+		Users:      redactUsers(users),
+		Pagination: pagination,
 	}, nil
 }
 
-func (u *UserService) UpdateUser(ctx context.Context, req *authv1.UpdateUserRequest) (*authv1.UpdateUserResponse, error) {
-	// Validate input
+func (u *UserService) SearchUsers(ctx context.Context, req *authv1.SearchUsersRequest) (*authv1.SearchUsersResponse, error) {
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		u.logger.Error("invalid identifier", "error", err)
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+	targetTenantID := req.GetTargetTenantId()
+
+	users, pagination, err := u.userAPI.SearchUsers(ctx, tenantID, userID, targetTenantID, req.GetSearch(), req.GetPagination())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to search users", "tenant_id", tenantID, "user_id", userID, "error", err)
 		return nil, infra_error.ToGRPCError(err)
 	}
 
+	return &authv1.SearchUsersResponse{
+		Users:      redactUsers(users),
+		Pagination: pagination,
+	}, nil
+}
+
+func (u *UserService) UpdateUser(ctx context.Context, req *authv1.UpdateUserRequest) (*authv1.UpdateUserResponse, error) {
+	identifier := req.GetIdentifier()
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 
 	newUser := req.GetUser()
 
 	// Add logic to verify only non important fields are updated
-	res, err := u.userAPI.UpdateUser(tenantID, userID, newUser)
+	res, err := u.userAPI.UpdateUser(ctx, tenantID, userID, newUser, req.GetUpdateMask())
 	if err != nil {
-		u.logger.Error("failed to update account", "tenantID", tenantID, "error", err)
+		u.logger.WithContext(ctx).Error("failed to update account", "tenantID", tenantID, "error", err)
 		err = infra_error.ToGRPCError(err)
 	}
 	return &authv1.UpdateUserResponse{
@@ -117,24 +119,270 @@ func (u *UserService) UpdateUser(ctx context.Context, req *authv1.UpdateUserRequ
 }
 
 func (u *UserService) DeleteUser(ctx context.Context, req *authv1.DeleteUserRequest) (*authv1.DeleteUserResponse, error) {
-	// Validate input
 	identifier := req.GetIdentifier()
-	if err := validator_infra.ValidateUserIdentifier(identifier); err != nil {
-		u.logger.Error("invalid identifier", "error", err)
-		return nil, infra_error.ToGRPCError(err)
-	}
-
 	tenantID := identifier.GetTenantId()
 	userID := identifier.GetUserId()
 	targetTenantID := req.GetTargetTenantId()
 	accountID := req.GetAccountId()
 
-	err := u.userAPI.DeleteUser(tenantID, userID, targetTenantID, accountID)
+	err := u.userAPI.DeleteUser(ctx, tenantID, userID, targetTenantID, accountID)
 	if err != nil {
-		u.logger.Error("failed to delete account", "tenantID", tenantID, "error", err)
+		u.logger.WithContext(ctx).Error("failed to delete account", "tenantID", tenantID, "error", err)
 		err = infra_error.ToGRPCError(err)
 	}
 	return &authv1.DeleteUserResponse{
 		Deleted: err == nil,
 	}, err
 }
+
+func (u *UserService) GetProfileCompletion(ctx context.Context, req *authv1.GetProfileCompletionRequest) (*authv1.ProfileCompletion, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	completion, err := u.userAPI.GetProfileCompletion(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetAccountId())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to get profile completion", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return completion, nil
+}
+
+func (u *UserService) GetProfileCompletionStats(ctx context.Context, req *authv1.GetProfileCompletionStatsRequest) (*authv1.ProfileCompletionStats, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	stats, err := u.userAPI.GetProfileCompletionStats(ctx, tenantID, userID, req.GetTargetTenantId())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to get profile completion stats", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return stats, nil
+}
+
+func (u *UserService) SendEmailVerification(ctx context.Context, req *authv1.SendEmailVerificationRequest) (*authv1.SendEmailVerificationResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := u.userAPI.SendEmailVerification(ctx, tenantID, userID, req.GetAccountId()); err != nil {
+		u.logger.WithContext(ctx).Error("failed to send email verification", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.SendEmailVerificationResponse{
+		Sent: true,
+	}, nil
+}
+
+// ConfirmEmailVerification is called from the verification link, the token itself authenticates the request
+func (u *UserService) ConfirmEmailVerification(ctx context.Context, req *authv1.ConfirmEmailVerificationRequest) (*authv1.ConfirmEmailVerificationResponse, error) {
+	tenantID := req.GetTenantId()
+	if err := u.userAPI.ConfirmEmailVerification(ctx, tenantID, req.GetToken()); err != nil {
+		u.logger.WithContext(ctx).Error("failed to confirm email verification", "tenant_id", tenantID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ConfirmEmailVerificationResponse{
+		Verified: true,
+	}, nil
+}
+
+func (u *UserService) ChangePassword(ctx context.Context, req *authv1.ChangePasswordRequest) (*authv1.ChangePasswordResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := u.userAPI.ChangePassword(ctx, tenantID, userID, req.GetCurrentPassword(), req.GetNewPassword()); err != nil {
+		u.logger.WithContext(ctx).Error("failed to change password", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ChangePasswordResponse{
+		Changed: true,
+	}, nil
+}
+
+func (u *UserService) ResetPassword(ctx context.Context, req *authv1.ResetPasswordRequest) (*authv1.ResetPasswordResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := u.userAPI.ResetPassword(ctx, tenantID, userID, req.GetAccountId(), req.GetNewPassword()); err != nil {
+		u.logger.WithContext(ctx).Error("failed to reset password", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ResetPasswordResponse{
+		PasswordReset: true,
+	}, nil
+}
+
+func (u *UserService) ExtendRoleAssignment(ctx context.Context, req *authv1.ExtendRoleAssignmentRequest) (*authv1.ExtendRoleAssignmentResponse, error) {
+	// Validate input
+	identifier := req.GetIdentifier()
+	if req.GetExpiresAt() == nil {
+		return nil, infra_error.ToGRPCError(infra_error.Validation(infra_error.ValidationRequiredFields, "expires_at"))
+	}
+
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := u.userAPI.ExtendRoleAssignment(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetAccountId(), req.GetRoleId(), req.GetExpiresAt().AsTime()); err != nil {
+		u.logger.WithContext(ctx).Error("failed to extend role assignment", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ExtendRoleAssignmentResponse{
+		Extended: true,
+	}, nil
+}
+
+func (u *UserService) GetLoginHistory(ctx context.Context, req *authv1.GetLoginHistoryRequest) (*authv1.GetLoginHistoryResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	records, pagination, err := u.userAPI.GetLoginHistory(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetAccountId(), req.GetFrom(), req.GetTo(), req.GetPagination())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to get login history", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.GetLoginHistoryResponse{
+		Records:    records,
+		Pagination: pagination,
+	}, nil
+}
+
+func (u *UserService) ListDevices(ctx context.Context, req *authv1.ListDevicesRequest) (*authv1.ListDevicesResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	devices, err := u.userAPI.ListDevices(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetAccountId())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to list devices", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ListDevicesResponse{
+		Devices: devices,
+	}, nil
+}
+
+func (u *UserService) RenameDevice(ctx context.Context, req *authv1.RenameDeviceRequest) (*authv1.Device, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	device, err := u.userAPI.RenameDevice(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetAccountId(), req.GetDeviceId(), req.GetName())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to rename device", "tenant_id", tenantID, "user_id", userID, "device_id", req.GetDeviceId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return device, nil
+}
+
+func (u *UserService) RevokeDevice(ctx context.Context, req *authv1.RevokeDeviceRequest) (*authv1.RevokeDeviceResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	tokensRevoked, err := u.userAPI.RevokeDevice(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetAccountId(), req.GetDeviceId())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to revoke device", "tenant_id", tenantID, "user_id", userID, "device_id", req.GetDeviceId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.RevokeDeviceResponse{
+		Revoked:       true,
+		TokensRevoked: tokensRevoked,
+	}, nil
+}
+
+func (u *UserService) UpdateMyProfile(ctx context.Context, req *authv1.UpdateMyProfileRequest) (*authv1.User, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	user, err := u.userAPI.UpdateMyProfile(ctx, tenantID, userID, req.GetProfile())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to update own profile", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	api.RedactUser(user)
+	return user, nil
+}
+
+func (u *UserService) ChangeMyEmail(ctx context.Context, req *authv1.ChangeMyEmailRequest) (*authv1.ChangeMyEmailResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	sent, err := u.userAPI.ChangeMyEmail(ctx, tenantID, userID, req.GetNewEmail(), req.GetCurrentPassword())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to change own email", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ChangeMyEmailResponse{
+		Changed:          true,
+		VerificationSent: sent,
+	}, nil
+}
+
+func (u *UserService) ChangeMyPassword(ctx context.Context, req *authv1.ChangeMyPasswordRequest) (*authv1.ChangeMyPasswordResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := u.userAPI.ChangeMyPassword(ctx, tenantID, userID, req.GetCurrentPassword(), req.GetNewPassword()); err != nil {
+		u.logger.WithContext(ctx).Error("failed to change own password", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ChangeMyPasswordResponse{
+		Changed: true,
+	}, nil
+}
+
+func (u *UserService) UpdateMyPreferences(ctx context.Context, req *authv1.UpdateMyPreferencesRequest) (*authv1.UserPreferences, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	preferences, err := u.userAPI.UpdateMyPreferences(ctx, tenantID, userID, req.GetPreferences())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to update own preferences", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return preferences, nil
+}
+
+func (u *UserService) DeleteMyAccount(ctx context.Context, req *authv1.DeleteMyAccountRequest) (*authv1.DeleteMyAccountResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	scheduledAt, err := u.userAPI.DeleteMyAccount(ctx, tenantID, userID, req.GetCurrentPassword())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to delete own account", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.DeleteMyAccountResponse{
+		DeletionScheduledAt: timestamppb.New(scheduledAt),
+	}, nil
+}
+
+func (u *UserService) EraseUser(ctx context.Context, req *authv1.EraseUserRequest) (*authv1.ErasureReceipt, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	receipt, err := u.userAPI.EraseUser(ctx, tenantID, userID, req.GetTargetTenantId(), req.GetAccountId(), req.GetReason())
+	if err != nil {
+		u.logger.WithContext(ctx).Error("failed to erase user", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return receipt, nil
+}
+
+// redactUsers clears the secrets of users before they are returned, see api.RedactUser
+func redactUsers(users []*authv1.User) []*authv1.User {
+	for _, user := range users {
+		api.RedactUser(user)
+	}
+	return users
+}
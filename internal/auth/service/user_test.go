@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/auth/api"
+	"erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/auth/rbac"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUserService_GetUser_RedactsSecrets(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+	userHandler, err := handler.NewUserHandler(log)
+	require.NoError(t, err)
+	roleHandler, err := handler.NewRoleHandler(log)
+	require.NoError(t, err)
+	permissionHandler, err := handler.NewPermissionHandler(log)
+	require.NoError(t, err)
+	permissionSetHandler, err := handler.NewPermissionSetHandler(log)
+	require.NoError(t, err)
+	tenantHandler, err := handler.NewTenantHandler(log)
+	require.NoError(t, err)
+	apiKeyHandler, err := handler.NewAPIKeyHandler(log)
+	require.NoError(t, err)
+	verificationManager := rbac.NewVerificationManager(userHandler, roleHandler, permissionHandler, permissionSetHandler, tenantHandler, apiKeyHandler, log)
+	rbacAPI := api.NewRBACAPI(roleHandler, permissionHandler, permissionSetHandler, verificationManager, log)
+	userAPI, err := api.NewUserAPI(rbacAPI, hash.NewCodeHasher("secret"), log)
+	require.NoError(t, err)
+	service := NewUserService(userAPI, log)
+
+	ctx := context.Background()
+	adminRoleID, err := roleHandler.CreateRole(ctx, &authv1.Role{
+		TenantId:    "tenant-1",
+		Name:        model_auth.RoleTenantAdmin,
+		Permissions: []string{"*"},
+		Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
+		CreatedBy:   "System",
+		CreatedAt:   timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	adminID, err := userHandler.CreateUser(ctx, &authv1.User{
+		TenantId:     "tenant-1",
+		Username:     "admin",
+		Email:        "admin@example.com",
+		PasswordHash: "admin-hash",
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		Roles:        []*authv1.UserRole{{RoleId: adminRoleID, TenantId: "tenant-1", AssignedBy: "System", AssignedAt: timestamppb.Now()}},
+		CreatedBy:    "System",
+		CreatedAt:    timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	userID, err := userHandler.CreateUser(ctx, &authv1.User{
+		TenantId:         "tenant-1",
+		Username:         "user",
+		Email:            "user@example.com",
+		PasswordHash:     "password-hash",
+		PasswordHistory:  []string{"old-password-hash"},
+		Status:           authv1.UserStatus_USER_STATUS_ACTIVE,
+		MfaEnabled:       true,
+		MfaSecret:        "totp-secret",
+		MfaRecoveryCodes: []string{"recovery-code-hash"},
+		MfaLastCounter:   42,
+		CreatedBy:        "System",
+		CreatedAt:        timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	identifier := &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: adminID}
+
+	assertRedacted := func(t *testing.T, user *authv1.User) {
+		t.Helper()
+		assert.Empty(t, user.GetPasswordHash())
+		assert.Empty(t, user.GetPasswordHistory())
+		assert.Empty(t, user.GetMfaSecret())
+		assert.Empty(t, user.GetMfaRecoveryCodes())
+		assert.Zero(t, user.GetMfaLastCounter())
+	}
+
+	user, err := service.GetUser(ctx, &authv1.GetUserRequest{Identifier: identifier, TargetTenantId: "tenant-1", AccountId: userID})
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", user.GetEmail())
+	assert.True(t, user.GetMfaEnabled())
+	assertRedacted(t, user)
+
+	list, err := service.ListUsers(ctx, &authv1.ListUsersRequest{Identifier: identifier, TargetTenantId: "tenant-1"})
+	require.NoError(t, err)
+	require.Len(t, list.GetUsers(), 2)
+	for _, user := range list.GetUsers() {
+		assertRedacted(t, user)
+	}
+
+	// The stored user keeps its secrets
+	stored, err := userHandler.GetUserByID(ctx, "tenant-1", userID)
+	require.NoError(t, err)
+	assert.Equal(t, "totp-secret", stored.GetMfaSecret())
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/auth/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+type WebhookService struct {
+	logger     logger.Logger
+	webhookAPI *api.WebhookAPI
+	authv1.UnimplementedWebhookServiceServer
+}
+
+func NewWebhookService(webhookAPI *api.WebhookAPI, logger logger.Logger) *WebhookService {
+	return &WebhookService{
+		logger:     logger,
+		webhookAPI: webhookAPI,
+	}
+}
+
+func (w *WebhookService) CreateWebhook(ctx context.Context, req *authv1.CreateWebhookRequest) (*authv1.CreateWebhookResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	webhook, secret, err := w.webhookAPI.CreateWebhook(ctx, tenantID, userID, req.GetUrl(), req.GetEvents(), req.GetSecret())
+	if err != nil {
+		w.logger.WithContext(ctx).Error("failed to create webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.CreateWebhookResponse{
+		Webhook: webhook,
+		Secret:  secret,
+	}, nil
+}
+
+func (w *WebhookService) GetWebhook(ctx context.Context, req *authv1.GetWebhookRequest) (*authv1.GetWebhookResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	webhook, err := w.webhookAPI.GetWebhook(ctx, tenantID, userID, req.GetWebhookId())
+	if err != nil {
+		w.logger.WithContext(ctx).Error("failed to get webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.GetWebhookResponse{
+		Webhook: webhook,
+	}, nil
+}
+
+func (w *WebhookService) ListWebhooks(ctx context.Context, req *authv1.ListWebhooksRequest) (*authv1.ListWebhooksResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	webhooks, err := w.webhookAPI.ListWebhooks(ctx, tenantID, userID)
+	if err != nil {
+		w.logger.WithContext(ctx).Error("failed to list webhooks", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ListWebhooksResponse{
+		Webhooks: webhooks,
+	}, nil
+}
+
+func (w *WebhookService) UpdateWebhook(ctx context.Context, req *authv1.UpdateWebhookRequest) (*authv1.UpdateWebhookResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	webhook, secret, err := w.webhookAPI.UpdateWebhook(ctx, tenantID, userID, req.GetWebhookId(), req.Url, req.GetEvents(), req.Active, req.GetRotateSecret())
+	if err != nil {
+		w.logger.WithContext(ctx).Error("failed to update webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.UpdateWebhookResponse{
+		Webhook: webhook,
+		Secret:  secret,
+	}, nil
+}
+
+func (w *WebhookService) DeleteWebhook(ctx context.Context, req *authv1.DeleteWebhookRequest) (*authv1.DeleteWebhookResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := w.webhookAPI.DeleteWebhook(ctx, tenantID, userID, req.GetWebhookId()); err != nil {
+		w.logger.WithContext(ctx).Error("failed to delete webhook", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.DeleteWebhookResponse{
+		Deleted: true,
+	}, nil
+}
+
+func (w *WebhookService) ListWebhookDeliveries(ctx context.Context, req *authv1.ListWebhookDeliveriesRequest) (*authv1.ListWebhookDeliveriesResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	deliveries, err := w.webhookAPI.ListDeliveries(ctx, tenantID, userID, req.GetWebhookId(), req.GetStatus())
+	if err != nil {
+		w.logger.WithContext(ctx).Error("failed to list webhook deliveries", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ListWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	}, nil
+}
+
+func (w *WebhookService) ReplayWebhookDelivery(ctx context.Context, req *authv1.ReplayWebhookDeliveryRequest) (*authv1.ReplayWebhookDeliveryResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	delivery, err := w.webhookAPI.ReplayDelivery(ctx, tenantID, userID, req.GetDeliveryId())
+	if err != nil {
+		w.logger.WithContext(ctx).Error("failed to replay webhook delivery", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &authv1.ReplayWebhookDeliveryResponse{
+		Delivery: delivery,
+	}, nil
+}
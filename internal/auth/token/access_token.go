@@ -15,8 +15,9 @@ type AccessTokenKeyHandler struct {
 }
 
 // NewAccessTokenKeyHandler creates a new AccessTokenHandler
-func NewAccessTokenKeyHandler(logger logger.Logger) (*AccessTokenKeyHandler, error) {
-	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.TokenMetadata](
+func NewAccessTokenKeyHandler(config *redis.RedisConfig, logger logger.Logger) (*AccessTokenKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandlerWithConfig[authv1_cache.TokenMetadata](
+		config,
 		model_redis.RedisKeyToken,
 		logger,
 	)
@@ -16,21 +16,49 @@ type JWTAccessClaims struct {
 	TenantID string   `json:"tenant_id"`
 	Username string   `json:"username"`
 	Email    string   `json:"email"`
-	Roles    []string `json:"roles"`
+	Roles    []string `json:"roles,omitempty"`
+	// PermissionsVersion is the hash of the effective permissions of the user, carried instead of the roles by the
+	// minimal tokens
+	PermissionsVersion string `json:"pv,omitempty"`
+	// Act names the user acting as UserID on the impersonation tokens (RFC 8693)
+	Act *ActorClaims `json:"act,omitempty"`
+}
+
+// ActorClaims identifies the user acting on behalf of the subject of a token
+type ActorClaims struct {
+	Subject  string `json:"sub"`
+	TenantID string `json:"tenant_id"`
 }
 
 // ToProtoClaims converts JWT claims to proto (jti is NOT included in proto)
 func (c *JWTAccessClaims) ToProtoClaims() *authv1.AccessTokenClaims {
 	return &authv1.AccessTokenClaims{
 		// NO TokenId - not needed for single token per user
-		UserId:    c.UserID,
-		TenantId:  c.TenantID,
-		Username:  c.Username,
-		Email:     c.Email,
-		Roles:     c.Roles,
-		IssuedAt:  timestamppb.New(c.IssuedAt.Time),
-		ExpiresAt: timestamppb.New(c.ExpiresAt.Time),
+		UserId:             c.UserID,
+		TenantId:           c.TenantID,
+		Username:           c.Username,
+		Email:              c.Email,
+		Roles:              c.Roles,
+		PermissionsVersion: c.PermissionsVersion,
+		IssuedAt:           timestamppb.New(c.IssuedAt.Time),
+		ExpiresAt:          timestamppb.New(c.ExpiresAt.Time),
+		Act:                c.Act.toProto(),
+	}
+}
+
+func (a *ActorClaims) toProto() *authv1.TokenActor {
+	if a == nil {
+		return nil
+	}
+	return &authv1.TokenActor{UserId: a.Subject, TenantId: a.TenantID}
+}
+
+// NewActorClaims returns the act claim of actor, nil without an actor
+func NewActorClaims(actor *authv1.TokenActor) *ActorClaims {
+	if actor == nil {
+		return nil
 	}
+	return &ActorClaims{Subject: actor.GetUserId(), TenantID: actor.GetTenantId()}
 }
 
 // FromProtoClaims creates JWT claims from proto
@@ -43,10 +71,12 @@ func FromProtoClaims(claims *authv1.AccessTokenClaims, issuer string) *JWTAccess
 			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt.AsTime()),
 			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt.AsTime()),
 		},
-		UserID:   claims.UserId,
-		TenantID: claims.TenantId,
-		Username: claims.Username,
-		Email:    claims.Email,
-		Roles:    claims.Roles,
+		UserID:             claims.UserId,
+		TenantID:           claims.TenantId,
+		Username:           claims.Username,
+		Email:              claims.Email,
+		Roles:              claims.Roles,
+		PermissionsVersion: claims.GetPermissionsVersion(),
+		Act:                NewActorClaims(claims.GetAct()),
 	}
 }
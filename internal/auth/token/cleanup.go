@@ -0,0 +1,226 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// cleanupReasonExpired is the reason of the entries deleted past their expiry
+	cleanupReasonExpired = "expired"
+	// cleanupReasonRevoked is the reason of the entries deleted after their revocation
+	cleanupReasonRevoked = "revoked"
+	// cleanupReasonOrphaned is the reason of the entries deleted because they cannot be read or never expire
+	cleanupReasonOrphaned = "orphaned"
+)
+
+var (
+	cleanupScanned = metrics.NewCounter("token_cleanup_scanned_total",
+		"Total number of token keys read by the token cleanup, by kind (access, refresh or family).", "kind")
+	cleanupDeleted = metrics.NewCounter("token_cleanup_deleted_total",
+		"Total number of token keys deleted by the token cleanup, by kind and reason (expired, revoked or orphaned).", "kind", "reason")
+)
+
+// CleanupConfig holds the settings of the token cleanup, loaded with infra_config.Load
+type CleanupConfig struct {
+	// Interval between two sweeps of the token keys
+	Interval time.Duration `yaml:"interval" env:"TOKEN_CLEANUP_INTERVAL" default:"1h"`
+	// Retention keeps the expired and revoked tokens for that long, e.g. to investigate a revocation
+	Retention time.Duration `yaml:"retention" env:"TOKEN_CLEANUP_RETENTION" default:"24h"`
+}
+
+// expiring is implemented by the token entries kept in Redis
+type expiring interface {
+	GetExpiresAt() *timestamppb.Timestamp
+}
+
+// revocable is implemented by the token entries revoked in place instead of deleted
+type revocable interface {
+	GetRevoked() bool
+	GetRevokedAt() *timestamppb.Timestamp
+}
+
+// Cleaner deletes the token entries left in Redis when their TTL was not set or was not honoured: the access and
+// refresh tokens expired or revoked before the retention window and the refresh token families past their expiry.
+// Entries that cannot be read, or have no expiry, are orphaned and deleted as well
+type Cleaner struct {
+	accessTokens  redis.KeyHandler[authv1_cache.TokenMetadata]
+	refreshTokens redis.KeyHandler[authv1_cache.RefreshToken]
+	families      redis.KeyHandler[authv1_cache.RefreshTokenFamily]
+	config        CleanupConfig
+	logger        logger.Logger
+}
+
+func NewCleaner(
+	accessTokens redis.KeyHandler[authv1_cache.TokenMetadata],
+	refreshTokens redis.KeyHandler[authv1_cache.RefreshToken],
+	families redis.KeyHandler[authv1_cache.RefreshTokenFamily],
+	config *CleanupConfig,
+	logger logger.Logger,
+) *Cleaner {
+	return &Cleaner{
+		accessTokens:  accessTokens,
+		refreshTokens: refreshTokens,
+		families:      families,
+		config:        *config,
+		logger:        logger,
+	}
+}
+
+// NewRedisCleaner creates a Cleaner of the token keys of the Redis configured by redisConfig
+func NewRedisCleaner(redisConfig *redis.RedisConfig, config *CleanupConfig, logger logger.Logger) (*Cleaner, error) {
+	accessTokens, err := NewAccessTokenKeyHandler(redisConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	refreshTokens, err := NewRefreshTokenKeyHandler(redisConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	families, err := NewRefreshTokenFamilyKeyHandler(redisConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	return NewCleaner(accessTokens, refreshTokens, families, config, logger), nil
+}
+
+// Run sweeps the token keys every configured interval until quit is closed
+func (c *Cleaner) Run(quit <-chan struct{}) {
+	interval := c.config.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	// Sweeps run in the background, not on behalf of a request
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = c.Sweep(ctx, time.Now())
+		case <-quit:
+			return
+		}
+	}
+}
+
+// Sweep deletes the stale token entries of every tenant as of now and returns how many were deleted. A kind that
+// fails to scan does not stop the others, the first error is returned
+func (c *Cleaner) Sweep(ctx context.Context, now time.Time) (int, error) {
+	var firstErr error
+	deleted := 0
+	for _, sweep := range []func() (int, error){
+		func() (int, error) {
+			return sweepTokens(ctx, c, c.accessTokens, model_redis.RedisKeyToken, "access", now)
+		},
+		func() (int, error) {
+			return sweepTokens(ctx, c, c.refreshTokens, model_redis.RedisKeyRefreshToken, "refresh", now)
+		},
+		func() (int, error) {
+			return sweepTokens(ctx, c, c.families, model_redis.RedisKeyRefreshTokenFamily, "family", now)
+		},
+	} {
+		count, err := sweep()
+		deleted += count
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if deleted > 0 {
+		c.logger.Info("deleted stale token keys", "count", deleted)
+	}
+	return deleted, firstErr
+}
+
+// sweepTokens deletes the stale entries of one kind of token keys, the keys of every tenant are scanned
+func sweepTokens[T any, P interface {
+	*T
+	expiring
+}](ctx context.Context, c *Cleaner, handler redis.KeyHandler[T], prefix model_redis.KeyPrefix, kind string, now time.Time) (int, error) {
+	keys, err := handler.ScanKeys(ctx, "*", "*")
+	if err != nil {
+		c.logger.Error("failed to scan token keys", "kind", kind, "error", err)
+		return 0, err
+	}
+	deleted := 0
+	for _, fullKey := range keys {
+		tenantID, key, ok := splitTokenKey(string(prefix), fullKey)
+		if !ok {
+			continue
+		}
+		cleanupScanned.Inc(kind)
+		value, err := handler.GetOne(ctx, tenantID, key)
+		var reason string
+		switch {
+		case redis.IsNotFound(err):
+			// Expired or deleted since the scan
+			continue
+		case isDecodeError(err):
+			reason = cleanupReasonOrphaned
+		case err != nil:
+			c.logger.Error("failed to read token key", "kind", kind, "tenant_id", tenantID, "key", key, "error", err)
+			continue
+		default:
+			reason = staleReason(P(value), now, c.config.Retention)
+		}
+		if reason == "" {
+			continue
+		}
+		if err := handler.Delete(ctx, tenantID, key); err != nil {
+			// Retried on the next sweep
+			c.logger.Error("failed to delete stale token key", "kind", kind, "tenant_id", tenantID, "key", key, "error", err)
+			continue
+		}
+		deleted++
+		cleanupDeleted.Inc(kind, reason)
+	}
+	return deleted, nil
+}
+
+// staleReason returns why entry is deleted as of now, empty while it is kept. Expired and revoked entries are kept
+// for retention, revoked entries without a revocation time are deleted right away
+func staleReason(entry expiring, now time.Time, retention time.Duration) string {
+	cutoff := now.Add(-retention)
+	if r, ok := entry.(revocable); ok && r.GetRevoked() {
+		if r.GetRevokedAt() == nil || !r.GetRevokedAt().AsTime().After(cutoff) {
+			return cleanupReasonRevoked
+		}
+	}
+	if entry.GetExpiresAt() == nil {
+		return cleanupReasonOrphaned
+	}
+	if !entry.GetExpiresAt().AsTime().After(cutoff) {
+		return cleanupReasonExpired
+	}
+	return ""
+}
+
+// splitTokenKey splits a scanned key prefix:{tenant_id}:{key} into the tenant and the key relative to it
+func splitTokenKey(prefix, fullKey string) (tenantID, key string, ok bool) {
+	rest, ok := strings.CutPrefix(fullKey, prefix+":")
+	if !ok {
+		return "", "", false
+	}
+	tenantID, key, ok = strings.Cut(rest, ":")
+	if !ok || tenantID == "" || key == "" {
+		return "", "", false
+	}
+	return tenantID, key, true
+}
+
+// isDecodeError reports whether err is a stored value that is not a token entry
+func isDecodeError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
@@ -0,0 +1,114 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	mock_redis "erp.localhost/internal/infra/db/redis/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/model/shared"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestStaleReason(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	retention := 24 * time.Hour
+	testCases := []struct {
+		name     string
+		entry    expiring
+		expected string
+	}{
+		{
+			name:  "valid",
+			entry: &authv1_cache.TokenMetadata{ExpiresAt: timestamppb.New(now.Add(time.Hour))},
+		},
+		{
+			name:  "expired within the retention",
+			entry: &authv1_cache.TokenMetadata{ExpiresAt: timestamppb.New(now.Add(-time.Hour))},
+		},
+		{
+			name:     "expired before the retention",
+			entry:    &authv1_cache.RefreshToken{ExpiresAt: timestamppb.New(now.Add(-25 * time.Hour))},
+			expected: cleanupReasonExpired,
+		},
+		{
+			name: "revoked within the retention",
+			entry: &authv1_cache.RefreshToken{ExpiresAt: timestamppb.New(now.Add(time.Hour)),
+				Revoked: true, RevokedAt: timestamppb.New(now.Add(-time.Hour))},
+		},
+		{
+			name: "revoked before the retention",
+			entry: &authv1_cache.RefreshToken{ExpiresAt: timestamppb.New(now.Add(time.Hour)),
+				Revoked: true, RevokedAt: timestamppb.New(now.Add(-48 * time.Hour))},
+			expected: cleanupReasonRevoked,
+		},
+		{
+			name:     "revoked without revocation time",
+			entry:    &authv1_cache.TokenMetadata{ExpiresAt: timestamppb.New(now.Add(time.Hour)), Revoked: true},
+			expected: cleanupReasonRevoked,
+		},
+		{
+			name:     "no expiry",
+			entry:    &authv1_cache.RefreshTokenFamily{FamilyId: "family-1"},
+			expected: cleanupReasonOrphaned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, staleReason(tc.entry, now, retention))
+		})
+	}
+}
+
+func TestSplitTokenKey(t *testing.T) {
+	tenantID, key, ok := splitTokenKey("tokens", "tokens:tenant-1:user-1")
+	require.True(t, ok)
+	assert.Equal(t, "tenant-1", tenantID)
+	assert.Equal(t, "user-1", key)
+
+	_, _, ok = splitTokenKey("tokens", "refresh_tokens:tenant-1:user-1")
+	assert.False(t, ok)
+	_, _, ok = splitTokenKey("tokens", "tokens:tenant-1")
+	assert.False(t, ok)
+}
+
+func TestCleaner_Sweep(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	accessTokens := mock_redis.NewMockKeyHandler[authv1_cache.TokenMetadata](ctrl)
+	refreshTokens := mock_redis.NewMockKeyHandler[authv1_cache.RefreshToken](ctrl)
+	families := mock_redis.NewMockKeyHandler[authv1_cache.RefreshTokenFamily](ctrl)
+
+	accessTokens.EXPECT().ScanKeys(gomock.Any(), "*", "*").Return([]string{"tokens:t1:u1", "tokens:t1:u2", "tokens:t2:u3"}, nil)
+	accessTokens.EXPECT().GetOne(gomock.Any(), "t1", "u1").Return(&authv1_cache.TokenMetadata{ExpiresAt: timestamppb.New(now.Add(-48 * time.Hour))}, nil)
+	accessTokens.EXPECT().GetOne(gomock.Any(), "t1", "u2").Return(&authv1_cache.TokenMetadata{ExpiresAt: timestamppb.New(now.Add(time.Hour))}, nil)
+	// Deleted since the scan
+	accessTokens.EXPECT().GetOne(gomock.Any(), "t2", "u3").Return(nil, infra_error.Internal(infra_error.InternalDatabaseError, goredis.Nil))
+	accessTokens.EXPECT().Delete(gomock.Any(), "t1", "u1").Return(nil)
+
+	refreshTokens.EXPECT().ScanKeys(gomock.Any(), "*", "*").Return([]string{"refresh_tokens:t1:u1", "refresh_tokens:t1:u2"}, nil)
+	refreshTokens.EXPECT().GetOne(gomock.Any(), "t1", "u1").Return(&authv1_cache.RefreshToken{ExpiresAt: timestamppb.New(now.Add(time.Hour)), Revoked: true}, nil)
+	refreshTokens.EXPECT().GetOne(gomock.Any(), "t1", "u2").Return(nil, infra_error.Internal(infra_error.InternalDatabaseError, &json.SyntaxError{}))
+	refreshTokens.EXPECT().Delete(gomock.Any(), "t1", "u1").Return(nil)
+	refreshTokens.EXPECT().Delete(gomock.Any(), "t1", "u2").Return(nil)
+
+	// A failed scan does not stop the other kinds
+	scanErr := errors.New("connection refused")
+	families.EXPECT().ScanKeys(gomock.Any(), "*", "*").Return(nil, scanErr)
+
+	cleaner := NewCleaner(accessTokens, refreshTokens, families, &CleanupConfig{Retention: 24 * time.Hour}, logger.NewBaseLogger(shared.ModuleAuth))
+	deleted, err := cleaner.Sweep(context.Background(), now)
+	assert.ErrorIs(t, err, scanErr)
+	assert.Equal(t, 3, deleted)
+}
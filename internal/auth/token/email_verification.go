@@ -0,0 +1,28 @@
+package token
+
+import (
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// EmailVerificationKeyHandler handles email verification tokens in Redis
+// Key pattern: email_verify:{tenant_id}:{token}
+type EmailVerificationKeyHandler struct {
+	*redis.BaseKeyHandler[authv1_cache.EmailVerificationToken]
+}
+
+// NewEmailVerificationKeyHandler creates a new EmailVerificationKeyHandler
+func NewEmailVerificationKeyHandler(logger logger.Logger) (*EmailVerificationKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.EmailVerificationToken](
+		model_redis.RedisKeyEmailVerify,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &EmailVerificationKeyHandler{
+		BaseKeyHandler: keyHandler,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package token
+
+import (
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// KnownDevicesKeyHandler handles the devices users logged in from in Redis
+// Key pattern: known_devices:{tenant_id}:{user_id}
+type KnownDevicesKeyHandler struct {
+	*redis.BaseKeyHandler[authv1_cache.KnownDevices]
+}
+
+// NewKnownDevicesKeyHandler creates a new KnownDevicesKeyHandler
+func NewKnownDevicesKeyHandler(logger logger.Logger) (*KnownDevicesKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.KnownDevices](
+		model_redis.RedisKeyKnownDevices,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &KnownDevicesKeyHandler{
+		BaseKeyHandler: keyHandler,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package token
+
+import (
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// LoginLinkKeyHandler handles pending magic link logins in Redis
+// Key pattern: login_link:{tenant_id}:{token}
+type LoginLinkKeyHandler struct {
+	*redis.BaseKeyHandler[authv1_cache.LoginLinkToken]
+}
+
+// NewLoginLinkKeyHandler creates a new LoginLinkKeyHandler
+func NewLoginLinkKeyHandler(logger logger.Logger) (*LoginLinkKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.LoginLinkToken](
+		model_redis.RedisKeyLoginLink,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginLinkKeyHandler{
+		BaseKeyHandler: keyHandler,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package token
+
+import (
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// MFACodeKeyHandler handles the one-time codes sent to users in Redis
+// Key pattern: mfa_code:{tenant_id}:{user_id}
+type MFACodeKeyHandler struct {
+	*redis.BaseKeyHandler[authv1_cache.MFACode]
+}
+
+// NewMFACodeKeyHandler creates a new MFACodeKeyHandler
+func NewMFACodeKeyHandler(logger logger.Logger) (*MFACodeKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.MFACode](
+		model_redis.RedisKeyMFACode,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &MFACodeKeyHandler{
+		BaseKeyHandler: keyHandler,
+	}, nil
+}
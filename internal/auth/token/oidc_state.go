@@ -0,0 +1,28 @@
+package token
+
+import (
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// OIDCStateKeyHandler handles pending OIDC authorization requests in Redis
+// Key pattern: oidc_state:{tenant_id}:{state}
+type OIDCStateKeyHandler struct {
+	*redis.BaseKeyHandler[authv1_cache.OIDCState]
+}
+
+// NewOIDCStateKeyHandler creates a new OIDCStateKeyHandler
+func NewOIDCStateKeyHandler(logger logger.Logger) (*OIDCStateKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.OIDCState](
+		model_redis.RedisKeyOIDCState,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCStateKeyHandler{
+		BaseKeyHandler: keyHandler,
+	}, nil
+}
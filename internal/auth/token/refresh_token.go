@@ -15,8 +15,9 @@ type RefreshTokenKeyHandler struct {
 }
 
 // NewRefreshTokenKeyHandler creates a new RefreshTokenHandler
-func NewRefreshTokenKeyHandler(logger logger.Logger) (*RefreshTokenKeyHandler, error) {
-	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.RefreshToken](
+func NewRefreshTokenKeyHandler(config *redis.RedisConfig, logger logger.Logger) (*RefreshTokenKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandlerWithConfig[authv1_cache.RefreshToken](
+		config,
 		model_redis.RedisKeyRefreshToken,
 		logger,
 	)
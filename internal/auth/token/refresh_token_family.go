@@ -0,0 +1,29 @@
+package token
+
+import (
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// RefreshTokenFamilyKeyHandler handles refresh token families in Redis
+// Key pattern: refresh_token_family:{tenant_id}:{family_id}
+type RefreshTokenFamilyKeyHandler struct {
+	*redis.BaseKeyHandler[authv1_cache.RefreshTokenFamily]
+}
+
+// NewRefreshTokenFamilyKeyHandler creates a new RefreshTokenFamilyKeyHandler
+func NewRefreshTokenFamilyKeyHandler(config *redis.RedisConfig, logger logger.Logger) (*RefreshTokenFamilyKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandlerWithConfig[authv1_cache.RefreshTokenFamily](
+		config,
+		model_redis.RedisKeyRefreshTokenFamily,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &RefreshTokenFamilyKeyHandler{
+		BaseKeyHandler: keyHandler,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package token
+
+import (
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// SAMLAssertionKeyHandler handles consumed SAML assertions in Redis
+// Key pattern: saml_assertion:{tenant_id}:{assertion_id}
+type SAMLAssertionKeyHandler struct {
+	*redis.BaseKeyHandler[authv1_cache.SAMLAssertion]
+}
+
+// NewSAMLAssertionKeyHandler creates a new SAMLAssertionKeyHandler
+func NewSAMLAssertionKeyHandler(logger logger.Logger) (*SAMLAssertionKeyHandler, error) {
+	keyHandler, err := redis.NewBaseKeyHandler[authv1_cache.SAMLAssertion](
+		model_redis.RedisKeySAMLAssertion,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &SAMLAssertionKeyHandler{
+		BaseKeyHandler: keyHandler,
+	}, nil
+}
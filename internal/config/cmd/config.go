@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	infra_config "erp.localhost/internal/infra/config"
+	"erp.localhost/internal/infra/secret"
+	"erp.localhost/internal/infra/shutdown"
+)
+
+// Config holds the settings of the config service, see infra_config.Load for how they are resolved
+type Config struct {
+	Server  infra_config.Server  `yaml:"server"`
+	Metrics infra_config.Metrics `yaml:"metrics"`
+	Mongo   infra_config.Mongo   `yaml:"mongo"`
+	TLS     infra_config.TLS     `yaml:"tls"`
+	Secrets secret.Config        `yaml:"secrets"`
+	// Draining of the calls and goroutines and closing of the dependencies at shutdown
+	Shutdown shutdown.Config `yaml:"shutdown"`
+}
+
+// loadConfig loads the settings of the service from its defaults, CONFIG_FILE, the environment and args
+func loadConfig(args []string) (*Config, error) {
+	config := &Config{
+		Server:  infra_config.NewServer(5002),
+		Metrics: infra_config.Metrics{Port: 9002},
+	}
+	if err := infra_config.Load(config, infra_config.WithArgs(args)); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
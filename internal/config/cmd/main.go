@@ -1,85 +1,107 @@
 package cmd
 
 import (
+	"context"
 	"os"
-	"os/signal"
-	"sync"
-	"syscall"
-	"time"
 
 	"erp.localhost/internal/config/service"
+	"erp.localhost/internal/infra/buildinfo"
+	db_mongo "erp.localhost/internal/infra/db/mongo"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/grpc/server"
 	grpc_server "erp.localhost/internal/infra/grpc/server"
 	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
 	configv1 "erp.localhost/internal/infra/model/config/v1"
 	model_shared "erp.localhost/internal/infra/model/shared"
-)
-
-const (
-	ServerPort = 5002
+	"erp.localhost/internal/infra/secret"
+	"erp.localhost/internal/infra/shutdown"
+	"erp.localhost/internal/infra/tracing"
 )
 
 func Main() {
 	logger := logger.NewBaseLogger(model_shared.ModuleConfig)
 	defer logger.Close()
-	logger.Info("Starting service...")
-	// Channel to listen for OS signals for graceful shutdown
-	stopChan := make(chan os.Signal, 1)
-	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
-
-	// Channel to signal the gRPC server goroutine to stop
-	quit := make(chan struct{})
+	logger.Info("Starting service...", buildinfo.Get().LogArgs()...)
+	config, err := loadConfig(os.Args[1:])
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		return
+	}
+	// Stops the server and the background goroutines, then closes the dependencies once main returns
+	coordinator := shutdown.New(&config.Shutdown, logger)
+	defer coordinator.Shutdown()
+	// The database clients are shared by the handlers and the services of the process, they close last
+	coordinator.OnCloseShared("mongo", db_mongo.CloseClients)
+	// Credentials are read from the secret store selected by SECRETS_PROVIDER
+	secrets, err := secret.New(&config.Secrets, logger)
+	if err != nil {
+		logger.Error("failed to create secret provider", "error", err)
+		return
+	}
+	coordinator.OnClose("secrets", func(context.Context) error { return secrets.Close() })
+	if uri, ok := secret.Lookup(context.Background(), secrets, secret.MongoURI, logger); ok {
+		config.Mongo.URI = uri
+	}
+	db_mongo.SetURI(config.Mongo.URI)
+	db_mongo.SetReadPreference(db_mongo.ReadPreference(config.Mongo.ReadPreference))
+	// Distributed tracing, spans are exported when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.LoadConfig(model_shared.ModuleConfig), logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	coordinator.OnClose("tracing", shutdownTracing)
 
-	insecure := false
+	// Connections between the services use mTLS, the service does not start without its certificates unless
+	// TLS_INSECURE is set
 	certs := model_shared.NewCerts()
-	if certs == nil {
-		logger.Warn("configuring insecure")
-		insecure = true
+	if config.TLS.CertsDir != "" {
+		certs = model_shared.NewCertsFromDir(config.TLS.CertsDir)
 	}
 
 	// Create server
 	// Create server
 	logger.Info("Creating gRPC server...")
 	srv, err := grpc_server.NewGRPCServer(&server.Config{
-		Port:             ServerPort,
-		Module:           model_shared.ModuleConfig,
-		Insecure:         insecure, // Set to false for production with certs
-		Certs:            certs,
-		EnableReflection: true,
-		KeepAliveTime:    30 * time.Second,
-		KeepAliveTimeout: 10 * time.Second,
+		Port:                config.Server.Port,
+		Module:              model_shared.ModuleConfig,
+		Insecure:            config.TLS.Insecure,
+		Certs:               certs,
+		AllowedClients:      config.TLS.AllowedClients,
+		CertReloadInterval:  config.TLS.ReloadInterval,
+		Environment:         config.Server.Environment,
+		EnableReflection:    config.Server.EnableReflection,
+		EnableDebugServices: config.Server.EnableDebugServices,
+		VerboseErrors:       config.Server.VerboseErrors,
+		KeepAliveTime:       config.Server.KeepAliveTime,
+		KeepAliveTimeout:    config.Server.KeepAliveTimeout,
+		ShutdownTimeout:     config.Shutdown.Timeout,
 	}, logger)
 	if err != nil {
 		logger.Error(infra_error.Internal(infra_error.InternalGRPCError, err).Error())
 		return
 	}
 
+	// Prometheus metrics of the process, see internal/infra/metrics
+	metricsServer := metrics.NewServer(config.Metrics.Port, logger)
+	metricsServer.Start()
+	coordinator.OnClose("metrics_server", metricsServer.Shutdown)
+
 	/* Register services */
 	logger.Info("Registering gRPC services...")
-	configService := service.NewConfigService()
+	configService, err := service.NewConfigService(logger)
+	if err != nil {
+		logger.Error("failed to create config service", "error", err)
+		return
+	}
 	srv.RegisterService(&configv1.ConfigService_ServiceDesc, configService)
+	featureFlagService, err := service.NewFeatureFlagService(logger)
+	if err != nil {
+		logger.Error("failed to create feature flag service", "error", err)
+		return
+	}
+	srv.RegisterService(&configv1.FeatureFlagService_ServiceDesc, featureFlagService)
 
-	// WaitGroup to wait for the gRPC server goroutine to finish
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		// Run gRPC Server
-		if err := srv.ListenAndServe(quit); err != nil {
-			logger.Warn("gRPC server stopped", "error", err)
-			return
-		}
-	}()
-
-	logger.Warn("gRPC server shutdown...")
-	// Wait for OS signal
-	<-stopChan
-
-	// Signal the gRPC server to stop
-	close(quit)
-
-	// Wait for the gRPC server goroutine to finish
-	wg.Wait()
-	logger.Warn("gRPC server stopped")
+	coordinator.ServeGRPC(srv)
 }
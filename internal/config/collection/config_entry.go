@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type ConfigEntryCollection struct {
+	*collection.BaseCollectionHandler[configv1.ConfigEntry]
+}
+
+func NewConfigEntryCollection(logger logger.Logger) (*ConfigEntryCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[configv1.ConfigEntry](
+		model_mongo.ConfigDB,
+		model_mongo.ConfigEntriesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigEntryCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type ConfigHistoryCollection struct {
+	*collection.BaseCollectionHandler[configv1.ConfigEntryChange]
+}
+
+func NewConfigHistoryCollection(logger logger.Logger) (*ConfigHistoryCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[configv1.ConfigEntryChange](
+		model_mongo.ConfigDB,
+		model_mongo.ConfigHistoryCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigHistoryCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type FeatureFlagCollection struct {
+	*collection.BaseCollectionHandler[configv1.FeatureFlag]
+}
+
+func NewFeatureFlagCollection(logger logger.Logger) (*FeatureFlagCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[configv1.FeatureFlag](
+		model_mongo.ConfigDB,
+		model_mongo.FeatureFlagsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &FeatureFlagCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
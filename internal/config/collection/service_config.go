@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type ServiceConfigCollection struct {
+	*collection.BaseCollectionHandler[configv1.ServiceConfig]
+}
+
+func NewServiceConfigCollection(logger logger.Logger) (*ServiceConfigCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[configv1.ServiceConfig](
+		model_mongo.ConfigDB,
+		model_mongo.ServiceConfigCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceConfigCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	collection_config "erp.localhost/internal/config/collection"
+	"erp.localhost/internal/config/schema"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConfigEntryHandler stores typed config entries validated against the schema registered for their key.
+// Every change bumps the version of the entry and is recorded in the config history.
+type ConfigEntryHandler struct {
+	entries collection_mongo.CollectionHandler[configv1.ConfigEntry]
+	history collection_mongo.CollectionHandler[configv1.ConfigEntryChange]
+	watcher *ConfigWatcher
+	logger  logger.Logger
+}
+
+func NewConfigEntryHandler(logger logger.Logger) (*ConfigEntryHandler, error) {
+	entries, err := collection_config.NewConfigEntryCollection(logger)
+	if err != nil {
+		logger.Error("failed to create config entry collection handler", "error", err)
+		return nil, err
+	}
+	history, err := collection_config.NewConfigHistoryCollection(logger)
+	if err != nil {
+		logger.Error("failed to create config history collection handler", "error", err)
+		return nil, err
+	}
+	return &ConfigEntryHandler{
+		entries: entries,
+		history: history,
+		watcher: NewConfigWatcher(),
+		logger:  logger,
+	}, nil
+}
+
+// validateRef checks that the tenant and module of ref match its scope
+func validateRef(ref *configv1.ConfigEntryRef) error {
+	if ref.GetKey() == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "key")
+	}
+	switch ref.GetScope() {
+	case configv1.ConfigScope_CONFIG_SCOPE_SYSTEM:
+		if ref.GetTenantId() != "" || ref.GetModule() != "" {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "tenant_id", "module")
+		}
+	case configv1.ConfigScope_CONFIG_SCOPE_MODULE:
+		if ref.GetModule() == "" || ref.GetTenantId() != "" {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "tenant_id", "module")
+		}
+	case configv1.ConfigScope_CONFIG_SCOPE_TENANT:
+		if ref.GetTenantId() == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
+		}
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "scope")
+	}
+	return nil
+}
+
+func refFilter(ref *configv1.ConfigEntryRef) map[string]any {
+	return map[string]any{
+		"key":       ref.GetKey(),
+		"scope":     ref.GetScope(),
+		"tenant_id": ref.GetTenantId(),
+		"module":    ref.GetModule(),
+	}
+}
+
+// find returns the entry of ref, nil when it is not set
+func (h *ConfigEntryHandler) find(ctx context.Context, ref *configv1.ConfigEntryRef) (*configv1.ConfigEntry, error) {
+	entries, err := h.entries.FindAll(ctx, refFilter(ref))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// GetEntry returns the entry of ref
+func (h *ConfigEntryHandler) GetEntry(ctx context.Context, ref *configv1.ConfigEntryRef) (*configv1.ConfigEntry, error) {
+	if err := validateRef(ref); err != nil {
+		return nil, err
+	}
+	entry, err := h.find(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, infra_error.NotFound(infra_error.NotFoundConfig, "config_entry", ref.GetKey())
+	}
+	return entry, nil
+}
+
+// SetEntry stores value as the next version of the entry of ref. The key must have a registered schema that
+// allows the scope and accepts the value, setting the current value again leaves the entry unchanged
+func (h *ConfigEntryHandler) SetEntry(ctx context.Context, ref *configv1.ConfigEntryRef, value *structpb.Value, updatedBy string) (*configv1.ConfigEntry, error) {
+	if err := validateRef(ref); err != nil {
+		return nil, err
+	}
+	if updatedBy == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "UpdatedBy")
+	}
+	keySchema, ok := schema.Lookup(ref.GetKey())
+	if !ok {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "key").WithDetails("key", ref.GetKey())
+	}
+	if !keySchema.AllowsScope(ref.GetScope()) {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "scope").WithDetails("key", ref.GetKey())
+	}
+	if err := keySchema.Validate(value); err != nil {
+		return nil, err
+	}
+
+	current, err := h.find(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && proto.Equal(current.GetValue(), value) {
+		return current, nil
+	}
+
+	now := timestamppb.Now()
+	entry := &configv1.ConfigEntry{
+		Key:       ref.GetKey(),
+		Scope:     ref.GetScope(),
+		TenantId:  ref.GetTenantId(),
+		Module:    ref.GetModule(),
+		Type:      keySchema.Type,
+		Value:     value,
+		CreatedAt: now,
+		UpdatedAt: now,
+		UpdatedBy: updatedBy,
+	}
+	h.logger.WithContext(ctx).Debug("Saving config entry", "key", ref.GetKey(), "scope", ref.GetScope(), "tenant_id", ref.GetTenantId(), "module", ref.GetModule())
+	if current == nil {
+		// Versions continue after a delete so the history stays ordered
+		version, err := h.lastVersion(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		entry.Version = version + 1
+		id, err := h.entries.Create(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		entry.Id = id
+	} else {
+		entry.Id = current.GetId()
+		entry.Version = current.GetVersion() + 1
+		entry.CreatedAt = current.GetCreatedAt()
+		if err := h.entries.Update(ctx, refFilter(ref), entry); err != nil {
+			return nil, err
+		}
+	}
+
+	h.recordChange(ctx, entry, configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, current.GetValue(), value, updatedBy)
+	h.watcher.publish(configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, entry)
+	return entry, nil
+}
+
+// ListEntries returns the entries of scope sorted by key, narrowed to the tenant, module and key prefix when set.
+// Every scope is listed when scope is unspecified
+func (h *ConfigEntryHandler) ListEntries(ctx context.Context, scope configv1.ConfigScope, tenantID, module, keyPrefix string) ([]*configv1.ConfigEntry, error) {
+	filter := map[string]any{}
+	if scope != configv1.ConfigScope_CONFIG_SCOPE_UNSPECIFIED {
+		filter["scope"] = scope
+	}
+	if tenantID != "" {
+		filter["tenant_id"] = tenantID
+	}
+	if module != "" {
+		filter["module"] = module
+	}
+	if keyPrefix != "" {
+		filter["key"] = map[string]any{"$regex": "^" + regexp.QuoteMeta(keyPrefix)}
+	}
+	entries, err := h.entries.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].GetKey() < entries[j].GetKey() })
+	return entries, nil
+}
+
+// DeleteEntry removes the entry of ref, readers fall back to the entries of the other scopes
+func (h *ConfigEntryHandler) DeleteEntry(ctx context.Context, ref *configv1.ConfigEntryRef, deletedBy string) error {
+	if deletedBy == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "DeletedBy")
+	}
+	current, err := h.GetEntry(ctx, ref)
+	if err != nil {
+		return err
+	}
+	h.logger.WithContext(ctx).Debug("Deleting config entry", "key", ref.GetKey(), "scope", ref.GetScope(), "tenant_id", ref.GetTenantId(), "module", ref.GetModule())
+	if err := h.entries.Delete(ctx, refFilter(ref)); err != nil {
+		return err
+	}
+	h.recordChange(ctx, current, configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_DELETE, current.GetValue(), nil, deletedBy)
+	h.watcher.publish(configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_DELETE, current)
+	return nil
+}
+
+// GetHistory returns the changes of the entry of ref newest first, at most limit when limit is positive
+func (h *ConfigEntryHandler) GetHistory(ctx context.Context, ref *configv1.ConfigEntryRef, limit int) ([]*configv1.ConfigEntryChange, error) {
+	if err := validateRef(ref); err != nil {
+		return nil, err
+	}
+	changes, err := h.history.FindAll(ctx, refFilter(ref))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].GetVersion() != changes[j].GetVersion() {
+			return changes[i].GetVersion() > changes[j].GetVersion()
+		}
+		// The delete of a version comes after its set
+		return changes[i].GetChangedAt().AsTime().After(changes[j].GetChangedAt().AsTime())
+	})
+	if limit > 0 && len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// Watch returns the changes of the entries matching req made through this instance, see ConfigWatcher.Subscribe
+func (h *ConfigEntryHandler) Watch(req *configv1.WatchConfigRequest) (<-chan *configv1.ConfigEvent, func()) {
+	return h.watcher.Subscribe(req)
+}
+
+// lastVersion returns the highest version recorded in the history of ref, 0 when it has none
+func (h *ConfigEntryHandler) lastVersion(ctx context.Context, ref *configv1.ConfigEntryRef) (int32, error) {
+	changes, err := h.history.FindAll(ctx, refFilter(ref))
+	if err != nil {
+		return 0, err
+	}
+	var version int32
+	for _, change := range changes {
+		version = max(version, change.GetVersion())
+	}
+	return version, nil
+}
+
+// recordChange adds a change of entry to the history. The entry is already saved, failures are logged
+func (h *ConfigEntryHandler) recordChange(ctx context.Context, entry *configv1.ConfigEntry, changeType configv1.ConfigChangeType, oldValue, newValue *structpb.Value, changedBy string) {
+	change := &configv1.ConfigEntryChange{
+		Key:        entry.GetKey(),
+		Scope:      entry.GetScope(),
+		TenantId:   entry.GetTenantId(),
+		Module:     entry.GetModule(),
+		ChangeType: changeType,
+		Version:    entry.GetVersion(),
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		ChangedBy:  changedBy,
+		ChangedAt:  timestamppb.Now(),
+	}
+	if _, err := h.history.Create(ctx, change); err != nil {
+		h.logger.WithContext(ctx).Error("failed to record config change", "key", entry.GetKey(), "version", entry.GetVersion(), "error", err)
+	}
+}
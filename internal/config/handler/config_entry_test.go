@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/config/schema"
+	mock_collection "erp.localhost/internal/infra/db/mongo/collection/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestConfigEntryHandler(ctrl *gomock.Controller) (*ConfigEntryHandler, *mock_collection.MockCollectionHandler[configv1.ConfigEntry], *mock_collection.MockCollectionHandler[configv1.ConfigEntryChange]) {
+	entries := mock_collection.NewMockCollectionHandler[configv1.ConfigEntry](ctrl)
+	history := mock_collection.NewMockCollectionHandler[configv1.ConfigEntryChange](ctrl)
+	return &ConfigEntryHandler{
+		entries: entries,
+		history: history,
+		logger:  logger.NewBaseLogger(shared.ModuleConfig),
+	}, entries, history
+}
+
+func tenantRef(key string) *configv1.ConfigEntryRef {
+	return &configv1.ConfigEntryRef{Key: key, Scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT, TenantId: "tenant-1"}
+}
+
+func requireErrorCode(t *testing.T, expected infra_error.ErrorDef, err error) {
+	t.Helper()
+	appErr, ok := infra_error.AsAppError(err)
+	require.True(t, ok, "expected an app error, got %v", err)
+	assert.Equal(t, expected.Code, appErr.Code)
+}
+
+func TestValidateRef(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ref     *configv1.ConfigEntryRef
+		wantErr bool
+	}{
+		{name: "system", ref: &configv1.ConfigEntryRef{Key: "k", Scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM}},
+		{name: "system with tenant", ref: &configv1.ConfigEntryRef{Key: "k", Scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM, TenantId: "tenant-1"}, wantErr: true},
+		{name: "module", ref: &configv1.ConfigEntryRef{Key: "k", Scope: configv1.ConfigScope_CONFIG_SCOPE_MODULE, Module: "auth"}},
+		{name: "module without module", ref: &configv1.ConfigEntryRef{Key: "k", Scope: configv1.ConfigScope_CONFIG_SCOPE_MODULE}, wantErr: true},
+		{name: "tenant", ref: tenantRef("k")},
+		{name: "tenant within a module", ref: &configv1.ConfigEntryRef{Key: "k", Scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT, TenantId: "tenant-1", Module: "auth"}},
+		{name: "tenant without tenant", ref: &configv1.ConfigEntryRef{Key: "k", Scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT}, wantErr: true},
+		{name: "unspecified scope", ref: &configv1.ConfigEntryRef{Key: "k"}, wantErr: true},
+		{name: "missing key", ref: &configv1.ConfigEntryRef{Scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRef(tc.ref)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigEntryHandler_SetEntry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the first version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, entries, history := newTestConfigEntryHandler(ctrl)
+		ref := tenantRef(schema.KeyPasswordMinLength)
+
+		entries.EXPECT().FindAll(ctx, refFilter(ref)).Return(nil, nil)
+		// Versions continue after the entry was deleted at version 2
+		history.EXPECT().FindAll(ctx, refFilter(ref)).Return([]*configv1.ConfigEntryChange{{Version: 1}, {Version: 2}}, nil)
+		entries.EXPECT().Create(ctx, gomock.Any()).Return("entry-1", nil)
+		history.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *configv1.ConfigEntryChange) (string, error) {
+			assert.Equal(t, configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, change.GetChangeType())
+			assert.Equal(t, int32(3), change.GetVersion())
+			assert.Nil(t, change.GetOldValue())
+			assert.Equal(t, float64(12), change.GetNewValue().GetNumberValue())
+			return "change-1", nil
+		})
+
+		entry, err := h.SetEntry(ctx, ref, structpb.NewNumberValue(12), "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, "entry-1", entry.GetId())
+		assert.Equal(t, int32(3), entry.GetVersion())
+		assert.Equal(t, configv1.ConfigValueType_CONFIG_VALUE_TYPE_INT, entry.GetType())
+	})
+
+	t.Run("updates the next version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, entries, history := newTestConfigEntryHandler(ctrl)
+		ref := tenantRef(schema.KeyPasswordMinLength)
+		created := timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		current := &configv1.ConfigEntry{Id: "entry-1", Key: ref.GetKey(), Version: 4, Value: structpb.NewNumberValue(10), CreatedAt: created}
+
+		entries.EXPECT().FindAll(ctx, refFilter(ref)).Return([]*configv1.ConfigEntry{current}, nil)
+		entries.EXPECT().Update(ctx, refFilter(ref), gomock.Any()).DoAndReturn(func(_ context.Context, _ map[string]any, entry *configv1.ConfigEntry) error {
+			assert.Equal(t, int32(5), entry.GetVersion())
+			assert.Equal(t, created, entry.GetCreatedAt())
+			assert.Equal(t, "user-2", entry.GetUpdatedBy())
+			return nil
+		})
+		history.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *configv1.ConfigEntryChange) (string, error) {
+			assert.Equal(t, float64(10), change.GetOldValue().GetNumberValue())
+			assert.Equal(t, float64(16), change.GetNewValue().GetNumberValue())
+			return "change-2", nil
+		})
+
+		entry, err := h.SetEntry(ctx, ref, structpb.NewNumberValue(16), "user-2")
+		require.NoError(t, err)
+		assert.Equal(t, "entry-1", entry.GetId())
+	})
+
+	t.Run("keeps an unchanged value", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, entries, _ := newTestConfigEntryHandler(ctrl)
+		ref := tenantRef(schema.KeyMFARequired)
+		current := &configv1.ConfigEntry{Id: "entry-1", Version: 2, Value: structpb.NewBoolValue(true)}
+
+		entries.EXPECT().FindAll(ctx, refFilter(ref)).Return([]*configv1.ConfigEntry{current}, nil)
+
+		entry, err := h.SetEntry(ctx, ref, structpb.NewBoolValue(true), "user-1")
+		require.NoError(t, err)
+		assert.Same(t, current, entry)
+	})
+
+	rejected := []struct {
+		name     string
+		ref      *configv1.ConfigEntryRef
+		value    *structpb.Value
+		expected infra_error.ErrorDef
+	}{
+		{name: "unknown key", ref: tenantRef("unknown.key"), value: structpb.NewBoolValue(true), expected: infra_error.ValidationInvalidValue},
+		{name: "scope not allowed", ref: tenantRef(schema.KeyMaintenanceMode), value: structpb.NewBoolValue(true), expected: infra_error.ValidationInvalidValue},
+		{name: "value of another type", ref: tenantRef(schema.KeyMFARequired), value: structpb.NewNumberValue(1), expected: infra_error.ValidationInvalidType},
+		{name: "invalid ref", ref: &configv1.ConfigEntryRef{Key: schema.KeyMFARequired}, value: structpb.NewBoolValue(true), expected: infra_error.ValidationInvalidValue},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			h, _, _ := newTestConfigEntryHandler(ctrl)
+			_, err := h.SetEntry(ctx, tc.ref, tc.value, "user-1")
+			require.Error(t, err)
+			requireErrorCode(t, tc.expected, err)
+		})
+	}
+}
+
+func TestConfigEntryHandler_DeleteEntry(t *testing.T) {
+	ctx := context.Background()
+	ref := tenantRef(schema.KeyMFARequired)
+
+	t.Run("records the deleted value", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, entries, history := newTestConfigEntryHandler(ctrl)
+		current := &configv1.ConfigEntry{Id: "entry-1", Key: ref.GetKey(), Version: 3, Value: structpb.NewBoolValue(true)}
+
+		entries.EXPECT().FindAll(ctx, refFilter(ref)).Return([]*configv1.ConfigEntry{current}, nil)
+		entries.EXPECT().Delete(ctx, refFilter(ref)).Return(nil)
+		history.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *configv1.ConfigEntryChange) (string, error) {
+			assert.Equal(t, configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_DELETE, change.GetChangeType())
+			assert.Equal(t, int32(3), change.GetVersion())
+			assert.True(t, change.GetOldValue().GetBoolValue())
+			assert.Nil(t, change.GetNewValue())
+			return "change-1", nil
+		})
+
+		require.NoError(t, h.DeleteEntry(ctx, ref, "user-1"))
+	})
+
+	t.Run("entry not set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, entries, _ := newTestConfigEntryHandler(ctrl)
+
+		entries.EXPECT().FindAll(ctx, refFilter(ref)).Return(nil, nil)
+
+		err := h.DeleteEntry(ctx, ref, "user-1")
+		require.Error(t, err)
+		requireErrorCode(t, infra_error.NotFoundConfig, err)
+	})
+}
+
+func TestConfigEntryHandler_GetHistory(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	h, _, history := newTestConfigEntryHandler(ctrl)
+	ref := tenantRef(schema.KeyMFARequired)
+	at := func(minute int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2026, 1, 1, 0, minute, 0, 0, time.UTC))
+	}
+
+	history.EXPECT().FindAll(ctx, refFilter(ref)).Return([]*configv1.ConfigEntryChange{
+		{Id: "set-1", Version: 1, ChangedAt: at(1)},
+		{Id: "delete-2", Version: 2, ChangedAt: at(3), ChangeType: configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_DELETE},
+		{Id: "set-2", Version: 2, ChangedAt: at(2)},
+	}, nil)
+
+	changes, err := h.GetHistory(ctx, ref, 2)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "delete-2", changes[0].GetId())
+	assert.Equal(t, "set-2", changes[1].GetId())
+}
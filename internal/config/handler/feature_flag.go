@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+
+	collection_config "erp.localhost/internal/config/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/featureflag"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// flagKeyPattern is the format of the keys of the feature flags, e.g. billing.new_invoice_editor
+var flagKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// FeatureFlagHandler stores the feature flags and evaluates them, see featureflag.Evaluate.
+// Updates carry the version they were read at and are refused when the flag changed since
+type FeatureFlagHandler struct {
+	flags  collection_mongo.CollectionHandler[configv1.FeatureFlag]
+	logger logger.Logger
+}
+
+func NewFeatureFlagHandler(logger logger.Logger) (*FeatureFlagHandler, error) {
+	flags, err := collection_config.NewFeatureFlagCollection(logger)
+	if err != nil {
+		logger.Error("failed to create feature flag collection handler", "error", err)
+		return nil, err
+	}
+	return &FeatureFlagHandler{
+		flags:  flags,
+		logger: logger,
+	}, nil
+}
+
+// validateFlag checks the key, name, scope and rollout percentage of flag
+func validateFlag(flag *configv1.FeatureFlag) error {
+	if flag.GetKey() == "" || flag.GetName() == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "key", "name")
+	}
+	if !flagKeyPattern.MatchString(flag.GetKey()) {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "key").WithDetails("key", flag.GetKey())
+	}
+	if _, ok := configv1.FeatureFlagScope_name[int32(flag.GetScope())]; !ok {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "scope")
+	}
+	if percentage := flag.GetRollout().GetPercentage(); percentage < 0 || percentage > 100 {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "rollout.percentage")
+	}
+	return nil
+}
+
+func flagFilter(key string) map[string]any {
+	return map[string]any{"key": key}
+}
+
+// find returns the flag of key, nil when it does not exist
+func (h *FeatureFlagHandler) find(ctx context.Context, key string) (*configv1.FeatureFlag, error) {
+	flags, err := h.flags.FindAll(ctx, flagFilter(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	return flags[0], nil
+}
+
+// GetFlag returns the flag of key
+func (h *FeatureFlagHandler) GetFlag(ctx context.Context, key string) (*configv1.FeatureFlag, error) {
+	if key == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "key")
+	}
+	flag, err := h.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if flag == nil {
+		return nil, infra_error.NotFound(infra_error.NotFoundResource, "feature_flag", key)
+	}
+	return flag, nil
+}
+
+// ListFlags returns every flag sorted by key
+func (h *FeatureFlagHandler) ListFlags(ctx context.Context) ([]*configv1.FeatureFlag, error) {
+	flags, err := h.flags.FindAll(ctx, map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].GetKey() < flags[j].GetKey() })
+	return flags, nil
+}
+
+// CreateFlag stores a new flag, its overrides are set with SetOverride
+func (h *FeatureFlagHandler) CreateFlag(ctx context.Context, flag *configv1.FeatureFlag, createdBy string) (*configv1.FeatureFlag, error) {
+	if err := validateFlag(flag); err != nil {
+		return nil, err
+	}
+	if createdBy == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "CreatedBy")
+	}
+	exists, err := h.flags.Exists(ctx, flagFilter(flag.GetKey()))
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, infra_error.Conflict(infra_error.ConflictDuplicateResource).WithDetails("key", flag.GetKey())
+	}
+
+	now := timestamppb.Now()
+	flag = proto.Clone(flag).(*configv1.FeatureFlag)
+	flag.Id = ""
+	flag.FlagId = flag.GetKey()
+	flag.Overrides = nil
+	flag.CreatedAt = now
+	flag.UpdatedAt = now
+	flag.UpdatedBy = createdBy
+	flag.Version = 0
+	h.logger.WithContext(ctx).Debug("Creating feature flag", "key", flag.GetKey())
+	id, err := h.flags.Create(ctx, flag)
+	if err != nil {
+		return nil, err
+	}
+	flag.Id = id
+	return flag, nil
+}
+
+// UpdateFlag replaces the definition of the flag of flag.Key, the overrides are kept. flag.Version must be the
+// version the flag was read at
+func (h *FeatureFlagHandler) UpdateFlag(ctx context.Context, flag *configv1.FeatureFlag, updatedBy string) (*configv1.FeatureFlag, error) {
+	if err := validateFlag(flag); err != nil {
+		return nil, err
+	}
+	if updatedBy == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "UpdatedBy")
+	}
+	current, err := h.GetFlag(ctx, flag.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	updated := proto.Clone(flag).(*configv1.FeatureFlag)
+	updated.Id = current.GetId()
+	updated.FlagId = current.GetFlagId()
+	updated.Overrides = current.GetOverrides()
+	updated.CreatedAt = current.GetCreatedAt()
+	updated.UpdatedBy = updatedBy
+	return h.save(ctx, updated, flag.GetVersion())
+}
+
+// DeleteFlag removes the flag of key, it evaluates to false from then on
+func (h *FeatureFlagHandler) DeleteFlag(ctx context.Context, key, deletedBy string) error {
+	if deletedBy == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "DeletedBy")
+	}
+	if _, err := h.GetFlag(ctx, key); err != nil {
+		return err
+	}
+	h.logger.WithContext(ctx).Debug("Deleting feature flag", "key", key)
+	return h.flags.Delete(ctx, flagFilter(key))
+}
+
+// SetOverride forces the flag of key on or off for the tenant, or for the user of the tenant when userID is set
+func (h *FeatureFlagHandler) SetOverride(ctx context.Context, key, tenantID, userID string, enabled bool, updatedBy string) (*configv1.FeatureFlag, error) {
+	if tenantID == "" || updatedBy == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id", "UpdatedBy")
+	}
+	flag, err := h.GetFlag(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	overrides := slices.DeleteFunc(flag.GetOverrides(), func(o *configv1.FeatureFlagOverride) bool {
+		return o.GetTenantId() == tenantID && o.GetUserId() == userID
+	})
+	flag.Overrides = append(overrides, &configv1.FeatureFlagOverride{TenantId: tenantID, UserId: userID, Enabled: enabled})
+	flag.UpdatedBy = updatedBy
+	return h.save(ctx, flag, flag.GetVersion())
+}
+
+// DeleteOverride removes the override of the tenant, or of the user of the tenant when userID is set
+func (h *FeatureFlagHandler) DeleteOverride(ctx context.Context, key, tenantID, userID, updatedBy string) (*configv1.FeatureFlag, error) {
+	if tenantID == "" || updatedBy == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id", "UpdatedBy")
+	}
+	flag, err := h.GetFlag(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	count := len(flag.GetOverrides())
+	flag.Overrides = slices.DeleteFunc(flag.GetOverrides(), func(o *configv1.FeatureFlagOverride) bool {
+		return o.GetTenantId() == tenantID && o.GetUserId() == userID
+	})
+	if len(flag.GetOverrides()) == count {
+		return nil, infra_error.NotFound(infra_error.NotFoundResource, "feature_flag_override", tenantID)
+	}
+	flag.UpdatedBy = updatedBy
+	return h.save(ctx, flag, flag.GetVersion())
+}
+
+// Evaluate returns whether each flag of keys is on for the user of the tenant, every flag when keys is empty.
+// Unknown keys are off
+func (h *FeatureFlagHandler) Evaluate(ctx context.Context, tenantID, userID string, keys []string) (map[string]bool, error) {
+	filter := map[string]any{}
+	if len(keys) > 0 {
+		filter["key"] = map[string]any{"$in": keys}
+	}
+	flags, err := h.flags.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, max(len(keys), len(flags)))
+	for _, key := range keys {
+		result[key] = false
+	}
+	for _, flag := range flags {
+		result[flag.GetKey()] = featureflag.Evaluate(flag, tenantID, userID)
+	}
+	return result, nil
+}
+
+// save stores flag as the version after expectedVersion
+func (h *FeatureFlagHandler) save(ctx context.Context, flag *configv1.FeatureFlag, expectedVersion int64) (*configv1.FeatureFlag, error) {
+	flag.UpdatedAt = timestamppb.Now()
+	h.logger.WithContext(ctx).Debug("Saving feature flag", "key", flag.GetKey(), "expected_version", expectedVersion)
+	if err := h.flags.UpdateVersioned(ctx, flagFilter(flag.GetKey()), flag, expectedVersion); err != nil {
+		return nil, err
+	}
+	flag.Version = expectedVersion + 1
+	return flag, nil
+}
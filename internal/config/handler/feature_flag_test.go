@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	mock_collection "erp.localhost/internal/infra/db/mongo/collection/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestFeatureFlagHandler(ctrl *gomock.Controller) (*FeatureFlagHandler, *mock_collection.MockCollectionHandler[configv1.FeatureFlag]) {
+	flags := mock_collection.NewMockCollectionHandler[configv1.FeatureFlag](ctrl)
+	return &FeatureFlagHandler{
+		flags:  flags,
+		logger: logger.NewBaseLogger(shared.ModuleConfig),
+	}, flags
+}
+
+func TestValidateFlag(t *testing.T) {
+	testCases := []struct {
+		name    string
+		flag    *configv1.FeatureFlag
+		wantErr bool
+	}{
+		{name: "valid", flag: &configv1.FeatureFlag{Key: "billing.new_editor", Name: "New editor", Rollout: &configv1.FeatureRollout{Percentage: 100}}},
+		{name: "missing name", flag: &configv1.FeatureFlag{Key: "billing.new_editor"}, wantErr: true},
+		{name: "invalid key", flag: &configv1.FeatureFlag{Key: "Billing New Editor", Name: "New editor"}, wantErr: true},
+		{name: "invalid percentage", flag: &configv1.FeatureFlag{Key: "k", Name: "n", Rollout: &configv1.FeatureRollout{Percentage: 101}}, wantErr: true},
+		{name: "invalid scope", flag: &configv1.FeatureFlag{Key: "k", Name: "n", Scope: configv1.FeatureFlagScope(9)}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateFlag(tc.flag)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeatureFlagHandler_CreateFlag(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the flag without overrides", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, flags := newTestFeatureFlagHandler(ctrl)
+		flag := &configv1.FeatureFlag{Key: "k", Name: "n", Enabled: true, Overrides: []*configv1.FeatureFlagOverride{{TenantId: "tenant-1"}}}
+
+		flags.EXPECT().Exists(ctx, flagFilter("k")).Return(false, nil)
+		flags.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, created *configv1.FeatureFlag) (string, error) {
+			assert.Empty(t, created.GetOverrides())
+			assert.Equal(t, "user-1", created.GetUpdatedBy())
+			return "flag-1", nil
+		})
+
+		created, err := h.CreateFlag(ctx, flag, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, "flag-1", created.GetId())
+		assert.Len(t, flag.GetOverrides(), 1, "the request is not modified")
+	})
+
+	t.Run("refuses an existing key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, flags := newTestFeatureFlagHandler(ctrl)
+
+		flags.EXPECT().Exists(ctx, flagFilter("k")).Return(true, nil)
+
+		_, err := h.CreateFlag(ctx, &configv1.FeatureFlag{Key: "k", Name: "n"}, "user-1")
+		requireErrorCode(t, infra_error.ConflictDuplicateResource, err)
+	})
+}
+
+func TestFeatureFlagHandler_UpdateFlag(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	h, flags := newTestFeatureFlagHandler(ctrl)
+	overrides := []*configv1.FeatureFlagOverride{{TenantId: "tenant-1", Enabled: true}}
+	current := &configv1.FeatureFlag{Id: "flag-1", Key: "k", Name: "n", Overrides: overrides, Version: 3}
+
+	flags.EXPECT().FindAll(ctx, flagFilter("k")).Return([]*configv1.FeatureFlag{current}, nil)
+	flags.EXPECT().UpdateVersioned(ctx, flagFilter("k"), gomock.Any(), int64(2)).DoAndReturn(func(_ context.Context, _ map[string]any, flag *configv1.FeatureFlag, _ int64) error {
+		assert.Equal(t, "flag-1", flag.GetId())
+		assert.Len(t, flag.GetOverrides(), 1)
+		return infra_error.Conflict(infra_error.ConflictResourceModified)
+	})
+
+	// The flag was read at version 2 and changed since
+	_, err := h.UpdateFlag(ctx, &configv1.FeatureFlag{Key: "k", Name: "renamed", Version: 2}, "user-1")
+	requireErrorCode(t, infra_error.ConflictResourceModified, err)
+}
+
+func TestFeatureFlagHandler_Overrides(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("replaces the override of the user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, flags := newTestFeatureFlagHandler(ctrl)
+		current := &configv1.FeatureFlag{Key: "k", Name: "n", Version: 1, Overrides: []*configv1.FeatureFlagOverride{
+			{TenantId: "tenant-1", Enabled: true},
+			{TenantId: "tenant-1", UserId: "user-2", Enabled: true},
+		}}
+
+		flags.EXPECT().FindAll(ctx, flagFilter("k")).Return([]*configv1.FeatureFlag{current}, nil)
+		flags.EXPECT().UpdateVersioned(ctx, flagFilter("k"), gomock.Any(), int64(1)).Return(nil)
+
+		flag, err := h.SetOverride(ctx, "k", "tenant-1", "user-2", false, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), flag.GetVersion())
+		require.Len(t, flag.GetOverrides(), 2)
+		assert.True(t, flag.GetOverrides()[0].GetEnabled())
+		assert.False(t, flag.GetOverrides()[1].GetEnabled())
+	})
+
+	t.Run("deletes a missing override", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		h, flags := newTestFeatureFlagHandler(ctrl)
+		current := &configv1.FeatureFlag{Key: "k", Name: "n", Overrides: []*configv1.FeatureFlagOverride{{TenantId: "tenant-1", Enabled: true}}}
+
+		flags.EXPECT().FindAll(ctx, flagFilter("k")).Return([]*configv1.FeatureFlag{current}, nil)
+
+		_, err := h.DeleteOverride(ctx, "k", "tenant-2", "", "user-1")
+		requireErrorCode(t, infra_error.NotFoundResource, err)
+	})
+}
+
+func TestFeatureFlagHandler_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	h, flags := newTestFeatureFlagHandler(ctrl)
+	keys := []string{"on", "off", "unknown"}
+
+	flags.EXPECT().FindAll(ctx, map[string]any{"key": map[string]any{"$in": keys}}).Return([]*configv1.FeatureFlag{
+		{Key: "on", Enabled: true, Rollout: &configv1.FeatureRollout{TenantIds: []string{"tenant-1"}}},
+		{Key: "off", Enabled: true},
+	}, nil)
+
+	result, err := h.Evaluate(ctx, "tenant-1", "", keys)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"on": true, "off": false, "unknown": false}, result)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+
+	collection_config "erp.localhost/internal/config/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ServiceConfigHandler stores versioned service configs, every change inserts a new version
+// so the history of a config is kept. The active config is the highest active version.
+type ServiceConfigHandler struct {
+	collection collection_mongo.CollectionHandler[configv1.ServiceConfig]
+	logger     logger.Logger
+}
+
+func NewServiceConfigHandler(logger logger.Logger) (*ServiceConfigHandler, error) {
+	collection, err := collection_config.NewServiceConfigCollection(logger)
+	if err != nil {
+		logger.Error("failed to create service config collection handler", "error", err)
+		return nil, err
+	}
+	return &ServiceConfigHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+// GetActiveConfig returns the latest active config of the service for the tenant, nil when there is none
+func (h *ServiceConfigHandler) GetActiveConfig(ctx context.Context, serviceName, environment, tenantID string) (*configv1.ServiceConfig, error) {
+	if serviceName == "" || environment == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "ServiceName", "Environment")
+	}
+	filter := map[string]any{
+		"service_name": serviceName,
+		"environment":  environment,
+		"tenant_id":    tenantID,
+		"is_active":    true,
+	}
+	if tenantID == "" {
+		// System-wide configs are stored without tenant_id, null also matches the missing field
+		filter["tenant_id"] = nil
+	}
+	h.logger.Debug("Getting active service config", "filter", filter)
+	configs, err := h.collection.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var latest *configv1.ServiceConfig
+	for _, config := range configs {
+		if latest == nil || config.GetVersion() > latest.GetVersion() {
+			latest = config
+		}
+	}
+	return latest, nil
+}
+
+// SaveConfig stores data as the next version of the service config for the tenant
+func (h *ServiceConfigHandler) SaveConfig(ctx context.Context, serviceName, environment, tenantID, updatedBy string, data *structpb.Struct) (*configv1.ServiceConfig, error) {
+	if serviceName == "" || environment == "" || updatedBy == "" || data == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "ServiceName", "Environment", "UpdatedBy", "Config")
+	}
+	current, err := h.GetActiveConfig(ctx, serviceName, environment, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := timestamppb.Now()
+	config := &configv1.ServiceConfig{
+		ConfigId:    serviceName + ":" + environment + ":" + tenantID,
+		ServiceName: serviceName,
+		Environment: environment,
+		TenantId:    tenantID,
+		Config:      data,
+		Version:     current.GetVersion() + 1,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		UpdatedBy:   updatedBy,
+	}
+	h.logger.Debug("Saving service config", "service_name", serviceName, "environment", environment, "tenant_id", tenantID, "version", config.GetVersion())
+	id, err := h.collection.Create(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	config.Id = id
+	return config, nil
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"strings"
+	"sync"
+
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+)
+
+// watchBufferSize is the number of events queued for a watcher, watchers falling further behind are dropped
+// and resync on reconnect
+const watchBufferSize = 64
+
+// ConfigWatcher fans out the changes of config entries to the WatchConfig streams of this instance
+type ConfigWatcher struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+}
+
+type watcher struct {
+	req    *configv1.WatchConfigRequest
+	events chan *configv1.ConfigEvent
+}
+
+func NewConfigWatcher() *ConfigWatcher {
+	return &ConfigWatcher{watchers: make(map[*watcher]struct{})}
+}
+
+// Subscribe returns the changes of the entries matching req. The channel is closed by cancel, or when the
+// watcher falls behind
+func (w *ConfigWatcher) Subscribe(req *configv1.WatchConfigRequest) (<-chan *configv1.ConfigEvent, func()) {
+	sub := &watcher{req: req, events: make(chan *configv1.ConfigEvent, watchBufferSize)}
+	w.mu.Lock()
+	w.watchers[sub] = struct{}{}
+	w.mu.Unlock()
+	return sub.events, func() { w.remove(sub) }
+}
+
+func (w *ConfigWatcher) remove(sub *watcher) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.watchers[sub]; ok {
+		delete(w.watchers, sub)
+		close(sub.events)
+	}
+}
+
+// publish sends a change to the watchers of the entry
+func (w *ConfigWatcher) publish(changeType configv1.ConfigChangeType, entry *configv1.ConfigEntry) {
+	if w == nil {
+		return
+	}
+	event := &configv1.ConfigEvent{Type: changeType, Entry: entry}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sub := range w.watchers {
+		if !WatchMatches(sub.req, entry) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			delete(w.watchers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// WatchMatches reports whether entry is watched by req
+func WatchMatches(req *configv1.WatchConfigRequest, entry *configv1.ConfigEntry) bool {
+	if prefixes := req.GetKeyPrefixes(); len(prefixes) > 0 {
+		matched := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(entry.GetKey(), prefix) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	sameModule := req.GetModule() == "" || entry.GetModule() == "" || entry.GetModule() == req.GetModule()
+	switch entry.GetScope() {
+	case configv1.ConfigScope_CONFIG_SCOPE_SYSTEM:
+		return true
+	case configv1.ConfigScope_CONFIG_SCOPE_MODULE:
+		return sameModule
+	case configv1.ConfigScope_CONFIG_SCOPE_TENANT:
+		sameTenant := req.GetAllTenants() || (req.GetTenantId() != "" && entry.GetTenantId() == req.GetTenantId())
+		return sameTenant && sameModule
+	default:
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"testing"
+
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchMatches(t *testing.T) {
+	system := &configv1.ConfigEntry{Key: "auth.mfa.required", Scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM}
+	authModule := &configv1.ConfigEntry{Key: "auth.mfa.required", Scope: configv1.ConfigScope_CONFIG_SCOPE_MODULE, Module: "auth"}
+	coreModule := &configv1.ConfigEntry{Key: "core.inventory.low_stock_threshold", Scope: configv1.ConfigScope_CONFIG_SCOPE_MODULE, Module: "core"}
+	tenant := &configv1.ConfigEntry{Key: "auth.mfa.required", Scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT, TenantId: "tenant-1"}
+	otherTenant := &configv1.ConfigEntry{Key: "auth.mfa.required", Scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT, TenantId: "tenant-2"}
+
+	testCases := []struct {
+		name     string
+		req      *configv1.WatchConfigRequest
+		expected []*configv1.ConfigEntry
+	}{
+		{
+			name:     "system and module entries",
+			req:      &configv1.WatchConfigRequest{Module: "auth"},
+			expected: []*configv1.ConfigEntry{system, authModule},
+		},
+		{
+			name:     "entries of the tenant",
+			req:      &configv1.WatchConfigRequest{Module: "auth", TenantId: "tenant-1"},
+			expected: []*configv1.ConfigEntry{system, authModule, tenant},
+		},
+		{
+			name:     "entries of every tenant",
+			req:      &configv1.WatchConfigRequest{AllTenants: true},
+			expected: []*configv1.ConfigEntry{system, authModule, coreModule, tenant, otherTenant},
+		},
+		{
+			name:     "key prefixes",
+			req:      &configv1.WatchConfigRequest{KeyPrefixes: []string{"core."}},
+			expected: []*configv1.ConfigEntry{coreModule},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var matched []*configv1.ConfigEntry
+			for _, entry := range []*configv1.ConfigEntry{system, authModule, coreModule, tenant, otherTenant} {
+				if WatchMatches(tc.req, entry) {
+					matched = append(matched, entry)
+				}
+			}
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestConfigWatcher(t *testing.T) {
+	w := NewConfigWatcher()
+	events, cancel := w.Subscribe(&configv1.WatchConfigRequest{KeyPrefixes: []string{"auth."}})
+	slow, _ := w.Subscribe(&configv1.WatchConfigRequest{})
+
+	entry := &configv1.ConfigEntry{Key: "auth.mfa.required", Scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM}
+	w.publish(configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, entry)
+	w.publish(configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, &configv1.ConfigEntry{Key: "core.pagination.default_page_size", Scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM})
+
+	event := <-events
+	assert.Equal(t, entry, event.GetEntry())
+	assert.Empty(t, events)
+
+	// Watchers that fall behind are dropped
+	for range watchBufferSize {
+		w.publish(configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, entry)
+	}
+	received := 0
+	for range slow {
+		received++
+	}
+	assert.Equal(t, watchBufferSize, received)
+
+	// Canceling closes the channel once, the queued events stay readable
+	cancel()
+	cancel()
+	queued := 0
+	for range events {
+		queued++
+	}
+	assert.Equal(t, watchBufferSize, queued)
+}
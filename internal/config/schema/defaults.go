@@ -0,0 +1,89 @@
+package schema
+
+import configv1 "erp.localhost/internal/infra/model/config/v1"
+
+var (
+	systemAndTenant = []configv1.ConfigScope{configv1.ConfigScope_CONFIG_SCOPE_SYSTEM, configv1.ConfigScope_CONFIG_SCOPE_TENANT}
+	systemAndModule = []configv1.ConfigScope{configv1.ConfigScope_CONFIG_SCOPE_SYSTEM, configv1.ConfigScope_CONFIG_SCOPE_MODULE}
+)
+
+// Keys of the built-in schemas
+const (
+	KeyPasswordMinLength    = "auth.password.min_length"
+	KeySessionIdleTimeout   = "auth.session.idle_timeout"
+	KeyMFARequired          = "auth.mfa.required"
+	KeyLogLevel             = "logging.level"
+	KeyLowStockThreshold    = "core.inventory.low_stock_threshold"
+	KeyDefaultPageSize      = "core.pagination.default_page_size"
+	KeyMaintenanceMode      = "system.maintenance_mode"
+	KeyMaintenanceMessage   = "system.maintenance_message"
+	KeyNotificationChannels = "notifications.channels"
+)
+
+func init() {
+	for _, schema := range []Schema{
+		{
+			Key:         KeyPasswordMinLength,
+			Description: "Minimum length of user passwords",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_INT,
+			Scopes:      systemAndTenant,
+			Min:         Bound(8),
+			Max:         Bound(128),
+		},
+		{
+			Key:         KeySessionIdleTimeout,
+			Description: "Idle time after which a session has to log in again",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_DURATION,
+			Scopes:      systemAndTenant,
+			Min:         Bound(60),
+			Max:         Bound(24 * 60 * 60),
+		},
+		{
+			Key:         KeyMFARequired,
+			Description: "Whether users have to enroll a second factor",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_BOOL,
+			Scopes:      systemAndTenant,
+		},
+		{
+			Key:         KeyLogLevel,
+			Description: "Log level of the services",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_STRING,
+			Scopes:      systemAndModule,
+			Enum:        []string{"trace", "debug", "info", "warn", "error", "fatal"},
+		},
+		{
+			Key:         KeyLowStockThreshold,
+			Description: "Quantity under which an inventory item is reported as low on stock",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_INT,
+			Scopes:      systemAndTenant,
+			Min:         Bound(0),
+		},
+		{
+			Key:         KeyDefaultPageSize,
+			Description: "Page size of listings that do not set one",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_INT,
+			Min:         Bound(1),
+			Max:         Bound(1000),
+		},
+		{
+			Key:         KeyMaintenanceMode,
+			Description: "Rejects requests of non system users while enabled",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_BOOL,
+			Scopes:      []configv1.ConfigScope{configv1.ConfigScope_CONFIG_SCOPE_SYSTEM},
+		},
+		{
+			Key:         KeyMaintenanceMessage,
+			Description: "Message shown to users during maintenance",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_STRING,
+			Scopes:      []configv1.ConfigScope{configv1.ConfigScope_CONFIG_SCOPE_SYSTEM},
+		},
+		{
+			Key:         KeyNotificationChannels,
+			Description: "Channels notifications are delivered on, e.g. [\"email\"]",
+			Type:        configv1.ConfigValueType_CONFIG_VALUE_TYPE_JSON,
+			Scopes:      systemAndTenant,
+		},
+	} {
+		Register(schema)
+	}
+}
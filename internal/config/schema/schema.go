@@ -0,0 +1,133 @@
+// Package schema holds the schemas config entries are validated against. A key can only be set once a schema
+// is registered for it, the schema fixes the type of its value and the scopes it can be set at.
+package schema
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Schema describes a config key
+type Schema struct {
+	Key         string
+	Description string
+	Type        configv1.ConfigValueType
+	// Scopes the key can be set at, every scope when empty
+	Scopes []configv1.ConfigScope
+	// Bounds of INT and FLOAT values, and of DURATION values in seconds. Unbounded when nil
+	Min, Max *float64
+	// Allowed STRING values, any value when empty
+	Enum []string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Schema)
+)
+
+// Register adds the schema of a key, replacing the one registered before for it
+func Register(schema Schema) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[schema.Key] = schema
+}
+
+// Lookup returns the schema registered for key
+func Lookup(key string) (Schema, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	schema, ok := registry[key]
+	return schema, ok
+}
+
+// Schemas returns the registered schemas sorted by key
+func Schemas() []Schema {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	schemas := make([]Schema, 0, len(registry))
+	for _, schema := range registry {
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Key < schemas[j].Key })
+	return schemas
+}
+
+// Bound returns a pointer to v, for Schema.Min and Schema.Max
+func Bound(v float64) *float64 {
+	return &v
+}
+
+// AllowsScope reports whether the key can be set at scope
+func (s Schema) AllowsScope(scope configv1.ConfigScope) bool {
+	return len(s.Scopes) == 0 || slices.Contains(s.Scopes, scope)
+}
+
+// Validate checks value against the type, bounds and allowed values of the schema
+func (s Schema) Validate(value *structpb.Value) error {
+	if value == nil || value.GetKind() == nil {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "value")
+	}
+	if _, ok := value.GetKind().(*structpb.Value_NullValue); ok {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "value")
+	}
+
+	switch s.Type {
+	case configv1.ConfigValueType_CONFIG_VALUE_TYPE_STRING:
+		str, ok := value.GetKind().(*structpb.Value_StringValue)
+		if !ok {
+			return infra_error.Validation(infra_error.ValidationInvalidType, "value")
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str.StringValue) {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "value")
+		}
+	case configv1.ConfigValueType_CONFIG_VALUE_TYPE_INT:
+		number, ok := value.GetKind().(*structpb.Value_NumberValue)
+		if !ok || number.NumberValue != math.Trunc(number.NumberValue) {
+			return infra_error.Validation(infra_error.ValidationInvalidType, "value")
+		}
+		return s.checkBounds(number.NumberValue)
+	case configv1.ConfigValueType_CONFIG_VALUE_TYPE_FLOAT:
+		number, ok := value.GetKind().(*structpb.Value_NumberValue)
+		if !ok {
+			return infra_error.Validation(infra_error.ValidationInvalidType, "value")
+		}
+		return s.checkBounds(number.NumberValue)
+	case configv1.ConfigValueType_CONFIG_VALUE_TYPE_BOOL:
+		if _, ok := value.GetKind().(*structpb.Value_BoolValue); !ok {
+			return infra_error.Validation(infra_error.ValidationInvalidType, "value")
+		}
+	case configv1.ConfigValueType_CONFIG_VALUE_TYPE_DURATION:
+		str, ok := value.GetKind().(*structpb.Value_StringValue)
+		if !ok {
+			return infra_error.Validation(infra_error.ValidationInvalidType, "value")
+		}
+		duration, err := time.ParseDuration(str.StringValue)
+		if err != nil {
+			return infra_error.Validation(infra_error.ValidationInvalidFormat, "value").WithError(err)
+		}
+		return s.checkBounds(duration.Seconds())
+	case configv1.ConfigValueType_CONFIG_VALUE_TYPE_JSON:
+		switch value.GetKind().(type) {
+		case *structpb.Value_StructValue, *structpb.Value_ListValue:
+		default:
+			return infra_error.Validation(infra_error.ValidationInvalidType, "value")
+		}
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidType, "type")
+	}
+	return nil
+}
+
+func (s Schema) checkBounds(v float64) error {
+	if (s.Min != nil && v < *s.Min) || (s.Max != nil && v > *s.Max) {
+		return infra_error.Validation(infra_error.ValidationOutOfRange, "value")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		key           string
+		value         *structpb.Value
+		expectedError *infra_error.ErrorDef
+	}{
+		{name: "int", key: KeyPasswordMinLength, value: structpb.NewNumberValue(12)},
+		{name: "int below min", key: KeyPasswordMinLength, value: structpb.NewNumberValue(4), expectedError: &infra_error.ValidationOutOfRange},
+		{name: "fractional int", key: KeyPasswordMinLength, value: structpb.NewNumberValue(8.5), expectedError: &infra_error.ValidationInvalidType},
+		{name: "duration", key: KeySessionIdleTimeout, value: structpb.NewStringValue("30m")},
+		{name: "malformed duration", key: KeySessionIdleTimeout, value: structpb.NewStringValue("soon"), expectedError: &infra_error.ValidationInvalidFormat},
+		{name: "duration above max", key: KeySessionIdleTimeout, value: structpb.NewStringValue("48h"), expectedError: &infra_error.ValidationOutOfRange},
+		{name: "bool", key: KeyMFARequired, value: structpb.NewBoolValue(true)},
+		{name: "string instead of bool", key: KeyMFARequired, value: structpb.NewStringValue("true"), expectedError: &infra_error.ValidationInvalidType},
+		{name: "enum", key: KeyLogLevel, value: structpb.NewStringValue("debug")},
+		{name: "value outside enum", key: KeyLogLevel, value: structpb.NewStringValue("verbose"), expectedError: &infra_error.ValidationInvalidValue},
+		{name: "json list", key: KeyNotificationChannels, value: structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("email")}})},
+		{name: "json scalar", key: KeyNotificationChannels, value: structpb.NewStringValue("email"), expectedError: &infra_error.ValidationInvalidType},
+		{name: "missing value", key: KeyMFARequired, value: nil, expectedError: &infra_error.ValidationRequiredFields},
+		{name: "null value", key: KeyMFARequired, value: structpb.NewNullValue(), expectedError: &infra_error.ValidationRequiredFields},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, ok := Lookup(tc.key)
+			require.True(t, ok)
+			err := schema.Validate(tc.value)
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			appErr, ok := infra_error.AsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedError.Code, appErr.Code)
+		})
+	}
+}
+
+func TestSchema_AllowsScope(t *testing.T) {
+	schema, ok := Lookup(KeyMaintenanceMode)
+	require.True(t, ok)
+	assert.True(t, schema.AllowsScope(configv1.ConfigScope_CONFIG_SCOPE_SYSTEM))
+	assert.False(t, schema.AllowsScope(configv1.ConfigScope_CONFIG_SCOPE_TENANT))
+
+	schema, ok = Lookup(KeyDefaultPageSize)
+	require.True(t, ok)
+	assert.True(t, schema.AllowsScope(configv1.ConfigScope_CONFIG_SCOPE_MODULE))
+}
+
+func TestRegister(t *testing.T) {
+	Register(Schema{Key: "test.flag", Type: configv1.ConfigValueType_CONFIG_VALUE_TYPE_BOOL})
+	schema, ok := Lookup("test.flag")
+	require.True(t, ok)
+	assert.Equal(t, configv1.ConfigValueType_CONFIG_VALUE_TYPE_BOOL, schema.Type)
+	assert.Contains(t, Schemas(), schema)
+
+	_, ok = Lookup("test.unknown")
+	assert.False(t, ok)
+}
@@ -1,13 +1,103 @@
 package service
 
-import "erp.localhost/internal/infra/model/shared"
+import (
+	"context"
+	"os"
+	"strings"
 
+	"erp.localhost/internal/config/handler"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const defaultEnvironment = "development"
+
+// ConfigService serves per-tenant module configs. An empty tenant id addresses the system-wide config of the module.
 type ConfigService struct {
-	configs map[shared.Module]ConfigService
+	logger        logger.Logger
+	environment   string
+	configHandler *handler.ServiceConfigHandler
+	entryHandler  *handler.ConfigEntryHandler
+	configv1.UnimplementedConfigServiceServer
 }
 
-func NewConfigService() *ConfigService {
+func NewConfigService(logger logger.Logger) (*ConfigService, error) {
+	configHandler, err := handler.NewServiceConfigHandler(logger)
+	if err != nil {
+		logger.Error("failed to create service config handler", "error", err)
+		return nil, err
+	}
+	entryHandler, err := handler.NewConfigEntryHandler(logger)
+	if err != nil {
+		logger.Error("failed to create config entry handler", "error", err)
+		return nil, err
+	}
+	environment := os.Getenv("ENVIRONMENT")
+	if environment == "" {
+		environment = defaultEnvironment
+	}
 	return &ConfigService{
-		configs: make(map[shared.Module]ConfigService),
+		logger:        logger,
+		environment:   environment,
+		configHandler: configHandler,
+		entryHandler:  entryHandler,
+	}, nil
+}
+
+func (c *ConfigService) GetConfig(ctx context.Context, req *configv1.ConfigRequest) (*configv1.ConfigResponse, error) {
+	module, err := normalizeModule(req.GetModule())
+	if err != nil {
+		c.logger.WithContext(ctx).Error("invalid module", "module", req.GetModule(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	config, err := c.configHandler.GetActiveConfig(ctx, module, c.environment, req.GetTenantId())
+	if err != nil {
+		c.logger.WithContext(ctx).Error("failed to get config", "module", module, "tenant_id", req.GetTenantId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	if config == nil {
+		return &configv1.ConfigResponse{
+			Data: &structpb.Struct{Fields: map[string]*structpb.Value{}},
+		}, nil
+	}
+	return &configv1.ConfigResponse{
+		Data:    config.GetConfig(),
+		Version: config.GetVersion(),
+	}, nil
+}
+
+func (c *ConfigService) SetConfig(ctx context.Context, req *configv1.SetConfigRequest) (*configv1.ConfigResponse, error) {
+	module, err := normalizeModule(req.GetModule())
+	if err != nil {
+		c.logger.WithContext(ctx).Error("invalid module", "module", req.GetModule(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	if req.GetUserId() == "" || req.GetData() == nil {
+		err := infra_error.Validation(infra_error.ValidationRequiredFields, "user_id", "data")
+		c.logger.WithContext(ctx).Error("failed to set config", "module", module, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	config, err := c.configHandler.SaveConfig(ctx, module, c.environment, req.GetTenantId(), req.GetUserId(), req.GetData())
+	if err != nil {
+		c.logger.WithContext(ctx).Error("failed to set config", "module", module, "tenant_id", req.GetTenantId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	c.logger.WithContext(ctx).Info("config updated", "module", module, "tenant_id", req.GetTenantId(), "version", config.GetVersion(), "updated_by", req.GetUserId())
+	return &configv1.ConfigResponse{
+		Data:    config.GetConfig(),
+		Version: config.GetVersion(),
+	}, nil
+}
+
+// normalizeModule returns the lower case module name used as the config service name
+func normalizeModule(module string) (string, error) {
+	if !shared.IsValidModule(module) {
+		return "", infra_error.Validation(infra_error.ValidationInvalidValue, "module")
 	}
+	return strings.ToLower(module), nil
 }
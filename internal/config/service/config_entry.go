@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"erp.localhost/internal/config/handler"
+	infra_error "erp.localhost/internal/infra/error"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+)
+
+// entryRequest is a request addressing a single config entry
+type entryRequest interface {
+	GetKey() string
+	GetScope() configv1.ConfigScope
+	GetTenantId() string
+	GetModule() string
+}
+
+// entryRef returns the entry addressed by req with the module normalized, tenant entries may omit the module
+func entryRef(req entryRequest) (*configv1.ConfigEntryRef, error) {
+	ref := &configv1.ConfigEntryRef{
+		Key:      req.GetKey(),
+		Scope:    req.GetScope(),
+		TenantId: req.GetTenantId(),
+	}
+	if req.GetModule() != "" {
+		module, err := normalizeModule(req.GetModule())
+		if err != nil {
+			return nil, err
+		}
+		ref.Module = module
+	}
+	return ref, nil
+}
+
+func (c *ConfigService) GetConfigEntry(ctx context.Context, req *configv1.GetConfigEntryRequest) (*configv1.ConfigEntryResponse, error) {
+	ref, err := entryRef(req)
+	if err != nil {
+		c.logger.WithContext(ctx).Error("invalid config entry", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	entry, err := c.entryHandler.GetEntry(ctx, ref)
+	if err != nil {
+		c.logger.WithContext(ctx).Error("failed to get config entry", "key", ref.GetKey(), "scope", ref.GetScope(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &configv1.ConfigEntryResponse{Entry: entry}, nil
+}
+
+func (c *ConfigService) SetConfigEntry(ctx context.Context, req *configv1.SetConfigEntryRequest) (*configv1.ConfigEntryResponse, error) {
+	ref, err := entryRef(req)
+	if err != nil {
+		c.logger.WithContext(ctx).Error("invalid config entry", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	if req.GetUserId() == "" {
+		err := infra_error.Validation(infra_error.ValidationRequiredFields, "user_id")
+		c.logger.WithContext(ctx).Error("failed to set config entry", "key", ref.GetKey(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	entry, err := c.entryHandler.SetEntry(ctx, ref, req.GetValue(), req.GetUserId())
+	if err != nil {
+		c.logger.WithContext(ctx).Error("failed to set config entry", "key", ref.GetKey(), "scope", ref.GetScope(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	c.logger.WithContext(ctx).Info("config entry updated", "key", entry.GetKey(), "scope", entry.GetScope(), "tenant_id", entry.GetTenantId(), "module", entry.GetModule(), "version", entry.GetVersion(), "updated_by", req.GetUserId())
+	return &configv1.ConfigEntryResponse{Entry: entry}, nil
+}
+
+func (c *ConfigService) ListConfigEntries(ctx context.Context, req *configv1.ListConfigEntriesRequest) (*configv1.ListConfigEntriesResponse, error) {
+	module := req.GetModule()
+	if module != "" {
+		var err error
+		if module, err = normalizeModule(module); err != nil {
+			c.logger.WithContext(ctx).Error("invalid module", "module", req.GetModule(), "error", err)
+			return nil, infra_error.ToGRPCError(err)
+		}
+	}
+	entries, err := c.entryHandler.ListEntries(ctx, req.GetScope(), req.GetTenantId(), module, req.GetKeyPrefix())
+	if err != nil {
+		c.logger.WithContext(ctx).Error("failed to list config entries", "scope", req.GetScope(), "tenant_id", req.GetTenantId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &configv1.ListConfigEntriesResponse{Entries: entries}, nil
+}
+
+func (c *ConfigService) DeleteConfigEntry(ctx context.Context, req *configv1.DeleteConfigEntryRequest) (*configv1.DeleteConfigEntryResponse, error) {
+	ref, err := entryRef(req)
+	if err != nil {
+		c.logger.WithContext(ctx).Error("invalid config entry", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	if req.GetUserId() == "" {
+		err := infra_error.Validation(infra_error.ValidationRequiredFields, "user_id")
+		c.logger.WithContext(ctx).Error("failed to delete config entry", "key", ref.GetKey(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+
+	if err := c.entryHandler.DeleteEntry(ctx, ref, req.GetUserId()); err != nil {
+		c.logger.WithContext(ctx).Error("failed to delete config entry", "key", ref.GetKey(), "scope", ref.GetScope(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	c.logger.WithContext(ctx).Info("config entry deleted", "key", ref.GetKey(), "scope", ref.GetScope(), "tenant_id", ref.GetTenantId(), "module", ref.GetModule(), "deleted_by", req.GetUserId())
+	return &configv1.DeleteConfigEntryResponse{}, nil
+}
+
+func (c *ConfigService) GetConfigHistory(ctx context.Context, req *configv1.GetConfigHistoryRequest) (*configv1.GetConfigHistoryResponse, error) {
+	ref, err := entryRef(req)
+	if err != nil {
+		c.logger.WithContext(ctx).Error("invalid config entry", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	changes, err := c.entryHandler.GetHistory(ctx, ref, int(req.GetLimit()))
+	if err != nil {
+		c.logger.WithContext(ctx).Error("failed to get config history", "key", ref.GetKey(), "scope", ref.GetScope(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &configv1.GetConfigHistoryResponse{Changes: changes}, nil
+}
+
+// WatchConfig streams the watched entries, then their changes until the client cancels the call
+func (c *ConfigService) WatchConfig(req *configv1.WatchConfigRequest, stream configv1.ConfigService_WatchConfigServer) error {
+	ctx := stream.Context()
+	if req.GetModule() != "" {
+		module, err := normalizeModule(req.GetModule())
+		if err != nil {
+			c.logger.WithContext(ctx).Error("invalid module", "module", req.GetModule(), "error", err)
+			return infra_error.ToGRPCError(err)
+		}
+		req.Module = module
+	}
+
+	// Subscribe before the snapshot so no change is missed, changes already in the snapshot are sent again
+	events, cancel := c.entryHandler.Watch(req)
+	defer cancel()
+
+	entries, err := c.entryHandler.ListEntries(ctx, configv1.ConfigScope_CONFIG_SCOPE_UNSPECIFIED, "", "", "")
+	if err != nil {
+		c.logger.WithContext(ctx).Error("failed to list watched config entries", "error", err)
+		return infra_error.ToGRPCError(err)
+	}
+	for _, entry := range entries {
+		if !handler.WatchMatches(req, entry) {
+			continue
+		}
+		if err := stream.Send(&configv1.ConfigEvent{Type: configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, Entry: entry}); err != nil {
+			return err
+		}
+	}
+	if err := stream.Send(&configv1.ConfigEvent{Synced: true}); err != nil {
+		return err
+	}
+	c.logger.WithContext(ctx).Debug("config watch started", "module", req.GetModule(), "tenant_id", req.GetTenantId(), "key_prefixes", req.GetKeyPrefixes())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				// Too slow to keep up, the client resyncs on reconnect
+				return infra_error.ToGRPCError(infra_error.Internal(infra_error.InternalServiceUnavailable, errors.New("config watcher fell behind")))
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
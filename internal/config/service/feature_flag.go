@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/config/handler"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+)
+
+// FeatureFlagService manages the feature flags and evaluates them for the tenants and users of the other services
+type FeatureFlagService struct {
+	logger      logger.Logger
+	flagHandler *handler.FeatureFlagHandler
+	configv1.UnimplementedFeatureFlagServiceServer
+}
+
+func NewFeatureFlagService(logger logger.Logger) (*FeatureFlagService, error) {
+	flagHandler, err := handler.NewFeatureFlagHandler(logger)
+	if err != nil {
+		logger.Error("failed to create feature flag handler", "error", err)
+		return nil, err
+	}
+	return &FeatureFlagService{
+		logger:      logger,
+		flagHandler: flagHandler,
+	}, nil
+}
+
+func (f *FeatureFlagService) CreateFeatureFlag(ctx context.Context, req *configv1.CreateFeatureFlagRequest) (*configv1.FeatureFlagResponse, error) {
+	flag, err := f.flagHandler.CreateFlag(ctx, req.GetFlag(), req.GetUserId())
+	if err != nil {
+		f.logger.WithContext(ctx).Error("failed to create feature flag", "key", req.GetFlag().GetKey(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	f.logger.WithContext(ctx).Info("feature flag created", "key", flag.GetKey(), "enabled", flag.GetEnabled(), "created_by", req.GetUserId())
+	return &configv1.FeatureFlagResponse{Flag: flag}, nil
+}
+
+func (f *FeatureFlagService) UpdateFeatureFlag(ctx context.Context, req *configv1.UpdateFeatureFlagRequest) (*configv1.FeatureFlagResponse, error) {
+	flag, err := f.flagHandler.UpdateFlag(ctx, req.GetFlag(), req.GetUserId())
+	if err != nil {
+		f.logger.WithContext(ctx).Error("failed to update feature flag", "key", req.GetFlag().GetKey(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	f.logger.WithContext(ctx).Info("feature flag updated", "key", flag.GetKey(), "enabled", flag.GetEnabled(), "version", flag.GetVersion(), "updated_by", req.GetUserId())
+	return &configv1.FeatureFlagResponse{Flag: flag}, nil
+}
+
+func (f *FeatureFlagService) GetFeatureFlag(ctx context.Context, req *configv1.GetFeatureFlagRequest) (*configv1.FeatureFlagResponse, error) {
+	flag, err := f.flagHandler.GetFlag(ctx, req.GetKey())
+	if err != nil {
+		f.logger.WithContext(ctx).Error("failed to get feature flag", "key", req.GetKey(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &configv1.FeatureFlagResponse{Flag: flag}, nil
+}
+
+func (f *FeatureFlagService) ListFeatureFlags(ctx context.Context, _ *configv1.ListFeatureFlagsRequest) (*configv1.ListFeatureFlagsResponse, error) {
+	flags, err := f.flagHandler.ListFlags(ctx)
+	if err != nil {
+		f.logger.WithContext(ctx).Error("failed to list feature flags", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &configv1.ListFeatureFlagsResponse{Flags: flags}, nil
+}
+
+func (f *FeatureFlagService) DeleteFeatureFlag(ctx context.Context, req *configv1.DeleteFeatureFlagRequest) (*configv1.DeleteFeatureFlagResponse, error) {
+	if err := f.flagHandler.DeleteFlag(ctx, req.GetKey(), req.GetUserId()); err != nil {
+		f.logger.WithContext(ctx).Error("failed to delete feature flag", "key", req.GetKey(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	f.logger.WithContext(ctx).Info("feature flag deleted", "key", req.GetKey(), "deleted_by", req.GetUserId())
+	return &configv1.DeleteFeatureFlagResponse{}, nil
+}
+
+func (f *FeatureFlagService) SetFeatureFlagOverride(ctx context.Context, req *configv1.SetFeatureFlagOverrideRequest) (*configv1.FeatureFlagResponse, error) {
+	flag, err := f.flagHandler.SetOverride(ctx, req.GetKey(), req.GetTenantId(), req.GetAccountId(), req.GetEnabled(), req.GetUserId())
+	if err != nil {
+		f.logger.WithContext(ctx).Error("failed to set feature flag override", "key", req.GetKey(), "tenant_id", req.GetTenantId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	f.logger.WithContext(ctx).Info("feature flag override set", "key", req.GetKey(), "tenant_id", req.GetTenantId(), "account_id", req.GetAccountId(), "enabled", req.GetEnabled(), "updated_by", req.GetUserId())
+	return &configv1.FeatureFlagResponse{Flag: flag}, nil
+}
+
+func (f *FeatureFlagService) DeleteFeatureFlagOverride(ctx context.Context, req *configv1.DeleteFeatureFlagOverrideRequest) (*configv1.FeatureFlagResponse, error) {
+	flag, err := f.flagHandler.DeleteOverride(ctx, req.GetKey(), req.GetTenantId(), req.GetAccountId(), req.GetUserId())
+	if err != nil {
+		f.logger.WithContext(ctx).Error("failed to delete feature flag override", "key", req.GetKey(), "tenant_id", req.GetTenantId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	f.logger.WithContext(ctx).Info("feature flag override deleted", "key", req.GetKey(), "tenant_id", req.GetTenantId(), "account_id", req.GetAccountId(), "updated_by", req.GetUserId())
+	return &configv1.FeatureFlagResponse{Flag: flag}, nil
+}
+
+func (f *FeatureFlagService) EvaluateFeatureFlags(ctx context.Context, req *configv1.EvaluateFeatureFlagsRequest) (*configv1.EvaluateFeatureFlagsResponse, error) {
+	if req.GetTenantId() == "" {
+		err := infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id")
+		f.logger.WithContext(ctx).Error("failed to evaluate feature flags", "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	flags, err := f.flagHandler.Evaluate(ctx, req.GetTenantId(), req.GetUserId(), req.GetKeys())
+	if err != nil {
+		f.logger.WithContext(ctx).Error("failed to evaluate feature flags", "tenant_id", req.GetTenantId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &configv1.EvaluateFeatureFlagsResponse{Flags: flags}, nil
+}
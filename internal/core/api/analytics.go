@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/core/currency"
+	"erp.localhost/internal/core/handler"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+)
+
+// defaultReportDays is the length of the range of a report that sets none
+const defaultReportDays = 30
+
+// AnalyticsAPI reports on the sales, stock and users of the tenants. The sales reports sum the amounts the orders
+// recorded in the current base currency of the tenant, see currency.Service
+type AnalyticsAPI struct {
+	logger           logger.Logger
+	analyticsHandler *handler.AnalyticsHandler
+	currencies       *currency.Service
+	rbac             client.RBACClient
+}
+
+func NewAnalyticsAPI(rbac client.RBACClient, currencies *currency.Service, logger logger.Logger) (*AnalyticsAPI, error) {
+	if rbac == nil || currencies == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "rbac", "currencies")
+	}
+	analyticsHandler, err := handler.NewAnalyticsHandler(logger)
+	if err != nil {
+		logger.Error("failed to create analytics handler", "error", err)
+		return nil, err
+	}
+	return &AnalyticsAPI{
+		logger:           logger,
+		analyticsHandler: analyticsHandler,
+		currencies:       currencies,
+		rbac:             rbac,
+	}, nil
+}
+
+// SalesByPeriod sums the sales orders of the tenant confirmed, shipped or delivered by the period they were created
+// in, it returns the periods and the currency of their amounts
+func (a *AnalyticsAPI) SalesByPeriod(ctx context.Context, tenantID, userID string, reportRange *corev1.ReportRange, period corev1.ReportPeriod, pagination *infrav1.PaginationRequest) ([]*corev1.SalesPeriod, string, *infrav1.PaginationResponse, error) {
+	from, to, baseCurrency, err := a.prepareSalesReport(ctx, tenantID, userID, reportRange)
+	if err != nil {
+		a.logger.Error("failed to report sales by period", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", nil, err
+	}
+	periods, page, err := a.analyticsHandler.SalesByPeriod(ctx, tenantID, baseCurrency, from, to, period, pagination)
+	if err != nil {
+		a.logger.Error("failed to report sales by period", "tenant_id", tenantID, "error", err)
+		return nil, "", nil, err
+	}
+	return periods, baseCurrency, page, nil
+}
+
+// TopProducts sums the sold lines of the products of the tenant, it returns the products and the currency of their
+// revenue
+func (a *AnalyticsAPI) TopProducts(ctx context.Context, tenantID, userID string, reportRange *corev1.ReportRange, sort corev1.TopProductsSort, pagination *infrav1.PaginationRequest) ([]*corev1.ProductSales, string, *infrav1.PaginationResponse, error) {
+	from, to, baseCurrency, err := a.prepareSalesReport(ctx, tenantID, userID, reportRange)
+	if err != nil {
+		a.logger.Error("failed to report top products", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, "", nil, err
+	}
+	products, page, err := a.analyticsHandler.TopProducts(ctx, tenantID, baseCurrency, from, to, sort, pagination)
+	if err != nil {
+		a.logger.Error("failed to report top products", "tenant_id", tenantID, "error", err)
+		return nil, "", nil, err
+	}
+	return products, baseCurrency, page, nil
+}
+
+// InventoryValuation values the current stock of the products of the tenant, in warehouseID unless it is empty, and
+// returns the total quantity and value of the stock
+func (a *AnalyticsAPI) InventoryValuation(ctx context.Context, tenantID, userID, warehouseID string, pagination *infrav1.PaginationRequest) ([]*corev1.ProductValuation, int64, float64, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		a.logger.Error("failed to report inventory valuation", "error", err)
+		return nil, 0, 0, nil, err
+	}
+	if err := a.hasPermission(ctx, tenantID, userID, permissions.AnalyticsRead); err != nil {
+		a.logger.Error("failed to report inventory valuation", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, 0, 0, nil, err
+	}
+	products, quantity, value, page, err := a.analyticsHandler.InventoryValuation(ctx, tenantID, warehouseID, pagination)
+	if err != nil {
+		a.logger.Error("failed to report inventory valuation", "tenant_id", tenantID, "warehouse_id", warehouseID, "error", err)
+		return nil, 0, 0, nil, err
+	}
+	return products, quantity, value, page, nil
+}
+
+// UserActivity counts the orders, invoices and stock movements each user of the tenant created
+func (a *AnalyticsAPI) UserActivity(ctx context.Context, tenantID, userID string, reportRange *corev1.ReportRange, pagination *infrav1.PaginationRequest) ([]*corev1.UserActivity, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		a.logger.Error("failed to report user activity", "error", err)
+		return nil, nil, err
+	}
+	from, to, err := reportBounds(reportRange, time.Now())
+	if err != nil {
+		a.logger.Error("failed to report user activity", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	if err := a.hasPermission(ctx, tenantID, userID, permissions.AnalyticsRead); err != nil {
+		a.logger.Error("failed to report user activity", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+	users, page, err := a.analyticsHandler.UserActivity(ctx, tenantID, from, to, pagination)
+	if err != nil {
+		a.logger.Error("failed to report user activity", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	return users, page, nil
+}
+
+// prepareSalesReport checks a sales report request and returns its bounds and the base currency of the tenant
+func (a *AnalyticsAPI) prepareSalesReport(ctx context.Context, tenantID, userID string, reportRange *corev1.ReportRange) (time.Time, time.Time, string, error) {
+	if tenantID == "" || userID == "" {
+		return time.Time{}, time.Time{}, "", infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+	}
+	from, to, err := reportBounds(reportRange, time.Now())
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+	if err := a.hasPermission(ctx, tenantID, userID, permissions.AnalyticsRead); err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+	baseCurrency, err := a.currencies.BaseCurrency(ctx, tenantID)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+	return from, to, baseCurrency, nil
+}
+
+// reportBounds returns the bounds of reportRange, it ends at now and starts defaultReportDays before its end unless
+// they are set
+func reportBounds(reportRange *corev1.ReportRange, now time.Time) (time.Time, time.Time, error) {
+	to := now.UTC()
+	if reportRange.GetTo() != nil {
+		to = reportRange.GetTo().AsTime()
+	}
+	from := to.AddDate(0, 0, -defaultReportDays)
+	if reportRange.GetFrom() != nil {
+		from = reportRange.GetFrom().AsTime()
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, infra_error.Validation(infra_error.ValidationInvalidValue, "from", "to").WithError(errors.New("a report range starts before it ends"))
+	}
+	return from, to, nil
+}
+
+func (a *AnalyticsAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return a.rbac.RequirePermissions(ctx, tenantID, userID, tenantID, permission)
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/core/currency"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestReportBounds(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	from, to, err := reportBounds(nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -30), from)
+	assert.Equal(t, now, to)
+
+	from, to, err = reportBounds(&corev1.ReportRange{From: timestamppb.New(start)}, now)
+	require.NoError(t, err)
+	assert.Equal(t, start, from)
+	assert.Equal(t, now, to)
+
+	from, _, err = reportBounds(&corev1.ReportRange{To: timestamppb.New(start)}, now)
+	require.NoError(t, err)
+	assert.Equal(t, start.AddDate(0, 0, -30), from)
+
+	_, _, err = reportBounds(&corev1.ReportRange{From: timestamppb.New(now), To: timestamppb.New(start)}, now)
+	assert.Error(t, err)
+}
+
+func TestAnalyticsAPI_ReportPipelines(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.CoreDB))
+	t.Cleanup(documents.Reset)
+
+	ctx := context.Background()
+	log := logger.NewBaseLogger(shared.ModuleCore)
+	currencies, err := currency.NewService(&currency.Config{DefaultBaseCurrency: "EUR"}, log)
+	require.NoError(t, err)
+	analytics, err := NewAnalyticsAPI(allowAllRBAC{}, currencies, log)
+	require.NoError(t, err)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	reportRange := &corev1.ReportRange{From: timestamppb.New(from), To: timestamppb.New(to)}
+	pagination := &infrav1.PaginationRequest{Page: 3, PageSize: 25}
+	errAggregation := errors.New("aggregation failed")
+
+	testCases := []struct {
+		name       string
+		collection model_mongo.Collection
+		// rangeStage is the index of the stage matching the report range, 0 for the reports without one
+		rangeStage int
+		report     func() (any, error)
+	}{
+		{
+			name:       "sales by period",
+			collection: model_mongo.OrdersCollection,
+			rangeStage: 1,
+			report: func() (any, error) {
+				periods, _, _, err := analytics.SalesByPeriod(ctx, "tenant-1", "user-1", reportRange, corev1.ReportPeriod_REPORT_PERIOD_MONTH, pagination)
+				return periods, err
+			},
+		},
+		{
+			name:       "top products",
+			collection: model_mongo.OrdersCollection,
+			rangeStage: 1,
+			report: func() (any, error) {
+				products, _, _, err := analytics.TopProducts(ctx, "tenant-1", "user-1", reportRange, corev1.TopProductsSort_TOP_PRODUCTS_SORT_REVENUE, pagination)
+				return products, err
+			},
+		},
+		{
+			name:       "user activity",
+			collection: model_mongo.OrdersCollection,
+			rangeStage: 1,
+			report: func() (any, error) {
+				users, _, err := analytics.UserActivity(ctx, "tenant-1", "user-1", reportRange, pagination)
+				return users, err
+			},
+		},
+		{
+			name:       "inventory valuation",
+			collection: model_mongo.InventoryCollection,
+			report: func() (any, error) {
+				products, _, _, _, err := analytics.InventoryValuation(ctx, "tenant-1", "user-1", "warehouse-1", pagination)
+				return products, err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pipelines [][]bson.M
+			documents.OnAggregate(func(collectionName string, pipeline any) error {
+				assert.Equal(t, string(tc.collection), collectionName)
+				pipelines = append(pipelines, pipeline.([]bson.M))
+				return errAggregation
+			})
+			t.Cleanup(func() { documents.OnAggregate(nil) })
+
+			// A failed aggregation fails the report
+			rows, err := tc.report()
+			require.ErrorIs(t, err, errAggregation)
+			assert.Nil(t, rows)
+			require.Len(t, pipelines, 1)
+			pipeline := pipelines[0]
+
+			// The report reads the documents of the tenant only
+			assert.Equal(t, bson.M{"$match": bson.M{"tenant_id": "tenant-1"}}, pipeline[0])
+			if tc.rangeStage > 0 {
+				match := pipeline[tc.rangeStage]["$match"].(bson.M)
+				assert.Equal(t, bson.M{"$gte": from, "$lt": to}, match["created_at"])
+			}
+
+			// The rows are paged after they are sorted, the total counts every row
+			facet := pipeline[len(pipeline)-1]["$facet"].(bson.M)
+			rowStages := facet["rows"].(bson.A)
+			require.Len(t, rowStages, 3)
+			assert.Contains(t, rowStages[0], "$sort")
+			assert.Equal(t, bson.M{"$skip": int64(50)}, rowStages[1])
+			assert.Equal(t, bson.M{"$limit": int32(25)}, rowStages[2])
+			assert.Equal(t, bson.A{bson.M{"$count": "count"}}, facet["total"])
+		})
+	}
+}
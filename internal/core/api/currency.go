@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/core/currency"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+)
+
+// CurrencyAPI manages the base currency and the exchange rates of the tenants, see package currency
+type CurrencyAPI struct {
+	logger     logger.Logger
+	currencies *currency.Service
+	rbac       client.RBACClient
+}
+
+func NewCurrencyAPI(rbac client.RBACClient, currencies *currency.Service, logger logger.Logger) (*CurrencyAPI, error) {
+	if rbac == nil || currencies == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "rbac", "currencies")
+	}
+	return &CurrencyAPI{
+		logger:     logger,
+		currencies: currencies,
+		rbac:       rbac,
+	}, nil
+}
+
+func (c *CurrencyAPI) GetCurrencySettings(ctx context.Context, tenantID, userID string) (*corev1.CurrencySettings, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		c.logger.Error("failed to get currency settings", "error", err)
+		return nil, err
+	}
+	if err := c.hasPermission(ctx, tenantID, userID, permissions.CurrencyRead); err != nil {
+		c.logger.Error("failed to get currency settings", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	settings, err := c.currencies.Settings(ctx, tenantID)
+	if err != nil {
+		c.logger.Error("failed to get currency settings", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateCurrencySettings sets the base currency of the tenant, the documents recorded before keep the base amounts
+// they were recorded with
+func (c *CurrencyAPI) UpdateCurrencySettings(ctx context.Context, tenantID, userID, baseCurrency string) (*corev1.CurrencySettings, error) {
+	if tenantID == "" || userID == "" || baseCurrency == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, base_currency"))
+		c.logger.Error("failed to update currency settings", "error", err)
+		return nil, err
+	}
+	if err := c.hasPermission(ctx, tenantID, userID, permissions.CurrencyUpdate); err != nil {
+		c.logger.Error("failed to update currency settings", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	settings, err := c.currencies.SetBaseCurrency(ctx, tenantID, userID, baseCurrency)
+	if err != nil {
+		c.logger.Error("failed to update currency settings", "tenant_id", tenantID, "base_currency", baseCurrency, "error", err)
+		return nil, err
+	}
+	c.logger.Info("base currency updated", "tenant_id", tenantID, "user_id", userID, "base_currency", settings.BaseCurrency)
+	return settings, nil
+}
+
+// CreateExchangeRate records a rate entered by hand, effective now unless it has an effective date
+func (c *CurrencyAPI) CreateExchangeRate(ctx context.Context, tenantID, userID string, rate *corev1.ExchangeRate) (*corev1.ExchangeRate, error) {
+	if tenantID == "" || userID == "" || rate == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, rate"))
+		c.logger.Error("failed to create exchange rate", "error", err)
+		return nil, err
+	}
+	if err := c.hasPermission(ctx, tenantID, userID, permissions.CurrencyUpdate); err != nil {
+		c.logger.Error("failed to create exchange rate", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	rate.TenantId = tenantID
+	rate.CreatedBy = userID
+	rate.Source = currency.SourceManual
+	created, err := c.currencies.AddRate(ctx, rate)
+	if err != nil {
+		c.logger.Error("failed to create exchange rate", "tenant_id", tenantID, "base", rate.BaseCurrency, "quote", rate.QuoteCurrency, "error", err)
+		return nil, err
+	}
+	c.logger.Info("exchange rate created", "tenant_id", tenantID, "user_id", userID, "rate_id", created.Id, "base", created.BaseCurrency, "quote", created.QuoteCurrency)
+	return created, nil
+}
+
+func (c *CurrencyAPI) SearchExchangeRates(ctx context.Context, tenantID, userID string, search *corev1.ExchangeRateSearch, pagination *infrav1.PaginationRequest) ([]*corev1.ExchangeRate, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		c.logger.Error("failed to search exchange rates", "error", err)
+		return nil, nil, err
+	}
+	if err := c.hasPermission(ctx, tenantID, userID, permissions.CurrencyRead); err != nil {
+		c.logger.Error("failed to search exchange rates", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+	rates, page, err := c.currencies.SearchRates(ctx, tenantID, search, pagination)
+	if err != nil {
+		c.logger.Error("failed to search exchange rates", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	return rates, page, nil
+}
+
+// ConvertAmount converts amount between two currencies with the rates of the tenant effective at at, it returns the
+// converted amount and the rate
+func (c *CurrencyAPI) ConvertAmount(ctx context.Context, tenantID, userID string, amount float64, from, to string, at time.Time) (float64, float64, error) {
+	if tenantID == "" || userID == "" || from == "" || to == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, from_currency, to_currency"))
+		c.logger.Error("failed to convert amount", "error", err)
+		return 0, 0, err
+	}
+	if err := c.hasPermission(ctx, tenantID, userID, permissions.CurrencyRead); err != nil {
+		c.logger.Error("failed to convert amount", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return 0, 0, err
+	}
+	converted, rate, err := c.currencies.Convert(ctx, tenantID, amount, from, to, at)
+	if err != nil {
+		c.logger.Error("failed to convert amount", "tenant_id", tenantID, "from", from, "to", to, "error", err)
+		return 0, 0, err
+	}
+	return converted, rate, nil
+}
+
+// ImportExchangeRates records the current rates of the base currency of the tenant from the configured provider
+func (c *CurrencyAPI) ImportExchangeRates(ctx context.Context, tenantID, userID string) ([]*corev1.ExchangeRate, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		c.logger.Error("failed to import exchange rates", "error", err)
+		return nil, err
+	}
+	if err := c.hasPermission(ctx, tenantID, userID, permissions.CurrencyUpdate); err != nil {
+		c.logger.Error("failed to import exchange rates", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	rates, err := c.currencies.Import(ctx, tenantID, userID, time.Now().UTC())
+	if err != nil {
+		c.logger.Error("failed to import exchange rates", "tenant_id", tenantID, "imported", len(rates), "error", err)
+		return nil, err
+	}
+	c.logger.Info("exchange rates imported", "tenant_id", tenantID, "user_id", userID, "rates", len(rates))
+	return rates, nil
+}
+
+// convertToBase returns the base currency of the tenant, the rate of code to it at at and amount converted at that
+// rate. The base currency is empty when the tenant has no rate for code, the amount is then left unconverted
+func convertToBase(ctx context.Context, currencies *currency.Service, tenantID, code string, amount float64, at time.Time) (string, float64, float64, error) {
+	base, rate, err := currencies.ToBase(ctx, tenantID, code, at)
+	if infra_error.IsCategory(err, infra_error.CategoryNotFound) {
+		return "", 0, 0, nil
+	}
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return base, rate, currency.Convert(amount, rate), nil
+}
+
+func (c *CurrencyAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return c.rbac.RequirePermissions(ctx, tenantID, userID, tenantID, permission)
+}
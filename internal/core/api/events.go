@@ -0,0 +1,23 @@
+package api
+
+import (
+	"erp.localhost/internal/infra/event/outbox"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+)
+
+/* Domain events of the core entities, written to the outbox with their change */
+
+func inventoryEvent(eventType, actorID string, item *corev1.StockItem) *eventv1.DomainEvent {
+	event := outbox.NewEvent(eventType, item.GetTenantId(), actorID)
+	event.Payload = &eventv1.DomainEvent_Inventory{Inventory: &eventv1.InventoryEvent{
+		StockItemId:       item.GetId(),
+		ProductId:         item.GetProductId(),
+		WarehouseId:       item.GetWarehouseId(),
+		Quantity:          item.GetQuantity(),
+		Reserved:          item.GetReserved(),
+		Available:         item.GetAvailable(),
+		LowStockThreshold: item.GetLowStockThreshold(),
+	}}
+	return event
+}
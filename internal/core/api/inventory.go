@@ -0,0 +1,471 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"erp.localhost/internal/core/handler"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/event/outbox"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// stockChangeAttempts caps the attempts of a stock change racing other changes of the same items
+const stockChangeAttempts = 3
+
+// InventoryAPI manages the warehouses of the tenants and the stock of their products. Stock changes are applied to
+// the items at the version they were read at in one transaction with the record of the change and their low stock
+// events, a change racing another one is retried
+type InventoryAPI struct {
+	logger           logger.Logger
+	warehouseHandler *handler.WarehouseHandler
+	inventoryHandler *handler.InventoryHandler
+	rbac             client.RBACClient
+	outbox           *outbox.Outbox
+}
+
+func NewInventoryAPI(rbac client.RBACClient, logger logger.Logger) (*InventoryAPI, error) {
+	if rbac == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "rbac")
+	}
+	warehouseHandler, err := handler.NewWarehouseHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new warehouse handler", "error", err)
+		return nil, err
+	}
+	inventoryHandler, err := handler.NewInventoryHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new inventory handler", "error", err)
+		return nil, err
+	}
+	return &InventoryAPI{
+		logger:           logger,
+		warehouseHandler: warehouseHandler,
+		inventoryHandler: inventoryHandler,
+		rbac:             rbac,
+	}, nil
+}
+
+// SetOutbox sets the outbox the stock changes write their events to, without one the changes are not transactional
+// and publish no events
+func (i *InventoryAPI) SetOutbox(outbox *outbox.Outbox) {
+	i.outbox = outbox
+}
+
+/* Stock items */
+
+func (i *InventoryAPI) GetStockItem(ctx context.Context, tenantID, userID, itemID string) (*corev1.StockItem, error) {
+	if tenantID == "" || userID == "" || itemID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, stock_item_id"))
+		i.logger.Error("failed to get stock item", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InventoryRead); err != nil {
+		i.logger.Error("failed to get stock item", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	item, err := i.inventoryHandler.GetStockItemByID(ctx, tenantID, itemID)
+	if err != nil {
+		i.logger.Error("failed to get stock item", "tenant_id", tenantID, "stock_item_id", itemID, "error", err)
+		return nil, err
+	}
+	return item, nil
+}
+
+// ListStockItems returns the stock items of the tenant, of a warehouse or a product when their id is not empty. With
+// lowStockOnly only the items at or below their low stock threshold are returned
+func (i *InventoryAPI) ListStockItems(ctx context.Context, tenantID, userID, warehouseID, productID string, lowStockOnly bool) ([]*corev1.StockItem, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		i.logger.Error("failed to list stock items", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InventoryRead); err != nil {
+		i.logger.Error("failed to list stock items", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	items, err := i.inventoryHandler.GetStockItems(ctx, tenantID, warehouseID, productID)
+	if err != nil {
+		i.logger.Error("failed to list stock items", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if !lowStockOnly {
+		return items, nil
+	}
+	lowStock := make([]*corev1.StockItem, 0, len(items))
+	for _, item := range items {
+		if isLowStock(item) {
+			lowStock = append(lowStock, item)
+		}
+	}
+	return lowStock, nil
+}
+
+// UpdateStockItem changes the settings of a stock item, the nil ones are kept. Its stock is only changed by movements
+// and reservations
+func (i *InventoryAPI) UpdateStockItem(ctx context.Context, tenantID, userID, itemID string, lowStockThreshold *int32, location *corev1.InventoryLocation) (*corev1.StockItem, error) {
+	if tenantID == "" || userID == "" || itemID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, stock_item_id"))
+		i.logger.Error("failed to update stock item", "error", err)
+		return nil, err
+	}
+	if lowStockThreshold != nil && *lowStockThreshold < 0 {
+		err := infra_error.Validation(infra_error.ValidationOutOfRange, "low_stock_threshold")
+		i.logger.Error("failed to update stock item", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InventoryUpdate); err != nil {
+		i.logger.Error("failed to update stock item", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	item, err := i.inventoryHandler.GetStockItemByID(ctx, tenantID, itemID)
+	if err != nil {
+		i.logger.Error("failed to update stock item", "tenant_id", tenantID, "stock_item_id", itemID, "error", err)
+		return nil, err
+	}
+	if lowStockThreshold != nil {
+		item.LowStockThreshold = *lowStockThreshold
+	}
+	if location != nil {
+		item.Location = location
+	}
+	if err := i.inventoryHandler.UpdateStockItem(ctx, item); err != nil {
+		i.logger.Error("failed to update stock item", "tenant_id", tenantID, "stock_item_id", itemID, "error", err)
+		return nil, err
+	}
+	i.logger.Info("stock item updated", "tenant_id", tenantID, "user_id", userID, "stock_item_id", itemID)
+	return item, nil
+}
+
+/* Movements */
+
+// RecordStockMovement applies a movement to the stock of its product and records it, it returns the movement and the
+// changed items. The first movement of a product into a warehouse creates its stock item, OUT and TRANSFER movements
+// fail with an insufficient stock error when they exceed the available stock and adjustments cannot lower the
+// quantity below the reserved stock
+func (i *InventoryAPI) RecordStockMovement(ctx context.Context, tenantID, userID string, movement *corev1.StockMovement) (*corev1.StockMovement, []*corev1.StockItem, error) {
+	if tenantID == "" || userID == "" || movement == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, movement"))
+		i.logger.Error("failed to record stock movement", "error", err)
+		return nil, nil, err
+	}
+	movement.Id = ""
+	movement.TenantId = tenantID
+	movement.CreatedBy = userID
+	if err := validator_core.ValidateStockMovement(movement); err != nil {
+		i.logger.Error("failed to record stock movement", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InventoryUpdate); err != nil {
+		i.logger.Error("failed to record stock movement", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+	for _, warehouseID := range []string{movement.WarehouseId, movement.ToWarehouseId} {
+		if warehouseID == "" {
+			continue
+		}
+		if _, err := i.warehouseHandler.GetWarehouseByID(ctx, tenantID, warehouseID); err != nil {
+			i.logger.Error("failed to record stock movement", "tenant_id", tenantID, "warehouse_id", warehouseID, "error", err)
+			return nil, nil, err
+		}
+	}
+
+	items, err := i.changeStock(ctx, tenantID, userID, movementChanges(movement), nil, func(ctx context.Context) error {
+		id, err := i.inventoryHandler.CreateMovement(ctx, movement)
+		movement.Id = id
+		return err
+	})
+	if err != nil {
+		i.logger.Error("failed to record stock movement", "tenant_id", tenantID, "product_id", movement.ProductId, "type", movement.Type, "error", err)
+		return nil, nil, err
+	}
+	i.logger.Info("stock movement recorded", "tenant_id", tenantID, "user_id", userID, "movement_id", movement.Id, "type", movement.Type, "product_id", movement.ProductId, "quantity", movement.Quantity)
+	return movement, items, nil
+}
+
+// ListStockMovements returns the movements of the tenant newest first, of a product or a warehouse when their id is
+// not empty
+func (i *InventoryAPI) ListStockMovements(ctx context.Context, tenantID, userID, productID, warehouseID string) ([]*corev1.StockMovement, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		i.logger.Error("failed to list stock movements", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InventoryRead); err != nil {
+		i.logger.Error("failed to list stock movements", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	movements, err := i.inventoryHandler.GetMovements(ctx, tenantID, productID, warehouseID)
+	if err != nil {
+		i.logger.Error("failed to list stock movements", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	return movements, nil
+}
+
+/* Reservations */
+
+// ReserveStock holds the stock of every line for reference, or none of it when a line exceeds the available stock
+func (i *InventoryAPI) ReserveStock(ctx context.Context, tenantID, userID, reference string, lines []*corev1.StockReservationLine) (*corev1.StockReservation, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		i.logger.Error("failed to reserve stock", "error", err)
+		return nil, err
+	}
+	reservation := &corev1.StockReservation{
+		TenantId:  tenantID,
+		Reference: reference,
+		Lines:     lines,
+		Status:    corev1.StockReservationStatus_STOCK_RESERVATION_STATUS_ACTIVE,
+		CreatedBy: userID,
+	}
+	if err := validator_core.ValidateStockReservation(reservation, true); err != nil {
+		i.logger.Error("failed to reserve stock", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InventoryReserve); err != nil {
+		i.logger.Error("failed to reserve stock", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	_, err := i.changeStock(ctx, tenantID, userID, reservationChanges(lines, 1), nil, func(ctx context.Context) error {
+		id, err := i.inventoryHandler.CreateReservation(ctx, reservation)
+		reservation.Id = id
+		return err
+	})
+	if err != nil {
+		i.logger.Error("failed to reserve stock", "tenant_id", tenantID, "reference", reference, "error", err)
+		return nil, err
+	}
+	i.logger.Info("stock reserved", "tenant_id", tenantID, "user_id", userID, "reservation_id", reservation.Id, "reference", reference)
+	return reservation, nil
+}
+
+// ReleaseStock returns the stock held by an active reservation to the available stock
+func (i *InventoryAPI) ReleaseStock(ctx context.Context, tenantID, userID, reservationID string) (*corev1.StockReservation, error) {
+	if tenantID == "" || userID == "" || reservationID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, reservation_id"))
+		i.logger.Error("failed to release stock", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InventoryReserve); err != nil {
+		i.logger.Error("failed to release stock", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	reservation, err := i.inventoryHandler.GetReservationByID(ctx, tenantID, reservationID)
+	if err != nil {
+		i.logger.Error("failed to release stock", "tenant_id", tenantID, "reservation_id", reservationID, "error", err)
+		return nil, err
+	}
+
+	// The reservation is marked released after its stock, so a failed stock change leaves it active. The release is
+	// conditioned on the version read by the guard, a concurrent release conflicts and its stock changes are reverted
+	// before the retry finds the reservation released
+	var current *corev1.StockReservation
+	_, err = i.changeStock(ctx, tenantID, userID, reservationChanges(reservation.Lines, -1), func(ctx context.Context) error {
+		current, err = i.inventoryHandler.GetReservationByID(ctx, tenantID, reservationID)
+		if err != nil {
+			return err
+		}
+		if current.Status != corev1.StockReservationStatus_STOCK_RESERVATION_STATUS_ACTIVE {
+			return infra_error.Business(infra_error.BusinessInvalidOperation).WithError(fmt.Errorf("reservation %s is not active", reservationID))
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		if err := i.inventoryHandler.ReleaseReservation(ctx, current, userID); err != nil {
+			return err
+		}
+		reservation = current
+		return nil
+	})
+	if err != nil {
+		i.logger.Error("failed to release stock", "tenant_id", tenantID, "reservation_id", reservationID, "error", err)
+		return nil, err
+	}
+	i.logger.Info("stock released", "tenant_id", tenantID, "user_id", userID, "reservation_id", reservationID, "reference", reservation.Reference)
+	return reservation, nil
+}
+
+/* Stock changes */
+
+// stockChange is a change of the stock of a product in a warehouse
+type stockChange struct {
+	productID   string
+	warehouseID string
+	// quantity is added to the quantity of the item and reserved to its reserved stock, both may be negative
+	quantity int32
+	reserved int32
+}
+
+// movementChanges returns the stock changes of a movement, a transfer leaves its warehouse before it enters the
+// destination
+func movementChanges(movement *corev1.StockMovement) []stockChange {
+	change := stockChange{productID: movement.ProductId, warehouseID: movement.WarehouseId, quantity: movement.Quantity}
+	switch movement.Type {
+	case corev1.StockMovementType_STOCK_MOVEMENT_TYPE_OUT:
+		change.quantity = -movement.Quantity
+	case corev1.StockMovementType_STOCK_MOVEMENT_TYPE_TRANSFER:
+		change.quantity = -movement.Quantity
+		return []stockChange{change, {productID: movement.ProductId, warehouseID: movement.ToWarehouseId, quantity: movement.Quantity}}
+	}
+	return []stockChange{change}
+}
+
+// reservationChanges returns the changes of the reserved stock of lines, sign is 1 to hold them and -1 to release them
+func reservationChanges(lines []*corev1.StockReservationLine, sign int32) []stockChange {
+	changes := make([]stockChange, 0, len(lines))
+	for _, line := range lines {
+		changes = append(changes, stockChange{productID: line.ProductId, warehouseID: line.WarehouseId, reserved: sign * line.Quantity})
+	}
+	return changes
+}
+
+// inverse returns the change undoing c
+func (c stockChange) inverse() stockChange {
+	return stockChange{productID: c.productID, warehouseID: c.warehouseID, quantity: -c.quantity, reserved: -c.reserved}
+}
+
+// applyStockChange applies change to item and updates its available stock. The quantity of an item cannot fall
+// below its reserved stock, which cannot fall below 0
+func applyStockChange(item *corev1.StockItem, change stockChange) error {
+	quantity := item.Quantity + change.quantity
+	reserved := item.Reserved + change.reserved
+	if reserved < 0 {
+		return infra_error.Business(infra_error.BusinessInvalidOperation).
+			WithError(fmt.Errorf("cannot release %d of product %s in warehouse %s, %d is reserved", -change.reserved, change.productID, change.warehouseID, item.Reserved))
+	}
+	if quantity < reserved {
+		return infra_error.Business(infra_error.BusinessInsufficientStock).
+			WithDetails("product_id", change.productID).
+			WithDetails("warehouse_id", change.warehouseID).
+			WithDetails("available", item.Available).
+			WithError(fmt.Errorf("product %s in warehouse %s has %d available", change.productID, change.warehouseID, item.Available))
+	}
+	item.Quantity = quantity
+	item.Reserved = reserved
+	item.Available = quantity - reserved
+	return nil
+}
+
+// isLowStock reports whether the available stock of item is at or below its low stock threshold
+func isLowStock(item *corev1.StockItem) bool {
+	return item.LowStockThreshold > 0 && item.Available <= item.LowStockThreshold
+}
+
+// crossedLowStock reports whether a change taking the available stock of item from before to its current value
+// made it low, the stock staying low does not cross it again
+func crossedLowStock(before int32, item *corev1.StockItem) bool {
+	return isLowStock(item) && before > item.LowStockThreshold
+}
+
+// changeStock applies changes to the stock items of the tenant with guard before them and record after them, either
+// may be nil, in one outbox transaction with the low stock events of the changed items. Conflicting changes are
+// retried, it returns the changed items in the order of changes
+func (i *InventoryAPI) changeStock(ctx context.Context, tenantID, actorID string, changes []stockChange, guard, record func(ctx context.Context) error) ([]*corev1.StockItem, error) {
+	var items []*corev1.StockItem
+	var err error
+	for attempt := 1; attempt <= stockChangeAttempts; attempt++ {
+		err = i.outbox.Record(ctx, func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+			items = make([]*corev1.StockItem, 0, len(changes))
+			events := []*eventv1.DomainEvent{}
+			applied := []stockChange{}
+			// Without a transaction the changes applied before a failing step are reverted
+			fail := func(err error) ([]*eventv1.DomainEvent, error) {
+				if driver_mongo.SessionFromContext(ctx) == nil {
+					i.revertStockChanges(ctx, tenantID, applied)
+				}
+				return nil, err
+			}
+			if guard != nil {
+				if err := guard(ctx); err != nil {
+					return nil, err
+				}
+			}
+			for _, change := range changes {
+				item, lowStock, err := i.applyStockChange(ctx, tenantID, change)
+				if err != nil {
+					return fail(err)
+				}
+				applied = append(applied, change)
+				items = append(items, item)
+				if lowStock {
+					events = append(events, inventoryEvent(model_event.EventInventoryLowStock, actorID, item))
+				}
+			}
+			if record != nil {
+				if err := record(ctx); err != nil {
+					return fail(err)
+				}
+			}
+			return events, nil
+		})
+		if !infra_error.IsCategory(err, infra_error.CategoryConflict) {
+			break
+		}
+		i.logger.Warn("stock change conflicted, retrying", "tenant_id", tenantID, "attempt", attempt, "error", err)
+	}
+	return items, err
+}
+
+// applyStockChange applies change to its stock item at the version it reads, the item is created by its first
+// change adding stock. It returns the item and whether the change made its stock low
+func (i *InventoryAPI) applyStockChange(ctx context.Context, tenantID string, change stockChange) (*corev1.StockItem, bool, error) {
+	item, err := i.inventoryHandler.GetStockItem(ctx, tenantID, change.productID, change.warehouseID)
+	if err != nil {
+		return nil, false, err
+	}
+	if item == nil {
+		item = &corev1.StockItem{
+			TenantId:     tenantID,
+			ProductId:    change.productID,
+			WarehouseId:  change.warehouseID,
+			ReceivedDate: timestamppb.Now(),
+		}
+		if err := applyStockChange(item, change); err != nil {
+			return nil, false, err
+		}
+		id, err := i.inventoryHandler.CreateStockItem(ctx, item)
+		if err != nil {
+			return nil, false, err
+		}
+		item.Id = id
+		return item, false, nil
+	}
+	before := item.Available
+	if err := applyStockChange(item, change); err != nil {
+		return nil, false, err
+	}
+	if err := i.inventoryHandler.UpdateStockItem(ctx, item); err != nil {
+		return nil, false, err
+	}
+	return item, crossedLowStock(before, item), nil
+}
+
+// revertStockChanges undoes the applied changes of a failed stock change, the items it cannot restore are logged
+func (i *InventoryAPI) revertStockChanges(ctx context.Context, tenantID string, applied []stockChange) {
+	for index := len(applied) - 1; index >= 0; index-- {
+		change := applied[index].inverse()
+		var err error
+		for attempt := 1; attempt <= stockChangeAttempts; attempt++ {
+			if _, _, err = i.applyStockChange(ctx, tenantID, change); !infra_error.IsCategory(err, infra_error.CategoryConflict) {
+				break
+			}
+		}
+		if err != nil {
+			i.logger.Error("failed to revert stock change", "tenant_id", tenantID, "product_id", change.productID, "warehouse_id", change.warehouseID, "error", err)
+		}
+	}
+}
+
+func (i *InventoryAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return i.rbac.RequirePermissions(ctx, tenantID, userID, tenantID, permission)
+}
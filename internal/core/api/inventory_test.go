@@ -0,0 +1,302 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMovementChanges(t *testing.T) {
+	testCases := []struct {
+		name     string
+		movement *corev1.StockMovement
+		expected []stockChange
+	}{
+		{
+			name:     "in",
+			movement: &corev1.StockMovement{Type: corev1.StockMovementType_STOCK_MOVEMENT_TYPE_IN, ProductId: "p1", WarehouseId: "w1", Quantity: 5},
+			expected: []stockChange{{productID: "p1", warehouseID: "w1", quantity: 5}},
+		},
+		{
+			name:     "out",
+			movement: &corev1.StockMovement{Type: corev1.StockMovementType_STOCK_MOVEMENT_TYPE_OUT, ProductId: "p1", WarehouseId: "w1", Quantity: 5},
+			expected: []stockChange{{productID: "p1", warehouseID: "w1", quantity: -5}},
+		},
+		{
+			name:     "transfer leaves before it enters",
+			movement: &corev1.StockMovement{Type: corev1.StockMovementType_STOCK_MOVEMENT_TYPE_TRANSFER, ProductId: "p1", WarehouseId: "w1", ToWarehouseId: "w2", Quantity: 5},
+			expected: []stockChange{{productID: "p1", warehouseID: "w1", quantity: -5}, {productID: "p1", warehouseID: "w2", quantity: 5}},
+		},
+		{
+			name:     "adjustment is signed",
+			movement: &corev1.StockMovement{Type: corev1.StockMovementType_STOCK_MOVEMENT_TYPE_ADJUSTMENT, ProductId: "p1", WarehouseId: "w1", Quantity: -3},
+			expected: []stockChange{{productID: "p1", warehouseID: "w1", quantity: -3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, movementChanges(tc.movement))
+		})
+	}
+}
+
+func TestReservationChanges(t *testing.T) {
+	lines := []*corev1.StockReservationLine{
+		{ProductId: "p1", WarehouseId: "w1", Quantity: 2},
+		{ProductId: "p2", WarehouseId: "w1", Quantity: 3},
+	}
+	reserve := reservationChanges(lines, 1)
+	assert.Equal(t, []stockChange{{productID: "p1", warehouseID: "w1", reserved: 2}, {productID: "p2", warehouseID: "w1", reserved: 3}}, reserve)
+	release := reservationChanges(lines, -1)
+	for index, change := range release {
+		assert.Equal(t, reserve[index].inverse(), change)
+	}
+}
+
+func TestApplyStockChange(t *testing.T) {
+	testCases := []struct {
+		name     string
+		change   stockChange
+		expected *corev1.StockItem
+		err      infra_error.ErrorDef
+	}{
+		{name: "receive", change: stockChange{quantity: 5}, expected: &corev1.StockItem{Quantity: 15, Reserved: 4, Available: 11}},
+		{name: "ship the available stock", change: stockChange{quantity: -6}, expected: &corev1.StockItem{Quantity: 4, Reserved: 4, Available: 0}},
+		{name: "ship reserved stock", change: stockChange{quantity: -7}, err: infra_error.BusinessInsufficientStock},
+		{name: "reserve", change: stockChange{reserved: 6}, expected: &corev1.StockItem{Quantity: 10, Reserved: 10, Available: 0}},
+		{name: "reserve more than available", change: stockChange{reserved: 7}, err: infra_error.BusinessInsufficientStock},
+		{name: "release", change: stockChange{reserved: -4}, expected: &corev1.StockItem{Quantity: 10, Reserved: 0, Available: 10}},
+		{name: "release more than reserved", change: stockChange{reserved: -5}, err: infra_error.BusinessInvalidOperation},
+		{name: "adjust below reserved", change: stockChange{quantity: -8}, err: infra_error.BusinessInsufficientStock},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := &corev1.StockItem{Quantity: 10, Reserved: 4, Available: 6}
+			err := applyStockChange(item, tc.change)
+			if tc.expected == nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, infra_error.New(tc.err)), err)
+				// A rejected change leaves the item unchanged
+				assert.Equal(t, int32(10), item.Quantity)
+				assert.Equal(t, int32(4), item.Reserved)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.Quantity, item.Quantity)
+			assert.Equal(t, tc.expected.Reserved, item.Reserved)
+			assert.Equal(t, tc.expected.Available, item.Available)
+		})
+	}
+}
+
+func TestCrossedLowStock(t *testing.T) {
+	testCases := []struct {
+		name      string
+		before    int32
+		available int32
+		threshold int32
+		expected  bool
+	}{
+		{name: "falls to the threshold", before: 6, available: 5, threshold: 5, expected: true},
+		{name: "falls below the threshold", before: 8, available: 2, threshold: 5, expected: true},
+		{name: "stays above", before: 8, available: 6, threshold: 5, expected: false},
+		{name: "already low", before: 4, available: 3, threshold: 5, expected: false},
+		{name: "restocked", before: 3, available: 9, threshold: 5, expected: false},
+		{name: "no threshold", before: 1, available: 0, threshold: 0, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := &corev1.StockItem{Available: tc.available, LowStockThreshold: tc.threshold}
+			assert.Equal(t, tc.expected, crossedLowStock(tc.before, item))
+		})
+	}
+}
+
+func TestInventoryEvent(t *testing.T) {
+	item := &corev1.StockItem{Id: "item-1", TenantId: "tenant-1", ProductId: "p1", WarehouseId: "w1", Quantity: 10, Reserved: 6, Available: 4, LowStockThreshold: 5}
+	event := inventoryEvent(model_event.EventInventoryLowStock, "user-1", item)
+
+	assert.Equal(t, model_event.EventInventoryLowStock, event.GetType())
+	assert.Equal(t, "tenant-1", event.GetTenantId())
+	assert.Equal(t, "user-1", event.GetActorId())
+	payload, ok := event.GetPayload().(*eventv1.DomainEvent_Inventory)
+	require.True(t, ok)
+	assert.Equal(t, "item-1", payload.Inventory.GetStockItemId())
+	assert.Equal(t, int32(4), payload.Inventory.GetAvailable())
+	assert.Equal(t, int32(5), payload.Inventory.GetLowStockThreshold())
+}
+
+// allowAllRBAC grants every permission
+type allowAllRBAC struct {
+	client.RBACClient
+}
+
+func (allowAllRBAC) RequirePermissions(context.Context, string, string, string, ...string) error {
+	return nil
+}
+
+func TestInventoryAPI_ReleaseStock_WithoutTransaction(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.CoreDB))
+	t.Cleanup(documents.Reset)
+
+	ctx := context.Background()
+	inventory, err := NewInventoryAPI(allowAllRBAC{}, logger.NewBaseLogger(shared.ModuleCore))
+	require.NoError(t, err)
+	for _, productID := range []string{"product-1", "product-2"} {
+		_, err := inventory.inventoryHandler.CreateStockItem(ctx, &corev1.StockItem{
+			TenantId: "tenant-1", ProductId: productID, WarehouseId: "warehouse-1", Quantity: 10, Available: 10,
+		})
+		require.NoError(t, err)
+	}
+	reservation, err := inventory.ReserveStock(ctx, "tenant-1", "user-1", "order-1", []*corev1.StockReservationLine{
+		{ProductId: "product-1", WarehouseId: "warehouse-1", Quantity: 5},
+		{ProductId: "product-2", WarehouseId: "warehouse-1", Quantity: 5},
+	})
+	require.NoError(t, err)
+
+	reserved := func(productID string) int32 {
+		item, err := inventory.inventoryHandler.GetStockItem(ctx, "tenant-1", productID, "warehouse-1")
+		require.NoError(t, err)
+		return item.Reserved
+	}
+	setReserved := func(productID string, quantity int32) {
+		item, err := inventory.inventoryHandler.GetStockItem(ctx, "tenant-1", productID, "warehouse-1")
+		require.NoError(t, err)
+		item.Reserved = quantity
+		item.Available = item.Quantity - quantity
+		require.NoError(t, inventory.inventoryHandler.UpdateStockItem(ctx, item))
+	}
+
+	// The second line cannot be released, the first one is reverted and the reservation stays active
+	setReserved("product-2", 2)
+	_, err = inventory.ReleaseStock(ctx, "tenant-1", "user-1", reservation.Id)
+	require.Error(t, err)
+	assert.Equal(t, int32(5), reserved("product-1"))
+	stored, err := inventory.inventoryHandler.GetReservationByID(ctx, "tenant-1", reservation.Id)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.StockReservationStatus_STOCK_RESERVATION_STATUS_ACTIVE, stored.Status)
+
+	// It can be released once the stock is fixed, and only once
+	setReserved("product-2", 5)
+	released, err := inventory.ReleaseStock(ctx, "tenant-1", "user-1", reservation.Id)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.StockReservationStatus_STOCK_RESERVATION_STATUS_RELEASED, released.Status)
+	assert.Equal(t, int32(0), reserved("product-1"))
+	assert.Equal(t, int32(0), reserved("product-2"))
+	_, err = inventory.ReleaseStock(ctx, "tenant-1", "user-1", reservation.Id)
+	assert.True(t, infra_error.Business(infra_error.BusinessInvalidOperation).Is(err))
+	assert.Equal(t, int32(0), reserved("product-1"))
+}
+
+func TestInventoryAPI_StockWriteFailures(t *testing.T) {
+	errWrite := errors.New("write failed")
+	// failWrites fails the writes of the documents of collection with the field set to value
+	failWrites := func(collection model_mongo.Collection, field string, value any) func(string, bson.M) error {
+		return func(collectionName string, doc bson.M) error {
+			if collectionName == string(collection) && doc[field] == value {
+				return errWrite
+			}
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		name string
+		// reserve fails the reservation, the release is attempted otherwise
+		reserve      bool
+		failWrites   func(string, bson.M) error
+		wantReserved int32
+	}{
+		{
+			name:       "stock update of a reserved line fails",
+			reserve:    true,
+			failWrites: failWrites(model_mongo.InventoryCollection, "product_id", "product-2"),
+		},
+		{
+			name:       "reservation create fails",
+			reserve:    true,
+			failWrites: failWrites(model_mongo.StockReservationsCollection, "reference", "order-1"),
+		},
+		{
+			name:         "stock update of a released line fails",
+			failWrites:   failWrites(model_mongo.InventoryCollection, "product_id", "product-2"),
+			wantReserved: 5,
+		},
+		{
+			name:         "reservation release fails",
+			failWrites:   failWrites(model_mongo.StockReservationsCollection, "reference", "order-1"),
+			wantReserved: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory.Enable()
+			documents := memory.SharedDocuments(string(model_mongo.CoreDB))
+			t.Cleanup(documents.Reset)
+
+			ctx := context.Background()
+			inventory, err := NewInventoryAPI(allowAllRBAC{}, logger.NewBaseLogger(shared.ModuleCore))
+			require.NoError(t, err)
+			for _, productID := range []string{"product-1", "product-2"} {
+				_, err := inventory.inventoryHandler.CreateStockItem(ctx, &corev1.StockItem{
+					TenantId: "tenant-1", ProductId: productID, WarehouseId: "warehouse-1", Quantity: 10, Available: 10,
+				})
+				require.NoError(t, err)
+			}
+			lines := []*corev1.StockReservationLine{
+				{ProductId: "product-1", WarehouseId: "warehouse-1", Quantity: 5},
+				{ProductId: "product-2", WarehouseId: "warehouse-1", Quantity: 5},
+			}
+			var reservation *corev1.StockReservation
+			if !tc.reserve {
+				reservation, err = inventory.ReserveStock(ctx, "tenant-1", "user-1", "order-1", lines)
+				require.NoError(t, err)
+			}
+
+			documents.OnWrite(tc.failWrites)
+			if tc.reserve {
+				reservation, err = inventory.ReserveStock(ctx, "tenant-1", "user-1", "order-1", lines)
+			} else {
+				reservation, err = inventory.ReleaseStock(ctx, "tenant-1", "user-1", reservation.Id)
+			}
+			require.ErrorIs(t, err, errWrite)
+			assert.Nil(t, reservation)
+			documents.OnWrite(nil)
+
+			// The stock changes applied before the failure are reverted
+			for _, productID := range []string{"product-1", "product-2"} {
+				item, err := inventory.inventoryHandler.GetStockItem(ctx, "tenant-1", productID, "warehouse-1")
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantReserved, item.Reserved, productID)
+				assert.Equal(t, 10-tc.wantReserved, item.Available, productID)
+			}
+			// A failed reservation is not stored, a failed release leaves the reservation active
+			var stored []*corev1.StockReservation
+			require.NoError(t, documents.FindAll(ctx, string(model_mongo.StockReservationsCollection), map[string]any{"tenant_id": "tenant-1"}, &stored))
+			if tc.reserve {
+				assert.Empty(t, stored)
+				return
+			}
+			require.Len(t, stored, 1)
+			assert.Equal(t, corev1.StockReservationStatus_STOCK_RESERVATION_STATUS_ACTIVE, stored[0].Status)
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/core/currency"
+	"erp.localhost/internal/core/handler"
+	"erp.localhost/internal/core/numbering"
+	"erp.localhost/internal/core/tax"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	model_core "erp.localhost/internal/infra/model/core"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// invoiceableOrderStatuses are the statuses of the sales orders that may be invoiced
+var invoiceableOrderStatuses = map[corev1.OrderStatus]bool{
+	corev1.OrderStatus_ORDER_STATUS_CONFIRMED: true,
+	corev1.OrderStatus_ORDER_STATUS_SHIPPED:   true,
+	corev1.OrderStatus_ORDER_STATUS_DELIVERED: true,
+}
+
+// InvoiceAPI bills the confirmed sales orders of the tenants. An order is invoiced once, the payment status of the
+// invoice follows the payments recorded against it and is mirrored to the order
+type InvoiceAPI struct {
+	logger         logger.Logger
+	invoiceHandler *handler.InvoiceHandler
+	orderHandler   *handler.OrderHandler
+	partnerHandler *handler.PartnerHandler
+	numbering      *numbering.Service
+	currencies     *currency.Service
+	rbac           client.RBACClient
+	taxRules       tax.Chain
+}
+
+func NewInvoiceAPI(rbac client.RBACClient, numbering *numbering.Service, currencies *currency.Service, logger logger.Logger) (*InvoiceAPI, error) {
+	if rbac == nil || numbering == nil || currencies == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "rbac", "numbering", "currencies")
+	}
+	invoiceHandler, err := handler.NewInvoiceHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new invoice handler", "error", err)
+		return nil, err
+	}
+	orderHandler, err := handler.NewOrderHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new order handler", "error", err)
+		return nil, err
+	}
+	partnerHandler, err := handler.NewPartnerHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new partner handler", "error", err)
+		return nil, err
+	}
+	return &InvoiceAPI{
+		logger:         logger,
+		invoiceHandler: invoiceHandler,
+		orderHandler:   orderHandler,
+		partnerHandler: partnerHandler,
+		numbering:      numbering,
+		currencies:     currencies,
+		rbac:           rbac,
+		taxRules:       tax.DefaultChain(),
+	}, nil
+}
+
+// SetTaxRules sets the rules the tax of new invoices is computed by, the first rule that applies to a line gives its
+// rate. Without rules the lines keep the tax rates of their orders
+func (i *InvoiceAPI) SetTaxRules(rules ...tax.Rule) {
+	if len(rules) == 0 {
+		i.taxRules = tax.DefaultChain()
+		return
+	}
+	i.taxRules = tax.Chain(rules)
+}
+
+// CreateInvoice invoices a confirmed, shipped or delivered sales order of the tenant with the next invoice number,
+// the invoice is due after the net days of the customer payment terms. Its total is converted to the base currency
+// of the tenant at the rate effective at the issue date
+func (i *InvoiceAPI) CreateInvoice(ctx context.Context, tenantID, userID, orderID, notes string) (*corev1.Invoice, error) {
+	if tenantID == "" || userID == "" || orderID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, order_id"))
+		i.logger.Error("failed to create invoice", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InvoiceCreate); err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	order, err := i.orderHandler.GetOrderByID(ctx, tenantID, orderID)
+	if err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return nil, err
+	}
+	if err := orderInvoiceable(order); err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "order_id", orderID, "order_type", order.OrderType, "status", order.Status, "error", err)
+		return nil, err
+	}
+	customer, err := i.partnerHandler.GetCustomerByID(ctx, tenantID, order.CustomerId)
+	if err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "order_id", orderID, "customer_id", order.CustomerId, "error", err)
+		return nil, err
+	}
+	// Checked before the invoice takes a number, the unique index of the order rejects concurrent invoices
+	invoiced, err := i.invoiceHandler.HasOrderInvoice(ctx, tenantID, orderID)
+	if err == nil && invoiced {
+		err = infra_error.Conflict(infra_error.ConflictInvoiceExists)
+	}
+	if err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return nil, err
+	}
+	now := time.Now()
+	invoice := newInvoice(order, customer, userID, notes, now)
+	calculateInvoiceTotals(invoice, i.taxRules)
+	if err := validator_core.ValidateNewInvoice(invoice); err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return nil, err
+	}
+	invoice.BaseCurrency, invoice.ExchangeRate, invoice.BaseTotal, err = convertToBase(ctx, i.currencies, tenantID, invoice.Currency, invoice.Total, now)
+	if err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "order_id", orderID, "currency", invoice.Currency, "error", err)
+		return nil, err
+	}
+	if invoice.BaseCurrency == "" {
+		i.logger.Warn("no exchange rate to the base currency, invoice total not converted", "tenant_id", tenantID, "currency", invoice.Currency)
+	}
+	var id string
+	err = i.numbering.Assign(ctx, tenantID, numbering.DocumentInvoice, now, func(ctx context.Context, number string) error {
+		invoice.InvoiceNumber = number
+		var err error
+		id, err = i.invoiceHandler.CreateInvoice(ctx, invoice)
+		return err
+	})
+	if err != nil {
+		i.logger.Error("failed to create invoice", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return nil, err
+	}
+	invoice.Id = id
+	i.logger.Info("invoice created", "tenant_id", tenantID, "user_id", userID, "invoice_id", id, "invoice_number", invoice.InvoiceNumber, "order_id", orderID)
+	return invoice, nil
+}
+
+func (i *InvoiceAPI) GetInvoice(ctx context.Context, tenantID, userID, invoiceID string) (*corev1.Invoice, error) {
+	if tenantID == "" || userID == "" || invoiceID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, invoice_id"))
+		i.logger.Error("failed to get invoice", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InvoiceRead); err != nil {
+		i.logger.Error("failed to get invoice", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	invoice, err := i.invoiceHandler.GetInvoiceByID(ctx, tenantID, invoiceID)
+	if err != nil {
+		i.logger.Error("failed to get invoice", "tenant_id", tenantID, "invoice_id", invoiceID, "error", err)
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// SearchInvoices returns a page of the tenant invoices matching search, newest first
+func (i *InvoiceAPI) SearchInvoices(ctx context.Context, tenantID, userID string, search *corev1.InvoiceSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Invoice, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		i.logger.Error("failed to search invoices", "error", err)
+		return nil, nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InvoiceRead); err != nil {
+		i.logger.Error("failed to search invoices", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+	invoices, page, err := i.invoiceHandler.SearchInvoices(ctx, tenantID, search, pagination)
+	if err != nil {
+		i.logger.Error("failed to search invoices", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	return invoices, page, nil
+}
+
+// RecordPayment records a payment of at most the amount due against the invoice and updates its payment status, the
+// status is mirrored to the invoiced order
+func (i *InvoiceAPI) RecordPayment(ctx context.Context, tenantID, userID, invoiceID string, payment *corev1.InvoicePayment) (*corev1.Invoice, error) {
+	if tenantID == "" || userID == "" || invoiceID == "" || payment == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, invoice_id, payment"))
+		i.logger.Error("failed to record payment", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InvoiceUpdate); err != nil {
+		i.logger.Error("failed to record payment", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	invoice, err := i.invoiceHandler.GetInvoiceByID(ctx, tenantID, invoiceID)
+	if err != nil {
+		i.logger.Error("failed to record payment", "tenant_id", tenantID, "invoice_id", invoiceID, "error", err)
+		return nil, err
+	}
+	if err := applyInvoicePayment(invoice, payment, userID, time.Now()); err != nil {
+		i.logger.Error("failed to record payment", "tenant_id", tenantID, "invoice_id", invoiceID, "amount", payment.Amount, "amount_due", invoice.AmountDue, "error", err)
+		return nil, err
+	}
+	if err := i.invoiceHandler.UpdateInvoice(ctx, invoice); err != nil {
+		i.logger.Error("failed to record payment", "tenant_id", tenantID, "invoice_id", invoiceID, "error", err)
+		return nil, err
+	}
+	i.logger.Info("payment recorded", "tenant_id", tenantID, "user_id", userID, "invoice_id", invoiceID, "amount", payment.Amount, "payment_status", model_core.PaymentStatusName(invoice.PaymentStatus))
+	i.syncOrderPayment(ctx, invoice, payment)
+	return invoice, nil
+}
+
+// GetInvoiceDocument returns the content of the printed invoice, its buyer is the current customer of the invoice
+func (i *InvoiceAPI) GetInvoiceDocument(ctx context.Context, tenantID, userID, invoiceID string) (*corev1.InvoiceDocument, error) {
+	if tenantID == "" || userID == "" || invoiceID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, invoice_id"))
+		i.logger.Error("failed to get invoice document", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.InvoiceRead); err != nil {
+		i.logger.Error("failed to get invoice document", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	invoice, err := i.invoiceHandler.GetInvoiceByID(ctx, tenantID, invoiceID)
+	if err != nil {
+		i.logger.Error("failed to get invoice document", "tenant_id", tenantID, "invoice_id", invoiceID, "error", err)
+		return nil, err
+	}
+	customer, err := i.partnerHandler.GetCustomerByID(ctx, tenantID, invoice.CustomerId)
+	if err != nil {
+		i.logger.Error("failed to get invoice document", "tenant_id", tenantID, "invoice_id", invoiceID, "customer_id", invoice.CustomerId, "error", err)
+		return nil, err
+	}
+	return buildInvoiceDocument(invoice, customer), nil
+}
+
+// syncOrderPayment mirrors the payment status of invoice to its order. The invoice is the record of the payments, a
+// failure is logged and left to the next payment
+func (i *InvoiceAPI) syncOrderPayment(ctx context.Context, invoice *corev1.Invoice, payment *corev1.InvoicePayment) {
+	order, err := i.orderHandler.GetOrderByID(ctx, invoice.TenantId, invoice.OrderId)
+	if err != nil {
+		i.logger.Warn("failed to sync order payment", "tenant_id", invoice.TenantId, "order_id", invoice.OrderId, "error", err)
+		return
+	}
+	if order.Payment == nil {
+		order.Payment = &corev1.PaymentInfo{}
+	}
+	order.Payment.Status = invoice.PaymentStatus
+	order.Payment.Method = payment.Method
+	order.Payment.TransactionId = payment.Reference
+	if invoice.PaymentStatus == corev1.PaymentStatus_PAYMENT_STATUS_PAID {
+		order.Payment.PaidAt = payment.PaidAt
+	}
+	if err := i.orderHandler.UpdateOrder(ctx, order); err != nil {
+		i.logger.Warn("failed to sync order payment", "tenant_id", invoice.TenantId, "order_id", invoice.OrderId, "error", err)
+	}
+}
+
+// orderInvoiceable returns an error when order is not a confirmed, shipped or delivered sales order
+func orderInvoiceable(order *corev1.Order) error {
+	if order.OrderType != corev1.OrderType_ORDER_TYPE_SALES {
+		return infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("only sales orders are invoiced"))
+	}
+	if !invoiceableOrderStatuses[order.Status] {
+		return infra_error.Business(infra_error.BusinessInvalidOrderStatus).
+			WithDetails("status", model_core.OrderStatusName(order.Status)).
+			WithError(errors.New("only confirmed, shipped or delivered orders are invoiced"))
+	}
+	return nil
+}
+
+// newInvoice returns an unpaid, unnumbered invoice of the lines of order issued at now, billed to the billing address
+// of the order or else the default billing address of customer
+func newInvoice(order *corev1.Order, customer *corev1.Customer, userID, notes string, now time.Time) *corev1.Invoice {
+	lines := make([]*corev1.InvoiceLine, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		if line.Status == corev1.OrderLineStatus_ORDER_LINE_STATUS_CANCELLED {
+			continue
+		}
+		lines = append(lines, &corev1.InvoiceLine{
+			OrderLineId: line.Id,
+			ProductId:   line.ProductId,
+			Sku:         line.Sku,
+			Name:        line.Name,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Discount:    line.Discount,
+			TaxRate:     line.TaxRate,
+		})
+	}
+	currency := order.GetTotals().GetCurrency()
+	if currency == "" {
+		currency = customer.GetPaymentTerms().GetCurrency()
+	}
+	billingAddress := order.GetBillingAddress()
+	if proto.Size(billingAddress) == 0 {
+		billingAddress = customerBillingAddress(customer)
+	}
+	if billingAddress != nil {
+		billingAddress = proto.Clone(billingAddress).(*corev1.Address)
+	}
+	netDays := int(customer.GetPaymentTerms().GetNetDays())
+	return &corev1.Invoice{
+		TenantId:       order.TenantId,
+		OrderId:        order.Id,
+		OrderNumber:    order.OrderNumber,
+		CustomerId:     order.CustomerId,
+		Lines:          lines,
+		Currency:       strings.ToUpper(currency),
+		Shipping:       order.GetTotals().GetShipping(),
+		PaymentStatus:  corev1.PaymentStatus_PAYMENT_STATUS_PENDING,
+		BillingAddress: billingAddress,
+		IssuedAt:       timestamppb.New(now),
+		DueAt:          timestamppb.New(now.AddDate(0, 0, netDays)),
+		Notes:          notes,
+		CreatedBy:      userID,
+	}
+}
+
+// customerBillingAddress returns the default billing address of customer, or its first billing address when none is
+// the default
+func customerBillingAddress(customer *corev1.Customer) *corev1.Address {
+	var billing *corev1.CustomerAddress
+	for _, address := range customer.GetAddresses() {
+		if address.Type != corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_BILLING {
+			continue
+		}
+		if billing == nil || address.IsDefault {
+			billing = address
+		}
+		if address.IsDefault {
+			break
+		}
+	}
+	if billing == nil {
+		return nil
+	}
+	return &corev1.Address{
+		Street:  billing.Street,
+		City:    billing.City,
+		State:   billing.State,
+		Zip:     billing.Zip,
+		Country: billing.Country,
+	}
+}
+
+// calculateInvoiceTotals computes the amounts of the invoice lines with the tax rates of rules and sums them with the
+// shipping into the invoice totals, the amount due and payment status follow the amount paid. Every amount is
+// rounded to cents
+func calculateInvoiceTotals(invoice *corev1.Invoice, rules tax.Chain) {
+	invoice.Subtotal, invoice.Discount, invoice.Tax = 0, 0, 0
+	for _, line := range invoice.Lines {
+		line.TaxRule, line.TaxRate = rules.Rate(invoice, line)
+		line.Subtotal = roundAmount(float64(line.Quantity) * line.UnitPrice)
+		line.Discount = roundAmount(line.Discount)
+		line.Tax = roundAmount((line.Subtotal - line.Discount) * line.TaxRate)
+		line.Total = roundAmount(line.Subtotal - line.Discount + line.Tax)
+		invoice.Subtotal += line.Subtotal
+		invoice.Discount += line.Discount
+		invoice.Tax += line.Tax
+	}
+	invoice.Subtotal = roundAmount(invoice.Subtotal)
+	invoice.Discount = roundAmount(invoice.Discount)
+	invoice.Tax = roundAmount(invoice.Tax)
+	invoice.Shipping = roundAmount(invoice.Shipping)
+	invoice.Total = roundAmount(invoice.Subtotal - invoice.Discount + invoice.Tax + invoice.Shipping)
+	invoice.AmountDue = roundAmount(invoice.Total - invoice.AmountPaid)
+	invoice.PaymentStatus = model_core.InvoicePaymentStatus(invoice.Total, invoice.AmountPaid)
+}
+
+// applyInvoicePayment records payment against invoice at now, a payment without a date was paid at now. A payment
+// may not exceed the amount due
+func applyInvoicePayment(invoice *corev1.Invoice, payment *corev1.InvoicePayment, userID string, now time.Time) error {
+	if err := validator_core.ValidateInvoicePayment(payment); err != nil {
+		return err
+	}
+	if invoice.PaymentStatus == corev1.PaymentStatus_PAYMENT_STATUS_PAID || invoice.AmountDue <= 0 {
+		return infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("the invoice is paid"))
+	}
+	amount := roundAmount(payment.Amount)
+	if amount > invoice.AmountDue {
+		return infra_error.Validation(infra_error.ValidationOutOfRange, "Amount").
+			WithDetails("amount_due", invoice.AmountDue).
+			WithError(errors.New("the payment exceeds the amount due"))
+	}
+	payment.Id = uuid.New().String()
+	payment.Amount = amount
+	payment.RecordedBy = userID
+	if payment.PaidAt == nil {
+		payment.PaidAt = timestamppb.New(now)
+	}
+	invoice.Payments = append(invoice.Payments, payment)
+	invoice.AmountPaid = roundAmount(invoice.AmountPaid + amount)
+	invoice.AmountDue = roundAmount(invoice.Total - invoice.AmountPaid)
+	invoice.PaymentStatus = model_core.InvoicePaymentStatus(invoice.Total, invoice.AmountPaid)
+	return nil
+}
+
+// buildInvoiceDocument returns the printed content of invoice billed to customer, the tax of its lines is summed by
+// rule and rate in the order the lines first use them
+func buildInvoiceDocument(invoice *corev1.Invoice, customer *corev1.Customer) *corev1.InvoiceDocument {
+	billTo := &corev1.InvoiceParty{
+		Name:    customer.GetName(),
+		Email:   customer.GetEmail(),
+		Phone:   customer.GetPhone(),
+		TaxId:   customer.GetCompany().GetTaxId(),
+		Address: invoice.GetBillingAddress(),
+	}
+	if company := customer.GetCompany().GetName(); company != "" {
+		billTo.Name = company
+	}
+
+	var taxes []*corev1.InvoiceTaxSummary
+	for _, line := range invoice.Lines {
+		var summary *corev1.InvoiceTaxSummary
+		for _, existing := range taxes {
+			if existing.Rule == line.TaxRule && existing.Rate == line.TaxRate {
+				summary = existing
+				break
+			}
+		}
+		if summary == nil {
+			summary = &corev1.InvoiceTaxSummary{Rule: line.TaxRule, Rate: line.TaxRate}
+			taxes = append(taxes, summary)
+		}
+		summary.Taxable = roundAmount(summary.Taxable + line.Subtotal - line.Discount)
+		summary.Tax = roundAmount(summary.Tax + line.Tax)
+	}
+
+	terms := customer.GetPaymentTerms().GetTerms()
+	if terms == "" && customer.GetPaymentTerms().GetNetDays() > 0 {
+		terms = fmt.Sprintf("NET%d", customer.GetPaymentTerms().GetNetDays())
+	}
+	return &corev1.InvoiceDocument{
+		InvoiceNumber: invoice.InvoiceNumber,
+		OrderNumber:   invoice.OrderNumber,
+		IssuedAt:      invoice.IssuedAt,
+		DueAt:         invoice.DueAt,
+		Currency:      invoice.Currency,
+		BillTo:        billTo,
+		Lines:         invoice.Lines,
+		Taxes:         taxes,
+		Subtotal:      invoice.Subtotal,
+		Discount:      invoice.Discount,
+		Tax:           invoice.Tax,
+		Shipping:      invoice.Shipping,
+		Total:         invoice.Total,
+		AmountPaid:    invoice.AmountPaid,
+		AmountDue:     invoice.AmountDue,
+		PaymentStatus: invoice.PaymentStatus,
+		Payments:      invoice.Payments,
+		PaymentTerms:  terms,
+		Notes:         invoice.Notes,
+	}
+}
+
+func (i *InvoiceAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return i.rbac.RequirePermissions(ctx, tenantID, userID, tenantID, permission)
+}
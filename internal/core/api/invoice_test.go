@@ -0,0 +1,171 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/core/tax"
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderInvoiceable(t *testing.T) {
+	testCases := []struct {
+		name      string
+		orderType corev1.OrderType
+		status    corev1.OrderStatus
+		err       *infra_error.ErrorDef
+	}{
+		{name: "confirmed sales order", orderType: corev1.OrderType_ORDER_TYPE_SALES, status: corev1.OrderStatus_ORDER_STATUS_CONFIRMED},
+		{name: "delivered sales order", orderType: corev1.OrderType_ORDER_TYPE_SALES, status: corev1.OrderStatus_ORDER_STATUS_DELIVERED},
+		{name: "pending sales order", orderType: corev1.OrderType_ORDER_TYPE_SALES, status: corev1.OrderStatus_ORDER_STATUS_PENDING, err: &infra_error.BusinessInvalidOrderStatus},
+		{name: "cancelled sales order", orderType: corev1.OrderType_ORDER_TYPE_SALES, status: corev1.OrderStatus_ORDER_STATUS_CANCELLED, err: &infra_error.BusinessInvalidOrderStatus},
+		{name: "purchase order", orderType: corev1.OrderType_ORDER_TYPE_PURCHASE, status: corev1.OrderStatus_ORDER_STATUS_CONFIRMED, err: &infra_error.BusinessInvalidOperation},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := orderInvoiceable(&corev1.Order{OrderType: tc.orderType, Status: tc.status})
+			if tc.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, infra_error.New(*tc.err)), err)
+		})
+	}
+}
+
+func TestNewInvoice(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	order := &corev1.Order{
+		Id:          "order-1",
+		TenantId:    "tenant-1",
+		OrderNumber: "SO-20260301-0001",
+		CustomerId:  "customer-1",
+		Lines: []*corev1.OrderLine{
+			{Id: "line-1", ProductId: "p1", Quantity: 2, UnitPrice: 10, TaxRate: 0.1},
+			{Id: "line-2", ProductId: "p2", Quantity: 1, UnitPrice: 5, Status: corev1.OrderLineStatus_ORDER_LINE_STATUS_CANCELLED},
+		},
+		Totals:         &corev1.OrderTotals{Shipping: 4},
+		BillingAddress: &corev1.Address{},
+	}
+	customer := &corev1.Customer{
+		PaymentTerms: &corev1.PaymentTerms{NetDays: 30, Currency: "eur"},
+		Addresses: []*corev1.CustomerAddress{
+			{Type: corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_SHIPPING, Country: "FR", IsDefault: true},
+			{Type: corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_BILLING, Country: "BE"},
+			{Type: corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_BILLING, Country: "DE", IsDefault: true},
+		},
+	}
+
+	invoice := newInvoice(order, customer, "user-1", "thanks", now)
+	// The number is given when the invoice is stored
+	assert.Empty(t, invoice.InvoiceNumber)
+	assert.Equal(t, "order-1", invoice.OrderId)
+	assert.Equal(t, "customer-1", invoice.CustomerId)
+	require.Len(t, invoice.Lines, 1)
+	assert.Equal(t, "line-1", invoice.Lines[0].OrderLineId)
+	assert.Equal(t, "EUR", invoice.Currency)
+	assert.Equal(t, 4.0, invoice.Shipping)
+	assert.Equal(t, "DE", invoice.BillingAddress.GetCountry())
+	assert.Equal(t, now.AddDate(0, 0, 30), invoice.DueAt.AsTime())
+	assert.Equal(t, corev1.PaymentStatus_PAYMENT_STATUS_PENDING, invoice.PaymentStatus)
+
+	order.BillingAddress = &corev1.Address{Country: "NL"}
+	order.Totals.Currency = "usd"
+	invoice = newInvoice(order, customer, "user-1", "", now)
+	assert.Equal(t, "NL", invoice.BillingAddress.GetCountry())
+	assert.Equal(t, "USD", invoice.Currency)
+}
+
+func TestCalculateInvoiceTotals(t *testing.T) {
+	invoice := &corev1.Invoice{
+		BillingAddress: &corev1.Address{Country: "DE"},
+		Lines: []*corev1.InvoiceLine{
+			{ProductId: "p1", Quantity: 3, UnitPrice: 9.99, TaxRate: 0.17},
+			{ProductId: "p2", Quantity: 2, UnitPrice: 50, Discount: 10, TaxRate: 0.1},
+		},
+		Shipping:   5,
+		AmountPaid: 20,
+	}
+	calculateInvoiceTotals(invoice, tax.DefaultChain())
+	assert.Equal(t, "order_line", invoice.Lines[0].TaxRule)
+	assert.Equal(t, 5.09, invoice.Lines[0].Tax)
+	assert.Equal(t, 9.0, invoice.Lines[1].Tax)
+	assert.Equal(t, 129.97, invoice.Subtotal)
+	assert.Equal(t, 10.0, invoice.Discount)
+	assert.Equal(t, 14.09, invoice.Tax)
+	assert.Equal(t, 139.06, invoice.Total)
+	assert.Equal(t, 119.06, invoice.AmountDue)
+	assert.Equal(t, corev1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID, invoice.PaymentStatus)
+
+	calculateInvoiceTotals(invoice, tax.Chain{tax.CountryRates{"DE": 0.19}, tax.LineRate{}})
+	assert.Equal(t, "country", invoice.Lines[0].TaxRule)
+	assert.Equal(t, 0.19, invoice.Lines[1].TaxRate)
+	assert.Equal(t, 5.69, invoice.Lines[0].Tax)
+	assert.Equal(t, 17.1, invoice.Lines[1].Tax)
+	assert.Equal(t, 147.76, invoice.Total)
+}
+
+func TestApplyInvoicePayment(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	invoice := &corev1.Invoice{Total: 100, AmountDue: 100, PaymentStatus: corev1.PaymentStatus_PAYMENT_STATUS_PENDING}
+
+	err := applyInvoicePayment(invoice, &corev1.InvoicePayment{Amount: 40.004, Method: "card"}, "user-1", now)
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, invoice.AmountPaid)
+	assert.Equal(t, 60.0, invoice.AmountDue)
+	assert.Equal(t, corev1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID, invoice.PaymentStatus)
+	require.Len(t, invoice.Payments, 1)
+	assert.NotEmpty(t, invoice.Payments[0].Id)
+	assert.Equal(t, "user-1", invoice.Payments[0].RecordedBy)
+	assert.Equal(t, now, invoice.Payments[0].PaidAt.AsTime())
+
+	err = applyInvoicePayment(invoice, &corev1.InvoicePayment{Amount: 60.01, Method: "card"}, "user-1", now)
+	assert.True(t, errors.Is(err, infra_error.New(infra_error.ValidationOutOfRange)), err)
+	assert.Equal(t, 60.0, invoice.AmountDue)
+
+	err = applyInvoicePayment(invoice, &corev1.InvoicePayment{Amount: 10}, "user-1", now)
+	require.Error(t, err)
+	assert.Len(t, invoice.Payments, 1)
+
+	require.NoError(t, applyInvoicePayment(invoice, &corev1.InvoicePayment{Amount: 60, Method: "bank_transfer"}, "user-1", now))
+	assert.Equal(t, 0.0, invoice.AmountDue)
+	assert.Equal(t, corev1.PaymentStatus_PAYMENT_STATUS_PAID, invoice.PaymentStatus)
+
+	err = applyInvoicePayment(invoice, &corev1.InvoicePayment{Amount: 1, Method: "card"}, "user-1", now)
+	assert.True(t, errors.Is(err, infra_error.New(infra_error.BusinessInvalidOperation)), err)
+}
+
+func TestBuildInvoiceDocument(t *testing.T) {
+	invoice := &corev1.Invoice{
+		InvoiceNumber:  "INV-20260301-0001",
+		BillingAddress: &corev1.Address{Country: "DE"},
+		Lines: []*corev1.InvoiceLine{
+			{ProductId: "p1", Subtotal: 100, Discount: 10, TaxRule: "country", TaxRate: 0.19, Tax: 17.1},
+			{ProductId: "p2", Subtotal: 20, TaxRule: "order_line", TaxRate: 0.07, Tax: 1.4},
+			{ProductId: "p3", Subtotal: 10, TaxRule: "country", TaxRate: 0.19, Tax: 1.9},
+		},
+		Total:     139.4,
+		AmountDue: 139.4,
+	}
+	customer := &corev1.Customer{
+		Name:         "Jane Doe",
+		Email:        "jane@acme.test",
+		Company:      &corev1.CompanyInfo{Name: "Acme GmbH", TaxId: "DE123"},
+		PaymentTerms: &corev1.PaymentTerms{NetDays: 14},
+	}
+
+	document := buildInvoiceDocument(invoice, customer)
+	assert.Equal(t, "Acme GmbH", document.BillTo.Name)
+	assert.Equal(t, "DE123", document.BillTo.TaxId)
+	assert.Equal(t, "DE", document.BillTo.Address.GetCountry())
+	assert.Equal(t, "NET14", document.PaymentTerms)
+	require.Len(t, document.Taxes, 2)
+	assert.Equal(t, &corev1.InvoiceTaxSummary{Rule: "country", Rate: 0.19, Taxable: 100, Tax: 19}, document.Taxes[0])
+	assert.Equal(t, &corev1.InvoiceTaxSummary{Rule: "order_line", Rate: 0.07, Taxable: 20, Tax: 1.4}, document.Taxes[1])
+	assert.Equal(t, 139.4, document.AmountDue)
+}
@@ -0,0 +1,537 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/core/currency"
+	"erp.localhost/internal/core/handler"
+	"erp.localhost/internal/core/numbering"
+	"erp.localhost/internal/core/pricing"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	model_core "erp.localhost/internal/infra/model/core"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	"erp.localhost/internal/infra/model/fieldmask"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// orderUpdateMaskFields are the fields of a draft order an update mask may name, the totals are computed from the
+// lines so only their shipping and currency are editable
+var orderUpdateMaskFields = []string{"customer_id", "vendor_id", "lines", "shipping_address", "billing_address", "notes", "totals.shipping", "totals.currency"}
+
+// orderNumberDocuments are the number sequences of each order type
+var orderNumberDocuments = map[corev1.OrderType]numbering.Document{
+	corev1.OrderType_ORDER_TYPE_SALES:    numbering.DocumentSalesOrder,
+	corev1.OrderType_ORDER_TYPE_PURCHASE: numbering.DocumentPurchaseOrder,
+	corev1.OrderType_ORDER_TYPE_TRANSFER: numbering.DocumentTransferOrder,
+}
+
+// OrderAPI manages the sales, purchase and transfer orders of the tenants. Orders are created as drafts, only drafts
+// are edited or deleted and the status of the others follows the transitions of model_core.CanTransitionOrder
+type OrderAPI struct {
+	logger         logger.Logger
+	orderHandler   *handler.OrderHandler
+	partnerHandler *handler.PartnerHandler
+	pricingHandler *handler.PricingHandler
+	numbering      *numbering.Service
+	currencies     *currency.Service
+	rbac           client.RBACClient
+}
+
+func NewOrderAPI(rbac client.RBACClient, numbering *numbering.Service, currencies *currency.Service, logger logger.Logger) (*OrderAPI, error) {
+	if rbac == nil || numbering == nil || currencies == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "rbac", "numbering", "currencies")
+	}
+	orderHandler, err := handler.NewOrderHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new order handler", "error", err)
+		return nil, err
+	}
+	partnerHandler, err := handler.NewPartnerHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new partner handler", "error", err)
+		return nil, err
+	}
+	pricingHandler, err := handler.NewPricingHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new pricing handler", "error", err)
+		return nil, err
+	}
+	return &OrderAPI{
+		logger:         logger,
+		orderHandler:   orderHandler,
+		partnerHandler: partnerHandler,
+		pricingHandler: pricingHandler,
+		numbering:      numbering,
+		currencies:     currencies,
+		rbac:           rbac,
+	}, nil
+}
+
+// CreateOrder creates a draft order of the tenant with the totals of its lines, the lines of a sales order are priced
+// with the pricing rules of the tenant and its total is converted to the base currency of the tenant. An order without
+// a number is given the next number of its type, see
+// numbering.Service
+func (o *OrderAPI) CreateOrder(ctx context.Context, tenantID, userID string, order *corev1.Order) (*corev1.Order, error) {
+	if tenantID == "" || userID == "" || order == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, order"))
+		o.logger.Error("failed to create order", "error", err)
+		return nil, err
+	}
+	now := time.Now()
+	order.Id = ""
+	order.TenantId = tenantID
+	order.CreatedBy = userID
+	order.Status = corev1.OrderStatus_ORDER_STATUS_DRAFT
+	order.Fulfillment = nil
+	order.Timeline = []*corev1.OrderTimelineEvent{timelineEvent(order.Status, userID, "", now)}
+	if order.Payment == nil {
+		order.Payment = &corev1.PaymentInfo{}
+	}
+	if order.Payment.Status == corev1.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED {
+		order.Payment.Status = corev1.PaymentStatus_PAYMENT_STATUS_PENDING
+	}
+	prepareOrderLines(order.Lines)
+	calculateOrderTotals(order)
+	if err := validator_core.ValidateNewOrder(order); err != nil {
+		o.logger.Error("failed to create order", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := o.hasPermission(ctx, tenantID, userID, permissions.OrderCreate); err != nil {
+		o.logger.Error("failed to create order", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	if err := o.checkPartners(ctx, order); err != nil {
+		o.logger.Error("failed to create order", "tenant_id", tenantID, "customer_id", order.CustomerId, "vendor_id", order.VendorId, "error", err)
+		return nil, err
+	}
+	if err := o.priceOrder(ctx, order, now); err != nil {
+		o.logger.Error("failed to create order", "tenant_id", tenantID, "customer_id", order.CustomerId, "error", err)
+		return nil, err
+	}
+	if err := o.convertOrderTotals(ctx, order, now); err != nil {
+		o.logger.Error("failed to create order", "tenant_id", tenantID, "currency", order.Totals.Currency, "error", err)
+		return nil, err
+	}
+	id, err := o.createOrder(ctx, order, now)
+	if err != nil {
+		o.logger.Error("failed to create order", "tenant_id", tenantID, "order_number", order.OrderNumber, "error", err)
+		return nil, err
+	}
+	order.Id = id
+	o.logger.Info("order created", "tenant_id", tenantID, "user_id", userID, "order_id", id, "order_number", order.OrderNumber)
+	return order, nil
+}
+
+func (o *OrderAPI) GetOrder(ctx context.Context, tenantID, userID, orderID string) (*corev1.Order, error) {
+	if tenantID == "" || userID == "" || orderID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, order_id"))
+		o.logger.Error("failed to get order", "error", err)
+		return nil, err
+	}
+	if err := o.hasPermission(ctx, tenantID, userID, permissions.OrderRead); err != nil {
+		o.logger.Error("failed to get order", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	order, err := o.orderHandler.GetOrderByID(ctx, tenantID, orderID)
+	if err != nil {
+		o.logger.Error("failed to get order", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return nil, err
+	}
+	return order, nil
+}
+
+// SearchOrders returns a page of the tenant orders matching search, newest first unless search sorts otherwise
+func (o *OrderAPI) SearchOrders(ctx context.Context, tenantID, userID string, search *corev1.OrderSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Order, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		o.logger.Error("failed to search orders", "error", err)
+		return nil, nil, err
+	}
+	if err := o.hasPermission(ctx, tenantID, userID, permissions.OrderRead); err != nil {
+		o.logger.Error("failed to search orders", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+	orders, page, err := o.orderHandler.SearchOrders(ctx, tenantID, search, pagination)
+	if err != nil {
+		o.logger.Error("failed to search orders", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	return orders, page, nil
+}
+
+// UpdateOrder replaces the editable fields of the stored draft with those of order, or when mask names fields only
+// changes those fields, and recomputes the totals. A sales order is priced again when its lines or customer change.
+// The update is rejected when the order changed since it was read at the version of order
+func (o *OrderAPI) UpdateOrder(ctx context.Context, tenantID, userID string, order *corev1.Order, mask *fieldmaskpb.FieldMask) (*corev1.Order, error) {
+	if tenantID == "" || userID == "" || order.GetId() == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, order.id"))
+		o.logger.Error("failed to update order", "error", err)
+		return nil, err
+	}
+	if fieldmask.IsEmpty(mask) {
+		mask = &fieldmaskpb.FieldMask{Paths: orderUpdateMaskFields}
+	} else if err := fieldmask.CheckPaths(mask, orderUpdateMaskFields...); err != nil {
+		o.logger.Error("failed to update order", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := o.hasPermission(ctx, tenantID, userID, permissions.OrderUpdate); err != nil {
+		o.logger.Error("failed to update order", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	existing, err := o.orderHandler.GetOrderByID(ctx, tenantID, order.Id)
+	if err != nil {
+		o.logger.Error("failed to update order", "tenant_id", tenantID, "order_id", order.Id, "error", err)
+		return nil, err
+	}
+	if existing.Status != corev1.OrderStatus_ORDER_STATUS_DRAFT {
+		err := infra_error.Business(infra_error.BusinessInvalidOrderStatus).WithError(errors.New("only draft orders can be edited"))
+		o.logger.Error("failed to update order", "tenant_id", tenantID, "order_id", order.Id, "status", existing.Status, "error", err)
+		return nil, err
+	}
+	merged, err := mergeOrderUpdate(existing, order, mask)
+	if err != nil {
+		o.logger.Error("failed to update order", "tenant_id", tenantID, "order_id", order.Id, "error", err)
+		return nil, err
+	}
+	if merged.CustomerId != existing.CustomerId || merged.VendorId != existing.VendorId {
+		if err := o.checkPartners(ctx, merged); err != nil {
+			o.logger.Error("failed to update order", "tenant_id", tenantID, "order_id", order.Id, "customer_id", merged.CustomerId, "vendor_id", merged.VendorId, "error", err)
+			return nil, err
+		}
+	}
+	now := time.Now()
+	if slices.ContainsFunc(mask.GetPaths(), repricesOrder) {
+		if err := o.priceOrder(ctx, merged, now); err != nil {
+			o.logger.Error("failed to update order", "tenant_id", tenantID, "order_id", order.Id, "customer_id", merged.CustomerId, "error", err)
+			return nil, err
+		}
+	}
+	if slices.ContainsFunc(mask.GetPaths(), changesOrderTotal) {
+		if err := o.convertOrderTotals(ctx, merged, now); err != nil {
+			o.logger.Error("failed to update order", "tenant_id", tenantID, "order_id", order.Id, "currency", merged.Totals.Currency, "error", err)
+			return nil, err
+		}
+	}
+	if err := o.orderHandler.UpdateOrder(ctx, merged); err != nil {
+		o.logger.Error("failed to update order", "tenant_id", tenantID, "order_id", order.Id, "error", err)
+		return nil, err
+	}
+	o.logger.Info("order updated", "tenant_id", tenantID, "user_id", userID, "order_id", order.Id)
+	return merged, nil
+}
+
+// ChangeOrderStatus moves the order to status and records the change in its timeline, fulfillment holds the
+// shipping details of a shipped order
+func (o *OrderAPI) ChangeOrderStatus(ctx context.Context, tenantID, userID, orderID string, status corev1.OrderStatus, notes string, fulfillment *corev1.FulfillmentInfo) (*corev1.Order, error) {
+	if tenantID == "" || userID == "" || orderID == "" || status == corev1.OrderStatus_ORDER_STATUS_UNSPECIFIED {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, order_id, status"))
+		o.logger.Error("failed to change order status", "error", err)
+		return nil, err
+	}
+	if err := o.hasPermission(ctx, tenantID, userID, permissions.OrderUpdate); err != nil {
+		o.logger.Error("failed to change order status", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	order, err := o.orderHandler.GetOrderByID(ctx, tenantID, orderID)
+	if err != nil {
+		o.logger.Error("failed to change order status", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return nil, err
+	}
+	from := order.Status
+	if err := transitionOrder(order, status, userID, notes, fulfillment, time.Now()); err != nil {
+		o.logger.Error("failed to change order status", "tenant_id", tenantID, "order_id", orderID, "from", from, "to", status, "error", err)
+		return nil, err
+	}
+	// The partners of a draft may have been deactivated since it was created
+	if from == corev1.OrderStatus_ORDER_STATUS_DRAFT && status == corev1.OrderStatus_ORDER_STATUS_PENDING {
+		if err := o.checkPartners(ctx, order); err != nil {
+			o.logger.Error("failed to change order status", "tenant_id", tenantID, "order_id", orderID, "customer_id", order.CustomerId, "vendor_id", order.VendorId, "error", err)
+			return nil, err
+		}
+	}
+	if err := o.orderHandler.UpdateOrder(ctx, order); err != nil {
+		o.logger.Error("failed to change order status", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return nil, err
+	}
+	o.logger.Info("order status changed", "tenant_id", tenantID, "user_id", userID, "order_id", orderID, "from", from, "to", status)
+	return order, nil
+}
+
+// DeleteOrder deletes a draft order, orders past the draft status are cancelled instead
+func (o *OrderAPI) DeleteOrder(ctx context.Context, tenantID, userID, orderID string) error {
+	if tenantID == "" || userID == "" || orderID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, order_id"))
+		o.logger.Error("failed to delete order", "error", err)
+		return err
+	}
+	if err := o.hasPermission(ctx, tenantID, userID, permissions.OrderDelete); err != nil {
+		o.logger.Error("failed to delete order", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	order, err := o.orderHandler.GetOrderByID(ctx, tenantID, orderID)
+	if err != nil {
+		o.logger.Error("failed to delete order", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return err
+	}
+	if order.Status != corev1.OrderStatus_ORDER_STATUS_DRAFT {
+		err := infra_error.Business(infra_error.BusinessInvalidOrderStatus).WithError(errors.New("only draft orders can be deleted"))
+		o.logger.Error("failed to delete order", "tenant_id", tenantID, "order_id", orderID, "status", order.Status, "error", err)
+		return err
+	}
+	if err := o.orderHandler.DeleteDraftOrder(ctx, tenantID, orderID); err != nil {
+		o.logger.Error("failed to delete order", "tenant_id", tenantID, "order_id", orderID, "error", err)
+		return err
+	}
+	o.logger.Info("order deleted", "tenant_id", tenantID, "user_id", userID, "order_id", orderID)
+	return nil
+}
+
+// mergeOrderUpdate returns a copy of stored with the fields of update named by mask and recomputed totals, at the
+// version update was read at
+func mergeOrderUpdate(stored, update *corev1.Order, mask *fieldmaskpb.FieldMask) (*corev1.Order, error) {
+	merged := proto.Clone(stored).(*corev1.Order)
+	if err := fieldmask.Apply(merged, update, mask); err != nil {
+		return nil, err
+	}
+	merged.Version = update.GetVersion()
+	prepareOrderLines(merged.Lines)
+	calculateOrderTotals(merged)
+	if err := validator_core.ValidateOrder(merged, false); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// transitionOrder moves order to status at now and appends the change to its timeline. A draft is submitted only
+// when it can be fulfilled, see validator_core.ValidateOrderSubmission
+func transitionOrder(order *corev1.Order, status corev1.OrderStatus, userID, notes string, fulfillment *corev1.FulfillmentInfo, now time.Time) error {
+	switch {
+	case order.Status == corev1.OrderStatus_ORDER_STATUS_CANCELLED:
+		return infra_error.Business(infra_error.BusinessOrderCancelled)
+	case model_core.IsFinalOrderStatus(order.Status):
+		return infra_error.Business(infra_error.BusinessOrderCompleted)
+	case !model_core.CanTransitionOrder(order.Status, status) && status == corev1.OrderStatus_ORDER_STATUS_CANCELLED:
+		return infra_error.Business(infra_error.BusinessOrderCannotCancel).WithDetails("status", model_core.OrderStatusName(order.Status))
+	case !model_core.CanTransitionOrder(order.Status, status):
+		return infra_error.Business(infra_error.BusinessInvalidOrderStatus).
+			WithDetails("from", model_core.OrderStatusName(order.Status)).
+			WithDetails("to", model_core.OrderStatusName(status))
+	}
+	if order.Status == corev1.OrderStatus_ORDER_STATUS_DRAFT && status != corev1.OrderStatus_ORDER_STATUS_CANCELLED {
+		if err := validator_core.ValidateOrderSubmission(order); err != nil {
+			return err
+		}
+	}
+
+	switch status {
+	case corev1.OrderStatus_ORDER_STATUS_SHIPPED:
+		if fulfillment != nil {
+			order.Fulfillment = proto.Clone(fulfillment).(*corev1.FulfillmentInfo)
+		}
+		if order.Fulfillment == nil {
+			order.Fulfillment = &corev1.FulfillmentInfo{}
+		}
+		order.Fulfillment.ShippedAt = timestamppb.New(now)
+		order.Fulfillment.DeliveredAt = nil
+	case corev1.OrderStatus_ORDER_STATUS_DELIVERED:
+		if order.Fulfillment == nil {
+			order.Fulfillment = &corev1.FulfillmentInfo{}
+		}
+		order.Fulfillment.DeliveredAt = timestamppb.New(now)
+		setOrderLinesStatus(order.Lines, corev1.OrderLineStatus_ORDER_LINE_STATUS_FULFILLED)
+	case corev1.OrderStatus_ORDER_STATUS_CANCELLED:
+		setOrderLinesStatus(order.Lines, corev1.OrderLineStatus_ORDER_LINE_STATUS_CANCELLED)
+	}
+	order.Status = status
+	order.Timeline = append(order.Timeline, timelineEvent(status, userID, notes, now))
+	return nil
+}
+
+func timelineEvent(status corev1.OrderStatus, userID, notes string, now time.Time) *corev1.OrderTimelineEvent {
+	return &corev1.OrderTimelineEvent{
+		Status:    model_core.OrderStatusName(status),
+		Timestamp: timestamppb.New(now),
+		UserId:    userID,
+		Notes:     notes,
+	}
+}
+
+// prepareOrderLines gives the new lines an id and the pending status
+func prepareOrderLines(lines []*corev1.OrderLine) {
+	for _, line := range lines {
+		if line.Id == "" {
+			line.Id = uuid.New().String()
+		}
+		if line.Status == corev1.OrderLineStatus_ORDER_LINE_STATUS_UNSPECIFIED {
+			line.Status = corev1.OrderLineStatus_ORDER_LINE_STATUS_PENDING
+		}
+	}
+}
+
+func setOrderLinesStatus(lines []*corev1.OrderLine, status corev1.OrderLineStatus) {
+	for _, line := range lines {
+		line.Status = status
+	}
+}
+
+// calculateOrderTotals computes the amounts of the order lines and sums them with the shipping into the order
+// totals, every amount is rounded to cents
+func calculateOrderTotals(order *corev1.Order) {
+	if order.Totals == nil {
+		order.Totals = &corev1.OrderTotals{}
+	}
+	totals := order.Totals
+	totals.Subtotal, totals.Discount, totals.Tax = 0, 0, 0
+	for _, line := range order.Lines {
+		line.Subtotal = roundAmount(float64(line.Quantity) * line.UnitPrice)
+		line.Discount = roundAmount(line.Discount)
+		line.Tax = roundAmount((line.Subtotal - line.Discount) * line.TaxRate)
+		line.Total = roundAmount(line.Subtotal - line.Discount + line.Tax)
+		totals.Subtotal += line.Subtotal
+		totals.Discount += line.Discount
+		totals.Tax += line.Tax
+	}
+	totals.Subtotal = roundAmount(totals.Subtotal)
+	totals.Discount = roundAmount(totals.Discount)
+	totals.Tax = roundAmount(totals.Tax)
+	totals.Shipping = roundAmount(totals.Shipping)
+	totals.Total = roundAmount(totals.Subtotal - totals.Discount + totals.Tax + totals.Shipping)
+	totals.Currency = strings.ToUpper(totals.Currency)
+}
+
+// roundAmount rounds amount to cents, half away from zero
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// createOrder stores order, an order without a number is numbered in the transaction that stores it
+func (o *OrderAPI) createOrder(ctx context.Context, order *corev1.Order, now time.Time) (string, error) {
+	if order.OrderNumber != "" {
+		return o.orderHandler.CreateOrder(ctx, order)
+	}
+	document, ok := orderNumberDocuments[order.OrderType]
+	if !ok {
+		return "", infra_error.Validation(infra_error.ValidationInvalidValue, "OrderType")
+	}
+	var id string
+	err := o.numbering.Assign(ctx, order.TenantId, document, now, func(ctx context.Context, number string) error {
+		order.OrderNumber = number
+		var err error
+		id, err = o.orderHandler.CreateOrder(ctx, order)
+		return err
+	})
+	if err != nil {
+		order.OrderNumber = ""
+	}
+	return id, err
+}
+
+// checkPartners returns an error when the customer or vendor the order references is not an active partner of its
+// tenant
+func (o *OrderAPI) checkPartners(ctx context.Context, order *corev1.Order) error {
+	if order.CustomerId != "" {
+		customer, err := o.partnerHandler.GetCustomerByID(ctx, order.TenantId, order.CustomerId)
+		if err != nil {
+			return err
+		}
+		if err := customerOrderable(customer); err != nil {
+			return err
+		}
+	}
+	if order.VendorId != "" {
+		vendor, err := o.partnerHandler.GetVendorByID(ctx, order.TenantId, order.VendorId)
+		if err != nil {
+			return err
+		}
+		if err := vendorOrderable(vendor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// priceOrder prices the lines of a sales order at now with the active pricing rules of its tenant for the group of
+// its customer and recomputes its totals
+func (o *OrderAPI) priceOrder(ctx context.Context, order *corev1.Order, now time.Time) error {
+	if order.OrderType != corev1.OrderType_ORDER_TYPE_SALES || len(order.Lines) == 0 {
+		return nil
+	}
+	rules, err := o.pricingHandler.ActiveRules(ctx, order.TenantId)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	var customerGroup string
+	if order.CustomerId != "" {
+		customer, err := o.partnerHandler.GetCustomerByID(ctx, order.TenantId, order.CustomerId)
+		if err != nil {
+			return err
+		}
+		customerGroup = customer.CustomerGroup
+	}
+	priceOrderLines(order.Lines, rules, customerGroup, now)
+	calculateOrderTotals(order)
+	return nil
+}
+
+// priceOrderLines prices lines at now with rules for a customer of customerGroup. A price list sets the unit price of
+// a line and a discount rule its discount, a line keeps its own discount when no discount rule applies to it
+func priceOrderLines(lines []*corev1.OrderLine, rules []*corev1.PricingRule, customerGroup string, now time.Time) {
+	requested := make([]*corev1.PriceRequestLine, 0, len(lines))
+	for _, line := range lines {
+		requested = append(requested, &corev1.PriceRequestLine{
+			ProductId: line.ProductId,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+		})
+	}
+	calculation := pricing.Calculate(rules, customerGroup, requested, now)
+	for i, line := range lines {
+		priced := calculation.Lines[i]
+		line.UnitPrice = priced.UnitPrice
+		if priced.Discount > 0 {
+			line.Discount = priced.Discount
+		}
+	}
+}
+
+// repricesOrder reports whether an update of path changes the price of a sales order
+func repricesOrder(path string) bool {
+	return path == "lines" || path == "customer_id"
+}
+
+// changesOrderTotal reports whether an update of path may change the total of an order or its currency
+func changesOrderTotal(path string) bool {
+	return repricesOrder(path) || strings.HasPrefix(path, "totals.")
+}
+
+// convertOrderTotals records the total of the order in the base currency of its tenant at the rate effective at now.
+// Without a rate for the currency of the order the base amounts are cleared
+func (o *OrderAPI) convertOrderTotals(ctx context.Context, order *corev1.Order, now time.Time) error {
+	totals := order.Totals
+	base, rate, baseTotal, err := convertToBase(ctx, o.currencies, order.TenantId, totals.Currency, totals.Total, now)
+	if err != nil {
+		return err
+	}
+	if base == "" {
+		o.logger.Warn("no exchange rate to the base currency, order total not converted", "tenant_id", order.TenantId, "currency", totals.Currency)
+	}
+	totals.BaseCurrency, totals.ExchangeRate, totals.BaseTotal = base, rate, baseTotal
+	return nil
+}
+
+func (o *OrderAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return o.rbac.RequirePermissions(ctx, tenantID, userID, tenantID, permission)
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/core/numbering"
+	infra_error "erp.localhost/internal/infra/error"
+	model_core "erp.localhost/internal/infra/model/core"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestCalculateOrderTotals(t *testing.T) {
+	order := &corev1.Order{
+		Lines: []*corev1.OrderLine{
+			{ProductId: "p1", Quantity: 3, UnitPrice: 9.99, TaxRate: 0.17},
+			{ProductId: "p2", Quantity: 2, UnitPrice: 50, Discount: 10, TaxRate: 0.1},
+		},
+		Totals: &corev1.OrderTotals{Shipping: 5, Currency: "usd", Total: 1000},
+	}
+	calculateOrderTotals(order)
+
+	first, second := order.Lines[0], order.Lines[1]
+	assert.Equal(t, 29.97, first.Subtotal)
+	assert.Equal(t, 5.09, first.Tax)
+	assert.Equal(t, 35.06, first.Total)
+	assert.Equal(t, 100.0, second.Subtotal)
+	assert.Equal(t, 9.0, second.Tax)
+	assert.Equal(t, 99.0, second.Total)
+
+	assert.Equal(t, 129.97, order.Totals.Subtotal)
+	assert.Equal(t, 10.0, order.Totals.Discount)
+	assert.Equal(t, 14.09, order.Totals.Tax)
+	assert.Equal(t, 139.06, order.Totals.Total)
+	assert.Equal(t, "USD", order.Totals.Currency)
+}
+
+func TestCalculateOrderTotals_NoLines(t *testing.T) {
+	order := &corev1.Order{}
+	calculateOrderTotals(order)
+	require.NotNil(t, order.Totals)
+	assert.Zero(t, order.Totals.Total)
+}
+
+func TestTransitionOrder(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	submittable := func(status corev1.OrderStatus) *corev1.Order {
+		return &corev1.Order{
+			Status:     status,
+			OrderType:  corev1.OrderType_ORDER_TYPE_SALES,
+			CustomerId: "customer-1",
+			Lines:      []*corev1.OrderLine{{ProductId: "p1", Quantity: 1, Status: corev1.OrderLineStatus_ORDER_LINE_STATUS_PENDING}},
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		order   *corev1.Order
+		status  corev1.OrderStatus
+		err     infra_error.ErrorDef
+		wantErr bool
+	}{
+		{name: "submit draft", order: submittable(corev1.OrderStatus_ORDER_STATUS_DRAFT), status: corev1.OrderStatus_ORDER_STATUS_PENDING},
+		{name: "ship confirmed", order: submittable(corev1.OrderStatus_ORDER_STATUS_CONFIRMED), status: corev1.OrderStatus_ORDER_STATUS_SHIPPED},
+		{name: "cancel pending", order: submittable(corev1.OrderStatus_ORDER_STATUS_PENDING), status: corev1.OrderStatus_ORDER_STATUS_CANCELLED},
+		{name: "cancel empty draft", order: &corev1.Order{Status: corev1.OrderStatus_ORDER_STATUS_DRAFT}, status: corev1.OrderStatus_ORDER_STATUS_CANCELLED},
+		{name: "submit draft without lines", order: &corev1.Order{Status: corev1.OrderStatus_ORDER_STATUS_DRAFT, OrderType: corev1.OrderType_ORDER_TYPE_SALES, CustomerId: "c"}, status: corev1.OrderStatus_ORDER_STATUS_PENDING, err: infra_error.ValidationRequiredFields, wantErr: true},
+		{name: "submit sales draft without customer", order: &corev1.Order{Status: corev1.OrderStatus_ORDER_STATUS_DRAFT, OrderType: corev1.OrderType_ORDER_TYPE_SALES, Lines: []*corev1.OrderLine{{}}}, status: corev1.OrderStatus_ORDER_STATUS_PENDING, err: infra_error.ValidationRequiredFields, wantErr: true},
+		{name: "skip confirmation", order: submittable(corev1.OrderStatus_ORDER_STATUS_PENDING), status: corev1.OrderStatus_ORDER_STATUS_SHIPPED, err: infra_error.BusinessInvalidOrderStatus, wantErr: true},
+		{name: "cancel shipped", order: submittable(corev1.OrderStatus_ORDER_STATUS_SHIPPED), status: corev1.OrderStatus_ORDER_STATUS_CANCELLED, err: infra_error.BusinessOrderCannotCancel, wantErr: true},
+		{name: "change cancelled", order: submittable(corev1.OrderStatus_ORDER_STATUS_CANCELLED), status: corev1.OrderStatus_ORDER_STATUS_PENDING, err: infra_error.BusinessOrderCancelled, wantErr: true},
+		{name: "change delivered", order: submittable(corev1.OrderStatus_ORDER_STATUS_DELIVERED), status: corev1.OrderStatus_ORDER_STATUS_CANCELLED, err: infra_error.BusinessOrderCompleted, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from := tc.order.Status
+			err := transitionOrder(tc.order, tc.status, "user-1", "note", nil, now)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, infra_error.New(tc.err)), err)
+				assert.Equal(t, from, tc.order.Status)
+				assert.Empty(t, tc.order.Timeline)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, tc.order.Status)
+			require.Len(t, tc.order.Timeline, 1)
+			event := tc.order.Timeline[0]
+			assert.Equal(t, model_core.OrderStatusName(tc.status), event.GetStatus())
+			assert.Equal(t, "user-1", event.GetUserId())
+			assert.Equal(t, "note", event.GetNotes())
+			assert.True(t, now.Equal(event.GetTimestamp().AsTime()))
+		})
+	}
+}
+
+func TestTransitionOrder_Fulfillment(t *testing.T) {
+	shippedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	order := &corev1.Order{
+		Status: corev1.OrderStatus_ORDER_STATUS_CONFIRMED,
+		Lines:  []*corev1.OrderLine{{ProductId: "p1", Quantity: 1, Status: corev1.OrderLineStatus_ORDER_LINE_STATUS_PENDING}},
+	}
+	fulfillment := &corev1.FulfillmentInfo{Carrier: "UPS", TrackingNumber: "1Z999"}
+
+	require.NoError(t, transitionOrder(order, corev1.OrderStatus_ORDER_STATUS_SHIPPED, "user-1", "", fulfillment, shippedAt))
+	assert.Equal(t, "UPS", order.GetFulfillment().GetCarrier())
+	assert.True(t, shippedAt.Equal(order.GetFulfillment().GetShippedAt().AsTime()))
+	assert.Equal(t, corev1.OrderLineStatus_ORDER_LINE_STATUS_PENDING, order.Lines[0].Status)
+
+	deliveredAt := shippedAt.Add(48 * time.Hour)
+	require.NoError(t, transitionOrder(order, corev1.OrderStatus_ORDER_STATUS_DELIVERED, "user-1", "", nil, deliveredAt))
+	assert.Equal(t, "1Z999", order.GetFulfillment().GetTrackingNumber())
+	assert.True(t, deliveredAt.Equal(order.GetFulfillment().GetDeliveredAt().AsTime()))
+	assert.Equal(t, corev1.OrderLineStatus_ORDER_LINE_STATUS_FULFILLED, order.Lines[0].Status)
+	assert.Len(t, order.Timeline, 2)
+}
+
+func TestMergeOrderUpdate(t *testing.T) {
+	stored := &corev1.Order{
+		Id:          "order-1",
+		TenantId:    "tenant-1",
+		OrderNumber: "SO-1",
+		OrderType:   corev1.OrderType_ORDER_TYPE_SALES,
+		Status:      corev1.OrderStatus_ORDER_STATUS_DRAFT,
+		CreatedBy:   "user-1",
+		Notes:       "keep",
+		Totals:      &corev1.OrderTotals{Currency: "USD"},
+		Version:     4,
+	}
+	update := &corev1.Order{
+		Lines:   []*corev1.OrderLine{{ProductId: "p1", Quantity: 2, UnitPrice: 10}},
+		Totals:  &corev1.OrderTotals{Shipping: 5, Total: 1},
+		Notes:   "ignored",
+		Version: 3,
+	}
+
+	merged, err := mergeOrderUpdate(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"lines", "totals.shipping"}})
+	require.NoError(t, err)
+	assert.Equal(t, "keep", merged.Notes)
+	assert.Equal(t, int64(3), merged.Version)
+	require.Len(t, merged.Lines, 1)
+	assert.NotEmpty(t, merged.Lines[0].Id)
+	assert.Equal(t, corev1.OrderLineStatus_ORDER_LINE_STATUS_PENDING, merged.Lines[0].Status)
+	assert.Equal(t, 25.0, merged.Totals.Total)
+	assert.Equal(t, "USD", merged.Totals.Currency)
+	// The stored order is not modified
+	assert.Empty(t, stored.Lines)
+
+	_, err = mergeOrderUpdate(stored, &corev1.Order{Lines: []*corev1.OrderLine{{ProductId: "p1", Quantity: 1, UnitPrice: 5, Discount: 6}}}, &fieldmaskpb.FieldMask{Paths: []string{"lines"}})
+	assert.Error(t, err)
+}
+
+func TestOrderNumberDocuments(t *testing.T) {
+	// Every order type is numbered from its own sequence
+	documents := map[numbering.Document]bool{}
+	for value := range corev1.OrderType_name {
+		orderType := corev1.OrderType(value)
+		if orderType == corev1.OrderType_ORDER_TYPE_UNSPECIFIED {
+			continue
+		}
+		document, ok := orderNumberDocuments[orderType]
+		require.True(t, ok, orderType)
+		documents[document] = true
+	}
+	assert.Len(t, documents, len(corev1.OrderType_name)-1)
+}
+
+func TestPriceOrderLines(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rules := []*corev1.PricingRule{
+		{Name: "wholesale", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_PRICE_LIST, Active: true, ProductIds: []string{"p1"}, CustomerGroup: "wholesale", UnitPrice: 8},
+		{Name: "volume", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_QUANTITY_TIERS, Active: true, Tiers: []*corev1.PriceTier{{MinQuantity: 10, DiscountPercent: 0.1}}},
+	}
+	lines := []*corev1.OrderLine{
+		{ProductId: "p1", Quantity: 10, UnitPrice: 12, Discount: 1},
+		{ProductId: "p2", Quantity: 2, UnitPrice: 3, Discount: 0.5},
+	}
+
+	priceOrderLines(lines, rules, "wholesale", now)
+	assert.Equal(t, 8.0, lines[0].UnitPrice)
+	assert.Equal(t, 8.0, lines[0].Discount)
+	// No rule applies, the line keeps its price and discount
+	assert.Equal(t, 3.0, lines[1].UnitPrice)
+	assert.Equal(t, 0.5, lines[1].Discount)
+}
@@ -0,0 +1,428 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"erp.localhost/internal/core/handler"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	"erp.localhost/internal/infra/model/fieldmask"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// customerUpdateMaskFields are the fields of a customer an update mask may name
+var customerUpdateMaskFields = []string{"type", "name", "email", "phone", "company", "addresses", "contacts", "payment_terms", "credit_limit", "status", "customer_group"}
+
+// vendorUpdateMaskFields are the fields of a vendor an update mask may name
+var vendorUpdateMaskFields = []string{"name", "code", "contact", "address", "payment_terms", "rating", "status", "products_supplied", "metadata", "contacts"}
+
+// PartnerAPI manages the business partners of the tenants: the customers sales orders are placed by and the vendors
+// purchase orders are placed with. Emails and tax IDs are unique within a tenant, partners referenced by orders are
+// deactivated instead of deleted
+type PartnerAPI struct {
+	logger         logger.Logger
+	partnerHandler *handler.PartnerHandler
+	orderHandler   *handler.OrderHandler
+	rbac           client.RBACClient
+}
+
+func NewPartnerAPI(rbac client.RBACClient, logger logger.Logger) (*PartnerAPI, error) {
+	if rbac == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "rbac")
+	}
+	partnerHandler, err := handler.NewPartnerHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new partner handler", "error", err)
+		return nil, err
+	}
+	orderHandler, err := handler.NewOrderHandler(logger)
+	if err != nil {
+		logger.Error("failed to create new order handler", "error", err)
+		return nil, err
+	}
+	return &PartnerAPI{
+		logger:         logger,
+		partnerHandler: partnerHandler,
+		orderHandler:   orderHandler,
+		rbac:           rbac,
+	}, nil
+}
+
+/* Customers */
+
+// CreateCustomer creates a customer of the tenant, it is active unless a status is given
+func (p *PartnerAPI) CreateCustomer(ctx context.Context, tenantID, userID string, customer *corev1.Customer) (*corev1.Customer, error) {
+	if tenantID == "" || userID == "" || customer == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, customer"))
+		p.logger.Error("failed to create customer", "error", err)
+		return nil, err
+	}
+	customer.Id = ""
+	customer.TenantId = tenantID
+	customer.CreatedBy = userID
+	customer.LifetimeValue, customer.TotalOrders, customer.LastOrderDate = 0, 0, nil
+	if customer.Status == corev1.CustomerStatus_CUSTOMER_STATUS_UNSPECIFIED {
+		customer.Status = corev1.CustomerStatus_CUSTOMER_STATUS_ACTIVE
+	}
+	normalizeCustomer(customer)
+	if err := validator_core.ValidateCustomer(customer, true); err != nil {
+		p.logger.Error("failed to create customer", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.CustomerCreate); err != nil {
+		p.logger.Error("failed to create customer", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	id, err := p.partnerHandler.CreateCustomer(ctx, customer)
+	if err != nil {
+		p.logger.Error("failed to create customer", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	customer.Id = id
+	p.logger.Info("customer created", "tenant_id", tenantID, "user_id", userID, "customer_id", id)
+	return customer, nil
+}
+
+func (p *PartnerAPI) GetCustomer(ctx context.Context, tenantID, userID, customerID string) (*corev1.Customer, error) {
+	if tenantID == "" || userID == "" || customerID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, customer_id"))
+		p.logger.Error("failed to get customer", "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.CustomerRead); err != nil {
+		p.logger.Error("failed to get customer", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	customer, err := p.partnerHandler.GetCustomerByID(ctx, tenantID, customerID)
+	if err != nil {
+		p.logger.Error("failed to get customer", "tenant_id", tenantID, "customer_id", customerID, "error", err)
+		return nil, err
+	}
+	return customer, nil
+}
+
+// SearchCustomers returns a page of the tenant customers matching search, ordered by name
+func (p *PartnerAPI) SearchCustomers(ctx context.Context, tenantID, userID string, search *corev1.CustomerSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Customer, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		p.logger.Error("failed to search customers", "error", err)
+		return nil, nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.CustomerRead); err != nil {
+		p.logger.Error("failed to search customers", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+	customers, page, err := p.partnerHandler.SearchCustomers(ctx, tenantID, search, pagination)
+	if err != nil {
+		p.logger.Error("failed to search customers", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	return customers, page, nil
+}
+
+// UpdateCustomer replaces the editable fields of the stored customer with those of customer, or when mask names
+// fields only changes those fields. The update is rejected when the customer changed since it was read at the
+// version of customer
+func (p *PartnerAPI) UpdateCustomer(ctx context.Context, tenantID, userID string, customer *corev1.Customer, mask *fieldmaskpb.FieldMask) (*corev1.Customer, error) {
+	if tenantID == "" || userID == "" || customer.GetId() == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, customer.id"))
+		p.logger.Error("failed to update customer", "error", err)
+		return nil, err
+	}
+	if fieldmask.IsEmpty(mask) {
+		mask = &fieldmaskpb.FieldMask{Paths: customerUpdateMaskFields}
+	} else if err := fieldmask.CheckPaths(mask, customerUpdateMaskFields...); err != nil {
+		p.logger.Error("failed to update customer", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.CustomerUpdate); err != nil {
+		p.logger.Error("failed to update customer", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	existing, err := p.partnerHandler.GetCustomerByID(ctx, tenantID, customer.Id)
+	if err != nil {
+		p.logger.Error("failed to update customer", "tenant_id", tenantID, "customer_id", customer.Id, "error", err)
+		return nil, err
+	}
+	merged := proto.Clone(existing).(*corev1.Customer)
+	if err := fieldmask.Apply(merged, customer, mask); err != nil {
+		p.logger.Error("failed to update customer", "tenant_id", tenantID, "customer_id", customer.Id, "error", err)
+		return nil, err
+	}
+	merged.Version = customer.GetVersion()
+	normalizeCustomer(merged)
+	if err := p.partnerHandler.UpdateCustomer(ctx, merged); err != nil {
+		p.logger.Error("failed to update customer", "tenant_id", tenantID, "customer_id", customer.Id, "error", err)
+		return nil, err
+	}
+	p.logger.Info("customer updated", "tenant_id", tenantID, "user_id", userID, "customer_id", customer.Id)
+	return merged, nil
+}
+
+// DeleteCustomer deletes a customer without orders and deactivates one with orders, it reports whether the customer
+// was deleted
+func (p *PartnerAPI) DeleteCustomer(ctx context.Context, tenantID, userID, customerID string) (bool, error) {
+	if tenantID == "" || userID == "" || customerID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, customer_id"))
+		p.logger.Error("failed to delete customer", "error", err)
+		return false, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.CustomerDelete); err != nil {
+		p.logger.Error("failed to delete customer", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, err
+	}
+	customer, err := p.partnerHandler.GetCustomerByID(ctx, tenantID, customerID)
+	if err != nil {
+		p.logger.Error("failed to delete customer", "tenant_id", tenantID, "customer_id", customerID, "error", err)
+		return false, err
+	}
+	referenced, err := p.orderHandler.HasPartnerOrders(ctx, tenantID, "customer_id", customerID)
+	if err != nil {
+		p.logger.Error("failed to delete customer", "tenant_id", tenantID, "customer_id", customerID, "error", err)
+		return false, err
+	}
+	if referenced {
+		if customer.Status == corev1.CustomerStatus_CUSTOMER_STATUS_ACTIVE {
+			customer.Status = corev1.CustomerStatus_CUSTOMER_STATUS_INACTIVE
+		}
+		if err := p.partnerHandler.UpdateCustomer(ctx, customer); err != nil {
+			p.logger.Error("failed to deactivate customer", "tenant_id", tenantID, "customer_id", customerID, "error", err)
+			return false, err
+		}
+		p.logger.Info("customer with orders deactivated", "tenant_id", tenantID, "user_id", userID, "customer_id", customerID)
+		return false, nil
+	}
+	if err := p.partnerHandler.DeleteCustomer(ctx, tenantID, customerID); err != nil {
+		p.logger.Error("failed to delete customer", "tenant_id", tenantID, "customer_id", customerID, "error", err)
+		return false, err
+	}
+	p.logger.Info("customer deleted", "tenant_id", tenantID, "user_id", userID, "customer_id", customerID)
+	return true, nil
+}
+
+/* Vendors */
+
+// CreateVendor creates a vendor of the tenant, it is active unless a status is given
+func (p *PartnerAPI) CreateVendor(ctx context.Context, tenantID, userID string, vendor *corev1.Vendor) (*corev1.Vendor, error) {
+	if tenantID == "" || userID == "" || vendor == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, vendor"))
+		p.logger.Error("failed to create vendor", "error", err)
+		return nil, err
+	}
+	vendor.Id = ""
+	vendor.TenantId = tenantID
+	vendor.CreatedBy = userID
+	if vendor.Status == corev1.VendorStatus_VENDOR_STATUS_UNSPECIFIED {
+		vendor.Status = corev1.VendorStatus_VENDOR_STATUS_ACTIVE
+	}
+	normalizeVendor(vendor)
+	if err := validator_core.ValidateVendor(vendor, true); err != nil {
+		p.logger.Error("failed to create vendor", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.VendorCreate); err != nil {
+		p.logger.Error("failed to create vendor", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	id, err := p.partnerHandler.CreateVendor(ctx, vendor)
+	if err != nil {
+		p.logger.Error("failed to create vendor", "tenant_id", tenantID, "code", vendor.Code, "error", err)
+		return nil, err
+	}
+	vendor.Id = id
+	p.logger.Info("vendor created", "tenant_id", tenantID, "user_id", userID, "vendor_id", id, "code", vendor.Code)
+	return vendor, nil
+}
+
+func (p *PartnerAPI) GetVendor(ctx context.Context, tenantID, userID, vendorID string) (*corev1.Vendor, error) {
+	if tenantID == "" || userID == "" || vendorID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, vendor_id"))
+		p.logger.Error("failed to get vendor", "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.VendorRead); err != nil {
+		p.logger.Error("failed to get vendor", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	vendor, err := p.partnerHandler.GetVendorByID(ctx, tenantID, vendorID)
+	if err != nil {
+		p.logger.Error("failed to get vendor", "tenant_id", tenantID, "vendor_id", vendorID, "error", err)
+		return nil, err
+	}
+	return vendor, nil
+}
+
+// SearchVendors returns a page of the tenant vendors matching search, ordered by name
+func (p *PartnerAPI) SearchVendors(ctx context.Context, tenantID, userID string, search *corev1.VendorSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Vendor, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		p.logger.Error("failed to search vendors", "error", err)
+		return nil, nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.VendorRead); err != nil {
+		p.logger.Error("failed to search vendors", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, nil, err
+	}
+	vendors, page, err := p.partnerHandler.SearchVendors(ctx, tenantID, search, pagination)
+	if err != nil {
+		p.logger.Error("failed to search vendors", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+	return vendors, page, nil
+}
+
+// UpdateVendor replaces the editable fields of the stored vendor with those of vendor, or when mask names fields
+// only changes those fields. The update is rejected when the vendor changed since it was read at the version of
+// vendor
+func (p *PartnerAPI) UpdateVendor(ctx context.Context, tenantID, userID string, vendor *corev1.Vendor, mask *fieldmaskpb.FieldMask) (*corev1.Vendor, error) {
+	if tenantID == "" || userID == "" || vendor.GetId() == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, vendor.id"))
+		p.logger.Error("failed to update vendor", "error", err)
+		return nil, err
+	}
+	if fieldmask.IsEmpty(mask) {
+		mask = &fieldmaskpb.FieldMask{Paths: vendorUpdateMaskFields}
+	} else if err := fieldmask.CheckPaths(mask, vendorUpdateMaskFields...); err != nil {
+		p.logger.Error("failed to update vendor", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.VendorUpdate); err != nil {
+		p.logger.Error("failed to update vendor", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	existing, err := p.partnerHandler.GetVendorByID(ctx, tenantID, vendor.Id)
+	if err != nil {
+		p.logger.Error("failed to update vendor", "tenant_id", tenantID, "vendor_id", vendor.Id, "error", err)
+		return nil, err
+	}
+	merged := proto.Clone(existing).(*corev1.Vendor)
+	if err := fieldmask.Apply(merged, vendor, mask); err != nil {
+		p.logger.Error("failed to update vendor", "tenant_id", tenantID, "vendor_id", vendor.Id, "error", err)
+		return nil, err
+	}
+	merged.Version = vendor.GetVersion()
+	normalizeVendor(merged)
+	if err := p.partnerHandler.UpdateVendor(ctx, merged); err != nil {
+		p.logger.Error("failed to update vendor", "tenant_id", tenantID, "vendor_id", vendor.Id, "error", err)
+		return nil, err
+	}
+	p.logger.Info("vendor updated", "tenant_id", tenantID, "user_id", userID, "vendor_id", vendor.Id)
+	return merged, nil
+}
+
+// DeleteVendor deletes a vendor without orders and deactivates one with orders, it reports whether the vendor was
+// deleted
+func (p *PartnerAPI) DeleteVendor(ctx context.Context, tenantID, userID, vendorID string) (bool, error) {
+	if tenantID == "" || userID == "" || vendorID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, vendor_id"))
+		p.logger.Error("failed to delete vendor", "error", err)
+		return false, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.VendorDelete); err != nil {
+		p.logger.Error("failed to delete vendor", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return false, err
+	}
+	vendor, err := p.partnerHandler.GetVendorByID(ctx, tenantID, vendorID)
+	if err != nil {
+		p.logger.Error("failed to delete vendor", "tenant_id", tenantID, "vendor_id", vendorID, "error", err)
+		return false, err
+	}
+	referenced, err := p.orderHandler.HasPartnerOrders(ctx, tenantID, "vendor_id", vendorID)
+	if err != nil {
+		p.logger.Error("failed to delete vendor", "tenant_id", tenantID, "vendor_id", vendorID, "error", err)
+		return false, err
+	}
+	if referenced {
+		vendor.Status = corev1.VendorStatus_VENDOR_STATUS_INACTIVE
+		if err := p.partnerHandler.UpdateVendor(ctx, vendor); err != nil {
+			p.logger.Error("failed to deactivate vendor", "tenant_id", tenantID, "vendor_id", vendorID, "error", err)
+			return false, err
+		}
+		p.logger.Info("vendor with orders deactivated", "tenant_id", tenantID, "user_id", userID, "vendor_id", vendorID)
+		return false, nil
+	}
+	if err := p.partnerHandler.DeleteVendor(ctx, tenantID, vendorID); err != nil {
+		p.logger.Error("failed to delete vendor", "tenant_id", tenantID, "vendor_id", vendorID, "error", err)
+		return false, err
+	}
+	p.logger.Info("vendor deleted", "tenant_id", tenantID, "user_id", userID, "vendor_id", vendorID)
+	return true, nil
+}
+
+/* Normalization, so duplicates are found however they were typed */
+
+func normalizeCustomer(customer *corev1.Customer) {
+	customer.Email = normalizeEmail(customer.Email)
+	if customer.Company != nil {
+		customer.Company.TaxId = normalizeTaxID(customer.Company.TaxId)
+	}
+	for _, address := range customer.Addresses {
+		if address.AddressId == "" {
+			address.AddressId = uuid.New().String()
+		}
+	}
+	normalizeContacts(customer.Contacts)
+}
+
+func normalizeVendor(vendor *corev1.Vendor) {
+	vendor.Code = strings.ToUpper(strings.TrimSpace(vendor.Code))
+	if vendor.Contact != nil {
+		vendor.Contact.Email = normalizeEmail(vendor.Contact.Email)
+	}
+	if vendor.Metadata != nil {
+		vendor.Metadata.TaxId = normalizeTaxID(vendor.Metadata.TaxId)
+	}
+	normalizeContacts(vendor.Contacts)
+}
+
+func normalizeContacts(contacts []*corev1.Contact) {
+	for _, contact := range contacts {
+		contact.Email = normalizeEmail(contact.Email)
+	}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizeTaxID drops the separators of a tax ID, e.g. 12-345 678 and 12345678 are the same ID
+func normalizeTaxID(taxID string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '/':
+			return -1
+		}
+		return r
+	}, taxID))
+}
+
+/* Order references */
+
+// customerOrderable returns why orders cannot be placed by customer, nil when they can
+func customerOrderable(customer *corev1.Customer) error {
+	if customer.GetStatus() != corev1.CustomerStatus_CUSTOMER_STATUS_ACTIVE {
+		return infra_error.Business(infra_error.BusinessCustomerInactive).WithDetails("customer_id", customer.GetId())
+	}
+	return nil
+}
+
+// vendorOrderable returns why orders cannot be placed with vendor, nil when they can
+func vendorOrderable(vendor *corev1.Vendor) error {
+	if vendor.GetStatus() != corev1.VendorStatus_VENDOR_STATUS_ACTIVE {
+		return infra_error.Business(infra_error.BusinessVendorInactive).WithDetails("vendor_id", vendor.GetId())
+	}
+	return nil
+}
+
+func (p *PartnerAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return p.rbac.RequirePermissions(ctx, tenantID, userID, tenantID, permission)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTaxID(t *testing.T) {
+	testCases := []struct {
+		taxID    string
+		expected string
+	}{
+		{taxID: "12-345 678", expected: "12345678"},
+		{taxID: "de 123.456.789", expected: "DE123456789"},
+		{taxID: "12/345", expected: "12345"},
+		{taxID: "", expected: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.taxID, func(t *testing.T) {
+			assert.Equal(t, tc.expected, normalizeTaxID(tc.taxID))
+		})
+	}
+}
+
+func TestNormalizeCustomer(t *testing.T) {
+	customer := &corev1.Customer{
+		Email:     "  Jane@Example.COM ",
+		Company:   &corev1.CompanyInfo{TaxId: "us-12 34"},
+		Addresses: []*corev1.CustomerAddress{{City: "Springfield"}, {AddressId: "kept"}},
+		Contacts:  []*corev1.Contact{{Name: "Bob", Email: "Bob@Example.com"}},
+	}
+	normalizeCustomer(customer)
+
+	assert.Equal(t, "jane@example.com", customer.Email)
+	assert.Equal(t, "US1234", customer.Company.TaxId)
+	assert.NotEmpty(t, customer.Addresses[0].AddressId)
+	assert.Equal(t, "kept", customer.Addresses[1].AddressId)
+	assert.Equal(t, "bob@example.com", customer.Contacts[0].Email)
+}
+
+func TestNormalizeVendor(t *testing.T) {
+	vendor := &corev1.Vendor{
+		Code:     " acme ",
+		Contact:  &corev1.VendorContact{Email: "Sales@Acme.com"},
+		Metadata: &corev1.VendorMetadata{TaxId: "99-1"},
+	}
+	normalizeVendor(vendor)
+
+	assert.Equal(t, "ACME", vendor.Code)
+	assert.Equal(t, "sales@acme.com", vendor.Contact.Email)
+	assert.Equal(t, "991", vendor.Metadata.TaxId)
+}
+
+func TestPartnerOrderable(t *testing.T) {
+	assert.NoError(t, customerOrderable(&corev1.Customer{Status: corev1.CustomerStatus_CUSTOMER_STATUS_ACTIVE}))
+	for _, status := range []corev1.CustomerStatus{corev1.CustomerStatus_CUSTOMER_STATUS_INACTIVE, corev1.CustomerStatus_CUSTOMER_STATUS_BLOCKED} {
+		err := customerOrderable(&corev1.Customer{Id: "customer-1", Status: status})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, infra_error.New(infra_error.BusinessCustomerInactive)), err)
+	}
+
+	assert.NoError(t, vendorOrderable(&corev1.Vendor{Status: corev1.VendorStatus_VENDOR_STATUS_ACTIVE}))
+	for _, status := range []corev1.VendorStatus{corev1.VendorStatus_VENDOR_STATUS_INACTIVE, corev1.VendorStatus_VENDOR_STATUS_PENDING_APPROVAL} {
+		err := vendorOrderable(&corev1.Vendor{Id: "vendor-1", Status: status})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, infra_error.New(infra_error.BusinessVendorInactive)), err)
+	}
+}
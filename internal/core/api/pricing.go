@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/core/handler"
+	"erp.localhost/internal/core/pricing"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	"erp.localhost/internal/infra/model/fieldmask"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// pricingRuleUpdateMaskFields are the fields of a pricing rule an update mask may name, the type of a rule is fixed
+var pricingRuleUpdateMaskFields = []string{"name", "product_ids", "customer_group", "priority", "active", "unit_price", "tiers", "discount_percent", "discount_amount", "starts_at", "ends_at"}
+
+// PricingAPI manages the pricing rules of the tenants and prices lines with them, see package pricing
+type PricingAPI struct {
+	logger         logger.Logger
+	pricingHandler *handler.PricingHandler
+	partnerHandler *handler.PartnerHandler
+	rbac           client.RBACClient
+}
+
+func NewPricingAPI(rbac client.RBACClient, logger logger.Logger) (*PricingAPI, error) {
+	if rbac == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "rbac")
+	}
+	pricingHandler, err := handler.NewPricingHandler(logger)
+	if err != nil {
+		logger.Error("failed to create pricing handler", "error", err)
+		return nil, err
+	}
+	partnerHandler, err := handler.NewPartnerHandler(logger)
+	if err != nil {
+		logger.Error("failed to create partner handler", "error", err)
+		return nil, err
+	}
+	return &PricingAPI{
+		logger:         logger,
+		pricingHandler: pricingHandler,
+		partnerHandler: partnerHandler,
+		rbac:           rbac,
+	}, nil
+}
+
+// CreatePricingRule creates a pricing rule of the tenant, it applies once active
+func (p *PricingAPI) CreatePricingRule(ctx context.Context, tenantID, userID string, rule *corev1.PricingRule) (*corev1.PricingRule, error) {
+	if tenantID == "" || userID == "" || rule == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, rule"))
+		p.logger.Error("failed to create pricing rule", "error", err)
+		return nil, err
+	}
+	rule.Id = ""
+	rule.TenantId = tenantID
+	rule.CreatedBy = userID
+	if err := validator_core.ValidatePricingRule(rule, true); err != nil {
+		p.logger.Error("failed to create pricing rule", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.PricingCreate); err != nil {
+		p.logger.Error("failed to create pricing rule", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	id, err := p.pricingHandler.CreateRule(ctx, rule)
+	if err != nil {
+		p.logger.Error("failed to create pricing rule", "tenant_id", tenantID, "name", rule.Name, "error", err)
+		return nil, err
+	}
+	rule.Id = id
+	p.logger.Info("pricing rule created", "tenant_id", tenantID, "user_id", userID, "rule_id", id, "type", rule.Type)
+	return rule, nil
+}
+
+func (p *PricingAPI) GetPricingRule(ctx context.Context, tenantID, userID, ruleID string) (*corev1.PricingRule, error) {
+	if tenantID == "" || userID == "" || ruleID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, rule_id"))
+		p.logger.Error("failed to get pricing rule", "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.PricingRead); err != nil {
+		p.logger.Error("failed to get pricing rule", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	rule, err := p.pricingHandler.GetRuleByID(ctx, tenantID, ruleID)
+	if err != nil {
+		p.logger.Error("failed to get pricing rule", "tenant_id", tenantID, "rule_id", ruleID, "error", err)
+		return nil, err
+	}
+	return rule, nil
+}
+
+// ListPricingRules returns the rules of the tenant, of ruleType unless it is unspecified, in the order they are
+// evaluated
+func (p *PricingAPI) ListPricingRules(ctx context.Context, tenantID, userID string, ruleType corev1.PricingRuleType) ([]*corev1.PricingRule, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		p.logger.Error("failed to list pricing rules", "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.PricingRead); err != nil {
+		p.logger.Error("failed to list pricing rules", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	rules, err := p.pricingHandler.ListRules(ctx, tenantID, ruleType)
+	if err != nil {
+		p.logger.Error("failed to list pricing rules", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	pricing.SortRules(rules)
+	return rules, nil
+}
+
+// UpdatePricingRule replaces the editable fields of the stored rule with those of rule, or when mask names fields
+// only changes those fields. The update is rejected when the rule changed since it was read at the version of rule
+func (p *PricingAPI) UpdatePricingRule(ctx context.Context, tenantID, userID string, rule *corev1.PricingRule, mask *fieldmaskpb.FieldMask) (*corev1.PricingRule, error) {
+	if tenantID == "" || userID == "" || rule.GetId() == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, rule.id"))
+		p.logger.Error("failed to update pricing rule", "error", err)
+		return nil, err
+	}
+	if fieldmask.IsEmpty(mask) {
+		mask = &fieldmaskpb.FieldMask{Paths: pricingRuleUpdateMaskFields}
+	} else if err := fieldmask.CheckPaths(mask, pricingRuleUpdateMaskFields...); err != nil {
+		p.logger.Error("failed to update pricing rule", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.PricingUpdate); err != nil {
+		p.logger.Error("failed to update pricing rule", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	existing, err := p.pricingHandler.GetRuleByID(ctx, tenantID, rule.Id)
+	if err != nil {
+		p.logger.Error("failed to update pricing rule", "tenant_id", tenantID, "rule_id", rule.Id, "error", err)
+		return nil, err
+	}
+	merged, err := mergePricingRuleUpdate(existing, rule, mask)
+	if err != nil {
+		p.logger.Error("failed to update pricing rule", "tenant_id", tenantID, "rule_id", rule.Id, "error", err)
+		return nil, err
+	}
+	if err := p.pricingHandler.UpdateRule(ctx, merged); err != nil {
+		p.logger.Error("failed to update pricing rule", "tenant_id", tenantID, "rule_id", rule.Id, "error", err)
+		return nil, err
+	}
+	p.logger.Info("pricing rule updated", "tenant_id", tenantID, "user_id", userID, "rule_id", rule.Id)
+	return merged, nil
+}
+
+func (p *PricingAPI) DeletePricingRule(ctx context.Context, tenantID, userID, ruleID string) error {
+	if tenantID == "" || userID == "" || ruleID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, rule_id"))
+		p.logger.Error("failed to delete pricing rule", "error", err)
+		return err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.PricingDelete); err != nil {
+		p.logger.Error("failed to delete pricing rule", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return err
+	}
+	if err := p.pricingHandler.DeleteRule(ctx, tenantID, ruleID); err != nil {
+		p.logger.Error("failed to delete pricing rule", "tenant_id", tenantID, "rule_id", ruleID, "error", err)
+		return err
+	}
+	p.logger.Info("pricing rule deleted", "tenant_id", tenantID, "user_id", userID, "rule_id", ruleID)
+	return nil
+}
+
+// CalculatePrice prices lines at at with the active rules of the tenant for the group of the customer, or with the
+// rules of every customer when customerID is empty. The calculation traces every rule evaluated for each line
+func (p *PricingAPI) CalculatePrice(ctx context.Context, tenantID, userID, customerID string, lines []*corev1.PriceRequestLine, at time.Time) (*corev1.PriceCalculation, error) {
+	if tenantID == "" || userID == "" || len(lines) == 0 {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, lines"))
+		p.logger.Error("failed to calculate price", "error", err)
+		return nil, err
+	}
+	if err := p.hasPermission(ctx, tenantID, userID, permissions.PricingRead); err != nil {
+		p.logger.Error("failed to calculate price", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	var customerGroup string
+	if customerID != "" {
+		customer, err := p.partnerHandler.GetCustomerByID(ctx, tenantID, customerID)
+		if err != nil {
+			p.logger.Error("failed to calculate price", "tenant_id", tenantID, "customer_id", customerID, "error", err)
+			return nil, err
+		}
+		customerGroup = customer.CustomerGroup
+	}
+	rules, err := p.pricingHandler.ActiveRules(ctx, tenantID)
+	if err != nil {
+		p.logger.Error("failed to calculate price", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	return pricing.Calculate(rules, customerGroup, lines, at), nil
+}
+
+// mergePricingRuleUpdate returns a copy of stored with the fields of update named by mask, at the version update was
+// read at
+func mergePricingRuleUpdate(stored, update *corev1.PricingRule, mask *fieldmaskpb.FieldMask) (*corev1.PricingRule, error) {
+	merged := proto.Clone(stored).(*corev1.PricingRule)
+	if err := fieldmask.Apply(merged, update, mask); err != nil {
+		return nil, err
+	}
+	merged.Version = update.GetVersion()
+	if err := validator_core.ValidatePricingRule(merged, false); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func (p *PricingAPI) hasPermission(ctx context.Context, tenantID, userID, permission string) error {
+	return p.rbac.RequirePermissions(ctx, tenantID, userID, tenantID, permission)
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	"erp.localhost/internal/infra/model/fieldmask"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// warehouseUpdateMaskFields are the fields of a warehouse an update mask may name
+var warehouseUpdateMaskFields = []string{"name", "code", "address", "contact", "capacity", "status"}
+
+// CreateWarehouse creates a warehouse of the tenant, it is active unless a status is given
+func (i *InventoryAPI) CreateWarehouse(ctx context.Context, tenantID, userID string, warehouse *corev1.Warehouse) (*corev1.Warehouse, error) {
+	if tenantID == "" || userID == "" || warehouse == nil {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, warehouse"))
+		i.logger.Error("failed to create warehouse", "error", err)
+		return nil, err
+	}
+	warehouse.Id = ""
+	warehouse.TenantId = tenantID
+	if warehouse.Status == corev1.WarehouseStatus_WAREHOUSE_STATUS_UNSPECIFIED {
+		warehouse.Status = corev1.WarehouseStatus_WAREHOUSE_STATUS_ACTIVE
+	}
+	if err := validator_core.ValidateWarehouse(warehouse, true); err != nil {
+		i.logger.Error("failed to create warehouse", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.WarehouseCreate); err != nil {
+		i.logger.Error("failed to create warehouse", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	id, err := i.warehouseHandler.CreateWarehouse(ctx, warehouse)
+	if err != nil {
+		i.logger.Error("failed to create warehouse", "tenant_id", tenantID, "code", warehouse.Code, "error", err)
+		return nil, err
+	}
+	warehouse.Id = id
+	i.logger.Info("warehouse created", "tenant_id", tenantID, "user_id", userID, "warehouse_id", id, "code", warehouse.Code)
+	return warehouse, nil
+}
+
+func (i *InventoryAPI) GetWarehouse(ctx context.Context, tenantID, userID, warehouseID string) (*corev1.Warehouse, error) {
+	if tenantID == "" || userID == "" || warehouseID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, warehouse_id"))
+		i.logger.Error("failed to get warehouse", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.WarehouseRead); err != nil {
+		i.logger.Error("failed to get warehouse", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	warehouse, err := i.warehouseHandler.GetWarehouseByID(ctx, tenantID, warehouseID)
+	if err != nil {
+		i.logger.Error("failed to get warehouse", "tenant_id", tenantID, "warehouse_id", warehouseID, "error", err)
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+func (i *InventoryAPI) ListWarehouses(ctx context.Context, tenantID, userID string) ([]*corev1.Warehouse, error) {
+	if tenantID == "" || userID == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id"))
+		i.logger.Error("failed to list warehouses", "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.WarehouseRead); err != nil {
+		i.logger.Error("failed to list warehouses", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	warehouses, err := i.warehouseHandler.GetWarehousesByTenantID(ctx, tenantID)
+	if err != nil {
+		i.logger.Error("failed to list warehouses", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	return warehouses, nil
+}
+
+// UpdateWarehouse replaces the editable fields of the stored warehouse with those of warehouse, or when mask names
+// fields only changes those fields
+func (i *InventoryAPI) UpdateWarehouse(ctx context.Context, tenantID, userID string, warehouse *corev1.Warehouse, mask *fieldmaskpb.FieldMask) (*corev1.Warehouse, error) {
+	if tenantID == "" || userID == "" || warehouse.GetId() == "" {
+		err := infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New("missing one or more: tenant_id, user_id, warehouse.id"))
+		i.logger.Error("failed to update warehouse", "error", err)
+		return nil, err
+	}
+	if fieldmask.IsEmpty(mask) {
+		mask = &fieldmaskpb.FieldMask{Paths: warehouseUpdateMaskFields}
+	} else if err := fieldmask.CheckPaths(mask, warehouseUpdateMaskFields...); err != nil {
+		i.logger.Error("failed to update warehouse", "tenant_id", tenantID, "error", err)
+		return nil, err
+	}
+	if err := i.hasPermission(ctx, tenantID, userID, permissions.WarehouseUpdate); err != nil {
+		i.logger.Error("failed to update warehouse", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	existing, err := i.warehouseHandler.GetWarehouseByID(ctx, tenantID, warehouse.Id)
+	if err != nil {
+		i.logger.Error("failed to update warehouse", "tenant_id", tenantID, "warehouse_id", warehouse.Id, "error", err)
+		return nil, err
+	}
+	merged, err := mergeWarehouseUpdate(existing, warehouse, mask)
+	if err != nil {
+		i.logger.Error("failed to update warehouse", "tenant_id", tenantID, "warehouse_id", warehouse.Id, "error", err)
+		return nil, err
+	}
+	if err := i.warehouseHandler.UpdateWarehouse(ctx, merged); err != nil {
+		i.logger.Error("failed to update warehouse", "tenant_id", tenantID, "warehouse_id", warehouse.Id, "error", err)
+		return nil, err
+	}
+	i.logger.Info("warehouse updated", "tenant_id", tenantID, "user_id", userID, "warehouse_id", warehouse.Id)
+	return merged, nil
+}
+
+// mergeWarehouseUpdate returns a copy of stored with the fields of update named by mask
+func mergeWarehouseUpdate(stored, update *corev1.Warehouse, mask *fieldmaskpb.FieldMask) (*corev1.Warehouse, error) {
+	merged := proto.Clone(stored).(*corev1.Warehouse)
+	if err := fieldmask.Apply(merged, update, mask); err != nil {
+		return nil, err
+	}
+	if err := validator_core.ValidateWarehouse(merged, false); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
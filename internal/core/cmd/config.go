@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"erp.localhost/internal/core/currency"
+	"erp.localhost/internal/core/numbering"
+	infra_config "erp.localhost/internal/infra/config"
+	"erp.localhost/internal/infra/event/outbox"
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/secret"
+	"erp.localhost/internal/infra/shutdown"
+)
+
+// Config holds the settings of the core service, see infra_config.Load for how they are resolved
+type Config struct {
+	Server  infra_config.Server  `yaml:"server"`
+	Metrics infra_config.Metrics `yaml:"metrics"`
+	Mongo   infra_config.Mongo   `yaml:"mongo"`
+	TLS     infra_config.TLS     `yaml:"tls"`
+	Client  infra_config.Client  `yaml:"client"`
+	Secrets secret.Config        `yaml:"secrets"`
+	// How the addresses of the other services are found
+	Discovery discovery.Config `yaml:"discovery"`
+	// Draining of the calls and goroutines and closing of the dependencies at shutdown
+	Shutdown shutdown.Config `yaml:"shutdown"`
+	// Domain events of the inventory, published to the broker through the outbox
+	Events outbox.Config `yaml:"events"`
+	// Formats of the numbers of the orders and invoices
+	Numbering numbering.Config `yaml:"numbering"`
+	// Default base currency of the tenants and the provider the exchange rates are imported from
+	Currency currency.Config `yaml:"currency"`
+	// Address of the auth service, the permissions of the users are verified by it. Found by the discovery when empty
+	AuthServiceAddress string `yaml:"auth_service_address" env:"AUTH_SERVICE_ADDRESS" flag:"auth-service-address"`
+}
+
+// loadConfig loads the settings of the service from its defaults, CONFIG_FILE, the environment and args
+func loadConfig(args []string) (*Config, error) {
+	config := &Config{
+		Server:  infra_config.NewServer(5001),
+		Metrics: infra_config.Metrics{Port: 9001},
+	}
+	if err := infra_config.Load(config, infra_config.WithArgs(args)); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
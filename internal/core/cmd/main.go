@@ -1,80 +1,217 @@
 package cmd
 
 import (
+	"context"
 	"os"
-	"os/signal"
-	"sync"
-	"syscall"
-	"time"
 
+	"erp.localhost/internal/core/api"
+	"erp.localhost/internal/core/currency"
+	"erp.localhost/internal/core/numbering"
+	"erp.localhost/internal/core/service"
+	"erp.localhost/internal/infra/buildinfo"
+	db_mongo "erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/event/broker"
+	"erp.localhost/internal/infra/event/outbox"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/grpc/interceptor"
 	"erp.localhost/internal/infra/grpc/server"
 	grpc_server "erp.localhost/internal/infra/grpc/server"
 	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
 	"erp.localhost/internal/infra/model/shared"
 	model_shared "erp.localhost/internal/infra/model/shared"
-)
-
-const (
-	ServerPort = 5001
+	"erp.localhost/internal/infra/secret"
+	"erp.localhost/internal/infra/shutdown"
+	"erp.localhost/internal/infra/tracing"
 )
 
 func Main() {
 	logger := logger.NewBaseLogger(model_shared.ModuleCore)
 	defer logger.Close()
-	logger.Info("Starting service...")
-	// Channel to listen for OS signals for graceful shutdown
-	stopChan := make(chan os.Signal, 1)
-	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
-
-	// Channel to signal the gRPC server goroutine to stop
-	quit := make(chan struct{})
+	logger.Info("Starting service...", buildinfo.Get().LogArgs()...)
+	config, err := loadConfig(os.Args[1:])
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		return
+	}
+	// Stops the server and the background goroutines, then closes the dependencies once main returns
+	coordinator := shutdown.New(&config.Shutdown, logger)
+	defer coordinator.Shutdown()
+	// The database clients are shared by the handlers and the services of the process, they close last
+	coordinator.OnCloseShared("mongo", db_mongo.CloseClients)
+	// Credentials are read from the secret store selected by SECRETS_PROVIDER
+	secrets, err := secret.New(&config.Secrets, logger)
+	if err != nil {
+		logger.Error("failed to create secret provider", "error", err)
+		return
+	}
+	coordinator.OnClose("secrets", func(context.Context) error { return secrets.Close() })
+	if uri, ok := secret.Lookup(context.Background(), secrets, secret.MongoURI, logger); ok {
+		config.Mongo.URI = uri
+	}
+	db_mongo.SetURI(config.Mongo.URI)
+	db_mongo.SetReadPreference(db_mongo.ReadPreference(config.Mongo.ReadPreference))
+	// Distributed tracing, spans are exported when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.LoadConfig(model_shared.ModuleCore), logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	coordinator.OnClose("tracing", shutdownTracing)
 
-	insecure := false
+	// Connections between the services use mTLS, the service does not start without its certificates unless
+	// TLS_INSECURE is set
 	certs := shared.NewCerts()
-	if certs == nil {
-		logger.Warn("configuring insecure")
-		insecure = true
+	if config.TLS.CertsDir != "" {
+		certs = shared.NewCertsFromDir(config.TLS.CertsDir)
 	}
 	// Create server
 	logger.Info("Creating gRPC server...")
 	srv, err := grpc_server.NewGRPCServer(&server.Config{
-		Port:             ServerPort,
-		Module:           shared.ModuleCore,
-		Insecure:         insecure, // Set to false for production with certs
-		Certs:            certs,
-		EnableReflection: true,
-		KeepAliveTime:    30 * time.Second,
-		KeepAliveTimeout: 10 * time.Second,
+		Port:                config.Server.Port,
+		Module:              shared.ModuleCore,
+		Insecure:            config.TLS.Insecure,
+		Certs:               certs,
+		AllowedClients:      config.TLS.AllowedClients,
+		CertReloadInterval:  config.TLS.ReloadInterval,
+		Environment:         config.Server.Environment,
+		EnableReflection:    config.Server.EnableReflection,
+		EnableDebugServices: config.Server.EnableDebugServices,
+		VerboseErrors:       config.Server.VerboseErrors,
+		KeepAliveTime:       config.Server.KeepAliveTime,
+		KeepAliveTimeout:    config.Server.KeepAliveTimeout,
+		ShutdownTimeout:     config.Shutdown.Timeout,
 	}, logger)
 	if err != nil {
 		logger.Error(infra_error.Internal(infra_error.InternalGRPCError, err).Error())
 		return
 	}
 
-	/* Register services */
-	logger.Info("Registering gRPC services...")
+	// Prometheus metrics of the process, see internal/infra/metrics
+	metricsServer := metrics.NewServer(config.Metrics.Port, logger)
+	metricsServer.Start()
+	coordinator.OnClose("metrics_server", metricsServer.Shutdown)
 
-	// WaitGroup to wait for the gRPC server goroutine to finish
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		// Run gRPC Server
-		if err := srv.ListenAndServe(quit); err != nil {
-			logger.Warn("gRPC server stopped", "error", err)
-			return
-		}
-	}()
+	// Clients of the other services share their connections
+	retry := interceptor.DefaultRetryPolicy()
+	retry.MaxAttempts = config.Client.RetryAttempts
+	clients := client.NewFactory(&client.Config{
+		Module:              model_shared.ModuleCore,
+		Insecure:            config.TLS.Insecure,
+		Certs:               certs,
+		CertReloadInterval:  config.TLS.ReloadInterval,
+		ConnectTimeout:      config.Client.ConnectTimeout,
+		RequestTimeout:      config.Client.RequestTimeout,
+		KeepAliveTime:       config.Client.KeepAliveTime,
+		KeepAliveTimeout:    config.Client.KeepAliveTimeout,
+		LoadBalancingPolicy: config.Client.LoadBalancingPolicy,
+		Retry:               retry,
+	}, logger)
+	coordinator.OnClose("grpc_clients", func(context.Context) error { return clients.Close() })
+	// Services without a configured address are found by the discovery, see DISCOVERY_PROVIDER
+	resolver, err := discovery.New(&config.Discovery)
+	if err != nil {
+		logger.Error("invalid service discovery", "error", err)
+		return
+	}
+	clients.SetResolver(resolver)
+
+	// The permissions of the users are verified by the auth service
+	rbacClient, err := clients.RBACClient(context.Background(), config.AuthServiceAddress)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalGRPCError, err).Error())
+		return
+	}
+
+	// Orders and invoices are numbered from per-tenant sequences
+	numberingService, err := numbering.NewService(&config.Numbering, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalConfigError, err).Error())
+		return
+	}
+	// Order and invoice totals are also recorded in the base currency of the tenant
+	currencies, err := currency.NewService(&config.Currency, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalConfigError, err).Error())
+		return
+	}
+	inventoryAPI, err := api.NewInventoryAPI(rbacClient, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	orderAPI, err := api.NewOrderAPI(rbacClient, numberingService, currencies, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	partnerAPI, err := api.NewPartnerAPI(rbacClient, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	invoiceAPI, err := api.NewInvoiceAPI(rbacClient, numberingService, currencies, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	pricingAPI, err := api.NewPricingAPI(rbacClient, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	currencyAPI, err := api.NewCurrencyAPI(rbacClient, currencies, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	analyticsAPI, err := api.NewAnalyticsAPI(rbacClient, currencies, logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
 
-	logger.Warn("gRPC server shutdown...")
-	// Wait for OS signal
-	<-stopChan
+	// Stock changes write their low stock events to the outbox, the publisher sends them to the broker selected by
+	// EVENTS_BROKER
+	eventBroker, err := broker.New(&config.Events.Broker, logger)
+	if err != nil {
+		logger.Error("failed to create events broker", "error", err)
+		return
+	}
+	coordinator.OnClose("events_broker", func(context.Context) error { return eventBroker.Close() })
+	outboxCollection, err := collection.NewBaseCollectionHandler[eventv1.OutboxEvent](model_mongo.CoreDB, model_mongo.CoreOutboxCollection, logger)
+	if err != nil {
+		logger.Error("failed to create outbox collection", "error", err)
+		return
+	}
+	events := outbox.NewOutbox(outboxCollection, logger)
+	inventoryAPI.SetOutbox(events)
+	publisher := outbox.NewPublisher(events, eventBroker, &config.Events, logger)
 
-	// Signal the gRPC server to stop
-	close(quit)
+	/* Register services */
+	logger.Info("Registering gRPC services...")
+	inventoryService := service.NewInventoryService(inventoryAPI, logger)
+	srv.RegisterService(&corev1.InventoryService_ServiceDesc, inventoryService)
+	orderService := service.NewOrderService(orderAPI, logger)
+	srv.RegisterService(&corev1.OrderService_ServiceDesc, orderService)
+	partnerService := service.NewPartnerService(partnerAPI, logger)
+	srv.RegisterService(&corev1.PartnerService_ServiceDesc, partnerService)
+	invoiceService := service.NewInvoiceService(invoiceAPI, logger)
+	srv.RegisterService(&corev1.InvoiceService_ServiceDesc, invoiceService)
+	pricingService := service.NewPricingService(pricingAPI, logger)
+	srv.RegisterService(&corev1.PricingService_ServiceDesc, pricingService)
+	currencyService := service.NewCurrencyService(currencyAPI, logger)
+	srv.RegisterService(&corev1.CurrencyService_ServiceDesc, currencyService)
+	analyticsService := service.NewAnalyticsService(analyticsAPI, logger)
+	srv.RegisterService(&corev1.AnalyticsService_ServiceDesc, analyticsService)
 
-	// Wait for the gRPC server goroutine to finish
-	wg.Wait()
-	logger.Warn("gRPC server stopped")
+	// Publish the domain events of the outbox until shutdown
+	coordinator.Go("outbox_publish", publisher.Run)
+	coordinator.ServeGRPC(srv)
 }
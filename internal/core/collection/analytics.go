@@ -0,0 +1,306 @@
+package collection
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// reportPeriodUnits are the $dateTrunc units of the report periods
+var reportPeriodUnits = map[corev1.ReportPeriod]string{
+	corev1.ReportPeriod_REPORT_PERIOD_DAY:   "day",
+	corev1.ReportPeriod_REPORT_PERIOD_WEEK:  "week",
+	corev1.ReportPeriod_REPORT_PERIOD_MONTH: "month",
+	corev1.ReportPeriod_REPORT_PERIOD_YEAR:  "year",
+}
+
+// soldOrderStatuses are the statuses of the sales orders the sales reports count
+var soldOrderStatuses = bson.A{
+	int32(corev1.OrderStatus_ORDER_STATUS_CONFIRMED),
+	int32(corev1.OrderStatus_ORDER_STATUS_SHIPPED),
+	int32(corev1.OrderStatus_ORDER_STATUS_DELIVERED),
+}
+
+// reportPage is the single document a report pipeline returns, a page of rows and the total row count
+type reportPage[R any] struct {
+	Rows  []*R `bson:"rows"`
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+// valuationPage is the page of an inventory valuation with the totals of every product
+type valuationPage struct {
+	Rows  []*corev1.ProductValuation `bson:"rows"`
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+	Totals []struct {
+		Quantity int64   `bson:"quantity"`
+		Value    float64 `bson:"value"`
+	} `bson:"totals"`
+}
+
+// AnalyticsCollection runs the reports of the tenants over their orders, invoices and stock, see
+// collection.BaseCollectionHandler.Aggregate
+type AnalyticsCollection struct {
+	orders *collection.BaseCollectionHandler[corev1.Order]
+	stock  *collection.BaseCollectionHandler[corev1.StockItem]
+	logger logger.Logger
+}
+
+func NewAnalyticsCollection(logger logger.Logger) (*AnalyticsCollection, error) {
+	orders, err := collection.NewBaseCollectionHandler[corev1.Order](model_mongo.CoreDB, model_mongo.OrdersCollection, logger)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := collection.NewBaseCollectionHandler[corev1.StockItem](model_mongo.CoreDB, model_mongo.InventoryCollection, logger)
+	if err != nil {
+		return nil, err
+	}
+	// The reports scan the history of the tenant, they are offloaded to the secondaries and tolerate their lag
+	orders.SetReadPreference(mongo.ReadSecondaryPreferred)
+	stock.SetReadPreference(mongo.ReadSecondaryPreferred)
+	return &AnalyticsCollection{
+		orders: orders,
+		stock:  stock,
+		logger: logger,
+	}, nil
+}
+
+// SalesByPeriod sums the sales of the tenant created between from and to by period, the amounts of the orders
+// recorded in baseCurrency
+func (a *AnalyticsCollection) SalesByPeriod(ctx context.Context, tenantID, baseCurrency string, from, to time.Time, period corev1.ReportPeriod, pagination *infrav1.PaginationRequest) ([]*corev1.SalesPeriod, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	return runReport[corev1.Order, corev1.SalesPeriod](ctx, a.orders, tenantID, buildSalesByPeriodPipeline(baseCurrency, from, to, period, page, pageSize), page, pageSize)
+}
+
+// TopProducts sums the sold lines of the products of the tenant created between from and to
+func (a *AnalyticsCollection) TopProducts(ctx context.Context, tenantID, baseCurrency string, from, to time.Time, sort corev1.TopProductsSort, pagination *infrav1.PaginationRequest) ([]*corev1.ProductSales, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	return runReport[corev1.Order, corev1.ProductSales](ctx, a.orders, tenantID, buildTopProductsPipeline(baseCurrency, from, to, sort, page, pageSize), page, pageSize)
+}
+
+// UserActivity counts the orders, invoices and stock movements the users of the tenant created between from and to
+func (a *AnalyticsCollection) UserActivity(ctx context.Context, tenantID string, from, to time.Time, pagination *infrav1.PaginationRequest) ([]*corev1.UserActivity, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	return runReport[corev1.Order, corev1.UserActivity](ctx, a.orders, tenantID, buildUserActivityPipeline(tenantID, from, to, page, pageSize), page, pageSize)
+}
+
+// InventoryValuation values the stock of the products of the tenant, in warehouseID unless it is empty, and returns
+// the total quantity and value of the stock
+func (a *AnalyticsCollection) InventoryValuation(ctx context.Context, tenantID, warehouseID string, pagination *infrav1.PaginationRequest) ([]*corev1.ProductValuation, int64, float64, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	var results []*valuationPage
+	if err := a.stock.Aggregate(ctx, tenantID, buildInventoryValuationPipeline(warehouseID, page, pageSize), &results); err != nil {
+		a.logger.Error("failed to value inventory", "tenant_id", tenantID, "error", err)
+		return nil, 0, 0, nil, err
+	}
+	products := []*corev1.ProductValuation{}
+	var total, quantity int64
+	var value float64
+	if len(results) > 0 {
+		report := reportPage[corev1.ProductValuation]{Rows: results[0].Rows, Total: results[0].Total}
+		products, total = report.rows()
+		if len(results[0].Totals) > 0 {
+			quantity, value = results[0].Totals[0].Quantity, results[0].Totals[0].Value
+		}
+	}
+	return products, quantity, value, paginationResponse(page, pageSize, total), nil
+}
+
+// runReport runs the report pipeline of the tenant on handler and returns its page of rows
+func runReport[T, R any](ctx context.Context, handler *collection.BaseCollectionHandler[T], tenantID string, pipeline []bson.M, page, pageSize int32) ([]*R, *infrav1.PaginationResponse, error) {
+	var results []*reportPage[R]
+	if err := handler.Aggregate(ctx, tenantID, pipeline, &results); err != nil {
+		return nil, nil, err
+	}
+	rows := []*R{}
+	var total int64
+	if len(results) > 0 {
+		rows, total = results[0].rows()
+	}
+	return rows, paginationResponse(page, pageSize, total), nil
+}
+
+func (p *reportPage[R]) rows() ([]*R, int64) {
+	rows := p.Rows
+	if rows == nil {
+		rows = []*R{}
+	}
+	if len(p.Total) == 0 {
+		return rows, 0
+	}
+	return rows, p.Total[0].Count
+}
+
+// reportFacet returns the stage splitting the rows of a report into a page sorted by sort and their total count
+func reportFacet(sort bson.D, page, pageSize int32) bson.M {
+	return bson.M{"$facet": bson.M{
+		"rows": bson.A{
+			bson.M{"$sort": sort},
+			bson.M{"$skip": int64(page-1) * int64(pageSize)},
+			bson.M{"$limit": pageSize},
+		},
+		"total": bson.A{
+			bson.M{"$count": "count"},
+		},
+	}}
+}
+
+// soldOrdersMatch matches the sales orders created between from and to that were sold, with their amounts recorded in
+// baseCurrency
+func soldOrdersMatch(baseCurrency string, from, to time.Time) bson.M {
+	return bson.M{"$match": bson.M{
+		"order_type":           int32(corev1.OrderType_ORDER_TYPE_SALES),
+		"status":               bson.M{"$in": soldOrderStatuses},
+		"created_at":           bson.M{"$gte": from, "$lt": to},
+		"totals.base_currency": baseCurrency,
+	}}
+}
+
+// buildSalesByPeriodPipeline groups the sold orders by the period they were created in, oldest period first
+func buildSalesByPeriodPipeline(baseCurrency string, from, to time.Time, period corev1.ReportPeriod, page, pageSize int32) []bson.M {
+	unit, ok := reportPeriodUnits[period]
+	if !ok {
+		unit = reportPeriodUnits[corev1.ReportPeriod_REPORT_PERIOD_MONTH]
+	}
+	trunc := bson.M{"date": "$created_at", "unit": unit}
+	if unit == "week" {
+		trunc["startOfWeek"] = "monday"
+	}
+	return []bson.M{
+		soldOrdersMatch(baseCurrency, from, to),
+		{"$group": bson.M{
+			"_id":         bson.M{"$dateTrunc": trunc},
+			"order_count": bson.M{"$sum": 1},
+			"revenue":     bson.M{"$sum": "$totals.base_total"},
+			"discount":    bson.M{"$sum": bson.M{"$multiply": bson.A{"$totals.discount", "$totals.exchange_rate"}}},
+		}},
+		{"$project": bson.M{
+			"_id":                 0,
+			"period_start":        "$_id",
+			"order_count":         1,
+			"revenue":             bson.M{"$round": bson.A{"$revenue", 2}},
+			"discount":            bson.M{"$round": bson.A{"$discount", 2}},
+			"average_order_value": bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$revenue", "$order_count"}}, 2}},
+		}},
+		reportFacet(bson.D{{Key: "period_start", Value: 1}}, page, pageSize),
+	}
+}
+
+// buildTopProductsPipeline sums the lines of the sold orders by product, by revenue or quantity, highest first
+func buildTopProductsPipeline(baseCurrency string, from, to time.Time, sort corev1.TopProductsSort, page, pageSize int32) []bson.M {
+	sortField := "revenue"
+	if sort == corev1.TopProductsSort_TOP_PRODUCTS_SORT_QUANTITY {
+		sortField = "quantity"
+	}
+	return []bson.M{
+		soldOrdersMatch(baseCurrency, from, to),
+		{"$unwind": "$lines"},
+		{"$group": bson.M{
+			"_id":      "$lines.product_id",
+			"sku":      bson.M{"$last": "$lines.sku"},
+			"name":     bson.M{"$last": "$lines.name"},
+			"quantity": bson.M{"$sum": "$lines.quantity"},
+			"revenue":  bson.M{"$sum": bson.M{"$multiply": bson.A{"$lines.total", "$totals.exchange_rate"}}},
+			"orders":   bson.M{"$addToSet": "$_id"},
+		}},
+		{"$project": bson.M{
+			"_id":         0,
+			"product_id":  "$_id",
+			"sku":         1,
+			"name":        1,
+			"quantity":    1,
+			"revenue":     bson.M{"$round": bson.A{"$revenue", 2}},
+			"order_count": bson.M{"$size": "$orders"},
+		}},
+		reportFacet(bson.D{{Key: sortField, Value: -1}, {Key: "product_id", Value: 1}}, page, pageSize),
+	}
+}
+
+// buildUserActivityPipeline counts the orders, invoices and stock movements of the tenant created between from and
+// to by the user who created them, most active first. The invoices and stock movements are read with $unionWith,
+// whose pipelines are scoped to the tenant here
+func buildUserActivityPipeline(tenantID string, from, to time.Time, page, pageSize int32) []bson.M {
+	created := bson.M{"$gte": from, "$lt": to}
+	activity := func(counter string) bson.M {
+		return bson.M{"$project": bson.M{"_id": 0, "user_id": "$created_by", "created_at": 1, counter: bson.M{"$literal": 1}}}
+	}
+	union := func(collection model_mongo.Collection, counter string) bson.M {
+		return bson.M{"$unionWith": bson.M{
+			"coll": string(collection),
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"tenant_id": tenantID, "created_at": created}},
+				activity(counter),
+			},
+		}}
+	}
+	return []bson.M{
+		{"$match": bson.M{"created_at": created}},
+		activity("orders"),
+		union(model_mongo.InvoicesCollection, "invoices"),
+		union(model_mongo.StockMovementsCollection, "stock_movements"),
+		{"$group": bson.M{
+			"_id":              "$user_id",
+			"orders_created":   bson.M{"$sum": "$orders"},
+			"invoices_created": bson.M{"$sum": "$invoices"},
+			"stock_movements":  bson.M{"$sum": "$stock_movements"},
+			"total":            bson.M{"$sum": 1},
+			"last_active_at":   bson.M{"$max": "$created_at"},
+		}},
+		{"$project": bson.M{
+			"_id":              0,
+			"user_id":          "$_id",
+			"orders_created":   1,
+			"invoices_created": 1,
+			"stock_movements":  1,
+			"total":            1,
+			"last_active_at":   1,
+		}},
+		reportFacet(bson.D{{Key: "total", Value: -1}, {Key: "user_id", Value: 1}}, page, pageSize),
+	}
+}
+
+// buildInventoryValuationPipeline values the stock items in stock by product, highest value first, with the totals
+// of every product
+func buildInventoryValuationPipeline(warehouseID string, page, pageSize int32) []bson.M {
+	match := bson.M{"quantity": bson.M{"$gt": 0}}
+	if warehouseID != "" {
+		match["warehouse_id"] = warehouseID
+	}
+	facet := reportFacet(bson.D{{Key: "value", Value: -1}, {Key: "product_id", Value: 1}}, page, pageSize)
+	facet["$facet"].(bson.M)["totals"] = bson.A{
+		bson.M{"$group": bson.M{
+			"_id":      nil,
+			"quantity": bson.M{"$sum": "$quantity"},
+			"value":    bson.M{"$sum": "$value"},
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "quantity": 1, "value": bson.M{"$round": bson.A{"$value", 2}}}},
+	}
+	return []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id":        "$product_id",
+			"quantity":   bson.M{"$sum": "$quantity"},
+			"value":      bson.M{"$sum": bson.M{"$multiply": bson.A{"$quantity", "$cost"}}},
+			"warehouses": bson.M{"$addToSet": "$warehouse_id"},
+		}},
+		{"$project": bson.M{
+			"_id":             0,
+			"product_id":      "$_id",
+			"quantity":        1,
+			"value":           bson.M{"$round": bson.A{"$value", 2}},
+			"average_cost":    bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$value", "$quantity"}}, 2}},
+			"warehouse_count": bson.M{"$size": "$warehouses"},
+		}},
+		facet,
+	}
+}
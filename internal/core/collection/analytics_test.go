@@ -0,0 +1,91 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildSalesByPeriodPipeline(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	pipeline := buildSalesByPeriodPipeline("USD", from, to, corev1.ReportPeriod_REPORT_PERIOD_WEEK, 2, 10)
+
+	require.Len(t, pipeline, 4)
+	assert.Equal(t, bson.M{
+		"order_type":           int32(corev1.OrderType_ORDER_TYPE_SALES),
+		"status":               bson.M{"$in": soldOrderStatuses},
+		"created_at":           bson.M{"$gte": from, "$lt": to},
+		"totals.base_currency": "USD",
+	}, pipeline[0]["$match"])
+	group := pipeline[1]["$group"].(bson.M)
+	assert.Equal(t, bson.M{"$dateTrunc": bson.M{"date": "$created_at", "unit": "week", "startOfWeek": "monday"}}, group["_id"])
+
+	rows := pipeline[3]["$facet"].(bson.M)["rows"].(bson.A)
+	assert.Equal(t, bson.M{"$sort": bson.D{{Key: "period_start", Value: 1}}}, rows[0])
+	assert.Equal(t, bson.M{"$skip": int64(10)}, rows[1])
+
+	// Months unless the period is set
+	pipeline = buildSalesByPeriodPipeline("USD", from, to, corev1.ReportPeriod_REPORT_PERIOD_UNSPECIFIED, 1, 10)
+	assert.Equal(t, bson.M{"$dateTrunc": bson.M{"date": "$created_at", "unit": "month"}}, pipeline[1]["$group"].(bson.M)["_id"])
+}
+
+func TestBuildTopProductsPipeline(t *testing.T) {
+	now := time.Now()
+	pipeline := buildTopProductsPipeline("USD", now.AddDate(0, -1, 0), now, corev1.TopProductsSort_TOP_PRODUCTS_SORT_QUANTITY, 1, 5)
+
+	assert.Equal(t, "$lines", pipeline[1]["$unwind"])
+	rows := pipeline[4]["$facet"].(bson.M)["rows"].(bson.A)
+	assert.Equal(t, bson.M{"$sort": bson.D{{Key: "quantity", Value: -1}, {Key: "product_id", Value: 1}}}, rows[0])
+
+	pipeline = buildTopProductsPipeline("USD", now.AddDate(0, -1, 0), now, corev1.TopProductsSort_TOP_PRODUCTS_SORT_UNSPECIFIED, 1, 5)
+	rows = pipeline[4]["$facet"].(bson.M)["rows"].(bson.A)
+	assert.Equal(t, bson.M{"$sort": bson.D{{Key: "revenue", Value: -1}, {Key: "product_id", Value: 1}}}, rows[0])
+}
+
+func TestBuildUserActivityPipeline(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	pipeline := buildUserActivityPipeline("tenant-1", from, to, 1, 20)
+
+	// The collections read by $unionWith are scoped to the tenant
+	for i, coll := range []string{"invoices", "stock_movements"} {
+		union := pipeline[2+i]["$unionWith"].(bson.M)
+		assert.Equal(t, coll, union["coll"])
+		assert.Equal(t, bson.M{"$match": bson.M{"tenant_id": "tenant-1", "created_at": bson.M{"$gte": from, "$lt": to}}}, union["pipeline"].(bson.A)[0])
+	}
+	rows := pipeline[6]["$facet"].(bson.M)["rows"].(bson.A)
+	assert.Equal(t, bson.M{"$sort": bson.D{{Key: "total", Value: -1}, {Key: "user_id", Value: 1}}}, rows[0])
+}
+
+func TestBuildInventoryValuationPipeline(t *testing.T) {
+	pipeline := buildInventoryValuationPipeline("warehouse-1", 1, 20)
+	assert.Equal(t, bson.M{"quantity": bson.M{"$gt": 0}, "warehouse_id": "warehouse-1"}, pipeline[0]["$match"])
+
+	facet := pipeline[3]["$facet"].(bson.M)
+	assert.Contains(t, facet, "rows")
+	assert.Contains(t, facet, "total")
+	assert.Contains(t, facet, "totals")
+
+	pipeline = buildInventoryValuationPipeline("", 1, 20)
+	assert.Equal(t, bson.M{"quantity": bson.M{"$gt": 0}}, pipeline[0]["$match"])
+}
+
+func TestValuationPageDecode(t *testing.T) {
+	raw, err := bson.Marshal(bson.M{
+		"rows":   bson.A{bson.M{"product_id": "p1", "quantity": int64(4), "value": 10.5}},
+		"total":  bson.A{bson.M{"count": int64(1)}},
+		"totals": bson.A{bson.M{"quantity": int64(4), "value": 10.5}},
+	})
+	require.NoError(t, err)
+	var page valuationPage
+	require.NoError(t, bson.Unmarshal(raw, &page))
+	require.Len(t, page.Rows, 1)
+	assert.Equal(t, "p1", page.Rows[0].ProductId)
+	assert.Equal(t, int64(1), page.Total[0].Count)
+	assert.Equal(t, 10.5, page.Totals[0].Value)
+}
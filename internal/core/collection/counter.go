@@ -0,0 +1,55 @@
+package collection
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type DocumentCounterCollection struct {
+	*collection.BaseCollectionHandler[corev1.DocumentCounter]
+	logger logger.Logger
+}
+
+func NewDocumentCounterCollection(logger logger.Logger) (*DocumentCounterCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.DocumentCounter](
+		model_mongo.CoreDB,
+		model_mongo.DocumentCountersCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &DocumentCounterCollection{
+		BaseCollectionHandler: collection,
+		logger:                logger,
+	}, nil
+}
+
+// Next increments the counter of the tenant document sequence in period and returns its new value, a new sequence
+// starts at 1. The increment is atomic, in the transaction of ctx if it has one
+func (d *DocumentCounterCollection) Next(ctx context.Context, tenantID, document string, period int32) (int64, error) {
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"document":  document,
+		"period":    period,
+	}
+	update := map[string]any{
+		"$inc": map[string]any{"value": int64(1)},
+		"$set": map[string]any{"updated_at": time.Now().UTC()},
+	}
+	counter, err := d.FindOneAndUpdate(ctx, filter, update, true)
+	if _, duplicate := collection.DuplicateKeyIndex(err); duplicate {
+		// A concurrent upsert created the counter first, the retry increments it
+		d.logger.Debug("document counter created concurrently, retrying", "tenant_id", tenantID, "document", document, "period", period)
+		counter, err = d.FindOneAndUpdate(ctx, filter, update, true)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return counter.GetValue(), nil
+}
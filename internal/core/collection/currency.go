@@ -0,0 +1,179 @@
+package collection
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type CurrencySettingsCollection struct {
+	*collection.BaseCollectionHandler[corev1.CurrencySettings]
+}
+
+func NewCurrencySettingsCollection(logger logger.Logger) (*CurrencySettingsCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.CurrencySettings](
+		model_mongo.CoreDB,
+		model_mongo.CurrencySettingsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &CurrencySettingsCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
+
+// SetBaseCurrency stores the base currency of the tenant and returns its settings, creating them on first use
+func (c *CurrencySettingsCollection) SetBaseCurrency(ctx context.Context, tenantID, baseCurrency, userID string) (*corev1.CurrencySettings, error) {
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	update := map[string]any{
+		"$set": map[string]any{
+			"base_currency": baseCurrency,
+			"updated_by":    userID,
+			"updated_at":    time.Now().UTC(),
+		},
+	}
+	settings, err := c.FindOneAndUpdate(ctx, filter, update, true)
+	if _, duplicate := collection.DuplicateKeyIndex(err); duplicate {
+		// A concurrent upsert created the settings first, the retry updates them
+		settings, err = c.FindOneAndUpdate(ctx, filter, update, true)
+	}
+	return settings, err
+}
+
+type ExchangeRateCollection struct {
+	*collection.BaseCollectionHandler[corev1.ExchangeRate]
+	latest *aggregation.BaseAggregationHandler[corev1.ExchangeRate]
+	search *aggregation.BaseAggregationHandler[exchangeRateSearchPage]
+	logger logger.Logger
+}
+
+// exchangeRateSearchPage is the single document the search pipeline returns, a page of rates and the total match
+// count
+type exchangeRateSearchPage struct {
+	Rates []*corev1.ExchangeRate `bson:"rates"`
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+func NewExchangeRateCollection(logger logger.Logger) (*ExchangeRateCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.ExchangeRate](
+		model_mongo.CoreDB,
+		model_mongo.ExchangeRatesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := aggregation.NewBaseAggregationHandler[corev1.ExchangeRate](
+		model_mongo.CoreDB,
+		model_mongo.ExchangeRatesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	search, err := aggregation.NewBaseAggregationHandler[exchangeRateSearchPage](
+		model_mongo.CoreDB,
+		model_mongo.ExchangeRatesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeRateCollection{
+		BaseCollectionHandler: collection,
+		latest:                latest,
+		search:                search,
+		logger:                logger,
+	}, nil
+}
+
+// EffectiveRate returns the rate of the tenant pair base/quote effective at at, the latest one before it, or nil
+// when the pair had none yet
+func (e *ExchangeRateCollection) EffectiveRate(ctx context.Context, tenantID, base, quote string, at time.Time) (*corev1.ExchangeRate, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"tenant_id":      tenantID,
+			"base_currency":  base,
+			"quote_currency": quote,
+			"effective_at":   bson.M{"$lte": at},
+		}},
+		{"$sort": bson.D{{Key: "effective_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{"$limit": 1},
+	}
+	rates, err := e.latest.Aggregate(ctx, pipeline, nil)
+	if err != nil || len(rates) == 0 {
+		return nil, err
+	}
+	return rates[0], nil
+}
+
+// Search returns the page of the tenant rates matching search, latest first, and the pagination of the results
+func (e *ExchangeRateCollection) Search(ctx context.Context, tenantID string, search *corev1.ExchangeRateSearch, pagination *infrav1.PaginationRequest) ([]*corev1.ExchangeRate, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	results, err := e.search.Aggregate(ctx, buildExchangeRateSearchPipeline(tenantID, search, page, pageSize), nil)
+	if err != nil {
+		e.logger.Error("failed to search exchange rates", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+
+	rates := []*corev1.ExchangeRate{}
+	var total int64
+	if len(results) > 0 {
+		rates = results[0].Rates
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+	return rates, paginationResponse(page, pageSize, total), nil
+}
+
+// buildExchangeRateSearchPipeline matches the tenant rates against search and returns one page of them by effective
+// date, latest first, with the total count
+func buildExchangeRateSearchPipeline(tenantID string, search *corev1.ExchangeRateSearch, page, pageSize int32) []bson.M {
+	if search == nil {
+		search = &corev1.ExchangeRateSearch{}
+	}
+	match := bson.M{"tenant_id": tenantID}
+	if search.BaseCurrency != nil {
+		match["base_currency"] = search.GetBaseCurrency()
+	}
+	if search.QuoteCurrency != nil {
+		match["quote_currency"] = search.GetQuoteCurrency()
+	}
+	effective := bson.M{}
+	if search.GetEffectiveAfter() != nil {
+		effective["$gte"] = search.GetEffectiveAfter().AsTime()
+	}
+	if search.GetEffectiveBefore() != nil {
+		effective["$lt"] = search.GetEffectiveBefore().AsTime()
+	}
+	if len(effective) > 0 {
+		match["effective_at"] = effective
+	}
+	return []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"rates": bson.A{
+				bson.M{"$sort": bson.D{{Key: "effective_at", Value: -1}, {Key: "_id", Value: -1}}},
+				bson.M{"$skip": int64(page-1) * int64(pageSize)},
+				bson.M{"$limit": pageSize},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+		}},
+	}
+}
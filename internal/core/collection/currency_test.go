@@ -0,0 +1,39 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBuildExchangeRateSearchPipeline(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	pipeline := buildExchangeRateSearchPipeline("tenant-1", &corev1.ExchangeRateSearch{
+		QuoteCurrency:   proto.String("EUR"),
+		EffectiveAfter:  timestamppb.New(after),
+		EffectiveBefore: timestamppb.New(before),
+	}, 2, 50)
+
+	require.Len(t, pipeline, 2)
+	assert.Equal(t, bson.M{
+		"tenant_id":      "tenant-1",
+		"quote_currency": "EUR",
+		"effective_at":   bson.M{"$gte": after, "$lt": before},
+	}, pipeline[0]["$match"])
+
+	page := pipeline[1]["$facet"].(bson.M)["rates"].(bson.A)
+	assert.Equal(t, bson.M{"$sort": bson.D{{Key: "effective_at", Value: -1}, {Key: "_id", Value: -1}}}, page[0])
+	assert.Equal(t, bson.M{"$skip": int64(50)}, page[1])
+}
+
+func TestBuildExchangeRateSearchPipeline_NoSearch(t *testing.T) {
+	pipeline := buildExchangeRateSearchPipeline("tenant-1", nil, 1, 20)
+	assert.Equal(t, bson.M{"tenant_id": "tenant-1"}, pipeline[0]["$match"])
+}
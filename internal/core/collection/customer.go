@@ -0,0 +1,90 @@
+package collection
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type CustomerCollection struct {
+	*collection.BaseCollectionHandler[corev1.Customer]
+	search *aggregation.BaseAggregationHandler[customerSearchPage]
+	logger logger.Logger
+}
+
+func NewCustomerCollection(logger logger.Logger) (*CustomerCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.Customer](
+		model_mongo.CoreDB,
+		model_mongo.CustomerCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	search, err := newCustomerSearchAggregation(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &CustomerCollection{
+		BaseCollectionHandler: collection,
+		search:                search,
+		logger:                logger,
+	}, nil
+}
+
+// Emails and company tax IDs are unique per tenant
+var customerUniqueness = uniqueness[corev1.Customer]{
+	constraints: []uniqueConstraint[corev1.Customer]{
+		{
+			index: "idx_tenant_email_unique",
+			err:   infra_error.ConflictDuplicateEmail,
+			filter: func(customer *corev1.Customer) map[string]any {
+				if customer.GetEmail() == "" {
+					return nil
+				}
+				return map[string]any{"tenant_id": customer.GetTenantId(), "email": customer.GetEmail()}
+			},
+		},
+		{
+			index: "idx_tenant_tax_id_unique",
+			err:   infra_error.ConflictDuplicateTaxID,
+			filter: func(customer *corev1.Customer) map[string]any {
+				if customer.GetCompany().GetTaxId() == "" {
+					return nil
+				}
+				return map[string]any{"tenant_id": customer.GetTenantId(), "company.tax_id": customer.GetCompany().GetTaxId()}
+			},
+		},
+	},
+	id: (*corev1.Customer).GetId,
+}
+
+func (c *CustomerCollection) Create(ctx context.Context, customer *corev1.Customer) (string, error) {
+	if err := customerUniqueness.check(ctx, c.BaseCollectionHandler, customer, false); err != nil {
+		c.logger.Warn("customer conflicts with an existing customer", "tenant_id", customer.GetTenantId(), "error", err)
+		return "", err
+	}
+	id, err := c.BaseCollectionHandler.Create(ctx, customer)
+	return id, customerUniqueness.writeError(err)
+}
+
+func (c *CustomerCollection) Update(ctx context.Context, filter map[string]any, customer *corev1.Customer) error {
+	if err := customerUniqueness.check(ctx, c.BaseCollectionHandler, customer, true); err != nil {
+		c.logger.Warn("customer conflicts with an existing customer", "tenant_id", customer.GetTenantId(), "customer_id", customer.GetId(), "error", err)
+		return err
+	}
+	return customerUniqueness.writeError(c.BaseCollectionHandler.Update(ctx, filter, customer))
+}
+
+func (c *CustomerCollection) UpdateVersioned(ctx context.Context, filter map[string]any, customer *corev1.Customer, expectedVersion int64) error {
+	if err := customerUniqueness.check(ctx, c.BaseCollectionHandler, customer, true); err != nil {
+		c.logger.Warn("customer conflicts with an existing customer", "tenant_id", customer.GetTenantId(), "customer_id", customer.GetId(), "error", err)
+		return err
+	}
+	return customerUniqueness.writeError(c.BaseCollectionHandler.UpdateVersioned(ctx, filter, customer, expectedVersion))
+}
@@ -0,0 +1,62 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type StockItemCollection struct {
+	*collection.BaseCollectionHandler[corev1.StockItem]
+}
+
+func NewStockItemCollection(logger logger.Logger) (*StockItemCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.StockItem](
+		model_mongo.CoreDB,
+		model_mongo.InventoryCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &StockItemCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
+
+type StockMovementCollection struct {
+	*collection.BaseCollectionHandler[corev1.StockMovement]
+}
+
+func NewStockMovementCollection(logger logger.Logger) (*StockMovementCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.StockMovement](
+		model_mongo.CoreDB,
+		model_mongo.StockMovementsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &StockMovementCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
+
+type StockReservationCollection struct {
+	*collection.BaseCollectionHandler[corev1.StockReservation]
+}
+
+func NewStockReservationCollection(logger logger.Logger) (*StockReservationCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.StockReservation](
+		model_mongo.CoreDB,
+		model_mongo.StockReservationsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &StockReservationCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
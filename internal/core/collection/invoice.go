@@ -0,0 +1,68 @@
+package collection
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type InvoiceCollection struct {
+	*collection.BaseCollectionHandler[corev1.Invoice]
+	search *aggregation.BaseAggregationHandler[invoiceSearchPage]
+	logger logger.Logger
+}
+
+func NewInvoiceCollection(logger logger.Logger) (*InvoiceCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.Invoice](
+		model_mongo.CoreDB,
+		model_mongo.InvoicesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	search, err := newInvoiceSearchAggregation(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &InvoiceCollection{
+		BaseCollectionHandler: collection,
+		search:                search,
+		logger:                logger,
+	}, nil
+}
+
+// An order is invoiced once and invoice numbers are unique per tenant
+var invoiceUniqueness = uniqueness[corev1.Invoice]{
+	constraints: []uniqueConstraint[corev1.Invoice]{
+		{
+			index: "idx_tenant_order_id_unique",
+			err:   infra_error.ConflictInvoiceExists,
+			filter: func(invoice *corev1.Invoice) map[string]any {
+				return map[string]any{"tenant_id": invoice.GetTenantId(), "order_id": invoice.GetOrderId()}
+			},
+		},
+		{
+			index: "idx_tenant_invoice_number",
+			err:   infra_error.ConflictDuplicateResource,
+			filter: func(invoice *corev1.Invoice) map[string]any {
+				return map[string]any{"tenant_id": invoice.GetTenantId(), "invoice_number": invoice.GetInvoiceNumber()}
+			},
+		},
+	},
+	id: (*corev1.Invoice).GetId,
+}
+
+func (i *InvoiceCollection) Create(ctx context.Context, invoice *corev1.Invoice) (string, error) {
+	if err := invoiceUniqueness.check(ctx, i.BaseCollectionHandler, invoice, false); err != nil {
+		i.logger.Warn("invoice conflicts with an existing invoice", "tenant_id", invoice.GetTenantId(), "order_id", invoice.GetOrderId(), "error", err)
+		return "", err
+	}
+	id, err := i.BaseCollectionHandler.Create(ctx, invoice)
+	return id, invoiceUniqueness.writeError(err)
+}
@@ -0,0 +1,88 @@
+package collection
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// invoiceSearchPage is the single document the search pipeline returns, a page of invoices and the total match count
+type invoiceSearchPage struct {
+	Invoices []*corev1.Invoice `bson:"invoices"`
+	Total    []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+func newInvoiceSearchAggregation(logger logger.Logger) (*aggregation.BaseAggregationHandler[invoiceSearchPage], error) {
+	return aggregation.NewBaseAggregationHandler[invoiceSearchPage](
+		model_mongo.CoreDB,
+		model_mongo.InvoicesCollection,
+		logger,
+	)
+}
+
+// Search returns the page of the tenant invoices matching search, newest first, and the pagination of the results
+func (i *InvoiceCollection) Search(ctx context.Context, tenantID string, search *corev1.InvoiceSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Invoice, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	results, err := i.search.Aggregate(ctx, buildInvoiceSearchPipeline(tenantID, search, time.Now(), page, pageSize), nil)
+	if err != nil {
+		i.logger.Error("failed to search invoices", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+
+	invoices := []*corev1.Invoice{}
+	var total int64
+	if len(results) > 0 {
+		invoices = results[0].Invoices
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+	return invoices, paginationResponse(page, pageSize, total), nil
+}
+
+// buildInvoiceSearchPipeline matches the tenant invoices against search and returns one page of them by issue date,
+// newest first, with the total count. Overdue invoices still have an amount due after their due date at now
+func buildInvoiceSearchPipeline(tenantID string, search *corev1.InvoiceSearch, now time.Time, page, pageSize int32) []bson.M {
+	if search == nil {
+		search = &corev1.InvoiceSearch{}
+	}
+	match := bson.M{"tenant_id": tenantID}
+	if statuses := search.GetPaymentStatuses(); len(statuses) > 0 {
+		values := make(bson.A, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, int32(status))
+		}
+		match["payment_status"] = bson.M{"$in": values}
+	}
+	if search.CustomerId != nil {
+		match["customer_id"] = search.GetCustomerId()
+	}
+	if search.OrderId != nil {
+		match["order_id"] = search.GetOrderId()
+	}
+	if search.GetOverdue() {
+		match["amount_due"] = bson.M{"$gt": 0}
+		match["due_at"] = bson.M{"$lt": now}
+	}
+	return []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"invoices": bson.A{
+				bson.M{"$sort": bson.D{{Key: "issued_at", Value: -1}, {Key: "_id", Value: -1}}},
+				bson.M{"$skip": int64(page-1) * int64(pageSize)},
+				bson.M{"$limit": pageSize},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+		}},
+	}
+}
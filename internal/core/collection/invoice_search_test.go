@@ -0,0 +1,41 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBuildInvoiceSearchPipeline(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	pipeline := buildInvoiceSearchPipeline("tenant-1", &corev1.InvoiceSearch{
+		PaymentStatuses: []corev1.PaymentStatus{corev1.PaymentStatus_PAYMENT_STATUS_PENDING, corev1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID},
+		CustomerId:      proto.String("customer-1"),
+		Overdue:         true,
+	}, now, 3, 10)
+
+	require.Len(t, pipeline, 2)
+	assert.Equal(t, bson.M{
+		"tenant_id":      "tenant-1",
+		"payment_status": bson.M{"$in": bson.A{int32(1), int32(5)}},
+		"customer_id":    "customer-1",
+		"amount_due":     bson.M{"$gt": 0},
+		"due_at":         bson.M{"$lt": now},
+	}, pipeline[0]["$match"])
+
+	page := pipeline[1]["$facet"].(bson.M)["invoices"].(bson.A)
+	assert.Equal(t, bson.M{"$sort": bson.D{{Key: "issued_at", Value: -1}, {Key: "_id", Value: -1}}}, page[0])
+	assert.Equal(t, bson.M{"$skip": int64(20)}, page[1])
+	assert.Equal(t, bson.M{"$limit": int32(10)}, page[2])
+}
+
+func TestBuildInvoiceSearchPipeline_NoSearch(t *testing.T) {
+	pipeline := buildInvoiceSearchPipeline("tenant-1", nil, time.Now(), 1, 20)
+	require.Len(t, pipeline, 2)
+	assert.Equal(t, bson.M{"tenant_id": "tenant-1"}, pipeline[0]["$match"])
+}
@@ -0,0 +1,35 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type OrderCollection struct {
+	*collection.BaseCollectionHandler[corev1.Order]
+	search *aggregation.BaseAggregationHandler[orderSearchPage]
+	logger logger.Logger
+}
+
+func NewOrderCollection(logger logger.Logger) (*OrderCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.Order](
+		model_mongo.CoreDB,
+		model_mongo.OrdersCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	search, err := newOrderSearchAggregation(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderCollection{
+		BaseCollectionHandler: collection,
+		search:                search,
+		logger:                logger,
+	}, nil
+}
@@ -0,0 +1,173 @@
+package collection
+
+import (
+	"context"
+	"regexp"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// Fields the free text query of an order search is matched against
+var orderSearchTextFields = []string{
+	"order_number",
+	"notes",
+}
+
+var orderSortFields = map[corev1.OrderSortField]string{
+	corev1.OrderSortField_ORDER_SORT_FIELD_UNSPECIFIED:  "created_at",
+	corev1.OrderSortField_ORDER_SORT_FIELD_CREATED_AT:   "created_at",
+	corev1.OrderSortField_ORDER_SORT_FIELD_ORDER_NUMBER: "order_number",
+	corev1.OrderSortField_ORDER_SORT_FIELD_TOTAL:        "totals.total",
+}
+
+// orderSearchPage is the single document the search pipeline returns, a page of orders and the total match count
+type orderSearchPage struct {
+	Orders []*corev1.Order `bson:"orders"`
+	Total  []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+func newOrderSearchAggregation(logger logger.Logger) (*aggregation.BaseAggregationHandler[orderSearchPage], error) {
+	return aggregation.NewBaseAggregationHandler[orderSearchPage](
+		model_mongo.CoreDB,
+		model_mongo.OrdersCollection,
+		logger,
+	)
+}
+
+// Search returns the page of the tenant orders matching search and the pagination of the results
+func (o *OrderCollection) Search(ctx context.Context, tenantID string, search *corev1.OrderSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Order, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	pipeline, err := buildOrderSearchPipeline(tenantID, search, page, pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := o.search.Aggregate(ctx, pipeline, nil)
+	if err != nil {
+		o.logger.Error("failed to search orders", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+
+	orders := []*corev1.Order{}
+	var total int64
+	if len(results) > 0 {
+		orders = results[0].Orders
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+	return orders, paginationResponse(page, pageSize, total), nil
+}
+
+// paginationResponse returns the pagination of page out of total items
+func paginationResponse(page, pageSize int32, total int64) *infrav1.PaginationResponse {
+	totalPages := int32((total + int64(pageSize) - 1) / int64(pageSize))
+	return &infrav1.PaginationResponse{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
+
+// searchPage returns the requested page, from 1, and page size with defaults applied and the size capped
+func searchPage(pagination *infrav1.PaginationRequest) (int32, int32) {
+	page, pageSize := pagination.GetPage(), pagination.GetPageSize()
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+	return page, pageSize
+}
+
+// buildOrderSearchPipeline matches the tenant orders against search and returns one page of them with the total
+// count, newest first unless the search sorts otherwise
+func buildOrderSearchPipeline(tenantID string, search *corev1.OrderSearch, page, pageSize int32) ([]bson.M, error) {
+	if search == nil {
+		search = &corev1.OrderSearch{}
+	}
+	match := bson.M{"tenant_id": tenantID}
+
+	if statuses := search.GetStatuses(); len(statuses) > 0 {
+		values := make(bson.A, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, int32(status))
+		}
+		match["status"] = bson.M{"$in": values}
+	}
+	if search.GetOrderType() != corev1.OrderType_ORDER_TYPE_UNSPECIFIED {
+		match["order_type"] = int32(search.GetOrderType())
+	}
+	if search.CustomerId != nil {
+		match["customer_id"] = search.GetCustomerId()
+	}
+	if search.VendorId != nil {
+		match["vendor_id"] = search.GetVendorId()
+	}
+
+	created := bson.M{}
+	if search.GetCreatedAfter() != nil {
+		created["$gte"] = search.GetCreatedAfter().AsTime()
+	}
+	if search.GetCreatedBefore() != nil {
+		created["$lt"] = search.GetCreatedBefore().AsTime()
+	}
+	if search.GetCreatedAfter() != nil && search.GetCreatedBefore() != nil &&
+		!search.GetCreatedAfter().AsTime().Before(search.GetCreatedBefore().AsTime()) {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "created_after", "created_before")
+	}
+	if len(created) > 0 {
+		match["created_at"] = created
+	}
+
+	if query := search.GetQuery(); query != "" {
+		pattern := regexp.QuoteMeta(query)
+		or := make(bson.A, 0, len(orderSearchTextFields))
+		for _, field := range orderSearchTextFields {
+			or = append(or, bson.M{field: bson.M{"$regex": pattern, "$options": "i"}})
+		}
+		match["$or"] = or
+	}
+
+	sortField, ok := orderSortFields[search.GetSortBy()]
+	if !ok {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "sort_by")
+	}
+	direction := -1
+	if search.GetAscending() {
+		direction = 1
+	}
+
+	return []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"orders": bson.A{
+				bson.M{"$sort": bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}}},
+				bson.M{"$skip": int64(page-1) * int64(pageSize)},
+				bson.M{"$limit": pageSize},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+		}},
+	}, nil
+}
@@ -0,0 +1,106 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBuildOrderSearchPipeline(t *testing.T) {
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		search    *corev1.OrderSearch
+		wantMatch bson.M
+		wantSort  bson.D
+		wantErr   bool
+	}{
+		{
+			name:      "newest first by default",
+			search:    nil,
+			wantMatch: bson.M{"tenant_id": "tenant-1"},
+			wantSort:  bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name: "all filters",
+			search: &corev1.OrderSearch{
+				Statuses:      []corev1.OrderStatus{corev1.OrderStatus_ORDER_STATUS_PENDING, corev1.OrderStatus_ORDER_STATUS_CONFIRMED},
+				OrderType:     corev1.OrderType_ORDER_TYPE_SALES,
+				CustomerId:    proto.String("customer-1"),
+				CreatedAfter:  timestamppb.New(after),
+				CreatedBefore: timestamppb.New(before),
+				SortBy:        corev1.OrderSortField_ORDER_SORT_FIELD_TOTAL,
+				Ascending:     true,
+			},
+			wantMatch: bson.M{
+				"tenant_id":   "tenant-1",
+				"status":      bson.M{"$in": bson.A{int32(2), int32(3)}},
+				"order_type":  int32(1),
+				"customer_id": "customer-1",
+				"created_at":  bson.M{"$gte": after, "$lt": before},
+			},
+			wantSort: bson.D{{Key: "totals.total", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name:   "free text is escaped",
+			search: &corev1.OrderSearch{Query: "SO-1.2", VendorId: proto.String("vendor-1")},
+			wantMatch: bson.M{
+				"tenant_id": "tenant-1",
+				"vendor_id": "vendor-1",
+				"$or": bson.A{
+					bson.M{"order_number": bson.M{"$regex": `SO-1\.2`, "$options": "i"}},
+					bson.M{"notes": bson.M{"$regex": `SO-1\.2`, "$options": "i"}},
+				},
+			},
+			wantSort: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name:    "empty created range",
+			search:  &corev1.OrderSearch{CreatedAfter: timestamppb.New(before), CreatedBefore: timestamppb.New(after)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort field",
+			search:  &corev1.OrderSearch{SortBy: corev1.OrderSortField(99)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pipeline, err := buildOrderSearchPipeline("tenant-1", tc.search, 3, 10)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, pipeline, 2)
+			assert.Equal(t, tc.wantMatch, pipeline[0]["$match"])
+
+			page := pipeline[1]["$facet"].(bson.M)["orders"].(bson.A)
+			assert.Equal(t, bson.M{"$sort": tc.wantSort}, page[0])
+			assert.Equal(t, bson.M{"$skip": int64(20)}, page[1])
+			assert.Equal(t, bson.M{"$limit": int32(10)}, page[2])
+		})
+	}
+}
+
+func TestPaginationResponse(t *testing.T) {
+	pagination := paginationResponse(2, 20, 45)
+	assert.Equal(t, int32(3), pagination.GetTotalPages())
+	assert.True(t, pagination.GetHasNext())
+	assert.True(t, pagination.GetHasPrev())
+
+	pagination = paginationResponse(1, 20, 0)
+	assert.Equal(t, int32(0), pagination.GetTotalPages())
+	assert.False(t, pagination.GetHasNext())
+	assert.False(t, pagination.GetHasPrev())
+}
@@ -0,0 +1,154 @@
+package collection
+
+import (
+	"context"
+	"regexp"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Fields the free text query of a customer search is matched against
+var customerSearchTextFields = []string{
+	"name",
+	"email",
+	"company.name",
+	"company.tax_id",
+}
+
+// Fields the free text query of a vendor search is matched against
+var vendorSearchTextFields = []string{
+	"name",
+	"code",
+	"contact.email",
+	"metadata.tax_id",
+}
+
+// customerSearchPage is the single document the search pipeline returns, a page of customers and the total match count
+type customerSearchPage struct {
+	Customers []*corev1.Customer `bson:"customers"`
+	Total     []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+// vendorSearchPage is the single document the search pipeline returns, a page of vendors and the total match count
+type vendorSearchPage struct {
+	Vendors []*corev1.Vendor `bson:"vendors"`
+	Total   []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+func newCustomerSearchAggregation(logger logger.Logger) (*aggregation.BaseAggregationHandler[customerSearchPage], error) {
+	return aggregation.NewBaseAggregationHandler[customerSearchPage](
+		model_mongo.CoreDB,
+		model_mongo.CustomerCollection,
+		logger,
+	)
+}
+
+func newVendorSearchAggregation(logger logger.Logger) (*aggregation.BaseAggregationHandler[vendorSearchPage], error) {
+	return aggregation.NewBaseAggregationHandler[vendorSearchPage](
+		model_mongo.CoreDB,
+		model_mongo.VendorsCollection,
+		logger,
+	)
+}
+
+// Search returns the page of the tenant customers matching search, ordered by name, and the pagination of the results
+func (c *CustomerCollection) Search(ctx context.Context, tenantID string, search *corev1.CustomerSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Customer, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	results, err := c.search.Aggregate(ctx, buildCustomerSearchPipeline(tenantID, search, page, pageSize), nil)
+	if err != nil {
+		c.logger.Error("failed to search customers", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+
+	customers := []*corev1.Customer{}
+	var total int64
+	if len(results) > 0 {
+		customers = results[0].Customers
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+	return customers, paginationResponse(page, pageSize, total), nil
+}
+
+// Search returns the page of the tenant vendors matching search, ordered by name, and the pagination of the results
+func (v *VendorCollection) Search(ctx context.Context, tenantID string, search *corev1.VendorSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Vendor, *infrav1.PaginationResponse, error) {
+	page, pageSize := searchPage(pagination)
+	results, err := v.search.Aggregate(ctx, buildVendorSearchPipeline(tenantID, search, page, pageSize), nil)
+	if err != nil {
+		v.logger.Error("failed to search vendors", "tenant_id", tenantID, "error", err)
+		return nil, nil, err
+	}
+
+	vendors := []*corev1.Vendor{}
+	var total int64
+	if len(results) > 0 {
+		vendors = results[0].Vendors
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+	return vendors, paginationResponse(page, pageSize, total), nil
+}
+
+func buildCustomerSearchPipeline(tenantID string, search *corev1.CustomerSearch, page, pageSize int32) []bson.M {
+	match := bson.M{"tenant_id": tenantID}
+	if statuses := search.GetStatuses(); len(statuses) > 0 {
+		values := make(bson.A, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, int32(status))
+		}
+		match["status"] = bson.M{"$in": values}
+	}
+	if search.GetType() != corev1.CustomerType_CUSTOMER_TYPE_UNSPECIFIED {
+		match["type"] = int32(search.GetType())
+	}
+	return partnerSearchPipeline(match, search.GetQuery(), customerSearchTextFields, "customers", page, pageSize)
+}
+
+func buildVendorSearchPipeline(tenantID string, search *corev1.VendorSearch, page, pageSize int32) []bson.M {
+	match := bson.M{"tenant_id": tenantID}
+	if statuses := search.GetStatuses(); len(statuses) > 0 {
+		values := make(bson.A, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, int32(status))
+		}
+		match["status"] = bson.M{"$in": values}
+	}
+	return partnerSearchPipeline(match, search.GetQuery(), vendorSearchTextFields, "vendors", page, pageSize)
+}
+
+// partnerSearchPipeline adds the free text query on textFields to match and returns one page of the matching
+// partners by name, under facet, with the total count
+func partnerSearchPipeline(match bson.M, query string, textFields []string, facet string, page, pageSize int32) []bson.M {
+	if query != "" {
+		pattern := regexp.QuoteMeta(query)
+		or := make(bson.A, 0, len(textFields))
+		for _, field := range textFields {
+			or = append(or, bson.M{field: bson.M{"$regex": pattern, "$options": "i"}})
+		}
+		match["$or"] = or
+	}
+	return []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			facet: bson.A{
+				bson.M{"$sort": bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+				bson.M{"$skip": int64(page-1) * int64(pageSize)},
+				bson.M{"$limit": pageSize},
+			},
+			"total": bson.A{
+				bson.M{"$count": "count"},
+			},
+		}},
+	}
+}
@@ -0,0 +1,48 @@
+package collection
+
+import (
+	"testing"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildCustomerSearchPipeline(t *testing.T) {
+	pipeline := buildCustomerSearchPipeline("tenant-1", &corev1.CustomerSearch{
+		Statuses: []corev1.CustomerStatus{corev1.CustomerStatus_CUSTOMER_STATUS_ACTIVE},
+		Type:     corev1.CustomerType_CUSTOMER_TYPE_BUSINESS,
+		Query:    "acme+",
+	}, 2, 25)
+
+	require.Len(t, pipeline, 2)
+	assert.Equal(t, bson.M{
+		"tenant_id": "tenant-1",
+		"status":    bson.M{"$in": bson.A{int32(1)}},
+		"type":      int32(2),
+		"$or": bson.A{
+			bson.M{"name": bson.M{"$regex": `acme\+`, "$options": "i"}},
+			bson.M{"email": bson.M{"$regex": `acme\+`, "$options": "i"}},
+			bson.M{"company.name": bson.M{"$regex": `acme\+`, "$options": "i"}},
+			bson.M{"company.tax_id": bson.M{"$regex": `acme\+`, "$options": "i"}},
+		},
+	}, pipeline[0]["$match"])
+
+	page := pipeline[1]["$facet"].(bson.M)["customers"].(bson.A)
+	assert.Equal(t, bson.M{"$sort": bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}, page[0])
+	assert.Equal(t, bson.M{"$skip": int64(25)}, page[1])
+	assert.Equal(t, bson.M{"$limit": int32(25)}, page[2])
+}
+
+func TestBuildVendorSearchPipeline(t *testing.T) {
+	pipeline := buildVendorSearchPipeline("tenant-1", nil, 1, 20)
+	require.Len(t, pipeline, 2)
+	assert.Equal(t, bson.M{"tenant_id": "tenant-1"}, pipeline[0]["$match"])
+	assert.Contains(t, pipeline[1]["$facet"].(bson.M), "vendors")
+
+	pipeline = buildVendorSearchPipeline("tenant-1", &corev1.VendorSearch{
+		Statuses: []corev1.VendorStatus{corev1.VendorStatus_VENDOR_STATUS_ACTIVE, corev1.VendorStatus_VENDOR_STATUS_PENDING_APPROVAL},
+	}, 1, 20)
+	assert.Equal(t, bson.M{"tenant_id": "tenant-1", "status": bson.M{"$in": bson.A{int32(1), int32(3)}}}, pipeline[0]["$match"])
+}
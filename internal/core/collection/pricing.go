@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type PricingRuleCollection struct {
+	*collection.BaseCollectionHandler[corev1.PricingRule]
+}
+
+func NewPricingRuleCollection(logger logger.Logger) (*PricingRuleCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.PricingRule](
+		model_mongo.CoreDB,
+		model_mongo.PricingRulesCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &PricingRuleCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package collection
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// uniqueConstraint is a combination of fields that must be unique within a tenant.
+// It is checked before writes for a clear error and enforced by a unique index against concurrent writes.
+type uniqueConstraint[T any] struct {
+	// index is the unique index backing the constraint, see model_mongo indexes
+	index string
+	err   infra_error.ErrorDef
+	// filter selects the documents item collides with, nil when item doesn't set the fields
+	filter func(item *T) map[string]any
+}
+
+// uniqueness enforces the unique constraints of a collection
+type uniqueness[T any] struct {
+	constraints []uniqueConstraint[T]
+	id          func(item *T) string
+}
+
+// check returns a conflict when another document already holds the unique fields of item.
+// On update item may collide with itself, writes of items without an id are left to the indexes.
+// Creates only check that a colliding document exists, updates read the colliding documents to skip item.
+func (u uniqueness[T]) check(ctx context.Context, handler collection.CollectionHandler[T], item *T, update bool) error {
+	if update && u.id(item) == "" {
+		return nil
+	}
+	for _, constraint := range u.constraints {
+		filter := constraint.filter(item)
+		if filter == nil {
+			continue
+		}
+		if !update {
+			exists, err := handler.Exists(ctx, filter)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return infra_error.Conflict(constraint.err)
+			}
+			continue
+		}
+		existing, err := handler.FindAll(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, document := range existing {
+			if u.id(document) != u.id(item) {
+				return infra_error.Conflict(constraint.err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeError converts a write rejected by the index of a constraint to the error of the constraint
+func (u uniqueness[T]) writeError(err error) error {
+	index, ok := collection.DuplicateKeyIndex(err)
+	if !ok {
+		return err
+	}
+	for _, constraint := range u.constraints {
+		if constraint.index == index {
+			return infra_error.Conflict(constraint.err).WithError(err)
+		}
+	}
+	return err
+}
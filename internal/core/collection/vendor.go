@@ -0,0 +1,100 @@
+package collection
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/mongo/aggregation"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type VendorCollection struct {
+	*collection.BaseCollectionHandler[corev1.Vendor]
+	search *aggregation.BaseAggregationHandler[vendorSearchPage]
+	logger logger.Logger
+}
+
+func NewVendorCollection(logger logger.Logger) (*VendorCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.Vendor](
+		model_mongo.CoreDB,
+		model_mongo.VendorsCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	search, err := newVendorSearchAggregation(logger)
+	if err != nil {
+		return nil, err
+	}
+	return &VendorCollection{
+		BaseCollectionHandler: collection,
+		search:                search,
+		logger:                logger,
+	}, nil
+}
+
+// Codes, emails and tax IDs are unique per tenant
+var vendorUniqueness = uniqueness[corev1.Vendor]{
+	constraints: []uniqueConstraint[corev1.Vendor]{
+		{
+			index: "idx_tenant_code_unique",
+			err:   infra_error.ConflictVendorExists,
+			filter: func(vendor *corev1.Vendor) map[string]any {
+				if vendor.GetCode() == "" {
+					return nil
+				}
+				return map[string]any{"tenant_id": vendor.GetTenantId(), "code": vendor.GetCode()}
+			},
+		},
+		{
+			index: "idx_tenant_email_unique",
+			err:   infra_error.ConflictDuplicateEmail,
+			filter: func(vendor *corev1.Vendor) map[string]any {
+				if vendor.GetContact().GetEmail() == "" {
+					return nil
+				}
+				return map[string]any{"tenant_id": vendor.GetTenantId(), "contact.email": vendor.GetContact().GetEmail()}
+			},
+		},
+		{
+			index: "idx_tenant_tax_id_unique",
+			err:   infra_error.ConflictDuplicateTaxID,
+			filter: func(vendor *corev1.Vendor) map[string]any {
+				if vendor.GetMetadata().GetTaxId() == "" {
+					return nil
+				}
+				return map[string]any{"tenant_id": vendor.GetTenantId(), "metadata.tax_id": vendor.GetMetadata().GetTaxId()}
+			},
+		},
+	},
+	id: (*corev1.Vendor).GetId,
+}
+
+func (v *VendorCollection) Create(ctx context.Context, vendor *corev1.Vendor) (string, error) {
+	if err := vendorUniqueness.check(ctx, v.BaseCollectionHandler, vendor, false); err != nil {
+		v.logger.Warn("vendor conflicts with an existing vendor", "tenant_id", vendor.GetTenantId(), "error", err)
+		return "", err
+	}
+	id, err := v.BaseCollectionHandler.Create(ctx, vendor)
+	return id, vendorUniqueness.writeError(err)
+}
+
+func (v *VendorCollection) Update(ctx context.Context, filter map[string]any, vendor *corev1.Vendor) error {
+	if err := vendorUniqueness.check(ctx, v.BaseCollectionHandler, vendor, true); err != nil {
+		v.logger.Warn("vendor conflicts with an existing vendor", "tenant_id", vendor.GetTenantId(), "vendor_id", vendor.GetId(), "error", err)
+		return err
+	}
+	return vendorUniqueness.writeError(v.BaseCollectionHandler.Update(ctx, filter, vendor))
+}
+
+func (v *VendorCollection) UpdateVersioned(ctx context.Context, filter map[string]any, vendor *corev1.Vendor, expectedVersion int64) error {
+	if err := vendorUniqueness.check(ctx, v.BaseCollectionHandler, vendor, true); err != nil {
+		v.logger.Warn("vendor conflicts with an existing vendor", "tenant_id", vendor.GetTenantId(), "vendor_id", vendor.GetId(), "error", err)
+		return err
+	}
+	return vendorUniqueness.writeError(v.BaseCollectionHandler.UpdateVersioned(ctx, filter, vendor, expectedVersion))
+}
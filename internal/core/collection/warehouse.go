@@ -0,0 +1,26 @@
+package collection
+
+import (
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+)
+
+type WarehouseCollection struct {
+	*collection.BaseCollectionHandler[corev1.Warehouse]
+}
+
+func NewWarehouseCollection(logger logger.Logger) (*WarehouseCollection, error) {
+	collection, err := collection.NewBaseCollectionHandler[corev1.Warehouse](
+		model_mongo.CoreDB,
+		model_mongo.WarehouseCollection,
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &WarehouseCollection{
+		BaseCollectionHandler: collection,
+	}, nil
+}
@@ -0,0 +1,64 @@
+// Package currency converts the amounts of the tenants between currencies with the exchange rates they keep, entered
+// by hand or imported from a Provider. The rates of a pair are kept over time so past amounts convert with the rate
+// effective when they were recorded
+package currency
+
+import (
+	"math"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// Normalize returns code as an ISO 4217 code, upper case without surrounding spaces
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// RateLookup returns the units of quote one unit of base is worth, ok is false when the pair has no rate
+type RateLookup func(base, quote string) (rate float64, ok bool, err error)
+
+// CrossRate returns the units of to one unit of from is worth: the rate of the pair, the inverse of the rate of the
+// reverse pair, or else the rates of both currencies against pivot, usually the base currency of the tenant
+func CrossRate(from, to, pivot string, lookup RateLookup) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok, err := pairRate(from, to, lookup); err != nil || ok {
+		return rate, err
+	}
+	if pivot != "" && pivot != from && pivot != to {
+		fromPivot, ok, err := pairRate(from, pivot, lookup)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			pivotTo, ok, err := pairRate(pivot, to, lookup)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				return fromPivot * pivotTo, nil
+			}
+		}
+	}
+	return 0, infra_error.NotFound(infra_error.NotFoundResource, "exchange rate", from+"/"+to)
+}
+
+// pairRate returns the rate of base/quote, or the inverse of the rate of quote/base
+func pairRate(base, quote string, lookup RateLookup) (float64, bool, error) {
+	rate, ok, err := lookup(base, quote)
+	if err != nil || ok {
+		return rate, ok, err
+	}
+	rate, ok, err = lookup(quote, base)
+	if err != nil || !ok || rate == 0 {
+		return 0, false, err
+	}
+	return 1 / rate, true, nil
+}
+
+// Convert returns amount at rate, rounded to cents half away from zero
+func Convert(amount, rate float64) float64 {
+	return math.Round(amount*rate*100) / 100
+}
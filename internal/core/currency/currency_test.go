@@ -0,0 +1,102 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossRate(t *testing.T) {
+	rates := map[string]float64{
+		"USD/EUR": 0.8,
+		"USD/ILS": 4,
+		"GBP/USD": 1.25,
+	}
+	lookup := func(base, quote string) (float64, bool, error) {
+		rate, ok := rates[base+"/"+quote]
+		return rate, ok, nil
+	}
+
+	testCases := []struct {
+		name string
+		from string
+		to   string
+		rate float64
+	}{
+		{name: "same currency", from: "EUR", to: "EUR", rate: 1},
+		{name: "direct", from: "USD", to: "EUR", rate: 0.8},
+		{name: "inverse", from: "EUR", to: "USD", rate: 1.25},
+		{name: "through the pivot", from: "EUR", to: "ILS", rate: 5},
+		{name: "inverse through the pivot", from: "GBP", to: "ILS", rate: 5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, err := CrossRate(tc.from, tc.to, "USD", lookup)
+			require.NoError(t, err)
+			assert.InDelta(t, tc.rate, rate, 1e-9)
+		})
+	}
+
+	_, err := CrossRate("EUR", "JPY", "USD", lookup)
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+
+	failure := errors.New("lookup failed")
+	_, err = CrossRate("EUR", "ILS", "USD", func(string, string) (float64, bool, error) { return 0, false, failure })
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestConvert(t *testing.T) {
+	assert.Equal(t, 92.6, Convert(100.65, 0.92))
+	assert.Equal(t, -1.01, Convert(-1.005, 1.0001))
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config Config
+		err    bool
+	}{
+		{name: "defaults", config: Config{DefaultBaseCurrency: "usd", Provider: ProviderNone}},
+		{name: "http", config: Config{DefaultBaseCurrency: "EUR", Provider: ProviderHTTP, ProviderURL: "https://rates.localhost/{base}"}},
+		{name: "http without url", config: Config{DefaultBaseCurrency: "EUR", Provider: ProviderHTTP}, err: true},
+		{name: "unknown provider", config: Config{DefaultBaseCurrency: "EUR", Provider: "ftp"}, err: true},
+		{name: "invalid base currency", config: Config{DefaultBaseCurrency: "EURO"}, err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") != "USD" {
+			http.Error(w, "unknown base", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"base":"USD","rates":{"eur":0.92,"ILS":3.7,"USD":1,"XX":2,"JPY":0}}`))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider(server.URL+"/latest?from={base}", time.Second)
+	assert.Equal(t, "http", provider.Name())
+	rates, err := provider.Rates(context.Background(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"EUR": 0.92, "ILS": 3.7}, rates)
+
+	_, err = provider.Rates(context.Background(), "GBP")
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryInternal))
+}
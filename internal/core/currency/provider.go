@@ -0,0 +1,124 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// ProviderKind selects the provider the exchange rates are imported from
+type ProviderKind string
+
+const (
+	// ProviderNone imports no rates, they are entered by hand
+	ProviderNone ProviderKind = "none"
+	// ProviderHTTP reads the rates from a JSON endpoint, see HTTPProvider
+	ProviderHTTP ProviderKind = "http"
+)
+
+// Provider returns the current exchange rates of a base currency
+type Provider interface {
+	// Name is recorded as the source of the imported rates
+	Name() string
+	// Rates returns the units of each currency one unit of base is worth
+	Rates(ctx context.Context, base string) (map[string]float64, error)
+}
+
+// Config holds the currency settings of the service, loaded with infra_config.Load
+type Config struct {
+	// DefaultBaseCurrency is the base currency of the tenants that did not set theirs
+	DefaultBaseCurrency string        `yaml:"default_base_currency" env:"CURRENCY_DEFAULT_BASE" default:"USD"`
+	Provider            ProviderKind  `yaml:"provider" env:"CURRENCY_RATES_PROVIDER" default:"none"`
+	ProviderURL         string        `yaml:"provider_url" env:"CURRENCY_RATES_URL"`
+	ProviderTimeout     time.Duration `yaml:"provider_timeout" env:"CURRENCY_RATES_TIMEOUT" default:"10s"`
+}
+
+// Validate checks the default base currency and the settings of the selected provider
+func (c *Config) Validate() error {
+	if len(Normalize(c.DefaultBaseCurrency)) != 3 {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "CURRENCY_DEFAULT_BASE")
+	}
+	switch c.Provider {
+	case ProviderNone, "":
+	case ProviderHTTP:
+		if c.ProviderURL == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "CURRENCY_RATES_URL")
+		}
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "CURRENCY_RATES_PROVIDER").
+			WithError(fmt.Errorf("unknown exchange rate provider %q", c.Provider))
+	}
+	return nil
+}
+
+// NewProvider creates the provider selected by config, nil when the rates are entered by hand
+func NewProvider(config *Config) (Provider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Provider == ProviderHTTP {
+		return NewHTTPProvider(config.ProviderURL, config.ProviderTimeout), nil
+	}
+	return nil, nil
+}
+
+// HTTPProvider reads the rates of a base currency from a JSON endpoint answering {"rates": {"EUR": 0.92, ...}}, e.g.
+// https://api.frankfurter.app/latest?from={base}. {base} in the URL is replaced by the base currency
+type HTTPProvider struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPProvider(url string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *HTTPProvider) Name() string {
+	return string(ProviderHTTP)
+}
+
+// httpRates is the body of the answer of the endpoint, its other fields are ignored
+type httpRates struct {
+	Rates map[string]float64 `json:"rates"`
+}
+
+func (p *HTTPProvider) Rates(ctx context.Context, base string) (map[string]float64, error) {
+	endpoint := strings.ReplaceAll(p.url, "{base}", url.QueryEscape(base))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, infra_error.Internal(infra_error.InternalServiceUnavailable,
+			fmt.Errorf("exchange rate provider returned %s: %s", resp.Status, strings.TrimSpace(string(message))))
+	}
+	var body httpRates
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, infra_error.Internal(infra_error.InternalServiceUnavailable, fmt.Errorf("decode exchange rates: %w", err))
+	}
+	rates := make(map[string]float64, len(body.Rates))
+	for code, rate := range body.Rates {
+		if code = Normalize(code); len(code) == 3 && code != base && rate > 0 {
+			rates[code] = rate
+		}
+	}
+	return rates, nil
+}
@@ -0,0 +1,209 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	collection_core "erp.localhost/internal/core/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SourceManual is the source of the rates entered by hand
+const SourceManual = "manual"
+
+// Service keeps the base currency and the exchange rates of the tenants and converts their amounts
+type Service struct {
+	settings            *collection_core.CurrencySettingsCollection
+	rates               *collection_core.ExchangeRateCollection
+	provider            Provider
+	defaultBaseCurrency string
+	logger              logger.Logger
+}
+
+func NewService(config *Config, logger logger.Logger) (*Service, error) {
+	provider, err := NewProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := collection_core.NewCurrencySettingsCollection(logger)
+	if err != nil {
+		logger.Error("failed to create currency settings collection handler", "error", err)
+		return nil, err
+	}
+	rates, err := collection_core.NewExchangeRateCollection(logger)
+	if err != nil {
+		logger.Error("failed to create exchange rate collection handler", "error", err)
+		return nil, err
+	}
+	return &Service{
+		settings:            settings,
+		rates:               rates,
+		provider:            provider,
+		defaultBaseCurrency: Normalize(config.DefaultBaseCurrency),
+		logger:              logger,
+	}, nil
+}
+
+// SetProvider replaces the provider the rates are imported from, nil disables the import
+func (s *Service) SetProvider(provider Provider) {
+	s.provider = provider
+}
+
+// Settings returns the currency settings of the tenant, with the default base currency when it did not set its own
+func (s *Service) Settings(ctx context.Context, tenantID string) (*corev1.CurrencySettings, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	settings, err := s.settings.FindOne(ctx, map[string]any{"tenant_id": tenantID})
+	if err != nil {
+		if errors.Is(err, driver_mongo.ErrNoDocuments) {
+			return &corev1.CurrencySettings{TenantId: tenantID, BaseCurrency: s.defaultBaseCurrency}, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// BaseCurrency returns the currency the amounts of the tenant are also recorded in
+func (s *Service) BaseCurrency(ctx context.Context, tenantID string) (string, error) {
+	settings, err := s.Settings(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	return settings.BaseCurrency, nil
+}
+
+// SetBaseCurrency changes the base currency of the tenant, the amounts recorded before keep the base they were
+// recorded in
+func (s *Service) SetBaseCurrency(ctx context.Context, tenantID, userID, baseCurrency string) (*corev1.CurrencySettings, error) {
+	baseCurrency = Normalize(baseCurrency)
+	if tenantID == "" || len(baseCurrency) != 3 {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "BaseCurrency")
+	}
+	return s.settings.SetBaseCurrency(ctx, tenantID, baseCurrency, userID)
+}
+
+// AddRate records rate from its effective date on, now when it has none
+func (s *Service) AddRate(ctx context.Context, rate *corev1.ExchangeRate) (*corev1.ExchangeRate, error) {
+	rate.Id = ""
+	rate.BaseCurrency = Normalize(rate.BaseCurrency)
+	rate.QuoteCurrency = Normalize(rate.QuoteCurrency)
+	rate.CreatedAt = timestamppb.Now()
+	if rate.EffectiveAt == nil {
+		rate.EffectiveAt = rate.CreatedAt
+	}
+	if rate.Source == "" {
+		rate.Source = SourceManual
+	}
+	if err := validator_core.ValidateExchangeRate(rate); err != nil {
+		return nil, err
+	}
+	s.logger.Debug("Creating exchange rate", "tenant_id", rate.TenantId, "base", rate.BaseCurrency, "quote", rate.QuoteCurrency)
+	id, err := s.rates.Create(ctx, rate)
+	if err != nil {
+		return nil, err
+	}
+	rate.Id = id
+	return rate, nil
+}
+
+// SearchRates returns a page of the rates of the tenant matching search, latest first
+func (s *Service) SearchRates(ctx context.Context, tenantID string, search *corev1.ExchangeRateSearch, pagination *infrav1.PaginationRequest) ([]*corev1.ExchangeRate, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	if search != nil {
+		if search.BaseCurrency != nil {
+			search.BaseCurrency = proto.String(Normalize(search.GetBaseCurrency()))
+		}
+		if search.QuoteCurrency != nil {
+			search.QuoteCurrency = proto.String(Normalize(search.GetQuoteCurrency()))
+		}
+	}
+	return s.rates.Search(ctx, tenantID, search, pagination)
+}
+
+// Rate returns the units of to one unit of from was worth at at, with the rates of the tenant effective then. When the
+// tenant has no rate between the two currencies they are converted through its base currency
+func (s *Service) Rate(ctx context.Context, tenantID, from, to string, at time.Time) (float64, error) {
+	from, to = Normalize(from), Normalize(to)
+	if from == to {
+		return 1, nil
+	}
+	base, err := s.BaseCurrency(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	lookup := func(base, quote string) (float64, bool, error) {
+		rate, err := s.rates.EffectiveRate(ctx, tenantID, base, quote, at)
+		if err != nil || rate == nil {
+			return 0, false, err
+		}
+		return rate.Rate, true, nil
+	}
+	return CrossRate(from, to, base, lookup)
+}
+
+// Convert returns amount in from converted to to at at, rounded to cents, and the rate it was converted at
+func (s *Service) Convert(ctx context.Context, tenantID string, amount float64, from, to string, at time.Time) (float64, float64, error) {
+	rate, err := s.Rate(ctx, tenantID, from, to, at)
+	if err != nil {
+		return 0, 0, err
+	}
+	return Convert(amount, rate), rate, nil
+}
+
+// ToBase returns the base currency of the tenant and the rate of currency to it at at
+func (s *Service) ToBase(ctx context.Context, tenantID, currency string, at time.Time) (string, float64, error) {
+	base, err := s.BaseCurrency(ctx, tenantID)
+	if err != nil {
+		return "", 0, err
+	}
+	rate, err := s.Rate(ctx, tenantID, currency, base, at)
+	if err != nil {
+		return "", 0, err
+	}
+	return base, rate, nil
+}
+
+// Import records the current rates of the base currency of the tenant returned by the provider, effective at now
+func (s *Service) Import(ctx context.Context, tenantID, userID string, now time.Time) ([]*corev1.ExchangeRate, error) {
+	if s.provider == nil {
+		return nil, infra_error.Business(infra_error.BusinessInvalidOperation).WithError(errors.New("no exchange rate provider is configured"))
+	}
+	base, err := s.BaseCurrency(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	quotes, err := s.provider.Rates(ctx, base)
+	if err != nil {
+		s.logger.Error("failed to fetch exchange rates", "tenant_id", tenantID, "provider", s.provider.Name(), "error", err)
+		return nil, err
+	}
+	rates := make([]*corev1.ExchangeRate, 0, len(quotes))
+	for quote, value := range quotes {
+		rate, err := s.AddRate(ctx, &corev1.ExchangeRate{
+			TenantId:      tenantID,
+			BaseCurrency:  base,
+			QuoteCurrency: quote,
+			Rate:          value,
+			EffectiveAt:   timestamppb.New(now),
+			Source:        s.provider.Name(),
+			CreatedBy:     userID,
+		})
+		if err != nil {
+			s.logger.Error("failed to import exchange rate", "tenant_id", tenantID, "base", base, "quote", quote, "error", err)
+			return rates, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	collection_core "erp.localhost/internal/core/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+)
+
+type AnalyticsHandler struct {
+	collection *collection_core.AnalyticsCollection
+	logger     logger.Logger
+}
+
+func NewAnalyticsHandler(logger logger.Logger) (*AnalyticsHandler, error) {
+	collection, err := collection_core.NewAnalyticsCollection(logger)
+	if err != nil {
+		logger.Error("failed to create analytics collection handler", "error", err)
+		return nil, err
+	}
+	return &AnalyticsHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+func (a *AnalyticsHandler) SalesByPeriod(ctx context.Context, tenantID, baseCurrency string, from, to time.Time, period corev1.ReportPeriod, pagination *infrav1.PaginationRequest) ([]*corev1.SalesPeriod, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || baseCurrency == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "BaseCurrency")
+	}
+	a.logger.Debug("Reporting sales by period", "tenant_id", tenantID, "from", from, "to", to, "period", period)
+	return a.collection.SalesByPeriod(ctx, tenantID, baseCurrency, from, to, period, pagination)
+}
+
+func (a *AnalyticsHandler) TopProducts(ctx context.Context, tenantID, baseCurrency string, from, to time.Time, sort corev1.TopProductsSort, pagination *infrav1.PaginationRequest) ([]*corev1.ProductSales, *infrav1.PaginationResponse, error) {
+	if tenantID == "" || baseCurrency == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "BaseCurrency")
+	}
+	a.logger.Debug("Reporting top products", "tenant_id", tenantID, "from", from, "to", to, "sort", sort)
+	return a.collection.TopProducts(ctx, tenantID, baseCurrency, from, to, sort, pagination)
+}
+
+func (a *AnalyticsHandler) InventoryValuation(ctx context.Context, tenantID, warehouseID string, pagination *infrav1.PaginationRequest) ([]*corev1.ProductValuation, int64, float64, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, 0, 0, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	a.logger.Debug("Reporting inventory valuation", "tenant_id", tenantID, "warehouse_id", warehouseID)
+	return a.collection.InventoryValuation(ctx, tenantID, warehouseID, pagination)
+}
+
+func (a *AnalyticsHandler) UserActivity(ctx context.Context, tenantID string, from, to time.Time, pagination *infrav1.PaginationRequest) ([]*corev1.UserActivity, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	a.logger.Debug("Reporting user activity", "tenant_id", tenantID, "from", from, "to", to)
+	return a.collection.UserActivity(ctx, tenantID, from, to, pagination)
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// findOne returns the item matching filter, a NotFound error naming resource and id when there is none
+func findOne[T any](ctx context.Context, collection collection_mongo.CollectionHandler[T], filter map[string]any, resource, id string) (*T, error) {
+	item, err := collection.FindOne(ctx, filter)
+	if errors.Is(err, driver_mongo.ErrNoDocuments) {
+		return nil, infra_error.NotFound(infra_error.NotFoundResource, resource, id)
+	}
+	return item, err
+}
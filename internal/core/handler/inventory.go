@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"context"
+	"slices"
+
+	collection_core "erp.localhost/internal/core/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// InventoryHandler stores the stock items of the warehouses, the movements changing them and the reservations
+// holding them
+type InventoryHandler struct {
+	itemCollection        collection_mongo.CollectionHandler[corev1.StockItem]
+	movementCollection    collection_mongo.CollectionHandler[corev1.StockMovement]
+	reservationCollection collection_mongo.CollectionHandler[corev1.StockReservation]
+	logger                logger.Logger
+}
+
+func NewInventoryHandler(logger logger.Logger) (*InventoryHandler, error) {
+	itemCollection, err := collection_core.NewStockItemCollection(logger)
+	if err != nil {
+		logger.Error("failed to create stock item collection handler", "error", err)
+		return nil, err
+	}
+	movementCollection, err := collection_core.NewStockMovementCollection(logger)
+	if err != nil {
+		logger.Error("failed to create stock movement collection handler", "error", err)
+		return nil, err
+	}
+	reservationCollection, err := collection_core.NewStockReservationCollection(logger)
+	if err != nil {
+		logger.Error("failed to create stock reservation collection handler", "error", err)
+		return nil, err
+	}
+	return &InventoryHandler{
+		itemCollection:        itemCollection,
+		movementCollection:    movementCollection,
+		reservationCollection: reservationCollection,
+		logger:                logger,
+	}, nil
+}
+
+/* Stock items */
+
+func (i *InventoryHandler) CreateStockItem(ctx context.Context, item *corev1.StockItem) (string, error) {
+	if err := validator_core.ValidateStockItem(item, true); err != nil {
+		return "", err
+	}
+	item.CreatedAt = timestamppb.Now()
+	item.UpdatedAt = item.CreatedAt
+	item.Version = 0
+	i.logger.Debug("Creating stock item", "tenant_id", item.GetTenantId(), "product_id", item.GetProductId(), "warehouse_id", item.GetWarehouseId())
+	return i.itemCollection.Create(ctx, item)
+}
+
+func (i *InventoryHandler) GetStockItemByID(ctx context.Context, tenantID, itemID string) (*corev1.StockItem, error) {
+	if tenantID == "" || itemID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "StockItemId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       itemID,
+	}
+	i.logger.Debug("Getting stock item by id", "filter", filter)
+	return findOne(ctx, i.itemCollection, filter, "stock item", itemID)
+}
+
+// GetStockItem returns the stock item of a product in a warehouse, nil when the product was never stocked there
+func (i *InventoryHandler) GetStockItem(ctx context.Context, tenantID, productID, warehouseID string) (*corev1.StockItem, error) {
+	if tenantID == "" || productID == "" || warehouseID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "ProductId", "WarehouseId")
+	}
+	filter := map[string]any{
+		"tenant_id":    tenantID,
+		"product_id":   productID,
+		"warehouse_id": warehouseID,
+	}
+	i.logger.Debug("Getting stock item", "filter", filter)
+	items, err := i.itemCollection.FindAll(ctx, filter)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// GetStockItems returns the stock items of the tenant, of a warehouse or a product when their id is not empty
+func (i *InventoryHandler) GetStockItems(ctx context.Context, tenantID, warehouseID, productID string) ([]*corev1.StockItem, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	if warehouseID != "" {
+		filter["warehouse_id"] = warehouseID
+	}
+	if productID != "" {
+		filter["product_id"] = productID
+	}
+	i.logger.Debug("Getting stock items", "filter", filter)
+	return i.itemCollection.FindAll(ctx, filter)
+}
+
+// UpdateStockItem stores item if it was not updated since it was read at its version, see
+// collection_mongo.CollectionHandler.UpdateVersioned. The version of item is advanced on success
+func (i *InventoryHandler) UpdateStockItem(ctx context.Context, item *corev1.StockItem) error {
+	if err := validator_core.ValidateStockItem(item, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": item.TenantId,
+		"_id":       item.Id,
+	}
+	item.UpdatedAt = timestamppb.Now()
+	i.logger.Debug("Updating stock item", "filter", filter, "version", item.Version)
+	if err := i.itemCollection.UpdateVersioned(ctx, filter, item, item.Version); err != nil {
+		return err
+	}
+	item.Version++
+	return nil
+}
+
+/* Movements */
+
+func (i *InventoryHandler) CreateMovement(ctx context.Context, movement *corev1.StockMovement) (string, error) {
+	if err := validator_core.ValidateStockMovement(movement); err != nil {
+		return "", err
+	}
+	movement.CreatedAt = timestamppb.Now()
+	i.logger.Debug("Creating stock movement", "tenant_id", movement.GetTenantId(), "type", movement.GetType(), "product_id", movement.GetProductId())
+	return i.movementCollection.Create(ctx, movement)
+}
+
+// GetMovements returns the movements of the tenant newest first, of a product or a warehouse when their id is not
+// empty. The movements of a warehouse include the transfers to it
+func (i *InventoryHandler) GetMovements(ctx context.Context, tenantID, productID, warehouseID string) ([]*corev1.StockMovement, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	if productID != "" {
+		filter["product_id"] = productID
+	}
+	if warehouseID != "" {
+		filter["$or"] = []map[string]any{
+			{"warehouse_id": warehouseID},
+			{"to_warehouse_id": warehouseID},
+		}
+	}
+	i.logger.Debug("Getting stock movements", "filter", filter)
+	movements, err := i.movementCollection.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(movements, func(a, b *corev1.StockMovement) int {
+		return b.GetCreatedAt().AsTime().Compare(a.GetCreatedAt().AsTime())
+	})
+	return movements, nil
+}
+
+/* Reservations */
+
+func (i *InventoryHandler) CreateReservation(ctx context.Context, reservation *corev1.StockReservation) (string, error) {
+	if err := validator_core.ValidateStockReservation(reservation, true); err != nil {
+		return "", err
+	}
+	reservation.CreatedAt = timestamppb.Now()
+	reservation.Version = 0
+	i.logger.Debug("Creating stock reservation", "tenant_id", reservation.GetTenantId(), "reference", reservation.GetReference())
+	return i.reservationCollection.Create(ctx, reservation)
+}
+
+func (i *InventoryHandler) GetReservationByID(ctx context.Context, tenantID, reservationID string) (*corev1.StockReservation, error) {
+	if tenantID == "" || reservationID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "ReservationId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       reservationID,
+	}
+	i.logger.Debug("Getting stock reservation by id", "filter", filter)
+	return findOne(ctx, i.reservationCollection, filter, "stock reservation", reservationID)
+}
+
+// ReleaseReservation marks an active reservation released by userID, it fails with a ConflictResourceModified error
+// when the reservation was updated since it was read
+func (i *InventoryHandler) ReleaseReservation(ctx context.Context, reservation *corev1.StockReservation, userID string) error {
+	filter := map[string]any{
+		"tenant_id": reservation.TenantId,
+		"_id":       reservation.Id,
+		"status":    int32(corev1.StockReservationStatus_STOCK_RESERVATION_STATUS_ACTIVE),
+	}
+	reservation.Status = corev1.StockReservationStatus_STOCK_RESERVATION_STATUS_RELEASED
+	reservation.ReleasedBy = userID
+	reservation.ReleasedAt = timestamppb.Now()
+	if err := validator_core.ValidateStockReservation(reservation, false); err != nil {
+		return err
+	}
+	i.logger.Debug("Releasing stock reservation", "filter", filter, "version", reservation.Version)
+	if err := i.reservationCollection.UpdateVersioned(ctx, filter, reservation, reservation.Version); err != nil {
+		return err
+	}
+	reservation.Version++
+	return nil
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+
+	collection_core "erp.localhost/internal/core/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// invoiceSearcher runs paginated invoice searches, implemented by InvoiceCollection
+type invoiceSearcher interface {
+	Search(ctx context.Context, tenantID string, search *corev1.InvoiceSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Invoice, *infrav1.PaginationResponse, error)
+}
+
+type InvoiceHandler struct {
+	collection collection_mongo.CollectionHandler[corev1.Invoice]
+	search     invoiceSearcher
+	logger     logger.Logger
+}
+
+func NewInvoiceHandler(logger logger.Logger) (*InvoiceHandler, error) {
+	collection, err := collection_core.NewInvoiceCollection(logger)
+	if err != nil {
+		logger.Error("failed to create invoice collection handler", "error", err)
+		return nil, err
+	}
+	return &InvoiceHandler{
+		collection: collection,
+		search:     collection,
+		logger:     logger,
+	}, nil
+}
+
+func (i *InvoiceHandler) CreateInvoice(ctx context.Context, invoice *corev1.Invoice) (string, error) {
+	if err := validator_core.ValidateInvoice(invoice, true); err != nil {
+		return "", err
+	}
+	invoice.CreatedAt = timestamppb.Now()
+	invoice.UpdatedAt = invoice.CreatedAt
+	invoice.Version = 0
+	i.logger.Debug("Creating invoice", "tenant_id", invoice.GetTenantId(), "invoice_number", invoice.GetInvoiceNumber(), "order_id", invoice.GetOrderId())
+	return i.collection.Create(ctx, invoice)
+}
+
+func (i *InvoiceHandler) GetInvoiceByID(ctx context.Context, tenantID, invoiceID string) (*corev1.Invoice, error) {
+	if tenantID == "" || invoiceID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "InvoiceId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       invoiceID,
+	}
+	i.logger.Debug("Getting invoice by id", "filter", filter)
+	return findOne(ctx, i.collection, filter, "invoice", invoiceID)
+}
+
+// SearchInvoices returns a page of the tenant invoices matching search
+func (i *InvoiceHandler) SearchInvoices(ctx context.Context, tenantID string, search *corev1.InvoiceSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Invoice, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	i.logger.Debug("Searching invoices", "tenant_id", tenantID, "search", search, "pagination", pagination)
+	return i.search.Search(ctx, tenantID, search, pagination)
+}
+
+// HasOrderInvoice reports whether the order of the tenant is invoiced
+func (i *InvoiceHandler) HasOrderInvoice(ctx context.Context, tenantID, orderID string) (bool, error) {
+	if tenantID == "" || orderID == "" {
+		return false, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "OrderId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"order_id":  orderID,
+	}
+	i.logger.Debug("Checking order invoice", "filter", filter)
+	return i.collection.Exists(ctx, filter)
+}
+
+// UpdateInvoice stores invoice if it was not updated since it was read at its version, see
+// collection_mongo.CollectionHandler.UpdateVersioned. The version of invoice is advanced on success
+func (i *InvoiceHandler) UpdateInvoice(ctx context.Context, invoice *corev1.Invoice) error {
+	if err := validator_core.ValidateInvoice(invoice, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": invoice.TenantId,
+		"_id":       invoice.Id,
+	}
+	invoice.UpdatedAt = timestamppb.Now()
+	i.logger.Debug("Updating invoice", "filter", filter, "version", invoice.Version)
+	if err := i.collection.UpdateVersioned(ctx, filter, invoice, invoice.Version); err != nil {
+		return err
+	}
+	invoice.Version++
+	return nil
+}
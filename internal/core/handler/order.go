@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	collection_core "erp.localhost/internal/core/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/db/mongo/query"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// orderSearcher runs paginated order searches, implemented by OrderCollection
+type orderSearcher interface {
+	Search(ctx context.Context, tenantID string, search *corev1.OrderSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Order, *infrav1.PaginationResponse, error)
+}
+
+type OrderHandler struct {
+	collection collection_mongo.CollectionHandler[corev1.Order]
+	search     orderSearcher
+	logger     logger.Logger
+}
+
+func NewOrderHandler(logger logger.Logger) (*OrderHandler, error) {
+	collection, err := collection_core.NewOrderCollection(logger)
+	if err != nil {
+		logger.Error("failed to create order collection handler", "error", err)
+		return nil, err
+	}
+	return &OrderHandler{
+		collection: collection,
+		search:     collection,
+		logger:     logger,
+	}, nil
+}
+
+func (o *OrderHandler) CreateOrder(ctx context.Context, order *corev1.Order) (string, error) {
+	if err := validator_core.ValidateOrder(order, true); err != nil {
+		return "", err
+	}
+	order.CreatedAt = timestamppb.Now()
+	order.UpdatedAt = order.CreatedAt
+	order.Version = 0
+	o.logger.Debug("Creating order", "tenant_id", order.GetTenantId(), "order_number", order.GetOrderNumber())
+	return o.collection.Create(ctx, order)
+}
+
+func (o *OrderHandler) GetOrderByID(ctx context.Context, tenantID, orderID string) (*corev1.Order, error) {
+	if tenantID == "" || orderID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "OrderId")
+	}
+	filter, err := query.New[corev1.Order]().Tenant(tenantID).ID(orderID).Build()
+	if err != nil {
+		return nil, err
+	}
+	o.logger.Debug("Getting order by id", "filter", filter)
+	return findOne(ctx, o.collection, filter, "order", orderID)
+}
+
+// SearchOrders returns a page of the tenant orders matching search
+func (o *OrderHandler) SearchOrders(ctx context.Context, tenantID string, search *corev1.OrderSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Order, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	o.logger.Debug("Searching orders", "tenant_id", tenantID, "search", search, "pagination", pagination)
+	return o.search.Search(ctx, tenantID, search, pagination)
+}
+
+// HasPartnerOrders reports whether an order of the tenant references the customer or vendor partnerID, partnerField
+// is customer_id or vendor_id
+func (o *OrderHandler) HasPartnerOrders(ctx context.Context, tenantID, partnerField, partnerID string) (bool, error) {
+	if tenantID == "" || partnerID == "" {
+		return false, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "PartnerId")
+	}
+	filter, err := query.New[corev1.Order]().Tenant(tenantID).Eq(partnerField, partnerID).Build()
+	if err != nil {
+		return false, err
+	}
+	o.logger.Debug("Checking partner orders", "filter", filter)
+	_, err = o.collection.FindOne(ctx, filter)
+	if errors.Is(err, driver_mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// UpdateOrder stores order if it was not updated since it was read at its version, see
+// collection_mongo.CollectionHandler.UpdateVersioned. The version of order is advanced on success
+func (o *OrderHandler) UpdateOrder(ctx context.Context, order *corev1.Order) error {
+	if err := validator_core.ValidateOrder(order, false); err != nil {
+		return err
+	}
+	filter, err := query.New[corev1.Order]().Tenant(order.TenantId).ID(order.Id).Build()
+	if err != nil {
+		return err
+	}
+	order.UpdatedAt = timestamppb.Now()
+	o.logger.Debug("Updating order", "filter", filter, "version", order.Version)
+	if err := o.collection.UpdateVersioned(ctx, filter, order, order.Version); err != nil {
+		return err
+	}
+	order.Version++
+	return nil
+}
+
+// DeleteDraftOrder deletes the order if it is still a draft
+func (o *OrderHandler) DeleteDraftOrder(ctx context.Context, tenantID, orderID string) error {
+	if tenantID == "" || orderID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "OrderId")
+	}
+	filter, err := query.New[corev1.Order]().Tenant(tenantID).ID(orderID).
+		Eq("status", int32(corev1.OrderStatus_ORDER_STATUS_DRAFT)).Build()
+	if err != nil {
+		return err
+	}
+	o.logger.Debug("Deleting draft order", "filter", filter)
+	return o.collection.Delete(ctx, filter)
+}
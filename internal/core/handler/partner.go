@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+
+	collection_core "erp.localhost/internal/core/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// customerSearcher runs paginated customer searches, implemented by CustomerCollection
+type customerSearcher interface {
+	Search(ctx context.Context, tenantID string, search *corev1.CustomerSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Customer, *infrav1.PaginationResponse, error)
+}
+
+// vendorSearcher runs paginated vendor searches, implemented by VendorCollection
+type vendorSearcher interface {
+	Search(ctx context.Context, tenantID string, search *corev1.VendorSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Vendor, *infrav1.PaginationResponse, error)
+}
+
+// PartnerHandler stores the business partners of the tenants, their customers and vendors
+type PartnerHandler struct {
+	customerCollection collection_mongo.CollectionHandler[corev1.Customer]
+	customerSearch     customerSearcher
+	vendorCollection   collection_mongo.CollectionHandler[corev1.Vendor]
+	vendorSearch       vendorSearcher
+	logger             logger.Logger
+}
+
+func NewPartnerHandler(logger logger.Logger) (*PartnerHandler, error) {
+	customerCollection, err := collection_core.NewCustomerCollection(logger)
+	if err != nil {
+		logger.Error("failed to create customer collection handler", "error", err)
+		return nil, err
+	}
+	vendorCollection, err := collection_core.NewVendorCollection(logger)
+	if err != nil {
+		logger.Error("failed to create vendor collection handler", "error", err)
+		return nil, err
+	}
+	return &PartnerHandler{
+		customerCollection: customerCollection,
+		customerSearch:     customerCollection,
+		vendorCollection:   vendorCollection,
+		vendorSearch:       vendorCollection,
+		logger:             logger,
+	}, nil
+}
+
+/* Customers */
+
+func (p *PartnerHandler) CreateCustomer(ctx context.Context, customer *corev1.Customer) (string, error) {
+	if err := validator_core.ValidateCustomer(customer, true); err != nil {
+		return "", err
+	}
+	customer.CreatedAt = timestamppb.Now()
+	customer.UpdatedAt = customer.CreatedAt
+	customer.Version = 0
+	p.logger.Debug("Creating customer", "tenant_id", customer.GetTenantId(), "name", customer.GetName())
+	return p.customerCollection.Create(ctx, customer)
+}
+
+func (p *PartnerHandler) GetCustomerByID(ctx context.Context, tenantID, customerID string) (*corev1.Customer, error) {
+	if tenantID == "" || customerID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "CustomerId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       customerID,
+	}
+	p.logger.Debug("Getting customer by id", "filter", filter)
+	return findOne(ctx, p.customerCollection, filter, "customer", customerID)
+}
+
+// SearchCustomers returns a page of the tenant customers matching search
+func (p *PartnerHandler) SearchCustomers(ctx context.Context, tenantID string, search *corev1.CustomerSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Customer, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	p.logger.Debug("Searching customers", "tenant_id", tenantID, "search", search, "pagination", pagination)
+	return p.customerSearch.Search(ctx, tenantID, search, pagination)
+}
+
+// UpdateCustomer stores customer if it was not updated since it was read at its version, see
+// collection_mongo.CollectionHandler.UpdateVersioned. The version of customer is advanced on success
+func (p *PartnerHandler) UpdateCustomer(ctx context.Context, customer *corev1.Customer) error {
+	if err := validator_core.ValidateCustomer(customer, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": customer.TenantId,
+		"_id":       customer.Id,
+	}
+	customer.UpdatedAt = timestamppb.Now()
+	p.logger.Debug("Updating customer", "filter", filter, "version", customer.Version)
+	if err := p.customerCollection.UpdateVersioned(ctx, filter, customer, customer.Version); err != nil {
+		return err
+	}
+	customer.Version++
+	return nil
+}
+
+func (p *PartnerHandler) DeleteCustomer(ctx context.Context, tenantID, customerID string) error {
+	if tenantID == "" || customerID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "CustomerId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       customerID,
+	}
+	p.logger.Debug("Deleting customer", "filter", filter)
+	return p.customerCollection.Delete(ctx, filter)
+}
+
+/* Vendors */
+
+func (p *PartnerHandler) CreateVendor(ctx context.Context, vendor *corev1.Vendor) (string, error) {
+	if err := validator_core.ValidateVendor(vendor, true); err != nil {
+		return "", err
+	}
+	vendor.CreatedAt = timestamppb.Now()
+	vendor.UpdatedAt = vendor.CreatedAt
+	vendor.Version = 0
+	p.logger.Debug("Creating vendor", "tenant_id", vendor.GetTenantId(), "name", vendor.GetName())
+	return p.vendorCollection.Create(ctx, vendor)
+}
+
+func (p *PartnerHandler) GetVendorByID(ctx context.Context, tenantID, vendorID string) (*corev1.Vendor, error) {
+	if tenantID == "" || vendorID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "VendorId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       vendorID,
+	}
+	p.logger.Debug("Getting vendor by id", "filter", filter)
+	return findOne(ctx, p.vendorCollection, filter, "vendor", vendorID)
+}
+
+// SearchVendors returns a page of the tenant vendors matching search
+func (p *PartnerHandler) SearchVendors(ctx context.Context, tenantID string, search *corev1.VendorSearch, pagination *infrav1.PaginationRequest) ([]*corev1.Vendor, *infrav1.PaginationResponse, error) {
+	if tenantID == "" {
+		return nil, nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	p.logger.Debug("Searching vendors", "tenant_id", tenantID, "search", search, "pagination", pagination)
+	return p.vendorSearch.Search(ctx, tenantID, search, pagination)
+}
+
+// UpdateVendor stores vendor if it was not updated since it was read at its version, see
+// collection_mongo.CollectionHandler.UpdateVersioned. The version of vendor is advanced on success
+func (p *PartnerHandler) UpdateVendor(ctx context.Context, vendor *corev1.Vendor) error {
+	if err := validator_core.ValidateVendor(vendor, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": vendor.TenantId,
+		"_id":       vendor.Id,
+	}
+	vendor.UpdatedAt = timestamppb.Now()
+	p.logger.Debug("Updating vendor", "filter", filter, "version", vendor.Version)
+	if err := p.vendorCollection.UpdateVersioned(ctx, filter, vendor, vendor.Version); err != nil {
+		return err
+	}
+	vendor.Version++
+	return nil
+}
+
+func (p *PartnerHandler) DeleteVendor(ctx context.Context, tenantID, vendorID string) error {
+	if tenantID == "" || vendorID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "VendorId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       vendorID,
+	}
+	p.logger.Debug("Deleting vendor", "filter", filter)
+	return p.vendorCollection.Delete(ctx, filter)
+}
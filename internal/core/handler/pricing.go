@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+
+	collection_core "erp.localhost/internal/core/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PricingHandler struct {
+	collection collection_mongo.CollectionHandler[corev1.PricingRule]
+	logger     logger.Logger
+}
+
+func NewPricingHandler(logger logger.Logger) (*PricingHandler, error) {
+	collection, err := collection_core.NewPricingRuleCollection(logger)
+	if err != nil {
+		logger.Error("failed to create pricing rule collection handler", "error", err)
+		return nil, err
+	}
+	return &PricingHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+func (p *PricingHandler) CreateRule(ctx context.Context, rule *corev1.PricingRule) (string, error) {
+	if err := validator_core.ValidatePricingRule(rule, true); err != nil {
+		return "", err
+	}
+	rule.CreatedAt = timestamppb.Now()
+	rule.UpdatedAt = rule.CreatedAt
+	rule.Version = 0
+	p.logger.Debug("Creating pricing rule", "tenant_id", rule.GetTenantId(), "name", rule.GetName(), "type", rule.GetType())
+	return p.collection.Create(ctx, rule)
+}
+
+func (p *PricingHandler) GetRuleByID(ctx context.Context, tenantID, ruleID string) (*corev1.PricingRule, error) {
+	if tenantID == "" || ruleID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "RuleId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       ruleID,
+	}
+	p.logger.Debug("Getting pricing rule by id", "filter", filter)
+	return findOne(ctx, p.collection, filter, "pricing rule", ruleID)
+}
+
+// ListRules returns the rules of the tenant, of ruleType unless it is unspecified
+func (p *PricingHandler) ListRules(ctx context.Context, tenantID string, ruleType corev1.PricingRuleType) ([]*corev1.PricingRule, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+	}
+	if ruleType != corev1.PricingRuleType_PRICING_RULE_TYPE_UNSPECIFIED {
+		filter["type"] = int32(ruleType)
+	}
+	p.logger.Debug("Listing pricing rules", "filter", filter)
+	return p.collection.FindAll(ctx, filter)
+}
+
+// ActiveRules returns the active rules of the tenant
+func (p *PricingHandler) ActiveRules(ctx context.Context, tenantID string) ([]*corev1.PricingRule, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"active":    true,
+	}
+	p.logger.Debug("Getting active pricing rules", "filter", filter)
+	return p.collection.FindAll(ctx, filter)
+}
+
+// UpdateRule stores rule if it was not updated since it was read at its version, see
+// collection_mongo.CollectionHandler.UpdateVersioned. The version of rule is advanced on success
+func (p *PricingHandler) UpdateRule(ctx context.Context, rule *corev1.PricingRule) error {
+	if err := validator_core.ValidatePricingRule(rule, false); err != nil {
+		return err
+	}
+	filter := map[string]any{
+		"tenant_id": rule.TenantId,
+		"_id":       rule.Id,
+	}
+	rule.UpdatedAt = timestamppb.Now()
+	p.logger.Debug("Updating pricing rule", "filter", filter, "version", rule.Version)
+	if err := p.collection.UpdateVersioned(ctx, filter, rule, rule.Version); err != nil {
+		return err
+	}
+	rule.Version++
+	return nil
+}
+
+func (p *PricingHandler) DeleteRule(ctx context.Context, tenantID, ruleID string) error {
+	if tenantID == "" || ruleID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "RuleId")
+	}
+	filter := map[string]any{
+		"tenant_id": tenantID,
+		"_id":       ruleID,
+	}
+	p.logger.Debug("Deleting pricing rule", "filter", filter)
+	return p.collection.Delete(ctx, filter)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+
+	collection_core "erp.localhost/internal/core/collection"
+	collection_mongo "erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/db/mongo/query"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	validator_core "erp.localhost/internal/infra/model/core/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WarehouseHandler struct {
+	collection collection_mongo.CollectionHandler[corev1.Warehouse]
+	logger     logger.Logger
+}
+
+func NewWarehouseHandler(logger logger.Logger) (*WarehouseHandler, error) {
+	collection, err := collection_core.NewWarehouseCollection(logger)
+	if err != nil {
+		logger.Error("failed to create warehouse collection handler", "error", err)
+		return nil, err
+	}
+	return &WarehouseHandler{
+		collection: collection,
+		logger:     logger,
+	}, nil
+}
+
+func (w *WarehouseHandler) CreateWarehouse(ctx context.Context, warehouse *corev1.Warehouse) (string, error) {
+	if err := validator_core.ValidateWarehouse(warehouse, true); err != nil {
+		return "", err
+	}
+	warehouse.CreatedAt = timestamppb.Now()
+	warehouse.UpdatedAt = warehouse.CreatedAt
+	w.logger.Debug("Creating warehouse", "tenant_id", warehouse.GetTenantId(), "code", warehouse.GetCode())
+	return w.collection.Create(ctx, warehouse)
+}
+
+func (w *WarehouseHandler) GetWarehouseByID(ctx context.Context, tenantID, warehouseID string) (*corev1.Warehouse, error) {
+	if tenantID == "" || warehouseID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId", "WarehouseId")
+	}
+	filter, err := query.New[corev1.Warehouse]().Tenant(tenantID).ID(warehouseID).Build()
+	if err != nil {
+		return nil, err
+	}
+	w.logger.Debug("Getting warehouse by id", "filter", filter)
+	return findOne(ctx, w.collection, filter, "warehouse", warehouseID)
+}
+
+func (w *WarehouseHandler) GetWarehousesByTenantID(ctx context.Context, tenantID string) ([]*corev1.Warehouse, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter, err := query.New[corev1.Warehouse]().Tenant(tenantID).Build()
+	if err != nil {
+		return nil, err
+	}
+	w.logger.Debug("Getting warehouses by tenant id", "filter", filter)
+	return w.collection.FindAll(ctx, filter)
+}
+
+func (w *WarehouseHandler) UpdateWarehouse(ctx context.Context, warehouse *corev1.Warehouse) error {
+	if err := validator_core.ValidateWarehouse(warehouse, false); err != nil {
+		return err
+	}
+	filter, err := query.New[corev1.Warehouse]().Tenant(warehouse.TenantId).ID(warehouse.Id).Build()
+	if err != nil {
+		return err
+	}
+	warehouse.UpdatedAt = timestamppb.Now()
+	w.logger.Debug("Updating warehouse", "filter", filter)
+	return w.collection.Update(ctx, filter, warehouse)
+}
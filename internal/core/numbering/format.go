@@ -0,0 +1,70 @@
+package numbering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	yearToken      = "{YYYY}"
+	sequenceToken  = "{SEQ"
+	maxPadding     = 12
+	defaultPadding = 6
+)
+
+// Format is how the numbers of a document type are written, parsed from a pattern such as INV-{YYYY}-{SEQ:6}:
+// the text around the tokens is kept, {YYYY} is the year the number is given in and {SEQ:n} the sequence value
+// zero padded to n digits, {SEQ} pads to 6. A pattern with {YYYY} restarts its sequence every year
+type Format struct {
+	prefix      string
+	suffix      string
+	padding     int
+	yearlyReset bool
+}
+
+// ParseFormat parses pattern, it must hold a single {SEQ} token
+func ParseFormat(pattern string) (Format, error) {
+	start := strings.Index(pattern, sequenceToken)
+	if start < 0 || strings.Count(pattern, sequenceToken) > 1 {
+		return Format{}, fmt.Errorf("numbering format %q must hold a single {SEQ} token", pattern)
+	}
+	length := strings.Index(pattern[start:], "}")
+	if length < 0 {
+		return Format{}, fmt.Errorf("numbering format %q has an unclosed {SEQ} token", pattern)
+	}
+	padding := defaultPadding
+	if options := pattern[start+len(sequenceToken) : start+length]; options != "" {
+		value, err := strconv.Atoi(strings.TrimPrefix(options, ":"))
+		if err != nil || !strings.HasPrefix(options, ":") || value < 1 || value > maxPadding {
+			return Format{}, fmt.Errorf("numbering format %q must pad {SEQ} to 1-%d digits", pattern, maxPadding)
+		}
+		padding = value
+	}
+	format := Format{
+		prefix:      pattern[:start],
+		suffix:      pattern[start+length+1:],
+		padding:     padding,
+		yearlyReset: strings.Contains(pattern, yearToken),
+	}
+	if rest := strings.ReplaceAll(format.prefix+format.suffix, yearToken, ""); strings.ContainsAny(rest, "{}") {
+		return Format{}, fmt.Errorf("numbering format %q has an unknown token", pattern)
+	}
+	return format, nil
+}
+
+// Period returns the period of the sequence a number given in year belongs to, 0 when the sequence is never reset
+func (f Format) Period(year int) int32 {
+	if !f.yearlyReset {
+		return 0
+	}
+	return int32(year)
+}
+
+// Number writes the number with the sequence value given in year
+func (f Format) Number(year int, value int64) string {
+	yearText := strconv.Itoa(year)
+	return strings.ReplaceAll(f.prefix, yearToken, yearText) +
+		fmt.Sprintf("%0*d", f.padding, value) +
+		strings.ReplaceAll(f.suffix, yearToken, yearText)
+}
@@ -0,0 +1,95 @@
+package numbering
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+		period  int32
+		number  string
+		err     bool
+	}{
+		{name: "yearly", pattern: "INV-{YYYY}-{SEQ:6}", period: 2026, number: "INV-2026-000042"},
+		{name: "default padding", pattern: "SO{SEQ}", period: 0, number: "SO000042"},
+		{name: "year after the sequence", pattern: "{SEQ:3}/{YYYY}", period: 2026, number: "042/2026"},
+		{name: "value wider than the padding", pattern: "PO-{SEQ:1}", period: 0, number: "PO-42"},
+		{name: "no sequence", pattern: "INV-{YYYY}", err: true},
+		{name: "two sequences", pattern: "{SEQ}-{SEQ}", err: true},
+		{name: "unclosed sequence", pattern: "INV-{SEQ:4", err: true},
+		{name: "invalid padding", pattern: "INV-{SEQ:x}", err: true},
+		{name: "padding too wide", pattern: "INV-{SEQ:13}", err: true},
+		{name: "unknown token", pattern: "INV-{YY}-{SEQ}", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := ParseFormat(tc.pattern)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.period, format.Period(2026))
+			assert.Equal(t, tc.number, format.Number(2026, 42))
+		})
+	}
+}
+
+// memoryCounter keeps the sequences in memory
+type memoryCounter map[string]int64
+
+func (m memoryCounter) Next(_ context.Context, tenantID, document string, period int32) (int64, error) {
+	key := fmt.Sprintf("%s/%s/%d", tenantID, document, period)
+	m[key]++
+	return m[key], nil
+}
+
+func TestServiceNext(t *testing.T) {
+	formats, err := parseFormats(&Config{PurchaseOrder: "P{SEQ:4}"})
+	require.NoError(t, err)
+	service := &Service{counter: memoryCounter{}, formats: formats, logger: logger.NewBaseLogger(shared.ModuleCore)}
+	ctx := context.Background()
+	now := time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)
+
+	next := func(tenantID string, document Document, now time.Time) string {
+		number, err := service.Next(ctx, tenantID, document, now)
+		require.NoError(t, err)
+		return number
+	}
+	assert.Equal(t, "INV-2026-000001", next("tenant-1", DocumentInvoice, now))
+	assert.Equal(t, "INV-2026-000002", next("tenant-1", DocumentInvoice, now))
+	// Every tenant and document type has its own sequence
+	assert.Equal(t, "INV-2026-000001", next("tenant-2", DocumentInvoice, now))
+	assert.Equal(t, "SO-2026-000001", next("tenant-1", DocumentSalesOrder, now))
+	// Yearly sequences restart, the others continue
+	assert.Equal(t, "P0001", next("tenant-1", DocumentPurchaseOrder, now))
+	assert.Equal(t, "INV-2027-000001", next("tenant-1", DocumentInvoice, now.Add(2*time.Hour)))
+	assert.Equal(t, "P0002", next("tenant-1", DocumentPurchaseOrder, now.Add(2*time.Hour)))
+
+	_, err = service.Next(ctx, "tenant-1", Document("quote"), now)
+	assert.Error(t, err)
+
+	var numbers []string
+	err = service.Assign(ctx, "tenant-1", DocumentInvoice, now, func(ctx context.Context, number string) error {
+		numbers = append(numbers, number)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"INV-2026-000003"}, numbers)
+}
+
+func TestParseFormats_Invalid(t *testing.T) {
+	_, err := parseFormats(&Config{Invoice: "INV"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// Package numbering gives the documents of the tenants sequential numbers. Every document type of a tenant has its
+// own sequence, optionally restarted every year, kept in a counter incremented atomically in MongoDB
+package numbering
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	collection_core "erp.localhost/internal/core/collection"
+	"erp.localhost/internal/infra/db/mongo"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// Document is a document type with its own number sequence
+type Document string
+
+const (
+	DocumentSalesOrder    Document = "sales_order"
+	DocumentPurchaseOrder Document = "purchase_order"
+	DocumentTransferOrder Document = "transfer_order"
+	DocumentInvoice       Document = "invoice"
+)
+
+// Config holds the number formats of the document types, see ParseFormat
+type Config struct {
+	SalesOrder    string `yaml:"sales_order" env:"NUMBERING_SALES_ORDER" default:"SO-{YYYY}-{SEQ:6}"`
+	PurchaseOrder string `yaml:"purchase_order" env:"NUMBERING_PURCHASE_ORDER" default:"PO-{YYYY}-{SEQ:6}"`
+	TransferOrder string `yaml:"transfer_order" env:"NUMBERING_TRANSFER_ORDER" default:"TO-{YYYY}-{SEQ:6}"`
+	Invoice       string `yaml:"invoice" env:"NUMBERING_INVOICE" default:"INV-{YYYY}-{SEQ:6}"`
+}
+
+// counter allocates the values of the sequences, implemented by collection_core.DocumentCounterCollection
+type counter interface {
+	Next(ctx context.Context, tenantID, document string, period int32) (int64, error)
+}
+
+// Service gives the documents of the tenants their numbers
+type Service struct {
+	counter counter
+	formats map[Document]Format
+	logger  logger.Logger
+}
+
+func NewService(config *Config, logger logger.Logger) (*Service, error) {
+	formats, err := parseFormats(config)
+	if err != nil {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "numbering").WithError(err)
+	}
+	counter, err := collection_core.NewDocumentCounterCollection(logger)
+	if err != nil {
+		logger.Error("failed to create document counter collection handler", "error", err)
+		return nil, err
+	}
+	return &Service{
+		counter: counter,
+		formats: formats,
+		logger:  logger,
+	}, nil
+}
+
+// Next returns the next number of document for the tenant, given at now
+func (s *Service) Next(ctx context.Context, tenantID string, document Document, now time.Time) (string, error) {
+	format, ok := s.formats[document]
+	if !ok {
+		return "", infra_error.Validation(infra_error.ValidationInvalidValue, "document").WithDetails("document", string(document))
+	}
+	if tenantID == "" {
+		return "", infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	year := now.UTC().Year()
+	value, err := s.counter.Next(ctx, tenantID, string(document), format.Period(year))
+	if err != nil {
+		s.logger.Error("failed to allocate document number", "tenant_id", tenantID, "document", document, "error", err)
+		return "", err
+	}
+	return format.Number(year, value), nil
+}
+
+// Assign runs create with the next number of document for the tenant. On deployments with transactions the number is
+// taken in the transaction of create so a failed create gives the number back and the sequence has no gaps, without
+// them a failed create leaves a gap
+func (s *Service) Assign(ctx context.Context, tenantID string, document Document, now time.Time, create func(ctx context.Context, number string) error) error {
+	run := func(ctx context.Context) error {
+		number, err := s.Next(ctx, tenantID, document, now)
+		if err != nil {
+			return err
+		}
+		return create(ctx, number)
+	}
+	if transactor, ok := s.counter.(mongo.Transactor); ok {
+		err := transactor.WithTransaction(ctx, run)
+		if !errors.Is(err, mongo.ErrTransactionsUnsupported) {
+			return err
+		}
+	}
+	return run(ctx)
+}
+
+// parseFormats parses the formats of config, the document types config leaves empty have their default format
+func parseFormats(config *Config) (map[Document]Format, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	defaults := DefaultConfig()
+	configured := config.patterns()
+	formats := map[Document]Format{}
+	for document, pattern := range defaults.patterns() {
+		if configured[document] != "" {
+			pattern = configured[document]
+		}
+		format, err := ParseFormat(pattern)
+		if err != nil {
+			return nil, err
+		}
+		formats[document] = format
+	}
+	return formats, nil
+}
+
+func (c *Config) patterns() map[Document]string {
+	return map[Document]string{
+		DocumentSalesOrder:    c.SalesOrder,
+		DocumentPurchaseOrder: c.PurchaseOrder,
+		DocumentTransferOrder: c.TransferOrder,
+		DocumentInvoice:       c.Invoice,
+	}
+}
+
+// DefaultConfig returns the formats of the documents when none are configured, e.g. INV-2026-000001
+func DefaultConfig() Config {
+	return Config{
+		SalesOrder:    "SO-{YYYY}-{SEQ:6}",
+		PurchaseOrder: "PO-{YYYY}-{SEQ:6}",
+		TransferOrder: "TO-{YYYY}-{SEQ:6}",
+		Invoice:       "INV-{YYYY}-{SEQ:6}",
+	}
+}
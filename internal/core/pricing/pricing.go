@@ -0,0 +1,172 @@
+// Package pricing prices order lines with the pricing rules of a tenant. The price list of highest priority applying
+// to a line sets its unit price, then the largest discount of the quantity tiers and promotions applying to it is
+// taken off its subtotal. Every rule evaluated for a line is recorded in its trace
+package pricing
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+// Reasons of the trace steps of the rules that do not apply to a line
+const (
+	reasonInactive      = "inactive"
+	reasonNotStarted    = "not started"
+	reasonEnded         = "ended"
+	reasonCustomerGroup = "customer group does not match"
+	reasonProduct       = "product not in rule"
+	reasonBelowTiers    = "quantity below the tiers"
+	reasonUnknownType   = "unknown rule type"
+)
+
+// SortRules orders rules the way they are evaluated, highest priority first and then by name
+func SortRules(rules []*corev1.PricingRule) {
+	slices.SortStableFunc(rules, func(a, b *corev1.PricingRule) int {
+		return cmp.Or(cmp.Compare(b.GetPriority(), a.GetPriority()), strings.Compare(a.GetName(), b.GetName()))
+	})
+}
+
+// Calculate prices lines at at for a customer of customerGroup, an empty group only matches the rules of every
+// customer
+func Calculate(rules []*corev1.PricingRule, customerGroup string, lines []*corev1.PriceRequestLine, at time.Time) *corev1.PriceCalculation {
+	ordered := slices.Clone(rules)
+	SortRules(ordered)
+	calculation := &corev1.PriceCalculation{
+		Lines:         make([]*corev1.PricedLine, 0, len(lines)),
+		CustomerGroup: customerGroup,
+	}
+	for _, line := range lines {
+		priced := priceLine(ordered, customerGroup, line, at)
+		calculation.Lines = append(calculation.Lines, priced)
+		calculation.Subtotal += priced.Subtotal
+		calculation.Discount += priced.Discount
+	}
+	calculation.Subtotal = round(calculation.Subtotal)
+	calculation.Discount = round(calculation.Discount)
+	calculation.Total = round(calculation.Subtotal - calculation.Discount)
+	return calculation
+}
+
+// priceLine prices line with rules, ordered by SortRules
+func priceLine(rules []*corev1.PricingRule, customerGroup string, line *corev1.PriceRequestLine, at time.Time) *corev1.PricedLine {
+	priced := &corev1.PricedLine{
+		ProductId: line.GetProductId(),
+		Quantity:  line.GetQuantity(),
+		ListPrice: round(line.GetUnitPrice()),
+		UnitPrice: round(line.GetUnitPrice()),
+	}
+
+	var priceList *corev1.PricingRule
+	for _, rule := range rules {
+		if rule.GetType() != corev1.PricingRuleType_PRICING_RULE_TYPE_PRICE_LIST {
+			continue
+		}
+		step := traceStep(rule)
+		priced.Trace = append(priced.Trace, step)
+		if step.Reason = skipReason(rule, customerGroup, line.GetProductId(), at); step.Reason != "" {
+			continue
+		}
+		if priceList != nil {
+			step.Reason = "overridden by " + priceList.GetName()
+			continue
+		}
+		priceList = rule
+		priced.UnitPrice = round(rule.GetUnitPrice())
+		step.Applied = true
+		step.Reason = fmt.Sprintf("unit price %.2f", priced.UnitPrice)
+	}
+	priced.Subtotal = round(float64(priced.Quantity) * priced.UnitPrice)
+
+	var best *corev1.PricingTraceStep
+	for _, rule := range rules {
+		if rule.GetType() == corev1.PricingRuleType_PRICING_RULE_TYPE_PRICE_LIST {
+			continue
+		}
+		step := traceStep(rule)
+		priced.Trace = append(priced.Trace, step)
+		if step.Reason = skipReason(rule, customerGroup, line.GetProductId(), at); step.Reason != "" {
+			continue
+		}
+		discount, reason := ruleDiscount(rule, priced.Quantity, priced.Subtotal)
+		if reason != "" {
+			step.Reason = reason
+			continue
+		}
+		// The first of equal discounts, of the higher priority, is kept
+		if best != nil && discount <= priced.Discount {
+			step.Reason = fmt.Sprintf("discount %.2f, not above %.2f of %s", discount, priced.Discount, best.RuleName)
+			continue
+		}
+		if best != nil {
+			best.Applied = false
+			best.Reason = fmt.Sprintf("discount %.2f, below %.2f of %s", priced.Discount, discount, rule.GetName())
+		}
+		best = step
+		priced.Discount = discount
+		step.Applied = true
+		step.Reason = fmt.Sprintf("discount %.2f", discount)
+	}
+	priced.Total = round(priced.Subtotal - priced.Discount)
+	return priced
+}
+
+// skipReason returns why rule does not apply to a line of productID priced at at for a customer of customerGroup,
+// empty when it applies
+func skipReason(rule *corev1.PricingRule, customerGroup, productID string, at time.Time) string {
+	switch {
+	case !rule.GetActive():
+		return reasonInactive
+	case rule.StartsAt != nil && at.Before(rule.StartsAt.AsTime()):
+		return reasonNotStarted
+	case rule.EndsAt != nil && !at.Before(rule.EndsAt.AsTime()):
+		return reasonEnded
+	case rule.GetCustomerGroup() != "" && !strings.EqualFold(rule.GetCustomerGroup(), customerGroup):
+		return reasonCustomerGroup
+	case len(rule.GetProductIds()) > 0 && !slices.Contains(rule.GetProductIds(), productID):
+		return reasonProduct
+	}
+	return ""
+}
+
+// ruleDiscount returns the discount of a quantity tiers or promotion rule on a line of quantity and subtotal, at most
+// the subtotal, or why the rule gives none
+func ruleDiscount(rule *corev1.PricingRule, quantity int32, subtotal float64) (float64, string) {
+	var discount float64
+	switch rule.GetType() {
+	case corev1.PricingRuleType_PRICING_RULE_TYPE_QUANTITY_TIERS:
+		var tier *corev1.PriceTier
+		for _, candidate := range rule.GetTiers() {
+			if candidate.GetMinQuantity() <= quantity && (tier == nil || candidate.GetMinQuantity() > tier.GetMinQuantity()) {
+				tier = candidate
+			}
+		}
+		if tier == nil {
+			return 0, reasonBelowTiers
+		}
+		discount = subtotal * tier.GetDiscountPercent()
+	case corev1.PricingRuleType_PRICING_RULE_TYPE_PROMOTION:
+		discount = subtotal*rule.GetDiscountPercent() + float64(quantity)*rule.GetDiscountAmount()
+	default:
+		return 0, reasonUnknownType
+	}
+	return round(min(discount, subtotal)), ""
+}
+
+func traceStep(rule *corev1.PricingRule) *corev1.PricingTraceStep {
+	return &corev1.PricingTraceStep{
+		RuleId:   rule.GetId(),
+		RuleName: rule.GetName(),
+		Type:     rule.GetType(),
+	}
+}
+
+// round rounds amount to cents, half away from zero
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,94 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCalculate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rules := []*corev1.PricingRule{
+		{Id: "r1", Name: "retail", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_PRICE_LIST, Active: true, ProductIds: []string{"p1"}, UnitPrice: 10},
+		{Id: "r2", Name: "wholesale", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_PRICE_LIST, Active: true, Priority: 10, ProductIds: []string{"p1"}, CustomerGroup: "wholesale", UnitPrice: 8},
+		{Id: "r3", Name: "volume", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_QUANTITY_TIERS, Active: true, Tiers: []*corev1.PriceTier{
+			{MinQuantity: 10, DiscountPercent: 0.05},
+			{MinQuantity: 50, DiscountPercent: 0.1},
+		}},
+		{Id: "r4", Name: "spring sale", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_PROMOTION, Active: true, DiscountAmount: 0.5,
+			StartsAt: timestamppb.New(now.AddDate(0, 0, -1)), EndsAt: timestamppb.New(now.AddDate(0, 0, 1))},
+		{Id: "r5", Name: "expired", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_PROMOTION, Active: true, DiscountPercent: 0.5, EndsAt: timestamppb.New(now)},
+		{Id: "r6", Name: "draft", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_PROMOTION, DiscountPercent: 0.5},
+	}
+	lines := []*corev1.PriceRequestLine{
+		{ProductId: "p1", Quantity: 60, UnitPrice: 12},
+		{ProductId: "p2", Quantity: 2, UnitPrice: 3},
+	}
+
+	calculation := Calculate(rules, "Wholesale", lines, now)
+	require.Len(t, calculation.Lines, 2)
+
+	// The wholesale price list has the higher priority, 10% off 480 beats 0.50 off each of the 60 units
+	line := calculation.Lines[0]
+	assert.Equal(t, 12.0, line.ListPrice)
+	assert.Equal(t, 8.0, line.UnitPrice)
+	assert.Equal(t, 480.0, line.Subtotal)
+	assert.Equal(t, 48.0, line.Discount)
+	assert.Equal(t, 432.0, line.Total)
+	assert.Equal(t, []string{"wholesale", "retail", "draft", "expired", "spring sale", "volume"}, traceRules(line))
+	assert.Equal(t, []bool{true, false, false, false, false, true}, traceApplied(line))
+	assert.Equal(t, "overridden by wholesale", line.Trace[1].Reason)
+	assert.Equal(t, "inactive", line.Trace[2].Reason)
+	assert.Equal(t, "ended", line.Trace[3].Reason)
+	assert.Equal(t, "discount 30.00, below 48.00 of volume", line.Trace[4].Reason)
+
+	// No price list applies and the quantity is below the tiers
+	line = calculation.Lines[1]
+	assert.Equal(t, 3.0, line.UnitPrice)
+	assert.Equal(t, 1.0, line.Discount)
+	assert.Equal(t, "product not in rule", line.Trace[0].Reason)
+	assert.True(t, line.Trace[4].Applied)
+	assert.Equal(t, "quantity below the tiers", line.Trace[5].Reason)
+
+	assert.Equal(t, 486.0, calculation.Subtotal)
+	assert.Equal(t, 49.0, calculation.Discount)
+	assert.Equal(t, 437.0, calculation.Total)
+
+	// Without the group the retail price applies
+	calculation = Calculate(rules, "", lines[:1], now)
+	line = calculation.Lines[0]
+	assert.Equal(t, 10.0, line.UnitPrice)
+	assert.Equal(t, 60.0, line.Discount)
+	assert.Equal(t, "customer group does not match", line.Trace[0].Reason)
+	assert.Equal(t, []bool{false, true, false, false, false, true}, traceApplied(line))
+	assert.Equal(t, "discount 30.00, below 60.00 of volume", line.Trace[4].Reason)
+}
+
+func TestCalculate_DiscountCappedAtSubtotal(t *testing.T) {
+	rules := []*corev1.PricingRule{
+		{Name: "clearance", Type: corev1.PricingRuleType_PRICING_RULE_TYPE_PROMOTION, Active: true, DiscountPercent: 0.5, DiscountAmount: 3},
+	}
+	calculation := Calculate(rules, "", []*corev1.PriceRequestLine{{ProductId: "p1", Quantity: 2, UnitPrice: 4}}, time.Now())
+	assert.Equal(t, 8.0, calculation.Lines[0].Discount)
+	assert.Equal(t, 0.0, calculation.Total)
+}
+
+func traceRules(line *corev1.PricedLine) []string {
+	names := make([]string, 0, len(line.Trace))
+	for _, step := range line.Trace {
+		names = append(names, step.RuleName)
+	}
+	return names
+}
+
+func traceApplied(line *corev1.PricedLine) []bool {
+	applied := make([]bool, 0, len(line.Trace))
+	for _, step := range line.Trace {
+		applied = append(applied, step.Applied)
+	}
+	return applied
+}
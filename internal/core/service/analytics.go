@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/core/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+type AnalyticsService struct {
+	logger       logger.Logger
+	analyticsAPI *api.AnalyticsAPI
+	corev1.UnimplementedAnalyticsServiceServer
+}
+
+func NewAnalyticsService(analyticsAPI *api.AnalyticsAPI, logger logger.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		logger:       logger,
+		analyticsAPI: analyticsAPI,
+	}
+}
+
+func (s *AnalyticsService) SalesByPeriod(ctx context.Context, req *corev1.SalesByPeriodRequest) (*corev1.SalesByPeriodResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	periods, currency, pagination, err := s.analyticsAPI.SalesByPeriod(ctx, tenantID, userID, req.GetRange(), req.GetPeriod(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to report sales by period", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.SalesByPeriodResponse{
+		Periods:    periods,
+		Currency:   currency,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *AnalyticsService) TopProducts(ctx context.Context, req *corev1.TopProductsRequest) (*corev1.TopProductsResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	products, currency, pagination, err := s.analyticsAPI.TopProducts(ctx, tenantID, userID, req.GetRange(), req.GetSort(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to report top products", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.TopProductsResponse{
+		Products:   products,
+		Currency:   currency,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *AnalyticsService) InventoryValuation(ctx context.Context, req *corev1.InventoryValuationRequest) (*corev1.InventoryValuationResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	products, quantity, value, pagination, err := s.analyticsAPI.InventoryValuation(ctx, tenantID, userID, req.GetWarehouseId(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to report inventory valuation", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.InventoryValuationResponse{
+		Products:      products,
+		TotalQuantity: quantity,
+		TotalValue:    value,
+		Pagination:    pagination,
+	}, nil
+}
+
+func (s *AnalyticsService) UserActivity(ctx context.Context, req *corev1.UserActivityRequest) (*corev1.UserActivityResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	users, pagination, err := s.analyticsAPI.UserActivity(ctx, tenantID, userID, req.GetRange(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to report user activity", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UserActivityResponse{
+		Users:      users,
+		Pagination: pagination,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/core/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+type CurrencyService struct {
+	logger      logger.Logger
+	currencyAPI *api.CurrencyAPI
+	corev1.UnimplementedCurrencyServiceServer
+}
+
+func NewCurrencyService(currencyAPI *api.CurrencyAPI, logger logger.Logger) *CurrencyService {
+	return &CurrencyService{
+		logger:      logger,
+		currencyAPI: currencyAPI,
+	}
+}
+
+func (s *CurrencyService) GetCurrencySettings(ctx context.Context, req *corev1.GetCurrencySettingsRequest) (*corev1.GetCurrencySettingsResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	settings, err := s.currencyAPI.GetCurrencySettings(ctx, tenantID, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get currency settings", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetCurrencySettingsResponse{
+		Settings: settings,
+	}, nil
+}
+
+func (s *CurrencyService) UpdateCurrencySettings(ctx context.Context, req *corev1.UpdateCurrencySettingsRequest) (*corev1.UpdateCurrencySettingsResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	settings, err := s.currencyAPI.UpdateCurrencySettings(ctx, tenantID, userID, req.GetBaseCurrency())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to update currency settings", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UpdateCurrencySettingsResponse{
+		Settings: settings,
+	}, nil
+}
+
+func (s *CurrencyService) CreateExchangeRate(ctx context.Context, req *corev1.CreateExchangeRateRequest) (*corev1.CreateExchangeRateResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	rate, err := s.currencyAPI.CreateExchangeRate(ctx, tenantID, userID, req.GetRate())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to create exchange rate", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CreateExchangeRateResponse{
+		Rate: rate,
+	}, nil
+}
+
+func (s *CurrencyService) SearchExchangeRates(ctx context.Context, req *corev1.SearchExchangeRatesRequest) (*corev1.SearchExchangeRatesResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	rates, pagination, err := s.currencyAPI.SearchExchangeRates(ctx, tenantID, userID, req.GetSearch(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to search exchange rates", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.SearchExchangeRatesResponse{
+		Rates:      rates,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *CurrencyService) ConvertAmount(ctx context.Context, req *corev1.ConvertAmountRequest) (*corev1.ConvertAmountResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	at := time.Now()
+	if req.GetAt() != nil {
+		at = req.GetAt().AsTime()
+	}
+	amount, rate, err := s.currencyAPI.ConvertAmount(ctx, tenantID, userID, req.GetAmount(), req.GetFromCurrency(), req.GetToCurrency(), at)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to convert amount", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ConvertAmountResponse{
+		Amount: amount,
+		Rate:   rate,
+	}, nil
+}
+
+func (s *CurrencyService) ImportExchangeRates(ctx context.Context, req *corev1.ImportExchangeRatesRequest) (*corev1.ImportExchangeRatesResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	rates, err := s.currencyAPI.ImportExchangeRates(ctx, tenantID, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to import exchange rates", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ImportExchangeRatesResponse{
+		Rates: rates,
+	}, nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/core/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+type InventoryService struct {
+	logger       logger.Logger
+	inventoryAPI *api.InventoryAPI
+	corev1.UnimplementedInventoryServiceServer
+}
+
+func NewInventoryService(inventoryAPI *api.InventoryAPI, logger logger.Logger) *InventoryService {
+	return &InventoryService{
+		logger:       logger,
+		inventoryAPI: inventoryAPI,
+	}
+}
+
+func (s *InventoryService) CreateWarehouse(ctx context.Context, req *corev1.CreateWarehouseRequest) (*corev1.CreateWarehouseResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	warehouse, err := s.inventoryAPI.CreateWarehouse(ctx, tenantID, userID, req.GetWarehouse())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to create warehouse", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CreateWarehouseResponse{
+		Warehouse: warehouse,
+	}, nil
+}
+
+func (s *InventoryService) GetWarehouse(ctx context.Context, req *corev1.GetWarehouseRequest) (*corev1.GetWarehouseResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	warehouse, err := s.inventoryAPI.GetWarehouse(ctx, tenantID, userID, req.GetWarehouseId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get warehouse", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetWarehouseResponse{
+		Warehouse: warehouse,
+	}, nil
+}
+
+func (s *InventoryService) ListWarehouses(ctx context.Context, req *corev1.ListWarehousesRequest) (*corev1.ListWarehousesResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	warehouses, err := s.inventoryAPI.ListWarehouses(ctx, tenantID, userID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to list warehouses", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ListWarehousesResponse{
+		Warehouses: warehouses,
+	}, nil
+}
+
+func (s *InventoryService) UpdateWarehouse(ctx context.Context, req *corev1.UpdateWarehouseRequest) (*corev1.UpdateWarehouseResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	warehouse, err := s.inventoryAPI.UpdateWarehouse(ctx, tenantID, userID, req.GetWarehouse(), req.GetUpdateMask())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to update warehouse", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UpdateWarehouseResponse{
+		Warehouse: warehouse,
+	}, nil
+}
+
+func (s *InventoryService) GetStockItem(ctx context.Context, req *corev1.GetStockItemRequest) (*corev1.GetStockItemResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	item, err := s.inventoryAPI.GetStockItem(ctx, tenantID, userID, req.GetStockItemId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get stock item", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetStockItemResponse{
+		StockItem: item,
+	}, nil
+}
+
+func (s *InventoryService) ListStockItems(ctx context.Context, req *corev1.ListStockItemsRequest) (*corev1.ListStockItemsResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	items, err := s.inventoryAPI.ListStockItems(ctx, tenantID, userID, req.GetWarehouseId(), req.GetProductId(), req.GetLowStockOnly())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to list stock items", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ListStockItemsResponse{
+		StockItems: items,
+	}, nil
+}
+
+func (s *InventoryService) UpdateStockItem(ctx context.Context, req *corev1.UpdateStockItemRequest) (*corev1.UpdateStockItemResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	item, err := s.inventoryAPI.UpdateStockItem(ctx, tenantID, userID, req.GetStockItemId(), req.LowStockThreshold, req.GetLocation())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to update stock item", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UpdateStockItemResponse{
+		StockItem: item,
+	}, nil
+}
+
+func (s *InventoryService) RecordStockMovement(ctx context.Context, req *corev1.RecordStockMovementRequest) (*corev1.RecordStockMovementResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	movement, items, err := s.inventoryAPI.RecordStockMovement(ctx, tenantID, userID, req.GetMovement())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to record stock movement", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.RecordStockMovementResponse{
+		Movement:   movement,
+		StockItems: items,
+	}, nil
+}
+
+func (s *InventoryService) ListStockMovements(ctx context.Context, req *corev1.ListStockMovementsRequest) (*corev1.ListStockMovementsResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	movements, err := s.inventoryAPI.ListStockMovements(ctx, tenantID, userID, req.GetProductId(), req.GetWarehouseId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to list stock movements", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ListStockMovementsResponse{
+		Movements: movements,
+	}, nil
+}
+
+func (s *InventoryService) ReserveStock(ctx context.Context, req *corev1.ReserveStockRequest) (*corev1.ReserveStockResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	reservation, err := s.inventoryAPI.ReserveStock(ctx, tenantID, userID, req.GetReference(), req.GetLines())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to reserve stock", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ReserveStockResponse{
+		Reservation: reservation,
+	}, nil
+}
+
+func (s *InventoryService) ReleaseStock(ctx context.Context, req *corev1.ReleaseStockRequest) (*corev1.ReleaseStockResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	reservation, err := s.inventoryAPI.ReleaseStock(ctx, tenantID, userID, req.GetReservationId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to release stock", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ReleaseStockResponse{
+		Reservation: reservation,
+	}, nil
+}
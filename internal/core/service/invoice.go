@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/core/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+type InvoiceService struct {
+	logger     logger.Logger
+	invoiceAPI *api.InvoiceAPI
+	corev1.UnimplementedInvoiceServiceServer
+}
+
+func NewInvoiceService(invoiceAPI *api.InvoiceAPI, logger logger.Logger) *InvoiceService {
+	return &InvoiceService{
+		logger:     logger,
+		invoiceAPI: invoiceAPI,
+	}
+}
+
+func (s *InvoiceService) CreateInvoice(ctx context.Context, req *corev1.CreateInvoiceRequest) (*corev1.CreateInvoiceResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	invoice, err := s.invoiceAPI.CreateInvoice(ctx, tenantID, userID, req.GetOrderId(), req.GetNotes())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to create invoice", "tenant_id", tenantID, "user_id", userID, "order_id", req.GetOrderId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CreateInvoiceResponse{
+		Invoice: invoice,
+	}, nil
+}
+
+func (s *InvoiceService) GetInvoice(ctx context.Context, req *corev1.GetInvoiceRequest) (*corev1.GetInvoiceResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	invoice, err := s.invoiceAPI.GetInvoice(ctx, tenantID, userID, req.GetInvoiceId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get invoice", "tenant_id", tenantID, "user_id", userID, "invoice_id", req.GetInvoiceId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetInvoiceResponse{
+		Invoice: invoice,
+	}, nil
+}
+
+func (s *InvoiceService) SearchInvoices(ctx context.Context, req *corev1.SearchInvoicesRequest) (*corev1.SearchInvoicesResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	invoices, pagination, err := s.invoiceAPI.SearchInvoices(ctx, tenantID, userID, req.GetSearch(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to search invoices", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.SearchInvoicesResponse{
+		Invoices:   invoices,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *InvoiceService) RecordPayment(ctx context.Context, req *corev1.RecordPaymentRequest) (*corev1.RecordPaymentResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	invoice, err := s.invoiceAPI.RecordPayment(ctx, tenantID, userID, req.GetInvoiceId(), req.GetPayment())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to record payment", "tenant_id", tenantID, "user_id", userID, "invoice_id", req.GetInvoiceId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.RecordPaymentResponse{
+		Invoice: invoice,
+	}, nil
+}
+
+func (s *InvoiceService) GetInvoiceDocument(ctx context.Context, req *corev1.GetInvoiceDocumentRequest) (*corev1.GetInvoiceDocumentResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	document, err := s.invoiceAPI.GetInvoiceDocument(ctx, tenantID, userID, req.GetInvoiceId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get invoice document", "tenant_id", tenantID, "user_id", userID, "invoice_id", req.GetInvoiceId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetInvoiceDocumentResponse{
+		Document: document,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/core/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+type OrderService struct {
+	logger   logger.Logger
+	orderAPI *api.OrderAPI
+	corev1.UnimplementedOrderServiceServer
+}
+
+func NewOrderService(orderAPI *api.OrderAPI, logger logger.Logger) *OrderService {
+	return &OrderService{
+		logger:   logger,
+		orderAPI: orderAPI,
+	}
+}
+
+func (s *OrderService) CreateOrder(ctx context.Context, req *corev1.CreateOrderRequest) (*corev1.CreateOrderResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	order, err := s.orderAPI.CreateOrder(ctx, tenantID, userID, req.GetOrder())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to create order", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CreateOrderResponse{
+		Order: order,
+	}, nil
+}
+
+func (s *OrderService) GetOrder(ctx context.Context, req *corev1.GetOrderRequest) (*corev1.GetOrderResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	order, err := s.orderAPI.GetOrder(ctx, tenantID, userID, req.GetOrderId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get order", "tenant_id", tenantID, "user_id", userID, "order_id", req.GetOrderId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetOrderResponse{
+		Order: order,
+	}, nil
+}
+
+func (s *OrderService) SearchOrders(ctx context.Context, req *corev1.SearchOrdersRequest) (*corev1.SearchOrdersResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	orders, pagination, err := s.orderAPI.SearchOrders(ctx, tenantID, userID, req.GetSearch(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to search orders", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.SearchOrdersResponse{
+		Orders:     orders,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *OrderService) UpdateOrder(ctx context.Context, req *corev1.UpdateOrderRequest) (*corev1.UpdateOrderResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	order, err := s.orderAPI.UpdateOrder(ctx, tenantID, userID, req.GetOrder(), req.GetUpdateMask())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to update order", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UpdateOrderResponse{
+		Order: order,
+	}, nil
+}
+
+func (s *OrderService) ChangeOrderStatus(ctx context.Context, req *corev1.ChangeOrderStatusRequest) (*corev1.ChangeOrderStatusResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	order, err := s.orderAPI.ChangeOrderStatus(ctx, tenantID, userID, req.GetOrderId(), req.GetStatus(), req.GetNotes(), req.GetFulfillment())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to change order status", "tenant_id", tenantID, "user_id", userID, "order_id", req.GetOrderId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ChangeOrderStatusResponse{
+		Order: order,
+	}, nil
+}
+
+func (s *OrderService) DeleteOrder(ctx context.Context, req *corev1.DeleteOrderRequest) (*corev1.DeleteOrderResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := s.orderAPI.DeleteOrder(ctx, tenantID, userID, req.GetOrderId()); err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete order", "tenant_id", tenantID, "user_id", userID, "order_id", req.GetOrderId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.DeleteOrderResponse{
+		Deleted: true,
+	}, nil
+}
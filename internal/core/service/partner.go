@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+
+	"erp.localhost/internal/core/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+type PartnerService struct {
+	logger     logger.Logger
+	partnerAPI *api.PartnerAPI
+	corev1.UnimplementedPartnerServiceServer
+}
+
+func NewPartnerService(partnerAPI *api.PartnerAPI, logger logger.Logger) *PartnerService {
+	return &PartnerService{
+		logger:     logger,
+		partnerAPI: partnerAPI,
+	}
+}
+
+func (s *PartnerService) CreateCustomer(ctx context.Context, req *corev1.CreateCustomerRequest) (*corev1.CreateCustomerResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	customer, err := s.partnerAPI.CreateCustomer(ctx, tenantID, userID, req.GetCustomer())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to create customer", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CreateCustomerResponse{
+		Customer: customer,
+	}, nil
+}
+
+func (s *PartnerService) GetCustomer(ctx context.Context, req *corev1.GetCustomerRequest) (*corev1.GetCustomerResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	customer, err := s.partnerAPI.GetCustomer(ctx, tenantID, userID, req.GetCustomerId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get customer", "tenant_id", tenantID, "user_id", userID, "customer_id", req.GetCustomerId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetCustomerResponse{
+		Customer: customer,
+	}, nil
+}
+
+func (s *PartnerService) SearchCustomers(ctx context.Context, req *corev1.SearchCustomersRequest) (*corev1.SearchCustomersResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	customers, pagination, err := s.partnerAPI.SearchCustomers(ctx, tenantID, userID, req.GetSearch(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to search customers", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.SearchCustomersResponse{
+		Customers:  customers,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *PartnerService) UpdateCustomer(ctx context.Context, req *corev1.UpdateCustomerRequest) (*corev1.UpdateCustomerResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	customer, err := s.partnerAPI.UpdateCustomer(ctx, tenantID, userID, req.GetCustomer(), req.GetUpdateMask())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to update customer", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UpdateCustomerResponse{
+		Customer: customer,
+	}, nil
+}
+
+func (s *PartnerService) DeleteCustomer(ctx context.Context, req *corev1.DeleteCustomerRequest) (*corev1.DeleteCustomerResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	deleted, err := s.partnerAPI.DeleteCustomer(ctx, tenantID, userID, req.GetCustomerId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete customer", "tenant_id", tenantID, "user_id", userID, "customer_id", req.GetCustomerId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.DeleteCustomerResponse{
+		Deleted: deleted,
+	}, nil
+}
+
+func (s *PartnerService) CreateVendor(ctx context.Context, req *corev1.CreateVendorRequest) (*corev1.CreateVendorResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	vendor, err := s.partnerAPI.CreateVendor(ctx, tenantID, userID, req.GetVendor())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to create vendor", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CreateVendorResponse{
+		Vendor: vendor,
+	}, nil
+}
+
+func (s *PartnerService) GetVendor(ctx context.Context, req *corev1.GetVendorRequest) (*corev1.GetVendorResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	vendor, err := s.partnerAPI.GetVendor(ctx, tenantID, userID, req.GetVendorId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get vendor", "tenant_id", tenantID, "user_id", userID, "vendor_id", req.GetVendorId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetVendorResponse{
+		Vendor: vendor,
+	}, nil
+}
+
+func (s *PartnerService) SearchVendors(ctx context.Context, req *corev1.SearchVendorsRequest) (*corev1.SearchVendorsResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	vendors, pagination, err := s.partnerAPI.SearchVendors(ctx, tenantID, userID, req.GetSearch(), req.GetPagination())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to search vendors", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.SearchVendorsResponse{
+		Vendors:    vendors,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *PartnerService) UpdateVendor(ctx context.Context, req *corev1.UpdateVendorRequest) (*corev1.UpdateVendorResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	vendor, err := s.partnerAPI.UpdateVendor(ctx, tenantID, userID, req.GetVendor(), req.GetUpdateMask())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to update vendor", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UpdateVendorResponse{
+		Vendor: vendor,
+	}, nil
+}
+
+func (s *PartnerService) DeleteVendor(ctx context.Context, req *corev1.DeleteVendorRequest) (*corev1.DeleteVendorResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	deleted, err := s.partnerAPI.DeleteVendor(ctx, tenantID, userID, req.GetVendorId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete vendor", "tenant_id", tenantID, "user_id", userID, "vendor_id", req.GetVendorId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.DeleteVendorResponse{
+		Deleted: deleted,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/core/api"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+type PricingService struct {
+	logger     logger.Logger
+	pricingAPI *api.PricingAPI
+	corev1.UnimplementedPricingServiceServer
+}
+
+func NewPricingService(pricingAPI *api.PricingAPI, logger logger.Logger) *PricingService {
+	return &PricingService{
+		logger:     logger,
+		pricingAPI: pricingAPI,
+	}
+}
+
+func (s *PricingService) CreatePricingRule(ctx context.Context, req *corev1.CreatePricingRuleRequest) (*corev1.CreatePricingRuleResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	rule, err := s.pricingAPI.CreatePricingRule(ctx, tenantID, userID, req.GetRule())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to create pricing rule", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CreatePricingRuleResponse{
+		Rule: rule,
+	}, nil
+}
+
+func (s *PricingService) GetPricingRule(ctx context.Context, req *corev1.GetPricingRuleRequest) (*corev1.GetPricingRuleResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	rule, err := s.pricingAPI.GetPricingRule(ctx, tenantID, userID, req.GetRuleId())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to get pricing rule", "tenant_id", tenantID, "user_id", userID, "rule_id", req.GetRuleId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.GetPricingRuleResponse{
+		Rule: rule,
+	}, nil
+}
+
+func (s *PricingService) ListPricingRules(ctx context.Context, req *corev1.ListPricingRulesRequest) (*corev1.ListPricingRulesResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	rules, err := s.pricingAPI.ListPricingRules(ctx, tenantID, userID, req.GetType())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to list pricing rules", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.ListPricingRulesResponse{
+		Rules: rules,
+	}, nil
+}
+
+func (s *PricingService) UpdatePricingRule(ctx context.Context, req *corev1.UpdatePricingRuleRequest) (*corev1.UpdatePricingRuleResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	rule, err := s.pricingAPI.UpdatePricingRule(ctx, tenantID, userID, req.GetRule(), req.GetUpdateMask())
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to update pricing rule", "tenant_id", tenantID, "user_id", userID, "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.UpdatePricingRuleResponse{
+		Rule: rule,
+	}, nil
+}
+
+func (s *PricingService) DeletePricingRule(ctx context.Context, req *corev1.DeletePricingRuleRequest) (*corev1.DeletePricingRuleResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	if err := s.pricingAPI.DeletePricingRule(ctx, tenantID, userID, req.GetRuleId()); err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete pricing rule", "tenant_id", tenantID, "user_id", userID, "rule_id", req.GetRuleId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.DeletePricingRuleResponse{
+		Deleted: true,
+	}, nil
+}
+
+func (s *PricingService) CalculatePrice(ctx context.Context, req *corev1.CalculatePriceRequest) (*corev1.CalculatePriceResponse, error) {
+	identifier := req.GetIdentifier()
+	tenantID := identifier.GetTenantId()
+	userID := identifier.GetUserId()
+
+	at := time.Now()
+	if req.GetAt() != nil {
+		at = req.GetAt().AsTime()
+	}
+	calculation, err := s.pricingAPI.CalculatePrice(ctx, tenantID, userID, req.GetCustomerId(), req.GetLines(), at)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to calculate price", "tenant_id", tenantID, "user_id", userID, "customer_id", req.GetCustomerId(), "error", err)
+		return nil, infra_error.ToGRPCError(err)
+	}
+	return &corev1.CalculatePriceResponse{
+		Calculation: calculation,
+	}, nil
+}
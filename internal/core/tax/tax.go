@@ -0,0 +1,78 @@
+// Package tax decides the tax rates of invoice lines. The rates come from a chain of rules, the first rule that
+// applies to a line gives its rate
+package tax
+
+import (
+	"strings"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+)
+
+// Rule decides the tax rate of invoice lines
+type Rule interface {
+	// Name identifies the rule in the invoice lines and the tax summary of the invoice documents
+	Name() string
+	// Rate returns the rate of line on invoice, false when the rule does not apply to the line
+	Rate(invoice *corev1.Invoice, line *corev1.InvoiceLine) (float64, bool)
+}
+
+// Chain is an ordered list of rules, the first rule that applies to a line gives its rate
+type Chain []Rule
+
+// DefaultChain keeps the rates of the order lines
+func DefaultChain() Chain {
+	return Chain{LineRate{}}
+}
+
+// Rate returns the name of the rule that applies to line and its rate, an empty name and a zero rate when no rule
+// applies
+func (c Chain) Rate(invoice *corev1.Invoice, line *corev1.InvoiceLine) (string, float64) {
+	for _, rule := range c {
+		if rate, ok := rule.Rate(invoice, line); ok {
+			return rule.Name(), rate
+		}
+	}
+	return "", 0
+}
+
+// LineRate applies the rate the line had on its order
+type LineRate struct{}
+
+func (LineRate) Name() string {
+	return "order_line"
+}
+
+func (LineRate) Rate(_ *corev1.Invoice, line *corev1.InvoiceLine) (float64, bool) {
+	return line.GetTaxRate(), true
+}
+
+// FlatRate applies the same rate to every line
+type FlatRate struct {
+	RuleName string
+	Value    float64
+}
+
+func (f FlatRate) Name() string {
+	return f.RuleName
+}
+
+func (f FlatRate) Rate(*corev1.Invoice, *corev1.InvoiceLine) (float64, bool) {
+	return f.Value, true
+}
+
+// CountryRates applies the rate of the billing country of the invoice, keyed by ISO 3166 code, e.g. DE. It does not
+// apply to invoices billed to other countries
+type CountryRates map[string]float64
+
+func (CountryRates) Name() string {
+	return "country"
+}
+
+func (c CountryRates) Rate(invoice *corev1.Invoice, _ *corev1.InvoiceLine) (float64, bool) {
+	country := strings.ToUpper(strings.TrimSpace(invoice.GetBillingAddress().GetCountry()))
+	if country == "" {
+		return 0, false
+	}
+	rate, ok := c[country]
+	return rate, ok
+}
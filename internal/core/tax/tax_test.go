@@ -0,0 +1,41 @@
+package tax
+
+import (
+	"testing"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainRate(t *testing.T) {
+	line := &corev1.InvoiceLine{TaxRate: 0.1}
+	countries := CountryRates{"DE": 0.19, "US": 0}
+
+	testCases := []struct {
+		name    string
+		chain   Chain
+		country string
+		rule    string
+		rate    float64
+	}{
+		{name: "default keeps the line rate", chain: DefaultChain(), country: "DE", rule: "order_line", rate: 0.1},
+		{name: "country rate", chain: Chain{countries, LineRate{}}, country: "de", rule: "country", rate: 0.19},
+		{name: "zero country rate applies", chain: Chain{countries, LineRate{}}, country: "US", rule: "country", rate: 0},
+		{name: "falls through an unknown country", chain: Chain{countries, LineRate{}}, country: "FR", rule: "order_line", rate: 0.1},
+		{name: "falls through a missing address", chain: Chain{countries, FlatRate{RuleName: "standard", Value: 0.2}}, rule: "standard", rate: 0.2},
+		{name: "no rule applies", chain: Chain{countries}, country: "FR"},
+		{name: "empty chain", chain: Chain{}, country: "DE"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			invoice := &corev1.Invoice{}
+			if tc.country != "" {
+				invoice.BillingAddress = &corev1.Address{Country: tc.country}
+			}
+			rule, rate := tc.chain.Rate(invoice, line)
+			assert.Equal(t, tc.rule, rule)
+			assert.Equal(t, tc.rate, rate)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	infra_config "erp.localhost/internal/infra/config"
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/shutdown"
+)
+
+// Config holds the settings of the gateway, see infra_config.Load for how they are resolved
+type Config struct {
+	TLS    infra_config.TLS    `yaml:"tls"`
+	Client infra_config.Client `yaml:"client"`
+	// How the addresses of the services are found
+	Discovery discovery.Config `yaml:"discovery"`
+	// Draining of the requests and closing of the connections at shutdown
+	Shutdown shutdown.Config `yaml:"shutdown"`
+}
+
+// loadConfig loads the settings of the gateway from its defaults, CONFIG_FILE, the environment and args
+func loadConfig(args []string) (*Config, error) {
+	config := &Config{}
+	if err := infra_config.Load(config, infra_config.WithArgs(args)); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
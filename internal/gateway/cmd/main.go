@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"erp.localhost/internal/gateway"
+	"erp.localhost/internal/infra/buildinfo"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	model_shared "erp.localhost/internal/infra/model/shared"
+	"erp.localhost/internal/infra/shutdown"
+	"erp.localhost/internal/infra/tracing"
+)
+
+const (
+	ServerPort = 4000
+	// Default port of the metrics endpoint, overridden by METRICS_PORT
+	MetricsPort = 9003
+)
+
+func Main() {
+	logger := logger.NewBaseLogger(model_shared.ModuleGateway)
+	defer logger.Close()
+	logger.Info("Starting service...", buildinfo.Get().LogArgs()...)
+	config, err := loadConfig(os.Args[1:])
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		return
+	}
+	// Stops the HTTP server, then closes the connections to the services once main returns
+	coordinator := shutdown.New(&config.Shutdown, logger)
+	defer coordinator.Shutdown()
+
+	// Distributed tracing, spans are exported when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.LoadConfig(model_shared.ModuleGateway), logger)
+	if err != nil {
+		logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+		return
+	}
+	coordinator.OnClose("tracing", shutdownTracing)
+
+	// Connections to the services use mTLS, the gateway does not start without its certificates unless
+	// TLS_INSECURE is set
+	certs := model_shared.NewCerts()
+	if config.TLS.CertsDir != "" {
+		certs = model_shared.NewCertsFromDir(config.TLS.CertsDir)
+	}
+
+	// Prometheus metrics of the process, see internal/infra/metrics
+	metricsServer := metrics.NewServer(MetricsPort, logger)
+	metricsServer.Start()
+	coordinator.OnClose("metrics_server", metricsServer.Shutdown)
+
+	// The routes of a service share one connection
+	retry := interceptor.DefaultRetryPolicy()
+	retry.MaxAttempts = config.Client.RetryAttempts
+	clients := client.NewFactory(&client.Config{
+		Module:              model_shared.ModuleGateway,
+		Insecure:            config.TLS.Insecure,
+		Certs:               certs,
+		CertReloadInterval:  config.TLS.ReloadInterval,
+		ConnectTimeout:      config.Client.ConnectTimeout,
+		RequestTimeout:      config.Client.RequestTimeout,
+		KeepAliveTime:       config.Client.KeepAliveTime,
+		KeepAliveTimeout:    config.Client.KeepAliveTimeout,
+		LoadBalancingPolicy: config.Client.LoadBalancingPolicy,
+		Retry:               retry,
+	}, logger)
+	coordinator.OnClose("grpc_clients", func(context.Context) error { return clients.Close() })
+	// Services without a configured address are found by the discovery, see DISCOVERY_PROVIDER
+	resolver, err := discovery.New(&config.Discovery)
+	if err != nil {
+		logger.Error("invalid service discovery", "error", err)
+		return
+	}
+	clients.SetResolver(resolver)
+
+	gw := gateway.NewGateway(logger)
+	services := []struct {
+		module model_shared.Module
+		routes []gateway.Route
+	}{
+		{module: model_shared.ModuleAuth, routes: gateway.AuthRoutes()},
+		{module: model_shared.ModuleConfig, routes: gateway.ConfigRoutes()},
+	}
+	for _, service := range services {
+		conn, err := clients.Conn(context.Background(), "", service.module)
+		if err != nil {
+			logger.Error(infra_error.Internal(infra_error.InternalGRPCError, err).Error())
+			return
+		}
+		coordinator.OnClose(string(service.module)+"_conn", func(context.Context) error { return conn.Close() })
+		if err := gw.Register(conn.Conn(), service.routes...); err != nil {
+			logger.Error(infra_error.Internal(infra_error.InternalUnexpectedError, err).Error())
+			return
+		}
+	}
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", ServerPort),
+		Handler:           gw,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// Shutdown stops accepting connections and waits for the in-flight requests until the shutdown deadline
+	coordinator.OnDrain("http_server", srv.Shutdown)
+	coordinator.Go("http_server", func(<-chan struct{}) {
+		logger.Info("HTTP gateway listening", "port", ServerPort)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("HTTP gateway stopped", "error", err)
+			coordinator.Stop()
+		}
+	})
+	coordinator.Wait()
+}
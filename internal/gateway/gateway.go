@@ -0,0 +1,261 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	"erp.localhost/internal/infra/logging/logger"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// TenantIDHeader and UserIDHeader set the identifier of requests that have one
+	TenantIDHeader = "X-Tenant-ID"
+	UserIDHeader   = "X-User-ID"
+	// APIKeyHeader is forwarded to the services, which authenticate the key
+	APIKeyHeader = "X-API-Key"
+	// RequestIDHeader is the correlation id of the request, returned on every response
+	RequestIDHeader = "X-Request-ID"
+
+	tracerName = "erp.localhost/internal/gateway"
+
+	// maxBodyBytes caps request bodies
+	maxBodyBytes = 1 << 20
+)
+
+// wildcardPattern matches the {name} and {name...} wildcards of a route path
+var wildcardPattern = regexp.MustCompile(`\{([^}.]+)(?:\.\.\.)?\}`)
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// Route exposes a unary RPC as an HTTP endpoint.
+// Path wildcards and query parameters set the request fields of the same name, nested fields are
+// addressed with dots in query parameters (pagination.page). Bodies of POST, PUT and PATCH requests
+// are the JSON encoding of the request message.
+type Route struct {
+	Method string
+	Path   string
+	// RPC is the full method name, e.g. authv1.UserService_GetUser_FullMethodName
+	RPC string
+}
+
+// endpoint is a route resolved against the registered proto descriptors
+type endpoint struct {
+	route    Route
+	conn     grpc.ClientConnInterface
+	input    protoreflect.MessageType
+	output   protoreflect.MessageType
+	wildcard []string
+}
+
+// Gateway serves the REST/JSON facade of the gRPC services
+type Gateway struct {
+	mux    *http.ServeMux
+	logger logger.Logger
+}
+
+func NewGateway(logger logger.Logger) *Gateway {
+	return &Gateway{
+		mux:    http.NewServeMux(),
+		logger: logger,
+	}
+}
+
+// Register exposes routes of the services reachable through conn
+func (g *Gateway) Register(conn grpc.ClientConnInterface, routes ...Route) error {
+	for _, route := range routes {
+		endpoint, err := resolve(route)
+		if err != nil {
+			return err
+		}
+		endpoint.conn = conn
+		g.mux.Handle(route.Method+" "+route.Path, endpoint.handler(g.logger))
+		g.logger.Debug("registered route", "method", route.Method, "path", route.Path, "rpc", route.RPC)
+	}
+	return nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// resolve looks up the request and response types of the route RPC and checks its path wildcards are request fields
+func resolve(route Route) (*endpoint, error) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(route.RPC, "/"), "/")
+	if !ok {
+		return nil, fmt.Errorf("route %s %s: invalid rpc %q", route.Method, route.Path, route.RPC)
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("route %s %s: unknown service %s: %w", route.Method, route.Path, service, err)
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("route %s %s: %s is not a service", route.Method, route.Path, service)
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(method))
+	if methodDescriptor == nil {
+		return nil, fmt.Errorf("route %s %s: unknown rpc %s", route.Method, route.Path, route.RPC)
+	}
+	if methodDescriptor.IsStreamingClient() || methodDescriptor.IsStreamingServer() {
+		return nil, fmt.Errorf("route %s %s: streaming rpc %s is not supported", route.Method, route.Path, route.RPC)
+	}
+	input, err := protoregistry.GlobalTypes.FindMessageByName(methodDescriptor.Input().FullName())
+	if err != nil {
+		return nil, fmt.Errorf("route %s %s: %w", route.Method, route.Path, err)
+	}
+	output, err := protoregistry.GlobalTypes.FindMessageByName(methodDescriptor.Output().FullName())
+	if err != nil {
+		return nil, fmt.Errorf("route %s %s: %w", route.Method, route.Path, err)
+	}
+
+	endpoint := &endpoint{route: route, input: input, output: output}
+	for _, match := range wildcardPattern.FindAllStringSubmatch(route.Path, -1) {
+		if methodDescriptor.Input().Fields().ByName(protoreflect.Name(match[1])) == nil {
+			return nil, fmt.Errorf("route %s %s: %s has no field %s", route.Method, route.Path, methodDescriptor.Input().FullName(), match[1])
+		}
+		endpoint.wildcard = append(endpoint.wildcard, match[1])
+	}
+	return endpoint, nil
+}
+
+func (e *endpoint) handler(log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Continue the trace of the caller, the rpc span is a child of the route span
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := otel.Tracer(tracerName).Start(ctx, e.route.Method+" "+e.route.Path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), semconv.HTTPRoute(e.route.Path)),
+		)
+		defer span.End()
+		// The services log the request under the correlation id of the caller, or a new one
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		ctx = logger.WithRequestID(ctx, requestID)
+		r = r.WithContext(ctx)
+
+		req, err := e.decode(r)
+		if err != nil {
+			log.WithContext(ctx).Warn("invalid request", "method", r.Method, "path", r.URL.Path, "error", err)
+			writeError(w, infra_error.Validation(infra_error.ValidationInvalidValue).WithError(err))
+			return
+		}
+
+		resp := e.output.New().Interface()
+		if err := e.conn.Invoke(outgoingContext(r), e.route.RPC, req, resp); err != nil {
+			appErr := infra_error.FromGRPCError(err)
+			span.SetStatus(codes.Error, appErr.Code)
+			log.WithContext(ctx).Debug("rpc failed", "rpc", e.route.RPC, "code", appErr.Code, "error", err)
+			writeError(w, appErr)
+			return
+		}
+		writeMessage(w, http.StatusOK, resp)
+	})
+}
+
+// decode builds the request message from the body, the query and the path, later sources override earlier ones
+func (e *endpoint) decode(r *http.Request) (proto.Message, error) {
+	req := e.input.New()
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body: %w", err)
+		}
+		if len(body) > 0 {
+			if err := unmarshalOptions.Unmarshal(body, req.Interface()); err != nil {
+				return nil, fmt.Errorf("invalid body: %w", err)
+			}
+		}
+	}
+	for name, values := range r.URL.Query() {
+		if err := setField(req, name, values); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range e.wildcard {
+		if err := setField(req, name, []string{r.PathValue(name)}); err != nil {
+			return nil, err
+		}
+	}
+	setIdentifier(req, r.Header.Get(TenantIDHeader), r.Header.Get(UserIDHeader))
+	return req.Interface(), nil
+}
+
+// setIdentifier sets the identifier of requests that have one from the identity headers, when both are present
+func setIdentifier(req protoreflect.Message, tenantID, userID string) {
+	if tenantID == "" || userID == "" {
+		return
+	}
+	identifier := &infrav1.UserIdentifier{TenantId: tenantID, UserId: userID}
+	field := req.Descriptor().Fields().ByName("identifier")
+	if field == nil || field.Message() == nil || field.Message().FullName() != identifier.ProtoReflect().Descriptor().FullName() {
+		return
+	}
+	req.Set(field, protoreflect.ValueOfMessage(identifier.ProtoReflect()))
+}
+
+// outgoingContext forwards the credentials and the client of the HTTP request to the services
+func outgoingContext(r *http.Request) context.Context {
+	ctx := interceptor.WithClient(r.Context(), clientIP(r), r.UserAgent())
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		ctx = interceptor.WithAPIKey(ctx, key)
+	}
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, interceptor.AuthorizationHeader, authorization)
+	}
+	return ctx
+}
+
+// clientIP returns the address of the client, the first X-Forwarded-For entry when behind a proxy
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(ip)
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// writeError writes err with the HTTP status of its catalog code or category
+func writeError(w http.ResponseWriter, err *infra_error.AppError) {
+	writeMessage(w, infra_error.GetHTTPStatus(err), infra_error.ToProto(err))
+}
+
+func writeMessage(w http.ResponseWriter, status int, msg proto.Message) {
+	body, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body = []byte(`{"code":"` + infra_error.InternalUnexpectedError.Code + `"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
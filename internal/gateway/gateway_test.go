@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeConn records the last call and answers it with reply or err
+type fakeConn struct {
+	method   string
+	request  proto.Message
+	metadata metadata.MD
+	reply    proto.Message
+	err      error
+}
+
+func (c *fakeConn) Invoke(ctx context.Context, method string, args any, reply any, _ ...grpc.CallOption) error {
+	c.method = method
+	c.request = args.(proto.Message)
+	c.metadata, _ = metadata.FromOutgoingContext(ctx)
+	if c.err != nil {
+		return c.err
+	}
+	if c.reply != nil {
+		proto.Merge(reply.(proto.Message), c.reply)
+	}
+	return nil
+}
+
+func (c *fakeConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	panic("not implemented")
+}
+
+func newTestGateway(t *testing.T, conn *fakeConn) *Gateway {
+	gw := NewGateway(logger.NewBaseLogger(shared.ModuleGateway))
+	require.NoError(t, gw.Register(conn, AuthRoutes()...))
+	require.NoError(t, gw.Register(conn, ConfigRoutes()...))
+	return gw
+}
+
+func TestGateway_Routes(t *testing.T) {
+	conn := &fakeConn{reply: &authv1.User{Id: "user-2", Email: "a@example.com"}}
+	gw := newTestGateway(t, conn)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/tenants/tenant-1/users/user-2", nil)
+	req.Header.Set(TenantIDHeader, "tenant-1")
+	req.Header.Set(UserIDHeader, "user-1")
+	req.Header.Set(APIKeyHeader, "key")
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, authv1.UserService_GetUser_FullMethodName, conn.method)
+	request := conn.request.(*authv1.GetUserRequest)
+	assert.Equal(t, "tenant-1", request.GetTargetTenantId())
+	assert.Equal(t, "user-2", request.GetAccountId())
+	assert.Equal(t, "tenant-1", request.GetIdentifier().GetTenantId())
+	assert.Equal(t, "user-1", request.GetIdentifier().GetUserId())
+	assert.Equal(t, []string{"key"}, conn.metadata.Get(interceptor.APIKeyHeader))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "user-2", body["id"])
+	assert.Equal(t, "a@example.com", body["email"])
+}
+
+func TestGateway_QueryAndBody(t *testing.T) {
+	conn := &fakeConn{}
+	gw := newTestGateway(t, conn)
+
+	// Nested, repeated and enum query parameters
+	req := httptest.NewRequest(http.MethodGet, "/v1/tenants/tenant-1/users/search?search.query=ann&search.statuses=USER_STATUS_ACTIVE&search.statuses=4&search.created_after=2026-01-01T00:00:00Z&pagination.page=2", nil)
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	search := conn.request.(*authv1.SearchUsersRequest)
+	assert.Equal(t, "ann", search.GetSearch().GetQuery())
+	assert.Equal(t, []authv1.UserStatus{authv1.UserStatus_USER_STATUS_ACTIVE, authv1.UserStatus_USER_STATUS_INVITED}, search.GetSearch().GetStatuses())
+	assert.Equal(t, int64(1767225600), search.GetSearch().GetCreatedAfter().GetSeconds())
+	assert.Equal(t, int32(2), search.GetPagination().GetPage())
+
+	// JSON body, proto and JSON field names are both accepted
+	req = httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader(`{"tenant_id":"tenant-1","email":"a@example.com","mfaCode":"123456"}`))
+	rec = httptest.NewRecorder()
+	gw.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	login := conn.request.(*authv1.LoginRequest)
+	assert.Equal(t, "tenant-1", login.GetTenantId())
+	assert.Equal(t, "a@example.com", login.GetEmail())
+	assert.Equal(t, "123456", login.GetMfaCode())
+}
+
+func TestGateway_Errors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		request    *http.Request
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "not found",
+			request:    httptest.NewRequest(http.MethodGet, "/v1/tenants/tenant-1/roles/role-1", nil),
+			err:        infra_error.ToGRPCError(infra_error.NotFound(infra_error.NotFoundResource, "role", "role-1")),
+			wantStatus: http.StatusNotFound,
+			wantCode:   infra_error.NotFoundResource.Code,
+		},
+		{
+			name:       "conflict",
+			request:    httptest.NewRequest(http.MethodPost, "/v1/roles", strings.NewReader(`{}`)),
+			err:        infra_error.ToGRPCError(infra_error.Conflict(infra_error.ConflictDuplicateRole)),
+			wantStatus: http.StatusConflict,
+			wantCode:   infra_error.ConflictDuplicateRole.Code,
+		},
+		{
+			name:       "permission denied",
+			request:    httptest.NewRequest(http.MethodGet, "/v1/tenants", nil),
+			err:        infra_error.ToGRPCError(infra_error.Auth(infra_error.AuthPermissionDenied)),
+			wantStatus: infra_error.AuthPermissionDenied.HTTPStatus,
+			wantCode:   infra_error.AuthPermissionDenied.Code,
+		},
+		{
+			name:       "invalid body",
+			request:    httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader(`{"unknown":1}`)),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   infra_error.ValidationInvalidValue.Code,
+		},
+		{
+			name:       "invalid query",
+			request:    httptest.NewRequest(http.MethodGet, "/v1/tenants/tenant-1/users/search?pagination.page=first", nil),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   infra_error.ValidationInvalidValue.Code,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gw := newTestGateway(t, &fakeConn{err: tc.err})
+			rec := httptest.NewRecorder()
+			gw.ServeHTTP(rec, tc.request)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.wantCode, body["code"])
+		})
+	}
+}
+
+func TestGateway_RegisterInvalidRoute(t *testing.T) {
+	gw := NewGateway(logger.NewBaseLogger(shared.ModuleGateway))
+	conn := &fakeConn{}
+	assert.Error(t, gw.Register(conn, Route{Method: http.MethodGet, Path: "/v1/x", RPC: "/auth.v1.UserService/Missing"}))
+	assert.Error(t, gw.Register(conn, Route{Method: http.MethodGet, Path: "/v1/x", RPC: "/auth.v1.MissingService/GetUser"}))
+	assert.Error(t, gw.Register(conn, Route{Method: http.MethodGet, Path: "/v1/x/{missing_field}", RPC: authv1.UserService_GetUser_FullMethodName}))
+}
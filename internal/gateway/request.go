@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// setField sets the field at path, dot separated proto field names, from its string values.
+// Repeated fields take every value, singular fields the last one.
+func setField(msg protoreflect.Message, path string, values []string) error {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		field := fieldByName(msg, name)
+		if field == nil || field.Message() == nil || field.IsList() || field.IsMap() {
+			return fmt.Errorf("unknown field %s", path)
+		}
+		msg = msg.Mutable(field).Message()
+	}
+
+	field := fieldByName(msg, names[len(names)-1])
+	if field == nil || field.IsMap() {
+		return fmt.Errorf("unknown field %s", path)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if field.IsList() {
+		list := msg.Mutable(field).List()
+		for _, value := range values {
+			element, err := parseValue(field, list.NewElement, value)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			list.Append(element)
+		}
+		return nil
+	}
+	value, err := parseValue(field, func() protoreflect.Value { return msg.NewField(field) }, values[len(values)-1])
+	if err != nil {
+		return fmt.Errorf("field %s: %w", path, err)
+	}
+	msg.Set(field, value)
+	return nil
+}
+
+// fieldByName accepts proto and JSON field names
+func fieldByName(msg protoreflect.Message, name string) protoreflect.FieldDescriptor {
+	fields := msg.Descriptor().Fields()
+	if field := fields.ByName(protoreflect.Name(name)); field != nil {
+		return field
+	}
+	return fields.ByJSONName(name)
+}
+
+// parseValue converts value to the kind of field, newMessage returns an empty value for message fields
+func parseValue(field protoreflect.FieldDescriptor, newMessage func() protoreflect.Value, value string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.BytesKind:
+		v, err := base64.URLEncoding.DecodeString(value)
+		return protoreflect.ValueOfBytes(v), err
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(value)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown %s value %q", field.Enum().FullName(), value)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// Well known types like timestamps have a string JSON form
+		message := newMessage()
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		if err := unmarshalOptions.Unmarshal(encoded, message.Message().Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return message, nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported kind %s", field.Kind())
+	}
+}
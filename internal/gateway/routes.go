@@ -0,0 +1,127 @@
+package gateway
+
+import (
+	"net/http"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+)
+
+// AuthRoutes are the routes of the auth service: AuthService, UserService, RoleService, PermissionService and TenantService
+func AuthRoutes() []Route {
+	var routes []Route
+	for _, group := range [][]Route{authServiceRoutes, userServiceRoutes, roleServiceRoutes, permissionServiceRoutes, tenantServiceRoutes} {
+		routes = append(routes, group...)
+	}
+	return routes
+}
+
+// ConfigRoutes are the routes of the config service
+func ConfigRoutes() []Route {
+	return configServiceRoutes
+}
+
+var authServiceRoutes = []Route{
+	{Method: http.MethodPost, Path: "/v1/auth/login", RPC: authv1.AuthService_Login_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/logout", RPC: authv1.AuthService_Logout_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/tokens/verify", RPC: authv1.AuthService_VerifyToken_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/tokens/refresh", RPC: authv1.AuthService_RefreshToken_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/tokens/revoke", RPC: authv1.AuthService_RevokeToken_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/tenants/{target_tenant_id}/tokens/revoke", RPC: authv1.AuthService_RevokeAllTenantTokens_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/mfa/enroll", RPC: authv1.AuthService_EnrollMFA_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/mfa/verify", RPC: authv1.AuthService_VerifyMFAEnrollment_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/mfa/disable", RPC: authv1.AuthService_DisableMFA_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/auth/oidc/{provider}/url", RPC: authv1.AuthService_GetOIDCAuthURL_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/oidc/login", RPC: authv1.AuthService_LoginWithOIDC_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/oidc/link", RPC: authv1.AuthService_LinkExternalIdentity_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/auth/oidc/{provider}/link", RPC: authv1.AuthService_UnlinkExternalIdentity_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/saml/login", RPC: authv1.AuthService_LoginWithSAML_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/login-link", RPC: authv1.AuthService_RequestLoginLink_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/auth/login-link/complete", RPC: authv1.AuthService_CompleteLoginLink_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/saml", RPC: authv1.AuthService_GetSAMLConfig_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/tenants/{target_tenant_id}/saml", RPC: authv1.AuthService_SetSAMLConfig_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/token-policy", RPC: authv1.AuthService_GetTokenPolicy_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/tenants/{target_tenant_id}/token-policy", RPC: authv1.AuthService_SetTokenPolicy_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/users/{account_id}/impersonate", RPC: authv1.AuthService_Impersonate_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/users/{account_id}/impersonate", RPC: authv1.AuthService_StopImpersonation_FullMethodName},
+}
+
+var userServiceRoutes = []Route{
+	{Method: http.MethodPost, Path: "/v1/users", RPC: authv1.UserService_CreateUser_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/users", RPC: authv1.UserService_UpdateUser_FullMethodName},
+	{Method: http.MethodPatch, Path: "/v1/users", RPC: authv1.UserService_UpdateUser_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/users", RPC: authv1.UserService_ListUsers_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/users/search", RPC: authv1.UserService_SearchUsers_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}", RPC: authv1.UserService_GetUser_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}", RPC: authv1.UserService_DeleteUser_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}/profile-completion", RPC: authv1.UserService_GetProfileCompletion_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}/login-history", RPC: authv1.UserService_GetLoginHistory_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}/devices", RPC: authv1.UserService_ListDevices_FullMethodName},
+	{Method: http.MethodPatch, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}/devices/{device_id}", RPC: authv1.UserService_RenameDevice_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}/devices/{device_id}", RPC: authv1.UserService_RevokeDevice_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}/erasure", RPC: authv1.UserService_EraseUser_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/profile-completion", RPC: authv1.UserService_GetProfileCompletionStats_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/users/email-verification", RPC: authv1.UserService_SendEmailVerification_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/users/email-verification/confirm", RPC: authv1.UserService_ConfirmEmailVerification_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/users/password", RPC: authv1.UserService_ChangePassword_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/users/{account_id}/password/reset", RPC: authv1.UserService_ResetPassword_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/tenants/{target_tenant_id}/users/{account_id}/roles/{role_id}/expiry", RPC: authv1.UserService_ExtendRoleAssignment_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/me/profile", RPC: authv1.UserService_UpdateMyProfile_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/me/email", RPC: authv1.UserService_ChangeMyEmail_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/me/password", RPC: authv1.UserService_ChangeMyPassword_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/me/preferences", RPC: authv1.UserService_UpdateMyPreferences_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/me/deletion", RPC: authv1.UserService_DeleteMyAccount_FullMethodName},
+}
+
+var roleServiceRoutes = []Route{
+	{Method: http.MethodPost, Path: "/v1/roles", RPC: authv1.RoleService_CreateRole_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/roles", RPC: authv1.RoleService_UpdateRole_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/roles", RPC: authv1.RoleService_ListRoles_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/roles/{role_id}", RPC: authv1.RoleService_GetRole_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/tenants/{target_tenant_id}/roles/{role_id}", RPC: authv1.RoleService_DeleteRole_FullMethodName},
+}
+
+var permissionServiceRoutes = []Route{
+	{Method: http.MethodPost, Path: "/v1/permissions", RPC: authv1.PermissionService_CreatePermission_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/permissions", RPC: authv1.PermissionService_UpdatePermission_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/permissions", RPC: authv1.PermissionService_ListPermissions_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/permissions/{permission_id}", RPC: authv1.PermissionService_GetPermission_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/tenants/{target_tenant_id}/permissions/{permission_id}", RPC: authv1.PermissionService_DeletePermission_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/permission-sets", RPC: authv1.PermissionService_CreatePermissionSet_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/permission-sets", RPC: authv1.PermissionService_UpdatePermissionSet_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/permission-sets", RPC: authv1.PermissionService_ListPermissionSets_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/permission-sets/{permission_set_id}", RPC: authv1.PermissionService_GetPermissionSet_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/tenants/{target_tenant_id}/permission-sets/{permission_set_id}", RPC: authv1.PermissionService_DeletePermissionSet_FullMethodName},
+}
+
+var tenantServiceRoutes = []Route{
+	{Method: http.MethodPost, Path: "/v1/tenants", RPC: authv1.TenantService_CreateTenant_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/tenants", RPC: authv1.TenantService_UpdateTenant_FullMethodName},
+	{Method: http.MethodPatch, Path: "/v1/tenants", RPC: authv1.TenantService_UpdateTenant_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants", RPC: authv1.TenantService_ListTenants_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{tenant_id}", RPC: authv1.TenantService_GetTenant_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/tenants/{tenant_id}", RPC: authv1.TenantService_DeleteTenant_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/usage", RPC: authv1.TenantService_GetUsage_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/usage/export", RPC: authv1.TenantService_ExportUsage_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/tenants/{target_tenant_id}/quotas", RPC: authv1.TenantService_GetTenantUsage_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/tenants/{target_tenant_id}/status", RPC: authv1.TenantService_ChangeTenantStatus_FullMethodName},
+}
+
+var configServiceRoutes = []Route{
+	{Method: http.MethodGet, Path: "/v1/config/env", RPC: configv1.ConfigService_GetEnv_FullMethodName},
+	{Method: http.MethodPost, Path: "/v1/config/feature-flags", RPC: configv1.FeatureFlagService_CreateFeatureFlag_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/config/feature-flags", RPC: configv1.FeatureFlagService_UpdateFeatureFlag_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/config/feature-flags", RPC: configv1.FeatureFlagService_ListFeatureFlags_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/config/feature-flags/evaluate", RPC: configv1.FeatureFlagService_EvaluateFeatureFlags_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/config/feature-flags/{key}", RPC: configv1.FeatureFlagService_GetFeatureFlag_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/config/feature-flags/{key}", RPC: configv1.FeatureFlagService_DeleteFeatureFlag_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/config/feature-flags/{key}/overrides", RPC: configv1.FeatureFlagService_SetFeatureFlagOverride_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/config/feature-flags/{key}/overrides", RPC: configv1.FeatureFlagService_DeleteFeatureFlagOverride_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/config/entries", RPC: configv1.ConfigService_ListConfigEntries_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/config/entries/{key}", RPC: configv1.ConfigService_GetConfigEntry_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/config/entries/{key}", RPC: configv1.ConfigService_SetConfigEntry_FullMethodName},
+	{Method: http.MethodDelete, Path: "/v1/config/entries/{key}", RPC: configv1.ConfigService_DeleteConfigEntry_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/config/entries/{key}/history", RPC: configv1.ConfigService_GetConfigHistory_FullMethodName},
+	{Method: http.MethodGet, Path: "/v1/config/{module}", RPC: configv1.ConfigService_GetConfig_FullMethodName},
+	{Method: http.MethodPut, Path: "/v1/config/{module}", RPC: configv1.ConfigService_SetConfig_FullMethodName},
+}
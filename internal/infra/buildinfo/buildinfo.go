@@ -0,0 +1,81 @@
+// Package buildinfo holds the version of the binary, stamped at build time with -ldflags:
+//
+//	go build -ldflags "-X erp.localhost/internal/infra/buildinfo.Version=v1.2.0 \
+//		-X erp.localhost/internal/infra/buildinfo.Commit=$(git rev-parse HEAD) \
+//		-X erp.localhost/internal/infra/buildinfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd
+//
+// The make build targets set them. Values that were not stamped fall back to the VCS information recorded by the
+// go toolchain, if any
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// Set with -ldflags -X, see the package documentation
+var (
+	Version   = ""
+	Commit    = ""
+	BuildDate = ""
+)
+
+// unknownVersion is the version of the binaries built without one, e.g. by go run
+const unknownVersion = "dev"
+
+// Info describes the build of the running binary
+type Info struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	GoVersion string
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build of the running binary
+func Get() Info {
+	once.Do(func() {
+		info = resolve(Version, Commit, BuildDate, debug.ReadBuildInfo)
+	})
+	return info
+}
+
+// resolve completes the stamped values with the build information of the toolchain
+func resolve(version, commit, buildDate string, read func() (*debug.BuildInfo, bool)) Info {
+	resolved := Info{Version: version, Commit: commit, BuildDate: buildDate, GoVersion: runtime.Version()}
+	if build, ok := read(); ok {
+		if resolved.Version == "" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+			resolved.Version = build.Main.Version
+		}
+		for _, setting := range build.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && resolved.Commit == "":
+				resolved.Commit = setting.Value
+			case setting.Key == "vcs.time" && resolved.BuildDate == "":
+				resolved.BuildDate = setting.Value
+			}
+		}
+	}
+	if resolved.Version == "" {
+		resolved.Version = unknownVersion
+	}
+	return resolved
+}
+
+// ShortCommit returns the first 12 characters of the commit
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 12 {
+		return i.Commit[:12]
+	}
+	return i.Commit
+}
+
+// LogArgs returns the build as key-value pairs of a log entry
+func (i Info) LogArgs() []any {
+	return []any{"version", i.Version, "commit", i.Commit, "build_date", i.BuildDate, "go_version", i.GoVersion}
+}
@@ -0,0 +1,38 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	read := func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Version: "(devel)"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123456789abcdef"},
+				{Key: "vcs.time", Value: "2026-01-01T00:00:00Z"},
+			},
+		}, true
+	}
+
+	// The stamped values win
+	info := resolve("v1.2.0", "fedcba9876543210", "2026-02-01T00:00:00Z", read)
+	assert.Equal(t, "v1.2.0", info.Version)
+	assert.Equal(t, "fedcba9876543210", info.Commit)
+	assert.Equal(t, "2026-02-01T00:00:00Z", info.BuildDate)
+	assert.Equal(t, "fedcba987654", info.ShortCommit())
+
+	// Unstamped binaries use the VCS information of the toolchain
+	info = resolve("", "", "", read)
+	assert.Equal(t, unknownVersion, info.Version)
+	assert.Equal(t, "0123456789abcdef", info.Commit)
+	assert.Equal(t, "2026-01-01T00:00:00Z", info.BuildDate)
+	assert.NotEmpty(t, info.GoVersion)
+
+	info = resolve("", "", "", func() (*debug.BuildInfo, bool) { return nil, false })
+	assert.Equal(t, unknownVersion, info.Version)
+	assert.Empty(t, info.Commit)
+}
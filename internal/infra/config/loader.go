@@ -0,0 +1,339 @@
+// Package config loads the settings of a service binary into a typed struct. Values are resolved, from lowest
+// to highest precedence, from the values already set in the struct and the `default` tags, an optional YAML
+// file, environment variables and command line flags, then validated.
+//
+// Fields are mapped with struct tags:
+//
+//	Port int `yaml:"port" env:"SERVER_PORT" flag:"port" default:"5000" validate:"port"`
+//
+// Nested structs are loaded recursively, their yaml tag names the section of the file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileEnv is the environment variable naming the YAML file of the settings
+	FileEnv = "CONFIG_FILE"
+	// FileFlag is the command line flag naming the YAML file of the settings, it takes precedence over FileEnv
+	FileFlag = "config"
+)
+
+// Validator is implemented by configs with rules spanning several fields, Validate is called by Load after
+// the tag rules passed
+type Validator interface {
+	Validate() error
+}
+
+type loader struct {
+	args   []string
+	file   string
+	lookup func(string) (string, bool)
+}
+
+// Option customizes Load
+type Option func(*loader)
+
+// WithArgs sets the command line arguments, without the program name. Flags not declared by the config are
+// ignored, so binaries hosting several services can share one command line
+func WithArgs(args []string) Option {
+	return func(l *loader) {
+		l.args = args
+	}
+}
+
+// WithFile sets the YAML file read when neither the -config flag nor CONFIG_FILE is set
+func WithFile(path string) Option {
+	return func(l *loader) {
+		l.file = path
+	}
+}
+
+// WithLookupEnv replaces os.LookupEnv, for tests
+func WithLookupEnv(lookup func(string) (string, bool)) Option {
+	return func(l *loader) {
+		l.lookup = lookup
+	}
+}
+
+// field is a leaf setting of the config
+type field struct {
+	value reflect.Value
+	// path is the dotted yaml path of the field, used in errors
+	path string
+	env  string
+	flag string
+	def  string
+	// validate holds the rules of the validation package
+	validate string
+}
+
+// name returns how the field is reported in errors, the environment variable when it has one
+func (f field) name() string {
+	if f.env != "" {
+		return f.env
+	}
+	return f.path
+}
+
+// Load fills cfg, a pointer to a struct, and validates it. Fields keep the value already set in cfg unless it is
+// the zero value and a `default` tag is present, so a service can share a section with other services and still
+// set its own defaults
+func Load(cfg any, opts ...Option) error {
+	l := &loader{lookup: os.LookupEnv}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	root := reflect.ValueOf(cfg)
+	if root.Kind() != reflect.Pointer || root.IsNil() || root.Elem().Kind() != reflect.Struct {
+		return infra_error.Validation(infra_error.ValidationInvalidType, "config").
+			WithError(fmt.Errorf("config must be a pointer to a struct, got %T", cfg))
+	}
+	fields := collectFields(root.Elem(), "")
+
+	// Defaults
+	for _, f := range fields {
+		if f.def != "" && f.value.IsZero() {
+			if err := setValue(f.value, f.def); err != nil {
+				return infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("invalid default of %s: %w", f.path, err))
+			}
+		}
+	}
+
+	flags, err := parseFlags(l.args, fields)
+	if err != nil {
+		return err
+	}
+
+	// YAML file
+	path := l.file
+	if value, ok := l.lookup(FileEnv); ok && value != "" {
+		path = value
+	}
+	if value, ok := flags[FileFlag]; ok {
+		path = value
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return err
+		}
+	}
+
+	// Environment variables
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+		if value, ok := l.lookup(f.env); ok && value != "" {
+			if err := setValue(f.value, value); err != nil {
+				return infra_error.Validation(infra_error.ValidationInvalidFormat, f.env).WithError(err)
+			}
+		}
+	}
+
+	// Command line flags
+	for _, f := range fields {
+		if f.flag == "" {
+			continue
+		}
+		if value, ok := flags[f.flag]; ok {
+			if err := setValue(f.value, value); err != nil {
+				return infra_error.Validation(infra_error.ValidationInvalidFormat, "-"+f.flag).WithError(err)
+			}
+		}
+	}
+
+	return validate(cfg, fields)
+}
+
+// collectFields walks the exported fields of v, nested structs are walked recursively
+func collectFields(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		value := v.Field(i)
+		if sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectFields(value, path)...)
+			continue
+		}
+		f := field{
+			value: value,
+			path:  path,
+			env:   sf.Tag.Get("env"),
+			flag:  sf.Tag.Get("flag"),
+			def:   sf.Tag.Get("default"),
+		}
+		f.validate = sf.Tag.Get(validation.Tag)
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue parses raw into v. Lists are comma separated, durations use time.ParseDuration
+func setValue(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", v.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items).Convert(v.Type()))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// parseFlags returns the values of the flags declared by fields and of FileFlag. Flags are written -name=value,
+// -name value or -name for booleans, with one or two dashes
+func parseFlags(args []string, fields []field) (map[string]string, error) {
+	declared := map[string]bool{FileFlag: false}
+	for _, f := range fields {
+		if f.flag != "" {
+			declared[f.flag] = f.value.Kind() == reflect.Bool
+		}
+	}
+
+	values := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		isBool, ok := declared[name]
+		if !ok {
+			continue
+		}
+		if !hasValue {
+			switch {
+			case isBool:
+				value = "true"
+			case i+1 < len(args):
+				i++
+				value = args[i]
+			default:
+				return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "-"+name).
+					WithError(fmt.Errorf("flag -%s needs a value", name))
+			}
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// loadFile decodes the YAML file at path into cfg, keys without a matching field are rejected so typos are caught
+func loadFile(path string, cfg any) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, FileEnv).WithError(err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return infra_error.Validation(infra_error.ValidationInvalidFormat, FileEnv).WithError(fmt.Errorf("%s: %w", path, err))
+	}
+	return nil
+}
+
+// validate checks the `validate` tag rules of fields, then calls the Validate method of cfg, if any.
+// The rules are those of the validation package, e.g. required, port (1-65535) and positive
+func validate(cfg any, fields []field) error {
+	invalid := []string{}
+	for _, f := range fields {
+		if f.validate == "" {
+			continue
+		}
+		rules, err := validation.ParseRules(f.validate)
+		if err != nil {
+			return infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("invalid rules of %s: %w", f.path, err))
+		}
+		for _, violation := range validation.Check(f.value, rules, false) {
+			invalid = append(invalid, f.name()+" "+violation.Message)
+		}
+	}
+	if len(invalid) > 0 {
+		return infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New(strings.Join(invalid, ", ")))
+	}
+	if v, ok := cfg.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSection struct {
+	Port    int           `yaml:"port" env:"TEST_PORT" flag:"port" validate:"port"`
+	Timeout time.Duration `yaml:"timeout" env:"TEST_TIMEOUT" flag:"timeout" default:"5s" validate:"positive"`
+}
+
+type testConfig struct {
+	Server  testSection `yaml:"server"`
+	Name    string      `yaml:"name" env:"TEST_NAME" flag:"name" default:"erp" validate:"required"`
+	Debug   bool        `yaml:"debug" env:"TEST_DEBUG" flag:"debug"`
+	Ratio   float64     `yaml:"ratio" env:"TEST_RATIO"`
+	Tags    []string    `yaml:"tags" env:"TEST_TAGS" flag:"tags"`
+	Retries uint        `yaml:"retries" env:"TEST_RETRIES"`
+}
+
+type validatedConfig struct {
+	Min int `yaml:"min" env:"TEST_MIN"`
+	Max int `yaml:"max" env:"TEST_MAX" default:"10"`
+}
+
+func (c *validatedConfig) Validate() error {
+	if c.Min > c.Max {
+		return assert.AnError
+	}
+	return nil
+}
+
+func envOf(values map[string]string) Option {
+	return WithLookupEnv(func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	})
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	file := writeFile(t, "server:\n  port: 6000\n  timeout: 1m\nname: from-file\ntags: [a, b]\n")
+
+	testCases := []struct {
+		name    string
+		initial testConfig
+		opts    []Option
+		want    testConfig
+		wantErr bool
+	}{
+		{
+			name:    "defaults and initial values",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil)},
+			want:    testConfig{Server: testSection{Port: 5000, Timeout: 5 * time.Second}, Name: "erp"},
+		},
+		{
+			name:    "file overrides defaults",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil), WithFile(file)},
+			want:    testConfig{Server: testSection{Port: 6000, Timeout: time.Minute}, Name: "from-file", Tags: []string{"a", "b"}},
+		},
+		{
+			name:    "file from environment",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(map[string]string{FileEnv: file})},
+			want:    testConfig{Server: testSection{Port: 6000, Timeout: time.Minute}, Name: "from-file", Tags: []string{"a", "b"}},
+		},
+		{
+			name:    "file from flag",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil), WithArgs([]string{"-config", file})},
+			want:    testConfig{Server: testSection{Port: 6000, Timeout: time.Minute}, Name: "from-file", Tags: []string{"a", "b"}},
+		},
+		{
+			name:    "environment overrides file",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts: []Option{WithFile(file), envOf(map[string]string{
+				"TEST_PORT":    "7000",
+				"TEST_NAME":    "from-env",
+				"TEST_DEBUG":   "true",
+				"TEST_RATIO":   "0.5",
+				"TEST_TAGS":    "x, y,,z",
+				"TEST_RETRIES": "3",
+			})},
+			want: testConfig{
+				Server:  testSection{Port: 7000, Timeout: time.Minute},
+				Name:    "from-env",
+				Debug:   true,
+				Ratio:   0.5,
+				Tags:    []string{"x", "y", "z"},
+				Retries: 3,
+			},
+		},
+		{
+			name:    "flags override environment",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts: []Option{
+				envOf(map[string]string{"TEST_PORT": "7000", "TEST_NAME": "from-env"}),
+				WithArgs([]string{"--port=8000", "-timeout", "2s", "-debug", "-name=from-flag", "serve", "-unknown", "1"}),
+			},
+			want: testConfig{Server: testSection{Port: 8000, Timeout: 2 * time.Second}, Name: "from-flag", Debug: true},
+		},
+		{
+			name:    "arguments after the terminator are ignored",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil), WithArgs([]string{"--", "-port=8000"})},
+			want:    testConfig{Server: testSection{Port: 5000, Timeout: 5 * time.Second}, Name: "erp"},
+		},
+		{
+			name:    "invalid environment value",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(map[string]string{"TEST_TIMEOUT": "soon"})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid flag value",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil), WithArgs([]string{"-port=http"})},
+			wantErr: true,
+		},
+		{
+			name:    "flag without value",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil), WithArgs([]string{"-port"})},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil), WithFile(filepath.Join(t.TempDir(), "missing.yaml"))},
+			wantErr: true,
+		},
+		{
+			name:    "unknown file key",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(nil), WithFile(writeFile(t, "server:\n  prot: 6000\n"))},
+			wantErr: true,
+		},
+		{
+			name:    "port out of range",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(map[string]string{"TEST_PORT": "70000"})},
+			wantErr: true,
+		},
+		{
+			name:    "missing port",
+			opts:    []Option{envOf(nil)},
+			wantErr: true,
+		},
+		{
+			name:    "non positive duration",
+			initial: testConfig{Server: testSection{Port: 5000}},
+			opts:    []Option{envOf(map[string]string{"TEST_TIMEOUT": "-1s"})},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := tc.initial
+			err := Load(&config, tc.opts...)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, config)
+		})
+	}
+}
+
+func TestLoad_Validator(t *testing.T) {
+	testCases := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "valid", env: map[string]string{"TEST_MIN": "5"}},
+		{name: "invalid", env: map[string]string{"TEST_MIN": "20"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Load(&validatedConfig{}, envOf(tc.env))
+			if tc.wantErr {
+				assert.ErrorIs(t, err, assert.AnError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoad_InvalidTarget(t *testing.T) {
+	var config testConfig
+	assert.Error(t, Load(config))
+	assert.Error(t, Load((*testConfig)(nil)))
+	assert.Error(t, Load(new(int)))
+}
+
+func TestNewServer(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "")
+	server := NewServer(5000)
+	assert.Equal(t, EnvironmentDevelopment, server.Environment)
+	assert.True(t, server.EnableReflection)
+	assert.True(t, server.EnableDebugServices)
+	assert.True(t, server.VerboseErrors)
+
+	// The debugging surfaces are off outside development unless turned on
+	t.Setenv("ENVIRONMENT", EnvironmentProduction)
+	cfg := &struct {
+		Server Server `yaml:"server"`
+	}{Server: NewServer(5000)}
+	require.NoError(t, Load(cfg, envOf(map[string]string{"GRPC_ENABLE_REFLECTION": "true"})))
+	assert.Equal(t, EnvironmentProduction, cfg.Server.Environment)
+	assert.True(t, cfg.Server.EnableReflection)
+	assert.False(t, cfg.Server.EnableDebugServices)
+	assert.False(t, cfg.Server.VerboseErrors)
+
+	cfg.Server = NewServer(5000)
+	assert.Error(t, Load(cfg, envOf(map[string]string{"ENVIRONMENT": "prod"})))
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// Deployment environments of the services
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "production"
+)
+
+// Server holds the gRPC server settings shared by the services, see NewServer for their defaults
+type Server struct {
+	Port int `yaml:"port" env:"SERVER_PORT" flag:"port" validate:"port"`
+	// Deployment environment, it picks the defaults of the debugging surfaces below
+	Environment string `yaml:"environment" env:"ENVIRONMENT" default:"development" validate:"oneof=development staging production"`
+	// Interval of the keepalive pings sent to idle clients, and how long the server waits for their reply
+	KeepAliveTime    time.Duration `yaml:"keepalive_time" env:"GRPC_KEEPALIVE_TIME" flag:"keepalive-time" default:"30s" validate:"positive"`
+	KeepAliveTimeout time.Duration `yaml:"keepalive_timeout" env:"GRPC_KEEPALIVE_TIMEOUT" flag:"keepalive-timeout" default:"10s" validate:"positive"`
+	EnableReflection bool          `yaml:"enable_reflection" env:"GRPC_ENABLE_REFLECTION" flag:"enable-reflection"`
+	// Channelz service, inspecting the connections and calls of the server
+	EnableDebugServices bool `yaml:"enable_debug_services" env:"GRPC_ENABLE_DEBUG_SERVICES" flag:"enable-debug-services"`
+	// Adds the underlying cause of the failed calls to their status as a google.rpc.DebugInfo detail
+	VerboseErrors bool `yaml:"verbose_errors" env:"GRPC_VERBOSE_ERRORS" flag:"verbose-errors"`
+}
+
+// NewServer returns the server settings of a service listening on port. Reflection, the debug services and the
+// verbose errors default to on in the development environment only, the environment is read from ENVIRONMENT
+// so the defaults follow it before the other settings are loaded
+func NewServer(port int) Server {
+	environment := os.Getenv("ENVIRONMENT")
+	if environment == "" {
+		environment = EnvironmentDevelopment
+	}
+	debug := environment == EnvironmentDevelopment
+	return Server{
+		Port:                port,
+		Environment:         environment,
+		EnableReflection:    debug,
+		EnableDebugServices: debug,
+		VerboseErrors:       debug,
+	}
+}
+
+// Metrics holds the settings of the Prometheus endpoint, each service sets its own default port
+type Metrics struct {
+	Port int `yaml:"port" env:"METRICS_PORT" flag:"metrics-port" validate:"port"`
+}
+
+// Mongo holds the connection settings of the MongoDB deployment
+type Mongo struct {
+	// Connection string of the deployment, the local development instance when empty
+	URI string `yaml:"uri" env:"MONGO_URI" flag:"mongo-uri"`
+	// Read preference of the reads that set none, e.g. secondaryPreferred, the one of the URI when empty. The
+	// report handlers prefer the secondaries regardless
+	ReadPreference string `yaml:"read_preference" env:"MONGO_READ_PREFERENCE" flag:"mongo-read-preference" validate:"oneof=primary primaryPreferred secondary secondaryPreferred nearest"`
+}
+
+// TLS holds the mTLS settings of the gRPC connections between the services
+type TLS struct {
+	// Directory of ca-cert.pem, cert.pem and key.pem, the resources/certs directory of the service when empty
+	CertsDir string `yaml:"certs_dir" env:"TLS_CERTS_DIR" flag:"tls-certs-dir"`
+	// Insecure disables TLS, for local development without certificates
+	Insecure bool `yaml:"insecure" env:"TLS_INSECURE" flag:"tls-insecure"`
+	// How often the certificate files are checked for a rotation
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"TLS_RELOAD_INTERVAL" default:"1m" validate:"positive"`
+	// Service identities allowed to call the server, e.g. gateway.erp.localhost. Any client with a certificate
+	// signed by the CA is accepted when empty
+	AllowedClients []string `yaml:"allowed_clients" env:"TLS_ALLOWED_CLIENTS" flag:"tls-allowed-clients"`
+}
+
+// Client holds the settings of the gRPC connections to the other services
+type Client struct {
+	ConnectTimeout time.Duration `yaml:"connect_timeout" env:"GRPC_CLIENT_CONNECT_TIMEOUT" flag:"grpc-client-connect-timeout" default:"5s" validate:"positive"`
+	// Deadline of the calls made without one, retries included
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"GRPC_CLIENT_REQUEST_TIMEOUT" flag:"grpc-client-request-timeout" default:"10s" validate:"positive"`
+	// Interval of the keepalive pings sent on idle connections, at least 10s, and how long their reply is waited for
+	KeepAliveTime    time.Duration `yaml:"keepalive_time" env:"GRPC_CLIENT_KEEPALIVE_TIME" flag:"grpc-client-keepalive-time" default:"30s" validate:"positive"`
+	KeepAliveTimeout time.Duration `yaml:"keepalive_timeout" env:"GRPC_CLIENT_KEEPALIVE_TIMEOUT" flag:"grpc-client-keepalive-timeout" default:"10s" validate:"positive"`
+	// Policy balancing the calls over the addresses a service resolves to, e.g. round_robin with dns:/// addresses
+	LoadBalancingPolicy string `yaml:"load_balancing_policy" env:"GRPC_CLIENT_LB_POLICY" flag:"grpc-client-lb-policy"`
+	// Attempts of the idempotent calls failing while a service is unavailable, 1 disables the retries
+	RetryAttempts int `yaml:"retry_attempts" env:"GRPC_CLIENT_RETRY_ATTEMPTS" flag:"grpc-client-retry-attempts" default:"3" validate:"positive"`
+}
@@ -1,12 +1,14 @@
 package db
 
+import "context"
+
 //go:generate mockgen -destination=mock/mock_db_handler.go -package=mock erp.localhost/internal/infra/db DBHandler
 
 type DBHandler interface {
 	Close() error
-	Create(db string, data any, opts ...map[string]any) (string, error)
-	FindOne(db string, filter map[string]any, result any) error
-	FindAll(db string, filter map[string]any, result any) error
-	Update(db string, filter map[string]any, data any, opts ...map[string]any) error
-	Delete(db string, filter map[string]any) error
+	Create(ctx context.Context, db string, data any, opts ...map[string]any) (string, error)
+	FindOne(ctx context.Context, db string, filter map[string]any, result any) error
+	FindAll(ctx context.Context, db string, filter map[string]any, result any) error
+	Update(ctx context.Context, db string, filter map[string]any, data any, opts ...map[string]any) error
+	Delete(ctx context.Context, db string, filter map[string]any) error
 }
@@ -0,0 +1,546 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/codec"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// duplicateKeyCode is the server error code of a write rejected by a unique index
+const duplicateKeyCode = 11000
+
+// Documents implements db.DBHandler on the collections of a database, with the filters of Match and the update
+// options of mongo.MongoDBManager. The documents are encoded with the codecs of the Mongo clients, in insertion
+// order. The only unique index is the one of _id
+type Documents struct {
+	mu          sync.RWMutex
+	collections map[string][]bson.M
+	// onAggregate sees the pipelines of the aggregations before they run, see OnAggregate
+	onAggregate func(collectionName string, pipeline any) error
+	// onWrite sees the documents inserted, updated and deleted before they are, see OnWrite
+	onWrite func(collectionName string, doc bson.M) error
+}
+
+// NewDocuments creates an empty database
+func NewDocuments() *Documents {
+	return &Documents{collections: map[string][]bson.M{}}
+}
+
+// Close does nothing, the documents are kept for the other handlers of the database
+func (d *Documents) Close() error {
+	return nil
+}
+
+// Reset removes every document of the database and the hooks
+func (d *Documents) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.collections = map[string][]bson.M{}
+	d.onAggregate = nil
+	d.onWrite = nil
+}
+
+// OnAggregate calls hook with the collection and the pipeline of every aggregation before it runs, an error of hook
+// fails the aggregation. Tests check with it the pipelines using stages the documents do not support, nil removes
+// the hook
+func (d *Documents) OnAggregate(hook func(collectionName string, pipeline any) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onAggregate = hook
+}
+
+// OnWrite calls hook with the collection and a copy of every document inserted, or updated or deleted as it is
+// stored, before it is written, an error of hook fails the write. Tests fail the writes of chosen documents with it,
+// hook runs with the database locked and must not use it. nil removes the hook
+func (d *Documents) OnWrite(hook func(collectionName string, doc bson.M) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onWrite = hook
+}
+
+// checkWrite returns the error of the write hook for doc. The caller holds mu
+func (d *Documents) checkWrite(collectionName string, doc bson.M) error {
+	if d.onWrite == nil {
+		return nil
+	}
+	return d.onWrite(collectionName, clone(doc))
+}
+
+// Create inserts data, an _id is generated when it has none, and returns the hex of its _id
+func (d *Documents) Create(ctx context.Context, collectionName string, data any, opts ...map[string]any) (string, error) {
+	doc, err := toDocument(data)
+	if err != nil {
+		return "", err
+	}
+	if id, ok := doc["_id"]; !ok || id == nil || id == primitive.NilObjectID {
+		doc["_id"] = primitive.NewObjectID()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.insert(collectionName, doc); err != nil {
+		return "", err
+	}
+	return idString(doc["_id"]), nil
+}
+
+// insert appends doc to the collection, unless a document has its _id. The caller holds mu
+func (d *Documents) insert(collectionName string, doc bson.M) error {
+	for _, existing := range d.collections[collectionName] {
+		if equal(existing["_id"], doc["_id"]) {
+			return duplicateKeyError(collectionName, doc["_id"])
+		}
+	}
+	if err := d.checkWrite(collectionName, doc); err != nil {
+		return err
+	}
+	d.collections[collectionName] = append(d.collections[collectionName], doc)
+	return nil
+}
+
+// remove deletes the document at index i of the collection. The caller holds mu
+func (d *Documents) remove(collectionName string, i int) error {
+	docs := d.collections[collectionName]
+	if err := d.checkWrite(collectionName, docs[i]); err != nil {
+		return err
+	}
+	d.collections[collectionName] = append(docs[:i:i], docs[i+1:]...)
+	return nil
+}
+
+// FindOne decodes the first document matching filter into result, mongo.ErrNoDocuments when none matches
+func (d *Documents) FindOne(ctx context.Context, collectionName string, filter map[string]any, result any) error {
+	if filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	i, err := d.find(collectionName, filter)
+	if err != nil {
+		return err
+	}
+	if i < 0 {
+		return driver_mongo.ErrNoDocuments
+	}
+	return decode(d.collections[collectionName][i], result)
+}
+
+// FindAll decodes the documents matching filter into result, a pointer to a slice
+func (d *Documents) FindAll(ctx context.Context, collectionName string, filter map[string]any, result any) error {
+	if filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	filter = convertFilter(filter)
+	d.mu.RLock()
+	matched := []any{}
+	for _, doc := range d.collections[collectionName] {
+		ok, err := Match(doc, filter)
+		if err != nil {
+			d.mu.RUnlock()
+			return err
+		}
+		if ok {
+			matched = append(matched, doc)
+		}
+	}
+	d.mu.RUnlock()
+	cursor, err := driver_mongo.NewCursorFromDocuments(matched, nil, codec.GetRegistry())
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, result)
+}
+
+// Count returns the number of documents matching filter, counting at most limit documents when it is positive
+func (d *Documents) Count(ctx context.Context, collectionName string, filter map[string]any, limit int64) (int64, error) {
+	if filter == nil {
+		return 0, errors.New("filter is required and cannot be nil")
+	}
+	filter = convertFilter(filter)
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var count int64
+	for _, doc := range d.collections[collectionName] {
+		if limit > 0 && count == limit {
+			break
+		}
+		ok, err := Match(doc, filter)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// List decodes the documents matching filter into result, a pointer to a slice, ordered, projected and limited by
+// opts as mongo.MongoDBManager.List does
+func (d *Documents) List(ctx context.Context, collectionName string, filter map[string]any, result any, opts mongo.ListOptions) error {
+	if filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	filter = convertFilter(filter)
+	d.mu.RLock()
+	matched := []bson.M{}
+	for _, doc := range d.collections[collectionName] {
+		ok, err := Match(doc, filter)
+		if err != nil {
+			d.mu.RUnlock()
+			return err
+		}
+		if ok {
+			matched = append(matched, doc)
+		}
+	}
+	d.mu.RUnlock()
+	listed, err := Pipeline(matched, opts.Stages())
+	if err != nil {
+		return err
+	}
+	documents := make([]any, len(listed))
+	for i, doc := range listed {
+		documents[i] = doc
+	}
+	cursor, err := driver_mongo.NewCursorFromDocuments(documents, nil, codec.GetRegistry())
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, result)
+}
+
+// Update sets the fields of data on the first document matching filter, with the mongo.UpdateOptionUnset and
+// mongo.UpdateOptionRequireMatch options
+func (d *Documents) Update(ctx context.Context, collectionName string, filter map[string]any, data any, opts ...map[string]any) error {
+	if filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	set, err := toDocument(data)
+	if err != nil {
+		return err
+	}
+	update := map[string]any{"$set": set}
+	unset := bson.M{}
+	for _, opt := range opts {
+		if fields, ok := opt[mongo.UpdateOptionUnset].([]string); ok {
+			for _, field := range fields {
+				unset[field] = ""
+			}
+		}
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, err := d.find(collectionName, filter)
+	if err != nil {
+		return err
+	}
+	if i < 0 {
+		if option(opts, mongo.UpdateOptionRequireMatch) {
+			return mongo.ErrNoMatch
+		}
+		return nil
+	}
+	return d.replace(collectionName, i, update, false)
+}
+
+// FindOneAndUpdate applies update to the first document matching filter and decodes the document after the update
+// into result, see mongo.MongoDBManager.FindOneAndUpdate
+func (d *Documents) FindOneAndUpdate(ctx context.Context, collectionName string, filter map[string]any, update map[string]any, result any, opts ...map[string]any) error {
+	if filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, err := d.find(collectionName, filter)
+	if err != nil {
+		return err
+	}
+	if i >= 0 {
+		if err := d.replace(collectionName, i, update, false); err != nil {
+			return err
+		}
+		return decode(d.collections[collectionName][i], result)
+	}
+	if !option(opts, mongo.UpdateOptionUpsert) {
+		return driver_mongo.ErrNoDocuments
+	}
+
+	doc, err := upsertDocument(convertFilter(filter))
+	if err != nil {
+		return err
+	}
+	if err := applyUpdate(doc, update, true); err != nil {
+		return err
+	}
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+	if err := d.insert(collectionName, doc); err != nil {
+		return err
+	}
+	return decode(doc, result)
+}
+
+// Delete removes the first document matching filter
+func (d *Documents) Delete(ctx context.Context, collectionName string, filter map[string]any) error {
+	if filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i, err := d.find(collectionName, filter)
+	if err != nil || i < 0 {
+		return err
+	}
+	return d.remove(collectionName, i)
+}
+
+// BulkWrite applies ops to the collection, see mongo.MongoDBManager.BulkWrite
+func (d *Documents) BulkWrite(ctx context.Context, collectionName string, ops []mongo.BulkOperation, opts mongo.BulkOptions) (*mongo.BulkResult, error) {
+	result := &mongo.BulkResult{InsertedIDs: make([]string, len(ops))}
+	bulkErr := &mongo.BulkWriteError{Attempted: len(ops)}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, op := range ops {
+		if err := d.bulkOperation(collectionName, op, i, result); err != nil {
+			bulkErr.Failures = append(bulkErr.Failures, mongo.BulkFailure{Index: i, Err: err})
+			if !opts.Unordered {
+				bulkErr.Attempted = i + 1
+				break
+			}
+		}
+	}
+	if len(bulkErr.Failures) > 0 {
+		return result, bulkErr
+	}
+	return result, nil
+}
+
+// bulkOperation applies the operation at index i of a bulk write and counts it in result. The caller holds mu
+func (d *Documents) bulkOperation(collectionName string, op mongo.BulkOperation, i int, result *mongo.BulkResult) error {
+	if op.Kind != mongo.BulkInsert && op.Filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	switch op.Kind {
+	case mongo.BulkInsert:
+		doc, err := toDocument(op.Document)
+		if err != nil {
+			return err
+		}
+		if id, ok := doc["_id"]; !ok || id == nil || id == primitive.NilObjectID {
+			doc["_id"] = primitive.NewObjectID()
+		}
+		if err := d.insert(collectionName, doc); err != nil {
+			return err
+		}
+		result.InsertedIDs[i] = idString(doc["_id"])
+	case mongo.BulkUpdate:
+		j, err := d.find(collectionName, op.Filter)
+		if err != nil {
+			return err
+		}
+		if j >= 0 {
+			if err := d.replace(collectionName, j, op.Update, false); err != nil {
+				return err
+			}
+			result.MatchedCount++
+			result.ModifiedCount++
+			return nil
+		}
+		if !op.Upsert {
+			return nil
+		}
+		doc, err := upsertDocument(convertFilter(op.Filter))
+		if err != nil {
+			return err
+		}
+		if err := applyUpdate(doc, op.Update, true); err != nil {
+			return err
+		}
+		if _, ok := doc["_id"]; !ok {
+			doc["_id"] = primitive.NewObjectID()
+		}
+		if err := d.insert(collectionName, doc); err != nil {
+			return err
+		}
+		result.UpsertedCount++
+	case mongo.BulkDelete:
+		j, err := d.find(collectionName, op.Filter)
+		if err != nil || j < 0 {
+			return err
+		}
+		if err := d.remove(collectionName, j); err != nil {
+			return err
+		}
+		result.DeletedCount++
+	default:
+		return fmt.Errorf("unknown bulk operation kind %d", op.Kind)
+	}
+	return nil
+}
+
+// Aggregate runs pipeline, a slice of stages, on the documents of the collection. See Pipeline for the stages it
+// supports
+func (d *Documents) Aggregate(ctx context.Context, collectionName string, pipeline interface{}) (*driver_mongo.Cursor, error) {
+	d.mu.RLock()
+	docs := make([]bson.M, 0, len(d.collections[collectionName]))
+	for _, doc := range d.collections[collectionName] {
+		docs = append(docs, clone(doc))
+	}
+	hook := d.onAggregate
+	d.mu.RUnlock()
+
+	if hook != nil {
+		if err := hook(collectionName, pipeline); err != nil {
+			return nil, err
+		}
+	}
+	output, err := Pipeline(docs, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]any, len(output))
+	for i, doc := range output {
+		results[i] = doc
+	}
+	return driver_mongo.NewCursorFromDocuments(results, nil, codec.GetRegistry())
+}
+
+// find returns the index of the first document matching filter, -1 when none matches. The caller holds mu
+func (d *Documents) find(collectionName string, filter map[string]any) (int, error) {
+	filter = convertFilter(filter)
+	for i, doc := range d.collections[collectionName] {
+		ok, err := Match(doc, filter)
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// replace applies update to a copy of the document at i and stores it, the document is left unchanged when the
+// update fails. The caller holds mu
+func (d *Documents) replace(collectionName string, i int, update map[string]any, inserting bool) error {
+	if err := d.checkWrite(collectionName, d.collections[collectionName][i]); err != nil {
+		return err
+	}
+	doc := clone(d.collections[collectionName][i])
+	if err := applyUpdate(doc, update, inserting); err != nil {
+		return err
+	}
+	if !equal(doc["_id"], d.collections[collectionName][i]["_id"]) {
+		return errors.New("the _id of a document is immutable")
+	}
+	d.collections[collectionName][i] = doc
+	return nil
+}
+
+// upsertDocument returns the document an upsert inserts for filter, with the fields of its equality conditions
+func upsertDocument(filter map[string]any) (bson.M, error) {
+	doc := bson.M{}
+	for key, condition := range filter {
+		if len(key) > 0 && key[0] == '$' {
+			continue
+		}
+		value := condition
+		if ops, ok := asMap(condition); ok && isOperatorDoc(ops) {
+			eq, ok := ops["$eq"]
+			if !ok {
+				continue
+			}
+			value = eq
+		}
+		normalized, err := normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		setPath(doc, key, normalized)
+	}
+	return doc, nil
+}
+
+// convertFilter returns filter with a hex string _id as an ObjectID, as the Mongo manager converts it, without
+// changing the filter of the caller
+func convertFilter(filter map[string]any) map[string]any {
+	id, ok := filter["_id"].(string)
+	if !ok {
+		return filter
+	}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return filter
+	}
+	converted := make(map[string]any, len(filter))
+	for key, value := range filter {
+		converted[key] = value
+	}
+	converted["_id"] = objectID
+	return converted
+}
+
+// option reports whether the bool option name is set in opts
+func option(opts []map[string]any, name string) bool {
+	for _, opt := range opts {
+		if enabled, _ := opt[name].(bool); enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// toDocument encodes data with the codecs of the Mongo clients and decodes it as a document
+func toDocument(data any) (bson.M, error) {
+	raw, err := bson.MarshalWithRegistry(codec.GetRegistry(), data)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// clone returns a deep copy of doc
+func clone(doc bson.M) bson.M {
+	copied, err := toDocument(doc)
+	if err != nil {
+		// The documents are decoded from BSON, they always encode
+		panic(err)
+	}
+	return copied
+}
+
+// decode decodes doc into result with the codecs of the Mongo clients
+func decode(doc bson.M, result any) error {
+	return driver_mongo.NewSingleResultFromDocument(doc, nil, codec.GetRegistry()).Decode(result)
+}
+
+func idString(id any) string {
+	if objectID, ok := id.(primitive.ObjectID); ok {
+		return objectID.Hex()
+	}
+	return fmt.Sprint(id)
+}
+
+// duplicateKeyError is the error of the server for an insert with the _id of a stored document
+func duplicateKeyError(collectionName string, id any) error {
+	return driver_mongo.WriteException{WriteErrors: driver_mongo.WriteErrors{{
+		Code:    duplicateKeyCode,
+		Message: fmt.Sprintf("E11000 duplicate key error collection: %s index: _id_ dup key: { _id: %v }", collectionName, id),
+	}}}
+}
@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type product struct {
+	ID        string                 `bson:"_id,omitempty"`
+	TenantID  string                 `bson:"tenant_id"`
+	Name      string                 `bson:"name"`
+	Quantity  int64                  `bson:"quantity"`
+	Tags      []string               `bson:"tags,omitempty"`
+	CreatedAt *timestamppb.Timestamp `bson:"created_at,omitempty"`
+}
+
+func TestDocuments_CRUD(t *testing.T) {
+	ctx := context.Background()
+	documents := NewDocuments()
+	createdAt := timestamppb.Now()
+
+	id, err := documents.Create(ctx, "products", &product{TenantID: "tenant-1", Name: "Widget", Quantity: 5, CreatedAt: createdAt})
+	require.NoError(t, err)
+	_, err = documents.Create(ctx, "products", &product{TenantID: "tenant-2", Name: "Gadget", Quantity: 1})
+	require.NoError(t, err)
+
+	var found product
+	require.NoError(t, documents.FindOne(ctx, "products", map[string]any{"_id": id}, &found))
+	assert.Equal(t, id, found.ID)
+	assert.Equal(t, "Widget", found.Name)
+	// The timestamps are stored in milliseconds
+	assert.WithinDuration(t, createdAt.AsTime(), found.CreatedAt.AsTime(), time.Millisecond)
+
+	var all []*product
+	require.NoError(t, documents.FindAll(ctx, "products", map[string]any{"quantity": map[string]any{"$gte": 1}}, &all))
+	assert.Len(t, all, 2)
+
+	require.NoError(t, documents.Update(ctx, "products", map[string]any{"_id": id}, bson.M{"name": "Widget 2"}))
+	require.NoError(t, documents.FindOne(ctx, "products", map[string]any{"_id": id}, &found))
+	assert.Equal(t, "Widget 2", found.Name)
+	assert.Equal(t, int64(5), found.Quantity)
+
+	require.NoError(t, documents.Delete(ctx, "products", map[string]any{"_id": id}))
+	err = documents.FindOne(ctx, "products", map[string]any{"_id": id}, &found)
+	assert.ErrorIs(t, err, driver_mongo.ErrNoDocuments)
+}
+
+func TestDocuments_FilterNotChanged(t *testing.T) {
+	ctx := context.Background()
+	documents := NewDocuments()
+	id, err := documents.Create(ctx, "products", &product{Name: "Widget"})
+	require.NoError(t, err)
+
+	filter := map[string]any{"_id": id}
+	var found product
+	require.NoError(t, documents.FindOne(ctx, "products", filter, &found))
+	assert.Equal(t, id, filter["_id"])
+}
+
+func TestDocuments_DuplicateID(t *testing.T) {
+	ctx := context.Background()
+	documents := NewDocuments()
+	id := primitive.NewObjectID()
+	_, err := documents.Create(ctx, "products", bson.M{"_id": id, "name": "Widget"})
+	require.NoError(t, err)
+
+	_, err = documents.Create(ctx, "products", bson.M{"_id": id, "name": "Gadget"})
+	assert.True(t, driver_mongo.IsDuplicateKeyError(err))
+}
+
+func TestDocuments_OnWrite(t *testing.T) {
+	ctx := context.Background()
+	documents := NewDocuments()
+	id, err := documents.Create(ctx, "products", &product{TenantID: "tenant-1", Name: "Widget", Quantity: 5})
+	require.NoError(t, err)
+
+	// The writes of the Widget fail, the other documents are written
+	errWrite := errors.New("write failed")
+	documents.OnWrite(func(collectionName string, doc bson.M) error {
+		if doc["name"] == "Widget" {
+			return errWrite
+		}
+		return nil
+	})
+	filter := map[string]any{"_id": id}
+	assert.ErrorIs(t, documents.Update(ctx, "products", filter, bson.M{"quantity": int64(6)}), errWrite)
+	assert.ErrorIs(t, documents.Delete(ctx, "products", filter), errWrite)
+	_, err = documents.BulkWrite(ctx, "products", []mongo.BulkOperation{mongo.DeleteOperation(filter)}, mongo.BulkOptions{})
+	assert.ErrorIs(t, err, errWrite)
+	_, err = documents.Create(ctx, "products", &product{TenantID: "tenant-1", Name: "Widget"})
+	assert.ErrorIs(t, err, errWrite)
+	_, err = documents.Create(ctx, "products", &product{TenantID: "tenant-1", Name: "Gadget"})
+	require.NoError(t, err)
+
+	var found product
+	require.NoError(t, documents.FindOne(ctx, "products", filter, &found))
+	assert.Equal(t, int64(5), found.Quantity)
+	var all []*product
+	require.NoError(t, documents.FindAll(ctx, "products", map[string]any{}, &all))
+	assert.Len(t, all, 2)
+
+	documents.OnWrite(nil)
+	require.NoError(t, documents.Delete(ctx, "products", filter))
+}
+
+func TestDocuments_UpdateOptions(t *testing.T) {
+	ctx := context.Background()
+	documents := NewDocuments()
+	id, err := documents.Create(ctx, "products", &product{Name: "Widget", Tags: []string{"new"}})
+	require.NoError(t, err)
+
+	err = documents.Update(ctx, "products", map[string]any{"_id": id}, bson.M{"name": "Widget"},
+		map[string]any{mongo.UpdateOptionUnset: []string{"tags"}})
+	require.NoError(t, err)
+	var found product
+	require.NoError(t, documents.FindOne(ctx, "products", map[string]any{"_id": id}, &found))
+	assert.Empty(t, found.Tags)
+
+	err = documents.Update(ctx, "products", map[string]any{"name": "Gadget"}, bson.M{"name": "Widget"},
+		map[string]any{mongo.UpdateOptionRequireMatch: true})
+	assert.ErrorIs(t, err, mongo.ErrNoMatch)
+	// Without the option an update matching nothing succeeds
+	require.NoError(t, documents.Update(ctx, "products", map[string]any{"name": "Gadget"}, bson.M{"name": "Widget"}))
+}
+
+func TestDocuments_FindOneAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	documents := NewDocuments()
+	filter := map[string]any{"tenant_id": "tenant-1", "name": "invoice"}
+	update := map[string]any{
+		"$inc":         map[string]any{"quantity": int64(1)},
+		"$addToSet":    map[string]any{"tags": "counter"},
+		"$setOnInsert": map[string]any{"created_at": timestamppb.Now()},
+	}
+
+	var counter product
+	err := documents.FindOneAndUpdate(ctx, "counters", filter, update, &counter)
+	require.ErrorIs(t, err, driver_mongo.ErrNoDocuments)
+
+	upsert := map[string]any{mongo.UpdateOptionUpsert: true}
+	require.NoError(t, documents.FindOneAndUpdate(ctx, "counters", filter, update, &counter, upsert))
+	assert.Equal(t, "tenant-1", counter.TenantID)
+	assert.Equal(t, int64(1), counter.Quantity)
+	assert.NotNil(t, counter.CreatedAt)
+
+	require.NoError(t, documents.FindOneAndUpdate(ctx, "counters", filter, update, &counter, upsert))
+	assert.Equal(t, int64(2), counter.Quantity)
+	assert.Equal(t, []string{"counter"}, counter.Tags)
+
+	var all []*product
+	require.NoError(t, documents.FindAll(ctx, "counters", map[string]any{}, &all))
+	assert.Len(t, all, 1)
+
+	pull := map[string]any{"$pull": map[string]any{"tags": "counter"}}
+	require.NoError(t, documents.FindOneAndUpdate(ctx, "counters", filter, pull, &counter))
+	assert.Empty(t, counter.Tags)
+}
+
+func TestDocuments_Aggregate(t *testing.T) {
+	ctx := context.Background()
+	documents := NewDocuments()
+	for _, p := range []*product{
+		{TenantID: "tenant-1", Name: "a", Quantity: 3, Tags: []string{"red", "blue"}},
+		{TenantID: "tenant-1", Name: "b", Quantity: 1, Tags: []string{"red"}},
+		{TenantID: "tenant-1", Name: "c", Quantity: 2},
+		{TenantID: "tenant-2", Name: "d", Quantity: 9, Tags: []string{"red"}},
+	} {
+		_, err := documents.Create(ctx, "products", p)
+		require.NoError(t, err)
+	}
+
+	cursor, err := documents.Aggregate(ctx, "products", []bson.M{
+		{"$match": bson.M{"tenant_id": "tenant-1"}},
+		{"$facet": bson.M{
+			"data":  bson.A{bson.M{"$sort": bson.D{{Key: "quantity", Value: -1}}}, bson.M{"$skip": 1}, bson.M{"$limit": 1}},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}},
+	})
+	require.NoError(t, err)
+	var page []struct {
+		Data  []product `bson:"data"`
+		Total []struct {
+			Count int32 `bson:"count"`
+		} `bson:"total"`
+	}
+	require.NoError(t, cursor.All(ctx, &page))
+	require.Len(t, page, 1)
+	require.Len(t, page[0].Data, 1)
+	assert.Equal(t, "c", page[0].Data[0].Name)
+	assert.Equal(t, int32(3), page[0].Total[0].Count)
+
+	cursor, err = documents.Aggregate(ctx, "products", []bson.M{
+		{"$unwind": "$tags"},
+		{"$group": bson.M{"_id": "$tags", "quantity": bson.M{"$sum": "$quantity"}, "count": bson.M{"$count": bson.M{}}}},
+		{"$sort": bson.M{"_id": 1}},
+	})
+	require.NoError(t, err)
+	var tags []struct {
+		Tag      string `bson:"_id"`
+		Quantity int64  `bson:"quantity"`
+		Count    int32  `bson:"count"`
+	}
+	require.NoError(t, cursor.All(ctx, &tags))
+	require.Len(t, tags, 2)
+	assert.Equal(t, "blue", tags[0].Tag)
+	assert.Equal(t, int64(3), tags[0].Quantity)
+	assert.Equal(t, "red", tags[1].Tag)
+	assert.Equal(t, int64(13), tags[1].Quantity)
+	assert.Equal(t, int32(3), tags[1].Count)
+
+	_, err = documents.Aggregate(ctx, "products", []bson.M{{"$lookup": bson.M{"from": "orders"}}})
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	// The hook sees the pipelines before they run and fails them with its error
+	errHook := errors.New("hook")
+	var seen []any
+	documents.OnAggregate(func(collectionName string, pipeline any) error {
+		assert.Equal(t, "products", collectionName)
+		seen = append(seen, pipeline)
+		return errHook
+	})
+	lookup := []bson.M{{"$lookup": bson.M{"from": "orders"}}}
+	_, err = documents.Aggregate(ctx, "products", lookup)
+	assert.ErrorIs(t, err, errHook)
+	assert.Equal(t, []any{lookup}, seen)
+	documents.Reset()
+	_, err = documents.Aggregate(ctx, "products", lookup)
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
@@ -0,0 +1,294 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+// errWrongType is returned for the operations on a key holding the other kind of value, as Redis does
+var errWrongType = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+// entry is the value of a key, a JSON value or the members of a set
+type entry struct {
+	value   []byte
+	members map[string]struct{}
+	// expiresAt is zero for the keys without TTL
+	expiresAt time.Time
+}
+
+// keyspace holds the keys of the prefixes sharing it, as a Redis database
+type keyspace struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	now     func() time.Time
+}
+
+func newKeyspace() *keyspace {
+	return &keyspace{entries: map[string]*entry{}, now: time.Now}
+}
+
+// get returns the entry of key, nil when missing or expired. The caller holds mu
+func (s *keyspace) get(key string) *entry {
+	e, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+	if !e.expiresAt.IsZero() && !s.now().Before(e.expiresAt) {
+		delete(s.entries, key)
+		return nil
+	}
+	return e
+}
+
+// keys returns the live keys matching pattern in order. The caller holds mu
+func (s *keyspace) keys(pattern string) []string {
+	keys := []string{}
+	for key := range s.entries {
+		if s.get(key) != nil && globMatch(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Keys implements db.DBHandler and the set and scan operations of the Redis handlers on the keys of a prefix, the
+// values are stored as JSON and the keys with a TTL expire on the clock of the keyspace
+type Keys struct {
+	space  *keyspace
+	prefix string
+}
+
+// NewKeys creates the keys of keyPrefix in a keyspace of their own
+func NewKeys(keyPrefix string) *Keys {
+	return &Keys{space: newKeyspace(), prefix: keyPrefix}
+}
+
+// SetClock replaces the clock the TTLs expire on, e.g. to expire keys in tests without waiting. It changes the
+// clock of every prefix of the keyspace
+func (k *Keys) SetClock(now func() time.Time) {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	k.space.now = now
+}
+
+func (k *Keys) key(key string) string {
+	return fmt.Sprintf("%s:%s", k.prefix, key)
+}
+
+// Close does nothing, the keys are kept for the other handlers of the keyspace
+func (k *Keys) Close() error {
+	return nil
+}
+
+// Create sets key to value, with the time.Duration "ttl" of the first options as its TTL
+func (k *Keys) Create(ctx context.Context, key string, value any, opts ...map[string]any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	e := &entry{value: data}
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	if len(opts) > 0 {
+		if ttl, ok := opts[0]["ttl"].(time.Duration); ok && ttl > 0 {
+			e.expiresAt = k.space.now().Add(ttl)
+		}
+	}
+	k.space.entries[k.key(key)] = e
+	return "OK", nil
+}
+
+// FindOne decodes the value of key into result, redis.Nil when the key is missing
+func (k *Keys) FindOne(ctx context.Context, key string, filter map[string]any, result any) error {
+	k.space.mu.Lock()
+	e := k.space.get(k.key(key))
+	k.space.mu.Unlock()
+	if e == nil {
+		return redis.Nil
+	}
+	if e.members != nil {
+		return errWrongType
+	}
+	return json.Unmarshal(e.value, result)
+}
+
+// FindAll appends the values of the keys starting with key to result, a *[]*T
+func (k *Keys) FindAll(ctx context.Context, key string, filter map[string]any, result any) error {
+	resultVal := reflect.ValueOf(result)
+	if resultVal.Kind() != reflect.Ptr ||
+		resultVal.Elem().Kind() != reflect.Slice ||
+		resultVal.Elem().Type().Elem().Kind() != reflect.Ptr {
+		return fmt.Errorf("result must be a pointer to a slice of pointers (e.g. *[]*T)")
+	}
+	sliceVal := resultVal.Elem()
+	elemType := sliceVal.Type().Elem().Elem()
+
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	for _, fullKey := range k.space.keys(k.key(key) + "*") {
+		e := k.space.get(fullKey)
+		if e.members != nil {
+			continue
+		}
+		newElem := reflect.New(elemType)
+		if err := json.Unmarshal(e.value, newElem.Interface()); err != nil {
+			return err
+		}
+		sliceVal.Set(reflect.Append(sliceVal, newElem))
+	}
+	return nil
+}
+
+// Update sets key to value, as a plain SET the TTL of the key is cleared
+func (k *Keys) Update(ctx context.Context, key string, filter map[string]any, value any, opts ...map[string]any) error {
+	_, err := k.Create(ctx, key, value)
+	return err
+}
+
+func (k *Keys) Delete(ctx context.Context, key string, filter map[string]any) error {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	delete(k.space.entries, k.key(key))
+	return nil
+}
+
+func (k *Keys) SAdd(ctx context.Context, key string, members ...any) error {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	fullKey := k.key(key)
+	e := k.space.get(fullKey)
+	if e == nil {
+		e = &entry{members: map[string]struct{}{}}
+		k.space.entries[fullKey] = e
+	}
+	if e.members == nil {
+		return errWrongType
+	}
+	for _, member := range members {
+		e.members[fmt.Sprint(member)] = struct{}{}
+	}
+	return nil
+}
+
+func (k *Keys) SRem(ctx context.Context, key string, members ...any) error {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	fullKey := k.key(key)
+	e := k.space.get(fullKey)
+	if e == nil {
+		return nil
+	}
+	if e.members == nil {
+		return errWrongType
+	}
+	for _, member := range members {
+		delete(e.members, fmt.Sprint(member))
+	}
+	// Redis removes the sets left empty
+	if len(e.members) == 0 {
+		delete(k.space.entries, fullKey)
+	}
+	return nil
+}
+
+// SMembers returns the members of the set of key in order, none when the key is missing
+func (k *Keys) SMembers(ctx context.Context, key string) ([]string, error) {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	e := k.space.get(k.key(key))
+	if e == nil {
+		return []string{}, nil
+	}
+	if e.members == nil {
+		return nil, errWrongType
+	}
+	members := make([]string, 0, len(e.members))
+	for member := range e.members {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// Expire sets the TTL of key, a TTL that is not positive deletes it
+func (k *Keys) Expire(ctx context.Context, key string, ttl int, unit time.Duration) error {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	fullKey := k.key(key)
+	e := k.space.get(fullKey)
+	if e == nil {
+		return nil
+	}
+	if ttl <= 0 {
+		delete(k.space.entries, fullKey)
+		return nil
+	}
+	e.expiresAt = k.space.now().Add(time.Duration(ttl) * unit)
+	return nil
+}
+
+func (k *Keys) Clear(ctx context.Context, key string) error {
+	return k.Delete(ctx, key, nil)
+}
+
+// Scan returns the keys matching pattern, relative to the prefix, with the prefix as SCAN does. batchSize is unused
+func (k *Keys) Scan(ctx context.Context, pattern string, batchSize int64) ([]string, error) {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	return k.space.keys(k.key(pattern)), nil
+}
+
+// DeleteByPattern deletes the keys matching pattern, relative to the prefix, and returns their number
+func (k *Keys) DeleteByPattern(ctx context.Context, pattern string) (int, error) {
+	k.space.mu.Lock()
+	defer k.space.mu.Unlock()
+	keys := k.space.keys(k.key(pattern))
+	for _, key := range keys {
+		delete(k.space.entries, key)
+	}
+	return len(keys), nil
+}
+
+// globMatch reports whether key matches the glob-style pattern of SCAN, with the * and ? wildcards and \ escapes
+func globMatch(pattern, key string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 0 && pattern[0] == '*' {
+				pattern = pattern[1:]
+			}
+			if pattern == "" {
+				return true
+			}
+			for i := 0; i <= len(key); i++ {
+				if globMatch(pattern, key[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if key == "" {
+				return false
+			}
+			pattern, key = pattern[1:], key[1:]
+		default:
+			if pattern[0] == '\\' && len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			if key == "" || key[0] != pattern[0] {
+				return false
+			}
+			pattern, key = pattern[1:], key[1:]
+		}
+	}
+	return key == ""
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type token struct {
+	ID string `json:"id"`
+}
+
+func TestKeys(t *testing.T) {
+	ctx := context.Background()
+	keys := NewKeys("tokens")
+
+	_, err := keys.Create(ctx, "tenant-1:user-1", &token{ID: "1"})
+	require.NoError(t, err)
+	_, err = keys.Create(ctx, "tenant-1:user-2", &token{ID: "2"})
+	require.NoError(t, err)
+	_, err = keys.Create(ctx, "tenant-2:user-1", &token{ID: "3"})
+	require.NoError(t, err)
+
+	var found token
+	require.NoError(t, keys.FindOne(ctx, "tenant-1:user-1", nil, &found))
+	assert.Equal(t, "1", found.ID)
+
+	var all []*token
+	require.NoError(t, keys.FindAll(ctx, "tenant-1:", nil, &all))
+	assert.Len(t, all, 2)
+
+	scanned, err := keys.Scan(ctx, "tenant-1:*", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tokens:tenant-1:user-1", "tokens:tenant-1:user-2"}, scanned)
+
+	deleted, err := keys.DeleteByPattern(ctx, "tenant-1:user-?")
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	err = keys.FindOne(ctx, "tenant-1:user-1", nil, &found)
+	assert.ErrorIs(t, err, redis.Nil)
+
+	require.NoError(t, keys.Delete(ctx, "tenant-2:user-1", nil))
+	assert.ErrorIs(t, keys.FindOne(ctx, "tenant-2:user-1", nil, &found), redis.Nil)
+}
+
+func TestKeys_TTL(t *testing.T) {
+	ctx := context.Background()
+	keys := NewKeys("tokens")
+	now := time.Now()
+	keys.SetClock(func() time.Time { return now })
+
+	_, err := keys.Create(ctx, "tenant-1:user-1", &token{ID: "1"}, map[string]any{"ttl": time.Minute})
+	require.NoError(t, err)
+	_, err = keys.Create(ctx, "tenant-1:user-2", &token{ID: "2"}, map[string]any{"ttl": time.Minute})
+	require.NoError(t, err)
+	// As a plain SET, the update clears the TTL
+	require.NoError(t, keys.Update(ctx, "tenant-1:user-2", nil, &token{ID: "2"}))
+
+	var found token
+	now = now.Add(59 * time.Second)
+	require.NoError(t, keys.FindOne(ctx, "tenant-1:user-1", nil, &found))
+
+	now = now.Add(time.Second)
+	assert.ErrorIs(t, keys.FindOne(ctx, "tenant-1:user-1", nil, &found), redis.Nil)
+	require.NoError(t, keys.FindOne(ctx, "tenant-1:user-2", nil, &found))
+
+	require.NoError(t, keys.Expire(ctx, "tenant-1:user-2", 10, time.Second))
+	now = now.Add(10 * time.Second)
+	scanned, err := keys.Scan(ctx, "*", 100)
+	require.NoError(t, err)
+	assert.Empty(t, scanned)
+}
+
+func TestKeys_Sets(t *testing.T) {
+	ctx := context.Background()
+	keys := NewKeys("sessions")
+
+	require.NoError(t, keys.SAdd(ctx, "tenant-1:user-1", "b", "a", "b"))
+	members, err := keys.SMembers(ctx, "tenant-1:user-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, members)
+
+	var found token
+	assert.Error(t, keys.FindOne(ctx, "tenant-1:user-1", nil, &found))
+
+	require.NoError(t, keys.SRem(ctx, "tenant-1:user-1", "a", "b"))
+	members, err = keys.SMembers(ctx, "tenant-1:user-1")
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func TestGlobMatch(t *testing.T) {
+	assert.True(t, globMatch("tokens:*", "tokens:tenant-1:user-1"))
+	assert.True(t, globMatch("tokens:tenant-?:*", "tokens:tenant-1:user-1"))
+	assert.True(t, globMatch(`tokens:a\*`, "tokens:a*"))
+	assert.False(t, globMatch(`tokens:a\*`, "tokens:ab"))
+	assert.False(t, globMatch("tokens:tenant-1:*", "tokens:tenant-2:user-1"))
+}
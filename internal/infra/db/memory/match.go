@@ -0,0 +1,458 @@
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"erp.localhost/internal/infra/db/mongo/codec"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Match reports whether doc matches filter, a MongoDB query filter. It supports the comparison ($eq, $ne, $gt, $gte,
+// $lt, $lte, $in, $nin), element ($exists), evaluation ($regex), array ($all, $elemMatch, $size) and logical ($and,
+// $or, $nor, $not) operators, the other operators are errors
+func Match(doc bson.M, filter map[string]any) (bool, error) {
+	for key, condition := range filter {
+		var ok bool
+		var err error
+		switch key {
+		case "$and", "$or", "$nor":
+			ok, err = matchLogical(doc, key, condition)
+		default:
+			if strings.HasPrefix(key, "$") {
+				return false, fmt.Errorf("%w: query operator %s", ErrUnsupported, key)
+			}
+			values, found := lookup(doc, key)
+			ok, err = matchCondition(values, found, condition)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchLogical(doc bson.M, op string, condition any) (bool, error) {
+	clauses, ok := asList(condition)
+	if !ok || len(clauses) == 0 {
+		return false, fmt.Errorf("%s takes a non empty array of filters", op)
+	}
+	for _, clause := range clauses {
+		filter, ok := asMap(clause)
+		if !ok {
+			return false, fmt.Errorf("%s takes an array of filters", op)
+		}
+		matched, err := Match(doc, filter)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case op == "$and" && !matched:
+			return false, nil
+		case op == "$or" && matched:
+			return true, nil
+		case op == "$nor" && matched:
+			return false, nil
+		}
+	}
+	return op != "$or", nil
+}
+
+// matchCondition matches the values of a field, found when the document has it, with condition, a value or an
+// operator document
+func matchCondition(values []any, found bool, condition any) (bool, error) {
+	ops, ok := asMap(condition)
+	if !ok || !isOperatorDoc(ops) {
+		return matchEq(values, found, condition)
+	}
+	for op, operand := range ops {
+		ok, err := matchOperator(values, found, op, operand, ops)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchOperator(values []any, found bool, op string, operand any, ops map[string]any) (bool, error) {
+	switch op {
+	case "$eq":
+		return matchEq(values, found, operand)
+	case "$ne":
+		ok, err := matchEq(values, found, operand)
+		return !ok, err
+	case "$in", "$nin":
+		list, ok := asList(operand)
+		if !ok {
+			return false, fmt.Errorf("%s takes an array", op)
+		}
+		in := false
+		for _, item := range list {
+			ok, err := matchEq(values, found, item)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				in = true
+				break
+			}
+		}
+		return in == (op == "$in"), nil
+	case "$gt", "$gte", "$lt", "$lte":
+		want, err := normalize(operand)
+		if err != nil {
+			return false, err
+		}
+		return anyElement(values, func(v any) bool {
+			c, ok := compare(v, want)
+			if !ok {
+				return false
+			}
+			switch op {
+			case "$gt":
+				return c > 0
+			case "$gte":
+				return c >= 0
+			case "$lt":
+				return c < 0
+			default:
+				return c <= 0
+			}
+		}), nil
+	case "$exists":
+		exists, _ := operand.(bool)
+		return found == exists, nil
+	case "$regex":
+		pattern, ok := operand.(string)
+		if !ok {
+			if regex, isRegex := operand.(primitive.Regex); isRegex {
+				pattern, ok = regex.Pattern, true
+				if _, hasOptions := ops["$options"]; !hasOptions {
+					ops = map[string]any{"$options": regex.Options}
+				}
+			}
+		}
+		if !ok {
+			return false, fmt.Errorf("$regex takes a string")
+		}
+		if options, _ := ops["$options"].(string); strings.Contains(options, "i") {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, err
+		}
+		return anyElement(values, func(v any) bool {
+			s, ok := v.(string)
+			return ok && re.MatchString(s)
+		}), nil
+	case "$options":
+		// Read by $regex
+		return true, nil
+	case "$size":
+		size, err := normalize(operand)
+		if err != nil {
+			return false, err
+		}
+		for _, v := range values {
+			if array, ok := v.(primitive.A); ok {
+				if c, ok := compare(int64(len(array)), size); ok && c == 0 {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case "$all":
+		list, ok := asList(operand)
+		if !ok {
+			return false, fmt.Errorf("$all takes an array")
+		}
+		for _, item := range list {
+			ok, err := matchEq(values, found, item)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return len(list) > 0, nil
+	case "$elemMatch":
+		filter, ok := asMap(operand)
+		if !ok {
+			return false, fmt.Errorf("$elemMatch takes a document")
+		}
+		for _, v := range values {
+			array, ok := v.(primitive.A)
+			if !ok {
+				continue
+			}
+			for _, element := range array {
+				var matched bool
+				var err error
+				if doc, isDoc := element.(bson.M); isDoc && !isOperatorDoc(filter) {
+					matched, err = Match(doc, filter)
+				} else {
+					matched, err = matchCondition([]any{element}, true, filter)
+				}
+				if err != nil {
+					return false, err
+				}
+				if matched {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case "$not":
+		ok, err := matchCondition(values, found, operand)
+		return !ok, err
+	default:
+		return false, fmt.Errorf("%w: query operator %s", ErrUnsupported, op)
+	}
+}
+
+// matchEq reports whether a value of the field equals want, or contains it for an array. A missing field equals nil
+func matchEq(values []any, found bool, want any) (bool, error) {
+	want, err := normalize(want)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return want == nil, nil
+	}
+	for _, v := range values {
+		if equal(v, want) {
+			return true, nil
+		}
+		if array, ok := v.(primitive.A); ok {
+			for _, element := range array {
+				if equal(element, want) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// anyElement reports whether fn holds for a value of the field, or an element of an array value
+func anyElement(values []any, fn func(v any) bool) bool {
+	for _, v := range values {
+		if array, ok := v.(primitive.A); ok {
+			for _, element := range array {
+				if fn(element) {
+					return true
+				}
+			}
+			continue
+		}
+		if fn(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the values of the dotted path in doc, the paths through arrays of documents reach the field of
+// every document. found is false when no value is reached
+func lookup(doc bson.M, path string) ([]any, bool) {
+	current := []any{doc}
+	for _, segment := range strings.Split(path, ".") {
+		next := []any{}
+		for _, value := range current {
+			switch value := value.(type) {
+			case bson.M:
+				if v, ok := value[segment]; ok {
+					next = append(next, v)
+				}
+			case primitive.A:
+				if i, err := strconv.Atoi(segment); err == nil {
+					if i >= 0 && i < len(value) {
+						next = append(next, value[i])
+					}
+					continue
+				}
+				for _, element := range value {
+					if element, ok := element.(bson.M); ok {
+						if v, ok := element[segment]; ok {
+							next = append(next, v)
+						}
+					}
+				}
+			}
+		}
+		current = next
+	}
+	return current, len(current) > 0
+}
+
+// normalize returns v as decoded from BSON, the values of the filters then compare with the ones of the documents
+func normalize(v any) (any, error) {
+	switch v.(type) {
+	case nil, string, bool, int32, int64, float64, primitive.ObjectID, primitive.DateTime:
+		return v, nil
+	}
+	raw, err := bson.MarshalWithRegistry(codec.GetRegistry(), bson.M{"v": v})
+	if err != nil {
+		return nil, err
+	}
+	var decoded bson.M
+	if err := bson.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded["v"], nil
+}
+
+// compare orders a and b of the same BSON type, the numbers compare whatever their type
+func compare(a, b any) (int, bool) {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+		return 0, false
+	}
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case y:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case primitive.DateTime:
+		if y, ok := b.(primitive.DateTime); ok {
+			return compareInt(int64(x), int64(y)), true
+		}
+	case primitive.ObjectID:
+		if y, ok := b.(primitive.ObjectID); ok {
+			return bytes.Compare(x[:], y[:]), true
+		}
+	case nil:
+		if b == nil {
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+func compareInt(x, y int64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// equal reports whether the normalized values a and b are equal, the documents and arrays field by field
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case bson.M:
+		y, ok := b.(bson.M)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case primitive.A:
+		y, ok := b.(primitive.A)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if c, ok := compare(a, b); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// isOperatorDoc reports whether the keys of m are operators, e.g. {"$in": [...]}
+func isOperatorDoc(m map[string]any) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for key := range m {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// asMap returns v as a map, whether it is a map[string]any, a bson.M or a bson.D
+func asMap(v any) (map[string]any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		return v, true
+	case bson.M:
+		return v, true
+	case bson.D:
+		m := make(map[string]any, len(v))
+		for _, e := range v {
+			m[e.Key] = e.Value
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// asList returns the elements of v, a slice or an array other than bytes
+func asList(v any) ([]any, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	list := make([]any, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, true
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMatch(t *testing.T) {
+	id := primitive.NewObjectID()
+	doc := bson.M{
+		"_id":       id,
+		"tenant_id": "tenant-1",
+		"status":    int32(2),
+		"quantity":  int64(5),
+		"name":      "Widget",
+		"role_ids":  primitive.A{"a", "b"},
+		"address":   bson.M{"city": "Haifa"},
+		"lines": primitive.A{
+			bson.M{"sku": "A-1", "quantity": int32(2)},
+			bson.M{"sku": "B-2", "quantity": int32(7)},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		filter   map[string]any
+		expected bool
+	}{
+		{name: "empty", filter: map[string]any{}, expected: true},
+		{name: "equal", filter: map[string]any{"tenant_id": "tenant-1", "status": 2}, expected: true},
+		{name: "not equal", filter: map[string]any{"tenant_id": "tenant-2"}, expected: false},
+		{name: "object id", filter: map[string]any{"_id": id}, expected: true},
+		{name: "numbers of other types", filter: map[string]any{"quantity": 5.0}, expected: true},
+		{name: "array contains", filter: map[string]any{"role_ids": "b"}, expected: true},
+		{name: "whole array", filter: map[string]any{"role_ids": []string{"a", "b"}}, expected: true},
+		{name: "dotted path", filter: map[string]any{"address.city": "Haifa"}, expected: true},
+		{name: "path through array", filter: map[string]any{"lines.sku": "B-2"}, expected: true},
+		{name: "missing equals nil", filter: map[string]any{"deleted_at": nil}, expected: true},
+		{name: "$ne", filter: map[string]any{"status": map[string]any{"$ne": 3}}, expected: true},
+		{name: "$in", filter: map[string]any{"role_ids": map[string]any{"$in": []string{"c", "a"}}}, expected: true},
+		{name: "$nin", filter: map[string]any{"status": map[string]any{"$nin": []int{1, 2}}}, expected: false},
+		{name: "range", filter: map[string]any{"quantity": map[string]any{"$gte": 5, "$lt": 10}}, expected: true},
+		{name: "out of range", filter: map[string]any{"quantity": map[string]any{"$gt": 5}}, expected: false},
+		{name: "range of other type", filter: map[string]any{"name": map[string]any{"$gt": 1}}, expected: false},
+		{name: "$exists", filter: map[string]any{"address": map[string]any{"$exists": true}}, expected: true},
+		{name: "$exists false", filter: map[string]any{"deleted_at": bson.M{"$exists": false}}, expected: true},
+		{name: "$regex", filter: map[string]any{"name": map[string]any{"$regex": "^wid", "$options": "i"}}, expected: true},
+		{name: "$size", filter: map[string]any{"role_ids": map[string]any{"$size": 2}}, expected: true},
+		{name: "$all", filter: map[string]any{"role_ids": map[string]any{"$all": []string{"b", "a"}}}, expected: true},
+		{
+			name:     "$elemMatch",
+			filter:   map[string]any{"lines": map[string]any{"$elemMatch": map[string]any{"sku": "A-1", "quantity": map[string]any{"$gt": 5}}}},
+			expected: false,
+		},
+		{name: "$not", filter: map[string]any{"status": map[string]any{"$not": map[string]any{"$gt": 3}}}, expected: true},
+		{name: "$or", filter: map[string]any{"$or": []map[string]any{{"status": 1}, {"name": "Widget"}}}, expected: true},
+		{name: "$and", filter: map[string]any{"$and": bson.A{bson.M{"status": 2}, bson.M{"name": "Gadget"}}}, expected: false},
+		{name: "$nor", filter: map[string]any{"$nor": []map[string]any{{"status": 1}}}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, err := Match(doc, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestMatch_UnsupportedOperator(t *testing.T) {
+	_, err := Match(bson.M{"location": "here"}, map[string]any{"location": map[string]any{"$near": 1}})
+	assert.ErrorIs(t, err, ErrUnsupported)
+	_, err = Match(bson.M{}, map[string]any{"$where": "true"})
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
@@ -0,0 +1,53 @@
+// Package memory stores the documents of the Mongo collections and the keys of Redis in the process, for the tests
+// and the local development without the servers. Documents implements the db.DBHandler of the collection handlers
+// with the filters, updates and simple pipelines of MongoDB, Keys implements the one of the key handlers with the
+// TTLs of Redis.
+//
+// In the in-memory mode, enabled with Enable before the handlers are created, the collection and key handlers of
+// the process share the stores of SharedDocuments and SharedKeys, and the data is lost when the process exits
+package memory
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrUnsupported is returned for the operations the in-memory stores do not implement, e.g. transactions, change
+// streams or a pipeline stage
+var ErrUnsupported = errors.New("not supported by the in-memory store")
+
+var enabled atomic.Bool
+
+// Enable switches the process to the in-memory mode, the handlers created afterwards use the shared stores
+func Enable() {
+	enabled.Store(true)
+}
+
+// Enabled reports whether the process runs in the in-memory mode
+func Enabled() bool {
+	return enabled.Load()
+}
+
+var (
+	sharedMu        sync.Mutex
+	sharedDocuments = map[string]*Documents{}
+	sharedKeyspace  = newKeyspace()
+)
+
+// SharedDocuments returns the documents of database dbName shared by the handlers of the process
+func SharedDocuments(dbName string) *Documents {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	documents, ok := sharedDocuments[dbName]
+	if !ok {
+		documents = NewDocuments()
+		sharedDocuments[dbName] = documents
+	}
+	return documents
+}
+
+// SharedKeys returns the keys of keyPrefix in the keyspace shared by the handlers of the process
+func SharedKeys(keyPrefix string) *Keys {
+	return &Keys{space: sharedKeyspace, prefix: keyPrefix}
+}
@@ -0,0 +1,523 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Pipeline runs pipeline, a slice of aggregation stages, on docs. It supports the $match, $sort, $skip, $limit,
+// $project, $addFields, $set, $unset, $unwind, $group, $count and $facet stages, with expressions made of field
+// paths ("$field") and literals. $group supports the $sum, $avg, $min, $max, $first, $last, $push, $addToSet and
+// $count accumulators. The other stages and operators are ErrUnsupported errors
+func Pipeline(docs []bson.M, pipeline any) ([]bson.M, error) {
+	stages, ok := asList(pipeline)
+	if !ok {
+		return nil, fmt.Errorf("pipeline must be an array of stages")
+	}
+	for _, stage := range stages {
+		spec, ok := asMap(stage)
+		if !ok || len(spec) != 1 {
+			return nil, fmt.Errorf("pipeline stage must be a document with one field")
+		}
+		for name, operand := range spec {
+			var err error
+			docs, err = runStage(docs, name, operand)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return docs, nil
+}
+
+func runStage(docs []bson.M, name string, operand any) ([]bson.M, error) {
+	switch name {
+	case "$match":
+		filter, ok := asMap(operand)
+		if !ok {
+			return nil, fmt.Errorf("$match takes a document")
+		}
+		matched := []bson.M{}
+		for _, doc := range docs {
+			ok, err := Match(doc, filter)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = append(matched, doc)
+			}
+		}
+		return matched, nil
+	case "$sort":
+		return sortStage(docs, operand)
+	case "$skip", "$limit":
+		n, ok := number(operand)
+		if !ok || n < 0 {
+			return nil, fmt.Errorf("%s takes a positive number", name)
+		}
+		count := min(int(n), len(docs))
+		if name == "$skip" {
+			return docs[count:], nil
+		}
+		return docs[:count], nil
+	case "$project":
+		return projectStage(docs, operand)
+	case "$addFields", "$set":
+		fields, ok := asMap(operand)
+		if !ok {
+			return nil, fmt.Errorf("%s takes a document", name)
+		}
+		out := make([]bson.M, 0, len(docs))
+		for _, doc := range docs {
+			doc = clone(doc)
+			for _, field := range sortedKeys(fields) {
+				value, found, err := evaluate(doc, fields[field])
+				if err != nil {
+					return nil, err
+				}
+				if found {
+					if err := setPath(doc, field, value); err != nil {
+						return nil, err
+					}
+				}
+			}
+			out = append(out, doc)
+		}
+		return out, nil
+	case "$unset":
+		fields, ok := asList(operand)
+		if field, isString := operand.(string); isString {
+			fields, ok = []any{field}, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("$unset takes a field or an array of fields")
+		}
+		out := make([]bson.M, 0, len(docs))
+		for _, doc := range docs {
+			doc = clone(doc)
+			for _, field := range fields {
+				deletePath(doc, fmt.Sprint(field))
+			}
+			out = append(out, doc)
+		}
+		return out, nil
+	case "$unwind":
+		return unwindStage(docs, operand)
+	case "$group":
+		return groupStage(docs, operand)
+	case "$count":
+		field, ok := operand.(string)
+		if !ok || field == "" {
+			return nil, fmt.Errorf("$count takes a field name")
+		}
+		if len(docs) == 0 {
+			return []bson.M{}, nil
+		}
+		return []bson.M{{field: int32(len(docs))}}, nil
+	case "$facet":
+		facets, ok := asMap(operand)
+		if !ok {
+			return nil, fmt.Errorf("$facet takes a document of pipelines")
+		}
+		out := bson.M{}
+		for field, pipeline := range facets {
+			results, err := Pipeline(docs, pipeline)
+			if err != nil {
+				return nil, err
+			}
+			array := make(primitive.A, len(results))
+			for i, result := range results {
+				array[i] = result
+			}
+			out[field] = array
+		}
+		return []bson.M{out}, nil
+	default:
+		return nil, fmt.Errorf("%w: pipeline stage %s", ErrUnsupported, name)
+	}
+}
+
+func sortStage(docs []bson.M, operand any) ([]bson.M, error) {
+	fields, spec, ok := orderedFields(operand)
+	if !ok || len(fields) == 0 {
+		return nil, fmt.Errorf("$sort takes a document of fields")
+	}
+	directions := make([]int, len(fields))
+	for i, field := range fields {
+		direction, ok := number(spec[field])
+		if !ok || (direction != 1 && direction != -1) {
+			return nil, fmt.Errorf("$sort direction of %s must be 1 or -1", field)
+		}
+		directions[i] = int(direction)
+	}
+	sorted := append([]bson.M{}, docs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for k, field := range fields {
+			a, _ := getPath(sorted[i], field)
+			b, _ := getPath(sorted[j], field)
+			if c := sortCompare(a, b); c != 0 {
+				return c*directions[k] < 0
+			}
+		}
+		return false
+	})
+	return sorted, nil
+}
+
+// sortCompare orders a and b in the BSON comparison order of the types, then by value
+func sortCompare(a, b any) int {
+	if rankA, rankB := typeRank(a), typeRank(b); rankA != rankB {
+		return compareInt(int64(rankA), int64(rankB))
+	}
+	c, _ := compare(a, b)
+	return c
+}
+
+func typeRank(v any) int {
+	if _, ok := number(v); ok {
+		return 2
+	}
+	switch v.(type) {
+	case nil:
+		return 1
+	case string:
+		return 3
+	case bson.M:
+		return 4
+	case primitive.A:
+		return 5
+	case primitive.Binary:
+		return 6
+	case primitive.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case primitive.DateTime:
+		return 9
+	default:
+		return 10
+	}
+}
+
+func projectStage(docs []bson.M, operand any) ([]bson.M, error) {
+	spec, ok := asMap(operand)
+	if !ok || len(spec) == 0 {
+		return nil, fmt.Errorf("$project takes a document of fields")
+	}
+	excludeID := false
+	if included, isFlag := flag(spec["_id"]); isFlag && !included {
+		excludeID = true
+	}
+	exclusion := true
+	for field, value := range spec {
+		if included, isFlag := flag(value); field != "_id" && (!isFlag || included) {
+			exclusion = false
+		}
+	}
+
+	out := make([]bson.M, 0, len(docs))
+	for _, doc := range docs {
+		if exclusion {
+			projected := clone(doc)
+			for field := range spec {
+				deletePath(projected, field)
+			}
+			out = append(out, projected)
+			continue
+		}
+		projected := bson.M{}
+		if id, ok := doc["_id"]; ok && !excludeID {
+			projected["_id"] = id
+		}
+		for _, field := range sortedKeys(spec) {
+			if included, isFlag := flag(spec[field]); isFlag {
+				if value, found := getPath(doc, field); found && included {
+					if err := setPath(projected, field, value); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+			value, found, err := evaluate(doc, spec[field])
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				if err := setPath(projected, field, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		out = append(out, projected)
+	}
+	return out, nil
+}
+
+// flag returns the inclusion of a $project field given 1, 0, true or false
+func flag(v any) (bool, bool) {
+	if b, ok := v.(bool); ok {
+		return b, true
+	}
+	if n, ok := number(v); ok {
+		return n != 0, true
+	}
+	return false, false
+}
+
+func unwindStage(docs []bson.M, operand any) ([]bson.M, error) {
+	path, preserve := "", false
+	switch spec := operand.(type) {
+	case string:
+		path = spec
+	default:
+		fields, ok := asMap(operand)
+		if !ok {
+			return nil, fmt.Errorf("$unwind takes a field path or a document")
+		}
+		for key, value := range fields {
+			switch key {
+			case "path":
+				path, _ = value.(string)
+			case "preserveNullAndEmptyArrays":
+				preserve, _ = value.(bool)
+			default:
+				return nil, fmt.Errorf("%w: $unwind option %s", ErrUnsupported, key)
+			}
+		}
+	}
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("$unwind path must start with $")
+	}
+	path = path[1:]
+
+	out := []bson.M{}
+	for _, doc := range docs {
+		value, found := getPath(doc, path)
+		array, isArray := value.(primitive.A)
+		switch {
+		case isArray && len(array) > 0:
+			for _, element := range array {
+				unwound := clone(doc)
+				if err := setPath(unwound, path, element); err != nil {
+					return nil, err
+				}
+				out = append(out, unwound)
+			}
+		case isArray || !found || value == nil:
+			if preserve {
+				unwound := clone(doc)
+				if isArray {
+					deletePath(unwound, path)
+				}
+				out = append(out, unwound)
+			}
+		default:
+			out = append(out, doc)
+		}
+	}
+	return out, nil
+}
+
+// group is the output document of a $group key, with the running sums of its $avg fields
+type group struct {
+	doc  bson.M
+	avgs map[string][2]float64
+}
+
+func groupStage(docs []bson.M, operand any) ([]bson.M, error) {
+	spec, ok := asMap(operand)
+	if !ok {
+		return nil, fmt.Errorf("$group takes a document")
+	}
+	idExpr, ok := spec["_id"]
+	if !ok {
+		return nil, fmt.Errorf("$group requires an _id")
+	}
+	fields := sortedKeys(spec)
+
+	groups := []*group{}
+	for _, doc := range docs {
+		id, _, err := evaluate(doc, idExpr)
+		if err != nil {
+			return nil, err
+		}
+		var current *group
+		for _, g := range groups {
+			if equal(g.doc["_id"], id) {
+				current = g
+				break
+			}
+		}
+		created := current == nil
+		if created {
+			current = &group{doc: bson.M{"_id": id}, avgs: map[string][2]float64{}}
+			groups = append(groups, current)
+		}
+		for _, field := range fields {
+			if field == "_id" {
+				continue
+			}
+			if err := accumulate(current, field, spec[field], doc, created); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out := make([]bson.M, 0, len(groups))
+	for _, g := range groups {
+		for field, avg := range g.avgs {
+			g.doc[field] = nil
+			if avg[1] > 0 {
+				g.doc[field] = avg[0] / avg[1]
+			}
+		}
+		out = append(out, g.doc)
+	}
+	return out, nil
+}
+
+func accumulate(g *group, field string, accumulator any, doc bson.M, created bool) error {
+	ops, ok := asMap(accumulator)
+	if !ok || len(ops) != 1 {
+		return fmt.Errorf("$group field %s must be an accumulator", field)
+	}
+	for op, expr := range ops {
+		if op == "$count" {
+			op, expr = "$sum", int32(1)
+		}
+		value, found, err := evaluate(doc, expr)
+		if err != nil {
+			return err
+		}
+		switch op {
+		case "$sum":
+			if created {
+				g.doc[field] = int32(0)
+			}
+			if sum, ok := add(g.doc[field], value); ok {
+				g.doc[field] = sum
+			}
+		case "$avg":
+			if n, ok := number(value); ok {
+				avg := g.avgs[field]
+				g.avgs[field] = [2]float64{avg[0] + n, avg[1] + 1}
+			} else if created {
+				g.avgs[field] = [2]float64{}
+			}
+		case "$min", "$max":
+			if !found || value == nil {
+				if created {
+					g.doc[field] = nil
+				}
+				continue
+			}
+			current, ok := g.doc[field]
+			c := sortCompare(value, current)
+			if !ok || current == nil || (op == "$min" && c < 0) || (op == "$max" && c > 0) {
+				g.doc[field] = value
+			}
+		case "$first":
+			if created {
+				g.doc[field] = value
+			}
+		case "$last":
+			g.doc[field] = value
+		case "$push", "$addToSet":
+			array, _ := g.doc[field].(primitive.A)
+			if array == nil {
+				array = primitive.A{}
+			}
+			if found && (op == "$push" || !containsEqual(array, value)) {
+				array = append(array, value)
+			}
+			g.doc[field] = array
+		default:
+			return fmt.Errorf("%w: accumulator %s", ErrUnsupported, op)
+		}
+	}
+	return nil
+}
+
+// evaluate returns the value of expr for doc, expr being a field path, a literal or a document or an array of
+// expressions. found is false for a path missing from doc
+func evaluate(doc bson.M, expr any) (any, bool, error) {
+	if path, ok := expr.(string); ok && strings.HasPrefix(path, "$") {
+		if strings.HasPrefix(path, "$$") {
+			return nil, false, fmt.Errorf("%w: variable %s", ErrUnsupported, path)
+		}
+		values, found := lookup(doc, path[1:])
+		switch {
+		case !found:
+			return nil, false, nil
+		case len(values) == 1:
+			return values[0], true, nil
+		default:
+			return primitive.A(values), true, nil
+		}
+	}
+	if fields, ok := asMap(expr); ok {
+		if isOperatorDoc(fields) {
+			if literal, ok := fields["$literal"]; ok && len(fields) == 1 {
+				value, err := normalize(literal)
+				return value, true, err
+			}
+			for op := range fields {
+				return nil, false, fmt.Errorf("%w: expression operator %s", ErrUnsupported, op)
+			}
+		}
+		out := bson.M{}
+		for field, value := range fields {
+			evaluated, found, err := evaluate(doc, value)
+			if err != nil {
+				return nil, false, err
+			}
+			if found {
+				out[field] = evaluated
+			}
+		}
+		return out, true, nil
+	}
+	if list, ok := asList(expr); ok {
+		out := make(primitive.A, 0, len(list))
+		for _, value := range list {
+			evaluated, _, err := evaluate(doc, value)
+			if err != nil {
+				return nil, false, err
+			}
+			out = append(out, evaluated)
+		}
+		return out, true, nil
+	}
+	value, err := normalize(expr)
+	return value, true, err
+}
+
+// orderedFields returns the fields of v in order, the one of a bson.D and the sorted one of a map
+func orderedFields(v any) ([]string, map[string]any, bool) {
+	if d, ok := v.(bson.D); ok {
+		fields := make([]string, len(d))
+		for i, e := range d {
+			fields[i] = e.Key
+		}
+		m, _ := asMap(d)
+		return fields, m, true
+	}
+	m, ok := asMap(v)
+	if !ok {
+		return nil, nil, false
+	}
+	return sortedKeys(m), m, true
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,247 @@
+package memory
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// applyUpdate applies update, an update document of the $set, $unset, $inc, $setOnInsert, $push, $addToSet and
+// $pull operators, to doc. $setOnInsert applies only when inserting
+func applyUpdate(doc bson.M, update map[string]any, inserting bool) error {
+	if len(update) == 0 {
+		return fmt.Errorf("update document must not be empty")
+	}
+	for op, operand := range update {
+		fields, ok := asMap(operand)
+		if !ok {
+			return fmt.Errorf("%s takes a document of fields, replacement documents are not supported", op)
+		}
+		for path, value := range fields {
+			var err error
+			switch op {
+			case "$set":
+				err = setNormalized(doc, path, value)
+			case "$setOnInsert":
+				if inserting {
+					err = setNormalized(doc, path, value)
+				}
+			case "$unset":
+				deletePath(doc, path)
+			case "$inc":
+				err = inc(doc, path, value)
+			case "$push", "$addToSet":
+				err = push(doc, path, value, op == "$addToSet")
+			case "$pull":
+				err = pull(doc, path, value)
+			default:
+				err = fmt.Errorf("%w: update operator %s", ErrUnsupported, op)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setNormalized(doc bson.M, path string, value any) error {
+	normalized, err := normalize(value)
+	if err != nil {
+		return err
+	}
+	return setPath(doc, path, normalized)
+}
+
+func inc(doc bson.M, path string, value any) error {
+	amount, err := normalize(value)
+	if err != nil {
+		return err
+	}
+	if _, ok := number(amount); !ok {
+		return fmt.Errorf("$inc of %s takes a number", path)
+	}
+	current, found := getPath(doc, path)
+	if !found || current == nil {
+		return setPath(doc, path, amount)
+	}
+	sum, ok := add(current, amount)
+	if !ok {
+		return fmt.Errorf("$inc of %s applies to a number", path)
+	}
+	return setPath(doc, path, sum)
+}
+
+// push appends the value, or the values of {"$each": [...]}, to the array of path. With unique the values
+// already in the array are skipped
+func push(doc bson.M, path string, value any, unique bool) error {
+	values := []any{value}
+	if ops, ok := asMap(value); ok {
+		if each, ok := ops["$each"]; ok {
+			if values, ok = asList(each); !ok {
+				return fmt.Errorf("$each takes an array")
+			}
+		}
+	}
+	array := primitive.A{}
+	if current, found := getPath(doc, path); found && current != nil {
+		existing, ok := current.(primitive.A)
+		if !ok {
+			return fmt.Errorf("%s is not an array", path)
+		}
+		array = append(array, existing...)
+	}
+	for _, v := range values {
+		normalized, err := normalize(v)
+		if err != nil {
+			return err
+		}
+		if unique && containsEqual(array, normalized) {
+			continue
+		}
+		array = append(array, normalized)
+	}
+	return setPath(doc, path, array)
+}
+
+// pull removes from the array of path the elements equal to condition, or matching it when it is a filter
+func pull(doc bson.M, path string, condition any) error {
+	current, found := getPath(doc, path)
+	if !found {
+		return nil
+	}
+	array, ok := current.(primitive.A)
+	if !ok {
+		return fmt.Errorf("%s is not an array", path)
+	}
+	kept := primitive.A{}
+	for _, element := range array {
+		var matched bool
+		var err error
+		filter, isFilter := asMap(condition)
+		if doc, isDoc := element.(bson.M); isDoc && isFilter && !isOperatorDoc(filter) {
+			matched, err = Match(doc, filter)
+		} else {
+			matched, err = matchCondition([]any{element}, true, condition)
+		}
+		if err != nil {
+			return err
+		}
+		if !matched {
+			kept = append(kept, element)
+		}
+	}
+	return setPath(doc, path, kept)
+}
+
+func containsEqual(array primitive.A, value any) bool {
+	for _, element := range array {
+		if equal(element, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// add returns the sum of the numbers a and b, of the widest of their types. The sums of int32 overflowing are int64
+func add(a, b any) (any, bool) {
+	x, ok := number(a)
+	if !ok {
+		return nil, false
+	}
+	y, ok := number(b)
+	if !ok {
+		return nil, false
+	}
+	_, aFloat := a.(float64)
+	_, bFloat := b.(float64)
+	if aFloat || bFloat {
+		return x + y, true
+	}
+	sum := int64(x) + int64(y)
+	_, aInt32 := a.(int32)
+	_, bInt32 := b.(int32)
+	if aInt32 && bInt32 && sum >= math.MinInt32 && sum <= math.MaxInt32 {
+		return int32(sum), true
+	}
+	return sum, true
+}
+
+// getPath returns the value of the dotted path in doc, through documents and array indexes
+func getPath(doc bson.M, path string) (any, bool) {
+	var current any = doc
+	for _, segment := range strings.Split(path, ".") {
+		switch value := current.(type) {
+		case bson.M:
+			next, ok := value[segment]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case primitive.A:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(value) {
+				return nil, false
+			}
+			current = value[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setPath sets the dotted path in doc to value, creating the missing documents on the way
+func setPath(doc bson.M, path string, value any) error {
+	segments := strings.Split(path, ".")
+	var current any = doc
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch container := current.(type) {
+		case bson.M:
+			if last {
+				container[segment] = value
+				return nil
+			}
+			next, ok := container[segment]
+			if !ok || next == nil {
+				next = bson.M{}
+				container[segment] = next
+			}
+			current = next
+		case primitive.A:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(container) {
+				return fmt.Errorf("cannot set %s, %s is not an index of the array", path, segment)
+			}
+			if last {
+				container[index] = value
+				return nil
+			}
+			current = container[index]
+		default:
+			return fmt.Errorf("cannot set %s, %s is not in a document", path, segment)
+		}
+	}
+	return nil
+}
+
+// deletePath removes the dotted path from doc
+func deletePath(doc bson.M, path string) {
+	parent, field := doc, path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		value, ok := getPath(doc, path[:i])
+		if !ok {
+			return
+		}
+		if parent, ok = value.(bson.M); !ok {
+			return
+		}
+		field = path[i+1:]
+	}
+	delete(parent, field)
+}
@@ -10,6 +10,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -54,9 +55,9 @@ func (mr *MockDBHandlerMockRecorder) Close() *gomock.Call {
 }
 
 // Create mocks base method.
-func (m *MockDBHandler) Create(db string, data any, opts ...map[string]any) (string, error) {
+func (m *MockDBHandler) Create(ctx context.Context, db string, data any, opts ...map[string]any) (string, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{db, data}
+	varargs := []any{ctx, db, data}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
@@ -67,58 +68,58 @@ func (m *MockDBHandler) Create(db string, data any, opts ...map[string]any) (str
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockDBHandlerMockRecorder) Create(db, data any, opts ...any) *gomock.Call {
+func (mr *MockDBHandlerMockRecorder) Create(ctx, db, data any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{db, data}, opts...)
+	varargs := append([]any{ctx, db, data}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDBHandler)(nil).Create), varargs...)
 }
 
 // Delete mocks base method.
-func (m *MockDBHandler) Delete(db string, filter map[string]any) error {
+func (m *MockDBHandler) Delete(ctx context.Context, db string, filter map[string]any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", db, filter)
+	ret := m.ctrl.Call(m, "Delete", ctx, db, filter)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockDBHandlerMockRecorder) Delete(db, filter any) *gomock.Call {
+func (mr *MockDBHandlerMockRecorder) Delete(ctx, db, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDBHandler)(nil).Delete), db, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDBHandler)(nil).Delete), ctx, db, filter)
 }
 
 // FindAll mocks base method.
-func (m *MockDBHandler) FindAll(db string, filter map[string]any, result any) error {
+func (m *MockDBHandler) FindAll(ctx context.Context, db string, filter map[string]any, result any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", db, filter, result)
+	ret := m.ctrl.Call(m, "FindAll", ctx, db, filter, result)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockDBHandlerMockRecorder) FindAll(db, filter, result any) *gomock.Call {
+func (mr *MockDBHandlerMockRecorder) FindAll(ctx, db, filter, result any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockDBHandler)(nil).FindAll), db, filter, result)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockDBHandler)(nil).FindAll), ctx, db, filter, result)
 }
 
 // FindOne mocks base method.
-func (m *MockDBHandler) FindOne(db string, filter map[string]any, result any) error {
+func (m *MockDBHandler) FindOne(ctx context.Context, db string, filter map[string]any, result any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOne", db, filter, result)
+	ret := m.ctrl.Call(m, "FindOne", ctx, db, filter, result)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FindOne indicates an expected call of FindOne.
-func (mr *MockDBHandlerMockRecorder) FindOne(db, filter, result any) *gomock.Call {
+func (mr *MockDBHandlerMockRecorder) FindOne(ctx, db, filter, result any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockDBHandler)(nil).FindOne), db, filter, result)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockDBHandler)(nil).FindOne), ctx, db, filter, result)
 }
 
 // Update mocks base method.
-func (m *MockDBHandler) Update(db string, filter map[string]any, data any, opts ...map[string]any) error {
+func (m *MockDBHandler) Update(ctx context.Context, db string, filter map[string]any, data any, opts ...map[string]any) error {
 	m.ctrl.T.Helper()
-	varargs := []any{db, filter, data}
+	varargs := []any{ctx, db, filter, data}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
@@ -128,8 +129,8 @@ func (m *MockDBHandler) Update(db string, filter map[string]any, data any, opts
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockDBHandlerMockRecorder) Update(db, filter, data any, opts ...any) *gomock.Call {
+func (mr *MockDBHandlerMockRecorder) Update(ctx, db, filter, data any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{db, filter, data}, opts...)
+	varargs := append([]any{ctx, db, filter, data}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDBHandler)(nil).Update), varargs...)
 }
@@ -3,11 +3,13 @@ package aggregation
 import (
 	"context"
 
+	"erp.localhost/internal/infra/db/memory"
 	"erp.localhost/internal/infra/db/mongo"
 	"erp.localhost/internal/infra/logging/logger"
 	model_mongo "erp.localhost/internal/infra/model/db/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
 )
 
 // AggregationHandler generic interface for MongoDB aggregation operations
@@ -20,16 +22,30 @@ type AggregationHandler[T any] interface {
 	BatchGetByIDs(ctx context.Context, tenantID string, ids []string, fields []string) ([]*T, error)
 }
 
+// aggregator runs the pipelines, mongo.MongoDBManager or memory.Documents in the in-memory mode
+type aggregator interface {
+	Aggregate(ctx context.Context, collectionName string, pipeline interface{}) (*driver_mongo.Cursor, error)
+}
+
 // BaseAggregationHandler provides generic aggregation functionality
 // Follows same pattern as BaseCollectionHandler[T]
 type BaseAggregationHandler[T any] struct {
-	dbHandler  *mongo.MongoDBManager
+	dbHandler  aggregator
 	collection string
 	logger     logger.Logger
+	// readPreference of the aggregations made without one, see SetReadPreference
+	readPreference mongo.ReadPreference
 }
 
 // NewBaseAggregationHandler creates a new generic aggregation handler
 func NewBaseAggregationHandler[T any](dbName model_mongo.DBName, collection model_mongo.Collection, logger logger.Logger) (*BaseAggregationHandler[T], error) {
+	if memory.Enabled() {
+		return &BaseAggregationHandler[T]{
+			dbHandler:  memory.SharedDocuments(string(dbName)),
+			collection: string(collection),
+			logger:     logger,
+		}, nil
+	}
 	dbHandler, err := mongo.NewMongoDBManager(dbName, logger)
 	if dbHandler == nil {
 		logger.Fatal("failed to create mongo db manager for aggregation handler", "error", err)
@@ -43,6 +59,12 @@ func NewBaseAggregationHandler[T any](dbName model_mongo.DBName, collection mode
 	}, nil
 }
 
+// SetReadPreference sends the aggregations made without a read preference of their own to the members selected by
+// pref, see mongo.WithReadPreference
+func (h *BaseAggregationHandler[T]) SetReadPreference(pref mongo.ReadPreference) {
+	h.readPreference = pref
+}
+
 // Aggregate executes an aggregation pipeline with optional field projection
 func (h *BaseAggregationHandler[T]) Aggregate(
 	ctx context.Context,
@@ -61,7 +83,7 @@ func (h *BaseAggregationHandler[T]) Aggregate(
 	h.logger.Debug("executing aggregation pipeline", "collection", h.collection, "stages", len(pipeline))
 
 	// Execute aggregation using dbHandler's Aggregate method
-	cursor, err := h.dbHandler.Aggregate(ctx, h.collection, pipeline)
+	cursor, err := h.dbHandler.Aggregate(mongo.WithDefaultReadPreference(ctx, h.readPreference), h.collection, pipeline)
 	if err != nil {
 		h.logger.Error("aggregation failed", "error", err, "collection", h.collection)
 		return nil, err
@@ -10,6 +10,21 @@ import (
 // ---------- Helpers ----------
 //
 
+// activeRoleMatch builds a $match stage keeping the unwound user roles without expires_at or expiring after now.
+// Documents of users without roles have no roles.expires_at and are kept.
+func activeRoleMatch() bson.M {
+	return bson.M{
+		"$match": bson.M{
+			"$expr": bson.M{
+				"$or": bson.A{
+					bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$roles.expires_at", nil}}, nil}},
+					bson.M{"$gt": bson.A{"$roles.expires_at", "$$NOW"}},
+				},
+			},
+		},
+	}
+}
+
 // safeObjectIdConvert builds a $convert expression that safely converts a value to ObjectId.
 // We use this everywhere IDs are stored as strings to avoid lookup mismatches and crashes.
 func safeObjectIdConvert(field string) bson.M {
@@ -61,6 +76,9 @@ func BuildUserPermissionsPipeline(tenantID, userID string) []bson.M {
 			},
 		},
 
+		// Drop the expired role assignments.
+		activeRoleMatch(),
+
 		// Convert roles.role_id from string → ObjectId so it can be joined
 		// against roles._id in the roles collection.
 		{
@@ -214,6 +232,9 @@ func BuildUserRolesPipeline(tenantID, userID string) []bson.M {
 			"$unwind": "$roles",
 		},
 
+		// Drop the expired role assignments.
+		activeRoleMatch(),
+
 		// Normalize role_id for lookup compatibility.
 		{
 			"$addFields": bson.M{
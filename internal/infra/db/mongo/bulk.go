@@ -0,0 +1,216 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"erp.localhost/internal/infra/db/mongo/codec"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BulkOperationKind is the kind of write of a BulkOperation
+type BulkOperationKind int
+
+const (
+	BulkInsert BulkOperationKind = iota
+	BulkUpdate
+	BulkDelete
+)
+
+// BulkOperation is one write of a bulk write, made with InsertOperation, UpdateOperation or DeleteOperation
+type BulkOperation struct {
+	Kind BulkOperationKind
+	// Document is the inserted document, it gets a new _id unless it has one
+	Document any
+	// Filter selects the document updated or deleted, the first matching one
+	Filter map[string]any
+	// Update is the update document of an update, e.g. {"$set": {...}}
+	Update map[string]any
+	// Upsert inserts the document of the filter and the update when no document matches an update
+	Upsert bool
+}
+
+// InsertOperation returns the bulk operation inserting document
+func InsertOperation(document any) BulkOperation {
+	return BulkOperation{Kind: BulkInsert, Document: document}
+}
+
+// UpdateOperation returns the bulk operation applying update to the first document matching filter
+func UpdateOperation(filter, update map[string]any, upsert bool) BulkOperation {
+	return BulkOperation{Kind: BulkUpdate, Filter: filter, Update: update, Upsert: upsert}
+}
+
+// DeleteOperation returns the bulk operation deleting the first document matching filter
+func DeleteOperation(filter map[string]any) BulkOperation {
+	return BulkOperation{Kind: BulkDelete, Filter: filter}
+}
+
+// BulkOptions are the options of a bulk write. The zero value writes in order
+type BulkOptions struct {
+	// Unordered attempts every operation whatever the failures, in any order. Ordered writes stop at the first
+	// failure, the operations after it are not attempted
+	Unordered bool
+}
+
+// BulkResult reports the operations of a bulk write that were applied
+type BulkResult struct {
+	// InsertedIDs are the ids of the inserted documents by operation index, empty for the other operations and the
+	// inserts that were not applied
+	InsertedIDs   []string
+	MatchedCount  int64
+	ModifiedCount int64
+	DeletedCount  int64
+	UpsertedCount int64
+}
+
+// BulkFailure is an operation of a bulk write that failed
+type BulkFailure struct {
+	Index int
+	Err   error
+}
+
+// BulkWriteError is returned with the BulkResult of a bulk write some operations of failed, the other operations
+// were applied as the result reports
+type BulkWriteError struct {
+	Failures []BulkFailure
+	// Attempted is the number of operations the write attempted, all of them unless it was ordered
+	Attempted int
+}
+
+func (e *BulkWriteError) Error() string {
+	failures := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		failures = append(failures, fmt.Sprintf("operation %d: %v", failure.Index, failure.Err))
+	}
+	return fmt.Sprintf("%d of %d bulk write operations failed: %s", len(e.Failures), e.Attempted, strings.Join(failures, "; "))
+}
+
+// Unwrap returns the errors of the failed operations
+func (e *BulkWriteError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
+
+// Applied reports whether the operation at index was applied, the operations after the first failure of an ordered
+// write were not attempted
+func (e *BulkWriteError) Applied(index int) bool {
+	if index >= e.Attempted {
+		return false
+	}
+	for _, failure := range e.Failures {
+		if failure.Index == index {
+			return false
+		}
+	}
+	return true
+}
+
+// BulkWrite applies ops to the collection in one round trip. When some operations fail the result reports the
+// operations applied and the error is a *BulkWriteError, any other error means nothing was written
+func (m *MongoDBManager) BulkWrite(ctx context.Context, collectionName string, ops []BulkOperation, opts BulkOptions) (*BulkResult, error) {
+	m.logger.Debug("bulk writing", "collection", collectionName, "operations", len(ops), "unordered", opts.Unordered)
+	if len(ops) == 0 {
+		return &BulkResult{}, nil
+	}
+	insertedIDs := make([]string, len(ops))
+	models := make([]mongo.WriteModel, 0, len(ops))
+	for i, op := range ops {
+		switch op.Kind {
+		case BulkInsert:
+			document, id, err := insertDocument(op.Document)
+			if err != nil {
+				return nil, err
+			}
+			insertedIDs[i] = id
+			models = append(models, mongo.NewInsertOneModel().SetDocument(document))
+		case BulkUpdate:
+			if op.Filter == nil {
+				return nil, errors.New("filter is required and cannot be nil")
+			}
+			m.convertFilterToMongoTypes(op.Filter)
+			models = append(models, mongo.NewUpdateOneModel().SetFilter(op.Filter).SetUpdate(op.Update).SetUpsert(op.Upsert))
+		case BulkDelete:
+			if op.Filter == nil {
+				return nil, errors.New("filter is required and cannot be nil")
+			}
+			m.convertFilterToMongoTypes(op.Filter)
+			models = append(models, mongo.NewDeleteOneModel().SetFilter(op.Filter))
+		default:
+			return nil, fmt.Errorf("unknown bulk operation kind %d", op.Kind)
+		}
+	}
+
+	collection := m.db.Collection(collectionName)
+	ctx, cancel := operationContext(ctx)
+	defer cancel()
+	result, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(!opts.Unordered))
+	var exception mongo.BulkWriteException
+	if err != nil && (!errors.As(err, &exception) || len(exception.WriteErrors) == 0) {
+		return nil, err
+	}
+	bulkResult := &BulkResult{InsertedIDs: insertedIDs}
+	if result != nil {
+		bulkResult.MatchedCount = result.MatchedCount
+		bulkResult.ModifiedCount = result.ModifiedCount
+		bulkResult.DeletedCount = result.DeletedCount
+		bulkResult.UpsertedCount = result.UpsertedCount
+	}
+	if err == nil {
+		return bulkResult, nil
+	}
+	bulkErr := &BulkWriteError{Attempted: len(ops)}
+	for _, writeError := range exception.WriteErrors {
+		bulkErr.Failures = append(bulkErr.Failures, BulkFailure{
+			Index: writeError.Index,
+			Err:   mongo.WriteException{WriteErrors: mongo.WriteErrors{writeError.WriteError}},
+		})
+		if !opts.Unordered {
+			bulkErr.Attempted = writeError.Index + 1
+		}
+	}
+	return bulkResult.dropUnapplied(bulkErr), bulkErr
+}
+
+// dropUnapplied clears the ids of the inserts err reports as not applied
+func (r *BulkResult) dropUnapplied(err *BulkWriteError) *BulkResult {
+	for i := range r.InsertedIDs {
+		if !err.Applied(i) {
+			r.InsertedIDs[i] = ""
+		}
+	}
+	return r
+}
+
+// insertDocument returns data as the document a bulk write inserts and its id, a new ObjectID unless data has an
+// _id
+func insertDocument(data any) (bson.M, string, error) {
+	raw, err := bson.MarshalWithRegistry(codec.GetRegistry(), data)
+	if err != nil {
+		return nil, "", err
+	}
+	document := bson.M{}
+	if err := bson.Unmarshal(raw, &document); err != nil {
+		return nil, "", err
+	}
+	id, ok := document["_id"]
+	if !ok || id == nil || id == primitive.NilObjectID || id == "" {
+		id = primitive.NewObjectID()
+		document["_id"] = id
+	}
+	switch id := id.(type) {
+	case primitive.ObjectID:
+		return document, id.Hex(), nil
+	case string:
+		return document, id, nil
+	default:
+		return document, fmt.Sprint(id), nil
+	}
+}
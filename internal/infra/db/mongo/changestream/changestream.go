@@ -0,0 +1,286 @@
+// Package changestream watches the changes of MongoDB collections and hands them to the subscribers of a Watcher,
+// e.g. to invalidate caches and publish the changes made outside the service APIs. The position of a watcher in
+// the stream is persisted once its subscribers handled a change, so every change is handled at least once across
+// failures and restarts
+package changestream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/codec"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Operation is the kind of a change
+type Operation string
+
+const (
+	OperationInsert  Operation = "insert"
+	OperationUpdate  Operation = "update"
+	OperationReplace Operation = "replace"
+	OperationDelete  Operation = "delete"
+	// operationInvalidate ends the stream, e.g. a watched collection was dropped or renamed
+	operationInvalidate Operation = "invalidate"
+)
+
+var (
+	changesHandled = metrics.NewCounter("change_stream_changes_total",
+		"Total number of document changes handled by the change stream watchers, by watcher, collection and operation.", "watcher", "collection", "operation")
+	streamRestarts = metrics.NewCounter("change_stream_restarts_total",
+		"Total number of change streams reopened after a failure, by watcher.", "watcher")
+)
+
+// errInvalidated is returned when the stream was invalidated, it is reopened from the current time
+var errInvalidated = errors.New("change stream invalidated")
+
+// Config holds the settings of the change stream watchers, loaded with infra_config.Load
+type Config struct {
+	Enabled bool `yaml:"enabled" env:"CHANGE_STREAM_ENABLED" default:"true"`
+	// How long a failed stream waits before it is reopened from the last handled change
+	RetryInterval time.Duration `yaml:"retry_interval" env:"CHANGE_STREAM_RETRY_INTERVAL" default:"5s" validate:"positive"`
+}
+
+// Change is the change of a document of a watched collection
+type Change struct {
+	// ID identifies the change in the stream, a change handled again after a failure has the same ID
+	ID         string
+	Operation  Operation
+	Collection string
+	DocumentID string
+	// TenantID is the tenant_id of the document, empty for the deletes and the documents without one
+	TenantID string
+	// Document is the document after the change, nil for the deletes and the documents deleted since
+	Document bson.Raw
+	Time     time.Time
+}
+
+// Decode decodes the document of the change into v, a not found error for the changes without a document
+func (c *Change) Decode(v any) error {
+	if len(c.Document) == 0 {
+		return infra_error.NotFound(infra_error.NotFoundResource, c.Collection, c.DocumentID)
+	}
+	if err := bson.UnmarshalWithRegistry(codec.GetRegistry(), c.Document, v); err != nil {
+		return infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+	return nil
+}
+
+// Subscriber handles a change. An error stops the watcher until the retry interval passed, the change is then
+// handled again by every subscriber
+type Subscriber func(ctx context.Context, change *Change) error
+
+// Stream is the change stream read by a Watcher, *mongo.ChangeStream implements it
+type Stream interface {
+	Next(ctx context.Context) bool
+	Decode(v any) error
+	ResumeToken() bson.Raw
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// OpenFunc opens the stream of the watched collections after resumeAfter, from the current time when nil
+type OpenFunc func(ctx context.Context, resumeAfter bson.Raw) (Stream, error)
+
+// Watcher calls its subscribers with the changes of a stream, in the order of the stream
+type Watcher struct {
+	name        string
+	open        OpenFunc
+	tokens      TokenStore
+	config      Config
+	logger      logger.Logger
+	subscribers []Subscriber
+}
+
+// NewWatcher creates a watcher of the streams opened by open, its position is persisted in tokens under name
+func NewWatcher(name string, open OpenFunc, tokens TokenStore, config *Config, logger logger.Logger) *Watcher {
+	return &Watcher{
+		name:   name,
+		open:   open,
+		tokens: tokens,
+		config: *config,
+		logger: logger,
+	}
+}
+
+// NewMongoWatcher creates a watcher of the collections of dbName, its position is persisted in the same database
+func NewMongoWatcher(dbName model_mongo.DBName, name string, collections []model_mongo.Collection, config *Config, logger logger.Logger) (*Watcher, error) {
+	if memory.Enabled() {
+		return nil, fmt.Errorf("change streams: %w", memory.ErrUnsupported)
+	}
+	manager, err := mongo.NewMongoDBManager(dbName, logger)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := NewMongoTokenStore(dbName, logger)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(collections))
+	for _, collection := range collections {
+		names = append(names, string(collection))
+	}
+	open := func(ctx context.Context, resumeAfter bson.Raw) (Stream, error) {
+		stream, err := manager.Watch(ctx, names, resumeAfter)
+		if err != nil {
+			return nil, err
+		}
+		return stream, nil
+	}
+	return NewWatcher(name, open, tokens, config, logger), nil
+}
+
+// Subscribe calls subscriber with the changes, subscribers are added before Run
+func (w *Watcher) Subscribe(subscriber Subscriber) {
+	w.subscribers = append(w.subscribers, subscriber)
+}
+
+// Run handles the changes until quit is closed. A failed stream is reopened from the last handled change after the
+// retry interval, the watcher stops on deployments without change streams
+func (w *Watcher) Run(quit <-chan struct{}) {
+	// The changes are handled in the background, not on behalf of a request
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	for {
+		err := w.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, mongo.ErrChangeStreamsUnsupported) {
+			w.logger.Warn("change streams are not supported by the deployment, the watcher is stopped", "watcher", w.name)
+			return
+		}
+		streamRestarts.Inc(w.name)
+		w.logger.Error("change stream failed", "watcher", w.name, "retry_in", w.config.RetryInterval, "error", err)
+		select {
+		case <-time.After(w.config.RetryInterval):
+		case <-quit:
+			return
+		}
+	}
+}
+
+// watch handles the changes of a stream opened after the persisted position until ctx ends or a change fails
+func (w *Watcher) watch(ctx context.Context) error {
+	token, err := w.tokens.Load(ctx, w.name)
+	if err != nil {
+		return err
+	}
+	stream, err := w.open(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+	w.logger.Info("watching changes", "watcher", w.name, "resumed", len(token) > 0)
+
+	for stream.Next(ctx) {
+		var event changeEvent
+		if err := stream.Decode(&event); err != nil {
+			return infra_error.Internal(infra_error.InternalDatabaseError, err)
+		}
+		token := stream.ResumeToken()
+		if event.OperationType == operationInvalidate {
+			// The position cannot be resumed after an invalidate, the next stream starts from the current time
+			if err := w.tokens.Save(ctx, w.name, nil); err != nil {
+				return err
+			}
+			return errInvalidated
+		}
+		if change := event.change(token); change != nil {
+			if err := w.handle(ctx, change); err != nil {
+				return err
+			}
+		}
+		if err := w.tokens.Save(ctx, w.name, token); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
+
+// handle calls the subscribers with change, the first error stops the others
+func (w *Watcher) handle(ctx context.Context, change *Change) error {
+	for _, subscriber := range w.subscribers {
+		if err := subscriber(ctx, change); err != nil {
+			return fmt.Errorf("handling %s of %s %s: %w", change.Operation, change.Collection, change.DocumentID, err)
+		}
+	}
+	changesHandled.Inc(w.name, change.Collection, string(change.Operation))
+	return nil
+}
+
+// changeEvent is the part of a change stream event read by the watchers
+type changeEvent struct {
+	OperationType Operation           `bson:"operationType"`
+	ClusterTime   primitive.Timestamp `bson:"clusterTime"`
+	Namespace     namespace           `bson:"ns"`
+	DocumentKey   documentKey         `bson:"documentKey"`
+	FullDocument  bson.Raw            `bson:"fullDocument"`
+}
+
+type namespace struct {
+	Collection string `bson:"coll"`
+}
+
+type documentKey struct {
+	ID any `bson:"_id"`
+}
+
+// change returns the Change of the event read at token, nil for the events not about a document, e.g. a drop
+func (e *changeEvent) change(token bson.Raw) *Change {
+	switch e.OperationType {
+	case OperationInsert, OperationUpdate, OperationReplace, OperationDelete:
+	default:
+		return nil
+	}
+	change := &Change{
+		ID:         tokenID(token),
+		Operation:  e.OperationType,
+		Collection: e.Namespace.Collection,
+		DocumentID: documentID(e.DocumentKey.ID),
+		Time:       time.Unix(int64(e.ClusterTime.T), 0).UTC(),
+	}
+	if len(e.FullDocument) > 0 {
+		change.Document = e.FullDocument
+		if tenantID, ok := e.FullDocument.Lookup("tenant_id").StringValueOK(); ok {
+			change.TenantID = tenantID
+		}
+	}
+	return change
+}
+
+// tokenID returns the position of a resume token in the stream
+func tokenID(token bson.Raw) string {
+	if data, ok := token.Lookup("_data").StringValueOK(); ok {
+		return data
+	}
+	return fmt.Sprintf("%x", []byte(token))
+}
+
+// documentID returns the _id of a document as the handlers use it, the hex of an ObjectID
+func documentID(id any) string {
+	switch id := id.(type) {
+	case primitive.ObjectID:
+		return id.Hex()
+	case string:
+		return id
+	default:
+		return fmt.Sprint(id)
+	}
+}
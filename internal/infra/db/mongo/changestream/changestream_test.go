@@ -0,0 +1,152 @@
+package changestream
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeStream replays events, each read at the resume token of its position
+type fakeStream struct {
+	events []bson.M
+	next   int
+	err    error
+}
+
+func (s *fakeStream) Next(ctx context.Context) bool {
+	if s.next >= len(s.events) {
+		return false
+	}
+	s.next++
+	return true
+}
+
+func (s *fakeStream) Decode(v any) error {
+	raw, err := bson.Marshal(s.events[s.next-1])
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(raw, v)
+}
+
+func (s *fakeStream) ResumeToken() bson.Raw {
+	raw, _ := bson.Marshal(bson.M{"_data": s.events[s.next-1]["token"]})
+	return raw
+}
+
+func (s *fakeStream) Err() error                      { return s.err }
+func (s *fakeStream) Close(ctx context.Context) error { return nil }
+
+type memoryTokens map[string]bson.Raw
+
+func (m memoryTokens) Load(ctx context.Context, name string) (bson.Raw, error) { return m[name], nil }
+
+func (m memoryTokens) Save(ctx context.Context, name string, token bson.Raw) error {
+	m[name] = token
+	return nil
+}
+
+func userEvent(token, operation string, id primitive.ObjectID) bson.M {
+	event := bson.M{
+		"token":         token,
+		"operationType": operation,
+		"clusterTime":   primitive.Timestamp{T: 1767225600},
+		"ns":            bson.M{"db": "auth_db", "coll": "users"},
+		"documentKey":   bson.M{"_id": id},
+	}
+	if operation != string(OperationDelete) {
+		event["fullDocument"] = bson.M{"_id": id, "tenant_id": "tenant-1", "username": "jane"}
+	}
+	return event
+}
+
+// openFrom returns an OpenFunc replaying events after the position it is resumed at
+func openFrom(events []bson.M, opened *[]string) OpenFunc {
+	return func(ctx context.Context, resumeAfter bson.Raw) (Stream, error) {
+		position, _ := resumeAfter.Lookup("_data").StringValueOK()
+		*opened = append(*opened, position)
+		start := 0
+		for i, event := range events {
+			if event["token"] == position {
+				start = i + 1
+			}
+		}
+		return &fakeStream{events: events[start:]}, nil
+	}
+}
+
+func TestWatcher_ResumesAfterHandledChanges(t *testing.T) {
+	id := primitive.NewObjectID()
+	events := []bson.M{
+		userEvent("t1", "insert", id),
+		userEvent("t2", "update", id),
+		{"token": "t3", "operationType": "drop", "ns": bson.M{"db": "auth_db", "coll": "users"}},
+		userEvent("t4", "delete", id),
+	}
+	var opened []string
+	tokens := memoryTokens{}
+	watcher := NewWatcher("auth", openFrom(events, &opened), tokens, &Config{RetryInterval: time.Millisecond},
+		logger.NewBaseLogger(shared.ModuleAuth))
+
+	var handled []*Change
+	failed := false
+	watcher.Subscribe(func(ctx context.Context, change *Change) error {
+		// The first delivery of the update fails, it is handled again once the stream is reopened
+		if change.Operation == OperationUpdate && !failed {
+			failed = true
+			return errors.New("redis unavailable")
+		}
+		handled = append(handled, change)
+		return nil
+	})
+
+	require.Error(t, watcher.watch(context.Background()))
+	require.NoError(t, watcher.watch(context.Background()))
+
+	assert.Equal(t, []string{"", "t1"}, opened)
+	require.Len(t, handled, 3)
+	assert.Equal(t, OperationInsert, handled[0].Operation)
+	assert.Equal(t, "users", handled[0].Collection)
+	assert.Equal(t, id.Hex(), handled[0].DocumentID)
+	assert.Equal(t, "tenant-1", handled[0].TenantID)
+	assert.Equal(t, "t1", handled[0].ID)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), handled[0].Time)
+	assert.Equal(t, OperationUpdate, handled[1].Operation)
+	assert.Equal(t, OperationDelete, handled[2].Operation)
+	assert.Empty(t, handled[2].TenantID)
+	assert.Nil(t, handled[2].Document)
+
+	var user struct {
+		Username string `bson:"username"`
+	}
+	require.NoError(t, handled[1].Decode(&user))
+	assert.Equal(t, "jane", user.Username)
+	assert.Error(t, handled[2].Decode(&user))
+
+	last, _ := tokens["auth"].Lookup("_data").StringValueOK()
+	assert.Equal(t, "t4", last)
+}
+
+func TestWatcher_Invalidate(t *testing.T) {
+	events := []bson.M{
+		userEvent("t1", "insert", primitive.NewObjectID()),
+		{"token": "t2", "operationType": "invalidate"},
+	}
+	var opened []string
+	tokens := memoryTokens{}
+	watcher := NewWatcher("auth", openFrom(events, &opened), tokens, &Config{RetryInterval: time.Millisecond},
+		logger.NewBaseLogger(shared.ModuleAuth))
+
+	err := watcher.watch(context.Background())
+	assert.ErrorIs(t, err, errInvalidated)
+	// The next stream starts from the current time
+	assert.Nil(t, tokens["auth"])
+}
@@ -0,0 +1,61 @@
+package changestream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/logging/logger"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// TokenStore persists the position of the watchers in their stream
+type TokenStore interface {
+	// Load returns the resume token of the watcher, nil when it has none
+	Load(ctx context.Context, name string) (bson.Raw, error)
+	// Save stores the resume token of the watcher, nil clears it
+	Save(ctx context.Context, name string, token bson.Raw) error
+}
+
+// resumeToken is the position of a watcher, stored in the resume_tokens collection
+type resumeToken struct {
+	Name      string    `bson:"name"`
+	Token     bson.Raw  `bson:"token,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// MongoTokenStore stores the resume tokens of the watchers of a database in it
+type MongoTokenStore struct {
+	collection *collection.BaseCollectionHandler[resumeToken]
+}
+
+func NewMongoTokenStore(dbName model_mongo.DBName, logger logger.Logger) (*MongoTokenStore, error) {
+	handler, err := collection.NewBaseCollectionHandler[resumeToken](dbName, model_mongo.ResumeTokensCollection, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &MongoTokenStore{collection: handler}, nil
+}
+
+func (s *MongoTokenStore) Load(ctx context.Context, name string) (bson.Raw, error) {
+	stored, err := s.collection.FindOne(ctx, map[string]any{"name": name})
+	if errors.Is(err, driver_mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stored.Token, nil
+}
+
+func (s *MongoTokenStore) Save(ctx context.Context, name string, token bson.Raw) error {
+	update := map[string]any{"$set": map[string]any{"token": token, "updated_at": time.Now().UTC()}}
+	if len(token) == 0 {
+		update = map[string]any{"$set": map[string]any{"updated_at": time.Now().UTC()}, "$unset": map[string]any{"token": ""}}
+	}
+	_, err := s.collection.FindOneAndUpdate(ctx, map[string]any{"name": name}, update, true)
+	return err
+}
@@ -0,0 +1,158 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	tStructPb = reflect.TypeOf((*structpb.Struct)(nil))
+	tValuePb  = reflect.TypeOf((*structpb.Value)(nil))
+)
+
+// StructCodec handles encoding/decoding of structpb.Struct to/from a plain BSON document,
+// so free-form values are stored as they would be written by hand instead of as protobuf internals
+type StructCodec struct{}
+
+// EncodeValue converts structpb.Struct to a BSON document
+func (sc *StructCodec) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	s, ok := val.Interface().(*structpb.Struct)
+	if !ok {
+		return fmt.Errorf("expected *structpb.Struct, got %T", val.Interface())
+	}
+
+	data, err := bson.Marshal(s.AsMap())
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %w", err)
+	}
+	return bsonrw.Copier{}.CopyDocumentFromBytes(vw, data)
+}
+
+// DecodeValue converts a BSON document to structpb.Struct
+func (sc *StructCodec) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return fmt.Errorf("cannot set value")
+	}
+
+	switch vr.Type() {
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	case bsontype.EmbeddedDocument:
+		data, err := bsonrw.Copier{}.CopyDocumentToBytes(vr)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded document: %w", err)
+		}
+		var document bson.D
+		if err := bson.Unmarshal(data, &document); err != nil {
+			return fmt.Errorf("failed to unmarshal embedded document: %w", err)
+		}
+		s, err := structpb.NewStruct(plainDocument(document))
+		if err != nil {
+			return fmt.Errorf("failed to convert embedded document: %w", err)
+		}
+		val.Set(reflect.ValueOf(s))
+		return nil
+	default:
+		return fmt.Errorf("expected embedded document, got %v", vr.Type())
+	}
+}
+
+// ValueCodec handles encoding/decoding of structpb.Value to/from the plain BSON value it holds
+type ValueCodec struct{}
+
+// EncodeValue converts structpb.Value to a BSON value
+func (vc *ValueCodec) EncodeValue(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.IsNil() {
+		return vw.WriteNull()
+	}
+
+	v, ok := val.Interface().(*structpb.Value)
+	if !ok {
+		return fmt.Errorf("expected *structpb.Value, got %T", val.Interface())
+	}
+
+	t, data, err := bson.MarshalValue(v.AsInterface())
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return bsonrw.Copier{}.CopyValueFromBytes(vw, t, data)
+}
+
+// DecodeValue converts a BSON value to structpb.Value, null decodes to a nil value
+func (vc *ValueCodec) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() {
+		return fmt.Errorf("cannot set value")
+	}
+
+	if vr.Type() == bsontype.Null {
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+
+	t, data, err := bsonrw.Copier{}.CopyValueToBytes(vr)
+	if err != nil {
+		return fmt.Errorf("failed to read value: %w", err)
+	}
+	var decoded any
+	if err := (bson.RawValue{Type: t, Value: data}).Unmarshal(&decoded); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	v, err := structpb.NewValue(plainValue(decoded))
+	if err != nil {
+		return fmt.Errorf("failed to convert value: %w", err)
+	}
+	val.Set(reflect.ValueOf(v))
+	return nil
+}
+
+// plainDocument converts a decoded BSON document to the Go types accepted by structpb
+func plainDocument(document bson.D) map[string]any {
+	result := make(map[string]any, len(document))
+	for _, element := range document {
+		result[element.Key] = plainValue(element.Value)
+	}
+	return result
+}
+
+func plainValue(value any) any {
+	switch v := value.(type) {
+	case bson.D:
+		return plainDocument(v)
+	case bson.M:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = plainValue(item)
+		}
+		return result
+	case bson.A:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = plainValue(item)
+		}
+		return result
+	case primitive.DateTime:
+		return v.Time().UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	case primitive.ObjectID:
+		return v.Hex()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,86 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type structDocument struct {
+	Name   string           `bson:"name"`
+	Config *structpb.Struct `bson:"config"`
+}
+
+func TestStructCodec_RoundTrip(t *testing.T) {
+	config, err := structpb.NewStruct(map[string]any{
+		"enabled": true,
+		"limit":   float64(10),
+		"tags":    []any{"a", "b"},
+		"nested":  map[string]any{"url": "https://idp.example.com"},
+	})
+	require.NoError(t, err)
+
+	data, err := bson.MarshalWithRegistry(GetRegistry(), &structDocument{Name: "saml", Config: config})
+	require.NoError(t, err)
+
+	// The config is stored as a plain document
+	var raw bson.M
+	require.NoError(t, bson.Unmarshal(data, &raw))
+	assert.Equal(t, true, raw["config"].(bson.M)["enabled"])
+
+	decoded := &structDocument{}
+	require.NoError(t, bson.UnmarshalWithRegistry(GetRegistry(), data, decoded))
+	assert.Equal(t, "saml", decoded.Name)
+	assert.Equal(t, config.AsMap(), decoded.Config.AsMap())
+}
+
+func TestStructCodec_Null(t *testing.T) {
+	data, err := bson.MarshalWithRegistry(GetRegistry(), &structDocument{Name: "empty"})
+	require.NoError(t, err)
+
+	decoded := &structDocument{}
+	require.NoError(t, bson.UnmarshalWithRegistry(GetRegistry(), data, decoded))
+	assert.Nil(t, decoded.Config)
+}
+
+type valueDocument struct {
+	Value *structpb.Value `bson:"value"`
+}
+
+func TestValueCodec_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value any
+	}{
+		{name: "string", value: "15m"},
+		{name: "number", value: float64(8)},
+		{name: "bool", value: true},
+		{name: "list", value: []any{"a", float64(1)}},
+		{name: "object", value: map[string]any{"url": "https://idp.example.com"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := structpb.NewValue(tc.value)
+			require.NoError(t, err)
+
+			data, err := bson.MarshalWithRegistry(GetRegistry(), &valueDocument{Value: value})
+			require.NoError(t, err)
+
+			decoded := &valueDocument{}
+			require.NoError(t, bson.UnmarshalWithRegistry(GetRegistry(), data, decoded))
+			assert.Equal(t, tc.value, decoded.Value.AsInterface())
+		})
+	}
+}
+
+func TestValueCodec_Null(t *testing.T) {
+	data, err := bson.MarshalWithRegistry(GetRegistry(), &valueDocument{})
+	require.NoError(t, err)
+
+	decoded := &valueDocument{}
+	require.NoError(t, bson.UnmarshalWithRegistry(GetRegistry(), data, decoded))
+	assert.Nil(t, decoded.Value)
+}
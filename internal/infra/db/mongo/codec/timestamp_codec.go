@@ -129,7 +129,7 @@ func (tc *TimestampCodec) DecodeValue(dc bsoncodec.DecodeContext, vr bsonrw.Valu
 	return fmt.Errorf("expected DateTime or embedded document, got %v", bsonType)
 }
 
-// GetRegistry creates a new BSON codec registry with timestamppb.Timestamp support
+// GetRegistry creates a new BSON codec registry with timestamppb.Timestamp, structpb.Struct and structpb.Value support
 func GetRegistry() *bsoncodec.Registry {
 	// Start with MongoDB's default registry
 	rb := bsoncodec.NewRegistryBuilder()
@@ -142,5 +142,13 @@ func GetRegistry() *bsoncodec.Registry {
 	rb.RegisterTypeEncoder(tTimestampPb, &TimestampCodec{})
 	rb.RegisterTypeDecoder(tTimestampPb, &TimestampCodec{})
 
+	// Register the custom codec for *structpb.Struct
+	rb.RegisterTypeEncoder(tStructPb, &StructCodec{})
+	rb.RegisterTypeDecoder(tStructPb, &StructCodec{})
+
+	// Register the custom codec for *structpb.Value
+	rb.RegisterTypeEncoder(tValuePb, &ValueCodec{})
+	rb.RegisterTypeDecoder(tValuePb, &ValueCodec{})
+
 	return rb.Build()
 }
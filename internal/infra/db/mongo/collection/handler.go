@@ -1,21 +1,66 @@
 package collection
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
 	db "erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/db/memory"
 	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/codec"
+	"erp.localhost/internal/infra/db/mongo/migration"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	model_mongo "erp.localhost/internal/infra/model/db/mongo"
 	"go.mongodb.org/mongo-driver/bson"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
 )
 
 //go:generate mockgen -destination=mock/mock_collection_handler.go -package=mock erp.localhost/internal/infra/db/mongo/collection CollectionHandler
 type CollectionHandler[T any] interface {
-	Create(item *T) (string, error)
-	FindOne(filter map[string]any) (*T, error)
-	FindAll(filter map[string]any) ([]*T, error)
-	Update(filter map[string]any, item *T) error
-	Delete(filter map[string]any) error
+	Create(ctx context.Context, item *T) (string, error)
+	FindOne(ctx context.Context, filter map[string]any) (*T, error)
+	FindAll(ctx context.Context, filter map[string]any, opts ...mongo.ListOptions) ([]*T, error)
+	Count(ctx context.Context, filter map[string]any) (int64, error)
+	Exists(ctx context.Context, filter map[string]any) (bool, error)
+	CreateMany(ctx context.Context, items []*T, opts mongo.BulkOptions) ([]string, error)
+	BulkWrite(ctx context.Context, ops []mongo.BulkOperation, opts mongo.BulkOptions) (*mongo.BulkResult, error)
+	Update(ctx context.Context, filter map[string]any, item *T) error
+	UpdateVersioned(ctx context.Context, filter map[string]any, item *T, expectedVersion int64) error
+	Delete(ctx context.Context, filter map[string]any) error
+}
+
+// versionField is the field of the documents updated with UpdateVersioned
+const versionField = "version"
+
+// duplicateKeyCode is the server error code of a write rejected by a unique index
+const duplicateKeyCode = 11000
+
+// findOneAndUpdater is the database handler of FindOneAndUpdate, mongo.MongoDBManager or memory.Documents
+type findOneAndUpdater interface {
+	FindOneAndUpdate(ctx context.Context, collectionName string, filter map[string]any, update map[string]any, result any, opts ...map[string]any) error
+}
+
+// lister is the database handler of the lists with options, mongo.MongoDBManager or memory.Documents
+type lister interface {
+	List(ctx context.Context, collectionName string, filter map[string]any, result any, opts mongo.ListOptions) error
+}
+
+// counter is the database handler of Count and Exists, mongo.MongoDBManager or memory.Documents
+type counter interface {
+	Count(ctx context.Context, collectionName string, filter map[string]any, limit int64) (int64, error)
+}
+
+// bulkWriter is the database handler of CreateMany and BulkWrite, mongo.MongoDBManager or memory.Documents
+type bulkWriter interface {
+	BulkWrite(ctx context.Context, collectionName string, ops []mongo.BulkOperation, opts mongo.BulkOptions) (*mongo.BulkResult, error)
+}
+
+// aggregator is the database handler of Aggregate, mongo.MongoDBManager or memory.Documents
+type aggregator interface {
+	Aggregate(ctx context.Context, collectionName string, pipeline interface{}) (*driver_mongo.Cursor, error)
 }
 
 // Generic Collection
@@ -23,12 +68,17 @@ type BaseCollectionHandler[T any] struct {
 	dbHandler  db.DBHandler
 	collection string
 	logger     logger.Logger
+	// readPreference of the reads made without one, see SetReadPreference
+	readPreference mongo.ReadPreference
 }
 
 func NewBaseCollectionHandler[T any](dbName model_mongo.DBName, collection model_mongo.Collection, logger logger.Logger) (*BaseCollectionHandler[T], error) {
 	if logger == nil {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "logger")
 	}
+	if memory.Enabled() {
+		return newMemoryCollectionHandler[T](memory.SharedDocuments(string(dbName)), collection, logger), nil
+	}
 	dbHandler, err := mongo.NewMongoDBManager(dbName, logger)
 	if err != nil {
 		return nil, err
@@ -45,6 +95,23 @@ func NewBaseCollectionHandler[T any](dbName model_mongo.DBName, collection model
 	return collectionHandler, nil
 }
 
+// NewInMemoryCollectionHandler creates a handler on a database of its own kept in memory, for the tests without
+// a Mongo server. Transactions are not supported, see memory.Documents for the filters and pipelines it runs
+func NewInMemoryCollectionHandler[T any](collection model_mongo.Collection, logger logger.Logger) (*BaseCollectionHandler[T], error) {
+	if logger == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "logger")
+	}
+	return newMemoryCollectionHandler[T](memory.NewDocuments(), collection, logger), nil
+}
+
+func newMemoryCollectionHandler[T any](documents *memory.Documents, collection model_mongo.Collection, logger logger.Logger) *BaseCollectionHandler[T] {
+	return &BaseCollectionHandler[T]{
+		dbHandler:  documents,
+		collection: string(collection),
+		logger:     logger,
+	}
+}
+
 func (r *BaseCollectionHandler[T]) createCollectionInDBIfNotExists() error {
 	if dbHandler, ok := r.dbHandler.(*mongo.MongoDBManager); ok {
 		return dbHandler.CreateCollectionInDBIfNotExists(r.collection)
@@ -52,56 +119,314 @@ func (r *BaseCollectionHandler[T]) createCollectionInDBIfNotExists() error {
 	return nil
 }
 
-func (r *BaseCollectionHandler[T]) Create(item *T) (string, error) {
-	r.logger.Debug("Creating item", "collection", r.collection)
-	id, err := r.dbHandler.Create(r.collection, item)
+// SetReadPreference sends the reads of the handler made without a read preference of their own to the members
+// selected by pref, e.g. mongo.ReadSecondaryPreferred for the list and report queries, see mongo.WithReadPreference
+func (r *BaseCollectionHandler[T]) SetReadPreference(pref mongo.ReadPreference) {
+	r.readPreference = pref
+}
+
+// WithTransaction runs fn in a transaction on the client of the handler, see mongo.MongoDBManager.WithTransaction
+func (r *BaseCollectionHandler[T]) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	dbHandler, ok := r.dbHandler.(*mongo.MongoDBManager)
+	if !ok {
+		return mongo.ErrTransactionsUnsupported
+	}
+	return dbHandler.WithTransaction(ctx, fn)
+}
+
+// FindOneAndUpdate atomically applies update, an update document such as {"$inc": {"value": 1}}, to the item
+// matching filter and returns the item after the update. With upsert the item is inserted when none matches, see
+// mongo.MongoDBManager.FindOneAndUpdate
+func (r *BaseCollectionHandler[T]) FindOneAndUpdate(ctx context.Context, filter map[string]any, update map[string]any, upsert bool) (*T, error) {
+	r.logger.WithContext(ctx).Debug("Finding and updating item", "collection", r.collection, "filter", filter, "update", update)
+	dbHandler, ok := r.dbHandler.(findOneAndUpdater)
+	if !ok {
+		err := infra_error.Internal(infra_error.InternalDatabaseError, errors.New("find and update needs a mongo database handler"))
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
+		return nil, err
+	}
+	result := new(T)
+	done := r.instrument(ctx, "find_one_and_update")
+	err := dbHandler.FindOneAndUpdate(ctx, r.collection, filter, update, result, map[string]any{mongo.UpdateOptionUpsert: upsert})
+	done(err)
 	if err != nil {
-		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
-		r.logger.Error(err.Error(), "collection", r.collection, "item", item)
+		err = writeError(err)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter, "update", update)
+		return nil, err
+	}
+	return result, nil
+}
+
+// Aggregate runs pipeline on the items of tenantID and decodes its output into results, a pointer to a slice. The
+// pipeline starts with a match on the tenant, stages reading other collections such as $lookup or $unionWith scope
+// their own pipelines
+func (r *BaseCollectionHandler[T]) Aggregate(ctx context.Context, tenantID string, pipeline []bson.M, results any) error {
+	if tenantID == "" {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	r.logger.WithContext(ctx).Debug("Aggregating items", "collection", r.collection, "tenant_id", tenantID, "stages", len(pipeline))
+	dbHandler, ok := r.dbHandler.(aggregator)
+	if !ok {
+		err := infra_error.Internal(infra_error.InternalDatabaseError, errors.New("aggregation needs a mongo database handler"))
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "tenant_id", tenantID)
+		return err
+	}
+	done := r.instrument(ctx, "aggregate")
+	cursor, err := dbHandler.Aggregate(mongo.WithDefaultReadPreference(ctx, r.readPreference), r.collection, tenantPipeline(tenantID, pipeline))
+	if err == nil {
+		err = cursor.All(ctx, results)
+		if err != nil {
+			err = infra_error.Internal(infra_error.InternalDatabaseError, err)
+		}
+	}
+	done(err)
+	if err != nil {
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "tenant_id", tenantID)
+		return err
+	}
+	return nil
+}
+
+// tenantPipeline returns pipeline preceded by a match on the items of tenantID
+func tenantPipeline(tenantID string, pipeline []bson.M) []bson.M {
+	scoped := make([]bson.M, 0, len(pipeline)+1)
+	scoped = append(scoped, bson.M{"$match": bson.M{"tenant_id": tenantID}})
+	return append(scoped, pipeline...)
+}
+
+func (r *BaseCollectionHandler[T]) Create(ctx context.Context, item *T) (string, error) {
+	r.logger.WithContext(ctx).Debug("Creating item", "collection", r.collection)
+	var document any = item
+	if latest := migration.Latest(model_mongo.Collection(r.collection)); latest > 0 {
+		versioned, err := toDocument(item)
+		if err != nil {
+			err = infra_error.Internal(infra_error.InternalDatabaseError, err)
+			r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "item", item)
+			return "", err
+		}
+		versioned[migration.VersionField] = int32(latest)
+		document = versioned
+	}
+	done := r.instrument(ctx, "create")
+	id, err := r.dbHandler.Create(ctx, r.collection, document)
+	done(err)
+	if err != nil {
+		err = writeError(err)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "item", item)
 		return "", err
 	}
 	return id, nil
 }
 
-func (r *BaseCollectionHandler[T]) FindOne(filter map[string]any) (*T, error) {
-	r.logger.Debug("Finding item", "collection", r.collection, "filter", filter)
+// CreateMany inserts items in one bulk write and returns their ids by index, empty for the items not inserted. When
+// some items fail the error is a *mongo.BulkWriteError, see BulkWrite
+func (r *BaseCollectionHandler[T]) CreateMany(ctx context.Context, items []*T, opts mongo.BulkOptions) ([]string, error) {
+	ops := make([]mongo.BulkOperation, 0, len(items))
+	for _, item := range items {
+		ops = append(ops, mongo.InsertOperation(item))
+	}
+	result, err := r.BulkWrite(ctx, ops, opts)
+	if result == nil {
+		return nil, err
+	}
+	return result.InsertedIDs, err
+}
+
+// BulkWrite applies ops in one round trip. When some operations fail the result reports the operations applied and
+// the error is a *mongo.BulkWriteError, the failures rejected by a unique index are conflicts
+func (r *BaseCollectionHandler[T]) BulkWrite(ctx context.Context, ops []mongo.BulkOperation, opts mongo.BulkOptions) (*mongo.BulkResult, error) {
+	r.logger.WithContext(ctx).Debug("Bulk writing items", "collection", r.collection, "operations", len(ops), "unordered", opts.Unordered)
+	dbHandler, ok := r.dbHandler.(bulkWriter)
+	if !ok {
+		err := infra_error.Internal(infra_error.InternalDatabaseError, errors.New("bulk writes need a mongo database handler"))
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection)
+		return nil, err
+	}
+	if latest := migration.Latest(model_mongo.Collection(r.collection)); latest > 0 {
+		versioned := make([]mongo.BulkOperation, len(ops))
+		for i, op := range ops {
+			if op.Kind == mongo.BulkInsert {
+				document, err := toDocument(op.Document)
+				if err != nil {
+					err = infra_error.Internal(infra_error.InternalDatabaseError, err)
+					r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "index", i)
+					return nil, err
+				}
+				document[migration.VersionField] = int32(latest)
+				op.Document = document
+			}
+			versioned[i] = op
+		}
+		ops = versioned
+	}
+	done := r.instrument(ctx, "bulk_write")
+	result, err := dbHandler.BulkWrite(ctx, r.collection, ops, opts)
+	done(err)
+	var bulkErr *mongo.BulkWriteError
+	if errors.As(err, &bulkErr) {
+		for i, failure := range bulkErr.Failures {
+			bulkErr.Failures[i].Err = writeError(failure.Err)
+		}
+		r.logger.WithContext(ctx).Error(bulkErr.Error(), "collection", r.collection, "failed", len(bulkErr.Failures), "attempted", bulkErr.Attempted)
+		return result, bulkErr
+	}
+	if err != nil {
+		err = writeError(err)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *BaseCollectionHandler[T]) FindOne(ctx context.Context, filter map[string]any) (*T, error) {
+	r.logger.WithContext(ctx).Debug("Finding item", "collection", r.collection, "filter", filter)
 	result := new(T)
-	err := r.dbHandler.FindOne(r.collection, filter, result)
+	done := r.instrument(ctx, "find_one")
+	var err error
+	if r.migrated() {
+		doc := bson.M{}
+		err = r.dbHandler.FindOne(mongo.WithDefaultReadPreference(ctx, r.readPreference), r.collection, filter, &doc)
+		if err == nil {
+			err = r.upgrade(doc, result, nil)
+		}
+	} else {
+		err = r.dbHandler.FindOne(mongo.WithDefaultReadPreference(ctx, r.readPreference), r.collection, filter, result)
+	}
+	done(err)
 	if err != nil {
 		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
-		r.logger.Error(err.Error(), "collection", r.collection, "filter", filter)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
 		return nil, err
 	}
 	// if result == nil {
 	// 	err = infra_error.NotFound(infra_error.NotFoundResource, r.collection, filter)
-	// 	r.logger.Error(err.Error(), "collection", r.collection, "filter", filter)
+	// 	r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
 	// 	return nil, err
 	// }
 
 	return result, nil
 }
 
-func (r *BaseCollectionHandler[T]) FindAll(filter map[string]any) ([]*T, error) {
+// FindAll returns the items matching filter, ordered, projected and limited by opts when given. The items of a
+// projection only have the selected fields set
+func (r *BaseCollectionHandler[T]) FindAll(ctx context.Context, filter map[string]any, opts ...mongo.ListOptions) ([]*T, error) {
 	if filter == nil {
-		r.logger.Debug("nil filter found", "collection", r.collection)
+		r.logger.WithContext(ctx).Debug("nil filter found", "collection", r.collection)
 		filter = make(map[string]any)
 	}
-	r.logger.Debug("Finding items", "collection", r.collection, "filter", filter)
+	r.logger.WithContext(ctx).Debug("Finding items", "collection", r.collection, "filter", filter, "options", opts)
+	options := listOptions(opts)
+	// A migration may read any field, the documents of migrated collections are projected once upgraded
+	var projection bson.M
+	if r.migrated() {
+		projection = options.Projection()
+		options.Fields = nil
+	}
+	find, err := r.finder(options)
+	if err != nil {
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
+		return nil, err
+	}
 	result := make([]*T, 0)
-	err := r.dbHandler.FindAll(r.collection, filter, &result)
+	done := r.instrument(ctx, "find_all")
+	if r.migrated() {
+		docs := []bson.M{}
+		err = find(mongo.WithDefaultReadPreference(ctx, r.readPreference), r.collection, filter, &docs)
+		for _, doc := range docs {
+			if err != nil {
+				break
+			}
+			item := new(T)
+			err = r.upgrade(doc, item, projection)
+			result = append(result, item)
+		}
+	} else {
+		err = find(mongo.WithDefaultReadPreference(ctx, r.readPreference), r.collection, filter, &result)
+	}
+	done(err)
 	if err != nil {
 		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
-		r.logger.Error(err.Error(), "collection", r.collection, "filter", filter)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
 		return nil, err
 	}
 	return result, nil
 }
 
-func (r *BaseCollectionHandler[T]) Update(filter map[string]any, item *T) error {
-	r.logger.Debug("Updating item", "collection", r.collection, "filter", filter, "item", item)
+// finder returns the read of the lists made with opts, FindAll of the database handler when they are zero
+func (r *BaseCollectionHandler[T]) finder(opts mongo.ListOptions) (func(ctx context.Context, collectionName string, filter map[string]any, result any) error, error) {
+	if opts.IsZero() {
+		return r.dbHandler.FindAll, nil
+	}
+	dbHandler, ok := r.dbHandler.(lister)
+	if !ok {
+		return nil, infra_error.Internal(infra_error.InternalDatabaseError, errors.New("list options need a mongo database handler"))
+	}
+	return func(ctx context.Context, collectionName string, filter map[string]any, result any) error {
+		return dbHandler.List(ctx, collectionName, filter, result, opts)
+	}, nil
+}
+
+// listOptions returns the last of opts, the zero options when there are none
+func listOptions(opts []mongo.ListOptions) mongo.ListOptions {
+	if len(opts) == 0 {
+		return mongo.ListOptions{}
+	}
+	return opts[len(opts)-1]
+}
+
+// Count returns the number of items matching filter, counted by the server
+func (r *BaseCollectionHandler[T]) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	r.logger.WithContext(ctx).Debug("Counting items", "collection", r.collection, "filter", filter)
+	done := r.instrument(ctx, "count")
+	count, err := r.count(ctx, filter, 0)
+	done(err)
+	if err != nil {
+		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
+		return 0, err
+	}
+	return count, nil
+}
+
+// Exists reports whether an item matches filter, the server stops at the first match
+func (r *BaseCollectionHandler[T]) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	r.logger.WithContext(ctx).Debug("Checking items exist", "collection", r.collection, "filter", filter)
+	done := r.instrument(ctx, "exists")
+	count, err := r.count(ctx, filter, 1)
+	done(err)
+	if err != nil {
+		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// count counts at most limit of the documents matching filter, all of them when it is 0. Database handlers without
+// Count fall back on decoding the documents
+func (r *BaseCollectionHandler[T]) count(ctx context.Context, filter map[string]any, limit int64) (int64, error) {
+	if filter == nil {
+		filter = make(map[string]any)
+	}
+	ctx = mongo.WithDefaultReadPreference(ctx, r.readPreference)
+	if dbHandler, ok := r.dbHandler.(counter); ok {
+		return dbHandler.Count(ctx, r.collection, filter, limit)
+	}
+	docs := []bson.M{}
+	if err := r.dbHandler.FindAll(ctx, r.collection, filter, &docs); err != nil {
+		return 0, err
+	}
+	if limit > 0 && int64(len(docs)) > limit {
+		return limit, nil
+	}
+	return int64(len(docs)), nil
+}
+
+func (r *BaseCollectionHandler[T]) Update(ctx context.Context, filter map[string]any, item *T) error {
+	r.logger.WithContext(ctx).Debug("Updating item", "collection", r.collection, "filter", filter, "item", item)
 	if filter == nil {
 		err := infra_error.Validation(infra_error.ValidationRequiredFields, "filter")
-		r.logger.Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
 		return err
 	}
 
@@ -109,48 +434,195 @@ func (r *BaseCollectionHandler[T]) Update(filter map[string]any, item *T) error
 	updateData, err := r.prepareUpdateData(item)
 	if err != nil {
 		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
-		r.logger.Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
 		return err
 	}
 
-	if err := r.dbHandler.Update(r.collection, filter, updateData); err != nil {
+	done := r.instrument(ctx, "update")
+	err = r.dbHandler.Update(ctx, r.collection, filter, updateData)
+	done(err)
+	if err != nil {
+		err = writeError(err)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
+		return err
+	}
+	return nil
+}
+
+// UpdateVersioned updates the item matching filter only if its stored version is expectedVersion and stores it at
+// the next version. It fails with a ConflictResourceModified error when the document was updated since it was read
+// at expectedVersion, or no longer matches filter. Documents stored before they were versioned are at version 0
+func (r *BaseCollectionHandler[T]) UpdateVersioned(ctx context.Context, filter map[string]any, item *T, expectedVersion int64) error {
+	r.logger.WithContext(ctx).Debug("Updating versioned item", "collection", r.collection, "filter", filter, "expected_version", expectedVersion)
+	if filter == nil {
+		err := infra_error.Validation(infra_error.ValidationRequiredFields, "filter")
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
+		return err
+	}
+
+	updateData, err := r.prepareUpdateData(item)
+	if err != nil {
 		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
-		r.logger.Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
+		return err
+	}
+	updateData[versionField] = expectedVersion + 1
+
+	versionedFilter := make(map[string]any, len(filter)+1)
+	for key, value := range filter {
+		versionedFilter[key] = value
+	}
+	versionedFilter[versionField] = expectedVersion
+	if expectedVersion == 0 {
+		versionedFilter[versionField] = map[string]any{"$in": bson.A{int64(0), nil}}
+	}
+
+	// The cleared omitempty fields are left out of the update data, they are removed so they are cleared when stored
+	opts := map[string]any{mongo.UpdateOptionRequireMatch: true}
+	if unset := omittedFields(item, updateData); len(unset) > 0 {
+		opts[mongo.UpdateOptionUnset] = unset
+	}
+
+	done := r.instrument(ctx, "update")
+	err = r.dbHandler.Update(ctx, r.collection, versionedFilter, updateData, opts)
+	done(err)
+	if errors.Is(err, mongo.ErrNoMatch) {
+		r.logger.WithContext(ctx).Warn("versioned update rejected", "collection", r.collection, "filter", filter, "expected_version", expectedVersion)
+		return infra_error.Conflict(infra_error.ConflictResourceModified)
+	}
+	if err != nil {
+		err = writeError(err)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter, "item", item)
 		return err
 	}
 	return nil
 }
 
+// writeError wraps a failed write, writes rejected by a unique index are conflicts and keep the driver error
+// so callers can tell which index rejected them, see DuplicateKeyIndex
+func writeError(err error) error {
+	if driver_mongo.IsDuplicateKeyError(err) {
+		return infra_error.Conflict(infra_error.ConflictDuplicateResource).WithError(err)
+	}
+	return infra_error.Internal(infra_error.InternalDatabaseError, err)
+}
+
+// DuplicateKeyIndex returns the name of the unique index that rejected a write, false for other errors
+func DuplicateKeyIndex(err error) (string, bool) {
+	var writeException driver_mongo.WriteException
+	if !errors.As(err, &writeException) {
+		return "", false
+	}
+	for _, writeError := range writeException.WriteErrors {
+		if writeError.Code != duplicateKeyCode {
+			continue
+		}
+		// E11000 duplicate key error collection: db.users index: idx_tenant_email_unique dup key: { ... }
+		_, rest, found := strings.Cut(writeError.Message, "index: ")
+		if !found {
+			return "", true
+		}
+		index, _, _ := strings.Cut(rest, " ")
+		return index, true
+	}
+	return "", false
+}
+
 // prepareUpdateData converts item to BSON map and excludes the _id field
 func (r *BaseCollectionHandler[T]) prepareUpdateData(item *T) (bson.M, error) {
-	// Marshal to BSON bytes
-	bytes, err := bson.Marshal(item)
+	updateMap, err := toDocument(item)
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal to bson.M
-	var updateMap bson.M
-	if err := bson.Unmarshal(bytes, &updateMap); err != nil {
-		return nil, err
-	}
-
 	// Remove _id field (immutable in MongoDB)
 	delete(updateMap, "_id")
 
+	// The item is in the shape of the latest schema version, as the handler upgrades the documents it reads
+	if latest := migration.Latest(model_mongo.Collection(r.collection)); latest > 0 {
+		updateMap[migration.VersionField] = int32(latest)
+	}
+
 	return updateMap, nil
 }
 
-func (r *BaseCollectionHandler[T]) Delete(filter map[string]any) error {
+// migrated reports whether the collection has migrations, its documents are then upgraded when they are read
+func (r *BaseCollectionHandler[T]) migrated() bool {
+	return migration.Latest(model_mongo.Collection(r.collection)) > 0
+}
+
+// upgrade runs the migrations doc is not at yet and decodes it into result, keeping only the fields of projection
+// when it is not nil, see migration.Upgrade
+func (r *BaseCollectionHandler[T]) upgrade(doc bson.M, result *T, projection bson.M) error {
+	if _, err := migration.Upgrade(model_mongo.Collection(r.collection), doc); err != nil {
+		return err
+	}
+	if projection != nil {
+		projected, err := memory.Pipeline([]bson.M{doc}, []bson.M{{"$project": projection}})
+		if err != nil {
+			return err
+		}
+		doc = projected[0]
+	}
+	raw, err := bson.MarshalWithRegistry(codec.GetRegistry(), doc)
+	if err != nil {
+		return err
+	}
+	return bson.UnmarshalWithRegistry(codec.GetRegistry(), raw, result)
+}
+
+// toDocument converts item to a BSON map, with the codecs of the client so protobuf values are stored as on create
+func toDocument(item any) (bson.M, error) {
+	raw, err := bson.MarshalWithRegistry(codec.GetRegistry(), item)
+	if err != nil {
+		return nil, err
+	}
+	doc := bson.M{}
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// omittedFields returns the omitempty BSON fields of item missing from its update data, except _id
+func omittedFields(item any, updateData bson.M) []string {
+	itemType := reflect.TypeOf(item)
+	for itemType != nil && itemType.Kind() == reflect.Pointer {
+		itemType = itemType.Elem()
+	}
+	if itemType == nil || itemType.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := []string{}
+	for i := 0; i < itemType.NumField(); i++ {
+		field := itemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		if name == "" || name == "-" || name == "_id" || !strings.Contains(options, "omitempty") {
+			continue
+		}
+		if _, ok := updateData[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+func (r *BaseCollectionHandler[T]) Delete(ctx context.Context, filter map[string]any) error {
 	if filter == nil {
 		err := infra_error.Validation(infra_error.ValidationRequiredFields, "filter")
-		r.logger.Error(err.Error(), "collection", r.collection, "filter", filter)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
 		return err
 	}
-	r.logger.Debug("Deleting items", "collection", r.collection, "filter", filter)
-	if err := r.dbHandler.Delete(r.collection, filter); err != nil {
+	r.logger.WithContext(ctx).Debug("Deleting items", "collection", r.collection, "filter", filter)
+	done := r.instrument(ctx, "delete")
+	err := r.dbHandler.Delete(ctx, r.collection, filter)
+	done(err)
+	if err != nil {
 		err = infra_error.Internal(infra_error.InternalDatabaseError, err)
-		r.logger.Error(err.Error(), "collection", r.collection, "filter", filter)
+		r.logger.WithContext(ctx).Error(err.Error(), "collection", r.collection, "filter", filter)
 		return err
 	}
 	return nil
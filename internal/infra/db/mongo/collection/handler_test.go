@@ -1,14 +1,21 @@
 package collection
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"erp.localhost/internal/infra/db/memory"
 	mock_db "erp.localhost/internal/infra/db/mock"
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/migration"
+	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	"erp.localhost/internal/infra/model/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/mock/gomock"
 )
 
@@ -47,7 +54,7 @@ func TestCollection_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockHandler := mock_db.NewMockDBHandler(ctrl)
-			mockHandler.EXPECT().Create(tc.collection, tc.data).Return(tc.returnID, tc.returnError)
+			mockHandler.EXPECT().Create(gomock.Any(), tc.collection, tc.data).Return(tc.returnID, tc.returnError)
 
 			collectionHanlder := BaseCollectionHandler[TestModel]{
 				dbHandler:  mockHandler,
@@ -55,7 +62,7 @@ func TestCollection_Create(t *testing.T) {
 				logger:     logger.NewBaseLogger(shared.ModuleDB),
 			}
 
-			id, err := collectionHanlder.Create(tc.data)
+			id, err := collectionHanlder.Create(context.Background(), tc.data)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -104,8 +111,8 @@ func TestCollection_FindOne(t *testing.T) {
 			mockHandler := mock_db.NewMockDBHandler(ctrl)
 			model := &TestModel{}
 			mockHandler.EXPECT().
-				FindOne(tc.collection, tc.filter, model).
-				DoAndReturn(func(collection string, filter map[string]any, result any) error {
+				FindOne(gomock.Any(), tc.collection, tc.filter, model).
+				DoAndReturn(func(_ context.Context, collection string, filter map[string]any, result any) error {
 					// Cast result to the correct type and set its value
 					if m, ok := result.(*TestModel); ok {
 						*m = tc.returnModel
@@ -118,7 +125,7 @@ func TestCollection_FindOne(t *testing.T) {
 				collection: tc.collection,
 				logger:     logger.NewBaseLogger(shared.ModuleDB),
 			}
-			result, err := collectionHanlder.FindOne(tc.filter)
+			result, err := collectionHanlder.FindOne(context.Background(), tc.filter)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -177,8 +184,8 @@ func TestCollection_FindAll(t *testing.T) {
 
 			models := make([]*TestModel, 0)
 			mockHandler.EXPECT().
-				FindAll(tc.collection, tc.filter, &models).
-				DoAndReturn(func(collection string, filter map[string]any, result any) error {
+				FindAll(gomock.Any(), tc.collection, tc.filter, &models).
+				DoAndReturn(func(_ context.Context, collection string, filter map[string]any, result any) error {
 					if m, ok := result.(*[]*TestModel); ok {
 						*m = make([]*TestModel, len(tc.returnModels))
 						for i, item := range tc.returnModels {
@@ -193,7 +200,7 @@ func TestCollection_FindAll(t *testing.T) {
 				collection: tc.collection,
 				logger:     logger.NewBaseLogger(shared.ModuleDB),
 			}
-			results, err := collectionHanlder.FindAll(tc.filter)
+			results, err := collectionHanlder.FindAll(context.Background(), tc.filter)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -244,14 +251,14 @@ func TestCollection_Update(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockHandler := mock_db.NewMockDBHandler(ctrl)
-			mockHandler.EXPECT().Update(tc.collection, tc.filter, tc.item).Return(tc.returnError).Times(tc.expectedCallTimes)
+			mockHandler.EXPECT().Update(gomock.Any(), tc.collection, tc.filter, tc.item).Return(tc.returnError).Times(tc.expectedCallTimes)
 
 			collectionHanlder := BaseCollectionHandler[TestModel]{
 				dbHandler:  mockHandler,
 				collection: tc.collection,
 				logger:     logger.NewBaseLogger(shared.ModuleDB),
 			}
-			err := collectionHanlder.Update(tc.filter, tc.item)
+			err := collectionHanlder.Update(context.Background(), tc.filter, tc.item)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -261,6 +268,80 @@ func TestCollection_Update(t *testing.T) {
 	}
 }
 
+func TestCollection_UpdateVersioned(t *testing.T) {
+	testCases := []struct {
+		name            string
+		expectedVersion int64
+		expectedFilter  map[string]any
+		returnError     error
+		expectedError   error
+	}{
+		{
+			name:            "successful update",
+			expectedVersion: 3,
+			expectedFilter:  map[string]any{"_id": "1", "version": int64(3)},
+		},
+		{
+			name:            "documents without version are at version 0",
+			expectedVersion: 0,
+			expectedFilter:  map[string]any{"_id": "1", "version": map[string]any{"$in": bson.A{int64(0), nil}}},
+		},
+		{
+			name:            "stale version",
+			expectedVersion: 3,
+			expectedFilter:  map[string]any{"_id": "1", "version": int64(3)},
+			returnError:     mongo.ErrNoMatch,
+			expectedError:   infra_error.Conflict(infra_error.ConflictResourceModified),
+		},
+		{
+			name:            "database error",
+			expectedVersion: 3,
+			expectedFilter:  map[string]any{"_id": "1", "version": int64(3)},
+			returnError:     errors.New("update failed"),
+			expectedError:   infra_error.Internal(infra_error.InternalDatabaseError, nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockHandler := mock_db.NewMockDBHandler(ctrl)
+			mockHandler.EXPECT().
+				Update(gomock.Any(), "test_collection", tc.expectedFilter, bson.M{"name": "updated", "version": tc.expectedVersion + 1}, map[string]any{mongo.UpdateOptionRequireMatch: true}).
+				Return(tc.returnError)
+
+			collectionHanlder := BaseCollectionHandler[TestModel]{
+				dbHandler:  mockHandler,
+				collection: "test_collection",
+				logger:     logger.NewBaseLogger(shared.ModuleDB),
+			}
+			filter := map[string]any{"_id": "1"}
+			err := collectionHanlder.UpdateVersioned(context.Background(), filter, &TestModel{ID: "1", Name: "updated"}, tc.expectedVersion)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			// The filter of the caller is not changed
+			assert.Equal(t, map[string]any{"_id": "1"}, filter)
+		})
+	}
+}
+
+func TestOmittedFields(t *testing.T) {
+	type item struct {
+		ID       string   `bson:"_id,omitempty"`
+		Name     string   `bson:"name"`
+		Email    string   `bson:"email,omitempty"`
+		Tags     []string `bson:"tags,omitempty"`
+		Internal string   `bson:"-"`
+	}
+	assert.Equal(t, []string{"tags"}, omittedFields(&item{Email: "a@example.com"}, bson.M{"name": "", "email": "a@example.com"}))
+	assert.Empty(t, omittedFields(&TestModel{}, bson.M{"name": ""}))
+	assert.Nil(t, omittedFields("not a struct", bson.M{}))
+}
+
 func TestCollection_Delete(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -297,14 +378,14 @@ func TestCollection_Delete(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			mockHandler := mock_db.NewMockDBHandler(ctrl)
-			mockHandler.EXPECT().Delete(tc.collection, tc.filter).Return(tc.returnError).Times(tc.expectedCallTimes)
+			mockHandler.EXPECT().Delete(gomock.Any(), tc.collection, tc.filter).Return(tc.returnError).Times(tc.expectedCallTimes)
 
 			collectionHanlder := BaseCollectionHandler[TestModel]{
 				dbHandler:  mockHandler,
 				collection: tc.collection,
 				logger:     logger.NewBaseLogger(shared.ModuleDB),
 			}
-			err := collectionHanlder.Delete(tc.filter)
+			err := collectionHanlder.Delete(context.Background(), tc.filter)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -313,3 +394,350 @@ func TestCollection_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestCollection_WithTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := &BaseCollectionHandler[TestModel]{
+		dbHandler:  mock_db.NewMockDBHandler(ctrl),
+		collection: "test_collection",
+		logger:     logger.NewBaseLogger(shared.ModuleDB),
+	}
+
+	called := false
+	err := handler.WithTransaction(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, mongo.ErrTransactionsUnsupported)
+	assert.False(t, called)
+}
+
+func TestCollection_Context(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := mock_db.NewMockDBHandler(ctrl)
+	handler := &BaseCollectionHandler[TestModel]{
+		dbHandler:  mockHandler,
+		collection: "test_collection",
+		logger:     logger.NewBaseLogger(shared.ModuleDB),
+	}
+
+	// The context of the caller reaches the database handler
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockHandler.EXPECT().Delete(ctx, "test_collection", map[string]any{"_id": "1"}).Return(ctx.Err())
+
+	err := handler.Delete(ctx, map[string]any{"_id": "1"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCollection_DuplicateKey(t *testing.T) {
+	duplicate := driver_mongo.WriteException{
+		WriteErrors: []driver_mongo.WriteError{{
+			Code:    11000,
+			Message: `E11000 duplicate key error collection: auth_db.users index: idx_tenant_email_unique dup key: { tenant_id: "t1", email: "a@b.c" }`,
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockHandler := mock_db.NewMockDBHandler(ctrl)
+	mockHandler.EXPECT().Create(gomock.Any(), "users", gomock.Any()).Return("", duplicate)
+	mockHandler.EXPECT().Update(gomock.Any(), "users", gomock.Any(), gomock.Any()).Return(errors.New("database connection failed"))
+
+	collectionHandler := BaseCollectionHandler[TestModel]{
+		dbHandler:  mockHandler,
+		collection: "users",
+		logger:     logger.NewBaseLogger(shared.ModuleDB),
+	}
+
+	_, err := collectionHandler.Create(context.Background(), &TestModel{Name: "test"})
+	var appErr *infra_error.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, infra_error.CategoryConflict, appErr.Category)
+	index, ok := DuplicateKeyIndex(err)
+	assert.True(t, ok)
+	assert.Equal(t, "idx_tenant_email_unique", index)
+
+	err = collectionHandler.Update(context.Background(), map[string]any{"_id": "id"}, &TestModel{Name: "test"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, infra_error.CategoryInternal, appErr.Category)
+	_, ok = DuplicateKeyIndex(err)
+	assert.False(t, ok)
+}
+
+func TestCollection_FindOneAndUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := &BaseCollectionHandler[TestModel]{
+		dbHandler:  mock_db.NewMockDBHandler(ctrl),
+		collection: "test_collection",
+		logger:     logger.NewBaseLogger(shared.ModuleDB),
+	}
+
+	// Atomic updates are only run by the mongo and in-memory handlers
+	result, err := handler.FindOneAndUpdate(context.Background(), map[string]any{"name": "counter"}, map[string]any{"$inc": map[string]any{"value": 1}}, true)
+	assert.Nil(t, result)
+	var appErr *infra_error.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, infra_error.CategoryInternal, appErr.Category)
+}
+
+func TestCollection_Aggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := &BaseCollectionHandler[TestModel]{
+		dbHandler:  mock_db.NewMockDBHandler(ctrl),
+		collection: "test_collection",
+		logger:     logger.NewBaseLogger(shared.ModuleDB),
+	}
+
+	var results []bson.M
+	err := handler.Aggregate(context.Background(), "", nil, &results)
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryValidation))
+
+	// Aggregations are only run by the mongo and in-memory handlers
+	err = handler.Aggregate(context.Background(), "tenant-1", nil, &results)
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryInternal))
+
+	pipeline := tenantPipeline("tenant-1", []bson.M{{"$count": "count"}})
+	assert.Equal(t, []bson.M{
+		{"$match": bson.M{"tenant_id": "tenant-1"}},
+		{"$count": "count"},
+	}, pipeline)
+}
+
+type versionedModel struct {
+	ID       string `bson:"_id,omitempty"`
+	TenantID string `bson:"tenant_id"`
+	Name     string `bson:"name"`
+	Version  int64  `bson:"version"`
+}
+
+func TestInMemoryCollectionHandler(t *testing.T) {
+	ctx := context.Background()
+	handler, err := NewInMemoryCollectionHandler[versionedModel]("test_collection", logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+
+	id, err := handler.Create(ctx, &versionedModel{TenantID: "tenant-1", Name: "first"})
+	require.NoError(t, err)
+	filter := map[string]any{"_id": id}
+	stored, err := handler.FindOne(ctx, filter)
+	require.NoError(t, err)
+
+	stored.Name = "second"
+	require.NoError(t, handler.UpdateVersioned(ctx, filter, stored, stored.Version))
+	// The copy read before the update is stale
+	err = handler.UpdateVersioned(ctx, filter, stored, stored.Version)
+	assert.ErrorIs(t, err, infra_error.Conflict(infra_error.ConflictResourceModified))
+
+	counter, err := handler.FindOneAndUpdate(ctx, map[string]any{"tenant_id": "tenant-1", "name": "counter"},
+		map[string]any{"$inc": map[string]any{"version": int64(1)}}, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counter.Version)
+
+	var results []bson.M
+	require.NoError(t, handler.Aggregate(ctx, "tenant-1", []bson.M{{"$count": "count"}}, &results))
+	assert.Equal(t, []bson.M{{"count": int32(2)}}, results)
+
+	_, err = handler.FindOne(ctx, map[string]any{"name": "first"})
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryInternal))
+	assert.ErrorIs(t, handler.WithTransaction(ctx, func(ctx context.Context) error { return nil }), mongo.ErrTransactionsUnsupported)
+}
+
+func TestInMemoryCollectionHandler_ListOptions(t *testing.T) {
+	ctx := context.Background()
+	handler, err := NewInMemoryCollectionHandler[versionedModel]("listed_collection", logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+	for _, name := range []string{"b", "c", "a", "b"} {
+		_, err := handler.Create(ctx, &versionedModel{TenantID: "tenant-1", Name: name, Version: 3})
+		require.NoError(t, err)
+	}
+	filter := map[string]any{"tenant_id": "tenant-1"}
+
+	all, err := handler.FindAll(ctx, filter, mongo.ListOptions{Sort: []mongo.SortField{{Field: "name", Descending: true}}})
+	require.NoError(t, err)
+	names := []string{}
+	for _, item := range all {
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"c", "b", "b", "a"}, names)
+	// Equal names are ordered by id
+	assert.Less(t, all[1].ID, all[2].ID)
+
+	limited, err := handler.FindAll(ctx, filter, mongo.ListOptions{Sort: []mongo.SortField{{Field: "name"}}, Fields: []string{"name"}, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, limited, 2)
+	assert.Equal(t, "a", limited[0].Name)
+	assert.NotEmpty(t, limited[0].ID, "the ids are always returned")
+	assert.Empty(t, limited[0].TenantID)
+	assert.Zero(t, limited[0].Version)
+}
+
+func TestInMemoryCollectionHandler_CountExists(t *testing.T) {
+	ctx := context.Background()
+	handler, err := NewInMemoryCollectionHandler[versionedModel]("counted_collection", logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+	for _, name := range []string{"a", "b", "b"} {
+		_, err := handler.Create(ctx, &versionedModel{TenantID: "tenant-1", Name: name})
+		require.NoError(t, err)
+	}
+
+	count, err := handler.Count(ctx, map[string]any{"tenant_id": "tenant-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	count, err = handler.Count(ctx, map[string]any{"name": "b"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	exists, err := handler.Exists(ctx, map[string]any{"name": "b"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = handler.Exists(ctx, map[string]any{"name": "c"})
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestInMemoryCollectionHandler_CreateMany(t *testing.T) {
+	ctx := context.Background()
+	handler, err := NewInMemoryCollectionHandler[versionedModel]("bulk_collection", logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+	_, err = handler.Create(ctx, &versionedModel{ID: "taken", TenantID: "tenant-1", Name: "existing"})
+	require.NoError(t, err)
+	batch := func() []*versionedModel {
+		return []*versionedModel{
+			{TenantID: "tenant-1", Name: "first"},
+			{ID: "taken", TenantID: "tenant-1", Name: "duplicate"},
+			{TenantID: "tenant-1", Name: "last"},
+		}
+	}
+
+	// Ordered writes stop at the first failure
+	ids, err := handler.CreateMany(ctx, batch(), mongo.BulkOptions{})
+	var bulkErr *mongo.BulkWriteError
+	require.ErrorAs(t, err, &bulkErr)
+	require.Len(t, bulkErr.Failures, 1)
+	assert.Equal(t, 1, bulkErr.Failures[0].Index)
+	assert.True(t, infra_error.IsCategory(bulkErr.Failures[0].Err, infra_error.CategoryConflict))
+	assert.Equal(t, 2, bulkErr.Attempted)
+	require.Len(t, ids, 3)
+	assert.NotEmpty(t, ids[0])
+	assert.Empty(t, ids[1])
+	assert.Empty(t, ids[2])
+	count, err := handler.Count(ctx, map[string]any{"tenant_id": "tenant-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// Unordered writes attempt every item
+	ids, err = handler.CreateMany(ctx, batch(), mongo.BulkOptions{Unordered: true})
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, 3, bulkErr.Attempted)
+	assert.NotEmpty(t, ids[0])
+	assert.Empty(t, ids[1])
+	assert.NotEmpty(t, ids[2])
+	last, err := handler.FindOne(ctx, map[string]any{"_id": ids[2]})
+	require.NoError(t, err)
+	assert.Equal(t, "last", last.Name)
+}
+
+func TestInMemoryCollectionHandler_BulkWrite(t *testing.T) {
+	ctx := context.Background()
+	handler, err := NewInMemoryCollectionHandler[versionedModel]("bulk_write_collection", logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+	_, err = handler.Create(ctx, &versionedModel{TenantID: "tenant-1", Name: "updated"})
+	require.NoError(t, err)
+	_, err = handler.Create(ctx, &versionedModel{TenantID: "tenant-1", Name: "deleted"})
+	require.NoError(t, err)
+
+	result, err := handler.BulkWrite(ctx, []mongo.BulkOperation{
+		mongo.InsertOperation(&versionedModel{TenantID: "tenant-1", Name: "inserted"}),
+		mongo.UpdateOperation(map[string]any{"name": "updated"}, map[string]any{"$inc": map[string]any{"version": 1}}, false),
+		mongo.UpdateOperation(map[string]any{"name": "upserted"}, map[string]any{"$set": map[string]any{"tenant_id": "tenant-1"}}, true),
+		mongo.DeleteOperation(map[string]any{"name": "deleted"}),
+	}, mongo.BulkOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.InsertedIDs[0])
+	assert.Equal(t, int64(1), result.MatchedCount)
+	assert.Equal(t, int64(1), result.UpsertedCount)
+	assert.Equal(t, int64(1), result.DeletedCount)
+
+	all, err := handler.FindAll(ctx, map[string]any{"tenant_id": "tenant-1"}, mongo.ListOptions{Sort: []mongo.SortField{{Field: "name"}}})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "inserted", all[0].Name)
+	assert.Equal(t, "updated", all[1].Name)
+	assert.Equal(t, int64(1), all[1].Version)
+	assert.Equal(t, "upserted", all[2].Name)
+}
+
+func TestCollection_Count_FallsBackOnFindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dbHandler := mock_db.NewMockDBHandler(ctrl)
+	handler := &BaseCollectionHandler[TestModel]{
+		dbHandler:  dbHandler,
+		collection: "test_collection",
+		logger:     logger.NewBaseLogger(shared.ModuleDB),
+	}
+	dbHandler.EXPECT().FindAll(gomock.Any(), "test_collection", map[string]any{"name": "a"}, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, collection string, filter map[string]any, result any) error {
+			*result.(*[]bson.M) = []bson.M{{"name": "a"}, {"name": "a"}}
+			return nil
+		}).Times(2)
+
+	count, err := handler.Count(context.Background(), map[string]any{"name": "a"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	exists, err := handler.Exists(context.Background(), map[string]any{"name": "a"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestCollection_FindAll_ListOptionsUnsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := &BaseCollectionHandler[TestModel]{
+		dbHandler:  mock_db.NewMockDBHandler(ctrl),
+		collection: "test_collection",
+		logger:     logger.NewBaseLogger(shared.ModuleDB),
+	}
+	_, err := handler.FindAll(context.Background(), map[string]any{}, mongo.ListOptions{Limit: 1})
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryInternal))
+}
+
+func TestInMemoryCollectionHandler_Migrations(t *testing.T) {
+	ctx := context.Background()
+	handler, err := NewInMemoryCollectionHandler[versionedModel]("migrated_collection", logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+	documents := handler.dbHandler.(*memory.Documents)
+	// A document stored before the name was required
+	oldID, err := documents.Create(ctx, "migrated_collection", bson.M{"tenant_id": "tenant-1", "title": "first"})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { migration.Reset("migrated_collection") })
+	migration.Register(migration.Migration{Collection: "migrated_collection", Version: 1, Up: func(doc bson.M) error {
+		doc["name"] = doc["title"]
+		delete(doc, "title")
+		return nil
+	}})
+
+	found, err := handler.FindOne(ctx, map[string]any{"_id": oldID})
+	require.NoError(t, err)
+	assert.Equal(t, "first", found.Name)
+
+	newID, err := handler.Create(ctx, &versionedModel{TenantID: "tenant-1", Name: "second"})
+	require.NoError(t, err)
+	all, err := handler.FindAll(ctx, map[string]any{"tenant_id": "tenant-1"})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "first", all[0].Name)
+	// The fields are selected once the documents are upgraded
+	projected, err := handler.FindAll(ctx, map[string]any{"tenant_id": "tenant-1"}, mongo.ListOptions{Fields: []string{"name"}})
+	require.NoError(t, err)
+	require.Len(t, projected, 2)
+	assert.Equal(t, "first", projected[0].Name)
+	assert.Empty(t, projected[0].TenantID)
+
+	// The documents written by the handler are at the latest version
+	require.NoError(t, handler.Update(ctx, map[string]any{"_id": oldID}, found))
+	for _, id := range []string{oldID, newID} {
+		stored := bson.M{}
+		require.NoError(t, documents.FindOne(ctx, "migrated_collection", map[string]any{"_id": id}, &stored))
+		assert.Equal(t, 1, migration.Version(stored))
+	}
+}
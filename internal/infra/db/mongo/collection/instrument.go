@@ -0,0 +1,29 @@
+package collection
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/metrics"
+	"erp.localhost/internal/infra/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+var operationSeconds = metrics.NewHistogram("mongo_operation_duration_seconds",
+	"Latency of Mongo collection operations in seconds, by collection, operation and status.",
+	metrics.DefaultBuckets, "collection", "operation", "status")
+
+// instrument starts a span for a database round trip of the handler as a child of the span in ctx, the
+// returned function ends it and records the latency of the operation
+func (r *BaseCollectionHandler[T]) instrument(ctx context.Context, operation string) func(err error) {
+	start := time.Now()
+	_, span := tracing.Start(ctx, "mongo."+operation,
+		semconv.DBSystemMongoDB,
+		semconv.DBCollectionName(r.collection),
+		semconv.DBOperationName(operation),
+	)
+	return func(err error) {
+		operationSeconds.ObserveSince(start, r.collection, operation, metrics.Status(err))
+		tracing.End(span, err)
+	}
+}
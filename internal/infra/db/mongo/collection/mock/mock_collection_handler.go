@@ -10,8 +10,10 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
+	mongo "erp.localhost/internal/infra/db/mongo"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,75 +41,154 @@ func (m *MockCollectionHandler[T]) EXPECT() *MockCollectionHandlerMockRecorder[T
 	return m.recorder
 }
 
+// BulkWrite mocks base method.
+func (m *MockCollectionHandler[T]) BulkWrite(ctx context.Context, ops []mongo.BulkOperation, opts mongo.BulkOptions) (*mongo.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkWrite", ctx, ops, opts)
+	ret0, _ := ret[0].(*mongo.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkWrite indicates an expected call of BulkWrite.
+func (mr *MockCollectionHandlerMockRecorder[T]) BulkWrite(ctx, ops, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWrite", reflect.TypeOf((*MockCollectionHandler[T])(nil).BulkWrite), ctx, ops, opts)
+}
+
+// Count mocks base method.
+func (m *MockCollectionHandler[T]) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockCollectionHandlerMockRecorder[T]) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockCollectionHandler[T])(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
-func (m *MockCollectionHandler[T]) Create(item *T) (string, error) {
+func (m *MockCollectionHandler[T]) Create(ctx context.Context, item *T) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", item)
+	ret := m.ctrl.Call(m, "Create", ctx, item)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockCollectionHandlerMockRecorder[T]) Create(item any) *gomock.Call {
+func (mr *MockCollectionHandlerMockRecorder[T]) Create(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCollectionHandler[T])(nil).Create), ctx, item)
+}
+
+// CreateMany mocks base method.
+func (m *MockCollectionHandler[T]) CreateMany(ctx context.Context, items []*T, opts mongo.BulkOptions) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, items, opts)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockCollectionHandlerMockRecorder[T]) CreateMany(ctx, items, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCollectionHandler[T])(nil).Create), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockCollectionHandler[T])(nil).CreateMany), ctx, items, opts)
 }
 
 // Delete mocks base method.
-func (m *MockCollectionHandler[T]) Delete(filter map[string]any) error {
+func (m *MockCollectionHandler[T]) Delete(ctx context.Context, filter map[string]any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", filter)
+	ret := m.ctrl.Call(m, "Delete", ctx, filter)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCollectionHandlerMockRecorder[T]) Delete(filter any) *gomock.Call {
+func (mr *MockCollectionHandlerMockRecorder[T]) Delete(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCollectionHandler[T])(nil).Delete), ctx, filter)
+}
+
+// Exists mocks base method.
+func (m *MockCollectionHandler[T]) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, filter)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockCollectionHandlerMockRecorder[T]) Exists(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCollectionHandler[T])(nil).Delete), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockCollectionHandler[T])(nil).Exists), ctx, filter)
 }
 
 // FindAll mocks base method.
-func (m *MockCollectionHandler[T]) FindAll(filter map[string]any) ([]*T, error) {
+func (m *MockCollectionHandler[T]) FindAll(ctx context.Context, filter map[string]any, opts ...mongo.ListOptions) ([]*T, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", filter)
+	varargs := []any{ctx, filter}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindAll", varargs...)
 	ret0, _ := ret[0].([]*T)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockCollectionHandlerMockRecorder[T]) FindAll(filter any) *gomock.Call {
+func (mr *MockCollectionHandlerMockRecorder[T]) FindAll(ctx, filter any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCollectionHandler[T])(nil).FindAll), filter)
+	varargs := append([]any{ctx, filter}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCollectionHandler[T])(nil).FindAll), varargs...)
 }
 
 // FindOne mocks base method.
-func (m *MockCollectionHandler[T]) FindOne(filter map[string]any) (*T, error) {
+func (m *MockCollectionHandler[T]) FindOne(ctx context.Context, filter map[string]any) (*T, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOne", filter)
+	ret := m.ctrl.Call(m, "FindOne", ctx, filter)
 	ret0, _ := ret[0].(*T)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindOne indicates an expected call of FindOne.
-func (mr *MockCollectionHandlerMockRecorder[T]) FindOne(filter any) *gomock.Call {
+func (mr *MockCollectionHandlerMockRecorder[T]) FindOne(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockCollectionHandler[T])(nil).FindOne), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockCollectionHandler[T])(nil).FindOne), ctx, filter)
 }
 
 // Update mocks base method.
-func (m *MockCollectionHandler[T]) Update(filter map[string]any, item *T) error {
+func (m *MockCollectionHandler[T]) Update(ctx context.Context, filter map[string]any, item *T) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", filter, item)
+	ret := m.ctrl.Call(m, "Update", ctx, filter, item)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockCollectionHandlerMockRecorder[T]) Update(filter, item any) *gomock.Call {
+func (mr *MockCollectionHandlerMockRecorder[T]) Update(ctx, filter, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCollectionHandler[T])(nil).Update), ctx, filter, item)
+}
+
+// UpdateVersioned mocks base method.
+func (m *MockCollectionHandler[T]) UpdateVersioned(ctx context.Context, filter map[string]any, item *T, expectedVersion int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVersioned", ctx, filter, item, expectedVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVersioned indicates an expected call of UpdateVersioned.
+func (mr *MockCollectionHandlerMockRecorder[T]) UpdateVersioned(ctx, filter, item, expectedVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCollectionHandler[T])(nil).Update), filter, item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVersioned", reflect.TypeOf((*MockCollectionHandler[T])(nil).UpdateVersioned), ctx, filter, item, expectedVersion)
 }
@@ -0,0 +1,263 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"erp.localhost/internal/infra/db/mongo"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// tenantField is the field scoping the documents to their tenant
+const tenantField = "tenant_id"
+
+type tenantIDKey struct{}
+
+// WithTenantID returns a copy of ctx scoping the calls of the TenantScopedCollectionHandlers created without a
+// tenant to tenantID
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+// TenantIDFromContext returns the tenant set on ctx by WithTenantID, empty when ctx has none
+func TenantIDFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDKey{}).(string)
+	return tenantID
+}
+
+// TenantScopedCollectionHandler constrains every call of a collection handler to one tenant, so the callers cannot
+// forget the tenant of a filter: the filters get the tenant_id of the tenant, the items created or updated get its
+// tenant_id, and a filter or item of another tenant fails with an AuthTenantAccessDenied error before reaching the
+// database
+type TenantScopedCollectionHandler[T any] struct {
+	handler  CollectionHandler[T]
+	tenantID string
+	// field is the index of the tenant_id field of T
+	field []int
+}
+
+// NewTenantScopedCollectionHandler wraps handler, a handler of a model with a string tenant_id field. The calls are
+// scoped to tenantID, or to the tenant of their context set with WithTenantID when tenantID is empty
+func NewTenantScopedCollectionHandler[T any](handler CollectionHandler[T], tenantID string) (*TenantScopedCollectionHandler[T], error) {
+	if handler == nil {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "handler")
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	field, ok := tenantFieldIndex(t)
+	if !ok {
+		return nil, infra_error.Internal(infra_error.InternalInvalidArgument, fmt.Errorf("%s has no %s field", t.Name(), tenantField))
+	}
+	return &TenantScopedCollectionHandler[T]{
+		handler:  handler,
+		tenantID: tenantID,
+		field:    field,
+	}, nil
+}
+
+// tenantFieldIndex returns the index of the string field of struct type t stored as tenant_id
+func tenantFieldIndex(t reflect.Type) ([]int, bool) {
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Type.Kind() != reflect.String {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("bson"), ",")
+		if name == tenantField || (name == "" && strings.ToLower(sf.Name) == tenantField) {
+			return sf.Index, true
+		}
+	}
+	return nil, false
+}
+
+// TenantID returns the tenant the calls made with ctx are scoped to
+func (h *TenantScopedCollectionHandler[T]) TenantID(ctx context.Context) (string, error) {
+	fromContext := TenantIDFromContext(ctx)
+	switch {
+	case h.tenantID == "" && fromContext == "":
+		return "", infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	case h.tenantID == "":
+		return fromContext, nil
+	case fromContext != "" && fromContext != h.tenantID:
+		return "", h.denied(ctx, h.tenantID, fromContext)
+	default:
+		return h.tenantID, nil
+	}
+}
+
+func (h *TenantScopedCollectionHandler[T]) Create(ctx context.Context, item *T) (string, error) {
+	if err := h.scopeItem(ctx, item); err != nil {
+		return "", err
+	}
+	return h.handler.Create(ctx, item)
+}
+
+func (h *TenantScopedCollectionHandler[T]) FindOne(ctx context.Context, filter map[string]any) (*T, error) {
+	scoped, err := h.scopeFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return h.handler.FindOne(ctx, scoped)
+}
+
+func (h *TenantScopedCollectionHandler[T]) FindAll(ctx context.Context, filter map[string]any, opts ...mongo.ListOptions) ([]*T, error) {
+	scoped, err := h.scopeFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return h.handler.FindAll(ctx, scoped, opts...)
+}
+
+func (h *TenantScopedCollectionHandler[T]) Count(ctx context.Context, filter map[string]any) (int64, error) {
+	scoped, err := h.scopeFilter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return h.handler.Count(ctx, scoped)
+}
+
+func (h *TenantScopedCollectionHandler[T]) Exists(ctx context.Context, filter map[string]any) (bool, error) {
+	scoped, err := h.scopeFilter(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return h.handler.Exists(ctx, scoped)
+}
+
+func (h *TenantScopedCollectionHandler[T]) Update(ctx context.Context, filter map[string]any, item *T) error {
+	scoped, err := h.scopeFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if err := h.scopeItem(ctx, item); err != nil {
+		return err
+	}
+	return h.handler.Update(ctx, scoped, item)
+}
+
+func (h *TenantScopedCollectionHandler[T]) UpdateVersioned(ctx context.Context, filter map[string]any, item *T, expectedVersion int64) error {
+	scoped, err := h.scopeFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if err := h.scopeItem(ctx, item); err != nil {
+		return err
+	}
+	return h.handler.UpdateVersioned(ctx, scoped, item, expectedVersion)
+}
+
+func (h *TenantScopedCollectionHandler[T]) CreateMany(ctx context.Context, items []*T, opts mongo.BulkOptions) ([]string, error) {
+	for _, item := range items {
+		if err := h.scopeItem(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+	return h.handler.CreateMany(ctx, items, opts)
+}
+
+// BulkWrite scopes every operation to the tenant, the inserted documents must be items of the collection and the
+// updates must not move a document to another tenant
+func (h *TenantScopedCollectionHandler[T]) BulkWrite(ctx context.Context, ops []mongo.BulkOperation, opts mongo.BulkOptions) (*mongo.BulkResult, error) {
+	scoped := make([]mongo.BulkOperation, 0, len(ops))
+	for _, op := range ops {
+		var err error
+		switch op.Kind {
+		case mongo.BulkInsert:
+			item, ok := op.Document.(*T)
+			if !ok {
+				return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "document").
+					WithError(fmt.Errorf("tenant scoped bulk writes insert %T documents, got %T", item, op.Document))
+			}
+			err = h.scopeItem(ctx, item)
+		default:
+			if op.Filter, err = h.scopeFilter(ctx, op.Filter); err == nil {
+				err = h.scopeUpdate(ctx, op.Update)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		scoped = append(scoped, op)
+	}
+	return h.handler.BulkWrite(ctx, scoped, opts)
+}
+
+func (h *TenantScopedCollectionHandler[T]) Delete(ctx context.Context, filter map[string]any) error {
+	scoped, err := h.scopeFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return h.handler.Delete(ctx, scoped)
+}
+
+// scopeFilter returns a copy of filter matching the items of the tenant only. A filter on another tenant, or on
+// the tenant with an operator, is denied
+func (h *TenantScopedCollectionHandler[T]) scopeFilter(ctx context.Context, filter map[string]any) (map[string]any, error) {
+	tenantID, err := h.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := filter[tenantField]; ok {
+		if requested, _ := value.(string); requested != tenantID {
+			return nil, h.denied(ctx, tenantID, value)
+		}
+	}
+	scoped := make(map[string]any, len(filter)+1)
+	for key, value := range filter {
+		scoped[key] = value
+	}
+	scoped[tenantField] = tenantID
+	return scoped, nil
+}
+
+// scopeUpdate denies an update setting the tenant_id of a document to another tenant
+func (h *TenantScopedCollectionHandler[T]) scopeUpdate(ctx context.Context, update map[string]any) error {
+	tenantID, err := h.TenantID(ctx)
+	if err != nil {
+		return err
+	}
+	for _, fields := range update {
+		set, ok := fields.(map[string]any)
+		if m, isM := fields.(bson.M); isM {
+			set, ok = m, true
+		}
+		if !ok {
+			continue
+		}
+		if value, ok := set[tenantField]; ok && value != tenantID {
+			return h.denied(ctx, tenantID, value)
+		}
+	}
+	return nil
+}
+
+// scopeItem sets the tenant_id of item to the tenant, an item of another tenant is denied
+func (h *TenantScopedCollectionHandler[T]) scopeItem(ctx context.Context, item *T) error {
+	if item == nil {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "item")
+	}
+	tenantID, err := h.TenantID(ctx)
+	if err != nil {
+		return err
+	}
+	field := reflect.ValueOf(item).Elem().FieldByIndex(h.field)
+	switch field.String() {
+	case tenantID:
+	case "":
+		field.SetString(tenantID)
+	default:
+		return h.denied(ctx, tenantID, field.String())
+	}
+	return nil
+}
+
+// denied logs and returns the error of a call of tenantID reaching the items of requested
+func (h *TenantScopedCollectionHandler[T]) denied(ctx context.Context, tenantID string, requested any) error {
+	logger.FromContext(ctx).Warn("cross-tenant access denied", "tenant_id", tenantID, "requested_tenant_id", requested)
+	return infra_error.Auth(infra_error.AuthTenantAccessDenied)
+}
@@ -0,0 +1,115 @@
+package collection
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/infra/db/mongo"
+	mock_collection "erp.localhost/internal/infra/db/mongo/collection/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type tenantModel struct {
+	ID       string `bson:"_id,omitempty"`
+	TenantID string `bson:"tenant_id"`
+	Name     string `bson:"name"`
+}
+
+func TestTenantScopedCollectionHandler_ScopesFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := mock_collection.NewMockCollectionHandler[tenantModel](ctrl)
+	handler, err := NewTenantScopedCollectionHandler[tenantModel](mockHandler, "tenant-1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	mockHandler.EXPECT().FindAll(ctx, map[string]any{"tenant_id": "tenant-1", "name": "a"}).Return(nil, nil)
+	_, err = handler.FindAll(ctx, map[string]any{"name": "a"})
+	require.NoError(t, err)
+
+	mockHandler.EXPECT().Count(ctx, map[string]any{"tenant_id": "tenant-1"}).Return(int64(2), nil)
+	count, err := handler.Count(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	mockHandler.EXPECT().Exists(ctx, map[string]any{"tenant_id": "tenant-1", "name": "a"}).Return(true, nil)
+	exists, err := handler.Exists(ctx, map[string]any{"name": "a"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	mockHandler.EXPECT().Delete(ctx, map[string]any{"tenant_id": "tenant-1", "_id": "id-1"}).Return(nil)
+	require.NoError(t, handler.Delete(ctx, map[string]any{"tenant_id": "tenant-1", "_id": "id-1"}))
+
+	// The filters reaching another tenant fail before the database
+	_, err = handler.FindOne(ctx, map[string]any{"tenant_id": "tenant-2"})
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+	_, err = handler.FindAll(ctx, map[string]any{"tenant_id": map[string]any{"$in": []string{"tenant-1", "tenant-2"}}})
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+	_, err = handler.FindAll(WithTenantID(ctx, "tenant-2"), nil)
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+	_, err = handler.Exists(ctx, map[string]any{"tenant_id": "tenant-2"})
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+}
+
+func TestTenantScopedCollectionHandler_ScopesItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := mock_collection.NewMockCollectionHandler[tenantModel](ctrl)
+	handler, err := NewTenantScopedCollectionHandler[tenantModel](mockHandler, "")
+	require.NoError(t, err)
+	ctx := WithTenantID(context.Background(), "tenant-1")
+
+	item := &tenantModel{Name: "a"}
+	mockHandler.EXPECT().Create(ctx, item).Return("id-1", nil)
+	_, err = handler.Create(ctx, item)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1", item.TenantID)
+
+	err = handler.Update(ctx, map[string]any{"_id": "id-2"}, &tenantModel{TenantID: "tenant-2"})
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+
+	// Without a tenant of its own the handler needs the tenant of the context
+	_, err = handler.Create(context.Background(), &tenantModel{})
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryValidation))
+}
+
+func TestTenantScopedCollectionHandler_ScopesBulkWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHandler := mock_collection.NewMockCollectionHandler[tenantModel](ctrl)
+	handler, err := NewTenantScopedCollectionHandler[tenantModel](mockHandler, "tenant-1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	items := []*tenantModel{{Name: "a"}, {TenantID: "tenant-1", Name: "b"}}
+	mockHandler.EXPECT().CreateMany(ctx, items, mongo.BulkOptions{}).Return([]string{"id-1", "id-2"}, nil)
+	_, err = handler.CreateMany(ctx, items, mongo.BulkOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1", items[0].TenantID)
+
+	mockHandler.EXPECT().BulkWrite(ctx, []mongo.BulkOperation{
+		mongo.InsertOperation(&tenantModel{TenantID: "tenant-1", Name: "c"}),
+		mongo.DeleteOperation(map[string]any{"tenant_id": "tenant-1", "name": "a"}),
+	}, mongo.BulkOptions{}).Return(&mongo.BulkResult{}, nil)
+	_, err = handler.BulkWrite(ctx, []mongo.BulkOperation{
+		mongo.InsertOperation(&tenantModel{Name: "c"}),
+		mongo.DeleteOperation(map[string]any{"name": "a"}),
+	}, mongo.BulkOptions{})
+	require.NoError(t, err)
+
+	// Items and updates of another tenant fail before the database
+	_, err = handler.CreateMany(ctx, []*tenantModel{{TenantID: "tenant-2"}}, mongo.BulkOptions{})
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+	_, err = handler.BulkWrite(ctx, []mongo.BulkOperation{
+		mongo.UpdateOperation(map[string]any{"name": "a"}, map[string]any{"$set": map[string]any{"tenant_id": "tenant-2"}}, false),
+	}, mongo.BulkOptions{})
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+	_, err = handler.BulkWrite(ctx, []mongo.BulkOperation{mongo.InsertOperation(map[string]any{"name": "a"})}, mongo.BulkOptions{})
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryValidation))
+}
+
+func TestNewTenantScopedCollectionHandler_RequiresTenantField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	_, err := NewTenantScopedCollectionHandler[TestModel](mock_collection.NewMockCollectionHandler[TestModel](ctrl), "tenant-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package mongo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// namespaceNotFoundCode is returned when listing the indexes of a collection that doesn't exist
+const namespaceNotFoundCode = 26
+
+// indexSpec is an index as reported by listIndexes, only the fields the definitions set are compared
+type indexSpec struct {
+	Name                    string   `bson:"name"`
+	Key                     bson.D   `bson:"key"`
+	Unique                  bool     `bson:"unique,omitempty"`
+	Sparse                  bool     `bson:"sparse,omitempty"`
+	ExpireAfterSeconds      *int32   `bson:"expireAfterSeconds,omitempty"`
+	PartialFilterExpression bson.Raw `bson:"partialFilterExpression,omitempty"`
+}
+
+// planIndexes returns the indexes to create and the names of the indexes to drop so existing matches defined.
+// Changed indexes are dropped and created again, Mongo can't modify an index in place.
+func planIndexes(existing []indexSpec, defined []mongo.IndexModel) ([]mongo.IndexModel, []string, error) {
+	current := make(map[string]indexSpec, len(existing))
+	for _, spec := range existing {
+		current[spec.Name] = spec
+	}
+
+	var create []mongo.IndexModel
+	var drop []string
+	names := make(map[string]bool, len(defined))
+	for _, model := range defined {
+		spec, err := specOf(model)
+		if err != nil {
+			return nil, nil, err
+		}
+		if names[spec.Name] {
+			return nil, nil, fmt.Errorf("index %s is defined twice", spec.Name)
+		}
+		names[spec.Name] = true
+
+		found, ok := current[spec.Name]
+		if !ok {
+			create = append(create, model)
+			continue
+		}
+		if !sameIndex(found, spec) {
+			drop = append(drop, spec.Name)
+			create = append(create, model)
+		}
+	}
+	for _, spec := range existing {
+		if strings.HasPrefix(spec.Name, model_mongo.ManagedIndexPrefix) && !names[spec.Name] {
+			drop = append(drop, spec.Name)
+		}
+	}
+	return create, drop, nil
+}
+
+// specOf converts an index definition to the form listIndexes reports it in
+func specOf(model mongo.IndexModel) (indexSpec, error) {
+	opts := model.Options
+	if opts == nil {
+		opts = options.Index()
+	}
+	if opts.Name == nil || *opts.Name == "" {
+		return indexSpec{}, errors.New("index definitions must be named")
+	}
+	spec := indexSpec{
+		Name:               *opts.Name,
+		ExpireAfterSeconds: opts.ExpireAfterSeconds,
+	}
+	if opts.Unique != nil {
+		spec.Unique = *opts.Unique
+	}
+	if opts.Sparse != nil {
+		spec.Sparse = *opts.Sparse
+	}
+
+	keys, err := bson.Marshal(model.Keys)
+	if err != nil {
+		return indexSpec{}, fmt.Errorf("index %s: invalid keys: %w", spec.Name, err)
+	}
+	if err := bson.Unmarshal(keys, &spec.Key); err != nil {
+		return indexSpec{}, fmt.Errorf("index %s: invalid keys: %w", spec.Name, err)
+	}
+	if opts.PartialFilterExpression != nil {
+		if spec.PartialFilterExpression, err = bson.Marshal(opts.PartialFilterExpression); err != nil {
+			return indexSpec{}, fmt.Errorf("index %s: invalid partial filter: %w", spec.Name, err)
+		}
+	}
+	return spec, nil
+}
+
+func sameIndex(a, b indexSpec) bool {
+	if a.Unique != b.Unique || a.Sparse != b.Sparse || len(a.Key) != len(b.Key) {
+		return false
+	}
+	if (a.ExpireAfterSeconds == nil) != (b.ExpireAfterSeconds == nil) ||
+		(a.ExpireAfterSeconds != nil && *a.ExpireAfterSeconds != *b.ExpireAfterSeconds) {
+		return false
+	}
+	if !bytes.Equal(a.PartialFilterExpression, b.PartialFilterExpression) {
+		return false
+	}
+	for i := range a.Key {
+		if a.Key[i].Key != b.Key[i].Key || keyDirection(a.Key[i].Value) != keyDirection(b.Key[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyDirection normalizes a key value, the server may report 1 as an int32, an int64 or a double
+func keyDirection(value any) string {
+	switch v := value.(type) {
+	case int32:
+		return fmt.Sprint(float64(v))
+	case int64:
+		return fmt.Sprint(float64(v))
+	case int:
+		return fmt.Sprint(float64(v))
+	case float64:
+		return fmt.Sprint(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestPlanIndexes(t *testing.T) {
+	ttl := int32(3600)
+	emailIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("idx_tenant_email_unique"),
+	}
+	ttlIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(ttl).SetName("idx_expires_at_ttl"),
+	}
+	emailSpec := indexSpec{Name: "idx_tenant_email_unique", Key: bson.D{{Key: "tenant_id", Value: int32(1)}, {Key: "email", Value: float64(1)}}, Unique: true}
+	ttlSpec := indexSpec{Name: "idx_expires_at_ttl", Key: bson.D{{Key: "expires_at", Value: int32(1)}}, ExpireAfterSeconds: &ttl}
+	idSpec := indexSpec{Name: "_id_", Key: bson.D{{Key: "_id", Value: int32(1)}}}
+
+	testCases := []struct {
+		name       string
+		existing   []indexSpec
+		defined    []mongo.IndexModel
+		wantCreate []string
+		wantDrop   []string
+		wantErr    bool
+	}{
+		{
+			name:       "new collection",
+			defined:    []mongo.IndexModel{emailIndex, ttlIndex},
+			wantCreate: []string{"idx_tenant_email_unique", "idx_expires_at_ttl"},
+		},
+		{
+			name:     "up to date",
+			existing: []indexSpec{idSpec, emailSpec, ttlSpec},
+			defined:  []mongo.IndexModel{emailIndex, ttlIndex},
+		},
+		{
+			name:       "unique option changed",
+			existing:   []indexSpec{idSpec, {Name: "idx_tenant_email_unique", Key: emailSpec.Key}},
+			defined:    []mongo.IndexModel{emailIndex},
+			wantCreate: []string{"idx_tenant_email_unique"},
+			wantDrop:   []string{"idx_tenant_email_unique"},
+		},
+		{
+			name:       "key order changed",
+			existing:   []indexSpec{{Name: "idx_tenant_email_unique", Key: bson.D{{Key: "email", Value: int32(1)}, {Key: "tenant_id", Value: int32(1)}}, Unique: true}},
+			defined:    []mongo.IndexModel{emailIndex},
+			wantCreate: []string{"idx_tenant_email_unique"},
+			wantDrop:   []string{"idx_tenant_email_unique"},
+		},
+		{
+			name:     "managed index no longer defined",
+			existing: []indexSpec{idSpec, emailSpec, ttlSpec, {Name: "manual_index", Key: bson.D{{Key: "name", Value: int32(1)}}}},
+			defined:  []mongo.IndexModel{emailIndex},
+			wantDrop: []string{"idx_expires_at_ttl"},
+		},
+		{
+			name:    "unnamed index",
+			defined: []mongo.IndexModel{{Keys: bson.D{{Key: "name", Value: 1}}}},
+			wantErr: true,
+		},
+		{
+			name:    "index defined twice",
+			defined: []mongo.IndexModel{emailIndex, emailIndex},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			create, drop, err := planIndexes(tc.existing, tc.defined)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var created []string
+			for _, model := range create {
+				created = append(created, *model.Options.Name)
+			}
+			assert.Equal(t, tc.wantCreate, created)
+			assert.Equal(t, tc.wantDrop, drop)
+		})
+	}
+}
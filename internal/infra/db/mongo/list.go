@@ -0,0 +1,109 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// idField is the field breaking the ties of the sorted lists
+const idField = "_id"
+
+// SortField orders a list by a field, ascending unless Descending
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ListOptions orders, projects and limits the documents of a list. The zero value lists the whole documents in the
+// order the server returns them
+type ListOptions struct {
+	// Sort orders the documents by each field in turn, ties are broken by _id so the order is stable
+	Sort []SortField
+	// Fields are the only fields returned besides _id, every field when empty
+	Fields []string
+	// Limit caps the number of documents, 0 lists them all
+	Limit int64
+}
+
+// IsZero reports whether the options list the whole documents in the server order
+func (o ListOptions) IsZero() bool {
+	return len(o.Sort) == 0 && len(o.Fields) == 0 && o.Limit <= 0
+}
+
+// SortDocument returns the $sort document of the options ending with _id, nil when they do not sort
+func (o ListOptions) SortDocument() bson.D {
+	if len(o.Sort) == 0 {
+		return nil
+	}
+	sort := make(bson.D, 0, len(o.Sort)+1)
+	for _, field := range o.Sort {
+		direction := 1
+		if field.Descending {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: field.Field, Value: direction})
+	}
+	if !slices.ContainsFunc(o.Sort, func(field SortField) bool { return field.Field == idField }) {
+		sort = append(sort, bson.E{Key: idField, Value: 1})
+	}
+	return sort
+}
+
+// Projection returns the $project document of the options, nil when they return every field
+func (o ListOptions) Projection() bson.M {
+	if len(o.Fields) == 0 {
+		return nil
+	}
+	projection := make(bson.M, len(o.Fields))
+	for _, field := range o.Fields {
+		projection[field] = 1
+	}
+	return projection
+}
+
+// Stages returns the options as the $sort, $project and $limit stages of a pipeline
+func (o ListOptions) Stages() []bson.M {
+	stages := []bson.M{}
+	if sort := o.SortDocument(); sort != nil {
+		stages = append(stages, bson.M{"$sort": sort})
+	}
+	if o.Limit > 0 {
+		stages = append(stages, bson.M{"$limit": o.Limit})
+	}
+	if projection := o.Projection(); projection != nil {
+		stages = append(stages, bson.M{"$project": projection})
+	}
+	return stages
+}
+
+// List decodes the documents matching filter into result, a pointer to a slice, ordered, projected and limited by
+// opts
+func (m *MongoDBManager) List(ctx context.Context, collectionName string, filter map[string]any, result any, opts ListOptions) error {
+	m.logger.Debug("listing", "collection", collectionName, "filter", filter, "options", opts)
+	if filter == nil {
+		return errors.New("filter is required and cannot be nil")
+	}
+	collection := m.readCollection(ctx, collectionName)
+	ctx, cancel := operationContext(ctx)
+	defer cancel()
+	m.convertFilterToMongoTypes(filter)
+	findOptions := options.Find()
+	if sort := opts.SortDocument(); sort != nil {
+		findOptions.SetSort(sort)
+	}
+	if projection := opts.Projection(); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+	if opts.Limit > 0 {
+		findOptions.SetLimit(opts.Limit)
+	}
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, result)
+}
@@ -0,0 +1,119 @@
+// Package migration upgrades the stored documents of a collection when its model changes. A Migration upgrades a
+// document from the previous schema version of its collection, the version of a document is kept in its
+// VersionField and documents stored before the first migration are at version 0.
+//
+// The documents are upgraded in two ways: the collection handlers upgrade the documents they read and store the
+// documents they write at the latest version, and `erp migrate` upgrades the stored documents in bulk, recording the
+// versions applied to each collection in the migrations collection
+package migration
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// VersionField is the field of the schema version of the documents of the collections with migrations
+const VersionField = "schema_version"
+
+// Migration upgrades a document of Collection from version Version-1 to Version
+type Migration struct {
+	Collection  model_mongo.Collection
+	Version     int
+	Description string
+	// Up changes doc in place, e.g. renames a field or sets the default of a new required one. Up should not
+	// depend on other documents, it runs on every read of a document until the document is stored upgraded
+	Up func(doc bson.M) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[model_mongo.Collection][]Migration{}
+)
+
+// Register adds migrations to the registry, usually from the init of the package of the model. The versions of a
+// collection start at 1 and follow each other, Register panics on a migration out of order as the services would
+// store documents at a version that does not exist
+func Register(migrations ...Migration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, migration := range migrations {
+		if migration.Up == nil {
+			panic(fmt.Sprintf("migration: %s version %d has no Up", migration.Collection, migration.Version))
+		}
+		registered := registry[migration.Collection]
+		if migration.Version != len(registered)+1 {
+			panic(fmt.Sprintf("migration: %s version %d registered after version %d", migration.Collection, migration.Version, len(registered)))
+		}
+		registry[migration.Collection] = append(registered, migration)
+	}
+}
+
+// Migrations returns the migrations of collection in version order
+func Migrations(collection model_mongo.Collection) []Migration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return slices.Clone(registry[collection])
+}
+
+// Collections returns the collections with migrations, sorted by name
+func Collections() []model_mongo.Collection {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	collections := make([]model_mongo.Collection, 0, len(registry))
+	for collection := range registry {
+		collections = append(collections, collection)
+	}
+	slices.Sort(collections)
+	return collections
+}
+
+// Latest returns the schema version of the documents of collection written now, 0 without migrations
+func Latest(collection model_mongo.Collection) int {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return len(registry[collection])
+}
+
+// Version returns the schema version of doc
+func Version(doc bson.M) int {
+	switch version := doc[VersionField].(type) {
+	case int32:
+		return int(version)
+	case int64:
+		return int(version)
+	case int:
+		return version
+	case float64:
+		return int(version)
+	}
+	return 0
+}
+
+// Upgrade runs the migrations of collection doc is not at yet and returns whether it changed. Documents at a version
+// newer than the latest, written by a newer release, are left as they are
+func Upgrade(collection model_mongo.Collection, doc bson.M) (bool, error) {
+	version := Version(doc)
+	changed := false
+	for _, migration := range Migrations(collection) {
+		if migration.Version <= version {
+			continue
+		}
+		if err := migration.Up(doc); err != nil {
+			return changed, fmt.Errorf("migration %s version %d: %w", collection, migration.Version, err)
+		}
+		doc[VersionField] = int32(migration.Version)
+		changed = true
+	}
+	return changed, nil
+}
+
+// Reset removes the migrations of collection, for the tests registering their own
+func Reset(collection model_mongo.Collection) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, collection)
+}
@@ -0,0 +1,75 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const testCollection model_mongo.Collection = "migration_test"
+
+// renameField returns the Up of a migration renaming the field from to the field to
+func renameField(from, to string) func(doc bson.M) error {
+	return func(doc bson.M) error {
+		if value, ok := doc[from]; ok {
+			doc[to] = value
+			delete(doc, from)
+		}
+		return nil
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	t.Cleanup(func() { Reset(testCollection) })
+	Register(
+		Migration{Collection: testCollection, Version: 1, Description: "rename qty", Up: renameField("qty", "quantity")},
+		Migration{Collection: testCollection, Version: 2, Description: "default status", Up: func(doc bson.M) error {
+			if _, ok := doc["status"]; !ok {
+				doc["status"] = "active"
+			}
+			return nil
+		}},
+	)
+	assert.Equal(t, 2, Latest(testCollection))
+	assert.Contains(t, Collections(), testCollection)
+
+	doc := bson.M{"qty": int64(5)}
+	changed, err := Upgrade(testCollection, doc)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, bson.M{"quantity": int64(5), "status": "active", VersionField: int32(2)}, doc)
+
+	// Only the migrations the document is not at yet run
+	doc = bson.M{"qty": int64(1), "status": "closed", VersionField: int64(1)}
+	_, err = Upgrade(testCollection, doc)
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"qty": int64(1), "status": "closed", VersionField: int32(2)}, doc)
+
+	// Documents of a newer release are left as they are
+	doc = bson.M{"amount": int64(1), VersionField: int32(3)}
+	changed, err = Upgrade(testCollection, doc)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestUpgrade_Error(t *testing.T) {
+	t.Cleanup(func() { Reset(testCollection) })
+	failure := errors.New("bad document")
+	Register(Migration{Collection: testCollection, Version: 1, Up: func(doc bson.M) error { return failure }})
+
+	_, err := Upgrade(testCollection, bson.M{})
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestRegister_OutOfOrder(t *testing.T) {
+	t.Cleanup(func() { Reset(testCollection) })
+	up := renameField("a", "b")
+	assert.Panics(t, func() { Register(Migration{Collection: testCollection, Version: 2, Up: up}) })
+	Register(Migration{Collection: testCollection, Version: 1, Up: up})
+	assert.Panics(t, func() { Register(Migration{Collection: testCollection, Version: 1, Up: up}) })
+	assert.Panics(t, func() { Register(Migration{Collection: testCollection, Version: 2}) })
+}
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"erp.localhost/internal/infra/db/mongo"
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// idField is the stored field of the ids, named id in the requests
+const idField = "_id"
+
+// List returns the list options of the documents of T from the order, fields and limit of a list request. orderBy
+// is a comma separated list of fields, each followed by an optional "asc" or "desc", e.g. "last_name, created_at
+// desc". The fields are bson paths of T, id stands for _id
+func List[T any](orderBy string, fields []string, limit int32) (mongo.ListOptions, error) {
+	opts := mongo.ListOptions{Limit: int64(limit)}
+	if limit < 0 {
+		return opts, infra_error.Validation(infra_error.ValidationInvalidValue, "limit").
+			WithError(fmt.Errorf("limit must not be negative, got %d", limit))
+	}
+	sort, err := parseOrderBy[T](orderBy)
+	if err != nil {
+		return opts, err
+	}
+	opts.Sort = sort
+	for _, field := range fields {
+		field = storedField(strings.TrimSpace(field))
+		if err := checkField[T](field); err != nil {
+			return opts, err
+		}
+		opts.Fields = append(opts.Fields, field)
+	}
+	return opts, nil
+}
+
+// parseOrderBy returns the sort fields of orderBy, none when it is empty
+func parseOrderBy[T any](orderBy string) ([]mongo.SortField, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+	var sort []mongo.SortField
+	for _, clause := range strings.Split(orderBy, ",") {
+		words := strings.Fields(clause)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "order_by").
+				WithError(fmt.Errorf("%q is not a field followed by an optional direction", strings.TrimSpace(clause)))
+		}
+		field := mongo.SortField{Field: storedField(words[0])}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				field.Descending = true
+			default:
+				return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "order_by").
+					WithError(fmt.Errorf("%q is not asc or desc", words[1]))
+			}
+		}
+		if err := checkField[T](field.Field); err != nil {
+			return nil, err
+		}
+		sort = append(sort, field)
+	}
+	return sort, nil
+}
+
+// storedField returns the stored path of a field named in a request
+func storedField(field string) string {
+	if field == "id" {
+		return idField
+	}
+	return field
+}
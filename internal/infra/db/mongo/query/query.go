@@ -0,0 +1,267 @@
+// Package query builds the filters of the collection handlers from typed conditions instead of hand-built maps. A
+// query is scoped to a tenant unless it explicitly spans all of them, and its field names are checked against the
+// bson fields of the model, so a typo fails the query instead of silently matching nothing:
+//
+//	filter, err := query.New[corev1.Order]().Tenant(tenantID).Eq("status", status).In("customer_id", ids).Build()
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	infra_error "erp.localhost/internal/infra/error"
+)
+
+// tenantField is the field scoping the documents to their tenant
+const tenantField = "tenant_id"
+
+// Query is a filter of the documents of model T, its methods return the query so the conditions chain. The first
+// invalid condition is reported by Build
+type Query[T any] struct {
+	tenantID   string
+	allTenants bool
+	// fields keeps the order the fields were added in, conditions holds their operators
+	fields     []string
+	conditions map[string]map[string]any
+	or         [][]map[string]any
+	err        error
+}
+
+// New starts a query of the documents of T, it must be scoped with Tenant or AllTenants
+func New[T any]() *Query[T] {
+	return &Query[T]{conditions: map[string]map[string]any{}}
+}
+
+// Tenant scopes the query to the documents of tenantID
+func (q *Query[T]) Tenant(tenantID string) *Query[T] {
+	if tenantID == "" {
+		return q.fail(infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId"))
+	}
+	if err := checkField[T](tenantField); err != nil {
+		return q.fail(err)
+	}
+	q.tenantID = tenantID
+	return q
+}
+
+// AllTenants lets the query match the documents of every tenant, for the system jobs and the models without a tenant
+func (q *Query[T]) AllTenants() *Query[T] {
+	q.allTenants = true
+	return q
+}
+
+// ID matches the document of id
+func (q *Query[T]) ID(id string) *Query[T] {
+	if id == "" {
+		return q.fail(infra_error.Validation(infra_error.ValidationRequiredFields, "Id"))
+	}
+	return q.Eq("_id", id)
+}
+
+// Eq matches the documents whose field equals value, or contains it for an array field
+func (q *Query[T]) Eq(field string, value any) *Query[T] { return q.where(field, "$eq", value) }
+
+// Ne matches the documents whose field differs from value
+func (q *Query[T]) Ne(field string, value any) *Query[T] { return q.where(field, "$ne", value) }
+
+// Gt, Gte, Lt and Lte compare the field to value, they combine on one field into a range
+func (q *Query[T]) Gt(field string, value any) *Query[T]  { return q.where(field, "$gt", value) }
+func (q *Query[T]) Gte(field string, value any) *Query[T] { return q.where(field, "$gte", value) }
+func (q *Query[T]) Lt(field string, value any) *Query[T]  { return q.where(field, "$lt", value) }
+func (q *Query[T]) Lte(field string, value any) *Query[T] { return q.where(field, "$lte", value) }
+
+// In matches the documents whose field is one of values, a slice
+func (q *Query[T]) In(field string, values any) *Query[T] { return q.whereList(field, "$in", values) }
+
+// NotIn matches the documents whose field is none of values, a slice
+func (q *Query[T]) NotIn(field string, values any) *Query[T] {
+	return q.whereList(field, "$nin", values)
+}
+
+// Exists matches the documents that have the field, or do not when exists is false
+func (q *Query[T]) Exists(field string, exists bool) *Query[T] {
+	return q.where(field, "$exists", exists)
+}
+
+// Or matches the documents matching any of branches, queries of T started with New and left unscoped, the scope of
+// the query applies to them
+func (q *Query[T]) Or(branches ...*Query[T]) *Query[T] {
+	compiled := make([]map[string]any, 0, len(branches))
+	for _, branch := range branches {
+		if branch.err != nil {
+			return q.fail(branch.err)
+		}
+		compiled = append(compiled, branch.compile())
+	}
+	if len(compiled) > 0 {
+		q.or = append(q.or, compiled)
+	}
+	return q
+}
+
+// Build returns the filter of the query for the collection handlers, with the tenant condition unless it spans all
+// the tenants
+func (q *Query[T]) Build() (map[string]any, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if q.tenantID == "" && !q.allTenants {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "TenantId")
+	}
+	filter := q.compile()
+	if q.tenantID != "" {
+		filter[tenantField] = q.tenantID
+	}
+	return filter, nil
+}
+
+// compile returns the conditions of the query, an equality alone is kept as the plain value so the handlers
+// convert the ids
+func (q *Query[T]) compile() map[string]any {
+	filter := make(map[string]any, len(q.fields)+1)
+	for _, field := range q.fields {
+		ops := q.conditions[field]
+		if value, ok := ops["$eq"]; ok && len(ops) == 1 {
+			filter[field] = value
+			continue
+		}
+		filter[field] = ops
+	}
+	switch len(q.or) {
+	case 0:
+	case 1:
+		filter["$or"] = q.or[0]
+	default:
+		and := make([]map[string]any, 0, len(q.or))
+		for _, branches := range q.or {
+			and = append(and, map[string]any{"$or": branches})
+		}
+		filter["$and"] = and
+	}
+	return filter
+}
+
+func (q *Query[T]) where(field, op string, value any) *Query[T] {
+	if q.err != nil {
+		return q
+	}
+	if err := checkField[T](field); err != nil {
+		return q.fail(err)
+	}
+	ops, ok := q.conditions[field]
+	if !ok {
+		ops = map[string]any{}
+		q.conditions[field] = ops
+		q.fields = append(q.fields, field)
+	}
+	if _, ok := ops[op]; ok {
+		return q.fail(infra_error.Validation(infra_error.ValidationInvalidValue, field).
+			WithError(fmt.Errorf("%s is set twice on %s", op, field)))
+	}
+	ops[op] = value
+	return q
+}
+
+func (q *Query[T]) whereList(field, op string, values any) *Query[T] {
+	if v := reflect.ValueOf(values); v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return q.fail(infra_error.Validation(infra_error.ValidationInvalidType, field).
+			WithError(fmt.Errorf("%s takes a slice, got %T", op, values)))
+	}
+	return q.where(field, op, values)
+}
+
+func (q *Query[T]) fail(err error) *Query[T] {
+	if q.err == nil {
+		q.err = err
+	}
+	return q
+}
+
+// modelFields caches the fields of the models by type
+var modelFields sync.Map
+
+// fieldSet holds the dotted bson paths of a model, open holds the paths whose subpaths are not checked, the maps and
+// the recursive types
+type fieldSet struct {
+	paths map[string]bool
+	open  map[string]bool
+}
+
+// checkField returns a validation error when field is not a bson path of T
+func checkField[T any](field string) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	cached, ok := modelFields.Load(t)
+	if !ok {
+		set := &fieldSet{paths: map[string]bool{}, open: map[string]bool{}}
+		collectFields(t, "", set, map[reflect.Type]bool{})
+		cached, _ = modelFields.LoadOrStore(t, set)
+	}
+	set := cached.(*fieldSet)
+	if set.paths[field] {
+		return nil
+	}
+	for prefix := field; ; {
+		i := strings.LastIndex(prefix, ".")
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+		if set.open[prefix] {
+			return nil
+		}
+	}
+	return infra_error.Validation(infra_error.ValidationInvalidValue, field).
+		WithError(fmt.Errorf("%s is not a field of %s", field, t.Name()))
+}
+
+// collectFields adds the bson paths of the fields of struct type t under prefix. The fields of a recursive type are
+// left open below its first level
+func collectFields(t reflect.Type, prefix string, set *fieldSet, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	if seen[t] {
+		set.open[prefix] = true
+		return
+	}
+	seen[t] = true
+	defer delete(seen, t)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(sf.Tag.Get("bson"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			collectFields(sf.Type, prefix, set, seen)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		set.paths[path] = true
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
+			ft = ft.Elem()
+		}
+		switch ft.Kind() {
+		case reflect.Map, reflect.Interface:
+			set.open[path] = true
+		case reflect.Struct:
+			collectFields(ft, path, set, seen)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package query
+
+import (
+	"testing"
+
+	"erp.localhost/internal/infra/db/mongo"
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `bson:"city"`
+}
+
+type item struct {
+	ID       string            `bson:"_id,omitempty"`
+	TenantID string            `bson:"tenant_id"`
+	Status   int32             `bson:"status"`
+	RoleIDs  []string          `bson:"role_ids"`
+	Quantity int64             `bson:"quantity"`
+	Address  *address          `bson:"address"`
+	Labels   map[string]string `bson:"labels"`
+	Parent   *item             `bson:"parent"`
+	internal string
+}
+
+type tenant struct {
+	ID   string `bson:"_id,omitempty"`
+	Name string `bson:"name"`
+}
+
+func TestQuery_Build(t *testing.T) {
+	filter, err := New[item]().Tenant("tenant-1").ID("item-1").Eq("status", int32(2)).
+		In("role_ids", []string{"a", "b"}).Gte("quantity", 1).Lt("quantity", 10).Build()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"tenant_id": "tenant-1",
+		"_id":       "item-1",
+		"status":    int32(2),
+		"role_ids":  map[string]any{"$in": []string{"a", "b"}},
+		"quantity":  map[string]any{"$gte": 1, "$lt": 10},
+	}, filter)
+}
+
+func TestQuery_Or(t *testing.T) {
+	filter, err := New[item]().Tenant("tenant-1").
+		Or(New[item]().Eq("status", 1), New[item]().Exists("parent", false)).Build()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"tenant_id": "tenant-1",
+		"$or": []map[string]any{
+			{"status": 1},
+			{"parent": map[string]any{"$exists": false}},
+		},
+	}, filter)
+}
+
+func TestQuery_RequiresTenant(t *testing.T) {
+	_, err := New[item]().Eq("status", 1).Build()
+	require.Error(t, err)
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryValidation))
+
+	_, err = New[item]().Tenant("").Build()
+	assert.Error(t, err)
+
+	// The models without a tenant span all the tenants explicitly
+	_, err = New[tenant]().Tenant("tenant-1").Build()
+	assert.Error(t, err)
+	filter, err := New[tenant]().AllTenants().Eq("name", "acme").Build()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "acme"}, filter)
+}
+
+func TestQuery_ValidatesFields(t *testing.T) {
+	for _, field := range []string{"address.city", "labels.env", "parent.status", "parent.address.city"} {
+		_, err := New[item]().Tenant("tenant-1").Eq(field, "x").Build()
+		assert.NoError(t, err, field)
+	}
+	for _, field := range []string{"statuss", "internal", "address.zip", "role_ids.name"} {
+		_, err := New[item]().Tenant("tenant-1").Eq(field, "x").Build()
+		assert.Error(t, err, field)
+	}
+
+	_, err := New[item]().Tenant("tenant-1").In("role_ids", "a").Build()
+	assert.Error(t, err)
+	_, err = New[item]().Tenant("tenant-1").Eq("status", 1).Eq("status", 2).Build()
+	assert.Error(t, err)
+}
+
+func TestList(t *testing.T) {
+	opts, err := List[item]("status desc, address.city,id ASC", []string{"id", "quantity", "address.city"}, 20)
+	require.NoError(t, err)
+	assert.Equal(t, mongo.ListOptions{
+		Sort: []mongo.SortField{
+			{Field: "status", Descending: true},
+			{Field: "address.city"},
+			{Field: "_id"},
+		},
+		Fields: []string{"_id", "quantity", "address.city"},
+		Limit:  20,
+	}, opts)
+
+	opts, err = List[item]("", nil, 0)
+	require.NoError(t, err)
+	assert.True(t, opts.IsZero())
+
+	for name, build := range map[string]func() error{
+		"unknown sort field": func() error { _, err := List[item]("missing", nil, 0); return err },
+		"unknown direction":  func() error { _, err := List[item]("status up", nil, 0); return err },
+		"empty clause":       func() error { _, err := List[item]("status,,quantity", nil, 0); return err },
+		"unknown field":      func() error { _, err := List[item]("", []string{"missing"}, 0); return err },
+		"negative limit":     func() error { _, err := List[item]("", nil, -1); return err },
+		"extra word":         func() error { _, err := List[item]("status desc asc", nil, 0); return err },
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := build()
+			require.Error(t, err)
+			assert.True(t, infra_error.IsCategory(err, infra_error.CategoryValidation))
+		})
+	}
+}
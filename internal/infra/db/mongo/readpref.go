@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// ReadPreference selects the members of the replica set the reads are sent to. The reads sent to the secondaries
+// may not see the latest writes, they suit the list and report queries rather than the reads following a write
+type ReadPreference string
+
+const (
+	// ReadPrimary sends the reads to the primary, the default of the deployment
+	ReadPrimary ReadPreference = "primary"
+	// ReadPrimaryPreferred sends the reads to the primary, to a secondary while there is none
+	ReadPrimaryPreferred ReadPreference = "primaryPreferred"
+	// ReadSecondary sends the reads to the secondaries, they fail while there is none
+	ReadSecondary ReadPreference = "secondary"
+	// ReadSecondaryPreferred sends the reads to the secondaries, to the primary while there is none
+	ReadSecondaryPreferred ReadPreference = "secondaryPreferred"
+	// ReadNearest sends the reads to the member of the lowest latency
+	ReadNearest ReadPreference = "nearest"
+)
+
+var (
+	readPrefMu            sync.RWMutex
+	defaultReadPreference ReadPreference
+)
+
+type readPreferenceKey struct{}
+
+// SetReadPreference sets the read preference of the reads of the process that set none, an empty preference
+// restores the one of the deployment URI
+func SetReadPreference(pref ReadPreference) {
+	readPrefMu.Lock()
+	defer readPrefMu.Unlock()
+	defaultReadPreference = pref
+}
+
+// WithReadPreference returns ctx sending the reads made with it to the members selected by pref, it takes
+// precedence over the preference of the collection handlers and the process
+func WithReadPreference(ctx context.Context, pref ReadPreference) context.Context {
+	return context.WithValue(ctx, readPreferenceKey{}, pref)
+}
+
+// WithDefaultReadPreference returns ctx with pref unless ctx carries a read preference or pref is empty, the
+// preference of a handler yields to the one of the call
+func WithDefaultReadPreference(ctx context.Context, pref ReadPreference) context.Context {
+	if pref == "" {
+		return ctx
+	}
+	if _, ok := ReadPreferenceFrom(ctx); ok {
+		return ctx
+	}
+	return WithReadPreference(ctx, pref)
+}
+
+// ReadPreferenceFrom returns the read preference set on ctx by WithReadPreference
+func ReadPreferenceFrom(ctx context.Context) (ReadPreference, bool) {
+	pref, ok := ctx.Value(readPreferenceKey{}).(ReadPreference)
+	return pref, ok && pref != ""
+}
+
+// readPref returns the driver read preference of p
+func (p ReadPreference) readPref() (*readpref.ReadPref, error) {
+	mode, err := readpref.ModeFromString(string(p))
+	if err != nil {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "read_preference").
+			WithError(fmt.Errorf("unknown read preference %q", p))
+	}
+	return readpref.New(mode)
+}
+
+// readCollection returns the collection the reads of ctx are made on, with the read preference of ctx or of the
+// process. Reads in a transaction keep the primary, transactions only read from it
+func (m *MongoDBManager) readCollection(ctx context.Context, collectionName string) *mongo.Collection {
+	if mongo.SessionFromContext(ctx) != nil {
+		return m.db.Collection(collectionName)
+	}
+	pref, ok := ReadPreferenceFrom(ctx)
+	if !ok {
+		readPrefMu.RLock()
+		pref = defaultReadPreference
+		readPrefMu.RUnlock()
+	}
+	if pref == "" {
+		return m.db.Collection(collectionName)
+	}
+	rp, err := pref.readPref()
+	if err != nil {
+		m.logger.Warn("ignoring invalid read preference", "read_preference", pref, "error", err)
+		return m.db.Collection(collectionName)
+	}
+	return m.db.Collection(collectionName, options.Collection().SetReadPreference(rp))
+}
@@ -0,0 +1,38 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestWithDefaultReadPreference(t *testing.T) {
+	ctx := context.Background()
+	_, ok := ReadPreferenceFrom(WithDefaultReadPreference(ctx, ""))
+	assert.False(t, ok)
+
+	// The preference of the handler applies to the calls without one
+	pref, ok := ReadPreferenceFrom(WithDefaultReadPreference(ctx, ReadSecondaryPreferred))
+	require.True(t, ok)
+	assert.Equal(t, ReadSecondaryPreferred, pref)
+
+	// The preference of the call takes precedence
+	pref, _ = ReadPreferenceFrom(WithDefaultReadPreference(WithReadPreference(ctx, ReadPrimary), ReadSecondaryPreferred))
+	assert.Equal(t, ReadPrimary, pref)
+}
+
+func TestReadPreference_ReadPref(t *testing.T) {
+	rp, err := ReadNearest.readPref()
+	require.NoError(t, err)
+	assert.Equal(t, readpref.NearestMode, rp.Mode())
+
+	rp, err = ReadPrimaryPreferred.readPref()
+	require.NoError(t, err)
+	assert.Equal(t, readpref.PrimaryPreferredMode, rp.Mode())
+
+	_, err = ReadPreference("secondaries").readPref()
+	assert.Error(t, err)
+}
@@ -0,0 +1,374 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	redis "github.com/redis/go-redis/v9"
+)
+
+// Mode is the topology of the Redis deployment
+type Mode string
+
+const (
+	ModeStandalone Mode = "standalone"
+	ModeSentinel   Mode = "sentinel"
+	ModeCluster    Mode = "cluster"
+)
+
+// defaultRedisURL is the local development instance, used when no address is configured
+const defaultRedisURL = "redis://:supersecretredis@localhost:6379"
+
+const (
+	// defaultScanCount is the COUNT hint of the SCAN commands when none is configured
+	defaultScanCount = 100
+	// defaultBatchSize is the most keys read by one MGET or pipeline when none is configured
+	defaultBatchSize = 500
+)
+
+// TLSConfig holds the TLS settings of the Redis connections
+type TLSConfig struct {
+	Enabled bool
+	// CAFile verifies the server certificate, the system pool is used when empty
+	CAFile string
+	// CertFile and KeyFile are the client certificate, for servers requiring mutual TLS
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// FallbackConfig controls the in-process cache serving reads while Redis is unavailable
+type FallbackConfig struct {
+	Enabled bool
+	// TTL caps how long a value is served from memory, entries written with a shorter TTL keep it
+	TTL time.Duration
+	// MaxEntries bounds the cache of each handler, the least recently used entries are evicted first
+	MaxEntries int
+	// BreakerFailures consecutive failures open the circuit, Redis is not called again until BreakerCooldown passed
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+// RedisConfig holds the connection settings of the Redis handlers
+type RedisConfig struct {
+	// ClientName is sent with CLIENT SETNAME, handlers with different names never share a connection pool
+	ClientName string
+	Mode       Mode
+	// Addrs are host:port pairs, the server for standalone, the sentinels for sentinel and the seed nodes for cluster
+	Addrs []string
+	// MasterName is the name of the master monitored by the sentinels
+	MasterName       string
+	Username         string
+	Password         string
+	SentinelUsername string
+	SentinelPassword string
+	// DB is the database selected after connecting, cluster deployments only have database 0
+	DB  int
+	TLS TLSConfig
+
+	// Connection pool, zero values use the go-redis defaults
+	PoolSize     int
+	MinIdleConns int
+	PoolTimeout  time.Duration
+
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+
+	// Retries of failed commands, with an exponential backoff between MinRetryBackoff and MaxRetryBackoff
+	MaxRetries      int
+	MinRetryBackoff time.Duration
+	MaxRetryBackoff time.Duration
+
+	// ScanCount is the COUNT hint of the SCAN commands, the keys examined by the server per call
+	ScanCount int
+	// BatchSize bounds the keys read by one MGET or pipeline, reads of more keys are split so a user with many
+	// sessions does not block the server with one large command
+	BatchSize int
+
+	Fallback FallbackConfig
+}
+
+// LoadRedisConfig loads the Redis configuration from environment variables.
+// REDIS_URL sets the address, credentials, database and TLS of a standalone server in one value,
+// the other variables override it
+func LoadRedisConfig() *RedisConfig {
+	config := &RedisConfig{Mode: ModeStandalone}
+	if options, err := redis.ParseURL(getEnv("REDIS_URL", defaultRedisURL)); err == nil {
+		config.Addrs = []string{options.Addr}
+		config.Username = options.Username
+		config.Password = options.Password
+		config.DB = options.DB
+		config.TLS.Enabled = options.TLSConfig != nil
+	}
+
+	if mode := os.Getenv("REDIS_MODE"); mode != "" {
+		config.Mode = Mode(strings.ToLower(mode))
+	}
+	if addrs := splitList(os.Getenv("REDIS_ADDRS")); len(addrs) > 0 {
+		config.Addrs = addrs
+	}
+	config.MasterName = os.Getenv("REDIS_MASTER_NAME")
+	config.Username = getEnv("REDIS_USERNAME", config.Username)
+	config.Password = getEnv("REDIS_PASSWORD", config.Password)
+	config.SentinelUsername = os.Getenv("REDIS_SENTINEL_USERNAME")
+	config.SentinelPassword = os.Getenv("REDIS_SENTINEL_PASSWORD")
+	config.DB = envInt("REDIS_DB", config.DB)
+
+	config.TLS.Enabled = envBool("REDIS_TLS_ENABLED", config.TLS.Enabled)
+	config.TLS.CAFile = os.Getenv("REDIS_TLS_CA_FILE")
+	config.TLS.CertFile = os.Getenv("REDIS_TLS_CERT_FILE")
+	config.TLS.KeyFile = os.Getenv("REDIS_TLS_KEY_FILE")
+	config.TLS.ServerName = os.Getenv("REDIS_TLS_SERVER_NAME")
+	config.TLS.InsecureSkipVerify = envBool("REDIS_TLS_INSECURE_SKIP_VERIFY", false)
+
+	config.PoolSize = envInt("REDIS_POOL_SIZE", 0)
+	config.MinIdleConns = envInt("REDIS_MIN_IDLE_CONNS", 0)
+	config.PoolTimeout = envDuration("REDIS_POOL_TIMEOUT", 0)
+	config.DialTimeout = envDuration("REDIS_DIAL_TIMEOUT", 5*time.Second)
+	config.ReadTimeout = envDuration("REDIS_READ_TIMEOUT", 3*time.Second)
+	config.WriteTimeout = envDuration("REDIS_WRITE_TIMEOUT", 3*time.Second)
+	config.MaxRetries = envInt("REDIS_MAX_RETRIES", 3)
+	config.MinRetryBackoff = envDuration("REDIS_MIN_RETRY_BACKOFF", 8*time.Millisecond)
+	config.MaxRetryBackoff = envDuration("REDIS_MAX_RETRY_BACKOFF", 512*time.Millisecond)
+	config.ScanCount = envInt("REDIS_SCAN_COUNT", defaultScanCount)
+	config.BatchSize = envInt("REDIS_BATCH_SIZE", defaultBatchSize)
+
+	config.Fallback.Enabled = envBool("REDIS_FALLBACK_ENABLED", false)
+	config.Fallback.TTL = envDuration("REDIS_FALLBACK_TTL", 30*time.Second)
+	config.Fallback.MaxEntries = envInt("REDIS_FALLBACK_MAX_ENTRIES", 10000)
+	config.Fallback.BreakerFailures = envInt("REDIS_BREAKER_FAILURES", 5)
+	config.Fallback.BreakerCooldown = envDuration("REDIS_BREAKER_COOLDOWN", 10*time.Second)
+	return config
+}
+
+// Validate checks the configuration is complete for its mode
+func (c *RedisConfig) Validate() error {
+	invalid := []string{}
+	switch c.Mode {
+	case ModeStandalone:
+		if len(c.Addrs) != 1 {
+			invalid = append(invalid, "standalone mode requires exactly one address")
+		}
+	case ModeSentinel:
+		if len(c.Addrs) == 0 {
+			invalid = append(invalid, "sentinel mode requires the sentinel addresses")
+		}
+		if c.MasterName == "" {
+			invalid = append(invalid, "sentinel mode requires the master name")
+		}
+	case ModeCluster:
+		if len(c.Addrs) == 0 {
+			invalid = append(invalid, "cluster mode requires the seed node addresses")
+		}
+		if c.DB != 0 {
+			invalid = append(invalid, "cluster mode only supports database 0")
+		}
+	default:
+		invalid = append(invalid, fmt.Sprintf("unknown mode %q", c.Mode))
+	}
+	if c.DB < 0 {
+		invalid = append(invalid, "database must not be negative")
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		invalid = append(invalid, "tls client certificate requires both cert and key files")
+	}
+	if c.PoolSize < 0 || c.MinIdleConns < 0 || (c.PoolSize > 0 && c.MinIdleConns > c.PoolSize) {
+		invalid = append(invalid, "invalid pool size")
+	}
+	if c.MaxRetries < -1 {
+		invalid = append(invalid, "max retries must be -1 (disabled) or more")
+	}
+	if c.MinRetryBackoff > 0 && c.MaxRetryBackoff > 0 && c.MinRetryBackoff > c.MaxRetryBackoff {
+		invalid = append(invalid, "min retry backoff must not exceed max retry backoff")
+	}
+	if c.ScanCount < 0 || c.BatchSize < 0 {
+		invalid = append(invalid, "scan count and batch size must not be negative")
+	}
+	if c.Fallback.Enabled && (c.Fallback.TTL <= 0 || c.Fallback.MaxEntries <= 0 || c.Fallback.BreakerFailures <= 0 || c.Fallback.BreakerCooldown <= 0) {
+		invalid = append(invalid, "fallback requires a positive ttl, max entries, breaker failures and breaker cooldown")
+	}
+	if len(invalid) > 0 {
+		return infra_error.Validation(infra_error.ValidationInvalidValue).WithError(errors.New(strings.Join(invalid, ", ")))
+	}
+	return nil
+}
+
+// options converts the configuration to go-redis options, NewUniversalClient picks the client of the mode from them
+func (c *RedisConfig) options() (*redis.UniversalOptions, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := c.TLS.build()
+	if err != nil {
+		return nil, err
+	}
+	options := &redis.UniversalOptions{
+		ClientName:      c.ClientName,
+		Addrs:           c.Addrs,
+		DB:              c.DB,
+		Username:        c.Username,
+		Password:        c.Password,
+		TLSConfig:       tlsConfig,
+		PoolSize:        c.PoolSize,
+		MinIdleConns:    c.MinIdleConns,
+		PoolTimeout:     c.PoolTimeout,
+		DialTimeout:     c.DialTimeout,
+		ReadTimeout:     c.ReadTimeout,
+		WriteTimeout:    c.WriteTimeout,
+		MaxRetries:      c.MaxRetries,
+		MinRetryBackoff: c.MinRetryBackoff,
+		MaxRetryBackoff: c.MaxRetryBackoff,
+	}
+	switch c.Mode {
+	case ModeSentinel:
+		options.MasterName = c.MasterName
+		options.SentinelUsername = c.SentinelUsername
+		options.SentinelPassword = c.SentinelPassword
+	case ModeCluster:
+		options.IsClusterMode = true
+	}
+	return options, nil
+}
+
+// build returns the TLS configuration of the connections, nil when TLS is disabled
+func (c TLSConfig) build() (*tls.Config, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CAFile != "" {
+		caCert, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, infra_error.Internal(infra_error.InternalConfigError, err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, infra_error.Internal(infra_error.InternalConfigError, errors.New("failed to append redis CA certificate"))
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	if c.CertFile != "" {
+		clientCert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, infra_error.Internal(infra_error.InternalConfigError, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
+
+// sharedClient is a client used by every handler with the same configuration, so the
+// handlers of a service share one connection pool instead of opening one each
+type sharedClient struct {
+	client redis.UniversalClient
+	refs   int
+}
+
+var (
+	sharedClientsMu sync.Mutex
+	sharedClients   = map[string]*sharedClient{}
+)
+
+// acquireClient returns the shared client of the configuration, creating it on first use.
+// The returned release function closes the client once every handler released it
+func acquireClient(config *RedisConfig) (redis.UniversalClient, func() error, error) {
+	options, err := config.options()
+	if err != nil {
+		return nil, nil, err
+	}
+	key := fmt.Sprintf("%+v", *config)
+
+	sharedClientsMu.Lock()
+	defer sharedClientsMu.Unlock()
+	shared, ok := sharedClients[key]
+	if !ok {
+		shared = &sharedClient{client: redis.NewUniversalClient(options)}
+		sharedClients[key] = shared
+	}
+	shared.refs++
+
+	var once sync.Once
+	release := func() error {
+		var err error
+		once.Do(func() {
+			sharedClientsMu.Lock()
+			defer sharedClientsMu.Unlock()
+			shared.refs--
+			// The client is already closed when CloseClients ran
+			if shared.refs == 0 && sharedClients[key] == shared {
+				delete(sharedClients, key)
+				err = shared.client.Close()
+			}
+		})
+		return err
+	}
+	return shared.client, release, nil
+}
+
+// CloseClients closes every client of the process whether handlers still use them, at shutdown once nothing runs
+// commands. The handlers created afterwards connect again
+func CloseClients() error {
+	sharedClientsMu.Lock()
+	defer sharedClientsMu.Unlock()
+	var errs []error
+	for key, shared := range sharedClients {
+		delete(sharedClients, key)
+		if err := shared.client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func envInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func envBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func envDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// splitList splits a comma separated value, ignoring empty entries
+func splitList(value string) []string {
+	values := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
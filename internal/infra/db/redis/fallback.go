@@ -0,0 +1,444 @@
+package redis
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+	redis "github.com/redis/go-redis/v9"
+)
+
+// Metrics of the degraded mode, by handler name: the reads served (or not) from memory, and how often and whether
+// Redis is skipped
+var (
+	fallbackReads = metrics.NewCounter("redis_fallback_reads_total",
+		"Total number of reads falling back to the in-process cache, by handler and result (hit or miss).", "handler", "result")
+	fallbackPrimaryFailures = metrics.NewCounter("redis_fallback_primary_failures_total",
+		"Total number of failed Redis calls of the fallback handlers, by handler.", "handler")
+	fallbackBreakerTrips = metrics.NewCounter("redis_fallback_breaker_trips_total",
+		"Total number of times the circuit breaker of a fallback handler opened, by handler.", "handler")
+	fallbackBreakerState = metrics.NewGauge("redis_fallback_breaker_state",
+		"State of the circuit breaker of a fallback handler, 0 closed, 1 open, 2 half open.", "handler")
+)
+
+// ErrCircuitOpen is returned when Redis is skipped by the open circuit breaker and the value is not cached in memory
+var ErrCircuitOpen = errors.New("redis circuit breaker is open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker stops calling Redis after consecutive failures, once the cooldown passed a single call probes it again
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether Redis may be called
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success closes the circuit, returns true when it was not closed
+func (b *circuitBreaker) success() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	recovered := b.state != breakerClosed
+	b.state = breakerClosed
+	b.failures = 0
+	b.probing = false
+	return recovered
+}
+
+// failure counts a failed call, returns true when it opened the circuit
+func (b *circuitBreaker) failure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || (b.state == breakerClosed && b.failures >= b.threshold) {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+		b.probing = false
+		return true
+	}
+	return false
+}
+
+// abandon gives up a call that ended without telling whether Redis works, another call may probe it
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *circuitBreaker) currentState() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// lruCache is a size bounded cache with per entry expiry, values are kept serialized so callers never share them
+type lruCache struct {
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newLRUCache(maxEntries int) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+func (c *lruCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *lruCache) set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *lruCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// deletePrefix removes the entries with keys starting with prefix
+func (c *lruCache) deletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(element)
+		}
+	}
+}
+
+func (c *lruCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *lruCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
+
+// FallbackKeyHandler serves reads from an in-process cache while Redis is unavailable.
+// Values read from or written to Redis are cached for a short TTL, writes and deletes always go to Redis
+// so the cache never holds a value Redis does not have, at most a value Redis expired or deleted in the meantime.
+type FallbackKeyHandler[T any] struct {
+	primary KeyHandler[T]
+	name    string
+	ttl     time.Duration
+	cache   *lruCache
+	breaker *circuitBreaker
+	logger  logger.Logger
+}
+
+// WithFallback wraps handler with the fallback cache when it is enabled in config, otherwise handler is returned as is
+func WithFallback[T any](handler KeyHandler[T], keyPrefix model_redis.KeyPrefix, config *RedisConfig, logger logger.Logger) KeyHandler[T] {
+	if config == nil || !config.Fallback.Enabled {
+		return handler
+	}
+	return NewFallbackKeyHandler(handler, string(keyPrefix), config.Fallback, logger)
+}
+
+// NewFallbackKeyHandler creates a fallback cache in front of primary, name identifies its metrics
+func NewFallbackKeyHandler[T any](primary KeyHandler[T], name string, config FallbackConfig, logger logger.Logger) *FallbackKeyHandler[T] {
+	fallbackBreakerState.Set(float64(breakerClosed), name)
+	return &FallbackKeyHandler[T]{
+		primary: primary,
+		name:    name,
+		ttl:     config.TTL,
+		cache:   newLRUCache(config.MaxEntries),
+		breaker: newCircuitBreaker(config.BreakerFailures, config.BreakerCooldown),
+		logger:  logger,
+	}
+}
+
+// Degraded reports whether Redis is currently skipped by the circuit breaker
+func (h *FallbackKeyHandler[T]) Degraded() bool {
+	return h.breaker.currentState() != breakerClosed
+}
+
+func (h *FallbackKeyHandler[T]) Set(ctx context.Context, tenantID string, key string, value *T, opts ...map[string]any) error {
+	return h.write(ctx, tenantID, key, value, opts, h.primary.Set)
+}
+
+func (h *FallbackKeyHandler[T]) Update(ctx context.Context, tenantID string, key string, value *T, opts ...map[string]any) error {
+	return h.write(ctx, tenantID, key, value, opts, h.primary.Update)
+}
+
+// GetOne reads from Redis and falls back to the cache when Redis fails or the circuit is open.
+// A key missing in Redis is removed from the cache, it was deleted or expired there.
+func (h *FallbackKeyHandler[T]) GetOne(ctx context.Context, tenantID string, key string) (*T, error) {
+	cacheKey := fallbackKey(tenantID, key)
+	if !h.breaker.allow() {
+		return h.fromCache(cacheKey, ErrCircuitOpen)
+	}
+
+	value, err := h.primary.GetOne(ctx, tenantID, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			h.onSuccess()
+			h.cache.delete(cacheKey)
+			return nil, err
+		}
+		h.onFailure(ctx, err)
+		return h.fromCache(cacheKey, err)
+	}
+	h.onSuccess()
+	h.store(cacheKey, value, h.ttl)
+	return value, nil
+}
+
+// GetAll is not cached, a partial list served from memory would silently drop tokens
+func (h *FallbackKeyHandler[T]) GetAll(ctx context.Context, tenantID string, userID string) ([]*T, error) {
+	if !h.breaker.allow() {
+		return nil, h.unavailable(ErrCircuitOpen)
+	}
+	values, err := h.primary.GetAll(ctx, tenantID, userID)
+	if err != nil {
+		h.onFailure(ctx, err)
+		return nil, err
+	}
+	h.onSuccess()
+	return values, nil
+}
+
+// Delete removes the key from the cache even when Redis fails, a deleted value must not be served again
+func (h *FallbackKeyHandler[T]) Delete(ctx context.Context, tenantID string, key string) error {
+	h.cache.delete(fallbackKey(tenantID, key))
+	if !h.breaker.allow() {
+		return h.unavailable(ErrCircuitOpen)
+	}
+	if err := h.primary.Delete(ctx, tenantID, key); err != nil {
+		h.onFailure(ctx, err)
+		return err
+	}
+	h.onSuccess()
+	return nil
+}
+
+func (h *FallbackKeyHandler[T]) ScanKeys(ctx context.Context, tenantID string, pattern string) ([]string, error) {
+	if !h.breaker.allow() {
+		return nil, h.unavailable(ErrCircuitOpen)
+	}
+	keys, err := h.primary.ScanKeys(ctx, tenantID, pattern)
+	if err != nil {
+		h.onFailure(ctx, err)
+		return nil, err
+	}
+	h.onSuccess()
+	return keys, nil
+}
+
+// DeleteByPattern removes the matching keys from the cache even when Redis fails
+func (h *FallbackKeyHandler[T]) DeleteByPattern(ctx context.Context, tenantID string, pattern string) (int, error) {
+	h.cache.deletePrefix(fallbackKey(tenantID, strings.TrimRight(pattern, "*")))
+	if !h.breaker.allow() {
+		return 0, h.unavailable(ErrCircuitOpen)
+	}
+	count, err := h.primary.DeleteByPattern(ctx, tenantID, pattern)
+	if err != nil {
+		h.onFailure(ctx, err)
+		return 0, err
+	}
+	h.onSuccess()
+	return count, nil
+}
+
+// write writes to Redis and caches the value on success, on failure the cached value is dropped
+// because Redis may or may not hold the new value
+func (h *FallbackKeyHandler[T]) write(ctx context.Context, tenantID string, key string, value *T, opts []map[string]any, fn func(context.Context, string, string, *T, ...map[string]any) error) error {
+	cacheKey := fallbackKey(tenantID, key)
+	if !h.breaker.allow() {
+		h.cache.delete(cacheKey)
+		return h.unavailable(ErrCircuitOpen)
+	}
+	if err := fn(ctx, tenantID, key, value, opts...); err != nil {
+		h.cache.delete(cacheKey)
+		h.onFailure(ctx, err)
+		return err
+	}
+	h.onSuccess()
+
+	ttl := h.ttl
+	if len(opts) > 0 {
+		if valueTTL, ok := opts[0]["ttl"].(time.Duration); ok && valueTTL > 0 && valueTTL < ttl {
+			ttl = valueTTL
+		}
+	}
+	h.store(cacheKey, value, ttl)
+	return nil
+}
+
+func (h *FallbackKeyHandler[T]) store(cacheKey string, value *T, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		h.logger.Warn("Failed to cache value in fallback cache", "handler", h.name, "error", err)
+		h.cache.delete(cacheKey)
+		return
+	}
+	h.cache.set(cacheKey, data, ttl)
+}
+
+// fromCache serves cacheKey from memory, cause is returned when it is not cached
+func (h *FallbackKeyHandler[T]) fromCache(cacheKey string, cause error) (*T, error) {
+	data, ok := h.cache.get(cacheKey)
+	if !ok {
+		fallbackReads.Inc(h.name, "miss")
+		return nil, h.unavailable(cause)
+	}
+	result := new(T)
+	if err := json.Unmarshal(data, result); err != nil {
+		h.cache.delete(cacheKey)
+		fallbackReads.Inc(h.name, "miss")
+		return nil, h.unavailable(cause)
+	}
+	fallbackReads.Inc(h.name, "hit")
+	h.logger.Debug("Served from fallback cache", "handler", h.name, "key", cacheKey)
+	return result, nil
+}
+
+func (h *FallbackKeyHandler[T]) unavailable(cause error) error {
+	if errors.Is(cause, ErrCircuitOpen) {
+		return infra_error.Internal(infra_error.InternalDatabaseError, cause)
+	}
+	return cause
+}
+
+func (h *FallbackKeyHandler[T]) onSuccess() {
+	if h.breaker.success() {
+		fallbackBreakerState.Set(float64(breakerClosed), h.name)
+		h.logger.Info("Redis recovered, leaving degraded mode", "handler", h.name)
+	}
+}
+
+// onFailure counts a failed call, calls cut short by the caller's context say nothing about Redis and are ignored
+func (h *FallbackKeyHandler[T]) onFailure(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		h.breaker.abandon()
+		return
+	}
+	fallbackPrimaryFailures.Inc(h.name)
+	if h.breaker.failure() {
+		fallbackBreakerTrips.Inc(h.name)
+		fallbackBreakerState.Set(float64(breakerOpen), h.name)
+		h.logger.Warn("Redis unavailable, circuit breaker opened, serving reads from fallback cache", "handler", h.name, "cooldown", h.breaker.cooldown, "error", err)
+	}
+}
+
+// FallbackCacheStats returns the hits and misses of all fallback caches, for the status report
+func FallbackCacheStats() (int64, int64, error) {
+	return int64(fallbackReads.Sum("result", "hit")), int64(fallbackReads.Sum("result", "miss")), nil
+}
+
+func fallbackKey(tenantID, key string) string {
+	return fmt.Sprintf("%s:%s", tenantID, key)
+}
@@ -0,0 +1,249 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock_redis "erp.localhost/internal/infra/db/redis/mock"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestFallbackHandler(t *testing.T) (*FallbackKeyHandler[TestModel], *mock_redis.MockKeyHandler[TestModel], *time.Time) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	primary := mock_redis.NewMockKeyHandler[TestModel](ctrl)
+	handler := NewFallbackKeyHandler[TestModel](primary, t.Name(), FallbackConfig{
+		Enabled:         true,
+		TTL:             30 * time.Second,
+		MaxEntries:      10,
+		BreakerFailures: 2,
+		BreakerCooldown: 10 * time.Second,
+	}, logger.NewBaseLogger(shared.ModuleDB))
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+	handler.cache.now = clock
+	handler.breaker.now = clock
+	return handler, primary, &now
+}
+
+func TestWithFallback(t *testing.T) {
+	primary := mock_redis.NewMockKeyHandler[TestModel](gomock.NewController(t))
+	log := logger.NewBaseLogger(shared.ModuleDB)
+
+	assert.Same(t, primary, WithFallback[TestModel](primary, "test", &RedisConfig{}, log))
+
+	wrapped := WithFallback[TestModel](primary, "test", &RedisConfig{Fallback: FallbackConfig{Enabled: true, TTL: time.Second, MaxEntries: 1, BreakerFailures: 1, BreakerCooldown: time.Second}}, log)
+	_, ok := wrapped.(*FallbackKeyHandler[TestModel])
+	assert.True(t, ok)
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.set("a", []byte("1"), time.Minute)
+	cache.set("b", []byte("2"), time.Minute)
+	_, ok := cache.get("a")
+	require.True(t, ok)
+
+	// b is the least recently used entry
+	cache.set("c", []byte("3"), time.Minute)
+	assert.Equal(t, 2, cache.len())
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+
+	cache.set("d", []byte("4"), time.Second)
+	now = now.Add(2 * time.Second)
+	_, ok = cache.get("d")
+	assert.False(t, ok)
+
+	cache.set("t1:x", []byte("5"), time.Minute)
+	cache.set("t2:x", []byte("6"), time.Minute)
+	cache.deletePrefix("t1:")
+	_, ok = cache.get("t1:x")
+	assert.False(t, ok)
+	_, ok = cache.get("t2:x")
+	assert.True(t, ok)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := newCircuitBreaker(2, 10*time.Second)
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	require.True(t, breaker.allow())
+	assert.False(t, breaker.failure())
+	assert.True(t, breaker.failure())
+	assert.Equal(t, breakerOpen, breaker.currentState())
+	assert.False(t, breaker.allow())
+
+	// A single probe is let through after the cooldown
+	now = now.Add(11 * time.Second)
+	assert.True(t, breaker.allow())
+	assert.Equal(t, breakerHalfOpen, breaker.currentState())
+	assert.False(t, breaker.allow())
+
+	// A failed probe opens the circuit again
+	assert.True(t, breaker.failure())
+	assert.False(t, breaker.allow())
+
+	now = now.Add(11 * time.Second)
+	require.True(t, breaker.allow())
+	assert.True(t, breaker.success())
+	assert.Equal(t, breakerClosed, breaker.currentState())
+	assert.False(t, breaker.success())
+}
+
+func TestFallbackKeyHandler_GetOne(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cached     bool
+		primaryErr error
+		wantValue  bool
+		wantErr    error
+	}{
+		{
+			name:      "served by redis",
+			wantValue: true,
+		},
+		{
+			name:       "redis down with cached value",
+			cached:     true,
+			primaryErr: infra_error.Internal(infra_error.InternalDatabaseError, errors.New("connection refused")),
+			wantValue:  true,
+		},
+		{
+			name:       "redis down without cached value",
+			primaryErr: infra_error.Internal(infra_error.InternalDatabaseError, errors.New("connection refused")),
+			wantErr:    infra_error.Internal(infra_error.InternalDatabaseError, errors.New("connection refused")),
+		},
+		{
+			name:       "missing in redis evicts cached value",
+			cached:     true,
+			primaryErr: infra_error.Internal(infra_error.InternalDatabaseError, redis.Nil),
+			wantErr:    redis.Nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, primary, _ := newTestFallbackHandler(t)
+			value := &TestModel{ID: "1", Name: "test"}
+			if tc.cached {
+				handler.store(fallbackKey("tenant", "key"), value, time.Minute)
+			}
+			if tc.primaryErr != nil {
+				primary.EXPECT().GetOne(gomock.Any(), "tenant", "key").Return(nil, tc.primaryErr)
+			} else {
+				primary.EXPECT().GetOne(gomock.Any(), "tenant", "key").Return(value, nil)
+			}
+
+			result, err := handler.GetOne(context.Background(), "tenant", "key")
+			if !tc.wantValue {
+				require.Error(t, err)
+				assert.Nil(t, result)
+				if errors.Is(tc.wantErr, redis.Nil) {
+					assert.ErrorIs(t, err, redis.Nil)
+					_, ok := handler.cache.get(fallbackKey("tenant", "key"))
+					assert.False(t, ok)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, value, result)
+		})
+	}
+}
+
+func TestFallbackKeyHandler_Degraded(t *testing.T) {
+	handler, primary, now := newTestFallbackHandler(t)
+	value := &TestModel{ID: "1", Name: "test"}
+	down := infra_error.Internal(infra_error.InternalDatabaseError, errors.New("connection refused"))
+
+	primary.EXPECT().Set(gomock.Any(), "tenant", "key", value).Return(nil)
+	require.NoError(t, handler.Set(context.Background(), "tenant", "key", value))
+
+	// Two failures open the circuit, Redis is not called while it is open
+	primary.EXPECT().GetOne(gomock.Any(), "tenant", "key").Return(nil, down).Times(2)
+	for range 2 {
+		result, err := handler.GetOne(context.Background(), "tenant", "key")
+		require.NoError(t, err)
+		assert.Equal(t, value, result)
+	}
+	assert.True(t, handler.Degraded())
+	assert.Equal(t, float64(breakerOpen), fallbackBreakerState.Value(t.Name()))
+
+	result, err := handler.GetOne(context.Background(), "tenant", "key")
+	require.NoError(t, err)
+	assert.Equal(t, value, result)
+
+	_, err = handler.GetOne(context.Background(), "tenant", "other")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorIs(t, handler.Set(context.Background(), "tenant", "key", value), ErrCircuitOpen)
+
+	// The failed write dropped the cached value
+	_, err = handler.GetOne(context.Background(), "tenant", "key")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// After the cooldown a probe closes the circuit again
+	*now = now.Add(11 * time.Second)
+	primary.EXPECT().GetOne(gomock.Any(), "tenant", "key").Return(value, nil)
+	_, err = handler.GetOne(context.Background(), "tenant", "key")
+	require.NoError(t, err)
+	assert.False(t, handler.Degraded())
+	assert.Equal(t, float64(breakerClosed), fallbackBreakerState.Value(t.Name()))
+	assert.Equal(t, float64(1), fallbackBreakerTrips.Value(t.Name()))
+}
+
+func TestFallbackKeyHandler_Delete(t *testing.T) {
+	handler, primary, _ := newTestFallbackHandler(t)
+	value := &TestModel{ID: "1", Name: "test"}
+	handler.store(fallbackKey("tenant", "key"), value, time.Minute)
+
+	primary.EXPECT().Delete(gomock.Any(), "tenant", "key").Return(errors.New("connection refused"))
+	require.Error(t, handler.Delete(context.Background(), "tenant", "key"))
+
+	// A deleted value is never served again, even when Redis failed to delete it
+	_, ok := handler.cache.get(fallbackKey("tenant", "key"))
+	assert.False(t, ok)
+}
+
+func TestFallbackKeyHandler_WriteTTL(t *testing.T) {
+	handler, primary, now := newTestFallbackHandler(t)
+	value := &TestModel{ID: "1", Name: "test"}
+	opts := map[string]any{"ttl": 5 * time.Second}
+
+	primary.EXPECT().Update(gomock.Any(), "tenant", "key", value, opts).Return(nil)
+	require.NoError(t, handler.Update(context.Background(), "tenant", "key", value, opts))
+	_, ok := handler.cache.get(fallbackKey("tenant", "key"))
+	require.True(t, ok)
+
+	// The value TTL is shorter than the fallback TTL
+	*now = now.Add(6 * time.Second)
+	_, ok = handler.cache.get(fallbackKey("tenant", "key"))
+	assert.False(t, ok)
+}
+
+func TestFallbackKeyHandler_Canceled(t *testing.T) {
+	handler, primary, _ := newTestFallbackHandler(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Calls given up by the caller say nothing about Redis and don't count as failures
+	primary.EXPECT().GetOne(ctx, "tenant", "key").Return(nil, ctx.Err()).Times(3)
+	for range 3 {
+		_, err := handler.GetOne(ctx, "tenant", "key")
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+	assert.False(t, handler.Degraded())
+	assert.Equal(t, breakerClosed, handler.breaker.currentState())
+}
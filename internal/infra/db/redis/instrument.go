@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/metrics"
+	"erp.localhost/internal/infra/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+var operationSeconds = metrics.NewHistogram("redis_operation_duration_seconds",
+	"Latency of Redis key operations in seconds, by operation and status.",
+	metrics.DefaultBuckets, "operation", "status")
+
+// instrument starts a span for a Redis round trip as a child of the span in ctx, the returned function ends it
+// and records the latency of the operation
+func instrument(ctx context.Context, operation string) func(err error) {
+	start := time.Now()
+	_, span := tracing.Start(ctx, "redis."+operation,
+		semconv.DBSystemRedis,
+		semconv.DBOperationName(operation),
+	)
+	return func(err error) {
+		operationSeconds.ObserveSince(start, operation, metrics.Status(err))
+		tracing.End(span, err)
+	}
+}
@@ -1,9 +1,11 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 
 	db "erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/db/memory"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	model_redis "erp.localhost/internal/infra/model/db/redis"
@@ -11,15 +13,21 @@ import (
 
 //go:generate mockgen -destination=mock/mock_key_handler.go -package=mock erp.localhost/internal/infra/db/redis KeyHandler
 type KeyHandler[T any] interface {
-	Set(tenantID string, key string, value *T, opts ...map[string]any) error
-	GetOne(tenantID string, key string) (*T, error)
-	GetAll(tenantID string, userID string) ([]*T, error)
-	Update(tenantID string, key string, value *T, opts ...map[string]any) error
-	Delete(tenantID string, key string) error
+	Set(ctx context.Context, tenantID string, key string, value *T, opts ...map[string]any) error
+	GetOne(ctx context.Context, tenantID string, key string) (*T, error)
+	GetAll(ctx context.Context, tenantID string, userID string) ([]*T, error)
+	Update(ctx context.Context, tenantID string, key string, value *T, opts ...map[string]any) error
+	Delete(ctx context.Context, tenantID string, key string) error
 	// ScanKeys scans for keys matching a pattern for a specific tenant
-	ScanKeys(tenantID string, pattern string) ([]string, error)
+	ScanKeys(ctx context.Context, tenantID string, pattern string) ([]string, error)
 	// DeleteByPattern deletes all keys matching a pattern for a specific tenant
-	DeleteByPattern(tenantID string, pattern string) (int, error)
+	DeleteByPattern(ctx context.Context, tenantID string, pattern string) (int, error)
+}
+
+// keyScanner scans and deletes the keys matching a pattern, BaseRedisHandler or memory.Keys
+type keyScanner interface {
+	Scan(ctx context.Context, pattern string, batchSize int64) ([]string, error)
+	DeleteByPattern(ctx context.Context, pattern string) (int, error)
 }
 
 type BaseKeyHandler[T any] struct {
@@ -27,8 +35,20 @@ type BaseKeyHandler[T any] struct {
 	logger    logger.Logger
 }
 
+// NewBaseKeyHandler creates a key handler connected with the configuration from the environment
 func NewBaseKeyHandler[T any](keyPrefix model_redis.KeyPrefix, logger logger.Logger) (*BaseKeyHandler[T], error) {
-	dbHandler, err := NewBaseRedisHandler(keyPrefix, logger)
+	return NewBaseKeyHandlerWithConfig[T](LoadRedisConfig(), keyPrefix, logger)
+}
+
+// NewBaseKeyHandlerWithConfig creates a key handler connected with config
+func NewBaseKeyHandlerWithConfig[T any](config *RedisConfig, keyPrefix model_redis.KeyPrefix, logger logger.Logger) (*BaseKeyHandler[T], error) {
+	if memory.Enabled() {
+		return &BaseKeyHandler[T]{
+			dbHandler: memory.SharedKeys(string(keyPrefix)),
+			logger:    logger,
+		}, nil
+	}
+	dbHandler, err := NewBaseRedisHandlerWithConfig(config, keyPrefix, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -38,21 +58,34 @@ func NewBaseKeyHandler[T any](keyPrefix model_redis.KeyPrefix, logger logger.Log
 	}, nil
 }
 
-func (k *BaseKeyHandler[T]) Set(tenantID string, key string, value *T, opts ...map[string]any) error {
+// NewInMemoryKeyHandler creates a key handler on a keyspace of its own kept in memory, for the tests without a
+// Redis server
+func NewInMemoryKeyHandler[T any](keyPrefix model_redis.KeyPrefix, logger logger.Logger) *BaseKeyHandler[T] {
+	return &BaseKeyHandler[T]{
+		dbHandler: memory.NewKeys(string(keyPrefix)),
+		logger:    logger,
+	}
+}
+
+func (k *BaseKeyHandler[T]) Set(ctx context.Context, tenantID string, key string, value *T, opts ...map[string]any) error {
 	k.logger.Debug("Setting key", "tenantID", tenantID, "key", key, "value", value)
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
-	_, err := k.dbHandler.Create(formattedKey, value, opts...)
+	done := instrument(ctx, "set")
+	_, err := k.dbHandler.Create(ctx, formattedKey, value, opts...)
+	done(err)
 	if err != nil {
 		return infra_error.Internal(infra_error.InternalDatabaseError, err)
 	}
 	return nil
 }
 
-func (k *BaseKeyHandler[T]) GetOne(tenantID string, key string) (*T, error) {
+func (k *BaseKeyHandler[T]) GetOne(ctx context.Context, tenantID string, key string) (*T, error) {
 	k.logger.Debug("Getting key", "tenantID", tenantID, "key", key)
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
 	result := new(T) // create a non-nil pointer for type T
-	err := k.dbHandler.FindOne(formattedKey, nil, result)
+	done := instrument(ctx, "get")
+	err := k.dbHandler.FindOne(ctx, formattedKey, nil, result)
+	done(err)
 	if err != nil {
 		return nil, infra_error.Internal(infra_error.InternalDatabaseError, err)
 	}
@@ -64,31 +97,37 @@ func (k *BaseKeyHandler[T]) GetOne(tenantID string, key string) (*T, error) {
 	return result, nil
 }
 
-func (k *BaseKeyHandler[T]) GetAll(tenantID string, userID string) ([]*T, error) {
+func (k *BaseKeyHandler[T]) GetAll(ctx context.Context, tenantID string, userID string) ([]*T, error) {
 	k.logger.Debug("Getting key", "tenantID", tenantID, "userID", userID)
 	result := make([]*T, 0)
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, userID)
-	err := k.dbHandler.FindAll(formattedKey, nil, &result)
+	done := instrument(ctx, "get_all")
+	err := k.dbHandler.FindAll(ctx, formattedKey, nil, &result)
+	done(err)
 	if err != nil {
 		return nil, infra_error.Internal(infra_error.InternalDatabaseError, err)
 	}
 	return result, nil
 }
 
-func (k *BaseKeyHandler[T]) Update(tenantID string, key string, value *T, opts ...map[string]any) error {
+func (k *BaseKeyHandler[T]) Update(ctx context.Context, tenantID string, key string, value *T, opts ...map[string]any) error {
 	k.logger.Debug("Updating key", "tenantID", tenantID, "key", key, "value", value)
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
-	err := k.dbHandler.Update(formattedKey, nil, value, opts...)
+	done := instrument(ctx, "update")
+	err := k.dbHandler.Update(ctx, formattedKey, nil, value, opts...)
+	done(err)
 	if err != nil {
 		return infra_error.Internal(infra_error.InternalDatabaseError, err)
 	}
 	return nil
 }
 
-func (k *BaseKeyHandler[T]) Delete(tenantID string, key string) error {
+func (k *BaseKeyHandler[T]) Delete(ctx context.Context, tenantID string, key string) error {
 	k.logger.Debug("Deleting key", "tenantID", tenantID, "key", key)
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
-	err := k.dbHandler.Delete(formattedKey, nil)
+	done := instrument(ctx, "delete")
+	err := k.dbHandler.Delete(ctx, formattedKey, nil)
+	done(err)
 	if err != nil {
 		return infra_error.Internal(infra_error.InternalDatabaseError, err)
 	}
@@ -97,18 +136,21 @@ func (k *BaseKeyHandler[T]) Delete(tenantID string, key string) error {
 
 // ScanKeys scans for keys matching a pattern for a specific tenant
 // Pattern is relative to tenant (e.g., "*" for all keys in tenant, "user-123" for specific user)
-func (k *BaseKeyHandler[T]) ScanKeys(tenantID string, pattern string) ([]string, error) {
+func (k *BaseKeyHandler[T]) ScanKeys(ctx context.Context, tenantID string, pattern string) ([]string, error) {
 	k.logger.Debug("Scanning keys", "tenantID", tenantID, "pattern", pattern)
 
-	// Type assert to get BaseRedisHandler
-	redisHandler, ok := k.dbHandler.(*BaseRedisHandler)
+	// Type assert to get BaseRedisHandler, or the in-memory keys
+	redisHandler, ok := k.dbHandler.(keyScanner)
 	if !ok {
 		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("dbHandler is not a BaseRedisHandler"))
 	}
 
 	// Build full pattern: tenant_id:pattern
 	fullPattern := fmt.Sprintf("%s:%s", tenantID, pattern)
-	keys, err := redisHandler.Scan(fullPattern, 100)
+	done := instrument(ctx, "scan")
+	// The COUNT hint of the handler configuration
+	keys, err := redisHandler.Scan(ctx, fullPattern, 0)
+	done(err)
 	if err != nil {
 		return nil, err
 	}
@@ -119,18 +161,20 @@ func (k *BaseKeyHandler[T]) ScanKeys(tenantID string, pattern string) ([]string,
 
 // DeleteByPattern deletes all keys matching a pattern for a specific tenant
 // Returns the number of keys deleted
-func (k *BaseKeyHandler[T]) DeleteByPattern(tenantID string, pattern string) (int, error) {
+func (k *BaseKeyHandler[T]) DeleteByPattern(ctx context.Context, tenantID string, pattern string) (int, error) {
 	k.logger.Debug("Deleting keys by pattern", "tenantID", tenantID, "pattern", pattern)
 
-	// Type assert to get BaseRedisHandler
-	redisHandler, ok := k.dbHandler.(*BaseRedisHandler)
+	// Type assert to get BaseRedisHandler, or the in-memory keys
+	redisHandler, ok := k.dbHandler.(keyScanner)
 	if !ok {
 		return 0, infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("dbHandler is not a BaseRedisHandler"))
 	}
 
 	// Build full pattern: tenant_id:pattern
 	fullPattern := fmt.Sprintf("%s:%s*", tenantID, pattern)
-	count, err := redisHandler.DeleteByPattern(fullPattern)
+	done := instrument(ctx, "delete_by_pattern")
+	count, err := redisHandler.DeleteByPattern(ctx, fullPattern)
+	done(err)
 	if err != nil {
 		return 0, err
 	}
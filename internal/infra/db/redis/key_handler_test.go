@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -62,9 +63,9 @@ func TestKeyHandler_Set(t *testing.T) {
 			defer ctrl.Finish()
 			mockHandler := mock_db.NewMockDBHandler(ctrl)
 			formattedKey := fmt.Sprintf("%s:%s", tc.tenantID, tc.key)
-			mockHandler.EXPECT().Create(formattedKey, tc.value).Return(tc.returnID, tc.returnError).Times(tc.expectedCallTimes)
+			mockHandler.EXPECT().Create(gomock.Any(), formattedKey, tc.value).Return(tc.returnID, tc.returnError).Times(tc.expectedCallTimes)
 			handler := createNewHandler(mockHandler)
-			err := handler.Set(tc.tenantID, tc.key, tc.value)
+			err := handler.Set(context.Background(), tc.tenantID, tc.key, tc.value)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -112,8 +113,8 @@ func TestKeyHandler_GetOne(t *testing.T) {
 			formattedKey := fmt.Sprintf("%s:%s", tc.tenantID, tc.key)
 			model := &TestModel{}
 			mockHandler.EXPECT().
-				FindOne(formattedKey, nil, model).
-				DoAndReturn(func(formattedKey string, filter map[string]any, result any) error {
+				FindOne(gomock.Any(), formattedKey, nil, model).
+				DoAndReturn(func(_ context.Context, formattedKey string, filter map[string]any, result any) error {
 					// Cast result to the correct type and set its value
 					if m, ok := result.(*TestModel); ok {
 						*m = tc.returnData
@@ -123,7 +124,7 @@ func TestKeyHandler_GetOne(t *testing.T) {
 
 			handler := createNewHandler(mockHandler)
 
-			result, err := handler.GetOne(tc.tenantID, tc.key)
+			result, err := handler.GetOne(context.Background(), tc.tenantID, tc.key)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -189,8 +190,8 @@ func TestKeyHandler_GetAll(t *testing.T) {
 
 			models := make([]*TestModel, 0)
 			mockHandler.EXPECT().
-				FindAll(formattedKey, nil, &models).
-				DoAndReturn(func(formattedKey string, filter map[string]any, result any) error {
+				FindAll(gomock.Any(), formattedKey, nil, &models).
+				DoAndReturn(func(_ context.Context, formattedKey string, filter map[string]any, result any) error {
 					if m, ok := result.(*[]*TestModel); ok {
 						*m = make([]*TestModel, len(tc.returnData))
 						for i, item := range tc.returnData {
@@ -202,7 +203,7 @@ func TestKeyHandler_GetAll(t *testing.T) {
 
 			handler := createNewHandler(mockHandler)
 
-			result, err := handler.GetAll(tc.tenantID, tc.key)
+			result, err := handler.GetAll(context.Background(), tc.tenantID, tc.key)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -246,10 +247,10 @@ func TestKeyHandler_Update(t *testing.T) {
 			defer ctrl.Finish()
 			mockHandler := mock_db.NewMockDBHandler(ctrl)
 			formattedKey := fmt.Sprintf("%s:%s", tc.tenantID, tc.key)
-			mockHandler.EXPECT().Update(formattedKey, nil, tc.value).Return(tc.returnError).Times(tc.expectedCallTimes)
+			mockHandler.EXPECT().Update(gomock.Any(), formattedKey, nil, tc.value).Return(tc.returnError).Times(tc.expectedCallTimes)
 			handler := createNewHandler(mockHandler)
 
-			err := handler.Update(tc.tenantID, tc.key, tc.value)
+			err := handler.Update(context.Background(), tc.tenantID, tc.key, tc.value)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -289,10 +290,10 @@ func TestKeyHandler_Delete(t *testing.T) {
 			defer ctrl.Finish()
 			mockHandler := mock_db.NewMockDBHandler(ctrl)
 			formattedKey := fmt.Sprintf("%s:%s", tc.tenantID, tc.key)
-			mockHandler.EXPECT().Delete(formattedKey, nil).Return(tc.returnError).Times(tc.expectedCallTimes)
+			mockHandler.EXPECT().Delete(gomock.Any(), formattedKey, nil).Return(tc.returnError).Times(tc.expectedCallTimes)
 			handler := createNewHandler(mockHandler)
 
-			err := handler.Delete(tc.tenantID, tc.key)
+			err := handler.Delete(context.Background(), tc.tenantID, tc.key)
 			if tc.returnError != nil {
 				require.Error(t, err)
 			} else {
@@ -301,3 +302,24 @@ func TestKeyHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestInMemoryKeyHandler(t *testing.T) {
+	ctx := context.Background()
+	handler := NewInMemoryKeyHandler[TestModel]("tokens", logger.NewBaseLogger(shared.ModuleDB))
+
+	require.NoError(t, handler.Set(ctx, "tenant-1", "user-1", &TestModel{ID: "1", Name: "first"}))
+	require.NoError(t, handler.Set(ctx, "tenant-1", "user-2", &TestModel{ID: "2", Name: "second"}))
+	stored, err := handler.GetOne(ctx, "tenant-1", "user-1")
+	require.NoError(t, err)
+	require.Equal(t, "first", stored.Name)
+
+	keys, err := handler.ScanKeys(ctx, "tenant-1", "*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tokens:tenant-1:user-1", "tokens:tenant-1:user-2"}, keys)
+
+	deleted, err := handler.DeleteByPattern(ctx, "tenant-1", "user-")
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	_, err = handler.GetOne(ctx, "tenant-1", "user-1")
+	require.Error(t, err)
+}
@@ -10,6 +10,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -41,37 +42,37 @@ func (m *MockRedisHandler) EXPECT() *MockRedisHandlerMockRecorder {
 }
 
 // Clear mocks base method.
-func (m *MockRedisHandler) Clear(key string) error {
+func (m *MockRedisHandler) Clear(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Clear", key)
+	ret := m.ctrl.Call(m, "Clear", ctx, key)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Clear indicates an expected call of Clear.
-func (mr *MockRedisHandlerMockRecorder) Clear(key any) *gomock.Call {
+func (mr *MockRedisHandlerMockRecorder) Clear(ctx, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockRedisHandler)(nil).Clear), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockRedisHandler)(nil).Clear), ctx, key)
 }
 
 // Expire mocks base method.
-func (m *MockRedisHandler) Expire(key string, ttl int, unit time.Duration) error {
+func (m *MockRedisHandler) Expire(ctx context.Context, key string, ttl int, unit time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Expire", key, ttl, unit)
+	ret := m.ctrl.Call(m, "Expire", ctx, key, ttl, unit)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Expire indicates an expected call of Expire.
-func (mr *MockRedisHandlerMockRecorder) Expire(key, ttl, unit any) *gomock.Call {
+func (mr *MockRedisHandlerMockRecorder) Expire(ctx, key, ttl, unit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockRedisHandler)(nil).Expire), key, ttl, unit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockRedisHandler)(nil).Expire), ctx, key, ttl, unit)
 }
 
 // SAdd mocks base method.
-func (m *MockRedisHandler) SAdd(key string, members ...any) error {
+func (m *MockRedisHandler) SAdd(ctx context.Context, key string, members ...any) error {
 	m.ctrl.T.Helper()
-	varargs := []any{key}
+	varargs := []any{ctx, key}
 	for _, a := range members {
 		varargs = append(varargs, a)
 	}
@@ -81,31 +82,31 @@ func (m *MockRedisHandler) SAdd(key string, members ...any) error {
 }
 
 // SAdd indicates an expected call of SAdd.
-func (mr *MockRedisHandlerMockRecorder) SAdd(key any, members ...any) *gomock.Call {
+func (mr *MockRedisHandlerMockRecorder) SAdd(ctx, key any, members ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{key}, members...)
+	varargs := append([]any{ctx, key}, members...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SAdd", reflect.TypeOf((*MockRedisHandler)(nil).SAdd), varargs...)
 }
 
 // SMembers mocks base method.
-func (m *MockRedisHandler) SMembers(key string) ([]string, error) {
+func (m *MockRedisHandler) SMembers(ctx context.Context, key string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SMembers", key)
+	ret := m.ctrl.Call(m, "SMembers", ctx, key)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SMembers indicates an expected call of SMembers.
-func (mr *MockRedisHandlerMockRecorder) SMembers(key any) *gomock.Call {
+func (mr *MockRedisHandlerMockRecorder) SMembers(ctx, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembers", reflect.TypeOf((*MockRedisHandler)(nil).SMembers), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembers", reflect.TypeOf((*MockRedisHandler)(nil).SMembers), ctx, key)
 }
 
 // SRem mocks base method.
-func (m *MockRedisHandler) SRem(key string, members ...any) error {
+func (m *MockRedisHandler) SRem(ctx context.Context, key string, members ...any) error {
 	m.ctrl.T.Helper()
-	varargs := []any{key}
+	varargs := []any{ctx, key}
 	for _, a := range members {
 		varargs = append(varargs, a)
 	}
@@ -115,8 +116,8 @@ func (m *MockRedisHandler) SRem(key string, members ...any) error {
 }
 
 // SRem indicates an expected call of SRem.
-func (mr *MockRedisHandlerMockRecorder) SRem(key any, members ...any) *gomock.Call {
+func (mr *MockRedisHandlerMockRecorder) SRem(ctx, key any, members ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{key}, members...)
+	varargs := append([]any{ctx, key}, members...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SRem", reflect.TypeOf((*MockRedisHandler)(nil).SRem), varargs...)
 }
@@ -10,6 +10,7 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,9 +41,9 @@ func (m *MockSetHandler) EXPECT() *MockSetHandlerMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockSetHandler) Add(tenantID, key, member string, opts ...map[string]any) error {
+func (m *MockSetHandler) Add(ctx context.Context, tenantID, key, member string, opts ...map[string]any) error {
 	m.ctrl.T.Helper()
-	varargs := []any{tenantID, key, member}
+	varargs := []any{ctx, tenantID, key, member}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
@@ -52,51 +53,51 @@ func (m *MockSetHandler) Add(tenantID, key, member string, opts ...map[string]an
 }
 
 // Add indicates an expected call of Add.
-func (mr *MockSetHandlerMockRecorder) Add(tenantID, key, member any, opts ...any) *gomock.Call {
+func (mr *MockSetHandlerMockRecorder) Add(ctx, tenantID, key, member any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{tenantID, key, member}, opts...)
+	varargs := append([]any{ctx, tenantID, key, member}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockSetHandler)(nil).Add), varargs...)
 }
 
 // Clear mocks base method.
-func (m *MockSetHandler) Clear(tenantID, key string) error {
+func (m *MockSetHandler) Clear(ctx context.Context, tenantID, key string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Clear", tenantID, key)
+	ret := m.ctrl.Call(m, "Clear", ctx, tenantID, key)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Clear indicates an expected call of Clear.
-func (mr *MockSetHandlerMockRecorder) Clear(tenantID, key any) *gomock.Call {
+func (mr *MockSetHandlerMockRecorder) Clear(ctx, tenantID, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockSetHandler)(nil).Clear), tenantID, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockSetHandler)(nil).Clear), ctx, tenantID, key)
 }
 
 // Members mocks base method.
-func (m *MockSetHandler) Members(tenantID, key string) ([]string, error) {
+func (m *MockSetHandler) Members(ctx context.Context, tenantID, key string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Members", tenantID, key)
+	ret := m.ctrl.Call(m, "Members", ctx, tenantID, key)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Members indicates an expected call of Members.
-func (mr *MockSetHandlerMockRecorder) Members(tenantID, key any) *gomock.Call {
+func (mr *MockSetHandlerMockRecorder) Members(ctx, tenantID, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockSetHandler)(nil).Members), tenantID, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockSetHandler)(nil).Members), ctx, tenantID, key)
 }
 
 // Remove mocks base method.
-func (m *MockSetHandler) Remove(tenantID, key, member string) error {
+func (m *MockSetHandler) Remove(ctx context.Context, tenantID, key, member string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Remove", tenantID, key, member)
+	ret := m.ctrl.Call(m, "Remove", ctx, tenantID, key, member)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Remove indicates an expected call of Remove.
-func (mr *MockSetHandlerMockRecorder) Remove(tenantID, key, member any) *gomock.Call {
+func (mr *MockSetHandlerMockRecorder) Remove(ctx, tenantID, key, member any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockSetHandler)(nil).Remove), tenantID, key, member)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockSetHandler)(nil).Remove), ctx, tenantID, key, member)
 }
@@ -3,8 +3,13 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	infra_error "erp.localhost/internal/infra/error"
@@ -15,11 +20,11 @@ import (
 
 //go:generate mockgen -destination=mock/mock_redis_handler.go -package=mock erp.localhost/internal/infra/db/redis RedisHandler
 type RedisHandler interface {
-	SAdd(key string, members ...any) error
-	SRem(key string, members ...any) error
-	SMembers(key string) ([]string, error)
-	Expire(key string, ttl int, unit time.Duration) error
-	Clear(key string) error
+	SAdd(ctx context.Context, key string, members ...any) error
+	SRem(ctx context.Context, key string, members ...any) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	Expire(ctx context.Context, key string, ttl int, unit time.Duration) error
+	Clear(ctx context.Context, key string) error
 }
 
 var (
@@ -27,47 +32,213 @@ var (
 )
 
 type BaseRedisHandler struct {
-	client    *redis.Client
+	// client is a standalone, sentinel (failover) or cluster client, shared by the handlers with the same config
+	client    redis.UniversalClient
+	release   func() error
 	logger    logger.Logger
 	keyPrefix model_redis.KeyPrefix
+	// scanCount and batchSize are the ScanCount and BatchSize of the config, the defaults when zero
+	scanCount int64
+	batchSize int
 }
 
+// NewBaseRedisHandler creates a handler connected with the configuration from the environment
 func NewBaseRedisHandler(keyPrefix model_redis.KeyPrefix, logger logger.Logger) (*BaseRedisHandler, error) {
+	return NewBaseRedisHandlerWithConfig(LoadRedisConfig(), keyPrefix, logger)
+}
+
+// NewBaseRedisHandlerWithConfig creates a handler connected with config
+func NewBaseRedisHandlerWithConfig(config *RedisConfig, keyPrefix model_redis.KeyPrefix, logger logger.Logger) (*BaseRedisHandler, error) {
 	redisHandler := &BaseRedisHandler{
 		logger:    logger,
 		keyPrefix: keyPrefix,
 	}
-	if err := redisHandler.init(); err != nil {
+	if err := redisHandler.init(config); err != nil {
 		redisHandler.logger.Error("Failed to initialize Redis", "error", err)
 		return nil, err
 	}
 	return redisHandler, nil
 }
 
-func (r *BaseRedisHandler) init() error {
-	uri := "redis://:supersecretredis@localhost:6379"
-	options, err := redis.ParseURL(uri)
+func (r *BaseRedisHandler) init(config *RedisConfig) error {
+	client, release, err := acquireClient(config)
 	if err != nil {
 		return err
 	}
-
-	client := redis.NewClient(options)
 	if err := client.Ping(redisContext).Err(); err != nil {
-		return err
+		if !config.Fallback.Enabled {
+			_ = release()
+			return err
+		}
+		// go-redis reconnects on demand, reads are served from the fallback cache until then
+		r.logger.Warn("Redis unavailable at startup, starting in degraded mode", "addrs", config.Addrs, "error", err)
 	}
 	r.client = client
+	r.release = release
+	r.scanCount = int64(config.ScanCount)
+	r.batchSize = config.BatchSize
+	r.logger.Debug("Redis client ready", "mode", config.Mode, "addrs", config.Addrs, "db", config.DB, "tls", config.TLS.Enabled)
 
 	return nil
 }
 
+// Close releases the handler's use of the shared client, the connections close with the last handler
 func (r *BaseRedisHandler) Close() error {
-	return r.client.Close()
+	return r.release()
+}
+
+// forEachServer runs fn on the server, or on every master of a cluster
+func (r *BaseRedisHandler) forEachServer(ctx context.Context, fn func(server redis.Cmdable) error) error {
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+			return fn(master)
+		})
+	}
+	return fn(r.client)
+}
+
+func (r *BaseRedisHandler) scanCountOrDefault() int64 {
+	if r.scanCount > 0 {
+		return r.scanCount
+	}
+	return defaultScanCount
+}
+
+func (r *BaseRedisHandler) batchSizeOrDefault() int {
+	if r.batchSize > 0 {
+		return r.batchSize
+	}
+	return defaultBatchSize
+}
+
+// scanPages calls fn with each page of keys matching fullPattern returned by SCAN, on a cluster every master is
+// scanned and fn is called from one goroutine per master. SCAN may return a key more than once
+func (r *BaseRedisHandler) scanPages(ctx context.Context, fullPattern string, count int64, fn func(keys []string) error) error {
+	return r.forEachServer(ctx, func(server redis.Cmdable) error {
+		var cursor uint64
+		for {
+			keys, nextCursor, err := server.Scan(ctx, cursor, fullPattern, count).Result()
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				if err := fn(keys); err != nil {
+					return err
+				}
+			}
+
+			// Cursor returns to 0 when iteration is complete
+			cursor = nextCursor
+			if cursor == 0 {
+				return nil
+			}
+		}
+	})
+}
+
+// scanKeys returns the keys matching fullPattern, on a cluster every master is scanned
+func (r *BaseRedisHandler) scanKeys(ctx context.Context, fullPattern string, count int64) ([]string, error) {
+	var mu sync.Mutex
+	allKeys := []string{}
+	err := r.scanPages(ctx, fullPattern, count, func(keys []string) error {
+		mu.Lock()
+		allKeys = append(allKeys, keys...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allKeys, nil
 }
 
-func (r *BaseRedisHandler) Create(key string, value any, opts ...map[string]any) (string, error) {
+// getMany returns the values of keys, nil for missing keys and keys that are not strings. The keys are read by
+// batches of at most batchSize keys, one round trip each
+func (r *BaseRedisHandler) getMany(ctx context.Context, keys []string) ([]any, error) {
+	values := make([]any, 0, len(keys))
+	for batch := range slices.Chunk(keys, r.batchSizeOrDefault()) {
+		batchValues, err := r.getBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, batchValues...)
+	}
+	return values, nil
+}
+
+// getBatch reads keys with one MGET. Keys of a cluster live in different hash slots, so they are read with a
+// pipeline of GET instead
+func (r *BaseRedisHandler) getBatch(ctx context.Context, keys []string) ([]any, error) {
+	if _, ok := r.client.(*redis.ClusterClient); !ok {
+		return r.client.MGet(ctx, keys...).Result()
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) && !isWrongType(err) {
+		return nil, err
+	}
+	values := make([]any, len(keys))
+	for i, cmd := range cmds {
+		if value, err := cmd.Result(); err == nil {
+			values[i] = value
+		}
+	}
+	return values, nil
+}
+
+// isWrongType reports whether err is a command run on a key holding another type, e.g. GET on a set
+func isWrongType(err error) bool {
+	return strings.HasPrefix(err.Error(), "WRONGTYPE")
+}
+
+// Ping round trips to the server and returns the latency
+func (r *BaseRedisHandler) Ping() (time.Duration, error) {
+	start := time.Now()
+	if err := r.client.Ping(redisContext).Err(); err != nil {
+		return time.Since(start), infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+	return time.Since(start), nil
+}
+
+// CacheStats returns the server wide keyspace hits and misses since the server started, summed over the masters of a cluster
+func (r *BaseRedisHandler) CacheStats() (int64, int64, error) {
+	var mu sync.Mutex
+	var hits, misses int64
+	err := r.forEachServer(redisContext, func(server redis.Cmdable) error {
+		info, err := server.Info(redisContext, "stats").Result()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, line := range strings.Split(info, "\n") {
+			key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+			if !found {
+				continue
+			}
+			count, _ := strconv.ParseInt(value, 10, 64)
+			switch key {
+			case "keyspace_hits":
+				hits += count
+			case "keyspace_misses":
+				misses += count
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+	return hits, misses, nil
+}
+
+func (r *BaseRedisHandler) Create(ctx context.Context, key string, value any, opts ...map[string]any) (string, error) {
 	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
 
-	exists, err := r.client.Exists(redisContext, key).Result()
+	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
 		return "", infra_error.Internal(infra_error.InternalDatabaseError, err)
 	}
@@ -80,22 +251,27 @@ func (r *BaseRedisHandler) Create(key string, value any, opts ...map[string]any)
 		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
 	}
 
-	result := r.client.Set(redisContext, formattedKey, valueBytes, 0)
+	result := r.client.Set(ctx, formattedKey, valueBytes, 0)
 	if result.Err() != nil {
 		return "", result.Err()
 	}
 	// Handle TTL if provided
 	if len(opts) > 0 {
 		if ttl, ok := opts[0]["ttl"].(time.Duration); ok && ttl > 0 {
-			r.Expire(key, int(ttl.Seconds()), time.Second)
+			r.Expire(ctx, key, int(ttl.Seconds()), time.Second)
 		}
 	}
 	return result.Val(), nil
 }
 
-func (r *BaseRedisHandler) FindOne(key string, filter map[string]any, result any) error {
+// IsNotFound reports whether err is returned for a missing key
+func IsNotFound(err error) bool {
+	return errors.Is(err, redis.Nil)
+}
+
+func (r *BaseRedisHandler) FindOne(ctx context.Context, key string, filter map[string]any, result any) error {
 	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	value, err := r.client.Get(redisContext, formattedKey).Bytes()
+	value, err := r.client.Get(ctx, formattedKey).Bytes()
 	if err != nil {
 		return err
 	}
@@ -106,7 +282,7 @@ func (r *BaseRedisHandler) FindOne(key string, filter map[string]any, result any
 	return nil
 }
 
-func (r *BaseRedisHandler) FindAll(key string, filter map[string]any, result any) error {
+func (r *BaseRedisHandler) FindAll(ctx context.Context, key string, filter map[string]any, result any) error {
 	formattedKey := fmt.Sprintf("%s:%s*", r.keyPrefix, key)
 
 	resultVal := reflect.ValueOf(result)
@@ -118,131 +294,125 @@ func (r *BaseRedisHandler) FindAll(key string, filter map[string]any, result any
 		return fmt.Errorf("result must be a pointer to a slice of pointers (e.g. *[]*T)")
 	}
 
-	// 1️⃣ SCAN keys
-	var cursor uint64
-	keys := make([]string, 0)
-
-	for {
-		batch, nextCursor, err := r.client.Scan(
-			redisContext,
-			cursor,
-			formattedKey,
-			100,
-		).Result()
-		if err != nil {
-			return err
-		}
-
-		keys = append(keys, batch...)
-
-		cursor = nextCursor
-		if cursor == 0 {
-			break
-		}
-	}
-
-	// No keys found → return empty slice
-	if len(keys) == 0 {
-		return nil
-	}
-
-	// 2️⃣ MGET values
-	vals, err := r.client.MGet(redisContext, keys...).Result()
-	if err != nil {
-		return err
-	}
-
 	sliceVal := resultVal.Elem()
 	elemType := sliceVal.Type().Elem().Elem() // T
 
-	// 3️⃣ Unmarshal + append
-	for i := range keys {
-		if vals[i] == nil {
-			continue // expired between SCAN and MGET
+	// The values of each SCAN page are read while the scan goes on, so the keys of a user with many sessions are
+	// never all held at once nor read with one large MGET
+	var mu sync.Mutex
+	seen := map[string]struct{}{}
+	return r.scanPages(ctx, formattedKey, r.scanCountOrDefault(), func(page []string) error {
+		mu.Lock()
+		keys := make([]string, 0, len(page))
+		for _, key := range page {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
 		}
-
-		var data []byte
-		switch v := vals[i].(type) {
-		case string:
-			data = []byte(v)
-		case []byte:
-			data = v
-		default:
-			continue
+		mu.Unlock()
+		if len(keys) == 0 {
+			return nil
 		}
 
-		newElem := reflect.New(elemType) // *T
-		if err := json.Unmarshal(data, newElem.Interface()); err != nil {
+		vals, err := r.getMany(ctx, keys)
+		if err != nil {
 			return err
 		}
 
-		sliceVal.Set(reflect.Append(sliceVal, newElem))
-	}
+		elems := make([]reflect.Value, 0, len(vals))
+		for _, val := range vals {
+			var data []byte
+			switch v := val.(type) {
+			case string:
+				data = []byte(v)
+			case []byte:
+				data = v
+			default:
+				continue // expired between SCAN and MGET, or not a string
+			}
+
+			newElem := reflect.New(elemType) // *T
+			if err := json.Unmarshal(data, newElem.Interface()); err != nil {
+				return err
+			}
+			elems = append(elems, newElem)
+		}
 
-	return nil
+		mu.Lock()
+		sliceVal.Set(reflect.Append(sliceVal, elems...))
+		mu.Unlock()
+		return nil
+	})
 }
 
-func (r *BaseRedisHandler) Update(key string, filter map[string]any, value any, opts ...map[string]any) error {
-	_, err := r.Create(key, value)
+func (r *BaseRedisHandler) Update(ctx context.Context, key string, filter map[string]any, value any, opts ...map[string]any) error {
+	_, err := r.Create(ctx, key, value)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *BaseRedisHandler) Delete(key string, filter map[string]any) error {
+func (r *BaseRedisHandler) Delete(ctx context.Context, key string, filter map[string]any) error {
+	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
+	return r.client.Del(ctx, formattedKey).Err()
+}
+
+func (r *BaseRedisHandler) SAdd(ctx context.Context, key string, members ...any) error {
 	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	return r.client.Del(redisContext, formattedKey).Err()
+	return r.client.SAdd(ctx, formattedKey, members...).Err()
 }
 
-func (r *BaseRedisHandler) SAdd(key string, members ...any) error {
+func (r *BaseRedisHandler) SRem(ctx context.Context, key string, members ...any) error {
 	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	return r.client.SAdd(redisContext, formattedKey, members...).Err()
+	return r.client.SRem(ctx, formattedKey, members...).Err()
 }
 
-func (r *BaseRedisHandler) SRem(key string, members ...any) error {
+func (r *BaseRedisHandler) Expire(ctx context.Context, key string, ttl int, unit time.Duration) error {
 	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	return r.client.SRem(redisContext, formattedKey, members...).Err()
+	return r.client.Expire(ctx, formattedKey, time.Duration(ttl)*unit).Err()
 }
 
-func (r *BaseRedisHandler) Expire(key string, ttl int, unit time.Duration) error {
+func (r *BaseRedisHandler) SMembers(ctx context.Context, key string) ([]string, error) {
 	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	return r.client.Expire(redisContext, formattedKey, time.Duration(ttl)*unit).Err()
+	return r.client.SMembers(ctx, formattedKey).Result()
 }
 
-func (r *BaseRedisHandler) SMembers(key string) ([]string, error) {
+// XAdd appends an entry of values to the stream of key and returns its id, the stream is trimmed to about
+// maxLen entries when maxLen is positive
+func (r *BaseRedisHandler) XAdd(ctx context.Context, key string, maxLen int64, values map[string]any) (string, error) {
 	formattedKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	return r.client.SMembers(redisContext, formattedKey).Result()
+	id, err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: formattedKey,
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: values,
+	}).Result()
+	if err != nil {
+		return "", infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+	return id, nil
 }
 
-func (r *BaseRedisHandler) Clear(key string) error {
-	return r.Delete(key, nil)
+func (r *BaseRedisHandler) Clear(ctx context.Context, key string) error {
+	return r.Delete(ctx, key, nil)
 }
 
 // Scan scans for keys matching a pattern
-// Returns keys in batches to avoid blocking Redis
+// Returns keys in batches to avoid blocking Redis, batchSize is the COUNT hint and the configured ScanCount when zero
 // Pattern should include the key prefix (e.g., "tokens:tenant-123:*")
-func (r *BaseRedisHandler) Scan(pattern string, batchSize int64) ([]string, error) {
-	var allKeys []string
-	var cursor uint64 = 0
-
+func (r *BaseRedisHandler) Scan(ctx context.Context, pattern string, batchSize int64) ([]string, error) {
 	// Format pattern with key prefix if not already included
 	fullPattern := fmt.Sprintf("%s:%s", r.keyPrefix, pattern)
+	if batchSize <= 0 {
+		batchSize = r.scanCountOrDefault()
+	}
 
-	for {
-		keys, nextCursor, err := r.client.Scan(redisContext, cursor, fullPattern, batchSize).Result()
-		if err != nil {
-			r.logger.Error("Failed to scan Redis keys", "error", err, "pattern", fullPattern)
-			return nil, infra_error.Internal(infra_error.InternalDatabaseError, err)
-		}
-
-		allKeys = append(allKeys, keys...)
-		cursor = nextCursor
-
-		// Cursor returns to 0 when iteration is complete
-		if cursor == 0 {
-			break
-		}
+	allKeys, err := r.scanKeys(ctx, fullPattern, batchSize)
+	if err != nil {
+		r.logger.Error("Failed to scan Redis keys", "error", err, "pattern", fullPattern)
+		return nil, infra_error.Internal(infra_error.InternalDatabaseError, err)
 	}
 
 	r.logger.Debug("Redis SCAN completed", "pattern", fullPattern, "keys_found", len(allKeys))
@@ -251,8 +421,8 @@ func (r *BaseRedisHandler) Scan(pattern string, batchSize int64) ([]string, erro
 
 // DeleteByPattern deletes all keys matching a pattern
 // Uses SCAN to find keys and pipeline for efficient deletion
-func (r *BaseRedisHandler) DeleteByPattern(pattern string) (int, error) {
-	keys, err := r.Scan(pattern, 100)
+func (r *BaseRedisHandler) DeleteByPattern(ctx context.Context, pattern string) (int, error) {
+	keys, err := r.Scan(ctx, pattern, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -262,16 +432,16 @@ func (r *BaseRedisHandler) DeleteByPattern(pattern string) (int, error) {
 		return 0, nil
 	}
 
-	// Delete in pipeline for efficiency
-	pipe := r.client.Pipeline()
-	for _, key := range keys {
-		pipe.Del(redisContext, key)
-	}
-
-	_, err = pipe.Exec(redisContext)
-	if err != nil {
-		r.logger.Error("Failed to delete keys by pattern", "error", err, "pattern", pattern, "keys_count", len(keys))
-		return 0, infra_error.Internal(infra_error.InternalDatabaseError, err)
+	// Delete in pipelines of at most batchSize keys for efficiency
+	for batch := range slices.Chunk(keys, r.batchSizeOrDefault()) {
+		pipe := r.client.Pipeline()
+		for _, key := range batch {
+			pipe.Del(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			r.logger.Error("Failed to delete keys by pattern", "error", err, "pattern", pattern, "keys_count", len(keys))
+			return 0, infra_error.Internal(infra_error.InternalDatabaseError, err)
+		}
 	}
 
 	r.logger.Info("Keys deleted by pattern", "pattern", pattern, "keys_deleted", len(keys))
@@ -0,0 +1,287 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"erp.localhost/internal/infra/logging/logger"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer is a Redis server over TCP answering the string commands used by the handlers, so the round trips
+// of the handlers are those of a real server
+type fakeServer struct {
+	listener net.Listener
+
+	mu     sync.Mutex
+	values map[string]string
+	// mgets records the number of keys of each MGET
+	mgets []int
+	// commands counts the commands received by name
+	commands map[string]int
+}
+
+func newFakeServer(t testing.TB) *fakeServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{listener: listener, values: map[string]string{}, commands: map[string]int{}}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+// newHandler returns a handler of prefix connected to the server with config
+func (s *fakeServer) newHandler(t testing.TB, config RedisConfig) *BaseRedisHandler {
+	t.Helper()
+	config.Mode = ModeStandalone
+	config.Addrs = []string{s.listener.Addr().String()}
+	handler, err := NewBaseRedisHandlerWithConfig(&config, model_redis.RedisKeyToken, logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+	t.Cleanup(func() { handler.Close() })
+	return handler
+}
+
+func (s *fakeServer) set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+func (s *fakeServer) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mgets = nil
+	s.commands = map[string]int{}
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.exec(w, args)
+		// Pipelined commands are answered together
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *fakeServer) exec(w *bufio.Writer, args []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := strings.ToUpper(args[0])
+	s.commands[name]++
+	switch name {
+	case "PING":
+		w.WriteString("+PONG\r\n")
+	case "CLIENT":
+		w.WriteString("+OK\r\n")
+	case "SET":
+		s.values[args[1]] = args[2]
+		w.WriteString("+OK\r\n")
+	case "GET":
+		writeBulk(w, s.values, args[1])
+	case "MGET":
+		s.mgets = append(s.mgets, len(args)-1)
+		fmt.Fprintf(w, "*%d\r\n", len(args)-1)
+		for _, key := range args[1:] {
+			writeBulk(w, s.values, key)
+		}
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.values[key]; ok {
+				delete(s.values, key)
+				deleted++
+			}
+		}
+		fmt.Fprintf(w, ":%d\r\n", deleted)
+	case "SCAN":
+		s.scan(w, args)
+	default:
+		fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+// scan examines COUNT keys in order from the cursor, the index of the next key, like the SCAN of Redis
+func (s *fakeServer) scan(w *bufio.Writer, args []string) {
+	cursor, _ := strconv.Atoi(args[1])
+	pattern, count := "*", 10
+	for i := 2; i+1 < len(args); i += 2 {
+		switch strings.ToUpper(args[i]) {
+		case "MATCH":
+			pattern = args[i+1]
+		case "COUNT":
+			count, _ = strconv.Atoi(args[i+1])
+		}
+	}
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	end := min(cursor+count, len(keys))
+	matched := []string{}
+	for _, key := range keys[min(cursor, end):end] {
+		if ok, _ := path.Match(pattern, key); ok {
+			matched = append(matched, key)
+		}
+	}
+	if end == len(keys) {
+		end = 0
+	}
+	next := strconv.Itoa(end)
+	fmt.Fprintf(w, "*2\r\n$%d\r\n%s\r\n*%d\r\n", len(next), next, len(matched))
+	for _, key := range matched {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(key), key)
+	}
+}
+
+func writeBulk(w *bufio.Writer, values map[string]string, key string) {
+	value, ok := values[key]
+	if !ok {
+		w.WriteString("$-1\r\n")
+		return
+	}
+	fmt.Fprintf(w, "$%d\r\n%s\r\n", len(value), value)
+}
+
+// readCommand reads a command sent as an array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid command %q", line)
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %q", line)
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+// seedSessions stores count sessions of user-1 of tenant-1 and one session of user-2
+func seedSessions(s *fakeServer, count int) {
+	for i := range count {
+		data, _ := json.Marshal(TestModel{ID: fmt.Sprintf("session-%04d", i), Name: "user-1"})
+		s.set(fmt.Sprintf("%s:tenant-1:user-1:session-%04d", model_redis.RedisKeyToken, i), string(data))
+	}
+	s.set(fmt.Sprintf("%s:tenant-1:user-2:session-0000", model_redis.RedisKeyToken), `{"id":"other","name":"user-2"}`)
+}
+
+func TestBaseRedisHandler_FindAll(t *testing.T) {
+	server := newFakeServer(t)
+	seedSessions(server, 10)
+	handler := server.newHandler(t, RedisConfig{ScanCount: 4, BatchSize: 3})
+	server.reset()
+
+	result := []*TestModel{}
+	require.NoError(t, handler.FindAll(context.Background(), "tenant-1:user-1", nil, &result))
+
+	ids := make([]string, 0, len(result))
+	for _, model := range result {
+		assert.Equal(t, "user-1", model.Name)
+		ids = append(ids, model.ID)
+	}
+	assert.ElementsMatch(t, []string{
+		"session-0000", "session-0001", "session-0002", "session-0003", "session-0004",
+		"session-0005", "session-0006", "session-0007", "session-0008", "session-0009",
+	}, ids)
+	// Pages of 4, 4 and 2 keys of user-1, read by at most 3 keys
+	assert.Equal(t, []int{3, 1, 3, 1, 2}, server.mgets)
+	assert.Zero(t, server.commands["GET"])
+	assert.Equal(t, 3, server.commands["SCAN"])
+}
+
+func TestBaseRedisHandler_DeleteByPattern(t *testing.T) {
+	server := newFakeServer(t)
+	seedSessions(server, 7)
+	handler := server.newHandler(t, RedisConfig{BatchSize: 3})
+
+	deleted, err := handler.DeleteByPattern(context.Background(), "tenant-1:user-1:*")
+	require.NoError(t, err)
+	assert.Equal(t, 7, deleted)
+	assert.Len(t, server.values, 1)
+}
+
+// BenchmarkGetAll reads the sessions of a user with many sessions, one GET per key after the scan against the
+// batched reads of FindAll
+func BenchmarkGetAll(b *testing.B) {
+	for _, sessions := range []int{100, 1000} {
+		server := newFakeServer(b)
+		seedSessions(server, sessions)
+		handler := server.newHandler(b, RedisConfig{})
+		pattern := fmt.Sprintf("%s:tenant-1:user-1*", model_redis.RedisKeyToken)
+
+		b.Run(fmt.Sprintf("sessions=%d/serial", sessions), func(b *testing.B) {
+			ctx := context.Background()
+			for b.Loop() {
+				keys, err := handler.scanKeys(ctx, pattern, defaultScanCount)
+				require.NoError(b, err)
+				result := make([]*TestModel, 0, len(keys))
+				for _, key := range keys {
+					data, err := handler.client.Get(ctx, key).Bytes()
+					require.NoError(b, err)
+					model := &TestModel{}
+					require.NoError(b, json.Unmarshal(data, model))
+					result = append(result, model)
+				}
+				require.Len(b, result, sessions)
+			}
+		})
+		b.Run(fmt.Sprintf("sessions=%d/batched", sessions), func(b *testing.B) {
+			ctx := context.Background()
+			for b.Loop() {
+				result := []*TestModel{}
+				require.NoError(b, handler.FindAll(ctx, "tenant-1:user-1", nil, &result))
+				require.Len(b, result, sessions)
+			}
+		})
+	}
+}
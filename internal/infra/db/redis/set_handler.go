@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -10,10 +11,10 @@ import (
 
 //go:generate mockgen -destination=mock/mock_set_handler.go -package=mock erp.localhost/internal/infra/db/redis SetHandler
 type SetHandler interface {
-	Add(tenantID string, key string, member string, opts ...map[string]any) error
-	Remove(tenantID string, key string, member string) error
-	Members(tenantID string, key string) ([]string, error)
-	Clear(tenantID string, key string) error
+	Add(ctx context.Context, tenantID string, key string, member string, opts ...map[string]any) error
+	Remove(ctx context.Context, tenantID string, key string, member string) error
+	Members(ctx context.Context, tenantID string, key string) ([]string, error)
+	Clear(ctx context.Context, tenantID string, key string) error
 }
 
 type BaseSetHandler struct {
@@ -28,9 +29,9 @@ func NewBaseSetHandler(redisHandler RedisHandler, logger logger.Logger) *BaseSet
 	}
 }
 
-func (h *BaseSetHandler) Add(tenantID string, key string, member string, opts ...map[string]any) error {
+func (h *BaseSetHandler) Add(ctx context.Context, tenantID string, key string, member string, opts ...map[string]any) error {
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
-	err := h.redisHandler.SAdd(formattedKey, member)
+	err := h.redisHandler.SAdd(ctx, formattedKey, member)
 	if err != nil {
 		h.logger.Error("Failed to add member to set", "error", err, "tenantID", tenantID, "key", key, "member", member)
 		return infra_error.Internal(infra_error.InternalDatabaseError, err)
@@ -43,7 +44,7 @@ func (h *BaseSetHandler) Add(tenantID string, key string, member string, opts ..
 					h.logger.Error("Failed to parse unit", "error", err, "tenantID", tenantID, "key", key, "member", member)
 					return infra_error.Internal(infra_error.InternalInvalidArgument, err)
 				}
-				err = h.redisHandler.Expire(formattedKey, ttl.(int), unit)
+				err = h.redisHandler.Expire(ctx, formattedKey, ttl.(int), unit)
 				if err != nil {
 					h.logger.Error("Failed to set TTL on set", "error", err, "tenantID", tenantID, "key", key, "member", member)
 					return infra_error.Internal(infra_error.InternalInvalidArgument, err)
@@ -56,9 +57,9 @@ func (h *BaseSetHandler) Add(tenantID string, key string, member string, opts ..
 	return nil
 }
 
-func (h *BaseSetHandler) Remove(tenantID string, key string, member string) error {
+func (h *BaseSetHandler) Remove(ctx context.Context, tenantID string, key string, member string) error {
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
-	err := h.redisHandler.SRem(formattedKey, member)
+	err := h.redisHandler.SRem(ctx, formattedKey, member)
 	if err != nil {
 		h.logger.Error("Failed to remove member from set", "error", err, "tenantID", tenantID, "key", key, "member", member)
 		return infra_error.Internal(infra_error.InternalDatabaseError, err)
@@ -67,9 +68,9 @@ func (h *BaseSetHandler) Remove(tenantID string, key string, member string) erro
 	return nil
 }
 
-func (h *BaseSetHandler) Members(tenantID string, key string) ([]string, error) {
+func (h *BaseSetHandler) Members(ctx context.Context, tenantID string, key string) ([]string, error) {
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
-	members, err := h.redisHandler.SMembers(formattedKey)
+	members, err := h.redisHandler.SMembers(ctx, formattedKey)
 	if err != nil {
 		h.logger.Error("Failed to get members from set", "error", err, "tenantID", tenantID, "key", key)
 		return nil, infra_error.Internal(infra_error.InternalDatabaseError, err)
@@ -77,9 +78,9 @@ func (h *BaseSetHandler) Members(tenantID string, key string) ([]string, error)
 	return members, nil
 }
 
-func (h *BaseSetHandler) Clear(tenantID string, key string) error {
+func (h *BaseSetHandler) Clear(ctx context.Context, tenantID string, key string) error {
 	formattedKey := fmt.Sprintf("%s:%s", tenantID, key)
-	err := h.redisHandler.Clear(formattedKey)
+	err := h.redisHandler.Clear(ctx, formattedKey)
 	if err != nil {
 		h.logger.Error("Failed to clear set", "error", err, "tenantID", tenantID, "key", key)
 		return infra_error.Internal(infra_error.InternalDatabaseError, err)
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -71,7 +72,7 @@ func TestBaseSetHandler_Add(t *testing.T) {
 			mockHandler := mock_redis.NewMockRedisHandler(ctrl)
 			if tc.expectedSAddCalls > 0 {
 				mockHandler.EXPECT().
-					SAdd(tc.expectedFormattedKey, tc.member).
+					SAdd(gomock.Any(), tc.expectedFormattedKey, tc.member).
 					Return(tc.returnError).
 					Times(tc.expectedSAddCalls)
 			}
@@ -79,7 +80,7 @@ func TestBaseSetHandler_Add(t *testing.T) {
 			logger := logger.NewBaseLogger(shared.ModuleDB)
 			handler := NewBaseSetHandler(mockHandler, logger)
 
-			err := handler.Add(tc.tenantID, tc.key, tc.member, tc.opts...)
+			err := handler.Add(context.Background(), tc.tenantID, tc.key, tc.member, tc.opts...)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -109,19 +110,19 @@ func TestBaseSetHandler_Add_WithTTL(t *testing.T) {
 	}
 
 	mockHandler.EXPECT().
-		SAdd(formattedKey, member).
+		SAdd(gomock.Any(), formattedKey, member).
 		Return(nil).
 		Times(1)
 
 	mockHandler.EXPECT().
-		Expire(formattedKey, ttl, time.Second).
+		Expire(gomock.Any(), formattedKey, ttl, time.Second).
 		Return(nil).
 		Times(1)
 
 	logger := logger.NewBaseLogger(shared.ModuleDB)
 	handler := NewBaseSetHandler(mockHandler, logger)
 
-	err := handler.Add(tenantID, key, member, opts...)
+	err := handler.Add(context.Background(), tenantID, key, member, opts...)
 	require.NoError(t, err)
 }
 
@@ -145,19 +146,19 @@ func TestBaseSetHandler_Add_WithTTL_ExpireFails(t *testing.T) {
 	}
 
 	mockHandler.EXPECT().
-		SAdd(formattedKey, member).
+		SAdd(gomock.Any(), formattedKey, member).
 		Return(nil).
 		Times(1)
 
 	mockHandler.EXPECT().
-		Expire(formattedKey, ttl, time.Second).
+		Expire(gomock.Any(), formattedKey, ttl, time.Second).
 		Return(errors.New("expire failed")).
 		Times(1)
 
 	logger := logger.NewBaseLogger(shared.ModuleDB)
 	handler := NewBaseSetHandler(mockHandler, logger)
 
-	err := handler.Add(tenantID, key, member, opts...)
+	err := handler.Add(context.Background(), tenantID, key, member, opts...)
 	require.Error(t, err)
 }
 
@@ -202,7 +203,7 @@ func TestBaseSetHandler_Remove(t *testing.T) {
 			mockHandler := mock_redis.NewMockRedisHandler(ctrl)
 			if tc.expectedSRemCalls > 0 {
 				mockHandler.EXPECT().
-					SRem(tc.expectedFormattedKey, tc.member).
+					SRem(gomock.Any(), tc.expectedFormattedKey, tc.member).
 					Return(tc.returnError).
 					Times(tc.expectedSRemCalls)
 			}
@@ -210,7 +211,7 @@ func TestBaseSetHandler_Remove(t *testing.T) {
 			logger := logger.NewBaseLogger(shared.ModuleDB)
 			handler := NewBaseSetHandler(mockHandler, logger)
 
-			err := handler.Remove(tc.tenantID, tc.key, tc.member)
+			err := handler.Remove(context.Background(), tc.tenantID, tc.key, tc.member)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
@@ -271,7 +272,7 @@ func TestBaseSetHandler_Members(t *testing.T) {
 			mockHandler := mock_redis.NewMockRedisHandler(ctrl)
 			if tc.expectedSMembersCalls > 0 {
 				mockHandler.EXPECT().
-					SMembers(tc.expectedFormattedKey).
+					SMembers(gomock.Any(), tc.expectedFormattedKey).
 					Return(tc.returnMembers, tc.returnError).
 					Times(tc.expectedSMembersCalls)
 			}
@@ -279,7 +280,7 @@ func TestBaseSetHandler_Members(t *testing.T) {
 			logger := logger.NewBaseLogger(shared.ModuleDB)
 			handler := NewBaseSetHandler(mockHandler, logger)
 
-			members, err := handler.Members(tc.tenantID, tc.key)
+			members, err := handler.Members(context.Background(), tc.tenantID, tc.key)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Nil(t, members)
@@ -329,7 +330,7 @@ func TestBaseSetHandler_Clear(t *testing.T) {
 			mockHandler := mock_redis.NewMockRedisHandler(ctrl)
 			if tc.expectedClearCalls > 0 {
 				mockHandler.EXPECT().
-					Clear(tc.expectedFormattedKey).
+					Clear(gomock.Any(), tc.expectedFormattedKey).
 					Return(tc.returnError).
 					Times(tc.expectedClearCalls)
 			}
@@ -337,7 +338,7 @@ func TestBaseSetHandler_Clear(t *testing.T) {
 			logger := logger.NewBaseLogger(shared.ModuleDB)
 			handler := NewBaseSetHandler(mockHandler, logger)
 
-			err := handler.Clear(tc.tenantID, tc.key)
+			err := handler.Clear(context.Background(), tc.tenantID, tc.key)
 			if tc.wantErr {
 				require.Error(t, err)
 			} else {
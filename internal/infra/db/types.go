@@ -4,17 +4,17 @@ import (
 	"time"
 
 	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
 )
 
 const (
 	SystemTenant          = "system"
 	SystemAdminUser       = model_auth.RoleSystemAdmin
 	SystemAdminEmail      = "system@system.com"
-	SystemAdminPassword   = "ERP@SystemAdmin.Secret5"
 	TenantAdminUser       = "admin"
 	TenantAdminRole       = model_auth.RoleTenantAdmin
-	TenantAdminPermission = model_auth.ResourceTypeAll + ":" + model_auth.PermissionActionAll
-	TenantAdminPassword   = "admin"
+	TenantAdminPermission = permissions.Wildcard
+	TenantAdminPassword   = "ERP@TenantAdmin.Secret5"
 )
 
 var (
@@ -0,0 +1,65 @@
+package error
+
+import (
+	"net/http"
+	"testing"
+
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCatalogMatchesProtoEnum(t *testing.T) {
+	defs := Catalog()
+	require.NotEmpty(t, defs)
+	// Every catalog code is in the generated enum and every enum value is in the catalog
+	assert.Len(t, infrav1.ErrorCode_name, len(defs)+1)
+	for _, def := range defs {
+		number, ok := infrav1.ErrorCode_value["ERROR_CODE_"+def.Code]
+		require.True(t, ok, "catalog code %s is missing from the ErrorCode enum, run go generate", def.Code)
+		assert.Equal(t, def.Number, number, def.Code)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	def, ok := Lookup(AuthPermissionDenied.Code)
+	require.True(t, ok)
+	assert.Equal(t, AuthPermissionDenied, def)
+
+	_, ok = Lookup("TEST_UNKNOWN_CODE")
+	assert.False(t, ok)
+}
+
+func TestCatalogMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *AppError
+		wantGRPC  codes.Code
+		wantHTTP  int
+		wantRetry bool
+	}{
+		{name: "category default", err: Auth(AuthInvalidCredentials), wantGRPC: codes.Unauthenticated, wantHTTP: http.StatusUnauthorized},
+		{name: "permission denied override", err: Auth(AuthTenantAccessDenied), wantGRPC: codes.PermissionDenied, wantHTTP: http.StatusForbidden},
+		{name: "quota override", err: Business(BusinessQuotaExceeded), wantGRPC: codes.ResourceExhausted, wantHTTP: http.StatusTooManyRequests},
+		{name: "retryable", err: Internal(InternalServiceUnavailable, nil), wantGRPC: codes.Unavailable, wantHTTP: http.StatusServiceUnavailable, wantRetry: true},
+		{name: "outside catalog", err: New(testNotFoundError), wantGRPC: codes.NotFound, wantHTTP: http.StatusNotFound},
+		{name: "outside catalog without category", err: New(testNoCategoryError), wantGRPC: codes.Internal, wantHTTP: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantGRPC, GetGRPCCode(tt.err))
+			assert.Equal(t, tt.wantHTTP, GetHTTPStatus(tt.err))
+			assert.Equal(t, tt.wantRetry, tt.err.Retryable())
+		})
+	}
+}
+
+func TestAppError_RenderMessage(t *testing.T) {
+	err := Validation(ValidationPasswordReused, "password").WithDetails("history_depth", 5)
+	assert.Equal(t, "Password matches one of the last 5 passwords and can not be reused: [password]", err.RenderMessage())
+	assert.Contains(t, err.Error(), "last 5 passwords")
+
+	missing := New(ValidationPasswordReused)
+	assert.Contains(t, missing.RenderMessage(), "{history_depth}")
+}
@@ -2,6 +2,7 @@ package error
 
 import (
 	"fmt"
+	"regexp"
 )
 
 // ErrorCategory represents the category of an error
@@ -25,12 +26,33 @@ type AppError struct {
 	Err      error          // Wrapped underlying error
 }
 
+// placeholderPattern matches {detail} placeholders of catalog messages
+var placeholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
 // Error implements the error interface
 func (e *AppError) Error() string {
 	if e.Err != nil {
-		return fmt.Sprintf("[%s] %s: %s - %v", e.Category, e.Code, e.Message, e.Err)
+		return fmt.Sprintf("[%s] %s: %s - %v", e.Category, e.Code, e.RenderMessage(), e.Err)
 	}
-	return fmt.Sprintf("[%s] %s: %s", e.Category, e.Code, e.Message)
+	return fmt.Sprintf("[%s] %s: %s", e.Category, e.Code, e.RenderMessage())
+}
+
+// RenderMessage returns the message with its {detail} placeholders filled from the error details.
+// Placeholders without a matching detail are kept as is.
+func (e *AppError) RenderMessage() string {
+	return placeholderPattern.ReplaceAllStringFunc(e.Message, func(placeholder string) string {
+		value, ok := e.Details[placeholder[1:len(placeholder)-1]]
+		if !ok {
+			return placeholder
+		}
+		return fmt.Sprint(value)
+	})
+}
+
+// Retryable reports whether the call may succeed when retried, codes outside the catalog are not retryable
+func (e *AppError) Retryable() bool {
+	def, ok := Lookup(e.Code)
+	return ok && def.Retryable
 }
 
 // Unwrap returns the wrapped error for errors.Is/As support
@@ -0,0 +1,201 @@
+// gen reads the error catalog and writes the error definitions file and the ErrorCode proto enum.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+)
+
+type catalogFile struct {
+	Errors []catalogEntry `json:"errors"`
+}
+
+type catalogEntry struct {
+	Number    int32  `json:"number"`
+	Name      string `json:"name"`
+	Code      string `json:"code"`
+	Category  string `json:"category"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+	GRPC      string `json:"grpc"`
+	HTTP      int    `json:"http"`
+}
+
+// categories maps catalog categories to their Go constant and default gRPC code
+var categories = map[string]struct {
+	constant string
+	grpc     string
+}{
+	"AUTH":       {constant: "CategoryAuth", grpc: "Unauthenticated"},
+	"VALIDATION": {constant: "CategoryValidation", grpc: "InvalidArgument"},
+	"NOT_FOUND":  {constant: "CategoryNotFound", grpc: "NotFound"},
+	"CONFLICT":   {constant: "CategoryConflict", grpc: "AlreadyExists"},
+	"BUSINESS":   {constant: "CategoryBusiness", grpc: "FailedPrecondition"},
+	"INTERNAL":   {constant: "CategoryInternal", grpc: "Internal"},
+}
+
+// grpcToHTTP is the default HTTP status of each gRPC code, following the grpc-gateway mapping
+var grpcToHTTP = map[string]int{
+	"InvalidArgument":    400,
+	"FailedPrecondition": 400,
+	"OutOfRange":         400,
+	"Unauthenticated":    401,
+	"PermissionDenied":   403,
+	"NotFound":           404,
+	"AlreadyExists":      409,
+	"Aborted":            409,
+	"ResourceExhausted":  429,
+	"Canceled":           499,
+	"Internal":           500,
+	"Unknown":            500,
+	"DataLoss":           500,
+	"Unimplemented":      501,
+	"Unavailable":        503,
+	"DeadlineExceeded":   504,
+}
+
+var (
+	codePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	namePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+func main() {
+	catalogPath := flag.String("catalog", "catalog.json", "path to the error catalog")
+	outPath := flag.String("out", "codes.gen.go", "path of the generated Go file")
+	protoPath := flag.String("proto", "", "path of the generated proto file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*catalogPath)
+	if err != nil {
+		log.Fatalf("failed to read catalog: %v", err)
+	}
+	var catalog catalogFile
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		log.Fatalf("failed to parse catalog: %v", err)
+	}
+	if err := resolve(catalog.Errors); err != nil {
+		log.Fatalf("invalid catalog: %v", err)
+	}
+
+	src, err := format.Source(goFile(catalog.Errors))
+	if err != nil {
+		log.Fatalf("failed to format generated file: %v", err)
+	}
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatalf("failed to write generated file: %v", err)
+	}
+	if *protoPath != "" {
+		if err := os.WriteFile(*protoPath, protoFile(catalog.Errors), 0o644); err != nil {
+			log.Fatalf("failed to write generated proto: %v", err)
+		}
+	}
+}
+
+// resolve validates entries and fills the default gRPC and HTTP mapping of their category
+func resolve(entries []catalogEntry) error {
+	numbers := map[int32]string{}
+	codes := map[string]bool{}
+	names := map[string]bool{}
+	for i := range entries {
+		e := &entries[i]
+		category, ok := categories[e.Category]
+		if !ok {
+			return fmt.Errorf("unknown category %q for %s", e.Category, e.Code)
+		}
+		if !codePattern.MatchString(e.Code) || !namePattern.MatchString(e.Name) || e.Message == "" {
+			return fmt.Errorf("entry %q needs an upper snake case code, a Go name and a message", e.Code)
+		}
+		if e.Number <= 0 {
+			return fmt.Errorf("entry %s needs a positive number", e.Code)
+		}
+		if other, ok := numbers[e.Number]; ok {
+			return fmt.Errorf("number %d used by both %s and %s", e.Number, other, e.Code)
+		}
+		if codes[e.Code] || names[e.Name] {
+			return fmt.Errorf("duplicate entry %s", e.Code)
+		}
+		numbers[e.Number], codes[e.Code], names[e.Name] = e.Code, true, true
+
+		if e.GRPC == "" {
+			e.GRPC = category.grpc
+		}
+		status, ok := grpcToHTTP[e.GRPC]
+		if !ok {
+			return fmt.Errorf("unknown grpc code %q for %s", e.GRPC, e.Code)
+		}
+		if e.HTTP == 0 {
+			e.HTTP = status
+		}
+	}
+	return nil
+}
+
+func goFile(entries []catalogEntry) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by error/gen from catalog.json. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package error")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `import "google.golang.org/grpc/codes"`)
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "var (")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s = ErrorDef{\n", e.Name)
+		fmt.Fprintf(&buf, "Code: %q,\n", e.Code)
+		fmt.Fprintf(&buf, "Number: %d,\n", e.Number)
+		fmt.Fprintf(&buf, "Message: %q,\n", e.Message)
+		fmt.Fprintf(&buf, "Category: %s,\n", categories[e.Category].constant)
+		fmt.Fprintf(&buf, "Retryable: %t,\n", e.Retryable)
+		fmt.Fprintf(&buf, "GRPCCode: codes.%s,\n", e.GRPC)
+		fmt.Fprintf(&buf, "HTTPStatus: %d,\n", e.HTTP)
+		fmt.Fprintln(&buf, "}")
+	}
+	fmt.Fprintln(&buf, ")")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "var ordered = []ErrorDef{")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s,\n", e.Name)
+	}
+	fmt.Fprintln(&buf, "}")
+	return buf.Bytes()
+}
+
+func protoFile(entries []catalogEntry) []byte {
+	sorted := make([]catalogEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by error/gen from internal/infra/error/catalog.json. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `syntax = "proto3";`)
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package infra.v1;")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `option go_package = "erp.localhost/internal/infra/model/infra/v1;infrav1";`)
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// Stable machine readable error codes, returned in infra.v1.Error details of failed calls.")
+	fmt.Fprintln(&buf, "// Numbers never change, removed codes are reserved.")
+	fmt.Fprintln(&buf, "enum ErrorCode {")
+	fmt.Fprintln(&buf, "  ERROR_CODE_UNSPECIFIED = 0;")
+	for _, e := range sorted {
+		fmt.Fprintf(&buf, "  // %s (%s, grpc %s, http %d%s)\n", e.Message, e.Category, e.GRPC, e.HTTP, retryableNote(e.Retryable))
+		fmt.Fprintf(&buf, "  ERROR_CODE_%s = %d;\n", e.Code, e.Number)
+	}
+	fmt.Fprintln(&buf, "}")
+	return buf.Bytes()
+}
+
+func retryableNote(retryable bool) string {
+	if retryable {
+		return ", retryable"
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validEntry() catalogEntry {
+	return catalogEntry{Number: 101, Name: "AuthInvalidCredentials", Code: "AUTH_INVALID_CREDENTIALS", Category: "AUTH", Message: "Invalid email or password"}
+}
+
+func TestResolve_Defaults(t *testing.T) {
+	override := validEntry()
+	override.Number, override.Name, override.Code = 102, "AuthPermissionDenied", "AUTH_PERMISSION_DENIED"
+	override.GRPC = "PermissionDenied"
+	explicitHTTP := validEntry()
+	explicitHTTP.Number, explicitHTTP.Name, explicitHTTP.Code = 103, "AuthTeapot", "AUTH_TEAPOT"
+	explicitHTTP.HTTP = 418
+	entries := []catalogEntry{validEntry(), override, explicitHTTP}
+
+	require.NoError(t, resolve(entries))
+	assert.Equal(t, "Unauthenticated", entries[0].GRPC)
+	assert.Equal(t, 401, entries[0].HTTP)
+	assert.Equal(t, "PermissionDenied", entries[1].GRPC)
+	assert.Equal(t, 403, entries[1].HTTP)
+	assert.Equal(t, 418, entries[2].HTTP)
+}
+
+func TestResolve_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(e *catalogEntry)
+		wantErr string
+	}{
+		{name: "unknown category", modify: func(e *catalogEntry) { e.Category = "UNKNOWN" }, wantErr: "unknown category"},
+		{name: "lower case code", modify: func(e *catalogEntry) { e.Code = "auth_invalid" }, wantErr: "upper snake case code"},
+		{name: "invalid name", modify: func(e *catalogEntry) { e.Name = "auth invalid" }, wantErr: "upper snake case code"},
+		{name: "missing message", modify: func(e *catalogEntry) { e.Message = "" }, wantErr: "upper snake case code"},
+		{name: "missing number", modify: func(e *catalogEntry) { e.Number = 0 }, wantErr: "positive number"},
+		{name: "unknown grpc code", modify: func(e *catalogEntry) { e.GRPC = "Teapot" }, wantErr: "unknown grpc code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := validEntry()
+			tt.modify(&entry)
+			err := resolve([]catalogEntry{entry})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestResolve_Duplicates(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(e *catalogEntry)
+		wantErr string
+	}{
+		{name: "number", modify: func(e *catalogEntry) { e.Name, e.Code = "AuthOther", "AUTH_OTHER" }, wantErr: "number 101 used by both"},
+		{name: "code", modify: func(e *catalogEntry) { e.Number, e.Name = 102, "AuthOther" }, wantErr: "duplicate entry"},
+		{name: "name", modify: func(e *catalogEntry) { e.Number, e.Code = 102, "AUTH_OTHER" }, wantErr: "duplicate entry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duplicate := validEntry()
+			tt.modify(&duplicate)
+			err := resolve([]catalogEntry{validEntry(), duplicate})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
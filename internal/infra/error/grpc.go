@@ -2,12 +2,18 @@ package error
 
 import (
 	"encoding/json"
+	"errors"
 
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-// categoryToGRPCCode maps error categories to gRPC status codes
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details of the errors of the services
+const ErrorDomain = "erp.localhost"
+
+// categoryToGRPCCode maps error categories to gRPC status codes, used for codes outside the catalog
 var categoryToGRPCCode = map[ErrorCategory]codes.Code{
 	CategoryAuth:       codes.Unauthenticated,
 	CategoryValidation: codes.InvalidArgument,
@@ -17,53 +23,82 @@ var categoryToGRPCCode = map[ErrorCategory]codes.Code{
 	CategoryInternal:   codes.Internal,
 }
 
-// Special cases where AUTH errors map to PermissionDenied
-var permissionDeniedCodes = map[string]bool{
-	"AUTH_PERMISSION_DENIED":    true,
-	"AUTH_INSUFFICIENT_ROLE":    true,
-	"AUTH_TENANT_ACCESS_DENIED": true,
-}
-
-// ToGRPCError converts an AppError to a gRPC status error
+// ToGRPCError converts an AppError to a gRPC status error.
+// The error is attached to the status details as an infra.v1.Error so clients can read its catalog code, and as a
+// google.rpc.ErrorInfo with the catalog code as reason for generic gRPC clients.
 func ToGRPCError(err error) error {
 	if err == nil {
 		return nil
 	}
 
-	appErr, ok := err.(*AppError)
-	if !ok {
-		return Internal(InternalUnexpectedError, err)
-	}
-	// Determine the gRPC status code
-	grpcCode := codes.Internal
-	if code, ok := categoryToGRPCCode[appErr.Category]; ok {
-		grpcCode = code
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		appErr = Internal(InternalUnexpectedError, err)
 	}
 
-	// Special handling for permission-related auth errors
-	if appErr.Category == CategoryAuth && permissionDeniedCodes[appErr.Code] {
-		grpcCode = codes.PermissionDenied
+	protoErr := ToProto(appErr)
+	st := status.New(GetGRPCCode(appErr), appErr.RenderMessage())
+	if withDetails, detailsErr := st.WithDetails(protoErr, toErrorInfo(protoErr)); detailsErr == nil {
+		st = withDetails
 	}
+	return st.Err()
+}
 
-	// Create gRPC status with error details
-	st := status.New(grpcCode, appErr.Message)
-
-	// Add error details as JSON in the status details
-	details := &errorDetails{
-		Code:     appErr.Code,
-		Category: string(appErr.Category),
-		Message:  appErr.Message,
-		Details:  appErr.Details,
+// toErrorInfo returns the google.rpc.ErrorInfo of an error, its metadata holds the category and the JSON encoded
+// details of the error
+func toErrorInfo(protoErr *infrav1.Error) *errdetails.ErrorInfo {
+	metadata := make(map[string]string, len(protoErr.GetDetails())+1)
+	for key, value := range protoErr.GetDetails() {
+		metadata[key] = value
+	}
+	if protoErr.GetCategory() != infrav1.ErrorCategory_ERROR_CATEGORY_UNSPECIFIED {
+		metadata["category"] = protoErr.GetCategory().String()
 	}
+	return &errdetails.ErrorInfo{
+		Reason:   protoErr.GetCode(),
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	}
+}
 
-	// Serialize details to JSON and add to status
-	if detailsJSON, jsonErr := json.Marshal(details); jsonErr == nil {
-		st, _ = st.WithDetails(&errorDetailsProto{
-			Json: string(detailsJSON),
-		})
+// ToProto converts an AppError to its wire representation, detail values are JSON encoded
+func ToProto(err *AppError) *infrav1.Error {
+	details := make(map[string]string, len(err.Details))
+	for key, value := range err.Details {
+		if encoded, jsonErr := json.Marshal(value); jsonErr == nil {
+			details[key] = string(encoded)
+		}
+	}
+	return &infrav1.Error{
+		Code:      err.Code,
+		Message:   err.RenderMessage(),
+		Category:  infrav1.ErrorCategory(infrav1.ErrorCategory_value["ERROR_CATEGORY_"+string(err.Category)]),
+		Details:   details,
+		ErrorCode: infrav1.ErrorCode(infrav1.ErrorCode_value["ERROR_CODE_"+err.Code]),
+		Retryable: err.Retryable(),
 	}
+}
 
-	return st.Err()
+// FromProto converts the wire representation of an error back to an AppError
+func FromProto(protoErr *infrav1.Error) *AppError {
+	details := make(map[string]any, len(protoErr.GetDetails()))
+	for key, encoded := range protoErr.GetDetails() {
+		var value any
+		if jsonErr := json.Unmarshal([]byte(encoded), &value); jsonErr != nil {
+			value = encoded
+		}
+		details[key] = value
+	}
+	category := ErrorCategory("")
+	if name, ok := infrav1.ErrorCategory_name[int32(protoErr.GetCategory())]; ok && protoErr.GetCategory() != infrav1.ErrorCategory_ERROR_CATEGORY_UNSPECIFIED {
+		category = ErrorCategory(name[len("ERROR_CATEGORY_"):])
+	}
+	return &AppError{
+		Code:     protoErr.GetCode(),
+		Message:  protoErr.GetMessage(),
+		Category: category,
+		Details:  details,
+	}
 }
 
 // FromGRPCError extracts an AppError from a gRPC error
@@ -85,106 +120,75 @@ func FromGRPCError(err error) *AppError {
 	}
 
 	// Try to extract error details from status
+	var errorInfo *errdetails.ErrorInfo
 	for _, detail := range st.Details() {
-		if ed, ok := detail.(*errorDetailsProto); ok {
-			var details errorDetails
-			if jsonErr := json.Unmarshal([]byte(ed.Json), &details); jsonErr == nil {
-				return &AppError{
-					Code:     details.Code,
-					Message:  details.Message,
-					Category: ErrorCategory(details.Category),
-					Details:  details.Details,
-				}
+		switch detail := detail.(type) {
+		case *infrav1.Error:
+			return FromProto(detail)
+		case *errdetails.ErrorInfo:
+			errorInfo = detail
+		}
+	}
+	// Errors of other services of the domain may only carry an ErrorInfo
+	if errorInfo.GetDomain() == ErrorDomain {
+		if def, ok := Lookup(errorInfo.GetReason()); ok {
+			return &AppError{
+				Code:     def.Code,
+				Message:  st.Message(),
+				Category: def.Category,
+				Details:  make(map[string]any),
 			}
 		}
 	}
 
 	// Fallback: create AppError from gRPC status code and message
-	category := grpcCodeToCategory(st.Code())
+	def := grpcCodeToErrorDef(st.Code())
 	return &AppError{
-		Code:     grpcCodeToErrorCode(st.Code()),
+		Code:     def.Code,
 		Message:  st.Message(),
-		Category: category,
+		Category: def.Category,
 		Details:  make(map[string]any),
 	}
 }
 
-// grpcCodeToCategory maps gRPC status codes to error categories
-func grpcCodeToCategory(code codes.Code) ErrorCategory {
-	switch code {
-	case codes.Unauthenticated, codes.PermissionDenied:
-		return CategoryAuth
-	case codes.InvalidArgument:
-		return CategoryValidation
-	case codes.NotFound:
-		return CategoryNotFound
-	case codes.AlreadyExists:
-		return CategoryConflict
-	case codes.FailedPrecondition:
-		return CategoryBusiness
-	default:
-		return CategoryInternal
-	}
-}
-
-// grpcCodeToErrorCode generates a generic error code from gRPC status code
-func grpcCodeToErrorCode(code codes.Code) string {
+// grpcCodeToErrorDef maps gRPC status codes of errors without details to a catalog error
+func grpcCodeToErrorDef(code codes.Code) ErrorDef {
 	switch code {
 	case codes.Unauthenticated:
-		return "AUTH_UNAUTHENTICATED"
+		return AuthUnauthenticated
 	case codes.PermissionDenied:
-		return "AUTH_PERMISSION_DENIED"
+		return AuthPermissionDenied
 	case codes.InvalidArgument:
-		return "VALIDATION_INVALID_ARGUMENT"
+		return ValidationInvalidValue
 	case codes.NotFound:
-		return "NOT_FOUND_RESOURCE"
+		return NotFoundResource
 	case codes.AlreadyExists:
-		return "CONFLICT_RESOURCE_EXISTS"
+		return ConflictDuplicateResource
 	case codes.FailedPrecondition:
-		return "BUSINESS_PRECONDITION_FAILED"
+		return BusinessInvalidOperation
+	case codes.ResourceExhausted:
+		return BusinessQuotaExceeded
 	case codes.Unavailable:
-		return "INTERNAL_SERVICE_UNAVAILABLE"
+		return InternalServiceUnavailable
 	case codes.DeadlineExceeded:
-		return "INTERNAL_TIMEOUT"
+		return InternalTimeout
 	default:
-		return "INTERNAL_UNEXPECTED_ERROR"
+		return InternalUnexpectedError
 	}
 }
 
-// errorDetails is used for JSON serialization of error details
-type errorDetails struct {
-	Code     string         `json:"code"`
-	Category string         `json:"category"`
-	Message  string         `json:"message"`
-	Details  map[string]any `json:"details,omitempty"`
-}
-
-// errorDetailsProto is a simple proto-like struct for gRPC details
-// This implements the proto.Message interface minimally for gRPC status details
-type errorDetailsProto struct {
-	Json string
-}
-
-func (e *errorDetailsProto) Reset()         { *e = errorDetailsProto{} }
-func (e *errorDetailsProto) String() string { return e.Json }
-func (e *errorDetailsProto) ProtoMessage()  {}
-
 // GetGRPCCode returns the gRPC status code for an AppError
 func GetGRPCCode(err *AppError) codes.Code {
 	if err == nil {
 		return codes.OK
 	}
-
-	grpcCode := codes.Internal
-	if code, ok := categoryToGRPCCode[err.Category]; ok {
-		grpcCode = code
+	if def, ok := Lookup(err.Code); ok {
+		return def.GRPCCode
 	}
-
-	if err.Category == CategoryAuth && permissionDeniedCodes[err.Code] {
-		grpcCode = codes.PermissionDenied
+	if code, ok := categoryToGRPCCode[err.Category]; ok {
+		return code
 	}
-
-	return grpcCode
+	return codes.Internal
 }
 
 // IsGRPCError checks if an error is a gRPC status error
@@ -0,0 +1,131 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToGRPCError_Details(t *testing.T) {
+	appErr := Validation(ValidationRequiredFields, "tenant_id").WithDetails("limit", 3)
+
+	grpcErr := ToGRPCError(appErr)
+	st, ok := status.FromError(grpcErr)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	require.Len(t, st.Details(), 2)
+	detail, ok := st.Details()[0].(*infrav1.Error)
+	require.True(t, ok)
+	assert.Equal(t, ValidationRequiredFields.Code, detail.GetCode())
+	assert.Equal(t, infrav1.ErrorCode_ERROR_CODE_VALIDATION_REQUIRED_FIELDS, detail.GetErrorCode())
+	assert.Equal(t, infrav1.ErrorCategory_ERROR_CATEGORY_VALIDATION, detail.GetCategory())
+	assert.False(t, detail.GetRetryable())
+
+	errorInfo, ok := st.Details()[1].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, ValidationRequiredFields.Code, errorInfo.GetReason())
+	assert.Equal(t, ErrorDomain, errorInfo.GetDomain())
+	assert.Equal(t, "ERROR_CATEGORY_VALIDATION", errorInfo.GetMetadata()["category"])
+	assert.Equal(t, "3", errorInfo.GetMetadata()["limit"])
+
+	// Round trip keeps code, category and details
+	back := FromGRPCError(grpcErr)
+	assert.Equal(t, appErr.Code, back.Code)
+	assert.Equal(t, appErr.Category, back.Category)
+	assert.Equal(t, appErr.Message, back.Message)
+	assert.Equal(t, []any{"tenant_id"}, back.Details["fields"])
+	assert.Equal(t, float64(3), back.Details["limit"])
+}
+
+func TestToGRPCError_Wrapped(t *testing.T) {
+	grpcErr := ToGRPCError(fmt.Errorf("loading user: %w", NotFound(NotFoundResource, "user", "user-1")))
+	assert.Equal(t, codes.NotFound, status.Code(grpcErr))
+	assert.Equal(t, NotFoundResource.Code, FromGRPCError(grpcErr).Code)
+}
+
+func TestFromGRPCError_ErrorInfo(t *testing.T) {
+	st, err := status.New(codes.PermissionDenied, "denied").WithDetails(&errdetails.ErrorInfo{Reason: AuthPermissionDenied.Code, Domain: ErrorDomain})
+	require.NoError(t, err)
+	appErr := FromGRPCError(st.Err())
+	assert.Equal(t, AuthPermissionDenied.Code, appErr.Code)
+	assert.Equal(t, CategoryAuth, appErr.Category)
+
+	// Reasons of other domains are not catalog codes
+	st, err = status.New(codes.Aborted, "aborted").WithDetails(&errdetails.ErrorInfo{Reason: AuthPermissionDenied.Code, Domain: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, InternalUnexpectedError.Code, FromGRPCError(st.Err()).Code)
+}
+
+func TestToGRPCError_NonAppError(t *testing.T) {
+	grpcErr := ToGRPCError(errors.New("boom"))
+	st, ok := status.FromError(grpcErr)
+	require.True(t, ok)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, InternalUnexpectedError.Code, FromGRPCError(grpcErr).Code)
+}
+
+func TestFromGRPCError_WithoutDetails(t *testing.T) {
+	tests := []struct {
+		code     codes.Code
+		wantCode string
+	}{
+		{code: codes.Unauthenticated, wantCode: AuthUnauthenticated.Code},
+		{code: codes.PermissionDenied, wantCode: AuthPermissionDenied.Code},
+		{code: codes.NotFound, wantCode: NotFoundResource.Code},
+		{code: codes.Unavailable, wantCode: InternalServiceUnavailable.Code},
+		{code: codes.Unknown, wantCode: InternalUnexpectedError.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			appErr := FromGRPCError(status.Error(tt.code, "message"))
+			assert.Equal(t, tt.wantCode, appErr.Code)
+			assert.Equal(t, "message", appErr.Message)
+			_, known := Lookup(appErr.Code)
+			assert.True(t, known)
+		})
+	}
+}
+
+func TestGRPCError_Nil(t *testing.T) {
+	assert.NoError(t, ToGRPCError(nil))
+	assert.Nil(t, FromGRPCError(nil))
+	assert.Equal(t, codes.OK, GetGRPCCode(nil))
+}
+
+func TestFromGRPCError_NonStatus(t *testing.T) {
+	cause := errors.New("connection reset")
+	appErr := FromGRPCError(cause)
+	assert.Equal(t, InternalUnexpectedError.Code, appErr.Code)
+	assert.Equal(t, CategoryInternal, appErr.Category)
+	assert.Equal(t, "connection reset", appErr.Message)
+	assert.ErrorIs(t, appErr, cause)
+}
+
+func TestFromProto_Malformed(t *testing.T) {
+	appErr := FromProto(&infrav1.Error{
+		Code:     "TEST_CODE",
+		Message:  "message",
+		Category: infrav1.ErrorCategory(99),
+		Details:  map[string]string{"raw": "not json", "count": "2"},
+	})
+	assert.Equal(t, "TEST_CODE", appErr.Code)
+	assert.Empty(t, appErr.Category)
+	// Values that are not JSON are kept as sent
+	assert.Equal(t, "not json", appErr.Details["raw"])
+	assert.Equal(t, float64(2), appErr.Details["count"])
+}
+
+func TestToProto_UnencodableDetails(t *testing.T) {
+	appErr := Validation(ValidationInvalidValue).WithDetails("callback", func() {}).WithDetails("limit", 3)
+	protoErr := ToProto(appErr)
+	assert.NotContains(t, protoErr.GetDetails(), "callback")
+	assert.Equal(t, "3", protoErr.GetDetails()["limit"])
+}
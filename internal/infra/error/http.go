@@ -0,0 +1,27 @@
+package error
+
+import "net/http"
+
+// categoryToHTTPStatus maps error categories to HTTP status codes, used for codes outside the catalog
+var categoryToHTTPStatus = map[ErrorCategory]int{
+	CategoryAuth:       http.StatusUnauthorized,
+	CategoryValidation: http.StatusBadRequest,
+	CategoryNotFound:   http.StatusNotFound,
+	CategoryConflict:   http.StatusConflict,
+	CategoryBusiness:   http.StatusBadRequest,
+	CategoryInternal:   http.StatusInternalServerError,
+}
+
+// GetHTTPStatus returns the HTTP status code for an AppError
+func GetHTTPStatus(err *AppError) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if def, ok := Lookup(err.Code); ok {
+		return def.HTTPStatus
+	}
+	if status, ok := categoryToHTTPStatus[err.Category]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
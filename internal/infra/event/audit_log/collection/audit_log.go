@@ -1,14 +1,36 @@
 package collection
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
 	eventv1 "erp.localhost/internal/infra/model/event/v1"
 	validator_event "erp.localhost/internal/infra/model/event/validator"
+	"go.mongodb.org/mongo-driver/bson"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// aggregator runs pipelines on the audit logs of a tenant, collection.BaseCollectionHandler
+type aggregator interface {
+	Aggregate(ctx context.Context, tenantID string, pipeline []bson.M, results any) error
+}
+
+// AuditLogPosition is the place of an audit log in the tail order of its tenant, by timestamp then id. The
+// timestamps are stored with millisecond precision
+type AuditLogPosition struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// PositionOf returns the position of auditLog
+func PositionOf(auditLog *eventv1.AuditLog) AuditLogPosition {
+	return AuditLogPosition{Timestamp: auditLog.GetTimestamp().AsTime(), ID: auditLog.GetId()}
+}
+
 // TODO: move this to Events service and consume from kafka topics
 type AuditLogsCollection struct {
 	collection collection.CollectionHandler[eventv1.AuditLog]
@@ -22,7 +44,7 @@ func NewAuditLogsCollection(collection collection.CollectionHandler[eventv1.Audi
 	}
 }
 
-func (c *AuditLogsCollection) CreateAuditLog(tenantID string, auditLog *eventv1.AuditLog) error {
+func (c *AuditLogsCollection) CreateAuditLog(ctx context.Context, tenantID string, auditLog *eventv1.AuditLog) error {
 	if tenantID == "" {
 		return infra_error.Validation(infra_error.ValidationRequiredFields, "tenantID")
 	}
@@ -34,7 +56,7 @@ func (c *AuditLogsCollection) CreateAuditLog(tenantID string, auditLog *eventv1.
 
 	auditLog.Timestamp = timestamppb.Now()
 	c.logger.Debug("Creating audit log", "auditLog", auditLog)
-	_, err := c.collection.Create(auditLog)
+	_, err := c.collection.Create(ctx, auditLog)
 	if err != nil {
 		return err
 	}
@@ -57,7 +79,7 @@ func (c *AuditLogsCollection) CreateAuditLog(tenantID string, auditLog *eventv1.
 // - resource_type
 // - resource_id
 // - resource_name
-func (c *AuditLogsCollection) GetAuditLogsByFilter(tenantID string, filter map[string]any) ([]*eventv1.AuditLog, error) {
+func (c *AuditLogsCollection) GetAuditLogsByFilter(ctx context.Context, tenantID string, filter map[string]any) ([]*eventv1.AuditLog, error) {
 	if tenantID == "" {
 		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenantID")
 	}
@@ -65,9 +87,95 @@ func (c *AuditLogsCollection) GetAuditLogsByFilter(tenantID string, filter map[s
 		filter = make(map[string]any)
 	}
 	filter["tenant_id"] = tenantID
-	auditLogs, err := c.collection.FindAll(filter)
+	auditLogs, err := c.collection.FindAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 	return auditLogs, nil
 }
+
+// TailAuditLogs returns up to limit audit logs of the tenant matching filter, stored after position, from the first
+// one when nil, and stamped no later than until, in tail order
+func (c *AuditLogsCollection) TailAuditLogs(ctx context.Context, tenantID string, filter map[string]any, after *AuditLogPosition, until time.Time, limit int) ([]*eventv1.AuditLog, error) {
+	if tenantID == "" {
+		return nil, infra_error.Validation(infra_error.ValidationRequiredFields, "tenantID")
+	}
+	aggregator, ok := c.collection.(aggregator)
+	if !ok {
+		return nil, infra_error.Internal(infra_error.InternalDatabaseError, errors.New("tailing the audit logs needs an aggregating collection handler"))
+	}
+
+	conditions := bson.A{bson.M{"timestamp": bson.M{"$lte": until.UTC()}}}
+	if len(filter) > 0 {
+		conditions = append(conditions, bson.M(filter))
+	}
+	if after != nil {
+		timestamp := after.Timestamp.UTC()
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"timestamp": bson.M{"$gt": timestamp}},
+			bson.M{"timestamp": timestamp, "_id": bson.M{"$gt": after.ID}},
+		}})
+	}
+	pipeline := []bson.M{
+		{"$match": bson.M{"$and": conditions}},
+		{"$sort": bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}},
+		{"$limit": int64(limit)},
+	}
+
+	auditLogs := []*eventv1.AuditLog{}
+	if err := aggregator.Aggregate(ctx, tenantID, pipeline, &auditLogs); err != nil {
+		return nil, err
+	}
+	return auditLogs, nil
+}
+
+// AnonymizeAuditLogs replaces the names of userID in the audit logs of the tenant with name and clears the request
+// context of the logs userID is the actor of. The actor and target ids are kept so the logs still refer to the
+// user, it returns how many logs were anonymized
+func (c *AuditLogsCollection) AnonymizeAuditLogs(ctx context.Context, tenantID, userID, name string) (int, error) {
+	if tenantID == "" || userID == "" {
+		return 0, infra_error.Validation(infra_error.ValidationRequiredFields, "tenantID", "userID")
+	}
+	auditLogs, err := c.collection.FindAll(ctx, map[string]any{
+		"tenant_id": tenantID,
+		"$or":       []any{map[string]any{"actor_id": userID}, map[string]any{"target_id": userID}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	anonymized := 0
+	for _, auditLog := range auditLogs {
+		if !anonymizeAuditLog(auditLog, userID, name) {
+			continue
+		}
+		if err := c.collection.Update(ctx, map[string]any{"tenant_id": tenantID, "_id": auditLog.GetId()}, auditLog); err != nil {
+			c.logger.Error("Failed to anonymize audit log", "tenantID", tenantID, "auditLogID", auditLog.GetId(), "error", err)
+			return anonymized, err
+		}
+		anonymized++
+	}
+	return anonymized, nil
+}
+
+// anonymizeAuditLog replaces the names of userID in auditLog and clears the request context when userID is the actor,
+// it reports whether auditLog changed. The stored fields are omitempty so the names are replaced, not cleared
+func anonymizeAuditLog(auditLog *eventv1.AuditLog, userID, name string) bool {
+	changed := false
+	if auditLog.GetActorId() == userID {
+		if auditLog.GetActorName() != "" && auditLog.GetActorName() != name {
+			auditLog.ActorName = name
+			changed = true
+		}
+		if auditContext := auditLog.GetContext(); auditContext.GetIpAddress() != "" || auditContext.GetUserAgent() != "" || auditContext.GetLocation() != "" {
+			auditContext.IpAddress = ""
+			auditContext.UserAgent = ""
+			auditContext.Location = ""
+			changed = true
+		}
+	}
+	if auditLog.GetTargetId() == userID && auditLog.GetTargetName() != "" && auditLog.GetTargetName() != name {
+		auditLog.TargetName = name
+		changed = true
+	}
+	return changed
+}
@@ -1,6 +1,7 @@
 package collection
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -134,13 +135,13 @@ func TestAuditLogsCollection_CreateAuditLog(t *testing.T) {
 			// Only expect Create call if we expect it to be called
 			if tc.expectedCallTimes > 0 {
 				mockHandler.EXPECT().
-					Create(auditLogMatcher{expected: tc.auditLog}).
+					Create(gomock.Any(), auditLogMatcher{expected: tc.auditLog}).
 					Return(tc.returnID, tc.returnError).
 					Times(tc.expectedCallTimes)
 			}
 
 			collection := NewAuditLogsCollection(mockHandler, baseAuditLogLogger)
-			err := collection.CreateAuditLog(tc.tenantID, tc.auditLog)
+			err := collection.CreateAuditLog(context.Background(), tc.tenantID, tc.auditLog)
 
 			if tc.expectedError != nil {
 				require.Error(t, err)
@@ -292,13 +293,13 @@ func TestAuditLogsCollection_GetAuditLogsByFilter(t *testing.T) {
 			// Only expect FindAll call if we expect it to be called
 			if tc.expectedCallTimes > 0 {
 				mockHandler.EXPECT().
-					FindAll(tc.expectedFilter).
+					FindAll(gomock.Any(), tc.expectedFilter).
 					Return(tc.returnLogs, tc.returnError).
 					Times(tc.expectedCallTimes)
 			}
 
 			collection := NewAuditLogsCollection(mockHandler, baseAuditLogLogger)
-			logs, err := collection.GetAuditLogsByFilter(tc.tenantID, tc.filter)
+			logs, err := collection.GetAuditLogsByFilter(context.Background(), tc.tenantID, tc.filter)
 
 			if tc.expectedError != nil {
 				require.Error(t, err)
@@ -311,3 +312,55 @@ func TestAuditLogsCollection_GetAuditLogsByFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditLogsCollection_AnonymizeAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	asActor := &eventv1.AuditLog{
+		Id:        "log-1",
+		TenantId:  "tenant-1",
+		ActorId:   "user-1",
+		ActorName: "john@example.com",
+		Context:   &eventv1.AuditContext{IpAddress: "10.0.0.1", UserAgent: "curl", RequestId: "request-1"},
+	}
+	asTarget := &eventv1.AuditLog{
+		Id:         "log-2",
+		TenantId:   "tenant-1",
+		ActorId:    "admin-1",
+		ActorName:  "admin@example.com",
+		TargetId:   "user-1",
+		TargetName: "john@example.com",
+	}
+	// Already anonymized logs are not written again
+	unchanged := &eventv1.AuditLog{Id: "log-3", TenantId: "tenant-1", TargetId: "user-1"}
+
+	mockHandler := mock_collection.NewMockCollectionHandler[eventv1.AuditLog](ctrl)
+	mockHandler.EXPECT().
+		FindAll(gomock.Any(), gomock.Any()).
+		Return([]*eventv1.AuditLog{asActor, asTarget, unchanged}, nil)
+	mockHandler.EXPECT().
+		Update(gomock.Any(), map[string]any{"tenant_id": "tenant-1", "_id": "log-1"}, asActor).
+		Return(nil)
+	mockHandler.EXPECT().
+		Update(gomock.Any(), map[string]any{"tenant_id": "tenant-1", "_id": "log-2"}, asTarget).
+		Return(nil)
+
+	collection := NewAuditLogsCollection(mockHandler, baseAuditLogLogger)
+	anonymized, err := collection.AnonymizeAuditLogs(context.Background(), "tenant-1", "user-1", "erased user")
+	require.NoError(t, err)
+	assert.Equal(t, 2, anonymized)
+
+	assert.Equal(t, "user-1", asActor.GetActorId())
+	assert.Equal(t, "erased user", asActor.GetActorName())
+	assert.Empty(t, asActor.GetContext().GetIpAddress())
+	assert.Empty(t, asActor.GetContext().GetUserAgent())
+	assert.Equal(t, "request-1", asActor.GetContext().GetRequestId())
+
+	assert.Equal(t, "admin@example.com", asTarget.GetActorName())
+	assert.Equal(t, "user-1", asTarget.GetTargetId())
+	assert.Equal(t, "erased user", asTarget.GetTargetName())
+
+	_, err = collection.AnonymizeAuditLogs(context.Background(), "tenant-1", "", "erased user")
+	assert.True(t, infra_error.Validation(infra_error.ValidationRequiredFields).Is(err))
+}
@@ -0,0 +1,121 @@
+// Package broker publishes the domain events of the services to a message broker, the broker of a service is
+// selected by its configuration so the other modules can consume from the one they run
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"erp.localhost/internal/infra/db/memory"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+//go:generate mockgen -destination=mock/mock_broker.go -package=mock erp.localhost/internal/infra/event/broker Broker
+
+// Kind selects the broker the events are published to
+type Kind string
+
+const (
+	// KindLog only logs the events, for development without a broker
+	KindLog Kind = "log"
+	// KindRedis appends the events to Redis streams, connected with the REDIS_* settings
+	KindRedis Kind = "redis"
+	// KindNATS publishes the events to NATS subjects
+	KindNATS Kind = "nats"
+	// KindKafka produces the events to Kafka topics through a Kafka REST proxy (v2 API)
+	KindKafka Kind = "kafka"
+)
+
+// Message is an event as published to the broker
+type Message struct {
+	// Topic is the stream, subject or topic of the message
+	Topic string
+	// Key orders the messages, the messages of a key go to the same partition
+	Key   string
+	Value []byte
+	// Headers describe the message, e.g. its event type and id
+	Headers map[string]string
+}
+
+// Broker publishes messages to a message broker
+type Broker interface {
+	// Publish returns once the broker accepted the message
+	Publish(ctx context.Context, msg *Message) error
+	Close() error
+}
+
+// Config selects and configures the broker of a service, loaded with infra_config.Load
+type Config struct {
+	Kind Kind `yaml:"kind" env:"EVENTS_BROKER" flag:"events-broker" default:"log"`
+	// Addresses are the host:port of the NATS servers or the URLs of the Kafka REST proxies, tried in order
+	Addresses []string `yaml:"addresses" env:"EVENTS_BROKER_ADDRS"`
+	// TopicPrefix is prepended to the topics, e.g. erp.auth.user
+	TopicPrefix string `yaml:"topic_prefix" env:"EVENTS_TOPIC_PREFIX" default:"erp"`
+	// StreamMaxLen caps the Redis streams to about this many entries, 0 keeps every entry
+	StreamMaxLen int64         `yaml:"stream_max_len" env:"EVENTS_STREAM_MAX_LEN" default:"100000"`
+	Timeout      time.Duration `yaml:"timeout" env:"EVENTS_BROKER_TIMEOUT" default:"5s"`
+}
+
+// Validate checks the settings of the selected broker are complete
+func (c *Config) Validate() error {
+	switch c.Kind {
+	case KindLog, KindRedis:
+	case KindNATS, KindKafka:
+		if len(c.Addresses) == 0 {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "EVENTS_BROKER_ADDRS")
+		}
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "EVENTS_BROKER").
+			WithError(fmt.Errorf("unknown events broker %q", c.Kind))
+	}
+	return nil
+}
+
+// Topic returns the topic of name with the configured prefix
+func (c *Config) Topic(name string) string {
+	if c.TopicPrefix == "" {
+		return name
+	}
+	return c.TopicPrefix + "." + name
+}
+
+// New creates the broker selected by config
+func New(config *Config, logger logger.Logger) (Broker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	switch config.Kind {
+	case KindRedis:
+		if memory.Enabled() {
+			logger.Warn("in-memory mode has no redis streams, logging the messages instead")
+			return NewLogBroker(logger), nil
+		}
+		return NewRedisBroker(config.StreamMaxLen, logger)
+	case KindNATS:
+		return NewNATSBroker(config.Addresses, config.Timeout, logger), nil
+	case KindKafka:
+		return NewKafkaBroker(config.Addresses, config.Timeout, logger), nil
+	default:
+		return NewLogBroker(logger), nil
+	}
+}
+
+// LogBroker logs the messages instead of publishing them
+type LogBroker struct {
+	logger logger.Logger
+}
+
+func NewLogBroker(logger logger.Logger) *LogBroker {
+	return &LogBroker{logger: logger}
+}
+
+func (b *LogBroker) Publish(ctx context.Context, msg *Message) error {
+	b.logger.Info("event published", "topic", msg.Topic, "key", msg.Key, "headers", msg.Headers, "size", len(msg.Value))
+	return nil
+}
+
+func (b *LogBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,157 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "log", config: Config{Kind: KindLog}},
+		{name: "redis", config: Config{Kind: KindRedis}},
+		{name: "nats", config: Config{Kind: KindNATS, Addresses: []string{"localhost:4222"}}},
+		{name: "nats without addresses", config: Config{Kind: KindNATS}, wantErr: true},
+		{name: "kafka without addresses", config: Config{Kind: KindKafka}, wantErr: true},
+		{name: "unknown broker", config: Config{Kind: "sqs"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfig_Topic(t *testing.T) {
+	assert.Equal(t, "erp.auth.user", (&Config{TopicPrefix: "erp"}).Topic("auth.user"))
+	assert.Equal(t, "auth.user", (&Config{}).Topic("auth.user"))
+}
+
+// natsServer accepts one connection and records the messages published on it, replying -ERR to the subjects in
+// reject
+func natsServer(t *testing.T, headers bool, reject string) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	published := make(chan string, 10)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"headers\":%t}\r\n", headers)
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			switch fields[0] {
+			case "PING":
+				conn.Write([]byte("PONG\r\n"))
+			case "PUB", "HPUB":
+				size, _ := strconv.Atoi(fields[len(fields)-1])
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				if fields[1] == reject {
+					conn.Write([]byte("-ERR 'Permissions Violation'\r\n"))
+					continue
+				}
+				published <- fields[0] + " " + fields[1] + " " + string(payload[:size])
+			}
+		}
+	}()
+	return listener.Addr().String(), published
+}
+
+func TestNATSBroker_Publish(t *testing.T) {
+	address, published := natsServer(t, false, "erp.denied")
+	broker := NewNATSBroker([]string{"127.0.0.1:1", "nats://" + address}, time.Second, logger.NewBaseLogger(shared.ModuleAuth))
+	defer broker.Close()
+
+	err := broker.Publish(context.Background(), &Message{Topic: "erp.auth.user", Key: "u1", Value: []byte("payload")})
+	require.NoError(t, err)
+	assert.Equal(t, "PUB erp.auth.user payload", <-published)
+
+	// Errors of the server are reported
+	err = broker.Publish(context.Background(), &Message{Topic: "erp.denied", Value: []byte("payload")})
+	assert.ErrorContains(t, err, "Permissions Violation")
+}
+
+func TestNATSBroker_PublishHeaders(t *testing.T) {
+	address, published := natsServer(t, true, "")
+	broker := NewNATSBroker([]string{address}, time.Second, logger.NewBaseLogger(shared.ModuleAuth))
+	defer broker.Close()
+
+	err := broker.Publish(context.Background(), &Message{
+		Topic:   "erp.auth.user",
+		Value:   []byte("payload"),
+		Headers: map[string]string{"event-type": "auth.user.created"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "HPUB erp.auth.user NATS/1.0\r\nevent-type: auth.user.created\r\n\r\npayload", <-published)
+}
+
+func TestKafkaBroker_Publish(t *testing.T) {
+	var received kafkaRecords
+	var path, contentType string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&received)
+		fmt.Fprint(w, `{"offsets":[{"partition":0,"offset":7}]}`)
+	}))
+	defer proxy.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	broker := NewKafkaBroker([]string{down.URL, proxy.URL}, time.Second, logger.NewBaseLogger(shared.ModuleAuth))
+	err := broker.Publish(context.Background(), &Message{Topic: "erp.auth.user", Key: "u1", Value: []byte("payload")})
+	require.NoError(t, err)
+	assert.Equal(t, "/topics/erp.auth.user", path)
+	assert.Equal(t, "application/vnd.kafka.binary.v2+json", contentType)
+	require.Len(t, received.Records, 1)
+	assert.Equal(t, "u1", string(received.Records[0].Key))
+	assert.Equal(t, "payload", string(received.Records[0].Value))
+}
+
+func TestKafkaBroker_PublishRejected(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"offsets":[{"partition":0,"offset":-1,"error_code":40403,"error":"topic not found"}]}`)
+	}))
+	defer proxy.Close()
+
+	broker := NewKafkaBroker([]string{proxy.URL}, time.Second, logger.NewBaseLogger(shared.ModuleAuth))
+	err := broker.Publish(context.Background(), &Message{Topic: "erp.missing", Value: []byte("payload")})
+	assert.ErrorContains(t, err, "topic not found")
+}
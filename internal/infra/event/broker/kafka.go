@@ -0,0 +1,113 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// KafkaBroker produces the messages to the Kafka topic of their topic through the v2 API of a Kafka REST proxy.
+// The API has no record headers, consumers read the event type and id from the value
+type KafkaBroker struct {
+	proxies []string
+	client  *http.Client
+	logger  logger.Logger
+}
+
+// NewKafkaBroker creates a broker producing through the first proxy of proxies that accepts the records
+func NewKafkaBroker(proxies []string, timeout time.Duration, logger logger.Logger) *KafkaBroker {
+	return &KafkaBroker{
+		proxies: proxies,
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}
+}
+
+// kafkaRecords is the body of a produce request, keys and values are base64 encoded by encoding/json
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   []byte `json:"key,omitempty"`
+	Value []byte `json:"value"`
+}
+
+// kafkaOffsets is the body of a produce response, a record rejected by Kafka has an error
+type kafkaOffsets struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		Offset    int64  `json:"offset"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (b *KafkaBroker) Publish(ctx context.Context, msg *Message) error {
+	record := kafkaRecord{Value: msg.Value}
+	if msg.Key != "" {
+		record.Key = []byte(msg.Key)
+	}
+	body, err := json.Marshal(kafkaRecords{Records: []kafkaRecord{record}})
+	if err != nil {
+		return infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+
+	var errs []error
+	for _, proxy := range b.proxies {
+		err := b.produce(ctx, proxy, msg.Topic, body)
+		if err == nil {
+			return nil
+		}
+		b.logger.Warn("failed to produce to kafka", "proxy", proxy, "topic", msg.Topic, "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", proxy, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return infra_error.Internal(infra_error.InternalServiceUnavailable, errors.Join(errs...))
+}
+
+func (b *KafkaBroker) produce(ctx context.Context, proxy, topic string, body []byte) error {
+	endpoint := fmt.Sprintf("%s/topics/%s", strings.TrimRight(proxy, "/"), url.PathEscape(topic))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.binary.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("kafka rest proxy returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	var offsets kafkaOffsets
+	if err := json.NewDecoder(resp.Body).Decode(&offsets); err != nil {
+		return fmt.Errorf("decode kafka rest proxy response: %w", err)
+	}
+	for _, offset := range offsets.Offsets {
+		if offset.Error != "" {
+			return fmt.Errorf("kafka rejected the record: %s", offset.Error)
+		}
+	}
+	return nil
+}
+
+func (b *KafkaBroker) Close() error {
+	b.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,179 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// NATSBroker publishes the messages to the NATS subject of their topic over the NATS client protocol, the headers
+// are sent with HPUB when the server supports them. A publish waits for the server to answer a PING sent after it
+// so errors of the server are reported. TLS and authentication are not supported
+type NATSBroker struct {
+	addresses []string
+	timeout   time.Duration
+	logger    logger.Logger
+
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+	headers bool
+}
+
+// NewNATSBroker creates a broker connecting to the first reachable server of addresses (host:port or
+// nats://host:port) on the first publish, and again after a failed one
+func NewNATSBroker(addresses []string, timeout time.Duration, logger logger.Logger) *NATSBroker {
+	return &NATSBroker{
+		addresses: addresses,
+		timeout:   timeout,
+		logger:    logger,
+	}
+}
+
+// natsInfo is the part of the INFO message of the server the broker uses
+type natsInfo struct {
+	Headers bool `json:"headers"`
+}
+
+func (b *NATSBroker) Publish(ctx context.Context, msg *Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn == nil {
+		if err := b.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := b.publish(ctx, msg); err != nil {
+		b.disconnect()
+		return infra_error.Internal(infra_error.InternalServiceUnavailable, err)
+	}
+	return nil
+}
+
+func (b *NATSBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.disconnect()
+	return nil
+}
+
+// connect opens a connection to the first server that completes the handshake
+func (b *NATSBroker) connect(ctx context.Context) error {
+	var errs []error
+	for _, address := range b.addresses {
+		address = strings.TrimPrefix(address, "nats://")
+		if err := b.dial(ctx, address); err != nil {
+			b.logger.Warn("failed to connect to nats", "address", address, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", address, err))
+			continue
+		}
+		return nil
+	}
+	return infra_error.Internal(infra_error.InternalServiceUnavailable, errors.Join(errs...))
+}
+
+func (b *NATSBroker) dial(ctx context.Context, address string) error {
+	dialer := net.Dialer{Timeout: b.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(b.deadline(ctx))
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	payload, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		conn.Close()
+		return fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(payload), &info); err != nil {
+		conn.Close()
+		return fmt.Errorf("decode server info: %w", err)
+	}
+
+	connect := fmt.Sprintf(`CONNECT {"verbose":false,"pedantic":false,"headers":%t,"name":"erp"}`, info.Headers)
+	if _, err := fmt.Fprintf(conn, "%s\r\nPING\r\n", connect); err != nil {
+		conn.Close()
+		return err
+	}
+	b.conn, b.reader, b.headers = conn, reader, info.Headers
+	if err := b.awaitPong(); err != nil {
+		b.disconnect()
+		return err
+	}
+	return nil
+}
+
+func (b *NATSBroker) publish(ctx context.Context, msg *Message) error {
+	b.conn.SetDeadline(b.deadline(ctx))
+	var frame strings.Builder
+	if b.headers && len(msg.Headers) > 0 {
+		var header strings.Builder
+		header.WriteString("NATS/1.0\r\n")
+		for name, value := range msg.Headers {
+			fmt.Fprintf(&header, "%s: %s\r\n", name, value)
+		}
+		header.WriteString("\r\n")
+		fmt.Fprintf(&frame, "HPUB %s %d %d\r\n%s", msg.Topic, header.Len(), header.Len()+len(msg.Value), header.String())
+	} else {
+		fmt.Fprintf(&frame, "PUB %s %d\r\n", msg.Topic, len(msg.Value))
+	}
+	frame.Write(msg.Value)
+	frame.WriteString("\r\nPING\r\n")
+	if _, err := b.conn.Write([]byte(frame.String())); err != nil {
+		return err
+	}
+	return b.awaitPong()
+}
+
+// awaitPong reads the messages of the server until the PONG answering the last PING, the server reports errors
+// of the publish before it
+func (b *NATSBroker) awaitPong() error {
+	for {
+		line, err := b.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := b.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", strings.Trim(strings.TrimPrefix(line, "-ERR"), " '"))
+		}
+	}
+}
+
+func (b *NATSBroker) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(b.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+func (b *NATSBroker) disconnect() {
+	if b.conn != nil {
+		b.conn.Close()
+	}
+	b.conn, b.reader = nil, nil
+}
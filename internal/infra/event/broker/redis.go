@@ -0,0 +1,42 @@
+package broker
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/db/redis"
+	"erp.localhost/internal/infra/logging/logger"
+	model_redis "erp.localhost/internal/infra/model/db/redis"
+)
+
+// RedisBroker appends the messages to the Redis stream of their topic, consumers read the streams with consumer
+// groups. The key and headers are fields of the entries next to the value
+type RedisBroker struct {
+	handler *redis.BaseRedisHandler
+	maxLen  int64
+}
+
+// NewRedisBroker creates a broker connected with the Redis configuration from the environment, the streams are
+// trimmed to about maxLen entries
+func NewRedisBroker(maxLen int64, logger logger.Logger) (*RedisBroker, error) {
+	handler, err := redis.NewBaseRedisHandler(model_redis.RedisKeyEventStream, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisBroker{handler: handler, maxLen: maxLen}, nil
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, msg *Message) error {
+	values := map[string]any{
+		"key":   msg.Key,
+		"value": msg.Value,
+	}
+	for name, value := range msg.Headers {
+		values["header:"+name] = value
+	}
+	_, err := b.handler.XAdd(ctx, msg.Topic, b.maxLen, values)
+	return err
+}
+
+func (b *RedisBroker) Close() error {
+	return b.handler.Close()
+}
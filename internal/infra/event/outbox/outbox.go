@@ -0,0 +1,169 @@
+// Package outbox publishes the domain events of a service with the transactional outbox pattern. Events are
+// written to the outbox collection in the transaction of the change they describe and a publisher sends them to
+// the broker afterwards, so an event is published if and only if its change is committed. Delivery is at least
+// once, consumers drop duplicates by event id
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var eventsLost = metrics.NewCounter("outbox_events_lost_total",
+	"Total number of events of committed changes that could not be written to the outbox, by event type.", "type")
+
+// Subscriber is called with every event written to the outbox, in the transaction of the change. An error fails
+// the write of the event like an error of the outbox itself
+type Subscriber func(ctx context.Context, event *eventv1.DomainEvent) error
+
+// Outbox stores the domain events of a service until they are published. A nil Outbox runs the changes without
+// recording their events
+type Outbox struct {
+	collection  collection.CollectionHandler[eventv1.OutboxEvent]
+	subscribers []Subscriber
+	logger      logger.Logger
+}
+
+func NewOutbox(collection collection.CollectionHandler[eventv1.OutboxEvent], logger logger.Logger) *Outbox {
+	return &Outbox{
+		collection: collection,
+		logger:     logger,
+	}
+}
+
+// Subscribe calls subscriber with the events written from then on, subscribers are added before the outbox is used
+func (o *Outbox) Subscribe(subscriber Subscriber) {
+	if o == nil {
+		return
+	}
+	o.subscribers = append(o.subscribers, subscriber)
+}
+
+// NewEvent creates an event of eventType made by actorID in tenantID, the caller sets its payload
+func NewEvent(eventType, tenantID, actorID string) *eventv1.DomainEvent {
+	return &eventv1.DomainEvent{
+		Id:         uuid.NewString(),
+		Type:       eventType,
+		TenantId:   tenantID,
+		ActorId:    actorID,
+		OccurredAt: timestamppb.Now(),
+	}
+}
+
+// AggregateID returns the id of the entity the event is about, the events of an entity are published in order
+func AggregateID(event *eventv1.DomainEvent) string {
+	switch payload := event.GetPayload().(type) {
+	case *eventv1.DomainEvent_User:
+		return payload.User.GetUserId()
+	case *eventv1.DomainEvent_Tenant:
+		return payload.Tenant.GetTenantId()
+	case *eventv1.DomainEvent_Role:
+		return payload.Role.GetRoleId()
+	case *eventv1.DomainEvent_Permission:
+		return payload.Permission.GetPermissionId()
+	case *eventv1.DomainEvent_Login:
+		return payload.Login.GetUserId()
+	case *eventv1.DomainEvent_Inventory:
+		return payload.Inventory.GetStockItemId()
+	default:
+		return event.GetTenantId()
+	}
+}
+
+// Record runs change and writes the events it returns in one transaction. On deployments without transactions
+// the events are written after the change, events that fail to be written then are logged and counted as lost
+// since the change is already committed
+func (o *Outbox) Record(ctx context.Context, change func(ctx context.Context) ([]*eventv1.DomainEvent, error)) error {
+	if o == nil {
+		_, err := change(ctx)
+		return err
+	}
+	if transactor, ok := o.collection.(mongo.Transactor); ok {
+		err := transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			events, err := change(ctx)
+			if err != nil {
+				return err
+			}
+			return o.Add(ctx, events...)
+		})
+		if !errors.Is(err, mongo.ErrTransactionsUnsupported) {
+			return err
+		}
+	}
+
+	events, err := change(ctx)
+	if err != nil {
+		return err
+	}
+	if err := o.Add(ctx, events...); err != nil {
+		for _, event := range events {
+			eventsLost.Inc(event.GetType())
+		}
+		o.logger.Error("failed to write the events of a committed change to the outbox", "count", len(events), "error", err)
+	}
+	return nil
+}
+
+// Add writes events to the outbox, in the transaction of ctx if it has one
+func (o *Outbox) Add(ctx context.Context, events ...*eventv1.DomainEvent) error {
+	if o == nil {
+		return nil
+	}
+	for _, event := range events {
+		if event.GetId() == "" || event.GetType() == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "Id", "Type")
+		}
+		payload, err := proto.Marshal(event)
+		if err != nil {
+			return infra_error.Internal(infra_error.InternalUnexpectedError, err)
+		}
+		now := timestamppb.Now()
+		record := &eventv1.OutboxEvent{
+			EventId:       event.GetId(),
+			Type:          event.GetType(),
+			TenantId:      event.GetTenantId(),
+			Aggregate:     model_event.AggregateOf(event.GetType()),
+			AggregateId:   AggregateID(event),
+			Payload:       payload,
+			Status:        model_event.OutboxStatusPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		}
+		if _, err := o.collection.Create(ctx, record); err != nil {
+			o.logger.Error("failed to write event to the outbox", "event_id", event.GetId(), "type", event.GetType(), "error", err)
+			return err
+		}
+		for _, subscriber := range o.subscribers {
+			if err := subscriber(ctx, event); err != nil {
+				o.logger.Error("outbox subscriber failed", "event_id", event.GetId(), "type", event.GetType(), "error", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Pending returns the pending events due for an attempt at now, oldest first
+func (o *Outbox) Pending(ctx context.Context, now time.Time) ([]*eventv1.OutboxEvent, error) {
+	return o.collection.FindAll(ctx, map[string]any{
+		"status":          model_event.OutboxStatusPending,
+		"next_attempt_at": map[string]any{"$lte": now},
+	})
+}
+
+// update stores the delivery state of a record
+func (o *Outbox) update(ctx context.Context, record *eventv1.OutboxEvent) error {
+	return o.collection.Update(ctx, map[string]any{"_id": record.GetId()}, record)
+}
@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock_collection "erp.localhost/internal/infra/db/mongo/collection/mock"
+	"erp.localhost/internal/infra/logging/logger"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+var baseOutboxLogger = logger.NewBaseLogger(shared.ModuleEvent)
+
+func userCreated(userID string) *eventv1.DomainEvent {
+	event := NewEvent(model_event.EventUserCreated, "tenant-1", "admin-1")
+	event.Payload = &eventv1.DomainEvent_User{User: &eventv1.UserEvent{UserId: userID, Email: userID + "@example.com"}}
+	return event
+}
+
+func TestOutbox_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	collection := mock_collection.NewMockCollectionHandler[eventv1.OutboxEvent](ctrl)
+	outbox := NewOutbox(collection, baseOutboxLogger)
+	event := userCreated("user-1")
+
+	var stored *eventv1.OutboxEvent
+	collection.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, record *eventv1.OutboxEvent) (string, error) {
+			stored = record
+			return "record-1", nil
+		})
+
+	err := outbox.Record(context.Background(), func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		return []*eventv1.DomainEvent{event}, nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, event.Id, stored.EventId)
+	assert.Equal(t, model_event.EventUserCreated, stored.Type)
+	assert.Equal(t, "auth.user", stored.Aggregate)
+	assert.Equal(t, "user-1", stored.AggregateId)
+	assert.Equal(t, model_event.OutboxStatusPending, stored.Status)
+	assert.NotNil(t, stored.NextAttemptAt)
+
+	decoded := &eventv1.DomainEvent{}
+	require.NoError(t, proto.Unmarshal(stored.Payload, decoded))
+	assert.True(t, proto.Equal(event, decoded))
+}
+
+func TestOutbox_RecordFailedChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	collection := mock_collection.NewMockCollectionHandler[eventv1.OutboxEvent](ctrl)
+	outbox := NewOutbox(collection, baseOutboxLogger)
+
+	// No event is written for a change that failed
+	changeErr := errors.New("duplicate user")
+	err := outbox.Record(context.Background(), func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		return nil, changeErr
+	})
+	assert.ErrorIs(t, err, changeErr)
+}
+
+func TestOutbox_RecordWriteFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	collection := mock_collection.NewMockCollectionHandler[eventv1.OutboxEvent](ctrl)
+	outbox := NewOutbox(collection, baseOutboxLogger)
+	collection.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", errors.New("connection reset"))
+
+	// Without a transaction the change is already committed, it is not reported as failed
+	err := outbox.Record(context.Background(), func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		return []*eventv1.DomainEvent{userCreated("user-1")}, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestOutbox_RecordNil(t *testing.T) {
+	var outbox *Outbox
+	changed := false
+	err := outbox.Record(context.Background(), func(ctx context.Context) ([]*eventv1.DomainEvent, error) {
+		changed = true
+		return []*eventv1.DomainEvent{userCreated("user-1")}, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestOutbox_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	collection := mock_collection.NewMockCollectionHandler[eventv1.OutboxEvent](ctrl)
+	outbox := NewOutbox(collection, baseOutboxLogger)
+	collection.EXPECT().Create(gomock.Any(), gomock.Any()).Return("record-1", nil).Times(2)
+
+	received := []string{}
+	outbox.Subscribe(func(_ context.Context, event *eventv1.DomainEvent) error {
+		received = append(received, event.GetId())
+		return nil
+	})
+	first, second := userCreated("user-1"), userCreated("user-2")
+	require.NoError(t, outbox.Add(context.Background(), first, second))
+	assert.Equal(t, []string{first.Id, second.Id}, received)
+
+	// A failed subscriber fails the write, in a transaction the change is rolled back with it
+	failing := NewOutbox(collection, baseOutboxLogger)
+	failing.Subscribe(func(context.Context, *eventv1.DomainEvent) error { return errors.New("subscriber failed") })
+	collection.EXPECT().Create(gomock.Any(), gomock.Any()).Return("record-2", nil)
+	assert.Error(t, failing.Add(context.Background(), userCreated("user-3")))
+}
+
+func TestAggregateID(t *testing.T) {
+	role := NewEvent(model_event.EventRoleUpdated, "tenant-1", "admin-1")
+	role.Payload = &eventv1.DomainEvent_Role{Role: &eventv1.RoleEvent{RoleId: "role-1"}}
+	tenant := NewEvent(model_event.EventTenantDeleted, "tenant-1", "admin-1")
+	tenant.Payload = &eventv1.DomainEvent_Tenant{Tenant: &eventv1.TenantEvent{TenantId: "tenant-2"}}
+
+	assert.Equal(t, "user-1", AggregateID(userCreated("user-1")))
+	assert.Equal(t, "role-1", AggregateID(role))
+	assert.Equal(t, "tenant-2", AggregateID(tenant))
+	assert.Equal(t, "tenant-1", AggregateID(NewEvent(model_event.EventTenantUpdated, "tenant-1", "")))
+}
@@ -0,0 +1,188 @@
+package outbox
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/infra/event/broker"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	eventsPublished = metrics.NewCounter("outbox_events_published_total",
+		"Total number of outbox events published to the broker, by event type.", "type")
+	publishFailures = metrics.NewCounter("outbox_publish_failures_total",
+		"Total number of failed attempts to publish an outbox event, by event type.", "type")
+	eventsFailed = metrics.NewCounter("outbox_events_failed_total",
+		"Total number of outbox events given up after their last attempt, by event type.", "type")
+)
+
+// Config holds the settings of the events of a service, loaded with infra_config.Load
+type Config struct {
+	Broker broker.Config `yaml:"broker"`
+	// Interval between two polls of the outbox
+	PublishInterval time.Duration `yaml:"publish_interval" env:"OUTBOX_PUBLISH_INTERVAL" default:"1s"`
+	// BatchSize caps the events published by a poll
+	BatchSize int `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" default:"100"`
+	// Failed attempts are retried with an exponential backoff from RetryBackoff up to MaxBackoff, an event is
+	// given up after MaxAttempts
+	MaxAttempts  int           `yaml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" default:"10"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env:"OUTBOX_RETRY_BACKOFF" default:"1s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env:"OUTBOX_MAX_BACKOFF" default:"5m"`
+}
+
+// Publisher sends the pending events of the outbox to the broker, the events of an entity in the order they
+// were recorded
+type Publisher struct {
+	outbox *Outbox
+	broker broker.Broker
+	config Config
+	logger logger.Logger
+
+	mu      sync.Mutex
+	running bool
+	lastRun time.Time
+	lastErr error
+}
+
+func NewPublisher(outbox *Outbox, broker broker.Broker, config *Config, logger logger.Logger) *Publisher {
+	return &Publisher{
+		outbox: outbox,
+		broker: broker,
+		config: *config,
+		logger: logger,
+	}
+}
+
+// Run publishes the pending events every PublishInterval until quit is closed
+func (p *Publisher) Run(quit <-chan struct{}) {
+	interval := p.config.PublishInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	p.setRunning(true)
+	defer p.setRunning(false)
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = p.PublishPending(ctx)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// PublishPending publishes the events due for an attempt and returns how many were published. An entity whose
+// event fails is skipped until the next poll, so its later events are not published before it
+func (p *Publisher) PublishPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	records, err := p.outbox.Pending(ctx, now)
+	p.finishRun(now, err)
+	if err != nil {
+		p.logger.Error("failed to read the pending events of the outbox", "error", err)
+		return 0, err
+	}
+	slices.SortStableFunc(records, func(a, b *eventv1.OutboxEvent) int {
+		return a.GetCreatedAt().AsTime().Compare(b.GetCreatedAt().AsTime())
+	})
+	if p.config.BatchSize > 0 && len(records) > p.config.BatchSize {
+		records = records[:p.config.BatchSize]
+	}
+
+	published := 0
+	blocked := map[string]bool{}
+	for _, record := range records {
+		key := record.GetAggregate() + "/" + record.GetAggregateId()
+		if blocked[key] {
+			continue
+		}
+		if err := p.publish(ctx, record); err != nil {
+			blocked[key] = true
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+// publish sends one record and stores the outcome of the attempt
+func (p *Publisher) publish(ctx context.Context, record *eventv1.OutboxEvent) error {
+	record.Attempts++
+	err := p.broker.Publish(ctx, &broker.Message{
+		Topic: p.config.Broker.Topic(record.GetAggregate()),
+		Key:   record.GetAggregateId(),
+		Value: record.GetPayload(),
+		Headers: map[string]string{
+			"event-id":   record.GetEventId(),
+			"event-type": record.GetType(),
+			"tenant-id":  record.GetTenantId(),
+		},
+	})
+	if err == nil {
+		record.Status = model_event.OutboxStatusPublished
+		record.PublishedAt = timestamppb.Now()
+		record.LastError = ""
+		eventsPublished.Inc(record.GetType())
+		if err := p.outbox.update(ctx, record); err != nil {
+			// The event goes out again on the next poll, consumers drop the duplicate
+			p.logger.Error("failed to mark outbox event published", "event_id", record.GetEventId(), "error", err)
+		}
+		return nil
+	}
+
+	publishFailures.Inc(record.GetType())
+	record.LastError = err.Error()
+	if p.config.MaxAttempts > 0 && int(record.Attempts) >= p.config.MaxAttempts {
+		record.Status = model_event.OutboxStatusFailed
+		eventsFailed.Inc(record.GetType())
+		p.logger.Error("giving up outbox event", "event_id", record.GetEventId(), "type", record.GetType(), "attempts", record.Attempts, "error", err)
+	} else {
+		record.NextAttemptAt = timestamppb.New(time.Now().Add(p.backoff(int(record.Attempts))))
+		p.logger.Warn("failed to publish outbox event", "event_id", record.GetEventId(), "type", record.GetType(), "attempts", record.Attempts, "error", err)
+	}
+	if err := p.outbox.update(ctx, record); err != nil {
+		p.logger.Error("failed to store outbox event attempt", "event_id", record.GetEventId(), "error", err)
+	}
+	return err
+}
+
+// backoff returns the wait before the attempt following attempt, attempts count from 1
+func (p *Publisher) backoff(attempt int) time.Duration {
+	backoff := p.config.RetryBackoff
+	for i := 1; i < attempt && backoff < p.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.config.MaxBackoff > 0 {
+		backoff = min(backoff, p.config.MaxBackoff)
+	}
+	return backoff
+}
+
+// Status returns whether the publisher runs, when it last polled the outbox and the error of that poll
+func (p *Publisher) Status() (bool, time.Time, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.running, p.lastRun, p.lastErr
+}
+
+func (p *Publisher) setRunning(running bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running = running
+}
+
+func (p *Publisher) finishRun(at time.Time, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastRun = at
+	p.lastErr = err
+}
@@ -0,0 +1,129 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock_collection "erp.localhost/internal/infra/db/mongo/collection/mock"
+	"erp.localhost/internal/infra/event/broker"
+	mock_broker "erp.localhost/internal/infra/event/broker/mock"
+	model_event "erp.localhost/internal/infra/model/event"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func pendingRecord(id, aggregateID string, created time.Time) *eventv1.OutboxEvent {
+	return &eventv1.OutboxEvent{
+		Id:          id,
+		EventId:     "event-" + id,
+		Type:        model_event.EventUserUpdated,
+		TenantId:    "tenant-1",
+		Aggregate:   "auth.user",
+		AggregateId: aggregateID,
+		Payload:     []byte(id),
+		Status:      model_event.OutboxStatusPending,
+		CreatedAt:   timestamppb.New(created),
+	}
+}
+
+func newTestPublisher(t *testing.T, records ...*eventv1.OutboxEvent) (*Publisher, *mock_broker.MockBroker, map[string]*eventv1.OutboxEvent) {
+	ctrl := gomock.NewController(t)
+	collection := mock_collection.NewMockCollectionHandler[eventv1.OutboxEvent](ctrl)
+	collection.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(records, nil)
+	updated := map[string]*eventv1.OutboxEvent{}
+	collection.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filter map[string]any, record *eventv1.OutboxEvent) error {
+			updated[filter["_id"].(string)] = record
+			return nil
+		}).AnyTimes()
+	brokerMock := mock_broker.NewMockBroker(ctrl)
+	publisher := NewPublisher(NewOutbox(collection, baseOutboxLogger), brokerMock, &Config{
+		Broker:       broker.Config{TopicPrefix: "erp"},
+		BatchSize:    10,
+		MaxAttempts:  3,
+		RetryBackoff: time.Second,
+		MaxBackoff:   time.Minute,
+	}, baseOutboxLogger)
+	return publisher, brokerMock, updated
+}
+
+func TestPublisher_PublishPending(t *testing.T) {
+	now := time.Now()
+	// Returned out of order, published oldest first
+	publisher, brokerMock, updated := newTestPublisher(t,
+		pendingRecord("2", "user-1", now.Add(-time.Second)),
+		pendingRecord("1", "user-1", now.Add(-2*time.Second)),
+	)
+
+	var topics, values []string
+	brokerMock.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg *broker.Message) error {
+		topics = append(topics, msg.Topic)
+		values = append(values, string(msg.Value))
+		assert.Equal(t, "user-1", msg.Key)
+		assert.Equal(t, model_event.EventUserUpdated, msg.Headers["event-type"])
+		return nil
+	}).Times(2)
+
+	published, err := publisher.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []string{"erp.auth.user", "erp.auth.user"}, topics)
+	assert.Equal(t, []string{"1", "2"}, values)
+	assert.Equal(t, model_event.OutboxStatusPublished, updated["1"].Status)
+	assert.NotNil(t, updated["1"].PublishedAt)
+}
+
+func TestPublisher_PublishPendingFailure(t *testing.T) {
+	now := time.Now()
+	publisher, brokerMock, updated := newTestPublisher(t,
+		pendingRecord("1", "user-1", now.Add(-3*time.Second)),
+		pendingRecord("2", "user-1", now.Add(-2*time.Second)),
+		pendingRecord("3", "user-2", now.Add(-time.Second)),
+	)
+
+	brokerMock.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg *broker.Message) error {
+		if string(msg.Value) == "1" {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}).Times(2)
+
+	published, err := publisher.PublishPending(context.Background())
+	require.NoError(t, err)
+	// The second event of user-1 waits for the first one, user-2 is not held back
+	assert.Equal(t, 1, published)
+	assert.NotContains(t, updated, "2")
+	assert.Equal(t, model_event.OutboxStatusPublished, updated["3"].Status)
+
+	failed := updated["1"]
+	assert.Equal(t, model_event.OutboxStatusPending, failed.Status)
+	assert.EqualValues(t, 1, failed.Attempts)
+	assert.Equal(t, "broker unavailable", failed.LastError)
+	assert.WithinDuration(t, time.Now().Add(time.Second), failed.NextAttemptAt.AsTime(), 500*time.Millisecond)
+}
+
+func TestPublisher_GivesUp(t *testing.T) {
+	record := pendingRecord("1", "user-1", time.Now())
+	record.Attempts = 2
+	publisher, brokerMock, updated := newTestPublisher(t, record)
+	brokerMock.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errors.New("broker unavailable"))
+
+	_, err := publisher.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, model_event.OutboxStatusFailed, updated["1"].Status)
+	assert.EqualValues(t, 3, updated["1"].Attempts)
+}
+
+func TestPublisher_Backoff(t *testing.T) {
+	publisher := NewPublisher(nil, nil, &Config{RetryBackoff: time.Second, MaxBackoff: 5 * time.Second}, baseOutboxLogger)
+	assert.Equal(t, time.Second, publisher.backoff(1))
+	assert.Equal(t, 2*time.Second, publisher.backoff(2))
+	assert.Equal(t, 4*time.Second, publisher.backoff(3))
+	assert.Equal(t, 5*time.Second, publisher.backoff(4))
+	assert.Equal(t, 5*time.Second, publisher.backoff(10))
+}
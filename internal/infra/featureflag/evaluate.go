@@ -0,0 +1,74 @@
+// Package featureflag evaluates the feature flags of the config service. The evaluation is shared by the config
+// service and the cached clients of the other services so both always give the same answer
+package featureflag
+
+import (
+	"hash/fnv"
+	"slices"
+
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+)
+
+// Evaluate returns whether flag is on for the user of the tenant, userID may be empty. A disabled flag is off for
+// everyone, a global one on for everyone. Otherwise the override of the user wins over the override of its tenant,
+// then the tenants and users listed by the rollout are on, and the others fall in a stable percentage bucket by
+// tenant, or by user for user scoped flags
+func Evaluate(flag *configv1.FeatureFlag, tenantID, userID string) bool {
+	if flag == nil || !flag.GetEnabled() {
+		return false
+	}
+	if flag.GetScope() == configv1.FeatureFlagScope_FEATURE_FLAG_SCOPE_GLOBAL {
+		return true
+	}
+	if enabled, ok := override(flag, tenantID, userID); ok {
+		return enabled
+	}
+
+	rollout := flag.GetRollout()
+	if tenantID != "" && slices.Contains(rollout.GetTenantIds(), tenantID) {
+		return true
+	}
+	if userID != "" && slices.Contains(rollout.GetUserIds(), userID) {
+		return true
+	}
+	subject := tenantID
+	if flag.GetScope() == configv1.FeatureFlagScope_FEATURE_FLAG_SCOPE_USER {
+		subject = userID
+	}
+	if subject == "" {
+		return rollout.GetPercentage() >= 100
+	}
+	return Bucket(flag.GetKey(), subject) < rollout.GetPercentage()
+}
+
+// override returns the forced value of the user of the tenant, or of the tenant, and whether one is set
+func override(flag *configv1.FeatureFlag, tenantID, userID string) (bool, bool) {
+	if tenantID == "" {
+		return false, false
+	}
+	var tenantOverride *configv1.FeatureFlagOverride
+	for _, o := range flag.GetOverrides() {
+		if o.GetTenantId() != tenantID {
+			continue
+		}
+		if o.GetUserId() == "" {
+			tenantOverride = o
+		} else if userID != "" && o.GetUserId() == userID {
+			return o.GetEnabled(), true
+		}
+	}
+	if tenantOverride != nil {
+		return tenantOverride.GetEnabled(), true
+	}
+	return false, false
+}
+
+// Bucket returns the rollout bucket of subject for the flag of key, from 0 to 99. A subject keeps its bucket, so
+// raising the percentage only adds subjects, and the buckets of the flags are independent
+func Bucket(key, subject string) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(subject))
+	return int32(h.Sum32() % 100)
+}
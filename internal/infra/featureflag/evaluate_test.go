@@ -0,0 +1,79 @@
+package featureflag
+
+import (
+	"fmt"
+	"testing"
+
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	overrides := []*configv1.FeatureFlagOverride{
+		{TenantId: "tenant-1", Enabled: true},
+		{TenantId: "tenant-1", UserId: "user-1", Enabled: false},
+		{TenantId: "tenant-2", Enabled: false},
+	}
+	testCases := []struct {
+		name     string
+		flag     *configv1.FeatureFlag
+		tenantID string
+		userID   string
+		want     bool
+	}{
+		{name: "missing flag", flag: nil, tenantID: "tenant-1", want: false},
+		{name: "disabled flag", flag: &configv1.FeatureFlag{Key: "k", Scope: configv1.FeatureFlagScope_FEATURE_FLAG_SCOPE_GLOBAL, Overrides: overrides}, tenantID: "tenant-1", want: false},
+		{name: "global flag", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Scope: configv1.FeatureFlagScope_FEATURE_FLAG_SCOPE_GLOBAL, Overrides: overrides}, tenantID: "tenant-2", want: true},
+		{name: "tenant override", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Overrides: overrides}, tenantID: "tenant-1", userID: "user-2", want: true},
+		{name: "user override wins", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Overrides: overrides}, tenantID: "tenant-1", userID: "user-1", want: false},
+		{name: "override wins over rollout", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Overrides: overrides, Rollout: &configv1.FeatureRollout{Percentage: 100}}, tenantID: "tenant-2", want: false},
+		{name: "user override of another tenant", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Overrides: overrides}, tenantID: "tenant-3", userID: "user-1", want: false},
+		{name: "listed tenant", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Rollout: &configv1.FeatureRollout{TenantIds: []string{"tenant-3"}}}, tenantID: "tenant-3", want: true},
+		{name: "listed user", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Rollout: &configv1.FeatureRollout{UserIds: []string{"user-3"}}}, tenantID: "tenant-3", userID: "user-3", want: true},
+		{name: "no rollout", flag: &configv1.FeatureFlag{Key: "k", Enabled: true}, tenantID: "tenant-3", want: false},
+		{name: "full rollout", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Rollout: &configv1.FeatureRollout{Percentage: 100}}, tenantID: "tenant-3", want: true},
+		{name: "full rollout of a user flag without user", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Scope: configv1.FeatureFlagScope_FEATURE_FLAG_SCOPE_USER, Rollout: &configv1.FeatureRollout{Percentage: 100}}, tenantID: "tenant-3", want: true},
+		{name: "partial rollout of a user flag without user", flag: &configv1.FeatureFlag{Key: "k", Enabled: true, Scope: configv1.FeatureFlagScope_FEATURE_FLAG_SCOPE_USER, Rollout: &configv1.FeatureRollout{Percentage: 99}}, tenantID: "tenant-3", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Evaluate(tc.flag, tc.tenantID, tc.userID))
+		})
+	}
+}
+
+func TestEvaluate_PercentageRollout(t *testing.T) {
+	tenantFlag := &configv1.FeatureFlag{Key: "billing.new_editor", Enabled: true, Rollout: &configv1.FeatureRollout{Percentage: 30}}
+	userFlag := &configv1.FeatureFlag{Key: "billing.new_editor", Enabled: true, Scope: configv1.FeatureFlagScope_FEATURE_FLAG_SCOPE_USER, Rollout: &configv1.FeatureRollout{Percentage: 30}}
+
+	enabled := 0
+	for i := range 1000 {
+		tenantID := fmt.Sprintf("tenant-%d", i)
+		if Evaluate(tenantFlag, tenantID, "") {
+			enabled++
+		}
+		// Every user of a tenant gets the answer of the tenant
+		assert.Equal(t, Evaluate(tenantFlag, tenantID, ""), Evaluate(tenantFlag, tenantID, "user-1"))
+		// User flags are bucketed by user whatever the tenant
+		assert.Equal(t, Evaluate(userFlag, "tenant-1", tenantID), Evaluate(userFlag, "tenant-2", tenantID))
+	}
+	assert.InDelta(t, 300, enabled, 60)
+
+	// Raising the percentage keeps the enabled subjects
+	raised := &configv1.FeatureFlag{Key: "billing.new_editor", Enabled: true, Rollout: &configv1.FeatureRollout{Percentage: 60}}
+	for i := range 1000 {
+		tenantID := fmt.Sprintf("tenant-%d", i)
+		if Evaluate(tenantFlag, tenantID, "") {
+			assert.True(t, Evaluate(raised, tenantID, ""))
+		}
+	}
+}
+
+func TestBucket(t *testing.T) {
+	assert.Equal(t, Bucket("k", "tenant-1"), Bucket("k", "tenant-1"))
+	for i := range 100 {
+		bucket := Bucket("k", fmt.Sprintf("tenant-%d", i))
+		assert.GreaterOrEqual(t, bucket, int32(0))
+		assert.Less(t, bucket, int32(100))
+	}
+}
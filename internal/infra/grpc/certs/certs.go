@@ -0,0 +1,228 @@
+// Package certs holds the certificates of the mTLS connections between the services. A Reloader keeps the
+// certificate, key and CA of a service loaded and picks up rotated files, the identity of a service is the
+// <module>.erp.localhost DNS name in the subject alternative names of its certificate.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+)
+
+// DefaultReloadInterval is how often the certificate files are checked for a rotation when no interval is set
+const DefaultReloadInterval = time.Minute
+
+// identityDomain is the domain of the service identities, see the certs targets of the service Makefiles
+const identityDomain = "erp.localhost"
+
+// Identity returns the service identity of module
+func Identity(module shared.Module) string {
+	return strings.ToLower(string(module)) + "." + identityDomain
+}
+
+// VerifyIdentity checks cert names one of identities in its DNS or URI subject alternative names, any
+// certificate is accepted when identities is empty
+func VerifyIdentity(cert *x509.Certificate, identities []string) error {
+	if len(identities) == 0 {
+		return nil
+	}
+	if cert == nil {
+		return infra_error.Auth(infra_error.AuthPermissionDenied).WithError(errors.New("no peer certificate"))
+	}
+	for _, name := range cert.DNSNames {
+		if slices.ContainsFunc(identities, func(identity string) bool { return strings.EqualFold(identity, name) }) {
+			return nil
+		}
+	}
+	for _, uri := range cert.URIs {
+		if slices.Contains(identities, uri.String()) {
+			return nil
+		}
+	}
+	return infra_error.Auth(infra_error.AuthPermissionDenied).
+		WithError(fmt.Errorf("peer %q is not one of %s", peerName(cert), strings.Join(identities, ", ")))
+}
+
+// peerName names the certificate in errors, its first DNS name or its common name
+func peerName(cert *x509.Certificate) string {
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	return cert.Subject.CommonName
+}
+
+// VerifyChain checks the peer certificates chain to a root of pool, with serverName checked against the leaf
+// when it is set
+func VerifyChain(peerCerts []*x509.Certificate, pool *x509.CertPool, serverName string, usage x509.ExtKeyUsage) (*x509.Certificate, error) {
+	if len(peerCerts) == 0 {
+		return nil, infra_error.Auth(infra_error.AuthPermissionDenied).WithError(errors.New("no peer certificate"))
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range peerCerts[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := peerCerts[0].Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		DNSName:       serverName,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	if err != nil {
+		return nil, infra_error.Auth(infra_error.AuthPermissionDenied).WithError(err)
+	}
+	return peerCerts[0], nil
+}
+
+// Reloader keeps the certificate, key and CA of shared.Certs loaded, the files are reloaded when one of them
+// changes so rotated certificates are used by new connections without a restart
+type Reloader struct {
+	certs    *shared.Certs
+	interval time.Duration
+	logger   logger.Logger
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewReloader loads the files of certs and checks them for a rotation every interval, DefaultReloadInterval when
+// 0. It fails when the files are missing or invalid, a service is never started without its certificates
+func NewReloader(certs *shared.Certs, interval time.Duration, logger logger.Logger) (*Reloader, error) {
+	if certs == nil || !certs.IsValidCerts() {
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("invalid or missing certificates"))
+	}
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	r := &Reloader{
+		certs:    certs,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	go r.run()
+	return r, nil
+}
+
+// Certificate returns the current certificate of the service
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// CAPool returns the current pool of the CA certificates peers are verified against
+func (r *Reloader) CAPool() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pool
+}
+
+// Close stops the rotation checks
+func (r *Reloader) Close() error {
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	<-r.done
+	return nil
+}
+
+func (r *Reloader) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.reload()
+		}
+	}
+}
+
+// reload loads the files again when one of them changed. A failed load keeps the current certificates, the
+// files may be observed halfway through their rotation
+func (r *Reloader) reload() {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		r.logger.Warn("failed to check certificates", "error", err)
+		return
+	}
+	r.mu.RLock()
+	changed := !modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+	if !changed {
+		return
+	}
+	if err := r.load(); err != nil {
+		r.logger.Warn("failed to reload certificates, keeping the current ones", "error", err)
+		return
+	}
+	r.logger.Info("certificates reloaded", "cert", r.certs.Cert)
+}
+
+func (r *Reloader) load() error {
+	// The modification time is read first, a rotation during the load is picked up by the next check
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to read certificates")).WithError(err)
+	}
+	cert, err := tls.LoadX509KeyPair(r.certs.Cert, r.certs.Key)
+	if err != nil {
+		return infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to load certificate")).WithError(err)
+	}
+	caCert, err := os.ReadFile(r.certs.CACert)
+	if err != nil {
+		return infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to read CA certificate")).WithError(err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to append CA certificate"))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.pool = pool
+	r.modTime = modTime
+	return nil
+}
+
+// latestModTime returns the modification time of the most recently changed file
+func (r *Reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, filename := range []string{r.certs.CACert, r.certs.Cert, r.certs.Key} {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
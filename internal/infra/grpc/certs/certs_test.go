@@ -0,0 +1,191 @@
+package certs_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/grpc/certs"
+	"erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/grpc/server"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA signs the service certificates of a test
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ERP Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// writeService writes the certificates of the service module to dir, in the layout of the service Makefiles
+func (ca *testCA) writeService(t *testing.T, dir string, module shared.Module) *shared.Certs {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: certs.Identity(module)},
+		DNSNames:     []string{certs.Identity(module), "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	serviceCerts := shared.NewCertsFromDir(dir)
+	require.NoError(t, os.WriteFile(serviceCerts.CACert, ca.pem, 0o600))
+	require.NoError(t, os.WriteFile(serviceCerts.Cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(serviceCerts.Key, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return serviceCerts
+}
+
+func TestNewReloader_MissingCertificates(t *testing.T) {
+	_, err := certs.NewReloader(shared.NewCertsFromDir(t.TempDir()), time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+	require.Error(t, err)
+
+	_, err = certs.NewReloader(nil, time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+	require.Error(t, err)
+}
+
+func TestVerifyIdentity(t *testing.T) {
+	ca := newTestCA(t)
+	reloader, err := certs.NewReloader(ca.writeService(t, t.TempDir(), shared.ModuleGateway), time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+	defer reloader.Close()
+	cert, err := x509.ParseCertificate(reloader.Certificate().Certificate[0])
+	require.NoError(t, err)
+
+	assert.NoError(t, certs.VerifyIdentity(cert, nil))
+	assert.NoError(t, certs.VerifyIdentity(cert, []string{"auth.erp.localhost", "gateway.erp.localhost"}))
+	assert.NoError(t, certs.VerifyIdentity(cert, []string{"Gateway.ERP.localhost"}))
+	assert.Error(t, certs.VerifyIdentity(cert, []string{certs.Identity(shared.ModuleAuth)}))
+	assert.Error(t, certs.VerifyIdentity(nil, []string{certs.Identity(shared.ModuleAuth)}))
+}
+
+func TestReloader_PicksUpRotation(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	serviceCerts := ca.writeService(t, dir, shared.ModuleAuth)
+	reloader, err := certs.NewReloader(serviceCerts, 10*time.Millisecond, logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+	defer reloader.Close()
+	before := reloader.Certificate()
+
+	// A certificate written halfway is not loaded
+	require.NoError(t, os.WriteFile(serviceCerts.Cert, []byte("partial"), 0o600))
+	later := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(serviceCerts.Cert, later, later))
+	time.Sleep(50 * time.Millisecond)
+	assert.Same(t, before, reloader.Certificate())
+
+	ca.writeService(t, dir, shared.ModuleAuth)
+	later = later.Add(time.Second)
+	require.NoError(t, os.Chtimes(serviceCerts.Key, later, later))
+	assert.Eventually(t, func() bool {
+		return reloader.Certificate() != before
+	}, time.Second, 10*time.Millisecond)
+}
+
+// startServer serves the health service of a gRPC server with serviceCerts on a free port
+func startServer(t *testing.T, serviceCerts *shared.Certs, allowedClients []string) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := lis.Addr().(*net.TCPAddr).Port
+	require.NoError(t, lis.Close())
+
+	srv, err := server.NewGRPCServer(&server.Config{
+		Port:           port,
+		Module:         shared.ModuleAuth,
+		Certs:          serviceCerts,
+		AllowedClients: allowedClients,
+	}, logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		srv.ListenAndServe(quit)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		close(quit)
+		<-done
+	})
+	return net.JoinHostPort("localhost", strconv.Itoa(port))
+}
+
+// probe connects to address with serviceCerts and checks its health
+func probe(t *testing.T, address string, serviceCerts *shared.Certs, serverIdentity string) error {
+	t.Helper()
+	conn, err := client.NewGRPCClient(context.Background(), &client.Config{
+		Address:        address,
+		Module:         shared.ModuleGateway,
+		Certs:          serviceCerts,
+		ServerIdentity: serverIdentity,
+	}, logger.NewBaseLogger(shared.ModuleGateway))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var probeErr error
+	for i := 0; i < 20; i++ {
+		if _, probeErr = conn.Probe(); probeErr == nil {
+			return nil
+		}
+		time.Sleep(25 * time.Millisecond)
+	}
+	return probeErr
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	serverCerts := ca.writeService(t, t.TempDir(), shared.ModuleAuth)
+	gatewayCerts := ca.writeService(t, t.TempDir(), shared.ModuleGateway)
+	coreCerts := ca.writeService(t, t.TempDir(), shared.ModuleCore)
+	address := startServer(t, serverCerts, []string{certs.Identity(shared.ModuleGateway)})
+
+	// The client identity is checked by the server, the server identity by the client
+	assert.NoError(t, probe(t, address, gatewayCerts, certs.Identity(shared.ModuleAuth)))
+	assert.Error(t, probe(t, address, coreCerts, certs.Identity(shared.ModuleAuth)))
+	assert.Error(t, probe(t, address, gatewayCerts, certs.Identity(shared.ModuleConfig)))
+
+	// Certificates of another CA are rejected
+	otherCerts := newTestCA(t).writeService(t, t.TempDir(), shared.ModuleGateway)
+	assert.Error(t, probe(t, address, otherCerts, certs.Identity(shared.ModuleAuth)))
+}
@@ -11,6 +11,7 @@ import (
 
 	// proto_infra "erp.localhost/internal/infra/proto/generated/infra/v1"
 	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
 )
 
 type TokensResponse struct {
@@ -48,16 +49,20 @@ type authClient struct {
 }
 
 func NewAuthGRPCClient(ctx context.Context, config *Config, logger logger.Logger) (AuthClient, error) {
-	grpcClient, err := NewGRPCClient(ctx, config, logger)
+	grpcClient, err := NewGRPCClient(ctx, withServerIdentity(config, shared.ModuleAuth), logger)
 	if err != nil {
 		return nil, infra_error.Internal(infra_error.InternalGRPCError, err)
 	}
-	stub := authv1.NewAuthServiceClient(grpcClient.Conn())
+	return newAuthClient(grpcClient, logger), nil
+}
+
+// newAuthClient creates an AuthClient over grpcClient, closed with the client
+func newAuthClient(grpcClient *GRPCClient, logger logger.Logger) *authClient {
 	return &authClient{
 		grpcClient: grpcClient,
 		logger:     logger,
-		stub:       stub,
-	}, nil
+		stub:       authv1.NewAuthServiceClient(grpcClient.Conn()),
+	}
 }
 
 func (a *authClient) Login(ctx context.Context, tenantID, email, username, password string) (*TokensResponse, error) {
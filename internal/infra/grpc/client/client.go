@@ -4,17 +4,20 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"errors"
-	"os"
+	"fmt"
 	"time"
 
 	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/certs"
 	"erp.localhost/internal/infra/grpc/interceptor"
 	"erp.localhost/internal/infra/logging/logger"
 	"erp.localhost/internal/infra/model/shared"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 )
 
 //go:generate mockgen -destination=mock/mock_rpc_client.go -package=mock erp.localhost/internal/infra/grpc/client RPCClient
@@ -23,24 +26,56 @@ type RPCClient interface {
 	Close() error
 }
 
+// probeTimeout bounds the health check of Probe
+const probeTimeout = 2 * time.Second
+
 type Config struct {
 	Address        string
 	Certs          *shared.Certs
 	Module         shared.Module
 	Insecure       bool
+	// Minimum time given to a connection attempt, the gRPC default when 0
 	ConnectTimeout time.Duration
+	// Deadline of the calls made without one, retries included, unbounded when 0
 	RequestTimeout time.Duration
+	// Interval of the keepalive pings sent on idle connections and how long their reply is waited for, no pings
+	// when 0
+	KeepAliveTime    time.Duration
+	KeepAliveTimeout time.Duration
+	// Policy balancing the calls over the addresses Address resolves to, e.g. round_robin with a dns:///
+	// address. pick_first when empty
+	LoadBalancingPolicy string
+	// Retries of the idempotent calls, see interceptor.DefaultRetryPolicy. No retries when nil
+	Retry *interceptor.RetryPolicy
+	// Service identity expected in the certificate of the server, see certs.Identity. The clients of a service
+	// default it to the identity of the service, only the address is checked against the certificate when empty
+	ServerIdentity string
+	// How often the certificates are checked for a rotation, certs.DefaultReloadInterval when 0
+	CertReloadInterval time.Duration
+}
+
+// withServerIdentity returns config expecting the identity of module from the server when it does not expect one
+func withServerIdentity(config *Config, module shared.Module) *Config {
+	if config.ServerIdentity != "" {
+		return config
+	}
+	withIdentity := *config
+	withIdentity.ServerIdentity = certs.Identity(module)
+	return &withIdentity
 }
 
 type GRPCClient struct {
 	conn   *grpc.ClientConn
+	certs  *certs.Reloader
 	config *Config
 	logger logger.Logger
+	// release replaces the closing of the connection for the clients sharing the connection of a Factory
+	release func() error
 }
 
 func NewGRPCClient(ctx context.Context, config *Config, logger logger.Logger) (*GRPCClient, error) {
 	// Build dial options
-	opts, err := buildDialOptions(config, logger)
+	opts, reloader, err := buildDialOptions(config, logger)
 	if err != nil {
 		logger.Error("failed to build options", "error", err)
 		return nil, err
@@ -48,6 +83,9 @@ func NewGRPCClient(ctx context.Context, config *Config, logger logger.Logger) (*
 
 	conn, err := grpc.NewClient(config.Address, opts...)
 	if err != nil {
+		if reloader != nil {
+			reloader.Close()
+		}
 		logger.Error("failed to connect to gRPC server", "address", config.Address, "error", err)
 		return nil, err
 	}
@@ -56,6 +94,7 @@ func NewGRPCClient(ctx context.Context, config *Config, logger logger.Logger) (*
 
 	return &GRPCClient{
 		conn:   conn,
+		certs:  reloader,
 		config: config,
 		logger: logger,
 	}, nil
@@ -66,8 +105,31 @@ func (c *GRPCClient) Conn() *grpc.ClientConn {
 	return c.conn
 }
 
+// Probe checks the health of the server behind the connection and returns the round trip latency,
+// it is a status.Probe for readiness checks of services that depend on this one
+func (c *GRPCClient) Probe() (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := healthpb.NewHealthClient(c.conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return time.Since(start), mapGRPCError(err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return time.Since(start), infra_error.Internal(infra_error.InternalServiceUnavailable, fmt.Errorf("%s is %s", c.config.Address, resp.GetStatus()))
+	}
+	return time.Since(start), nil
+}
+
 // Close closes the gRPC connection
 func (c *GRPCClient) Close() error {
+	if c.release != nil {
+		return c.release()
+	}
+	if c.certs != nil {
+		c.certs.Close()
+	}
 	if c.Conn() != nil {
 		c.logger.Info("closing gRPC client connection")
 		return c.Conn().Close()
@@ -75,62 +137,73 @@ func (c *GRPCClient) Close() error {
 	return nil
 }
 
-func buildDialOptions(config *Config, logger logger.Logger) ([]grpc.DialOption, error) {
+func buildDialOptions(config *Config, logger logger.Logger) ([]grpc.DialOption, *certs.Reloader, error) {
+	// The timeout bounds all the attempts of a call, every attempt is traced, measured and logged
+	interceptors := []grpc.UnaryClientInterceptor{interceptor.ClientTimeoutInterceptor(config.RequestTimeout)}
+	if config.Retry != nil {
+		interceptors = append(interceptors, interceptor.ClientRetryInterceptor(config.Retry))
+	}
+	interceptors = append(interceptors,
+		interceptor.ClientTracingInterceptor(),
+		interceptor.ClientMetricsInterceptor(),
+		interceptor.ClientLoggingInterceptor(logger),
+	)
 	opts := []grpc.DialOption{
-		grpc.WithChainUnaryInterceptor(
-			interceptor.ClientLoggingInterceptor(logger),
-			// Add more interceptors as needed
-		),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	}
+
+	if config.ConnectTimeout > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: config.ConnectTimeout,
+		}))
+	}
+	if config.KeepAliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    config.KeepAliveTime,
+			Timeout: config.KeepAliveTimeout,
+		}))
+	}
+	if config.LoadBalancingPolicy != "" {
+		opts = append(opts, grpc.WithDefaultServiceConfig(
+			fmt.Sprintf(`{"loadBalancingConfig": [{%q: {}}]}`, config.LoadBalancingPolicy)))
 	}
 
 	// Handle credentials
 	if config.Insecure {
 		logger.Warn("using insecure connection (no TLS)")
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		tlsOpts, err := buildTLSOptions(config.Certs)
-		if err != nil {
-			logger.Error("failed to configure mTLS", "error", err)
-			return nil, err
-		}
-		opts = append(opts, tlsOpts...)
-	}
-
-	return opts, nil
-}
-
-func buildTLSOptions(certs *shared.Certs) ([]grpc.DialOption, error) {
-	if certs == nil || !certs.IsValidCerts() {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("invalid or missing certificates"))
-	}
-
-	// Load client certificate
-	clientCert, err := tls.LoadX509KeyPair(certs.Cert, certs.Key)
-	if err != nil {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to load client certificate")).WithError(err)
+		return opts, nil, nil
 	}
-
-	// Load CA certificate
-	caCert, err := os.ReadFile(certs.CACert)
+	reloader, err := certs.NewReloader(config.Certs, config.CertReloadInterval, logger)
 	if err != nil {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to read CA certificate")).WithError(err)
+		logger.Error("failed to configure mTLS", "error", err)
+		return nil, nil, err
 	}
+	opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(buildTLSConfig(reloader, config.ServerIdentity))))
 
-	// Create cert pool
-	caCertPool := x509.NewCertPool()
-	if !caCertPool.AppendCertsFromPEM(caCert) {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to append CA certificate"))
-	}
+	return opts, reloader, nil
+}
 
-	// Create TLS config
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{clientCert},
-		RootCAs:      caCertPool,
+// buildTLSConfig presents the certificate of the service and verifies the server certificate against the CA, its
+// address and serverIdentity. Both are read from reloader on every handshake so rotations apply to new connections
+func buildTLSConfig(reloader *certs.Reloader, serverIdentity string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return reloader.Certificate(), nil
+		},
+		// RootCAs would pin the CA loaded at start, the chain is verified by VerifyConnection against the current one
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			peer, err := certs.VerifyChain(state.PeerCertificates, reloader.CAPool(), state.ServerName, x509.ExtKeyUsageServerAuth)
+			if err != nil {
+				return err
+			}
+			if serverIdentity == "" {
+				return nil
+			}
+			return certs.VerifyIdentity(peer, []string{serverIdentity})
+		},
 	}
-
-	creds := credentials.NewTLS(tlsConfig)
-
-	return []grpc.DialOption{
-		grpc.WithTransportCredentials(creds),
-	}, nil
 }
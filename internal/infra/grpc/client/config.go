@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type ConfigClient interface {
+	// GetConfig returns the module config of the tenant and its version, an empty config with version 0 when none is stored
+	GetConfig(ctx context.Context, tenantID, module string) (*structpb.Struct, int32, error)
+	// SetConfig stores data as the new module config of the tenant and returns its version
+	SetConfig(ctx context.Context, tenantID, userID, module string, data *structpb.Struct) (int32, error)
+	// Watch opens a WatchConfig stream, see ConfigCache for a cache kept up to date by it
+	Watch(ctx context.Context, req *configv1.WatchConfigRequest) (configv1.ConfigService_WatchConfigClient, error)
+	// Probe checks the config service is serving, see GRPCClient.Probe
+	Probe() (time.Duration, error)
+
+	Close() error
+}
+
+// configClient implements ConfigClient
+type configClient struct {
+	grpcClient *GRPCClient
+	logger     logger.Logger
+	stub       configv1.ConfigServiceClient
+}
+
+func NewConfigGRPCClient(ctx context.Context, config *Config, logger logger.Logger) (ConfigClient, error) {
+	grpcClient, err := NewGRPCClient(ctx, withServerIdentity(config, shared.ModuleConfig), logger)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalGRPCError, err)
+	}
+	return newConfigClient(grpcClient, logger), nil
+}
+
+// newConfigClient creates a ConfigClient over grpcClient, closed with the client
+func newConfigClient(grpcClient *GRPCClient, logger logger.Logger) *configClient {
+	return &configClient{
+		grpcClient: grpcClient,
+		logger:     logger,
+		stub:       configv1.NewConfigServiceClient(grpcClient.Conn()),
+	}
+}
+
+func (c *configClient) GetConfig(ctx context.Context, tenantID, module string) (*structpb.Struct, int32, error) {
+	req := &configv1.ConfigRequest{
+		TenantId: tenantID,
+		Module:   module,
+	}
+	res, err := c.stub.GetConfig(ctx, req)
+	if err != nil {
+		return nil, 0, mapGRPCError(err)
+	}
+	return res.GetData(), res.GetVersion(), nil
+}
+
+func (c *configClient) SetConfig(ctx context.Context, tenantID, userID, module string, data *structpb.Struct) (int32, error) {
+	req := &configv1.SetConfigRequest{
+		TenantId: tenantID,
+		UserId:   userID,
+		Module:   module,
+		Data:     data,
+	}
+	res, err := c.stub.SetConfig(ctx, req)
+	if err != nil {
+		return 0, mapGRPCError(err)
+	}
+	return res.GetVersion(), nil
+}
+
+func (c *configClient) Watch(ctx context.Context, req *configv1.WatchConfigRequest) (configv1.ConfigService_WatchConfigClient, error) {
+	stream, err := c.stub.WatchConfig(ctx, req)
+	if err != nil {
+		return nil, mapGRPCError(err)
+	}
+	return stream, nil
+}
+
+func (c *configClient) Probe() (time.Duration, error) {
+	return c.grpcClient.Probe()
+}
+
+func (c *configClient) Close() error {
+	return c.grpcClient.Close()
+}
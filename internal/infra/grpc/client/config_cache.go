@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// Delays between reconnects of the watch stream, doubled on every failed attempt
+	watchRetryMin = time.Second
+	watchRetryMax = 30 * time.Second
+)
+
+// entryID identifies a config entry in the cache
+type entryID struct {
+	key      string
+	scope    configv1.ConfigScope
+	tenantID string
+	module   string
+}
+
+func idOf(entry *configv1.ConfigEntry) entryID {
+	return entryID{key: entry.GetKey(), scope: entry.GetScope(), tenantID: entry.GetTenantId(), module: entry.GetModule()}
+}
+
+// ConfigCache keeps the config entries watched by a service in memory, kept up to date by the WatchConfig stream
+// of the config service and reconnected when it breaks. Reads never call the service, they resolve the most
+// specific entry of a key: the tenant entry of the module, the tenant entry, the module entry, the system entry
+type ConfigCache struct {
+	client ConfigClient
+	req    *configv1.WatchConfigRequest
+	logger logger.Logger
+
+	mu        sync.RWMutex
+	entries   map[entryID]*configv1.ConfigEntry
+	listeners []func(*configv1.ConfigEvent)
+
+	synced     chan struct{}
+	syncedOnce sync.Once
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewConfigCache creates a cache of the entries watched by req, Start begins watching
+func NewConfigCache(client ConfigClient, req *configv1.WatchConfigRequest, logger logger.Logger) *ConfigCache {
+	req.Module = strings.ToLower(req.GetModule())
+	return &ConfigCache{
+		client:  client,
+		req:     req,
+		logger:  logger,
+		entries: make(map[entryID]*configv1.ConfigEntry),
+		synced:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start watches the config service in the background until Close
+func (c *ConfigCache) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	go c.run(ctx)
+}
+
+// WaitSynced blocks until the cache received the watched entries once, or ctx is done
+func (c *ConfigCache) WaitSynced(ctx context.Context) error {
+	select {
+	case <-c.synced:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops watching, the cached entries stay readable
+func (c *ConfigCache) Close() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+}
+
+// OnChange registers fn to be called with every change received after the first sync
+func (c *ConfigCache) OnChange(fn func(*configv1.ConfigEvent)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+func (c *ConfigCache) run(ctx context.Context) {
+	defer close(c.done)
+	delay := watchRetryMin
+	for {
+		err := c.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		c.logger.Warn("config watch interrupted, reconnecting", "error", err, "retry_in", delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if err != nil {
+			delay = min(delay*2, watchRetryMax)
+		} else {
+			delay = watchRetryMin
+		}
+	}
+}
+
+// watch reads a stream until it breaks. The entries of the snapshot replace the cached ones once it is complete,
+// so entries deleted while disconnected are dropped
+func (c *ConfigCache) watch(ctx context.Context) error {
+	stream, err := c.client.Watch(ctx, c.req)
+	if err != nil {
+		return err
+	}
+	snapshot := make(map[entryID]*configv1.ConfigEntry)
+	synced := false
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case !synced && event.GetSynced():
+			synced = true
+			c.mu.Lock()
+			c.entries = snapshot
+			c.mu.Unlock()
+			c.syncedOnce.Do(func() { close(c.synced) })
+			c.logger.Debug("config cache synced", "entries", len(snapshot))
+		case !synced:
+			if event.GetEntry() != nil {
+				snapshot[idOf(event.GetEntry())] = event.GetEntry()
+			}
+		default:
+			c.apply(event)
+		}
+	}
+}
+
+// apply updates the cache with a change and notifies the listeners, changes older than the cached entry are ignored
+func (c *ConfigCache) apply(event *configv1.ConfigEvent) {
+	entry := event.GetEntry()
+	if entry == nil {
+		return
+	}
+	id := idOf(entry)
+	c.mu.Lock()
+	current, ok := c.entries[id]
+	if ok && current.GetVersion() > entry.GetVersion() {
+		c.mu.Unlock()
+		return
+	}
+	if event.GetType() == configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_DELETE {
+		if !ok {
+			c.mu.Unlock()
+			return
+		}
+		delete(c.entries, id)
+	} else {
+		if ok && current.GetVersion() == entry.GetVersion() {
+			// Sent again after the snapshot
+			c.mu.Unlock()
+			return
+		}
+		c.entries[id] = entry
+	}
+	listeners := slices.Clone(c.listeners)
+	c.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
+
+// Value returns the most specific value of key for the tenant, tenantID may be empty
+func (c *ConfigCache) Value(tenantID, key string) (*structpb.Value, bool) {
+	module := c.req.GetModule()
+	candidates := []entryID{
+		{key: key, scope: configv1.ConfigScope_CONFIG_SCOPE_MODULE, module: module},
+		{key: key, scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM},
+	}
+	if tenantID != "" {
+		candidates = append([]entryID{
+			{key: key, scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT, tenantID: tenantID, module: module},
+			{key: key, scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT, tenantID: tenantID},
+		}, candidates...)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, id := range candidates {
+		if id.module == "" && id.scope == configv1.ConfigScope_CONFIG_SCOPE_MODULE {
+			continue
+		}
+		if entry, ok := c.entries[id]; ok {
+			return entry.GetValue(), true
+		}
+	}
+	return nil, false
+}
+
+// String returns the value of key for the tenant, fallback when it is not set or not a string
+func (c *ConfigCache) String(tenantID, key, fallback string) string {
+	if value, ok := c.Value(tenantID, key); ok {
+		if v, ok := value.GetKind().(*structpb.Value_StringValue); ok {
+			return v.StringValue
+		}
+	}
+	return fallback
+}
+
+// Int returns the value of key for the tenant, fallback when it is not set or not a number
+func (c *ConfigCache) Int(tenantID, key string, fallback int64) int64 {
+	if value, ok := c.Value(tenantID, key); ok {
+		if v, ok := value.GetKind().(*structpb.Value_NumberValue); ok {
+			return int64(v.NumberValue)
+		}
+	}
+	return fallback
+}
+
+// Float returns the value of key for the tenant, fallback when it is not set or not a number
+func (c *ConfigCache) Float(tenantID, key string, fallback float64) float64 {
+	if value, ok := c.Value(tenantID, key); ok {
+		if v, ok := value.GetKind().(*structpb.Value_NumberValue); ok {
+			return v.NumberValue
+		}
+	}
+	return fallback
+}
+
+// Bool returns the value of key for the tenant, fallback when it is not set or not a bool
+func (c *ConfigCache) Bool(tenantID, key string, fallback bool) bool {
+	if value, ok := c.Value(tenantID, key); ok {
+		if v, ok := value.GetKind().(*structpb.Value_BoolValue); ok {
+			return v.BoolValue
+		}
+	}
+	return fallback
+}
+
+// Duration returns the value of key for the tenant, fallback when it is not set or not a duration
+func (c *ConfigCache) Duration(tenantID, key string, fallback time.Duration) time.Duration {
+	if value, ok := c.Value(tenantID, key); ok {
+		if v, ok := value.GetKind().(*structpb.Value_StringValue); ok {
+			if d, err := time.ParseDuration(v.StringValue); err == nil {
+				return d
+			}
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fakeWatchStream returns the events sent on its channel, then blocks until the call is canceled
+type fakeWatchStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *configv1.ConfigEvent
+}
+
+func (s *fakeWatchStream) Recv() (*configv1.ConfigEvent, error) {
+	select {
+	case event := <-s.events:
+		return event, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+type fakeConfigClient struct {
+	ConfigClient
+	events chan *configv1.ConfigEvent
+}
+
+func (c *fakeConfigClient) Watch(ctx context.Context, _ *configv1.WatchConfigRequest) (configv1.ConfigService_WatchConfigClient, error) {
+	return &fakeWatchStream{ctx: ctx, events: c.events}, nil
+}
+
+func TestConfigCache(t *testing.T) {
+	client := &fakeConfigClient{events: make(chan *configv1.ConfigEvent, 16)}
+	cache := NewConfigCache(client, &configv1.WatchConfigRequest{Module: "AUTH", AllTenants: true}, logger.NewBaseLogger(shared.ModuleAuth))
+	cache.Start()
+	defer cache.Close()
+
+	set := func(entry *configv1.ConfigEntry) *configv1.ConfigEvent {
+		return &configv1.ConfigEvent{Type: configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_SET, Entry: entry}
+	}
+	system := &configv1.ConfigEntry{Key: "auth.session.idle_timeout", Scope: configv1.ConfigScope_CONFIG_SCOPE_SYSTEM, Version: 1, Value: structpb.NewStringValue("30m")}
+	tenant := &configv1.ConfigEntry{Key: "auth.session.idle_timeout", Scope: configv1.ConfigScope_CONFIG_SCOPE_TENANT, TenantId: "tenant-1", Version: 1, Value: structpb.NewStringValue("5m")}
+	client.events <- set(system)
+	client.events <- set(tenant)
+	client.events <- &configv1.ConfigEvent{Synced: true}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, cache.WaitSynced(ctx))
+
+	// Tenant entries override the system entry
+	assert.Equal(t, 5*time.Minute, cache.Duration("tenant-1", "auth.session.idle_timeout", time.Hour))
+	assert.Equal(t, 30*time.Minute, cache.Duration("tenant-2", "auth.session.idle_timeout", time.Hour))
+	assert.Equal(t, time.Hour, cache.Duration("tenant-1", "auth.unknown", time.Hour))
+
+	changes := make(chan *configv1.ConfigEvent, 4)
+	cache.OnChange(func(event *configv1.ConfigEvent) { changes <- event })
+
+	// A module entry is more specific than the system entry
+	module := &configv1.ConfigEntry{Key: "auth.password.min_length", Scope: configv1.ConfigScope_CONFIG_SCOPE_MODULE, Module: "auth", Version: 1, Value: structpb.NewNumberValue(12)}
+	client.events <- set(module)
+	client.events <- &configv1.ConfigEvent{Type: configv1.ConfigChangeType_CONFIG_CHANGE_TYPE_DELETE, Entry: tenant}
+
+	for range 2 {
+		select {
+		case <-changes:
+		case <-ctx.Done():
+			t.Fatal("changes were not applied")
+		}
+	}
+	assert.Equal(t, int64(12), cache.Int("tenant-1", "auth.password.min_length", 8))
+	assert.Equal(t, 30*time.Minute, cache.Duration("tenant-1", "auth.session.idle_timeout", time.Hour))
+	assert.False(t, cache.Bool("", "auth.password.min_length", false))
+}
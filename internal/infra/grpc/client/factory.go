@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+)
+
+// Factory creates the clients a service uses to call the other services. The clients share the settings of the
+// factory and one connection per server, a connection is closed with its last client. The clients of an empty
+// address call the server found by the resolver of the factory, see SetResolver
+type Factory struct {
+	defaults Config
+	logger   logger.Logger
+	resolver discovery.Resolver
+
+	mu    sync.Mutex
+	conns map[connKey]*pooledConn
+}
+
+// connKey identifies a pooled connection, the clients of a server expecting different identities do not share
+type connKey struct {
+	address        string
+	serverIdentity string
+}
+
+type pooledConn struct {
+	client *GRPCClient
+	refs   int
+}
+
+// NewFactory creates a factory of clients with the settings of defaults, its Address is ignored
+func NewFactory(defaults *Config, logger logger.Logger) *Factory {
+	return &Factory{
+		defaults: *defaults,
+		logger:   logger,
+		conns:    make(map[connKey]*pooledConn),
+	}
+}
+
+// SetResolver sets how the addresses of the services are found when a client is created without one
+func (f *Factory) SetResolver(resolver discovery.Resolver) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resolver = resolver
+}
+
+// Conn returns a client of the server at address, or of the service of module found by the resolver when address
+// is empty. It expects the identity of module from the server unless the defaults set one, and balances the calls
+// over the addresses of a dns:/// target with round_robin unless the defaults set a policy. Close releases the client
+func (f *Factory) Conn(ctx context.Context, address string, module shared.Module) (*GRPCClient, error) {
+	if address == "" {
+		resolved, err := f.resolve(module)
+		if err != nil {
+			return nil, err
+		}
+		address = resolved
+	}
+	config := f.defaults
+	config.Address = address
+	if config.LoadBalancingPolicy == "" && strings.HasPrefix(address, "dns:///") {
+		config.LoadBalancingPolicy = "round_robin"
+	}
+	config = *withServerIdentity(&config, module)
+	key := connKey{address: config.Address, serverIdentity: config.ServerIdentity}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pooled, ok := f.conns[key]
+	if !ok {
+		grpcClient, err := NewGRPCClient(ctx, &config, f.logger)
+		if err != nil {
+			return nil, err
+		}
+		pooled = &pooledConn{client: grpcClient}
+		f.conns[key] = pooled
+	}
+	pooled.refs++
+
+	var once sync.Once
+	return &GRPCClient{
+		conn:   pooled.client.conn,
+		config: pooled.client.config,
+		logger: f.logger,
+		release: func() error {
+			var err error
+			once.Do(func() { err = f.release(key) })
+			return err
+		},
+	}, nil
+}
+
+// resolve returns the address of the service of module found by the resolver
+func (f *Factory) resolve(module shared.Module) (string, error) {
+	f.mu.Lock()
+	resolver := f.resolver
+	f.mu.Unlock()
+	if resolver == nil {
+		return "", infra_error.Validation(infra_error.ValidationRequiredFields, "address")
+	}
+	address, err := resolver.Resolve(module)
+	if err != nil {
+		return "", err
+	}
+	f.logger.Debug("resolved service address", "service", module, "address", address)
+	return address, nil
+}
+
+// AuthClient returns a client of the auth service at address, found by the resolver when empty
+func (f *Factory) AuthClient(ctx context.Context, address string) (AuthClient, error) {
+	grpcClient, err := f.Conn(ctx, address, shared.ModuleAuth)
+	if err != nil {
+		return nil, err
+	}
+	return newAuthClient(grpcClient, f.logger), nil
+}
+
+// RBACClient returns a client of the permission verification of the auth service at address, found by the
+// resolver when empty
+func (f *Factory) RBACClient(ctx context.Context, address string) (RBACClient, error) {
+	grpcClient, err := f.Conn(ctx, address, shared.ModuleAuth)
+	if err != nil {
+		return nil, err
+	}
+	return newRBACClient(grpcClient, f.logger), nil
+}
+
+// ConfigClient returns a client of the config service at address, found by the resolver when empty
+func (f *Factory) ConfigClient(ctx context.Context, address string) (ConfigClient, error) {
+	grpcClient, err := f.Conn(ctx, address, shared.ModuleConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigClient(grpcClient, f.logger), nil
+}
+
+// FeatureFlagClient returns a client of the feature flags of the config service at address, found by the resolver
+// when empty
+func (f *Factory) FeatureFlagClient(ctx context.Context, address string) (FeatureFlagClient, error) {
+	grpcClient, err := f.Conn(ctx, address, shared.ModuleConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newFeatureFlagClient(grpcClient, f.logger), nil
+}
+
+// release drops a reference to the connection of key and closes it with the last one
+func (f *Factory) release(key connKey) error {
+	f.mu.Lock()
+	pooled, ok := f.conns[key]
+	if !ok {
+		f.mu.Unlock()
+		return nil
+	}
+	pooled.refs--
+	if pooled.refs > 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	delete(f.conns, key)
+	f.mu.Unlock()
+	return pooled.client.Close()
+}
+
+// Close closes every connection of the factory, the clients still open stop working
+func (f *Factory) Close() error {
+	f.mu.Lock()
+	conns := f.conns
+	f.conns = make(map[connKey]*pooledConn)
+	f.mu.Unlock()
+
+	var firstErr error
+	for _, pooled := range conns {
+		if err := pooled.client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
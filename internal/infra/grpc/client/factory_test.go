@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/infra/grpc/discovery"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestFactory_SharesConnections(t *testing.T) {
+	factory := NewFactory(&Config{Module: shared.ModuleGateway, Insecure: true}, logger.NewBaseLogger(shared.ModuleGateway))
+	defer factory.Close()
+
+	first, err := factory.Conn(context.Background(), "localhost:5000", shared.ModuleAuth)
+	require.NoError(t, err)
+	second, err := factory.AuthClient(context.Background(), "localhost:5000")
+	require.NoError(t, err)
+	other, err := factory.ConfigClient(context.Background(), "localhost:5002")
+	require.NoError(t, err)
+	defer other.Close()
+
+	assert.Same(t, first.Conn(), second.(*authClient).grpcClient.Conn())
+	assert.NotSame(t, first.Conn(), other.(*configClient).grpcClient.Conn())
+
+	// The connection is closed with its last client, closing a client twice releases it once
+	conn := first.Conn()
+	require.NoError(t, first.Close())
+	require.NoError(t, first.Close())
+	assert.NotEqual(t, connectivity.Shutdown, conn.GetState())
+	require.NoError(t, second.Close())
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+
+	// A new client of the address opens a new connection
+	third, err := factory.Conn(context.Background(), "localhost:5000", shared.ModuleAuth)
+	require.NoError(t, err)
+	defer third.Close()
+	assert.NotSame(t, conn, third.Conn())
+}
+
+func TestFactory_ResolvesAddresses(t *testing.T) {
+	factory := NewFactory(&Config{Module: shared.ModuleGateway, Insecure: true}, logger.NewBaseLogger(shared.ModuleGateway))
+	defer factory.Close()
+
+	// Without a resolver the address is required
+	_, err := factory.Conn(context.Background(), "", shared.ModuleAuth)
+	require.Error(t, err)
+
+	factory.SetResolver(discovery.NewDNSResolver("erp.svc.cluster.local"))
+	conn, err := factory.Conn(context.Background(), "", shared.ModuleAuth)
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, "dns:///auth.erp.svc.cluster.local:5000", conn.Conn().Target())
+	assert.Equal(t, "round_robin", conn.config.LoadBalancingPolicy)
+}
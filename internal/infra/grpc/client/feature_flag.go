@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"erp.localhost/internal/infra/model/shared"
+)
+
+type FeatureFlagClient interface {
+	// ListFeatureFlags returns every feature flag, see FeatureFlagCache for the flags evaluated locally
+	ListFeatureFlags(ctx context.Context) ([]*configv1.FeatureFlag, error)
+	// EvaluateFeatureFlags evaluates keys for the user of the tenant on the config service, every flag when keys is empty
+	EvaluateFeatureFlags(ctx context.Context, tenantID, userID string, keys ...string) (map[string]bool, error)
+	// Probe checks the config service is serving, see GRPCClient.Probe
+	Probe() (time.Duration, error)
+
+	Close() error
+}
+
+// featureFlagClient implements FeatureFlagClient
+type featureFlagClient struct {
+	grpcClient *GRPCClient
+	logger     logger.Logger
+	stub       configv1.FeatureFlagServiceClient
+}
+
+func NewFeatureFlagGRPCClient(ctx context.Context, config *Config, logger logger.Logger) (FeatureFlagClient, error) {
+	grpcClient, err := NewGRPCClient(ctx, withServerIdentity(config, shared.ModuleConfig), logger)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalGRPCError, err)
+	}
+	return newFeatureFlagClient(grpcClient, logger), nil
+}
+
+// newFeatureFlagClient creates a FeatureFlagClient over grpcClient, closed with the client
+func newFeatureFlagClient(grpcClient *GRPCClient, logger logger.Logger) *featureFlagClient {
+	return &featureFlagClient{
+		grpcClient: grpcClient,
+		logger:     logger,
+		stub:       configv1.NewFeatureFlagServiceClient(grpcClient.Conn()),
+	}
+}
+
+func (c *featureFlagClient) ListFeatureFlags(ctx context.Context) ([]*configv1.FeatureFlag, error) {
+	res, err := c.stub.ListFeatureFlags(ctx, &configv1.ListFeatureFlagsRequest{})
+	if err != nil {
+		return nil, mapGRPCError(err)
+	}
+	return res.GetFlags(), nil
+}
+
+func (c *featureFlagClient) EvaluateFeatureFlags(ctx context.Context, tenantID, userID string, keys ...string) (map[string]bool, error) {
+	req := &configv1.EvaluateFeatureFlagsRequest{
+		TenantId: tenantID,
+		UserId:   userID,
+		Keys:     keys,
+	}
+	res, err := c.stub.EvaluateFeatureFlags(ctx, req)
+	if err != nil {
+		return nil, mapGRPCError(err)
+	}
+	return res.GetFlags(), nil
+}
+
+func (c *featureFlagClient) Probe() (time.Duration, error) {
+	return c.grpcClient.Probe()
+}
+
+func (c *featureFlagClient) Close() error {
+	return c.grpcClient.Close()
+}
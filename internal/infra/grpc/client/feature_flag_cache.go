@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/infra/featureflag"
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+)
+
+// DefaultFeatureFlagTTL is how long a FeatureFlagCache serves its flags before listing them again
+const DefaultFeatureFlagTTL = 30 * time.Second
+
+// FeatureFlagCache evaluates the feature flags locally with featureflag.Evaluate, the same evaluation as the
+// config service. The flags are listed again once they are older than the TTL, and the stale flags keep being
+// served while the config service cannot be reached. Flags are off until they were listed once
+type FeatureFlagCache struct {
+	client FeatureFlagClient
+	ttl    time.Duration
+	logger logger.Logger
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	flags     map[string]*configv1.FeatureFlag
+	fetchedAt time.Time
+
+	// refreshMu lets one caller list the flags while the others read the current ones
+	refreshMu sync.Mutex
+}
+
+// NewFeatureFlagCache creates a cache of the flags listed by client, DefaultFeatureFlagTTL applies when ttl is not
+// positive
+func NewFeatureFlagCache(client FeatureFlagClient, ttl time.Duration, logger logger.Logger) *FeatureFlagCache {
+	if ttl <= 0 {
+		ttl = DefaultFeatureFlagTTL
+	}
+	return &FeatureFlagCache{
+		client: client,
+		ttl:    ttl,
+		logger: logger,
+		now:    time.Now,
+		flags:  make(map[string]*configv1.FeatureFlag),
+	}
+}
+
+// IsEnabled returns whether the flag of key is on for the user of the tenant, userID may be empty. Unknown flags
+// are off
+func (c *FeatureFlagCache) IsEnabled(ctx context.Context, key, tenantID, userID string) bool {
+	c.refreshIfStale(ctx)
+	c.mu.RLock()
+	flag := c.flags[key]
+	c.mu.RUnlock()
+	return featureflag.Evaluate(flag, tenantID, userID)
+}
+
+// Refresh lists the flags now, the cached flags are kept when it fails
+func (c *FeatureFlagCache) Refresh(ctx context.Context) error {
+	flags, err := c.client.ListFeatureFlags(ctx)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*configv1.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		byKey[flag.GetKey()] = flag
+	}
+	c.mu.Lock()
+	c.flags = byKey
+	c.fetchedAt = c.now()
+	c.mu.Unlock()
+	c.logger.Debug("feature flags refreshed", "flags", len(byKey))
+	return nil
+}
+
+// refreshIfStale lists the flags when they are older than the TTL and no other caller is listing them
+func (c *FeatureFlagCache) refreshIfStale(ctx context.Context) {
+	if !c.stale() || !c.refreshMu.TryLock() {
+		return
+	}
+	defer c.refreshMu.Unlock()
+	if !c.stale() {
+		return
+	}
+	if err := c.Refresh(ctx); err != nil {
+		// Retried after another TTL, the stale flags are served meanwhile
+		c.mu.Lock()
+		c.fetchedAt = c.now()
+		c.mu.Unlock()
+		c.logger.Warn("failed to refresh feature flags, serving cached flags", "error", err)
+	}
+}
+
+func (c *FeatureFlagCache) stale() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.fetchedAt.IsZero() || c.now().Sub(c.fetchedAt) >= c.ttl
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	configv1 "erp.localhost/internal/infra/model/config/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeFeatureFlagClient struct {
+	FeatureFlagClient
+	flags []*configv1.FeatureFlag
+	err   error
+	calls int
+}
+
+func (c *fakeFeatureFlagClient) ListFeatureFlags(context.Context) ([]*configv1.FeatureFlag, error) {
+	c.calls++
+	return c.flags, c.err
+}
+
+func TestFeatureFlagCache_IsEnabled(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &fakeFeatureFlagClient{flags: []*configv1.FeatureFlag{
+		{Key: "k", Enabled: true, Overrides: []*configv1.FeatureFlagOverride{{TenantId: "tenant-1", Enabled: true}}},
+	}}
+	cache := NewFeatureFlagCache(client, time.Minute, logger.NewBaseLogger(shared.ModuleConfig))
+	cache.now = func() time.Time { return now }
+
+	assert.True(t, cache.IsEnabled(ctx, "k", "tenant-1", "user-1"))
+	assert.False(t, cache.IsEnabled(ctx, "k", "tenant-2", ""))
+	assert.False(t, cache.IsEnabled(ctx, "unknown", "tenant-1", ""))
+	assert.Equal(t, 1, client.calls, "the flags are listed once per TTL")
+
+	// The stale flags are served while the service fails, and listed again after another TTL
+	now = now.Add(time.Minute)
+	client.err = errors.New("unavailable")
+	assert.True(t, cache.IsEnabled(ctx, "k", "tenant-1", ""))
+	assert.True(t, cache.IsEnabled(ctx, "k", "tenant-1", ""))
+	assert.Equal(t, 2, client.calls)
+
+	now = now.Add(time.Minute)
+	client.err = nil
+	client.flags = nil
+	assert.False(t, cache.IsEnabled(ctx, "k", "tenant-1", ""))
+	assert.Equal(t, 3, client.calls)
+}
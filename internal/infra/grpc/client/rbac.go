@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"erp.localhost/internal/infra/model/shared"
+)
+
+// VerifyResult is the outcome of RBACClient.VerifyPermissions
+type VerifyResult struct {
+	// Allowed is true when the user holds every permission and role checked
+	Allowed bool
+	// Permissions maps every "resource:action" checked to whether the user holds it
+	Permissions map[string]bool
+	// Roles maps every role name checked to whether the user holds it
+	Roles map[string]bool
+}
+
+type RBACClient interface {
+	// VerifyPermissions checks the "resource:action" permissions and the role names of a user on targetTenantID in
+	// one call, targetTenantID defaults to the tenant of the user. The conditions of the permissions are evaluated
+	// against attributes, e.g. the owner of the resource accessed
+	VerifyPermissions(ctx context.Context, tenantID, userID, targetTenantID string, permissions, roleNames []string, attributes map[string]string) (*VerifyResult, error)
+	// RequirePermissions returns a permission denied error unless the user holds every permission on targetTenantID
+	RequirePermissions(ctx context.Context, tenantID, userID, targetTenantID string, permissions ...string) error
+
+	Close() error
+}
+
+// rbacClient implements RBACClient
+type rbacClient struct {
+	grpcClient *GRPCClient
+	logger     logger.Logger
+	stub       authv1.VerificationServiceClient
+}
+
+func NewRBACGRPCClient(ctx context.Context, config *Config, logger logger.Logger) (RBACClient, error) {
+	grpcClient, err := NewGRPCClient(ctx, withServerIdentity(config, shared.ModuleAuth), logger)
+	if err != nil {
+		return nil, infra_error.Internal(infra_error.InternalGRPCError, err)
+	}
+	return newRBACClient(grpcClient, logger), nil
+}
+
+// newRBACClient creates an RBACClient over grpcClient, closed with the client
+func newRBACClient(grpcClient *GRPCClient, logger logger.Logger) *rbacClient {
+	return &rbacClient{
+		grpcClient: grpcClient,
+		logger:     logger,
+		stub:       authv1.NewVerificationServiceClient(grpcClient.Conn()),
+	}
+}
+
+func (r *rbacClient) VerifyPermissions(ctx context.Context, tenantID, userID, targetTenantID string, permissions, roleNames []string, attributes map[string]string) (*VerifyResult, error) {
+	req := &authv1.VerifyPermissionsRequest{
+		Identifier: &infrav1.UserIdentifier{
+			TenantId: tenantID,
+			UserId:   userID,
+		},
+		RoleNames:      roleNames,
+		TargetTenantId: targetTenantID,
+		Attributes:     attributes,
+	}
+	for _, permission := range permissions {
+		resource, action, ok := strings.Cut(permission, ":")
+		if !ok {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "permissions")
+		}
+		req.Checks = append(req.Checks, &authv1.PermissionCheck{Resource: resource, Action: action})
+	}
+	res, err := r.stub.VerifyPermissions(ctx, req)
+	if err != nil {
+		return nil, mapGRPCError(err)
+	}
+	return &VerifyResult{
+		Allowed:     res.GetAllowed(),
+		Permissions: res.GetPermissions(),
+		Roles:       res.GetRoles(),
+	}, nil
+}
+
+func (r *rbacClient) RequirePermissions(ctx context.Context, tenantID, userID, targetTenantID string, permissions ...string) error {
+	res, err := r.VerifyPermissions(ctx, tenantID, userID, targetTenantID, permissions, nil, nil)
+	if err != nil {
+		return err
+	}
+	if !res.Allowed {
+		r.logger.Debug("permission denied", "tenant_id", tenantID, "user_id", userID, "target_tenant_id", targetTenantID, "permissions", res.Permissions)
+		return infra_error.Auth(infra_error.AuthPermissionDenied)
+	}
+	return nil
+}
+
+func (r *rbacClient) Close() error {
+	return r.grpcClient.Close()
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// verificationStub answers VerifyPermissions with the permissions it grants
+type verificationStub struct {
+	authv1.VerificationServiceClient
+	granted map[string]bool
+	calls   []*authv1.VerifyPermissionsRequest
+}
+
+func (s *verificationStub) VerifyPermissions(_ context.Context, req *authv1.VerifyPermissionsRequest, _ ...grpc.CallOption) (*authv1.VerifyPermissionsResponse, error) {
+	s.calls = append(s.calls, req)
+	res := &authv1.VerifyPermissionsResponse{Allowed: true, Permissions: map[string]bool{}}
+	for _, check := range req.GetChecks() {
+		permission := check.GetResource() + ":" + check.GetAction()
+		res.Permissions[permission] = s.granted[permission]
+		res.Allowed = res.Allowed && s.granted[permission]
+	}
+	return res, nil
+}
+
+func TestRBACClient_RequirePermissions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		permissions []string
+		wantErr     bool
+	}{
+		{name: "all granted", permissions: []string{"user:read", "role:read"}},
+		{name: "one denied", permissions: []string{"user:read", "user:delete"}, wantErr: true},
+		{name: "invalid permission", permissions: []string{"user"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stub := &verificationStub{granted: map[string]bool{"user:read": true, "role:read": true}}
+			client := &rbacClient{stub: stub, logger: logger.NewBaseLogger(shared.ModuleAuth)}
+
+			err := client.RequirePermissions(context.Background(), "tenant-123", "user-123", "tenant-123", tc.permissions...)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			// Every permission is checked in one call
+			require.Len(t, stub.calls, 1)
+			assert.Len(t, stub.calls[0].GetChecks(), len(tc.permissions))
+			assert.Equal(t, "tenant-123", stub.calls[0].GetIdentifier().GetTenantId())
+		})
+	}
+}
+
+func TestRBACClient_RequirePermissions_Denied(t *testing.T) {
+	stub := &verificationStub{granted: map[string]bool{}}
+	client := &rbacClient{stub: stub, logger: logger.NewBaseLogger(shared.ModuleAuth)}
+
+	err := client.RequirePermissions(context.Background(), "tenant-123", "user-123", "tenant-123", "user:read")
+	appErr, ok := infra_error.AsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, infra_error.AuthPermissionDenied.Code, appErr.Code)
+}
@@ -0,0 +1,160 @@
+// Package discovery resolves the gRPC targets of the services, so a service finds the others from its
+// configuration instead of hardcoded addresses: the same binary runs on localhost, in docker-compose and in
+// Kubernetes. The targets are consumed by the client.Factory
+package discovery
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/shared"
+)
+
+// Kind selects how the addresses of the services are resolved
+type Kind string
+
+const (
+	// KindStatic resolves the services to the configured addresses, localhost and their default port otherwise
+	KindStatic Kind = "static"
+	// KindEnv resolves a service to its <SERVICE>_SERVICE_ADDRESS environment variable, e.g. AUTH_SERVICE_ADDRESS,
+	// and to its static address when the variable is not set
+	KindEnv Kind = "env"
+	// KindDNS resolves a service to a dns:/// target of its name, every address of the name is used with the
+	// round_robin load balancing policy, e.g. a Kubernetes headless service
+	KindDNS Kind = "dns"
+)
+
+// DefaultPorts are the gRPC ports the services listen on unless configured otherwise
+var DefaultPorts = map[shared.Module]int{
+	shared.ModuleAuth:   5000,
+	shared.ModuleCore:   5001,
+	shared.ModuleConfig: 5002,
+}
+
+// Resolver returns the gRPC target of a service
+type Resolver interface {
+	// Resolve returns the target of the service of module, a not found error when the service is unknown
+	Resolve(module shared.Module) (string, error)
+}
+
+// Config selects and configures the resolver of a service, loaded with infra_config.Load
+type Config struct {
+	Kind Kind `yaml:"provider" env:"DISCOVERY_PROVIDER" flag:"discovery-provider" default:"env"`
+	// Addresses of the services by name, e.g. auth: auth.internal:5000, they override the default addresses of
+	// the static resolver
+	Addresses map[string]string `yaml:"addresses"`
+	// Domain the names of the services are resolved in by the dns resolver, e.g. erp.svc.cluster.local. The bare
+	// names are resolved when empty, e.g. the docker-compose services
+	DNSDomain string `yaml:"dns_domain" env:"DISCOVERY_DNS_DOMAIN" flag:"discovery-dns-domain"`
+}
+
+// Validate checks the resolver is known
+func (c *Config) Validate() error {
+	switch c.Kind {
+	case KindStatic, KindEnv, KindDNS:
+		return nil
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "DISCOVERY_PROVIDER").
+			WithError(fmt.Errorf("unknown discovery provider %q", c.Kind))
+	}
+}
+
+// New creates the resolver selected by config
+func New(config *Config) (Resolver, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	static := NewStaticResolver(config.Addresses)
+	switch config.Kind {
+	case KindStatic:
+		return static, nil
+	case KindDNS:
+		return NewDNSResolver(config.DNSDomain), nil
+	default:
+		return NewEnvResolver(os.LookupEnv, static), nil
+	}
+}
+
+// serviceName returns the name of the service of module, e.g. auth
+func serviceName(module shared.Module) string {
+	return strings.ToLower(string(module))
+}
+
+// unknownService is returned for modules that are not a gRPC service
+func unknownService(module shared.Module) error {
+	return infra_error.NotFound(infra_error.NotFoundResource, "service", string(module))
+}
+
+// StaticResolver resolves the services to fixed addresses
+type StaticResolver struct {
+	addresses map[string]string
+}
+
+// NewStaticResolver creates a resolver of addresses, keyed by service name. The services missing from addresses
+// resolve to localhost and their default port
+func NewStaticResolver(addresses map[string]string) *StaticResolver {
+	r := &StaticResolver{addresses: make(map[string]string, len(DefaultPorts))}
+	for module, port := range DefaultPorts {
+		r.addresses[serviceName(module)] = fmt.Sprintf("localhost:%d", port)
+	}
+	for name, address := range addresses {
+		r.addresses[strings.ToLower(name)] = address
+	}
+	return r
+}
+
+func (r *StaticResolver) Resolve(module shared.Module) (string, error) {
+	address, ok := r.addresses[serviceName(module)]
+	if !ok || address == "" {
+		return "", unknownService(module)
+	}
+	return address, nil
+}
+
+// EnvResolver resolves the services to the addresses of their environment variables
+type EnvResolver struct {
+	lookup   func(string) (string, bool)
+	fallback Resolver
+}
+
+// NewEnvResolver creates a resolver reading the variables with lookup, e.g. os.LookupEnv. The services without a
+// variable are resolved by fallback
+func NewEnvResolver(lookup func(string) (string, bool), fallback Resolver) *EnvResolver {
+	return &EnvResolver{lookup: lookup, fallback: fallback}
+}
+
+// EnvVar returns the environment variable of the address of the service of module, e.g. AUTH_SERVICE_ADDRESS
+func EnvVar(module shared.Module) string {
+	return strings.ToUpper(string(module)) + "_SERVICE_ADDRESS"
+}
+
+func (r *EnvResolver) Resolve(module shared.Module) (string, error) {
+	if address, ok := r.lookup(EnvVar(module)); ok && address != "" {
+		return address, nil
+	}
+	return r.fallback.Resolve(module)
+}
+
+// DNSResolver resolves the services to dns:/// targets, the gRPC DNS resolver watches the addresses of the names
+type DNSResolver struct {
+	domain string
+}
+
+// NewDNSResolver creates a resolver of the names of the services in domain, the bare names when empty
+func NewDNSResolver(domain string) *DNSResolver {
+	return &DNSResolver{domain: strings.Trim(domain, ".")}
+}
+
+func (r *DNSResolver) Resolve(module shared.Module) (string, error) {
+	port, ok := DefaultPorts[module]
+	if !ok {
+		return "", unknownService(module)
+	}
+	host := serviceName(module)
+	if r.domain != "" {
+		host += "." + r.domain
+	}
+	return fmt.Sprintf("dns:///%s:%d", host, port), nil
+}
@@ -0,0 +1,59 @@
+package discovery
+
+import (
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvers(t *testing.T) {
+	static := NewStaticResolver(map[string]string{"Config": "config.internal:6002"})
+	env := NewEnvResolver(func(name string) (string, bool) {
+		if name == "AUTH_SERVICE_ADDRESS" {
+			return "auth:5000", true
+		}
+		return "", false
+	}, static)
+
+	testCases := []struct {
+		name     string
+		resolver Resolver
+		module   shared.Module
+		expected string
+	}{
+		{name: "static default", resolver: static, module: shared.ModuleAuth, expected: "localhost:5000"},
+		{name: "static configured", resolver: static, module: shared.ModuleConfig, expected: "config.internal:6002"},
+		{name: "env variable", resolver: env, module: shared.ModuleAuth, expected: "auth:5000"},
+		{name: "env fallback", resolver: env, module: shared.ModuleCore, expected: "localhost:5001"},
+		{name: "dns bare name", resolver: NewDNSResolver(""), module: shared.ModuleCore, expected: "dns:///core:5001"},
+		{name: "dns domain", resolver: NewDNSResolver("erp.svc.cluster.local."), module: shared.ModuleAuth,
+			expected: "dns:///auth.erp.svc.cluster.local:5000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address, err := tc.resolver.Resolve(tc.module)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, address)
+		})
+	}
+}
+
+func TestResolvers_UnknownService(t *testing.T) {
+	for _, resolver := range []Resolver{NewStaticResolver(nil), NewDNSResolver("")} {
+		_, err := resolver.Resolve(shared.ModuleGateway)
+		assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+	}
+}
+
+func TestNew(t *testing.T) {
+	resolver, err := New(&Config{Kind: KindDNS})
+	require.NoError(t, err)
+	assert.IsType(t, &DNSResolver{}, resolver)
+
+	_, err = New(&Config{Kind: "consul"})
+	assert.Error(t, err)
+}
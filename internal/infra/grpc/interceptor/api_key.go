@@ -0,0 +1,75 @@
+package interceptor
+
+import (
+	"context"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// APIKeyHeader is the metadata key carrying an API key
+const APIKeyHeader = "x-api-key"
+
+// APIKeyAuthenticator resolves an API key to the tenant and user id its calls run as
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (tenantID, userID string, err error)
+}
+
+// WithAPIKey returns a context that sends key with outgoing calls
+func WithAPIKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, APIKeyHeader, key)
+}
+
+// ServerAPIKeyInterceptor creates a server-side interceptor that authenticates calls carrying an API key.
+// The request identifier is replaced with the identity of the key, so callers cannot act as another user.
+// Calls without an API key are passed through unchanged.
+func ServerAPIKeyInterceptor(authenticator APIKeyAuthenticator, log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(APIKeyHeader)
+		if len(keys) == 0 {
+			return handler(ctx, req)
+		}
+
+		tenantID, userID, err := authenticator.AuthenticateAPIKey(ctx, keys[0])
+		if err != nil {
+			log.Warn("api key authentication failed", "method", info.FullMethod, "error", err)
+			return nil, infra_error.ToGRPCError(err)
+		}
+
+		if requested := tenantFromRequest(req); requested != "" && requested != tenantID {
+			log.Warn("api key used for another tenant", "tenant_id", tenantID, "requested_tenant_id", requested, "method", info.FullMethod)
+			return nil, infra_error.ToGRPCError(infra_error.Auth(infra_error.AuthTenantAccessDenied))
+		}
+		if !setIdentifier(req, &infrav1.UserIdentifier{TenantId: tenantID, UserId: userID}) {
+			log.Warn("api key used for a method without identifier", "tenant_id", tenantID, "method", info.FullMethod)
+			return nil, infra_error.ToGRPCError(infra_error.Auth(infra_error.AuthPermissionDenied))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// setIdentifier sets the identifier field of req, it returns false when req has no identifier field
+func setIdentifier(req interface{}, identifier *infrav1.UserIdentifier) bool {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return false
+	}
+	reflected := msg.ProtoReflect()
+	field := reflected.Descriptor().Fields().ByName("identifier")
+	if field == nil || field.Message() == nil || field.Message().FullName() != identifier.ProtoReflect().Descriptor().FullName() {
+		return false
+	}
+	reflected.Set(field, protoreflect.ValueOfMessage(identifier.ProtoReflect()))
+	return true
+}
@@ -0,0 +1,108 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	shared "erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAPIKeyAuthenticator struct {
+	keys map[string][2]string
+}
+
+func (f *fakeAPIKeyAuthenticator) AuthenticateAPIKey(_ context.Context, key string) (string, string, error) {
+	identity, ok := f.keys[key]
+	if !ok {
+		return "", "", infra_error.Auth(infra_error.AuthTokenInvalid)
+	}
+	return identity[0], identity[1], nil
+}
+
+func TestServerAPIKeyInterceptor(t *testing.T) {
+	authenticator := &fakeAPIKeyAuthenticator{keys: map[string][2]string{
+		"valid-key": {"tenant-1", "apikey:key-1"},
+	}}
+	intercept := ServerAPIKeyInterceptor(authenticator, logger.NewBaseLogger(shared.ModuleAuth))
+	info := &grpc.UnaryServerInfo{FullMethod: "/auth.v1.APIKeyService/ListAPIKeys"}
+
+	testCases := []struct {
+		name           string
+		key            string
+		req            interface{}
+		wantCode       codes.Code
+		wantIdentifier *infrav1.UserIdentifier
+	}{
+		{
+			name:           "no api key passes through",
+			req:            &authv1.ListAPIKeysRequest{Identifier: &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "user-1"}},
+			wantCode:       codes.OK,
+			wantIdentifier: &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "user-1"},
+		},
+		{
+			name:           "identifier is replaced by the key identity",
+			key:            "valid-key",
+			req:            &authv1.ListAPIKeysRequest{Identifier: &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "user-1"}},
+			wantCode:       codes.OK,
+			wantIdentifier: &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "apikey:key-1"},
+		},
+		{
+			name:           "missing identifier is set",
+			key:            "valid-key",
+			req:            &authv1.ListAPIKeysRequest{},
+			wantCode:       codes.OK,
+			wantIdentifier: &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "apikey:key-1"},
+		},
+		{
+			name:     "invalid key",
+			key:      "invalid-key",
+			req:      &authv1.ListAPIKeysRequest{},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "other tenant",
+			key:      "valid-key",
+			req:      &authv1.ListAPIKeysRequest{Identifier: &infrav1.UserIdentifier{TenantId: "tenant-2", UserId: "user-1"}},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "method without identifier",
+			key:      "valid-key",
+			req:      &authv1.VerifyTokenRequest{Token: "token"},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.key != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyHeader, tc.key))
+			}
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return req, nil
+			}
+
+			_, err := intercept(ctx, tc.req, info, handler)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Equal(t, tc.wantCode == codes.OK, called)
+			if tc.wantIdentifier != nil {
+				identifier := tc.req.(*authv1.ListAPIKeysRequest).GetIdentifier()
+				require.NotNil(t, identifier)
+				assert.Equal(t, tc.wantIdentifier.GetTenantId(), identifier.GetTenantId())
+				assert.Equal(t, tc.wantIdentifier.GetUserId(), identifier.GetUserId())
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthorizationHeader is the metadata key carrying the bearer access token relayed by the gateway
+const AuthorizationHeader = "authorization"
+
+// BearerTokenFromContext returns the bearer token of an incoming call, empty when there is none
+func BearerTokenFromContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AuthorizationHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
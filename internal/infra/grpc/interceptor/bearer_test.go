@@ -0,0 +1,34 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestBearerTokenFromContext(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authorization []string
+		want          string
+	}{
+		{name: "none"},
+		{name: "bearer", authorization: []string{"Bearer token-1"}, want: "token-1"},
+		{name: "scheme is case insensitive", authorization: []string{"bearer token-1"}, want: "token-1"},
+		{name: "other scheme", authorization: []string{"Basic dXNlcjpwYXNz"}},
+		{name: "no scheme", authorization: []string{"token-1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tc.authorization != nil {
+				md.Set(AuthorizationHeader, tc.authorization...)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			assert.Equal(t, tc.want, BearerTokenFromContext(ctx))
+		})
+	}
+}
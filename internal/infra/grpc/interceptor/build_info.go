@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"context"
+
+	"erp.localhost/internal/infra/buildinfo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Response headers naming the build that served a call, to tell the versions apart in mixed-version deployments
+const (
+	BuildVersionHeader = "x-build-version"
+	BuildCommitHeader  = "x-build-commit"
+)
+
+// buildInfoHeader returns the response headers of the running build
+func buildInfoHeader() metadata.MD {
+	build := buildinfo.Get()
+	return metadata.Pairs(BuildVersionHeader, build.Version, BuildCommitHeader, build.ShortCommit())
+}
+
+// ServerBuildInfoInterceptor creates a server-side interceptor adding the version and commit of the build to the
+// response headers of every call, failed calls included
+func ServerBuildInfoInterceptor() grpc.UnaryServerInterceptor {
+	header := buildInfoHeader()
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		// Fails only outside of a call, e.g. when the interceptor is called directly
+		_ = grpc.SetHeader(ctx, header)
+		return handler(ctx, req)
+	}
+}
+
+// ServerBuildInfoStreamInterceptor is the streaming counterpart of ServerBuildInfoInterceptor
+func ServerBuildInfoStreamInterceptor() grpc.StreamServerInterceptor {
+	header := buildInfoHeader()
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		_ = stream.SetHeader(header)
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,39 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/infra/buildinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTransportStream records the headers set by the handlers of a call
+type fakeTransportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *fakeTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestServerBuildInfoInterceptor(t *testing.T) {
+	stream := &fakeTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	failing := func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+
+	_, err := ServerBuildInfoInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, failing)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	require.NotNil(t, stream.header)
+	assert.Equal(t, []string{buildinfo.Get().Version}, stream.header.Get(BuildVersionHeader))
+	assert.Equal(t, []string{buildinfo.Get().ShortCommit()}, stream.header.Get(BuildCommitHeader))
+}
@@ -0,0 +1,47 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// ClientIPHeader and ClientUserAgentHeader are the metadata keys carrying the address and user agent of the end
+	// client of a call relayed by the gateway
+	ClientIPHeader        = "x-client-ip"
+	ClientUserAgentHeader = "x-client-user-agent"
+)
+
+// WithClient returns a context that sends the address and user agent of the end client with outgoing calls
+func WithClient(ctx context.Context, ip, userAgent string) context.Context {
+	if ip != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ClientIPHeader, ip)
+	}
+	if userAgent != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ClientUserAgentHeader, userAgent)
+	}
+	return ctx
+}
+
+// ClientFromContext returns the address and user agent of the end client of an incoming call, the ones relayed by
+// the gateway or else the ones of the caller
+func ClientFromContext(ctx context.Context) (ip, userAgent string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(ClientIPHeader); len(values) > 0 {
+		ip = values[0]
+	} else if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if values := md.Get(ClientUserAgentHeader); len(values) > 0 {
+		userAgent = values[0]
+	} else if values := md.Get("user-agent"); len(values) > 0 {
+		userAgent = values[0]
+	}
+	return ip, userAgent
+}
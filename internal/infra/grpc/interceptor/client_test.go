@@ -0,0 +1,47 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientFromContext(t *testing.T) {
+	caller := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 5123}})
+
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		wantIP        string
+		wantUserAgent string
+	}{
+		{
+			name: "none",
+			ctx:  context.Background(),
+		},
+		{
+			name:          "caller",
+			ctx:           metadata.NewIncomingContext(caller, metadata.Pairs("user-agent", "grpc-go/1.0")),
+			wantIP:        "10.0.0.5",
+			wantUserAgent: "grpc-go/1.0",
+		},
+		{
+			name:          "relayed by the gateway",
+			ctx:           metadata.NewIncomingContext(caller, metadata.Pairs("user-agent", "grpc-go/1.0", ClientIPHeader, "203.0.113.7", ClientUserAgentHeader, "Mozilla/5.0")),
+			wantIP:        "203.0.113.7",
+			wantUserAgent: "Mozilla/5.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ip, userAgent := ClientFromContext(tc.ctx)
+			assert.Equal(t, tc.wantIP, ip)
+			assert.Equal(t, tc.wantUserAgent, userAgent)
+		})
+	}
+}
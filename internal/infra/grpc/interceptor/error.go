@@ -0,0 +1,86 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerErrorInterceptor creates a server-side interceptor that converts the errors returned by the handlers to
+// gRPC status errors, see StatusError, and VerboseStatusError when verbose. It runs closest to the handlers so the
+// other interceptors see the status
+func ServerErrorInterceptor(verbose bool) grpc.UnaryServerInterceptor {
+	convert := statusConverter(verbose)
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, convert(err)
+	}
+}
+
+// ServerErrorStreamInterceptor is the streaming counterpart of ServerErrorInterceptor
+func ServerErrorStreamInterceptor(verbose bool) grpc.StreamServerInterceptor {
+	convert := statusConverter(verbose)
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return convert(handler(srv, stream))
+	}
+}
+
+func statusConverter(verbose bool) func(error) error {
+	if verbose {
+		return VerboseStatusError
+	}
+	return StatusError
+}
+
+// StatusError converts err to a gRPC status error. AppErrors, even wrapped, get the status code of their catalog
+// entry, e.g. validation errors are InvalidArgument and missing resources NotFound, with the error attached as
+// details. Status errors are kept, canceled and timed out contexts get their own code and any other error is
+// Internal
+func StatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var appErr *infra_error.AppError
+	if errors.As(err, &appErr) {
+		return infra_error.ToGRPCError(appErr)
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return infra_error.ToGRPCError(infra_error.Internal(infra_error.InternalTimeout, err))
+	}
+	return infra_error.ToGRPCError(err)
+}
+
+// VerboseStatusError converts err like StatusError and adds the full error, with its underlying cause, to the
+// status as a google.rpc.DebugInfo detail. Status errors returned by the handlers are kept as they are
+func VerboseStatusError(err error) error {
+	statusErr := StatusError(err)
+	if statusErr == nil || statusErr == err {
+		return statusErr
+	}
+	st := status.Convert(statusErr)
+	if withDebugInfo, detailsErr := st.WithDetails(&errdetails.DebugInfo{Detail: err.Error()}); detailsErr == nil {
+		return withDebugInfo.Err()
+	}
+	return statusErr
+}
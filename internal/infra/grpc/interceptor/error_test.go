@@ -0,0 +1,93 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "validation", err: infra_error.Validation(infra_error.ValidationRequiredFields, "tenant_id"), wantCode: codes.InvalidArgument},
+		{name: "not found", err: infra_error.NotFound(infra_error.NotFoundResource, "role", "role-1"), wantCode: codes.NotFound},
+		{name: "conflict", err: infra_error.Conflict(infra_error.ConflictDuplicateResource), wantCode: codes.AlreadyExists},
+		{name: "unauthenticated", err: infra_error.Auth(infra_error.AuthUnauthenticated), wantCode: codes.Unauthenticated},
+		{name: "permission denied", err: infra_error.Auth(infra_error.AuthPermissionDenied), wantCode: codes.PermissionDenied},
+		{name: "wrapped", err: fmt.Errorf("get role: %w", infra_error.NotFound(infra_error.NotFoundResource, "role", "role-1")), wantCode: codes.NotFound},
+		{name: "status", err: status.Error(codes.Unavailable, "down"), wantCode: codes.Unavailable},
+		{name: "canceled", err: context.Canceled, wantCode: codes.Canceled},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), wantCode: codes.DeadlineExceeded},
+		{name: "other", err: errors.New("boom"), wantCode: codes.Internal},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantCode, status.Code(StatusError(tc.err)))
+		})
+	}
+	assert.NoError(t, StatusError(nil))
+}
+
+func TestServerErrorInterceptor(t *testing.T) {
+	intercept := ServerErrorInterceptor(false)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Errors"}
+	notFound := func(context.Context, interface{}) (interface{}, error) {
+		return nil, infra_error.NotFound(infra_error.NotFoundResource, "user", "user-1")
+	}
+
+	_, err := intercept(context.Background(), nil, info, notFound)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	var errorInfo *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			errorInfo = info
+		}
+	}
+	require.NotNil(t, errorInfo)
+	assert.Equal(t, infra_error.NotFoundResource.Code, errorInfo.GetReason())
+
+	resp, err := intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) { return "ok", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	streamErr := ServerErrorStreamInterceptor(false)(nil, nil, &grpc.StreamServerInfo{}, func(interface{}, grpc.ServerStream) error {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "format")
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(streamErr))
+}
+
+func TestVerboseStatusError(t *testing.T) {
+	debugInfo := func(err error) *errdetails.DebugInfo {
+		for _, detail := range status.Convert(err).Details() {
+			if info, ok := detail.(*errdetails.DebugInfo); ok {
+				return info
+			}
+		}
+		return nil
+	}
+
+	err := VerboseStatusError(infra_error.Internal(infra_error.InternalDatabaseError, errors.New("connection refused")))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	require.NotNil(t, debugInfo(err))
+	assert.Contains(t, debugInfo(err).GetDetail(), "connection refused")
+	// The cause is only sent when verbose
+	assert.Nil(t, debugInfo(StatusError(infra_error.Internal(infra_error.InternalDatabaseError, errors.New("connection refused")))))
+
+	unavailable := status.Error(codes.Unavailable, "down")
+	assert.Same(t, unavailable, VerboseStatusError(unavailable))
+	assert.NoError(t, VerboseStatusError(nil))
+}
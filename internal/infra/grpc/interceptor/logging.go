@@ -5,10 +5,25 @@ import (
 	"time"
 
 	"erp.localhost/internal/infra/logging/logger"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// RequestIDHeader is the metadata key of the correlation id shared by all the calls of a request
+const RequestIDHeader = "x-request-id"
+
+// requestIDFromMetadata returns the correlation id sent by the caller, or a new one for calls that start a request
+func requestIDFromMetadata(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return uuid.New().String()
+}
+
 // Shared logging helper
 func logGRPCCall(log logger.Logger, method string, duration time.Duration, err error, isClient bool) {
 	side := "server"
@@ -42,6 +57,13 @@ func ClientLoggingInterceptor(log logger.Logger) grpc.UnaryClientInterceptor {
 		opts ...grpc.CallOption,
 	) error {
 		start := time.Now()
+		// Downstream services log the request under the same correlation id
+		if requestID := logger.RequestID(ctx); requestID != "" {
+			if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(RequestIDHeader)) == 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+			}
+		}
+		log := log.WithContext(ctx)
 		log.Debug("gRPC client request started", "method", method)
 
 		err := invoker(ctx, method, req, reply, cc, opts...)
@@ -51,7 +73,8 @@ func ClientLoggingInterceptor(log logger.Logger) grpc.UnaryClientInterceptor {
 	}
 }
 
-// ServerLoggingInterceptor creates a server-side logging interceptor
+// ServerLoggingInterceptor creates a server-side logging interceptor. It stores the correlation id of the
+// request and a logger carrying it in the context, see logger.FromContext, and returns the id in the response header
 func ServerLoggingInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -60,6 +83,12 @@ func ServerLoggingInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
 		start := time.Now()
+		requestID := requestIDFromMetadata(ctx)
+		ctx = logger.WithRequestID(ctx, requestID)
+		// Fails outside of a transport stream, e.g. when the handler is called directly
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+		log := log.WithContext(ctx)
+		ctx = logger.NewContext(ctx, log)
 		log.Debug("gRPC server request started", "method", info.FullMethod)
 
 		resp, err := handler(ctx, req)
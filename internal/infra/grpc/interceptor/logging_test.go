@@ -0,0 +1,71 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerLoggingInterceptor_RequestID(t *testing.T) {
+	intercept := ServerLoggingInterceptor(logger.NewBaseLogger(shared.ModuleAuth))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Logging"}
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "reuses the id of the caller",
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "request-1")),
+			expected: "request-1",
+		},
+		{
+			name: "generates an id for new requests",
+			ctx:  context.Background(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestID string
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				requestID = logger.RequestID(ctx)
+				assert.NotNil(t, logger.FromContext(ctx))
+				return "ok", nil
+			}
+
+			_, err := intercept(tc.ctx, nil, info, handler)
+			require.NoError(t, err)
+			if tc.expected != "" {
+				assert.Equal(t, tc.expected, requestID)
+			} else {
+				assert.NotEmpty(t, requestID)
+			}
+		})
+	}
+}
+
+func TestClientLoggingInterceptor_RequestID(t *testing.T) {
+	intercept := ClientLoggingInterceptor(logger.NewBaseLogger(shared.ModuleAuth))
+
+	var sent []string
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(RequestIDHeader)
+		return nil
+	}
+
+	ctx := logger.WithRequestID(context.Background(), "request-1")
+	require.NoError(t, intercept(ctx, "/test.v1.TestService/Logging", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"request-1"}, sent)
+
+	require.NoError(t, intercept(context.Background(), "/test.v1.TestService/Logging", nil, nil, nil, invoker))
+	assert.Empty(t, sent)
+}
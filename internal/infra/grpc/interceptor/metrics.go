@@ -0,0 +1,55 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	serverHandled = metrics.NewCounter("grpc_server_handled_total",
+		"Total number of RPCs completed on the server, by method and status code.", "grpc_method", "grpc_code")
+	serverHandlingSeconds = metrics.NewHistogram("grpc_server_handling_seconds",
+		"Latency of RPCs handled by the server in seconds.", metrics.DefaultBuckets, "grpc_method")
+	clientHandled = metrics.NewCounter("grpc_client_handled_total",
+		"Total number of RPCs completed by the client, by method and status code.", "grpc_method", "grpc_code")
+	clientHandlingSeconds = metrics.NewHistogram("grpc_client_handling_seconds",
+		"Latency of RPCs until the response is received by the client in seconds.", metrics.DefaultBuckets, "grpc_method")
+)
+
+// ServerMetricsInterceptor creates a server-side interceptor that counts requests by status code and records their latency
+func ServerMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		serverHandlingSeconds.ObserveSince(start, info.FullMethod)
+		serverHandled.Inc(info.FullMethod, status.Code(err).String())
+		return resp, err
+	}
+}
+
+// ClientMetricsInterceptor creates a client-side interceptor that counts requests by status code and records their latency
+func ClientMetricsInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		clientHandlingSeconds.ObserveSince(start, method)
+		clientHandled.Inc(method, status.Code(err).String())
+		return err
+	}
+}
@@ -0,0 +1,34 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestServerMetricsInterceptor(t *testing.T) {
+	intercept := ServerMetricsInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Metrics"}
+	ok := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+	notFound := func(context.Context, interface{}) (interface{}, error) {
+		return nil, infra_error.ToGRPCError(infra_error.NotFound(infra_error.NotFoundResource, "user", "user-1"))
+	}
+
+	okBefore := serverHandled.Value(info.FullMethod, codes.OK.String())
+	notFoundBefore := serverHandled.Value(info.FullMethod, codes.NotFound.String())
+	observedBefore := serverHandlingSeconds.Count(info.FullMethod)
+
+	resp, err := intercept(context.Background(), nil, info, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	_, err = intercept(context.Background(), nil, info, notFound)
+	assert.Error(t, err)
+
+	assert.Equal(t, okBefore+1, serverHandled.Value(info.FullMethod, codes.OK.String()))
+	assert.Equal(t, notFoundBefore+1, serverHandled.Value(info.FullMethod, codes.NotFound.String()))
+	assert.Equal(t, observedBefore+2, serverHandlingSeconds.Count(info.FullMethod))
+}
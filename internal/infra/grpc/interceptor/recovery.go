@@ -0,0 +1,77 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/metrics"
+	"google.golang.org/grpc"
+)
+
+var serverPanics = metrics.NewCounter("grpc_server_panics_total",
+	"Total number of panics recovered in RPC handlers, by method.", "grpc_method")
+
+// PanicReporter is called with the panics recovered in the handlers and their stack, e.g. to send them to an
+// external error tracker
+type PanicReporter func(ctx context.Context, method string, recovered any, stack []byte)
+
+// ServerRecoveryInterceptor creates a server-side interceptor that recovers the panics of the handlers, so they fail
+// the call with codes.Internal instead of crashing the process. The panic is logged with its stack, counted and
+// passed to report, which may be nil
+func ServerRecoveryInterceptor(log logger.Logger, report PanicReporter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				resp, err = nil, handlePanic(ctx, log, report, info.FullMethod, recovered)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// ServerRecoveryStreamInterceptor is the streaming counterpart of ServerRecoveryInterceptor
+func ServerRecoveryStreamInterceptor(log logger.Logger, report PanicReporter) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = handlePanic(stream.Context(), log, report, info.FullMethod, recovered)
+			}
+		}()
+		return handler(srv, stream)
+	}
+}
+
+// handlePanic logs, counts and reports a recovered panic and returns the error of the call, the panic value is not
+// sent to the client
+func handlePanic(ctx context.Context, log logger.Logger, report PanicReporter, method string, recovered any) error {
+	stack := debug.Stack()
+	serverPanics.Inc(method)
+	log.WithContext(ctx).Error("gRPC handler panicked", "method", method, "panic", recovered, "stack", string(stack))
+	if report != nil {
+		reportPanic(ctx, log, report, method, recovered, stack)
+	}
+	return infra_error.ToGRPCError(infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("panic in %s: %v", method, recovered)))
+}
+
+// reportPanic calls report, a failing reporter must not crash the process either
+func reportPanic(ctx context.Context, log logger.Logger, report PanicReporter, method string, recovered any, stack []byte) {
+	defer func() {
+		if reporterPanic := recover(); reporterPanic != nil {
+			log.WithContext(ctx).Error("panic reporter panicked", "method", method, "panic", reporterPanic)
+		}
+	}()
+	report(ctx, method, recovered, stack)
+}
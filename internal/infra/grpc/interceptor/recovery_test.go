@@ -0,0 +1,72 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testServerStream is a server stream carrying only a context
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestServerRecoveryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Panic"}
+	var reported []any
+	report := func(_ context.Context, method string, recovered any, stack []byte) {
+		assert.Equal(t, info.FullMethod, method)
+		assert.NotEmpty(t, stack)
+		reported = append(reported, recovered)
+	}
+	intercept := ServerRecoveryInterceptor(logger.NewBaseLogger(shared.ModuleAuth), report)
+	panicsBefore := serverPanics.Value(info.FullMethod)
+
+	resp, err := intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	// The panic value is not sent to the client
+	assert.NotContains(t, status.Convert(err).Message(), "boom")
+	assert.Equal(t, []any{"boom"}, reported)
+	assert.Equal(t, panicsBefore+1, serverPanics.Value(info.FullMethod))
+
+	resp, err = intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) { return "ok", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	// A panicking reporter is recovered too
+	intercept = ServerRecoveryInterceptor(logger.NewBaseLogger(shared.ModuleAuth), func(context.Context, string, any, []byte) {
+		panic("reporter")
+	})
+	_, err = intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestServerRecoveryStreamInterceptor(t *testing.T) {
+	intercept := ServerRecoveryStreamInterceptor(logger.NewBaseLogger(shared.ModuleAuth), nil)
+	info := &grpc.StreamServerInfo{FullMethod: "/test.v1.TestService/PanicStream"}
+	stream := &testServerStream{ctx: context.Background()}
+
+	err := intercept(nil, stream, info, func(interface{}, grpc.ServerStream) error {
+		var values map[string]int
+		values["nil map"] = 1
+		return nil
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NoError(t, intercept(nil, stream, info, func(interface{}, grpc.ServerStream) error { return nil }))
+}
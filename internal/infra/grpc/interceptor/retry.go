@@ -0,0 +1,129 @@
+package interceptor
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"erp.localhost/internal/infra/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var clientRetries = metrics.NewCounter("grpc_client_retries_total",
+	"Total number of RPCs retried by the client, by method and status code of the failed attempt.", "grpc_method", "grpc_code")
+
+// idempotentPrefixes are the prefixes of the read only methods of the services, see IsIdempotentMethod
+var idempotentPrefixes = []string{"Get", "List", "Search", "Has", "Is", "Check", "Export"}
+
+// idempotentMethods are the read only methods without one of idempotentPrefixes
+var idempotentMethods = []string{"VerifyToken", "VerifyPermissions"}
+
+// IsIdempotentMethod reports whether the method of fullMethod (/package.Service/Method) only reads, so a call
+// that may have reached the server can be sent again
+func IsIdempotentMethod(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if slices.Contains(idempotentMethods, method) {
+		return true
+	}
+	return slices.ContainsFunc(idempotentPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(method, prefix)
+	})
+}
+
+// RetryPolicy configures ClientRetryInterceptor
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a call, including the first one
+	MaxAttempts int
+	// Backoff before the first retry, doubled for every following one up to MaxBackoff. The actual wait is
+	// picked at random up to the backoff so clients retrying together spread their attempts
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryableCodes are the status codes of the attempts that are retried
+	RetryableCodes []codes.Code
+	// Idempotent reports whether a method may be retried, IsIdempotentMethod when nil
+	Idempotent func(fullMethod string) bool
+}
+
+// DefaultRetryPolicy retries idempotent calls twice while the server is unavailable
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+	}
+}
+
+// backoff returns the wait before the retry following attempt, attempts count from 1
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.MaxBackoff)
+	if backoff <= 0 {
+		return 0
+	}
+	return rand.N(backoff) + 1
+}
+
+// ClientRetryInterceptor creates a client-side interceptor that retries the idempotent calls failing with one of
+// the retryable codes, with an exponential backoff. Retries stop when the context of the call is done
+func ClientRetryInterceptor(policy *RetryPolicy) grpc.UnaryClientInterceptor {
+	idempotent := policy.Idempotent
+	if idempotent == nil {
+		idempotent = IsIdempotentMethod
+	}
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if policy.MaxAttempts <= 1 || !idempotent(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			code := status.Code(err)
+			if err == nil || attempt >= policy.MaxAttempts || !slices.Contains(policy.RetryableCodes, code) {
+				return err
+			}
+			timer := time.NewTimer(policy.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			clientRetries.Inc(method, code.String())
+		}
+	}
+}
+
+// ClientTimeoutInterceptor creates a client-side interceptor that bounds the calls without a deadline to
+// timeout, retries included when it is chained before ClientRetryInterceptor
+func ClientTimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,130 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsIdempotentMethod(t *testing.T) {
+	assert.True(t, IsIdempotentMethod("/auth.v1.UserService/GetUser"))
+	assert.True(t, IsIdempotentMethod("/auth.v1.UserService/ListUsers"))
+	assert.True(t, IsIdempotentMethod("/auth.v1.RBACService/HasPermission"))
+	assert.True(t, IsIdempotentMethod("/auth.v1.AuthService/VerifyToken"))
+	assert.True(t, IsIdempotentMethod("/auth.v1.VerificationService/VerifyPermissions"))
+	assert.False(t, IsIdempotentMethod("/auth.v1.UserService/CreateUser"))
+	assert.False(t, IsIdempotentMethod("/auth.v1.AuthService/Login"))
+	assert.False(t, IsIdempotentMethod("/auth.v1.AuthService/VerifyMFAEnrollment"))
+}
+
+func TestClientRetryInterceptor(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}
+	intercept := ClientRetryInterceptor(policy)
+
+	// invoker fails with the codes in order and succeeds once they are used up
+	invoker := func(calls *int, failures ...codes.Code) grpc.UnaryInvoker {
+		return func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			*calls++
+			if *calls <= len(failures) {
+				return status.Error(failures[*calls-1], "failed")
+			}
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		failures      []codes.Code
+		expectedCalls int
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "recovers after unavailable",
+			method:        "/auth.v1.UserService/GetUser",
+			failures:      []codes.Code{codes.Unavailable, codes.Unavailable},
+			expectedCalls: 3,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "gives up after max attempts",
+			method:        "/auth.v1.UserService/GetUser",
+			failures:      []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable},
+			expectedCalls: 3,
+			expectedCode:  codes.Unavailable,
+		},
+		{
+			name:          "code not retryable",
+			method:        "/auth.v1.UserService/GetUser",
+			failures:      []codes.Code{codes.NotFound},
+			expectedCalls: 1,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:          "method not idempotent",
+			method:        "/auth.v1.UserService/CreateUser",
+			failures:      []codes.Code{codes.Unavailable},
+			expectedCalls: 1,
+			expectedCode:  codes.Unavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			err := intercept(context.Background(), tc.method, nil, nil, nil, invoker(&calls, tc.failures...))
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func TestClientRetryInterceptor_StopsWithContext(t *testing.T) {
+	intercept := ClientRetryInterceptor(&RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Hour,
+		MaxBackoff:     time.Hour,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := intercept(ctx, "/auth.v1.UserService/GetUser", nil, nil, nil,
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			calls++
+			return status.Error(codes.Unavailable, "failed")
+		})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientTimeoutInterceptor(t *testing.T) {
+	intercept := ClientTimeoutInterceptor(time.Minute)
+	deadline := func(deadline *time.Time) grpc.UnaryInvoker {
+		return func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			*deadline, _ = ctx.Deadline()
+			return nil
+		}
+	}
+
+	var got time.Time
+	assert.NoError(t, intercept(context.Background(), "/test.v1.TestService/Get", nil, nil, nil, deadline(&got)))
+	assert.WithinDuration(t, time.Now().Add(time.Minute), got, time.Second)
+
+	// The deadline of the caller is kept
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	assert.NoError(t, intercept(ctx, "/test.v1.TestService/Get", nil, nil, nil, deadline(&got)))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), got, time.Second)
+}
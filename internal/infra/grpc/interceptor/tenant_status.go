@@ -0,0 +1,37 @@
+package interceptor
+
+import (
+	"context"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"google.golang.org/grpc"
+)
+
+// TenantStatusChecker returns the error the calls of a tenant fail with, nil when the tenant is served. It is
+// called on every call, implementations cache the tenants
+type TenantStatusChecker interface {
+	CheckTenantStatus(ctx context.Context, tenantID string) error
+}
+
+// ServerTenantStatusInterceptor creates a server-side interceptor that rejects the calls of suspended and inactive
+// tenants before they reach their method. Calls without a tenant are passed through
+func ServerTenantStatusInterceptor(checker TenantStatusChecker, log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		tenantID := tenantFromRequest(req)
+		if tenantID == "" {
+			return handler(ctx, req)
+		}
+
+		if err := checker.CheckTenantStatus(ctx, tenantID); err != nil {
+			log.Warn("call refused for tenant status", "tenant_id", tenantID, "method", info.FullMethod, "error", err)
+			return nil, infra_error.ToGRPCError(err)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,70 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	shared "erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeTenantStatusChecker struct {
+	refused map[string]error
+	checked []string
+}
+
+func (f *fakeTenantStatusChecker) CheckTenantStatus(_ context.Context, tenantID string) error {
+	f.checked = append(f.checked, tenantID)
+	return f.refused[tenantID]
+}
+
+func TestServerTenantStatusInterceptor(t *testing.T) {
+	checker := &fakeTenantStatusChecker{refused: map[string]error{
+		"suspended": infra_error.Auth(infra_error.AuthTenantSuspended),
+	}}
+	intercept := ServerTenantStatusInterceptor(checker, logger.NewBaseLogger(shared.ModuleAuth))
+	info := &grpc.UnaryServerInfo{FullMethod: "/auth.v1.APIKeyService/ListAPIKeys"}
+
+	testCases := []struct {
+		name     string
+		req      interface{}
+		wantCode codes.Code
+	}{
+		{
+			name:     "active tenant",
+			req:      &authv1.ListAPIKeysRequest{Identifier: &infrav1.UserIdentifier{TenantId: "active", UserId: "user-1"}},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "suspended tenant",
+			req:      &authv1.ListAPIKeysRequest{Identifier: &infrav1.UserIdentifier{TenantId: "suspended", UserId: "user-1"}},
+			wantCode: status.Code(infra_error.ToGRPCError(infra_error.Auth(infra_error.AuthTenantSuspended))),
+		},
+		{
+			name:     "call without tenant",
+			req:      &authv1.VerifyTokenRequest{Token: "token"},
+			wantCode: codes.OK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return req, nil
+			}
+
+			_, err := intercept(context.Background(), tc.req, info, handler)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Equal(t, tc.wantCode == codes.OK, called)
+		})
+	}
+	assert.Equal(t, []string{"active", "suspended"}, checker.checked)
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otel_codes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracingInterceptors(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	method := "/auth.v1.UserService/GetUser"
+	server := ServerTracingInterceptor()
+	var serverSpan trace.SpanContext
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		serverSpan = trace.SpanContextFromContext(ctx)
+		return nil, infra_error.ToGRPCError(infra_error.NotFound(infra_error.NotFoundResource, "user", "user-1"))
+	}
+
+	// The client span is propagated in the outgoing metadata and continued by the server
+	invoker := func(ctx context.Context, method string, req, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		require.True(t, ok)
+		require.NotEmpty(t, md.Get("traceparent"))
+		_, err := server(metadata.NewIncomingContext(context.Background(), md), req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "key")
+	err := ClientTracingInterceptor()(ctx, method, nil, nil, nil, invoker)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	serverRecorded, clientRecorded := spans[0], spans[1]
+	assert.Equal(t, trace.SpanKindServer, serverRecorded.SpanKind())
+	assert.Equal(t, trace.SpanKindClient, clientRecorded.SpanKind())
+	assert.Equal(t, method, clientRecorded.Name())
+	assert.Equal(t, clientRecorded.SpanContext().TraceID(), serverSpan.TraceID())
+	assert.Equal(t, clientRecorded.SpanContext().SpanID(), serverRecorded.Parent().SpanID())
+	assert.Equal(t, otel_codes.Error, serverRecorded.Status().Code)
+
+	// Metadata of the caller is kept
+	md, _ := metadata.FromOutgoingContext(ctx)
+	assert.Empty(t, md.Get("traceparent"))
+	assert.Equal(t, []string{"key"}, md.Get("x-api-key"))
+}
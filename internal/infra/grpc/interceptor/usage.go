@@ -0,0 +1,56 @@
+package interceptor
+
+import (
+	"context"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"google.golang.org/grpc"
+)
+
+// UsageTracker counts API calls per tenant and enforces monthly caps
+type UsageTracker interface {
+	CheckCap(ctx context.Context, tenantID string) error
+	Record(tenantID, fullMethod string, failed bool)
+}
+
+// tenantFromRequest returns the tenant of the caller, requests without a tenant are not tracked
+func tenantFromRequest(req interface{}) string {
+	if r, ok := req.(interface {
+		GetIdentifier() *infrav1.UserIdentifier
+	}); ok {
+		if tenantID := r.GetIdentifier().GetTenantId(); tenantID != "" {
+			return tenantID
+		}
+	}
+	if r, ok := req.(interface{ GetTenantId() string }); ok {
+		return r.GetTenantId()
+	}
+	return ""
+}
+
+// ServerUsageInterceptor creates a server-side interceptor that rejects calls over the tenant
+// monthly cap and records every call for usage rollups
+func ServerUsageInterceptor(tracker UsageTracker, log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		tenantID := tenantFromRequest(req)
+		if tenantID == "" {
+			return handler(ctx, req)
+		}
+
+		if err := tracker.CheckCap(ctx, tenantID); err != nil {
+			log.Warn("tenant api quota exceeded", "tenant_id", tenantID, "method", info.FullMethod)
+			return nil, infra_error.ToGRPCError(err)
+		}
+
+		resp, err := handler(ctx, req)
+		tracker.Record(tenantID, info.FullMethod, err != nil)
+		return resp, err
+	}
+}
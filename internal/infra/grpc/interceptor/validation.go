@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/validation"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServerValidationInterceptor creates a server-side interceptor that validates the request messages against the
+// `validate` rules of their proto fields, see validation.Message. Invalid requests fail with InvalidArgument before
+// the handler runs, the details name the fields by their proto names, e.g. identifier.tenant_id
+func ServerValidationInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := validateRequest(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ServerValidationStreamInterceptor is the streaming counterpart of ServerValidationInterceptor, every message
+// received from the client is validated
+func ServerValidationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &validatingServerStream{ServerStream: stream})
+	}
+}
+
+// validatingServerStream validates the messages received on the wrapped stream
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateRequest(m)
+}
+
+// validateRequest returns the status error of the violations of req, the requests that are not proto messages are
+// not validated
+func validateRequest(req interface{}) error {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := validation.Message(message); err != nil {
+		return infra_error.ToGRPCError(err)
+	}
+	return nil
+}
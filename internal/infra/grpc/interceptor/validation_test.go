@@ -0,0 +1,83 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// recvServerStream is a server stream receiving a copy of msg
+type recvServerStream struct {
+	grpc.ServerStream
+	msg proto.Message
+}
+
+func (s *recvServerStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.msg)
+	return nil
+}
+
+func TestServerValidationInterceptor(t *testing.T) {
+	intercept := ServerValidationInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/auth.v1.RBACService/GetRole"}
+	called := false
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		called = true
+		return "ok", nil
+	}
+
+	resp, err := intercept(context.Background(), &authv1.GetRoleRequest{Identifier: &infrav1.UserIdentifier{TenantId: "tenant-1"}}, info, handler)
+	assert.Nil(t, resp)
+	assert.False(t, called, "the handler must not run for invalid requests")
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	reason := ""
+	for _, detail := range st.Details() {
+		if errorInfo, ok := detail.(*errdetails.ErrorInfo); ok {
+			reason = errorInfo.GetReason()
+		}
+	}
+	assert.Equal(t, infra_error.ValidationRequiredFields.Code, reason)
+	// The fields are named as in the proto messages
+	assert.Contains(t, st.Message(), "identifier.user_id")
+
+	resp, err = intercept(context.Background(), &authv1.GetRoleRequest{
+		Identifier:     &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "user-1"},
+		RoleId:         "role-1",
+		TargetTenantId: "tenant-1",
+	}, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	assert.True(t, called)
+
+	// Requests that are not proto messages are passed through
+	_, err = intercept(context.Background(), "not a message", info, handler)
+	assert.NoError(t, err)
+}
+
+func TestServerValidationStreamInterceptor(t *testing.T) {
+	intercept := ServerValidationStreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/auth.v1.RBACService/GetRole"}
+	recv := func(_ interface{}, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&authv1.GetRoleRequest{})
+	}
+
+	err := intercept(nil, &recvServerStream{msg: &authv1.GetRoleRequest{RoleId: "role-1"}}, info, recv)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = intercept(nil, &recvServerStream{msg: &authv1.GetRoleRequest{
+		Identifier:     &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "user-1"},
+		RoleId:         "role-1",
+		TargetTenantId: "tenant-1",
+	}}, info, recv)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/status"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// DefaultHealthCheckInterval is how often dependencies are probed, and how old a result Check may return
+const DefaultHealthCheckInterval = 10 * time.Second
+
+type dependency struct {
+	name  string
+	probe status.Probe
+}
+
+// HealthServer implements grpc.health.v1 for a server.
+// Each dependency is reported as a service of its own name. The overall service ("") and the registered
+// services are serving while the server accepts traffic and every dependency probe succeeds.
+type HealthServer struct {
+	*health.Server
+	logger   logger.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	refreshMu sync.Mutex
+
+	mu           sync.Mutex
+	dependencies []dependency
+	services     []string
+	checkedAt    time.Time
+	notServing   bool
+}
+
+func NewHealthServer(interval time.Duration, logger logger.Logger) *HealthServer {
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	return &HealthServer{
+		Server:   health.NewServer(),
+		logger:   logger,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// AddDependency registers a dependency probed for readiness, e.g. a Mongo or Redis ping or a downstream gRPC client
+func (h *HealthServer) AddDependency(name string, probe status.Probe) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dependencies = append(h.dependencies, dependency{name: name, probe: probe})
+	h.checkedAt = time.Time{}
+}
+
+// addService reports a registered gRPC service, it follows the overall status
+func (h *HealthServer) addService(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.services = append(h.services, name)
+	h.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	h.checkedAt = time.Time{}
+}
+
+// SetServing reports the server as accepting traffic again, dependencies are probed on the next check
+func (h *HealthServer) SetServing() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.notServing = false
+	h.checkedAt = time.Time{}
+	h.Resume()
+}
+
+// SetNotServing reports every service as not serving until SetServing, used to drain traffic before shutdown
+func (h *HealthServer) SetNotServing() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.notServing = true
+	h.Shutdown()
+}
+
+// Check probes the dependencies when the last results are older than the check interval
+func (h *HealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	h.mu.Lock()
+	stale := h.now().Sub(h.checkedAt) >= h.interval
+	h.mu.Unlock()
+	if stale {
+		h.Refresh()
+	}
+	return h.Server.Check(ctx, req)
+}
+
+// Refresh probes all dependencies concurrently and updates the reported statuses
+func (h *HealthServer) Refresh() {
+	h.refreshMu.Lock()
+	defer h.refreshMu.Unlock()
+
+	h.mu.Lock()
+	dependencies := append([]dependency(nil), h.dependencies...)
+	h.mu.Unlock()
+
+	healthy := make([]bool, len(dependencies))
+	var wg sync.WaitGroup
+	for i, dependency := range dependencies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := dependency.probe(); err != nil {
+				h.logger.Warn("health dependency probe failed", "dependency", dependency.name, "error", err)
+				return
+			}
+			healthy[i] = true
+		}()
+	}
+	wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checkedAt = h.now()
+	if h.notServing {
+		return
+	}
+	overall := healthpb.HealthCheckResponse_SERVING
+	for i, dependency := range dependencies {
+		dependencyStatus := healthpb.HealthCheckResponse_SERVING
+		if !healthy[i] {
+			dependencyStatus = healthpb.HealthCheckResponse_NOT_SERVING
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		h.SetServingStatus(dependency.name, dependencyStatus)
+	}
+	h.SetServingStatus("", overall)
+	for _, service := range h.services {
+		h.SetServingStatus(service, overall)
+	}
+}
+
+// run refreshes the statuses every interval until quit is closed, so watchers see dependency changes
+func (h *HealthServer) run(quit <-chan struct{}) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		h.Refresh()
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
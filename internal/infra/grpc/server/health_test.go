@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func checkStatus(t *testing.T, h *HealthServer, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestHealthServer_Dependencies(t *testing.T) {
+	h := NewHealthServer(time.Minute, logger.NewBaseLogger(shared.ModuleDB))
+	now := time.Now()
+	h.now = func() time.Time { return now }
+
+	var redisErr error
+	h.AddDependency("mongo", func() (time.Duration, error) { return time.Millisecond, nil })
+	h.AddDependency("redis", func() (time.Duration, error) { return time.Millisecond, redisErr })
+	h.addService("auth.v1.UserService")
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, "redis"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, "auth.v1.UserService"))
+
+	// Results are reused within the interval
+	redisErr = errors.New("connection refused")
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, "redis"))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, h, "redis"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, "mongo"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, h, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, h, "auth.v1.UserService"))
+
+	_, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHealthServer_Serving(t *testing.T) {
+	h := NewHealthServer(time.Minute, logger.NewBaseLogger(shared.ModuleDB))
+	h.AddDependency("mongo", func() (time.Duration, error) { return time.Millisecond, nil })
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, ""))
+
+	h.SetNotServing()
+	h.Refresh()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, h, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, h, "mongo"))
+
+	h.SetServing()
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, h, "mongo"))
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"erp.localhost/internal/infra/buildinfo"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Features reported by GetServerInfo
+const (
+	FeatureReflection    = "reflection"
+	FeatureChannelz      = "channelz"
+	FeatureVerboseErrors = "verbose_errors"
+)
+
+// InfoServer implements infra.v1.ServerInfoService, it is registered on every server
+type InfoServer struct {
+	infrav1.UnimplementedServerInfoServiceServer
+	info *infrav1.GetServerInfoResponse
+}
+
+func newInfoServer(config *Config) *InfoServer {
+	build := buildinfo.Get()
+	return &InfoServer{info: &infrav1.GetServerInfoResponse{
+		Module:      string(config.Module),
+		Environment: config.Environment,
+		Version:     build.Version,
+		Commit:      build.Commit,
+		BuildDate:   build.BuildDate,
+		GoVersion:   build.GoVersion,
+		Features:    enabledFeatures(config),
+		StartedAt:   timestamppb.New(time.Now()),
+	}}
+}
+
+// enabledFeatures returns the debugging surfaces enabled by config
+func enabledFeatures(config *Config) []string {
+	features := []string{}
+	if config.EnableReflection {
+		features = append(features, FeatureReflection)
+	}
+	if config.EnableDebugServices {
+		features = append(features, FeatureChannelz)
+	}
+	if config.VerboseErrors {
+		features = append(features, FeatureVerboseErrors)
+	}
+	return features
+}
+
+func (s *InfoServer) GetServerInfo(context.Context, *infrav1.GetServerInfoRequest) (*infrav1.GetServerInfoResponse, error) {
+	return s.info, nil
+}
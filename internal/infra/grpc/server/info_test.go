@@ -0,0 +1,23 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoServer_GetServerInfo(t *testing.T) {
+	info := newInfoServer(&Config{Module: shared.ModuleAuth, Environment: "staging", EnableReflection: true, VerboseErrors: true})
+
+	res, err := info.GetServerInfo(context.Background(), &infrav1.GetServerInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, string(shared.ModuleAuth), res.GetModule())
+	assert.Equal(t, "staging", res.GetEnvironment())
+	assert.Equal(t, []string{FeatureReflection, FeatureVerboseErrors}, res.GetFeatures())
+	assert.NotEmpty(t, res.GetGoVersion())
+	assert.NotNil(t, res.GetStartedAt())
+}
@@ -12,6 +12,7 @@ package mock
 import (
 	reflect "reflect"
 
+	server "erp.localhost/internal/infra/grpc/server"
 	gomock "go.uber.org/mock/gomock"
 	grpc "google.golang.org/grpc"
 )
@@ -40,6 +41,20 @@ func (m *MockRPCServer) EXPECT() *MockRPCServerMockRecorder {
 	return m.recorder
 }
 
+// Health mocks base method.
+func (m *MockRPCServer) Health() *server.HealthServer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].(*server.HealthServer)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockRPCServerMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockRPCServer)(nil).Health))
+}
+
 // ListenAndServe mocks base method.
 func (m *MockRPCServer) ListenAndServe(quit <-chan struct{}) error {
 	m.ctrl.T.Helper()
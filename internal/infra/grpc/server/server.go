@@ -3,27 +3,34 @@ package server
 import (
 	"crypto/tls"
 	"crypto/x509"
-	"errors"
 	"fmt"
 	"net"
-	"os"
 	"time"
 
 	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/grpc/certs"
 	"erp.localhost/internal/infra/grpc/interceptor"
 	"erp.localhost/internal/infra/logging/logger"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
 	"erp.localhost/internal/infra/model/shared"
 	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
+// minClientPingInterval is the shortest keepalive interval accepted from the clients, faster pings close the
+// connection. It is the minimum of the gRPC clients
+const minClientPingInterval = 10 * time.Second
+
 //go:generate mockgen -destination=mock/mock_rpc_server.go -package=mock erp.localhost/internal/infra/grpc/server RPCServer
 type RPCServer interface {
 	Server() *grpc.Server
 	RegisterService(desc *grpc.ServiceDesc, impl interface{})
 	ListenAndServe(quit <-chan struct{}) error
+	Health() *HealthServer
 }
 
 type Config struct {
@@ -36,17 +43,38 @@ type Config struct {
 	MaxConnectionAge  time.Duration
 	KeepAliveTime     time.Duration
 	KeepAliveTimeout  time.Duration
+	// Deployment environment, reported by GetServerInfo
+	Environment string
+	// Registers the channelz service, inspecting the connections and calls of the server
+	EnableDebugServices bool
+	// Adds the underlying cause of the failed calls to their status, see interceptor.VerboseStatusError
+	VerboseErrors bool
+	// Extra interceptors chained after the built-in ones
+	UnaryInterceptors []grpc.UnaryServerInterceptor
+	// Called with the panics recovered in the handlers, e.g. to send them to an error tracker, optional
+	PanicReporter interceptor.PanicReporter
+	// How often the health service probes dependencies, DefaultHealthCheckInterval when 0
+	HealthCheckInterval time.Duration
+	// Service identities of the clients allowed to connect, see certs.Identity. Any client with a certificate
+	// signed by the CA is accepted when empty
+	AllowedClients []string
+	// How often the certificates are checked for a rotation, certs.DefaultReloadInterval when 0
+	CertReloadInterval time.Duration
+	// How long the in-flight calls are drained at shutdown before they are cancelled, no limit when 0
+	ShutdownTimeout time.Duration
 }
 
 type GRPCServer struct {
 	server *grpc.Server
+	health *HealthServer
+	certs  *certs.Reloader
 	config *Config
 	logger logger.Logger
 }
 
 func NewGRPCServer(config *Config, logger logger.Logger) (*GRPCServer, error) {
 	// Build server options
-	opts, err := buildServerOptions(config, logger)
+	opts, reloader, err := buildServerOptions(config, logger)
 	if err != nil {
 		logger.Error("failed to build options", "error", err)
 		return nil, err
@@ -59,9 +87,23 @@ func NewGRPCServer(config *Config, logger logger.Logger) (*GRPCServer, error) {
 		reflection.Register(grpcServer)
 		logger.Info("gRPC reflection enabled")
 	}
+	if config.EnableDebugServices {
+		channelz.RegisterChannelzServiceToServer(grpcServer)
+		logger.Info("gRPC channelz service enabled")
+	}
+	if config.VerboseErrors {
+		logger.Info("verbose gRPC errors enabled")
+	}
+	infrav1.RegisterServerInfoServiceServer(grpcServer, newInfoServer(config))
+
+	// Health service for orchestrator probes, dependencies are added by the service entry point
+	health := NewHealthServer(config.HealthCheckInterval, logger)
+	healthpb.RegisterHealthServer(grpcServer, health)
 
 	return &GRPCServer{
 		server: grpcServer,
+		health: health,
+		certs:  reloader,
 		config: config,
 		logger: logger,
 	}, nil
@@ -75,9 +117,15 @@ func (s *GRPCServer) Server() *grpc.Server {
 // RegisterService registers a service implementation with the server
 func (s *GRPCServer) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
 	s.server.RegisterService(desc, impl)
+	s.health.addService(desc.ServiceName)
 	s.logger.Info("registered gRPC service", "service", desc.ServiceName)
 }
 
+// Health returns the health service of the server, used to add dependencies and drain traffic
+func (s *GRPCServer) Health() *HealthServer {
+	return s.health
+}
+
 func (s *GRPCServer) ListenAndServe(quit <-chan struct{}) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
 	if err != nil {
@@ -91,6 +139,12 @@ func (s *GRPCServer) ListenAndServe(quit <-chan struct{}) error {
 	// Channel to signal when the server has shut down
 	serverStopped := make(chan struct{})
 
+	healthStopped := make(chan struct{})
+	go func() {
+		s.health.run(quit)
+		close(healthStopped)
+	}()
+
 	go func() {
 		if err = s.server.Serve(lis); err != nil {
 			err := infra_error.Internal(infra_error.InternalGRPCError, err)
@@ -102,24 +156,67 @@ func (s *GRPCServer) ListenAndServe(quit <-chan struct{}) error {
 	<-quit
 
 	s.logger.Info("initiating graceful shutdown...")
-	s.server.GracefulStop()
+	s.health.SetNotServing()
+	s.gracefulStop()
 
 	<-serverStopped
+	<-healthStopped
+	if s.certs != nil {
+		s.certs.Close()
+	}
 	s.logger.Info("server shutdown complete")
 
 	return nil
 }
 
-func buildServerOptions(config *Config, logger logger.Logger) ([]grpc.ServerOption, error) {
+// gracefulStop waits for the in-flight calls to finish, they are cancelled once the shutdown timeout passed
+func (s *GRPCServer) gracefulStop() {
+	if s.config.ShutdownTimeout <= 0 {
+		s.server.GracefulStop()
+		return
+	}
+	drained := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(drained)
+	}()
+	timer := time.NewTimer(s.config.ShutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+	case <-timer.C:
+		s.logger.Warn("in-flight calls not drained before the shutdown timeout, cancelling them", "timeout", s.config.ShutdownTimeout)
+		// Stop also ends the pending GracefulStop
+		s.server.Stop()
+		<-drained
+	}
+}
+
+func buildServerOptions(config *Config, logger logger.Logger) ([]grpc.ServerOption, *certs.Reloader, error) {
 	var opts []grpc.ServerOption
 
 	// Add interceptors (from your interceptor package)
-	opts = append(opts,
-		grpc.ChainUnaryInterceptor(
-			// Add your interceptors here
-			interceptor.ServerLoggingInterceptor(logger),
-		),
-	)
+	interceptors := []grpc.UnaryServerInterceptor{
+		// Add your interceptors here
+		interceptor.ServerBuildInfoInterceptor(),
+		interceptor.ServerTracingInterceptor(),
+		interceptor.ServerMetricsInterceptor(),
+		interceptor.ServerLoggingInterceptor(logger),
+		// Panics fail the call, they are still logged and measured by the interceptors above
+		interceptor.ServerRecoveryInterceptor(logger, config.PanicReporter),
+	}
+	interceptors = append(interceptors, config.UnaryInterceptors...)
+	// After the extra interceptors, e.g. the API key interceptor sets the identifier of the requests
+	interceptors = append(interceptors, interceptor.ServerValidationInterceptor())
+	// Innermost, the errors of the handlers are converted to status errors before the other interceptors see them
+	interceptors = append(interceptors, interceptor.ServerErrorInterceptor(config.VerboseErrors))
+	opts = append(opts, grpc.ChainUnaryInterceptor(interceptors...))
+	opts = append(opts, grpc.ChainStreamInterceptor(
+		interceptor.ServerBuildInfoStreamInterceptor(),
+		interceptor.ServerRecoveryStreamInterceptor(logger, config.PanicReporter),
+		interceptor.ServerValidationStreamInterceptor(),
+		interceptor.ServerErrorStreamInterceptor(config.VerboseErrors),
+	))
 
 	// Keep-alive settings
 	if config.KeepAliveTime > 0 {
@@ -129,6 +226,12 @@ func buildServerOptions(config *Config, logger logger.Logger) ([]grpc.ServerOpti
 		}))
 	}
 
+	// Clients keep their idle connections alive, see client.Config.KeepAliveTime
+	opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             minClientPingInterval,
+		PermitWithoutStream: true,
+	}))
+
 	// Connection limits
 	if config.MaxConnectionIdle > 0 || config.MaxConnectionAge > 0 {
 		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -140,50 +243,38 @@ func buildServerOptions(config *Config, logger logger.Logger) ([]grpc.ServerOpti
 	// Handle credentials
 	if config.Insecure {
 		logger.Warn("running server in INSECURE mode (no TLS)")
-		// No additional credentials needed for insecure
-	} else {
-		tlsOpts, err := buildTLSOptions(config.Certs)
-		if err != nil {
-			return nil, err
-		}
-		opts = append(opts, tlsOpts...)
-	}
-
-	return opts, nil
-}
-
-func buildTLSOptions(certs *shared.Certs) ([]grpc.ServerOption, error) {
-	if certs == nil || !certs.IsValidCerts() {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("invalid or missing certificates"))
+		return opts, nil, nil
 	}
-
-	// Load client certificate
-	serverCert, err := tls.LoadX509KeyPair(certs.Cert, certs.Key)
+	reloader, err := certs.NewReloader(config.Certs, config.CertReloadInterval, logger)
 	if err != nil {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to load client certificate")).WithError(err)
+		return nil, nil, err
 	}
+	opts = append(opts, grpc.Creds(credentials.NewTLS(buildTLSConfig(reloader, config.AllowedClients))))
 
-	// Load CA certificate
-	caCert, err := os.ReadFile(certs.CACert)
-	if err != nil {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to read CA certificate")).WithError(err)
-	}
-	// Create cert pool
-	caCertPool := x509.NewCertPool()
-	if !caCertPool.AppendCertsFromPEM(caCert) {
-		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, errors.New("failed to append CA certificate"))
-	}
+	return opts, reloader, nil
+}
 
-	// Create TLS config for mTLS
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientCAs:    caCertPool,
-		ClientAuth:   tls.RequireAndVerifyClientCert,
+// buildTLSConfig requires clients to present a certificate signed by the CA and naming one of allowedClients.
+// The configuration is built for every handshake so rotated certificates are used by new connections
+func buildTLSConfig(reloader *certs.Reloader, allowedClients []string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				// credentials.NewTLS only sets the HTTP/2 protocol of gRPC on the outer configuration
+				NextProtos:   []string{"h2"},
+				Certificates: []tls.Certificate{*reloader.Certificate()},
+				ClientCAs:    reloader.CAPool(),
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				VerifyConnection: func(state tls.ConnectionState) error {
+					var peer *x509.Certificate
+					if len(state.PeerCertificates) > 0 {
+						peer = state.PeerCertificates[0]
+					}
+					return certs.VerifyIdentity(peer, allowedClients)
+				},
+			}, nil
+		},
 	}
-
-	creds := credentials.NewTLS(tlsConfig)
-
-	return []grpc.ServerOption{
-		grpc.Creds(creds),
-	}, nil
 }
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	loggerKey    struct{}
+	requestIDKey struct{}
+)
+
+// defaultLogger is returned by FromContext for contexts without a request logger
+var defaultLogger = sync.OnceValue(func() Logger { return NewBaseLogger("") })
+
+// NewContext returns a copy of ctx carrying l, see FromContext
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the request logger stored by the gRPC logging interceptor, which adds the request_id,
+// trace_id and span_id of the request to every line. Contexts without one get the default logger
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return l
+	}
+	return defaultLogger().WithContext(ctx)
+}
+
+// WithRequestID returns a copy of ctx carrying the correlation id of the request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the correlation id of the request, empty when ctx has none
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	base := &BaseLogger{logger: zerolog.New(&buf), module: shared.ModuleAuth}
+
+	ctx := WithRequestID(context.Background(), "request-1")
+	assert.Equal(t, "request-1", RequestID(ctx))
+	assert.Empty(t, RequestID(context.Background()))
+
+	ctx = NewContext(ctx, base.WithContext(ctx))
+	FromContext(ctx).Info("handled")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "request-1", entry["request_id"])
+	assert.Equal(t, "handled", entry[zerolog.MessageFieldName])
+
+	// Contexts without a request logger get the default logger
+	assert.NotNil(t, FromContext(context.Background()))
+	assert.Same(t, base, base.WithContext(context.Background()))
+}
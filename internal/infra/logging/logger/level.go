@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/rs/zerolog"
+)
+
+// DefaultLevel is the level of modules without an override when LOG_LEVEL is not set
+const DefaultLevel = zerolog.TraceLevel
+
+// levelRegistry holds the process wide level and the per-module overrides, checked on every log call so
+// levels can be changed at runtime
+type levelRegistry struct {
+	once    sync.Once
+	mu      sync.RWMutex
+	global  zerolog.Level
+	modules map[shared.Module]zerolog.Level
+}
+
+var levels = &levelRegistry{global: DefaultLevel, modules: make(map[shared.Module]zerolog.Level)}
+
+// loadFromEnv reads LOG_LEVEL and the LOG_LEVEL_<MODULE> overrides, e.g. LOG_LEVEL=info LOG_LEVEL_AUTH=debug.
+// Invalid values are ignored
+func (r *levelRegistry) loadFromEnv() {
+	r.once.Do(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if level, err := ParseLevel(os.Getenv("LOG_LEVEL")); err == nil {
+			r.global = level
+		}
+		for _, entry := range os.Environ() {
+			key, value, _ := strings.Cut(entry, "=")
+			name, ok := strings.CutPrefix(key, "LOG_LEVEL_")
+			if !ok {
+				continue
+			}
+			module, ok := shared.ParseModule(name)
+			if !ok {
+				continue
+			}
+			if level, err := ParseLevel(value); err == nil {
+				r.modules[module] = level
+			}
+		}
+	})
+}
+
+func (r *levelRegistry) enabled(module shared.Module, level zerolog.Level) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	threshold, ok := r.modules[module]
+	if !ok {
+		threshold = r.global
+	}
+	return level >= threshold
+}
+
+// ParseLevel parses a level name (trace, debug, info, warn, error, fatal), case insensitive
+func ParseLevel(value string) (zerolog.Level, error) {
+	level, err := zerolog.ParseLevel(strings.ToLower(strings.TrimSpace(value)))
+	if err != nil || value == "" || level == zerolog.NoLevel || level == zerolog.Disabled || level == zerolog.PanicLevel {
+		return zerolog.NoLevel, fmt.Errorf("invalid log level %q", value)
+	}
+	return level, nil
+}
+
+// SetLevel sets the level of module, or the process wide level of modules without an override when
+// module is empty
+func SetLevel(module shared.Module, level zerolog.Level) {
+	levels.loadFromEnv()
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	if module == "" {
+		levels.global = level
+		return
+	}
+	levels.modules[module] = level
+}
+
+// ResetLevel removes the override of module, it logs at the process wide level again
+func ResetLevel(module shared.Module) {
+	levels.loadFromEnv()
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	delete(levels.modules, module)
+}
+
+// Levels returns the process wide level and the per-module overrides
+func Levels() (zerolog.Level, map[shared.Module]zerolog.Level) {
+	levels.loadFromEnv()
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+	modules := make(map[shared.Module]zerolog.Level, len(levels.modules))
+	for module, level := range levels.modules {
+		modules[module] = level
+	}
+	return levels.global, modules
+}
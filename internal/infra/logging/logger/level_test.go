@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		value   string
+		want    zerolog.Level
+		wantErr bool
+	}{
+		{value: "debug", want: zerolog.DebugLevel},
+		{value: "INFO", want: zerolog.InfoLevel},
+		{value: " warn ", want: zerolog.WarnLevel},
+		{value: "", wantErr: true},
+		{value: "verbose", wantErr: true},
+		{value: "disabled", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			level, err := ParseLevel(tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, level)
+		})
+	}
+}
+
+func TestLevelRegistry_LoadFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_LEVEL_AUTH", "debug")
+	t.Setenv("LOG_LEVEL_UNKNOWN", "debug")
+	t.Setenv("LOG_LEVEL_CORE", "invalid")
+
+	registry := &levelRegistry{global: DefaultLevel, modules: make(map[shared.Module]zerolog.Level)}
+	registry.loadFromEnv()
+
+	assert.Equal(t, zerolog.InfoLevel, registry.global)
+	assert.Equal(t, map[shared.Module]zerolog.Level{shared.ModuleAuth: zerolog.DebugLevel}, registry.modules)
+	assert.True(t, registry.enabled(shared.ModuleAuth, zerolog.DebugLevel))
+	assert.False(t, registry.enabled(shared.ModuleCore, zerolog.DebugLevel))
+	assert.True(t, registry.enabled(shared.ModuleCore, zerolog.InfoLevel))
+}
+
+func TestBaseLogger_Levels(t *testing.T) {
+	global, _ := Levels()
+	t.Cleanup(func() {
+		SetLevel("", global)
+		ResetLevel(shared.ModuleAuth)
+	})
+
+	var buf bytes.Buffer
+	auth := &BaseLogger{logger: zerolog.New(&buf), module: shared.ModuleAuth}
+	core := &BaseLogger{logger: zerolog.New(&buf), module: shared.ModuleCore}
+
+	SetLevel("", zerolog.InfoLevel)
+	SetLevel(shared.ModuleAuth, zerolog.DebugLevel)
+	auth.Debug("auth debug")
+	core.Debug("core debug")
+	core.Info("core info")
+
+	_, modules := Levels()
+	assert.Equal(t, zerolog.DebugLevel, modules[shared.ModuleAuth])
+
+	// Without the override auth follows the process level
+	ResetLevel(shared.ModuleAuth)
+	auth.Debug("auth debug after reset")
+
+	var messages []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		messages = append(messages, entry[zerolog.MessageFieldName].(string))
+	}
+	assert.Equal(t, []string{"auth debug", "core info"}, messages)
+}
+
+func TestEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	jsonLogger := zerolog.New(encoder(&buf, FormatJSON))
+	jsonLogger.Info().Str("module", "Auth").Msg("json line")
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "json line", entry[zerolog.MessageFieldName])
+
+	buf.Reset()
+	textLogger := zerolog.New(encoder(&buf, FormatText))
+	textLogger.Info().Str("module", "Auth").Msg("text line")
+	assert.Equal(t, " | INFO | Auth | text line\n", buf.String())
+}
+
+func TestLoggerConfig_Sampling(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "JSON")
+	t.Setenv("LOG_SAMPLING_BURST", "5")
+	t.Setenv("LOG_SAMPLING_PERIOD", "2s")
+	config := getLoggerConfigFromEnv()
+	assert.Equal(t, FormatJSON, config.Format)
+	assert.Equal(t, uint32(5), config.SamplingBurst)
+	assert.Equal(t, "2s", config.SamplingPeriod.String())
+}
@@ -2,15 +2,22 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"erp.localhost/internal/infra/model/shared"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate mockgen -destination=mock/mock_logger.go -package=mock erp.localhost/internal/infra/logging/logger Logger
@@ -22,6 +29,8 @@ type Logger interface {
 	Warn(msg string, extraFields ...any)
 	Error(msg string, extraFields ...any)
 	Fatal(msg string, extraFields ...any)
+	// WithContext returns a logger that adds the request scoped fields of ctx: request_id, trace_id and span_id
+	WithContext(ctx context.Context) Logger
 }
 
 // FileOpenMode defines how log files should be opened
@@ -32,16 +41,50 @@ const (
 	FileModeTruncate
 )
 
+// Format is the encoding of log lines
+type Format string
+
+const (
+	// FormatText writes pipe separated lines: time | LEVEL | module | message | key=value
+	FormatText Format = "text"
+	// FormatJSON writes one JSON object per line, for log shippers
+	FormatJSON Format = "json"
+)
+
+// Sink is a destination of log lines, several sinks can be enabled at once
+type Sink string
+
+const (
+	SinkConsole Sink = "console"
+	SinkFile    Sink = "file"
+	SinkSyslog  Sink = "syslog"
+	SinkLoki    Sink = "loki"
+)
+
+// DefaultSamplingPeriod is the window of LOG_SAMPLING_BURST when LOG_SAMPLING_PERIOD is not set
+const DefaultSamplingPeriod = time.Second
+
 // LoggerConfig holds configuration for the logger from environment variables
 type LoggerConfig struct {
 	LogsDir        string
 	FileMode       FileOpenMode
 	ConsoleEnabled bool
 	Module         shared.Module
+	Format         Format
+	// Trace and debug lines kept per SamplingPeriod, the rest are dropped. 0 disables sampling
+	SamplingBurst  uint32
+	SamplingPeriod time.Duration
+	// Destinations of the log lines, console and file (when LogsDir is set) when LOG_SINKS is not set
+	Sinks    []Sink
+	Rotation RotationConfig
+	// Syslog server as network://host:port, e.g. udp://localhost:514. The local syslog daemon when empty
+	SyslogAddress string
+	Loki          LokiConfig
 }
 
 type BaseLogger struct {
 	logger      zerolog.Logger
+	module      shared.Module
 	fileCleanup func()
 }
 
@@ -51,6 +94,8 @@ func getLoggerConfigFromEnv() LoggerConfig {
 		LogsDir:        os.Getenv("LOG_FILE_PATH"),
 		FileMode:       FileModeTruncate,
 		ConsoleEnabled: true,
+		Format:         FormatText,
+		SamplingPeriod: DefaultSamplingPeriod,
 	}
 
 	// Parse LOG_FILE_MODE
@@ -63,9 +108,77 @@ func getLoggerConfigFromEnv() LoggerConfig {
 		config.ConsoleEnabled = false
 	}
 
+	// Parse LOG_FORMAT
+	if format := os.Getenv("LOG_FORMAT"); strings.EqualFold(format, string(FormatJSON)) {
+		config.Format = FormatJSON
+	}
+
+	// Parse LOG_SAMPLING_BURST and LOG_SAMPLING_PERIOD
+	if burst, err := strconv.ParseUint(os.Getenv("LOG_SAMPLING_BURST"), 10, 32); err == nil {
+		config.SamplingBurst = uint32(burst)
+	}
+	if period, err := time.ParseDuration(os.Getenv("LOG_SAMPLING_PERIOD")); err == nil && period > 0 {
+		config.SamplingPeriod = period
+	}
+
+	// Parse LOG_FILE_MAX_SIZE_MB, LOG_FILE_MAX_AGE and LOG_FILE_MAX_BACKUPS
+	config.Rotation = RotationConfig{MaxSize: DefaultMaxFileSize}
+	if size, err := strconv.ParseInt(os.Getenv("LOG_FILE_MAX_SIZE_MB"), 10, 64); err == nil && size >= 0 {
+		config.Rotation.MaxSize = size << 20
+	}
+	if age, err := time.ParseDuration(os.Getenv("LOG_FILE_MAX_AGE")); err == nil && age > 0 {
+		config.Rotation.MaxAge = age
+	}
+	if backups, err := strconv.Atoi(os.Getenv("LOG_FILE_MAX_BACKUPS")); err == nil && backups > 0 {
+		config.Rotation.MaxBackups = backups
+	}
+
+	// Parse LOG_SYSLOG_ADDRESS
+	config.SyslogAddress = os.Getenv("LOG_SYSLOG_ADDRESS")
+
+	// Parse LOG_LOKI_URL, LOG_LOKI_BATCH_SIZE and LOG_LOKI_BATCH_WAIT
+	config.Loki = LokiConfig{
+		URL:       os.Getenv("LOG_LOKI_URL"),
+		BatchSize: DefaultLokiBatchSize,
+		BatchWait: DefaultLokiBatchWait,
+	}
+	if size, err := strconv.Atoi(os.Getenv("LOG_LOKI_BATCH_SIZE")); err == nil && size > 0 {
+		config.Loki.BatchSize = size
+	}
+	if wait, err := time.ParseDuration(os.Getenv("LOG_LOKI_BATCH_WAIT")); err == nil && wait > 0 {
+		config.Loki.BatchWait = wait
+	}
+
+	// Parse LOG_SINKS, e.g. console,file,loki
+	config.Sinks = parseSinks(os.Getenv("LOG_SINKS"), config)
+
 	return config
 }
 
+// parseSinks parses a comma separated list of sinks, unknown names are ignored. Without LOG_SINKS the console
+// is enabled unless LOG_CONSOLE_ENABLED=false, and the file when LOG_FILE_PATH is set
+func parseSinks(value string, config LoggerConfig) []Sink {
+	var sinks []Sink
+	if strings.TrimSpace(value) == "" {
+		if config.ConsoleEnabled {
+			sinks = append(sinks, SinkConsole)
+		}
+		if config.LogsDir != "" {
+			sinks = append(sinks, SinkFile)
+		}
+		return sinks
+	}
+	for _, name := range strings.Split(value, ",") {
+		switch sink := Sink(strings.ToLower(strings.TrimSpace(name))); sink {
+		case SinkConsole, SinkFile, SinkSyslog, SinkLoki:
+			if !slices.Contains(sinks, sink) {
+				sinks = append(sinks, sink)
+			}
+		}
+	}
+	return sinks
+}
+
 // findProjectRoot walks up the directory tree to find go.mod
 func findProjectRoot() (string, error) {
 	// Start from executable directory
@@ -95,8 +208,8 @@ func findProjectRoot() (string, error) {
 	return os.Getwd()
 }
 
-// createFileWriter creates a file writer with the specified configuration
-func createFileWriter(config LoggerConfig) (io.Writer, *os.File, error) {
+// createFileWriter creates a rotating file writer with the specified configuration
+func createFileWriter(config LoggerConfig) (io.Writer, io.Closer, error) {
 	if config.LogsDir == "" {
 		return nil, nil, fmt.Errorf("file path is empty")
 	}
@@ -118,60 +231,70 @@ func createFileWriter(config LoggerConfig) (io.Writer, *os.File, error) {
 		return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 
-	// Determine file opening flags
-	flags := os.O_CREATE | os.O_WRONLY
-	if config.FileMode == FileModeAppend {
-		flags |= os.O_APPEND
-	} else {
-		flags |= os.O_TRUNC
-	}
-
-	// Open file
-	file, err := os.OpenFile(filePath, flags, 0644)
+	file, err := openRotatingFile(filePath, config.FileMode, config.Rotation)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
 	}
 
-	// Wrap in pipeFormatter
-	writer := &pipeFormatter{w: file}
-	return writer, file, nil
+	return encoder(file, config.Format), file, nil
+}
+
+// createSinkWriter creates the writer of a single sink, the closer is nil when the sink holds no resources
+func createSinkWriter(sink Sink, config LoggerConfig) (io.Writer, io.Closer, error) {
+	switch sink {
+	case SinkConsole:
+		return encoder(os.Stdout, config.Format), nil, nil
+	case SinkFile:
+		return createFileWriter(config)
+	case SinkSyslog:
+		return newSyslogWriter(config)
+	case SinkLoki:
+		if config.Loki.URL == "" {
+			return nil, nil, fmt.Errorf("loki url is empty")
+		}
+		w := newLokiWriter(config)
+		return w, w, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown log sink %q", sink)
+	}
 }
 
-// createMultiWriter creates a writer based on configuration (console, file, or both)
+// createMultiWriter creates a writer duplicating every line to the configured sinks. Sinks that fail to
+// initialize are skipped and reported in the returned error, the console is used when none is left
 func createMultiWriter(config LoggerConfig) (io.Writer, func(), error) {
 	var writers []io.Writer
-	var fileHandle *os.File
+	var closers []io.Closer
+	var errs []error
 
-	// Cleanup function to close file handle
+	// Cleanup function to flush and close the sinks
 	cleanup := func() {
-		if fileHandle != nil {
-			fileHandle.Close()
+		for _, closer := range closers {
+			closer.Close()
 		}
 	}
 
-	// Add console writer if enabled
-	if config.ConsoleEnabled {
-		writers = append(writers, &pipeFormatter{w: os.Stdout})
-	}
-
-	// Add file writer if path is provided
-	if config.LogsDir != "" {
-		fileWriter, file, err := createFileWriter(config)
+	for _, sink := range config.Sinks {
+		writer, closer, err := createSinkWriter(sink, config)
 		if err != nil {
-			return nil, cleanup, err
+			errs = append(errs, fmt.Errorf("%s sink: %w", sink, err))
+			continue
+		}
+		writers = append(writers, writer)
+		if closer != nil {
+			closers = append(closers, closer)
 		}
-		writers = append(writers, fileWriter)
-		fileHandle = file
 	}
 
 	// Return appropriate writer
-	if len(writers) == 0 {
+	switch len(writers) {
+	case 0:
 		// No writers - fallback to console
-		return &pipeFormatter{w: os.Stdout}, cleanup, nil
-	} else if len(writers) == 1 {
-		return writers[0], cleanup, nil
-	} else {
-		return io.MultiWriter(writers...), cleanup, nil
+		return encoder(os.Stdout, config.Format), cleanup, errors.Join(errs...)
+	case 1:
+		return writers[0], cleanup, errors.Join(errs...)
+	default:
+		// Level aware sinks (syslog, loki) receive the level of each line
+		return zerolog.MultiLevelWriter(writers...), cleanup, errors.Join(errs...)
 	}
 }
 
@@ -180,35 +303,33 @@ func NewBaseLogger(module shared.Module, opts ...map[string]string) *BaseLogger
 	// Read configuration from environment
 	config := getLoggerConfigFromEnv()
 	config.Module = module
+	levels.loadFromEnv()
 
-	// Create multi-writer (console, file, or both)
+	// Create multi-writer over the configured sinks
 	writer, cleanup, err := createMultiWriter(config)
-	if err != nil {
-		// If file logging fails, fall back to console-only and log warning
-		writer = newConsoleWriter()
-		cleanup = func() {}
-
-		// Create a temporary logger to log the warning
-		tempLogger := zerolog.New(writer).
-			With().
-			Timestamp().
-			Str("module", string(module)).
-			Logger()
-		tempLogger.Warn().
-			Err(err).
-			Str("file_path", config.LogsDir).
-			Msg("Failed to initialize file logging, using console only")
-	}
 
 	baseLogger := zerolog.New(writer).
 		With().
 		Timestamp().
 		Str("module", string(module)).
 		Logger()
+	if err != nil {
+		// The sinks that failed are skipped, the others keep logging
+		baseLogger.Warn().
+			Err(err).
+			Str("file_path", config.LogsDir).
+			Msg("Failed to initialize log sinks, using the remaining ones")
+	}
+	if config.SamplingBurst > 0 {
+		// Noisy trace and debug lines are rate limited, higher levels are always written
+		sampler := &zerolog.BurstSampler{Burst: config.SamplingBurst, Period: config.SamplingPeriod}
+		baseLogger = baseLogger.Sample(zerolog.LevelSampler{TraceSampler: sampler, DebugSampler: sampler})
+	}
 
 	return &BaseLogger{
 		logger:      baseLogger,
-		fileCleanup: cleanup,
+		module:      module,
+		fileCleanup: sync.OnceFunc(cleanup),
 	}
 }
 
@@ -272,9 +393,12 @@ func (f *pipeFormatter) Write(p []byte) (n int, err error) {
 	return len(p), err
 }
 
-// newConsoleWriter creates a console writer for the logger with custom pipe-separated format.
-func newConsoleWriter() io.Writer {
-	return &pipeFormatter{w: os.Stdout}
+// encoder wraps w with the formatter of format, JSON lines are written as zerolog encodes them
+func encoder(w io.Writer, format Format) io.Writer {
+	if format == FormatJSON {
+		return w
+	}
+	return &pipeFormatter{w: w}
 }
 
 func (l *BaseLogger) Trace(msg string, extraFields ...any) {
@@ -320,6 +444,9 @@ func (l *BaseLogger) Fatal(msg string, extraFields ...any) {
 }
 
 func (l *BaseLogger) log(level zerolog.Level, msg string, extraFields ...any) {
+	if level != zerolog.FatalLevel && !levels.enabled(l.module, level) {
+		return
+	}
 	if len(extraFields)%2 != 0 {
 		l.logger.Error().Msg("extraFields must be key-value pairs")
 		return
@@ -339,7 +466,33 @@ func (l *BaseLogger) log(level zerolog.Level, msg string, extraFields ...any) {
 	ev.Msg(msg)
 }
 
-// Close releases any resources held by the logger (e.g., file handles)
+// WithContext returns a logger that adds the request_id of ctx and the trace_id and span_id of the span in ctx,
+// the logger is returned as is when ctx carries none of them
+func (l *BaseLogger) WithContext(ctx context.Context) Logger {
+	if l == nil {
+		return l
+	}
+	requestID := RequestID(ctx)
+	spanContext := trace.SpanContextFromContext(ctx)
+	if requestID == "" && !spanContext.IsValid() {
+		return l
+	}
+	fields := l.logger.With()
+	if requestID != "" {
+		fields = fields.Str("request_id", requestID)
+	}
+	if spanContext.IsValid() {
+		fields = fields.Str("trace_id", spanContext.TraceID().String()).Str("span_id", spanContext.SpanID().String())
+	}
+	// The sinks stay owned by the parent logger
+	return &BaseLogger{
+		logger: fields.Logger(),
+		module: l.module,
+	}
+}
+
+// Close flushes the sinks and releases any resources held by the logger (e.g., file handles), only the first call
+// closes them
 func (l *BaseLogger) Close() error {
 	if l != nil && l.fileCleanup != nil {
 		l.fileCleanup()
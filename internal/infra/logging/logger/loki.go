@@ -0,0 +1,209 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultLokiBatchSize is the number of lines pushed at once when LOG_LOKI_BATCH_SIZE is not set
+	DefaultLokiBatchSize = 500
+	// DefaultLokiBatchWait is the longest a line waits to be pushed when LOG_LOKI_BATCH_WAIT is not set
+	DefaultLokiBatchWait = time.Second
+	// lokiPushPath is appended to LokiConfig.URL when it has no path
+	lokiPushPath = "/loki/api/v1/push"
+	// lokiMaxPendingBatches bounds the lines buffered while Loki is unreachable, newer lines are dropped
+	lokiMaxPendingBatches = 10
+	lokiPushTimeout       = 5 * time.Second
+)
+
+// LokiConfig holds the configuration of the Loki push sink
+type LokiConfig struct {
+	// Base URL of Loki, e.g. http://localhost:3100, or the full push endpoint
+	URL       string
+	BatchSize int
+	BatchWait time.Duration
+}
+
+type lokiEntry struct {
+	level string
+	time  time.Time
+	line  string
+}
+
+// lokiStream is a stream of the push API, lines sharing the same labels
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// lokiWriter buffers lines and pushes them to Loki in batches from a background goroutine, so logging never
+// waits on the network. Lines are labelled with the service, module and level
+type lokiWriter struct {
+	client  *http.Client
+	url     string
+	labels  map[string]string
+	format  Format
+	config  LokiConfig
+	mu      sync.Mutex
+	pending []lokiEntry
+	dropped int
+	flush   chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+	close   sync.Once
+}
+
+func newLokiWriter(config LoggerConfig) *lokiWriter {
+	pushURL := config.Loki.URL
+	if u, err := url.Parse(pushURL); err == nil && strings.Trim(u.Path, "/") == "" {
+		pushURL = strings.TrimSuffix(pushURL, "/") + lokiPushPath
+	}
+	w := &lokiWriter{
+		client: &http.Client{Timeout: lokiPushTimeout},
+		url:    pushURL,
+		labels: map[string]string{
+			"service": "erp-" + strings.ToLower(string(config.Module)),
+			"module":  string(config.Module),
+		},
+		format:  config.Format,
+		config:  config.Loki,
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *lokiWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *lokiWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var buf bytes.Buffer
+	if _, err := encoder(&buf, w.format).Write(p); err != nil {
+		return 0, err
+	}
+	entry := lokiEntry{level: level.String(), time: time.Now(), line: strings.TrimSuffix(buf.String(), "\n")}
+	if level == zerolog.NoLevel {
+		entry.level = zerolog.InfoLevel.String()
+	}
+
+	w.mu.Lock()
+	if len(w.pending) >= w.config.BatchSize*lokiMaxPendingBatches {
+		w.dropped++
+		w.mu.Unlock()
+		return len(p), nil
+	}
+	w.pending = append(w.pending, entry)
+	full := len(w.pending) >= w.config.BatchSize
+	w.mu.Unlock()
+
+	if full {
+		select {
+		case w.flush <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+func (w *lokiWriter) run() {
+	defer close(w.stopped)
+	ticker := time.NewTicker(w.config.BatchWait)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.push()
+		case <-w.flush:
+			w.push()
+		case <-w.done:
+			w.push()
+			return
+		}
+	}
+}
+
+// push sends the pending lines, failures are reported on stderr since the logger cannot log its own sink
+func (w *lokiWriter) push() {
+	w.mu.Lock()
+	entries, dropped := w.pending, w.dropped
+	w.pending, w.dropped = nil, 0
+	w.mu.Unlock()
+
+	if dropped > 0 {
+		fmt.Fprintf(os.Stderr, "loki sink: dropped %d log lines, the buffer is full\n", dropped)
+	}
+	for len(entries) > 0 {
+		n := min(len(entries), w.config.BatchSize)
+		if err := w.send(entries[:n]); err != nil {
+			fmt.Fprintf(os.Stderr, "loki sink: failed to push %d log lines: %v\n", n, err)
+		}
+		entries = entries[n:]
+	}
+}
+
+func (w *lokiWriter) send(entries []lokiEntry) error {
+	// One stream per level, indexed by level
+	streams := make(map[string]int)
+	var request lokiPushRequest
+	for _, entry := range entries {
+		i, ok := streams[entry.level]
+		if !ok {
+			labels := make(map[string]string, len(w.labels)+1)
+			for k, v := range w.labels {
+				labels[k] = v
+			}
+			labels["level"] = entry.level
+			i = len(request.Streams)
+			request.Streams = append(request.Streams, lokiStream{Stream: labels})
+			streams[entry.level] = i
+		}
+		request.Streams[i].Values = append(request.Streams[i].Values, [2]string{strconv.FormatInt(entry.time.UnixNano(), 10), entry.line})
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lokiPushTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Close pushes the pending lines and stops the background goroutine
+func (w *lokiWriter) Close() error {
+	w.close.Do(func() { close(w.done) })
+	<-w.stopped
+	return nil
+}
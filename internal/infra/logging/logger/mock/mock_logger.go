@@ -10,8 +10,10 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
+	logger "erp.localhost/internal/infra/logging/logger"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -140,3 +142,17 @@ func (mr *MockLoggerMockRecorder) Warn(msg any, extraFields ...any) *gomock.Call
 	varargs := append([]any{msg}, extraFields...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLogger)(nil).Warn), varargs...)
 }
+
+// WithContext mocks base method.
+func (m *MockLogger) WithContext(ctx context.Context) logger.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", ctx)
+	ret0, _ := ret[0].(logger.Logger)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext.
+func (mr *MockLoggerMockRecorder) WithContext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockLogger)(nil).WithContext), ctx)
+}
@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxFileSize is the size at which log files are rotated when LOG_FILE_MAX_SIZE_MB is not set
+const DefaultMaxFileSize = 100 << 20
+
+// backupTimeFormat is the timestamp of rotated files, it sorts in chronological order
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotationConfig holds the limits of the log file
+type RotationConfig struct {
+	// Size in bytes at which the file is rotated, 0 disables rotation
+	MaxSize int64
+	// Rotated files older than MaxAge are removed, 0 keeps them regardless of age
+	MaxAge time.Duration
+	// Number of rotated files kept, 0 keeps all of them
+	MaxBackups int
+}
+
+// rotatingFile is a log file renamed to <name>-<timestamp>.log once it reaches MaxSize, a new file is
+// opened in its place and the rotated files beyond the age and count limits are removed
+type rotatingFile struct {
+	mu     sync.Mutex
+	path   string
+	config RotationConfig
+	file   *os.File
+	size   int64
+	now    func() time.Time
+}
+
+func openRotatingFile(path string, mode FileOpenMode, config RotationConfig) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, config: config, now: time.Now}
+	if err := f.open(mode); err != nil {
+		return nil, err
+	}
+	f.prune()
+	return f, nil
+}
+
+func (f *rotatingFile) open(mode FileOpenMode) error {
+	flags := os.O_CREATE | os.O_WRONLY
+	if mode == FileModeAppend {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(f.path, flags, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.config.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.config.MaxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate renames the current file and opens a new one, called with the lock held
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.path, f.backupName(f.now())); err != nil {
+		return err
+	}
+	if err := f.open(FileModeTruncate); err != nil {
+		f.file = nil
+		return err
+	}
+	f.prune()
+	return nil
+}
+
+func (f *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// backups returns the rotated files of the log file, oldest first
+func (f *rotatingFile) backups() []string {
+	ext := filepath.Ext(f.path)
+	matches, err := filepath.Glob(strings.TrimSuffix(f.path, ext) + "-*" + ext)
+	if err != nil {
+		return nil
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// prune removes the rotated files beyond MaxBackups and older than MaxAge
+func (f *rotatingFile) prune() {
+	backups := f.backups()
+	if f.config.MaxBackups > 0 && len(backups) > f.config.MaxBackups {
+		for _, name := range backups[:len(backups)-f.config.MaxBackups] {
+			os.Remove(name)
+		}
+		backups = backups[len(backups)-f.config.MaxBackups:]
+	}
+	if f.config.MaxAge > 0 {
+		cutoff := f.now().Add(-f.config.MaxAge)
+		for _, name := range backups {
+			if info, err := os.Stat(name); err == nil && info.ModTime().Before(cutoff) {
+				os.Remove(name)
+			}
+		}
+	}
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
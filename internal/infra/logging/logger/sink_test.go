@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSinks(t *testing.T) {
+	testCases := []struct {
+		name   string
+		value  string
+		config LoggerConfig
+		want   []Sink
+	}{
+		{name: "console by default", config: LoggerConfig{ConsoleEnabled: true}, want: []Sink{SinkConsole}},
+		{name: "file when a path is set", config: LoggerConfig{ConsoleEnabled: true, LogsDir: "logs"}, want: []Sink{SinkConsole, SinkFile}},
+		{name: "console disabled", config: LoggerConfig{LogsDir: "logs"}, want: []Sink{SinkFile}},
+		{name: "explicit list", value: " Loki,syslog ,console,loki,unknown", want: []Sink{SinkLoki, SinkSyslog, SinkConsole}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, parseSinks(tc.value, tc.config))
+		})
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.log")
+	f, err := openRotatingFile(path, FileModeTruncate, RotationConfig{MaxSize: 10, MaxBackups: 2})
+	require.NoError(t, err)
+	defer f.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	for _, line := range []string{"line-1\n", "line-2\n", "line-3\n", "line-4\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	// Every line rotates the previous one, only the two newest backups are kept
+	backups := f.backups()
+	require.Len(t, backups, 2)
+	content, err := os.ReadFile(backups[1])
+	require.NoError(t, err)
+	assert.Equal(t, "line-3\n", string(content))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "line-4\n", string(content))
+}
+
+func TestRotatingFile_MaxAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.log")
+	old := filepath.Join(dir, "auth-2020-01-01T00-00-00.000.log")
+	require.NoError(t, os.WriteFile(old, []byte("old\n"), 0644))
+	require.NoError(t, os.Chtimes(old, time.Now().Add(-48*time.Hour), time.Now().Add(-48*time.Hour)))
+
+	f, err := openRotatingFile(path, FileModeAppend, RotationConfig{MaxAge: 24 * time.Hour})
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.NoFileExists(t, old)
+}
+
+func TestLokiWriter(t *testing.T) {
+	var mu sync.Mutex
+	var requests []lokiPushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, lokiPushPath, r.URL.Path)
+		var request lokiPushRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	w := newLokiWriter(LoggerConfig{
+		Module: shared.ModuleAuth,
+		Format: FormatJSON,
+		Loki:   LokiConfig{URL: server.URL, BatchSize: 10, BatchWait: time.Hour},
+	})
+	log := zerolog.New(w)
+	log.Info().Msg("first")
+	log.Error().Msg("second")
+	log.Info().Msg("third")
+	require.NoError(t, w.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 1)
+	lines := make(map[string][]string)
+	for _, stream := range requests[0].Streams {
+		assert.Equal(t, "erp-auth", stream.Stream["service"])
+		for _, value := range stream.Values {
+			lines[stream.Stream["level"]] = append(lines[stream.Stream["level"]], value[1])
+		}
+	}
+	require.Len(t, lines["info"], 2)
+	assert.True(t, strings.Contains(lines["info"][1], `"message":"third"`))
+	require.Len(t, lines["error"], 1)
+	assert.True(t, strings.Contains(lines["error"][0], `"message":"second"`))
+}
@@ -0,0 +1,70 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// syslogWriter writes each line to syslog at the priority matching its level
+type syslogWriter struct {
+	w      *syslog.Writer
+	format Format
+}
+
+// newSyslogWriter connects to the syslog server of config.SyslogAddress, the local daemon when empty
+func newSyslogWriter(config LoggerConfig) (io.Writer, io.Closer, error) {
+	var network, address string
+	if config.SyslogAddress != "" {
+		u, err := url.Parse(config.SyslogAddress)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, nil, fmt.Errorf("invalid syslog address %q, expected network://host:port", config.SyslogAddress)
+		}
+		network, address = u.Scheme, u.Host
+	}
+	tag := "erp-" + strings.ToLower(string(config.Module))
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_LOCAL0, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &syslogWriter{w: w, format: config.Format}, w, nil
+}
+
+func (s *syslogWriter) Write(p []byte) (int, error) {
+	return s.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (s *syslogWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var buf bytes.Buffer
+	if _, err := encoder(&buf, s.format).Write(p); err != nil {
+		return 0, err
+	}
+	line := strings.TrimSuffix(buf.String(), "\n")
+
+	var err error
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		err = s.w.Debug(line)
+	case zerolog.WarnLevel:
+		err = s.w.Warning(line)
+	case zerolog.ErrorLevel:
+		err = s.w.Err(line)
+	case zerolog.FatalLevel:
+		err = s.w.Crit(line)
+	case zerolog.PanicLevel:
+		err = s.w.Emerg(line)
+	default:
+		err = s.w.Info(line)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,43 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogWriter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w, closer, err := newSyslogWriter(LoggerConfig{
+		Module:        shared.ModuleAuth,
+		Format:        FormatText,
+		SyslogAddress: "udp://" + conn.LocalAddr().String(),
+	})
+	require.NoError(t, err)
+	defer closer.Close()
+
+	log := zerolog.New(w)
+	log.Error().Msg("failed")
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	// local0 (16) * 8 + err (3)
+	assert.Contains(t, string(buf[:n]), "<131>")
+	assert.Contains(t, string(buf[:n]), "erp-auth")
+	assert.Contains(t, string(buf[:n]), "ERROR |  | failed")
+
+	_, _, err = newSyslogWriter(LoggerConfig{SyslogAddress: "localhost"})
+	assert.Error(t, err)
+}
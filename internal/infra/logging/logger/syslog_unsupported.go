@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logger
+
+import (
+	"errors"
+	"io"
+)
+
+// newSyslogWriter fails, syslog is not available on this platform
+func newSyslogWriter(LoggerConfig) (io.Writer, io.Closer, error) {
+	return nil, nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,377 @@
+// Package metrics collects counters, gauges and histograms of a service and exposes them in the Prometheus text
+// exposition format, scraped from the /metrics endpoint of each service binary.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the histogram buckets in seconds used for request and operation latencies
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// DefaultRegistry holds the metrics of the process, served by Handler
+var DefaultRegistry = NewRegistry()
+
+// collector is a metric family written by the registry
+type collector interface {
+	name() string
+	write(w *bufio.Writer)
+}
+
+// Registry holds metric families by name
+type Registry struct {
+	mu         sync.RWMutex
+	collectors map[string]collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]collector)}
+}
+
+// register adds c to the registry, metric names are unique and registering one twice is a programming error
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.collectors[c.name()]; ok {
+		panic(fmt.Sprintf("metrics: %s is already registered", c.name()))
+	}
+	r.collectors[c.name()] = c
+}
+
+// Write writes every metric family sorted by name in the text exposition format
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.RLock()
+	collectors := make([]collector, 0, len(r.collectors))
+	for _, c := range r.collectors {
+		collectors = append(collectors, c)
+	}
+	r.mu.RUnlock()
+	sort.Slice(collectors, func(i, j int) bool { return collectors[i].name() < collectors[j].name() })
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Handler serves the metrics of the registry
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.Write(w)
+	})
+}
+
+// Handler serves the metrics of DefaultRegistry
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+// family holds the series of a metric keyed by their label values
+type family[T any] struct {
+	metricName string
+	help       string
+	labels     []string
+	newSeries  func() *T
+
+	mu     sync.Mutex
+	series map[string]*T
+	values map[string][]string
+}
+
+func newFamily[T any](name, help string, labels []string, newSeries func() *T) *family[T] {
+	return &family[T]{
+		metricName: name,
+		help:       help,
+		labels:     labels,
+		newSeries:  newSeries,
+		series:     make(map[string]*T),
+		values:     make(map[string][]string),
+	}
+}
+
+func (f *family[T]) name() string {
+	return f.metricName
+}
+
+// with returns the series of the label values, creating it on first use
+func (f *family[T]) with(values []string) *T {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.metricName, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	series, ok := f.series[key]
+	if !ok {
+		series = f.newSeries()
+		f.series[key] = series
+		f.values[key] = append([]string(nil), values...)
+	}
+	return series
+}
+
+// each calls fn with the label pairs of every series sorted by label values
+func (f *family[T]) each(fn func(labels string, series *T)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fn(formatLabels(f.labels, f.values[key]), f.series[key])
+	}
+}
+
+func (f *family[T]) writeHeader(w *bufio.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.metricName, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.metricName, kind)
+}
+
+// Counter is a monotonically increasing metric partitioned by labels
+type Counter struct {
+	*family[counterSeries]
+}
+
+type counterSeries struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// NewCounter registers a counter in DefaultRegistry
+func NewCounter(name, help string, labels ...string) *Counter {
+	return DefaultRegistry.NewCounter(name, help, labels...)
+}
+
+// NewCounter registers a counter with the given label names
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{newFamily(name, help, labels, func() *counterSeries { return &counterSeries{} })}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the series of the label values
+func (c *Counter) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add adds v to the series of the label values, negative values are ignored
+func (c *Counter) Add(v float64, values ...string) {
+	if v < 0 {
+		return
+	}
+	series := c.with(values)
+	series.mu.Lock()
+	series.value += v
+	series.mu.Unlock()
+}
+
+// Value returns the current value of the series of the label values
+func (c *Counter) Value(values ...string) float64 {
+	series := c.with(values)
+	series.mu.Lock()
+	defer series.mu.Unlock()
+	return series.value
+}
+
+// Sum returns the sum of the series whose label has value, e.g. the total of a result across the other labels
+func (c *Counter) Sum(label, value string) float64 {
+	index := slices.Index(c.labels, label)
+	if index < 0 {
+		panic(fmt.Sprintf("metrics: %s has no label %s", c.metricName, label))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var sum float64
+	for key, series := range c.series {
+		if c.values[key][index] != value {
+			continue
+		}
+		series.mu.Lock()
+		sum += series.value
+		series.mu.Unlock()
+	}
+	return sum
+}
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.writeHeader(w, "counter")
+	c.each(func(labels string, series *counterSeries) {
+		series.mu.Lock()
+		defer series.mu.Unlock()
+		fmt.Fprintf(w, "%s%s %s\n", c.metricName, braces(labels), formatFloat(series.value))
+	})
+}
+
+// Gauge is a metric partitioned by labels that is set to its current value
+type Gauge struct {
+	*family[gaugeSeries]
+}
+
+type gaugeSeries struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// NewGauge registers a gauge in DefaultRegistry
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return DefaultRegistry.NewGauge(name, help, labels...)
+}
+
+// NewGauge registers a gauge with the given label names
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	g := &Gauge{newFamily(name, help, labels, func() *gaugeSeries { return &gaugeSeries{} })}
+	r.register(g)
+	return g
+}
+
+// Set sets the series of the label values to v
+func (g *Gauge) Set(v float64, values ...string) {
+	series := g.with(values)
+	series.mu.Lock()
+	series.value = v
+	series.mu.Unlock()
+}
+
+// Value returns the current value of the series of the label values
+func (g *Gauge) Value(values ...string) float64 {
+	series := g.with(values)
+	series.mu.Lock()
+	defer series.mu.Unlock()
+	return series.value
+}
+
+func (g *Gauge) write(w *bufio.Writer) {
+	g.writeHeader(w, "gauge")
+	g.each(func(labels string, series *gaugeSeries) {
+		series.mu.Lock()
+		defer series.mu.Unlock()
+		fmt.Fprintf(w, "%s%s %s\n", g.metricName, braces(labels), formatFloat(series.value))
+	})
+}
+
+// Histogram counts observations in cumulative buckets partitioned by labels
+type Histogram struct {
+	*family[histogramSeries]
+	buckets []float64
+}
+
+type histogramSeries struct {
+	mu     sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram registers a histogram in DefaultRegistry
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return DefaultRegistry.NewHistogram(name, help, buckets, labels...)
+}
+
+// NewHistogram registers a histogram with the given upper bounds and label names, DefaultBuckets when buckets is empty
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	h := &Histogram{buckets: buckets}
+	h.family = newFamily(name, help, labels, func() *histogramSeries {
+		return &histogramSeries{counts: make([]uint64, len(buckets))}
+	})
+	r.register(h)
+	return h
+}
+
+// Observe records v in the series of the label values
+func (h *Histogram) Observe(v float64, values ...string) {
+	series := h.with(values)
+	series.mu.Lock()
+	defer series.mu.Unlock()
+	for i, bound := range h.buckets {
+		if v <= bound {
+			series.counts[i]++
+		}
+	}
+	series.count++
+	series.sum += v
+}
+
+// ObserveSince records the seconds elapsed since start
+func (h *Histogram) ObserveSince(start time.Time, values ...string) {
+	h.Observe(time.Since(start).Seconds(), values...)
+}
+
+// Count returns the number of observations of the series of the label values
+func (h *Histogram) Count(values ...string) uint64 {
+	series := h.with(values)
+	series.mu.Lock()
+	defer series.mu.Unlock()
+	return series.count
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.writeHeader(w, "histogram")
+	h.each(func(labels string, series *histogramSeries) {
+		series.mu.Lock()
+		defer series.mu.Unlock()
+		prefix := labels
+		if prefix != "" {
+			prefix += ","
+		}
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket{%sle=\"%s\"} %d\n", h.metricName, prefix, formatFloat(bound), series.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", h.metricName, prefix, series.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.metricName, braces(labels), formatFloat(series.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.metricName, braces(labels), series.count)
+	})
+}
+
+// Status is the status label of an operation, "ok" or "error"
+func Status(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(names, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelValueEscaper.Replace(values[i]) + `"`
+	}
+	return strings.Join(pairs, ",")
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	registry := NewRegistry()
+	requests := registry.NewCounter("requests_total", "Total requests.", "method", "code")
+	latency := registry.NewHistogram("latency_seconds", "Request latency.", []float64{0.5, 0.1}, "method")
+	inFlight := registry.NewGauge("in_flight", "Requests in flight.", "method")
+
+	requests.Inc("/a", "OK")
+	requests.Add(2, "/a", "OK")
+	requests.Inc("/b", `quoted "value"`)
+	requests.Add(-1, "/a", "OK")
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.3, "/a")
+	latency.Observe(2, "/a")
+	inFlight.Set(4, "/a")
+	inFlight.Set(2, "/a")
+
+	assert.Equal(t, float64(3), requests.Value("/a", "OK"))
+	assert.Equal(t, float64(2), inFlight.Value("/a"))
+	assert.Equal(t, uint64(3), latency.Count("/a"))
+
+	var out strings.Builder
+	require.NoError(t, registry.Write(&out))
+	assert.Equal(t, `# HELP in_flight Requests in flight.
+# TYPE in_flight gauge
+in_flight{method="/a"} 2
+# HELP latency_seconds Request latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{method="/a",le="0.1"} 1
+latency_seconds_bucket{method="/a",le="0.5"} 2
+latency_seconds_bucket{method="/a",le="+Inf"} 3
+latency_seconds_sum{method="/a"} 2.35
+latency_seconds_count{method="/a"} 3
+# HELP requests_total Total requests.
+# TYPE requests_total counter
+requests_total{method="/a",code="OK"} 3
+requests_total{method="/b",code="quoted \"value\""} 1
+`, out.String())
+}
+
+func TestCounter_Sum(t *testing.T) {
+	registry := NewRegistry()
+	reads := registry.NewCounter("reads_total", "Total reads.", "handler", "result")
+	reads.Add(2, "a", "hit")
+	reads.Add(3, "b", "hit")
+	reads.Inc("a", "miss")
+
+	assert.Equal(t, float64(5), reads.Sum("result", "hit"))
+	assert.Equal(t, float64(1), reads.Sum("result", "miss"))
+	assert.Equal(t, float64(3), reads.Sum("handler", "a"))
+	assert.Zero(t, reads.Sum("result", "error"))
+	assert.Panics(t, func() { reads.Sum("status", "ok") })
+}
+
+func TestRegistry_Invalid(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("requests_total", "Total requests.", "method")
+	assert.Panics(t, func() { registry.NewCounter("requests_total", "Total requests.") })
+	assert.Panics(t, func() { counter.Inc("/a", "extra") })
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("up", "Process is up.").Inc()
+
+	rec := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rec.Body.String(), "up 1\n")
+}
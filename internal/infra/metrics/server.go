@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+const (
+	// Path of the metrics endpoint
+	Path = "/metrics"
+	// MetricsPortEnv overrides the port of the metrics endpoint of a service binary
+	MetricsPortEnv = "METRICS_PORT"
+)
+
+// Server serves DefaultRegistry on Path for Prometheus scrapes
+type Server struct {
+	server *http.Server
+	logger logger.Logger
+}
+
+// NewServer creates a metrics server on METRICS_PORT, or on defaultPort when it is not set
+func NewServer(defaultPort int, logger logger.Logger) *Server {
+	port := defaultPort
+	if value := os.Getenv(MetricsPortEnv); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			port = parsed
+		} else {
+			logger.Warn("invalid metrics port, using default", "value", value, "port", defaultPort)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+	return &Server{
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// Start serves the endpoint in the background until Shutdown
+func (s *Server) Start() {
+	go func() {
+		s.logger.Info("metrics endpoint listening", "address", s.server.Addr, "path", Path)
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("metrics endpoint stopped", "error", err)
+		}
+	}()
+}
+
+// Shutdown stops the endpoint, waiting for in-flight scrapes until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -2,9 +2,12 @@ package auth
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
 )
 
 /* User */
@@ -31,6 +34,37 @@ func IsValidUserStatus(userStatus string) bool {
 	return validUserStatuses[userStatus]
 }
 
+// Optional profile fields tracked by progressive profiling
+const (
+	ProfileFieldPhone      = "phone"
+	ProfileFieldTitle      = "title"
+	ProfileFieldDepartment = "department"
+	ProfileFieldMFA        = "mfa"
+)
+
+// DefaultEventuallyRequiredProfileFields is used when a tenant did not configure its own list
+var DefaultEventuallyRequiredProfileFields = []string{
+	ProfileFieldPhone,
+	ProfileFieldTitle,
+	ProfileFieldDepartment,
+	ProfileFieldMFA,
+}
+
+func IsValidProfileField(profileField string) bool {
+	if profileField == "" {
+		return false
+	}
+	profileField = strings.ToLower(profileField)
+	validProfileFields := map[string]bool{
+		ProfileFieldPhone:      true,
+		ProfileFieldTitle:      true,
+		ProfileFieldDepartment: true,
+		ProfileFieldMFA:        true,
+	}
+
+	return validProfileFields[profileField]
+}
+
 /* Tenant */
 // System tenant ID for cross-tenant operations
 const (
@@ -60,6 +94,72 @@ func IsValidTenantStatus(tenantStatus string) bool {
 	return validTenantStatuses[tenantStatus]
 }
 
+// tenantStatusTransitions lists the statuses each tenant status can change to: trials end active, suspended or
+// inactive, active and suspended tenants move between each other or to inactive, inactive tenants can be reactivated
+var tenantStatusTransitions = map[authv1.TenantStatus][]authv1.TenantStatus{
+	authv1.TenantStatus_TENANT_STATUS_TRIAL:     {authv1.TenantStatus_TENANT_STATUS_ACTIVE, authv1.TenantStatus_TENANT_STATUS_SUSPENDED, authv1.TenantStatus_TENANT_STATUS_INACTIVE},
+	authv1.TenantStatus_TENANT_STATUS_ACTIVE:    {authv1.TenantStatus_TENANT_STATUS_SUSPENDED, authv1.TenantStatus_TENANT_STATUS_INACTIVE},
+	authv1.TenantStatus_TENANT_STATUS_SUSPENDED: {authv1.TenantStatus_TENANT_STATUS_ACTIVE, authv1.TenantStatus_TENANT_STATUS_INACTIVE},
+	authv1.TenantStatus_TENANT_STATUS_INACTIVE:  {authv1.TenantStatus_TENANT_STATUS_ACTIVE},
+}
+
+// CanChangeTenantStatus reports whether a tenant in status from can move to status to
+func CanChangeTenantStatus(from, to authv1.TenantStatus) bool {
+	return slices.Contains(tenantStatusTransitions[from], to)
+}
+
+// IsTenantTrialExpired reports whether tenant is a trial whose trial period ended at now
+func IsTenantTrialExpired(tenant *authv1.Tenant, now time.Time) bool {
+	return tenant.GetStatus() == authv1.TenantStatus_TENANT_STATUS_TRIAL &&
+		tenant.GetTrialEnd() != nil && !tenant.GetTrialEnd().AsTime().After(now)
+}
+
+// TenantLoginError returns the error the logins of the users of tenant fail with at now, nil for active tenants and
+// running trials. Expired trials are refused before the sweeps suspend them
+func TenantLoginError(tenant *authv1.Tenant, now time.Time) error {
+	switch tenant.GetStatus() {
+	case authv1.TenantStatus_TENANT_STATUS_SUSPENDED:
+		return infra_error.Auth(infra_error.AuthTenantSuspended)
+	case authv1.TenantStatus_TENANT_STATUS_INACTIVE:
+		return infra_error.Auth(infra_error.AuthTenantInactive)
+	}
+	if IsTenantTrialExpired(tenant, now) {
+		return infra_error.Auth(infra_error.AuthTenantSuspended)
+	}
+	return nil
+}
+
+// Resources limited by the tenant quotas
+const (
+	TenantResourceUsers   = "users"
+	TenantResourceRoles   = "roles"
+	TenantResourceAPIKeys = "api_keys"
+)
+
+// TenantLimit returns the limit of resource in limits, 0 when unlimited
+func TenantLimit(limits *authv1.TenantLimits, resource string) int32 {
+	switch resource {
+	case TenantResourceUsers:
+		return limits.GetMaxUsers()
+	case TenantResourceRoles:
+		return limits.GetMaxRoles()
+	case TenantResourceAPIKeys:
+		return limits.GetMaxApiKeys()
+	}
+	return 0
+}
+
+// CheckTenantLimit returns the error creating one more resource fails with when the tenant already has count of them,
+// nil when limit is 0
+func CheckTenantLimit(resource string, limit int32, count int) error {
+	if limit <= 0 || count < int(limit) {
+		return nil
+	}
+	return infra_error.Business(infra_error.BusinessTenantLimitReached).
+		WithDetails("resource", resource).
+		WithDetails("limit", limit)
+}
+
 /* RBAC */
 
 func CreatePermissionString(resource string, action string) (string, error) {
@@ -123,6 +223,11 @@ const (
 	PermissionActionDelete           = "delete"
 	PermissionActionModifyPermission = "permission"
 	PermissionActionModifyRole       = "role"
+	PermissionActionImpersonate      = "impersonate"
+	PermissionActionExport           = "export"
+	PermissionActionImport           = "import"
+	PermissionActionErase            = "erase"
+	PermissionActionReserve          = "reserve"
 )
 
 func IsValidPermissionAction(permissionAction string) bool {
@@ -137,6 +242,11 @@ func IsValidPermissionAction(permissionAction string) bool {
 		PermissionActionDelete:           true,
 		PermissionActionModifyPermission: true,
 		PermissionActionModifyRole:       true,
+		PermissionActionImpersonate:      true,
+		PermissionActionExport:           true,
+		PermissionActionImport:           true,
+		PermissionActionErase:            true,
+		PermissionActionReserve:          true,
 	}
 	return validPermissionActions[permissionAction]
 }
@@ -190,6 +300,15 @@ const (
 	ResourceTypeConfig     = "config"
 	ResourceTypeTenant     = "tenant"
 	ResourceTypeToken      = "token"
+	ResourceTypeAPIKey     = "apikey"
+	ResourceTypeWebhook    = "webhook"
+	ResourceTypeAudit      = "audit"
+	ResourceTypeWarehouse  = "warehouse"
+	ResourceTypeInventory  = "inventory"
+	ResourceTypeInvoice    = "invoice"
+	ResourceTypePricing    = "pricing"
+	ResourceTypeCurrency   = "currency"
+	ResourceTypeAnalytics  = "analytics"
 )
 
 func IsValidResourceType(resourceType string) bool {
@@ -209,7 +328,52 @@ func IsValidResourceType(resourceType string) bool {
 		ResourceTypeConfig:     true,
 		ResourceTypeTenant:     true,
 		ResourceTypeToken:      true,
+		ResourceTypeAPIKey:     true,
+		ResourceTypeWebhook:    true,
+		ResourceTypeAudit:      true,
+		ResourceTypeWarehouse:  true,
+		ResourceTypeInventory:  true,
+		ResourceTypeInvoice:    true,
+		ResourceTypePricing:    true,
+		ResourceTypeCurrency:   true,
+		ResourceTypeAnalytics:  true,
 	}
 
 	return validResourceTypes[resourceType]
 }
+
+/* Role assignment */
+// IsRoleAssignmentActive reports whether the role assignment has not expired at now, an assignment without
+// ExpiresAt never expires
+func IsRoleAssignmentActive(userRole *authv1.UserRole, now time.Time) bool {
+	return userRole.GetExpiresAt() == nil || userRole.GetExpiresAt().AsTime().After(now)
+}
+
+/* Impersonation */
+// ImpersonationSessionSeparator joins the impersonated user and the actor in the id of an impersonation session
+const (
+	ImpersonationSessionSeparator = "~"
+)
+
+// ImpersonationSessionID returns the id the access token of actorID impersonating userID is stored under, apart
+// from the token of the user
+func ImpersonationSessionID(userID, actorID string) string {
+	return userID + ImpersonationSessionSeparator + actorID
+}
+
+/* API key */
+// APIKeyPrincipalPrefix prefixes the user id of calls authenticated with an API key
+const (
+	APIKeyPrincipalPrefix = "apikey:"
+)
+
+// APIKeyPrincipal returns the user id used by calls authenticated with the API key keyID
+func APIKeyPrincipal(keyID string) string {
+	return APIKeyPrincipalPrefix + keyID
+}
+
+// APIKeyIDFromPrincipal returns the API key id of userID, ok is false for regular users
+func APIKeyIDFromPrincipal(userID string) (string, bool) {
+	keyID, ok := strings.CutPrefix(userID, APIKeyPrincipalPrefix)
+	return keyID, ok && keyID != ""
+}
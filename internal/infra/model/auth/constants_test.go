@@ -2,8 +2,12 @@ package auth
 
 import (
 	"testing"
+	"time"
 
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestCreatePermissionString(t *testing.T) {
@@ -332,3 +336,140 @@ func TestIsValidPermissionFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestIsRoleAssignmentActive(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		userRole *authv1.UserRole
+		expected bool
+	}{
+		{
+			name:     "permanent assignment",
+			userRole: &authv1.UserRole{RoleId: "role-1"},
+			expected: true,
+		},
+		{
+			name:     "expires later",
+			userRole: &authv1.UserRole{RoleId: "role-1", ExpiresAt: timestamppb.New(now.Add(time.Hour))},
+			expected: true,
+		},
+		{
+			name:     "expires now",
+			userRole: &authv1.UserRole{RoleId: "role-1", ExpiresAt: timestamppb.New(now)},
+			expected: false,
+		},
+		{
+			name:     "expired",
+			userRole: &authv1.UserRole{RoleId: "role-1", ExpiresAt: timestamppb.New(now.Add(-time.Hour))},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRoleAssignmentActive(tt.userRole, now))
+		})
+	}
+}
+
+func TestCanChangeTenantStatus(t *testing.T) {
+	trial := authv1.TenantStatus_TENANT_STATUS_TRIAL
+	active := authv1.TenantStatus_TENANT_STATUS_ACTIVE
+	suspended := authv1.TenantStatus_TENANT_STATUS_SUSPENDED
+	inactive := authv1.TenantStatus_TENANT_STATUS_INACTIVE
+
+	tests := []struct {
+		name     string
+		from     authv1.TenantStatus
+		to       authv1.TenantStatus
+		expected bool
+	}{
+		{name: "trial converted", from: trial, to: active, expected: true},
+		{name: "trial expired", from: trial, to: suspended, expected: true},
+		{name: "active suspended", from: active, to: suspended, expected: true},
+		{name: "suspended reactivated", from: suspended, to: active, expected: true},
+		{name: "suspended deactivated", from: suspended, to: inactive, expected: true},
+		{name: "inactive reactivated", from: inactive, to: active, expected: true},
+		{name: "back to trial", from: active, to: trial, expected: false},
+		{name: "inactive suspended", from: inactive, to: suspended, expected: false},
+		{name: "unchanged", from: active, to: active, expected: false},
+		{name: "unspecified", from: active, to: authv1.TenantStatus_TENANT_STATUS_UNSPECIFIED, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CanChangeTenantStatus(tt.from, tt.to))
+		})
+	}
+}
+
+func TestTenantLoginError(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		tenant   *authv1.Tenant
+		expected *infra_error.AppError
+	}{
+		{
+			name:   "active",
+			tenant: &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_ACTIVE},
+		},
+		{
+			name:   "running trial",
+			tenant: &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_TRIAL, TrialEnd: timestamppb.New(now.Add(time.Hour))},
+		},
+		{
+			name:     "expired trial",
+			tenant:   &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_TRIAL, TrialEnd: timestamppb.New(now)},
+			expected: infra_error.Auth(infra_error.AuthTenantSuspended),
+		},
+		{
+			name:     "suspended",
+			tenant:   &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_SUSPENDED},
+			expected: infra_error.Auth(infra_error.AuthTenantSuspended),
+		},
+		{
+			name:     "inactive",
+			tenant:   &authv1.Tenant{Status: authv1.TenantStatus_TENANT_STATUS_INACTIVE},
+			expected: infra_error.Auth(infra_error.AuthTenantInactive),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TenantLoginError(tt.tenant, now)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.expected.Is(err))
+		})
+	}
+}
+
+func TestCheckTenantLimit(t *testing.T) {
+	limits := &authv1.TenantLimits{MaxUsers: 2, MaxApiKeys: 1}
+	tests := []struct {
+		name        string
+		resource    string
+		count       int
+		expectedErr bool
+	}{
+		{name: "under limit", resource: TenantResourceUsers, count: 1},
+		{name: "limit reached", resource: TenantResourceUsers, count: 2, expectedErr: true},
+		{name: "over limit", resource: TenantResourceAPIKeys, count: 3, expectedErr: true},
+		{name: "unlimited", resource: TenantResourceRoles, count: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTenantLimit(tt.resource, TenantLimit(limits, tt.resource), tt.count)
+			if !tt.expectedErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, infra_error.Business(infra_error.BusinessTenantLimitReached).Is(err))
+		})
+	}
+}
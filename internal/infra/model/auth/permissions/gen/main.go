@@ -0,0 +1,118 @@
+// gen reads the permission catalog and writes the permissions constants file.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+
+	model_auth "erp.localhost/internal/infra/model/auth"
+)
+
+type catalogFile struct {
+	Resources []struct {
+		Resource string   `json:"resource"`
+		Actions  []string `json:"actions"`
+	} `json:"resources"`
+}
+
+// actionNames maps actions to the constant suffix used in model_auth
+var actionNames = map[string]string{
+	model_auth.PermissionActionCreate:           "Create",
+	model_auth.PermissionActionRead:             "Read",
+	model_auth.PermissionActionUpdate:           "Update",
+	model_auth.PermissionActionDelete:           "Delete",
+	model_auth.PermissionActionModifyRole:       "ModifyRole",
+	model_auth.PermissionActionModifyPermission: "ModifyPermission",
+	model_auth.PermissionActionImpersonate:      "Impersonate",
+	model_auth.PermissionActionExport:           "Export",
+	model_auth.PermissionActionImport:           "Import",
+	model_auth.PermissionActionErase:            "Erase",
+	model_auth.PermissionActionReserve:          "Reserve",
+}
+
+func main() {
+	catalogPath := flag.String("catalog", "catalog.json", "path to the permission catalog")
+	outPath := flag.String("out", "permissions.gen.go", "path of the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*catalogPath)
+	if err != nil {
+		log.Fatalf("failed to read catalog: %v", err)
+	}
+	var catalog catalogFile
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		log.Fatalf("failed to parse catalog: %v", err)
+	}
+
+	type entry struct {
+		name  string
+		value string
+	}
+	entries := []entry{}
+	seen := map[string]bool{}
+	for _, r := range catalog.Resources {
+		for _, action := range r.Actions {
+			name, err := constantName(r.Resource, action)
+			if err != nil {
+				log.Fatalf("invalid catalog entry: %v", err)
+			}
+			value := r.Resource + ":" + action
+			if seen[value] {
+				log.Fatalf("duplicate catalog entry: %s", value)
+			}
+			seen[value] = true
+			entries = append(entries, entry{name: name, value: value})
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by permissions/gen from catalog.json. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package permissions")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "const (")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s = %q\n", e.name, e.value)
+	}
+	fmt.Fprintln(&buf, ")")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "var ordered = []string{")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s,\n", e.name)
+	}
+	fmt.Fprintln(&buf, "}")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "var catalog = map[string]struct{}{")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s: {},\n", e.name)
+	}
+	fmt.Fprintln(&buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated file: %v", err)
+	}
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatalf("failed to write generated file: %v", err)
+	}
+}
+
+func constantName(resource, action string) (string, error) {
+	if resource == model_auth.ResourceTypeAll && action == model_auth.PermissionActionAll {
+		return "Wildcard", nil
+	}
+	if !model_auth.IsValidResourceType(resource) || resource == model_auth.ResourceTypeAll {
+		return "", fmt.Errorf("unknown resource %q", resource)
+	}
+	suffix, ok := actionNames[action]
+	if !ok {
+		return "", fmt.Errorf("unknown action %q for resource %q", action, resource)
+	}
+	return strings.ToUpper(resource[:1]) + resource[1:] + suffix, nil
+}
@@ -0,0 +1,19 @@
+// Package permissions holds the permission strings every module checks and seeds.
+// The constants are generated from catalog.json, which is the single source of truth
+// for "[resource]:[action]" pairs - add new pairs there and run go generate.
+package permissions
+
+//go:generate go run ./gen -catalog catalog.json -out permissions.gen.go
+
+// IsKnown reports whether permission is declared in the catalog
+func IsKnown(permission string) bool {
+	_, ok := catalog[permission]
+	return ok
+}
+
+// All returns every permission declared in the catalog, in catalog order
+func All() []string {
+	result := make([]string, len(ordered))
+	copy(result, ordered)
+	return result
+}
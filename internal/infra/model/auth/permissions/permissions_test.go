@@ -0,0 +1,102 @@
+package permissions
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogEntriesAreValid(t *testing.T) {
+	for _, permission := range All() {
+		if permission == Wildcard {
+			continue
+		}
+		assert.True(t, model_auth.IsValidPermissionFormat(permission), "catalog entry %q is not a valid permission", permission)
+	}
+}
+
+func TestIsKnown(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		want       bool
+	}{
+		{name: "wildcard", permission: Wildcard, want: true},
+		{name: "user create", permission: "user:create", want: true},
+		{name: "tenant delete", permission: TenantDelete, want: true},
+		{name: "plural resource", permission: "users:read", want: false},
+		{name: "undeclared action", permission: "token:create", want: false},
+		{name: "empty", permission: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsKnown(tt.permission))
+		})
+	}
+}
+
+// TestNoUnknownPermissionLiterals scans the module sources for string literals shaped like
+// "[resource]:[action]" and fails on any that are not declared in the catalog
+func TestNoUnknownPermissionLiterals(t *testing.T) {
+	root := filepath.Join("..", "..", "..", "..")
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "functional" || d.Name() == "mock" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") || strings.HasSuffix(path, ".pb.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil || !looksLikePermission(value) {
+				return true
+			}
+			assert.True(t, IsKnown(value), "%s: unknown permission string %q", fset.Position(lit.Pos()), value)
+			return true
+		})
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func looksLikePermission(value string) bool {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return false
+	}
+	for _, part := range parts {
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && r != '*' && r != '_' {
+				return false
+			}
+		}
+	}
+	return model_auth.IsValidResourceType(strings.TrimSuffix(parts[0], "s")) || parts[0] == model_auth.ResourceTypeAll
+}
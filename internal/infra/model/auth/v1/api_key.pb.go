@@ -0,0 +1,606 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/api_key.proto
+
+package authv1
+
+import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// APIKey model for MongoDB auth_db.api_keys collection
+// The key acts on behalf of created_by, limited to its scopes
+type APIKey struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name" bson:"name" validate:"required"`
+	// Public part of the key used to look it up, safe to display
+	Prefix string `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix" bson:"prefix" validate:"required"`
+	// SHA-256 of the full key, the key itself is never stored
+	KeyHash string `protobuf:"bytes,5,opt,name=key_hash,json=keyHash,proto3" json:"-" bson:"key_hash" validate:"required"`
+	// Permission strings the key is allowed to use
+	Scopes    []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes" bson:"scopes" validate:"required,min=1"`
+	CreatedBy string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	// Unset means the key does not expire
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	RevokedBy     string                 `protobuf:"bytes,12,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty" bson:"revoked_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_auth_v1_api_key_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_api_key_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_auth_v1_api_key_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetKeyHash() string {
+	if x != nil {
+		return x.KeyHash
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetRevokedBy() string {
+	if x != nil {
+		return x.RevokedBy
+	}
+	return ""
+}
+
+// =============================================================================
+// API key management
+// =============================================================================
+type CreateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_auth_v1_api_key_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_api_key_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_api_key_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAPIKeyRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey *APIKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// Shown once, only the hash is persisted
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_auth_v1_api_key_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_api_key_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_api_key_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_auth_v1_api_key_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_api_key_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_api_key_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RevokeAPIKeyRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *RevokeAPIKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type RevokeAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revoked       bool                   `protobuf:"varint,1,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyResponse) Reset() {
+	*x = RevokeAPIKeyResponse{}
+	mi := &file_auth_v1_api_key_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyResponse) ProtoMessage() {}
+
+func (x *RevokeAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_api_key_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_api_key_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RevokeAPIKeyResponse) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+type ListAPIKeysRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	IncludeRevoked bool                   `protobuf:"varint,2,opt,name=include_revoked,json=includeRevoked,proto3" json:"include_revoked,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_auth_v1_api_key_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_api_key_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_api_key_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAPIKeysRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListAPIKeysRequest) GetIncludeRevoked() bool {
+	if x != nil {
+		return x.IncludeRevoked
+	}
+	return false
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_auth_v1_api_key_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_api_key_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_api_key_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+var File_auth_v1_api_key_proto protoreflect.FileDescriptor
+
+const file_auth_v1_api_key_proto_rawDesc = "" +
+	"\n" +
+	"\x15auth/v1/api_key.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xfb\b\n" +
+	"\x06APIKey\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12D\n" +
+	"\x04name\x18\x03 \x01(\tB0\x9a\x84\x9e\x03+bson:\"name\" json:\"name\" validate:\"required\"R\x04name\x12L\n" +
+	"\x06prefix\x18\x04 \x01(\tB4\x9a\x84\x9e\x03/bson:\"prefix\" json:\"prefix\" validate:\"required\"R\x06prefix\x12L\n" +
+	"\bkey_hash\x18\x05 \x01(\tB1\x9a\x84\x9e\x03,bson:\"key_hash\" json:\"-\" validate:\"required\"R\akeyHash\x12R\n" +
+	"\x06scopes\x18\x06 \x03(\tB:\x9a\x84\x9e\x035bson:\"scopes\" json:\"scopes\" validate:\"required,min=1\"R\x06scopes\x12[\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12c\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12w\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB<\x9a\x84\x9e\x037bson:\"expires_at,omitempty\" json:\"expires_at,omitempty\"R\texpiresAt\x12~\n" +
+	"\flast_used_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB@\x9a\x84\x9e\x03;bson:\"last_used_at,omitempty\" json:\"last_used_at,omitempty\"R\n" +
+	"lastUsedAt\x12w\n" +
+	"\n" +
+	"revoked_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB<\x9a\x84\x9e\x037bson:\"revoked_at,omitempty\" json:\"revoked_at,omitempty\"R\trevokedAt\x12[\n" +
+	"\n" +
+	"revoked_by\x18\f \x01(\tB<\x9a\x84\x9e\x037bson:\"revoked_by,omitempty\" json:\"revoked_by,omitempty\"R\trevokedBy\"\xd5\x01\n" +
+	"\x13CreateAPIKeyRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"R\n" +
+	"\x14CreateAPIKeyResponse\x12(\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x0f.auth.v1.APIKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"\x85\x01\n" +
+	"\x13RevokeAPIKeyRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\"0\n" +
+	"\x14RevokeAPIKeyResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\bR\arevoked\"\x96\x01\n" +
+	"\x12ListAPIKeysRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12'\n" +
+	"\x0finclude_revoked\x18\x02 \x01(\bR\x0eincludeRevoked\"A\n" +
+	"\x13ListAPIKeysResponse\x12*\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x0f.auth.v1.APIKeyR\aapiKeys2\xf3\x01\n" +
+	"\rAPIKeyService\x12K\n" +
+	"\fCreateAPIKey\x12\x1c.auth.v1.CreateAPIKeyRequest\x1a\x1d.auth.v1.CreateAPIKeyResponse\x12K\n" +
+	"\fRevokeAPIKey\x12\x1c.auth.v1.RevokeAPIKeyRequest\x1a\x1d.auth.v1.RevokeAPIKeyResponse\x12H\n" +
+	"\vListAPIKeys\x12\x1b.auth.v1.ListAPIKeysRequest\x1a\x1c.auth.v1.ListAPIKeysResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+
+var (
+	file_auth_v1_api_key_proto_rawDescOnce sync.Once
+	file_auth_v1_api_key_proto_rawDescData []byte
+)
+
+func file_auth_v1_api_key_proto_rawDescGZIP() []byte {
+	file_auth_v1_api_key_proto_rawDescOnce.Do(func() {
+		file_auth_v1_api_key_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_api_key_proto_rawDesc), len(file_auth_v1_api_key_proto_rawDesc)))
+	})
+	return file_auth_v1_api_key_proto_rawDescData
+}
+
+var file_auth_v1_api_key_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_auth_v1_api_key_proto_goTypes = []any{
+	(*APIKey)(nil),                // 0: auth.v1.APIKey
+	(*CreateAPIKeyRequest)(nil),   // 1: auth.v1.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),  // 2: auth.v1.CreateAPIKeyResponse
+	(*RevokeAPIKeyRequest)(nil),   // 3: auth.v1.RevokeAPIKeyRequest
+	(*RevokeAPIKeyResponse)(nil),  // 4: auth.v1.RevokeAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),    // 5: auth.v1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),   // 6: auth.v1.ListAPIKeysResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),     // 8: infra.v1.UserIdentifier
+}
+var file_auth_v1_api_key_proto_depIdxs = []int32{
+	7,  // 0: auth.v1.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 1: auth.v1.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	7,  // 2: auth.v1.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	7,  // 3: auth.v1.APIKey.revoked_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: auth.v1.CreateAPIKeyRequest.identifier:type_name -> infra.v1.UserIdentifier
+	7,  // 5: auth.v1.CreateAPIKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: auth.v1.CreateAPIKeyResponse.api_key:type_name -> auth.v1.APIKey
+	8,  // 7: auth.v1.RevokeAPIKeyRequest.identifier:type_name -> infra.v1.UserIdentifier
+	8,  // 8: auth.v1.ListAPIKeysRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 9: auth.v1.ListAPIKeysResponse.api_keys:type_name -> auth.v1.APIKey
+	1,  // 10: auth.v1.APIKeyService.CreateAPIKey:input_type -> auth.v1.CreateAPIKeyRequest
+	3,  // 11: auth.v1.APIKeyService.RevokeAPIKey:input_type -> auth.v1.RevokeAPIKeyRequest
+	5,  // 12: auth.v1.APIKeyService.ListAPIKeys:input_type -> auth.v1.ListAPIKeysRequest
+	2,  // 13: auth.v1.APIKeyService.CreateAPIKey:output_type -> auth.v1.CreateAPIKeyResponse
+	4,  // 14: auth.v1.APIKeyService.RevokeAPIKey:output_type -> auth.v1.RevokeAPIKeyResponse
+	6,  // 15: auth.v1.APIKeyService.ListAPIKeys:output_type -> auth.v1.ListAPIKeysResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_api_key_proto_init() }
+func file_auth_v1_api_key_proto_init() {
+	if File_auth_v1_api_key_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_api_key_proto_rawDesc), len(file_auth_v1_api_key_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_auth_v1_api_key_proto_goTypes,
+		DependencyIndexes: file_auth_v1_api_key_proto_depIdxs,
+		MessageInfos:      file_auth_v1_api_key_proto_msgTypes,
+	}.Build()
+	File_auth_v1_api_key_proto = out.File
+	file_auth_v1_api_key_proto_goTypes = nil
+	file_auth_v1_api_key_proto_depIdxs = nil
+}
@@ -0,0 +1,296 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/audit.proto
+
+package authv1
+
+import (
+	v11 "erp.localhost/internal/infra/model/event/v1"
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuditEventFilter selects the events of an audit stream, the empty fields match every event
+type AuditEventFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User or API key that did the action
+	ActorId string `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	// Type of the resource the action was on, the target_type of the audit logs, e.g. user, role, token
+	ResourceType string `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	// Events stored at or after since, ignored when resuming from a cursor
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEventFilter) Reset() {
+	*x = AuditEventFilter{}
+	mi := &file_auth_v1_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEventFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEventFilter) ProtoMessage() {}
+
+func (x *AuditEventFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEventFilter.ProtoReflect.Descriptor instead.
+func (*AuditEventFilter) Descriptor() ([]byte, []int) {
+	return file_auth_v1_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditEventFilter) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEventFilter) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AuditEventFilter) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type StreamAuditEventsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Tenant whose events are streamed, the tenant of the identifier when empty
+	TargetTenantId string            `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	Filter         *AuditEventFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Cursor of the last event received, the stream resumes right after it
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAuditEventsRequest) Reset() {
+	*x = StreamAuditEventsRequest{}
+	mi := &file_auth_v1_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAuditEventsRequest) ProtoMessage() {}
+
+func (x *StreamAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamAuditEventsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *StreamAuditEventsRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *StreamAuditEventsRequest) GetFilter() *AuditEventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *StreamAuditEventsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// AuditEvent is a message of the StreamAuditEvents stream
+type AuditEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Log   *v11.AuditLog          `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	// Opaque position of the event, a stream started with it resumes after the event
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_auth_v1_audit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_audit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_auth_v1_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuditEvent) GetLog() *v11.AuditLog {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+var File_auth_v1_audit_proto protoreflect.FileDescriptor
+
+const file_auth_v1_audit_proto_rawDesc = "" +
+	"\n" +
+	"\x13auth/v1/audit.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x14event/v1/audit.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\x84\x01\n" +
+	"\x10AuditEventFilter\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12#\n" +
+	"\rresource_type\x18\x02 \x01(\tR\fresourceType\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\xe8\x01\n" +
+	"\x18StreamAuditEventsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x121\n" +
+	"\x06filter\x18\x03 \x01(\v2\x19.auth.v1.AuditEventFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"J\n" +
+	"\n" +
+	"AuditEvent\x12$\n" +
+	"\x03log\x18\x01 \x01(\v2\x12.event.v1.AuditLogR\x03log\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor2]\n" +
+	"\fAuditService\x12M\n" +
+	"\x11StreamAuditEvents\x12!.auth.v1.StreamAuditEventsRequest\x1a\x13.auth.v1.AuditEvent0\x01B3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+
+var (
+	file_auth_v1_audit_proto_rawDescOnce sync.Once
+	file_auth_v1_audit_proto_rawDescData []byte
+)
+
+func file_auth_v1_audit_proto_rawDescGZIP() []byte {
+	file_auth_v1_audit_proto_rawDescOnce.Do(func() {
+		file_auth_v1_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_audit_proto_rawDesc), len(file_auth_v1_audit_proto_rawDesc)))
+	})
+	return file_auth_v1_audit_proto_rawDescData
+}
+
+var file_auth_v1_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_auth_v1_audit_proto_goTypes = []any{
+	(*AuditEventFilter)(nil),         // 0: auth.v1.AuditEventFilter
+	(*StreamAuditEventsRequest)(nil), // 1: auth.v1.StreamAuditEventsRequest
+	(*AuditEvent)(nil),               // 2: auth.v1.AuditEvent
+	(*timestamppb.Timestamp)(nil),    // 3: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),        // 4: infra.v1.UserIdentifier
+	(*v11.AuditLog)(nil),             // 5: event.v1.AuditLog
+}
+var file_auth_v1_audit_proto_depIdxs = []int32{
+	3, // 0: auth.v1.AuditEventFilter.since:type_name -> google.protobuf.Timestamp
+	4, // 1: auth.v1.StreamAuditEventsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0, // 2: auth.v1.StreamAuditEventsRequest.filter:type_name -> auth.v1.AuditEventFilter
+	5, // 3: auth.v1.AuditEvent.log:type_name -> event.v1.AuditLog
+	1, // 4: auth.v1.AuditService.StreamAuditEvents:input_type -> auth.v1.StreamAuditEventsRequest
+	2, // 5: auth.v1.AuditService.StreamAuditEvents:output_type -> auth.v1.AuditEvent
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_audit_proto_init() }
+func file_auth_v1_audit_proto_init() {
+	if File_auth_v1_audit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_audit_proto_rawDesc), len(file_auth_v1_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_auth_v1_audit_proto_goTypes,
+		DependencyIndexes: file_auth_v1_audit_proto_depIdxs,
+		MessageInfos:      file_auth_v1_audit_proto_msgTypes,
+	}.Build()
+	File_auth_v1_audit_proto = out.File
+	file_auth_v1_audit_proto_goTypes = nil
+	file_auth_v1_audit_proto_depIdxs = nil
+}
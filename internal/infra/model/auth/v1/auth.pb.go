@@ -8,6 +8,7 @@ package authv1
 
 import (
 	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -32,8 +33,11 @@ type LoginRequest struct {
 	//
 	//	*LoginRequest_Email
 	//	*LoginRequest_Username
-	AccountId     isLoginRequest_AccountId `protobuf_oneof:"account_id"`
-	Password      string                   `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	AccountId isLoginRequest_AccountId `protobuf_oneof:"account_id"`
+	Password  string                   `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	// TOTP or recovery code, required when the user has MFA enabled. For users without MFA, the code emailed when
+	// a suspicious login needs a step-up
+	MfaCode       string `protobuf:"bytes,5,opt,name=mfa_code,json=mfaCode,proto3" json:"mfa_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -107,6 +111,13 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequest) GetMfaCode() string {
+	if x != nil {
+		return x.MfaCode
+	}
+	return ""
+}
+
 type isLoginRequest_AccountId interface {
 	isLoginRequest_AccountId()
 }
@@ -125,7 +136,7 @@ func (*LoginRequest_Username) isLoginRequest_AccountId() {}
 
 type LogoutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
 	Tokens        *Tokens                `protobuf:"bytes,2,opt,name=tokens,proto3" json:"tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -325,9 +336,11 @@ func (x *ExpiresIn) GetRefreshToken() int64 {
 }
 
 type TokensResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tokens        *Tokens                `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"`
-	ExpiresIn     *ExpiresIn             `protobuf:"bytes,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Tokens    *Tokens                `protobuf:"bytes,1,opt,name=tokens,proto3" json:"tokens,omitempty"`
+	ExpiresIn *ExpiresIn             `protobuf:"bytes,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	// Set on logins
+	Risk          *LoginRisk `protobuf:"bytes,3,opt,name=risk,proto3" json:"risk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -376,6 +389,68 @@ func (x *TokensResponse) GetExpiresIn() *ExpiresIn {
 	return nil
 }
 
+func (x *TokensResponse) GetRisk() *LoginRisk {
+	if x != nil {
+		return x.Risk
+	}
+	return nil
+}
+
+// Risk of a login against the devices and networks the user logged in from before
+type LoginRisk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 to 100, logins from known devices score 0
+	Score int32 `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
+	// new_device, new_network
+	Reasons       []string `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRisk) Reset() {
+	*x = LoginRisk{}
+	mi := &file_auth_v1_auth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRisk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRisk) ProtoMessage() {}
+
+func (x *LoginRisk) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRisk.ProtoReflect.Descriptor instead.
+func (*LoginRisk) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LoginRisk) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *LoginRisk) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
 type VerifyTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -385,7 +460,7 @@ type VerifyTokenRequest struct {
 
 func (x *VerifyTokenRequest) Reset() {
 	*x = VerifyTokenRequest{}
-	mi := &file_auth_v1_auth_proto_msgTypes[6]
+	mi := &file_auth_v1_auth_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -397,7 +472,7 @@ func (x *VerifyTokenRequest) String() string {
 func (*VerifyTokenRequest) ProtoMessage() {}
 
 func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_auth_proto_msgTypes[6]
+	mi := &file_auth_v1_auth_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +485,7 @@ func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyTokenRequest.ProtoReflect.Descriptor instead.
 func (*VerifyTokenRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_auth_proto_rawDescGZIP(), []int{6}
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *VerifyTokenRequest) GetToken() string {
@@ -429,7 +504,7 @@ type VerifyTokenResponse struct {
 
 func (x *VerifyTokenResponse) Reset() {
 	*x = VerifyTokenResponse{}
-	mi := &file_auth_v1_auth_proto_msgTypes[7]
+	mi := &file_auth_v1_auth_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -441,7 +516,7 @@ func (x *VerifyTokenResponse) String() string {
 func (*VerifyTokenResponse) ProtoMessage() {}
 
 func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_auth_proto_msgTypes[7]
+	mi := &file_auth_v1_auth_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -454,7 +529,7 @@ func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyTokenResponse.ProtoReflect.Descriptor instead.
 func (*VerifyTokenResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_auth_proto_rawDescGZIP(), []int{7}
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VerifyTokenResponse) GetValid() bool {
@@ -466,7 +541,7 @@ func (x *VerifyTokenResponse) GetValid() bool {
 
 type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
 	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -474,7 +549,7 @@ type RefreshTokenRequest struct {
 
 func (x *RefreshTokenRequest) Reset() {
 	*x = RefreshTokenRequest{}
-	mi := &file_auth_v1_auth_proto_msgTypes[8]
+	mi := &file_auth_v1_auth_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +561,7 @@ func (x *RefreshTokenRequest) String() string {
 func (*RefreshTokenRequest) ProtoMessage() {}
 
 func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_auth_proto_msgTypes[8]
+	mi := &file_auth_v1_auth_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +574,7 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
 func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_auth_proto_rawDescGZIP(), []int{8}
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RefreshTokenRequest) GetIdentifier() *v1.UserIdentifier {
@@ -518,7 +593,7 @@ func (x *RefreshTokenRequest) GetRefreshToken() string {
 
 type RevokeTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
 	RevokedBy     string                 `protobuf:"bytes,2,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
 	Tokens        *Tokens                `protobuf:"bytes,3,opt,name=tokens,proto3" json:"tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -527,7 +602,7 @@ type RevokeTokenRequest struct {
 
 func (x *RevokeTokenRequest) Reset() {
 	*x = RevokeTokenRequest{}
-	mi := &file_auth_v1_auth_proto_msgTypes[9]
+	mi := &file_auth_v1_auth_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -539,7 +614,7 @@ func (x *RevokeTokenRequest) String() string {
 func (*RevokeTokenRequest) ProtoMessage() {}
 
 func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_auth_proto_msgTypes[9]
+	mi := &file_auth_v1_auth_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,7 +627,7 @@ func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeTokenRequest.ProtoReflect.Descriptor instead.
 func (*RevokeTokenRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_auth_proto_rawDescGZIP(), []int{9}
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RevokeTokenRequest) GetIdentifier() *v1.UserIdentifier {
@@ -585,7 +660,7 @@ type RevokeTokenResponse struct {
 
 func (x *RevokeTokenResponse) Reset() {
 	*x = RevokeTokenResponse{}
-	mi := &file_auth_v1_auth_proto_msgTypes[10]
+	mi := &file_auth_v1_auth_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -597,7 +672,7 @@ func (x *RevokeTokenResponse) String() string {
 func (*RevokeTokenResponse) ProtoMessage() {}
 
 func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_auth_proto_msgTypes[10]
+	mi := &file_auth_v1_auth_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +685,7 @@ func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeTokenResponse.ProtoReflect.Descriptor instead.
 func (*RevokeTokenResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_auth_proto_rawDescGZIP(), []int{10}
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RevokeTokenResponse) GetRevoked() bool {
@@ -623,7 +698,7 @@ func (x *RevokeTokenResponse) GetRevoked() bool {
 // Tenant-level token management
 type RevokeAllTenantTokensRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
 	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -631,7 +706,7 @@ type RevokeAllTenantTokensRequest struct {
 
 func (x *RevokeAllTenantTokensRequest) Reset() {
 	*x = RevokeAllTenantTokensRequest{}
-	mi := &file_auth_v1_auth_proto_msgTypes[11]
+	mi := &file_auth_v1_auth_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -643,7 +718,7 @@ func (x *RevokeAllTenantTokensRequest) String() string {
 func (*RevokeAllTenantTokensRequest) ProtoMessage() {}
 
 func (x *RevokeAllTenantTokensRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_auth_proto_msgTypes[11]
+	mi := &file_auth_v1_auth_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,7 +731,7 @@ func (x *RevokeAllTenantTokensRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeAllTenantTokensRequest.ProtoReflect.Descriptor instead.
 func (*RevokeAllTenantTokensRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_auth_proto_rawDescGZIP(), []int{11}
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RevokeAllTenantTokensRequest) GetIdentifier() *v1.UserIdentifier {
@@ -684,7 +759,7 @@ type RevokeAllTenantTokensResponse struct {
 
 func (x *RevokeAllTenantTokensResponse) Reset() {
 	*x = RevokeAllTenantTokensResponse{}
-	mi := &file_auth_v1_auth_proto_msgTypes[12]
+	mi := &file_auth_v1_auth_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -696,7 +771,7 @@ func (x *RevokeAllTenantTokensResponse) String() string {
 func (*RevokeAllTenantTokensResponse) ProtoMessage() {}
 
 func (x *RevokeAllTenantTokensResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_auth_proto_msgTypes[12]
+	mi := &file_auth_v1_auth_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,7 +784,7 @@ func (x *RevokeAllTenantTokensResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeAllTenantTokensResponse.ProtoReflect.Descriptor instead.
 func (*RevokeAllTenantTokensResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_auth_proto_rawDescGZIP(), []int{12}
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RevokeAllTenantTokensResponse) GetRevoked() bool {
@@ -733,69 +808,1638 @@ func (x *RevokeAllTenantTokensResponse) GetRefreshTokensRevoked() int32 {
 	return 0
 }
 
-var File_auth_v1_auth_proto protoreflect.FileDescriptor
+// =============================================================================
+// MFA (TOTP)
+// =============================================================================
+type EnrollMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_auth_v1_auth_proto_rawDesc = "" +
-	"\n" +
-	"\x12auth/v1/auth.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\"\x8b\x01\n" +
-	"\fLoginRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
-	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x12\x1c\n" +
-	"\busername\x18\x03 \x01(\tH\x00R\busername\x12\x1a\n" +
-	"\bpassword\x18\x04 \x01(\tR\bpasswordB\f\n" +
-	"\n" +
-	"account_id\"r\n" +
-	"\rLogoutRequest\x128\n" +
-	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12'\n" +
-	"\x06tokens\x18\x02 \x01(\v2\x0f.auth.v1.TokensR\x06tokens\"*\n" +
-	"\x0eLogoutResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"C\n" +
-	"\x06Tokens\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"F\n" +
-	"\tExpiresIn\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\x03R\x05token\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\x03R\frefreshToken\"l\n" +
-	"\x0eTokensResponse\x12'\n" +
-	"\x06tokens\x18\x01 \x01(\v2\x0f.auth.v1.TokensR\x06tokens\x121\n" +
-	"\n" +
-	"expires_in\x18\x02 \x01(\v2\x12.auth.v1.ExpiresInR\texpiresIn\"*\n" +
-	"\x12VerifyTokenRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"+\n" +
-	"\x13VerifyTokenResponse\x12\x14\n" +
-	"\x05valid\x18\x01 \x01(\bR\x05valid\"t\n" +
-	"\x13RefreshTokenRequest\x128\n" +
-	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"\x96\x01\n" +
-	"\x12RevokeTokenRequest\x128\n" +
-	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12\x1d\n" +
-	"\n" +
-	"revoked_by\x18\x02 \x01(\tR\trevokedBy\x12'\n" +
-	"\x06tokens\x18\x03 \x01(\v2\x0f.auth.v1.TokensR\x06tokens\"/\n" +
-	"\x13RevokeTokenResponse\x12\x18\n" +
-	"\arevoked\x18\x01 \x01(\bR\arevoked\"\x82\x01\n" +
-	"\x1cRevokeAllTenantTokensRequest\x128\n" +
-	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12(\n" +
-	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\"\xa3\x01\n" +
-	"\x1dRevokeAllTenantTokensResponse\x12\x18\n" +
-	"\arevoked\x18\x01 \x01(\bR\arevoked\x122\n" +
-	"\x15access_tokens_revoked\x18\x02 \x01(\x05R\x13accessTokensRevoked\x124\n" +
-	"\x16refresh_tokens_revoked\x18\x03 \x01(\x05R\x14refreshTokensRevoked2\xc4\x03\n" +
-	"\vAuthService\x127\n" +
-	"\x05Login\x12\x15.auth.v1.LoginRequest\x1a\x17.auth.v1.TokensResponse\x129\n" +
-	"\x06Logout\x12\x16.auth.v1.LogoutRequest\x1a\x17.auth.v1.LogoutResponse\x12H\n" +
-	"\vVerifyToken\x12\x1b.auth.v1.VerifyTokenRequest\x1a\x1c.auth.v1.VerifyTokenResponse\x12E\n" +
-	"\fRefreshToken\x12\x1c.auth.v1.RefreshTokenRequest\x1a\x17.auth.v1.TokensResponse\x12H\n" +
-	"\vRevokeToken\x12\x1b.auth.v1.RevokeTokenRequest\x1a\x1c.auth.v1.RevokeTokenResponse\x12f\n" +
-	"\x15RevokeAllTenantTokens\x12%.auth.v1.RevokeAllTenantTokensRequest\x1a&.auth.v1.RevokeAllTenantTokensResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+func (x *EnrollMFARequest) Reset() {
+	*x = EnrollMFARequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollMFARequest) ProtoMessage() {}
+
+func (x *EnrollMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollMFARequest.ProtoReflect.Descriptor instead.
+func (*EnrollMFARequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EnrollMFARequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type EnrollMFAResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Secret string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// otpauth:// URI to be rendered as a QR code by the client
+	ProvisioningUri string `protobuf:"bytes,2,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollMFAResponse) Reset() {
+	*x = EnrollMFAResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollMFAResponse) ProtoMessage() {}
+
+func (x *EnrollMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollMFAResponse.ProtoReflect.Descriptor instead.
+func (*EnrollMFAResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EnrollMFAResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollMFAResponse) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+type VerifyMFAEnrollmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFAEnrollmentRequest) Reset() {
+	*x = VerifyMFAEnrollmentRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFAEnrollmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFAEnrollmentRequest) ProtoMessage() {}
+
+func (x *VerifyMFAEnrollmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFAEnrollmentRequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFAEnrollmentRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VerifyMFAEnrollmentRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *VerifyMFAEnrollmentRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyMFAEnrollmentResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Shown once, only hashes are persisted
+	RecoveryCodes []string `protobuf:"bytes,2,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFAEnrollmentResponse) Reset() {
+	*x = VerifyMFAEnrollmentResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFAEnrollmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFAEnrollmentResponse) ProtoMessage() {}
+
+func (x *VerifyMFAEnrollmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFAEnrollmentResponse.ProtoReflect.Descriptor instead.
+func (*VerifyMFAEnrollmentResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VerifyMFAEnrollmentResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *VerifyMFAEnrollmentResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFARequest) Reset() {
+	*x = DisableMFARequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFARequest) ProtoMessage() {}
+
+func (x *DisableMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFARequest.ProtoReflect.Descriptor instead.
+func (*DisableMFARequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DisableMFARequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DisableMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disabled      bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableMFAResponse) Reset() {
+	*x = DisableMFAResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableMFAResponse) ProtoMessage() {}
+
+func (x *DisableMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableMFAResponse.ProtoReflect.Descriptor instead.
+func (*DisableMFAResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DisableMFAResponse) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+// =============================================================================
+// Federated login (OIDC)
+// =============================================================================
+type GetOIDCAuthURLRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Provider string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Set to link the provider account to this user instead of logging in
+	Identifier    *v1.UserIdentifier `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOIDCAuthURLRequest) Reset() {
+	*x = GetOIDCAuthURLRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOIDCAuthURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOIDCAuthURLRequest) ProtoMessage() {}
+
+func (x *GetOIDCAuthURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOIDCAuthURLRequest.ProtoReflect.Descriptor instead.
+func (*GetOIDCAuthURLRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetOIDCAuthURLRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetOIDCAuthURLRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GetOIDCAuthURLRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type GetOIDCAuthURLResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Provider login page the user is redirected to
+	AuthUrl       string `protobuf:"bytes,1,opt,name=auth_url,json=authUrl,proto3" json:"auth_url,omitempty"`
+	State         string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOIDCAuthURLResponse) Reset() {
+	*x = GetOIDCAuthURLResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOIDCAuthURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOIDCAuthURLResponse) ProtoMessage() {}
+
+func (x *GetOIDCAuthURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOIDCAuthURLResponse.ProtoReflect.Descriptor instead.
+func (*GetOIDCAuthURLResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetOIDCAuthURLResponse) GetAuthUrl() string {
+	if x != nil {
+		return x.AuthUrl
+	}
+	return ""
+}
+
+func (x *GetOIDCAuthURLResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type LoginWithOIDCRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// state and code returned by the provider on the redirect URL
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Code  string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	// TOTP or recovery code, required when the user has MFA enabled
+	MfaCode       string `protobuf:"bytes,4,opt,name=mfa_code,json=mfaCode,proto3" json:"mfa_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithOIDCRequest) Reset() {
+	*x = LoginWithOIDCRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithOIDCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithOIDCRequest) ProtoMessage() {}
+
+func (x *LoginWithOIDCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithOIDCRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithOIDCRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LoginWithOIDCRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *LoginWithOIDCRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *LoginWithOIDCRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LoginWithOIDCRequest) GetMfaCode() string {
+	if x != nil {
+		return x.MfaCode
+	}
+	return ""
+}
+
+type LinkExternalIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkExternalIdentityRequest) Reset() {
+	*x = LinkExternalIdentityRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkExternalIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkExternalIdentityRequest) ProtoMessage() {}
+
+func (x *LinkExternalIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkExternalIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkExternalIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LinkExternalIdentityRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *LinkExternalIdentityRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *LinkExternalIdentityRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type LinkExternalIdentityResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ExternalIdentity *ExternalIdentity      `protobuf:"bytes,1,opt,name=external_identity,json=externalIdentity,proto3" json:"external_identity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LinkExternalIdentityResponse) Reset() {
+	*x = LinkExternalIdentityResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkExternalIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkExternalIdentityResponse) ProtoMessage() {}
+
+func (x *LinkExternalIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkExternalIdentityResponse.ProtoReflect.Descriptor instead.
+func (*LinkExternalIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *LinkExternalIdentityResponse) GetExternalIdentity() *ExternalIdentity {
+	if x != nil {
+		return x.ExternalIdentity
+	}
+	return nil
+}
+
+type UnlinkExternalIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkExternalIdentityRequest) Reset() {
+	*x = UnlinkExternalIdentityRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkExternalIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkExternalIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkExternalIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkExternalIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkExternalIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnlinkExternalIdentityRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UnlinkExternalIdentityRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type UnlinkExternalIdentityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Unlinked      bool                   `protobuf:"varint,1,opt,name=unlinked,proto3" json:"unlinked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkExternalIdentityResponse) Reset() {
+	*x = UnlinkExternalIdentityResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkExternalIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkExternalIdentityResponse) ProtoMessage() {}
+
+func (x *UnlinkExternalIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkExternalIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkExternalIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnlinkExternalIdentityResponse) GetUnlinked() bool {
+	if x != nil {
+		return x.Unlinked
+	}
+	return false
+}
+
+// =============================================================================
+// Federated login (SAML)
+// =============================================================================
+type GetSAMLConfigRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSAMLConfigRequest) Reset() {
+	*x = GetSAMLConfigRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSAMLConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSAMLConfigRequest) ProtoMessage() {}
+
+func (x *GetSAMLConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSAMLConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetSAMLConfigRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetSAMLConfigRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetSAMLConfigRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+type SetSAMLConfigRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	Settings       *SAMLSettings          `protobuf:"bytes,3,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetSAMLConfigRequest) Reset() {
+	*x = SetSAMLConfigRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSAMLConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSAMLConfigRequest) ProtoMessage() {}
+
+func (x *SetSAMLConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSAMLConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetSAMLConfigRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetSAMLConfigRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SetSAMLConfigRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *SetSAMLConfigRequest) GetSettings() *SAMLSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SAMLConfigResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Settings *SAMLSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	// Config service version of the settings, 0 when SAML was never configured
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Values to register at the IdP
+	SpEntityId    string `protobuf:"bytes,3,opt,name=sp_entity_id,json=spEntityId,proto3" json:"sp_entity_id,omitempty"`
+	AcsUrl        string `protobuf:"bytes,4,opt,name=acs_url,json=acsUrl,proto3" json:"acs_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SAMLConfigResponse) Reset() {
+	*x = SAMLConfigResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAMLConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAMLConfigResponse) ProtoMessage() {}
+
+func (x *SAMLConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAMLConfigResponse.ProtoReflect.Descriptor instead.
+func (*SAMLConfigResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SAMLConfigResponse) GetSettings() *SAMLSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *SAMLConfigResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SAMLConfigResponse) GetSpEntityId() string {
+	if x != nil {
+		return x.SpEntityId
+	}
+	return ""
+}
+
+func (x *SAMLConfigResponse) GetAcsUrl() string {
+	if x != nil {
+		return x.AcsUrl
+	}
+	return ""
+}
+
+type LoginWithSAMLRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Base64 SAMLResponse posted by the IdP to the ACS URL
+	SamlResponse string `protobuf:"bytes,2,opt,name=saml_response,json=samlResponse,proto3" json:"saml_response,omitempty"`
+	// TOTP or recovery code, required when the user has MFA enabled
+	MfaCode       string `protobuf:"bytes,3,opt,name=mfa_code,json=mfaCode,proto3" json:"mfa_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginWithSAMLRequest) Reset() {
+	*x = LoginWithSAMLRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginWithSAMLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginWithSAMLRequest) ProtoMessage() {}
+
+func (x *LoginWithSAMLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginWithSAMLRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithSAMLRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LoginWithSAMLRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *LoginWithSAMLRequest) GetSamlResponse() string {
+	if x != nil {
+		return x.SamlResponse
+	}
+	return ""
+}
+
+func (x *LoginWithSAMLRequest) GetMfaCode() string {
+	if x != nil {
+		return x.MfaCode
+	}
+	return ""
+}
+
+// =============================================================================
+// Magic link login
+// =============================================================================
+type RequestLoginLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestLoginLinkRequest) Reset() {
+	*x = RequestLoginLinkRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLoginLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginLinkRequest) ProtoMessage() {}
+
+func (x *RequestLoginLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestLoginLinkRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RequestLoginLinkRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RequestLoginLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestLoginLinkResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same message whether or not a link was sent, so the response does not tell which emails have an account
+	Message       string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestLoginLinkResponse) Reset() {
+	*x = RequestLoginLinkResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLoginLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginLinkResponse) ProtoMessage() {}
+
+func (x *RequestLoginLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginLinkResponse.ProtoReflect.Descriptor instead.
+func (*RequestLoginLinkResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RequestLoginLinkResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CompleteLoginLinkRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Token of the link emailed by RequestLoginLink
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// TOTP or recovery code, required when the user has MFA enabled
+	MfaCode       string `protobuf:"bytes,3,opt,name=mfa_code,json=mfaCode,proto3" json:"mfa_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteLoginLinkRequest) Reset() {
+	*x = CompleteLoginLinkRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteLoginLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteLoginLinkRequest) ProtoMessage() {}
+
+func (x *CompleteLoginLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteLoginLinkRequest.ProtoReflect.Descriptor instead.
+func (*CompleteLoginLinkRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CompleteLoginLinkRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CompleteLoginLinkRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CompleteLoginLinkRequest) GetMfaCode() string {
+	if x != nil {
+		return x.MfaCode
+	}
+	return ""
+}
+
+// =============================================================================
+// Token policy
+// =============================================================================
+type GetTokenPolicyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetTokenPolicyRequest) Reset() {
+	*x = GetTokenPolicyRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenPolicyRequest) ProtoMessage() {}
+
+func (x *GetTokenPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetTokenPolicyRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetTokenPolicyRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+type SetTokenPolicyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	Policy         *TokenPolicy           `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetTokenPolicyRequest) Reset() {
+	*x = SetTokenPolicyRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenPolicyRequest) ProtoMessage() {}
+
+func (x *SetTokenPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetTokenPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetTokenPolicyRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SetTokenPolicyRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *SetTokenPolicyRequest) GetPolicy() *TokenPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type TokenPolicyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Policy stored for the tenant, unset values use the global defaults
+	Policy *TokenPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Policy applied to the tokens of the tenant
+	EffectivePolicy *TokenPolicy `protobuf:"bytes,2,opt,name=effective_policy,json=effectivePolicy,proto3" json:"effective_policy,omitempty"`
+	// Config service version of the policy, 0 when the tenant never set one
+	Version       int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenPolicyResponse) Reset() {
+	*x = TokenPolicyResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenPolicyResponse) ProtoMessage() {}
+
+func (x *TokenPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenPolicyResponse.ProtoReflect.Descriptor instead.
+func (*TokenPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TokenPolicyResponse) GetPolicy() *TokenPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *TokenPolicyResponse) GetEffectivePolicy() *TokenPolicy {
+	if x != nil {
+		return x.EffectivePolicy
+	}
+	return nil
+}
+
+func (x *TokenPolicyResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// =============================================================================
+// Impersonation
+// =============================================================================
+type ImpersonateRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// User of the tenant of identifier to act as
+	AccountId string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Why the user is impersonated, kept in the audit log
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateRequest) Reset() {
+	*x = ImpersonateRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateRequest) ProtoMessage() {}
+
+func (x *ImpersonateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ImpersonateRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ImpersonateRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ImpersonateRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type StopImpersonationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopImpersonationRequest) Reset() {
+	*x = StopImpersonationRequest{}
+	mi := &file_auth_v1_auth_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopImpersonationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopImpersonationRequest) ProtoMessage() {}
+
+func (x *StopImpersonationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopImpersonationRequest.ProtoReflect.Descriptor instead.
+func (*StopImpersonationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StopImpersonationRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *StopImpersonationRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type StopImpersonationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stopped       bool                   `protobuf:"varint,1,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopImpersonationResponse) Reset() {
+	*x = StopImpersonationResponse{}
+	mi := &file_auth_v1_auth_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopImpersonationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopImpersonationResponse) ProtoMessage() {}
+
+func (x *StopImpersonationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopImpersonationResponse.ProtoReflect.Descriptor instead.
+func (*StopImpersonationResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StopImpersonationResponse) GetStopped() bool {
+	if x != nil {
+		return x.Stopped
+	}
+	return false
+}
+
+var File_auth_v1_auth_proto protoreflect.FileDescriptor
+
+const file_auth_v1_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x12auth/v1/auth.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x12auth/v1/user.proto\x1a\x12auth/v1/saml.proto\x1a\x1aauth/v1/token_policy.proto\x1a\x13tagger/tagger.proto\"\xa6\x01\n" +
+	"\fLoginRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x16\n" +
+	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x12\x1c\n" +
+	"\busername\x18\x03 \x01(\tH\x00R\busername\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12\x19\n" +
+	"\bmfa_code\x18\x05 \x01(\tR\amfaCodeB\f\n" +
+	"\n" +
+	"account_id\"\x91\x01\n" +
+	"\rLogoutRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12'\n" +
+	"\x06tokens\x18\x02 \x01(\v2\x0f.auth.v1.TokensR\x06tokens\"*\n" +
+	"\x0eLogoutResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"C\n" +
+	"\x06Tokens\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"F\n" +
+	"\tExpiresIn\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\x03R\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\x03R\frefreshToken\"\x94\x01\n" +
+	"\x0eTokensResponse\x12'\n" +
+	"\x06tokens\x18\x01 \x01(\v2\x0f.auth.v1.TokensR\x06tokens\x121\n" +
+	"\n" +
+	"expires_in\x18\x02 \x01(\v2\x12.auth.v1.ExpiresInR\texpiresIn\x12&\n" +
+	"\x04risk\x18\x03 \x01(\v2\x12.auth.v1.LoginRiskR\x04risk\";\n" +
+	"\tLoginRisk\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x05R\x05score\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\"*\n" +
+	"\x12VerifyTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"+\n" +
+	"\x13VerifyTokenResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\"\x93\x01\n" +
+	"\x13RefreshTokenRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"\xb5\x01\n" +
+	"\x12RevokeTokenRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x1d\n" +
+	"\n" +
+	"revoked_by\x18\x02 \x01(\tR\trevokedBy\x12'\n" +
+	"\x06tokens\x18\x03 \x01(\v2\x0f.auth.v1.TokensR\x06tokens\"/\n" +
+	"\x13RevokeTokenResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\bR\arevoked\"\xa1\x01\n" +
+	"\x1cRevokeAllTenantTokensRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\"\xa3\x01\n" +
+	"\x1dRevokeAllTenantTokensResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\bR\arevoked\x122\n" +
+	"\x15access_tokens_revoked\x18\x02 \x01(\x05R\x13accessTokensRevoked\x124\n" +
+	"\x16refresh_tokens_revoked\x18\x03 \x01(\x05R\x14refreshTokensRevoked\"k\n" +
+	"\x10EnrollMFARequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"V\n" +
+	"\x11EnrollMFAResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x02 \x01(\tR\x0fprovisioningUri\"\x89\x01\n" +
+	"\x1aVerifyMFAEnrollmentRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"^\n" +
+	"\x1bVerifyMFAEnrollmentResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12%\n" +
+	"\x0erecovery_codes\x18\x02 \x03(\tR\rrecoveryCodes\"\x80\x01\n" +
+	"\x11DisableMFARequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"0\n" +
+	"\x12DisableMFAResponse\x12\x1a\n" +
+	"\bdisabled\x18\x01 \x01(\bR\bdisabled\"\xa0\x01\n" +
+	"\x15GetOIDCAuthURLRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12N\n" +
+	"\n" +
+	"identifier\x18\x03 \x01(\v2\x18.infra.v1.UserIdentifierB\x14\x9a\x84\x9e\x03\x0fvalidate:\"dive\"R\n" +
+	"identifier\"I\n" +
+	"\x16GetOIDCAuthURLResponse\x12\x19\n" +
+	"\bauth_url\x18\x01 \x01(\tR\aauthUrl\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"x\n" +
+	"\x14LoginWithOIDCRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x19\n" +
+	"\bmfa_code\x18\x04 \x01(\tR\amfaCode\"\xa0\x01\n" +
+	"\x1bLinkExternalIdentityRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\"f\n" +
+	"\x1cLinkExternalIdentityResponse\x12F\n" +
+	"\x11external_identity\x18\x01 \x01(\v2\x19.auth.v1.ExternalIdentityR\x10externalIdentity\"\x94\x01\n" +
+	"\x1dUnlinkExternalIdentityRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\"<\n" +
+	"\x1eUnlinkExternalIdentityResponse\x12\x1a\n" +
+	"\bunlinked\x18\x01 \x01(\bR\bunlinked\"\x99\x01\n" +
+	"\x14GetSAMLConfigRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\"\xcc\x01\n" +
+	"\x14SetSAMLConfigRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x121\n" +
+	"\bsettings\x18\x03 \x01(\v2\x15.auth.v1.SAMLSettingsR\bsettings\"\x9c\x01\n" +
+	"\x12SAMLConfigResponse\x121\n" +
+	"\bsettings\x18\x01 \x01(\v2\x15.auth.v1.SAMLSettingsR\bsettings\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12 \n" +
+	"\fsp_entity_id\x18\x03 \x01(\tR\n" +
+	"spEntityId\x12\x17\n" +
+	"\aacs_url\x18\x04 \x01(\tR\x06acsUrl\"s\n" +
+	"\x14LoginWithSAMLRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12#\n" +
+	"\rsaml_response\x18\x02 \x01(\tR\fsamlResponse\x12\x19\n" +
+	"\bmfa_code\x18\x03 \x01(\tR\amfaCode\"L\n" +
+	"\x17RequestLoginLinkRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"4\n" +
+	"\x18RequestLoginLinkResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"h\n" +
+	"\x18CompleteLoginLinkRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x19\n" +
+	"\bmfa_code\x18\x03 \x01(\tR\amfaCode\"\x9a\x01\n" +
+	"\x15GetTokenPolicyRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\"\xc8\x01\n" +
+	"\x15SetTokenPolicyRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12,\n" +
+	"\x06policy\x18\x03 \x01(\v2\x14.auth.v1.TokenPolicyR\x06policy\"\x9e\x01\n" +
+	"\x13TokenPolicyResponse\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.auth.v1.TokenPolicyR\x06policy\x12?\n" +
+	"\x10effective_policy\x18\x02 \x01(\v2\x14.auth.v1.TokenPolicyR\x0feffectivePolicy\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"\xa4\x01\n" +
+	"\x12ImpersonateRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x92\x01\n" +
+	"\x18StopImpersonationRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\"5\n" +
+	"\x19StopImpersonationResponse\x12\x18\n" +
+	"\astopped\x18\x01 \x01(\bR\astopped2\xeb\r\n" +
+	"\vAuthService\x127\n" +
+	"\x05Login\x12\x15.auth.v1.LoginRequest\x1a\x17.auth.v1.TokensResponse\x129\n" +
+	"\x06Logout\x12\x16.auth.v1.LogoutRequest\x1a\x17.auth.v1.LogoutResponse\x12H\n" +
+	"\vVerifyToken\x12\x1b.auth.v1.VerifyTokenRequest\x1a\x1c.auth.v1.VerifyTokenResponse\x12E\n" +
+	"\fRefreshToken\x12\x1c.auth.v1.RefreshTokenRequest\x1a\x17.auth.v1.TokensResponse\x12H\n" +
+	"\vRevokeToken\x12\x1b.auth.v1.RevokeTokenRequest\x1a\x1c.auth.v1.RevokeTokenResponse\x12f\n" +
+	"\x15RevokeAllTenantTokens\x12%.auth.v1.RevokeAllTenantTokensRequest\x1a&.auth.v1.RevokeAllTenantTokensResponse\x12B\n" +
+	"\tEnrollMFA\x12\x19.auth.v1.EnrollMFARequest\x1a\x1a.auth.v1.EnrollMFAResponse\x12`\n" +
+	"\x13VerifyMFAEnrollment\x12#.auth.v1.VerifyMFAEnrollmentRequest\x1a$.auth.v1.VerifyMFAEnrollmentResponse\x12E\n" +
+	"\n" +
+	"DisableMFA\x12\x1a.auth.v1.DisableMFARequest\x1a\x1b.auth.v1.DisableMFAResponse\x12Q\n" +
+	"\x0eGetOIDCAuthURL\x12\x1e.auth.v1.GetOIDCAuthURLRequest\x1a\x1f.auth.v1.GetOIDCAuthURLResponse\x12G\n" +
+	"\rLoginWithOIDC\x12\x1d.auth.v1.LoginWithOIDCRequest\x1a\x17.auth.v1.TokensResponse\x12c\n" +
+	"\x14LinkExternalIdentity\x12$.auth.v1.LinkExternalIdentityRequest\x1a%.auth.v1.LinkExternalIdentityResponse\x12i\n" +
+	"\x16UnlinkExternalIdentity\x12&.auth.v1.UnlinkExternalIdentityRequest\x1a'.auth.v1.UnlinkExternalIdentityResponse\x12K\n" +
+	"\rGetSAMLConfig\x12\x1d.auth.v1.GetSAMLConfigRequest\x1a\x1b.auth.v1.SAMLConfigResponse\x12K\n" +
+	"\rSetSAMLConfig\x12\x1d.auth.v1.SetSAMLConfigRequest\x1a\x1b.auth.v1.SAMLConfigResponse\x12G\n" +
+	"\rLoginWithSAML\x12\x1d.auth.v1.LoginWithSAMLRequest\x1a\x17.auth.v1.TokensResponse\x12W\n" +
+	"\x10RequestLoginLink\x12 .auth.v1.RequestLoginLinkRequest\x1a!.auth.v1.RequestLoginLinkResponse\x12O\n" +
+	"\x11CompleteLoginLink\x12!.auth.v1.CompleteLoginLinkRequest\x1a\x17.auth.v1.TokensResponse\x12N\n" +
+	"\x0eGetTokenPolicy\x12\x1e.auth.v1.GetTokenPolicyRequest\x1a\x1c.auth.v1.TokenPolicyResponse\x12N\n" +
+	"\x0eSetTokenPolicy\x12\x1e.auth.v1.SetTokenPolicyRequest\x1a\x1c.auth.v1.TokenPolicyResponse\x12C\n" +
+	"\vImpersonate\x12\x1b.auth.v1.ImpersonateRequest\x1a\x17.auth.v1.TokensResponse\x12Z\n" +
+	"\x11StopImpersonation\x12!.auth.v1.StopImpersonationRequest\x1a\".auth.v1.StopImpersonationResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
 
 var (
 	file_auth_v1_auth_proto_rawDescOnce sync.Once
@@ -809,49 +2453,130 @@ func file_auth_v1_auth_proto_rawDescGZIP() []byte {
 	return file_auth_v1_auth_proto_rawDescData
 }
 
-var file_auth_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_auth_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_auth_v1_auth_proto_goTypes = []any{
-	(*LoginRequest)(nil),                  // 0: auth.v1.LoginRequest
-	(*LogoutRequest)(nil),                 // 1: auth.v1.LogoutRequest
-	(*LogoutResponse)(nil),                // 2: auth.v1.LogoutResponse
-	(*Tokens)(nil),                        // 3: auth.v1.Tokens
-	(*ExpiresIn)(nil),                     // 4: auth.v1.ExpiresIn
-	(*TokensResponse)(nil),                // 5: auth.v1.TokensResponse
-	(*VerifyTokenRequest)(nil),            // 6: auth.v1.VerifyTokenRequest
-	(*VerifyTokenResponse)(nil),           // 7: auth.v1.VerifyTokenResponse
-	(*RefreshTokenRequest)(nil),           // 8: auth.v1.RefreshTokenRequest
-	(*RevokeTokenRequest)(nil),            // 9: auth.v1.RevokeTokenRequest
-	(*RevokeTokenResponse)(nil),           // 10: auth.v1.RevokeTokenResponse
-	(*RevokeAllTenantTokensRequest)(nil),  // 11: auth.v1.RevokeAllTenantTokensRequest
-	(*RevokeAllTenantTokensResponse)(nil), // 12: auth.v1.RevokeAllTenantTokensResponse
-	(*v1.UserIdentifier)(nil),             // 13: infra.v1.UserIdentifier
+	(*LoginRequest)(nil),                   // 0: auth.v1.LoginRequest
+	(*LogoutRequest)(nil),                  // 1: auth.v1.LogoutRequest
+	(*LogoutResponse)(nil),                 // 2: auth.v1.LogoutResponse
+	(*Tokens)(nil),                         // 3: auth.v1.Tokens
+	(*ExpiresIn)(nil),                      // 4: auth.v1.ExpiresIn
+	(*TokensResponse)(nil),                 // 5: auth.v1.TokensResponse
+	(*LoginRisk)(nil),                      // 6: auth.v1.LoginRisk
+	(*VerifyTokenRequest)(nil),             // 7: auth.v1.VerifyTokenRequest
+	(*VerifyTokenResponse)(nil),            // 8: auth.v1.VerifyTokenResponse
+	(*RefreshTokenRequest)(nil),            // 9: auth.v1.RefreshTokenRequest
+	(*RevokeTokenRequest)(nil),             // 10: auth.v1.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),            // 11: auth.v1.RevokeTokenResponse
+	(*RevokeAllTenantTokensRequest)(nil),   // 12: auth.v1.RevokeAllTenantTokensRequest
+	(*RevokeAllTenantTokensResponse)(nil),  // 13: auth.v1.RevokeAllTenantTokensResponse
+	(*EnrollMFARequest)(nil),               // 14: auth.v1.EnrollMFARequest
+	(*EnrollMFAResponse)(nil),              // 15: auth.v1.EnrollMFAResponse
+	(*VerifyMFAEnrollmentRequest)(nil),     // 16: auth.v1.VerifyMFAEnrollmentRequest
+	(*VerifyMFAEnrollmentResponse)(nil),    // 17: auth.v1.VerifyMFAEnrollmentResponse
+	(*DisableMFARequest)(nil),              // 18: auth.v1.DisableMFARequest
+	(*DisableMFAResponse)(nil),             // 19: auth.v1.DisableMFAResponse
+	(*GetOIDCAuthURLRequest)(nil),          // 20: auth.v1.GetOIDCAuthURLRequest
+	(*GetOIDCAuthURLResponse)(nil),         // 21: auth.v1.GetOIDCAuthURLResponse
+	(*LoginWithOIDCRequest)(nil),           // 22: auth.v1.LoginWithOIDCRequest
+	(*LinkExternalIdentityRequest)(nil),    // 23: auth.v1.LinkExternalIdentityRequest
+	(*LinkExternalIdentityResponse)(nil),   // 24: auth.v1.LinkExternalIdentityResponse
+	(*UnlinkExternalIdentityRequest)(nil),  // 25: auth.v1.UnlinkExternalIdentityRequest
+	(*UnlinkExternalIdentityResponse)(nil), // 26: auth.v1.UnlinkExternalIdentityResponse
+	(*GetSAMLConfigRequest)(nil),           // 27: auth.v1.GetSAMLConfigRequest
+	(*SetSAMLConfigRequest)(nil),           // 28: auth.v1.SetSAMLConfigRequest
+	(*SAMLConfigResponse)(nil),             // 29: auth.v1.SAMLConfigResponse
+	(*LoginWithSAMLRequest)(nil),           // 30: auth.v1.LoginWithSAMLRequest
+	(*RequestLoginLinkRequest)(nil),        // 31: auth.v1.RequestLoginLinkRequest
+	(*RequestLoginLinkResponse)(nil),       // 32: auth.v1.RequestLoginLinkResponse
+	(*CompleteLoginLinkRequest)(nil),       // 33: auth.v1.CompleteLoginLinkRequest
+	(*GetTokenPolicyRequest)(nil),          // 34: auth.v1.GetTokenPolicyRequest
+	(*SetTokenPolicyRequest)(nil),          // 35: auth.v1.SetTokenPolicyRequest
+	(*TokenPolicyResponse)(nil),            // 36: auth.v1.TokenPolicyResponse
+	(*ImpersonateRequest)(nil),             // 37: auth.v1.ImpersonateRequest
+	(*StopImpersonationRequest)(nil),       // 38: auth.v1.StopImpersonationRequest
+	(*StopImpersonationResponse)(nil),      // 39: auth.v1.StopImpersonationResponse
+	(*v1.UserIdentifier)(nil),              // 40: infra.v1.UserIdentifier
+	(*ExternalIdentity)(nil),               // 41: auth.v1.ExternalIdentity
+	(*SAMLSettings)(nil),                   // 42: auth.v1.SAMLSettings
+	(*TokenPolicy)(nil),                    // 43: auth.v1.TokenPolicy
 }
 var file_auth_v1_auth_proto_depIdxs = []int32{
-	13, // 0: auth.v1.LogoutRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 0: auth.v1.LogoutRequest.identifier:type_name -> infra.v1.UserIdentifier
 	3,  // 1: auth.v1.LogoutRequest.tokens:type_name -> auth.v1.Tokens
 	3,  // 2: auth.v1.TokensResponse.tokens:type_name -> auth.v1.Tokens
 	4,  // 3: auth.v1.TokensResponse.expires_in:type_name -> auth.v1.ExpiresIn
-	13, // 4: auth.v1.RefreshTokenRequest.identifier:type_name -> infra.v1.UserIdentifier
-	13, // 5: auth.v1.RevokeTokenRequest.identifier:type_name -> infra.v1.UserIdentifier
-	3,  // 6: auth.v1.RevokeTokenRequest.tokens:type_name -> auth.v1.Tokens
-	13, // 7: auth.v1.RevokeAllTenantTokensRequest.identifier:type_name -> infra.v1.UserIdentifier
-	0,  // 8: auth.v1.AuthService.Login:input_type -> auth.v1.LoginRequest
-	1,  // 9: auth.v1.AuthService.Logout:input_type -> auth.v1.LogoutRequest
-	6,  // 10: auth.v1.AuthService.VerifyToken:input_type -> auth.v1.VerifyTokenRequest
-	8,  // 11: auth.v1.AuthService.RefreshToken:input_type -> auth.v1.RefreshTokenRequest
-	9,  // 12: auth.v1.AuthService.RevokeToken:input_type -> auth.v1.RevokeTokenRequest
-	11, // 13: auth.v1.AuthService.RevokeAllTenantTokens:input_type -> auth.v1.RevokeAllTenantTokensRequest
-	5,  // 14: auth.v1.AuthService.Login:output_type -> auth.v1.TokensResponse
-	2,  // 15: auth.v1.AuthService.Logout:output_type -> auth.v1.LogoutResponse
-	7,  // 16: auth.v1.AuthService.VerifyToken:output_type -> auth.v1.VerifyTokenResponse
-	5,  // 17: auth.v1.AuthService.RefreshToken:output_type -> auth.v1.TokensResponse
-	10, // 18: auth.v1.AuthService.RevokeToken:output_type -> auth.v1.RevokeTokenResponse
-	12, // 19: auth.v1.AuthService.RevokeAllTenantTokens:output_type -> auth.v1.RevokeAllTenantTokensResponse
-	14, // [14:20] is the sub-list for method output_type
-	8,  // [8:14] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	6,  // 4: auth.v1.TokensResponse.risk:type_name -> auth.v1.LoginRisk
+	40, // 5: auth.v1.RefreshTokenRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 6: auth.v1.RevokeTokenRequest.identifier:type_name -> infra.v1.UserIdentifier
+	3,  // 7: auth.v1.RevokeTokenRequest.tokens:type_name -> auth.v1.Tokens
+	40, // 8: auth.v1.RevokeAllTenantTokensRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 9: auth.v1.EnrollMFARequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 10: auth.v1.VerifyMFAEnrollmentRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 11: auth.v1.DisableMFARequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 12: auth.v1.GetOIDCAuthURLRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 13: auth.v1.LinkExternalIdentityRequest.identifier:type_name -> infra.v1.UserIdentifier
+	41, // 14: auth.v1.LinkExternalIdentityResponse.external_identity:type_name -> auth.v1.ExternalIdentity
+	40, // 15: auth.v1.UnlinkExternalIdentityRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 16: auth.v1.GetSAMLConfigRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 17: auth.v1.SetSAMLConfigRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 18: auth.v1.SetSAMLConfigRequest.settings:type_name -> auth.v1.SAMLSettings
+	42, // 19: auth.v1.SAMLConfigResponse.settings:type_name -> auth.v1.SAMLSettings
+	40, // 20: auth.v1.GetTokenPolicyRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 21: auth.v1.SetTokenPolicyRequest.identifier:type_name -> infra.v1.UserIdentifier
+	43, // 22: auth.v1.SetTokenPolicyRequest.policy:type_name -> auth.v1.TokenPolicy
+	43, // 23: auth.v1.TokenPolicyResponse.policy:type_name -> auth.v1.TokenPolicy
+	43, // 24: auth.v1.TokenPolicyResponse.effective_policy:type_name -> auth.v1.TokenPolicy
+	40, // 25: auth.v1.ImpersonateRequest.identifier:type_name -> infra.v1.UserIdentifier
+	40, // 26: auth.v1.StopImpersonationRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 27: auth.v1.AuthService.Login:input_type -> auth.v1.LoginRequest
+	1,  // 28: auth.v1.AuthService.Logout:input_type -> auth.v1.LogoutRequest
+	7,  // 29: auth.v1.AuthService.VerifyToken:input_type -> auth.v1.VerifyTokenRequest
+	9,  // 30: auth.v1.AuthService.RefreshToken:input_type -> auth.v1.RefreshTokenRequest
+	10, // 31: auth.v1.AuthService.RevokeToken:input_type -> auth.v1.RevokeTokenRequest
+	12, // 32: auth.v1.AuthService.RevokeAllTenantTokens:input_type -> auth.v1.RevokeAllTenantTokensRequest
+	14, // 33: auth.v1.AuthService.EnrollMFA:input_type -> auth.v1.EnrollMFARequest
+	16, // 34: auth.v1.AuthService.VerifyMFAEnrollment:input_type -> auth.v1.VerifyMFAEnrollmentRequest
+	18, // 35: auth.v1.AuthService.DisableMFA:input_type -> auth.v1.DisableMFARequest
+	20, // 36: auth.v1.AuthService.GetOIDCAuthURL:input_type -> auth.v1.GetOIDCAuthURLRequest
+	22, // 37: auth.v1.AuthService.LoginWithOIDC:input_type -> auth.v1.LoginWithOIDCRequest
+	23, // 38: auth.v1.AuthService.LinkExternalIdentity:input_type -> auth.v1.LinkExternalIdentityRequest
+	25, // 39: auth.v1.AuthService.UnlinkExternalIdentity:input_type -> auth.v1.UnlinkExternalIdentityRequest
+	27, // 40: auth.v1.AuthService.GetSAMLConfig:input_type -> auth.v1.GetSAMLConfigRequest
+	28, // 41: auth.v1.AuthService.SetSAMLConfig:input_type -> auth.v1.SetSAMLConfigRequest
+	30, // 42: auth.v1.AuthService.LoginWithSAML:input_type -> auth.v1.LoginWithSAMLRequest
+	31, // 43: auth.v1.AuthService.RequestLoginLink:input_type -> auth.v1.RequestLoginLinkRequest
+	33, // 44: auth.v1.AuthService.CompleteLoginLink:input_type -> auth.v1.CompleteLoginLinkRequest
+	34, // 45: auth.v1.AuthService.GetTokenPolicy:input_type -> auth.v1.GetTokenPolicyRequest
+	35, // 46: auth.v1.AuthService.SetTokenPolicy:input_type -> auth.v1.SetTokenPolicyRequest
+	37, // 47: auth.v1.AuthService.Impersonate:input_type -> auth.v1.ImpersonateRequest
+	38, // 48: auth.v1.AuthService.StopImpersonation:input_type -> auth.v1.StopImpersonationRequest
+	5,  // 49: auth.v1.AuthService.Login:output_type -> auth.v1.TokensResponse
+	2,  // 50: auth.v1.AuthService.Logout:output_type -> auth.v1.LogoutResponse
+	8,  // 51: auth.v1.AuthService.VerifyToken:output_type -> auth.v1.VerifyTokenResponse
+	5,  // 52: auth.v1.AuthService.RefreshToken:output_type -> auth.v1.TokensResponse
+	11, // 53: auth.v1.AuthService.RevokeToken:output_type -> auth.v1.RevokeTokenResponse
+	13, // 54: auth.v1.AuthService.RevokeAllTenantTokens:output_type -> auth.v1.RevokeAllTenantTokensResponse
+	15, // 55: auth.v1.AuthService.EnrollMFA:output_type -> auth.v1.EnrollMFAResponse
+	17, // 56: auth.v1.AuthService.VerifyMFAEnrollment:output_type -> auth.v1.VerifyMFAEnrollmentResponse
+	19, // 57: auth.v1.AuthService.DisableMFA:output_type -> auth.v1.DisableMFAResponse
+	21, // 58: auth.v1.AuthService.GetOIDCAuthURL:output_type -> auth.v1.GetOIDCAuthURLResponse
+	5,  // 59: auth.v1.AuthService.LoginWithOIDC:output_type -> auth.v1.TokensResponse
+	24, // 60: auth.v1.AuthService.LinkExternalIdentity:output_type -> auth.v1.LinkExternalIdentityResponse
+	26, // 61: auth.v1.AuthService.UnlinkExternalIdentity:output_type -> auth.v1.UnlinkExternalIdentityResponse
+	29, // 62: auth.v1.AuthService.GetSAMLConfig:output_type -> auth.v1.SAMLConfigResponse
+	29, // 63: auth.v1.AuthService.SetSAMLConfig:output_type -> auth.v1.SAMLConfigResponse
+	5,  // 64: auth.v1.AuthService.LoginWithSAML:output_type -> auth.v1.TokensResponse
+	32, // 65: auth.v1.AuthService.RequestLoginLink:output_type -> auth.v1.RequestLoginLinkResponse
+	5,  // 66: auth.v1.AuthService.CompleteLoginLink:output_type -> auth.v1.TokensResponse
+	36, // 67: auth.v1.AuthService.GetTokenPolicy:output_type -> auth.v1.TokenPolicyResponse
+	36, // 68: auth.v1.AuthService.SetTokenPolicy:output_type -> auth.v1.TokenPolicyResponse
+	5,  // 69: auth.v1.AuthService.Impersonate:output_type -> auth.v1.TokensResponse
+	39, // 70: auth.v1.AuthService.StopImpersonation:output_type -> auth.v1.StopImpersonationResponse
+	49, // [49:71] is the sub-list for method output_type
+	27, // [27:49] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_auth_proto_init() }
@@ -859,6 +2584,9 @@ func file_auth_v1_auth_proto_init() {
 	if File_auth_v1_auth_proto != nil {
 		return
 	}
+	file_auth_v1_user_proto_init()
+	file_auth_v1_saml_proto_init()
+	file_auth_v1_token_policy_proto_init()
 	file_auth_v1_auth_proto_msgTypes[0].OneofWrappers = []any{
 		(*LoginRequest_Email)(nil),
 		(*LoginRequest_Username)(nil),
@@ -869,7 +2597,7 @@ func file_auth_v1_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_auth_proto_rawDesc), len(file_auth_v1_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
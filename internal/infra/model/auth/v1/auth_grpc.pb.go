@@ -19,12 +19,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Login_FullMethodName                 = "/auth.v1.AuthService/Login"
-	AuthService_Logout_FullMethodName                = "/auth.v1.AuthService/Logout"
-	AuthService_VerifyToken_FullMethodName           = "/auth.v1.AuthService/VerifyToken"
-	AuthService_RefreshToken_FullMethodName          = "/auth.v1.AuthService/RefreshToken"
-	AuthService_RevokeToken_FullMethodName           = "/auth.v1.AuthService/RevokeToken"
-	AuthService_RevokeAllTenantTokens_FullMethodName = "/auth.v1.AuthService/RevokeAllTenantTokens"
+	AuthService_Login_FullMethodName                  = "/auth.v1.AuthService/Login"
+	AuthService_Logout_FullMethodName                 = "/auth.v1.AuthService/Logout"
+	AuthService_VerifyToken_FullMethodName            = "/auth.v1.AuthService/VerifyToken"
+	AuthService_RefreshToken_FullMethodName           = "/auth.v1.AuthService/RefreshToken"
+	AuthService_RevokeToken_FullMethodName            = "/auth.v1.AuthService/RevokeToken"
+	AuthService_RevokeAllTenantTokens_FullMethodName  = "/auth.v1.AuthService/RevokeAllTenantTokens"
+	AuthService_EnrollMFA_FullMethodName              = "/auth.v1.AuthService/EnrollMFA"
+	AuthService_VerifyMFAEnrollment_FullMethodName    = "/auth.v1.AuthService/VerifyMFAEnrollment"
+	AuthService_DisableMFA_FullMethodName             = "/auth.v1.AuthService/DisableMFA"
+	AuthService_GetOIDCAuthURL_FullMethodName         = "/auth.v1.AuthService/GetOIDCAuthURL"
+	AuthService_LoginWithOIDC_FullMethodName          = "/auth.v1.AuthService/LoginWithOIDC"
+	AuthService_LinkExternalIdentity_FullMethodName   = "/auth.v1.AuthService/LinkExternalIdentity"
+	AuthService_UnlinkExternalIdentity_FullMethodName = "/auth.v1.AuthService/UnlinkExternalIdentity"
+	AuthService_GetSAMLConfig_FullMethodName          = "/auth.v1.AuthService/GetSAMLConfig"
+	AuthService_SetSAMLConfig_FullMethodName          = "/auth.v1.AuthService/SetSAMLConfig"
+	AuthService_LoginWithSAML_FullMethodName          = "/auth.v1.AuthService/LoginWithSAML"
+	AuthService_RequestLoginLink_FullMethodName       = "/auth.v1.AuthService/RequestLoginLink"
+	AuthService_CompleteLoginLink_FullMethodName      = "/auth.v1.AuthService/CompleteLoginLink"
+	AuthService_GetTokenPolicy_FullMethodName         = "/auth.v1.AuthService/GetTokenPolicy"
+	AuthService_SetTokenPolicy_FullMethodName         = "/auth.v1.AuthService/SetTokenPolicy"
+	AuthService_Impersonate_FullMethodName            = "/auth.v1.AuthService/Impersonate"
+	AuthService_StopImpersonation_FullMethodName      = "/auth.v1.AuthService/StopImpersonation"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -40,6 +56,27 @@ type AuthServiceClient interface {
 	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error)
 	// Tenant-level token management
 	RevokeAllTenantTokens(ctx context.Context, in *RevokeAllTenantTokensRequest, opts ...grpc.CallOption) (*RevokeAllTenantTokensResponse, error)
+	// MFA enrollment and management
+	EnrollMFA(ctx context.Context, in *EnrollMFARequest, opts ...grpc.CallOption) (*EnrollMFAResponse, error)
+	VerifyMFAEnrollment(ctx context.Context, in *VerifyMFAEnrollmentRequest, opts ...grpc.CallOption) (*VerifyMFAEnrollmentResponse, error)
+	DisableMFA(ctx context.Context, in *DisableMFARequest, opts ...grpc.CallOption) (*DisableMFAResponse, error)
+	// Federated login and external identity linking
+	GetOIDCAuthURL(ctx context.Context, in *GetOIDCAuthURLRequest, opts ...grpc.CallOption) (*GetOIDCAuthURLResponse, error)
+	LoginWithOIDC(ctx context.Context, in *LoginWithOIDCRequest, opts ...grpc.CallOption) (*TokensResponse, error)
+	LinkExternalIdentity(ctx context.Context, in *LinkExternalIdentityRequest, opts ...grpc.CallOption) (*LinkExternalIdentityResponse, error)
+	UnlinkExternalIdentity(ctx context.Context, in *UnlinkExternalIdentityRequest, opts ...grpc.CallOption) (*UnlinkExternalIdentityResponse, error)
+	GetSAMLConfig(ctx context.Context, in *GetSAMLConfigRequest, opts ...grpc.CallOption) (*SAMLConfigResponse, error)
+	SetSAMLConfig(ctx context.Context, in *SetSAMLConfigRequest, opts ...grpc.CallOption) (*SAMLConfigResponse, error)
+	LoginWithSAML(ctx context.Context, in *LoginWithSAMLRequest, opts ...grpc.CallOption) (*TokensResponse, error)
+	// Password-less login with a single use link emailed to the user, when enabled for the tenant
+	RequestLoginLink(ctx context.Context, in *RequestLoginLinkRequest, opts ...grpc.CallOption) (*RequestLoginLinkResponse, error)
+	CompleteLoginLink(ctx context.Context, in *CompleteLoginLinkRequest, opts ...grpc.CallOption) (*TokensResponse, error)
+	// Tenant token policy
+	GetTokenPolicy(ctx context.Context, in *GetTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicyResponse, error)
+	SetTokenPolicy(ctx context.Context, in *SetTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicyResponse, error)
+	// Impersonation, the access token acts as another user and names the impersonating user in its act claim
+	Impersonate(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*TokensResponse, error)
+	StopImpersonation(ctx context.Context, in *StopImpersonationRequest, opts ...grpc.CallOption) (*StopImpersonationResponse, error)
 }
 
 type authServiceClient struct {
@@ -110,6 +147,166 @@ func (c *authServiceClient) RevokeAllTenantTokens(ctx context.Context, in *Revok
 	return out, nil
 }
 
+func (c *authServiceClient) EnrollMFA(ctx context.Context, in *EnrollMFARequest, opts ...grpc.CallOption) (*EnrollMFAResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollMFAResponse)
+	err := c.cc.Invoke(ctx, AuthService_EnrollMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyMFAEnrollment(ctx context.Context, in *VerifyMFAEnrollmentRequest, opts ...grpc.CallOption) (*VerifyMFAEnrollmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyMFAEnrollmentResponse)
+	err := c.cc.Invoke(ctx, AuthService_VerifyMFAEnrollment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DisableMFA(ctx context.Context, in *DisableMFARequest, opts ...grpc.CallOption) (*DisableMFAResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableMFAResponse)
+	err := c.cc.Invoke(ctx, AuthService_DisableMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetOIDCAuthURL(ctx context.Context, in *GetOIDCAuthURLRequest, opts ...grpc.CallOption) (*GetOIDCAuthURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOIDCAuthURLResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetOIDCAuthURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LoginWithOIDC(ctx context.Context, in *LoginWithOIDCRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokensResponse)
+	err := c.cc.Invoke(ctx, AuthService_LoginWithOIDC_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LinkExternalIdentity(ctx context.Context, in *LinkExternalIdentityRequest, opts ...grpc.CallOption) (*LinkExternalIdentityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkExternalIdentityResponse)
+	err := c.cc.Invoke(ctx, AuthService_LinkExternalIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) UnlinkExternalIdentity(ctx context.Context, in *UnlinkExternalIdentityRequest, opts ...grpc.CallOption) (*UnlinkExternalIdentityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlinkExternalIdentityResponse)
+	err := c.cc.Invoke(ctx, AuthService_UnlinkExternalIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetSAMLConfig(ctx context.Context, in *GetSAMLConfigRequest, opts ...grpc.CallOption) (*SAMLConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SAMLConfigResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetSAMLConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetSAMLConfig(ctx context.Context, in *SetSAMLConfigRequest, opts ...grpc.CallOption) (*SAMLConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SAMLConfigResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetSAMLConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LoginWithSAML(ctx context.Context, in *LoginWithSAMLRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokensResponse)
+	err := c.cc.Invoke(ctx, AuthService_LoginWithSAML_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestLoginLink(ctx context.Context, in *RequestLoginLinkRequest, opts ...grpc.CallOption) (*RequestLoginLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestLoginLinkResponse)
+	err := c.cc.Invoke(ctx, AuthService_RequestLoginLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CompleteLoginLink(ctx context.Context, in *CompleteLoginLinkRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokensResponse)
+	err := c.cc.Invoke(ctx, AuthService_CompleteLoginLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetTokenPolicy(ctx context.Context, in *GetTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenPolicyResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetTokenPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetTokenPolicy(ctx context.Context, in *SetTokenPolicyRequest, opts ...grpc.CallOption) (*TokenPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenPolicyResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetTokenPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Impersonate(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokensResponse)
+	err := c.cc.Invoke(ctx, AuthService_Impersonate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StopImpersonation(ctx context.Context, in *StopImpersonationRequest, opts ...grpc.CallOption) (*StopImpersonationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopImpersonationResponse)
+	err := c.cc.Invoke(ctx, AuthService_StopImpersonation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -123,6 +320,27 @@ type AuthServiceServer interface {
 	RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error)
 	// Tenant-level token management
 	RevokeAllTenantTokens(context.Context, *RevokeAllTenantTokensRequest) (*RevokeAllTenantTokensResponse, error)
+	// MFA enrollment and management
+	EnrollMFA(context.Context, *EnrollMFARequest) (*EnrollMFAResponse, error)
+	VerifyMFAEnrollment(context.Context, *VerifyMFAEnrollmentRequest) (*VerifyMFAEnrollmentResponse, error)
+	DisableMFA(context.Context, *DisableMFARequest) (*DisableMFAResponse, error)
+	// Federated login and external identity linking
+	GetOIDCAuthURL(context.Context, *GetOIDCAuthURLRequest) (*GetOIDCAuthURLResponse, error)
+	LoginWithOIDC(context.Context, *LoginWithOIDCRequest) (*TokensResponse, error)
+	LinkExternalIdentity(context.Context, *LinkExternalIdentityRequest) (*LinkExternalIdentityResponse, error)
+	UnlinkExternalIdentity(context.Context, *UnlinkExternalIdentityRequest) (*UnlinkExternalIdentityResponse, error)
+	GetSAMLConfig(context.Context, *GetSAMLConfigRequest) (*SAMLConfigResponse, error)
+	SetSAMLConfig(context.Context, *SetSAMLConfigRequest) (*SAMLConfigResponse, error)
+	LoginWithSAML(context.Context, *LoginWithSAMLRequest) (*TokensResponse, error)
+	// Password-less login with a single use link emailed to the user, when enabled for the tenant
+	RequestLoginLink(context.Context, *RequestLoginLinkRequest) (*RequestLoginLinkResponse, error)
+	CompleteLoginLink(context.Context, *CompleteLoginLinkRequest) (*TokensResponse, error)
+	// Tenant token policy
+	GetTokenPolicy(context.Context, *GetTokenPolicyRequest) (*TokenPolicyResponse, error)
+	SetTokenPolicy(context.Context, *SetTokenPolicyRequest) (*TokenPolicyResponse, error)
+	// Impersonation, the access token acts as another user and names the impersonating user in its act claim
+	Impersonate(context.Context, *ImpersonateRequest) (*TokensResponse, error)
+	StopImpersonation(context.Context, *StopImpersonationRequest) (*StopImpersonationResponse, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -151,6 +369,54 @@ func (UnimplementedAuthServiceServer) RevokeToken(context.Context, *RevokeTokenR
 func (UnimplementedAuthServiceServer) RevokeAllTenantTokens(context.Context, *RevokeAllTenantTokensRequest) (*RevokeAllTenantTokensResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RevokeAllTenantTokens not implemented")
 }
+func (UnimplementedAuthServiceServer) EnrollMFA(context.Context, *EnrollMFARequest) (*EnrollMFAResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnrollMFA not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyMFAEnrollment(context.Context, *VerifyMFAEnrollmentRequest) (*VerifyMFAEnrollmentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyMFAEnrollment not implemented")
+}
+func (UnimplementedAuthServiceServer) DisableMFA(context.Context, *DisableMFARequest) (*DisableMFAResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisableMFA not implemented")
+}
+func (UnimplementedAuthServiceServer) GetOIDCAuthURL(context.Context, *GetOIDCAuthURLRequest) (*GetOIDCAuthURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOIDCAuthURL not implemented")
+}
+func (UnimplementedAuthServiceServer) LoginWithOIDC(context.Context, *LoginWithOIDCRequest) (*TokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoginWithOIDC not implemented")
+}
+func (UnimplementedAuthServiceServer) LinkExternalIdentity(context.Context, *LinkExternalIdentityRequest) (*LinkExternalIdentityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LinkExternalIdentity not implemented")
+}
+func (UnimplementedAuthServiceServer) UnlinkExternalIdentity(context.Context, *UnlinkExternalIdentityRequest) (*UnlinkExternalIdentityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnlinkExternalIdentity not implemented")
+}
+func (UnimplementedAuthServiceServer) GetSAMLConfig(context.Context, *GetSAMLConfigRequest) (*SAMLConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSAMLConfig not implemented")
+}
+func (UnimplementedAuthServiceServer) SetSAMLConfig(context.Context, *SetSAMLConfigRequest) (*SAMLConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetSAMLConfig not implemented")
+}
+func (UnimplementedAuthServiceServer) LoginWithSAML(context.Context, *LoginWithSAMLRequest) (*TokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LoginWithSAML not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestLoginLink(context.Context, *RequestLoginLinkRequest) (*RequestLoginLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestLoginLink not implemented")
+}
+func (UnimplementedAuthServiceServer) CompleteLoginLink(context.Context, *CompleteLoginLinkRequest) (*TokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteLoginLink not implemented")
+}
+func (UnimplementedAuthServiceServer) GetTokenPolicy(context.Context, *GetTokenPolicyRequest) (*TokenPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTokenPolicy not implemented")
+}
+func (UnimplementedAuthServiceServer) SetTokenPolicy(context.Context, *SetTokenPolicyRequest) (*TokenPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTokenPolicy not implemented")
+}
+func (UnimplementedAuthServiceServer) Impersonate(context.Context, *ImpersonateRequest) (*TokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Impersonate not implemented")
+}
+func (UnimplementedAuthServiceServer) StopImpersonation(context.Context, *StopImpersonationRequest) (*StopImpersonationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StopImpersonation not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -280,6 +546,294 @@ func _AuthService_RevokeAllTenantTokens_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_EnrollMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).EnrollMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_EnrollMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).EnrollMFA(ctx, req.(*EnrollMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyMFAEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMFAEnrollmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyMFAEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyMFAEnrollment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyMFAEnrollment(ctx, req.(*VerifyMFAEnrollmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DisableMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DisableMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DisableMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DisableMFA(ctx, req.(*DisableMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetOIDCAuthURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOIDCAuthURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetOIDCAuthURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetOIDCAuthURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetOIDCAuthURL(ctx, req.(*GetOIDCAuthURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LoginWithOIDC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithOIDCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LoginWithOIDC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LoginWithOIDC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LoginWithOIDC(ctx, req.(*LoginWithOIDCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LinkExternalIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkExternalIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LinkExternalIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LinkExternalIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LinkExternalIdentity(ctx, req.(*LinkExternalIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UnlinkExternalIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkExternalIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UnlinkExternalIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UnlinkExternalIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UnlinkExternalIdentity(ctx, req.(*UnlinkExternalIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetSAMLConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSAMLConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetSAMLConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetSAMLConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetSAMLConfig(ctx, req.(*GetSAMLConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetSAMLConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSAMLConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetSAMLConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetSAMLConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetSAMLConfig(ctx, req.(*SetSAMLConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LoginWithSAML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginWithSAMLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LoginWithSAML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LoginWithSAML_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LoginWithSAML(ctx, req.(*LoginWithSAMLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RequestLoginLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestLoginLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestLoginLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestLoginLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestLoginLink(ctx, req.(*RequestLoginLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CompleteLoginLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteLoginLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CompleteLoginLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CompleteLoginLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CompleteLoginLink(ctx, req.(*CompleteLoginLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetTokenPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetTokenPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetTokenPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetTokenPolicy(ctx, req.(*GetTokenPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetTokenPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTokenPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetTokenPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetTokenPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetTokenPolicy(ctx, req.(*SetTokenPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Impersonate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpersonateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Impersonate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Impersonate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Impersonate(ctx, req.(*ImpersonateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StopImpersonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopImpersonationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StopImpersonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StopImpersonation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StopImpersonation(ctx, req.(*StopImpersonationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -311,6 +865,70 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeAllTenantTokens",
 			Handler:    _AuthService_RevokeAllTenantTokens_Handler,
 		},
+		{
+			MethodName: "EnrollMFA",
+			Handler:    _AuthService_EnrollMFA_Handler,
+		},
+		{
+			MethodName: "VerifyMFAEnrollment",
+			Handler:    _AuthService_VerifyMFAEnrollment_Handler,
+		},
+		{
+			MethodName: "DisableMFA",
+			Handler:    _AuthService_DisableMFA_Handler,
+		},
+		{
+			MethodName: "GetOIDCAuthURL",
+			Handler:    _AuthService_GetOIDCAuthURL_Handler,
+		},
+		{
+			MethodName: "LoginWithOIDC",
+			Handler:    _AuthService_LoginWithOIDC_Handler,
+		},
+		{
+			MethodName: "LinkExternalIdentity",
+			Handler:    _AuthService_LinkExternalIdentity_Handler,
+		},
+		{
+			MethodName: "UnlinkExternalIdentity",
+			Handler:    _AuthService_UnlinkExternalIdentity_Handler,
+		},
+		{
+			MethodName: "GetSAMLConfig",
+			Handler:    _AuthService_GetSAMLConfig_Handler,
+		},
+		{
+			MethodName: "SetSAMLConfig",
+			Handler:    _AuthService_SetSAMLConfig_Handler,
+		},
+		{
+			MethodName: "LoginWithSAML",
+			Handler:    _AuthService_LoginWithSAML_Handler,
+		},
+		{
+			MethodName: "RequestLoginLink",
+			Handler:    _AuthService_RequestLoginLink_Handler,
+		},
+		{
+			MethodName: "CompleteLoginLink",
+			Handler:    _AuthService_CompleteLoginLink_Handler,
+		},
+		{
+			MethodName: "GetTokenPolicy",
+			Handler:    _AuthService_GetTokenPolicy_Handler,
+		},
+		{
+			MethodName: "SetTokenPolicy",
+			Handler:    _AuthService_SetTokenPolicy_Handler,
+		},
+		{
+			MethodName: "Impersonate",
+			Handler:    _AuthService_Impersonate_Handler,
+		},
+		{
+			MethodName: "StopImpersonation",
+			Handler:    _AuthService_StopImpersonation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/v1/auth.proto",
@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/cache/login_link.proto
+
+package authcache
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LoginLinkToken is a pending magic link login, consumed by the first login with its link
+type LoginLinkToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// HMAC of the token with the pepper of the tenant, the token itself is only in the link sent
+	TokenHash string `protobuf:"bytes,1,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash"`
+	UserId    string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	// Email the link was sent to, the login is refused when the user changed it since
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginLinkToken) Reset() {
+	*x = LoginLinkToken{}
+	mi := &file_auth_v1_cache_login_link_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginLinkToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginLinkToken) ProtoMessage() {}
+
+func (x *LoginLinkToken) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_cache_login_link_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginLinkToken.ProtoReflect.Descriptor instead.
+func (*LoginLinkToken) Descriptor() ([]byte, []int) {
+	return file_auth_v1_cache_login_link_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginLinkToken) GetTokenHash() string {
+	if x != nil {
+		return x.TokenHash
+	}
+	return ""
+}
+
+func (x *LoginLinkToken) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginLinkToken) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginLinkToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LoginLinkToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+var File_auth_v1_cache_login_link_proto protoreflect.FileDescriptor
+
+const file_auth_v1_cache_login_link_proto_rawDesc = "" +
+	"\n" +
+	"\x1eauth/v1/cache/login_link.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xc4\x02\n" +
+	"\x0eLoginLinkToken\x125\n" +
+	"\n" +
+	"token_hash\x18\x01 \x01(\tB\x16\x9a\x84\x9e\x03\x11json:\"token_hash\"R\ttokenHash\x12,\n" +
+	"\auser_id\x18\x02 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x12'\n" +
+	"\x05email\x18\x03 \x01(\tB\x11\x9a\x84\x9e\x03\fjson:\"email\"R\x05email\x12Q\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"created_at\"R\tcreatedAt\x12Q\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"expires_at\"R\texpiresAtB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
+
+var (
+	file_auth_v1_cache_login_link_proto_rawDescOnce sync.Once
+	file_auth_v1_cache_login_link_proto_rawDescData []byte
+)
+
+func file_auth_v1_cache_login_link_proto_rawDescGZIP() []byte {
+	file_auth_v1_cache_login_link_proto_rawDescOnce.Do(func() {
+		file_auth_v1_cache_login_link_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_cache_login_link_proto_rawDesc), len(file_auth_v1_cache_login_link_proto_rawDesc)))
+	})
+	return file_auth_v1_cache_login_link_proto_rawDescData
+}
+
+var file_auth_v1_cache_login_link_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_cache_login_link_proto_goTypes = []any{
+	(*LoginLinkToken)(nil),        // 0: auth.v1.cache.LoginLinkToken
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_auth_v1_cache_login_link_proto_depIdxs = []int32{
+	1, // 0: auth.v1.cache.LoginLinkToken.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: auth.v1.cache.LoginLinkToken.expires_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_cache_login_link_proto_init() }
+func file_auth_v1_cache_login_link_proto_init() {
+	if File_auth_v1_cache_login_link_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_cache_login_link_proto_rawDesc), len(file_auth_v1_cache_login_link_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_v1_cache_login_link_proto_goTypes,
+		DependencyIndexes: file_auth_v1_cache_login_link_proto_depIdxs,
+		MessageInfos:      file_auth_v1_cache_login_link_proto_msgTypes,
+	}.Build()
+	File_auth_v1_cache_login_link_proto = out.File
+	file_auth_v1_cache_login_link_proto_goTypes = nil
+	file_auth_v1_cache_login_link_proto_depIdxs = nil
+}
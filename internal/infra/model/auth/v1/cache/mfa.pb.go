@@ -25,9 +25,10 @@ const (
 
 // MFACode represents a temporary MFA code
 type MFACode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	// HMAC of the code with the pepper of the tenant, the code itself is never stored
+	CodeHash      string                 `protobuf:"bytes,2,opt,name=code_hash,json=codeHash,proto3" json:"code_hash"`
 	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
 	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
@@ -74,9 +75,9 @@ func (x *MFACode) GetUserId() string {
 	return ""
 }
 
-func (x *MFACode) GetCode() string {
+func (x *MFACode) GetCodeHash() string {
 	if x != nil {
-		return x.Code
+		return x.CodeHash
 	}
 	return ""
 }
@@ -120,10 +121,10 @@ var File_auth_v1_cache_mfa_proto protoreflect.FileDescriptor
 
 const file_auth_v1_cache_mfa_proto_rawDesc = "" +
 	"\n" +
-	"\x17auth/v1/cache/mfa.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\x93\x03\n" +
+	"\x17auth/v1/cache/mfa.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xa1\x03\n" +
 	"\aMFACode\x12,\n" +
-	"\auser_id\x18\x01 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x12$\n" +
-	"\x04code\x18\x02 \x01(\tB\x10\x9a\x84\x9e\x03\vjson:\"code\"R\x04code\x12*\n" +
+	"\auser_id\x18\x01 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x122\n" +
+	"\tcode_hash\x18\x02 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"code_hash\"R\bcodeHash\x12*\n" +
 	"\x06method\x18\x03 \x01(\tB\x12\x9a\x84\x9e\x03\rjson:\"method\"R\x06method\x12Q\n" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"created_at\"R\tcreatedAt\x12Q\n" +
@@ -0,0 +1,194 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/cache/oidc.proto
+
+package authcache
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OIDCState is a pending OIDC authorization request, consumed when the provider redirects back
+type OIDCState struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	State        string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state"`
+	TenantId     string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
+	Provider     string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider"`
+	Nonce        string                 `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce"`
+	CodeVerifier string                 `protobuf:"bytes,5,opt,name=code_verifier,json=codeVerifier,proto3" json:"code_verifier"`
+	// Set when an authenticated user links the identity to their account instead of logging in
+	UserId        string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCState) Reset() {
+	*x = OIDCState{}
+	mi := &file_auth_v1_cache_oidc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCState) ProtoMessage() {}
+
+func (x *OIDCState) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_cache_oidc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCState.ProtoReflect.Descriptor instead.
+func (*OIDCState) Descriptor() ([]byte, []int) {
+	return file_auth_v1_cache_oidc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OIDCState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *OIDCState) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *OIDCState) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *OIDCState) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *OIDCState) GetCodeVerifier() string {
+	if x != nil {
+		return x.CodeVerifier
+	}
+	return ""
+}
+
+func (x *OIDCState) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OIDCState) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OIDCState) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+var File_auth_v1_cache_oidc_proto protoreflect.FileDescriptor
+
+const file_auth_v1_cache_oidc_proto_rawDesc = "" +
+	"\n" +
+	"\x18auth/v1/cache/oidc.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xe1\x03\n" +
+	"\tOIDCState\x12'\n" +
+	"\x05state\x18\x01 \x01(\tB\x11\x9a\x84\x9e\x03\fjson:\"state\"R\x05state\x122\n" +
+	"\ttenant_id\x18\x02 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"tenant_id\"R\btenantId\x120\n" +
+	"\bprovider\x18\x03 \x01(\tB\x14\x9a\x84\x9e\x03\x0fjson:\"provider\"R\bprovider\x12'\n" +
+	"\x05nonce\x18\x04 \x01(\tB\x11\x9a\x84\x9e\x03\fjson:\"nonce\"R\x05nonce\x12>\n" +
+	"\rcode_verifier\x18\x05 \x01(\tB\x19\x9a\x84\x9e\x03\x14json:\"code_verifier\"R\fcodeVerifier\x126\n" +
+	"\auser_id\x18\x06 \x01(\tB\x1d\x9a\x84\x9e\x03\x18json:\"user_id,omitempty\"R\x06userId\x12Q\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"created_at\"R\tcreatedAt\x12Q\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"expires_at\"R\texpiresAtB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
+
+var (
+	file_auth_v1_cache_oidc_proto_rawDescOnce sync.Once
+	file_auth_v1_cache_oidc_proto_rawDescData []byte
+)
+
+func file_auth_v1_cache_oidc_proto_rawDescGZIP() []byte {
+	file_auth_v1_cache_oidc_proto_rawDescOnce.Do(func() {
+		file_auth_v1_cache_oidc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_cache_oidc_proto_rawDesc), len(file_auth_v1_cache_oidc_proto_rawDesc)))
+	})
+	return file_auth_v1_cache_oidc_proto_rawDescData
+}
+
+var file_auth_v1_cache_oidc_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_cache_oidc_proto_goTypes = []any{
+	(*OIDCState)(nil),             // 0: auth.v1.cache.OIDCState
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_auth_v1_cache_oidc_proto_depIdxs = []int32{
+	1, // 0: auth.v1.cache.OIDCState.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: auth.v1.cache.OIDCState.expires_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_cache_oidc_proto_init() }
+func file_auth_v1_cache_oidc_proto_init() {
+	if File_auth_v1_cache_oidc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_cache_oidc_proto_rawDesc), len(file_auth_v1_cache_oidc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_v1_cache_oidc_proto_goTypes,
+		DependencyIndexes: file_auth_v1_cache_oidc_proto_depIdxs,
+		MessageInfos:      file_auth_v1_cache_oidc_proto_msgTypes,
+	}.Build()
+	File_auth_v1_cache_oidc_proto = out.File
+	file_auth_v1_cache_oidc_proto_goTypes = nil
+	file_auth_v1_cache_oidc_proto_depIdxs = nil
+}
@@ -25,12 +25,16 @@ const (
 
 // UserPermissionsCache represents cached permissions in Redis
 type UserPermissionsCache struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
-	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
-	Permissions   []string               `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions"`
-	CachedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=cached_at,json=cachedAt,proto3" json:"cached_at"`
-	Version       int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	TenantId    string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
+	Permissions []string               `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions"`
+	CachedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=cached_at,json=cachedAt,proto3" json:"cached_at"`
+	Version     int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version"`
+	// Permissions explicitly revoked from the user, they override the permissions of its roles
+	DeniedPermissions []string `protobuf:"bytes,6,rep,name=denied_permissions,json=deniedPermissions,proto3" json:"denied_permissions,omitempty"`
+	// Conditions of the conditional permissions, by permission
+	Conditions    map[string]string `protobuf:"bytes,7,rep,name=conditions,proto3" json:"conditions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -100,6 +104,20 @@ func (x *UserPermissionsCache) GetVersion() int32 {
 	return 0
 }
 
+func (x *UserPermissionsCache) GetDeniedPermissions() []string {
+	if x != nil {
+		return x.DeniedPermissions
+	}
+	return nil
+}
+
+func (x *UserPermissionsCache) GetConditions() map[string]string {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
 // UserRolesCache represents cached user roles
 type UserRolesCache struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -318,13 +336,20 @@ var File_auth_v1_cache_rbac_proto protoreflect.FileDescriptor
 
 const file_auth_v1_cache_rbac_proto_rawDesc = "" +
 	"\n" +
-	"\x18auth/v1/cache/rbac.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xb2\x02\n" +
+	"\x18auth/v1/cache/rbac.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xc1\x04\n" +
 	"\x14UserPermissionsCache\x12,\n" +
 	"\auser_id\x18\x01 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x122\n" +
 	"\ttenant_id\x18\x02 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"tenant_id\"R\btenantId\x129\n" +
 	"\vpermissions\x18\x03 \x03(\tB\x17\x9a\x84\x9e\x03\x12json:\"permissions\"R\vpermissions\x12N\n" +
 	"\tcached_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x15\x9a\x84\x9e\x03\x10json:\"cached_at\"R\bcachedAt\x12-\n" +
-	"\aversion\x18\x05 \x01(\x05B\x13\x9a\x84\x9e\x03\x0ejson:\"version\"R\aversion\"\xb6\x02\n" +
+	"\aversion\x18\x05 \x01(\x05B\x13\x9a\x84\x9e\x03\x0ejson:\"version\"R\aversion\x12W\n" +
+	"\x12denied_permissions\x18\x06 \x03(\tB(\x9a\x84\x9e\x03#json:\"denied_permissions,omitempty\"R\x11deniedPermissions\x12u\n" +
+	"\n" +
+	"conditions\x18\a \x03(\v23.auth.v1.cache.UserPermissionsCache.ConditionsEntryB \x9a\x84\x9e\x03\x1bjson:\"conditions,omitempty\"R\n" +
+	"conditions\x1a=\n" +
+	"\x0fConditionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb6\x02\n" +
 	"\x0eUserRolesCache\x12,\n" +
 	"\auser_id\x18\x01 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x122\n" +
 	"\ttenant_id\x18\x02 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"tenant_id\"R\btenantId\x12C\n" +
@@ -354,24 +379,26 @@ func file_auth_v1_cache_rbac_proto_rawDescGZIP() []byte {
 	return file_auth_v1_cache_rbac_proto_rawDescData
 }
 
-var file_auth_v1_cache_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_auth_v1_cache_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_auth_v1_cache_rbac_proto_goTypes = []any{
 	(*UserPermissionsCache)(nil),  // 0: auth.v1.cache.UserPermissionsCache
 	(*UserRolesCache)(nil),        // 1: auth.v1.cache.UserRolesCache
 	(*RoleSummary)(nil),           // 2: auth.v1.cache.RoleSummary
 	(*RolePermissionsCache)(nil),  // 3: auth.v1.cache.RolePermissionsCache
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	nil,                           // 4: auth.v1.cache.UserPermissionsCache.ConditionsEntry
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
 }
 var file_auth_v1_cache_rbac_proto_depIdxs = []int32{
-	4, // 0: auth.v1.cache.UserPermissionsCache.cached_at:type_name -> google.protobuf.Timestamp
-	2, // 1: auth.v1.cache.UserRolesCache.roles:type_name -> auth.v1.cache.RoleSummary
-	4, // 2: auth.v1.cache.UserRolesCache.cached_at:type_name -> google.protobuf.Timestamp
-	4, // 3: auth.v1.cache.RolePermissionsCache.cached_at:type_name -> google.protobuf.Timestamp
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 0: auth.v1.cache.UserPermissionsCache.cached_at:type_name -> google.protobuf.Timestamp
+	4, // 1: auth.v1.cache.UserPermissionsCache.conditions:type_name -> auth.v1.cache.UserPermissionsCache.ConditionsEntry
+	2, // 2: auth.v1.cache.UserRolesCache.roles:type_name -> auth.v1.cache.RoleSummary
+	5, // 3: auth.v1.cache.UserRolesCache.cached_at:type_name -> google.protobuf.Timestamp
+	5, // 4: auth.v1.cache.RolePermissionsCache.cached_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_cache_rbac_proto_init() }
@@ -385,7 +412,7 @@ func file_auth_v1_cache_rbac_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_cache_rbac_proto_rawDesc), len(file_auth_v1_cache_rbac_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -25,17 +25,22 @@ const (
 
 // RefreshToken model for MongoDB auth_db.refresh_tokens collection
 type RefreshToken struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
-	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
-	TokenHash     string                 `protobuf:"bytes,3,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
-	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
-	IpAddress     string                 `protobuf:"bytes,7,opt,name=ip_address,json=ipAddress,proto3" json:"user_agent"`
-	Revoked       bool                   `protobuf:"varint,9,opt,name=revoked,proto3" json:"revoked"`
-	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
-	RevokedBy     string                 `protobuf:"bytes,11,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	TenantId   string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
+	TokenHash  string                 `protobuf:"bytes,3,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	IpAddress  string                 `protobuf:"bytes,7,opt,name=ip_address,json=ipAddress,proto3" json:"user_agent"`
+	Revoked    bool                   `protobuf:"varint,9,opt,name=revoked,proto3" json:"revoked"`
+	RevokedAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	RevokedBy  string                 `protobuf:"bytes,11,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	TokenId    string                 `protobuf:"bytes,12,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	FamilyId   string                 `protobuf:"bytes,13,opt,name=family_id,json=familyId,proto3" json:"family_id,omitempty"`
+	ParentId   string                 `protobuf:"bytes,14,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Fingerprint of the device of the login, kept by the rotated tokens
+	DeviceId      string `protobuf:"bytes,15,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -140,11 +145,187 @@ func (x *RefreshToken) GetRevokedBy() string {
 	return ""
 }
 
+func (x *RefreshToken) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *RefreshToken) GetFamilyId() string {
+	if x != nil {
+		return x.FamilyId
+	}
+	return ""
+}
+
+func (x *RefreshToken) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *RefreshToken) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+// RefreshTokenFamily tracks every token rotated from the same login, a rotated token presented
+// again means the family leaked and the whole session is revoked
+type RefreshTokenFamily struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FamilyId       string                 `protobuf:"bytes,1,opt,name=family_id,json=familyId,proto3" json:"family_id"`
+	TenantId       string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	CurrentTokenId string                 `protobuf:"bytes,4,opt,name=current_token_id,json=currentTokenId,proto3" json:"current_token_id"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
+	RotatedTokens  []*RotatedRefreshToken `protobuf:"bytes,8,rep,name=rotated_tokens,json=rotatedTokens,proto3" json:"rotated_tokens,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RefreshTokenFamily) Reset() {
+	*x = RefreshTokenFamily{}
+	mi := &file_auth_v1_cache_refresh_token_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenFamily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenFamily) ProtoMessage() {}
+
+func (x *RefreshTokenFamily) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_cache_refresh_token_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenFamily.ProtoReflect.Descriptor instead.
+func (*RefreshTokenFamily) Descriptor() ([]byte, []int) {
+	return file_auth_v1_cache_refresh_token_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RefreshTokenFamily) GetFamilyId() string {
+	if x != nil {
+		return x.FamilyId
+	}
+	return ""
+}
+
+func (x *RefreshTokenFamily) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RefreshTokenFamily) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RefreshTokenFamily) GetCurrentTokenId() string {
+	if x != nil {
+		return x.CurrentTokenId
+	}
+	return ""
+}
+
+func (x *RefreshTokenFamily) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RefreshTokenFamily) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *RefreshTokenFamily) GetRotatedTokens() []*RotatedRefreshToken {
+	if x != nil {
+		return x.RotatedTokens
+	}
+	return nil
+}
+
+// RotatedRefreshToken is a token rotated out of its family, kept by the hash of the token so only the
+// token itself, not a guess of its id, counts as a reuse
+type RotatedRefreshToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       string                 `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id"`
+	TokenHash     string                 `protobuf:"bytes,2,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotatedRefreshToken) Reset() {
+	*x = RotatedRefreshToken{}
+	mi := &file_auth_v1_cache_refresh_token_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotatedRefreshToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotatedRefreshToken) ProtoMessage() {}
+
+func (x *RotatedRefreshToken) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_cache_refresh_token_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotatedRefreshToken.ProtoReflect.Descriptor instead.
+func (*RotatedRefreshToken) Descriptor() ([]byte, []int) {
+	return file_auth_v1_cache_refresh_token_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RotatedRefreshToken) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *RotatedRefreshToken) GetTokenHash() string {
+	if x != nil {
+		return x.TokenHash
+	}
+	return ""
+}
+
 var File_auth_v1_cache_refresh_token_proto protoreflect.FileDescriptor
 
 const file_auth_v1_cache_refresh_token_proto_rawDesc = "" +
 	"\n" +
-	"!auth/v1/cache/refresh_token.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xb3\x05\n" +
+	"!auth/v1/cache/refresh_token.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xa8\a\n" +
 	"\fRefreshToken\x12,\n" +
 	"\auser_id\x18\x01 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x122\n" +
 	"\ttenant_id\x18\x02 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"tenant_id\"R\btenantId\x125\n" +
@@ -163,7 +344,25 @@ const file_auth_v1_cache_refresh_token_proto_rawDesc = "" +
 	"revoked_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampB \x9a\x84\x9e\x03\x1bjson:\"revoked_at,omitempty\"R\trevokedAt\x12?\n" +
 	"\n" +
-	"revoked_by\x18\v \x01(\tB \x9a\x84\x9e\x03\x1bjson:\"revoked_by,omitempty\"R\trevokedByB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
+	"revoked_by\x18\v \x01(\tB \x9a\x84\x9e\x03\x1bjson:\"revoked_by,omitempty\"R\trevokedBy\x129\n" +
+	"\btoken_id\x18\f \x01(\tB\x1e\x9a\x84\x9e\x03\x19json:\"token_id,omitempty\"R\atokenId\x12<\n" +
+	"\tfamily_id\x18\r \x01(\tB\x1f\x9a\x84\x9e\x03\x1ajson:\"family_id,omitempty\"R\bfamilyId\x12<\n" +
+	"\tparent_id\x18\x0e \x01(\tB\x1f\x9a\x84\x9e\x03\x1ajson:\"parent_id,omitempty\"R\bparentId\x12<\n" +
+	"\tdevice_id\x18\x0f \x01(\tB\x1f\x9a\x84\x9e\x03\x1ajson:\"device_id,omitempty\"R\bdeviceId\"\xa2\x04\n" +
+	"\x12RefreshTokenFamily\x122\n" +
+	"\tfamily_id\x18\x01 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"family_id\"R\bfamilyId\x122\n" +
+	"\ttenant_id\x18\x02 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"tenant_id\"R\btenantId\x12,\n" +
+	"\auser_id\x18\x03 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x12F\n" +
+	"\x10current_token_id\x18\x04 \x01(\tB\x1c\x9a\x84\x9e\x03\x17json:\"current_token_id\"R\x0ecurrentTokenId\x12Q\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"created_at\"R\tcreatedAt\x12Q\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"expires_at\"R\texpiresAt\x12o\n" +
+	"\x0erotated_tokens\x18\b \x03(\v2\".auth.v1.cache.RotatedRefreshTokenB$\x9a\x84\x9e\x03\x1fjson:\"rotated_tokens,omitempty\"R\rrotatedTokensJ\x04\b\x05\x10\x06R\x11rotated_token_ids\"}\n" +
+	"\x13RotatedRefreshToken\x12/\n" +
+	"\btoken_id\x18\x01 \x01(\tB\x14\x9a\x84\x9e\x03\x0fjson:\"token_id\"R\atokenId\x125\n" +
+	"\n" +
+	"token_hash\x18\x02 \x01(\tB\x16\x9a\x84\x9e\x03\x11json:\"token_hash\"R\ttokenHashB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
 
 var (
 	file_auth_v1_cache_refresh_token_proto_rawDescOnce sync.Once
@@ -177,21 +376,26 @@ func file_auth_v1_cache_refresh_token_proto_rawDescGZIP() []byte {
 	return file_auth_v1_cache_refresh_token_proto_rawDescData
 }
 
-var file_auth_v1_cache_refresh_token_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_cache_refresh_token_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_auth_v1_cache_refresh_token_proto_goTypes = []any{
 	(*RefreshToken)(nil),          // 0: auth.v1.cache.RefreshToken
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*RefreshTokenFamily)(nil),    // 1: auth.v1.cache.RefreshTokenFamily
+	(*RotatedRefreshToken)(nil),   // 2: auth.v1.cache.RotatedRefreshToken
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
 }
 var file_auth_v1_cache_refresh_token_proto_depIdxs = []int32{
-	1, // 0: auth.v1.cache.RefreshToken.expires_at:type_name -> google.protobuf.Timestamp
-	1, // 1: auth.v1.cache.RefreshToken.created_at:type_name -> google.protobuf.Timestamp
-	1, // 2: auth.v1.cache.RefreshToken.last_used_at:type_name -> google.protobuf.Timestamp
-	1, // 3: auth.v1.cache.RefreshToken.revoked_at:type_name -> google.protobuf.Timestamp
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3, // 0: auth.v1.cache.RefreshToken.expires_at:type_name -> google.protobuf.Timestamp
+	3, // 1: auth.v1.cache.RefreshToken.created_at:type_name -> google.protobuf.Timestamp
+	3, // 2: auth.v1.cache.RefreshToken.last_used_at:type_name -> google.protobuf.Timestamp
+	3, // 3: auth.v1.cache.RefreshToken.revoked_at:type_name -> google.protobuf.Timestamp
+	3, // 4: auth.v1.cache.RefreshTokenFamily.created_at:type_name -> google.protobuf.Timestamp
+	3, // 5: auth.v1.cache.RefreshTokenFamily.expires_at:type_name -> google.protobuf.Timestamp
+	2, // 6: auth.v1.cache.RefreshTokenFamily.rotated_tokens:type_name -> auth.v1.cache.RotatedRefreshToken
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_cache_refresh_token_proto_init() }
@@ -205,7 +409,7 @@ func file_auth_v1_cache_refresh_token_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_cache_refresh_token_proto_rawDesc), len(file_auth_v1_cache_refresh_token_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
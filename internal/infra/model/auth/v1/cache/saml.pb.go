@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/cache/saml.proto
+
+package authcache
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SAMLAssertion records a consumed assertion until it expires, so the same assertion can't log in twice
+type SAMLAssertion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssertionId   string                 `protobuf:"bytes,1,opt,name=assertion_id,json=assertionId,proto3" json:"assertion_id"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
+	Issuer        string                 `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ConsumedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=consumed_at,json=consumedAt,proto3" json:"consumed_at"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SAMLAssertion) Reset() {
+	*x = SAMLAssertion{}
+	mi := &file_auth_v1_cache_saml_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAMLAssertion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAMLAssertion) ProtoMessage() {}
+
+func (x *SAMLAssertion) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_cache_saml_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAMLAssertion.ProtoReflect.Descriptor instead.
+func (*SAMLAssertion) Descriptor() ([]byte, []int) {
+	return file_auth_v1_cache_saml_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SAMLAssertion) GetAssertionId() string {
+	if x != nil {
+		return x.AssertionId
+	}
+	return ""
+}
+
+func (x *SAMLAssertion) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SAMLAssertion) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *SAMLAssertion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SAMLAssertion) GetConsumedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConsumedAt
+	}
+	return nil
+}
+
+func (x *SAMLAssertion) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+var File_auth_v1_cache_saml_proto protoreflect.FileDescriptor
+
+const file_auth_v1_cache_saml_proto_rawDesc = "" +
+	"\n" +
+	"\x18auth/v1/cache/saml.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\x8d\x03\n" +
+	"\rSAMLAssertion\x12;\n" +
+	"\fassertion_id\x18\x01 \x01(\tB\x18\x9a\x84\x9e\x03\x13json:\"assertion_id\"R\vassertionId\x122\n" +
+	"\ttenant_id\x18\x02 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"tenant_id\"R\btenantId\x12*\n" +
+	"\x06issuer\x18\x03 \x01(\tB\x12\x9a\x84\x9e\x03\rjson:\"issuer\"R\x06issuer\x126\n" +
+	"\auser_id\x18\x04 \x01(\tB\x1d\x9a\x84\x9e\x03\x18json:\"user_id,omitempty\"R\x06userId\x12T\n" +
+	"\vconsumed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x17\x9a\x84\x9e\x03\x12json:\"consumed_at\"R\n" +
+	"consumedAt\x12Q\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"expires_at\"R\texpiresAtB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
+
+var (
+	file_auth_v1_cache_saml_proto_rawDescOnce sync.Once
+	file_auth_v1_cache_saml_proto_rawDescData []byte
+)
+
+func file_auth_v1_cache_saml_proto_rawDescGZIP() []byte {
+	file_auth_v1_cache_saml_proto_rawDescOnce.Do(func() {
+		file_auth_v1_cache_saml_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_cache_saml_proto_rawDesc), len(file_auth_v1_cache_saml_proto_rawDesc)))
+	})
+	return file_auth_v1_cache_saml_proto_rawDescData
+}
+
+var file_auth_v1_cache_saml_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_cache_saml_proto_goTypes = []any{
+	(*SAMLAssertion)(nil),         // 0: auth.v1.cache.SAMLAssertion
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_auth_v1_cache_saml_proto_depIdxs = []int32{
+	1, // 0: auth.v1.cache.SAMLAssertion.consumed_at:type_name -> google.protobuf.Timestamp
+	1, // 1: auth.v1.cache.SAMLAssertion.expires_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_cache_saml_proto_init() }
+func file_auth_v1_cache_saml_proto_init() {
+	if File_auth_v1_cache_saml_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_cache_saml_proto_rawDesc), len(file_auth_v1_cache_saml_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_v1_cache_saml_proto_goTypes,
+		DependencyIndexes: file_auth_v1_cache_saml_proto_depIdxs,
+		MessageInfos:      file_auth_v1_cache_saml_proto_msgTypes,
+	}.Build()
+	File_auth_v1_cache_saml_proto = out.File
+	file_auth_v1_cache_saml_proto_goTypes = nil
+	file_auth_v1_cache_saml_proto_depIdxs = nil
+}
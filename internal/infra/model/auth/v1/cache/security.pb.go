@@ -116,6 +116,147 @@ func (x *LoginAttempts) GetFailedIps() []string {
 	return nil
 }
 
+// KnownDevices are the devices and networks a user logged in from, logins from others are risky
+// Key: known_devices:{tenant_id}:{user_id}
+type KnownDevices struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	// Most recently seen first
+	Devices       []*KnownDevice         `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnownDevices) Reset() {
+	*x = KnownDevices{}
+	mi := &file_auth_v1_cache_security_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnownDevices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnownDevices) ProtoMessage() {}
+
+func (x *KnownDevices) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_cache_security_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnownDevices.ProtoReflect.Descriptor instead.
+func (*KnownDevices) Descriptor() ([]byte, []int) {
+	return file_auth_v1_cache_security_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KnownDevices) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *KnownDevices) GetDevices() []*KnownDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *KnownDevices) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type KnownDevice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hash of the user agent
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id"`
+	// IP range the device logged in from, /24 for IPv4 and /48 for IPv6
+	Network       string                 `protobuf:"bytes,2,opt,name=network,proto3" json:"network"`
+	UserAgent     string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent"`
+	FirstSeen     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnownDevice) Reset() {
+	*x = KnownDevice{}
+	mi := &file_auth_v1_cache_security_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnownDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnownDevice) ProtoMessage() {}
+
+func (x *KnownDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_cache_security_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnownDevice.ProtoReflect.Descriptor instead.
+func (*KnownDevice) Descriptor() ([]byte, []int) {
+	return file_auth_v1_cache_security_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KnownDevice) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *KnownDevice) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *KnownDevice) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *KnownDevice) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *KnownDevice) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
 var File_auth_v1_cache_security_proto protoreflect.FileDescriptor
 
 const file_auth_v1_cache_security_proto_rawDesc = "" +
@@ -131,7 +272,20 @@ const file_auth_v1_cache_security_proto_rawDesc = "" +
 	"\x06locked\x18\x05 \x01(\bB\x12\x9a\x84\x9e\x03\rjson:\"locked\"R\x06locked\x12a\n" +
 	"\flocked_until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\"\x9a\x84\x9e\x03\x1djson:\"locked_until,omitempty\"R\vlockedUntil\x12?\n" +
 	"\n" +
-	"failed_ips\x18\a \x03(\tB \x9a\x84\x9e\x03\x1bjson:\"failed_ips,omitempty\"R\tfailedIpsB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
+	"failed_ips\x18\a \x03(\tB \x9a\x84\x9e\x03\x1bjson:\"failed_ips,omitempty\"R\tfailedIps\"\xda\x01\n" +
+	"\fKnownDevices\x12,\n" +
+	"\auser_id\x18\x01 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x12I\n" +
+	"\adevices\x18\x02 \x03(\v2\x1a.auth.v1.cache.KnownDeviceB\x13\x9a\x84\x9e\x03\x0ejson:\"devices\"R\adevices\x12Q\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"updated_at\"R\tupdatedAt\"\xca\x02\n" +
+	"\vKnownDevice\x122\n" +
+	"\tdevice_id\x18\x01 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"device_id\"R\bdeviceId\x12-\n" +
+	"\anetwork\x18\x02 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"network\"R\anetwork\x125\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tB\x16\x9a\x84\x9e\x03\x11json:\"user_agent\"R\tuserAgent\x12Q\n" +
+	"\n" +
+	"first_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"first_seen\"R\tfirstSeen\x12N\n" +
+	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x15\x9a\x84\x9e\x03\x10json:\"last_seen\"R\blastSeenB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
 
 var (
 	file_auth_v1_cache_security_proto_rawDescOnce sync.Once
@@ -145,20 +299,26 @@ func file_auth_v1_cache_security_proto_rawDescGZIP() []byte {
 	return file_auth_v1_cache_security_proto_rawDescData
 }
 
-var file_auth_v1_cache_security_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_cache_security_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_auth_v1_cache_security_proto_goTypes = []any{
 	(*LoginAttempts)(nil),         // 0: auth.v1.cache.LoginAttempts
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*KnownDevices)(nil),          // 1: auth.v1.cache.KnownDevices
+	(*KnownDevice)(nil),           // 2: auth.v1.cache.KnownDevice
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
 }
 var file_auth_v1_cache_security_proto_depIdxs = []int32{
-	1, // 0: auth.v1.cache.LoginAttempts.first_attempt:type_name -> google.protobuf.Timestamp
-	1, // 1: auth.v1.cache.LoginAttempts.last_attempt:type_name -> google.protobuf.Timestamp
-	1, // 2: auth.v1.cache.LoginAttempts.locked_until:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 0: auth.v1.cache.LoginAttempts.first_attempt:type_name -> google.protobuf.Timestamp
+	3, // 1: auth.v1.cache.LoginAttempts.last_attempt:type_name -> google.protobuf.Timestamp
+	3, // 2: auth.v1.cache.LoginAttempts.locked_until:type_name -> google.protobuf.Timestamp
+	2, // 3: auth.v1.cache.KnownDevices.devices:type_name -> auth.v1.cache.KnownDevice
+	3, // 4: auth.v1.cache.KnownDevices.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 5: auth.v1.cache.KnownDevice.first_seen:type_name -> google.protobuf.Timestamp
+	3, // 6: auth.v1.cache.KnownDevice.last_seen:type_name -> google.protobuf.Timestamp
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_cache_security_proto_init() }
@@ -172,7 +332,7 @@ func file_auth_v1_cache_security_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_cache_security_proto_rawDesc), len(file_auth_v1_cache_security_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
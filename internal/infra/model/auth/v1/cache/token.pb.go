@@ -25,18 +25,20 @@ const (
 
 // TokenMetadata represents token metadata stored in Redis
 type TokenMetadata struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jti           string                 `protobuf:"bytes,1,opt,name=jti,proto3" json:"jti"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id"`
-	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id"`
-	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at"`
-	Revoked       bool                   `protobuf:"varint,6,opt,name=revoked,proto3" json:"revoked"`
-	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
-	RevokedBy     string                 `protobuf:"bytes,8,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
-	IpAddress     string                 `protobuf:"bytes,9,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address"`
-	UserAgent     string                 `protobuf:"bytes,10,opt,name=user_agent,json=userAgent,proto3" json:"user_agent"`
-	Scopes        []string               `protobuf:"bytes,11,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Jti       string                 `protobuf:"bytes,1,opt,name=jti,proto3" json:"jti" validate:"required"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id" validate:"required"`
+	TenantId  string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" validate:"required"`
+	IssuedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at" validate:"required"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at" validate:"required"`
+	Revoked   bool                   `protobuf:"varint,6,opt,name=revoked,proto3" json:"revoked"`
+	RevokedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	RevokedBy string                 `protobuf:"bytes,8,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	IpAddress string                 `protobuf:"bytes,9,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address"`
+	UserAgent string                 `protobuf:"bytes,10,opt,name=user_agent,json=userAgent,proto3" json:"user_agent"`
+	Scopes    []string               `protobuf:"bytes,11,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Fingerprint of the device the token was issued on, see auth.v1.Device
+	DeviceId      string `protobuf:"bytes,12,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -148,19 +150,25 @@ func (x *TokenMetadata) GetScopes() []string {
 	return nil
 }
 
+func (x *TokenMetadata) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
 var File_auth_v1_cache_token_proto protoreflect.FileDescriptor
 
 const file_auth_v1_cache_token_proto_rawDesc = "" +
 	"\n" +
-	"\x19auth/v1/cache/token.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xa8\x05\n" +
-	"\rTokenMetadata\x12!\n" +
-	"\x03jti\x18\x01 \x01(\tB\x0f\x9a\x84\x9e\x03\n" +
-	"json:\"jti\"R\x03jti\x12,\n" +
-	"\auser_id\x18\x02 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x122\n" +
-	"\ttenant_id\x18\x03 \x01(\tB\x15\x9a\x84\x9e\x03\x10json:\"tenant_id\"R\btenantId\x12N\n" +
-	"\tissued_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x15\x9a\x84\x9e\x03\x10json:\"issued_at\"R\bissuedAt\x12Q\n" +
+	"\x19auth/v1/cache/token.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xca\x06\n" +
+	"\rTokenMetadata\x125\n" +
+	"\x03jti\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ejson:\"jti\" validate:\"required\"R\x03jti\x12@\n" +
+	"\auser_id\x18\x02 \x01(\tB'\x9a\x84\x9e\x03\"json:\"user_id\" validate:\"required\"R\x06userId\x12F\n" +
+	"\ttenant_id\x18\x03 \x01(\tB)\x9a\x84\x9e\x03$json:\"tenant_id\" validate:\"required\"R\btenantId\x12b\n" +
+	"\tissued_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB)\x9a\x84\x9e\x03$json:\"issued_at\" validate:\"required\"R\bissuedAt\x12e\n" +
 	"\n" +
-	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x16\x9a\x84\x9e\x03\x11json:\"expires_at\"R\texpiresAt\x12-\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB*\x9a\x84\x9e\x03%json:\"expires_at\" validate:\"required\"R\texpiresAt\x12-\n" +
 	"\arevoked\x18\x06 \x01(\bB\x13\x9a\x84\x9e\x03\x0ejson:\"revoked\"R\arevoked\x12[\n" +
 	"\n" +
 	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB \x9a\x84\x9e\x03\x1bjson:\"revoked_at,omitempty\"R\trevokedAt\x12?\n" +
@@ -171,7 +179,8 @@ const file_auth_v1_cache_token_proto_rawDesc = "" +
 	"\n" +
 	"user_agent\x18\n" +
 	" \x01(\tB\x16\x9a\x84\x9e\x03\x11json:\"user_agent\"R\tuserAgent\x124\n" +
-	"\x06scopes\x18\v \x03(\tB\x1c\x9a\x84\x9e\x03\x17json:\"scopes,omitempty\"R\x06scopesB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
+	"\x06scopes\x18\v \x03(\tB\x1c\x9a\x84\x9e\x03\x17json:\"scopes,omitempty\"R\x06scopes\x12<\n" +
+	"\tdevice_id\x18\f \x01(\tB\x1f\x9a\x84\x9e\x03\x1ajson:\"device_id,omitempty\"R\bdeviceIdB<Z:erp.localhost/internal/infra/model/auth/v1/cache;authcacheb\x06proto3"
 
 var (
 	file_auth_v1_cache_token_proto_rawDescOnce sync.Once
@@ -25,8 +25,9 @@ const (
 
 // EmailVerificationToken represents an email verification token
 type EmailVerificationToken struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// HMAC of the token with the pepper of the tenant, the token itself is only in the link sent
+	TokenHash     string                 `protobuf:"bytes,1,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id"`
 	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
@@ -67,9 +68,9 @@ func (*EmailVerificationToken) Descriptor() ([]byte, []int) {
 	return file_auth_v1_cache_verification_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *EmailVerificationToken) GetToken() string {
+func (x *EmailVerificationToken) GetTokenHash() string {
 	if x != nil {
-		return x.Token
+		return x.TokenHash
 	}
 	return ""
 }
@@ -120,9 +121,10 @@ var File_auth_v1_cache_verification_proto protoreflect.FileDescriptor
 
 const file_auth_v1_cache_verification_proto_rawDesc = "" +
 	"\n" +
-	" auth/v1/cache/verification.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xd0\x03\n" +
-	"\x16EmailVerificationToken\x12'\n" +
-	"\x05token\x18\x01 \x01(\tB\x11\x9a\x84\x9e\x03\fjson:\"token\"R\x05token\x12,\n" +
+	" auth/v1/cache/verification.proto\x12\rauth.v1.cache\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xde\x03\n" +
+	"\x16EmailVerificationToken\x125\n" +
+	"\n" +
+	"token_hash\x18\x01 \x01(\tB\x16\x9a\x84\x9e\x03\x11json:\"token_hash\"R\ttokenHash\x12,\n" +
 	"\auser_id\x18\x02 \x01(\tB\x13\x9a\x84\x9e\x03\x0ejson:\"user_id\"R\x06userId\x12'\n" +
 	"\x05email\x18\x03 \x01(\tB\x11\x9a\x84\x9e\x03\fjson:\"email\"R\x05email\x12Q\n" +
 	"\n" +
@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/login_link.proto
+
+package authv1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LoginLinkSettings turns the magic link login on for a tenant, stored through the config service
+type LoginLinkSettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginLinkSettings) Reset() {
+	*x = LoginLinkSettings{}
+	mi := &file_auth_v1_login_link_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginLinkSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginLinkSettings) ProtoMessage() {}
+
+func (x *LoginLinkSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_login_link_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginLinkSettings.ProtoReflect.Descriptor instead.
+func (*LoginLinkSettings) Descriptor() ([]byte, []int) {
+	return file_auth_v1_login_link_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginLinkSettings) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+var File_auth_v1_login_link_proto protoreflect.FileDescriptor
+
+const file_auth_v1_login_link_proto_rawDesc = "" +
+	"\n" +
+	"\x18auth/v1/login_link.proto\x12\aauth.v1\x1a\x13tagger/tagger.proto\"B\n" +
+	"\x11LoginLinkSettings\x12-\n" +
+	"\aenabled\x18\x01 \x01(\bB\x13\x9a\x84\x9e\x03\x0ejson:\"enabled\"R\aenabledB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+
+var (
+	file_auth_v1_login_link_proto_rawDescOnce sync.Once
+	file_auth_v1_login_link_proto_rawDescData []byte
+)
+
+func file_auth_v1_login_link_proto_rawDescGZIP() []byte {
+	file_auth_v1_login_link_proto_rawDescOnce.Do(func() {
+		file_auth_v1_login_link_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_login_link_proto_rawDesc), len(file_auth_v1_login_link_proto_rawDesc)))
+	})
+	return file_auth_v1_login_link_proto_rawDescData
+}
+
+var file_auth_v1_login_link_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_login_link_proto_goTypes = []any{
+	(*LoginLinkSettings)(nil), // 0: auth.v1.LoginLinkSettings
+}
+var file_auth_v1_login_link_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_login_link_proto_init() }
+func file_auth_v1_login_link_proto_init() {
+	if File_auth_v1_login_link_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_login_link_proto_rawDesc), len(file_auth_v1_login_link_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_v1_login_link_proto_goTypes,
+		DependencyIndexes: file_auth_v1_login_link_proto_depIdxs,
+		MessageInfos:      file_auth_v1_login_link_proto_msgTypes,
+	}.Build()
+	File_auth_v1_login_link_proto = out.File
+	file_auth_v1_login_link_proto_goTypes = nil
+	file_auth_v1_login_link_proto_depIdxs = nil
+}
@@ -79,24 +79,31 @@ func (PermissionStatus) EnumDescriptor() ([]byte, []int) {
 // Permission model for MongoDB auth_db.permissions collection
 type Permission struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	TenantId         string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	Resource         string                 `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource" bson:"resource"`
-	Action           string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action" bson:"action"`
-	PermissionString string                 `protobuf:"bytes,5,opt,name=permission_string,json=permissionString,proto3" json:"permission_string" bson:"permission_string"`
-	DisplayName      string                 `protobuf:"bytes,6,opt,name=display_name,json=displayName,proto3" json:"display_name" bson:"display_name"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId         string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Resource         string                 `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource" bson:"resource" validate:"required"`
+	Action           string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action" bson:"action" validate:"required"`
+	PermissionString string                 `protobuf:"bytes,5,opt,name=permission_string,json=permissionString,proto3" json:"permission_string" bson:"permission_string" validate:"required"`
+	DisplayName      string                 `protobuf:"bytes,6,opt,name=display_name,json=displayName,proto3" json:"display_name" bson:"display_name" validate:"required"`
 	Description      string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description" bson:"description"`
 	Category         string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category" bson:"category"`
-	Status           PermissionStatus       `protobuf:"varint,9,opt,name=status,proto3,enum=auth.v1.PermissionStatus" json:"status" bson:"status"`
+	Status           PermissionStatus       `protobuf:"varint,9,opt,name=status,proto3,enum=auth.v1.PermissionStatus" json:"status" bson:"status" validate:"required"`
 	IsDangerous      bool                   `protobuf:"varint,10,opt,name=is_dangerous,json=isDangerous,proto3" json:"is_dangerous" bson:"is_dangerous"`
 	RequiresApproval bool                   `protobuf:"varint,11,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval" bson:"requires_approval"`
 	Dependencies     []string               `protobuf:"bytes,12,rep,name=dependencies,proto3" json:"dependencies,omitempty" bson:"dependencies,omitempty"`
 	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
 	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
-	CreatedBy        string                 `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by"`
+	CreatedBy        string                 `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
 	Metadata         *PermissionMetadata    `protobuf:"bytes,16,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Condition the permission is held under, evaluated against the attributes of the verified request, e.g.
+	// "owner == subject.user_id". Held unconditionally when empty
+	Condition string `protobuf:"bytes,17,opt,name=condition,proto3" json:"condition,omitempty" bson:"condition,omitempty"`
+	// Protected permissions, e.g. *:*, are created, changed and deleted only by system administrators
+	Protected bool `protobuf:"varint,18,opt,name=protected,proto3" json:"protected,omitempty" bson:"protected,omitempty"`
+	// Incremented on every update, an update must carry the version it was read at
+	Version       int64 `protobuf:"varint,19,opt,name=version,proto3" json:"version" bson:"version"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Permission) Reset() {
@@ -241,6 +248,27 @@ func (x *Permission) GetMetadata() *PermissionMetadata {
 	return nil
 }
 
+func (x *Permission) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *Permission) GetProtected() bool {
+	if x != nil {
+		return x.Protected
+	}
+	return false
+}
+
+func (x *Permission) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type PermissionMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Module        string                 `protobuf:"bytes,1,opt,name=module,proto3" json:"module" bson:"module"`
@@ -297,19 +325,18 @@ var File_auth_v1_permission_proto protoreflect.FileDescriptor
 
 const file_auth_v1_permission_proto_rawDesc = "" +
 	"\n" +
-	"\x18auth/v1/permission.proto\x12\aauth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xbf\n" +
-	"\n" +
+	"\x18auth/v1/permission.proto\x12\aauth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xdb\r\n" +
 	"\n" +
-	"Permission\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
-	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12@\n" +
-	"\bresource\x18\x03 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"resource\" json:\"resource\"R\bresource\x128\n" +
-	"\x06action\x18\x04 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"action\" json:\"action\"R\x06action\x12c\n" +
-	"\x11permission_string\x18\x05 \x01(\tB6\x9a\x84\x9e\x031bson:\"permission_string\" json:\"permission_string\"R\x10permissionString\x12O\n" +
-	"\fdisplay_name\x18\x06 \x01(\tB,\x9a\x84\x9e\x03'bson:\"display_name\" json:\"display_name\"R\vdisplayName\x12L\n" +
+	"Permission\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12T\n" +
+	"\bresource\x18\x03 \x01(\tB8\x9a\x84\x9e\x033bson:\"resource\" json:\"resource\" validate:\"required\"R\bresource\x12L\n" +
+	"\x06action\x18\x04 \x01(\tB4\x9a\x84\x9e\x03/bson:\"action\" json:\"action\" validate:\"required\"R\x06action\x12w\n" +
+	"\x11permission_string\x18\x05 \x01(\tBJ\x9a\x84\x9e\x03Ebson:\"permission_string\" json:\"permission_string\" validate:\"required\"R\x10permissionString\x12c\n" +
+	"\fdisplay_name\x18\x06 \x01(\tB@\x9a\x84\x9e\x03;bson:\"display_name\" json:\"display_name\" validate:\"required\"R\vdisplayName\x12L\n" +
 	"\vdescription\x18\a \x01(\tB*\x9a\x84\x9e\x03%bson:\"description\" json:\"description\"R\vdescription\x12@\n" +
-	"\bcategory\x18\b \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"category\" json:\"category\"R\bcategory\x12S\n" +
-	"\x06status\x18\t \x01(\x0e2\x19.auth.v1.PermissionStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12O\n" +
+	"\bcategory\x18\b \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"category\" json:\"category\"R\bcategory\x12g\n" +
+	"\x06status\x18\t \x01(\x0e2\x19.auth.v1.PermissionStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12O\n" +
 	"\fis_dangerous\x18\n" +
 	" \x01(\bB,\x9a\x84\x9e\x03'bson:\"is_dangerous\" json:\"is_dangerous\"R\visDangerous\x12c\n" +
 	"\x11requires_approval\x18\v \x01(\bB6\x9a\x84\x9e\x031bson:\"requires_approval\" json:\"requires_approval\"R\x10requiresApproval\x12d\n" +
@@ -317,10 +344,13 @@ const file_auth_v1_permission_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
-	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12G\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12[\n" +
 	"\n" +
-	"created_by\x18\x0f \x01(\tB(\x9a\x84\x9e\x03#bson:\"created_by\" json:\"created_by\"R\tcreatedBy\x12q\n" +
-	"\bmetadata\x18\x10 \x01(\v2\x1b.auth.v1.PermissionMetadataB8\x9a\x84\x9e\x033bson:\"metadata,omitempty\" json:\"metadata,omitempty\"R\bmetadata\"\x8f\x01\n" +
+	"created_by\x18\x0f \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12q\n" +
+	"\bmetadata\x18\x10 \x01(\v2\x1b.auth.v1.PermissionMetadataB8\x9a\x84\x9e\x033bson:\"metadata,omitempty\" json:\"metadata,omitempty\"R\bmetadata\x12X\n" +
+	"\tcondition\x18\x11 \x01(\tB:\x9a\x84\x9e\x035bson:\"condition,omitempty\" json:\"condition,omitempty\"R\tcondition\x12X\n" +
+	"\tprotected\x18\x12 \x01(\bB:\x9a\x84\x9e\x035bson:\"protected,omitempty\" json:\"protected,omitempty\"R\tprotected\x12<\n" +
+	"\aversion\x18\x13 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\"\x8f\x01\n" +
 	"\x12PermissionMetadata\x128\n" +
 	"\x06module\x18\x01 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"module\" json:\"module\"R\x06module\x12?\n" +
 	"\bui_group\x18\x02 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"ui_group\" json:\"ui_group\"R\auiGroup*\x94\x01\n" +
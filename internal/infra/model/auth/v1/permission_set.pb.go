@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/permission_set.proto
+
+package authv1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PermissionSet model for MongoDB auth_db.permission_sets collection, a named bundle of permissions attached to
+// roles as a whole
+type PermissionSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name" bson:"name" validate:"required"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description" bson:"description"`
+	Permissions   []string               `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions" bson:"permissions" validate:"required,min=1"` // Permission IDs
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PermissionSet) Reset() {
+	*x = PermissionSet{}
+	mi := &file_auth_v1_permission_set_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionSet) ProtoMessage() {}
+
+func (x *PermissionSet) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_permission_set_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionSet.ProtoReflect.Descriptor instead.
+func (*PermissionSet) Descriptor() ([]byte, []int) {
+	return file_auth_v1_permission_set_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PermissionSet) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PermissionSet) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *PermissionSet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PermissionSet) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PermissionSet) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *PermissionSet) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PermissionSet) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PermissionSet) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+var File_auth_v1_permission_set_proto protoreflect.FileDescriptor
+
+const file_auth_v1_permission_set_proto_rawDesc = "" +
+	"\n" +
+	"\x1cauth/v1/permission_set.proto\x12\aauth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xde\x05\n" +
+	"\rPermissionSet\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12D\n" +
+	"\x04name\x18\x03 \x01(\tB0\x9a\x84\x9e\x03+bson:\"name\" json:\"name\" validate:\"required\"R\x04name\x12L\n" +
+	"\vdescription\x18\x04 \x01(\tB*\x9a\x84\x9e\x03%bson:\"description\" json:\"description\"R\vdescription\x12f\n" +
+	"\vpermissions\x18\x05 \x03(\tBD\x9a\x84\x9e\x03?bson:\"permissions\" json:\"permissions\" validate:\"required,min=1\"R\vpermissions\x12c\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12[\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedByB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+
+var (
+	file_auth_v1_permission_set_proto_rawDescOnce sync.Once
+	file_auth_v1_permission_set_proto_rawDescData []byte
+)
+
+func file_auth_v1_permission_set_proto_rawDescGZIP() []byte {
+	file_auth_v1_permission_set_proto_rawDescOnce.Do(func() {
+		file_auth_v1_permission_set_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_permission_set_proto_rawDesc), len(file_auth_v1_permission_set_proto_rawDesc)))
+	})
+	return file_auth_v1_permission_set_proto_rawDescData
+}
+
+var file_auth_v1_permission_set_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_permission_set_proto_goTypes = []any{
+	(*PermissionSet)(nil),         // 0: auth.v1.PermissionSet
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_auth_v1_permission_set_proto_depIdxs = []int32{
+	1, // 0: auth.v1.PermissionSet.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: auth.v1.PermissionSet.updated_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_permission_set_proto_init() }
+func file_auth_v1_permission_set_proto_init() {
+	if File_auth_v1_permission_set_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_permission_set_proto_rawDesc), len(file_auth_v1_permission_set_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_v1_permission_set_proto_goTypes,
+		DependencyIndexes: file_auth_v1_permission_set_proto_depIdxs,
+		MessageInfos:      file_auth_v1_permission_set_proto_msgTypes,
+	}.Build()
+	File_auth_v1_permission_set_proto = out.File
+	file_auth_v1_permission_set_proto_goTypes = nil
+	file_auth_v1_permission_set_proto_depIdxs = nil
+}
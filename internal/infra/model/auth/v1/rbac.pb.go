@@ -8,6 +8,7 @@ package authv1
 
 import (
 	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -145,8 +146,8 @@ func (x *RemoveRolesRequest) GetRemovedBy() string {
 // Role Service Messages
 type CreateRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"` // Requestor identity
-	Role          *Role                  `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`             // Role data to create
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"` // Requestor identity
+	Role          *Role                  `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`                                      // Role data to create
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -241,8 +242,8 @@ func (x *CreateRoleResponse) GetRoleId() string {
 
 type UpdateRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"` // Requestor identity
-	Role          *Role                  `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`             // Role data to update
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"` // Requestor identity
+	Role          *Role                  `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`                                      // Role data to update
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -293,9 +294,9 @@ func (x *UpdateRoleRequest) GetRole() *Role {
 
 type GetRoleRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                 // Requestor identity
-	RoleId         string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`                           // Role ID to retrieve
-	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"` // Target tenant (for cross-tenant operations)
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                            // Requestor identity
+	RoleId         string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" validate:"required"`                           // Role ID to retrieve
+	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"` // Target tenant (for cross-tenant operations)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -353,8 +354,8 @@ func (x *GetRoleRequest) GetTargetTenantId() string {
 
 type ListRolesRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                 // Requestor identity
-	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"` // Target tenant (for cross-tenant operations)
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                            // Requestor identity
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"` // Target tenant (for cross-tenant operations)
 	Pagination     *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3,oneof" json:"pagination,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -465,9 +466,9 @@ func (x *ListRolesResponse) GetPagination() *v1.PaginationResponse {
 
 type DeleteRoleRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                 // Requestor identity
-	RoleId         string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`                           // Role ID to delete
-	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"` // Target tenant (for cross-tenant operations)
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                            // Requestor identity
+	RoleId         string                 `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty" validate:"required"`                           // Role ID to delete
+	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"` // Target tenant (for cross-tenant operations)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -526,8 +527,8 @@ func (x *DeleteRoleRequest) GetTargetTenantId() string {
 // Permission Service Messages
 type CreatePermissionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"` // Requestor identity
-	Permission    *Permission            `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"` // Permission data to create
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"` // Requestor identity
+	Permission    *Permission            `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`                          // Permission data to create
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -622,8 +623,8 @@ func (x *CreatePermissionResponse) GetPermissionId() string {
 
 type UpdatePermissionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"` // Requestor identity
-	Permission    *Permission            `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"` // Permission data to update
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"` // Requestor identity
+	Permission    *Permission            `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`                          // Permission data to update
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -674,9 +675,9 @@ func (x *UpdatePermissionRequest) GetPermission() *Permission {
 
 type GetPermissionRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                 // Requestor identity
-	PermissionId   string                 `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`         // Permission ID to retrieve
-	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"` // Target tenant (for cross-tenant operations)
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                            // Requestor identity
+	PermissionId   string                 `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty" validate:"required"`         // Permission ID to retrieve
+	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"` // Target tenant (for cross-tenant operations)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -734,8 +735,8 @@ func (x *GetPermissionRequest) GetTargetTenantId() string {
 
 type ListPermissionsRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                 // Requestor identity
-	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"` // Target tenant (for cross-tenant operations)
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                            // Requestor identity
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"` // Target tenant (for cross-tenant operations)
 	Pagination     *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3,oneof" json:"pagination,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -846,9 +847,9 @@ func (x *ListPermissionsResponse) GetPagination() *v1.PaginationResponse {
 
 type DeletePermissionRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                 // Requestor identity
-	PermissionId   string                 `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`         // Permission ID to delete
-	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"` // Target tenant (for cross-tenant operations)
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                            // Requestor identity
+	PermissionId   string                 `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty" validate:"required"`         // Permission ID to delete
+	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"` // Target tenant (for cross-tenant operations)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -904,18 +905,383 @@ func (x *DeletePermissionRequest) GetTargetTenantId() string {
 	return ""
 }
 
+// Permission Set Messages
+type CreatePermissionSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`   // Requestor identity
+	PermissionSet *PermissionSet         `protobuf:"bytes,2,opt,name=permission_set,json=permissionSet,proto3" json:"permission_set,omitempty"` // Permission set data to create
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePermissionSetRequest) Reset() {
+	*x = CreatePermissionSetRequest{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePermissionSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePermissionSetRequest) ProtoMessage() {}
+
+func (x *CreatePermissionSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePermissionSetRequest.ProtoReflect.Descriptor instead.
+func (*CreatePermissionSetRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreatePermissionSetRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreatePermissionSetRequest) GetPermissionSet() *PermissionSet {
+	if x != nil {
+		return x.PermissionSet
+	}
+	return nil
+}
+
+type CreatePermissionSetResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PermissionSetId string                 `protobuf:"bytes,1,opt,name=permission_set_id,json=permissionSetId,proto3" json:"permission_set_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePermissionSetResponse) Reset() {
+	*x = CreatePermissionSetResponse{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePermissionSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePermissionSetResponse) ProtoMessage() {}
+
+func (x *CreatePermissionSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePermissionSetResponse.ProtoReflect.Descriptor instead.
+func (*CreatePermissionSetResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreatePermissionSetResponse) GetPermissionSetId() string {
+	if x != nil {
+		return x.PermissionSetId
+	}
+	return ""
+}
+
+type UpdatePermissionSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`   // Requestor identity
+	PermissionSet *PermissionSet         `protobuf:"bytes,2,opt,name=permission_set,json=permissionSet,proto3" json:"permission_set,omitempty"` // Permission set data to update
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePermissionSetRequest) Reset() {
+	*x = UpdatePermissionSetRequest{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePermissionSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePermissionSetRequest) ProtoMessage() {}
+
+func (x *UpdatePermissionSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePermissionSetRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePermissionSetRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdatePermissionSetRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdatePermissionSetRequest) GetPermissionSet() *PermissionSet {
+	if x != nil {
+		return x.PermissionSet
+	}
+	return nil
+}
+
+type GetPermissionSetRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Identifier      *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                               // Requestor identity
+	PermissionSetId string                 `protobuf:"bytes,2,opt,name=permission_set_id,json=permissionSetId,proto3" json:"permission_set_id,omitempty" validate:"required"` // Permission set ID to retrieve
+	TargetTenantId  string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"`    // Target tenant (for cross-tenant operations)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetPermissionSetRequest) Reset() {
+	*x = GetPermissionSetRequest{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPermissionSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPermissionSetRequest) ProtoMessage() {}
+
+func (x *GetPermissionSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPermissionSetRequest.ProtoReflect.Descriptor instead.
+func (*GetPermissionSetRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPermissionSetRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetPermissionSetRequest) GetPermissionSetId() string {
+	if x != nil {
+		return x.PermissionSetId
+	}
+	return ""
+}
+
+func (x *GetPermissionSetRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+type ListPermissionSetsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                            // Requestor identity
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"` // Target tenant (for cross-tenant operations)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPermissionSetsRequest) Reset() {
+	*x = ListPermissionSetsRequest{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPermissionSetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPermissionSetsRequest) ProtoMessage() {}
+
+func (x *ListPermissionSetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPermissionSetsRequest.ProtoReflect.Descriptor instead.
+func (*ListPermissionSetsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPermissionSetsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListPermissionSetsRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+type ListPermissionSetsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PermissionSets []*PermissionSet       `protobuf:"bytes,1,rep,name=permission_sets,json=permissionSets,proto3" json:"permission_sets,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPermissionSetsResponse) Reset() {
+	*x = ListPermissionSetsResponse{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPermissionSetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPermissionSetsResponse) ProtoMessage() {}
+
+func (x *ListPermissionSetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPermissionSetsResponse.ProtoReflect.Descriptor instead.
+func (*ListPermissionSetsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListPermissionSetsResponse) GetPermissionSets() []*PermissionSet {
+	if x != nil {
+		return x.PermissionSets
+	}
+	return nil
+}
+
+type DeletePermissionSetRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Identifier      *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                               // Requestor identity
+	PermissionSetId string                 `protobuf:"bytes,2,opt,name=permission_set_id,json=permissionSetId,proto3" json:"permission_set_id,omitempty" validate:"required"` // Permission set ID to delete, it is detached from the roles
+	TargetTenantId  string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"`    // Target tenant (for cross-tenant operations)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeletePermissionSetRequest) Reset() {
+	*x = DeletePermissionSetRequest{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePermissionSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePermissionSetRequest) ProtoMessage() {}
+
+func (x *DeletePermissionSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePermissionSetRequest.ProtoReflect.Descriptor instead.
+func (*DeletePermissionSetRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeletePermissionSetRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DeletePermissionSetRequest) GetPermissionSetId() string {
+	if x != nil {
+		return x.PermissionSetId
+	}
+	return ""
+}
+
+func (x *DeletePermissionSetRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
 // Verification Service Messages
 type CheckPermissionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`   // User to check
-	Permissions   []string               `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"` // Permissions to check
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"` // User to check
+	Permissions   []string               `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`                        // Permissions to check
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CheckPermissionsRequest) Reset() {
 	*x = CheckPermissionsRequest{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[16]
+	mi := &file_auth_v1_rbac_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -927,7 +1293,7 @@ func (x *CheckPermissionsRequest) String() string {
 func (*CheckPermissionsRequest) ProtoMessage() {}
 
 func (x *CheckPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[16]
+	mi := &file_auth_v1_rbac_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -940,7 +1306,7 @@ func (x *CheckPermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckPermissionsRequest.ProtoReflect.Descriptor instead.
 func (*CheckPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{16}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CheckPermissionsRequest) GetIdentifier() *v1.UserIdentifier {
@@ -966,7 +1332,7 @@ type CheckPermissionsResponse struct {
 
 func (x *CheckPermissionsResponse) Reset() {
 	*x = CheckPermissionsResponse{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[17]
+	mi := &file_auth_v1_rbac_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -978,7 +1344,7 @@ func (x *CheckPermissionsResponse) String() string {
 func (*CheckPermissionsResponse) ProtoMessage() {}
 
 func (x *CheckPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[17]
+	mi := &file_auth_v1_rbac_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -991,7 +1357,7 @@ func (x *CheckPermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckPermissionsResponse.ProtoReflect.Descriptor instead.
 func (*CheckPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{17}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CheckPermissionsResponse) GetPermissions() map[string]bool {
@@ -1003,16 +1369,17 @@ func (x *CheckPermissionsResponse) GetPermissions() map[string]bool {
 
 type HasPermissionRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                 // User to check
-	Permission     string                 `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`                                 // Permission to check
-	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"` // Target tenant (for cross-tenant operations)
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                                                  // User to check
+	Permission     string                 `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty" validate:"required"`                                                       // Permission to check
+	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty" validate:"required"`                       // Target tenant (for cross-tenant operations)
+	Attributes     map[string]string      `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Request attributes the permission conditions are evaluated against
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *HasPermissionRequest) Reset() {
 	*x = HasPermissionRequest{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[18]
+	mi := &file_auth_v1_rbac_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1024,7 +1391,7 @@ func (x *HasPermissionRequest) String() string {
 func (*HasPermissionRequest) ProtoMessage() {}
 
 func (x *HasPermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[18]
+	mi := &file_auth_v1_rbac_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1037,7 +1404,7 @@ func (x *HasPermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasPermissionRequest.ProtoReflect.Descriptor instead.
 func (*HasPermissionRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{18}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *HasPermissionRequest) GetIdentifier() *v1.UserIdentifier {
@@ -1061,6 +1428,13 @@ func (x *HasPermissionRequest) GetTargetTenantId() string {
 	return ""
 }
 
+func (x *HasPermissionRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
 type HasPermissionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	HasPermission bool                   `protobuf:"varint,1,opt,name=has_permission,json=hasPermission,proto3" json:"has_permission,omitempty"`
@@ -1070,7 +1444,7 @@ type HasPermissionResponse struct {
 
 func (x *HasPermissionResponse) Reset() {
 	*x = HasPermissionResponse{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[19]
+	mi := &file_auth_v1_rbac_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1082,7 +1456,7 @@ func (x *HasPermissionResponse) String() string {
 func (*HasPermissionResponse) ProtoMessage() {}
 
 func (x *HasPermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[19]
+	mi := &file_auth_v1_rbac_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1095,7 +1469,7 @@ func (x *HasPermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HasPermissionResponse.ProtoReflect.Descriptor instead.
 func (*HasPermissionResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{19}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *HasPermissionResponse) GetHasPermission() bool {
@@ -1107,14 +1481,14 @@ func (x *HasPermissionResponse) GetHasPermission() bool {
 
 type GetUserPermissionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"` // User to check
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"` // User to check
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserPermissionsRequest) Reset() {
 	*x = GetUserPermissionsRequest{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[20]
+	mi := &file_auth_v1_rbac_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1126,7 +1500,7 @@ func (x *GetUserPermissionsRequest) String() string {
 func (*GetUserPermissionsRequest) ProtoMessage() {}
 
 func (x *GetUserPermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[20]
+	mi := &file_auth_v1_rbac_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1139,7 +1513,7 @@ func (x *GetUserPermissionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPermissionsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserPermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{20}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetUserPermissionsRequest) GetIdentifier() *v1.UserIdentifier {
@@ -1158,7 +1532,7 @@ type GetUserPermissionsResponse struct {
 
 func (x *GetUserPermissionsResponse) Reset() {
 	*x = GetUserPermissionsResponse{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[21]
+	mi := &file_auth_v1_rbac_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1170,7 +1544,7 @@ func (x *GetUserPermissionsResponse) String() string {
 func (*GetUserPermissionsResponse) ProtoMessage() {}
 
 func (x *GetUserPermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[21]
+	mi := &file_auth_v1_rbac_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1183,7 +1557,7 @@ func (x *GetUserPermissionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPermissionsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserPermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{21}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetUserPermissionsResponse) GetPermissions() map[string]bool {
@@ -1195,14 +1569,14 @@ func (x *GetUserPermissionsResponse) GetPermissions() map[string]bool {
 
 type GetUserRolesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"` // User to check
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"` // User to check
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserRolesRequest) Reset() {
 	*x = GetUserRolesRequest{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[22]
+	mi := &file_auth_v1_rbac_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1214,7 +1588,7 @@ func (x *GetUserRolesRequest) String() string {
 func (*GetUserRolesRequest) ProtoMessage() {}
 
 func (x *GetUserRolesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[22]
+	mi := &file_auth_v1_rbac_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1601,7 @@ func (x *GetUserRolesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRolesRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRolesRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{22}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetUserRolesRequest) GetIdentifier() *v1.UserIdentifier {
@@ -1246,7 +1620,7 @@ type GetUserRolesResponse struct {
 
 func (x *GetUserRolesResponse) Reset() {
 	*x = GetUserRolesResponse{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[23]
+	mi := &file_auth_v1_rbac_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1258,7 +1632,7 @@ func (x *GetUserRolesResponse) String() string {
 func (*GetUserRolesResponse) ProtoMessage() {}
 
 func (x *GetUserRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[23]
+	mi := &file_auth_v1_rbac_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1271,7 +1645,7 @@ func (x *GetUserRolesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRolesResponse.ProtoReflect.Descriptor instead.
 func (*GetUserRolesResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{23}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GetUserRolesResponse) GetRoleIds() []string {
@@ -1281,6 +1655,195 @@ func (x *GetUserRolesResponse) GetRoleIds() []string {
 	return nil
 }
 
+// PermissionCheck is a permission checked by VerifyPermissions
+type PermissionCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"` // Resource type, e.g. "user"
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`     // Action on the resource, e.g. "read"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PermissionCheck) Reset() {
+	*x = PermissionCheck{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionCheck) ProtoMessage() {}
+
+func (x *PermissionCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionCheck.ProtoReflect.Descriptor instead.
+func (*PermissionCheck) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PermissionCheck) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *PermissionCheck) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type VerifyPermissionsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`                                                  // User to check
+	Checks         []*PermissionCheck     `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`                                                                                   // Permissions to check
+	RoleNames      []string               `protobuf:"bytes,3,rep,name=role_names,json=roleNames,proto3" json:"role_names,omitempty"`                                                            // Role names to check
+	TargetTenantId string                 `protobuf:"bytes,4,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`                                           // Target tenant (for cross-tenant operations)
+	Attributes     map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Request attributes the permission conditions are evaluated against
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyPermissionsRequest) Reset() {
+	*x = VerifyPermissionsRequest{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPermissionsRequest) ProtoMessage() {}
+
+func (x *VerifyPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *VerifyPermissionsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *VerifyPermissionsRequest) GetChecks() []*PermissionCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *VerifyPermissionsRequest) GetRoleNames() []string {
+	if x != nil {
+		return x.RoleNames
+	}
+	return nil
+}
+
+func (x *VerifyPermissionsRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *VerifyPermissionsRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type VerifyPermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`                                                                                   // The user holds every permission and role checked
+	Permissions   map[string]bool        `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Map of "resource:action" -> has_permission
+	Roles         map[string]bool        `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`             // Map of role name -> has_role
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPermissionsResponse) Reset() {
+	*x = VerifyPermissionsResponse{}
+	mi := &file_auth_v1_rbac_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPermissionsResponse) ProtoMessage() {}
+
+func (x *VerifyPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_rbac_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPermissionsResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VerifyPermissionsResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *VerifyPermissionsResponse) GetPermissions() map[string]bool {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *VerifyPermissionsResponse) GetRoles() map[string]bool {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
 type IsSystemTenantUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"` // Tenant ID to check
@@ -1290,7 +1853,7 @@ type IsSystemTenantUserRequest struct {
 
 func (x *IsSystemTenantUserRequest) Reset() {
 	*x = IsSystemTenantUserRequest{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[24]
+	mi := &file_auth_v1_rbac_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1302,7 +1865,7 @@ func (x *IsSystemTenantUserRequest) String() string {
 func (*IsSystemTenantUserRequest) ProtoMessage() {}
 
 func (x *IsSystemTenantUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[24]
+	mi := &file_auth_v1_rbac_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1315,7 +1878,7 @@ func (x *IsSystemTenantUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsSystemTenantUserRequest.ProtoReflect.Descriptor instead.
 func (*IsSystemTenantUserRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{24}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *IsSystemTenantUserRequest) GetTenantId() string {
@@ -1334,7 +1897,7 @@ type IsSystemTenantUserResponse struct {
 
 func (x *IsSystemTenantUserResponse) Reset() {
 	*x = IsSystemTenantUserResponse{}
-	mi := &file_auth_v1_rbac_proto_msgTypes[25]
+	mi := &file_auth_v1_rbac_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1346,7 +1909,7 @@ func (x *IsSystemTenantUserResponse) String() string {
 func (*IsSystemTenantUserResponse) ProtoMessage() {}
 
 func (x *IsSystemTenantUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_rbac_proto_msgTypes[25]
+	mi := &file_auth_v1_rbac_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1359,7 +1922,7 @@ func (x *IsSystemTenantUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IsSystemTenantUserResponse.ProtoReflect.Descriptor instead.
 func (*IsSystemTenantUserResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{25}
+	return file_auth_v1_rbac_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *IsSystemTenantUserResponse) GetIsSystemTenant() bool {
@@ -1373,7 +1936,7 @@ var File_auth_v1_rbac_proto protoreflect.FileDescriptor
 
 const file_auth_v1_rbac_proto_rawDesc = "" +
 	"\n" +
-	"\x12auth/v1/rbac.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x12auth/v1/role.proto\x1a\x18auth/v1/permission.proto\"\x8a\x01\n" +
+	"\x12auth/v1/rbac.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x12auth/v1/role.proto\x1a\x18auth/v1/permission.proto\x1a\x1cauth/v1/permission_set.proto\x1a\x13tagger/tagger.proto\"\x8a\x01\n" +
 	"\x12AssignRolesRequest\x128\n" +
 	"\n" +
 	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
@@ -1387,30 +1950,30 @@ const file_auth_v1_rbac_proto_rawDesc = "" +
 	"identifier\x12\x19\n" +
 	"\brole_ids\x18\x02 \x03(\tR\aroleIds\x12\x1d\n" +
 	"\n" +
-	"removed_by\x18\x03 \x01(\tR\tremovedBy\"p\n" +
-	"\x11CreateRoleRequest\x128\n" +
+	"removed_by\x18\x03 \x01(\tR\tremovedBy\"\x8f\x01\n" +
+	"\x11CreateRoleRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12!\n" +
 	"\x04role\x18\x02 \x01(\v2\r.auth.v1.RoleR\x04role\"-\n" +
 	"\x12CreateRoleResponse\x12\x17\n" +
-	"\arole_id\x18\x01 \x01(\tR\x06roleId\"p\n" +
-	"\x11UpdateRoleRequest\x128\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\"\x8f\x01\n" +
+	"\x11UpdateRoleRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12!\n" +
-	"\x04role\x18\x02 \x01(\v2\r.auth.v1.RoleR\x04role\"\x8d\x01\n" +
-	"\x0eGetRoleRequest\x128\n" +
+	"\x04role\x18\x02 \x01(\v2\r.auth.v1.RoleR\x04role\"\xe0\x01\n" +
+	"\x0eGetRoleRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12\x17\n" +
-	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12(\n" +
-	"\x10target_tenant_id\x18\x03 \x01(\tR\x0etargetTenantId\"\xc7\x01\n" +
-	"\x10ListRolesRequest\x128\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x121\n" +
+	"\arole_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06roleId\x12B\n" +
+	"\x10target_tenant_id\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\"\x80\x02\n" +
+	"\x10ListRolesRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12(\n" +
-	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12@\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12B\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\x12@\n" +
 	"\n" +
 	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestH\x00R\n" +
 	"pagination\x88\x01\x01B\r\n" +
@@ -1419,40 +1982,40 @@ const file_auth_v1_rbac_proto_rawDesc = "" +
 	"\x05roles\x18\x01 \x03(\v2\r.auth.v1.RoleR\x05roles\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
-	"pagination\"\x90\x01\n" +
-	"\x11DeleteRoleRequest\x128\n" +
+	"pagination\"\xe3\x01\n" +
+	"\x11DeleteRoleRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12\x17\n" +
-	"\arole_id\x18\x02 \x01(\tR\x06roleId\x12(\n" +
-	"\x10target_tenant_id\x18\x03 \x01(\tR\x0etargetTenantId\"\x88\x01\n" +
-	"\x17CreatePermissionRequest\x128\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x121\n" +
+	"\arole_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06roleId\x12B\n" +
+	"\x10target_tenant_id\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\"\xa7\x01\n" +
+	"\x17CreatePermissionRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x123\n" +
 	"\n" +
 	"permission\x18\x02 \x01(\v2\x13.auth.v1.PermissionR\n" +
 	"permission\"?\n" +
 	"\x18CreatePermissionResponse\x12#\n" +
-	"\rpermission_id\x18\x01 \x01(\tR\fpermissionId\"\x88\x01\n" +
-	"\x17UpdatePermissionRequest\x128\n" +
+	"\rpermission_id\x18\x01 \x01(\tR\fpermissionId\"\xa7\x01\n" +
+	"\x17UpdatePermissionRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x123\n" +
 	"\n" +
 	"permission\x18\x02 \x01(\v2\x13.auth.v1.PermissionR\n" +
-	"permission\"\x9f\x01\n" +
-	"\x14GetPermissionRequest\x128\n" +
+	"permission\"\xf2\x01\n" +
+	"\x14GetPermissionRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12#\n" +
-	"\rpermission_id\x18\x02 \x01(\tR\fpermissionId\x12(\n" +
-	"\x10target_tenant_id\x18\x03 \x01(\tR\x0etargetTenantId\"\xcd\x01\n" +
-	"\x16ListPermissionsRequest\x128\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12=\n" +
+	"\rpermission_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\fpermissionId\x12B\n" +
+	"\x10target_tenant_id\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\"\x86\x02\n" +
+	"\x16ListPermissionsRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12(\n" +
-	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12@\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12B\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\x12@\n" +
 	"\n" +
 	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestH\x00R\n" +
 	"pagination\x88\x01\x01B\r\n" +
@@ -1461,48 +2024,113 @@ const file_auth_v1_rbac_proto_rawDesc = "" +
 	"\vpermissions\x18\x01 \x03(\v2\x13.auth.v1.PermissionR\vpermissions\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
-	"pagination\"\xa2\x01\n" +
-	"\x17DeletePermissionRequest\x128\n" +
+	"pagination\"\xf5\x01\n" +
+	"\x17DeletePermissionRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12#\n" +
-	"\rpermission_id\x18\x02 \x01(\tR\fpermissionId\x12(\n" +
-	"\x10target_tenant_id\x18\x03 \x01(\tR\x0etargetTenantId\"u\n" +
-	"\x17CheckPermissionsRequest\x128\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12=\n" +
+	"\rpermission_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\fpermissionId\x12B\n" +
+	"\x10target_tenant_id\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\"\xb4\x01\n" +
+	"\x1aCreatePermissionSetRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12=\n" +
+	"\x0epermission_set\x18\x02 \x01(\v2\x16.auth.v1.PermissionSetR\rpermissionSet\"I\n" +
+	"\x1bCreatePermissionSetResponse\x12*\n" +
+	"\x11permission_set_id\x18\x01 \x01(\tR\x0fpermissionSetId\"\xb4\x01\n" +
+	"\x1aUpdatePermissionSetRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12=\n" +
+	"\x0epermission_set\x18\x02 \x01(\v2\x16.auth.v1.PermissionSetR\rpermissionSet\"\xfc\x01\n" +
+	"\x17GetPermissionSetRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12D\n" +
+	"\x11permission_set_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0fpermissionSetId\x12B\n" +
+	"\x10target_tenant_id\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\"\xb8\x01\n" +
+	"\x19ListPermissionSetsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12B\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\"]\n" +
+	"\x1aListPermissionSetsResponse\x12?\n" +
+	"\x0fpermission_sets\x18\x01 \x03(\v2\x16.auth.v1.PermissionSetR\x0epermissionSets\"\xff\x01\n" +
+	"\x1aDeletePermissionSetRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12D\n" +
+	"\x11permission_set_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0fpermissionSetId\x12B\n" +
+	"\x10target_tenant_id\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\"\x94\x01\n" +
+	"\x17CheckPermissionsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12 \n" +
 	"\vpermissions\x18\x02 \x03(\tR\vpermissions\"\xb0\x01\n" +
 	"\x18CheckPermissionsResponse\x12T\n" +
 	"\vpermissions\x18\x01 \x03(\v22.auth.v1.CheckPermissionsResponse.PermissionsEntryR\vpermissions\x1a>\n" +
 	"\x10PermissionsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\x9a\x01\n" +
-	"\x14HasPermissionRequest\x128\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"\xfb\x02\n" +
+	"\x14HasPermissionRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
-	"identifier\x12\x1e\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x128\n" +
 	"\n" +
-	"permission\x18\x02 \x01(\tR\n" +
-	"permission\x12(\n" +
-	"\x10target_tenant_id\x18\x03 \x01(\tR\x0etargetTenantId\">\n" +
+	"permission\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\n" +
+	"permission\x12B\n" +
+	"\x10target_tenant_id\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x0etargetTenantId\x12M\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\v2-.auth.v1.HasPermissionRequest.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
 	"\x15HasPermissionResponse\x12%\n" +
-	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission\"U\n" +
-	"\x19GetUserPermissionsRequest\x128\n" +
+	"\x0ehas_permission\x18\x01 \x01(\bR\rhasPermission\"t\n" +
+	"\x19GetUserPermissionsRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\"\xb4\x01\n" +
 	"\x1aGetUserPermissionsResponse\x12V\n" +
 	"\vpermissions\x18\x01 \x03(\v24.auth.v1.GetUserPermissionsResponse.PermissionsEntryR\vpermissions\x1a>\n" +
 	"\x10PermissionsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"O\n" +
-	"\x13GetUserRolesRequest\x128\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"n\n" +
+	"\x13GetUserRolesRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\"1\n" +
 	"\x14GetUserRolesResponse\x12\x19\n" +
-	"\brole_ids\x18\x01 \x03(\tR\aroleIds\"8\n" +
+	"\brole_ids\x18\x01 \x03(\tR\aroleIds\"E\n" +
+	"\x0fPermissionCheck\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\"\x80\x03\n" +
+	"\x18VerifyPermissionsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x120\n" +
+	"\x06checks\x18\x02 \x03(\v2\x18.auth.v1.PermissionCheckR\x06checks\x12\x1d\n" +
+	"\n" +
+	"role_names\x18\x03 \x03(\tR\troleNames\x12(\n" +
+	"\x10target_tenant_id\x18\x04 \x01(\tR\x0etargetTenantId\x12Q\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v21.auth.v1.VerifyPermissionsRequest.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcb\x02\n" +
+	"\x19VerifyPermissionsResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12U\n" +
+	"\vpermissions\x18\x02 \x03(\v23.auth.v1.VerifyPermissionsResponse.PermissionsEntryR\vpermissions\x12C\n" +
+	"\x05roles\x18\x03 \x03(\v2-.auth.v1.VerifyPermissionsResponse.RolesEntryR\x05roles\x1a>\n" +
+	"\x10PermissionsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"RolesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01\"8\n" +
 	"\x19IsSystemTenantUserRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"F\n" +
 	"\x1aIsSystemTenantUserResponse\x12(\n" +
@@ -1515,19 +2143,25 @@ const file_auth_v1_rbac_proto_rawDesc = "" +
 	"\aGetRole\x12\x17.auth.v1.GetRoleRequest\x1a\r.auth.v1.Role\x12B\n" +
 	"\tListRoles\x12\x19.auth.v1.ListRolesRequest\x1a\x1a.auth.v1.ListRolesResponse\x12<\n" +
 	"\n" +
-	"DeleteRole\x12\x1a.auth.v1.DeleteRoleRequest\x1a\x12.infra.v1.Response2\x9b\x03\n" +
+	"DeleteRole\x12\x1a.auth.v1.DeleteRoleRequest\x1a\x12.infra.v1.Response2\xca\x06\n" +
 	"\x11PermissionService\x12W\n" +
 	"\x10CreatePermission\x12 .auth.v1.CreatePermissionRequest\x1a!.auth.v1.CreatePermissionResponse\x12H\n" +
 	"\x10UpdatePermission\x12 .auth.v1.UpdatePermissionRequest\x1a\x12.infra.v1.Response\x12C\n" +
 	"\rGetPermission\x12\x1d.auth.v1.GetPermissionRequest\x1a\x13.auth.v1.Permission\x12T\n" +
 	"\x0fListPermissions\x12\x1f.auth.v1.ListPermissionsRequest\x1a .auth.v1.ListPermissionsResponse\x12H\n" +
-	"\x10DeletePermission\x12 .auth.v1.DeletePermissionRequest\x1a\x12.infra.v1.Response2\xc9\x03\n" +
+	"\x10DeletePermission\x12 .auth.v1.DeletePermissionRequest\x1a\x12.infra.v1.Response\x12`\n" +
+	"\x13CreatePermissionSet\x12#.auth.v1.CreatePermissionSetRequest\x1a$.auth.v1.CreatePermissionSetResponse\x12N\n" +
+	"\x13UpdatePermissionSet\x12#.auth.v1.UpdatePermissionSetRequest\x1a\x12.infra.v1.Response\x12L\n" +
+	"\x10GetPermissionSet\x12 .auth.v1.GetPermissionSetRequest\x1a\x16.auth.v1.PermissionSet\x12]\n" +
+	"\x12ListPermissionSets\x12\".auth.v1.ListPermissionSetsRequest\x1a#.auth.v1.ListPermissionSetsResponse\x12N\n" +
+	"\x13DeletePermissionSet\x12#.auth.v1.DeletePermissionSetRequest\x1a\x12.infra.v1.Response2\xa5\x04\n" +
 	"\x13VerificationService\x12W\n" +
 	"\x10CheckPermissions\x12 .auth.v1.CheckPermissionsRequest\x1a!.auth.v1.CheckPermissionsResponse\x12N\n" +
 	"\rHasPermission\x12\x1d.auth.v1.HasPermissionRequest\x1a\x1e.auth.v1.HasPermissionResponse\x12]\n" +
 	"\x12GetUserPermissions\x12\".auth.v1.GetUserPermissionsRequest\x1a#.auth.v1.GetUserPermissionsResponse\x12K\n" +
 	"\fGetUserRoles\x12\x1c.auth.v1.GetUserRolesRequest\x1a\x1d.auth.v1.GetUserRolesResponse\x12]\n" +
-	"\x12IsSystemTenantUser\x12\".auth.v1.IsSystemTenantUserRequest\x1a#.auth.v1.IsSystemTenantUserResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+	"\x12IsSystemTenantUser\x12\".auth.v1.IsSystemTenantUserRequest\x1a#.auth.v1.IsSystemTenantUserResponse\x12Z\n" +
+	"\x11VerifyPermissions\x12!.auth.v1.VerifyPermissionsRequest\x1a\".auth.v1.VerifyPermissionsResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
 
 var (
 	file_auth_v1_rbac_proto_rawDescOnce sync.Once
@@ -1541,107 +2175,148 @@ func file_auth_v1_rbac_proto_rawDescGZIP() []byte {
 	return file_auth_v1_rbac_proto_rawDescData
 }
 
-var file_auth_v1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_auth_v1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_auth_v1_rbac_proto_goTypes = []any{
-	(*AssignRolesRequest)(nil),         // 0: auth.v1.AssignRolesRequest
-	(*RemoveRolesRequest)(nil),         // 1: auth.v1.RemoveRolesRequest
-	(*CreateRoleRequest)(nil),          // 2: auth.v1.CreateRoleRequest
-	(*CreateRoleResponse)(nil),         // 3: auth.v1.CreateRoleResponse
-	(*UpdateRoleRequest)(nil),          // 4: auth.v1.UpdateRoleRequest
-	(*GetRoleRequest)(nil),             // 5: auth.v1.GetRoleRequest
-	(*ListRolesRequest)(nil),           // 6: auth.v1.ListRolesRequest
-	(*ListRolesResponse)(nil),          // 7: auth.v1.ListRolesResponse
-	(*DeleteRoleRequest)(nil),          // 8: auth.v1.DeleteRoleRequest
-	(*CreatePermissionRequest)(nil),    // 9: auth.v1.CreatePermissionRequest
-	(*CreatePermissionResponse)(nil),   // 10: auth.v1.CreatePermissionResponse
-	(*UpdatePermissionRequest)(nil),    // 11: auth.v1.UpdatePermissionRequest
-	(*GetPermissionRequest)(nil),       // 12: auth.v1.GetPermissionRequest
-	(*ListPermissionsRequest)(nil),     // 13: auth.v1.ListPermissionsRequest
-	(*ListPermissionsResponse)(nil),    // 14: auth.v1.ListPermissionsResponse
-	(*DeletePermissionRequest)(nil),    // 15: auth.v1.DeletePermissionRequest
-	(*CheckPermissionsRequest)(nil),    // 16: auth.v1.CheckPermissionsRequest
-	(*CheckPermissionsResponse)(nil),   // 17: auth.v1.CheckPermissionsResponse
-	(*HasPermissionRequest)(nil),       // 18: auth.v1.HasPermissionRequest
-	(*HasPermissionResponse)(nil),      // 19: auth.v1.HasPermissionResponse
-	(*GetUserPermissionsRequest)(nil),  // 20: auth.v1.GetUserPermissionsRequest
-	(*GetUserPermissionsResponse)(nil), // 21: auth.v1.GetUserPermissionsResponse
-	(*GetUserRolesRequest)(nil),        // 22: auth.v1.GetUserRolesRequest
-	(*GetUserRolesResponse)(nil),       // 23: auth.v1.GetUserRolesResponse
-	(*IsSystemTenantUserRequest)(nil),  // 24: auth.v1.IsSystemTenantUserRequest
-	(*IsSystemTenantUserResponse)(nil), // 25: auth.v1.IsSystemTenantUserResponse
-	nil,                                // 26: auth.v1.CheckPermissionsResponse.PermissionsEntry
-	nil,                                // 27: auth.v1.GetUserPermissionsResponse.PermissionsEntry
-	(*v1.UserIdentifier)(nil),          // 28: infra.v1.UserIdentifier
-	(*Role)(nil),                       // 29: auth.v1.Role
-	(*v1.PaginationRequest)(nil),       // 30: infra.v1.PaginationRequest
-	(*v1.PaginationResponse)(nil),      // 31: infra.v1.PaginationResponse
-	(*Permission)(nil),                 // 32: auth.v1.Permission
-	(*v1.Response)(nil),                // 33: infra.v1.Response
+	(*AssignRolesRequest)(nil),          // 0: auth.v1.AssignRolesRequest
+	(*RemoveRolesRequest)(nil),          // 1: auth.v1.RemoveRolesRequest
+	(*CreateRoleRequest)(nil),           // 2: auth.v1.CreateRoleRequest
+	(*CreateRoleResponse)(nil),          // 3: auth.v1.CreateRoleResponse
+	(*UpdateRoleRequest)(nil),           // 4: auth.v1.UpdateRoleRequest
+	(*GetRoleRequest)(nil),              // 5: auth.v1.GetRoleRequest
+	(*ListRolesRequest)(nil),            // 6: auth.v1.ListRolesRequest
+	(*ListRolesResponse)(nil),           // 7: auth.v1.ListRolesResponse
+	(*DeleteRoleRequest)(nil),           // 8: auth.v1.DeleteRoleRequest
+	(*CreatePermissionRequest)(nil),     // 9: auth.v1.CreatePermissionRequest
+	(*CreatePermissionResponse)(nil),    // 10: auth.v1.CreatePermissionResponse
+	(*UpdatePermissionRequest)(nil),     // 11: auth.v1.UpdatePermissionRequest
+	(*GetPermissionRequest)(nil),        // 12: auth.v1.GetPermissionRequest
+	(*ListPermissionsRequest)(nil),      // 13: auth.v1.ListPermissionsRequest
+	(*ListPermissionsResponse)(nil),     // 14: auth.v1.ListPermissionsResponse
+	(*DeletePermissionRequest)(nil),     // 15: auth.v1.DeletePermissionRequest
+	(*CreatePermissionSetRequest)(nil),  // 16: auth.v1.CreatePermissionSetRequest
+	(*CreatePermissionSetResponse)(nil), // 17: auth.v1.CreatePermissionSetResponse
+	(*UpdatePermissionSetRequest)(nil),  // 18: auth.v1.UpdatePermissionSetRequest
+	(*GetPermissionSetRequest)(nil),     // 19: auth.v1.GetPermissionSetRequest
+	(*ListPermissionSetsRequest)(nil),   // 20: auth.v1.ListPermissionSetsRequest
+	(*ListPermissionSetsResponse)(nil),  // 21: auth.v1.ListPermissionSetsResponse
+	(*DeletePermissionSetRequest)(nil),  // 22: auth.v1.DeletePermissionSetRequest
+	(*CheckPermissionsRequest)(nil),     // 23: auth.v1.CheckPermissionsRequest
+	(*CheckPermissionsResponse)(nil),    // 24: auth.v1.CheckPermissionsResponse
+	(*HasPermissionRequest)(nil),        // 25: auth.v1.HasPermissionRequest
+	(*HasPermissionResponse)(nil),       // 26: auth.v1.HasPermissionResponse
+	(*GetUserPermissionsRequest)(nil),   // 27: auth.v1.GetUserPermissionsRequest
+	(*GetUserPermissionsResponse)(nil),  // 28: auth.v1.GetUserPermissionsResponse
+	(*GetUserRolesRequest)(nil),         // 29: auth.v1.GetUserRolesRequest
+	(*GetUserRolesResponse)(nil),        // 30: auth.v1.GetUserRolesResponse
+	(*PermissionCheck)(nil),             // 31: auth.v1.PermissionCheck
+	(*VerifyPermissionsRequest)(nil),    // 32: auth.v1.VerifyPermissionsRequest
+	(*VerifyPermissionsResponse)(nil),   // 33: auth.v1.VerifyPermissionsResponse
+	(*IsSystemTenantUserRequest)(nil),   // 34: auth.v1.IsSystemTenantUserRequest
+	(*IsSystemTenantUserResponse)(nil),  // 35: auth.v1.IsSystemTenantUserResponse
+	nil,                                 // 36: auth.v1.CheckPermissionsResponse.PermissionsEntry
+	nil,                                 // 37: auth.v1.HasPermissionRequest.AttributesEntry
+	nil,                                 // 38: auth.v1.GetUserPermissionsResponse.PermissionsEntry
+	nil,                                 // 39: auth.v1.VerifyPermissionsRequest.AttributesEntry
+	nil,                                 // 40: auth.v1.VerifyPermissionsResponse.PermissionsEntry
+	nil,                                 // 41: auth.v1.VerifyPermissionsResponse.RolesEntry
+	(*v1.UserIdentifier)(nil),           // 42: infra.v1.UserIdentifier
+	(*Role)(nil),                        // 43: auth.v1.Role
+	(*v1.PaginationRequest)(nil),        // 44: infra.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),       // 45: infra.v1.PaginationResponse
+	(*Permission)(nil),                  // 46: auth.v1.Permission
+	(*PermissionSet)(nil),               // 47: auth.v1.PermissionSet
+	(*v1.Response)(nil),                 // 48: infra.v1.Response
 }
 var file_auth_v1_rbac_proto_depIdxs = []int32{
-	28, // 0: auth.v1.AssignRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
-	28, // 1: auth.v1.RemoveRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
-	28, // 2: auth.v1.CreateRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
-	29, // 3: auth.v1.CreateRoleRequest.role:type_name -> auth.v1.Role
-	28, // 4: auth.v1.UpdateRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
-	29, // 5: auth.v1.UpdateRoleRequest.role:type_name -> auth.v1.Role
-	28, // 6: auth.v1.GetRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
-	28, // 7: auth.v1.ListRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
-	30, // 8: auth.v1.ListRolesRequest.pagination:type_name -> infra.v1.PaginationRequest
-	29, // 9: auth.v1.ListRolesResponse.roles:type_name -> auth.v1.Role
-	31, // 10: auth.v1.ListRolesResponse.pagination:type_name -> infra.v1.PaginationResponse
-	28, // 11: auth.v1.DeleteRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
-	28, // 12: auth.v1.CreatePermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
-	32, // 13: auth.v1.CreatePermissionRequest.permission:type_name -> auth.v1.Permission
-	28, // 14: auth.v1.UpdatePermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
-	32, // 15: auth.v1.UpdatePermissionRequest.permission:type_name -> auth.v1.Permission
-	28, // 16: auth.v1.GetPermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
-	28, // 17: auth.v1.ListPermissionsRequest.identifier:type_name -> infra.v1.UserIdentifier
-	30, // 18: auth.v1.ListPermissionsRequest.pagination:type_name -> infra.v1.PaginationRequest
-	32, // 19: auth.v1.ListPermissionsResponse.permissions:type_name -> auth.v1.Permission
-	31, // 20: auth.v1.ListPermissionsResponse.pagination:type_name -> infra.v1.PaginationResponse
-	28, // 21: auth.v1.DeletePermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
-	28, // 22: auth.v1.CheckPermissionsRequest.identifier:type_name -> infra.v1.UserIdentifier
-	26, // 23: auth.v1.CheckPermissionsResponse.permissions:type_name -> auth.v1.CheckPermissionsResponse.PermissionsEntry
-	28, // 24: auth.v1.HasPermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
-	28, // 25: auth.v1.GetUserPermissionsRequest.identifier:type_name -> infra.v1.UserIdentifier
-	27, // 26: auth.v1.GetUserPermissionsResponse.permissions:type_name -> auth.v1.GetUserPermissionsResponse.PermissionsEntry
-	28, // 27: auth.v1.GetUserRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
-	2,  // 28: auth.v1.RoleService.CreateRole:input_type -> auth.v1.CreateRoleRequest
-	4,  // 29: auth.v1.RoleService.UpdateRole:input_type -> auth.v1.UpdateRoleRequest
-	5,  // 30: auth.v1.RoleService.GetRole:input_type -> auth.v1.GetRoleRequest
-	6,  // 31: auth.v1.RoleService.ListRoles:input_type -> auth.v1.ListRolesRequest
-	8,  // 32: auth.v1.RoleService.DeleteRole:input_type -> auth.v1.DeleteRoleRequest
-	9,  // 33: auth.v1.PermissionService.CreatePermission:input_type -> auth.v1.CreatePermissionRequest
-	11, // 34: auth.v1.PermissionService.UpdatePermission:input_type -> auth.v1.UpdatePermissionRequest
-	12, // 35: auth.v1.PermissionService.GetPermission:input_type -> auth.v1.GetPermissionRequest
-	13, // 36: auth.v1.PermissionService.ListPermissions:input_type -> auth.v1.ListPermissionsRequest
-	15, // 37: auth.v1.PermissionService.DeletePermission:input_type -> auth.v1.DeletePermissionRequest
-	16, // 38: auth.v1.VerificationService.CheckPermissions:input_type -> auth.v1.CheckPermissionsRequest
-	18, // 39: auth.v1.VerificationService.HasPermission:input_type -> auth.v1.HasPermissionRequest
-	20, // 40: auth.v1.VerificationService.GetUserPermissions:input_type -> auth.v1.GetUserPermissionsRequest
-	22, // 41: auth.v1.VerificationService.GetUserRoles:input_type -> auth.v1.GetUserRolesRequest
-	24, // 42: auth.v1.VerificationService.IsSystemTenantUser:input_type -> auth.v1.IsSystemTenantUserRequest
-	3,  // 43: auth.v1.RoleService.CreateRole:output_type -> auth.v1.CreateRoleResponse
-	33, // 44: auth.v1.RoleService.UpdateRole:output_type -> infra.v1.Response
-	29, // 45: auth.v1.RoleService.GetRole:output_type -> auth.v1.Role
-	7,  // 46: auth.v1.RoleService.ListRoles:output_type -> auth.v1.ListRolesResponse
-	33, // 47: auth.v1.RoleService.DeleteRole:output_type -> infra.v1.Response
-	10, // 48: auth.v1.PermissionService.CreatePermission:output_type -> auth.v1.CreatePermissionResponse
-	33, // 49: auth.v1.PermissionService.UpdatePermission:output_type -> infra.v1.Response
-	32, // 50: auth.v1.PermissionService.GetPermission:output_type -> auth.v1.Permission
-	14, // 51: auth.v1.PermissionService.ListPermissions:output_type -> auth.v1.ListPermissionsResponse
-	33, // 52: auth.v1.PermissionService.DeletePermission:output_type -> infra.v1.Response
-	17, // 53: auth.v1.VerificationService.CheckPermissions:output_type -> auth.v1.CheckPermissionsResponse
-	19, // 54: auth.v1.VerificationService.HasPermission:output_type -> auth.v1.HasPermissionResponse
-	21, // 55: auth.v1.VerificationService.GetUserPermissions:output_type -> auth.v1.GetUserPermissionsResponse
-	23, // 56: auth.v1.VerificationService.GetUserRoles:output_type -> auth.v1.GetUserRolesResponse
-	25, // 57: auth.v1.VerificationService.IsSystemTenantUser:output_type -> auth.v1.IsSystemTenantUserResponse
-	43, // [43:58] is the sub-list for method output_type
-	28, // [28:43] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	42, // 0: auth.v1.AssignRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 1: auth.v1.RemoveRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 2: auth.v1.CreateRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	43, // 3: auth.v1.CreateRoleRequest.role:type_name -> auth.v1.Role
+	42, // 4: auth.v1.UpdateRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	43, // 5: auth.v1.UpdateRoleRequest.role:type_name -> auth.v1.Role
+	42, // 6: auth.v1.GetRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 7: auth.v1.ListRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	44, // 8: auth.v1.ListRolesRequest.pagination:type_name -> infra.v1.PaginationRequest
+	43, // 9: auth.v1.ListRolesResponse.roles:type_name -> auth.v1.Role
+	45, // 10: auth.v1.ListRolesResponse.pagination:type_name -> infra.v1.PaginationResponse
+	42, // 11: auth.v1.DeleteRoleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 12: auth.v1.CreatePermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
+	46, // 13: auth.v1.CreatePermissionRequest.permission:type_name -> auth.v1.Permission
+	42, // 14: auth.v1.UpdatePermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
+	46, // 15: auth.v1.UpdatePermissionRequest.permission:type_name -> auth.v1.Permission
+	42, // 16: auth.v1.GetPermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 17: auth.v1.ListPermissionsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	44, // 18: auth.v1.ListPermissionsRequest.pagination:type_name -> infra.v1.PaginationRequest
+	46, // 19: auth.v1.ListPermissionsResponse.permissions:type_name -> auth.v1.Permission
+	45, // 20: auth.v1.ListPermissionsResponse.pagination:type_name -> infra.v1.PaginationResponse
+	42, // 21: auth.v1.DeletePermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 22: auth.v1.CreatePermissionSetRequest.identifier:type_name -> infra.v1.UserIdentifier
+	47, // 23: auth.v1.CreatePermissionSetRequest.permission_set:type_name -> auth.v1.PermissionSet
+	42, // 24: auth.v1.UpdatePermissionSetRequest.identifier:type_name -> infra.v1.UserIdentifier
+	47, // 25: auth.v1.UpdatePermissionSetRequest.permission_set:type_name -> auth.v1.PermissionSet
+	42, // 26: auth.v1.GetPermissionSetRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 27: auth.v1.ListPermissionSetsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	47, // 28: auth.v1.ListPermissionSetsResponse.permission_sets:type_name -> auth.v1.PermissionSet
+	42, // 29: auth.v1.DeletePermissionSetRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 30: auth.v1.CheckPermissionsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	36, // 31: auth.v1.CheckPermissionsResponse.permissions:type_name -> auth.v1.CheckPermissionsResponse.PermissionsEntry
+	42, // 32: auth.v1.HasPermissionRequest.identifier:type_name -> infra.v1.UserIdentifier
+	37, // 33: auth.v1.HasPermissionRequest.attributes:type_name -> auth.v1.HasPermissionRequest.AttributesEntry
+	42, // 34: auth.v1.GetUserPermissionsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	38, // 35: auth.v1.GetUserPermissionsResponse.permissions:type_name -> auth.v1.GetUserPermissionsResponse.PermissionsEntry
+	42, // 36: auth.v1.GetUserRolesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	42, // 37: auth.v1.VerifyPermissionsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	31, // 38: auth.v1.VerifyPermissionsRequest.checks:type_name -> auth.v1.PermissionCheck
+	39, // 39: auth.v1.VerifyPermissionsRequest.attributes:type_name -> auth.v1.VerifyPermissionsRequest.AttributesEntry
+	40, // 40: auth.v1.VerifyPermissionsResponse.permissions:type_name -> auth.v1.VerifyPermissionsResponse.PermissionsEntry
+	41, // 41: auth.v1.VerifyPermissionsResponse.roles:type_name -> auth.v1.VerifyPermissionsResponse.RolesEntry
+	2,  // 42: auth.v1.RoleService.CreateRole:input_type -> auth.v1.CreateRoleRequest
+	4,  // 43: auth.v1.RoleService.UpdateRole:input_type -> auth.v1.UpdateRoleRequest
+	5,  // 44: auth.v1.RoleService.GetRole:input_type -> auth.v1.GetRoleRequest
+	6,  // 45: auth.v1.RoleService.ListRoles:input_type -> auth.v1.ListRolesRequest
+	8,  // 46: auth.v1.RoleService.DeleteRole:input_type -> auth.v1.DeleteRoleRequest
+	9,  // 47: auth.v1.PermissionService.CreatePermission:input_type -> auth.v1.CreatePermissionRequest
+	11, // 48: auth.v1.PermissionService.UpdatePermission:input_type -> auth.v1.UpdatePermissionRequest
+	12, // 49: auth.v1.PermissionService.GetPermission:input_type -> auth.v1.GetPermissionRequest
+	13, // 50: auth.v1.PermissionService.ListPermissions:input_type -> auth.v1.ListPermissionsRequest
+	15, // 51: auth.v1.PermissionService.DeletePermission:input_type -> auth.v1.DeletePermissionRequest
+	16, // 52: auth.v1.PermissionService.CreatePermissionSet:input_type -> auth.v1.CreatePermissionSetRequest
+	18, // 53: auth.v1.PermissionService.UpdatePermissionSet:input_type -> auth.v1.UpdatePermissionSetRequest
+	19, // 54: auth.v1.PermissionService.GetPermissionSet:input_type -> auth.v1.GetPermissionSetRequest
+	20, // 55: auth.v1.PermissionService.ListPermissionSets:input_type -> auth.v1.ListPermissionSetsRequest
+	22, // 56: auth.v1.PermissionService.DeletePermissionSet:input_type -> auth.v1.DeletePermissionSetRequest
+	23, // 57: auth.v1.VerificationService.CheckPermissions:input_type -> auth.v1.CheckPermissionsRequest
+	25, // 58: auth.v1.VerificationService.HasPermission:input_type -> auth.v1.HasPermissionRequest
+	27, // 59: auth.v1.VerificationService.GetUserPermissions:input_type -> auth.v1.GetUserPermissionsRequest
+	29, // 60: auth.v1.VerificationService.GetUserRoles:input_type -> auth.v1.GetUserRolesRequest
+	34, // 61: auth.v1.VerificationService.IsSystemTenantUser:input_type -> auth.v1.IsSystemTenantUserRequest
+	32, // 62: auth.v1.VerificationService.VerifyPermissions:input_type -> auth.v1.VerifyPermissionsRequest
+	3,  // 63: auth.v1.RoleService.CreateRole:output_type -> auth.v1.CreateRoleResponse
+	48, // 64: auth.v1.RoleService.UpdateRole:output_type -> infra.v1.Response
+	43, // 65: auth.v1.RoleService.GetRole:output_type -> auth.v1.Role
+	7,  // 66: auth.v1.RoleService.ListRoles:output_type -> auth.v1.ListRolesResponse
+	48, // 67: auth.v1.RoleService.DeleteRole:output_type -> infra.v1.Response
+	10, // 68: auth.v1.PermissionService.CreatePermission:output_type -> auth.v1.CreatePermissionResponse
+	48, // 69: auth.v1.PermissionService.UpdatePermission:output_type -> infra.v1.Response
+	46, // 70: auth.v1.PermissionService.GetPermission:output_type -> auth.v1.Permission
+	14, // 71: auth.v1.PermissionService.ListPermissions:output_type -> auth.v1.ListPermissionsResponse
+	48, // 72: auth.v1.PermissionService.DeletePermission:output_type -> infra.v1.Response
+	17, // 73: auth.v1.PermissionService.CreatePermissionSet:output_type -> auth.v1.CreatePermissionSetResponse
+	48, // 74: auth.v1.PermissionService.UpdatePermissionSet:output_type -> infra.v1.Response
+	47, // 75: auth.v1.PermissionService.GetPermissionSet:output_type -> auth.v1.PermissionSet
+	21, // 76: auth.v1.PermissionService.ListPermissionSets:output_type -> auth.v1.ListPermissionSetsResponse
+	48, // 77: auth.v1.PermissionService.DeletePermissionSet:output_type -> infra.v1.Response
+	24, // 78: auth.v1.VerificationService.CheckPermissions:output_type -> auth.v1.CheckPermissionsResponse
+	26, // 79: auth.v1.VerificationService.HasPermission:output_type -> auth.v1.HasPermissionResponse
+	28, // 80: auth.v1.VerificationService.GetUserPermissions:output_type -> auth.v1.GetUserPermissionsResponse
+	30, // 81: auth.v1.VerificationService.GetUserRoles:output_type -> auth.v1.GetUserRolesResponse
+	35, // 82: auth.v1.VerificationService.IsSystemTenantUser:output_type -> auth.v1.IsSystemTenantUserResponse
+	33, // 83: auth.v1.VerificationService.VerifyPermissions:output_type -> auth.v1.VerifyPermissionsResponse
+	63, // [63:84] is the sub-list for method output_type
+	42, // [42:63] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_rbac_proto_init() }
@@ -1651,6 +2326,7 @@ func file_auth_v1_rbac_proto_init() {
 	}
 	file_auth_v1_role_proto_init()
 	file_auth_v1_permission_proto_init()
+	file_auth_v1_permission_set_proto_init()
 	file_auth_v1_rbac_proto_msgTypes[6].OneofWrappers = []any{}
 	file_auth_v1_rbac_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
@@ -1659,7 +2335,7 @@ func file_auth_v1_rbac_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_rbac_proto_rawDesc), len(file_auth_v1_rbac_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   28,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
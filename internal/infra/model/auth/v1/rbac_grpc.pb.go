@@ -278,11 +278,16 @@ var RoleService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	PermissionService_CreatePermission_FullMethodName = "/auth.v1.PermissionService/CreatePermission"
-	PermissionService_UpdatePermission_FullMethodName = "/auth.v1.PermissionService/UpdatePermission"
-	PermissionService_GetPermission_FullMethodName    = "/auth.v1.PermissionService/GetPermission"
-	PermissionService_ListPermissions_FullMethodName  = "/auth.v1.PermissionService/ListPermissions"
-	PermissionService_DeletePermission_FullMethodName = "/auth.v1.PermissionService/DeletePermission"
+	PermissionService_CreatePermission_FullMethodName    = "/auth.v1.PermissionService/CreatePermission"
+	PermissionService_UpdatePermission_FullMethodName    = "/auth.v1.PermissionService/UpdatePermission"
+	PermissionService_GetPermission_FullMethodName       = "/auth.v1.PermissionService/GetPermission"
+	PermissionService_ListPermissions_FullMethodName     = "/auth.v1.PermissionService/ListPermissions"
+	PermissionService_DeletePermission_FullMethodName    = "/auth.v1.PermissionService/DeletePermission"
+	PermissionService_CreatePermissionSet_FullMethodName = "/auth.v1.PermissionService/CreatePermissionSet"
+	PermissionService_UpdatePermissionSet_FullMethodName = "/auth.v1.PermissionService/UpdatePermissionSet"
+	PermissionService_GetPermissionSet_FullMethodName    = "/auth.v1.PermissionService/GetPermissionSet"
+	PermissionService_ListPermissionSets_FullMethodName  = "/auth.v1.PermissionService/ListPermissionSets"
+	PermissionService_DeletePermissionSet_FullMethodName = "/auth.v1.PermissionService/DeletePermissionSet"
 )
 
 // PermissionServiceClient is the client API for PermissionService service.
@@ -296,6 +301,12 @@ type PermissionServiceClient interface {
 	GetPermission(ctx context.Context, in *GetPermissionRequest, opts ...grpc.CallOption) (*Permission, error)
 	ListPermissions(ctx context.Context, in *ListPermissionsRequest, opts ...grpc.CallOption) (*ListPermissionsResponse, error)
 	DeletePermission(ctx context.Context, in *DeletePermissionRequest, opts ...grpc.CallOption) (*v1.Response, error)
+	// Permission sets, named bundles of permissions attached to roles
+	CreatePermissionSet(ctx context.Context, in *CreatePermissionSetRequest, opts ...grpc.CallOption) (*CreatePermissionSetResponse, error)
+	UpdatePermissionSet(ctx context.Context, in *UpdatePermissionSetRequest, opts ...grpc.CallOption) (*v1.Response, error)
+	GetPermissionSet(ctx context.Context, in *GetPermissionSetRequest, opts ...grpc.CallOption) (*PermissionSet, error)
+	ListPermissionSets(ctx context.Context, in *ListPermissionSetsRequest, opts ...grpc.CallOption) (*ListPermissionSetsResponse, error)
+	DeletePermissionSet(ctx context.Context, in *DeletePermissionSetRequest, opts ...grpc.CallOption) (*v1.Response, error)
 }
 
 type permissionServiceClient struct {
@@ -356,6 +367,56 @@ func (c *permissionServiceClient) DeletePermission(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *permissionServiceClient) CreatePermissionSet(ctx context.Context, in *CreatePermissionSetRequest, opts ...grpc.CallOption) (*CreatePermissionSetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePermissionSetResponse)
+	err := c.cc.Invoke(ctx, PermissionService_CreatePermissionSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) UpdatePermissionSet(ctx context.Context, in *UpdatePermissionSetRequest, opts ...grpc.CallOption) (*v1.Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.Response)
+	err := c.cc.Invoke(ctx, PermissionService_UpdatePermissionSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) GetPermissionSet(ctx context.Context, in *GetPermissionSetRequest, opts ...grpc.CallOption) (*PermissionSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PermissionSet)
+	err := c.cc.Invoke(ctx, PermissionService_GetPermissionSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) ListPermissionSets(ctx context.Context, in *ListPermissionSetsRequest, opts ...grpc.CallOption) (*ListPermissionSetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPermissionSetsResponse)
+	err := c.cc.Invoke(ctx, PermissionService_ListPermissionSets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) DeletePermissionSet(ctx context.Context, in *DeletePermissionSetRequest, opts ...grpc.CallOption) (*v1.Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.Response)
+	err := c.cc.Invoke(ctx, PermissionService_DeletePermissionSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PermissionServiceServer is the server API for PermissionService service.
 // All implementations must embed UnimplementedPermissionServiceServer
 // for forward compatibility.
@@ -367,6 +428,12 @@ type PermissionServiceServer interface {
 	GetPermission(context.Context, *GetPermissionRequest) (*Permission, error)
 	ListPermissions(context.Context, *ListPermissionsRequest) (*ListPermissionsResponse, error)
 	DeletePermission(context.Context, *DeletePermissionRequest) (*v1.Response, error)
+	// Permission sets, named bundles of permissions attached to roles
+	CreatePermissionSet(context.Context, *CreatePermissionSetRequest) (*CreatePermissionSetResponse, error)
+	UpdatePermissionSet(context.Context, *UpdatePermissionSetRequest) (*v1.Response, error)
+	GetPermissionSet(context.Context, *GetPermissionSetRequest) (*PermissionSet, error)
+	ListPermissionSets(context.Context, *ListPermissionSetsRequest) (*ListPermissionSetsResponse, error)
+	DeletePermissionSet(context.Context, *DeletePermissionSetRequest) (*v1.Response, error)
 	mustEmbedUnimplementedPermissionServiceServer()
 }
 
@@ -392,6 +459,21 @@ func (UnimplementedPermissionServiceServer) ListPermissions(context.Context, *Li
 func (UnimplementedPermissionServiceServer) DeletePermission(context.Context, *DeletePermissionRequest) (*v1.Response, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeletePermission not implemented")
 }
+func (UnimplementedPermissionServiceServer) CreatePermissionSet(context.Context, *CreatePermissionSetRequest) (*CreatePermissionSetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePermissionSet not implemented")
+}
+func (UnimplementedPermissionServiceServer) UpdatePermissionSet(context.Context, *UpdatePermissionSetRequest) (*v1.Response, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePermissionSet not implemented")
+}
+func (UnimplementedPermissionServiceServer) GetPermissionSet(context.Context, *GetPermissionSetRequest) (*PermissionSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPermissionSet not implemented")
+}
+func (UnimplementedPermissionServiceServer) ListPermissionSets(context.Context, *ListPermissionSetsRequest) (*ListPermissionSetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPermissionSets not implemented")
+}
+func (UnimplementedPermissionServiceServer) DeletePermissionSet(context.Context, *DeletePermissionSetRequest) (*v1.Response, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePermissionSet not implemented")
+}
 func (UnimplementedPermissionServiceServer) mustEmbedUnimplementedPermissionServiceServer() {}
 func (UnimplementedPermissionServiceServer) testEmbeddedByValue()                           {}
 
@@ -503,6 +585,96 @@ func _PermissionService_DeletePermission_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PermissionService_CreatePermissionSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePermissionSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).CreatePermissionSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionService_CreatePermissionSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).CreatePermissionSet(ctx, req.(*CreatePermissionSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_UpdatePermissionSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePermissionSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).UpdatePermissionSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionService_UpdatePermissionSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).UpdatePermissionSet(ctx, req.(*UpdatePermissionSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_GetPermissionSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPermissionSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).GetPermissionSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionService_GetPermissionSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).GetPermissionSet(ctx, req.(*GetPermissionSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_ListPermissionSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPermissionSetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).ListPermissionSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionService_ListPermissionSets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).ListPermissionSets(ctx, req.(*ListPermissionSetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_DeletePermissionSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePermissionSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).DeletePermissionSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionService_DeletePermissionSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).DeletePermissionSet(ctx, req.(*DeletePermissionSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PermissionService_ServiceDesc is the grpc.ServiceDesc for PermissionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -530,6 +702,26 @@ var PermissionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeletePermission",
 			Handler:    _PermissionService_DeletePermission_Handler,
 		},
+		{
+			MethodName: "CreatePermissionSet",
+			Handler:    _PermissionService_CreatePermissionSet_Handler,
+		},
+		{
+			MethodName: "UpdatePermissionSet",
+			Handler:    _PermissionService_UpdatePermissionSet_Handler,
+		},
+		{
+			MethodName: "GetPermissionSet",
+			Handler:    _PermissionService_GetPermissionSet_Handler,
+		},
+		{
+			MethodName: "ListPermissionSets",
+			Handler:    _PermissionService_ListPermissionSets_Handler,
+		},
+		{
+			MethodName: "DeletePermissionSet",
+			Handler:    _PermissionService_DeletePermissionSet_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/v1/rbac.proto",
@@ -541,6 +733,7 @@ const (
 	VerificationService_GetUserPermissions_FullMethodName = "/auth.v1.VerificationService/GetUserPermissions"
 	VerificationService_GetUserRoles_FullMethodName       = "/auth.v1.VerificationService/GetUserRoles"
 	VerificationService_IsSystemTenantUser_FullMethodName = "/auth.v1.VerificationService/IsSystemTenantUser"
+	VerificationService_VerifyPermissions_FullMethodName  = "/auth.v1.VerificationService/VerifyPermissions"
 )
 
 // VerificationServiceClient is the client API for VerificationService service.
@@ -554,6 +747,8 @@ type VerificationServiceClient interface {
 	GetUserPermissions(ctx context.Context, in *GetUserPermissionsRequest, opts ...grpc.CallOption) (*GetUserPermissionsResponse, error)
 	GetUserRoles(ctx context.Context, in *GetUserRolesRequest, opts ...grpc.CallOption) (*GetUserRolesResponse, error)
 	IsSystemTenantUser(ctx context.Context, in *IsSystemTenantUserRequest, opts ...grpc.CallOption) (*IsSystemTenantUserResponse, error)
+	// VerifyPermissions checks several permissions and roles of a user in one call
+	VerifyPermissions(ctx context.Context, in *VerifyPermissionsRequest, opts ...grpc.CallOption) (*VerifyPermissionsResponse, error)
 }
 
 type verificationServiceClient struct {
@@ -614,6 +809,16 @@ func (c *verificationServiceClient) IsSystemTenantUser(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *verificationServiceClient) VerifyPermissions(ctx context.Context, in *VerifyPermissionsRequest, opts ...grpc.CallOption) (*VerifyPermissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPermissionsResponse)
+	err := c.cc.Invoke(ctx, VerificationService_VerifyPermissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VerificationServiceServer is the server API for VerificationService service.
 // All implementations must embed UnimplementedVerificationServiceServer
 // for forward compatibility.
@@ -625,6 +830,8 @@ type VerificationServiceServer interface {
 	GetUserPermissions(context.Context, *GetUserPermissionsRequest) (*GetUserPermissionsResponse, error)
 	GetUserRoles(context.Context, *GetUserRolesRequest) (*GetUserRolesResponse, error)
 	IsSystemTenantUser(context.Context, *IsSystemTenantUserRequest) (*IsSystemTenantUserResponse, error)
+	// VerifyPermissions checks several permissions and roles of a user in one call
+	VerifyPermissions(context.Context, *VerifyPermissionsRequest) (*VerifyPermissionsResponse, error)
 	mustEmbedUnimplementedVerificationServiceServer()
 }
 
@@ -650,6 +857,9 @@ func (UnimplementedVerificationServiceServer) GetUserRoles(context.Context, *Get
 func (UnimplementedVerificationServiceServer) IsSystemTenantUser(context.Context, *IsSystemTenantUserRequest) (*IsSystemTenantUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method IsSystemTenantUser not implemented")
 }
+func (UnimplementedVerificationServiceServer) VerifyPermissions(context.Context, *VerifyPermissionsRequest) (*VerifyPermissionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyPermissions not implemented")
+}
 func (UnimplementedVerificationServiceServer) mustEmbedUnimplementedVerificationServiceServer() {}
 func (UnimplementedVerificationServiceServer) testEmbeddedByValue()                             {}
 
@@ -761,6 +971,24 @@ func _VerificationService_IsSystemTenantUser_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VerificationService_VerifyPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VerificationServiceServer).VerifyPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VerificationService_VerifyPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VerificationServiceServer).VerifyPermissions(ctx, req.(*VerifyPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VerificationService_ServiceDesc is the grpc.ServiceDesc for VerificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -788,6 +1016,10 @@ var VerificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IsSystemTenantUser",
 			Handler:    _VerificationService_IsSystemTenantUser_Handler,
 		},
+		{
+			MethodName: "VerifyPermissions",
+			Handler:    _VerificationService_VerifyPermissions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/v1/rbac.proto",
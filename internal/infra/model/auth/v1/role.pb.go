@@ -131,19 +131,23 @@ func (RoleStatus) EnumDescriptor() ([]byte, []int) {
 
 // Role model for MongoDB auth_db.roles collection
 type Role struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name" bson:"name"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description" bson:"description"`
-	Type          RoleType               `protobuf:"varint,5,opt,name=type,proto3,enum=auth.v1.RoleType" json:"type" bson:"type"`
-	Permissions   []string               `protobuf:"bytes,6,rep,name=permissions,proto3" json:"permissions" bson:"permissions"`
-	IsDefault     bool                   `protobuf:"varint,7,opt,name=is_default,json=isDefault,proto3" json:"is_default" bson:"is_default"`
-	Status        RoleStatus             `protobuf:"varint,8,opt,name=status,proto3,enum=auth.v1.RoleStatus" json:"status" bson:"status"`
-	Metadata      *RoleMetadata          `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
-	CreatedBy     string                 `protobuf:"bytes,12,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId    string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name" bson:"name" validate:"required"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description" bson:"description"`
+	Type        RoleType               `protobuf:"varint,5,opt,name=type,proto3,enum=auth.v1.RoleType" json:"type" bson:"type"`
+	Permissions []string               `protobuf:"bytes,6,rep,name=permissions,proto3" json:"permissions" bson:"permissions" validate:"required"`
+	IsDefault   bool                   `protobuf:"varint,7,opt,name=is_default,json=isDefault,proto3" json:"is_default" bson:"is_default"`
+	Status      RoleStatus             `protobuf:"varint,8,opt,name=status,proto3,enum=auth.v1.RoleStatus" json:"status" bson:"status" validate:"required"`
+	Metadata    *RoleMetadata          `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	CreatedBy   string                 `protobuf:"bytes,12,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	// IDs of the permission sets attached to the role, their permissions are granted with those of the role
+	PermissionSets []string `protobuf:"bytes,13,rep,name=permission_sets,json=permissionSets,proto3" json:"permission_sets,omitempty" bson:"permission_sets,omitempty"`
+	// Protected roles, e.g. tenant_admin, are created, changed and deleted only by system administrators
+	Protected     bool `protobuf:"varint,14,opt,name=protected,proto3" json:"protected,omitempty" bson:"protected,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -262,6 +266,20 @@ func (x *Role) GetCreatedBy() string {
 	return ""
 }
 
+func (x *Role) GetPermissionSets() []string {
+	if x != nil {
+		return x.PermissionSets
+	}
+	return nil
+}
+
+func (x *Role) GetProtected() bool {
+	if x != nil {
+		return x.Protected
+	}
+	return false
+}
+
 type RoleMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Priority      int32                  `protobuf:"varint,1,opt,name=priority,proto3" json:"priority" bson:"priority"`
@@ -318,25 +336,27 @@ var File_auth_v1_role_proto protoreflect.FileDescriptor
 
 const file_auth_v1_role_proto_rawDesc = "" +
 	"\n" +
-	"\x12auth/v1/role.proto\x12\aauth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xab\a\n" +
-	"\x04Role\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
-	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x120\n" +
-	"\x04name\x18\x03 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x12L\n" +
+	"\x12auth/v1/role.proto\x12\aauth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xf8\t\n" +
+	"\x04Role\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12D\n" +
+	"\x04name\x18\x03 \x01(\tB0\x9a\x84\x9e\x03+bson:\"name\" json:\"name\" validate:\"required\"R\x04name\x12L\n" +
 	"\vdescription\x18\x04 \x01(\tB*\x9a\x84\x9e\x03%bson:\"description\" json:\"description\"R\vdescription\x12C\n" +
-	"\x04type\x18\x05 \x01(\x0e2\x11.auth.v1.RoleTypeB\x1c\x9a\x84\x9e\x03\x17bson:\"type\" json:\"type\"R\x04type\x12L\n" +
-	"\vpermissions\x18\x06 \x03(\tB*\x9a\x84\x9e\x03%bson:\"permissions\" json:\"permissions\"R\vpermissions\x12G\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x11.auth.v1.RoleTypeB\x1c\x9a\x84\x9e\x03\x17bson:\"type\" json:\"type\"R\x04type\x12`\n" +
+	"\vpermissions\x18\x06 \x03(\tB>\x9a\x84\x9e\x039bson:\"permissions\" json:\"permissions\" validate:\"required\"R\vpermissions\x12G\n" +
 	"\n" +
-	"is_default\x18\a \x01(\bB(\x9a\x84\x9e\x03#bson:\"is_default\" json:\"is_default\"R\tisDefault\x12M\n" +
-	"\x06status\x18\b \x01(\x0e2\x13.auth.v1.RoleStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12k\n" +
+	"is_default\x18\a \x01(\bB(\x9a\x84\x9e\x03#bson:\"is_default\" json:\"is_default\"R\tisDefault\x12a\n" +
+	"\x06status\x18\b \x01(\x0e2\x13.auth.v1.RoleStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12k\n" +
 	"\bmetadata\x18\t \x01(\v2\x15.auth.v1.RoleMetadataB8\x9a\x84\x9e\x033bson:\"metadata,omitempty\" json:\"metadata,omitempty\"R\bmetadata\x12c\n" +
 	"\n" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12G\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12[\n" +
 	"\n" +
-	"created_by\x18\f \x01(\tB(\x9a\x84\x9e\x03#bson:\"created_by\" json:\"created_by\"R\tcreatedBy\"\xb9\x01\n" +
+	"created_by\x18\f \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12o\n" +
+	"\x0fpermission_sets\x18\r \x03(\tBF\x9a\x84\x9e\x03Abson:\"permission_sets,omitempty\" json:\"permission_sets,omitempty\"R\x0epermissionSets\x12X\n" +
+	"\tprotected\x18\x0e \x01(\bB:\x9a\x84\x9e\x035bson:\"protected,omitempty\" json:\"protected,omitempty\"R\tprotected\"\xb9\x01\n" +
 	"\fRoleMetadata\x12@\n" +
 	"\bpriority\x18\x01 \x01(\x05B$\x9a\x84\x9e\x03\x1fbson:\"priority\" json:\"priority\"R\bpriority\x12g\n" +
 	"\rinherits_from\x18\x02 \x03(\tBB\x9a\x84\x9e\x03=bson:\"inherits_from,omitempty\" json:\"inherits_from,omitempty\"R\finheritsFrom*g\n" +
@@ -0,0 +1,369 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/saml.proto
+
+package authv1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SAMLSettings is the SAML SSO configuration of a tenant, stored through the config service
+type SAMLSettings struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled"`
+	// IdP metadata XML, when set the entity id, SSO URL and certificates are read from it
+	IdpMetadataXml string `protobuf:"bytes,2,opt,name=idp_metadata_xml,json=idpMetadataXml,proto3" json:"idp_metadata_xml,omitempty"`
+	IdpEntityId    string `protobuf:"bytes,3,opt,name=idp_entity_id,json=idpEntityId,proto3" json:"idp_entity_id,omitempty"`
+	IdpSsoUrl      string `protobuf:"bytes,4,opt,name=idp_sso_url,json=idpSsoUrl,proto3" json:"idp_sso_url,omitempty"`
+	// Base64 DER or PEM signing certificates of the IdP
+	IdpCertificates []string              `protobuf:"bytes,5,rep,name=idp_certificates,json=idpCertificates,proto3" json:"idp_certificates,omitempty"`
+	Attributes      *SAMLAttributeMapping `protobuf:"bytes,6,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	RoleMappings    []*SAMLRoleMapping    `protobuf:"bytes,7,rep,name=role_mappings,json=roleMappings,proto3" json:"role_mappings,omitempty"`
+	// Create an account on first login when no user is linked to the SAML subject
+	AutoProvision bool `protobuf:"varint,8,opt,name=auto_provision,json=autoProvision,proto3" json:"auto_provision"`
+	// Roles assigned to auto-provisioned users in addition to mapped roles
+	DefaultRoleIds []string `protobuf:"bytes,9,rep,name=default_role_ids,json=defaultRoleIds,proto3" json:"default_role_ids,omitempty"`
+	// Link the SAML subject to the user with the same email, the IdP is trusted to have verified it
+	LinkByEmail bool `protobuf:"varint,10,opt,name=link_by_email,json=linkByEmail,proto3" json:"link_by_email"`
+	// Email domains allowed to be auto-provisioned, any domain when empty
+	AllowedDomains []string `protobuf:"bytes,11,rep,name=allowed_domains,json=allowedDomains,proto3" json:"allowed_domains,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SAMLSettings) Reset() {
+	*x = SAMLSettings{}
+	mi := &file_auth_v1_saml_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAMLSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAMLSettings) ProtoMessage() {}
+
+func (x *SAMLSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_saml_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAMLSettings.ProtoReflect.Descriptor instead.
+func (*SAMLSettings) Descriptor() ([]byte, []int) {
+	return file_auth_v1_saml_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SAMLSettings) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SAMLSettings) GetIdpMetadataXml() string {
+	if x != nil {
+		return x.IdpMetadataXml
+	}
+	return ""
+}
+
+func (x *SAMLSettings) GetIdpEntityId() string {
+	if x != nil {
+		return x.IdpEntityId
+	}
+	return ""
+}
+
+func (x *SAMLSettings) GetIdpSsoUrl() string {
+	if x != nil {
+		return x.IdpSsoUrl
+	}
+	return ""
+}
+
+func (x *SAMLSettings) GetIdpCertificates() []string {
+	if x != nil {
+		return x.IdpCertificates
+	}
+	return nil
+}
+
+func (x *SAMLSettings) GetAttributes() *SAMLAttributeMapping {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *SAMLSettings) GetRoleMappings() []*SAMLRoleMapping {
+	if x != nil {
+		return x.RoleMappings
+	}
+	return nil
+}
+
+func (x *SAMLSettings) GetAutoProvision() bool {
+	if x != nil {
+		return x.AutoProvision
+	}
+	return false
+}
+
+func (x *SAMLSettings) GetDefaultRoleIds() []string {
+	if x != nil {
+		return x.DefaultRoleIds
+	}
+	return nil
+}
+
+func (x *SAMLSettings) GetLinkByEmail() bool {
+	if x != nil {
+		return x.LinkByEmail
+	}
+	return false
+}
+
+func (x *SAMLSettings) GetAllowedDomains() []string {
+	if x != nil {
+		return x.AllowedDomains
+	}
+	return nil
+}
+
+// SAMLAttributeMapping names the assertion attributes user fields are read from
+type SAMLAttributeMapping struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The NameID is used when email is empty
+	Email       string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName   string `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName    string `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	DisplayName string `protobuf:"bytes,4,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Attribute with the IdP groups or roles matched against role_mappings
+	Roles         string `protobuf:"bytes,5,opt,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SAMLAttributeMapping) Reset() {
+	*x = SAMLAttributeMapping{}
+	mi := &file_auth_v1_saml_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAMLAttributeMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAMLAttributeMapping) ProtoMessage() {}
+
+func (x *SAMLAttributeMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_saml_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAMLAttributeMapping.ProtoReflect.Descriptor instead.
+func (*SAMLAttributeMapping) Descriptor() ([]byte, []int) {
+	return file_auth_v1_saml_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SAMLAttributeMapping) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SAMLAttributeMapping) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *SAMLAttributeMapping) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *SAMLAttributeMapping) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *SAMLAttributeMapping) GetRoles() string {
+	if x != nil {
+		return x.Roles
+	}
+	return ""
+}
+
+// SAMLRoleMapping grants role_ids to users whose roles attribute contains value
+type SAMLRoleMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value"`
+	RoleIds       []string               `protobuf:"bytes,2,rep,name=role_ids,json=roleIds,proto3" json:"role_ids"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SAMLRoleMapping) Reset() {
+	*x = SAMLRoleMapping{}
+	mi := &file_auth_v1_saml_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SAMLRoleMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SAMLRoleMapping) ProtoMessage() {}
+
+func (x *SAMLRoleMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_saml_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SAMLRoleMapping.ProtoReflect.Descriptor instead.
+func (*SAMLRoleMapping) Descriptor() ([]byte, []int) {
+	return file_auth_v1_saml_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SAMLRoleMapping) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SAMLRoleMapping) GetRoleIds() []string {
+	if x != nil {
+		return x.RoleIds
+	}
+	return nil
+}
+
+var File_auth_v1_saml_proto protoreflect.FileDescriptor
+
+const file_auth_v1_saml_proto_rawDesc = "" +
+	"\n" +
+	"\x12auth/v1/saml.proto\x12\aauth.v1\x1a\x13tagger/tagger.proto\"\xd7\x06\n" +
+	"\fSAMLSettings\x12-\n" +
+	"\aenabled\x18\x01 \x01(\bB\x13\x9a\x84\x9e\x03\x0ejson:\"enabled\"R\aenabled\x12P\n" +
+	"\x10idp_metadata_xml\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!json:\"idp_metadata_xml,omitempty\"R\x0eidpMetadataXml\x12G\n" +
+	"\ridp_entity_id\x18\x03 \x01(\tB#\x9a\x84\x9e\x03\x1ejson:\"idp_entity_id,omitempty\"R\vidpEntityId\x12A\n" +
+	"\vidp_sso_url\x18\x04 \x01(\tB!\x9a\x84\x9e\x03\x1cjson:\"idp_sso_url,omitempty\"R\tidpSsoUrl\x12Q\n" +
+	"\x10idp_certificates\x18\x05 \x03(\tB&\x9a\x84\x9e\x03!json:\"idp_certificates,omitempty\"R\x0fidpCertificates\x12_\n" +
+	"\n" +
+	"attributes\x18\x06 \x01(\v2\x1d.auth.v1.SAMLAttributeMappingB \x9a\x84\x9e\x03\x1bjson:\"attributes,omitempty\"R\n" +
+	"attributes\x12b\n" +
+	"\rrole_mappings\x18\a \x03(\v2\x18.auth.v1.SAMLRoleMappingB#\x9a\x84\x9e\x03\x1ejson:\"role_mappings,omitempty\"R\froleMappings\x12A\n" +
+	"\x0eauto_provision\x18\b \x01(\bB\x1a\x9a\x84\x9e\x03\x15json:\"auto_provision\"R\rautoProvision\x12P\n" +
+	"\x10default_role_ids\x18\t \x03(\tB&\x9a\x84\x9e\x03!json:\"default_role_ids,omitempty\"R\x0edefaultRoleIds\x12=\n" +
+	"\rlink_by_email\x18\n" +
+	" \x01(\bB\x19\x9a\x84\x9e\x03\x14json:\"link_by_email\"R\vlinkByEmail\x12N\n" +
+	"\x0fallowed_domains\x18\v \x03(\tB%\x9a\x84\x9e\x03 json:\"allowed_domains,omitempty\"R\x0eallowedDomains\"\xc2\x02\n" +
+	"\x14SAMLAttributeMapping\x121\n" +
+	"\x05email\x18\x01 \x01(\tB\x1b\x9a\x84\x9e\x03\x16json:\"email,omitempty\"R\x05email\x12?\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tB \x9a\x84\x9e\x03\x1bjson:\"first_name,omitempty\"R\tfirstName\x12<\n" +
+	"\tlast_name\x18\x03 \x01(\tB\x1f\x9a\x84\x9e\x03\x1ajson:\"last_name,omitempty\"R\blastName\x12E\n" +
+	"\fdisplay_name\x18\x04 \x01(\tB\"\x9a\x84\x9e\x03\x1djson:\"display_name,omitempty\"R\vdisplayName\x121\n" +
+	"\x05roles\x18\x05 \x01(\tB\x1b\x9a\x84\x9e\x03\x16json:\"roles,omitempty\"R\x05roles\"k\n" +
+	"\x0fSAMLRoleMapping\x12'\n" +
+	"\x05value\x18\x01 \x01(\tB\x11\x9a\x84\x9e\x03\fjson:\"value\"R\x05value\x12/\n" +
+	"\brole_ids\x18\x02 \x03(\tB\x14\x9a\x84\x9e\x03\x0fjson:\"role_ids\"R\aroleIdsB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+
+var (
+	file_auth_v1_saml_proto_rawDescOnce sync.Once
+	file_auth_v1_saml_proto_rawDescData []byte
+)
+
+func file_auth_v1_saml_proto_rawDescGZIP() []byte {
+	file_auth_v1_saml_proto_rawDescOnce.Do(func() {
+		file_auth_v1_saml_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_saml_proto_rawDesc), len(file_auth_v1_saml_proto_rawDesc)))
+	})
+	return file_auth_v1_saml_proto_rawDescData
+}
+
+var file_auth_v1_saml_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_auth_v1_saml_proto_goTypes = []any{
+	(*SAMLSettings)(nil),         // 0: auth.v1.SAMLSettings
+	(*SAMLAttributeMapping)(nil), // 1: auth.v1.SAMLAttributeMapping
+	(*SAMLRoleMapping)(nil),      // 2: auth.v1.SAMLRoleMapping
+}
+var file_auth_v1_saml_proto_depIdxs = []int32{
+	1, // 0: auth.v1.SAMLSettings.attributes:type_name -> auth.v1.SAMLAttributeMapping
+	2, // 1: auth.v1.SAMLSettings.role_mappings:type_name -> auth.v1.SAMLRoleMapping
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_saml_proto_init() }
+func file_auth_v1_saml_proto_init() {
+	if File_auth_v1_saml_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_saml_proto_rawDesc), len(file_auth_v1_saml_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_v1_saml_proto_goTypes,
+		DependencyIndexes: file_auth_v1_saml_proto_depIdxs,
+		MessageInfos:      file_auth_v1_saml_proto_msgTypes,
+	}.Build()
+	File_auth_v1_saml_proto = out.File
+	file_auth_v1_saml_proto_goTypes = nil
+	file_auth_v1_saml_proto_depIdxs = nil
+}
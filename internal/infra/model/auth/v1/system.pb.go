@@ -0,0 +1,1471 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/system.proto
+
+package authv1
+
+import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// =============================================================================
+// System status
+// =============================================================================
+type HealthState int32
+
+const (
+	HealthState_HEALTH_STATE_UNSPECIFIED HealthState = 0
+	HealthState_HEALTH_STATE_HEALTHY     HealthState = 1
+	HealthState_HEALTH_STATE_DEGRADED    HealthState = 2
+	HealthState_HEALTH_STATE_UNHEALTHY   HealthState = 3
+)
+
+// Enum value maps for HealthState.
+var (
+	HealthState_name = map[int32]string{
+		0: "HEALTH_STATE_UNSPECIFIED",
+		1: "HEALTH_STATE_HEALTHY",
+		2: "HEALTH_STATE_DEGRADED",
+		3: "HEALTH_STATE_UNHEALTHY",
+	}
+	HealthState_value = map[string]int32{
+		"HEALTH_STATE_UNSPECIFIED": 0,
+		"HEALTH_STATE_HEALTHY":     1,
+		"HEALTH_STATE_DEGRADED":    2,
+		"HEALTH_STATE_UNHEALTHY":   3,
+	}
+)
+
+func (x HealthState) Enum() *HealthState {
+	p := new(HealthState)
+	*p = x
+	return p
+}
+
+func (x HealthState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HealthState) Descriptor() protoreflect.EnumDescriptor {
+	return file_auth_v1_system_proto_enumTypes[0].Descriptor()
+}
+
+func (HealthState) Type() protoreflect.EnumType {
+	return &file_auth_v1_system_proto_enumTypes[0]
+}
+
+func (x HealthState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HealthState.Descriptor instead.
+func (HealthState) EnumDescriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{0}
+}
+
+// Dependency probed with a round trip, such as Mongo or Redis
+type ComponentStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State         HealthState            `protobuf:"varint,2,opt,name=state,proto3,enum=auth.v1.HealthState" json:"state,omitempty"`
+	LatencyMs     int64                  `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentStatus) Reset() {
+	*x = ComponentStatus{}
+	mi := &file_auth_v1_system_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentStatus) ProtoMessage() {}
+
+func (x *ComponentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentStatus.ProtoReflect.Descriptor instead.
+func (*ComponentStatus) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ComponentStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ComponentStatus) GetState() HealthState {
+	if x != nil {
+		return x.State
+	}
+	return HealthState_HEALTH_STATE_UNSPECIFIED
+}
+
+func (x *ComponentStatus) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ComponentStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type QueueStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Depth int64                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// 0 means unbounded
+	Capacity      int64 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueStatus) Reset() {
+	*x = QueueStatus{}
+	mi := &file_auth_v1_system_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatus) ProtoMessage() {}
+
+func (x *QueueStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatus.ProtoReflect.Descriptor instead.
+func (*QueueStatus) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QueueStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueueStatus) GetDepth() int64 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *QueueStatus) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type JobStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State         HealthState            `protobuf:"varint,2,opt,name=state,proto3,enum=auth.v1.HealthState" json:"state,omitempty"`
+	Running       bool                   `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	LastRun       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	LastError     string                 `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobStatus) Reset() {
+	*x = JobStatus{}
+	mi := &file_auth_v1_system_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobStatus) ProtoMessage() {}
+
+func (x *JobStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
+func (*JobStatus) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JobStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobStatus) GetState() HealthState {
+	if x != nil {
+		return x.State
+	}
+	return HealthState_HEALTH_STATE_UNSPECIFIED
+}
+
+func (x *JobStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *JobStatus) GetLastRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRun
+	}
+	return nil
+}
+
+func (x *JobStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type CacheStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Hits          int64                  `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses        int64                  `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	HitRate       float64                `protobuf:"fixed64,4,opt,name=hit_rate,json=hitRate,proto3" json:"hit_rate,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CacheStatus) Reset() {
+	*x = CacheStatus{}
+	mi := &file_auth_v1_system_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheStatus) ProtoMessage() {}
+
+func (x *CacheStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheStatus.ProtoReflect.Descriptor instead.
+func (*CacheStatus) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CacheStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CacheStatus) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *CacheStatus) GetMisses() int64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *CacheStatus) GetHitRate() float64 {
+	if x != nil {
+		return x.HitRate
+	}
+	return 0
+}
+
+func (x *CacheStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ModuleBuildInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        string                 `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Revision      string                 `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	BuildTime     string                 `protobuf:"bytes,4,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	GoVersion     string                 `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleBuildInfo) Reset() {
+	*x = ModuleBuildInfo{}
+	mi := &file_auth_v1_system_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleBuildInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleBuildInfo) ProtoMessage() {}
+
+func (x *ModuleBuildInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleBuildInfo.ProtoReflect.Descriptor instead.
+func (*ModuleBuildInfo) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ModuleBuildInfo) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ModuleBuildInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ModuleBuildInfo) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *ModuleBuildInfo) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *ModuleBuildInfo) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+type SystemStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Worst state of all components and jobs
+	State         HealthState            `protobuf:"varint,1,opt,name=state,proto3,enum=auth.v1.HealthState" json:"state,omitempty"`
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	Components    []*ComponentStatus     `protobuf:"bytes,3,rep,name=components,proto3" json:"components,omitempty"`
+	Queues        []*QueueStatus         `protobuf:"bytes,4,rep,name=queues,proto3" json:"queues,omitempty"`
+	Jobs          []*JobStatus           `protobuf:"bytes,5,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Caches        []*CacheStatus         `protobuf:"bytes,6,rep,name=caches,proto3" json:"caches,omitempty"`
+	Modules       []*ModuleBuildInfo     `protobuf:"bytes,7,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemStatus) Reset() {
+	*x = SystemStatus{}
+	mi := &file_auth_v1_system_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStatus) ProtoMessage() {}
+
+func (x *SystemStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStatus.ProtoReflect.Descriptor instead.
+func (*SystemStatus) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SystemStatus) GetState() HealthState {
+	if x != nil {
+		return x.State
+	}
+	return HealthState_HEALTH_STATE_UNSPECIFIED
+}
+
+func (x *SystemStatus) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetComponents() []*ComponentStatus {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetQueues() []*QueueStatus {
+	if x != nil {
+		return x.Queues
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetJobs() []*JobStatus {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetCaches() []*CacheStatus {
+	if x != nil {
+		return x.Caches
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetModules() []*ModuleBuildInfo {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+type GetSystemStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemStatusRequest) Reset() {
+	*x = GetSystemStatusRequest{}
+	mi := &file_auth_v1_system_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemStatusRequest) ProtoMessage() {}
+
+func (x *GetSystemStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemStatusRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetSystemStatusRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+// =============================================================================
+// System reports
+// =============================================================================
+type TenantReport struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name" bson:"name"`
+	Status   TenantStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=auth.v1.TenantStatus" json:"status" bson:"status"`
+	Users    int64                  `protobuf:"varint,4,opt,name=users,proto3" json:"users" bson:"users"`
+	// Users with a successful login in the 30 days before the report
+	MonthlyActiveUsers int64 `protobuf:"varint,5,opt,name=monthly_active_users,json=monthlyActiveUsers,proto3" json:"monthly_active_users" bson:"monthly_active_users"`
+	// BSON size of the tenant documents in the auth database
+	StorageBytes  int64 `protobuf:"varint,6,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes" bson:"storage_bytes"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantReport) Reset() {
+	*x = TenantReport{}
+	mi := &file_auth_v1_system_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantReport) ProtoMessage() {}
+
+func (x *TenantReport) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantReport.ProtoReflect.Descriptor instead.
+func (*TenantReport) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TenantReport) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *TenantReport) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TenantReport) GetStatus() TenantStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TenantStatus_TENANT_STATUS_UNSPECIFIED
+}
+
+func (x *TenantReport) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *TenantReport) GetMonthlyActiveUsers() int64 {
+	if x != nil {
+		return x.MonthlyActiveUsers
+	}
+	return 0
+}
+
+func (x *TenantReport) GetStorageBytes() int64 {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return 0
+}
+
+type TenantStatusCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        TenantStatus           `protobuf:"varint,1,opt,name=status,proto3,enum=auth.v1.TenantStatus" json:"status" bson:"status"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count" bson:"count"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantStatusCount) Reset() {
+	*x = TenantStatusCount{}
+	mi := &file_auth_v1_system_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantStatusCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantStatusCount) ProtoMessage() {}
+
+func (x *TenantStatusCount) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantStatusCount.ProtoReflect.Descriptor instead.
+func (*TenantStatusCount) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TenantStatusCount) GetStatus() TenantStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TenantStatus_TENANT_STATUS_UNSPECIFIED
+}
+
+func (x *TenantStatusCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// SystemReport model for MongoDB auth_db.system_reports collection
+type SystemReport struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	GeneratedAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at" bson:"generated_at"`
+	TotalTenants       int64                  `protobuf:"varint,3,opt,name=total_tenants,json=totalTenants,proto3" json:"total_tenants" bson:"total_tenants"`
+	TenantsByStatus    []*TenantStatusCount   `protobuf:"bytes,4,rep,name=tenants_by_status,json=tenantsByStatus,proto3" json:"tenants_by_status" bson:"tenants_by_status"`
+	TotalUsers         int64                  `protobuf:"varint,5,opt,name=total_users,json=totalUsers,proto3" json:"total_users" bson:"total_users"`
+	MonthlyActiveUsers int64                  `protobuf:"varint,6,opt,name=monthly_active_users,json=monthlyActiveUsers,proto3" json:"monthly_active_users" bson:"monthly_active_users"`
+	StorageBytes       int64                  `protobuf:"varint,7,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes" bson:"storage_bytes"`
+	Tenants            []*TenantReport        `protobuf:"bytes,8,rep,name=tenants,proto3" json:"tenants" bson:"tenants"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SystemReport) Reset() {
+	*x = SystemReport{}
+	mi := &file_auth_v1_system_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemReport) ProtoMessage() {}
+
+func (x *SystemReport) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemReport.ProtoReflect.Descriptor instead.
+func (*SystemReport) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SystemReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SystemReport) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *SystemReport) GetTotalTenants() int64 {
+	if x != nil {
+		return x.TotalTenants
+	}
+	return 0
+}
+
+func (x *SystemReport) GetTenantsByStatus() []*TenantStatusCount {
+	if x != nil {
+		return x.TenantsByStatus
+	}
+	return nil
+}
+
+func (x *SystemReport) GetTotalUsers() int64 {
+	if x != nil {
+		return x.TotalUsers
+	}
+	return 0
+}
+
+func (x *SystemReport) GetMonthlyActiveUsers() int64 {
+	if x != nil {
+		return x.MonthlyActiveUsers
+	}
+	return 0
+}
+
+func (x *SystemReport) GetStorageBytes() int64 {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return 0
+}
+
+func (x *SystemReport) GetTenants() []*TenantReport {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+type GetSystemReportRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Generate a new report instead of returning the latest scheduled one
+	Refresh       bool `protobuf:"varint,2,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemReportRequest) Reset() {
+	*x = GetSystemReportRequest{}
+	mi := &file_auth_v1_system_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemReportRequest) ProtoMessage() {}
+
+func (x *GetSystemReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemReportRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemReportRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetSystemReportRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetSystemReportRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+type ExportSystemReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSystemReportRequest) Reset() {
+	*x = ExportSystemReportRequest{}
+	mi := &file_auth_v1_system_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSystemReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSystemReportRequest) ProtoMessage() {}
+
+func (x *ExportSystemReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSystemReportRequest.ProtoReflect.Descriptor instead.
+func (*ExportSystemReportRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportSystemReportRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type ExportSystemReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSystemReportResponse) Reset() {
+	*x = ExportSystemReportResponse{}
+	mi := &file_auth_v1_system_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSystemReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSystemReportResponse) ProtoMessage() {}
+
+func (x *ExportSystemReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSystemReportResponse.ProtoReflect.Descriptor instead.
+func (*ExportSystemReportResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportSystemReportResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportSystemReportResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportSystemReportResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// =============================================================================
+// Log levels
+// =============================================================================
+// Levels are trace, debug, info, warn, error and fatal
+type LogLevels struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Level of modules without an override
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// Overrides by module name
+	Modules       map[string]string `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLevels) Reset() {
+	*x = LogLevels{}
+	mi := &file_auth_v1_system_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLevels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevels) ProtoMessage() {}
+
+func (x *LogLevels) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevels.ProtoReflect.Descriptor instead.
+func (*LogLevels) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LogLevels) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogLevels) GetModules() map[string]string {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+type GetLogLevelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelsRequest) Reset() {
+	*x = GetLogLevelsRequest{}
+	mi := &file_auth_v1_system_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelsRequest) ProtoMessage() {}
+
+func (x *GetLogLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetLogLevelsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type SetLogLevelRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Module to override, empty sets the level of modules without an override
+	Module string `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	Level  string `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
+	// Remove the override of module instead of setting it
+	Reset_        bool `protobuf:"varint,4,opt,name=reset,proto3" json:"reset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_auth_v1_system_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetLogLevelRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SetLogLevelRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetReset_() bool {
+	if x != nil {
+		return x.Reset_
+	}
+	return false
+}
+
+type RotateSystemAdminRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateSystemAdminRequest) Reset() {
+	*x = RotateSystemAdminRequest{}
+	mi := &file_auth_v1_system_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateSystemAdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSystemAdminRequest) ProtoMessage() {}
+
+func (x *RotateSystemAdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSystemAdminRequest.ProtoReflect.Descriptor instead.
+func (*RotateSystemAdminRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RotateSystemAdminRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type RotateSystemAdminResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The generated password, returned only in this response. It must be changed on the next login
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateSystemAdminResponse) Reset() {
+	*x = RotateSystemAdminResponse{}
+	mi := &file_auth_v1_system_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateSystemAdminResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSystemAdminResponse) ProtoMessage() {}
+
+func (x *RotateSystemAdminResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_system_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSystemAdminResponse.ProtoReflect.Descriptor instead.
+func (*RotateSystemAdminResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_system_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RotateSystemAdminResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RotateSystemAdminResponse) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+var File_auth_v1_system_proto protoreflect.FileDescriptor
+
+const file_auth_v1_system_proto_rawDesc = "" +
+	"\n" +
+	"\x14auth/v1/system.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x14auth/v1/tenant.proto\"\x86\x01\n" +
+	"\x0fComponentStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.auth.v1.HealthStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x03 \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"S\n" +
+	"\vQueueStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x03R\x05depth\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x03R\bcapacity\"\xbb\x01\n" +
+	"\tJobStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.auth.v1.HealthStateR\x05state\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\bR\arunning\x125\n" +
+	"\blast_run\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\alastRun\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x05 \x01(\tR\tlastError\"~\n" +
+	"\vCacheStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04hits\x18\x02 \x01(\x03R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x03R\x06misses\x12\x19\n" +
+	"\bhit_rate\x18\x04 \x01(\x01R\ahitRate\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x9d\x01\n" +
+	"\x0fModuleBuildInfo\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\tR\brevision\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x04 \x01(\tR\tbuildTime\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion\"\xe7\x02\n" +
+	"\fSystemStatus\x12*\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x14.auth.v1.HealthStateR\x05state\x129\n" +
+	"\n" +
+	"checked_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x128\n" +
+	"\n" +
+	"components\x18\x03 \x03(\v2\x18.auth.v1.ComponentStatusR\n" +
+	"components\x12,\n" +
+	"\x06queues\x18\x04 \x03(\v2\x14.auth.v1.QueueStatusR\x06queues\x12&\n" +
+	"\x04jobs\x18\x05 \x03(\v2\x12.auth.v1.JobStatusR\x04jobs\x12,\n" +
+	"\x06caches\x18\x06 \x03(\v2\x14.auth.v1.CacheStatusR\x06caches\x122\n" +
+	"\amodules\x18\a \x03(\v2\x18.auth.v1.ModuleBuildInfoR\amodules\"q\n" +
+	"\x16GetSystemStatusRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"\xd1\x03\n" +
+	"\fTenantReport\x12C\n" +
+	"\ttenant_id\x18\x01 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x120\n" +
+	"\x04name\x18\x02 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x12O\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.auth.v1.TenantStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x124\n" +
+	"\x05users\x18\x04 \x01(\x03B\x1e\x9a\x84\x9e\x03\x19bson:\"users\" json:\"users\"R\x05users\x12n\n" +
+	"\x14monthly_active_users\x18\x05 \x01(\x03B<\x9a\x84\x9e\x037bson:\"monthly_active_users\" json:\"monthly_active_users\"R\x12monthlyActiveUsers\x12S\n" +
+	"\rstorage_bytes\x18\x06 \x01(\x03B.\x9a\x84\x9e\x03)bson:\"storage_bytes\" json:\"storage_bytes\"R\fstorageBytes\"\x9a\x01\n" +
+	"\x11TenantStatusCount\x12O\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x15.auth.v1.TenantStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x124\n" +
+	"\x05count\x18\x02 \x01(\x03B\x1e\x9a\x84\x9e\x03\x19bson:\"count\" json:\"count\"R\x05count\"\xec\x05\n" +
+	"\fSystemReport\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12k\n" +
+	"\fgenerated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB,\x9a\x84\x9e\x03'bson:\"generated_at\" json:\"generated_at\"R\vgeneratedAt\x12S\n" +
+	"\rtotal_tenants\x18\x03 \x01(\x03B.\x9a\x84\x9e\x03)bson:\"total_tenants\" json:\"total_tenants\"R\ftotalTenants\x12~\n" +
+	"\x11tenants_by_status\x18\x04 \x03(\v2\x1a.auth.v1.TenantStatusCountB6\x9a\x84\x9e\x031bson:\"tenants_by_status\" json:\"tenants_by_status\"R\x0ftenantsByStatus\x12K\n" +
+	"\vtotal_users\x18\x05 \x01(\x03B*\x9a\x84\x9e\x03%bson:\"total_users\" json:\"total_users\"R\n" +
+	"totalUsers\x12n\n" +
+	"\x14monthly_active_users\x18\x06 \x01(\x03B<\x9a\x84\x9e\x037bson:\"monthly_active_users\" json:\"monthly_active_users\"R\x12monthlyActiveUsers\x12S\n" +
+	"\rstorage_bytes\x18\a \x01(\x03B.\x9a\x84\x9e\x03)bson:\"storage_bytes\" json:\"storage_bytes\"R\fstorageBytes\x12S\n" +
+	"\atenants\x18\b \x03(\v2\x15.auth.v1.TenantReportB\"\x9a\x84\x9e\x03\x1dbson:\"tenants\" json:\"tenants\"R\atenants\"\x8b\x01\n" +
+	"\x16GetSystemReportRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x18\n" +
+	"\arefresh\x18\x02 \x01(\bR\arefresh\"t\n" +
+	"\x19ExportSystemReportRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"p\n" +
+	"\x1aExportSystemReportResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x98\x01\n" +
+	"\tLogLevels\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x129\n" +
+	"\amodules\x18\x02 \x03(\v2\x1f.auth.v1.LogLevels.ModulesEntryR\amodules\x1a:\n" +
+	"\fModulesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"n\n" +
+	"\x13GetLogLevelsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"\xb1\x01\n" +
+	"\x12SetLogLevelRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x16\n" +
+	"\x06module\x18\x02 \x01(\tR\x06module\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\tR\x05level\x12\x14\n" +
+	"\x05reset\x18\x04 \x01(\bR\x05reset\"s\n" +
+	"\x18RotateSystemAdminRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"P\n" +
+	"\x19RotateSystemAdminResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword*|\n" +
+	"\vHealthState\x12\x1c\n" +
+	"\x18HEALTH_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14HEALTH_STATE_HEALTHY\x10\x01\x12\x19\n" +
+	"\x15HEALTH_STATE_DEGRADED\x10\x02\x12\x1a\n" +
+	"\x16HEALTH_STATE_UNHEALTHY\x10\x032\xe2\x03\n" +
+	"\rSystemService\x12I\n" +
+	"\x0fGetSystemStatus\x12\x1f.auth.v1.GetSystemStatusRequest\x1a\x15.auth.v1.SystemStatus\x12I\n" +
+	"\x0fGetSystemReport\x12\x1f.auth.v1.GetSystemReportRequest\x1a\x15.auth.v1.SystemReport\x12]\n" +
+	"\x12ExportSystemReport\x12\".auth.v1.ExportSystemReportRequest\x1a#.auth.v1.ExportSystemReportResponse\x12@\n" +
+	"\fGetLogLevels\x12\x1c.auth.v1.GetLogLevelsRequest\x1a\x12.auth.v1.LogLevels\x12>\n" +
+	"\vSetLogLevel\x12\x1b.auth.v1.SetLogLevelRequest\x1a\x12.auth.v1.LogLevels\x12Z\n" +
+	"\x11RotateSystemAdmin\x12!.auth.v1.RotateSystemAdminRequest\x1a\".auth.v1.RotateSystemAdminResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+
+var (
+	file_auth_v1_system_proto_rawDescOnce sync.Once
+	file_auth_v1_system_proto_rawDescData []byte
+)
+
+func file_auth_v1_system_proto_rawDescGZIP() []byte {
+	file_auth_v1_system_proto_rawDescOnce.Do(func() {
+		file_auth_v1_system_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_system_proto_rawDesc), len(file_auth_v1_system_proto_rawDesc)))
+	})
+	return file_auth_v1_system_proto_rawDescData
+}
+
+var file_auth_v1_system_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_auth_v1_system_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_auth_v1_system_proto_goTypes = []any{
+	(HealthState)(0),                   // 0: auth.v1.HealthState
+	(*ComponentStatus)(nil),            // 1: auth.v1.ComponentStatus
+	(*QueueStatus)(nil),                // 2: auth.v1.QueueStatus
+	(*JobStatus)(nil),                  // 3: auth.v1.JobStatus
+	(*CacheStatus)(nil),                // 4: auth.v1.CacheStatus
+	(*ModuleBuildInfo)(nil),            // 5: auth.v1.ModuleBuildInfo
+	(*SystemStatus)(nil),               // 6: auth.v1.SystemStatus
+	(*GetSystemStatusRequest)(nil),     // 7: auth.v1.GetSystemStatusRequest
+	(*TenantReport)(nil),               // 8: auth.v1.TenantReport
+	(*TenantStatusCount)(nil),          // 9: auth.v1.TenantStatusCount
+	(*SystemReport)(nil),               // 10: auth.v1.SystemReport
+	(*GetSystemReportRequest)(nil),     // 11: auth.v1.GetSystemReportRequest
+	(*ExportSystemReportRequest)(nil),  // 12: auth.v1.ExportSystemReportRequest
+	(*ExportSystemReportResponse)(nil), // 13: auth.v1.ExportSystemReportResponse
+	(*LogLevels)(nil),                  // 14: auth.v1.LogLevels
+	(*GetLogLevelsRequest)(nil),        // 15: auth.v1.GetLogLevelsRequest
+	(*SetLogLevelRequest)(nil),         // 16: auth.v1.SetLogLevelRequest
+	(*RotateSystemAdminRequest)(nil),   // 17: auth.v1.RotateSystemAdminRequest
+	(*RotateSystemAdminResponse)(nil),  // 18: auth.v1.RotateSystemAdminResponse
+	nil,                                // 19: auth.v1.LogLevels.ModulesEntry
+	(*timestamppb.Timestamp)(nil),      // 20: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),          // 21: infra.v1.UserIdentifier
+	(TenantStatus)(0),                  // 22: auth.v1.TenantStatus
+}
+var file_auth_v1_system_proto_depIdxs = []int32{
+	0,  // 0: auth.v1.ComponentStatus.state:type_name -> auth.v1.HealthState
+	0,  // 1: auth.v1.JobStatus.state:type_name -> auth.v1.HealthState
+	20, // 2: auth.v1.JobStatus.last_run:type_name -> google.protobuf.Timestamp
+	0,  // 3: auth.v1.SystemStatus.state:type_name -> auth.v1.HealthState
+	20, // 4: auth.v1.SystemStatus.checked_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: auth.v1.SystemStatus.components:type_name -> auth.v1.ComponentStatus
+	2,  // 6: auth.v1.SystemStatus.queues:type_name -> auth.v1.QueueStatus
+	3,  // 7: auth.v1.SystemStatus.jobs:type_name -> auth.v1.JobStatus
+	4,  // 8: auth.v1.SystemStatus.caches:type_name -> auth.v1.CacheStatus
+	5,  // 9: auth.v1.SystemStatus.modules:type_name -> auth.v1.ModuleBuildInfo
+	21, // 10: auth.v1.GetSystemStatusRequest.identifier:type_name -> infra.v1.UserIdentifier
+	22, // 11: auth.v1.TenantReport.status:type_name -> auth.v1.TenantStatus
+	22, // 12: auth.v1.TenantStatusCount.status:type_name -> auth.v1.TenantStatus
+	20, // 13: auth.v1.SystemReport.generated_at:type_name -> google.protobuf.Timestamp
+	9,  // 14: auth.v1.SystemReport.tenants_by_status:type_name -> auth.v1.TenantStatusCount
+	8,  // 15: auth.v1.SystemReport.tenants:type_name -> auth.v1.TenantReport
+	21, // 16: auth.v1.GetSystemReportRequest.identifier:type_name -> infra.v1.UserIdentifier
+	21, // 17: auth.v1.ExportSystemReportRequest.identifier:type_name -> infra.v1.UserIdentifier
+	19, // 18: auth.v1.LogLevels.modules:type_name -> auth.v1.LogLevels.ModulesEntry
+	21, // 19: auth.v1.GetLogLevelsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	21, // 20: auth.v1.SetLogLevelRequest.identifier:type_name -> infra.v1.UserIdentifier
+	21, // 21: auth.v1.RotateSystemAdminRequest.identifier:type_name -> infra.v1.UserIdentifier
+	7,  // 22: auth.v1.SystemService.GetSystemStatus:input_type -> auth.v1.GetSystemStatusRequest
+	11, // 23: auth.v1.SystemService.GetSystemReport:input_type -> auth.v1.GetSystemReportRequest
+	12, // 24: auth.v1.SystemService.ExportSystemReport:input_type -> auth.v1.ExportSystemReportRequest
+	15, // 25: auth.v1.SystemService.GetLogLevels:input_type -> auth.v1.GetLogLevelsRequest
+	16, // 26: auth.v1.SystemService.SetLogLevel:input_type -> auth.v1.SetLogLevelRequest
+	17, // 27: auth.v1.SystemService.RotateSystemAdmin:input_type -> auth.v1.RotateSystemAdminRequest
+	6,  // 28: auth.v1.SystemService.GetSystemStatus:output_type -> auth.v1.SystemStatus
+	10, // 29: auth.v1.SystemService.GetSystemReport:output_type -> auth.v1.SystemReport
+	13, // 30: auth.v1.SystemService.ExportSystemReport:output_type -> auth.v1.ExportSystemReportResponse
+	14, // 31: auth.v1.SystemService.GetLogLevels:output_type -> auth.v1.LogLevels
+	14, // 32: auth.v1.SystemService.SetLogLevel:output_type -> auth.v1.LogLevels
+	18, // 33: auth.v1.SystemService.RotateSystemAdmin:output_type -> auth.v1.RotateSystemAdminResponse
+	28, // [28:34] is the sub-list for method output_type
+	22, // [22:28] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_system_proto_init() }
+func file_auth_v1_system_proto_init() {
+	if File_auth_v1_system_proto != nil {
+		return
+	}
+	file_auth_v1_tenant_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_system_proto_rawDesc), len(file_auth_v1_system_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_auth_v1_system_proto_goTypes,
+		DependencyIndexes: file_auth_v1_system_proto_depIdxs,
+		EnumInfos:         file_auth_v1_system_proto_enumTypes,
+		MessageInfos:      file_auth_v1_system_proto_msgTypes,
+	}.Build()
+	File_auth_v1_system_proto = out.File
+	file_auth_v1_system_proto_goTypes = nil
+	file_auth_v1_system_proto_depIdxs = nil
+}
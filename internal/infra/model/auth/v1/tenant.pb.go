@@ -12,6 +12,7 @@ import (
 	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -81,22 +82,133 @@ func (TenantStatus) EnumDescriptor() ([]byte, []int) {
 	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{0}
 }
 
+type UsageExportFormat int32
+
+const (
+	UsageExportFormat_USAGE_EXPORT_FORMAT_UNSPECIFIED UsageExportFormat = 0
+	UsageExportFormat_USAGE_EXPORT_FORMAT_CSV         UsageExportFormat = 1
+	UsageExportFormat_USAGE_EXPORT_FORMAT_JSON        UsageExportFormat = 2
+)
+
+// Enum value maps for UsageExportFormat.
+var (
+	UsageExportFormat_name = map[int32]string{
+		0: "USAGE_EXPORT_FORMAT_UNSPECIFIED",
+		1: "USAGE_EXPORT_FORMAT_CSV",
+		2: "USAGE_EXPORT_FORMAT_JSON",
+	}
+	UsageExportFormat_value = map[string]int32{
+		"USAGE_EXPORT_FORMAT_UNSPECIFIED": 0,
+		"USAGE_EXPORT_FORMAT_CSV":         1,
+		"USAGE_EXPORT_FORMAT_JSON":        2,
+	}
+)
+
+func (x UsageExportFormat) Enum() *UsageExportFormat {
+	p := new(UsageExportFormat)
+	*p = x
+	return p
+}
+
+func (x UsageExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UsageExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_auth_v1_tenant_proto_enumTypes[1].Descriptor()
+}
+
+func (UsageExportFormat) Type() protoreflect.EnumType {
+	return &file_auth_v1_tenant_proto_enumTypes[1]
+}
+
+func (x UsageExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UsageExportFormat.Descriptor instead.
+func (UsageExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{1}
+}
+
+// Data export
+type TenantExportFormat int32
+
+const (
+	TenantExportFormat_TENANT_EXPORT_FORMAT_UNSPECIFIED TenantExportFormat = 0
+	// One JSON document per line, the default
+	TenantExportFormat_TENANT_EXPORT_FORMAT_NDJSON TenantExportFormat = 1
+	// Concatenated BSON documents, as written by mongodump
+	TenantExportFormat_TENANT_EXPORT_FORMAT_BSON TenantExportFormat = 2
+)
+
+// Enum value maps for TenantExportFormat.
+var (
+	TenantExportFormat_name = map[int32]string{
+		0: "TENANT_EXPORT_FORMAT_UNSPECIFIED",
+		1: "TENANT_EXPORT_FORMAT_NDJSON",
+		2: "TENANT_EXPORT_FORMAT_BSON",
+	}
+	TenantExportFormat_value = map[string]int32{
+		"TENANT_EXPORT_FORMAT_UNSPECIFIED": 0,
+		"TENANT_EXPORT_FORMAT_NDJSON":      1,
+		"TENANT_EXPORT_FORMAT_BSON":        2,
+	}
+)
+
+func (x TenantExportFormat) Enum() *TenantExportFormat {
+	p := new(TenantExportFormat)
+	*p = x
+	return p
+}
+
+func (x TenantExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TenantExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_auth_v1_tenant_proto_enumTypes[2].Descriptor()
+}
+
+func (TenantExportFormat) Type() protoreflect.EnumType {
+	return &file_auth_v1_tenant_proto_enumTypes[2]
+}
+
+func (x TenantExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TenantExportFormat.Descriptor instead.
+func (TenantExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{2}
+}
+
 // Tenant model for MongoDB auth_db.tenants collection
 type Tenant struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name" bson:"name"`
-	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug" bson:"slug"`
-	Domain        string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty" bson:"domain,omitempty"`
-	Status        TenantStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=auth.v1.TenantStatus" json:"status" bson:"status"`
-	Subscription  *Subscription          `protobuf:"bytes,6,opt,name=subscription,proto3" json:"subscription" bson:"subscription"`
-	Settings      *TenantSettings        `protobuf:"bytes,7,opt,name=settings,proto3" json:"settings" bson:"settings"`
-	Contact       *ContactInfo           `protobuf:"bytes,8,opt,name=contact,proto3" json:"contact" bson:"contact"`
-	Branding      *Branding              `protobuf:"bytes,9,opt,name=branding,proto3" json:"branding,omitempty" bson:"branding,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
-	CreatedBy     string                 `protobuf:"bytes,12,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by"`
-	Metadata      *TenantMetadata        `protobuf:"bytes,13,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name" bson:"name" validate:"required"`
+	Slug         string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug" bson:"slug"`
+	Domain       string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty" bson:"domain,omitempty"`
+	Status       TenantStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=auth.v1.TenantStatus" json:"status" bson:"status" validate:"required"`
+	Subscription *Subscription          `protobuf:"bytes,6,opt,name=subscription,proto3" json:"subscription" bson:"subscription"`
+	Settings     *TenantSettings        `protobuf:"bytes,7,opt,name=settings,proto3" json:"settings" bson:"settings"`
+	Contact      *ContactInfo           `protobuf:"bytes,8,opt,name=contact,proto3" json:"contact" bson:"contact"`
+	Branding     *Branding              `protobuf:"bytes,9,opt,name=branding,proto3" json:"branding,omitempty" bson:"branding,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	CreatedBy    string                 `protobuf:"bytes,12,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	Metadata     *TenantMetadata        `protobuf:"bytes,13,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
+	// Trial period of the tenants created in TENANT_STATUS_TRIAL, the tenant is suspended when it ends
+	TrialStart *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=trial_start,json=trialStart,proto3" json:"trial_start,omitempty" bson:"trial_start,omitempty"`
+	TrialEnd   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=trial_end,json=trialEnd,proto3" json:"trial_end,omitempty" bson:"trial_end,omitempty"`
+	// Last status change, set by ChangeTenantStatus and the trial expiry
+	StatusChangedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=status_changed_at,json=statusChangedAt,proto3" json:"status_changed_at,omitempty" bson:"status_changed_at,omitempty"`
+	StatusReason    string                 `protobuf:"bytes,17,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty" bson:"status_reason,omitempty"`
+	// Quotas checked when users, roles and API keys are created, unlimited when unset
+	Limits *TenantLimits `protobuf:"bytes,18,opt,name=limits,proto3" json:"limits,omitempty" bson:"limits,omitempty"`
+	// Incremented on every update, an update must carry the version it was read at
+	Version       int64 `protobuf:"varint,19,opt,name=version,proto3" json:"version" bson:"version"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -222,6 +334,111 @@ func (x *Tenant) GetMetadata() *TenantMetadata {
 	return nil
 }
 
+func (x *Tenant) GetTrialStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TrialStart
+	}
+	return nil
+}
+
+func (x *Tenant) GetTrialEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TrialEnd
+	}
+	return nil
+}
+
+func (x *Tenant) GetStatusChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return nil
+}
+
+func (x *Tenant) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Tenant) GetLimits() *TenantLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *Tenant) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// 0 means unlimited
+type TenantLimits struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MaxUsers int32                  `protobuf:"varint,1,opt,name=max_users,json=maxUsers,proto3" json:"max_users,omitempty" bson:"max_users,omitempty"`
+	// Roles created in the tenant, the system roles are not counted
+	MaxRoles int32 `protobuf:"varint,2,opt,name=max_roles,json=maxRoles,proto3" json:"max_roles,omitempty" bson:"max_roles,omitempty"`
+	// Keys neither revoked nor expired
+	MaxApiKeys    int32 `protobuf:"varint,3,opt,name=max_api_keys,json=maxApiKeys,proto3" json:"max_api_keys,omitempty" bson:"max_api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantLimits) Reset() {
+	*x = TenantLimits{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantLimits) ProtoMessage() {}
+
+func (x *TenantLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantLimits.ProtoReflect.Descriptor instead.
+func (*TenantLimits) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TenantLimits) GetMaxUsers() int32 {
+	if x != nil {
+		return x.MaxUsers
+	}
+	return 0
+}
+
+func (x *TenantLimits) GetMaxRoles() int32 {
+	if x != nil {
+		return x.MaxRoles
+	}
+	return 0
+}
+
+func (x *TenantLimits) GetMaxApiKeys() int32 {
+	if x != nil {
+		return x.MaxApiKeys
+	}
+	return 0
+}
+
 type Subscription struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Plan          string                 `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan" bson:"plan"`
@@ -235,7 +452,7 @@ type Subscription struct {
 
 func (x *Subscription) Reset() {
 	*x = Subscription{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[1]
+	mi := &file_auth_v1_tenant_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -247,7 +464,7 @@ func (x *Subscription) String() string {
 func (*Subscription) ProtoMessage() {}
 
 func (x *Subscription) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[1]
+	mi := &file_auth_v1_tenant_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -260,7 +477,7 @@ func (x *Subscription) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
 func (*Subscription) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{1}
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Subscription) GetPlan() string {
@@ -304,13 +521,15 @@ type SubscriptionLimits struct {
 	MaxProducts       int32                  `protobuf:"varint,2,opt,name=max_products,json=maxProducts,proto3" json:"max_products" bson:"max_products"`
 	MaxOrdersPerMonth int32                  `protobuf:"varint,3,opt,name=max_orders_per_month,json=maxOrdersPerMonth,proto3" json:"max_orders_per_month" bson:"max_orders_per_month"`
 	StorageGb         int32                  `protobuf:"varint,4,opt,name=storage_gb,json=storageGb,proto3" json:"storage_gb" bson:"storage_gb"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Hard cap on API calls per calendar month (UTC), 0 means unlimited
+	MaxApiCallsPerMonth int64 `protobuf:"varint,5,opt,name=max_api_calls_per_month,json=maxApiCallsPerMonth,proto3" json:"max_api_calls_per_month,omitempty" bson:"max_api_calls_per_month,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *SubscriptionLimits) Reset() {
 	*x = SubscriptionLimits{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[2]
+	mi := &file_auth_v1_tenant_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -322,7 +541,7 @@ func (x *SubscriptionLimits) String() string {
 func (*SubscriptionLimits) ProtoMessage() {}
 
 func (x *SubscriptionLimits) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[2]
+	mi := &file_auth_v1_tenant_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +554,7 @@ func (x *SubscriptionLimits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscriptionLimits.ProtoReflect.Descriptor instead.
 func (*SubscriptionLimits) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{2}
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SubscriptionLimits) GetMaxUsers() int32 {
@@ -366,6 +585,13 @@ func (x *SubscriptionLimits) GetStorageGb() int32 {
 	return 0
 }
 
+func (x *SubscriptionLimits) GetMaxApiCallsPerMonth() int64 {
+	if x != nil {
+		return x.MaxApiCallsPerMonth
+	}
+	return 0
+}
+
 type TenantSettings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timezone      string                 `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone" bson:"timezone"`
@@ -373,13 +599,19 @@ type TenantSettings struct {
 	DateFormat    string                 `protobuf:"bytes,3,opt,name=date_format,json=dateFormat,proto3" json:"date_format" bson:"date_format"`
 	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language" bson:"language"`
 	BusinessHours map[string]*Hours      `protobuf:"bytes,5,rep,name=business_hours,json=businessHours,proto3" json:"business_hours,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value" bson:"business_hours,omitempty"`
+	// Optional profile fields (phone, title, department, mfa) users should eventually complete
+	EventuallyRequiredProfileFields []string `protobuf:"bytes,6,rep,name=eventually_required_profile_fields,json=eventuallyRequiredProfileFields,proto3" json:"eventually_required_profile_fields,omitempty" bson:"eventually_required_profile_fields,omitempty"`
+	// Password rules of the tenant users, the system default policy is used when unset
+	PasswordPolicy *PasswordPolicy `protobuf:"bytes,7,opt,name=password_policy,json=passwordPolicy,proto3" json:"password_policy,omitempty" bson:"password_policy,omitempty"`
+	// Federated login through OIDC providers, disabled when unset
+	Oidc          *OIDCSettings `protobuf:"bytes,8,opt,name=oidc,proto3" json:"oidc,omitempty" bson:"oidc,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TenantSettings) Reset() {
 	*x = TenantSettings{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[3]
+	mi := &file_auth_v1_tenant_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -391,7 +623,7 @@ func (x *TenantSettings) String() string {
 func (*TenantSettings) ProtoMessage() {}
 
 func (x *TenantSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[3]
+	mi := &file_auth_v1_tenant_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +636,7 @@ func (x *TenantSettings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TenantSettings.ProtoReflect.Descriptor instead.
 func (*TenantSettings) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{3}
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TenantSettings) GetTimezone() string {
@@ -442,81 +674,57 @@ func (x *TenantSettings) GetBusinessHours() map[string]*Hours {
 	return nil
 }
 
-type Hours struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start" bson:"start"`
-	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end" bson:"end"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Hours) Reset() {
-	*x = Hours{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Hours) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Hours) ProtoMessage() {}
-
-func (x *Hours) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[4]
+func (x *TenantSettings) GetEventuallyRequiredProfileFields() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EventuallyRequiredProfileFields
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Hours.ProtoReflect.Descriptor instead.
-func (*Hours) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{4}
+	return nil
 }
 
-func (x *Hours) GetStart() string {
+func (x *TenantSettings) GetPasswordPolicy() *PasswordPolicy {
 	if x != nil {
-		return x.Start
+		return x.PasswordPolicy
 	}
-	return ""
+	return nil
 }
 
-func (x *Hours) GetEnd() string {
+func (x *TenantSettings) GetOidc() *OIDCSettings {
 	if x != nil {
-		return x.End
+		return x.Oidc
 	}
-	return ""
-}
-
-type ContactInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email" bson:"email"`
-	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone" bson:"phone"`
-	Address       *v1.Address            `protobuf:"bytes,3,opt,name=address,proto3" json:"address" bson:"address"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return nil
 }
 
-func (x *ContactInfo) Reset() {
-	*x = ContactInfo{}
+type OIDCSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Names of the configured providers the tenant users may log in with
+	Providers []string `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers" bson:"providers"`
+	// Create an account on first login when no user is linked to the external identity
+	AutoProvision bool `protobuf:"varint,2,opt,name=auto_provision,json=autoProvision,proto3" json:"auto_provision" bson:"auto_provision"`
+	// Roles assigned to auto-provisioned users
+	DefaultRoleIds []string `protobuf:"bytes,3,rep,name=default_role_ids,json=defaultRoleIds,proto3" json:"default_role_ids,omitempty" bson:"default_role_ids,omitempty"`
+	// Link the external identity to the user with the same email when the provider verified it
+	LinkByVerifiedEmail bool `protobuf:"varint,4,opt,name=link_by_verified_email,json=linkByVerifiedEmail,proto3" json:"link_by_verified_email" bson:"link_by_verified_email"`
+	// Email domains allowed to be auto-provisioned, any domain when empty
+	AllowedDomains []string `protobuf:"bytes,5,rep,name=allowed_domains,json=allowedDomains,proto3" json:"allowed_domains,omitempty" bson:"allowed_domains,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OIDCSettings) Reset() {
+	*x = OIDCSettings{}
 	mi := &file_auth_v1_tenant_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ContactInfo) String() string {
+func (x *OIDCSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ContactInfo) ProtoMessage() {}
+func (*OIDCSettings) ProtoMessage() {}
 
-func (x *ContactInfo) ProtoReflect() protoreflect.Message {
+func (x *OIDCSettings) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_v1_tenant_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -528,55 +736,79 @@ func (x *ContactInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ContactInfo.ProtoReflect.Descriptor instead.
-func (*ContactInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use OIDCSettings.ProtoReflect.Descriptor instead.
+func (*OIDCSettings) Descriptor() ([]byte, []int) {
 	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ContactInfo) GetEmail() string {
+func (x *OIDCSettings) GetProviders() []string {
 	if x != nil {
-		return x.Email
+		return x.Providers
 	}
-	return ""
+	return nil
 }
 
-func (x *ContactInfo) GetPhone() string {
+func (x *OIDCSettings) GetAutoProvision() bool {
 	if x != nil {
-		return x.Phone
+		return x.AutoProvision
 	}
-	return ""
+	return false
 }
 
-func (x *ContactInfo) GetAddress() *v1.Address {
+func (x *OIDCSettings) GetDefaultRoleIds() []string {
 	if x != nil {
-		return x.Address
+		return x.DefaultRoleIds
 	}
 	return nil
 }
 
-type Branding struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	LogoUrl       string                 `protobuf:"bytes,1,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty" bson:"logo_url,omitempty"`
-	PrimaryColor  string                 `protobuf:"bytes,2,opt,name=primary_color,json=primaryColor,proto3" json:"primary_color,omitempty" bson:"primary_color,omitempty"`
-	CompanyName   string                 `protobuf:"bytes,3,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty" bson:"company_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *OIDCSettings) GetLinkByVerifiedEmail() bool {
+	if x != nil {
+		return x.LinkByVerifiedEmail
+	}
+	return false
 }
 
-func (x *Branding) Reset() {
-	*x = Branding{}
+func (x *OIDCSettings) GetAllowedDomains() []string {
+	if x != nil {
+		return x.AllowedDomains
+	}
+	return nil
+}
+
+type PasswordPolicy struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MinLength        int32                  `protobuf:"varint,1,opt,name=min_length,json=minLength,proto3" json:"min_length" bson:"min_length"`
+	RequireUppercase bool                   `protobuf:"varint,2,opt,name=require_uppercase,json=requireUppercase,proto3" json:"require_uppercase" bson:"require_uppercase"`
+	RequireLowercase bool                   `protobuf:"varint,3,opt,name=require_lowercase,json=requireLowercase,proto3" json:"require_lowercase" bson:"require_lowercase"`
+	RequireDigit     bool                   `protobuf:"varint,4,opt,name=require_digit,json=requireDigit,proto3" json:"require_digit" bson:"require_digit"`
+	RequireSymbol    bool                   `protobuf:"varint,5,opt,name=require_symbol,json=requireSymbol,proto3" json:"require_symbol" bson:"require_symbol"`
+	// Passwords rejected in addition to the built-in list, compared case-insensitively
+	BannedPasswords []string `protobuf:"bytes,6,rep,name=banned_passwords,json=bannedPasswords,proto3" json:"banned_passwords,omitempty" bson:"banned_passwords,omitempty"`
+	// Number of previous passwords that cannot be reused, 0 disables the check
+	HistoryDepth int32 `protobuf:"varint,7,opt,name=history_depth,json=historyDepth,proto3" json:"history_depth" bson:"history_depth"`
+	// Days after which a password must be changed, 0 disables expiry
+	MaxAgeDays int32 `protobuf:"varint,8,opt,name=max_age_days,json=maxAgeDays,proto3" json:"max_age_days" bson:"max_age_days"`
+	// Minimum estimated entropy, 0 disables the check
+	MinEntropyBits float64 `protobuf:"fixed64,9,opt,name=min_entropy_bits,json=minEntropyBits,proto3" json:"min_entropy_bits" bson:"min_entropy_bits"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PasswordPolicy) Reset() {
+	*x = PasswordPolicy{}
 	mi := &file_auth_v1_tenant_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Branding) String() string {
+func (x *PasswordPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Branding) ProtoMessage() {}
+func (*PasswordPolicy) ProtoMessage() {}
 
-func (x *Branding) ProtoReflect() protoreflect.Message {
+func (x *PasswordPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_v1_tenant_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -588,55 +820,96 @@ func (x *Branding) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Branding.ProtoReflect.Descriptor instead.
-func (*Branding) Descriptor() ([]byte, []int) {
+// Deprecated: Use PasswordPolicy.ProtoReflect.Descriptor instead.
+func (*PasswordPolicy) Descriptor() ([]byte, []int) {
 	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Branding) GetLogoUrl() string {
+func (x *PasswordPolicy) GetMinLength() int32 {
 	if x != nil {
-		return x.LogoUrl
+		return x.MinLength
 	}
-	return ""
+	return 0
 }
 
-func (x *Branding) GetPrimaryColor() string {
+func (x *PasswordPolicy) GetRequireUppercase() bool {
 	if x != nil {
-		return x.PrimaryColor
+		return x.RequireUppercase
 	}
-	return ""
+	return false
 }
 
-func (x *Branding) GetCompanyName() string {
+func (x *PasswordPolicy) GetRequireLowercase() bool {
 	if x != nil {
-		return x.CompanyName
+		return x.RequireLowercase
 	}
-	return ""
+	return false
 }
 
-type TenantMetadata struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	OnboardingCompleted bool                   `protobuf:"varint,1,opt,name=onboarding_completed,json=onboardingCompleted,proto3" json:"onboarding_completed" bson:"onboarding_completed"`
-	Industry            string                 `protobuf:"bytes,2,opt,name=industry,proto3" json:"industry,omitempty" bson:"industry,omitempty"`
-	CompanySize         string                 `protobuf:"bytes,3,opt,name=company_size,json=companySize,proto3" json:"company_size,omitempty" bson:"company_size,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *PasswordPolicy) GetRequireDigit() bool {
+	if x != nil {
+		return x.RequireDigit
+	}
+	return false
 }
 
-func (x *TenantMetadata) Reset() {
-	*x = TenantMetadata{}
+func (x *PasswordPolicy) GetRequireSymbol() bool {
+	if x != nil {
+		return x.RequireSymbol
+	}
+	return false
+}
+
+func (x *PasswordPolicy) GetBannedPasswords() []string {
+	if x != nil {
+		return x.BannedPasswords
+	}
+	return nil
+}
+
+func (x *PasswordPolicy) GetHistoryDepth() int32 {
+	if x != nil {
+		return x.HistoryDepth
+	}
+	return 0
+}
+
+func (x *PasswordPolicy) GetMaxAgeDays() int32 {
+	if x != nil {
+		return x.MaxAgeDays
+	}
+	return 0
+}
+
+func (x *PasswordPolicy) GetMinEntropyBits() float64 {
+	if x != nil {
+		return x.MinEntropyBits
+	}
+	return 0
+}
+
+type Hours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start" bson:"start"`
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end" bson:"end"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hours) Reset() {
+	*x = Hours{}
 	mi := &file_auth_v1_tenant_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TenantMetadata) String() string {
+func (x *Hours) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TenantMetadata) ProtoMessage() {}
+func (*Hours) ProtoMessage() {}
 
-func (x *TenantMetadata) ProtoReflect() protoreflect.Message {
+func (x *Hours) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_v1_tenant_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -648,54 +921,48 @@ func (x *TenantMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TenantMetadata.ProtoReflect.Descriptor instead.
-func (*TenantMetadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use Hours.ProtoReflect.Descriptor instead.
+func (*Hours) Descriptor() ([]byte, []int) {
 	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *TenantMetadata) GetOnboardingCompleted() bool {
-	if x != nil {
-		return x.OnboardingCompleted
-	}
-	return false
-}
-
-func (x *TenantMetadata) GetIndustry() string {
+func (x *Hours) GetStart() string {
 	if x != nil {
-		return x.Industry
+		return x.Start
 	}
 	return ""
 }
 
-func (x *TenantMetadata) GetCompanySize() string {
+func (x *Hours) GetEnd() string {
 	if x != nil {
-		return x.CompanySize
+		return x.End
 	}
 	return ""
 }
 
-type CreateTenantRequest struct {
+type ContactInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	Tenant        *Tenant                `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email" bson:"email"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone" bson:"phone"`
+	Address       *v1.Address            `protobuf:"bytes,3,opt,name=address,proto3" json:"address" bson:"address"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTenantRequest) Reset() {
-	*x = CreateTenantRequest{}
+func (x *ContactInfo) Reset() {
+	*x = ContactInfo{}
 	mi := &file_auth_v1_tenant_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTenantRequest) String() string {
+func (x *ContactInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTenantRequest) ProtoMessage() {}
+func (*ContactInfo) ProtoMessage() {}
 
-func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
+func (x *ContactInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_v1_tenant_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -707,46 +974,55 @@ func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTenantRequest.ProtoReflect.Descriptor instead.
-func (*CreateTenantRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ContactInfo.ProtoReflect.Descriptor instead.
+func (*ContactInfo) Descriptor() ([]byte, []int) {
 	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CreateTenantRequest) GetIdentifier() *v11.UserIdentifier {
+func (x *ContactInfo) GetEmail() string {
 	if x != nil {
-		return x.Identifier
+		return x.Email
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateTenantRequest) GetTenant() *Tenant {
+func (x *ContactInfo) GetPhone() string {
 	if x != nil {
-		return x.Tenant
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *ContactInfo) GetAddress() *v1.Address {
+	if x != nil {
+		return x.Address
 	}
 	return nil
 }
 
-type CreateTenantResponse struct {
+type Branding struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	LogoUrl       string                 `protobuf:"bytes,1,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty" bson:"logo_url,omitempty"`
+	PrimaryColor  string                 `protobuf:"bytes,2,opt,name=primary_color,json=primaryColor,proto3" json:"primary_color,omitempty" bson:"primary_color,omitempty"`
+	CompanyName   string                 `protobuf:"bytes,3,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty" bson:"company_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTenantResponse) Reset() {
-	*x = CreateTenantResponse{}
+func (x *Branding) Reset() {
+	*x = Branding{}
 	mi := &file_auth_v1_tenant_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTenantResponse) String() string {
+func (x *Branding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTenantResponse) ProtoMessage() {}
+func (*Branding) ProtoMessage() {}
 
-func (x *CreateTenantResponse) ProtoReflect() protoreflect.Message {
+func (x *Branding) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_v1_tenant_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -758,45 +1034,1306 @@ func (x *CreateTenantResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTenantResponse.ProtoReflect.Descriptor instead.
-func (*CreateTenantResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Branding.ProtoReflect.Descriptor instead.
+func (*Branding) Descriptor() ([]byte, []int) {
 	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateTenantResponse) GetTenantId() string {
+func (x *Branding) GetLogoUrl() string {
+	if x != nil {
+		return x.LogoUrl
+	}
+	return ""
+}
+
+func (x *Branding) GetPrimaryColor() string {
+	if x != nil {
+		return x.PrimaryColor
+	}
+	return ""
+}
+
+func (x *Branding) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+type TenantMetadata struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	OnboardingCompleted bool                   `protobuf:"varint,1,opt,name=onboarding_completed,json=onboardingCompleted,proto3" json:"onboarding_completed" bson:"onboarding_completed"`
+	Industry            string                 `protobuf:"bytes,2,opt,name=industry,proto3" json:"industry,omitempty" bson:"industry,omitempty"`
+	CompanySize         string                 `protobuf:"bytes,3,opt,name=company_size,json=companySize,proto3" json:"company_size,omitempty" bson:"company_size,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *TenantMetadata) Reset() {
+	*x = TenantMetadata{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantMetadata) ProtoMessage() {}
+
+func (x *TenantMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantMetadata.ProtoReflect.Descriptor instead.
+func (*TenantMetadata) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TenantMetadata) GetOnboardingCompleted() bool {
+	if x != nil {
+		return x.OnboardingCompleted
+	}
+	return false
+}
+
+func (x *TenantMetadata) GetIndustry() string {
+	if x != nil {
+		return x.Industry
+	}
+	return ""
+}
+
+func (x *TenantMetadata) GetCompanySize() string {
+	if x != nil {
+		return x.CompanySize
+	}
+	return ""
+}
+
+// API usage daily rollup for MongoDB auth_db.api_usage collection
+type UsageRollup struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	// UTC day in YYYY-MM-DD format
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date" bson:"date"`
+	Service       string                 `protobuf:"bytes,4,opt,name=service,proto3" json:"service" bson:"service"`
+	Method        string                 `protobuf:"bytes,5,opt,name=method,proto3" json:"method" bson:"method"`
+	Calls         int64                  `protobuf:"varint,6,opt,name=calls,proto3" json:"calls" bson:"calls"`
+	Errors        int64                  `protobuf:"varint,7,opt,name=errors,proto3" json:"errors" bson:"errors"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageRollup) Reset() {
+	*x = UsageRollup{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageRollup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageRollup) ProtoMessage() {}
+
+func (x *UsageRollup) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageRollup.ProtoReflect.Descriptor instead.
+func (*UsageRollup) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UsageRollup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UsageRollup) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UsageRollup) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *UsageRollup) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *UsageRollup) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *UsageRollup) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *UsageRollup) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *UsageRollup) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Tenant        *Tenant                `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTenantRequest) Reset() {
+	*x = CreateTenantRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantRequest) ProtoMessage() {}
+
+func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantRequest.ProtoReflect.Descriptor instead.
+func (*CreateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateTenantRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateTenantRequest) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+type CreateTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTenantResponse) Reset() {
+	*x = CreateTenantResponse{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantResponse) ProtoMessage() {}
+
+func (x *CreateTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantResponse.ProtoReflect.Descriptor instead.
+func (*CreateTenantResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateTenantResponse) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type GetTenantRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Types that are valid to be assigned to Tenant:
+	//
+	//	*GetTenantRequest_TenantId
+	//	*GetTenantRequest_Name
+	Tenant        isGetTenantRequest_Tenant `protobuf_oneof:"tenant"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantRequest) Reset() {
+	*x = GetTenantRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantRequest) ProtoMessage() {}
+
+func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTenantRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetTenantRequest) GetTenant() isGetTenantRequest_Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *GetTenantRequest) GetTenantId() string {
+	if x != nil {
+		if x, ok := x.Tenant.(*GetTenantRequest_TenantId); ok {
+			return x.TenantId
+		}
+	}
+	return ""
+}
+
+func (x *GetTenantRequest) GetName() string {
+	if x != nil {
+		if x, ok := x.Tenant.(*GetTenantRequest_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+type isGetTenantRequest_Tenant interface {
+	isGetTenantRequest_Tenant()
+}
+
+type GetTenantRequest_TenantId struct {
+	TenantId string `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3,oneof"`
+}
+
+type GetTenantRequest_Name struct {
+	Name string `protobuf:"bytes,3,opt,name=name,proto3,oneof"`
+}
+
+func (*GetTenantRequest_TenantId) isGetTenantRequest_Tenant() {}
+
+func (*GetTenantRequest_Name) isGetTenantRequest_Tenant() {}
+
+type ListTenantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Status        *string                `protobuf:"bytes,2,opt,name=status,proto3,oneof" json:"status,omitempty"` // Filter by status
+	Pagination    *v11.PaginationRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsRequest) Reset() {
+	*x = ListTenantsRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsRequest) ProtoMessage() {}
+
+func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTenantsRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListTenantsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *ListTenantsRequest) GetPagination() *v11.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListTenantsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Tenants       []*Tenant               `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	Pagination    *v11.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsResponse) Reset() {
+	*x = ListTenantsResponse{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsResponse) ProtoMessage() {}
+
+func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTenantsResponse) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+func (x *ListTenantsResponse) GetPagination() *v11.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UpdateTenantRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Tenant     *Tenant                `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Fields of tenant to change, the named fields unset in tenant are cleared. The whole tenant is replaced when empty
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTenantRequest) Reset() {
+	*x = UpdateTenantRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantRequest) ProtoMessage() {}
+
+func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateTenantRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateTenantRequest) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+func (x *UpdateTenantRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       bool                   `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTenantResponse) Reset() {
+	*x = UpdateTenantResponse{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantResponse) ProtoMessage() {}
+
+func (x *UpdateTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTenantResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateTenantResponse) GetUpdated() bool {
+	if x != nil {
+		return x.Updated
+	}
+	return false
+}
+
+type DeleteTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantRequest) Reset() {
+	*x = DeleteTenantRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantRequest) ProtoMessage() {}
+
+func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteTenantRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DeleteTenantRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type DeleteTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantResponse) Reset() {
+	*x = DeleteTenantResponse{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantResponse) ProtoMessage() {}
+
+func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTenantResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteTenantResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Usage
+type GetUsageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Inclusive UTC days in YYYY-MM-DD format, defaults to the current month
+	FromDate      string `protobuf:"bytes,3,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        string `protobuf:"bytes,4,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetUsageRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetUsageRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+type UsageReport struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TenantId         string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Rollups          []*UsageRollup         `protobuf:"bytes,2,rep,name=rollups,proto3" json:"rollups,omitempty"`
+	TotalCalls       int64                  `protobuf:"varint,3,opt,name=total_calls,json=totalCalls,proto3" json:"total_calls,omitempty"`
+	MonthToDateCalls int64                  `protobuf:"varint,4,opt,name=month_to_date_calls,json=monthToDateCalls,proto3" json:"month_to_date_calls,omitempty"`
+	// 0 means unlimited
+	MonthlyCap    int64 `protobuf:"varint,5,opt,name=monthly_cap,json=monthlyCap,proto3" json:"monthly_cap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UsageReport) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UsageReport) GetRollups() []*UsageRollup {
+	if x != nil {
+		return x.Rollups
+	}
+	return nil
+}
+
+func (x *UsageReport) GetTotalCalls() int64 {
+	if x != nil {
+		return x.TotalCalls
+	}
+	return 0
+}
+
+func (x *UsageReport) GetMonthToDateCalls() int64 {
+	if x != nil {
+		return x.MonthToDateCalls
+	}
+	return 0
+}
+
+func (x *UsageReport) GetMonthlyCap() int64 {
+	if x != nil {
+		return x.MonthlyCap
+	}
+	return 0
+}
+
+type ExportUsageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Billing month in YYYY-MM format, defaults to the previous month
+	Month         string            `protobuf:"bytes,3,opt,name=month,proto3" json:"month,omitempty"`
+	Format        UsageExportFormat `protobuf:"varint,4,opt,name=format,proto3,enum=auth.v1.UsageExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsageRequest) Reset() {
+	*x = ExportUsageRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsageRequest) ProtoMessage() {}
+
+func (x *ExportUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsageRequest.ProtoReflect.Descriptor instead.
+func (*ExportUsageRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExportUsageRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ExportUsageRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *ExportUsageRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ExportUsageRequest) GetFormat() UsageExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return UsageExportFormat_USAGE_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type ExportUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsageResponse) Reset() {
+	*x = ExportUsageResponse{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsageResponse) ProtoMessage() {}
+
+func (x *ExportUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsageResponse.ProtoReflect.Descriptor instead.
+func (*ExportUsageResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ExportUsageResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportUsageResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportUsageResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Quotas
+type GetTenantUsageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetTenantUsageRequest) Reset() {
+	*x = GetTenantUsageRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantUsageRequest) ProtoMessage() {}
+
+func (x *GetTenantUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantUsageRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTenantUsageRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetTenantUsageRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+type ResourceUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Count int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// 0 means unlimited
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceUsage) Reset() {
+	*x = ResourceUsage{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceUsage) ProtoMessage() {}
+
+func (x *ResourceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceUsage.ProtoReflect.Descriptor instead.
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ResourceUsage) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TenantUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Users         *ResourceUsage         `protobuf:"bytes,2,opt,name=users,proto3" json:"users,omitempty"`
+	Roles         *ResourceUsage         `protobuf:"bytes,3,opt,name=roles,proto3" json:"roles,omitempty"`
+	ApiKeys       *ResourceUsage         `protobuf:"bytes,4,opt,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantUsage) Reset() {
+	*x = TenantUsage{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantUsage) ProtoMessage() {}
+
+func (x *TenantUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantUsage.ProtoReflect.Descriptor instead.
+func (*TenantUsage) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TenantUsage) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *TenantUsage) GetUsers() *ResourceUsage {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *TenantUsage) GetRoles() *ResourceUsage {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *TenantUsage) GetApiKeys() *ResourceUsage {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+type ExportTenantDataRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	Format         TenantExportFormat     `protobuf:"varint,3,opt,name=format,proto3,enum=auth.v1.TenantExportFormat" json:"format,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportTenantDataRequest) Reset() {
+	*x = ExportTenantDataRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTenantDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTenantDataRequest) ProtoMessage() {}
+
+func (x *ExportTenantDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTenantDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportTenantDataRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ExportTenantDataRequest) GetIdentifier() *v11.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ExportTenantDataRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *ExportTenantDataRequest) GetFormat() TenantExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TenantExportFormat_TENANT_EXPORT_FORMAT_UNSPECIFIED
+}
+
+// A chunk of the documents of one collection, the chunks of a collection are sent in order
+type ExportTenantDataChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Format        TenantExportFormat     `protobuf:"varint,2,opt,name=format,proto3,enum=auth.v1.TenantExportFormat" json:"format,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	DocumentCount int32                  `protobuf:"varint,4,opt,name=document_count,json=documentCount,proto3" json:"document_count,omitempty"`
+	Progress      *ExportProgress        `protobuf:"bytes,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTenantDataChunk) Reset() {
+	*x = ExportTenantDataChunk{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTenantDataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTenantDataChunk) ProtoMessage() {}
+
+func (x *ExportTenantDataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTenantDataChunk.ProtoReflect.Descriptor instead.
+func (*ExportTenantDataChunk) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportTenantDataChunk) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ExportTenantDataChunk) GetFormat() TenantExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TenantExportFormat_TENANT_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportTenantDataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportTenantDataChunk) GetDocumentCount() int32 {
+	if x != nil {
+		return x.DocumentCount
+	}
+	return 0
+}
+
+func (x *ExportTenantDataChunk) GetProgress() *ExportProgress {
 	if x != nil {
-		return x.TenantId
+		return x.Progress
 	}
-	return ""
+	return nil
 }
 
-type GetTenantRequest struct {
-	state      protoimpl.MessageState `protogen:"open.v1"`
-	Identifier *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	// Types that are valid to be assigned to Tenant:
-	//
-	//	*GetTenantRequest_TenantId
-	//	*GetTenantRequest_Name
-	Tenant        isGetTenantRequest_Tenant `protobuf_oneof:"tenant"`
+type ExportProgress struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CollectionsDone   int32                  `protobuf:"varint,1,opt,name=collections_done,json=collectionsDone,proto3" json:"collections_done,omitempty"`
+	CollectionsTotal  int32                  `protobuf:"varint,2,opt,name=collections_total,json=collectionsTotal,proto3" json:"collections_total,omitempty"`
+	DocumentsExported int64                  `protobuf:"varint,3,opt,name=documents_exported,json=documentsExported,proto3" json:"documents_exported,omitempty"`
+	// Set on the last chunk of the export
+	Done          bool `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTenantRequest) Reset() {
-	*x = GetTenantRequest{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[10]
+func (x *ExportProgress) Reset() {
+	*x = ExportProgress{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTenantRequest) String() string {
+func (x *ExportProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTenantRequest) ProtoMessage() {}
+func (*ExportProgress) ProtoMessage() {}
 
-func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[10]
+func (x *ExportProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -807,83 +2344,69 @@ func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTenantRequest.ProtoReflect.Descriptor instead.
-func (*GetTenantRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ExportProgress.ProtoReflect.Descriptor instead.
+func (*ExportProgress) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *GetTenantRequest) GetIdentifier() *v11.UserIdentifier {
+func (x *ExportProgress) GetCollectionsDone() int32 {
 	if x != nil {
-		return x.Identifier
+		return x.CollectionsDone
 	}
-	return nil
+	return 0
 }
 
-func (x *GetTenantRequest) GetTenant() isGetTenantRequest_Tenant {
+func (x *ExportProgress) GetCollectionsTotal() int32 {
 	if x != nil {
-		return x.Tenant
+		return x.CollectionsTotal
 	}
-	return nil
+	return 0
 }
 
-func (x *GetTenantRequest) GetTenantId() string {
+func (x *ExportProgress) GetDocumentsExported() int64 {
 	if x != nil {
-		if x, ok := x.Tenant.(*GetTenantRequest_TenantId); ok {
-			return x.TenantId
-		}
+		return x.DocumentsExported
 	}
-	return ""
+	return 0
 }
 
-func (x *GetTenantRequest) GetName() string {
+func (x *ExportProgress) GetDone() bool {
 	if x != nil {
-		if x, ok := x.Tenant.(*GetTenantRequest_Name); ok {
-			return x.Name
-		}
+		return x.Done
 	}
-	return ""
-}
-
-type isGetTenantRequest_Tenant interface {
-	isGetTenantRequest_Tenant()
-}
-
-type GetTenantRequest_TenantId struct {
-	TenantId string `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3,oneof"`
-}
-
-type GetTenantRequest_Name struct {
-	Name string `protobuf:"bytes,3,opt,name=name,proto3,oneof"`
+	return false
 }
 
-func (*GetTenantRequest_TenantId) isGetTenantRequest_Tenant() {}
-
-func (*GetTenantRequest_Name) isGetTenantRequest_Tenant() {}
-
-type ListTenantsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	Status        *string                `protobuf:"bytes,2,opt,name=status,proto3,oneof" json:"status,omitempty"` // Filter by status
-	Pagination    *v11.PaginationRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+// Data import
+type ImportTenantDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Read from the first message of the stream
+	Identifier *v11.UserIdentifier `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// Tenant the documents are imported into, a new tenant is created from the exported tenant when empty
+	TargetTenantId string `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Report the conflicts without writing
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Chunk of ExportTenantData, audit logs are not imported
+	Chunk         *ExportTenantDataChunk `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTenantsRequest) Reset() {
-	*x = ListTenantsRequest{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[11]
+func (x *ImportTenantDataRequest) Reset() {
+	*x = ImportTenantDataRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTenantsRequest) String() string {
+func (x *ImportTenantDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTenantsRequest) ProtoMessage() {}
+func (*ImportTenantDataRequest) ProtoMessage() {}
 
-func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[11]
+func (x *ImportTenantDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,55 +2417,65 @@ func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
-func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ImportTenantDataRequest.ProtoReflect.Descriptor instead.
+func (*ImportTenantDataRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *ListTenantsRequest) GetIdentifier() *v11.UserIdentifier {
+func (x *ImportTenantDataRequest) GetIdentifier() *v11.UserIdentifier {
 	if x != nil {
 		return x.Identifier
 	}
 	return nil
 }
 
-func (x *ListTenantsRequest) GetStatus() string {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *ImportTenantDataRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
 	}
 	return ""
 }
 
-func (x *ListTenantsRequest) GetPagination() *v11.PaginationRequest {
+func (x *ImportTenantDataRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Pagination
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportTenantDataRequest) GetChunk() *ExportTenantDataChunk {
+	if x != nil {
+		return x.Chunk
 	}
 	return nil
 }
 
-type ListTenantsResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Tenants       []*Tenant               `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
-	Pagination    *v11.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+type ImportCollectionResult struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Collection string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// Documents inserted, or to insert on a dry run
+	Imported int32 `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Documents mapped onto the matching system documents of the tenant, e.g. the tenant_admin role
+	Reused        int32 `protobuf:"varint,3,opt,name=reused,proto3" json:"reused,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTenantsResponse) Reset() {
-	*x = ListTenantsResponse{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[12]
+func (x *ImportCollectionResult) Reset() {
+	*x = ImportCollectionResult{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTenantsResponse) String() string {
+func (x *ImportCollectionResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTenantsResponse) ProtoMessage() {}
+func (*ImportCollectionResult) ProtoMessage() {}
 
-func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[12]
+func (x *ImportCollectionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -953,48 +2486,59 @@ func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
-func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ImportCollectionResult.ProtoReflect.Descriptor instead.
+func (*ImportCollectionResult) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *ListTenantsResponse) GetTenants() []*Tenant {
+func (x *ImportCollectionResult) GetCollection() string {
 	if x != nil {
-		return x.Tenants
+		return x.Collection
 	}
-	return nil
+	return ""
 }
 
-func (x *ListTenantsResponse) GetPagination() *v11.PaginationResponse {
+func (x *ImportCollectionResult) GetImported() int32 {
 	if x != nil {
-		return x.Pagination
+		return x.Imported
 	}
-	return nil
+	return 0
 }
 
-type UpdateTenantRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	Tenant        *Tenant                `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+func (x *ImportCollectionResult) GetReused() int32 {
+	if x != nil {
+		return x.Reused
+	}
+	return 0
+}
+
+type ImportConflict struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Collection string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// Id of the document in the export
+	SourceId      string `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Field         string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	Value         string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTenantRequest) Reset() {
-	*x = UpdateTenantRequest{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[13]
+func (x *ImportConflict) Reset() {
+	*x = ImportConflict{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTenantRequest) String() string {
+func (x *ImportConflict) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTenantRequest) ProtoMessage() {}
+func (*ImportConflict) ProtoMessage() {}
 
-func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[13]
+func (x *ImportConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,47 +2549,73 @@ func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTenantRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTenantRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ImportConflict.ProtoReflect.Descriptor instead.
+func (*ImportConflict) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *UpdateTenantRequest) GetIdentifier() *v11.UserIdentifier {
+func (x *ImportConflict) GetCollection() string {
 	if x != nil {
-		return x.Identifier
+		return x.Collection
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateTenantRequest) GetTenant() *Tenant {
+func (x *ImportConflict) GetSourceId() string {
 	if x != nil {
-		return x.Tenant
+		return x.SourceId
 	}
-	return nil
+	return ""
 }
 
-type UpdateTenantResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Updated       bool                   `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+func (x *ImportConflict) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ImportConflict) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ImportConflict) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportTenantDataResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	DryRun   bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// False on dry runs and when conflicts were found, nothing is written then
+	Applied       bool                      `protobuf:"varint,3,opt,name=applied,proto3" json:"applied,omitempty"`
+	Collections   []*ImportCollectionResult `protobuf:"bytes,4,rep,name=collections,proto3" json:"collections,omitempty"`
+	Conflicts     []*ImportConflict         `protobuf:"bytes,5,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTenantResponse) Reset() {
-	*x = UpdateTenantResponse{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[14]
+func (x *ImportTenantDataResponse) Reset() {
+	*x = ImportTenantDataResponse{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTenantResponse) String() string {
+func (x *ImportTenantDataResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTenantResponse) ProtoMessage() {}
+func (*ImportTenantDataResponse) ProtoMessage() {}
 
-func (x *UpdateTenantResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[14]
+func (x *ImportTenantDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1056,41 +2626,74 @@ func (x *UpdateTenantResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTenantResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTenantResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ImportTenantDataResponse.ProtoReflect.Descriptor instead.
+func (*ImportTenantDataResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *UpdateTenantResponse) GetUpdated() bool {
+func (x *ImportTenantDataResponse) GetTenantId() string {
 	if x != nil {
-		return x.Updated
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ImportTenantDataResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
 	}
 	return false
 }
 
-type DeleteTenantRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+func (x *ImportTenantDataResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ImportTenantDataResponse) GetCollections() []*ImportCollectionResult {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+func (x *ImportTenantDataResponse) GetConflicts() []*ImportConflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+// Lifecycle
+type ChangeTenantStatusRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v11.UserIdentifier    `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Allowed transitions: trial to active, suspended or inactive, active to suspended or inactive, suspended to
+	// active or inactive and inactive to active
+	Status        TenantStatus `protobuf:"varint,3,opt,name=status,proto3,enum=auth.v1.TenantStatus" json:"status,omitempty"`
+	Reason        string       `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTenantRequest) Reset() {
-	*x = DeleteTenantRequest{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[15]
+func (x *ChangeTenantStatusRequest) Reset() {
+	*x = ChangeTenantStatusRequest{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTenantRequest) String() string {
+func (x *ChangeTenantStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTenantRequest) ProtoMessage() {}
+func (*ChangeTenantStatusRequest) ProtoMessage() {}
 
-func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[15]
+func (x *ChangeTenantStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1101,47 +2704,62 @@ func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ChangeTenantStatusRequest.ProtoReflect.Descriptor instead.
+func (*ChangeTenantStatusRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *DeleteTenantRequest) GetIdentifier() *v11.UserIdentifier {
+func (x *ChangeTenantStatusRequest) GetIdentifier() *v11.UserIdentifier {
 	if x != nil {
 		return x.Identifier
 	}
 	return nil
 }
 
-func (x *DeleteTenantRequest) GetTenantId() string {
+func (x *ChangeTenantStatusRequest) GetTargetTenantId() string {
 	if x != nil {
-		return x.TenantId
+		return x.TargetTenantId
 	}
 	return ""
 }
 
-type DeleteTenantResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ChangeTenantStatusRequest) GetStatus() TenantStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TenantStatus_TENANT_STATUS_UNSPECIFIED
 }
 
-func (x *DeleteTenantResponse) Reset() {
-	*x = DeleteTenantResponse{}
-	mi := &file_auth_v1_tenant_proto_msgTypes[16]
+func (x *ChangeTenantStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ChangeTenantStatusResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PreviousStatus TenantStatus           `protobuf:"varint,1,opt,name=previous_status,json=previousStatus,proto3,enum=auth.v1.TenantStatus" json:"previous_status,omitempty"`
+	Status         TenantStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=auth.v1.TenantStatus" json:"status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChangeTenantStatusResponse) Reset() {
+	*x = ChangeTenantStatusResponse{}
+	mi := &file_auth_v1_tenant_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTenantResponse) String() string {
+func (x *ChangeTenantStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTenantResponse) ProtoMessage() {}
+func (*ChangeTenantStatusResponse) ProtoMessage() {}
 
-func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_tenant_proto_msgTypes[16]
+func (x *ChangeTenantStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_tenant_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1152,29 +2770,36 @@ func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTenantResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTenantResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ChangeTenantStatusResponse.ProtoReflect.Descriptor instead.
+func (*ChangeTenantStatusResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_tenant_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *DeleteTenantResponse) GetDeleted() bool {
+func (x *ChangeTenantStatusResponse) GetPreviousStatus() TenantStatus {
 	if x != nil {
-		return x.Deleted
+		return x.PreviousStatus
 	}
-	return false
+	return TenantStatus_TENANT_STATUS_UNSPECIFIED
+}
+
+func (x *ChangeTenantStatusResponse) GetStatus() TenantStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TenantStatus_TENANT_STATUS_UNSPECIFIED
 }
 
 var File_auth_v1_tenant_proto protoreflect.FileDescriptor
 
 const file_auth_v1_tenant_proto_rawDesc = "" +
 	"\n" +
-	"\x14auth/v1/tenant.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x15core/v1/address.proto\"\xc3\b\n" +
-	"\x06Tenant\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x120\n" +
-	"\x04name\x18\x02 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x120\n" +
+	"\x14auth/v1/tenant.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x13tagger/tagger.proto\x1a\x15core/v1/address.proto\"\xb0\x0e\n" +
+	"\x06Tenant\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12D\n" +
+	"\x04name\x18\x02 \x01(\tB0\x9a\x84\x9e\x03+bson:\"name\" json:\"name\" validate:\"required\"R\x04name\x120\n" +
 	"\x04slug\x18\x03 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"slug\" json:\"slug\"R\x04slug\x12L\n" +
-	"\x06domain\x18\x04 \x01(\tB4\x9a\x84\x9e\x03/bson:\"domain,omitempty\" json:\"domain,omitempty\"R\x06domain\x12O\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x15.auth.v1.TenantStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12g\n" +
+	"\x06domain\x18\x04 \x01(\tB4\x9a\x84\x9e\x03/bson:\"domain,omitempty\" json:\"domain,omitempty\"R\x06domain\x12c\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x15.auth.v1.TenantStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12g\n" +
 	"\fsubscription\x18\x06 \x01(\v2\x15.auth.v1.SubscriptionB,\x9a\x84\x9e\x03'bson:\"subscription\" json:\"subscription\"R\fsubscription\x12Y\n" +
 	"\bsettings\x18\a \x01(\v2\x17.auth.v1.TenantSettingsB$\x9a\x84\x9e\x03\x1fbson:\"settings\" json:\"settings\"R\bsettings\x12R\n" +
 	"\acontact\x18\b \x01(\v2\x14.auth.v1.ContactInfoB\"\x9a\x84\x9e\x03\x1dbson:\"contact\" json:\"contact\"R\acontact\x12g\n" +
@@ -1183,33 +2808,67 @@ const file_auth_v1_tenant_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12G\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12[\n" +
 	"\n" +
-	"created_by\x18\f \x01(\tB(\x9a\x84\x9e\x03#bson:\"created_by\" json:\"created_by\"R\tcreatedBy\x12m\n" +
-	"\bmetadata\x18\r \x01(\v2\x17.auth.v1.TenantMetadataB8\x9a\x84\x9e\x033bson:\"metadata,omitempty\" json:\"metadata,omitempty\"R\bmetadata\"\x9b\x03\n" +
+	"created_by\x18\f \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12m\n" +
+	"\bmetadata\x18\r \x01(\v2\x17.auth.v1.TenantMetadataB8\x9a\x84\x9e\x033bson:\"metadata,omitempty\" json:\"metadata,omitempty\"R\bmetadata\x12{\n" +
+	"\vtrial_start\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampB>\x9a\x84\x9e\x039bson:\"trial_start,omitempty\" json:\"trial_start,omitempty\"R\n" +
+	"trialStart\x12s\n" +
+	"\ttrial_end\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampB:\x9a\x84\x9e\x035bson:\"trial_end,omitempty\" json:\"trial_end,omitempty\"R\btrialEnd\x12\x92\x01\n" +
+	"\x11status_changed_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampBJ\x9a\x84\x9e\x03Ebson:\"status_changed_at,omitempty\" json:\"status_changed_at,omitempty\"R\x0fstatusChangedAt\x12g\n" +
+	"\rstatus_reason\x18\x11 \x01(\tBB\x9a\x84\x9e\x03=bson:\"status_reason,omitempty\" json:\"status_reason,omitempty\"R\fstatusReason\x12c\n" +
+	"\x06limits\x18\x12 \x01(\v2\x15.auth.v1.TenantLimitsB4\x9a\x84\x9e\x03/bson:\"limits,omitempty\" json:\"limits,omitempty\"R\x06limits\x12<\n" +
+	"\aversion\x18\x13 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\"\xa4\x02\n" +
+	"\fTenantLimits\x12W\n" +
+	"\tmax_users\x18\x01 \x01(\x05B:\x9a\x84\x9e\x035bson:\"max_users,omitempty\" json:\"max_users,omitempty\"R\bmaxUsers\x12W\n" +
+	"\tmax_roles\x18\x02 \x01(\x05B:\x9a\x84\x9e\x035bson:\"max_roles,omitempty\" json:\"max_roles,omitempty\"R\bmaxRoles\x12b\n" +
+	"\fmax_api_keys\x18\x03 \x01(\x05B@\x9a\x84\x9e\x03;bson:\"max_api_keys,omitempty\" json:\"max_api_keys,omitempty\"R\n" +
+	"maxApiKeys\"\x9b\x03\n" +
 	"\fSubscription\x120\n" +
 	"\x04plan\x18\x01 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"plan\" json:\"plan\"R\x04plan\x12c\n" +
 	"\n" +
 	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"start_date\" json:\"start_date\"R\tstartDate\x12[\n" +
 	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB$\x9a\x84\x9e\x03\x1fbson:\"end_date\" json:\"end_date\"R\aendDate\x12@\n" +
 	"\bfeatures\x18\x04 \x03(\tB$\x9a\x84\x9e\x03\x1fbson:\"features\" json:\"features\"R\bfeatures\x12U\n" +
-	"\x06limits\x18\x05 \x01(\v2\x1b.auth.v1.SubscriptionLimitsB \x9a\x84\x9e\x03\x1bbson:\"limits\" json:\"limits\"R\x06limits\"\xe2\x02\n" +
+	"\x06limits\x18\x05 \x01(\v2\x1b.auth.v1.SubscriptionLimitsB \x9a\x84\x9e\x03\x1bbson:\"limits\" json:\"limits\"R\x06limits\"\xf1\x03\n" +
 	"\x12SubscriptionLimits\x12C\n" +
 	"\tmax_users\x18\x01 \x01(\x05B&\x9a\x84\x9e\x03!bson:\"max_users\" json:\"max_users\"R\bmaxUsers\x12O\n" +
 	"\fmax_products\x18\x02 \x01(\x05B,\x9a\x84\x9e\x03'bson:\"max_products\" json:\"max_products\"R\vmaxProducts\x12m\n" +
 	"\x14max_orders_per_month\x18\x03 \x01(\x05B<\x9a\x84\x9e\x037bson:\"max_orders_per_month\" json:\"max_orders_per_month\"R\x11maxOrdersPerMonth\x12G\n" +
 	"\n" +
-	"storage_gb\x18\x04 \x01(\x05B(\x9a\x84\x9e\x03#bson:\"storage_gb\" json:\"storage_gb\"R\tstorageGb\"\x8f\x04\n" +
+	"storage_gb\x18\x04 \x01(\x05B(\x9a\x84\x9e\x03#bson:\"storage_gb\" json:\"storage_gb\"R\tstorageGb\x12\x8c\x01\n" +
+	"\x17max_api_calls_per_month\x18\x05 \x01(\x03BV\x9a\x84\x9e\x03Qbson:\"max_api_calls_per_month,omitempty\" json:\"max_api_calls_per_month,omitempty\"R\x13maxApiCallsPerMonth\"\xb3\a\n" +
 	"\x0eTenantSettings\x12@\n" +
 	"\btimezone\x18\x01 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"timezone\" json:\"timezone\"R\btimezone\x12@\n" +
 	"\bcurrency\x18\x02 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"currency\" json:\"currency\"R\bcurrency\x12K\n" +
 	"\vdate_format\x18\x03 \x01(\tB*\x9a\x84\x9e\x03%bson:\"date_format\" json:\"date_format\"R\n" +
 	"dateFormat\x12@\n" +
 	"\blanguage\x18\x04 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"language\" json:\"language\"R\blanguage\x12\x97\x01\n" +
-	"\x0ebusiness_hours\x18\x05 \x03(\v2*.auth.v1.TenantSettings.BusinessHoursEntryBD\x9a\x84\x9e\x03?bson:\"business_hours,omitempty\" json:\"business_hours,omitempty\"R\rbusinessHours\x1aP\n" +
+	"\x0ebusiness_hours\x18\x05 \x03(\v2*.auth.v1.TenantSettings.BusinessHoursEntryBD\x9a\x84\x9e\x03?bson:\"business_hours,omitempty\" json:\"business_hours,omitempty\"R\rbusinessHours\x12\xb9\x01\n" +
+	"\"eventually_required_profile_fields\x18\x06 \x03(\tBl\x9a\x84\x9e\x03gbson:\"eventually_required_profile_fields,omitempty\" json:\"eventually_required_profile_fields,omitempty\"R\x1feventuallyRequiredProfileFields\x12\x88\x01\n" +
+	"\x0fpassword_policy\x18\a \x01(\v2\x17.auth.v1.PasswordPolicyBF\x9a\x84\x9e\x03Abson:\"password_policy,omitempty\" json:\"password_policy,omitempty\"R\x0epasswordPolicy\x12[\n" +
+	"\x04oidc\x18\b \x01(\v2\x15.auth.v1.OIDCSettingsB0\x9a\x84\x9e\x03+bson:\"oidc,omitempty\" json:\"oidc,omitempty\"R\x04oidc\x1aP\n" +
 	"\x12BusinessHoursEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12$\n" +
-	"\x05value\x18\x02 \x01(\v2\x0e.auth.v1.HoursR\x05value:\x028\x01\"k\n" +
+	"\x05value\x18\x02 \x01(\v2\x0e.auth.v1.HoursR\x05value:\x028\x01\"\x89\x04\n" +
+	"\fOIDCSettings\x12D\n" +
+	"\tproviders\x18\x01 \x03(\tB&\x9a\x84\x9e\x03!bson:\"providers\" json:\"providers\"R\tproviders\x12W\n" +
+	"\x0eauto_provision\x18\x02 \x01(\bB0\x9a\x84\x9e\x03+bson:\"auto_provision\" json:\"auto_provision\"R\rautoProvision\x12r\n" +
+	"\x10default_role_ids\x18\x03 \x03(\tBH\x9a\x84\x9e\x03Cbson:\"default_role_ids,omitempty\" json:\"default_role_ids,omitempty\"R\x0edefaultRoleIds\x12u\n" +
+	"\x16link_by_verified_email\x18\x04 \x01(\bB@\x9a\x84\x9e\x03;bson:\"link_by_verified_email\" json:\"link_by_verified_email\"R\x13linkByVerifiedEmail\x12o\n" +
+	"\x0fallowed_domains\x18\x05 \x03(\tBF\x9a\x84\x9e\x03Abson:\"allowed_domains,omitempty\" json:\"allowed_domains,omitempty\"R\x0eallowedDomains\"\xcb\x06\n" +
+	"\x0ePasswordPolicy\x12G\n" +
+	"\n" +
+	"min_length\x18\x01 \x01(\x05B(\x9a\x84\x9e\x03#bson:\"min_length\" json:\"min_length\"R\tminLength\x12c\n" +
+	"\x11require_uppercase\x18\x02 \x01(\bB6\x9a\x84\x9e\x031bson:\"require_uppercase\" json:\"require_uppercase\"R\x10requireUppercase\x12c\n" +
+	"\x11require_lowercase\x18\x03 \x01(\bB6\x9a\x84\x9e\x031bson:\"require_lowercase\" json:\"require_lowercase\"R\x10requireLowercase\x12S\n" +
+	"\rrequire_digit\x18\x04 \x01(\bB.\x9a\x84\x9e\x03)bson:\"require_digit\" json:\"require_digit\"R\frequireDigit\x12W\n" +
+	"\x0erequire_symbol\x18\x05 \x01(\bB0\x9a\x84\x9e\x03+bson:\"require_symbol\" json:\"require_symbol\"R\rrequireSymbol\x12s\n" +
+	"\x10banned_passwords\x18\x06 \x03(\tBH\x9a\x84\x9e\x03Cbson:\"banned_passwords,omitempty\" json:\"banned_passwords,omitempty\"R\x0fbannedPasswords\x12S\n" +
+	"\rhistory_depth\x18\a \x01(\x05B.\x9a\x84\x9e\x03)bson:\"history_depth\" json:\"history_depth\"R\fhistoryDepth\x12N\n" +
+	"\fmax_age_days\x18\b \x01(\x05B,\x9a\x84\x9e\x03'bson:\"max_age_days\" json:\"max_age_days\"R\n" +
+	"maxAgeDays\x12^\n" +
+	"\x10min_entropy_bits\x18\t \x01(\x01B4\x9a\x84\x9e\x03/bson:\"min_entropy_bits\" json:\"min_entropy_bits\"R\x0eminEntropyBits\"k\n" +
 	"\x05Hours\x124\n" +
 	"\x05start\x18\x01 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"start\" json:\"start\"R\x05start\x12,\n" +
 	"\x03end\x18\x02 \x01(\tB\x1a\x9a\x84\x9e\x03\x15bson:\"end\" json:\"end\"R\x03end\"\xc9\x01\n" +
@@ -1224,24 +2883,34 @@ const file_auth_v1_tenant_proto_rawDesc = "" +
 	"\x0eTenantMetadata\x12o\n" +
 	"\x14onboarding_completed\x18\x01 \x01(\bB<\x9a\x84\x9e\x037bson:\"onboarding_completed\" json:\"onboarding_completed\"R\x13onboardingCompleted\x12T\n" +
 	"\bindustry\x18\x02 \x01(\tB8\x9a\x84\x9e\x033bson:\"industry,omitempty\" json:\"industry,omitempty\"R\bindustry\x12c\n" +
-	"\fcompany_size\x18\x03 \x01(\tB@\x9a\x84\x9e\x03;bson:\"company_size,omitempty\" json:\"company_size,omitempty\"R\vcompanySize\"x\n" +
-	"\x13CreateTenantRequest\x128\n" +
+	"\fcompany_size\x18\x03 \x01(\tB@\x9a\x84\x9e\x03;bson:\"company_size,omitempty\" json:\"company_size,omitempty\"R\vcompanySize\"\x86\x04\n" +
+	"\vUsageRollup\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
+	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x120\n" +
+	"\x04date\x18\x03 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"date\" json:\"date\"R\x04date\x12<\n" +
+	"\aservice\x18\x04 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"service\" json:\"service\"R\aservice\x128\n" +
+	"\x06method\x18\x05 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"method\" json:\"method\"R\x06method\x124\n" +
+	"\x05calls\x18\x06 \x01(\x03B\x1e\x9a\x84\x9e\x03\x19bson:\"calls\" json:\"calls\"R\x05calls\x128\n" +
+	"\x06errors\x18\a \x01(\x03B \x9a\x84\x9e\x03\x1bbson:\"errors\" json:\"errors\"R\x06errors\x12c\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\"\x97\x01\n" +
+	"\x13CreateTenantRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12'\n" +
 	"\x06tenant\x18\x02 \x01(\v2\x0f.auth.v1.TenantR\x06tenant\"3\n" +
 	"\x14CreateTenantResponse\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"\x8b\x01\n" +
-	"\x10GetTenantRequest\x128\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"\xaa\x01\n" +
+	"\x10GetTenantRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12\x1d\n" +
 	"\ttenant_id\x18\x02 \x01(\tH\x00R\btenantId\x12\x14\n" +
 	"\x04name\x18\x03 \x01(\tH\x00R\x04nameB\b\n" +
-	"\x06tenant\"\xb3\x01\n" +
-	"\x12ListTenantsRequest\x128\n" +
+	"\x06tenant\"\xd2\x01\n" +
+	"\x12ListTenantsRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12\x1b\n" +
 	"\x06status\x18\x02 \x01(\tH\x00R\x06status\x88\x01\x01\x12;\n" +
 	"\n" +
@@ -1252,33 +2921,144 @@ const file_auth_v1_tenant_proto_rawDesc = "" +
 	"\atenants\x18\x01 \x03(\v2\x0f.auth.v1.TenantR\atenants\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
-	"pagination\"x\n" +
-	"\x13UpdateTenantRequest\x128\n" +
+	"pagination\"\xd4\x01\n" +
+	"\x13UpdateTenantRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12'\n" +
-	"\x06tenant\x18\x02 \x01(\v2\x0f.auth.v1.TenantR\x06tenant\"0\n" +
+	"\x06tenant\x18\x02 \x01(\v2\x0f.auth.v1.TenantR\x06tenant\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"0\n" +
 	"\x14UpdateTenantResponse\x12\x18\n" +
-	"\aupdated\x18\x01 \x01(\bR\aupdated\"l\n" +
-	"\x13DeleteTenantRequest\x128\n" +
+	"\aupdated\x18\x01 \x01(\bR\aupdated\"\x8b\x01\n" +
+	"\x13DeleteTenantRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\"0\n" +
 	"\x14DeleteTenantResponse\x12\x18\n" +
-	"\adeleted\x18\x01 \x01(\bR\adeleted*\x99\x01\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xca\x01\n" +
+	"\x0fGetUsageRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\x1b\n" +
+	"\tfrom_date\x18\x03 \x01(\tR\bfromDate\x12\x17\n" +
+	"\ato_date\x18\x04 \x01(\tR\x06toDate\"\xcb\x01\n" +
+	"\vUsageReport\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12.\n" +
+	"\arollups\x18\x02 \x03(\v2\x14.auth.v1.UsageRollupR\arollups\x12\x1f\n" +
+	"\vtotal_calls\x18\x03 \x01(\x03R\n" +
+	"totalCalls\x12-\n" +
+	"\x13month_to_date_calls\x18\x04 \x01(\x03R\x10monthToDateCalls\x12\x1f\n" +
+	"\vmonthly_cap\x18\x05 \x01(\x03R\n" +
+	"monthlyCap\"\xe1\x01\n" +
+	"\x12ExportUsageRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\x14\n" +
+	"\x05month\x18\x03 \x01(\tR\x05month\x122\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x1a.auth.v1.UsageExportFormatR\x06format\"i\n" +
+	"\x13ExportUsageResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x9a\x01\n" +
+	"\x15GetTenantUsageRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\";\n" +
+	"\rResourceUsage\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xb9\x01\n" +
+	"\vTenantUsage\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12,\n" +
+	"\x05users\x18\x02 \x01(\v2\x16.auth.v1.ResourceUsageR\x05users\x12,\n" +
+	"\x05roles\x18\x03 \x01(\v2\x16.auth.v1.ResourceUsageR\x05roles\x121\n" +
+	"\bapi_keys\x18\x04 \x01(\v2\x16.auth.v1.ResourceUsageR\aapiKeys\"\xb2\x01\n" +
+	"\x17ExportTenantDataRequest\x128\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x123\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x1b.auth.v1.TenantExportFormatR\x06format\"\xdc\x01\n" +
+	"\x15ExportTenantDataChunk\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x123\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1b.auth.v1.TenantExportFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12%\n" +
+	"\x0edocument_count\x18\x04 \x01(\x05R\rdocumentCount\x123\n" +
+	"\bprogress\x18\x05 \x01(\v2\x17.auth.v1.ExportProgressR\bprogress\"\xab\x01\n" +
+	"\x0eExportProgress\x12)\n" +
+	"\x10collections_done\x18\x01 \x01(\x05R\x0fcollectionsDone\x12+\n" +
+	"\x11collections_total\x18\x02 \x01(\x05R\x10collectionsTotal\x12-\n" +
+	"\x12documents_exported\x18\x03 \x01(\x03R\x11documentsExported\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\"\xcc\x01\n" +
+	"\x17ImportTenantDataRequest\x128\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x124\n" +
+	"\x05chunk\x18\x04 \x01(\v2\x1e.auth.v1.ExportTenantDataChunkR\x05chunk\"l\n" +
+	"\x16ImportCollectionResult\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x05R\bimported\x12\x16\n" +
+	"\x06reused\x18\x03 \x01(\x05R\x06reused\"\x91\x01\n" +
+	"\x0eImportConflict\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x1b\n" +
+	"\tsource_id\x18\x02 \x01(\tR\bsourceId\x12\x14\n" +
+	"\x05field\x18\x03 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xe4\x01\n" +
+	"\x18ImportTenantDataResponse\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\aapplied\x18\x03 \x01(\bR\aapplied\x12A\n" +
+	"\vcollections\x18\x04 \x03(\v2\x1f.auth.v1.ImportCollectionResultR\vcollections\x125\n" +
+	"\tconflicts\x18\x05 \x03(\v2\x17.auth.v1.ImportConflictR\tconflicts\"\xe5\x01\n" +
+	"\x19ChangeTenantStatusRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.auth.v1.TenantStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x8b\x01\n" +
+	"\x1aChangeTenantStatusResponse\x12>\n" +
+	"\x0fprevious_status\x18\x01 \x01(\x0e2\x15.auth.v1.TenantStatusR\x0epreviousStatus\x12-\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x15.auth.v1.TenantStatusR\x06status*\x99\x01\n" +
 	"\fTenantStatus\x12\x1d\n" +
 	"\x19TENANT_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14TENANT_STATUS_ACTIVE\x10\x01\x12\x1b\n" +
 	"\x17TENANT_STATUS_SUSPENDED\x10\x02\x12\x1a\n" +
 	"\x16TENANT_STATUS_INACTIVE\x10\x03\x12\x17\n" +
-	"\x13TENANT_STATUS_TRIAL\x10\x042\xf9\x02\n" +
+	"\x13TENANT_STATUS_TRIAL\x10\x04*s\n" +
+	"\x11UsageExportFormat\x12#\n" +
+	"\x1fUSAGE_EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17USAGE_EXPORT_FORMAT_CSV\x10\x01\x12\x1c\n" +
+	"\x18USAGE_EXPORT_FORMAT_JSON\x10\x02*z\n" +
+	"\x12TenantExportFormat\x12$\n" +
+	" TENANT_EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bTENANT_EXPORT_FORMAT_NDJSON\x10\x01\x12\x1d\n" +
+	"\x19TENANT_EXPORT_FORMAT_BSON\x10\x022\xd9\x06\n" +
 	"\rTenantService\x12K\n" +
 	"\fCreateTenant\x12\x1c.auth.v1.CreateTenantRequest\x1a\x1d.auth.v1.CreateTenantResponse\x127\n" +
 	"\tGetTenant\x12\x19.auth.v1.GetTenantRequest\x1a\x0f.auth.v1.Tenant\x12H\n" +
 	"\vListTenants\x12\x1b.auth.v1.ListTenantsRequest\x1a\x1c.auth.v1.ListTenantsResponse\x12K\n" +
 	"\fUpdateTenant\x12\x1c.auth.v1.UpdateTenantRequest\x1a\x1d.auth.v1.UpdateTenantResponse\x12K\n" +
-	"\fDeleteTenant\x12\x1c.auth.v1.DeleteTenantRequest\x1a\x1d.auth.v1.DeleteTenantResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+	"\fDeleteTenant\x12\x1c.auth.v1.DeleteTenantRequest\x1a\x1d.auth.v1.DeleteTenantResponse\x12:\n" +
+	"\bGetUsage\x12\x18.auth.v1.GetUsageRequest\x1a\x14.auth.v1.UsageReport\x12H\n" +
+	"\vExportUsage\x12\x1b.auth.v1.ExportUsageRequest\x1a\x1c.auth.v1.ExportUsageResponse\x12F\n" +
+	"\x0eGetTenantUsage\x12\x1e.auth.v1.GetTenantUsageRequest\x1a\x14.auth.v1.TenantUsage\x12V\n" +
+	"\x10ExportTenantData\x12 .auth.v1.ExportTenantDataRequest\x1a\x1e.auth.v1.ExportTenantDataChunk0\x01\x12Y\n" +
+	"\x10ImportTenantData\x12 .auth.v1.ImportTenantDataRequest\x1a!.auth.v1.ImportTenantDataResponse(\x01\x12]\n" +
+	"\x12ChangeTenantStatus\x12\".auth.v1.ChangeTenantStatusRequest\x1a#.auth.v1.ChangeTenantStatusResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
 
 var (
 	file_auth_v1_tenant_proto_rawDescOnce sync.Once
@@ -1292,74 +3072,137 @@ func file_auth_v1_tenant_proto_rawDescGZIP() []byte {
 	return file_auth_v1_tenant_proto_rawDescData
 }
 
-var file_auth_v1_tenant_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_auth_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_auth_v1_tenant_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_auth_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_auth_v1_tenant_proto_goTypes = []any{
-	(TenantStatus)(0),              // 0: auth.v1.TenantStatus
-	(*Tenant)(nil),                 // 1: auth.v1.Tenant
-	(*Subscription)(nil),           // 2: auth.v1.Subscription
-	(*SubscriptionLimits)(nil),     // 3: auth.v1.SubscriptionLimits
-	(*TenantSettings)(nil),         // 4: auth.v1.TenantSettings
-	(*Hours)(nil),                  // 5: auth.v1.Hours
-	(*ContactInfo)(nil),            // 6: auth.v1.ContactInfo
-	(*Branding)(nil),               // 7: auth.v1.Branding
-	(*TenantMetadata)(nil),         // 8: auth.v1.TenantMetadata
-	(*CreateTenantRequest)(nil),    // 9: auth.v1.CreateTenantRequest
-	(*CreateTenantResponse)(nil),   // 10: auth.v1.CreateTenantResponse
-	(*GetTenantRequest)(nil),       // 11: auth.v1.GetTenantRequest
-	(*ListTenantsRequest)(nil),     // 12: auth.v1.ListTenantsRequest
-	(*ListTenantsResponse)(nil),    // 13: auth.v1.ListTenantsResponse
-	(*UpdateTenantRequest)(nil),    // 14: auth.v1.UpdateTenantRequest
-	(*UpdateTenantResponse)(nil),   // 15: auth.v1.UpdateTenantResponse
-	(*DeleteTenantRequest)(nil),    // 16: auth.v1.DeleteTenantRequest
-	(*DeleteTenantResponse)(nil),   // 17: auth.v1.DeleteTenantResponse
-	nil,                            // 18: auth.v1.TenantSettings.BusinessHoursEntry
-	(*timestamppb.Timestamp)(nil),  // 19: google.protobuf.Timestamp
-	(*v1.Address)(nil),             // 20: core.v1.Address
-	(*v11.UserIdentifier)(nil),     // 21: infra.v1.UserIdentifier
-	(*v11.PaginationRequest)(nil),  // 22: infra.v1.PaginationRequest
-	(*v11.PaginationResponse)(nil), // 23: infra.v1.PaginationResponse
+	(TenantStatus)(0),                  // 0: auth.v1.TenantStatus
+	(UsageExportFormat)(0),             // 1: auth.v1.UsageExportFormat
+	(TenantExportFormat)(0),            // 2: auth.v1.TenantExportFormat
+	(*Tenant)(nil),                     // 3: auth.v1.Tenant
+	(*TenantLimits)(nil),               // 4: auth.v1.TenantLimits
+	(*Subscription)(nil),               // 5: auth.v1.Subscription
+	(*SubscriptionLimits)(nil),         // 6: auth.v1.SubscriptionLimits
+	(*TenantSettings)(nil),             // 7: auth.v1.TenantSettings
+	(*OIDCSettings)(nil),               // 8: auth.v1.OIDCSettings
+	(*PasswordPolicy)(nil),             // 9: auth.v1.PasswordPolicy
+	(*Hours)(nil),                      // 10: auth.v1.Hours
+	(*ContactInfo)(nil),                // 11: auth.v1.ContactInfo
+	(*Branding)(nil),                   // 12: auth.v1.Branding
+	(*TenantMetadata)(nil),             // 13: auth.v1.TenantMetadata
+	(*UsageRollup)(nil),                // 14: auth.v1.UsageRollup
+	(*CreateTenantRequest)(nil),        // 15: auth.v1.CreateTenantRequest
+	(*CreateTenantResponse)(nil),       // 16: auth.v1.CreateTenantResponse
+	(*GetTenantRequest)(nil),           // 17: auth.v1.GetTenantRequest
+	(*ListTenantsRequest)(nil),         // 18: auth.v1.ListTenantsRequest
+	(*ListTenantsResponse)(nil),        // 19: auth.v1.ListTenantsResponse
+	(*UpdateTenantRequest)(nil),        // 20: auth.v1.UpdateTenantRequest
+	(*UpdateTenantResponse)(nil),       // 21: auth.v1.UpdateTenantResponse
+	(*DeleteTenantRequest)(nil),        // 22: auth.v1.DeleteTenantRequest
+	(*DeleteTenantResponse)(nil),       // 23: auth.v1.DeleteTenantResponse
+	(*GetUsageRequest)(nil),            // 24: auth.v1.GetUsageRequest
+	(*UsageReport)(nil),                // 25: auth.v1.UsageReport
+	(*ExportUsageRequest)(nil),         // 26: auth.v1.ExportUsageRequest
+	(*ExportUsageResponse)(nil),        // 27: auth.v1.ExportUsageResponse
+	(*GetTenantUsageRequest)(nil),      // 28: auth.v1.GetTenantUsageRequest
+	(*ResourceUsage)(nil),              // 29: auth.v1.ResourceUsage
+	(*TenantUsage)(nil),                // 30: auth.v1.TenantUsage
+	(*ExportTenantDataRequest)(nil),    // 31: auth.v1.ExportTenantDataRequest
+	(*ExportTenantDataChunk)(nil),      // 32: auth.v1.ExportTenantDataChunk
+	(*ExportProgress)(nil),             // 33: auth.v1.ExportProgress
+	(*ImportTenantDataRequest)(nil),    // 34: auth.v1.ImportTenantDataRequest
+	(*ImportCollectionResult)(nil),     // 35: auth.v1.ImportCollectionResult
+	(*ImportConflict)(nil),             // 36: auth.v1.ImportConflict
+	(*ImportTenantDataResponse)(nil),   // 37: auth.v1.ImportTenantDataResponse
+	(*ChangeTenantStatusRequest)(nil),  // 38: auth.v1.ChangeTenantStatusRequest
+	(*ChangeTenantStatusResponse)(nil), // 39: auth.v1.ChangeTenantStatusResponse
+	nil,                                // 40: auth.v1.TenantSettings.BusinessHoursEntry
+	(*timestamppb.Timestamp)(nil),      // 41: google.protobuf.Timestamp
+	(*v1.Address)(nil),                 // 42: core.v1.Address
+	(*v11.UserIdentifier)(nil),         // 43: infra.v1.UserIdentifier
+	(*v11.PaginationRequest)(nil),      // 44: infra.v1.PaginationRequest
+	(*v11.PaginationResponse)(nil),     // 45: infra.v1.PaginationResponse
+	(*fieldmaskpb.FieldMask)(nil),      // 46: google.protobuf.FieldMask
 }
 var file_auth_v1_tenant_proto_depIdxs = []int32{
 	0,  // 0: auth.v1.Tenant.status:type_name -> auth.v1.TenantStatus
-	2,  // 1: auth.v1.Tenant.subscription:type_name -> auth.v1.Subscription
-	4,  // 2: auth.v1.Tenant.settings:type_name -> auth.v1.TenantSettings
-	6,  // 3: auth.v1.Tenant.contact:type_name -> auth.v1.ContactInfo
-	7,  // 4: auth.v1.Tenant.branding:type_name -> auth.v1.Branding
-	19, // 5: auth.v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
-	19, // 6: auth.v1.Tenant.updated_at:type_name -> google.protobuf.Timestamp
-	8,  // 7: auth.v1.Tenant.metadata:type_name -> auth.v1.TenantMetadata
-	19, // 8: auth.v1.Subscription.start_date:type_name -> google.protobuf.Timestamp
-	19, // 9: auth.v1.Subscription.end_date:type_name -> google.protobuf.Timestamp
-	3,  // 10: auth.v1.Subscription.limits:type_name -> auth.v1.SubscriptionLimits
-	18, // 11: auth.v1.TenantSettings.business_hours:type_name -> auth.v1.TenantSettings.BusinessHoursEntry
-	20, // 12: auth.v1.ContactInfo.address:type_name -> core.v1.Address
-	21, // 13: auth.v1.CreateTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
-	1,  // 14: auth.v1.CreateTenantRequest.tenant:type_name -> auth.v1.Tenant
-	21, // 15: auth.v1.GetTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
-	21, // 16: auth.v1.ListTenantsRequest.identifier:type_name -> infra.v1.UserIdentifier
-	22, // 17: auth.v1.ListTenantsRequest.pagination:type_name -> infra.v1.PaginationRequest
-	1,  // 18: auth.v1.ListTenantsResponse.tenants:type_name -> auth.v1.Tenant
-	23, // 19: auth.v1.ListTenantsResponse.pagination:type_name -> infra.v1.PaginationResponse
-	21, // 20: auth.v1.UpdateTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
-	1,  // 21: auth.v1.UpdateTenantRequest.tenant:type_name -> auth.v1.Tenant
-	21, // 22: auth.v1.DeleteTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
-	5,  // 23: auth.v1.TenantSettings.BusinessHoursEntry.value:type_name -> auth.v1.Hours
-	9,  // 24: auth.v1.TenantService.CreateTenant:input_type -> auth.v1.CreateTenantRequest
-	11, // 25: auth.v1.TenantService.GetTenant:input_type -> auth.v1.GetTenantRequest
-	12, // 26: auth.v1.TenantService.ListTenants:input_type -> auth.v1.ListTenantsRequest
-	14, // 27: auth.v1.TenantService.UpdateTenant:input_type -> auth.v1.UpdateTenantRequest
-	16, // 28: auth.v1.TenantService.DeleteTenant:input_type -> auth.v1.DeleteTenantRequest
-	10, // 29: auth.v1.TenantService.CreateTenant:output_type -> auth.v1.CreateTenantResponse
-	1,  // 30: auth.v1.TenantService.GetTenant:output_type -> auth.v1.Tenant
-	13, // 31: auth.v1.TenantService.ListTenants:output_type -> auth.v1.ListTenantsResponse
-	15, // 32: auth.v1.TenantService.UpdateTenant:output_type -> auth.v1.UpdateTenantResponse
-	17, // 33: auth.v1.TenantService.DeleteTenant:output_type -> auth.v1.DeleteTenantResponse
-	29, // [29:34] is the sub-list for method output_type
-	24, // [24:29] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	5,  // 1: auth.v1.Tenant.subscription:type_name -> auth.v1.Subscription
+	7,  // 2: auth.v1.Tenant.settings:type_name -> auth.v1.TenantSettings
+	11, // 3: auth.v1.Tenant.contact:type_name -> auth.v1.ContactInfo
+	12, // 4: auth.v1.Tenant.branding:type_name -> auth.v1.Branding
+	41, // 5: auth.v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
+	41, // 6: auth.v1.Tenant.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 7: auth.v1.Tenant.metadata:type_name -> auth.v1.TenantMetadata
+	41, // 8: auth.v1.Tenant.trial_start:type_name -> google.protobuf.Timestamp
+	41, // 9: auth.v1.Tenant.trial_end:type_name -> google.protobuf.Timestamp
+	41, // 10: auth.v1.Tenant.status_changed_at:type_name -> google.protobuf.Timestamp
+	4,  // 11: auth.v1.Tenant.limits:type_name -> auth.v1.TenantLimits
+	41, // 12: auth.v1.Subscription.start_date:type_name -> google.protobuf.Timestamp
+	41, // 13: auth.v1.Subscription.end_date:type_name -> google.protobuf.Timestamp
+	6,  // 14: auth.v1.Subscription.limits:type_name -> auth.v1.SubscriptionLimits
+	40, // 15: auth.v1.TenantSettings.business_hours:type_name -> auth.v1.TenantSettings.BusinessHoursEntry
+	9,  // 16: auth.v1.TenantSettings.password_policy:type_name -> auth.v1.PasswordPolicy
+	8,  // 17: auth.v1.TenantSettings.oidc:type_name -> auth.v1.OIDCSettings
+	42, // 18: auth.v1.ContactInfo.address:type_name -> core.v1.Address
+	41, // 19: auth.v1.UsageRollup.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 20: auth.v1.CreateTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
+	3,  // 21: auth.v1.CreateTenantRequest.tenant:type_name -> auth.v1.Tenant
+	43, // 22: auth.v1.GetTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
+	43, // 23: auth.v1.ListTenantsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	44, // 24: auth.v1.ListTenantsRequest.pagination:type_name -> infra.v1.PaginationRequest
+	3,  // 25: auth.v1.ListTenantsResponse.tenants:type_name -> auth.v1.Tenant
+	45, // 26: auth.v1.ListTenantsResponse.pagination:type_name -> infra.v1.PaginationResponse
+	43, // 27: auth.v1.UpdateTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
+	3,  // 28: auth.v1.UpdateTenantRequest.tenant:type_name -> auth.v1.Tenant
+	46, // 29: auth.v1.UpdateTenantRequest.update_mask:type_name -> google.protobuf.FieldMask
+	43, // 30: auth.v1.DeleteTenantRequest.identifier:type_name -> infra.v1.UserIdentifier
+	43, // 31: auth.v1.GetUsageRequest.identifier:type_name -> infra.v1.UserIdentifier
+	14, // 32: auth.v1.UsageReport.rollups:type_name -> auth.v1.UsageRollup
+	43, // 33: auth.v1.ExportUsageRequest.identifier:type_name -> infra.v1.UserIdentifier
+	1,  // 34: auth.v1.ExportUsageRequest.format:type_name -> auth.v1.UsageExportFormat
+	43, // 35: auth.v1.GetTenantUsageRequest.identifier:type_name -> infra.v1.UserIdentifier
+	29, // 36: auth.v1.TenantUsage.users:type_name -> auth.v1.ResourceUsage
+	29, // 37: auth.v1.TenantUsage.roles:type_name -> auth.v1.ResourceUsage
+	29, // 38: auth.v1.TenantUsage.api_keys:type_name -> auth.v1.ResourceUsage
+	43, // 39: auth.v1.ExportTenantDataRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 40: auth.v1.ExportTenantDataRequest.format:type_name -> auth.v1.TenantExportFormat
+	2,  // 41: auth.v1.ExportTenantDataChunk.format:type_name -> auth.v1.TenantExportFormat
+	33, // 42: auth.v1.ExportTenantDataChunk.progress:type_name -> auth.v1.ExportProgress
+	43, // 43: auth.v1.ImportTenantDataRequest.identifier:type_name -> infra.v1.UserIdentifier
+	32, // 44: auth.v1.ImportTenantDataRequest.chunk:type_name -> auth.v1.ExportTenantDataChunk
+	35, // 45: auth.v1.ImportTenantDataResponse.collections:type_name -> auth.v1.ImportCollectionResult
+	36, // 46: auth.v1.ImportTenantDataResponse.conflicts:type_name -> auth.v1.ImportConflict
+	43, // 47: auth.v1.ChangeTenantStatusRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 48: auth.v1.ChangeTenantStatusRequest.status:type_name -> auth.v1.TenantStatus
+	0,  // 49: auth.v1.ChangeTenantStatusResponse.previous_status:type_name -> auth.v1.TenantStatus
+	0,  // 50: auth.v1.ChangeTenantStatusResponse.status:type_name -> auth.v1.TenantStatus
+	10, // 51: auth.v1.TenantSettings.BusinessHoursEntry.value:type_name -> auth.v1.Hours
+	15, // 52: auth.v1.TenantService.CreateTenant:input_type -> auth.v1.CreateTenantRequest
+	17, // 53: auth.v1.TenantService.GetTenant:input_type -> auth.v1.GetTenantRequest
+	18, // 54: auth.v1.TenantService.ListTenants:input_type -> auth.v1.ListTenantsRequest
+	20, // 55: auth.v1.TenantService.UpdateTenant:input_type -> auth.v1.UpdateTenantRequest
+	22, // 56: auth.v1.TenantService.DeleteTenant:input_type -> auth.v1.DeleteTenantRequest
+	24, // 57: auth.v1.TenantService.GetUsage:input_type -> auth.v1.GetUsageRequest
+	26, // 58: auth.v1.TenantService.ExportUsage:input_type -> auth.v1.ExportUsageRequest
+	28, // 59: auth.v1.TenantService.GetTenantUsage:input_type -> auth.v1.GetTenantUsageRequest
+	31, // 60: auth.v1.TenantService.ExportTenantData:input_type -> auth.v1.ExportTenantDataRequest
+	34, // 61: auth.v1.TenantService.ImportTenantData:input_type -> auth.v1.ImportTenantDataRequest
+	38, // 62: auth.v1.TenantService.ChangeTenantStatus:input_type -> auth.v1.ChangeTenantStatusRequest
+	16, // 63: auth.v1.TenantService.CreateTenant:output_type -> auth.v1.CreateTenantResponse
+	3,  // 64: auth.v1.TenantService.GetTenant:output_type -> auth.v1.Tenant
+	19, // 65: auth.v1.TenantService.ListTenants:output_type -> auth.v1.ListTenantsResponse
+	21, // 66: auth.v1.TenantService.UpdateTenant:output_type -> auth.v1.UpdateTenantResponse
+	23, // 67: auth.v1.TenantService.DeleteTenant:output_type -> auth.v1.DeleteTenantResponse
+	25, // 68: auth.v1.TenantService.GetUsage:output_type -> auth.v1.UsageReport
+	27, // 69: auth.v1.TenantService.ExportUsage:output_type -> auth.v1.ExportUsageResponse
+	30, // 70: auth.v1.TenantService.GetTenantUsage:output_type -> auth.v1.TenantUsage
+	32, // 71: auth.v1.TenantService.ExportTenantData:output_type -> auth.v1.ExportTenantDataChunk
+	37, // 72: auth.v1.TenantService.ImportTenantData:output_type -> auth.v1.ImportTenantDataResponse
+	39, // 73: auth.v1.TenantService.ChangeTenantStatus:output_type -> auth.v1.ChangeTenantStatusResponse
+	63, // [63:74] is the sub-list for method output_type
+	52, // [52:63] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_tenant_proto_init() }
@@ -1367,18 +3210,18 @@ func file_auth_v1_tenant_proto_init() {
 	if File_auth_v1_tenant_proto != nil {
 		return
 	}
-	file_auth_v1_tenant_proto_msgTypes[10].OneofWrappers = []any{
+	file_auth_v1_tenant_proto_msgTypes[14].OneofWrappers = []any{
 		(*GetTenantRequest_TenantId)(nil),
 		(*GetTenantRequest_Name)(nil),
 	}
-	file_auth_v1_tenant_proto_msgTypes[11].OneofWrappers = []any{}
+	file_auth_v1_tenant_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_tenant_proto_rawDesc), len(file_auth_v1_tenant_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   18,
+			NumEnums:      3,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TenantService_CreateTenant_FullMethodName = "/auth.v1.TenantService/CreateTenant"
-	TenantService_GetTenant_FullMethodName    = "/auth.v1.TenantService/GetTenant"
-	TenantService_ListTenants_FullMethodName  = "/auth.v1.TenantService/ListTenants"
-	TenantService_UpdateTenant_FullMethodName = "/auth.v1.TenantService/UpdateTenant"
-	TenantService_DeleteTenant_FullMethodName = "/auth.v1.TenantService/DeleteTenant"
+	TenantService_CreateTenant_FullMethodName       = "/auth.v1.TenantService/CreateTenant"
+	TenantService_GetTenant_FullMethodName          = "/auth.v1.TenantService/GetTenant"
+	TenantService_ListTenants_FullMethodName        = "/auth.v1.TenantService/ListTenants"
+	TenantService_UpdateTenant_FullMethodName       = "/auth.v1.TenantService/UpdateTenant"
+	TenantService_DeleteTenant_FullMethodName       = "/auth.v1.TenantService/DeleteTenant"
+	TenantService_GetUsage_FullMethodName           = "/auth.v1.TenantService/GetUsage"
+	TenantService_ExportUsage_FullMethodName        = "/auth.v1.TenantService/ExportUsage"
+	TenantService_GetTenantUsage_FullMethodName     = "/auth.v1.TenantService/GetTenantUsage"
+	TenantService_ExportTenantData_FullMethodName   = "/auth.v1.TenantService/ExportTenantData"
+	TenantService_ImportTenantData_FullMethodName   = "/auth.v1.TenantService/ImportTenantData"
+	TenantService_ChangeTenantStatus_FullMethodName = "/auth.v1.TenantService/ChangeTenantStatus"
 )
 
 // TenantServiceClient is the client API for TenantService service.
@@ -36,6 +42,17 @@ type TenantServiceClient interface {
 	ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error)
 	UpdateTenant(ctx context.Context, in *UpdateTenantRequest, opts ...grpc.CallOption) (*UpdateTenantResponse, error)
 	DeleteTenant(ctx context.Context, in *DeleteTenantRequest, opts ...grpc.CallOption) (*DeleteTenantResponse, error)
+	// API usage
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*UsageReport, error)
+	ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (*ExportUsageResponse, error)
+	// Quotas
+	GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*TenantUsage, error)
+	// Data export, users are exported without their password hashes and secrets
+	ExportTenantData(ctx context.Context, in *ExportTenantDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTenantDataChunk], error)
+	// Data import of the chunks of an export, the documents get new ids and are written in one transaction
+	ImportTenantData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTenantDataRequest, ImportTenantDataResponse], error)
+	// Lifecycle
+	ChangeTenantStatus(ctx context.Context, in *ChangeTenantStatusRequest, opts ...grpc.CallOption) (*ChangeTenantStatusResponse, error)
 }
 
 type tenantServiceClient struct {
@@ -96,6 +113,78 @@ func (c *tenantServiceClient) DeleteTenant(ctx context.Context, in *DeleteTenant
 	return out, nil
 }
 
+func (c *tenantServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*UsageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, TenantService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (*ExportUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUsageResponse)
+	err := c.cc.Invoke(ctx, TenantService_ExportUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) GetTenantUsage(ctx context.Context, in *GetTenantUsageRequest, opts ...grpc.CallOption) (*TenantUsage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TenantUsage)
+	err := c.cc.Invoke(ctx, TenantService_GetTenantUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) ExportTenantData(ctx context.Context, in *ExportTenantDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTenantDataChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TenantService_ServiceDesc.Streams[0], TenantService_ExportTenantData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportTenantDataRequest, ExportTenantDataChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TenantService_ExportTenantDataClient = grpc.ServerStreamingClient[ExportTenantDataChunk]
+
+func (c *tenantServiceClient) ImportTenantData(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTenantDataRequest, ImportTenantDataResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TenantService_ServiceDesc.Streams[1], TenantService_ImportTenantData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportTenantDataRequest, ImportTenantDataResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TenantService_ImportTenantDataClient = grpc.ClientStreamingClient[ImportTenantDataRequest, ImportTenantDataResponse]
+
+func (c *tenantServiceClient) ChangeTenantStatus(ctx context.Context, in *ChangeTenantStatusRequest, opts ...grpc.CallOption) (*ChangeTenantStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeTenantStatusResponse)
+	err := c.cc.Invoke(ctx, TenantService_ChangeTenantStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TenantServiceServer is the server API for TenantService service.
 // All implementations must embed UnimplementedTenantServiceServer
 // for forward compatibility.
@@ -106,6 +195,17 @@ type TenantServiceServer interface {
 	ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error)
 	UpdateTenant(context.Context, *UpdateTenantRequest) (*UpdateTenantResponse, error)
 	DeleteTenant(context.Context, *DeleteTenantRequest) (*DeleteTenantResponse, error)
+	// API usage
+	GetUsage(context.Context, *GetUsageRequest) (*UsageReport, error)
+	ExportUsage(context.Context, *ExportUsageRequest) (*ExportUsageResponse, error)
+	// Quotas
+	GetTenantUsage(context.Context, *GetTenantUsageRequest) (*TenantUsage, error)
+	// Data export, users are exported without their password hashes and secrets
+	ExportTenantData(*ExportTenantDataRequest, grpc.ServerStreamingServer[ExportTenantDataChunk]) error
+	// Data import of the chunks of an export, the documents get new ids and are written in one transaction
+	ImportTenantData(grpc.ClientStreamingServer[ImportTenantDataRequest, ImportTenantDataResponse]) error
+	// Lifecycle
+	ChangeTenantStatus(context.Context, *ChangeTenantStatusRequest) (*ChangeTenantStatusResponse, error)
 	mustEmbedUnimplementedTenantServiceServer()
 }
 
@@ -131,6 +231,24 @@ func (UnimplementedTenantServiceServer) UpdateTenant(context.Context, *UpdateTen
 func (UnimplementedTenantServiceServer) DeleteTenant(context.Context, *DeleteTenantRequest) (*DeleteTenantResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteTenant not implemented")
 }
+func (UnimplementedTenantServiceServer) GetUsage(context.Context, *GetUsageRequest) (*UsageReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedTenantServiceServer) ExportUsage(context.Context, *ExportUsageRequest) (*ExportUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUsage not implemented")
+}
+func (UnimplementedTenantServiceServer) GetTenantUsage(context.Context, *GetTenantUsageRequest) (*TenantUsage, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTenantUsage not implemented")
+}
+func (UnimplementedTenantServiceServer) ExportTenantData(*ExportTenantDataRequest, grpc.ServerStreamingServer[ExportTenantDataChunk]) error {
+	return status.Error(codes.Unimplemented, "method ExportTenantData not implemented")
+}
+func (UnimplementedTenantServiceServer) ImportTenantData(grpc.ClientStreamingServer[ImportTenantDataRequest, ImportTenantDataResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportTenantData not implemented")
+}
+func (UnimplementedTenantServiceServer) ChangeTenantStatus(context.Context, *ChangeTenantStatusRequest) (*ChangeTenantStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ChangeTenantStatus not implemented")
+}
 func (UnimplementedTenantServiceServer) mustEmbedUnimplementedTenantServiceServer() {}
 func (UnimplementedTenantServiceServer) testEmbeddedByValue()                       {}
 
@@ -242,6 +360,96 @@ func _TenantService_DeleteTenant_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TenantService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_ExportUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).ExportUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_ExportUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).ExportUsage(ctx, req.(*ExportUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_GetTenantUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTenantUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).GetTenantUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_GetTenantUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).GetTenantUsage(ctx, req.(*GetTenantUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_ExportTenantData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTenantDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TenantServiceServer).ExportTenantData(m, &grpc.GenericServerStream[ExportTenantDataRequest, ExportTenantDataChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TenantService_ExportTenantDataServer = grpc.ServerStreamingServer[ExportTenantDataChunk]
+
+func _TenantService_ImportTenantData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TenantServiceServer).ImportTenantData(&grpc.GenericServerStream[ImportTenantDataRequest, ImportTenantDataResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TenantService_ImportTenantDataServer = grpc.ClientStreamingServer[ImportTenantDataRequest, ImportTenantDataResponse]
+
+func _TenantService_ChangeTenantStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeTenantStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).ChangeTenantStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_ChangeTenantStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).ChangeTenantStatus(ctx, req.(*ChangeTenantStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TenantService_ServiceDesc is the grpc.ServiceDesc for TenantService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -269,7 +477,34 @@ var TenantService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTenant",
 			Handler:    _TenantService_DeleteTenant_Handler,
 		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _TenantService_GetUsage_Handler,
+		},
+		{
+			MethodName: "ExportUsage",
+			Handler:    _TenantService_ExportUsage_Handler,
+		},
+		{
+			MethodName: "GetTenantUsage",
+			Handler:    _TenantService_GetTenantUsage_Handler,
+		},
+		{
+			MethodName: "ChangeTenantStatus",
+			Handler:    _TenantService_ChangeTenantStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportTenantData",
+			Handler:       _TenantService_ExportTenantData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportTenantData",
+			Handler:       _TenantService_ImportTenantData_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/v1/tenant.proto",
 }
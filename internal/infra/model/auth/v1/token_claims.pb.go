@@ -25,17 +25,22 @@ const (
 
 // AccessTokenClaims represents the claims in an access token
 type AccessTokenClaims struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id" bson:"user_id"`
-	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username" bson:"username"`
-	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email" bson:"email"`
-	Roles         []string               `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles" bson:"roles"`
-	Permissions   []string               `protobuf:"bytes,6,rep,name=permissions,proto3" json:"permissions" bson:"permissions"`
-	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at" bson:"issued_at"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at" bson:"expires_at"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id" bson:"user_id"`
+	TenantId    string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	Username    string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username" bson:"username"`
+	Email       string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email" bson:"email"`
+	Roles       []string               `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles" bson:"roles"`
+	Permissions []string               `protobuf:"bytes,6,rep,name=permissions,proto3" json:"permissions" bson:"permissions"`
+	IssuedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at" bson:"issued_at"`
+	ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at" bson:"expires_at"`
+	// User acting as user_id, set on the impersonation tokens
+	Act *TokenActor `protobuf:"bytes,9,opt,name=act,proto3" json:"act,omitempty" bson:"act,omitempty"`
+	// Hash of the effective permissions of the user when the token was issued, set instead of the roles on the minimal
+	// tokens
+	PermissionsVersion string `protobuf:"bytes,10,opt,name=permissions_version,json=permissionsVersion,proto3" json:"permissions_version,omitempty" bson:"permissions_version,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *AccessTokenClaims) Reset() {
@@ -124,11 +129,78 @@ func (x *AccessTokenClaims) GetExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AccessTokenClaims) GetAct() *TokenActor {
+	if x != nil {
+		return x.Act
+	}
+	return nil
+}
+
+func (x *AccessTokenClaims) GetPermissionsVersion() string {
+	if x != nil {
+		return x.PermissionsVersion
+	}
+	return ""
+}
+
+// TokenActor is the user acting on behalf of the subject of a token
+type TokenActor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id" bson:"user_id"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenActor) Reset() {
+	*x = TokenActor{}
+	mi := &file_auth_v1_token_claims_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenActor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenActor) ProtoMessage() {}
+
+func (x *TokenActor) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_token_claims_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenActor.ProtoReflect.Descriptor instead.
+func (*TokenActor) Descriptor() ([]byte, []int) {
+	return file_auth_v1_token_claims_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TokenActor) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TokenActor) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
 var File_auth_v1_token_claims_proto protoreflect.FileDescriptor
 
 const file_auth_v1_token_claims_proto_rawDesc = "" +
 	"\n" +
-	"\x1aauth/v1/token_claims.proto\x12\aauth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xd7\x04\n" +
+	"\x1aauth/v1/token_claims.proto\x12\aauth.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xaf\x06\n" +
 	"\x11AccessTokenClaims\x12;\n" +
 	"\auser_id\x18\x01 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"user_id\" json:\"user_id\"R\x06userId\x12C\n" +
 	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12@\n" +
@@ -138,7 +210,14 @@ const file_auth_v1_token_claims_proto_rawDesc = "" +
 	"\vpermissions\x18\x06 \x03(\tB*\x9a\x84\x9e\x03%bson:\"permissions\" json:\"permissions\"R\vpermissions\x12_\n" +
 	"\tissued_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB&\x9a\x84\x9e\x03!bson:\"issued_at\" json:\"issued_at\"R\bissuedAt\x12c\n" +
 	"\n" +
-	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"expires_at\" json:\"expires_at\"R\texpiresAtB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"expires_at\" json:\"expires_at\"R\texpiresAt\x12U\n" +
+	"\x03act\x18\t \x01(\v2\x13.auth.v1.TokenActorB.\x9a\x84\x9e\x03)bson:\"act,omitempty\" json:\"act,omitempty\"R\x03act\x12\x7f\n" +
+	"\x13permissions_version\x18\n" +
+	" \x01(\tBN\x9a\x84\x9e\x03Ibson:\"permissions_version,omitempty\" json:\"permissions_version,omitempty\"R\x12permissionsVersion\"\x8e\x01\n" +
+	"\n" +
+	"TokenActor\x12;\n" +
+	"\auser_id\x18\x01 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"user_id\" json:\"user_id\"R\x06userId\x12C\n" +
+	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantIdB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
 
 var (
 	file_auth_v1_token_claims_proto_rawDescOnce sync.Once
@@ -152,19 +231,21 @@ func file_auth_v1_token_claims_proto_rawDescGZIP() []byte {
 	return file_auth_v1_token_claims_proto_rawDescData
 }
 
-var file_auth_v1_token_claims_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_token_claims_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_auth_v1_token_claims_proto_goTypes = []any{
 	(*AccessTokenClaims)(nil),     // 0: auth.v1.AccessTokenClaims
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*TokenActor)(nil),            // 1: auth.v1.TokenActor
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
 }
 var file_auth_v1_token_claims_proto_depIdxs = []int32{
-	1, // 0: auth.v1.AccessTokenClaims.issued_at:type_name -> google.protobuf.Timestamp
-	1, // 1: auth.v1.AccessTokenClaims.expires_at:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: auth.v1.AccessTokenClaims.issued_at:type_name -> google.protobuf.Timestamp
+	2, // 1: auth.v1.AccessTokenClaims.expires_at:type_name -> google.protobuf.Timestamp
+	1, // 2: auth.v1.AccessTokenClaims.act:type_name -> auth.v1.TokenActor
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_token_claims_proto_init() }
@@ -178,7 +259,7 @@ func file_auth_v1_token_claims_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_token_claims_proto_rawDesc), len(file_auth_v1_token_claims_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
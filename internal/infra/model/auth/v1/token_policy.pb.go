@@ -0,0 +1,154 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: auth/v1/token_policy.proto
+
+package authv1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TokenPolicy is the token configuration of a tenant, stored through the config service.
+// Unset values fall back to the global defaults of the auth service
+type TokenPolicy struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	AccessTokenTtlSeconds  int64                  `protobuf:"varint,1,opt,name=access_token_ttl_seconds,json=accessTokenTtlSeconds,proto3" json:"access_token_ttl_seconds,omitempty"`
+	RefreshTokenTtlSeconds int64                  `protobuf:"varint,2,opt,name=refresh_token_ttl_seconds,json=refreshTokenTtlSeconds,proto3" json:"refresh_token_ttl_seconds,omitempty"`
+	// iss claim of the access tokens issued for the tenant
+	Issuer string `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// aud claim of the access tokens issued for the tenant
+	Audience      []string `protobuf:"bytes,5,rep,name=audience,proto3" json:"audience,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenPolicy) Reset() {
+	*x = TokenPolicy{}
+	mi := &file_auth_v1_token_policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenPolicy) ProtoMessage() {}
+
+func (x *TokenPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_token_policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenPolicy.ProtoReflect.Descriptor instead.
+func (*TokenPolicy) Descriptor() ([]byte, []int) {
+	return file_auth_v1_token_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TokenPolicy) GetAccessTokenTtlSeconds() int64 {
+	if x != nil {
+		return x.AccessTokenTtlSeconds
+	}
+	return 0
+}
+
+func (x *TokenPolicy) GetRefreshTokenTtlSeconds() int64 {
+	if x != nil {
+		return x.RefreshTokenTtlSeconds
+	}
+	return 0
+}
+
+func (x *TokenPolicy) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *TokenPolicy) GetAudience() []string {
+	if x != nil {
+		return x.Audience
+	}
+	return nil
+}
+
+var File_auth_v1_token_policy_proto protoreflect.FileDescriptor
+
+const file_auth_v1_token_policy_proto_rawDesc = "" +
+	"\n" +
+	"\x1aauth/v1/token_policy.proto\x12\aauth.v1\x1a\x13tagger/tagger.proto\"\xf3\x02\n" +
+	"\vTokenPolicy\x12g\n" +
+	"\x18access_token_ttl_seconds\x18\x01 \x01(\x03B.\x9a\x84\x9e\x03)json:\"access_token_ttl_seconds,omitempty\"R\x15accessTokenTtlSeconds\x12j\n" +
+	"\x19refresh_token_ttl_seconds\x18\x02 \x01(\x03B/\x9a\x84\x9e\x03*json:\"refresh_token_ttl_seconds,omitempty\"R\x16refreshTokenTtlSeconds\x124\n" +
+	"\x06issuer\x18\x04 \x01(\tB\x1c\x9a\x84\x9e\x03\x17json:\"issuer,omitempty\"R\x06issuer\x12:\n" +
+	"\baudience\x18\x05 \x03(\tB\x1e\x9a\x84\x9e\x03\x19json:\"audience,omitempty\"R\baudienceJ\x04\b\x03\x10\x04R\x17max_concurrent_sessionsB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+
+var (
+	file_auth_v1_token_policy_proto_rawDescOnce sync.Once
+	file_auth_v1_token_policy_proto_rawDescData []byte
+)
+
+func file_auth_v1_token_policy_proto_rawDescGZIP() []byte {
+	file_auth_v1_token_policy_proto_rawDescOnce.Do(func() {
+		file_auth_v1_token_policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_v1_token_policy_proto_rawDesc), len(file_auth_v1_token_policy_proto_rawDesc)))
+	})
+	return file_auth_v1_token_policy_proto_rawDescData
+}
+
+var file_auth_v1_token_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_v1_token_policy_proto_goTypes = []any{
+	(*TokenPolicy)(nil), // 0: auth.v1.TokenPolicy
+}
+var file_auth_v1_token_policy_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_auth_v1_token_policy_proto_init() }
+func file_auth_v1_token_policy_proto_init() {
+	if File_auth_v1_token_policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_token_policy_proto_rawDesc), len(file_auth_v1_token_policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_v1_token_policy_proto_goTypes,
+		DependencyIndexes: file_auth_v1_token_policy_proto_depIdxs,
+		MessageInfos:      file_auth_v1_token_policy_proto_msgTypes,
+	}.Build()
+	File_auth_v1_token_policy_proto = out.File
+	file_auth_v1_token_policy_proto_goTypes = nil
+	file_auth_v1_token_policy_proto_depIdxs = nil
+}
@@ -11,6 +11,7 @@ import (
 	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -81,19 +82,78 @@ func (UserStatus) EnumDescriptor() ([]byte, []int) {
 	return file_auth_v1_user_proto_rawDescGZIP(), []int{0}
 }
 
+// Sort orders of user searches, all break ties by id for stable pages
+type UserSortField int32
+
+const (
+	UserSortField_USER_SORT_FIELD_UNSPECIFIED UserSortField = 0 // created_at
+	UserSortField_USER_SORT_FIELD_CREATED_AT  UserSortField = 1
+	UserSortField_USER_SORT_FIELD_EMAIL       UserSortField = 2
+	UserSortField_USER_SORT_FIELD_USERNAME    UserSortField = 3
+	UserSortField_USER_SORT_FIELD_LAST_NAME   UserSortField = 4
+	UserSortField_USER_SORT_FIELD_LAST_LOGIN  UserSortField = 5
+)
+
+// Enum value maps for UserSortField.
+var (
+	UserSortField_name = map[int32]string{
+		0: "USER_SORT_FIELD_UNSPECIFIED",
+		1: "USER_SORT_FIELD_CREATED_AT",
+		2: "USER_SORT_FIELD_EMAIL",
+		3: "USER_SORT_FIELD_USERNAME",
+		4: "USER_SORT_FIELD_LAST_NAME",
+		5: "USER_SORT_FIELD_LAST_LOGIN",
+	}
+	UserSortField_value = map[string]int32{
+		"USER_SORT_FIELD_UNSPECIFIED": 0,
+		"USER_SORT_FIELD_CREATED_AT":  1,
+		"USER_SORT_FIELD_EMAIL":       2,
+		"USER_SORT_FIELD_USERNAME":    3,
+		"USER_SORT_FIELD_LAST_NAME":   4,
+		"USER_SORT_FIELD_LAST_LOGIN":  5,
+	}
+)
+
+func (x UserSortField) Enum() *UserSortField {
+	p := new(UserSortField)
+	*p = x
+	return p
+}
+
+func (x UserSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_auth_v1_user_proto_enumTypes[1].Descriptor()
+}
+
+func (UserSortField) Type() protoreflect.EnumType {
+	return &file_auth_v1_user_proto_enumTypes[1]
+}
+
+func (x UserSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserSortField.Descriptor instead.
+func (UserSortField) EnumDescriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
 // User model for MongoDB auth_db.users collection
 type User struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
-	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	TenantId              string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId              string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
 	Email                 string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email" bson:"email"`
 	Username              string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username" bson:"username"`
-	PasswordHash          string                 `protobuf:"bytes,5,opt,name=password_hash,json=passwordHash,proto3" json:"-" bson:"password_hash"`
+	PasswordHash          string                 `protobuf:"bytes,5,opt,name=password_hash,json=passwordHash,proto3" json:"-" bson:"password_hash" validate:"required"`
 	Profile               *UserProfile           `protobuf:"bytes,6,opt,name=profile,proto3" json:"profile" bson:"profile"`
-	Roles                 []*UserRole            `protobuf:"bytes,7,rep,name=roles,proto3" json:"roles" bson:"roles"`
+	Roles                 []*UserRole            `protobuf:"bytes,7,rep,name=roles,proto3" json:"roles" bson:"roles" validate:"dive"`
 	AdditionalPermissions []string               `protobuf:"bytes,8,rep,name=additional_permissions,json=additionalPermissions,proto3" json:"additional_permissions,omitempty" bson:"additional_permissions,omitempty"`
 	RevokedPermissions    []string               `protobuf:"bytes,9,rep,name=revoked_permissions,json=revokedPermissions,proto3" json:"revoked_permissions,omitempty" bson:"revoked_permissions,omitempty"`
-	Status                UserStatus             `protobuf:"varint,10,opt,name=status,proto3,enum=auth.v1.UserStatus" json:"status" bson:"status"`
+	Status                UserStatus             `protobuf:"varint,10,opt,name=status,proto3,enum=auth.v1.UserStatus" json:"status" bson:"status" validate:"required"`
 	EmailVerified         bool                   `protobuf:"varint,11,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified" bson:"email_verified"`
 	PhoneVerified         bool                   `protobuf:"varint,12,opt,name=phone_verified,json=phoneVerified,proto3" json:"phone_verified" bson:"phone_verified"`
 	MfaEnabled            bool                   `protobuf:"varint,13,opt,name=mfa_enabled,json=mfaEnabled,proto3" json:"mfa_enabled" bson:"mfa_enabled"`
@@ -105,11 +165,28 @@ type User struct {
 	Preferences           *UserPreferences       `protobuf:"bytes,19,opt,name=preferences,proto3" json:"preferences" bson:"preferences"`
 	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
 	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
-	CreatedBy             string                 `protobuf:"bytes,22,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by"`
+	CreatedBy             string                 `protobuf:"bytes,22,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
 	LastActivity          *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity" bson:"last_activity"`
 	LoginHistory          []*LoginRecord         `protobuf:"bytes,24,rep,name=login_history,json=loginHistory,proto3" json:"login_history,omitempty" bson:"login_history,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	MfaRecoveryCodes      []string               `protobuf:"bytes,25,rep,name=mfa_recovery_codes,json=mfaRecoveryCodes,proto3" json:"-" bson:"mfa_recovery_codes,omitempty"`
+	// Hashes of previous passwords, newest first, kept up to the tenant password policy history depth
+	PasswordHistory []string `protobuf:"bytes,26,rep,name=password_history,json=passwordHistory,proto3" json:"-" bson:"password_history,omitempty"`
+	// Accounts at OIDC providers the user can log in with
+	ExternalIdentities []*ExternalIdentity `protobuf:"bytes,27,rep,name=external_identities,json=externalIdentities,proto3" json:"external_identities,omitempty" bson:"external_identities,omitempty"`
+	// Set when the user asked to delete the account, it is deleted at this time unless the user logs in before
+	DeletionScheduledAt *timestamppb.Timestamp `protobuf:"bytes,28,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty" bson:"deletion_scheduled_at,omitempty"`
+	// Incremented on every update, an update must carry the version it was read at
+	Version int64 `protobuf:"varint,29,opt,name=version,proto3" json:"version" bson:"version"`
+	// Set on the generated passwords, the user must change the password before logging in. Not omitempty, the
+	// updates clear it
+	MustChangePassword bool `protobuf:"varint,30,opt,name=must_change_password,json=mustChangePassword,proto3" json:"must_change_password,omitempty" bson:"must_change_password"`
+	// Id of the user at the identity provider that provisions it through SCIM
+	ExternalId string `protobuf:"bytes,31,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty" bson:"external_id,omitempty"`
+	// TOTP time step of the last accepted code, the codes of this step and the earlier ones are refused so an accepted
+	// code cannot be replayed
+	MfaLastCounter int64 `protobuf:"varint,32,opt,name=mfa_last_counter,json=mfaLastCounter,proto3" json:"-" bson:"mfa_last_counter,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -310,22 +387,156 @@ func (x *User) GetLoginHistory() []*LoginRecord {
 	return nil
 }
 
+func (x *User) GetMfaRecoveryCodes() []string {
+	if x != nil {
+		return x.MfaRecoveryCodes
+	}
+	return nil
+}
+
+func (x *User) GetPasswordHistory() []string {
+	if x != nil {
+		return x.PasswordHistory
+	}
+	return nil
+}
+
+func (x *User) GetExternalIdentities() []*ExternalIdentity {
+	if x != nil {
+		return x.ExternalIdentities
+	}
+	return nil
+}
+
+func (x *User) GetDeletionScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletionScheduledAt
+	}
+	return nil
+}
+
+func (x *User) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *User) GetMustChangePassword() bool {
+	if x != nil {
+		return x.MustChangePassword
+	}
+	return false
+}
+
+func (x *User) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *User) GetMfaLastCounter() int64 {
+	if x != nil {
+		return x.MfaLastCounter
+	}
+	return 0
+}
+
+type ExternalIdentity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Provider name as configured in the auth service
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider" bson:"provider"`
+	Issuer   string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer" bson:"issuer"`
+	// Stable user id at the provider (the id token sub claim)
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject" bson:"subject"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty" bson:"email,omitempty"`
+	LinkedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at" bson:"linked_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalIdentity) Reset() {
+	*x = ExternalIdentity{}
+	mi := &file_auth_v1_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalIdentity) ProtoMessage() {}
+
+func (x *ExternalIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalIdentity.ProtoReflect.Descriptor instead.
+func (*ExternalIdentity) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExternalIdentity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ExternalIdentity) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *ExternalIdentity) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ExternalIdentity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ExternalIdentity) GetLinkedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return nil
+}
+
 type UserProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FirstName     string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name" bson:"first_name"`
-	LastName      string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name" bson:"last_name"`
-	DisplayName   string                 `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name" bson:"display_name"`
+	FirstName     string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name" bson:"first_name" validate:"max=100"`
+	LastName      string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name" bson:"last_name" validate:"max=100"`
+	DisplayName   string                 `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name" bson:"display_name" validate:"max=200"`
 	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty" bson:"avatar_url,omitempty"`
 	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty" bson:"phone,omitempty"`
-	Title         string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty" bson:"title,omitempty"`
-	Department    string                 `protobuf:"bytes,7,opt,name=department,proto3" json:"department,omitempty" bson:"department,omitempty"`
+	Title         string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty" bson:"title,omitempty" validate:"max=100"`
+	Department    string                 `protobuf:"bytes,7,opt,name=department,proto3" json:"department,omitempty" bson:"department,omitempty" validate:"max=100"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UserProfile) Reset() {
 	*x = UserProfile{}
-	mi := &file_auth_v1_user_proto_msgTypes[1]
+	mi := &file_auth_v1_user_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +548,7 @@ func (x *UserProfile) String() string {
 func (*UserProfile) ProtoMessage() {}
 
 func (x *UserProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[1]
+	mi := &file_auth_v1_user_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +561,7 @@ func (x *UserProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
 func (*UserProfile) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{1}
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *UserProfile) GetFirstName() string {
@@ -404,10 +615,10 @@ func (x *UserProfile) GetDepartment() string {
 
 type UserRole struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id" bson:"role_id"`
-	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id" bson:"role_id" validate:"required"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
 	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at" bson:"assigned_at"`
-	AssignedBy    string                 `protobuf:"bytes,4,opt,name=assigned_by,json=assignedBy,proto3" json:"assigned_by" bson:"assigned_by"`
+	AssignedBy    string                 `protobuf:"bytes,4,opt,name=assigned_by,json=assignedBy,proto3" json:"assigned_by" bson:"assigned_by" validate:"required"`
 	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty" bson:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -415,7 +626,7 @@ type UserRole struct {
 
 func (x *UserRole) Reset() {
 	*x = UserRole{}
-	mi := &file_auth_v1_user_proto_msgTypes[2]
+	mi := &file_auth_v1_user_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -427,7 +638,7 @@ func (x *UserRole) String() string {
 func (*UserRole) ProtoMessage() {}
 
 func (x *UserRole) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[2]
+	mi := &file_auth_v1_user_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,7 +651,7 @@ func (x *UserRole) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserRole.ProtoReflect.Descriptor instead.
 func (*UserRole) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{2}
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UserRole) GetRoleId() string {
@@ -480,8 +691,8 @@ func (x *UserRole) GetExpiresAt() *timestamppb.Timestamp {
 
 type UserPreferences struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
-	Language        string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language" bson:"language"`
-	Timezone        string                 `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone" bson:"timezone"`
+	Language        string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language" bson:"language" validate:"max=10"`
+	Timezone        string                 `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone" bson:"timezone" validate:"max=100"`
 	Theme           string                 `protobuf:"bytes,3,opt,name=theme,proto3" json:"theme" bson:"theme"`
 	Notifications   *NotificationSettings  `protobuf:"bytes,4,opt,name=notifications,proto3" json:"notifications" bson:"notifications"`
 	DashboardLayout *structpb.Struct       `protobuf:"bytes,5,opt,name=dashboard_layout,json=dashboardLayout,proto3" json:"dashboard_layout,omitempty" bson:"dashboard_layout,omitempty"`
@@ -491,7 +702,7 @@ type UserPreferences struct {
 
 func (x *UserPreferences) Reset() {
 	*x = UserPreferences{}
-	mi := &file_auth_v1_user_proto_msgTypes[3]
+	mi := &file_auth_v1_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -503,7 +714,7 @@ func (x *UserPreferences) String() string {
 func (*UserPreferences) ProtoMessage() {}
 
 func (x *UserPreferences) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[3]
+	mi := &file_auth_v1_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +727,7 @@ func (x *UserPreferences) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserPreferences.ProtoReflect.Descriptor instead.
 func (*UserPreferences) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{3}
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UserPreferences) GetLanguage() string {
@@ -565,7 +776,7 @@ type NotificationSettings struct {
 
 func (x *NotificationSettings) Reset() {
 	*x = NotificationSettings{}
-	mi := &file_auth_v1_user_proto_msgTypes[4]
+	mi := &file_auth_v1_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +788,7 @@ func (x *NotificationSettings) String() string {
 func (*NotificationSettings) ProtoMessage() {}
 
 func (x *NotificationSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[4]
+	mi := &file_auth_v1_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +801,7 @@ func (x *NotificationSettings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NotificationSettings.ProtoReflect.Descriptor instead.
 func (*NotificationSettings) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{4}
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NotificationSettings) GetEmail() bool {
@@ -615,18 +826,27 @@ func (x *NotificationSettings) GetSms() bool {
 }
 
 type LoginRecord struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp" bson:"timestamp"`
-	IpAddress     string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address" bson:"ip_address"`
-	UserAgent     string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent" bson:"user_agent"`
-	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success" bson:"success"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp" bson:"timestamp"`
+	IpAddress string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address" bson:"ip_address"`
+	UserAgent string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent" bson:"user_agent"`
+	Success   bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success" bson:"success"`
+	// Set on the records of the login_history collection, the records embedded in users predate it
+	Id       string `protobuf:"bytes,5,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	TenantId string `protobuf:"bytes,6,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	// Empty for attempts on unknown accounts
+	UserId string `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id" bson:"user_id"`
+	// Email or username the attempt was made with
+	Account string `protobuf:"bytes,8,opt,name=account,proto3" json:"account" bson:"account"`
+	// Error code of failed attempts
+	FailureReason string `protobuf:"bytes,9,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason" bson:"failure_reason"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LoginRecord) Reset() {
 	*x = LoginRecord{}
-	mi := &file_auth_v1_user_proto_msgTypes[5]
+	mi := &file_auth_v1_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -638,7 +858,7 @@ func (x *LoginRecord) String() string {
 func (*LoginRecord) ProtoMessage() {}
 
 func (x *LoginRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[5]
+	mi := &file_auth_v1_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -651,7 +871,7 @@ func (x *LoginRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRecord.ProtoReflect.Descriptor instead.
 func (*LoginRecord) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{5}
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *LoginRecord) GetTimestamp() *timestamppb.Timestamp {
@@ -682,79 +902,77 @@ func (x *LoginRecord) GetSuccess() bool {
 	return false
 }
 
-type CreateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateUserRequest) Reset() {
-	*x = CreateUserRequest{}
-	mi := &file_auth_v1_user_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateUserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *LoginRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (*CreateUserRequest) ProtoMessage() {}
-
-func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[6]
+func (x *LoginRecord) GetTenantId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TenantId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
-func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{6}
+func (x *LoginRecord) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *CreateUserRequest) GetIdentifier() *v1.UserIdentifier {
+func (x *LoginRecord) GetAccount() string {
 	if x != nil {
-		return x.Identifier
+		return x.Account
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateUserRequest) GetUser() *User {
+func (x *LoginRecord) GetFailureReason() string {
 	if x != nil {
-		return x.User
+		return x.FailureReason
 	}
-	return nil
+	return ""
 }
 
-type CreateUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+// Device model for MongoDB auth_db.devices collection, a device a user logged in from. Devices are registered on
+// login and keyed by the fingerprint of their user agent, the tokens issued on a device carry its fingerprint
+type Device struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	TenantId    string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	UserId      string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id" bson:"user_id"`
+	Fingerprint string                 `protobuf:"bytes,4,opt,name=fingerprint,proto3" json:"fingerprint" bson:"fingerprint"`
+	// Defaults to the platform, renamed by the user
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name" bson:"name"`
+	// Operating system parsed from the user agent, e.g. "Windows" or "iOS"
+	Platform      string                 `protobuf:"bytes,6,opt,name=platform,proto3" json:"platform" bson:"platform"`
+	UserAgent     string                 `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent" bson:"user_agent"`
+	LastIpAddress string                 `protobuf:"bytes,8,opt,name=last_ip_address,json=lastIpAddress,proto3" json:"last_ip_address" bson:"last_ip_address"`
+	FirstSeen     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen" bson:"first_seen"`
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen" bson:"last_seen"`
+	// Set once a login from the device passed MFA or a step-up code
+	Trusted       bool `protobuf:"varint,11,opt,name=trusted,proto3" json:"trusted" bson:"trusted"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateUserResponse) Reset() {
-	*x = CreateUserResponse{}
+func (x *Device) Reset() {
+	*x = Device{}
 	mi := &file_auth_v1_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateUserResponse) String() string {
+func (x *Device) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateUserResponse) ProtoMessage() {}
+func (*Device) ProtoMessage() {}
 
-func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
+func (x *Device) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_v1_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -766,102 +984,2237 @@ func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
-func (*CreateUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
 	return file_auth_v1_user_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateUserResponse) GetUserId() string {
+func (x *Device) GetId() string {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return ""
 }
 
-type GetUserRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
-	AccountId      string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
-}
-
-func (x *GetUserRequest) Reset() {
-	*x = GetUserRequest{}
-	mi := &file_auth_v1_user_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Device) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
 }
 
-func (x *GetUserRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Device) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (*GetUserRequest) ProtoMessage() {}
-
-func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[8]
+func (x *Device) GetFingerprint() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Fingerprint
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
-func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{8}
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *GetUserRequest) GetIdentifier() *v1.UserIdentifier {
+func (x *Device) GetPlatform() string {
 	if x != nil {
-		return x.Identifier
+		return x.Platform
 	}
-	return nil
+	return ""
 }
 
-func (x *GetUserRequest) GetTargetTenantId() string {
+func (x *Device) GetUserAgent() string {
 	if x != nil {
-		return x.TargetTenantId
+		return x.UserAgent
 	}
 	return ""
 }
 
-func (x *GetUserRequest) GetAccountId() string {
+func (x *Device) GetLastIpAddress() string {
 	if x != nil {
-		return x.AccountId
+		return x.LastIpAddress
 	}
 	return ""
 }
 
-type ListUsersRequest struct {
+func (x *Device) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *Device) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *Device) GetTrusted() bool {
+	if x != nil {
+		return x.Trusted
+	}
+	return false
+}
+
+type CreateUserRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	User       *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// Plain text password, validated against the tenant password policy and hashed by the server
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateUserRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CreateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserResponse) Reset() {
+	*x = CreateUserResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserResponse) ProtoMessage() {}
+
+func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
+func (*CreateUserResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateUserResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
 	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
-	RoleId         *string                `protobuf:"bytes,3,opt,name=role_id,json=roleId,proto3,oneof" json:"role_id,omitempty"`
+	AccountId      string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ListUsersRequest) Reset() {
-	*x = ListUsersRequest{}
-	mi := &file_auth_v1_user_proto_msgTypes[9]
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetUserRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetUserRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *GetUserRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	RoleId         *string                `protobuf:"bytes,3,opt,name=role_id,json=roleId,proto3,oneof" json:"role_id,omitempty"`
+	// Order of the users, e.g. "username" or "created_at desc, email", ties are broken by id
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Fields of the returned users, every field when empty, the id is always returned
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	// Most users returned, all of them when 0
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListUsersRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetRoleId() string {
+	if x != nil && x.RoleId != nil {
+		return *x.RoleId
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// User search criteria, all set criteria must match
+type UserSearch struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Statuses []UserStatus           `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=auth.v1.UserStatus" json:"statuses,omitempty"`
+	RoleId   *string                `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3,oneof" json:"role_id,omitempty"`
+	// Matches emails at the domain, without the @
+	EmailDomain *string `protobuf:"bytes,3,opt,name=email_domain,json=emailDomain,proto3,oneof" json:"email_domain,omitempty"`
+	// Inclusive lower and exclusive upper bound of created_at
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Case insensitive match on email, username and the profile names, title and department
+	Query         string        `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
+	SortBy        UserSortField `protobuf:"varint,7,opt,name=sort_by,json=sortBy,proto3,enum=auth.v1.UserSortField" json:"sort_by,omitempty"`
+	Descending    bool          `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSearch) Reset() {
+	*x = UserSearch{}
+	mi := &file_auth_v1_user_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSearch) ProtoMessage() {}
+
+func (x *UserSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSearch.ProtoReflect.Descriptor instead.
+func (*UserSearch) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UserSearch) GetStatuses() []UserStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *UserSearch) GetRoleId() string {
+	if x != nil && x.RoleId != nil {
+		return *x.RoleId
+	}
+	return ""
+}
+
+func (x *UserSearch) GetEmailDomain() string {
+	if x != nil && x.EmailDomain != nil {
+		return *x.EmailDomain
+	}
+	return ""
+}
+
+func (x *UserSearch) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *UserSearch) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *UserSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *UserSearch) GetSortBy() UserSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return UserSortField_USER_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *UserSearch) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type SearchUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	Search         *UserSearch            `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	Pagination     *v1.PaginationRequest  `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchUsersRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SearchUsersRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetSearch() *UserSearch {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *SearchUsersRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SearchUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *SearchUsersResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UpdateUserRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	User       *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// Fields of user to change, the named fields unset in user are cleared. The whole user is replaced when empty
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateUserRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       bool                   `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserResponse) ProtoMessage() {}
+
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateUserResponse) GetUpdated() bool {
+	if x != nil {
+		return x.Updated
+	}
+	return false
+}
+
+type DeleteUserRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	AccountId      *string                `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteUserRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DeleteUserRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *DeleteUserRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteUserResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type GetProfileCompletionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Defaults to the requesting user
+	AccountId     *string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileCompletionRequest) Reset() {
+	*x = GetProfileCompletionRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileCompletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileCompletionRequest) ProtoMessage() {}
+
+func (x *GetProfileCompletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileCompletionRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileCompletionRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetProfileCompletionRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetProfileCompletionRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *GetProfileCompletionRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+type ProfileCompletion struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CompletedFields   []string               `protobuf:"bytes,2,rep,name=completed_fields,json=completedFields,proto3" json:"completed_fields,omitempty"`
+	MissingFields     []string               `protobuf:"bytes,3,rep,name=missing_fields,json=missingFields,proto3" json:"missing_fields,omitempty"`
+	CompletionPercent int32                  `protobuf:"varint,4,opt,name=completion_percent,json=completionPercent,proto3" json:"completion_percent,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ProfileCompletion) Reset() {
+	*x = ProfileCompletion{}
+	mi := &file_auth_v1_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileCompletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileCompletion) ProtoMessage() {}
+
+func (x *ProfileCompletion) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileCompletion.ProtoReflect.Descriptor instead.
+func (*ProfileCompletion) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProfileCompletion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProfileCompletion) GetCompletedFields() []string {
+	if x != nil {
+		return x.CompletedFields
+	}
+	return nil
+}
+
+func (x *ProfileCompletion) GetMissingFields() []string {
+	if x != nil {
+		return x.MissingFields
+	}
+	return nil
+}
+
+func (x *ProfileCompletion) GetCompletionPercent() int32 {
+	if x != nil {
+		return x.CompletionPercent
+	}
+	return 0
+}
+
+type GetProfileCompletionStatsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetProfileCompletionStatsRequest) Reset() {
+	*x = GetProfileCompletionStatsRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileCompletionStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileCompletionStatsRequest) ProtoMessage() {}
+
+func (x *GetProfileCompletionStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileCompletionStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileCompletionStatsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetProfileCompletionStatsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetProfileCompletionStatsRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+type ProfileCompletionStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalUsers     int32                  `protobuf:"varint,1,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
+	CompletedUsers int32                  `protobuf:"varint,2,opt,name=completed_users,json=completedUsers,proto3" json:"completed_users,omitempty"`
+	// Number of users missing each eventually required field
+	MissingByField           map[string]int32 `protobuf:"bytes,3,rep,name=missing_by_field,json=missingByField,proto3" json:"missing_by_field,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	EventuallyRequiredFields []string         `protobuf:"bytes,4,rep,name=eventually_required_fields,json=eventuallyRequiredFields,proto3" json:"eventually_required_fields,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ProfileCompletionStats) Reset() {
+	*x = ProfileCompletionStats{}
+	mi := &file_auth_v1_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileCompletionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileCompletionStats) ProtoMessage() {}
+
+func (x *ProfileCompletionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileCompletionStats.ProtoReflect.Descriptor instead.
+func (*ProfileCompletionStats) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProfileCompletionStats) GetTotalUsers() int32 {
+	if x != nil {
+		return x.TotalUsers
+	}
+	return 0
+}
+
+func (x *ProfileCompletionStats) GetCompletedUsers() int32 {
+	if x != nil {
+		return x.CompletedUsers
+	}
+	return 0
+}
+
+func (x *ProfileCompletionStats) GetMissingByField() map[string]int32 {
+	if x != nil {
+		return x.MissingByField
+	}
+	return nil
+}
+
+func (x *ProfileCompletionStats) GetEventuallyRequiredFields() []string {
+	if x != nil {
+		return x.EventuallyRequiredFields
+	}
+	return nil
+}
+
+type SendEmailVerificationRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Defaults to the requesting user, resending for another account requires user update permission
+	AccountId     *string `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailVerificationRequest) Reset() {
+	*x = SendEmailVerificationRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailVerificationRequest) ProtoMessage() {}
+
+func (x *SendEmailVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailVerificationRequest.ProtoReflect.Descriptor instead.
+func (*SendEmailVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SendEmailVerificationRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SendEmailVerificationRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+type SendEmailVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sent          bool                   `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailVerificationResponse) Reset() {
+	*x = SendEmailVerificationResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailVerificationResponse) ProtoMessage() {}
+
+func (x *SendEmailVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailVerificationResponse.ProtoReflect.Descriptor instead.
+func (*SendEmailVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SendEmailVerificationResponse) GetSent() bool {
+	if x != nil {
+		return x.Sent
+	}
+	return false
+}
+
+type ConfirmEmailVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailVerificationRequest) Reset() {
+	*x = ConfirmEmailVerificationRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailVerificationRequest) ProtoMessage() {}
+
+func (x *ConfirmEmailVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailVerificationRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConfirmEmailVerificationRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ConfirmEmailVerificationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ConfirmEmailVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailVerificationResponse) Reset() {
+	*x = ConfirmEmailVerificationResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailVerificationResponse) ProtoMessage() {}
+
+func (x *ConfirmEmailVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailVerificationResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ConfirmEmailVerificationResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+// Password management
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Identifier      *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	CurrentPassword string                 `protobuf:"bytes,2,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ChangePasswordRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ChangePasswordResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+// Administrative reset of another account password
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ResetPasswordRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ResetPasswordRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ResetPasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PasswordReset bool                   `protobuf:"varint,1,opt,name=password_reset,json=passwordReset,proto3" json:"password_reset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordResponse) ProtoMessage() {}
+
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ResetPasswordResponse) GetPasswordReset() bool {
+	if x != nil {
+		return x.PasswordReset
+	}
+	return false
+}
+
+// Role assignments
+type ExtendRoleAssignmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	AccountId      string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	RoleId         string                 `protobuf:"bytes,4,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// New expiry of the assignment, in the future
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendRoleAssignmentRequest) Reset() {
+	*x = ExtendRoleAssignmentRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendRoleAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendRoleAssignmentRequest) ProtoMessage() {}
+
+func (x *ExtendRoleAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendRoleAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*ExtendRoleAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExtendRoleAssignmentRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ExtendRoleAssignmentRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *ExtendRoleAssignmentRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ExtendRoleAssignmentRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *ExtendRoleAssignmentRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ExtendRoleAssignmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Extended      bool                   `protobuf:"varint,1,opt,name=extended,proto3" json:"extended,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtendRoleAssignmentResponse) Reset() {
+	*x = ExtendRoleAssignmentResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendRoleAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendRoleAssignmentResponse) ProtoMessage() {}
+
+func (x *ExtendRoleAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendRoleAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*ExtendRoleAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExtendRoleAssignmentResponse) GetExtended() bool {
+	if x != nil {
+		return x.Extended
+	}
+	return false
+}
+
+// Login history
+type GetLoginHistoryRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Defaults to the requesting user, other accounts require user read permission
+	AccountId *string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	// Inclusive lower and exclusive upper bound of the attempt timestamp
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,6,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginHistoryRequest) Reset() {
+	*x = GetLoginHistoryRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginHistoryRequest) ProtoMessage() {}
+
+func (x *GetLoginHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLoginHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetLoginHistoryRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetLoginHistoryRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *GetLoginHistoryRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *GetLoginHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetLoginHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetLoginHistoryRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type GetLoginHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Records       []*LoginRecord         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginHistoryResponse) Reset() {
+	*x = GetLoginHistoryResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginHistoryResponse) ProtoMessage() {}
+
+func (x *GetLoginHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetLoginHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetLoginHistoryResponse) GetRecords() []*LoginRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *GetLoginHistoryResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// Devices
+type ListDevicesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Defaults to the requesting user, other accounts require user read permission
+	AccountId     *string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListDevicesRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListDevicesRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *ListDevicesRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+type ListDevicesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most recently seen first
+	Devices       []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type RenameDeviceRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Defaults to the requesting user, other accounts require user update permission
+	AccountId     *string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	DeviceId      string  `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Name          string  `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameDeviceRequest) Reset() {
+	*x = RenameDeviceRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameDeviceRequest) ProtoMessage() {}
+
+func (x *RenameDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RenameDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RenameDeviceRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *RenameDeviceRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *RenameDeviceRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *RenameDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *RenameDeviceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RevokeDeviceRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	// Defaults to the requesting user, other accounts require user update permission
+	AccountId     *string `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	DeviceId      string  `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceRequest) Reset() {
+	*x = RevokeDeviceRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceRequest) ProtoMessage() {}
+
+func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RevokeDeviceRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *RevokeDeviceRequest) GetTargetTenantId() string {
+	if x != nil {
+		return x.TargetTenantId
+	}
+	return ""
+}
+
+func (x *RevokeDeviceRequest) GetAccountId() string {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return ""
+}
+
+func (x *RevokeDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RevokeDeviceResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Revoked bool                   `protobuf:"varint,1,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	// Whether the session of the user was issued on the device and revoked with it
+	TokensRevoked bool `protobuf:"varint,2,opt,name=tokens_revoked,json=tokensRevoked,proto3" json:"tokens_revoked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceResponse) Reset() {
+	*x = RevokeDeviceResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceResponse) ProtoMessage() {}
+
+func (x *RevokeDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RevokeDeviceResponse) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+func (x *RevokeDeviceResponse) GetTokensRevoked() bool {
+	if x != nil {
+		return x.TokensRevoked
+	}
+	return false
+}
+
+// Self-service
+type UpdateMyProfileRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Replaces the whole profile
+	Profile       *UserProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMyProfileRequest) Reset() {
+	*x = UpdateMyProfileRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMyProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMyProfileRequest) ProtoMessage() {}
+
+func (x *UpdateMyProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMyProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMyProfileRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UpdateMyProfileRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateMyProfileRequest) GetProfile() *UserProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type ChangeMyEmailRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Identifier      *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	NewEmail        string                 `protobuf:"bytes,2,opt,name=new_email,json=newEmail,proto3" json:"new_email,omitempty"`
+	CurrentPassword string                 `protobuf:"bytes,3,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangeMyEmailRequest) Reset() {
+	*x = ChangeMyEmailRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeMyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeMyEmailRequest) ProtoMessage() {}
+
+func (x *ChangeMyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeMyEmailRequest.ProtoReflect.Descriptor instead.
+func (*ChangeMyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ChangeMyEmailRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ChangeMyEmailRequest) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+func (x *ChangeMyEmailRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+type ChangeMyEmailResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Changed bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
+	// The new address is unverified until the emailed link is opened
+	VerificationSent bool `protobuf:"varint,2,opt,name=verification_sent,json=verificationSent,proto3" json:"verification_sent,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChangeMyEmailResponse) Reset() {
+	*x = ChangeMyEmailResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeMyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeMyEmailResponse) ProtoMessage() {}
+
+func (x *ChangeMyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeMyEmailResponse.ProtoReflect.Descriptor instead.
+func (*ChangeMyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ChangeMyEmailResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *ChangeMyEmailResponse) GetVerificationSent() bool {
+	if x != nil {
+		return x.VerificationSent
+	}
+	return false
+}
+
+type ChangeMyPasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Identifier      *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	CurrentPassword string                 `protobuf:"bytes,2,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangeMyPasswordRequest) Reset() {
+	*x = ChangeMyPasswordRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersRequest) String() string {
+func (x *ChangeMyPasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersRequest) ProtoMessage() {}
+func (*ChangeMyPasswordRequest) ProtoMessage() {}
 
-func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[9]
+func (x *ChangeMyPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -872,55 +3225,54 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
-func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ChangeMyPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangeMyPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *ListUsersRequest) GetIdentifier() *v1.UserIdentifier {
+func (x *ChangeMyPasswordRequest) GetIdentifier() *v1.UserIdentifier {
 	if x != nil {
 		return x.Identifier
 	}
 	return nil
 }
 
-func (x *ListUsersRequest) GetTargetTenantId() string {
+func (x *ChangeMyPasswordRequest) GetCurrentPassword() string {
 	if x != nil {
-		return x.TargetTenantId
+		return x.CurrentPassword
 	}
 	return ""
 }
 
-func (x *ListUsersRequest) GetRoleId() string {
-	if x != nil && x.RoleId != nil {
-		return *x.RoleId
+func (x *ChangeMyPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
 	}
 	return ""
 }
 
-type ListUsersResponse struct {
+type ChangeMyPasswordResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Changed       bool                   `protobuf:"varint,1,opt,name=changed,proto3" json:"changed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersResponse) Reset() {
-	*x = ListUsersResponse{}
-	mi := &file_auth_v1_user_proto_msgTypes[10]
+func (x *ChangeMyPasswordResponse) Reset() {
+	*x = ChangeMyPasswordResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersResponse) String() string {
+func (x *ChangeMyPasswordResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersResponse) ProtoMessage() {}
+func (*ChangeMyPasswordResponse) ProtoMessage() {}
 
-func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[10]
+func (x *ChangeMyPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -931,48 +3283,94 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
-func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ChangeMyPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangeMyPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *ListUsersResponse) GetUsers() []*User {
+func (x *ChangeMyPasswordResponse) GetChanged() bool {
 	if x != nil {
-		return x.Users
+		return x.Changed
+	}
+	return false
+}
+
+type UpdateMyPreferencesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Replaces the whole preferences
+	Preferences   *UserPreferences `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMyPreferencesRequest) Reset() {
+	*x = UpdateMyPreferencesRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMyPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMyPreferencesRequest) ProtoMessage() {}
+
+func (x *UpdateMyPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMyPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMyPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateMyPreferencesRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
 	}
 	return nil
 }
 
-func (x *ListUsersResponse) GetPagination() *v1.PaginationResponse {
+func (x *UpdateMyPreferencesRequest) GetPreferences() *UserPreferences {
 	if x != nil {
-		return x.Pagination
+		return x.Preferences
 	}
 	return nil
 }
 
-type UpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type DeleteMyAccountRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Identifier      *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	CurrentPassword string                 `protobuf:"bytes,2,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *UpdateUserRequest) Reset() {
-	*x = UpdateUserRequest{}
-	mi := &file_auth_v1_user_proto_msgTypes[11]
+func (x *DeleteMyAccountRequest) Reset() {
+	*x = DeleteMyAccountRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserRequest) String() string {
+func (x *DeleteMyAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserRequest) ProtoMessage() {}
+func (*DeleteMyAccountRequest) ProtoMessage() {}
 
-func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[11]
+func (x *DeleteMyAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,47 +3381,48 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DeleteMyAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMyAccountRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *UpdateUserRequest) GetIdentifier() *v1.UserIdentifier {
+func (x *DeleteMyAccountRequest) GetIdentifier() *v1.UserIdentifier {
 	if x != nil {
 		return x.Identifier
 	}
 	return nil
 }
 
-func (x *UpdateUserRequest) GetUser() *User {
+func (x *DeleteMyAccountRequest) GetCurrentPassword() string {
 	if x != nil {
-		return x.User
+		return x.CurrentPassword
 	}
-	return nil
+	return ""
 }
 
-type UpdateUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Updated       bool                   `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type DeleteMyAccountResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Logging in before this time cancels the deletion
+	DeletionScheduledAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *UpdateUserResponse) Reset() {
-	*x = UpdateUserResponse{}
-	mi := &file_auth_v1_user_proto_msgTypes[12]
+func (x *DeleteMyAccountResponse) Reset() {
+	*x = DeleteMyAccountResponse{}
+	mi := &file_auth_v1_user_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserResponse) String() string {
+func (x *DeleteMyAccountResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserResponse) ProtoMessage() {}
+func (*DeleteMyAccountResponse) ProtoMessage() {}
 
-func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[12]
+func (x *DeleteMyAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,42 +3433,45 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use DeleteMyAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMyAccountResponse) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *UpdateUserResponse) GetUpdated() bool {
+func (x *DeleteMyAccountResponse) GetDeletionScheduledAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Updated
+		return x.DeletionScheduledAt
 	}
-	return false
+	return nil
 }
 
-type DeleteUserRequest struct {
+// Data erasure
+type EraseUserRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	TargetTenantId string                 `protobuf:"bytes,3,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
-	AccountId      *string                `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	Identifier     *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	TargetTenantId string                 `protobuf:"bytes,2,opt,name=target_tenant_id,json=targetTenantId,proto3" json:"target_tenant_id,omitempty"`
+	AccountId      string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Recorded on the erasure receipt
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserRequest) Reset() {
-	*x = DeleteUserRequest{}
-	mi := &file_auth_v1_user_proto_msgTypes[13]
+func (x *EraseUserRequest) Reset() {
+	*x = EraseUserRequest{}
+	mi := &file_auth_v1_user_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserRequest) String() string {
+func (x *EraseUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserRequest) ProtoMessage() {}
+func (*EraseUserRequest) ProtoMessage() {}
 
-func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[13]
+func (x *EraseUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1080,54 +3482,73 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
-func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use EraseUserRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *DeleteUserRequest) GetIdentifier() *v1.UserIdentifier {
+func (x *EraseUserRequest) GetIdentifier() *v1.UserIdentifier {
 	if x != nil {
 		return x.Identifier
 	}
 	return nil
 }
 
-func (x *DeleteUserRequest) GetTargetTenantId() string {
+func (x *EraseUserRequest) GetTargetTenantId() string {
 	if x != nil {
 		return x.TargetTenantId
 	}
 	return ""
 }
 
-func (x *DeleteUserRequest) GetAccountId() string {
-	if x != nil && x.AccountId != nil {
-		return *x.AccountId
+func (x *EraseUserRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
 	}
 	return ""
 }
 
-type DeleteUserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *EraseUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (x *DeleteUserResponse) Reset() {
-	*x = DeleteUserResponse{}
-	mi := &file_auth_v1_user_proto_msgTypes[14]
+// Record of the erasure of the personal data of a user, also written to the audit log of the tenant
+type ErasureReceipt struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// The user id is kept so the audit records of the user still resolve
+	UserId   string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ErasedBy string                 `protobuf:"bytes,4,opt,name=erased_by,json=erasedBy,proto3" json:"erased_by,omitempty"`
+	ErasedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	Reason   string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Fields of the user that were cleared or replaced
+	ErasedFields           []string `protobuf:"bytes,7,rep,name=erased_fields,json=erasedFields,proto3" json:"erased_fields,omitempty"`
+	LoginRecordsAnonymized int32    `protobuf:"varint,8,opt,name=login_records_anonymized,json=loginRecordsAnonymized,proto3" json:"login_records_anonymized,omitempty"`
+	AuditLogsAnonymized    int32    `protobuf:"varint,9,opt,name=audit_logs_anonymized,json=auditLogsAnonymized,proto3" json:"audit_logs_anonymized,omitempty"`
+	SessionsRevoked        bool     `protobuf:"varint,10,opt,name=sessions_revoked,json=sessionsRevoked,proto3" json:"sessions_revoked,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ErasureReceipt) Reset() {
+	*x = ErasureReceipt{}
+	mi := &file_auth_v1_user_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserResponse) String() string {
+func (x *ErasureReceipt) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserResponse) ProtoMessage() {}
+func (*ErasureReceipt) ProtoMessage() {}
 
-func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_v1_user_proto_msgTypes[14]
+func (x *ErasureReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_user_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1138,14 +3559,77 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
-func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_auth_v1_user_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ErasureReceipt.ProtoReflect.Descriptor instead.
+func (*ErasureReceipt) Descriptor() ([]byte, []int) {
+	return file_auth_v1_user_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *DeleteUserResponse) GetDeleted() bool {
+func (x *ErasureReceipt) GetId() string {
 	if x != nil {
-		return x.Deleted
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ErasureReceipt) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ErasureReceipt) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ErasureReceipt) GetErasedBy() string {
+	if x != nil {
+		return x.ErasedBy
+	}
+	return ""
+}
+
+func (x *ErasureReceipt) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+func (x *ErasureReceipt) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ErasureReceipt) GetErasedFields() []string {
+	if x != nil {
+		return x.ErasedFields
+	}
+	return nil
+}
+
+func (x *ErasureReceipt) GetLoginRecordsAnonymized() int32 {
+	if x != nil {
+		return x.LoginRecordsAnonymized
+	}
+	return 0
+}
+
+func (x *ErasureReceipt) GetAuditLogsAnonymized() int32 {
+	if x != nil {
+		return x.AuditLogsAnonymized
+	}
+	return 0
+}
+
+func (x *ErasureReceipt) GetSessionsRevoked() bool {
+	if x != nil {
+		return x.SessionsRevoked
 	}
 	return false
 }
@@ -1154,19 +3638,19 @@ var File_auth_v1_user_proto protoreflect.FileDescriptor
 
 const file_auth_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x12auth/v1/user.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13tagger/tagger.proto\"\xe7\x11\n" +
-	"\x04User\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
-	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x124\n" +
+	"\x12auth/v1/user.proto\x12\aauth.v1\x1a\x14infra/v1/infra.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a google/protobuf/field_mask.proto\x1a\x13tagger/tagger.proto\"\xd8\x19\n" +
+	"\x04User\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x124\n" +
 	"\x05email\x18\x03 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"email\" json:\"email\"R\x05email\x12@\n" +
-	"\busername\x18\x04 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"username\" json:\"username\"R\busername\x12G\n" +
-	"\rpassword_hash\x18\x05 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"password_hash\" json:\"-\"R\fpasswordHash\x12R\n" +
-	"\aprofile\x18\x06 \x01(\v2\x14.auth.v1.UserProfileB\"\x9a\x84\x9e\x03\x1dbson:\"profile\" json:\"profile\"R\aprofile\x12G\n" +
-	"\x05roles\x18\a \x03(\v2\x11.auth.v1.UserRoleB\x1e\x9a\x84\x9e\x03\x19bson:\"roles\" json:\"roles\"R\x05roles\x12\x8b\x01\n" +
+	"\busername\x18\x04 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"username\" json:\"username\"R\busername\x12[\n" +
+	"\rpassword_hash\x18\x05 \x01(\tB6\x9a\x84\x9e\x031bson:\"password_hash\" json:\"-\" validate:\"required\"R\fpasswordHash\x12R\n" +
+	"\aprofile\x18\x06 \x01(\v2\x14.auth.v1.UserProfileB\"\x9a\x84\x9e\x03\x1dbson:\"profile\" json:\"profile\"R\aprofile\x12W\n" +
+	"\x05roles\x18\a \x03(\v2\x11.auth.v1.UserRoleB.\x9a\x84\x9e\x03)bson:\"roles\" json:\"roles\" validate:\"dive\"R\x05roles\x12\x8b\x01\n" +
 	"\x16additional_permissions\x18\b \x03(\tBT\x9a\x84\x9e\x03Obson:\"additional_permissions,omitempty\" json:\"additional_permissions,omitempty\"R\x15additionalPermissions\x12\x7f\n" +
-	"\x13revoked_permissions\x18\t \x03(\tBN\x9a\x84\x9e\x03Ibson:\"revoked_permissions,omitempty\" json:\"revoked_permissions,omitempty\"R\x12revokedPermissions\x12M\n" +
+	"\x13revoked_permissions\x18\t \x03(\tBN\x9a\x84\x9e\x03Ibson:\"revoked_permissions,omitempty\" json:\"revoked_permissions,omitempty\"R\x12revokedPermissions\x12a\n" +
 	"\x06status\x18\n" +
-	" \x01(\x0e2\x13.auth.v1.UserStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12W\n" +
+	" \x01(\x0e2\x13.auth.v1.UserStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12W\n" +
 	"\x0eemail_verified\x18\v \x01(\bB0\x9a\x84\x9e\x03+bson:\"email_verified\" json:\"email_verified\"R\remailVerified\x12W\n" +
 	"\x0ephone_verified\x18\f \x01(\bB0\x9a\x84\x9e\x03+bson:\"phone_verified\" json:\"phone_verified\"R\rphoneVerified\x12K\n" +
 	"\vmfa_enabled\x18\r \x01(\bB*\x9a\x84\x9e\x03%bson:\"mfa_enabled\" json:\"mfa_enabled\"R\n" +
@@ -1182,109 +3666,378 @@ const file_auth_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
-	"updated_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12G\n" +
+	"updated_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12[\n" +
 	"\n" +
-	"created_by\x18\x16 \x01(\tB(\x9a\x84\x9e\x03#bson:\"created_by\" json:\"created_by\"R\tcreatedBy\x12o\n" +
+	"created_by\x18\x16 \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12o\n" +
 	"\rlast_activity\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampB.\x9a\x84\x9e\x03)bson:\"last_activity\" json:\"last_activity\"R\flastActivity\x12}\n" +
-	"\rlogin_history\x18\x18 \x03(\v2\x14.auth.v1.LoginRecordBB\x9a\x84\x9e\x03=bson:\"login_history,omitempty\" json:\"login_history,omitempty\"R\floginHistory\"\xbb\x04\n" +
-	"\vUserProfile\x12G\n" +
+	"\rlogin_history\x18\x18 \x03(\v2\x14.auth.v1.LoginRecordBB\x9a\x84\x9e\x03=bson:\"login_history,omitempty\" json:\"login_history,omitempty\"R\floginHistory\x12_\n" +
+	"\x12mfa_recovery_codes\x18\x19 \x03(\tB1\x9a\x84\x9e\x03,bson:\"mfa_recovery_codes,omitempty\" json:\"-\"R\x10mfaRecoveryCodes\x12Z\n" +
+	"\x10password_history\x18\x1a \x03(\tB/\x9a\x84\x9e\x03*bson:\"password_history,omitempty\" json:\"-\"R\x0fpasswordHistory\x12\x9a\x01\n" +
+	"\x13external_identities\x18\x1b \x03(\v2\x19.auth.v1.ExternalIdentityBN\x9a\x84\x9e\x03Ibson:\"external_identities,omitempty\" json:\"external_identities,omitempty\"R\x12externalIdentities\x12\xa2\x01\n" +
+	"\x15deletion_scheduled_at\x18\x1c \x01(\v2\x1a.google.protobuf.TimestampBR\x9a\x84\x9e\x03Mbson:\"deletion_scheduled_at,omitempty\" json:\"deletion_scheduled_at,omitempty\"R\x13deletionScheduledAt\x12<\n" +
+	"\aversion\x18\x1d \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\x12x\n" +
+	"\x14must_change_password\x18\x1e \x01(\bBF\x9a\x84\x9e\x03Abson:\"must_change_password\" json:\"must_change_password,omitempty\"R\x12mustChangePassword\x12_\n" +
+	"\vexternal_id\x18\x1f \x01(\tB>\x9a\x84\x9e\x039bson:\"external_id,omitempty\" json:\"external_id,omitempty\"R\n" +
+	"externalId\x12Y\n" +
+	"\x10mfa_last_counter\x18  \x01(\x03B/\x9a\x84\x9e\x03*bson:\"mfa_last_counter,omitempty\" json:\"-\"R\x0emfaLastCounter\"\xf7\x02\n" +
+	"\x10ExternalIdentity\x12@\n" +
+	"\bprovider\x18\x01 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"provider\" json:\"provider\"R\bprovider\x128\n" +
+	"\x06issuer\x18\x02 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"issuer\" json:\"issuer\"R\x06issuer\x12<\n" +
+	"\asubject\x18\x03 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"subject\" json:\"subject\"R\asubject\x12H\n" +
+	"\x05email\x18\x04 \x01(\tB2\x9a\x84\x9e\x03-bson:\"email,omitempty\" json:\"email,omitempty\"R\x05email\x12_\n" +
+	"\tlinked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB&\x9a\x84\x9e\x03!bson:\"linked_at\" json:\"linked_at\"R\blinkedAt\"\x9a\x05\n" +
+	"\vUserProfile\x12Z\n" +
 	"\n" +
-	"first_name\x18\x01 \x01(\tB(\x9a\x84\x9e\x03#bson:\"first_name\" json:\"first_name\"R\tfirstName\x12C\n" +
-	"\tlast_name\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"last_name\" json:\"last_name\"R\blastName\x12O\n" +
-	"\fdisplay_name\x18\x03 \x01(\tB,\x9a\x84\x9e\x03'bson:\"display_name\" json:\"display_name\"R\vdisplayName\x12[\n" +
+	"first_name\x18\x01 \x01(\tB;\x9a\x84\x9e\x036bson:\"first_name\" json:\"first_name\" validate:\"max=100\"R\tfirstName\x12V\n" +
+	"\tlast_name\x18\x02 \x01(\tB9\x9a\x84\x9e\x034bson:\"last_name\" json:\"last_name\" validate:\"max=100\"R\blastName\x12b\n" +
+	"\fdisplay_name\x18\x03 \x01(\tB?\x9a\x84\x9e\x03:bson:\"display_name\" json:\"display_name\" validate:\"max=200\"R\vdisplayName\x12[\n" +
 	"\n" +
 	"avatar_url\x18\x04 \x01(\tB<\x9a\x84\x9e\x037bson:\"avatar_url,omitempty\" json:\"avatar_url,omitempty\"R\tavatarUrl\x12H\n" +
-	"\x05phone\x18\x05 \x01(\tB2\x9a\x84\x9e\x03-bson:\"phone,omitempty\" json:\"phone,omitempty\"R\x05phone\x12H\n" +
-	"\x05title\x18\x06 \x01(\tB2\x9a\x84\x9e\x03-bson:\"title,omitempty\" json:\"title,omitempty\"R\x05title\x12\\\n" +
+	"\x05phone\x18\x05 \x01(\tB2\x9a\x84\x9e\x03-bson:\"phone,omitempty\" json:\"phone,omitempty\"R\x05phone\x12[\n" +
+	"\x05title\x18\x06 \x01(\tBE\x9a\x84\x9e\x03@bson:\"title,omitempty\" json:\"title,omitempty\" validate:\"max=100\"R\x05title\x12o\n" +
 	"\n" +
-	"department\x18\a \x01(\tB<\x9a\x84\x9e\x037bson:\"department,omitempty\" json:\"department,omitempty\"R\n" +
-	"department\"\xbb\x03\n" +
-	"\bUserRole\x12;\n" +
-	"\arole_id\x18\x01 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"role_id\" json:\"role_id\"R\x06roleId\x12C\n" +
-	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12g\n" +
+	"department\x18\a \x01(\tBO\x9a\x84\x9e\x03Jbson:\"department,omitempty\" json:\"department,omitempty\" validate:\"max=100\"R\n" +
+	"department\"\xf7\x03\n" +
+	"\bUserRole\x12O\n" +
+	"\arole_id\x18\x01 \x01(\tB6\x9a\x84\x9e\x031bson:\"role_id\" json:\"role_id\" validate:\"required\"R\x06roleId\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12g\n" +
 	"\vassigned_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB*\x9a\x84\x9e\x03%bson:\"assigned_at\" json:\"assigned_at\"R\n" +
-	"assignedAt\x12K\n" +
-	"\vassigned_by\x18\x04 \x01(\tB*\x9a\x84\x9e\x03%bson:\"assigned_by\" json:\"assigned_by\"R\n" +
+	"assignedAt\x12_\n" +
+	"\vassigned_by\x18\x04 \x01(\tB>\x9a\x84\x9e\x039bson:\"assigned_by\" json:\"assigned_by\" validate:\"required\"R\n" +
 	"assignedBy\x12w\n" +
 	"\n" +
-	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB<\x9a\x84\x9e\x037bson:\"expires_at,omitempty\" json:\"expires_at,omitempty\"R\texpiresAt\"\xcf\x03\n" +
-	"\x0fUserPreferences\x12@\n" +
-	"\blanguage\x18\x01 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"language\" json:\"language\"R\blanguage\x12@\n" +
-	"\btimezone\x18\x02 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"timezone\" json:\"timezone\"R\btimezone\x124\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB<\x9a\x84\x9e\x037bson:\"expires_at,omitempty\" json:\"expires_at,omitempty\"R\texpiresAt\"\xf4\x03\n" +
+	"\x0fUserPreferences\x12R\n" +
+	"\blanguage\x18\x01 \x01(\tB6\x9a\x84\x9e\x031bson:\"language\" json:\"language\" validate:\"max=10\"R\blanguage\x12S\n" +
+	"\btimezone\x18\x02 \x01(\tB7\x9a\x84\x9e\x032bson:\"timezone\" json:\"timezone\" validate:\"max=100\"R\btimezone\x124\n" +
 	"\x05theme\x18\x03 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"theme\" json:\"theme\"R\x05theme\x12s\n" +
 	"\rnotifications\x18\x04 \x01(\v2\x1d.auth.v1.NotificationSettingsB.\x9a\x84\x9e\x03)bson:\"notifications\" json:\"notifications\"R\rnotifications\x12\x8c\x01\n" +
 	"\x10dashboard_layout\x18\x05 \x01(\v2\x17.google.protobuf.StructBH\x9a\x84\x9e\x03Cbson:\"dashboard_layout,omitempty\" json:\"dashboard_layout,omitempty\"R\x0fdashboardLayout\"\xac\x01\n" +
 	"\x14NotificationSettings\x124\n" +
 	"\x05email\x18\x01 \x01(\bB\x1e\x9a\x84\x9e\x03\x19bson:\"email\" json:\"email\"R\x05email\x120\n" +
 	"\x04push\x18\x02 \x01(\bB\x1c\x9a\x84\x9e\x03\x17bson:\"push\" json:\"push\"R\x04push\x12,\n" +
-	"\x03sms\x18\x03 \x01(\bB\x1a\x9a\x84\x9e\x03\x15bson:\"sms\" json:\"sms\"R\x03sms\"\xbf\x02\n" +
+	"\x03sms\x18\x03 \x01(\bB\x1a\x9a\x84\x9e\x03\x15bson:\"sms\" json:\"sms\"R\x03sms\"\x8d\x05\n" +
 	"\vLoginRecord\x12`\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampB&\x9a\x84\x9e\x03!bson:\"timestamp\" json:\"timestamp\"R\ttimestamp\x12G\n" +
 	"\n" +
 	"ip_address\x18\x02 \x01(\tB(\x9a\x84\x9e\x03#bson:\"ip_address\" json:\"ip_address\"R\tipAddress\x12G\n" +
 	"\n" +
 	"user_agent\x18\x03 \x01(\tB(\x9a\x84\x9e\x03#bson:\"user_agent\" json:\"user_agent\"R\tuserAgent\x12<\n" +
-	"\asuccess\x18\x04 \x01(\bB\"\x9a\x84\x9e\x03\x1dbson:\"success\" json:\"success\"R\asuccess\"p\n" +
-	"\x11CreateUserRequest\x128\n" +
+	"\asuccess\x18\x04 \x01(\bB\"\x9a\x84\x9e\x03\x1dbson:\"success\" json:\"success\"R\asuccess\x123\n" +
+	"\x02id\x18\x05 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
+	"\ttenant_id\x18\x06 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12;\n" +
+	"\auser_id\x18\a \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"user_id\" json:\"user_id\"R\x06userId\x12<\n" +
+	"\aaccount\x18\b \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"account\" json:\"account\"R\aaccount\x12W\n" +
+	"\x0efailure_reason\x18\t \x01(\tB0\x9a\x84\x9e\x03+bson:\"failure_reason\" json:\"failure_reason\"R\rfailureReason\"\xaa\x06\n" +
+	"\x06Device\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
+	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12;\n" +
+	"\auser_id\x18\x03 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"user_id\" json:\"user_id\"R\x06userId\x12L\n" +
+	"\vfingerprint\x18\x04 \x01(\tB*\x9a\x84\x9e\x03%bson:\"fingerprint\" json:\"fingerprint\"R\vfingerprint\x120\n" +
+	"\x04name\x18\x05 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x12@\n" +
+	"\bplatform\x18\x06 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"platform\" json:\"platform\"R\bplatform\x12G\n" +
+	"\n" +
+	"user_agent\x18\a \x01(\tB(\x9a\x84\x9e\x03#bson:\"user_agent\" json:\"user_agent\"R\tuserAgent\x12Z\n" +
+	"\x0flast_ip_address\x18\b \x01(\tB2\x9a\x84\x9e\x03-bson:\"last_ip_address\" json:\"last_ip_address\"R\rlastIpAddress\x12c\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"first_seen\x18\t \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"first_seen\" json:\"first_seen\"R\tfirstSeen\x12_\n" +
+	"\tlast_seen\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB&\x9a\x84\x9e\x03!bson:\"last_seen\" json:\"last_seen\"R\blastSeen\x12<\n" +
+	"\atrusted\x18\v \x01(\bB\"\x9a\x84\x9e\x03\x1dbson:\"trusted\" json:\"trusted\"R\atrusted\"\xab\x01\n" +
+	"\x11CreateUserRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12!\n" +
-	"\x04user\x18\x02 \x01(\v2\r.auth.v1.UserR\x04user\"-\n" +
+	"\x04user\x18\x02 \x01(\v2\r.auth.v1.UserR\x04user\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"-\n" +
 	"\x12CreateUserResponse\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x93\x01\n" +
-	"\x0eGetUserRequest\x128\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xb2\x01\n" +
+	"\x0eGetUserRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12(\n" +
 	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x03 \x01(\tR\taccountId\"\xa0\x01\n" +
-	"\x10ListUsersRequest\x128\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\"\xa9\x02\n" +
+	"\x10ListUsersRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12(\n" +
 	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\x1c\n" +
-	"\arole_id\x18\x03 \x01(\tH\x00R\x06roleId\x88\x01\x01B\n" +
+	"\arole_id\x18\x03 \x01(\tH\x00R\x06roleId\x88\x01\x01\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x127\n" +
+	"\tread_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limitB\n" +
 	"\n" +
 	"\b_role_id\"v\n" +
 	"\x11ListUsersResponse\x12#\n" +
 	"\x05users\x18\x01 \x03(\v2\r.auth.v1.UserR\x05users\x12<\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
-	"pagination\"p\n" +
-	"\x11UpdateUserRequest\x128\n" +
+	"pagination\"\x8b\x03\n" +
+	"\n" +
+	"UserSearch\x12/\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x13.auth.v1.UserStatusR\bstatuses\x12\x1c\n" +
+	"\arole_id\x18\x02 \x01(\tH\x00R\x06roleId\x88\x01\x01\x12&\n" +
+	"\femail_domain\x18\x03 \x01(\tH\x01R\vemailDomain\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x14\n" +
+	"\x05query\x18\x06 \x01(\tR\x05query\x12/\n" +
+	"\asort_by\x18\a \x01(\x0e2\x16.auth.v1.UserSortFieldR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descendingB\n" +
+	"\n" +
+	"\b_role_idB\x0f\n" +
+	"\r_email_domain\"\x81\x02\n" +
+	"\x12SearchUsersRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12+\n" +
+	"\x06search\x18\x03 \x01(\v2\x13.auth.v1.UserSearchR\x06search\x12;\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"x\n" +
+	"\x13SearchUsersResponse\x12#\n" +
+	"\x05users\x18\x01 \x03(\v2\r.auth.v1.UserR\x05users\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xcc\x01\n" +
+	"\x11UpdateUserRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12!\n" +
-	"\x04user\x18\x02 \x01(\v2\r.auth.v1.UserR\x04user\".\n" +
+	"\x04user\x18\x02 \x01(\v2\r.auth.v1.UserR\x04user\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\".\n" +
 	"\x12UpdateUserResponse\x12\x18\n" +
-	"\aupdated\x18\x02 \x01(\bR\aupdated\"\xaa\x01\n" +
-	"\x11DeleteUserRequest\x128\n" +
+	"\aupdated\x18\x02 \x01(\bR\aupdated\"\xc9\x01\n" +
+	"\x11DeleteUserRequest\x12W\n" +
 	"\n" +
-	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierR\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
 	"identifier\x12(\n" +
 	"\x10target_tenant_id\x18\x03 \x01(\tR\x0etargetTenantId\x12\"\n" +
 	"\n" +
 	"account_id\x18\x02 \x01(\tH\x00R\taccountId\x88\x01\x01B\r\n" +
 	"\v_account_id\".\n" +
 	"\x12DeleteUserResponse\x12\x18\n" +
-	"\adeleted\x18\x01 \x01(\bR\adeleted*\x8f\x01\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xd3\x01\n" +
+	"\x1bGetProfileCompletionRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\"\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tH\x00R\taccountId\x88\x01\x01B\r\n" +
+	"\v_account_id\"\xad\x01\n" +
+	"\x11ProfileCompletion\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x10completed_fields\x18\x02 \x03(\tR\x0fcompletedFields\x12%\n" +
+	"\x0emissing_fields\x18\x03 \x03(\tR\rmissingFields\x12-\n" +
+	"\x12completion_percent\x18\x04 \x01(\x05R\x11completionPercent\"\xa5\x01\n" +
+	" GetProfileCompletionStatsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\"\xc2\x02\n" +
+	"\x16ProfileCompletionStats\x12\x1f\n" +
+	"\vtotal_users\x18\x01 \x01(\x05R\n" +
+	"totalUsers\x12'\n" +
+	"\x0fcompleted_users\x18\x02 \x01(\x05R\x0ecompletedUsers\x12]\n" +
+	"\x10missing_by_field\x18\x03 \x03(\v23.auth.v1.ProfileCompletionStats.MissingByFieldEntryR\x0emissingByField\x12<\n" +
+	"\x1aeventually_required_fields\x18\x04 \x03(\tR\x18eventuallyRequiredFields\x1aA\n" +
+	"\x13MissingByFieldEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xaa\x01\n" +
+	"\x1cSendEmailVerificationRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\"\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tH\x00R\taccountId\x88\x01\x01B\r\n" +
+	"\v_account_id\"3\n" +
+	"\x1dSendEmailVerificationResponse\x12\x12\n" +
+	"\x04sent\x18\x01 \x01(\bR\x04sent\"T\n" +
+	"\x1fConfirmEmailVerificationRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\">\n" +
+	" ConfirmEmailVerificationResponse\x12\x1a\n" +
+	"\bverified\x18\x01 \x01(\bR\bverified\"\xbe\x01\n" +
+	"\x15ChangePasswordRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12)\n" +
+	"\x10current_password\x18\x02 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"2\n" +
+	"\x16ChangePasswordResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\"\xb1\x01\n" +
+	"\x14ResetPasswordRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\">\n" +
+	"\x15ResetPasswordResponse\x12%\n" +
+	"\x0epassword_reset\x18\x01 \x01(\bR\rpasswordReset\"\x93\x02\n" +
+	"\x1bExtendRoleAssignmentRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x17\n" +
+	"\arole_id\x18\x04 \x01(\tR\x06roleId\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\":\n" +
+	"\x1cExtendRoleAssignmentResponse\x12\x1a\n" +
+	"\bextended\x18\x01 \x01(\bR\bextended\"\xe7\x02\n" +
+	"\x16GetLoginHistoryRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\"\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tH\x00R\taccountId\x88\x01\x01\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12;\n" +
+	"\n" +
+	"pagination\x18\x06 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"paginationB\r\n" +
+	"\v_account_id\"\x87\x01\n" +
+	"\x17GetLoginHistoryResponse\x12.\n" +
+	"\arecords\x18\x01 \x03(\v2\x14.auth.v1.LoginRecordR\arecords\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xca\x01\n" +
+	"\x12ListDevicesRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\"\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tH\x00R\taccountId\x88\x01\x01B\r\n" +
+	"\v_account_id\"@\n" +
+	"\x13ListDevicesResponse\x12)\n" +
+	"\adevices\x18\x01 \x03(\v2\x0f.auth.v1.DeviceR\adevices\"\xfc\x01\n" +
+	"\x13RenameDeviceRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\"\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tH\x00R\taccountId\x88\x01\x01\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04nameB\r\n" +
+	"\v_account_id\"\xe8\x01\n" +
+	"\x13RevokeDeviceRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\"\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tH\x00R\taccountId\x88\x01\x01\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceIdB\r\n" +
+	"\v_account_id\"W\n" +
+	"\x14RevokeDeviceResponse\x12\x18\n" +
+	"\arevoked\x18\x01 \x01(\bR\arevoked\x12%\n" +
+	"\x0etokens_revoked\x18\x02 \x01(\bR\rtokensRevoked\"\xa1\x01\n" +
+	"\x16UpdateMyProfileRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12.\n" +
+	"\aprofile\x18\x02 \x01(\v2\x14.auth.v1.UserProfileR\aprofile\"\xb7\x01\n" +
+	"\x14ChangeMyEmailRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x1b\n" +
+	"\tnew_email\x18\x02 \x01(\tR\bnewEmail\x12)\n" +
+	"\x10current_password\x18\x03 \x01(\tR\x0fcurrentPassword\"^\n" +
+	"\x15ChangeMyEmailResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\x12+\n" +
+	"\x11verification_sent\x18\x02 \x01(\bR\x10verificationSent\"\xc0\x01\n" +
+	"\x17ChangeMyPasswordRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12)\n" +
+	"\x10current_password\x18\x02 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"4\n" +
+	"\x18ChangeMyPasswordResponse\x12\x18\n" +
+	"\achanged\x18\x01 \x01(\bR\achanged\"\xb1\x01\n" +
+	"\x1aUpdateMyPreferencesRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12:\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x18.auth.v1.UserPreferencesR\vpreferences\"\x9c\x01\n" +
+	"\x16DeleteMyAccountRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12)\n" +
+	"\x10current_password\x18\x02 \x01(\tR\x0fcurrentPassword\"i\n" +
+	"\x17DeleteMyAccountResponse\x12N\n" +
+	"\x15deletion_scheduled_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x13deletionScheduledAt\"\xcc\x01\n" +
+	"\x10EraseUserRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12(\n" +
+	"\x10target_tenant_id\x18\x02 \x01(\tR\x0etargetTenantId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x82\x03\n" +
+	"\x0eErasureReceipt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\terased_by\x18\x04 \x01(\tR\berasedBy\x127\n" +
+	"\terased_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\berasedAt\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12#\n" +
+	"\rerased_fields\x18\a \x03(\tR\ferasedFields\x128\n" +
+	"\x18login_records_anonymized\x18\b \x01(\x05R\x16loginRecordsAnonymized\x122\n" +
+	"\x15audit_logs_anonymized\x18\t \x01(\x05R\x13auditLogsAnonymized\x12)\n" +
+	"\x10sessions_revoked\x18\n" +
+	" \x01(\bR\x0fsessionsRevoked*\x8f\x01\n" +
 	"\n" +
 	"UserStatus\x12\x1b\n" +
 	"\x17USER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12USER_STATUS_ACTIVE\x10\x01\x12\x18\n" +
 	"\x14USER_STATUS_INACTIVE\x10\x02\x12\x19\n" +
 	"\x15USER_STATUS_SUSPENDED\x10\x03\x12\x17\n" +
-	"\x13USER_STATUS_INVITED\x10\x042\xd9\x02\n" +
+	"\x13USER_STATUS_INVITED\x10\x04*\xc8\x01\n" +
+	"\rUserSortField\x12\x1f\n" +
+	"\x1bUSER_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aUSER_SORT_FIELD_CREATED_AT\x10\x01\x12\x19\n" +
+	"\x15USER_SORT_FIELD_EMAIL\x10\x02\x12\x1c\n" +
+	"\x18USER_SORT_FIELD_USERNAME\x10\x03\x12\x1d\n" +
+	"\x19USER_SORT_FIELD_LAST_NAME\x10\x04\x12\x1e\n" +
+	"\x1aUSER_SORT_FIELD_LAST_LOGIN\x10\x052\xcc\x0e\n" +
 	"\vUserService\x12E\n" +
 	"\n" +
 	"CreateUser\x12\x1a.auth.v1.CreateUserRequest\x1a\x1b.auth.v1.CreateUserResponse\x121\n" +
 	"\aGetUser\x12\x17.auth.v1.GetUserRequest\x1a\r.auth.v1.User\x12B\n" +
-	"\tListUsers\x12\x19.auth.v1.ListUsersRequest\x1a\x1a.auth.v1.ListUsersResponse\x12E\n" +
+	"\tListUsers\x12\x19.auth.v1.ListUsersRequest\x1a\x1a.auth.v1.ListUsersResponse\x12H\n" +
+	"\vSearchUsers\x12\x1b.auth.v1.SearchUsersRequest\x1a\x1c.auth.v1.SearchUsersResponse\x12E\n" +
 	"\n" +
 	"UpdateUser\x12\x1a.auth.v1.UpdateUserRequest\x1a\x1b.auth.v1.UpdateUserResponse\x12E\n" +
 	"\n" +
-	"DeleteUser\x12\x1a.auth.v1.DeleteUserRequest\x1a\x1b.auth.v1.DeleteUserResponseB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
+	"DeleteUser\x12\x1a.auth.v1.DeleteUserRequest\x1a\x1b.auth.v1.DeleteUserResponse\x12X\n" +
+	"\x14GetProfileCompletion\x12$.auth.v1.GetProfileCompletionRequest\x1a\x1a.auth.v1.ProfileCompletion\x12g\n" +
+	"\x19GetProfileCompletionStats\x12).auth.v1.GetProfileCompletionStatsRequest\x1a\x1f.auth.v1.ProfileCompletionStats\x12f\n" +
+	"\x15SendEmailVerification\x12%.auth.v1.SendEmailVerificationRequest\x1a&.auth.v1.SendEmailVerificationResponse\x12o\n" +
+	"\x18ConfirmEmailVerification\x12(.auth.v1.ConfirmEmailVerificationRequest\x1a).auth.v1.ConfirmEmailVerificationResponse\x12Q\n" +
+	"\x0eChangePassword\x12\x1e.auth.v1.ChangePasswordRequest\x1a\x1f.auth.v1.ChangePasswordResponse\x12N\n" +
+	"\rResetPassword\x12\x1d.auth.v1.ResetPasswordRequest\x1a\x1e.auth.v1.ResetPasswordResponse\x12c\n" +
+	"\x14ExtendRoleAssignment\x12$.auth.v1.ExtendRoleAssignmentRequest\x1a%.auth.v1.ExtendRoleAssignmentResponse\x12T\n" +
+	"\x0fGetLoginHistory\x12\x1f.auth.v1.GetLoginHistoryRequest\x1a .auth.v1.GetLoginHistoryResponse\x12H\n" +
+	"\vListDevices\x12\x1b.auth.v1.ListDevicesRequest\x1a\x1c.auth.v1.ListDevicesResponse\x12=\n" +
+	"\fRenameDevice\x12\x1c.auth.v1.RenameDeviceRequest\x1a\x0f.auth.v1.Device\x12K\n" +
+	"\fRevokeDevice\x12\x1c.auth.v1.RevokeDeviceRequest\x1a\x1d.auth.v1.RevokeDeviceResponse\x12A\n" +
+	"\x0fUpdateMyProfile\x12\x1f.auth.v1.UpdateMyProfileRequest\x1a\r.auth.v1.User\x12N\n" +
+	"\rChangeMyEmail\x12\x1d.auth.v1.ChangeMyEmailRequest\x1a\x1e.auth.v1.ChangeMyEmailResponse\x12W\n" +
+	"\x10ChangeMyPassword\x12 .auth.v1.ChangeMyPasswordRequest\x1a!.auth.v1.ChangeMyPasswordResponse\x12T\n" +
+	"\x13UpdateMyPreferences\x12#.auth.v1.UpdateMyPreferencesRequest\x1a\x18.auth.v1.UserPreferences\x12T\n" +
+	"\x0fDeleteMyAccount\x12\x1f.auth.v1.DeleteMyAccountRequest\x1a .auth.v1.DeleteMyAccountResponse\x12?\n" +
+	"\tEraseUser\x12\x19.auth.v1.EraseUserRequest\x1a\x17.auth.v1.ErasureReceiptB3Z1erp.localhost/internal/infra/model/auth/v1;authv1b\x06proto3"
 
 var (
 	file_auth_v1_user_proto_rawDescOnce sync.Once
@@ -1298,71 +4051,191 @@ func file_auth_v1_user_proto_rawDescGZIP() []byte {
 	return file_auth_v1_user_proto_rawDescData
 }
 
-var file_auth_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_auth_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_auth_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_auth_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_auth_v1_user_proto_goTypes = []any{
-	(UserStatus)(0),               // 0: auth.v1.UserStatus
-	(*User)(nil),                  // 1: auth.v1.User
-	(*UserProfile)(nil),           // 2: auth.v1.UserProfile
-	(*UserRole)(nil),              // 3: auth.v1.UserRole
-	(*UserPreferences)(nil),       // 4: auth.v1.UserPreferences
-	(*NotificationSettings)(nil),  // 5: auth.v1.NotificationSettings
-	(*LoginRecord)(nil),           // 6: auth.v1.LoginRecord
-	(*CreateUserRequest)(nil),     // 7: auth.v1.CreateUserRequest
-	(*CreateUserResponse)(nil),    // 8: auth.v1.CreateUserResponse
-	(*GetUserRequest)(nil),        // 9: auth.v1.GetUserRequest
-	(*ListUsersRequest)(nil),      // 10: auth.v1.ListUsersRequest
-	(*ListUsersResponse)(nil),     // 11: auth.v1.ListUsersResponse
-	(*UpdateUserRequest)(nil),     // 12: auth.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),    // 13: auth.v1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),     // 14: auth.v1.DeleteUserRequest
-	(*DeleteUserResponse)(nil),    // 15: auth.v1.DeleteUserResponse
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),       // 17: google.protobuf.Struct
-	(*v1.UserIdentifier)(nil),     // 18: infra.v1.UserIdentifier
-	(*v1.PaginationResponse)(nil), // 19: infra.v1.PaginationResponse
+	(UserStatus)(0),                          // 0: auth.v1.UserStatus
+	(UserSortField)(0),                       // 1: auth.v1.UserSortField
+	(*User)(nil),                             // 2: auth.v1.User
+	(*ExternalIdentity)(nil),                 // 3: auth.v1.ExternalIdentity
+	(*UserProfile)(nil),                      // 4: auth.v1.UserProfile
+	(*UserRole)(nil),                         // 5: auth.v1.UserRole
+	(*UserPreferences)(nil),                  // 6: auth.v1.UserPreferences
+	(*NotificationSettings)(nil),             // 7: auth.v1.NotificationSettings
+	(*LoginRecord)(nil),                      // 8: auth.v1.LoginRecord
+	(*Device)(nil),                           // 9: auth.v1.Device
+	(*CreateUserRequest)(nil),                // 10: auth.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),               // 11: auth.v1.CreateUserResponse
+	(*GetUserRequest)(nil),                   // 12: auth.v1.GetUserRequest
+	(*ListUsersRequest)(nil),                 // 13: auth.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),                // 14: auth.v1.ListUsersResponse
+	(*UserSearch)(nil),                       // 15: auth.v1.UserSearch
+	(*SearchUsersRequest)(nil),               // 16: auth.v1.SearchUsersRequest
+	(*SearchUsersResponse)(nil),              // 17: auth.v1.SearchUsersResponse
+	(*UpdateUserRequest)(nil),                // 18: auth.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),               // 19: auth.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),                // 20: auth.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),               // 21: auth.v1.DeleteUserResponse
+	(*GetProfileCompletionRequest)(nil),      // 22: auth.v1.GetProfileCompletionRequest
+	(*ProfileCompletion)(nil),                // 23: auth.v1.ProfileCompletion
+	(*GetProfileCompletionStatsRequest)(nil), // 24: auth.v1.GetProfileCompletionStatsRequest
+	(*ProfileCompletionStats)(nil),           // 25: auth.v1.ProfileCompletionStats
+	(*SendEmailVerificationRequest)(nil),     // 26: auth.v1.SendEmailVerificationRequest
+	(*SendEmailVerificationResponse)(nil),    // 27: auth.v1.SendEmailVerificationResponse
+	(*ConfirmEmailVerificationRequest)(nil),  // 28: auth.v1.ConfirmEmailVerificationRequest
+	(*ConfirmEmailVerificationResponse)(nil), // 29: auth.v1.ConfirmEmailVerificationResponse
+	(*ChangePasswordRequest)(nil),            // 30: auth.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),           // 31: auth.v1.ChangePasswordResponse
+	(*ResetPasswordRequest)(nil),             // 32: auth.v1.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),            // 33: auth.v1.ResetPasswordResponse
+	(*ExtendRoleAssignmentRequest)(nil),      // 34: auth.v1.ExtendRoleAssignmentRequest
+	(*ExtendRoleAssignmentResponse)(nil),     // 35: auth.v1.ExtendRoleAssignmentResponse
+	(*GetLoginHistoryRequest)(nil),           // 36: auth.v1.GetLoginHistoryRequest
+	(*GetLoginHistoryResponse)(nil),          // 37: auth.v1.GetLoginHistoryResponse
+	(*ListDevicesRequest)(nil),               // 38: auth.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),              // 39: auth.v1.ListDevicesResponse
+	(*RenameDeviceRequest)(nil),              // 40: auth.v1.RenameDeviceRequest
+	(*RevokeDeviceRequest)(nil),              // 41: auth.v1.RevokeDeviceRequest
+	(*RevokeDeviceResponse)(nil),             // 42: auth.v1.RevokeDeviceResponse
+	(*UpdateMyProfileRequest)(nil),           // 43: auth.v1.UpdateMyProfileRequest
+	(*ChangeMyEmailRequest)(nil),             // 44: auth.v1.ChangeMyEmailRequest
+	(*ChangeMyEmailResponse)(nil),            // 45: auth.v1.ChangeMyEmailResponse
+	(*ChangeMyPasswordRequest)(nil),          // 46: auth.v1.ChangeMyPasswordRequest
+	(*ChangeMyPasswordResponse)(nil),         // 47: auth.v1.ChangeMyPasswordResponse
+	(*UpdateMyPreferencesRequest)(nil),       // 48: auth.v1.UpdateMyPreferencesRequest
+	(*DeleteMyAccountRequest)(nil),           // 49: auth.v1.DeleteMyAccountRequest
+	(*DeleteMyAccountResponse)(nil),          // 50: auth.v1.DeleteMyAccountResponse
+	(*EraseUserRequest)(nil),                 // 51: auth.v1.EraseUserRequest
+	(*ErasureReceipt)(nil),                   // 52: auth.v1.ErasureReceipt
+	nil,                                      // 53: auth.v1.ProfileCompletionStats.MissingByFieldEntry
+	(*timestamppb.Timestamp)(nil),            // 54: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                  // 55: google.protobuf.Struct
+	(*v1.UserIdentifier)(nil),                // 56: infra.v1.UserIdentifier
+	(*fieldmaskpb.FieldMask)(nil),            // 57: google.protobuf.FieldMask
+	(*v1.PaginationResponse)(nil),            // 58: infra.v1.PaginationResponse
+	(*v1.PaginationRequest)(nil),             // 59: infra.v1.PaginationRequest
 }
 var file_auth_v1_user_proto_depIdxs = []int32{
-	2,  // 0: auth.v1.User.profile:type_name -> auth.v1.UserProfile
-	3,  // 1: auth.v1.User.roles:type_name -> auth.v1.UserRole
+	4,  // 0: auth.v1.User.profile:type_name -> auth.v1.UserProfile
+	5,  // 1: auth.v1.User.roles:type_name -> auth.v1.UserRole
 	0,  // 2: auth.v1.User.status:type_name -> auth.v1.UserStatus
-	16, // 3: auth.v1.User.last_login:type_name -> google.protobuf.Timestamp
-	16, // 4: auth.v1.User.last_password_change:type_name -> google.protobuf.Timestamp
-	16, // 5: auth.v1.User.password_reset_expires:type_name -> google.protobuf.Timestamp
-	4,  // 6: auth.v1.User.preferences:type_name -> auth.v1.UserPreferences
-	16, // 7: auth.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	16, // 8: auth.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	16, // 9: auth.v1.User.last_activity:type_name -> google.protobuf.Timestamp
-	6,  // 10: auth.v1.User.login_history:type_name -> auth.v1.LoginRecord
-	16, // 11: auth.v1.UserRole.assigned_at:type_name -> google.protobuf.Timestamp
-	16, // 12: auth.v1.UserRole.expires_at:type_name -> google.protobuf.Timestamp
-	5,  // 13: auth.v1.UserPreferences.notifications:type_name -> auth.v1.NotificationSettings
-	17, // 14: auth.v1.UserPreferences.dashboard_layout:type_name -> google.protobuf.Struct
-	16, // 15: auth.v1.LoginRecord.timestamp:type_name -> google.protobuf.Timestamp
-	18, // 16: auth.v1.CreateUserRequest.identifier:type_name -> infra.v1.UserIdentifier
-	1,  // 17: auth.v1.CreateUserRequest.user:type_name -> auth.v1.User
-	18, // 18: auth.v1.GetUserRequest.identifier:type_name -> infra.v1.UserIdentifier
-	18, // 19: auth.v1.ListUsersRequest.identifier:type_name -> infra.v1.UserIdentifier
-	1,  // 20: auth.v1.ListUsersResponse.users:type_name -> auth.v1.User
-	19, // 21: auth.v1.ListUsersResponse.pagination:type_name -> infra.v1.PaginationResponse
-	18, // 22: auth.v1.UpdateUserRequest.identifier:type_name -> infra.v1.UserIdentifier
-	1,  // 23: auth.v1.UpdateUserRequest.user:type_name -> auth.v1.User
-	18, // 24: auth.v1.DeleteUserRequest.identifier:type_name -> infra.v1.UserIdentifier
-	7,  // 25: auth.v1.UserService.CreateUser:input_type -> auth.v1.CreateUserRequest
-	9,  // 26: auth.v1.UserService.GetUser:input_type -> auth.v1.GetUserRequest
-	10, // 27: auth.v1.UserService.ListUsers:input_type -> auth.v1.ListUsersRequest
-	12, // 28: auth.v1.UserService.UpdateUser:input_type -> auth.v1.UpdateUserRequest
-	14, // 29: auth.v1.UserService.DeleteUser:input_type -> auth.v1.DeleteUserRequest
-	8,  // 30: auth.v1.UserService.CreateUser:output_type -> auth.v1.CreateUserResponse
-	1,  // 31: auth.v1.UserService.GetUser:output_type -> auth.v1.User
-	11, // 32: auth.v1.UserService.ListUsers:output_type -> auth.v1.ListUsersResponse
-	13, // 33: auth.v1.UserService.UpdateUser:output_type -> auth.v1.UpdateUserResponse
-	15, // 34: auth.v1.UserService.DeleteUser:output_type -> auth.v1.DeleteUserResponse
-	30, // [30:35] is the sub-list for method output_type
-	25, // [25:30] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	54, // 3: auth.v1.User.last_login:type_name -> google.protobuf.Timestamp
+	54, // 4: auth.v1.User.last_password_change:type_name -> google.protobuf.Timestamp
+	54, // 5: auth.v1.User.password_reset_expires:type_name -> google.protobuf.Timestamp
+	6,  // 6: auth.v1.User.preferences:type_name -> auth.v1.UserPreferences
+	54, // 7: auth.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	54, // 8: auth.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	54, // 9: auth.v1.User.last_activity:type_name -> google.protobuf.Timestamp
+	8,  // 10: auth.v1.User.login_history:type_name -> auth.v1.LoginRecord
+	3,  // 11: auth.v1.User.external_identities:type_name -> auth.v1.ExternalIdentity
+	54, // 12: auth.v1.User.deletion_scheduled_at:type_name -> google.protobuf.Timestamp
+	54, // 13: auth.v1.ExternalIdentity.linked_at:type_name -> google.protobuf.Timestamp
+	54, // 14: auth.v1.UserRole.assigned_at:type_name -> google.protobuf.Timestamp
+	54, // 15: auth.v1.UserRole.expires_at:type_name -> google.protobuf.Timestamp
+	7,  // 16: auth.v1.UserPreferences.notifications:type_name -> auth.v1.NotificationSettings
+	55, // 17: auth.v1.UserPreferences.dashboard_layout:type_name -> google.protobuf.Struct
+	54, // 18: auth.v1.LoginRecord.timestamp:type_name -> google.protobuf.Timestamp
+	54, // 19: auth.v1.Device.first_seen:type_name -> google.protobuf.Timestamp
+	54, // 20: auth.v1.Device.last_seen:type_name -> google.protobuf.Timestamp
+	56, // 21: auth.v1.CreateUserRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 22: auth.v1.CreateUserRequest.user:type_name -> auth.v1.User
+	56, // 23: auth.v1.GetUserRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 24: auth.v1.ListUsersRequest.identifier:type_name -> infra.v1.UserIdentifier
+	57, // 25: auth.v1.ListUsersRequest.read_mask:type_name -> google.protobuf.FieldMask
+	2,  // 26: auth.v1.ListUsersResponse.users:type_name -> auth.v1.User
+	58, // 27: auth.v1.ListUsersResponse.pagination:type_name -> infra.v1.PaginationResponse
+	0,  // 28: auth.v1.UserSearch.statuses:type_name -> auth.v1.UserStatus
+	54, // 29: auth.v1.UserSearch.created_after:type_name -> google.protobuf.Timestamp
+	54, // 30: auth.v1.UserSearch.created_before:type_name -> google.protobuf.Timestamp
+	1,  // 31: auth.v1.UserSearch.sort_by:type_name -> auth.v1.UserSortField
+	56, // 32: auth.v1.SearchUsersRequest.identifier:type_name -> infra.v1.UserIdentifier
+	15, // 33: auth.v1.SearchUsersRequest.search:type_name -> auth.v1.UserSearch
+	59, // 34: auth.v1.SearchUsersRequest.pagination:type_name -> infra.v1.PaginationRequest
+	2,  // 35: auth.v1.SearchUsersResponse.users:type_name -> auth.v1.User
+	58, // 36: auth.v1.SearchUsersResponse.pagination:type_name -> infra.v1.PaginationResponse
+	56, // 37: auth.v1.UpdateUserRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 38: auth.v1.UpdateUserRequest.user:type_name -> auth.v1.User
+	57, // 39: auth.v1.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	56, // 40: auth.v1.DeleteUserRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 41: auth.v1.GetProfileCompletionRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 42: auth.v1.GetProfileCompletionStatsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	53, // 43: auth.v1.ProfileCompletionStats.missing_by_field:type_name -> auth.v1.ProfileCompletionStats.MissingByFieldEntry
+	56, // 44: auth.v1.SendEmailVerificationRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 45: auth.v1.ChangePasswordRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 46: auth.v1.ResetPasswordRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 47: auth.v1.ExtendRoleAssignmentRequest.identifier:type_name -> infra.v1.UserIdentifier
+	54, // 48: auth.v1.ExtendRoleAssignmentRequest.expires_at:type_name -> google.protobuf.Timestamp
+	56, // 49: auth.v1.GetLoginHistoryRequest.identifier:type_name -> infra.v1.UserIdentifier
+	54, // 50: auth.v1.GetLoginHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	54, // 51: auth.v1.GetLoginHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	59, // 52: auth.v1.GetLoginHistoryRequest.pagination:type_name -> infra.v1.PaginationRequest
+	8,  // 53: auth.v1.GetLoginHistoryResponse.records:type_name -> auth.v1.LoginRecord
+	58, // 54: auth.v1.GetLoginHistoryResponse.pagination:type_name -> infra.v1.PaginationResponse
+	56, // 55: auth.v1.ListDevicesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	9,  // 56: auth.v1.ListDevicesResponse.devices:type_name -> auth.v1.Device
+	56, // 57: auth.v1.RenameDeviceRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 58: auth.v1.RevokeDeviceRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 59: auth.v1.UpdateMyProfileRequest.identifier:type_name -> infra.v1.UserIdentifier
+	4,  // 60: auth.v1.UpdateMyProfileRequest.profile:type_name -> auth.v1.UserProfile
+	56, // 61: auth.v1.ChangeMyEmailRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 62: auth.v1.ChangeMyPasswordRequest.identifier:type_name -> infra.v1.UserIdentifier
+	56, // 63: auth.v1.UpdateMyPreferencesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	6,  // 64: auth.v1.UpdateMyPreferencesRequest.preferences:type_name -> auth.v1.UserPreferences
+	56, // 65: auth.v1.DeleteMyAccountRequest.identifier:type_name -> infra.v1.UserIdentifier
+	54, // 66: auth.v1.DeleteMyAccountResponse.deletion_scheduled_at:type_name -> google.protobuf.Timestamp
+	56, // 67: auth.v1.EraseUserRequest.identifier:type_name -> infra.v1.UserIdentifier
+	54, // 68: auth.v1.ErasureReceipt.erased_at:type_name -> google.protobuf.Timestamp
+	10, // 69: auth.v1.UserService.CreateUser:input_type -> auth.v1.CreateUserRequest
+	12, // 70: auth.v1.UserService.GetUser:input_type -> auth.v1.GetUserRequest
+	13, // 71: auth.v1.UserService.ListUsers:input_type -> auth.v1.ListUsersRequest
+	16, // 72: auth.v1.UserService.SearchUsers:input_type -> auth.v1.SearchUsersRequest
+	18, // 73: auth.v1.UserService.UpdateUser:input_type -> auth.v1.UpdateUserRequest
+	20, // 74: auth.v1.UserService.DeleteUser:input_type -> auth.v1.DeleteUserRequest
+	22, // 75: auth.v1.UserService.GetProfileCompletion:input_type -> auth.v1.GetProfileCompletionRequest
+	24, // 76: auth.v1.UserService.GetProfileCompletionStats:input_type -> auth.v1.GetProfileCompletionStatsRequest
+	26, // 77: auth.v1.UserService.SendEmailVerification:input_type -> auth.v1.SendEmailVerificationRequest
+	28, // 78: auth.v1.UserService.ConfirmEmailVerification:input_type -> auth.v1.ConfirmEmailVerificationRequest
+	30, // 79: auth.v1.UserService.ChangePassword:input_type -> auth.v1.ChangePasswordRequest
+	32, // 80: auth.v1.UserService.ResetPassword:input_type -> auth.v1.ResetPasswordRequest
+	34, // 81: auth.v1.UserService.ExtendRoleAssignment:input_type -> auth.v1.ExtendRoleAssignmentRequest
+	36, // 82: auth.v1.UserService.GetLoginHistory:input_type -> auth.v1.GetLoginHistoryRequest
+	38, // 83: auth.v1.UserService.ListDevices:input_type -> auth.v1.ListDevicesRequest
+	40, // 84: auth.v1.UserService.RenameDevice:input_type -> auth.v1.RenameDeviceRequest
+	41, // 85: auth.v1.UserService.RevokeDevice:input_type -> auth.v1.RevokeDeviceRequest
+	43, // 86: auth.v1.UserService.UpdateMyProfile:input_type -> auth.v1.UpdateMyProfileRequest
+	44, // 87: auth.v1.UserService.ChangeMyEmail:input_type -> auth.v1.ChangeMyEmailRequest
+	46, // 88: auth.v1.UserService.ChangeMyPassword:input_type -> auth.v1.ChangeMyPasswordRequest
+	48, // 89: auth.v1.UserService.UpdateMyPreferences:input_type -> auth.v1.UpdateMyPreferencesRequest
+	49, // 90: auth.v1.UserService.DeleteMyAccount:input_type -> auth.v1.DeleteMyAccountRequest
+	51, // 91: auth.v1.UserService.EraseUser:input_type -> auth.v1.EraseUserRequest
+	11, // 92: auth.v1.UserService.CreateUser:output_type -> auth.v1.CreateUserResponse
+	2,  // 93: auth.v1.UserService.GetUser:output_type -> auth.v1.User
+	14, // 94: auth.v1.UserService.ListUsers:output_type -> auth.v1.ListUsersResponse
+	17, // 95: auth.v1.UserService.SearchUsers:output_type -> auth.v1.SearchUsersResponse
+	19, // 96: auth.v1.UserService.UpdateUser:output_type -> auth.v1.UpdateUserResponse
+	21, // 97: auth.v1.UserService.DeleteUser:output_type -> auth.v1.DeleteUserResponse
+	23, // 98: auth.v1.UserService.GetProfileCompletion:output_type -> auth.v1.ProfileCompletion
+	25, // 99: auth.v1.UserService.GetProfileCompletionStats:output_type -> auth.v1.ProfileCompletionStats
+	27, // 100: auth.v1.UserService.SendEmailVerification:output_type -> auth.v1.SendEmailVerificationResponse
+	29, // 101: auth.v1.UserService.ConfirmEmailVerification:output_type -> auth.v1.ConfirmEmailVerificationResponse
+	31, // 102: auth.v1.UserService.ChangePassword:output_type -> auth.v1.ChangePasswordResponse
+	33, // 103: auth.v1.UserService.ResetPassword:output_type -> auth.v1.ResetPasswordResponse
+	35, // 104: auth.v1.UserService.ExtendRoleAssignment:output_type -> auth.v1.ExtendRoleAssignmentResponse
+	37, // 105: auth.v1.UserService.GetLoginHistory:output_type -> auth.v1.GetLoginHistoryResponse
+	39, // 106: auth.v1.UserService.ListDevices:output_type -> auth.v1.ListDevicesResponse
+	9,  // 107: auth.v1.UserService.RenameDevice:output_type -> auth.v1.Device
+	42, // 108: auth.v1.UserService.RevokeDevice:output_type -> auth.v1.RevokeDeviceResponse
+	2,  // 109: auth.v1.UserService.UpdateMyProfile:output_type -> auth.v1.User
+	45, // 110: auth.v1.UserService.ChangeMyEmail:output_type -> auth.v1.ChangeMyEmailResponse
+	47, // 111: auth.v1.UserService.ChangeMyPassword:output_type -> auth.v1.ChangeMyPasswordResponse
+	6,  // 112: auth.v1.UserService.UpdateMyPreferences:output_type -> auth.v1.UserPreferences
+	50, // 113: auth.v1.UserService.DeleteMyAccount:output_type -> auth.v1.DeleteMyAccountResponse
+	52, // 114: auth.v1.UserService.EraseUser:output_type -> auth.v1.ErasureReceipt
+	92, // [92:115] is the sub-list for method output_type
+	69, // [69:92] is the sub-list for method input_type
+	69, // [69:69] is the sub-list for extension type_name
+	69, // [69:69] is the sub-list for extension extendee
+	0,  // [0:69] is the sub-list for field type_name
 }
 
 func init() { file_auth_v1_user_proto_init() }
@@ -1370,15 +4243,22 @@ func file_auth_v1_user_proto_init() {
 	if File_auth_v1_user_proto != nil {
 		return
 	}
-	file_auth_v1_user_proto_msgTypes[9].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[11].OneofWrappers = []any{}
 	file_auth_v1_user_proto_msgTypes[13].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[18].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[20].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[24].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[34].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[36].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[38].OneofWrappers = []any{}
+	file_auth_v1_user_proto_msgTypes[39].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_user_proto_rawDesc), len(file_auth_v1_user_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   15,
+			NumEnums:      2,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,24 @@
+package validator
+
+import (
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+func ValidateAPIKey(k *authv1.APIKey, createOperation bool) error {
+	return validation.Struct(k, !createOperation)
+}
+
+// ValidateActiveAPIKey returns an error when the key was revoked or has expired
+func ValidateActiveAPIKey(k *authv1.APIKey) error {
+	if k.GetRevokedAt() != nil {
+		return infra_error.Auth(infra_error.AuthTokenRevoked)
+	}
+	if k.GetExpiresAt() != nil && time.Now().After(k.GetExpiresAt().AsTime()) {
+		return infra_error.Auth(infra_error.AuthTokenExpired)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+	infra_error "erp.localhost/internal/infra/error"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+)
+
+func ValidateLoginLinkToken(lt *authv1_cache.LoginLinkToken) error {
+	missingFields := []string{}
+	if lt.TokenHash == "" {
+		missingFields = append(missingFields, "TokenHash")
+	}
+	if lt.UserId == "" {
+		missingFields = append(missingFields, "UserId")
+	}
+	if lt.Email == "" {
+		missingFields = append(missingFields, "Email")
+	}
+	if lt.ExpiresAt.AsTime().IsZero() {
+		missingFields = append(missingFields, "ExpiresAt")
+	}
+	if len(missingFields) > 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	}
+	return nil
+}
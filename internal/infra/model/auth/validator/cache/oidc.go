@@ -0,0 +1,32 @@
+package cache
+
+import (
+	infra_error "erp.localhost/internal/infra/error"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+)
+
+func ValidateOIDCState(s *authv1_cache.OIDCState) error {
+	missingFields := []string{}
+	if s.State == "" {
+		missingFields = append(missingFields, "State")
+	}
+	if s.TenantId == "" {
+		missingFields = append(missingFields, "TenantId")
+	}
+	if s.Provider == "" {
+		missingFields = append(missingFields, "Provider")
+	}
+	if s.Nonce == "" {
+		missingFields = append(missingFields, "Nonce")
+	}
+	if s.CodeVerifier == "" {
+		missingFields = append(missingFields, "CodeVerifier")
+	}
+	if s.ExpiresAt.AsTime().IsZero() {
+		missingFields = append(missingFields, "ExpiresAt")
+	}
+	if len(missingFields) > 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package cache
+
+import (
+	infra_error "erp.localhost/internal/infra/error"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+)
+
+func ValidateSAMLAssertion(a *authv1_cache.SAMLAssertion) error {
+	missingFields := []string{}
+	if a.AssertionId == "" {
+		missingFields = append(missingFields, "AssertionId")
+	}
+	if a.TenantId == "" {
+		missingFields = append(missingFields, "TenantId")
+	}
+	if a.Issuer == "" {
+		missingFields = append(missingFields, "Issuer")
+	}
+	if a.ExpiresAt.AsTime().IsZero() {
+		missingFields = append(missingFields, "ExpiresAt")
+	}
+	if len(missingFields) > 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	}
+	return nil
+}
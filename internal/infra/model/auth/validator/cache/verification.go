@@ -0,0 +1,26 @@
+package cache
+
+import (
+	infra_error "erp.localhost/internal/infra/error"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+)
+
+func ValidateEmailVerificationToken(vt *authv1_cache.EmailVerificationToken) error {
+	missingFields := []string{}
+	if vt.TokenHash == "" {
+		missingFields = append(missingFields, "TokenHash")
+	}
+	if vt.UserId == "" {
+		missingFields = append(missingFields, "UserId")
+	}
+	if vt.Email == "" {
+		missingFields = append(missingFields, "Email")
+	}
+	if vt.ExpiresAt.AsTime().IsZero() {
+		missingFields = append(missingFields, "ExpiresAt")
+	}
+	if len(missingFields) > 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	}
+	return nil
+}
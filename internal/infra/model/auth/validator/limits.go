@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"os"
+	"strconv"
+	"sync"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// ArrayLimitPolicy decides what happens when an embedded array grows past its limit
+type ArrayLimitPolicy int
+
+const (
+	// ArrayLimitReject fails validation, used for arrays carrying authorization data
+	ArrayLimitReject ArrayLimitPolicy = iota
+	// ArrayLimitTrimOldest drops the oldest entries, used for append-only history
+	ArrayLimitTrimOldest
+)
+
+// Embedded user array field names, as reported in errors and metrics
+const (
+	UserFieldLoginHistory          = "login_history"
+	UserFieldRoles                 = "roles"
+	UserFieldAdditionalPermissions = "additional_permissions"
+	UserFieldRevokedPermissions    = "revoked_permissions"
+)
+
+// NearLimitRatio is the fill ratio from which a document is reported as near its limit
+const NearLimitRatio = 0.9
+
+type ArrayLimit struct {
+	Max    int
+	Policy ArrayLimitPolicy
+}
+
+type UserArrayLimits struct {
+	LoginHistory          ArrayLimit
+	Roles                 ArrayLimit
+	AdditionalPermissions ArrayLimit
+	RevokedPermissions    ArrayLimit
+}
+
+var (
+	userArrayLimitsMu sync.RWMutex
+	userArrayLimits   = LoadUserArrayLimits()
+)
+
+// LoadUserArrayLimits reads the user array limits from the environment, falling back to defaults
+func LoadUserArrayLimits() UserArrayLimits {
+	return UserArrayLimits{
+		LoginHistory:          ArrayLimit{Max: envInt("USER_MAX_LOGIN_HISTORY", 50), Policy: ArrayLimitTrimOldest},
+		Roles:                 ArrayLimit{Max: envInt("USER_MAX_ROLES", 50), Policy: ArrayLimitReject},
+		AdditionalPermissions: ArrayLimit{Max: envInt("USER_MAX_ADDITIONAL_PERMISSIONS", 200), Policy: ArrayLimitReject},
+		RevokedPermissions:    ArrayLimit{Max: envInt("USER_MAX_REVOKED_PERMISSIONS", 200), Policy: ArrayLimitReject},
+	}
+}
+
+// SetUserArrayLimits overrides the limits enforced for user documents
+func SetUserArrayLimits(limits UserArrayLimits) {
+	userArrayLimitsMu.Lock()
+	defer userArrayLimitsMu.Unlock()
+	userArrayLimits = limits
+}
+
+// GetUserArrayLimits returns the limits currently enforced for user documents
+func GetUserArrayLimits() UserArrayLimits {
+	userArrayLimitsMu.RLock()
+	defer userArrayLimitsMu.RUnlock()
+	return userArrayLimits
+}
+
+// ValidateUserArrayLimits rejects users whose reject-policy arrays exceed their limit.
+// Trim-policy arrays are never rejected, they are trimmed by EnforceUserArrayLimits.
+func ValidateUserArrayLimits(u *authv1.User) error {
+	tooLong := []string{}
+	for _, field := range userArrayFields(u, GetUserArrayLimits()) {
+		if field.limit.Policy == ArrayLimitReject && field.limit.Max > 0 && field.length > field.limit.Max {
+			tooLong = append(tooLong, field.name)
+		}
+	}
+	if len(tooLong) > 0 {
+		return infra_error.Validation(infra_error.ValidationTooLong, tooLong...)
+	}
+	return nil
+}
+
+// EnforceUserArrayLimits trims trim-policy arrays oldest-first and returns the trimmed field names
+func EnforceUserArrayLimits(u *authv1.User) []string {
+	limits := GetUserArrayLimits()
+	trimmed := []string{}
+	if max := limits.LoginHistory.Max; limits.LoginHistory.Policy == ArrayLimitTrimOldest && max > 0 && len(u.LoginHistory) > max {
+		// login records are appended, the oldest are at the front
+		u.LoginHistory = u.LoginHistory[len(u.LoginHistory)-max:]
+		trimmed = append(trimmed, UserFieldLoginHistory)
+	}
+	return trimmed
+}
+
+// NearLimitUserArrays returns the user array fields filled above NearLimitRatio
+func NearLimitUserArrays(u *authv1.User) []string {
+	nearLimit := []string{}
+	for _, field := range userArrayFields(u, GetUserArrayLimits()) {
+		if field.limit.Max > 0 && float64(field.length) >= float64(field.limit.Max)*NearLimitRatio {
+			nearLimit = append(nearLimit, field.name)
+		}
+	}
+	return nearLimit
+}
+
+type userArrayField struct {
+	name   string
+	length int
+	limit  ArrayLimit
+}
+
+func userArrayFields(u *authv1.User, limits UserArrayLimits) []userArrayField {
+	return []userArrayField{
+		{name: UserFieldLoginHistory, length: len(u.GetLoginHistory()), limit: limits.LoginHistory},
+		{name: UserFieldRoles, length: len(u.GetRoles()), limit: limits.Roles},
+		{name: UserFieldAdditionalPermissions, length: len(u.GetAdditionalPermissions()), limit: limits.AdditionalPermissions},
+		{name: UserFieldRevokedPermissions, length: len(u.GetRevokedPermissions()), limit: limits.RevokedPermissions},
+	}
+}
+
+func envInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value >= 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func withUserArrayLimits(t *testing.T, limits UserArrayLimits) {
+	t.Helper()
+	previous := GetUserArrayLimits()
+	SetUserArrayLimits(limits)
+	t.Cleanup(func() { SetUserArrayLimits(previous) })
+}
+
+func loginHistory(n int) []*authv1.LoginRecord {
+	records := make([]*authv1.LoginRecord, n)
+	for i := range records {
+		records[i] = &authv1.LoginRecord{Timestamp: timestamppb.New(time.Now().Add(-time.Duration(n-i) * time.Minute))}
+	}
+	return records
+}
+
+func TestValidateUserArrayLimits(t *testing.T) {
+	withUserArrayLimits(t, UserArrayLimits{
+		LoginHistory:          ArrayLimit{Max: 2, Policy: ArrayLimitTrimOldest},
+		Roles:                 ArrayLimit{Max: 1, Policy: ArrayLimitReject},
+		AdditionalPermissions: ArrayLimit{Max: 2, Policy: ArrayLimitReject},
+		RevokedPermissions:    ArrayLimit{Max: 0, Policy: ArrayLimitReject},
+	})
+
+	testCases := []struct {
+		name           string
+		user           *authv1.User
+		wantErr        bool
+		expectedFields []string
+	}{
+		{
+			name: "within limits",
+			user: &authv1.User{Roles: []*authv1.UserRole{{}}, AdditionalPermissions: []string{"user:read"}},
+		},
+		{
+			name:    "trim policy field is never rejected",
+			user:    &authv1.User{LoginHistory: loginHistory(5)},
+			wantErr: false,
+		},
+		{
+			name:           "reject policy fields over limit",
+			user:           &authv1.User{Roles: []*authv1.UserRole{{}, {}}, AdditionalPermissions: []string{"a", "b", "c"}},
+			wantErr:        true,
+			expectedFields: []string{UserFieldRoles, UserFieldAdditionalPermissions},
+		},
+		{
+			name: "zero max disables the limit",
+			user: &authv1.User{RevokedPermissions: []string{"a", "b", "c"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateUserArrayLimits(tc.user)
+			if !tc.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			appErr, ok := infra_error.AsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, infra_error.ValidationTooLong.Code, appErr.Code)
+			assert.Equal(t, tc.expectedFields, appErr.Details["fields"])
+		})
+	}
+}
+
+func TestEnforceUserArrayLimits_TrimsOldestFirst(t *testing.T) {
+	withUserArrayLimits(t, UserArrayLimits{
+		LoginHistory: ArrayLimit{Max: 2, Policy: ArrayLimitTrimOldest},
+	})
+
+	history := loginHistory(4)
+	user := &authv1.User{LoginHistory: history}
+
+	trimmed := EnforceUserArrayLimits(user)
+
+	assert.Equal(t, []string{UserFieldLoginHistory}, trimmed)
+	assert.Equal(t, history[2:], user.LoginHistory)
+	assert.Empty(t, EnforceUserArrayLimits(user))
+}
+
+func TestNearLimitUserArrays(t *testing.T) {
+	withUserArrayLimits(t, UserArrayLimits{
+		LoginHistory: ArrayLimit{Max: 10, Policy: ArrayLimitTrimOldest},
+		Roles:        ArrayLimit{Max: 10, Policy: ArrayLimitReject},
+	})
+
+	user := &authv1.User{LoginHistory: loginHistory(9), Roles: []*authv1.UserRole{{}}}
+	assert.Equal(t, []string{UserFieldLoginHistory}, NearLimitUserArrays(user))
+}
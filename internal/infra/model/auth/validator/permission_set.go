@@ -0,0 +1,10 @@
+package validator
+
+import (
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+func ValidatePermissionSet(s *authv1.PermissionSet, createOperation bool) error {
+	return validation.Struct(s, !createOperation)
+}
@@ -33,6 +33,29 @@ func ValidateRefreshToken(r *authv1_cache.RefreshToken) error {
 	return nil
 }
 
+func ValidateRefreshTokenFamily(f *authv1_cache.RefreshTokenFamily) error {
+	missingFields := []string{}
+	if f.FamilyId == "" {
+		missingFields = append(missingFields, "FamilyID")
+	}
+	if f.TenantId == "" {
+		missingFields = append(missingFields, "TenantID")
+	}
+	if f.UserId == "" {
+		missingFields = append(missingFields, "UserID")
+	}
+	if f.CurrentTokenId == "" {
+		missingFields = append(missingFields, "CurrentTokenID")
+	}
+	if f.ExpiresAt.AsTime().IsZero() {
+		missingFields = append(missingFields, "ExpiresAt")
+	}
+	if len(missingFields) > 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	}
+	return nil
+}
+
 // IsValid - Check if refresh token is still valid
 func IsValidRefreshToken(r *authv1_cache.RefreshToken) bool {
 	return !r.Revoked && !IsExpired(r)
@@ -2,30 +2,59 @@ package validator
 
 import (
 	infra_error "erp.localhost/internal/infra/error"
+	model_auth "erp.localhost/internal/infra/model/auth"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+const (
+	maxPasswordPolicyLength = 128
+	maxPasswordHistoryDepth = 24
 )
 
 func ValidateTenant(t *authv1.Tenant, createOperation bool) error {
-	missingFields := []string{}
-	if !createOperation {
-		if t.Id == "" {
-			missingFields = append(missingFields, "Id")
+	violations, err := validation.Violations(t, !createOperation)
+	if err != nil {
+		return err
+	}
+	if t.GetContact().GetEmail() == "" {
+		violations = append(violations, validation.Violation{Field: "EMail", Def: infra_error.ValidationRequiredFields, Message: "is required"})
+	}
+	if err := validation.Error(violations); err != nil {
+		return err
+	}
+	invalidFields := []string{}
+	for _, field := range t.GetSettings().GetEventuallyRequiredProfileFields() {
+		if !model_auth.IsValidProfileField(field) {
+			invalidFields = append(invalidFields, field)
 		}
 	}
-	if t.Name == "" {
-		missingFields = append(missingFields, "Name")
+	if len(invalidFields) > 0 {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, invalidFields...)
 	}
-	if t.CreatedBy == "" {
-		missingFields = append(missingFields, "CreatedBy")
+	return ValidatePasswordPolicy(t.GetSettings().GetPasswordPolicy())
+}
+
+// ValidatePasswordPolicy checks the policy values are in range, a nil policy is valid
+func ValidatePasswordPolicy(policy *authv1.PasswordPolicy) error {
+	if policy == nil {
+		return nil
 	}
-	if t.Status == authv1.TenantStatus_TENANT_STATUS_UNSPECIFIED {
-		missingFields = append(missingFields, "Status")
+	outOfRange := []string{}
+	if policy.GetMinLength() < 0 || policy.GetMinLength() > maxPasswordPolicyLength {
+		outOfRange = append(outOfRange, "password_policy.min_length")
 	}
-	if t.GetContact().GetEmail() == "" {
-		missingFields = append(missingFields, "EMail")
+	if policy.GetHistoryDepth() < 0 || policy.GetHistoryDepth() > maxPasswordHistoryDepth {
+		outOfRange = append(outOfRange, "password_policy.history_depth")
+	}
+	if policy.GetMaxAgeDays() < 0 {
+		outOfRange = append(outOfRange, "password_policy.max_age_days")
+	}
+	if policy.GetMinEntropyBits() < 0 {
+		outOfRange = append(outOfRange, "password_policy.min_entropy_bits")
 	}
-	if len(missingFields) > 0 {
-		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	if len(outOfRange) > 0 {
+		return infra_error.Validation(infra_error.ValidationOutOfRange, outOfRange...)
 	}
 	return nil
 }
@@ -6,12 +6,10 @@ import (
 
 	infra_error "erp.localhost/internal/infra/error"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/validation"
 )
 
 var (
-	// Email validation regex (basic RFC 5322 validation)
-	emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
-
 	// Username validation: 3-50 characters, alphanumeric, underscore, hyphen, dot
 	usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9._\-]{3,50}$`)
 
@@ -20,60 +18,22 @@ var (
 )
 
 func ValidateUser(u *authv1.User, createOperation bool) error {
-	missingFields := []string{}
-	if !createOperation {
-		if u.Id == "" {
-			missingFields = append(missingFields, "Id")
-		}
-	}
-	if u.TenantId == "" {
-		missingFields = append(missingFields, "TenantId")
+	violations, err := validation.Violations(u, !createOperation)
+	if err != nil {
+		return err
 	}
 	if (u.Email == "" || !IsValidEmail(u.Email)) && (u.Username == "" || !IsValidUsername(u.Username)) {
-		missingFields = append(missingFields, "Email or Username")
-	}
-	if u.PasswordHash == "" {
-		missingFields = append(missingFields, "PasswordHash")
-	}
-	if u.CreatedBy == "" {
-		missingFields = append(missingFields, "CreatedBy")
-	}
-	if u.Status == authv1.UserStatus_USER_STATUS_UNSPECIFIED {
-		missingFields = append(missingFields, "Status")
-	}
-	if len(u.Roles) > 0 {
-		for _, role := range u.Roles {
-			if err := ValidateUserRole(role); err != nil {
-				missingFields = append(missingFields, "Roles")
-				break
-			}
-		}
+		violations = append(violations, validation.Violation{Field: "Email or Username", Def: infra_error.ValidationRequiredFields, Message: "is required"})
 	}
-	if len(missingFields) > 0 {
-		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
+	if err := validation.Error(violations); err != nil {
+		return err
 	}
 
-	return nil
+	return ValidateUserArrayLimits(u)
 }
 
 func ValidateUserRole(u *authv1.UserRole) error {
-	missingFields := []string{}
-
-	if u.RoleId == "" {
-		missingFields = append(missingFields, "Id")
-	}
-	if u.TenantId == "" {
-		missingFields = append(missingFields, "TenantId")
-	}
-	if u.AssignedBy == "" {
-		missingFields = append(missingFields, "AssignedBy")
-	}
-
-	if len(missingFields) > 0 {
-		return infra_error.Validation(infra_error.ValidationRequiredFields, missingFields...)
-	}
-
-	return nil
+	return validation.Struct(u, false)
 }
 
 func ValidateUserProfile(profile *authv1.UserProfile) error {
@@ -87,23 +47,7 @@ func ValidateUserProfile(profile *authv1.UserProfile) error {
 	}
 
 	// Validate field lengths
-	if len(profile.FirstName) > 100 {
-		return infra_error.Validation(infra_error.ValidationTooLong, "profile.first_name")
-	}
-	if len(profile.LastName) > 100 {
-		return infra_error.Validation(infra_error.ValidationTooLong, "profile.last_name")
-	}
-	if len(profile.DisplayName) > 200 {
-		return infra_error.Validation(infra_error.ValidationTooLong, "profile.display_name")
-	}
-	if len(profile.Title) > 100 {
-		return infra_error.Validation(infra_error.ValidationTooLong, "profile.title")
-	}
-	if len(profile.Department) > 100 {
-		return infra_error.Validation(infra_error.ValidationTooLong, "profile.department")
-	}
-
-	return nil
+	return validation.Struct(profile, false)
 }
 
 func ValidateUserPreferences(preferences *authv1.UserPreferences) error {
@@ -111,14 +55,9 @@ func ValidateUserPreferences(preferences *authv1.UserPreferences) error {
 		return nil // Preferences are optional
 	}
 
-	// Validate timezone format (basic validation)
-	if preferences.Timezone != "" && len(preferences.Timezone) > 100 {
-		return infra_error.Validation(infra_error.ValidationTooLong, "preferences.timezone")
-	}
-
-	// Validate language code (basic validation)
-	if preferences.Language != "" && len(preferences.Language) > 10 {
-		return infra_error.Validation(infra_error.ValidationTooLong, "preferences.language")
+	// Validate timezone and language code lengths (basic validation)
+	if err := validation.Struct(preferences, false); err != nil {
+		return err
 	}
 
 	// Validate theme
@@ -136,11 +75,7 @@ func IsValidEmail(email string) bool {
 	if email == "" {
 		return false
 	}
-	email = strings.TrimSpace(email)
-	if len(email) > 254 { // RFC 5321
-		return false
-	}
-	return emailRegex.MatchString(email)
+	return validation.IsEmail(email)
 }
 
 func IsValidUsername(username string) bool {
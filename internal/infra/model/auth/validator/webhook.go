@@ -0,0 +1,29 @@
+package validator
+
+import (
+	"net/url"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+func ValidateWebhook(w *authv1.Webhook, createOperation bool) error {
+	if err := validation.Struct(w, !createOperation); err != nil {
+		return err
+	}
+	return ValidateWebhookURL(w.Url)
+}
+
+// ValidateWebhookURL returns an error unless rawURL is an absolute http or https URL
+func ValidateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "Url")
+	}
+	return nil
+}
+
+func ValidateWebhookDelivery(d *authv1.WebhookDelivery, createOperation bool) error {
+	return validation.Struct(d, !createOperation)
+}
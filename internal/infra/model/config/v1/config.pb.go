@@ -83,8 +83,10 @@ func (x *ConfigRequest) GetModule() string {
 }
 
 type ConfigResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          *structpb.Struct       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  *structpb.Struct       `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Version of the returned config, 0 when the module has no config for the tenant
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -126,26 +128,38 @@ func (x *ConfigResponse) GetData() *structpb.Struct {
 	return nil
 }
 
-type EnvRequest struct {
+func (x *ConfigResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Stores a new version of the module config for the tenant, replacing the previous one
+type SetConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Module        string                 `protobuf:"bytes,3,opt,name=module,proto3" json:"module,omitempty"`
+	Data          *structpb.Struct       `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnvRequest) Reset() {
-	*x = EnvRequest{}
+func (x *SetConfigRequest) Reset() {
+	*x = SetConfigRequest{}
 	mi := &file_config_v1_config_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnvRequest) String() string {
+func (x *SetConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnvRequest) ProtoMessage() {}
+func (*SetConfigRequest) ProtoMessage() {}
 
-func (x *EnvRequest) ProtoReflect() protoreflect.Message {
+func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_config_v1_config_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -157,31 +171,65 @@ func (x *EnvRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnvRequest.ProtoReflect.Descriptor instead.
-func (*EnvRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetConfigRequest) Descriptor() ([]byte, []int) {
 	return file_config_v1_config_proto_rawDescGZIP(), []int{2}
 }
 
-type EnvResponse struct {
+func (x *SetConfigRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SetConfigRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetConfigRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *SetConfigRequest) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Identifies a config entry: system entries have neither tenant_id nor module, module entries have only module,
+// tenant entries have tenant_id and optionally module
+type ConfigEntryRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Scope         ConfigScope            `protobuf:"varint,2,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Module        string                 `protobuf:"bytes,4,opt,name=module,proto3" json:"module,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnvResponse) Reset() {
-	*x = EnvResponse{}
+func (x *ConfigEntryRef) Reset() {
+	*x = ConfigEntryRef{}
 	mi := &file_config_v1_config_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnvResponse) String() string {
+func (x *ConfigEntryRef) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnvResponse) ProtoMessage() {}
+func (*ConfigEntryRef) ProtoMessage() {}
 
-func (x *EnvResponse) ProtoReflect() protoreflect.Message {
+func (x *ConfigEntryRef) ProtoReflect() protoreflect.Message {
 	mi := &file_config_v1_config_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -193,31 +241,63 @@ func (x *EnvResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnvResponse.ProtoReflect.Descriptor instead.
-func (*EnvResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigEntryRef.ProtoReflect.Descriptor instead.
+func (*ConfigEntryRef) Descriptor() ([]byte, []int) {
 	return file_config_v1_config_proto_rawDescGZIP(), []int{3}
 }
 
-type FeatureFlagRequest struct {
+func (x *ConfigEntryRef) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigEntryRef) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *ConfigEntryRef) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ConfigEntryRef) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+type GetConfigEntryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Scope         ConfigScope            `protobuf:"varint,2,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Module        string                 `protobuf:"bytes,4,opt,name=module,proto3" json:"module,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FeatureFlagRequest) Reset() {
-	*x = FeatureFlagRequest{}
+func (x *GetConfigEntryRequest) Reset() {
+	*x = GetConfigEntryRequest{}
 	mi := &file_config_v1_config_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FeatureFlagRequest) String() string {
+func (x *GetConfigEntryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FeatureFlagRequest) ProtoMessage() {}
+func (*GetConfigEntryRequest) ProtoMessage() {}
 
-func (x *FeatureFlagRequest) ProtoReflect() protoreflect.Message {
+func (x *GetConfigEntryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_config_v1_config_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -229,31 +309,66 @@ func (x *FeatureFlagRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FeatureFlagRequest.ProtoReflect.Descriptor instead.
-func (*FeatureFlagRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetConfigEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigEntryRequest) Descriptor() ([]byte, []int) {
 	return file_config_v1_config_proto_rawDescGZIP(), []int{4}
 }
 
-type FeatureFlagResponse struct {
+func (x *GetConfigEntryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetConfigEntryRequest) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *GetConfigEntryRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetConfigEntryRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+// Stores value as the next version of the entry, the value must match the schema registered for the key
+type SetConfigEntryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Scope         ConfigScope            `protobuf:"varint,2,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Module        string                 `protobuf:"bytes,4,opt,name=module,proto3" json:"module,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	UserId        string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FeatureFlagResponse) Reset() {
-	*x = FeatureFlagResponse{}
+func (x *SetConfigEntryRequest) Reset() {
+	*x = SetConfigEntryRequest{}
 	mi := &file_config_v1_config_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FeatureFlagResponse) String() string {
+func (x *SetConfigEntryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FeatureFlagResponse) ProtoMessage() {}
+func (*SetConfigEntryRequest) ProtoMessage() {}
 
-func (x *FeatureFlagResponse) ProtoReflect() protoreflect.Message {
+func (x *SetConfigEntryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_config_v1_config_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -265,31 +380,733 @@ func (x *FeatureFlagResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FeatureFlagResponse.ProtoReflect.Descriptor instead.
-func (*FeatureFlagResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetConfigEntryRequest.ProtoReflect.Descriptor instead.
+func (*SetConfigEntryRequest) Descriptor() ([]byte, []int) {
 	return file_config_v1_config_proto_rawDescGZIP(), []int{5}
 }
 
-var File_config_v1_config_proto protoreflect.FileDescriptor
+func (x *SetConfigEntryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
 
-const file_config_v1_config_proto_rawDesc = "" +
-	"\n" +
-	"\x16config/v1/config.proto\x12\tconfig.v1\x1a\x1cgoogle/protobuf/struct.proto\"]\n" +
-	"\rConfigRequest\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06module\x18\x03 \x01(\tR\x06module\"=\n" +
-	"\x0eConfigResponse\x12+\n" +
-	"\x04data\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x04data\"\f\n" +
+func (x *SetConfigEntryRequest) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *SetConfigEntryRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SetConfigEntryRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *SetConfigEntryRequest) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SetConfigEntryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ConfigEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *ConfigEntry           `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigEntryResponse) Reset() {
+	*x = ConfigEntryResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigEntryResponse) ProtoMessage() {}
+
+func (x *ConfigEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigEntryResponse.ProtoReflect.Descriptor instead.
+func (*ConfigEntryResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConfigEntryResponse) GetEntry() *ConfigEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// Lists the entries of the scope, narrowed to a tenant, a module and a key prefix when set
+type ListConfigEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scope         ConfigScope            `protobuf:"varint,1,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Module        string                 `protobuf:"bytes,3,opt,name=module,proto3" json:"module,omitempty"`
+	KeyPrefix     string                 `protobuf:"bytes,4,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigEntriesRequest) Reset() {
+	*x = ListConfigEntriesRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigEntriesRequest) ProtoMessage() {}
+
+func (x *ListConfigEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListConfigEntriesRequest) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *ListConfigEntriesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListConfigEntriesRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ListConfigEntriesRequest) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+type ListConfigEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*ConfigEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigEntriesResponse) Reset() {
+	*x = ListConfigEntriesResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigEntriesResponse) ProtoMessage() {}
+
+func (x *ListConfigEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListConfigEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListConfigEntriesResponse) GetEntries() []*ConfigEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type DeleteConfigEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Scope         ConfigScope            `protobuf:"varint,2,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope,omitempty"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Module        string                 `protobuf:"bytes,4,opt,name=module,proto3" json:"module,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigEntryRequest) Reset() {
+	*x = DeleteConfigEntryRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigEntryRequest) ProtoMessage() {}
+
+func (x *DeleteConfigEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigEntryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConfigEntryRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteConfigEntryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DeleteConfigEntryRequest) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *DeleteConfigEntryRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DeleteConfigEntryRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *DeleteConfigEntryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteConfigEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigEntryResponse) Reset() {
+	*x = DeleteConfigEntryResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigEntryResponse) ProtoMessage() {}
+
+func (x *DeleteConfigEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigEntryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteConfigEntryResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{10}
+}
+
+// Lists the changes of an entry, newest first
+type GetConfigHistoryRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Key      string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Scope    ConfigScope            `protobuf:"varint,2,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope,omitempty"`
+	TenantId string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Module   string                 `protobuf:"bytes,4,opt,name=module,proto3" json:"module,omitempty"`
+	// Maximum number of changes returned, all when 0
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigHistoryRequest) Reset() {
+	*x = GetConfigHistoryRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigHistoryRequest) ProtoMessage() {}
+
+func (x *GetConfigHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetConfigHistoryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetConfigHistoryRequest) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *GetConfigHistoryRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetConfigHistoryRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *GetConfigHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetConfigHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*ConfigEntryChange   `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigHistoryResponse) Reset() {
+	*x = GetConfigHistoryResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigHistoryResponse) ProtoMessage() {}
+
+func (x *GetConfigHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetConfigHistoryResponse) GetChanges() []*ConfigEntryChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Subscribes to the entries of the system, the module and the tenant, narrowed to keys starting with one of
+// key_prefixes when set
+type WatchConfigRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	KeyPrefixes []string               `protobuf:"bytes,1,rep,name=key_prefixes,json=keyPrefixes,proto3" json:"key_prefixes,omitempty"`
+	// Module entries of other modules are not sent when set, nor tenant entries of other modules
+	Module   string `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	TenantId string `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Sends the entries of every tenant, for services caching the config of all their tenants
+	AllTenants    bool `protobuf:"varint,4,opt,name=all_tenants,json=allTenants,proto3" json:"all_tenants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchConfigRequest) Reset() {
+	*x = WatchConfigRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchConfigRequest) ProtoMessage() {}
+
+func (x *WatchConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchConfigRequest.ProtoReflect.Descriptor instead.
+func (*WatchConfigRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchConfigRequest) GetKeyPrefixes() []string {
+	if x != nil {
+		return x.KeyPrefixes
+	}
+	return nil
+}
+
+func (x *WatchConfigRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *WatchConfigRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *WatchConfigRequest) GetAllTenants() bool {
+	if x != nil {
+		return x.AllTenants
+	}
+	return false
+}
+
+// ConfigEvent is a message of the WatchConfig stream. The stream starts with the watched entries as SET events,
+// followed by an event with synced set and no entry, then every change of the watched entries
+type ConfigEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  ConfigChangeType       `protobuf:"varint,1,opt,name=type,proto3,enum=config.v1.ConfigChangeType" json:"type,omitempty"`
+	// The entry after the change, the deleted entry for deletes
+	Entry         *ConfigEntry `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	Synced        bool         `protobuf:"varint,3,opt,name=synced,proto3" json:"synced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigEvent) Reset() {
+	*x = ConfigEvent{}
+	mi := &file_config_v1_config_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigEvent) ProtoMessage() {}
+
+func (x *ConfigEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigEvent.ProtoReflect.Descriptor instead.
+func (*ConfigEvent) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConfigEvent) GetType() ConfigChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ConfigChangeType_CONFIG_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ConfigEvent) GetEntry() *ConfigEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *ConfigEvent) GetSynced() bool {
+	if x != nil {
+		return x.Synced
+	}
+	return false
+}
+
+type EnvRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvRequest) Reset() {
+	*x = EnvRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvRequest) ProtoMessage() {}
+
+func (x *EnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvRequest.ProtoReflect.Descriptor instead.
+func (*EnvRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{15}
+}
+
+type EnvResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvResponse) Reset() {
+	*x = EnvResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvResponse) ProtoMessage() {}
+
+func (x *EnvResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvResponse.ProtoReflect.Descriptor instead.
+func (*EnvResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{16}
+}
+
+var File_config_v1_config_proto protoreflect.FileDescriptor
+
+const file_config_v1_config_proto_rawDesc = "" +
+	"\n" +
+	"\x16config/v1/config.proto\x12\tconfig.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1cconfig/v1/config_entry.proto\"]\n" +
+	"\rConfigRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06module\x18\x03 \x01(\tR\x06module\"W\n" +
+	"\x0eConfigResponse\x12+\n" +
+	"\x04data\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x04data\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x8d\x01\n" +
+	"\x10SetConfigRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06module\x18\x03 \x01(\tR\x06module\x12+\n" +
+	"\x04data\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x04data\"\x85\x01\n" +
+	"\x0eConfigEntryRef\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x16.config.v1.ConfigScopeR\x05scope\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06module\x18\x04 \x01(\tR\x06module\"\x8c\x01\n" +
+	"\x15GetConfigEntryRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x16.config.v1.ConfigScopeR\x05scope\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06module\x18\x04 \x01(\tR\x06module\"\xd3\x01\n" +
+	"\x15SetConfigEntryRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x16.config.v1.ConfigScopeR\x05scope\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06module\x18\x04 \x01(\tR\x06module\x12,\n" +
+	"\x05value\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\x05value\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\"C\n" +
+	"\x13ConfigEntryResponse\x12,\n" +
+	"\x05entry\x18\x01 \x01(\v2\x16.config.v1.ConfigEntryR\x05entry\"\x9c\x01\n" +
+	"\x18ListConfigEntriesRequest\x12,\n" +
+	"\x05scope\x18\x01 \x01(\x0e2\x16.config.v1.ConfigScopeR\x05scope\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06module\x18\x03 \x01(\tR\x06module\x12\x1d\n" +
+	"\n" +
+	"key_prefix\x18\x04 \x01(\tR\tkeyPrefix\"M\n" +
+	"\x19ListConfigEntriesResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.config.v1.ConfigEntryR\aentries\"\xa8\x01\n" +
+	"\x18DeleteConfigEntryRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x16.config.v1.ConfigScopeR\x05scope\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06module\x18\x04 \x01(\tR\x06module\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\"\x1b\n" +
+	"\x19DeleteConfigEntryResponse\"\xa4\x01\n" +
+	"\x17GetConfigHistoryRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x16.config.v1.ConfigScopeR\x05scope\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x16\n" +
+	"\x06module\x18\x04 \x01(\tR\x06module\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"R\n" +
+	"\x18GetConfigHistoryResponse\x126\n" +
+	"\achanges\x18\x01 \x03(\v2\x1c.config.v1.ConfigEntryChangeR\achanges\"\x8d\x01\n" +
+	"\x12WatchConfigRequest\x12!\n" +
+	"\fkey_prefixes\x18\x01 \x03(\tR\vkeyPrefixes\x12\x16\n" +
+	"\x06module\x18\x02 \x01(\tR\x06module\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x1f\n" +
+	"\vall_tenants\x18\x04 \x01(\bR\n" +
+	"allTenants\"\x84\x01\n" +
+	"\vConfigEvent\x12/\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1b.config.v1.ConfigChangeTypeR\x04type\x12,\n" +
+	"\x05entry\x18\x02 \x01(\v2\x16.config.v1.ConfigEntryR\x05entry\x12\x16\n" +
+	"\x06synced\x18\x03 \x01(\bR\x06synced\"\f\n" +
 	"\n" +
 	"EnvRequest\"\r\n" +
-	"\vEnvResponse\"\x14\n" +
-	"\x12FeatureFlagRequest\"\x15\n" +
-	"\x13FeatureFlagResponse2\xdb\x01\n" +
+	"\vEnvResponse2\xdc\x05\n" +
 	"\rConfigService\x12@\n" +
-	"\tGetConfig\x12\x18.config.v1.ConfigRequest\x1a\x19.config.v1.ConfigResponse\x127\n" +
-	"\x06GetEnv\x12\x15.config.v1.EnvRequest\x1a\x16.config.v1.EnvResponse\x12O\n" +
-	"\x0eSetFeatureFlag\x12\x1d.config.v1.FeatureFlagRequest\x1a\x1e.config.v1.FeatureFlagResponseB7Z5erp.localhost/internal/infra/model/config/v1;configv1b\x06proto3"
+	"\tGetConfig\x12\x18.config.v1.ConfigRequest\x1a\x19.config.v1.ConfigResponse\x12C\n" +
+	"\tSetConfig\x12\x1b.config.v1.SetConfigRequest\x1a\x19.config.v1.ConfigResponse\x12R\n" +
+	"\x0eGetConfigEntry\x12 .config.v1.GetConfigEntryRequest\x1a\x1e.config.v1.ConfigEntryResponse\x12R\n" +
+	"\x0eSetConfigEntry\x12 .config.v1.SetConfigEntryRequest\x1a\x1e.config.v1.ConfigEntryResponse\x12^\n" +
+	"\x11ListConfigEntries\x12#.config.v1.ListConfigEntriesRequest\x1a$.config.v1.ListConfigEntriesResponse\x12^\n" +
+	"\x11DeleteConfigEntry\x12#.config.v1.DeleteConfigEntryRequest\x1a$.config.v1.DeleteConfigEntryResponse\x12[\n" +
+	"\x10GetConfigHistory\x12\".config.v1.GetConfigHistoryRequest\x1a#.config.v1.GetConfigHistoryResponse\x12F\n" +
+	"\vWatchConfig\x12\x1d.config.v1.WatchConfigRequest\x1a\x16.config.v1.ConfigEvent0\x01\x127\n" +
+	"\x06GetEnv\x12\x15.config.v1.EnvRequest\x1a\x16.config.v1.EnvResponseB7Z5erp.localhost/internal/infra/model/config/v1;configv1b\x06proto3"
 
 var (
 	file_config_v1_config_proto_rawDescOnce sync.Once
@@ -303,29 +1120,70 @@ func file_config_v1_config_proto_rawDescGZIP() []byte {
 	return file_config_v1_config_proto_rawDescData
 }
 
-var file_config_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_config_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_config_v1_config_proto_goTypes = []any{
-	(*ConfigRequest)(nil),       // 0: config.v1.ConfigRequest
-	(*ConfigResponse)(nil),      // 1: config.v1.ConfigResponse
-	(*EnvRequest)(nil),          // 2: config.v1.EnvRequest
-	(*EnvResponse)(nil),         // 3: config.v1.EnvResponse
-	(*FeatureFlagRequest)(nil),  // 4: config.v1.FeatureFlagRequest
-	(*FeatureFlagResponse)(nil), // 5: config.v1.FeatureFlagResponse
-	(*structpb.Struct)(nil),     // 6: google.protobuf.Struct
+	(*ConfigRequest)(nil),             // 0: config.v1.ConfigRequest
+	(*ConfigResponse)(nil),            // 1: config.v1.ConfigResponse
+	(*SetConfigRequest)(nil),          // 2: config.v1.SetConfigRequest
+	(*ConfigEntryRef)(nil),            // 3: config.v1.ConfigEntryRef
+	(*GetConfigEntryRequest)(nil),     // 4: config.v1.GetConfigEntryRequest
+	(*SetConfigEntryRequest)(nil),     // 5: config.v1.SetConfigEntryRequest
+	(*ConfigEntryResponse)(nil),       // 6: config.v1.ConfigEntryResponse
+	(*ListConfigEntriesRequest)(nil),  // 7: config.v1.ListConfigEntriesRequest
+	(*ListConfigEntriesResponse)(nil), // 8: config.v1.ListConfigEntriesResponse
+	(*DeleteConfigEntryRequest)(nil),  // 9: config.v1.DeleteConfigEntryRequest
+	(*DeleteConfigEntryResponse)(nil), // 10: config.v1.DeleteConfigEntryResponse
+	(*GetConfigHistoryRequest)(nil),   // 11: config.v1.GetConfigHistoryRequest
+	(*GetConfigHistoryResponse)(nil),  // 12: config.v1.GetConfigHistoryResponse
+	(*WatchConfigRequest)(nil),        // 13: config.v1.WatchConfigRequest
+	(*ConfigEvent)(nil),               // 14: config.v1.ConfigEvent
+	(*EnvRequest)(nil),                // 15: config.v1.EnvRequest
+	(*EnvResponse)(nil),               // 16: config.v1.EnvResponse
+	(*structpb.Struct)(nil),           // 17: google.protobuf.Struct
+	(ConfigScope)(0),                  // 18: config.v1.ConfigScope
+	(*structpb.Value)(nil),            // 19: google.protobuf.Value
+	(*ConfigEntry)(nil),               // 20: config.v1.ConfigEntry
+	(*ConfigEntryChange)(nil),         // 21: config.v1.ConfigEntryChange
+	(ConfigChangeType)(0),             // 22: config.v1.ConfigChangeType
 }
 var file_config_v1_config_proto_depIdxs = []int32{
-	6, // 0: config.v1.ConfigResponse.data:type_name -> google.protobuf.Struct
-	0, // 1: config.v1.ConfigService.GetConfig:input_type -> config.v1.ConfigRequest
-	2, // 2: config.v1.ConfigService.GetEnv:input_type -> config.v1.EnvRequest
-	4, // 3: config.v1.ConfigService.SetFeatureFlag:input_type -> config.v1.FeatureFlagRequest
-	1, // 4: config.v1.ConfigService.GetConfig:output_type -> config.v1.ConfigResponse
-	3, // 5: config.v1.ConfigService.GetEnv:output_type -> config.v1.EnvResponse
-	5, // 6: config.v1.ConfigService.SetFeatureFlag:output_type -> config.v1.FeatureFlagResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	17, // 0: config.v1.ConfigResponse.data:type_name -> google.protobuf.Struct
+	17, // 1: config.v1.SetConfigRequest.data:type_name -> google.protobuf.Struct
+	18, // 2: config.v1.ConfigEntryRef.scope:type_name -> config.v1.ConfigScope
+	18, // 3: config.v1.GetConfigEntryRequest.scope:type_name -> config.v1.ConfigScope
+	18, // 4: config.v1.SetConfigEntryRequest.scope:type_name -> config.v1.ConfigScope
+	19, // 5: config.v1.SetConfigEntryRequest.value:type_name -> google.protobuf.Value
+	20, // 6: config.v1.ConfigEntryResponse.entry:type_name -> config.v1.ConfigEntry
+	18, // 7: config.v1.ListConfigEntriesRequest.scope:type_name -> config.v1.ConfigScope
+	20, // 8: config.v1.ListConfigEntriesResponse.entries:type_name -> config.v1.ConfigEntry
+	18, // 9: config.v1.DeleteConfigEntryRequest.scope:type_name -> config.v1.ConfigScope
+	18, // 10: config.v1.GetConfigHistoryRequest.scope:type_name -> config.v1.ConfigScope
+	21, // 11: config.v1.GetConfigHistoryResponse.changes:type_name -> config.v1.ConfigEntryChange
+	22, // 12: config.v1.ConfigEvent.type:type_name -> config.v1.ConfigChangeType
+	20, // 13: config.v1.ConfigEvent.entry:type_name -> config.v1.ConfigEntry
+	0,  // 14: config.v1.ConfigService.GetConfig:input_type -> config.v1.ConfigRequest
+	2,  // 15: config.v1.ConfigService.SetConfig:input_type -> config.v1.SetConfigRequest
+	4,  // 16: config.v1.ConfigService.GetConfigEntry:input_type -> config.v1.GetConfigEntryRequest
+	5,  // 17: config.v1.ConfigService.SetConfigEntry:input_type -> config.v1.SetConfigEntryRequest
+	7,  // 18: config.v1.ConfigService.ListConfigEntries:input_type -> config.v1.ListConfigEntriesRequest
+	9,  // 19: config.v1.ConfigService.DeleteConfigEntry:input_type -> config.v1.DeleteConfigEntryRequest
+	11, // 20: config.v1.ConfigService.GetConfigHistory:input_type -> config.v1.GetConfigHistoryRequest
+	13, // 21: config.v1.ConfigService.WatchConfig:input_type -> config.v1.WatchConfigRequest
+	15, // 22: config.v1.ConfigService.GetEnv:input_type -> config.v1.EnvRequest
+	1,  // 23: config.v1.ConfigService.GetConfig:output_type -> config.v1.ConfigResponse
+	1,  // 24: config.v1.ConfigService.SetConfig:output_type -> config.v1.ConfigResponse
+	6,  // 25: config.v1.ConfigService.GetConfigEntry:output_type -> config.v1.ConfigEntryResponse
+	6,  // 26: config.v1.ConfigService.SetConfigEntry:output_type -> config.v1.ConfigEntryResponse
+	8,  // 27: config.v1.ConfigService.ListConfigEntries:output_type -> config.v1.ListConfigEntriesResponse
+	10, // 28: config.v1.ConfigService.DeleteConfigEntry:output_type -> config.v1.DeleteConfigEntryResponse
+	12, // 29: config.v1.ConfigService.GetConfigHistory:output_type -> config.v1.GetConfigHistoryResponse
+	14, // 30: config.v1.ConfigService.WatchConfig:output_type -> config.v1.ConfigEvent
+	16, // 31: config.v1.ConfigService.GetEnv:output_type -> config.v1.EnvResponse
+	23, // [23:32] is the sub-list for method output_type
+	14, // [14:23] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_config_v1_config_proto_init() }
@@ -333,13 +1191,14 @@ func file_config_v1_config_proto_init() {
 	if File_config_v1_config_proto != nil {
 		return
 	}
+	file_config_v1_config_entry_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_v1_config_proto_rawDesc), len(file_config_v1_config_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,569 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: config/v1/config_entry.proto
+
+package configv1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConfigScope is the level a config entry applies to
+type ConfigScope int32
+
+const (
+	ConfigScope_CONFIG_SCOPE_UNSPECIFIED ConfigScope = 0
+	// Applies to every tenant and module
+	ConfigScope_CONFIG_SCOPE_SYSTEM ConfigScope = 1
+	// Applies to a tenant, optionally within a single module
+	ConfigScope_CONFIG_SCOPE_TENANT ConfigScope = 2
+	// Applies to a module for every tenant
+	ConfigScope_CONFIG_SCOPE_MODULE ConfigScope = 3
+)
+
+// Enum value maps for ConfigScope.
+var (
+	ConfigScope_name = map[int32]string{
+		0: "CONFIG_SCOPE_UNSPECIFIED",
+		1: "CONFIG_SCOPE_SYSTEM",
+		2: "CONFIG_SCOPE_TENANT",
+		3: "CONFIG_SCOPE_MODULE",
+	}
+	ConfigScope_value = map[string]int32{
+		"CONFIG_SCOPE_UNSPECIFIED": 0,
+		"CONFIG_SCOPE_SYSTEM":      1,
+		"CONFIG_SCOPE_TENANT":      2,
+		"CONFIG_SCOPE_MODULE":      3,
+	}
+)
+
+func (x ConfigScope) Enum() *ConfigScope {
+	p := new(ConfigScope)
+	*p = x
+	return p
+}
+
+func (x ConfigScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_config_v1_config_entry_proto_enumTypes[0].Descriptor()
+}
+
+func (ConfigScope) Type() protoreflect.EnumType {
+	return &file_config_v1_config_entry_proto_enumTypes[0]
+}
+
+func (x ConfigScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigScope.Descriptor instead.
+func (ConfigScope) EnumDescriptor() ([]byte, []int) {
+	return file_config_v1_config_entry_proto_rawDescGZIP(), []int{0}
+}
+
+// ConfigValueType is the type of the value of a config entry, set by its registered schema
+type ConfigValueType int32
+
+const (
+	ConfigValueType_CONFIG_VALUE_TYPE_UNSPECIFIED ConfigValueType = 0
+	ConfigValueType_CONFIG_VALUE_TYPE_STRING      ConfigValueType = 1
+	ConfigValueType_CONFIG_VALUE_TYPE_INT         ConfigValueType = 2
+	ConfigValueType_CONFIG_VALUE_TYPE_FLOAT       ConfigValueType = 3
+	ConfigValueType_CONFIG_VALUE_TYPE_BOOL        ConfigValueType = 4
+	// Go duration string, e.g. 15m
+	ConfigValueType_CONFIG_VALUE_TYPE_DURATION ConfigValueType = 5
+	// Free-form object or list
+	ConfigValueType_CONFIG_VALUE_TYPE_JSON ConfigValueType = 6
+)
+
+// Enum value maps for ConfigValueType.
+var (
+	ConfigValueType_name = map[int32]string{
+		0: "CONFIG_VALUE_TYPE_UNSPECIFIED",
+		1: "CONFIG_VALUE_TYPE_STRING",
+		2: "CONFIG_VALUE_TYPE_INT",
+		3: "CONFIG_VALUE_TYPE_FLOAT",
+		4: "CONFIG_VALUE_TYPE_BOOL",
+		5: "CONFIG_VALUE_TYPE_DURATION",
+		6: "CONFIG_VALUE_TYPE_JSON",
+	}
+	ConfigValueType_value = map[string]int32{
+		"CONFIG_VALUE_TYPE_UNSPECIFIED": 0,
+		"CONFIG_VALUE_TYPE_STRING":      1,
+		"CONFIG_VALUE_TYPE_INT":         2,
+		"CONFIG_VALUE_TYPE_FLOAT":       3,
+		"CONFIG_VALUE_TYPE_BOOL":        4,
+		"CONFIG_VALUE_TYPE_DURATION":    5,
+		"CONFIG_VALUE_TYPE_JSON":        6,
+	}
+)
+
+func (x ConfigValueType) Enum() *ConfigValueType {
+	p := new(ConfigValueType)
+	*p = x
+	return p
+}
+
+func (x ConfigValueType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigValueType) Descriptor() protoreflect.EnumDescriptor {
+	return file_config_v1_config_entry_proto_enumTypes[1].Descriptor()
+}
+
+func (ConfigValueType) Type() protoreflect.EnumType {
+	return &file_config_v1_config_entry_proto_enumTypes[1]
+}
+
+func (x ConfigValueType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigValueType.Descriptor instead.
+func (ConfigValueType) EnumDescriptor() ([]byte, []int) {
+	return file_config_v1_config_entry_proto_rawDescGZIP(), []int{1}
+}
+
+type ConfigChangeType int32
+
+const (
+	ConfigChangeType_CONFIG_CHANGE_TYPE_UNSPECIFIED ConfigChangeType = 0
+	ConfigChangeType_CONFIG_CHANGE_TYPE_SET         ConfigChangeType = 1
+	ConfigChangeType_CONFIG_CHANGE_TYPE_DELETE      ConfigChangeType = 2
+)
+
+// Enum value maps for ConfigChangeType.
+var (
+	ConfigChangeType_name = map[int32]string{
+		0: "CONFIG_CHANGE_TYPE_UNSPECIFIED",
+		1: "CONFIG_CHANGE_TYPE_SET",
+		2: "CONFIG_CHANGE_TYPE_DELETE",
+	}
+	ConfigChangeType_value = map[string]int32{
+		"CONFIG_CHANGE_TYPE_UNSPECIFIED": 0,
+		"CONFIG_CHANGE_TYPE_SET":         1,
+		"CONFIG_CHANGE_TYPE_DELETE":      2,
+	}
+)
+
+func (x ConfigChangeType) Enum() *ConfigChangeType {
+	p := new(ConfigChangeType)
+	*p = x
+	return p
+}
+
+func (x ConfigChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_config_v1_config_entry_proto_enumTypes[2].Descriptor()
+}
+
+func (ConfigChangeType) Type() protoreflect.EnumType {
+	return &file_config_v1_config_entry_proto_enumTypes[2]
+}
+
+func (x ConfigChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigChangeType.Descriptor instead.
+func (ConfigChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_config_v1_config_entry_proto_rawDescGZIP(), []int{2}
+}
+
+// ConfigEntry is a single typed config value
+// Stored in MongoDB config_db.config_entries collection
+type ConfigEntry struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	Key      string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key" bson:"key"`
+	Scope    ConfigScope            `protobuf:"varint,3,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope" bson:"scope"`
+	TenantId string                 `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" bson:"tenant_id"`
+	Module   string                 `protobuf:"bytes,5,opt,name=module,proto3" json:"module,omitempty" bson:"module"`
+	Type     ConfigValueType        `protobuf:"varint,6,opt,name=type,proto3,enum=config.v1.ConfigValueType" json:"type" bson:"type"`
+	Value    *structpb.Value        `protobuf:"bytes,7,opt,name=value,proto3" json:"value" bson:"value"`
+	// Incremented on every change, starts at 1
+	Version       int32                  `protobuf:"varint,8,opt,name=version,proto3" json:"version" bson:"version"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	UpdatedBy     string                 `protobuf:"bytes,11,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by" bson:"updated_by"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigEntry) Reset() {
+	*x = ConfigEntry{}
+	mi := &file_config_v1_config_entry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigEntry) ProtoMessage() {}
+
+func (x *ConfigEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_entry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigEntry.ProtoReflect.Descriptor instead.
+func (*ConfigEntry) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_entry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConfigEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConfigEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigEntry) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *ConfigEntry) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ConfigEntry) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ConfigEntry) GetType() ConfigValueType {
+	if x != nil {
+		return x.Type
+	}
+	return ConfigValueType_CONFIG_VALUE_TYPE_UNSPECIFIED
+}
+
+func (x *ConfigEntry) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ConfigEntry) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConfigEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ConfigEntry) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ConfigEntry) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+// ConfigEntryChange records a change of a config entry
+// Stored in MongoDB config_db.config_history collection
+type ConfigEntryChange struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	Key        string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key" bson:"key"`
+	Scope      ConfigScope            `protobuf:"varint,3,opt,name=scope,proto3,enum=config.v1.ConfigScope" json:"scope" bson:"scope"`
+	TenantId   string                 `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" bson:"tenant_id"`
+	Module     string                 `protobuf:"bytes,5,opt,name=module,proto3" json:"module,omitempty" bson:"module"`
+	ChangeType ConfigChangeType       `protobuf:"varint,6,opt,name=change_type,json=changeType,proto3,enum=config.v1.ConfigChangeType" json:"change_type" bson:"change_type"`
+	// Version of the entry after the change, the deleted version for deletes
+	Version       int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version" bson:"version"`
+	OldValue      *structpb.Value        `protobuf:"bytes,8,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty" bson:"old_value,omitempty"`
+	NewValue      *structpb.Value        `protobuf:"bytes,9,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty" bson:"new_value,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,10,opt,name=changed_by,json=changedBy,proto3" json:"changed_by" bson:"changed_by"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=changed_at,json=changedAt,proto3" json:"changed_at" bson:"changed_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigEntryChange) Reset() {
+	*x = ConfigEntryChange{}
+	mi := &file_config_v1_config_entry_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigEntryChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigEntryChange) ProtoMessage() {}
+
+func (x *ConfigEntryChange) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_entry_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigEntryChange.ProtoReflect.Descriptor instead.
+func (*ConfigEntryChange) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_entry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfigEntryChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConfigEntryChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigEntryChange) GetScope() ConfigScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ConfigScope_CONFIG_SCOPE_UNSPECIFIED
+}
+
+func (x *ConfigEntryChange) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ConfigEntryChange) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ConfigEntryChange) GetChangeType() ConfigChangeType {
+	if x != nil {
+		return x.ChangeType
+	}
+	return ConfigChangeType_CONFIG_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ConfigEntryChange) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ConfigEntryChange) GetOldValue() *structpb.Value {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *ConfigEntryChange) GetNewValue() *structpb.Value {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+func (x *ConfigEntryChange) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *ConfigEntryChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+var File_config_v1_config_entry_proto protoreflect.FileDescriptor
+
+const file_config_v1_config_entry_proto_rawDesc = "" +
+	"\n" +
+	"\x1cconfig/v1/config_entry.proto\x12\tconfig.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13tagger/tagger.proto\"\xbe\x06\n" +
+	"\vConfigEntry\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12,\n" +
+	"\x03key\x18\x02 \x01(\tB\x1a\x9a\x84\x9e\x03\x15bson:\"key\" json:\"key\"R\x03key\x12L\n" +
+	"\x05scope\x18\x03 \x01(\x0e2\x16.config.v1.ConfigScopeB\x1e\x9a\x84\x9e\x03\x19bson:\"scope\" json:\"scope\"R\x05scope\x12M\n" +
+	"\ttenant_id\x18\x04 \x01(\tB0\x9a\x84\x9e\x03+bson:\"tenant_id\" json:\"tenant_id,omitempty\"R\btenantId\x12B\n" +
+	"\x06module\x18\x05 \x01(\tB*\x9a\x84\x9e\x03%bson:\"module\" json:\"module,omitempty\"R\x06module\x12L\n" +
+	"\x04type\x18\x06 \x01(\x0e2\x1a.config.v1.ConfigValueTypeB\x1c\x9a\x84\x9e\x03\x17bson:\"type\" json:\"type\"R\x04type\x12L\n" +
+	"\x05value\x18\a \x01(\v2\x16.google.protobuf.ValueB\x1e\x9a\x84\x9e\x03\x19bson:\"value\" json:\"value\"R\x05value\x12<\n" +
+	"\aversion\x18\b \x01(\x05B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\x12c\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12G\n" +
+	"\n" +
+	"updated_by\x18\v \x01(\tB(\x9a\x84\x9e\x03#bson:\"updated_by\" json:\"updated_by\"R\tupdatedBy\"\x8f\a\n" +
+	"\x11ConfigEntryChange\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12,\n" +
+	"\x03key\x18\x02 \x01(\tB\x1a\x9a\x84\x9e\x03\x15bson:\"key\" json:\"key\"R\x03key\x12L\n" +
+	"\x05scope\x18\x03 \x01(\x0e2\x16.config.v1.ConfigScopeB\x1e\x9a\x84\x9e\x03\x19bson:\"scope\" json:\"scope\"R\x05scope\x12M\n" +
+	"\ttenant_id\x18\x04 \x01(\tB0\x9a\x84\x9e\x03+bson:\"tenant_id\" json:\"tenant_id,omitempty\"R\btenantId\x12B\n" +
+	"\x06module\x18\x05 \x01(\tB*\x9a\x84\x9e\x03%bson:\"module\" json:\"module,omitempty\"R\x06module\x12h\n" +
+	"\vchange_type\x18\x06 \x01(\x0e2\x1b.config.v1.ConfigChangeTypeB*\x9a\x84\x9e\x03%bson:\"change_type\" json:\"change_type\"R\n" +
+	"changeType\x12<\n" +
+	"\aversion\x18\a \x01(\x05B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\x12o\n" +
+	"\told_value\x18\b \x01(\v2\x16.google.protobuf.ValueB:\x9a\x84\x9e\x035bson:\"old_value,omitempty\" json:\"old_value,omitempty\"R\boldValue\x12o\n" +
+	"\tnew_value\x18\t \x01(\v2\x16.google.protobuf.ValueB:\x9a\x84\x9e\x035bson:\"new_value,omitempty\" json:\"new_value,omitempty\"R\bnewValue\x12G\n" +
+	"\n" +
+	"changed_by\x18\n" +
+	" \x01(\tB(\x9a\x84\x9e\x03#bson:\"changed_by\" json:\"changed_by\"R\tchangedBy\x12c\n" +
+	"\n" +
+	"changed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"changed_at\" json:\"changed_at\"R\tchangedAt*v\n" +
+	"\vConfigScope\x12\x1c\n" +
+	"\x18CONFIG_SCOPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CONFIG_SCOPE_SYSTEM\x10\x01\x12\x17\n" +
+	"\x13CONFIG_SCOPE_TENANT\x10\x02\x12\x17\n" +
+	"\x13CONFIG_SCOPE_MODULE\x10\x03*\xe2\x01\n" +
+	"\x0fConfigValueType\x12!\n" +
+	"\x1dCONFIG_VALUE_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CONFIG_VALUE_TYPE_STRING\x10\x01\x12\x19\n" +
+	"\x15CONFIG_VALUE_TYPE_INT\x10\x02\x12\x1b\n" +
+	"\x17CONFIG_VALUE_TYPE_FLOAT\x10\x03\x12\x1a\n" +
+	"\x16CONFIG_VALUE_TYPE_BOOL\x10\x04\x12\x1e\n" +
+	"\x1aCONFIG_VALUE_TYPE_DURATION\x10\x05\x12\x1a\n" +
+	"\x16CONFIG_VALUE_TYPE_JSON\x10\x06*q\n" +
+	"\x10ConfigChangeType\x12\"\n" +
+	"\x1eCONFIG_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16CONFIG_CHANGE_TYPE_SET\x10\x01\x12\x1d\n" +
+	"\x19CONFIG_CHANGE_TYPE_DELETE\x10\x02B7Z5erp.localhost/internal/infra/model/config/v1;configv1b\x06proto3"
+
+var (
+	file_config_v1_config_entry_proto_rawDescOnce sync.Once
+	file_config_v1_config_entry_proto_rawDescData []byte
+)
+
+func file_config_v1_config_entry_proto_rawDescGZIP() []byte {
+	file_config_v1_config_entry_proto_rawDescOnce.Do(func() {
+		file_config_v1_config_entry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_config_v1_config_entry_proto_rawDesc), len(file_config_v1_config_entry_proto_rawDesc)))
+	})
+	return file_config_v1_config_entry_proto_rawDescData
+}
+
+var file_config_v1_config_entry_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_config_v1_config_entry_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_config_v1_config_entry_proto_goTypes = []any{
+	(ConfigScope)(0),              // 0: config.v1.ConfigScope
+	(ConfigValueType)(0),          // 1: config.v1.ConfigValueType
+	(ConfigChangeType)(0),         // 2: config.v1.ConfigChangeType
+	(*ConfigEntry)(nil),           // 3: config.v1.ConfigEntry
+	(*ConfigEntryChange)(nil),     // 4: config.v1.ConfigEntryChange
+	(*structpb.Value)(nil),        // 5: google.protobuf.Value
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_config_v1_config_entry_proto_depIdxs = []int32{
+	0,  // 0: config.v1.ConfigEntry.scope:type_name -> config.v1.ConfigScope
+	1,  // 1: config.v1.ConfigEntry.type:type_name -> config.v1.ConfigValueType
+	5,  // 2: config.v1.ConfigEntry.value:type_name -> google.protobuf.Value
+	6,  // 3: config.v1.ConfigEntry.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 4: config.v1.ConfigEntry.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: config.v1.ConfigEntryChange.scope:type_name -> config.v1.ConfigScope
+	2,  // 6: config.v1.ConfigEntryChange.change_type:type_name -> config.v1.ConfigChangeType
+	5,  // 7: config.v1.ConfigEntryChange.old_value:type_name -> google.protobuf.Value
+	5,  // 8: config.v1.ConfigEntryChange.new_value:type_name -> google.protobuf.Value
+	6,  // 9: config.v1.ConfigEntryChange.changed_at:type_name -> google.protobuf.Timestamp
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_config_v1_config_entry_proto_init() }
+func file_config_v1_config_entry_proto_init() {
+	if File_config_v1_config_entry_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_v1_config_entry_proto_rawDesc), len(file_config_v1_config_entry_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_config_v1_config_entry_proto_goTypes,
+		DependencyIndexes: file_config_v1_config_entry_proto_depIdxs,
+		EnumInfos:         file_config_v1_config_entry_proto_enumTypes,
+		MessageInfos:      file_config_v1_config_entry_proto_msgTypes,
+	}.Build()
+	File_config_v1_config_entry_proto = out.File
+	file_config_v1_config_entry_proto_goTypes = nil
+	file_config_v1_config_entry_proto_depIdxs = nil
+}
@@ -19,9 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ConfigService_GetConfig_FullMethodName      = "/config.v1.ConfigService/GetConfig"
-	ConfigService_GetEnv_FullMethodName         = "/config.v1.ConfigService/GetEnv"
-	ConfigService_SetFeatureFlag_FullMethodName = "/config.v1.ConfigService/SetFeatureFlag"
+	ConfigService_GetConfig_FullMethodName         = "/config.v1.ConfigService/GetConfig"
+	ConfigService_SetConfig_FullMethodName         = "/config.v1.ConfigService/SetConfig"
+	ConfigService_GetConfigEntry_FullMethodName    = "/config.v1.ConfigService/GetConfigEntry"
+	ConfigService_SetConfigEntry_FullMethodName    = "/config.v1.ConfigService/SetConfigEntry"
+	ConfigService_ListConfigEntries_FullMethodName = "/config.v1.ConfigService/ListConfigEntries"
+	ConfigService_DeleteConfigEntry_FullMethodName = "/config.v1.ConfigService/DeleteConfigEntry"
+	ConfigService_GetConfigHistory_FullMethodName  = "/config.v1.ConfigService/GetConfigHistory"
+	ConfigService_WatchConfig_FullMethodName       = "/config.v1.ConfigService/WatchConfig"
+	ConfigService_GetEnv_FullMethodName            = "/config.v1.ConfigService/GetEnv"
 )
 
 // ConfigServiceClient is the client API for ConfigService service.
@@ -29,8 +35,14 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ConfigServiceClient interface {
 	GetConfig(ctx context.Context, in *ConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error)
+	GetConfigEntry(ctx context.Context, in *GetConfigEntryRequest, opts ...grpc.CallOption) (*ConfigEntryResponse, error)
+	SetConfigEntry(ctx context.Context, in *SetConfigEntryRequest, opts ...grpc.CallOption) (*ConfigEntryResponse, error)
+	ListConfigEntries(ctx context.Context, in *ListConfigEntriesRequest, opts ...grpc.CallOption) (*ListConfigEntriesResponse, error)
+	DeleteConfigEntry(ctx context.Context, in *DeleteConfigEntryRequest, opts ...grpc.CallOption) (*DeleteConfigEntryResponse, error)
+	GetConfigHistory(ctx context.Context, in *GetConfigHistoryRequest, opts ...grpc.CallOption) (*GetConfigHistoryResponse, error)
+	WatchConfig(ctx context.Context, in *WatchConfigRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConfigEvent], error)
 	GetEnv(ctx context.Context, in *EnvRequest, opts ...grpc.CallOption) (*EnvResponse, error)
-	SetFeatureFlag(ctx context.Context, in *FeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error)
 }
 
 type configServiceClient struct {
@@ -51,20 +63,89 @@ func (c *configServiceClient) GetConfig(ctx context.Context, in *ConfigRequest,
 	return out, nil
 }
 
-func (c *configServiceClient) GetEnv(ctx context.Context, in *EnvRequest, opts ...grpc.CallOption) (*EnvResponse, error) {
+func (c *configServiceClient) SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*ConfigResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(EnvResponse)
-	err := c.cc.Invoke(ctx, ConfigService_GetEnv_FullMethodName, in, out, cOpts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ConfigService_SetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) GetConfigEntry(ctx context.Context, in *GetConfigEntryRequest, opts ...grpc.CallOption) (*ConfigEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigEntryResponse)
+	err := c.cc.Invoke(ctx, ConfigService_GetConfigEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) SetConfigEntry(ctx context.Context, in *SetConfigEntryRequest, opts ...grpc.CallOption) (*ConfigEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigEntryResponse)
+	err := c.cc.Invoke(ctx, ConfigService_SetConfigEntry_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *configServiceClient) SetFeatureFlag(ctx context.Context, in *FeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error) {
+func (c *configServiceClient) ListConfigEntries(ctx context.Context, in *ListConfigEntriesRequest, opts ...grpc.CallOption) (*ListConfigEntriesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(FeatureFlagResponse)
-	err := c.cc.Invoke(ctx, ConfigService_SetFeatureFlag_FullMethodName, in, out, cOpts...)
+	out := new(ListConfigEntriesResponse)
+	err := c.cc.Invoke(ctx, ConfigService_ListConfigEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) DeleteConfigEntry(ctx context.Context, in *DeleteConfigEntryRequest, opts ...grpc.CallOption) (*DeleteConfigEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConfigEntryResponse)
+	err := c.cc.Invoke(ctx, ConfigService_DeleteConfigEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) GetConfigHistory(ctx context.Context, in *GetConfigHistoryRequest, opts ...grpc.CallOption) (*GetConfigHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigHistoryResponse)
+	err := c.cc.Invoke(ctx, ConfigService_GetConfigHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) WatchConfig(ctx context.Context, in *WatchConfigRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ConfigEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConfigService_ServiceDesc.Streams[0], ConfigService_WatchConfig_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchConfigRequest, ConfigEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigClient = grpc.ServerStreamingClient[ConfigEvent]
+
+func (c *configServiceClient) GetEnv(ctx context.Context, in *EnvRequest, opts ...grpc.CallOption) (*EnvResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnvResponse)
+	err := c.cc.Invoke(ctx, ConfigService_GetEnv_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +157,14 @@ func (c *configServiceClient) SetFeatureFlag(ctx context.Context, in *FeatureFla
 // for forward compatibility.
 type ConfigServiceServer interface {
 	GetConfig(context.Context, *ConfigRequest) (*ConfigResponse, error)
+	SetConfig(context.Context, *SetConfigRequest) (*ConfigResponse, error)
+	GetConfigEntry(context.Context, *GetConfigEntryRequest) (*ConfigEntryResponse, error)
+	SetConfigEntry(context.Context, *SetConfigEntryRequest) (*ConfigEntryResponse, error)
+	ListConfigEntries(context.Context, *ListConfigEntriesRequest) (*ListConfigEntriesResponse, error)
+	DeleteConfigEntry(context.Context, *DeleteConfigEntryRequest) (*DeleteConfigEntryResponse, error)
+	GetConfigHistory(context.Context, *GetConfigHistoryRequest) (*GetConfigHistoryResponse, error)
+	WatchConfig(*WatchConfigRequest, grpc.ServerStreamingServer[ConfigEvent]) error
 	GetEnv(context.Context, *EnvRequest) (*EnvResponse, error)
-	SetFeatureFlag(context.Context, *FeatureFlagRequest) (*FeatureFlagResponse, error)
 	mustEmbedUnimplementedConfigServiceServer()
 }
 
@@ -91,12 +178,30 @@ type UnimplementedConfigServiceServer struct{}
 func (UnimplementedConfigServiceServer) GetConfig(context.Context, *ConfigRequest) (*ConfigResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetConfig not implemented")
 }
+func (UnimplementedConfigServiceServer) SetConfig(context.Context, *SetConfigRequest) (*ConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetConfig not implemented")
+}
+func (UnimplementedConfigServiceServer) GetConfigEntry(context.Context, *GetConfigEntryRequest) (*ConfigEntryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfigEntry not implemented")
+}
+func (UnimplementedConfigServiceServer) SetConfigEntry(context.Context, *SetConfigEntryRequest) (*ConfigEntryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetConfigEntry not implemented")
+}
+func (UnimplementedConfigServiceServer) ListConfigEntries(context.Context, *ListConfigEntriesRequest) (*ListConfigEntriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConfigEntries not implemented")
+}
+func (UnimplementedConfigServiceServer) DeleteConfigEntry(context.Context, *DeleteConfigEntryRequest) (*DeleteConfigEntryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteConfigEntry not implemented")
+}
+func (UnimplementedConfigServiceServer) GetConfigHistory(context.Context, *GetConfigHistoryRequest) (*GetConfigHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfigHistory not implemented")
+}
+func (UnimplementedConfigServiceServer) WatchConfig(*WatchConfigRequest, grpc.ServerStreamingServer[ConfigEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchConfig not implemented")
+}
 func (UnimplementedConfigServiceServer) GetEnv(context.Context, *EnvRequest) (*EnvResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetEnv not implemented")
 }
-func (UnimplementedConfigServiceServer) SetFeatureFlag(context.Context, *FeatureFlagRequest) (*FeatureFlagResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method SetFeatureFlag not implemented")
-}
 func (UnimplementedConfigServiceServer) mustEmbedUnimplementedConfigServiceServer() {}
 func (UnimplementedConfigServiceServer) testEmbeddedByValue()                       {}
 
@@ -136,38 +241,139 @@ func _ConfigService_GetConfig_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ConfigService_GetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EnvRequest)
+func _ConfigService_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ConfigServiceServer).GetEnv(ctx, in)
+		return srv.(ConfigServiceServer).SetConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ConfigService_GetEnv_FullMethodName,
+		FullMethod: ConfigService_SetConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ConfigServiceServer).GetEnv(ctx, req.(*EnvRequest))
+		return srv.(ConfigServiceServer).SetConfig(ctx, req.(*SetConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ConfigService_SetFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FeatureFlagRequest)
+func _ConfigService_GetConfigEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigEntryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ConfigServiceServer).SetFeatureFlag(ctx, in)
+		return srv.(ConfigServiceServer).GetConfigEntry(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ConfigService_SetFeatureFlag_FullMethodName,
+		FullMethod: ConfigService_GetConfigEntry_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ConfigServiceServer).SetFeatureFlag(ctx, req.(*FeatureFlagRequest))
+		return srv.(ConfigServiceServer).GetConfigEntry(ctx, req.(*GetConfigEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_SetConfigEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).SetConfigEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_SetConfigEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).SetConfigEntry(ctx, req.(*SetConfigEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_ListConfigEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).ListConfigEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_ListConfigEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).ListConfigEntries(ctx, req.(*ListConfigEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_DeleteConfigEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConfigEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).DeleteConfigEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_DeleteConfigEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).DeleteConfigEntry(ctx, req.(*DeleteConfigEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_GetConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).GetConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_GetConfigHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).GetConfigHistory(ctx, req.(*GetConfigHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_WatchConfig_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchConfigRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigServiceServer).WatchConfig(m, &grpc.GenericServerStream[WatchConfigRequest, ConfigEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigServer = grpc.ServerStreamingServer[ConfigEvent]
+
+func _ConfigService_GetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).GetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_GetEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).GetEnv(ctx, req.(*EnvRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -183,15 +389,41 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfig",
 			Handler:    _ConfigService_GetConfig_Handler,
 		},
+		{
+			MethodName: "SetConfig",
+			Handler:    _ConfigService_SetConfig_Handler,
+		},
+		{
+			MethodName: "GetConfigEntry",
+			Handler:    _ConfigService_GetConfigEntry_Handler,
+		},
+		{
+			MethodName: "SetConfigEntry",
+			Handler:    _ConfigService_SetConfigEntry_Handler,
+		},
+		{
+			MethodName: "ListConfigEntries",
+			Handler:    _ConfigService_ListConfigEntries_Handler,
+		},
+		{
+			MethodName: "DeleteConfigEntry",
+			Handler:    _ConfigService_DeleteConfigEntry_Handler,
+		},
+		{
+			MethodName: "GetConfigHistory",
+			Handler:    _ConfigService_GetConfigHistory_Handler,
+		},
 		{
 			MethodName: "GetEnv",
 			Handler:    _ConfigService_GetEnv_Handler,
 		},
+	},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "SetFeatureFlag",
-			Handler:    _ConfigService_SetFeatureFlag_Handler,
+			StreamName:    "WatchConfig",
+			Handler:       _ConfigService_WatchConfig_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "config/v1/config.proto",
 }
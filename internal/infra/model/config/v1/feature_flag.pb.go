@@ -23,20 +23,85 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// FeatureFlagScope is the level a feature flag is evaluated at
+type FeatureFlagScope int32
+
+const (
+	// Evaluated as FEATURE_FLAG_SCOPE_TENANT
+	FeatureFlagScope_FEATURE_FLAG_SCOPE_UNSPECIFIED FeatureFlagScope = 0
+	// On or off for everyone, the rollout and the overrides are ignored
+	FeatureFlagScope_FEATURE_FLAG_SCOPE_GLOBAL FeatureFlagScope = 1
+	// Evaluated per tenant, every user of a tenant gets the same answer
+	FeatureFlagScope_FEATURE_FLAG_SCOPE_TENANT FeatureFlagScope = 2
+	// Evaluated per user, the percentage rollout picks users
+	FeatureFlagScope_FEATURE_FLAG_SCOPE_USER FeatureFlagScope = 3
+)
+
+// Enum value maps for FeatureFlagScope.
+var (
+	FeatureFlagScope_name = map[int32]string{
+		0: "FEATURE_FLAG_SCOPE_UNSPECIFIED",
+		1: "FEATURE_FLAG_SCOPE_GLOBAL",
+		2: "FEATURE_FLAG_SCOPE_TENANT",
+		3: "FEATURE_FLAG_SCOPE_USER",
+	}
+	FeatureFlagScope_value = map[string]int32{
+		"FEATURE_FLAG_SCOPE_UNSPECIFIED": 0,
+		"FEATURE_FLAG_SCOPE_GLOBAL":      1,
+		"FEATURE_FLAG_SCOPE_TENANT":      2,
+		"FEATURE_FLAG_SCOPE_USER":        3,
+	}
+)
+
+func (x FeatureFlagScope) Enum() *FeatureFlagScope {
+	p := new(FeatureFlagScope)
+	*p = x
+	return p
+}
+
+func (x FeatureFlagScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FeatureFlagScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_config_v1_feature_flag_proto_enumTypes[0].Descriptor()
+}
+
+func (FeatureFlagScope) Type() protoreflect.EnumType {
+	return &file_config_v1_feature_flag_proto_enumTypes[0]
+}
+
+func (x FeatureFlagScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeatureFlagScope.Descriptor instead.
+func (FeatureFlagScope) EnumDescriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{0}
+}
+
 // FeatureFlag represents a feature flag
 // Stored in MongoDB config_db.feature_flags collection
 type FeatureFlag struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	FlagId        string                 `protobuf:"bytes,2,opt,name=flag_id,json=flagId,proto3" json:"flag_id" bson:"flag_id"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name" bson:"name"`
-	Key           string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key" bson:"key"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description" bson:"description"`
-	Enabled       bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled" bson:"enabled"`
-	Rollout       *FeatureRollout        `protobuf:"bytes,7,opt,name=rollout,proto3" json:"rollout" bson:"rollout"`
-	Metadata      *FeatureFlagMetadata   `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	FlagId string                 `protobuf:"bytes,2,opt,name=flag_id,json=flagId,proto3" json:"flag_id" bson:"flag_id"`
+	Name   string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name" bson:"name"`
+	// Unique, the flags are addressed and evaluated by key
+	Key         string `protobuf:"bytes,4,opt,name=key,proto3" json:"key" bson:"key"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description" bson:"description"`
+	// Turns the flag off for everyone when false, whatever the rollout and the overrides
+	Enabled   bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled" bson:"enabled"`
+	Rollout   *FeatureRollout        `protobuf:"bytes,7,opt,name=rollout,proto3" json:"rollout" bson:"rollout"`
+	Metadata  *FeatureFlagMetadata   `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	Scope     FeatureFlagScope       `protobuf:"varint,11,opt,name=scope,proto3,enum=config.v1.FeatureFlagScope" json:"scope" bson:"scope"`
+	// Forced values of tenants and users, a user override wins over the override of its tenant
+	Overrides []*FeatureFlagOverride `protobuf:"bytes,12,rep,name=overrides,proto3" json:"overrides,omitempty" bson:"overrides,omitempty"`
+	UpdatedBy string                 `protobuf:"bytes,13,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by" bson:"updated_by"`
+	// Incremented on every update, updates of a stale version are refused
+	Version       int64 `protobuf:"varint,14,opt,name=version,proto3" json:"version" bson:"version"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -141,11 +206,41 @@ func (x *FeatureFlag) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *FeatureFlag) GetScope() FeatureFlagScope {
+	if x != nil {
+		return x.Scope
+	}
+	return FeatureFlagScope_FEATURE_FLAG_SCOPE_UNSPECIFIED
+}
+
+func (x *FeatureFlag) GetOverrides() []*FeatureFlagOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *FeatureFlag) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// FeatureRollout turns an enabled flag on for the listed tenants and users and for a stable percentage of the others
 type FeatureRollout struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Percentage    int32                  `protobuf:"varint,1,opt,name=percentage,proto3" json:"percentage" bson:"percentage"`
-	TenantIds     []string               `protobuf:"bytes,2,rep,name=tenant_ids,json=tenantIds,proto3" json:"tenant_ids,omitempty" bson:"tenant_ids,omitempty"`
-	UserIds       []string               `protobuf:"bytes,3,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty" bson:"user_ids,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 to 100, tenants or users are bucketed by a hash of the flag key and their id
+	Percentage    int32    `protobuf:"varint,1,opt,name=percentage,proto3" json:"percentage" bson:"percentage"`
+	TenantIds     []string `protobuf:"bytes,2,rep,name=tenant_ids,json=tenantIds,proto3" json:"tenant_ids,omitempty" bson:"tenant_ids,omitempty"`
+	UserIds       []string `protobuf:"bytes,3,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty" bson:"user_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -201,6 +296,67 @@ func (x *FeatureRollout) GetUserIds() []string {
 	return nil
 }
 
+// FeatureFlagOverride forces the value of a flag for a tenant, or for a user of the tenant when user_id is set
+type FeatureFlagOverride struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled" bson:"enabled"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureFlagOverride) Reset() {
+	*x = FeatureFlagOverride{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureFlagOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlagOverride) ProtoMessage() {}
+
+func (x *FeatureFlagOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlagOverride.ProtoReflect.Descriptor instead.
+func (*FeatureFlagOverride) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FeatureFlagOverride) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *FeatureFlagOverride) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FeatureFlagOverride) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
 type FeatureFlagMetadata struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Category         string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty" bson:"category,omitempty"`
@@ -212,7 +368,7 @@ type FeatureFlagMetadata struct {
 
 func (x *FeatureFlagMetadata) Reset() {
 	*x = FeatureFlagMetadata{}
-	mi := &file_config_v1_feature_flag_proto_msgTypes[2]
+	mi := &file_config_v1_feature_flag_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -224,7 +380,7 @@ func (x *FeatureFlagMetadata) String() string {
 func (*FeatureFlagMetadata) ProtoMessage() {}
 
 func (x *FeatureFlagMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_config_v1_feature_flag_proto_msgTypes[2]
+	mi := &file_config_v1_feature_flag_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,7 +393,7 @@ func (x *FeatureFlagMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeatureFlagMetadata.ProtoReflect.Descriptor instead.
 func (*FeatureFlagMetadata) Descriptor() ([]byte, []int) {
-	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{2}
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *FeatureFlagMetadata) GetCategory() string {
@@ -261,11 +417,624 @@ func (x *FeatureFlagMetadata) GetDocumentationUrl() string {
 	return ""
 }
 
+type CreateFeatureFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flag          *FeatureFlag           `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFeatureFlagRequest) Reset() {
+	*x = CreateFeatureFlagRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFeatureFlagRequest) ProtoMessage() {}
+
+func (x *CreateFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*CreateFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateFeatureFlagRequest) GetFlag() *FeatureFlag {
+	if x != nil {
+		return x.Flag
+	}
+	return nil
+}
+
+func (x *CreateFeatureFlagRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Replaces the definition of the flag of flag.key, its overrides are kept
+type UpdateFeatureFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flag          *FeatureFlag           `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFeatureFlagRequest) Reset() {
+	*x = UpdateFeatureFlagRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFeatureFlagRequest) ProtoMessage() {}
+
+func (x *UpdateFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateFeatureFlagRequest) GetFlag() *FeatureFlag {
+	if x != nil {
+		return x.Flag
+	}
+	return nil
+}
+
+func (x *UpdateFeatureFlagRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetFeatureFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeatureFlagRequest) Reset() {
+	*x = GetFeatureFlagRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeatureFlagRequest) ProtoMessage() {}
+
+func (x *GetFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*GetFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetFeatureFlagRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type FeatureFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flag          *FeatureFlag           `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureFlagResponse) Reset() {
+	*x = FeatureFlagResponse{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlagResponse) ProtoMessage() {}
+
+func (x *FeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*FeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FeatureFlagResponse) GetFlag() *FeatureFlag {
+	if x != nil {
+		return x.Flag
+	}
+	return nil
+}
+
+type ListFeatureFlagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsRequest) Reset() {
+	*x = ListFeatureFlagsRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsRequest) ProtoMessage() {}
+
+func (x *ListFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{8}
+}
+
+type ListFeatureFlagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         []*FeatureFlag         `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsResponse) Reset() {
+	*x = ListFeatureFlagsResponse{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeatureFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsResponse) ProtoMessage() {}
+
+func (x *ListFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListFeatureFlagsResponse) GetFlags() []*FeatureFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type DeleteFeatureFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeatureFlagRequest) Reset() {
+	*x = DeleteFeatureFlagRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeatureFlagRequest) ProtoMessage() {}
+
+func (x *DeleteFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteFeatureFlagRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DeleteFeatureFlagRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteFeatureFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeatureFlagResponse) Reset() {
+	*x = DeleteFeatureFlagResponse{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeatureFlagResponse) ProtoMessage() {}
+
+func (x *DeleteFeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{11}
+}
+
+// Forces the flag on or off for a tenant, or for a user of the tenant when account_id is set
+type SetFeatureFlagOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeatureFlagOverrideRequest) Reset() {
+	*x = SetFeatureFlagOverrideRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeatureFlagOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeatureFlagOverrideRequest) ProtoMessage() {}
+
+func (x *SetFeatureFlagOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeatureFlagOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetFeatureFlagOverrideRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagOverrideRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagOverrideRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetFeatureFlagOverrideRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetFeatureFlagOverrideRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Removes the override of the tenant, or of the user of the tenant when account_id is set
+type DeleteFeatureFlagOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeatureFlagOverrideRequest) Reset() {
+	*x = DeleteFeatureFlagOverrideRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeatureFlagOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeatureFlagOverrideRequest) ProtoMessage() {}
+
+func (x *DeleteFeatureFlagOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeatureFlagOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFeatureFlagOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteFeatureFlagOverrideRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DeleteFeatureFlagOverrideRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DeleteFeatureFlagOverrideRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *DeleteFeatureFlagOverrideRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Evaluates flags for a tenant and optionally a user of it, every flag when keys is empty
+type EvaluateFeatureFlagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Keys          []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateFeatureFlagsRequest) Reset() {
+	*x = EvaluateFeatureFlagsRequest{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateFeatureFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateFeatureFlagsRequest) ProtoMessage() {}
+
+func (x *EvaluateFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EvaluateFeatureFlagsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *EvaluateFeatureFlagsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EvaluateFeatureFlagsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type EvaluateFeatureFlagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unknown keys evaluate to false
+	Flags         map[string]bool `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateFeatureFlagsResponse) Reset() {
+	*x = EvaluateFeatureFlagsResponse{}
+	mi := &file_config_v1_feature_flag_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateFeatureFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateFeatureFlagsResponse) ProtoMessage() {}
+
+func (x *EvaluateFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_feature_flag_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_feature_flag_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EvaluateFeatureFlagsResponse) GetFlags() map[string]bool {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
 var File_config_v1_feature_flag_proto protoreflect.FileDescriptor
 
 const file_config_v1_feature_flag_proto_rawDesc = "" +
 	"\n" +
-	"\x1cconfig/v1/feature_flag.proto\x12\tconfig.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\x84\x06\n" +
+	"\x1cconfig/v1/feature_flag.proto\x12\tconfig.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xd8\b\n" +
 	"\vFeatureFlag\x123\n" +
 	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12;\n" +
 	"\aflag_id\x18\x02 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"flag_id\" json:\"flag_id\"R\x06flagId\x120\n" +
@@ -279,19 +1048,82 @@ const file_config_v1_feature_flag_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\"\x8c\x02\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12Q\n" +
+	"\x05scope\x18\v \x01(\x0e2\x1b.config.v1.FeatureFlagScopeB\x1e\x9a\x84\x9e\x03\x19bson:\"scope\" json:\"scope\"R\x05scope\x12x\n" +
+	"\toverrides\x18\f \x03(\v2\x1e.config.v1.FeatureFlagOverrideB:\x9a\x84\x9e\x035bson:\"overrides,omitempty\" json:\"overrides,omitempty\"R\toverrides\x12G\n" +
+	"\n" +
+	"updated_by\x18\r \x01(\tB(\x9a\x84\x9e\x03#bson:\"updated_by\" json:\"updated_by\"R\tupdatedBy\x12<\n" +
+	"\aversion\x18\x0e \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\"\x8c\x02\n" +
 	"\x0eFeatureRollout\x12H\n" +
 	"\n" +
 	"percentage\x18\x01 \x01(\x05B(\x9a\x84\x9e\x03#bson:\"percentage\" json:\"percentage\"R\n" +
 	"percentage\x12[\n" +
 	"\n" +
 	"tenant_ids\x18\x02 \x03(\tB<\x9a\x84\x9e\x037bson:\"tenant_ids,omitempty\" json:\"tenant_ids,omitempty\"R\ttenantIds\x12S\n" +
-	"\buser_ids\x18\x03 \x03(\tB8\x9a\x84\x9e\x033bson:\"user_ids,omitempty\" json:\"user_ids,omitempty\"R\auserIds\"\xc1\x02\n" +
+	"\buser_ids\x18\x03 \x03(\tB8\x9a\x84\x9e\x033bson:\"user_ids,omitempty\" json:\"user_ids,omitempty\"R\auserIds\"\xe9\x01\n" +
+	"\x13FeatureFlagOverride\x12C\n" +
+	"\ttenant_id\x18\x01 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12O\n" +
+	"\auser_id\x18\x02 \x01(\tB6\x9a\x84\x9e\x031bson:\"user_id,omitempty\" json:\"user_id,omitempty\"R\x06userId\x12<\n" +
+	"\aenabled\x18\x03 \x01(\bB\"\x9a\x84\x9e\x03\x1dbson:\"enabled\" json:\"enabled\"R\aenabled\"\xc1\x02\n" +
 	"\x13FeatureFlagMetadata\x12T\n" +
 	"\bcategory\x18\x01 \x01(\tB8\x9a\x84\x9e\x033bson:\"category,omitempty\" json:\"category,omitempty\"R\bcategory\x12[\n" +
 	"\n" +
 	"owner_team\x18\x02 \x01(\tB<\x9a\x84\x9e\x037bson:\"owner_team,omitempty\" json:\"owner_team,omitempty\"R\townerTeam\x12w\n" +
-	"\x11documentation_url\x18\x03 \x01(\tBJ\x9a\x84\x9e\x03Ebson:\"documentation_url,omitempty\" json:\"documentation_url,omitempty\"R\x10documentationUrlB7Z5erp.localhost/internal/infra/model/config/v1;configv1b\x06proto3"
+	"\x11documentation_url\x18\x03 \x01(\tBJ\x9a\x84\x9e\x03Ebson:\"documentation_url,omitempty\" json:\"documentation_url,omitempty\"R\x10documentationUrl\"_\n" +
+	"\x18CreateFeatureFlagRequest\x12*\n" +
+	"\x04flag\x18\x01 \x01(\v2\x16.config.v1.FeatureFlagR\x04flag\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"_\n" +
+	"\x18UpdateFeatureFlagRequest\x12*\n" +
+	"\x04flag\x18\x01 \x01(\v2\x16.config.v1.FeatureFlagR\x04flag\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\")\n" +
+	"\x15GetFeatureFlagRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"A\n" +
+	"\x13FeatureFlagResponse\x12*\n" +
+	"\x04flag\x18\x01 \x01(\v2\x16.config.v1.FeatureFlagR\x04flag\"\x19\n" +
+	"\x17ListFeatureFlagsRequest\"H\n" +
+	"\x18ListFeatureFlagsResponse\x12,\n" +
+	"\x05flags\x18\x01 \x03(\v2\x16.config.v1.FeatureFlagR\x05flags\"E\n" +
+	"\x18DeleteFeatureFlagRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x1b\n" +
+	"\x19DeleteFeatureFlagResponse\"\xa0\x01\n" +
+	"\x1dSetFeatureFlagOverrideRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\"\x89\x01\n" +
+	" DeleteFeatureFlagOverrideRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\"g\n" +
+	"\x1bEvaluateFeatureFlagsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\"\xa2\x01\n" +
+	"\x1cEvaluateFeatureFlagsResponse\x12H\n" +
+	"\x05flags\x18\x01 \x03(\v22.config.v1.EvaluateFeatureFlagsResponse.FlagsEntryR\x05flags\x1a8\n" +
+	"\n" +
+	"FlagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\bR\x05value:\x028\x01*\x91\x01\n" +
+	"\x10FeatureFlagScope\x12\"\n" +
+	"\x1eFEATURE_FLAG_SCOPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19FEATURE_FLAG_SCOPE_GLOBAL\x10\x01\x12\x1d\n" +
+	"\x19FEATURE_FLAG_SCOPE_TENANT\x10\x02\x12\x1b\n" +
+	"\x17FEATURE_FLAG_SCOPE_USER\x10\x032\x90\x06\n" +
+	"\x12FeatureFlagService\x12X\n" +
+	"\x11CreateFeatureFlag\x12#.config.v1.CreateFeatureFlagRequest\x1a\x1e.config.v1.FeatureFlagResponse\x12X\n" +
+	"\x11UpdateFeatureFlag\x12#.config.v1.UpdateFeatureFlagRequest\x1a\x1e.config.v1.FeatureFlagResponse\x12R\n" +
+	"\x0eGetFeatureFlag\x12 .config.v1.GetFeatureFlagRequest\x1a\x1e.config.v1.FeatureFlagResponse\x12[\n" +
+	"\x10ListFeatureFlags\x12\".config.v1.ListFeatureFlagsRequest\x1a#.config.v1.ListFeatureFlagsResponse\x12^\n" +
+	"\x11DeleteFeatureFlag\x12#.config.v1.DeleteFeatureFlagRequest\x1a$.config.v1.DeleteFeatureFlagResponse\x12b\n" +
+	"\x16SetFeatureFlagOverride\x12(.config.v1.SetFeatureFlagOverrideRequest\x1a\x1e.config.v1.FeatureFlagResponse\x12h\n" +
+	"\x19DeleteFeatureFlagOverride\x12+.config.v1.DeleteFeatureFlagOverrideRequest\x1a\x1e.config.v1.FeatureFlagResponse\x12g\n" +
+	"\x14EvaluateFeatureFlags\x12&.config.v1.EvaluateFeatureFlagsRequest\x1a'.config.v1.EvaluateFeatureFlagsResponseB7Z5erp.localhost/internal/infra/model/config/v1;configv1b\x06proto3"
 
 var (
 	file_config_v1_feature_flag_proto_rawDescOnce sync.Once
@@ -305,23 +1137,62 @@ func file_config_v1_feature_flag_proto_rawDescGZIP() []byte {
 	return file_config_v1_feature_flag_proto_rawDescData
 }
 
-var file_config_v1_feature_flag_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_config_v1_feature_flag_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_config_v1_feature_flag_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_config_v1_feature_flag_proto_goTypes = []any{
-	(*FeatureFlag)(nil),           // 0: config.v1.FeatureFlag
-	(*FeatureRollout)(nil),        // 1: config.v1.FeatureRollout
-	(*FeatureFlagMetadata)(nil),   // 2: config.v1.FeatureFlagMetadata
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(FeatureFlagScope)(0),                    // 0: config.v1.FeatureFlagScope
+	(*FeatureFlag)(nil),                      // 1: config.v1.FeatureFlag
+	(*FeatureRollout)(nil),                   // 2: config.v1.FeatureRollout
+	(*FeatureFlagOverride)(nil),              // 3: config.v1.FeatureFlagOverride
+	(*FeatureFlagMetadata)(nil),              // 4: config.v1.FeatureFlagMetadata
+	(*CreateFeatureFlagRequest)(nil),         // 5: config.v1.CreateFeatureFlagRequest
+	(*UpdateFeatureFlagRequest)(nil),         // 6: config.v1.UpdateFeatureFlagRequest
+	(*GetFeatureFlagRequest)(nil),            // 7: config.v1.GetFeatureFlagRequest
+	(*FeatureFlagResponse)(nil),              // 8: config.v1.FeatureFlagResponse
+	(*ListFeatureFlagsRequest)(nil),          // 9: config.v1.ListFeatureFlagsRequest
+	(*ListFeatureFlagsResponse)(nil),         // 10: config.v1.ListFeatureFlagsResponse
+	(*DeleteFeatureFlagRequest)(nil),         // 11: config.v1.DeleteFeatureFlagRequest
+	(*DeleteFeatureFlagResponse)(nil),        // 12: config.v1.DeleteFeatureFlagResponse
+	(*SetFeatureFlagOverrideRequest)(nil),    // 13: config.v1.SetFeatureFlagOverrideRequest
+	(*DeleteFeatureFlagOverrideRequest)(nil), // 14: config.v1.DeleteFeatureFlagOverrideRequest
+	(*EvaluateFeatureFlagsRequest)(nil),      // 15: config.v1.EvaluateFeatureFlagsRequest
+	(*EvaluateFeatureFlagsResponse)(nil),     // 16: config.v1.EvaluateFeatureFlagsResponse
+	nil,                                      // 17: config.v1.EvaluateFeatureFlagsResponse.FlagsEntry
+	(*timestamppb.Timestamp)(nil),            // 18: google.protobuf.Timestamp
 }
 var file_config_v1_feature_flag_proto_depIdxs = []int32{
-	1, // 0: config.v1.FeatureFlag.rollout:type_name -> config.v1.FeatureRollout
-	2, // 1: config.v1.FeatureFlag.metadata:type_name -> config.v1.FeatureFlagMetadata
-	3, // 2: config.v1.FeatureFlag.created_at:type_name -> google.protobuf.Timestamp
-	3, // 3: config.v1.FeatureFlag.updated_at:type_name -> google.protobuf.Timestamp
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	2,  // 0: config.v1.FeatureFlag.rollout:type_name -> config.v1.FeatureRollout
+	4,  // 1: config.v1.FeatureFlag.metadata:type_name -> config.v1.FeatureFlagMetadata
+	18, // 2: config.v1.FeatureFlag.created_at:type_name -> google.protobuf.Timestamp
+	18, // 3: config.v1.FeatureFlag.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: config.v1.FeatureFlag.scope:type_name -> config.v1.FeatureFlagScope
+	3,  // 5: config.v1.FeatureFlag.overrides:type_name -> config.v1.FeatureFlagOverride
+	1,  // 6: config.v1.CreateFeatureFlagRequest.flag:type_name -> config.v1.FeatureFlag
+	1,  // 7: config.v1.UpdateFeatureFlagRequest.flag:type_name -> config.v1.FeatureFlag
+	1,  // 8: config.v1.FeatureFlagResponse.flag:type_name -> config.v1.FeatureFlag
+	1,  // 9: config.v1.ListFeatureFlagsResponse.flags:type_name -> config.v1.FeatureFlag
+	17, // 10: config.v1.EvaluateFeatureFlagsResponse.flags:type_name -> config.v1.EvaluateFeatureFlagsResponse.FlagsEntry
+	5,  // 11: config.v1.FeatureFlagService.CreateFeatureFlag:input_type -> config.v1.CreateFeatureFlagRequest
+	6,  // 12: config.v1.FeatureFlagService.UpdateFeatureFlag:input_type -> config.v1.UpdateFeatureFlagRequest
+	7,  // 13: config.v1.FeatureFlagService.GetFeatureFlag:input_type -> config.v1.GetFeatureFlagRequest
+	9,  // 14: config.v1.FeatureFlagService.ListFeatureFlags:input_type -> config.v1.ListFeatureFlagsRequest
+	11, // 15: config.v1.FeatureFlagService.DeleteFeatureFlag:input_type -> config.v1.DeleteFeatureFlagRequest
+	13, // 16: config.v1.FeatureFlagService.SetFeatureFlagOverride:input_type -> config.v1.SetFeatureFlagOverrideRequest
+	14, // 17: config.v1.FeatureFlagService.DeleteFeatureFlagOverride:input_type -> config.v1.DeleteFeatureFlagOverrideRequest
+	15, // 18: config.v1.FeatureFlagService.EvaluateFeatureFlags:input_type -> config.v1.EvaluateFeatureFlagsRequest
+	8,  // 19: config.v1.FeatureFlagService.CreateFeatureFlag:output_type -> config.v1.FeatureFlagResponse
+	8,  // 20: config.v1.FeatureFlagService.UpdateFeatureFlag:output_type -> config.v1.FeatureFlagResponse
+	8,  // 21: config.v1.FeatureFlagService.GetFeatureFlag:output_type -> config.v1.FeatureFlagResponse
+	10, // 22: config.v1.FeatureFlagService.ListFeatureFlags:output_type -> config.v1.ListFeatureFlagsResponse
+	12, // 23: config.v1.FeatureFlagService.DeleteFeatureFlag:output_type -> config.v1.DeleteFeatureFlagResponse
+	8,  // 24: config.v1.FeatureFlagService.SetFeatureFlagOverride:output_type -> config.v1.FeatureFlagResponse
+	8,  // 25: config.v1.FeatureFlagService.DeleteFeatureFlagOverride:output_type -> config.v1.FeatureFlagResponse
+	16, // 26: config.v1.FeatureFlagService.EvaluateFeatureFlags:output_type -> config.v1.EvaluateFeatureFlagsResponse
+	19, // [19:27] is the sub-list for method output_type
+	11, // [11:19] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_config_v1_feature_flag_proto_init() }
@@ -334,13 +1205,14 @@ func file_config_v1_feature_flag_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_v1_feature_flag_proto_rawDesc), len(file_config_v1_feature_flag_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   17,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_config_v1_feature_flag_proto_goTypes,
 		DependencyIndexes: file_config_v1_feature_flag_proto_depIdxs,
+		EnumInfos:         file_config_v1_feature_flag_proto_enumTypes,
 		MessageInfos:      file_config_v1_feature_flag_proto_msgTypes,
 	}.Build()
 	File_config_v1_feature_flag_proto = out.File
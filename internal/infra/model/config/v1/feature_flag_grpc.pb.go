@@ -0,0 +1,387 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: config/v1/feature_flag.proto
+
+package configv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FeatureFlagService_CreateFeatureFlag_FullMethodName         = "/config.v1.FeatureFlagService/CreateFeatureFlag"
+	FeatureFlagService_UpdateFeatureFlag_FullMethodName         = "/config.v1.FeatureFlagService/UpdateFeatureFlag"
+	FeatureFlagService_GetFeatureFlag_FullMethodName            = "/config.v1.FeatureFlagService/GetFeatureFlag"
+	FeatureFlagService_ListFeatureFlags_FullMethodName          = "/config.v1.FeatureFlagService/ListFeatureFlags"
+	FeatureFlagService_DeleteFeatureFlag_FullMethodName         = "/config.v1.FeatureFlagService/DeleteFeatureFlag"
+	FeatureFlagService_SetFeatureFlagOverride_FullMethodName    = "/config.v1.FeatureFlagService/SetFeatureFlagOverride"
+	FeatureFlagService_DeleteFeatureFlagOverride_FullMethodName = "/config.v1.FeatureFlagService/DeleteFeatureFlagOverride"
+	FeatureFlagService_EvaluateFeatureFlags_FullMethodName      = "/config.v1.FeatureFlagService/EvaluateFeatureFlags"
+)
+
+// FeatureFlagServiceClient is the client API for FeatureFlagService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FeatureFlagServiceClient interface {
+	CreateFeatureFlag(ctx context.Context, in *CreateFeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error)
+	UpdateFeatureFlag(ctx context.Context, in *UpdateFeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error)
+	GetFeatureFlag(ctx context.Context, in *GetFeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error)
+	ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error)
+	DeleteFeatureFlag(ctx context.Context, in *DeleteFeatureFlagRequest, opts ...grpc.CallOption) (*DeleteFeatureFlagResponse, error)
+	SetFeatureFlagOverride(ctx context.Context, in *SetFeatureFlagOverrideRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error)
+	DeleteFeatureFlagOverride(ctx context.Context, in *DeleteFeatureFlagOverrideRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error)
+	EvaluateFeatureFlags(ctx context.Context, in *EvaluateFeatureFlagsRequest, opts ...grpc.CallOption) (*EvaluateFeatureFlagsResponse, error)
+}
+
+type featureFlagServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeatureFlagServiceClient(cc grpc.ClientConnInterface) FeatureFlagServiceClient {
+	return &featureFlagServiceClient{cc}
+}
+
+func (c *featureFlagServiceClient) CreateFeatureFlag(ctx context.Context, in *CreateFeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_CreateFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) UpdateFeatureFlag(ctx context.Context, in *UpdateFeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_UpdateFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) GetFeatureFlag(ctx context.Context, in *GetFeatureFlagRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_GetFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_ListFeatureFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) DeleteFeatureFlag(ctx context.Context, in *DeleteFeatureFlagRequest, opts ...grpc.CallOption) (*DeleteFeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_DeleteFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) SetFeatureFlagOverride(ctx context.Context, in *SetFeatureFlagOverrideRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_SetFeatureFlagOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) DeleteFeatureFlagOverride(ctx context.Context, in *DeleteFeatureFlagOverrideRequest, opts ...grpc.CallOption) (*FeatureFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureFlagResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_DeleteFeatureFlagOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureFlagServiceClient) EvaluateFeatureFlags(ctx context.Context, in *EvaluateFeatureFlagsRequest, opts ...grpc.CallOption) (*EvaluateFeatureFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, FeatureFlagService_EvaluateFeatureFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeatureFlagServiceServer is the server API for FeatureFlagService service.
+// All implementations must embed UnimplementedFeatureFlagServiceServer
+// for forward compatibility.
+type FeatureFlagServiceServer interface {
+	CreateFeatureFlag(context.Context, *CreateFeatureFlagRequest) (*FeatureFlagResponse, error)
+	UpdateFeatureFlag(context.Context, *UpdateFeatureFlagRequest) (*FeatureFlagResponse, error)
+	GetFeatureFlag(context.Context, *GetFeatureFlagRequest) (*FeatureFlagResponse, error)
+	ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error)
+	DeleteFeatureFlag(context.Context, *DeleteFeatureFlagRequest) (*DeleteFeatureFlagResponse, error)
+	SetFeatureFlagOverride(context.Context, *SetFeatureFlagOverrideRequest) (*FeatureFlagResponse, error)
+	DeleteFeatureFlagOverride(context.Context, *DeleteFeatureFlagOverrideRequest) (*FeatureFlagResponse, error)
+	EvaluateFeatureFlags(context.Context, *EvaluateFeatureFlagsRequest) (*EvaluateFeatureFlagsResponse, error)
+	mustEmbedUnimplementedFeatureFlagServiceServer()
+}
+
+// UnimplementedFeatureFlagServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFeatureFlagServiceServer struct{}
+
+func (UnimplementedFeatureFlagServiceServer) CreateFeatureFlag(context.Context, *CreateFeatureFlagRequest) (*FeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateFeatureFlag not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) UpdateFeatureFlag(context.Context, *UpdateFeatureFlagRequest) (*FeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateFeatureFlag not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) GetFeatureFlag(context.Context, *GetFeatureFlagRequest) (*FeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeatureFlag not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFeatureFlags not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) DeleteFeatureFlag(context.Context, *DeleteFeatureFlagRequest) (*DeleteFeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFeatureFlag not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) SetFeatureFlagOverride(context.Context, *SetFeatureFlagOverrideRequest) (*FeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetFeatureFlagOverride not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) DeleteFeatureFlagOverride(context.Context, *DeleteFeatureFlagOverrideRequest) (*FeatureFlagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteFeatureFlagOverride not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) EvaluateFeatureFlags(context.Context, *EvaluateFeatureFlagsRequest) (*EvaluateFeatureFlagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EvaluateFeatureFlags not implemented")
+}
+func (UnimplementedFeatureFlagServiceServer) mustEmbedUnimplementedFeatureFlagServiceServer() {}
+func (UnimplementedFeatureFlagServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeFeatureFlagServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeatureFlagServiceServer will
+// result in compilation errors.
+type UnsafeFeatureFlagServiceServer interface {
+	mustEmbedUnimplementedFeatureFlagServiceServer()
+}
+
+func RegisterFeatureFlagServiceServer(s grpc.ServiceRegistrar, srv FeatureFlagServiceServer) {
+	// If the following call panics, it indicates UnimplementedFeatureFlagServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FeatureFlagService_ServiceDesc, srv)
+}
+
+func _FeatureFlagService_CreateFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).CreateFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_CreateFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).CreateFeatureFlag(ctx, req.(*CreateFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_UpdateFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).UpdateFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_UpdateFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).UpdateFeatureFlag(ctx, req.(*UpdateFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_GetFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).GetFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_GetFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).GetFeatureFlag(ctx, req.(*GetFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_ListFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeatureFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).ListFeatureFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_ListFeatureFlags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).ListFeatureFlags(ctx, req.(*ListFeatureFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_DeleteFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).DeleteFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_DeleteFeatureFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).DeleteFeatureFlag(ctx, req.(*DeleteFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_SetFeatureFlagOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeatureFlagOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).SetFeatureFlagOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_SetFeatureFlagOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).SetFeatureFlagOverride(ctx, req.(*SetFeatureFlagOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_DeleteFeatureFlagOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFeatureFlagOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).DeleteFeatureFlagOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_DeleteFeatureFlagOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).DeleteFeatureFlagOverride(ctx, req.(*DeleteFeatureFlagOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureFlagService_EvaluateFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateFeatureFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureFlagServiceServer).EvaluateFeatureFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureFlagService_EvaluateFeatureFlags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureFlagServiceServer).EvaluateFeatureFlags(ctx, req.(*EvaluateFeatureFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FeatureFlagService_ServiceDesc is the grpc.ServiceDesc for FeatureFlagService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeatureFlagService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "config.v1.FeatureFlagService",
+	HandlerType: (*FeatureFlagServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFeatureFlag",
+			Handler:    _FeatureFlagService_CreateFeatureFlag_Handler,
+		},
+		{
+			MethodName: "UpdateFeatureFlag",
+			Handler:    _FeatureFlagService_UpdateFeatureFlag_Handler,
+		},
+		{
+			MethodName: "GetFeatureFlag",
+			Handler:    _FeatureFlagService_GetFeatureFlag_Handler,
+		},
+		{
+			MethodName: "ListFeatureFlags",
+			Handler:    _FeatureFlagService_ListFeatureFlags_Handler,
+		},
+		{
+			MethodName: "DeleteFeatureFlag",
+			Handler:    _FeatureFlagService_DeleteFeatureFlag_Handler,
+		},
+		{
+			MethodName: "SetFeatureFlagOverride",
+			Handler:    _FeatureFlagService_SetFeatureFlagOverride_Handler,
+		},
+		{
+			MethodName: "DeleteFeatureFlagOverride",
+			Handler:    _FeatureFlagService_DeleteFeatureFlagOverride_Handler,
+		},
+		{
+			MethodName: "EvaluateFeatureFlags",
+			Handler:    _FeatureFlagService_EvaluateFeatureFlags_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "config/v1/feature_flag.proto",
+}
@@ -19,10 +19,11 @@ const (
 
 // Payment statuses
 const (
-	PaymentStatusPending  = "pending"
-	PaymentStatusPaid     = "paid"
-	PaymentStatusRefunded = "refunded"
-	PaymentStatusFailed   = "failed"
+	PaymentStatusPending       = "pending"
+	PaymentStatusPaid          = "paid"
+	PaymentStatusRefunded      = "refunded"
+	PaymentStatusFailed        = "failed"
+	PaymentStatusPartiallyPaid = "partially_paid"
 )
 
 // Product statuses
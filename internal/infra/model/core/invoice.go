@@ -0,0 +1,29 @@
+package core
+
+import corev1 "erp.localhost/internal/infra/model/core/v1"
+
+var paymentStatusNames = map[corev1.PaymentStatus]string{
+	corev1.PaymentStatus_PAYMENT_STATUS_PENDING:        PaymentStatusPending,
+	corev1.PaymentStatus_PAYMENT_STATUS_PAID:           PaymentStatusPaid,
+	corev1.PaymentStatus_PAYMENT_STATUS_REFUNDED:       PaymentStatusRefunded,
+	corev1.PaymentStatus_PAYMENT_STATUS_FAILED:         PaymentStatusFailed,
+	corev1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID: PaymentStatusPartiallyPaid,
+}
+
+// PaymentStatusName returns the name of status, e.g. partially_paid, or an empty string for an unknown status
+func PaymentStatusName(status corev1.PaymentStatus) string {
+	return paymentStatusNames[status]
+}
+
+// InvoicePaymentStatus returns the payment status of an invoice of total of which paid was paid, amounts are in
+// cents precision
+func InvoicePaymentStatus(total, paid float64) corev1.PaymentStatus {
+	switch {
+	case paid <= 0 && total > 0:
+		return corev1.PaymentStatus_PAYMENT_STATUS_PENDING
+	case paid < total:
+		return corev1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID
+	default:
+		return corev1.PaymentStatus_PAYMENT_STATUS_PAID
+	}
+}
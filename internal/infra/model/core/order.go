@@ -0,0 +1,41 @@
+package core
+
+import corev1 "erp.localhost/internal/infra/model/core/v1"
+
+var orderStatusNames = map[corev1.OrderStatus]string{
+	corev1.OrderStatus_ORDER_STATUS_DRAFT:     OrderStatusDraft,
+	corev1.OrderStatus_ORDER_STATUS_PENDING:   OrderStatusPending,
+	corev1.OrderStatus_ORDER_STATUS_CONFIRMED: OrderStatusConfirmed,
+	corev1.OrderStatus_ORDER_STATUS_SHIPPED:   OrderStatusShipped,
+	corev1.OrderStatus_ORDER_STATUS_DELIVERED: OrderStatusDelivered,
+	corev1.OrderStatus_ORDER_STATUS_CANCELLED: OrderStatusCancelled,
+}
+
+// orderTransitions are the statuses an order in a status may move to, delivered and cancelled orders are final
+var orderTransitions = map[corev1.OrderStatus][]corev1.OrderStatus{
+	corev1.OrderStatus_ORDER_STATUS_DRAFT:     {corev1.OrderStatus_ORDER_STATUS_PENDING, corev1.OrderStatus_ORDER_STATUS_CANCELLED},
+	corev1.OrderStatus_ORDER_STATUS_PENDING:   {corev1.OrderStatus_ORDER_STATUS_CONFIRMED, corev1.OrderStatus_ORDER_STATUS_CANCELLED},
+	corev1.OrderStatus_ORDER_STATUS_CONFIRMED: {corev1.OrderStatus_ORDER_STATUS_SHIPPED, corev1.OrderStatus_ORDER_STATUS_CANCELLED},
+	corev1.OrderStatus_ORDER_STATUS_SHIPPED:   {corev1.OrderStatus_ORDER_STATUS_DELIVERED},
+}
+
+// OrderStatusName returns the name of status, e.g. confirmed, or an empty string for an unknown status
+func OrderStatusName(status corev1.OrderStatus) string {
+	return orderStatusNames[status]
+}
+
+// CanTransitionOrder reports whether an order in status from may move to status to
+func CanTransitionOrder(from, to corev1.OrderStatus) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinalOrderStatus reports whether an order in status can no longer change
+func IsFinalOrderStatus(status corev1.OrderStatus) bool {
+	_, known := orderStatusNames[status]
+	return known && len(orderTransitions[status]) == 0
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"testing"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionOrder(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     corev1.OrderStatus
+		to       corev1.OrderStatus
+		expected bool
+	}{
+		{name: "submit draft", from: corev1.OrderStatus_ORDER_STATUS_DRAFT, to: corev1.OrderStatus_ORDER_STATUS_PENDING, expected: true},
+		{name: "confirm pending", from: corev1.OrderStatus_ORDER_STATUS_PENDING, to: corev1.OrderStatus_ORDER_STATUS_CONFIRMED, expected: true},
+		{name: "ship confirmed", from: corev1.OrderStatus_ORDER_STATUS_CONFIRMED, to: corev1.OrderStatus_ORDER_STATUS_SHIPPED, expected: true},
+		{name: "deliver shipped", from: corev1.OrderStatus_ORDER_STATUS_SHIPPED, to: corev1.OrderStatus_ORDER_STATUS_DELIVERED, expected: true},
+		{name: "cancel confirmed", from: corev1.OrderStatus_ORDER_STATUS_CONFIRMED, to: corev1.OrderStatus_ORDER_STATUS_CANCELLED, expected: true},
+		{name: "cancel shipped", from: corev1.OrderStatus_ORDER_STATUS_SHIPPED, to: corev1.OrderStatus_ORDER_STATUS_CANCELLED, expected: false},
+		{name: "skip confirmation", from: corev1.OrderStatus_ORDER_STATUS_PENDING, to: corev1.OrderStatus_ORDER_STATUS_SHIPPED, expected: false},
+		{name: "back to draft", from: corev1.OrderStatus_ORDER_STATUS_PENDING, to: corev1.OrderStatus_ORDER_STATUS_DRAFT, expected: false},
+		{name: "reopen cancelled", from: corev1.OrderStatus_ORDER_STATUS_CANCELLED, to: corev1.OrderStatus_ORDER_STATUS_PENDING, expected: false},
+		{name: "same status", from: corev1.OrderStatus_ORDER_STATUS_PENDING, to: corev1.OrderStatus_ORDER_STATUS_PENDING, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CanTransitionOrder(tc.from, tc.to))
+		})
+	}
+}
+
+func TestIsFinalOrderStatus(t *testing.T) {
+	assert.True(t, IsFinalOrderStatus(corev1.OrderStatus_ORDER_STATUS_DELIVERED))
+	assert.True(t, IsFinalOrderStatus(corev1.OrderStatus_ORDER_STATUS_CANCELLED))
+	assert.False(t, IsFinalOrderStatus(corev1.OrderStatus_ORDER_STATUS_SHIPPED))
+	assert.False(t, IsFinalOrderStatus(corev1.OrderStatus_ORDER_STATUS_UNSPECIFIED))
+	assert.Equal(t, OrderStatusConfirmed, OrderStatusName(corev1.OrderStatus_ORDER_STATUS_CONFIRMED))
+}
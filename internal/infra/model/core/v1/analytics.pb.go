@@ -0,0 +1,1232 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: core/v1/analytics.proto
+
+package corev1
+
+import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Report period enum, the length of the buckets of a report over time
+type ReportPeriod int32
+
+const (
+	ReportPeriod_REPORT_PERIOD_UNSPECIFIED ReportPeriod = 0
+	ReportPeriod_REPORT_PERIOD_DAY         ReportPeriod = 1
+	// Weeks start on Monday
+	ReportPeriod_REPORT_PERIOD_WEEK  ReportPeriod = 2
+	ReportPeriod_REPORT_PERIOD_MONTH ReportPeriod = 3
+	ReportPeriod_REPORT_PERIOD_YEAR  ReportPeriod = 4
+)
+
+// Enum value maps for ReportPeriod.
+var (
+	ReportPeriod_name = map[int32]string{
+		0: "REPORT_PERIOD_UNSPECIFIED",
+		1: "REPORT_PERIOD_DAY",
+		2: "REPORT_PERIOD_WEEK",
+		3: "REPORT_PERIOD_MONTH",
+		4: "REPORT_PERIOD_YEAR",
+	}
+	ReportPeriod_value = map[string]int32{
+		"REPORT_PERIOD_UNSPECIFIED": 0,
+		"REPORT_PERIOD_DAY":         1,
+		"REPORT_PERIOD_WEEK":        2,
+		"REPORT_PERIOD_MONTH":       3,
+		"REPORT_PERIOD_YEAR":        4,
+	}
+)
+
+func (x ReportPeriod) Enum() *ReportPeriod {
+	p := new(ReportPeriod)
+	*p = x
+	return p
+}
+
+func (x ReportPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReportPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_analytics_proto_enumTypes[0].Descriptor()
+}
+
+func (ReportPeriod) Type() protoreflect.EnumType {
+	return &file_core_v1_analytics_proto_enumTypes[0]
+}
+
+func (x ReportPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReportPeriod.Descriptor instead.
+func (ReportPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+// Top products sort enum
+type TopProductsSort int32
+
+const (
+	TopProductsSort_TOP_PRODUCTS_SORT_UNSPECIFIED TopProductsSort = 0
+	TopProductsSort_TOP_PRODUCTS_SORT_REVENUE     TopProductsSort = 1
+	TopProductsSort_TOP_PRODUCTS_SORT_QUANTITY    TopProductsSort = 2
+)
+
+// Enum value maps for TopProductsSort.
+var (
+	TopProductsSort_name = map[int32]string{
+		0: "TOP_PRODUCTS_SORT_UNSPECIFIED",
+		1: "TOP_PRODUCTS_SORT_REVENUE",
+		2: "TOP_PRODUCTS_SORT_QUANTITY",
+	}
+	TopProductsSort_value = map[string]int32{
+		"TOP_PRODUCTS_SORT_UNSPECIFIED": 0,
+		"TOP_PRODUCTS_SORT_REVENUE":     1,
+		"TOP_PRODUCTS_SORT_QUANTITY":    2,
+	}
+)
+
+func (x TopProductsSort) Enum() *TopProductsSort {
+	p := new(TopProductsSort)
+	*p = x
+	return p
+}
+
+func (x TopProductsSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopProductsSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_analytics_proto_enumTypes[1].Descriptor()
+}
+
+func (TopProductsSort) Type() protoreflect.EnumType {
+	return &file_core_v1_analytics_proto_enumTypes[1]
+}
+
+func (x TopProductsSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopProductsSort.Descriptor instead.
+func (TopProductsSort) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+// ReportRange bounds the documents a report covers by their creation time, from is inclusive and to exclusive
+type ReportRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportRange) Reset() {
+	*x = ReportRange{}
+	mi := &file_core_v1_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRange) ProtoMessage() {}
+
+func (x *ReportRange) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRange.ProtoReflect.Descriptor instead.
+func (*ReportRange) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportRange) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ReportRange) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// SalesPeriod sums the sales orders created in a period, the amounts are in the base currency of the tenant
+type SalesPeriod struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start" bson:"period_start"`
+	OrderCount        int64                  `protobuf:"varint,2,opt,name=order_count,json=orderCount,proto3" json:"order_count" bson:"order_count"`
+	Revenue           float64                `protobuf:"fixed64,3,opt,name=revenue,proto3" json:"revenue" bson:"revenue"`
+	Discount          float64                `protobuf:"fixed64,4,opt,name=discount,proto3" json:"discount" bson:"discount"`
+	AverageOrderValue float64                `protobuf:"fixed64,5,opt,name=average_order_value,json=averageOrderValue,proto3" json:"average_order_value" bson:"average_order_value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SalesPeriod) Reset() {
+	*x = SalesPeriod{}
+	mi := &file_core_v1_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalesPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalesPeriod) ProtoMessage() {}
+
+func (x *SalesPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalesPeriod.ProtoReflect.Descriptor instead.
+func (*SalesPeriod) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SalesPeriod) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *SalesPeriod) GetOrderCount() int64 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+func (x *SalesPeriod) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *SalesPeriod) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *SalesPeriod) GetAverageOrderValue() float64 {
+	if x != nil {
+		return x.AverageOrderValue
+	}
+	return 0
+}
+
+// ProductSales sums the sold lines of a product, the revenue is in the base currency of the tenant
+type ProductSales struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty" bson:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty" bson:"name,omitempty"`
+	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity" bson:"quantity"`
+	Revenue       float64                `protobuf:"fixed64,5,opt,name=revenue,proto3" json:"revenue" bson:"revenue"`
+	OrderCount    int64                  `protobuf:"varint,6,opt,name=order_count,json=orderCount,proto3" json:"order_count" bson:"order_count"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSales) Reset() {
+	*x = ProductSales{}
+	mi := &file_core_v1_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSales) ProtoMessage() {}
+
+func (x *ProductSales) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSales.ProtoReflect.Descriptor instead.
+func (*ProductSales) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProductSales) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductSales) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductSales) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductSales) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ProductSales) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *ProductSales) GetOrderCount() int64 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+// ProductValuation values the stock of a product at the cost of its stock items
+type ProductValuation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id"`
+	Quantity  int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity" bson:"quantity"`
+	Value     float64                `protobuf:"fixed64,3,opt,name=value,proto3" json:"value" bson:"value"`
+	// value / quantity
+	AverageCost    float64 `protobuf:"fixed64,4,opt,name=average_cost,json=averageCost,proto3" json:"average_cost" bson:"average_cost"`
+	WarehouseCount int64   `protobuf:"varint,5,opt,name=warehouse_count,json=warehouseCount,proto3" json:"warehouse_count" bson:"warehouse_count"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProductValuation) Reset() {
+	*x = ProductValuation{}
+	mi := &file_core_v1_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductValuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductValuation) ProtoMessage() {}
+
+func (x *ProductValuation) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductValuation.ProtoReflect.Descriptor instead.
+func (*ProductValuation) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProductValuation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductValuation) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ProductValuation) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ProductValuation) GetAverageCost() float64 {
+	if x != nil {
+		return x.AverageCost
+	}
+	return 0
+}
+
+func (x *ProductValuation) GetWarehouseCount() int64 {
+	if x != nil {
+		return x.WarehouseCount
+	}
+	return 0
+}
+
+// UserActivity counts the documents a user created
+type UserActivity struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id" bson:"user_id"`
+	OrdersCreated   int64                  `protobuf:"varint,2,opt,name=orders_created,json=ordersCreated,proto3" json:"orders_created" bson:"orders_created"`
+	InvoicesCreated int64                  `protobuf:"varint,3,opt,name=invoices_created,json=invoicesCreated,proto3" json:"invoices_created" bson:"invoices_created"`
+	StockMovements  int64                  `protobuf:"varint,4,opt,name=stock_movements,json=stockMovements,proto3" json:"stock_movements" bson:"stock_movements"`
+	Total           int64                  `protobuf:"varint,5,opt,name=total,proto3" json:"total" bson:"total"`
+	LastActiveAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_active_at,json=lastActiveAt,proto3" json:"last_active_at" bson:"last_active_at"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserActivity) Reset() {
+	*x = UserActivity{}
+	mi := &file_core_v1_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserActivity) ProtoMessage() {}
+
+func (x *UserActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserActivity.ProtoReflect.Descriptor instead.
+func (*UserActivity) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserActivity) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserActivity) GetOrdersCreated() int64 {
+	if x != nil {
+		return x.OrdersCreated
+	}
+	return 0
+}
+
+func (x *UserActivity) GetInvoicesCreated() int64 {
+	if x != nil {
+		return x.InvoicesCreated
+	}
+	return 0
+}
+
+func (x *UserActivity) GetStockMovements() int64 {
+	if x != nil {
+		return x.StockMovements
+	}
+	return 0
+}
+
+func (x *UserActivity) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *UserActivity) GetLastActiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActiveAt
+	}
+	return nil
+}
+
+// =============================================================================
+// Requests
+// =============================================================================
+type SalesByPeriodRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Range      *ReportRange           `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	// Months unless set
+	Period        ReportPeriod          `protobuf:"varint,3,opt,name=period,proto3,enum=core.v1.ReportPeriod" json:"period,omitempty"`
+	Pagination    *v1.PaginationRequest `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SalesByPeriodRequest) Reset() {
+	*x = SalesByPeriodRequest{}
+	mi := &file_core_v1_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalesByPeriodRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalesByPeriodRequest) ProtoMessage() {}
+
+func (x *SalesByPeriodRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalesByPeriodRequest.ProtoReflect.Descriptor instead.
+func (*SalesByPeriodRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SalesByPeriodRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SalesByPeriodRequest) GetRange() *ReportRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *SalesByPeriodRequest) GetPeriod() ReportPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return ReportPeriod_REPORT_PERIOD_UNSPECIFIED
+}
+
+func (x *SalesByPeriodRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SalesByPeriodResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first, the periods without sales are left out
+	Periods []*SalesPeriod `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+	// Base currency of the tenant
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SalesByPeriodResponse) Reset() {
+	*x = SalesByPeriodResponse{}
+	mi := &file_core_v1_analytics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalesByPeriodResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalesByPeriodResponse) ProtoMessage() {}
+
+func (x *SalesByPeriodResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalesByPeriodResponse.ProtoReflect.Descriptor instead.
+func (*SalesByPeriodResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SalesByPeriodResponse) GetPeriods() []*SalesPeriod {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+func (x *SalesByPeriodResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SalesByPeriodResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type TopProductsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Range      *ReportRange           `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	// By revenue unless set
+	Sort          TopProductsSort       `protobuf:"varint,3,opt,name=sort,proto3,enum=core.v1.TopProductsSort" json:"sort,omitempty"`
+	Pagination    *v1.PaginationRequest `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopProductsRequest) Reset() {
+	*x = TopProductsRequest{}
+	mi := &file_core_v1_analytics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopProductsRequest) ProtoMessage() {}
+
+func (x *TopProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopProductsRequest.ProtoReflect.Descriptor instead.
+func (*TopProductsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TopProductsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *TopProductsRequest) GetRange() *ReportRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *TopProductsRequest) GetSort() TopProductsSort {
+	if x != nil {
+		return x.Sort
+	}
+	return TopProductsSort_TOP_PRODUCTS_SORT_UNSPECIFIED
+}
+
+func (x *TopProductsRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type TopProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductSales        `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopProductsResponse) Reset() {
+	*x = TopProductsResponse{}
+	mi := &file_core_v1_analytics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopProductsResponse) ProtoMessage() {}
+
+func (x *TopProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopProductsResponse.ProtoReflect.Descriptor instead.
+func (*TopProductsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TopProductsResponse) GetProducts() []*ProductSales {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *TopProductsResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TopProductsResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type InventoryValuationRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Values the stock of every warehouse unless set
+	WarehouseId   *string               `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3,oneof" json:"warehouse_id,omitempty"`
+	Pagination    *v1.PaginationRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryValuationRequest) Reset() {
+	*x = InventoryValuationRequest{}
+	mi := &file_core_v1_analytics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryValuationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryValuationRequest) ProtoMessage() {}
+
+func (x *InventoryValuationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryValuationRequest.ProtoReflect.Descriptor instead.
+func (*InventoryValuationRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InventoryValuationRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *InventoryValuationRequest) GetWarehouseId() string {
+	if x != nil && x.WarehouseId != nil {
+		return *x.WarehouseId
+	}
+	return ""
+}
+
+func (x *InventoryValuationRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type InventoryValuationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Highest value first
+	Products      []*ProductValuation    `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	TotalQuantity int64                  `protobuf:"varint,2,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
+	TotalValue    float64                `protobuf:"fixed64,3,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryValuationResponse) Reset() {
+	*x = InventoryValuationResponse{}
+	mi := &file_core_v1_analytics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryValuationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryValuationResponse) ProtoMessage() {}
+
+func (x *InventoryValuationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryValuationResponse.ProtoReflect.Descriptor instead.
+func (*InventoryValuationResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InventoryValuationResponse) GetProducts() []*ProductValuation {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *InventoryValuationResponse) GetTotalQuantity() int64 {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return 0
+}
+
+func (x *InventoryValuationResponse) GetTotalValue() float64 {
+	if x != nil {
+		return x.TotalValue
+	}
+	return 0
+}
+
+func (x *InventoryValuationResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UserActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Range         *ReportRange           `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserActivityRequest) Reset() {
+	*x = UserActivityRequest{}
+	mi := &file_core_v1_analytics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserActivityRequest) ProtoMessage() {}
+
+func (x *UserActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserActivityRequest.ProtoReflect.Descriptor instead.
+func (*UserActivityRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UserActivityRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UserActivityRequest) GetRange() *ReportRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *UserActivityRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UserActivityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most active first
+	Users         []*UserActivity        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserActivityResponse) Reset() {
+	*x = UserActivityResponse{}
+	mi := &file_core_v1_analytics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserActivityResponse) ProtoMessage() {}
+
+func (x *UserActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_analytics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserActivityResponse.ProtoReflect.Descriptor instead.
+func (*UserActivityResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_analytics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UserActivityResponse) GetUsers() []*UserActivity {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *UserActivityResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_core_v1_analytics_proto protoreflect.FileDescriptor
+
+const file_core_v1_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x17core/v1/analytics.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x14infra/v1/infra.proto\"i\n" +
+	"\vReportRange\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xb3\x03\n" +
+	"\vSalesPeriod\x12k\n" +
+	"\fperiod_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampB,\x9a\x84\x9e\x03'bson:\"period_start\" json:\"period_start\"R\vperiodStart\x12K\n" +
+	"\vorder_count\x18\x02 \x01(\x03B*\x9a\x84\x9e\x03%bson:\"order_count\" json:\"order_count\"R\n" +
+	"orderCount\x12<\n" +
+	"\arevenue\x18\x03 \x01(\x01B\"\x9a\x84\x9e\x03\x1dbson:\"revenue\" json:\"revenue\"R\arevenue\x12@\n" +
+	"\bdiscount\x18\x04 \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"discount\" json:\"discount\"R\bdiscount\x12j\n" +
+	"\x13average_order_value\x18\x05 \x01(\x01B:\x9a\x84\x9e\x035bson:\"average_order_value\" json:\"average_order_value\"R\x11averageOrderValue\"\xac\x03\n" +
+	"\fProductSales\x12G\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB(\x9a\x84\x9e\x03#bson:\"product_id\" json:\"product_id\"R\tproductId\x12@\n" +
+	"\x03sku\x18\x02 \x01(\tB.\x9a\x84\x9e\x03)bson:\"sku,omitempty\" json:\"sku,omitempty\"R\x03sku\x12D\n" +
+	"\x04name\x18\x03 \x01(\tB0\x9a\x84\x9e\x03+bson:\"name,omitempty\" json:\"name,omitempty\"R\x04name\x12@\n" +
+	"\bquantity\x18\x04 \x01(\x03B$\x9a\x84\x9e\x03\x1fbson:\"quantity\" json:\"quantity\"R\bquantity\x12<\n" +
+	"\arevenue\x18\x05 \x01(\x01B\"\x9a\x84\x9e\x03\x1dbson:\"revenue\" json:\"revenue\"R\arevenue\x12K\n" +
+	"\vorder_count\x18\x06 \x01(\x03B*\x9a\x84\x9e\x03%bson:\"order_count\" json:\"order_count\"R\n" +
+	"orderCount\"\x81\x03\n" +
+	"\x10ProductValuation\x12G\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB(\x9a\x84\x9e\x03#bson:\"product_id\" json:\"product_id\"R\tproductId\x12@\n" +
+	"\bquantity\x18\x02 \x01(\x03B$\x9a\x84\x9e\x03\x1fbson:\"quantity\" json:\"quantity\"R\bquantity\x124\n" +
+	"\x05value\x18\x03 \x01(\x01B\x1e\x9a\x84\x9e\x03\x19bson:\"value\" json:\"value\"R\x05value\x12O\n" +
+	"\faverage_cost\x18\x04 \x01(\x01B,\x9a\x84\x9e\x03'bson:\"average_cost\" json:\"average_cost\"R\vaverageCost\x12[\n" +
+	"\x0fwarehouse_count\x18\x05 \x01(\x03B2\x9a\x84\x9e\x03-bson:\"warehouse_count\" json:\"warehouse_count\"R\x0ewarehouseCount\"\x8c\x04\n" +
+	"\fUserActivity\x12;\n" +
+	"\auser_id\x18\x01 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"user_id\" json:\"user_id\"R\x06userId\x12W\n" +
+	"\x0eorders_created\x18\x02 \x01(\x03B0\x9a\x84\x9e\x03+bson:\"orders_created\" json:\"orders_created\"R\rordersCreated\x12_\n" +
+	"\x10invoices_created\x18\x03 \x01(\x03B4\x9a\x84\x9e\x03/bson:\"invoices_created\" json:\"invoices_created\"R\x0finvoicesCreated\x12[\n" +
+	"\x0fstock_movements\x18\x04 \x01(\x03B2\x9a\x84\x9e\x03-bson:\"stock_movements\" json:\"stock_movements\"R\x0estockMovements\x124\n" +
+	"\x05total\x18\x05 \x01(\x03B\x1e\x9a\x84\x9e\x03\x19bson:\"total\" json:\"total\"R\x05total\x12r\n" +
+	"\x0elast_active_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB0\x9a\x84\x9e\x03+bson:\"last_active_at\" json:\"last_active_at\"R\flastActiveAt\"\x87\x02\n" +
+	"\x14SalesByPeriodRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12*\n" +
+	"\x05range\x18\x02 \x01(\v2\x14.core.v1.ReportRangeR\x05range\x12-\n" +
+	"\x06period\x18\x03 \x01(\x0e2\x15.core.v1.ReportPeriodR\x06period\x12;\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"\xa1\x01\n" +
+	"\x15SalesByPeriodResponse\x12.\n" +
+	"\aperiods\x18\x01 \x03(\v2\x14.core.v1.SalesPeriodR\aperiods\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12<\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\x84\x02\n" +
+	"\x12TopProductsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12*\n" +
+	"\x05range\x18\x02 \x01(\v2\x14.core.v1.ReportRangeR\x05range\x12,\n" +
+	"\x04sort\x18\x03 \x01(\x0e2\x18.core.v1.TopProductsSortR\x04sort\x12;\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"\xa2\x01\n" +
+	"\x13TopProductsResponse\x121\n" +
+	"\bproducts\x18\x01 \x03(\v2\x15.core.v1.ProductSalesR\bproducts\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12<\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xea\x01\n" +
+	"\x19InventoryValuationRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12&\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tH\x00R\vwarehouseId\x88\x01\x01\x12;\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"paginationB\x0f\n" +
+	"\r_warehouse_id\"\xd9\x01\n" +
+	"\x1aInventoryValuationResponse\x125\n" +
+	"\bproducts\x18\x01 \x03(\v2\x19.core.v1.ProductValuationR\bproducts\x12%\n" +
+	"\x0etotal_quantity\x18\x02 \x01(\x03R\rtotalQuantity\x12\x1f\n" +
+	"\vtotal_value\x18\x03 \x01(\x01R\n" +
+	"totalValue\x12<\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xd7\x01\n" +
+	"\x13UserActivityRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12*\n" +
+	"\x05range\x18\x02 \x01(\v2\x14.core.v1.ReportRangeR\x05range\x12;\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"\x81\x01\n" +
+	"\x14UserActivityResponse\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.core.v1.UserActivityR\x05users\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination*\x8d\x01\n" +
+	"\fReportPeriod\x12\x1d\n" +
+	"\x19REPORT_PERIOD_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11REPORT_PERIOD_DAY\x10\x01\x12\x16\n" +
+	"\x12REPORT_PERIOD_WEEK\x10\x02\x12\x17\n" +
+	"\x13REPORT_PERIOD_MONTH\x10\x03\x12\x16\n" +
+	"\x12REPORT_PERIOD_YEAR\x10\x04*s\n" +
+	"\x0fTopProductsSort\x12!\n" +
+	"\x1dTOP_PRODUCTS_SORT_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19TOP_PRODUCTS_SORT_REVENUE\x10\x01\x12\x1e\n" +
+	"\x1aTOP_PRODUCTS_SORT_QUANTITY\x10\x022\xd8\x02\n" +
+	"\x10AnalyticsService\x12N\n" +
+	"\rSalesByPeriod\x12\x1d.core.v1.SalesByPeriodRequest\x1a\x1e.core.v1.SalesByPeriodResponse\x12H\n" +
+	"\vTopProducts\x12\x1b.core.v1.TopProductsRequest\x1a\x1c.core.v1.TopProductsResponse\x12]\n" +
+	"\x12InventoryValuation\x12\".core.v1.InventoryValuationRequest\x1a#.core.v1.InventoryValuationResponse\x12K\n" +
+	"\fUserActivity\x12\x1c.core.v1.UserActivityRequest\x1a\x1d.core.v1.UserActivityResponseB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_analytics_proto_rawDescOnce sync.Once
+	file_core_v1_analytics_proto_rawDescData []byte
+)
+
+func file_core_v1_analytics_proto_rawDescGZIP() []byte {
+	file_core_v1_analytics_proto_rawDescOnce.Do(func() {
+		file_core_v1_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_analytics_proto_rawDesc), len(file_core_v1_analytics_proto_rawDesc)))
+	})
+	return file_core_v1_analytics_proto_rawDescData
+}
+
+var file_core_v1_analytics_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_core_v1_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_core_v1_analytics_proto_goTypes = []any{
+	(ReportPeriod)(0),                  // 0: core.v1.ReportPeriod
+	(TopProductsSort)(0),               // 1: core.v1.TopProductsSort
+	(*ReportRange)(nil),                // 2: core.v1.ReportRange
+	(*SalesPeriod)(nil),                // 3: core.v1.SalesPeriod
+	(*ProductSales)(nil),               // 4: core.v1.ProductSales
+	(*ProductValuation)(nil),           // 5: core.v1.ProductValuation
+	(*UserActivity)(nil),               // 6: core.v1.UserActivity
+	(*SalesByPeriodRequest)(nil),       // 7: core.v1.SalesByPeriodRequest
+	(*SalesByPeriodResponse)(nil),      // 8: core.v1.SalesByPeriodResponse
+	(*TopProductsRequest)(nil),         // 9: core.v1.TopProductsRequest
+	(*TopProductsResponse)(nil),        // 10: core.v1.TopProductsResponse
+	(*InventoryValuationRequest)(nil),  // 11: core.v1.InventoryValuationRequest
+	(*InventoryValuationResponse)(nil), // 12: core.v1.InventoryValuationResponse
+	(*UserActivityRequest)(nil),        // 13: core.v1.UserActivityRequest
+	(*UserActivityResponse)(nil),       // 14: core.v1.UserActivityResponse
+	(*timestamppb.Timestamp)(nil),      // 15: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),          // 16: infra.v1.UserIdentifier
+	(*v1.PaginationRequest)(nil),       // 17: infra.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),      // 18: infra.v1.PaginationResponse
+}
+var file_core_v1_analytics_proto_depIdxs = []int32{
+	15, // 0: core.v1.ReportRange.from:type_name -> google.protobuf.Timestamp
+	15, // 1: core.v1.ReportRange.to:type_name -> google.protobuf.Timestamp
+	15, // 2: core.v1.SalesPeriod.period_start:type_name -> google.protobuf.Timestamp
+	15, // 3: core.v1.UserActivity.last_active_at:type_name -> google.protobuf.Timestamp
+	16, // 4: core.v1.SalesByPeriodRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 5: core.v1.SalesByPeriodRequest.range:type_name -> core.v1.ReportRange
+	0,  // 6: core.v1.SalesByPeriodRequest.period:type_name -> core.v1.ReportPeriod
+	17, // 7: core.v1.SalesByPeriodRequest.pagination:type_name -> infra.v1.PaginationRequest
+	3,  // 8: core.v1.SalesByPeriodResponse.periods:type_name -> core.v1.SalesPeriod
+	18, // 9: core.v1.SalesByPeriodResponse.pagination:type_name -> infra.v1.PaginationResponse
+	16, // 10: core.v1.TopProductsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 11: core.v1.TopProductsRequest.range:type_name -> core.v1.ReportRange
+	1,  // 12: core.v1.TopProductsRequest.sort:type_name -> core.v1.TopProductsSort
+	17, // 13: core.v1.TopProductsRequest.pagination:type_name -> infra.v1.PaginationRequest
+	4,  // 14: core.v1.TopProductsResponse.products:type_name -> core.v1.ProductSales
+	18, // 15: core.v1.TopProductsResponse.pagination:type_name -> infra.v1.PaginationResponse
+	16, // 16: core.v1.InventoryValuationRequest.identifier:type_name -> infra.v1.UserIdentifier
+	17, // 17: core.v1.InventoryValuationRequest.pagination:type_name -> infra.v1.PaginationRequest
+	5,  // 18: core.v1.InventoryValuationResponse.products:type_name -> core.v1.ProductValuation
+	18, // 19: core.v1.InventoryValuationResponse.pagination:type_name -> infra.v1.PaginationResponse
+	16, // 20: core.v1.UserActivityRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 21: core.v1.UserActivityRequest.range:type_name -> core.v1.ReportRange
+	17, // 22: core.v1.UserActivityRequest.pagination:type_name -> infra.v1.PaginationRequest
+	6,  // 23: core.v1.UserActivityResponse.users:type_name -> core.v1.UserActivity
+	18, // 24: core.v1.UserActivityResponse.pagination:type_name -> infra.v1.PaginationResponse
+	7,  // 25: core.v1.AnalyticsService.SalesByPeriod:input_type -> core.v1.SalesByPeriodRequest
+	9,  // 26: core.v1.AnalyticsService.TopProducts:input_type -> core.v1.TopProductsRequest
+	11, // 27: core.v1.AnalyticsService.InventoryValuation:input_type -> core.v1.InventoryValuationRequest
+	13, // 28: core.v1.AnalyticsService.UserActivity:input_type -> core.v1.UserActivityRequest
+	8,  // 29: core.v1.AnalyticsService.SalesByPeriod:output_type -> core.v1.SalesByPeriodResponse
+	10, // 30: core.v1.AnalyticsService.TopProducts:output_type -> core.v1.TopProductsResponse
+	12, // 31: core.v1.AnalyticsService.InventoryValuation:output_type -> core.v1.InventoryValuationResponse
+	14, // 32: core.v1.AnalyticsService.UserActivity:output_type -> core.v1.UserActivityResponse
+	29, // [29:33] is the sub-list for method output_type
+	25, // [25:29] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_analytics_proto_init() }
+func file_core_v1_analytics_proto_init() {
+	if File_core_v1_analytics_proto != nil {
+		return
+	}
+	file_core_v1_analytics_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_analytics_proto_rawDesc), len(file_core_v1_analytics_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_core_v1_analytics_proto_goTypes,
+		DependencyIndexes: file_core_v1_analytics_proto_depIdxs,
+		EnumInfos:         file_core_v1_analytics_proto_enumTypes,
+		MessageInfos:      file_core_v1_analytics_proto_msgTypes,
+	}.Build()
+	File_core_v1_analytics_proto = out.File
+	file_core_v1_analytics_proto_goTypes = nil
+	file_core_v1_analytics_proto_depIdxs = nil
+}
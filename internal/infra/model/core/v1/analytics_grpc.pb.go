@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: core/v1/analytics.proto
+
+package corev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_SalesByPeriod_FullMethodName      = "/core.v1.AnalyticsService/SalesByPeriod"
+	AnalyticsService_TopProducts_FullMethodName        = "/core.v1.AnalyticsService/TopProducts"
+	AnalyticsService_InventoryValuation_FullMethodName = "/core.v1.AnalyticsService/InventoryValuation"
+	AnalyticsService_UserActivity_FullMethodName       = "/core.v1.AnalyticsService/UserActivity"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// =============================================================================
+// Analytics Service
+// =============================================================================
+// The reports cover the last 30 days unless their range is set
+type AnalyticsServiceClient interface {
+	// Sales orders confirmed, shipped or delivered, by the period they were created in
+	SalesByPeriod(ctx context.Context, in *SalesByPeriodRequest, opts ...grpc.CallOption) (*SalesByPeriodResponse, error)
+	TopProducts(ctx context.Context, in *TopProductsRequest, opts ...grpc.CallOption) (*TopProductsResponse, error)
+	// The current stock of the products, the range does not apply
+	InventoryValuation(ctx context.Context, in *InventoryValuationRequest, opts ...grpc.CallOption) (*InventoryValuationResponse, error)
+	// Orders, invoices and stock movements created by each user
+	UserActivity(ctx context.Context, in *UserActivityRequest, opts ...grpc.CallOption) (*UserActivityResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) SalesByPeriod(ctx context.Context, in *SalesByPeriodRequest, opts ...grpc.CallOption) (*SalesByPeriodResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SalesByPeriodResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_SalesByPeriod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) TopProducts(ctx context.Context, in *TopProductsRequest, opts ...grpc.CallOption) (*TopProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopProductsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_TopProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) InventoryValuation(ctx context.Context, in *InventoryValuationRequest, opts ...grpc.CallOption) (*InventoryValuationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InventoryValuationResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_InventoryValuation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) UserActivity(ctx context.Context, in *UserActivityRequest, opts ...grpc.CallOption) (*UserActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserActivityResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_UserActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// =============================================================================
+// Analytics Service
+// =============================================================================
+// The reports cover the last 30 days unless their range is set
+type AnalyticsServiceServer interface {
+	// Sales orders confirmed, shipped or delivered, by the period they were created in
+	SalesByPeriod(context.Context, *SalesByPeriodRequest) (*SalesByPeriodResponse, error)
+	TopProducts(context.Context, *TopProductsRequest) (*TopProductsResponse, error)
+	// The current stock of the products, the range does not apply
+	InventoryValuation(context.Context, *InventoryValuationRequest) (*InventoryValuationResponse, error)
+	// Orders, invoices and stock movements created by each user
+	UserActivity(context.Context, *UserActivityRequest) (*UserActivityResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) SalesByPeriod(context.Context, *SalesByPeriodRequest) (*SalesByPeriodResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SalesByPeriod not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) TopProducts(context.Context, *TopProductsRequest) (*TopProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TopProducts not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) InventoryValuation(context.Context, *InventoryValuationRequest) (*InventoryValuationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InventoryValuation not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) UserActivity(context.Context, *UserActivityRequest) (*UserActivityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UserActivity not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_SalesByPeriod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SalesByPeriodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).SalesByPeriod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_SalesByPeriod_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).SalesByPeriod(ctx, req.(*SalesByPeriodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_TopProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).TopProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_TopProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).TopProducts(ctx, req.(*TopProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_InventoryValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InventoryValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).InventoryValuation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_InventoryValuation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).InventoryValuation(ctx, req.(*InventoryValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_UserActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).UserActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_UserActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).UserActivity(ctx, req.(*UserActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "core.v1.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SalesByPeriod",
+			Handler:    _AnalyticsService_SalesByPeriod_Handler,
+		},
+		{
+			MethodName: "TopProducts",
+			Handler:    _AnalyticsService_TopProducts_Handler,
+		},
+		{
+			MethodName: "InventoryValuation",
+			Handler:    _AnalyticsService_InventoryValuation_Handler,
+		},
+		{
+			MethodName: "UserActivity",
+			Handler:    _AnalyticsService_UserActivity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "core/v1/analytics.proto",
+}
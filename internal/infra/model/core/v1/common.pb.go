@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: core/v1/common.proto
+
+package corev1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Contact is a person at a business partner
+type Contact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name" bson:"name" validate:"required,max=200"`
+	Email string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty" bson:"email,omitempty" validate:"email"`
+	Phone string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty" bson:"phone,omitempty" validate:"max=50"`
+	// e.g. billing, purchasing or sales
+	Role          string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty" bson:"role,omitempty" validate:"max=100"`
+	IsPrimary     bool   `protobuf:"varint,5,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary" bson:"is_primary"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Contact) Reset() {
+	*x = Contact{}
+	mi := &file_core_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contact) ProtoMessage() {}
+
+func (x *Contact) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contact.ProtoReflect.Descriptor instead.
+func (*Contact) Descriptor() ([]byte, []int) {
+	return file_core_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Contact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Contact) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Contact) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Contact) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Contact) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+// PaymentTerms are the terms a business partner pays or is paid on
+type PaymentTerms struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Free text terms, e.g. NET30
+	Terms string `protobuf:"bytes,1,opt,name=terms,proto3" json:"terms" bson:"terms" validate:"max=100"`
+	// Credit a vendor grants the tenant, the credit of a customer is its own credit_limit
+	CreditLimit float64 `protobuf:"fixed64,2,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit" bson:"credit_limit" validate:"min=0"`
+	// ISO 4217 code, e.g. USD
+	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency" bson:"currency" validate:"min=3,max=3"`
+	// Days after invoicing a payment is due
+	NetDays       int32 `protobuf:"varint,4,opt,name=net_days,json=netDays,proto3" json:"net_days" bson:"net_days" validate:"min=0,max=365"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentTerms) Reset() {
+	*x = PaymentTerms{}
+	mi := &file_core_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentTerms) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentTerms) ProtoMessage() {}
+
+func (x *PaymentTerms) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentTerms.ProtoReflect.Descriptor instead.
+func (*PaymentTerms) Descriptor() ([]byte, []int) {
+	return file_core_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaymentTerms) GetTerms() string {
+	if x != nil {
+		return x.Terms
+	}
+	return ""
+}
+
+func (x *PaymentTerms) GetCreditLimit() float64 {
+	if x != nil {
+		return x.CreditLimit
+	}
+	return 0
+}
+
+func (x *PaymentTerms) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentTerms) GetNetDays() int32 {
+	if x != nil {
+		return x.NetDays
+	}
+	return 0
+}
+
+var File_core_v1_common_proto protoreflect.FileDescriptor
+
+const file_core_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x14core/v1/common.proto\x12\acore.v1\x1a\x13tagger/tagger.proto\"\xb0\x03\n" +
+	"\aContact\x12L\n" +
+	"\x04name\x18\x01 \x01(\tB8\x9a\x84\x9e\x033bson:\"name\" json:\"name\" validate:\"required,max=200\"R\x04name\x12Y\n" +
+	"\x05email\x18\x02 \x01(\tBC\x9a\x84\x9e\x03>bson:\"email,omitempty\" json:\"email,omitempty\" validate:\"email\"R\x05email\x12Z\n" +
+	"\x05phone\x18\x03 \x01(\tBD\x9a\x84\x9e\x03?bson:\"phone,omitempty\" json:\"phone,omitempty\" validate:\"max=50\"R\x05phone\x12W\n" +
+	"\x04role\x18\x04 \x01(\tBC\x9a\x84\x9e\x03>bson:\"role,omitempty\" json:\"role,omitempty\" validate:\"max=100\"R\x04role\x12G\n" +
+	"\n" +
+	"is_primary\x18\x05 \x01(\bB(\x9a\x84\x9e\x03#bson:\"is_primary\" json:\"is_primary\"R\tisPrimary\"\xec\x02\n" +
+	"\fPaymentTerms\x12G\n" +
+	"\x05terms\x18\x01 \x01(\tB1\x9a\x84\x9e\x03,bson:\"terms\" json:\"terms\" validate:\"max=100\"R\x05terms\x12`\n" +
+	"\fcredit_limit\x18\x02 \x01(\x01B=\x9a\x84\x9e\x038bson:\"credit_limit\" json:\"credit_limit\" validate:\"min=0\"R\vcreditLimit\x12W\n" +
+	"\bcurrency\x18\x03 \x01(\tB;\x9a\x84\x9e\x036bson:\"currency\" json:\"currency\" validate:\"min=3,max=3\"R\bcurrency\x12X\n" +
+	"\bnet_days\x18\x04 \x01(\x05B=\x9a\x84\x9e\x038bson:\"net_days\" json:\"net_days\" validate:\"min=0,max=365\"R\anetDaysB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_common_proto_rawDescOnce sync.Once
+	file_core_v1_common_proto_rawDescData []byte
+)
+
+func file_core_v1_common_proto_rawDescGZIP() []byte {
+	file_core_v1_common_proto_rawDescOnce.Do(func() {
+		file_core_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_common_proto_rawDesc), len(file_core_v1_common_proto_rawDesc)))
+	})
+	return file_core_v1_common_proto_rawDescData
+}
+
+var file_core_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_core_v1_common_proto_goTypes = []any{
+	(*Contact)(nil),      // 0: core.v1.Contact
+	(*PaymentTerms)(nil), // 1: core.v1.PaymentTerms
+}
+var file_core_v1_common_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_common_proto_init() }
+func file_core_v1_common_proto_init() {
+	if File_core_v1_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_common_proto_rawDesc), len(file_core_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_v1_common_proto_goTypes,
+		DependencyIndexes: file_core_v1_common_proto_depIdxs,
+		MessageInfos:      file_core_v1_common_proto_msgTypes,
+	}.Build()
+	File_core_v1_common_proto = out.File
+	file_core_v1_common_proto_goTypes = nil
+	file_core_v1_common_proto_depIdxs = nil
+}
@@ -0,0 +1,1094 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: core/v1/currency.proto
+
+package corev1
+
+import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CurrencySettings model for MongoDB core_db.currency_settings collection
+// The amounts of the orders and invoices of a tenant are also recorded in its base currency
+type CurrencySettings struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	// ISO 4217 code, e.g. USD
+	BaseCurrency  string                 `protobuf:"bytes,3,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency" bson:"base_currency" validate:"required,min=3,max=3"`
+	UpdatedBy     string                 `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty" bson:"updated_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencySettings) Reset() {
+	*x = CurrencySettings{}
+	mi := &file_core_v1_currency_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencySettings) ProtoMessage() {}
+
+func (x *CurrencySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencySettings.ProtoReflect.Descriptor instead.
+func (*CurrencySettings) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CurrencySettings) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CurrencySettings) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CurrencySettings) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *CurrencySettings) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *CurrencySettings) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ExchangeRate model for MongoDB core_db.exchange_rates collection
+// One unit of the base currency is worth rate units of the quote currency from effective_at until the next rate of
+// the pair, the rates are kept for the amounts of the past
+type ExchangeRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	BaseCurrency  string                 `protobuf:"bytes,3,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency" bson:"base_currency" validate:"required,min=3,max=3"`
+	QuoteCurrency string                 `protobuf:"bytes,4,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency" bson:"quote_currency" validate:"required,min=3,max=3"`
+	Rate          float64                `protobuf:"fixed64,5,opt,name=rate,proto3" json:"rate" bson:"rate" validate:"positive"`
+	EffectiveAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at" bson:"effective_at" validate:"required"`
+	// manual, or the name of the provider the rate was imported from
+	Source        string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source" bson:"source" validate:"required,max=50"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRate) Reset() {
+	*x = ExchangeRate{}
+	mi := &file_core_v1_currency_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRate) ProtoMessage() {}
+
+func (x *ExchangeRate) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRate.ProtoReflect.Descriptor instead.
+func (*ExchangeRate) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExchangeRate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ExchangeRate) GetEffectiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return nil
+}
+
+func (x *ExchangeRate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ExchangeRate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ExchangeRateSearch filters the exchange rates of a tenant, every set criterion must match
+type ExchangeRateSearch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseCurrency  *string                `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3,oneof" json:"base_currency,omitempty"`
+	QuoteCurrency *string                `protobuf:"bytes,2,opt,name=quote_currency,json=quoteCurrency,proto3,oneof" json:"quote_currency,omitempty"`
+	// Inclusive lower and exclusive upper bound of effective_at
+	EffectiveAfter  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=effective_after,json=effectiveAfter,proto3" json:"effective_after,omitempty"`
+	EffectiveBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=effective_before,json=effectiveBefore,proto3" json:"effective_before,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExchangeRateSearch) Reset() {
+	*x = ExchangeRateSearch{}
+	mi := &file_core_v1_currency_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRateSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRateSearch) ProtoMessage() {}
+
+func (x *ExchangeRateSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRateSearch.ProtoReflect.Descriptor instead.
+func (*ExchangeRateSearch) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExchangeRateSearch) GetBaseCurrency() string {
+	if x != nil && x.BaseCurrency != nil {
+		return *x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRateSearch) GetQuoteCurrency() string {
+	if x != nil && x.QuoteCurrency != nil {
+		return *x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRateSearch) GetEffectiveAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveAfter
+	}
+	return nil
+}
+
+func (x *ExchangeRateSearch) GetEffectiveBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveBefore
+	}
+	return nil
+}
+
+// =============================================================================
+// Requests
+// =============================================================================
+type GetCurrencySettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrencySettingsRequest) Reset() {
+	*x = GetCurrencySettingsRequest{}
+	mi := &file_core_v1_currency_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrencySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrencySettingsRequest) ProtoMessage() {}
+
+func (x *GetCurrencySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrencySettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrencySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCurrencySettingsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type GetCurrencySettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *CurrencySettings      `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrencySettingsResponse) Reset() {
+	*x = GetCurrencySettingsResponse{}
+	mi := &file_core_v1_currency_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrencySettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrencySettingsResponse) ProtoMessage() {}
+
+func (x *GetCurrencySettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrencySettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrencySettingsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCurrencySettingsResponse) GetSettings() *CurrencySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateCurrencySettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	BaseCurrency  string                 `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty" validate:"required,min=3,max=3"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCurrencySettingsRequest) Reset() {
+	*x = UpdateCurrencySettingsRequest{}
+	mi := &file_core_v1_currency_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCurrencySettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCurrencySettingsRequest) ProtoMessage() {}
+
+func (x *UpdateCurrencySettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCurrencySettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCurrencySettingsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCurrencySettingsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateCurrencySettingsRequest) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+type UpdateCurrencySettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *CurrencySettings      `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCurrencySettingsResponse) Reset() {
+	*x = UpdateCurrencySettingsResponse{}
+	mi := &file_core_v1_currency_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCurrencySettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCurrencySettingsResponse) ProtoMessage() {}
+
+func (x *UpdateCurrencySettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCurrencySettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCurrencySettingsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCurrencySettingsResponse) GetSettings() *CurrencySettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type CreateExchangeRateRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Effective now unless effective_at is set
+	Rate          *ExchangeRate `protobuf:"bytes,2,opt,name=rate,proto3" json:"rate,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExchangeRateRequest) Reset() {
+	*x = CreateExchangeRateRequest{}
+	mi := &file_core_v1_currency_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExchangeRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExchangeRateRequest) ProtoMessage() {}
+
+func (x *CreateExchangeRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExchangeRateRequest.ProtoReflect.Descriptor instead.
+func (*CreateExchangeRateRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateExchangeRateRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateExchangeRateRequest) GetRate() *ExchangeRate {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+type CreateExchangeRateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rate          *ExchangeRate          `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExchangeRateResponse) Reset() {
+	*x = CreateExchangeRateResponse{}
+	mi := &file_core_v1_currency_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExchangeRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExchangeRateResponse) ProtoMessage() {}
+
+func (x *CreateExchangeRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExchangeRateResponse.ProtoReflect.Descriptor instead.
+func (*CreateExchangeRateResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateExchangeRateResponse) GetRate() *ExchangeRate {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+type SearchExchangeRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Search        *ExchangeRateSearch    `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchExchangeRatesRequest) Reset() {
+	*x = SearchExchangeRatesRequest{}
+	mi := &file_core_v1_currency_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchExchangeRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchExchangeRatesRequest) ProtoMessage() {}
+
+func (x *SearchExchangeRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchExchangeRatesRequest.ProtoReflect.Descriptor instead.
+func (*SearchExchangeRatesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchExchangeRatesRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SearchExchangeRatesRequest) GetSearch() *ExchangeRateSearch {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *SearchExchangeRatesRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchExchangeRatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Latest first
+	Rates         []*ExchangeRate        `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchExchangeRatesResponse) Reset() {
+	*x = SearchExchangeRatesResponse{}
+	mi := &file_core_v1_currency_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchExchangeRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchExchangeRatesResponse) ProtoMessage() {}
+
+func (x *SearchExchangeRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchExchangeRatesResponse.ProtoReflect.Descriptor instead.
+func (*SearchExchangeRatesResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchExchangeRatesResponse) GetRates() []*ExchangeRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *SearchExchangeRatesResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ConvertAmountRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Identifier   *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Amount       float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	FromCurrency string                 `protobuf:"bytes,3,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty" validate:"required,min=3,max=3"`
+	ToCurrency   string                 `protobuf:"bytes,4,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty" validate:"required,min=3,max=3"`
+	// Converted with the rates effective at the time, now unless set
+	At            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertAmountRequest) Reset() {
+	*x = ConvertAmountRequest{}
+	mi := &file_core_v1_currency_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertAmountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertAmountRequest) ProtoMessage() {}
+
+func (x *ConvertAmountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertAmountRequest.ProtoReflect.Descriptor instead.
+func (*ConvertAmountRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConvertAmountRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ConvertAmountRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ConvertAmountRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAmountRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAmountRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type ConvertAmountResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Rounded to cents
+	Amount float64 `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Units of to_currency one unit of from_currency is worth
+	Rate          float64 `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertAmountResponse) Reset() {
+	*x = ConvertAmountResponse{}
+	mi := &file_core_v1_currency_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertAmountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertAmountResponse) ProtoMessage() {}
+
+func (x *ConvertAmountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertAmountResponse.ProtoReflect.Descriptor instead.
+func (*ConvertAmountResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConvertAmountResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ConvertAmountResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type ImportExchangeRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportExchangeRatesRequest) Reset() {
+	*x = ImportExchangeRatesRequest{}
+	mi := &file_core_v1_currency_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportExchangeRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportExchangeRatesRequest) ProtoMessage() {}
+
+func (x *ImportExchangeRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportExchangeRatesRequest.ProtoReflect.Descriptor instead.
+func (*ImportExchangeRatesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImportExchangeRatesRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type ImportExchangeRatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The rates of the base currency of the tenant returned by the provider, effective now
+	Rates         []*ExchangeRate `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportExchangeRatesResponse) Reset() {
+	*x = ImportExchangeRatesResponse{}
+	mi := &file_core_v1_currency_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportExchangeRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportExchangeRatesResponse) ProtoMessage() {}
+
+func (x *ImportExchangeRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_currency_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportExchangeRatesResponse.ProtoReflect.Descriptor instead.
+func (*ImportExchangeRatesResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_currency_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportExchangeRatesResponse) GetRates() []*ExchangeRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+var File_core_v1_currency_proto protoreflect.FileDescriptor
+
+const file_core_v1_currency_proto_rawDesc = "" +
+	"\n" +
+	"\x16core/v1/currency.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x14infra/v1/infra.proto\"\xd7\x03\n" +
+	"\x10CurrencySettings\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12s\n" +
+	"\rbase_currency\x18\x03 \x01(\tBN\x9a\x84\x9e\x03Ibson:\"base_currency\" json:\"base_currency\" validate:\"required,min=3,max=3\"R\fbaseCurrency\x12[\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tB<\x9a\x84\x9e\x037bson:\"updated_by,omitempty\" json:\"updated_by,omitempty\"R\tupdatedBy\x12c\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\"\xe8\x06\n" +
+	"\fExchangeRate\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12s\n" +
+	"\rbase_currency\x18\x03 \x01(\tBN\x9a\x84\x9e\x03Ibson:\"base_currency\" json:\"base_currency\" validate:\"required,min=3,max=3\"R\fbaseCurrency\x12w\n" +
+	"\x0equote_currency\x18\x04 \x01(\tBP\x9a\x84\x9e\x03Kbson:\"quote_currency\" json:\"quote_currency\" validate:\"required,min=3,max=3\"R\rquoteCurrency\x12D\n" +
+	"\x04rate\x18\x05 \x01(\x01B0\x9a\x84\x9e\x03+bson:\"rate\" json:\"rate\" validate:\"positive\"R\x04rate\x12\x7f\n" +
+	"\feffective_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB@\x9a\x84\x9e\x03;bson:\"effective_at\" json:\"effective_at\" validate:\"required\"R\veffectiveAt\x12S\n" +
+	"\x06source\x18\a \x01(\tB;\x9a\x84\x9e\x036bson:\"source\" json:\"source\" validate:\"required,max=50\"R\x06source\x12[\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12c\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\"\x9b\x02\n" +
+	"\x12ExchangeRateSearch\x12(\n" +
+	"\rbase_currency\x18\x01 \x01(\tH\x00R\fbaseCurrency\x88\x01\x01\x12*\n" +
+	"\x0equote_currency\x18\x02 \x01(\tH\x01R\rquoteCurrency\x88\x01\x01\x12C\n" +
+	"\x0feffective_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0eeffectiveAfter\x12E\n" +
+	"\x10effective_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0feffectiveBeforeB\x10\n" +
+	"\x0e_base_currencyB\x11\n" +
+	"\x0f_quote_currency\"u\n" +
+	"\x1aGetCurrencySettingsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"T\n" +
+	"\x1bGetCurrencySettingsResponse\x125\n" +
+	"\bsettings\x18\x01 \x01(\v2\x19.core.v1.CurrencySettingsR\bsettings\"\xc3\x01\n" +
+	"\x1dUpdateCurrencySettingsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12I\n" +
+	"\rbase_currency\x18\x02 \x01(\tB$\x9a\x84\x9e\x03\x1fvalidate:\"required,min=3,max=3\"R\fbaseCurrency\"W\n" +
+	"\x1eUpdateCurrencySettingsResponse\x125\n" +
+	"\bsettings\x18\x01 \x01(\v2\x19.core.v1.CurrencySettingsR\bsettings\"\xb9\x01\n" +
+	"\x19CreateExchangeRateRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12C\n" +
+	"\x04rate\x18\x02 \x01(\v2\x15.core.v1.ExchangeRateB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x04rate\"G\n" +
+	"\x1aCreateExchangeRateResponse\x12)\n" +
+	"\x04rate\x18\x01 \x01(\v2\x15.core.v1.ExchangeRateR\x04rate\"\xe7\x01\n" +
+	"\x1aSearchExchangeRatesRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x123\n" +
+	"\x06search\x18\x02 \x01(\v2\x1b.core.v1.ExchangeRateSearchR\x06search\x12;\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"\x88\x01\n" +
+	"\x1bSearchExchangeRatesResponse\x12+\n" +
+	"\x05rates\x18\x01 \x03(\v2\x15.core.v1.ExchangeRateR\x05rates\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xc5\x02\n" +
+	"\x14ConvertAmountRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12I\n" +
+	"\rfrom_currency\x18\x03 \x01(\tB$\x9a\x84\x9e\x03\x1fvalidate:\"required,min=3,max=3\"R\ffromCurrency\x12E\n" +
+	"\vto_currency\x18\x04 \x01(\tB$\x9a\x84\x9e\x03\x1fvalidate:\"required,min=3,max=3\"R\n" +
+	"toCurrency\x12*\n" +
+	"\x02at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\"C\n" +
+	"\x15ConvertAmountResponse\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\"u\n" +
+	"\x1aImportExchangeRatesRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"J\n" +
+	"\x1bImportExchangeRatesResponse\x12+\n" +
+	"\x05rates\x18\x01 \x03(\v2\x15.core.v1.ExchangeRateR\x05rates2\xd1\x04\n" +
+	"\x0fCurrencyService\x12`\n" +
+	"\x13GetCurrencySettings\x12#.core.v1.GetCurrencySettingsRequest\x1a$.core.v1.GetCurrencySettingsResponse\x12i\n" +
+	"\x16UpdateCurrencySettings\x12&.core.v1.UpdateCurrencySettingsRequest\x1a'.core.v1.UpdateCurrencySettingsResponse\x12]\n" +
+	"\x12CreateExchangeRate\x12\".core.v1.CreateExchangeRateRequest\x1a#.core.v1.CreateExchangeRateResponse\x12`\n" +
+	"\x13SearchExchangeRates\x12#.core.v1.SearchExchangeRatesRequest\x1a$.core.v1.SearchExchangeRatesResponse\x12N\n" +
+	"\rConvertAmount\x12\x1d.core.v1.ConvertAmountRequest\x1a\x1e.core.v1.ConvertAmountResponse\x12`\n" +
+	"\x13ImportExchangeRates\x12#.core.v1.ImportExchangeRatesRequest\x1a$.core.v1.ImportExchangeRatesResponseB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_currency_proto_rawDescOnce sync.Once
+	file_core_v1_currency_proto_rawDescData []byte
+)
+
+func file_core_v1_currency_proto_rawDescGZIP() []byte {
+	file_core_v1_currency_proto_rawDescOnce.Do(func() {
+		file_core_v1_currency_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_currency_proto_rawDesc), len(file_core_v1_currency_proto_rawDesc)))
+	})
+	return file_core_v1_currency_proto_rawDescData
+}
+
+var file_core_v1_currency_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_core_v1_currency_proto_goTypes = []any{
+	(*CurrencySettings)(nil),               // 0: core.v1.CurrencySettings
+	(*ExchangeRate)(nil),                   // 1: core.v1.ExchangeRate
+	(*ExchangeRateSearch)(nil),             // 2: core.v1.ExchangeRateSearch
+	(*GetCurrencySettingsRequest)(nil),     // 3: core.v1.GetCurrencySettingsRequest
+	(*GetCurrencySettingsResponse)(nil),    // 4: core.v1.GetCurrencySettingsResponse
+	(*UpdateCurrencySettingsRequest)(nil),  // 5: core.v1.UpdateCurrencySettingsRequest
+	(*UpdateCurrencySettingsResponse)(nil), // 6: core.v1.UpdateCurrencySettingsResponse
+	(*CreateExchangeRateRequest)(nil),      // 7: core.v1.CreateExchangeRateRequest
+	(*CreateExchangeRateResponse)(nil),     // 8: core.v1.CreateExchangeRateResponse
+	(*SearchExchangeRatesRequest)(nil),     // 9: core.v1.SearchExchangeRatesRequest
+	(*SearchExchangeRatesResponse)(nil),    // 10: core.v1.SearchExchangeRatesResponse
+	(*ConvertAmountRequest)(nil),           // 11: core.v1.ConvertAmountRequest
+	(*ConvertAmountResponse)(nil),          // 12: core.v1.ConvertAmountResponse
+	(*ImportExchangeRatesRequest)(nil),     // 13: core.v1.ImportExchangeRatesRequest
+	(*ImportExchangeRatesResponse)(nil),    // 14: core.v1.ImportExchangeRatesResponse
+	(*timestamppb.Timestamp)(nil),          // 15: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),              // 16: infra.v1.UserIdentifier
+	(*v1.PaginationRequest)(nil),           // 17: infra.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),          // 18: infra.v1.PaginationResponse
+}
+var file_core_v1_currency_proto_depIdxs = []int32{
+	15, // 0: core.v1.CurrencySettings.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 1: core.v1.ExchangeRate.effective_at:type_name -> google.protobuf.Timestamp
+	15, // 2: core.v1.ExchangeRate.created_at:type_name -> google.protobuf.Timestamp
+	15, // 3: core.v1.ExchangeRateSearch.effective_after:type_name -> google.protobuf.Timestamp
+	15, // 4: core.v1.ExchangeRateSearch.effective_before:type_name -> google.protobuf.Timestamp
+	16, // 5: core.v1.GetCurrencySettingsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 6: core.v1.GetCurrencySettingsResponse.settings:type_name -> core.v1.CurrencySettings
+	16, // 7: core.v1.UpdateCurrencySettingsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 8: core.v1.UpdateCurrencySettingsResponse.settings:type_name -> core.v1.CurrencySettings
+	16, // 9: core.v1.CreateExchangeRateRequest.identifier:type_name -> infra.v1.UserIdentifier
+	1,  // 10: core.v1.CreateExchangeRateRequest.rate:type_name -> core.v1.ExchangeRate
+	1,  // 11: core.v1.CreateExchangeRateResponse.rate:type_name -> core.v1.ExchangeRate
+	16, // 12: core.v1.SearchExchangeRatesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 13: core.v1.SearchExchangeRatesRequest.search:type_name -> core.v1.ExchangeRateSearch
+	17, // 14: core.v1.SearchExchangeRatesRequest.pagination:type_name -> infra.v1.PaginationRequest
+	1,  // 15: core.v1.SearchExchangeRatesResponse.rates:type_name -> core.v1.ExchangeRate
+	18, // 16: core.v1.SearchExchangeRatesResponse.pagination:type_name -> infra.v1.PaginationResponse
+	16, // 17: core.v1.ConvertAmountRequest.identifier:type_name -> infra.v1.UserIdentifier
+	15, // 18: core.v1.ConvertAmountRequest.at:type_name -> google.protobuf.Timestamp
+	16, // 19: core.v1.ImportExchangeRatesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	1,  // 20: core.v1.ImportExchangeRatesResponse.rates:type_name -> core.v1.ExchangeRate
+	3,  // 21: core.v1.CurrencyService.GetCurrencySettings:input_type -> core.v1.GetCurrencySettingsRequest
+	5,  // 22: core.v1.CurrencyService.UpdateCurrencySettings:input_type -> core.v1.UpdateCurrencySettingsRequest
+	7,  // 23: core.v1.CurrencyService.CreateExchangeRate:input_type -> core.v1.CreateExchangeRateRequest
+	9,  // 24: core.v1.CurrencyService.SearchExchangeRates:input_type -> core.v1.SearchExchangeRatesRequest
+	11, // 25: core.v1.CurrencyService.ConvertAmount:input_type -> core.v1.ConvertAmountRequest
+	13, // 26: core.v1.CurrencyService.ImportExchangeRates:input_type -> core.v1.ImportExchangeRatesRequest
+	4,  // 27: core.v1.CurrencyService.GetCurrencySettings:output_type -> core.v1.GetCurrencySettingsResponse
+	6,  // 28: core.v1.CurrencyService.UpdateCurrencySettings:output_type -> core.v1.UpdateCurrencySettingsResponse
+	8,  // 29: core.v1.CurrencyService.CreateExchangeRate:output_type -> core.v1.CreateExchangeRateResponse
+	10, // 30: core.v1.CurrencyService.SearchExchangeRates:output_type -> core.v1.SearchExchangeRatesResponse
+	12, // 31: core.v1.CurrencyService.ConvertAmount:output_type -> core.v1.ConvertAmountResponse
+	14, // 32: core.v1.CurrencyService.ImportExchangeRates:output_type -> core.v1.ImportExchangeRatesResponse
+	27, // [27:33] is the sub-list for method output_type
+	21, // [21:27] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_currency_proto_init() }
+func file_core_v1_currency_proto_init() {
+	if File_core_v1_currency_proto != nil {
+		return
+	}
+	file_core_v1_currency_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_currency_proto_rawDesc), len(file_core_v1_currency_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_core_v1_currency_proto_goTypes,
+		DependencyIndexes: file_core_v1_currency_proto_depIdxs,
+		MessageInfos:      file_core_v1_currency_proto_msgTypes,
+	}.Build()
+	File_core_v1_currency_proto = out.File
+	file_core_v1_currency_proto_goTypes = nil
+	file_core_v1_currency_proto_depIdxs = nil
+}
@@ -178,27 +178,35 @@ func (CustomerAddressType) EnumDescriptor() ([]byte, []int) {
 }
 
 // Customer model for MongoDB core_db.customers collection
+// The email and company tax ID of a customer are unique within its tenant
 type Customer struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
 	CustomerId     string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id" bson:"customer_id"`
-	TenantId       string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	Type           CustomerType           `protobuf:"varint,4,opt,name=type,proto3,enum=core.v1.CustomerType" json:"type" bson:"type"`
-	Name           string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name" bson:"name"`
-	Email          string                 `protobuf:"bytes,6,opt,name=email,proto3" json:"email" bson:"email"`
-	Phone          string                 `protobuf:"bytes,7,opt,name=phone,proto3" json:"phone" bson:"phone"`
-	Company        *CompanyInfo           `protobuf:"bytes,8,opt,name=company,proto3" json:"company,omitempty" bson:"company,omitempty"`
-	Addresses      []*CustomerAddress     `protobuf:"bytes,9,rep,name=addresses,proto3" json:"addresses" bson:"addresses"`
+	TenantId       string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Type           CustomerType           `protobuf:"varint,4,opt,name=type,proto3,enum=core.v1.CustomerType" json:"type" bson:"type" validate:"required"`
+	Name           string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name" bson:"name" validate:"required,max=200"`
+	Email          string                 `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty" bson:"email,omitempty" validate:"email"`
+	Phone          string                 `protobuf:"bytes,7,opt,name=phone,proto3" json:"phone,omitempty" bson:"phone,omitempty" validate:"max=50"`
+	Company        *CompanyInfo           `protobuf:"bytes,8,opt,name=company,proto3" json:"company,omitempty" bson:"company,omitempty" validate:"dive"`
+	Addresses      []*CustomerAddress     `protobuf:"bytes,9,rep,name=addresses,proto3" json:"addresses" bson:"addresses" validate:"max=20,dive"`
 	PaymentMethods []*PaymentMethod       `protobuf:"bytes,10,rep,name=payment_methods,json=paymentMethods,proto3" json:"payment_methods,omitempty" bson:"payment_methods,omitempty"`
-	CreditLimit    float64                `protobuf:"fixed64,11,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit" bson:"credit_limit"`
-	Status         CustomerStatus         `protobuf:"varint,12,opt,name=status,proto3,enum=core.v1.CustomerStatus" json:"status" bson:"status"`
+	CreditLimit    float64                `protobuf:"fixed64,11,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit" bson:"credit_limit" validate:"min=0"`
+	Status         CustomerStatus         `protobuf:"varint,12,opt,name=status,proto3,enum=core.v1.CustomerStatus" json:"status" bson:"status" validate:"required"`
 	LifetimeValue  float64                `protobuf:"fixed64,13,opt,name=lifetime_value,json=lifetimeValue,proto3" json:"lifetime_value" bson:"lifetime_value"`
 	TotalOrders    int32                  `protobuf:"varint,14,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders" bson:"total_orders"`
 	LastOrderDate  *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=last_order_date,json=lastOrderDate,proto3" json:"last_order_date,omitempty" bson:"last_order_date,omitempty"`
 	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
 	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	Contacts       []*Contact             `protobuf:"bytes,18,rep,name=contacts,proto3" json:"contacts,omitempty" bson:"contacts,omitempty" validate:"max=50,dive"`
+	PaymentTerms   *PaymentTerms          `protobuf:"bytes,19,opt,name=payment_terms,json=paymentTerms,proto3" json:"payment_terms,omitempty" bson:"payment_terms,omitempty" validate:"dive"`
+	CreatedBy      string                 `protobuf:"bytes,20,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	// Incremented by every update, a customer is updated at the version it was read at
+	Version int64 `protobuf:"varint,21,opt,name=version,proto3" json:"version" bson:"version"`
+	// Selects the pricing rules of the group for the orders of the customer, e.g. wholesale
+	CustomerGroup string `protobuf:"bytes,22,opt,name=customer_group,json=customerGroup,proto3" json:"customer_group,omitempty" bson:"customer_group,omitempty" validate:"max=50"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Customer) Reset() {
@@ -350,10 +358,45 @@ func (x *Customer) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Customer) GetContacts() []*Contact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *Customer) GetPaymentTerms() *PaymentTerms {
+	if x != nil {
+		return x.PaymentTerms
+	}
+	return nil
+}
+
+func (x *Customer) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Customer) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Customer) GetCustomerGroup() string {
+	if x != nil {
+		return x.CustomerGroup
+	}
+	return ""
+}
+
 type CompanyInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty" bson:"name,omitempty"`
-	TaxId         string                 `protobuf:"bytes,2,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty" bson:"tax_id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty" bson:"name,omitempty" validate:"max=200"`
+	TaxId         string                 `protobuf:"bytes,2,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty" bson:"tax_id,omitempty" validate:"max=50"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -405,7 +448,7 @@ func (x *CompanyInfo) GetTaxId() string {
 type CustomerAddress struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id" bson:"address_id"`
-	Type          CustomerAddressType    `protobuf:"varint,2,opt,name=type,proto3,enum=core.v1.CustomerAddressType" json:"type" bson:"type"`
+	Type          CustomerAddressType    `protobuf:"varint,2,opt,name=type,proto3,enum=core.v1.CustomerAddressType" json:"type" bson:"type" validate:"required"`
 	IsDefault     bool                   `protobuf:"varint,3,opt,name=is_default,json=isDefault,proto3" json:"is_default" bson:"is_default"`
 	Street        string                 `protobuf:"bytes,4,opt,name=street,proto3" json:"street" bson:"street"`
 	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city" bson:"city"`
@@ -574,36 +617,42 @@ var File_core_v1_customer_proto protoreflect.FileDescriptor
 
 const file_core_v1_customer_proto_rawDesc = "" +
 	"\n" +
-	"\x16core/v1/customer.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13tagger/tagger.proto\"\xaf\v\n" +
-	"\bCustomer\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12K\n" +
+	"\x16core/v1/customer.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13tagger/tagger.proto\x1a\x14core/v1/common.proto\"\xd2\x11\n" +
+	"\bCustomer\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12K\n" +
 	"\vcustomer_id\x18\x02 \x01(\tB*\x9a\x84\x9e\x03%bson:\"customer_id\" json:\"customer_id\"R\n" +
-	"customerId\x12C\n" +
-	"\ttenant_id\x18\x03 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12G\n" +
-	"\x04type\x18\x04 \x01(\x0e2\x15.core.v1.CustomerTypeB\x1c\x9a\x84\x9e\x03\x17bson:\"type\" json:\"type\"R\x04type\x120\n" +
-	"\x04name\x18\x05 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x124\n" +
-	"\x05email\x18\x06 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"email\" json:\"email\"R\x05email\x124\n" +
-	"\x05phone\x18\a \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"phone\" json:\"phone\"R\x05phone\x12f\n" +
-	"\acompany\x18\b \x01(\v2\x14.core.v1.CompanyInfoB6\x9a\x84\x9e\x031bson:\"company,omitempty\" json:\"company,omitempty\"R\acompany\x12^\n" +
-	"\taddresses\x18\t \x03(\v2\x18.core.v1.CustomerAddressB&\x9a\x84\x9e\x03!bson:\"addresses\" json:\"addresses\"R\taddresses\x12\x87\x01\n" +
+	"customerId\x12W\n" +
+	"\ttenant_id\x18\x03 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12[\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x15.core.v1.CustomerTypeB0\x9a\x84\x9e\x03+bson:\"type\" json:\"type\" validate:\"required\"R\x04type\x12L\n" +
+	"\x04name\x18\x05 \x01(\tB8\x9a\x84\x9e\x033bson:\"name\" json:\"name\" validate:\"required,max=200\"R\x04name\x12Y\n" +
+	"\x05email\x18\x06 \x01(\tBC\x9a\x84\x9e\x03>bson:\"email,omitempty\" json:\"email,omitempty\" validate:\"email\"R\x05email\x12Z\n" +
+	"\x05phone\x18\a \x01(\tBD\x9a\x84\x9e\x03?bson:\"phone,omitempty\" json:\"phone,omitempty\" validate:\"max=50\"R\x05phone\x12v\n" +
+	"\acompany\x18\b \x01(\v2\x14.core.v1.CompanyInfoBF\x9a\x84\x9e\x03Abson:\"company,omitempty\" json:\"company,omitempty\" validate:\"dive\"R\acompany\x12u\n" +
+	"\taddresses\x18\t \x03(\v2\x18.core.v1.CustomerAddressB=\x9a\x84\x9e\x038bson:\"addresses\" json:\"addresses\" validate:\"max=20,dive\"R\taddresses\x12\x87\x01\n" +
 	"\x0fpayment_methods\x18\n" +
-	" \x03(\v2\x16.core.v1.PaymentMethodBF\x9a\x84\x9e\x03Abson:\"payment_methods,omitempty\" json:\"payment_methods,omitempty\"R\x0epaymentMethods\x12O\n" +
-	"\fcredit_limit\x18\v \x01(\x01B,\x9a\x84\x9e\x03'bson:\"credit_limit\" json:\"credit_limit\"R\vcreditLimit\x12Q\n" +
-	"\x06status\x18\f \x01(\x0e2\x17.core.v1.CustomerStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12W\n" +
+	" \x03(\v2\x16.core.v1.PaymentMethodBF\x9a\x84\x9e\x03Abson:\"payment_methods,omitempty\" json:\"payment_methods,omitempty\"R\x0epaymentMethods\x12`\n" +
+	"\fcredit_limit\x18\v \x01(\x01B=\x9a\x84\x9e\x038bson:\"credit_limit\" json:\"credit_limit\" validate:\"min=0\"R\vcreditLimit\x12e\n" +
+	"\x06status\x18\f \x01(\x0e2\x17.core.v1.CustomerStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12W\n" +
 	"\x0elifetime_value\x18\r \x01(\x01B0\x9a\x84\x9e\x03+bson:\"lifetime_value\" json:\"lifetime_value\"R\rlifetimeValue\x12O\n" +
 	"\ftotal_orders\x18\x0e \x01(\x05B,\x9a\x84\x9e\x03'bson:\"total_orders\" json:\"total_orders\"R\vtotalOrders\x12\x8a\x01\n" +
 	"\x0flast_order_date\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampBF\x9a\x84\x9e\x03Abson:\"last_order_date,omitempty\" json:\"last_order_date,omitempty\"R\rlastOrderDate\x12c\n" +
 	"\n" +
 	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
-	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\"\xa0\x01\n" +
-	"\vCompanyInfo\x12D\n" +
-	"\x04name\x18\x01 \x01(\tB0\x9a\x84\x9e\x03+bson:\"name,omitempty\" json:\"name,omitempty\"R\x04name\x12K\n" +
-	"\x06tax_id\x18\x02 \x01(\tB4\x9a\x84\x9e\x03/bson:\"tax_id,omitempty\" json:\"tax_id,omitempty\"R\x05taxId\"\x81\x04\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12}\n" +
+	"\bcontacts\x18\x12 \x03(\v2\x10.core.v1.ContactBO\x9a\x84\x9e\x03Jbson:\"contacts,omitempty\" json:\"contacts,omitempty\" validate:\"max=50,dive\"R\bcontacts\x12\x8e\x01\n" +
+	"\rpayment_terms\x18\x13 \x01(\v2\x15.core.v1.PaymentTermsBR\x9a\x84\x9e\x03Mbson:\"payment_terms,omitempty\" json:\"payment_terms,omitempty\" validate:\"dive\"R\fpaymentTerms\x12[\n" +
+	"\n" +
+	"created_by\x18\x14 \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12<\n" +
+	"\aversion\x18\x15 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\x12}\n" +
+	"\x0ecustomer_group\x18\x16 \x01(\tBV\x9a\x84\x9e\x03Qbson:\"customer_group,omitempty\" json:\"customer_group,omitempty\" validate:\"max=50\"R\rcustomerGroup\"\xc5\x01\n" +
+	"\vCompanyInfo\x12W\n" +
+	"\x04name\x18\x01 \x01(\tBC\x9a\x84\x9e\x03>bson:\"name,omitempty\" json:\"name,omitempty\" validate:\"max=200\"R\x04name\x12]\n" +
+	"\x06tax_id\x18\x02 \x01(\tBF\x9a\x84\x9e\x03Abson:\"tax_id,omitempty\" json:\"tax_id,omitempty\" validate:\"max=50\"R\x05taxId\"\x95\x04\n" +
 	"\x0fCustomerAddress\x12G\n" +
 	"\n" +
-	"address_id\x18\x01 \x01(\tB(\x9a\x84\x9e\x03#bson:\"address_id\" json:\"address_id\"R\taddressId\x12N\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x1c.core.v1.CustomerAddressTypeB\x1c\x9a\x84\x9e\x03\x17bson:\"type\" json:\"type\"R\x04type\x12G\n" +
+	"address_id\x18\x01 \x01(\tB(\x9a\x84\x9e\x03#bson:\"address_id\" json:\"address_id\"R\taddressId\x12b\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1c.core.v1.CustomerAddressTypeB0\x9a\x84\x9e\x03+bson:\"type\" json:\"type\" validate:\"required\"R\x04type\x12G\n" +
 	"\n" +
 	"is_default\x18\x03 \x01(\bB(\x9a\x84\x9e\x03#bson:\"is_default\" json:\"is_default\"R\tisDefault\x128\n" +
 	"\x06street\x18\x04 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"street\" json:\"street\"R\x06street\x120\n" +
@@ -654,7 +703,9 @@ var file_core_v1_customer_proto_goTypes = []any{
 	(*CustomerAddress)(nil),       // 5: core.v1.CustomerAddress
 	(*PaymentMethod)(nil),         // 6: core.v1.PaymentMethod
 	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),       // 8: google.protobuf.Struct
+	(*Contact)(nil),               // 8: core.v1.Contact
+	(*PaymentTerms)(nil),          // 9: core.v1.PaymentTerms
+	(*structpb.Struct)(nil),       // 10: google.protobuf.Struct
 }
 var file_core_v1_customer_proto_depIdxs = []int32{
 	0,  // 0: core.v1.Customer.type:type_name -> core.v1.CustomerType
@@ -665,13 +716,15 @@ var file_core_v1_customer_proto_depIdxs = []int32{
 	7,  // 5: core.v1.Customer.last_order_date:type_name -> google.protobuf.Timestamp
 	7,  // 6: core.v1.Customer.created_at:type_name -> google.protobuf.Timestamp
 	7,  // 7: core.v1.Customer.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 8: core.v1.CustomerAddress.type:type_name -> core.v1.CustomerAddressType
-	8,  // 9: core.v1.PaymentMethod.details:type_name -> google.protobuf.Struct
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	8,  // 8: core.v1.Customer.contacts:type_name -> core.v1.Contact
+	9,  // 9: core.v1.Customer.payment_terms:type_name -> core.v1.PaymentTerms
+	2,  // 10: core.v1.CustomerAddress.type:type_name -> core.v1.CustomerAddressType
+	10, // 11: core.v1.PaymentMethod.details:type_name -> google.protobuf.Struct
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_core_v1_customer_proto_init() }
@@ -679,6 +732,7 @@ func file_core_v1_customer_proto_init() {
 	if File_core_v1_customer_proto != nil {
 		return
 	}
+	file_core_v1_common_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -7,9 +7,11 @@
 package corev1
 
 import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
 	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,17 +25,127 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Inventory model for MongoDB core_db.inventory collection
-type Inventory struct {
+// Stock movement type enum
+type StockMovementType int32
+
+const (
+	StockMovementType_STOCK_MOVEMENT_TYPE_UNSPECIFIED StockMovementType = 0
+	// Received into the warehouse
+	StockMovementType_STOCK_MOVEMENT_TYPE_IN StockMovementType = 1
+	// Shipped or consumed from the warehouse
+	StockMovementType_STOCK_MOVEMENT_TYPE_OUT StockMovementType = 2
+	// Moved from the warehouse to to_warehouse_id
+	StockMovementType_STOCK_MOVEMENT_TYPE_TRANSFER StockMovementType = 3
+	// Correction after a count, the quantity is signed
+	StockMovementType_STOCK_MOVEMENT_TYPE_ADJUSTMENT StockMovementType = 4
+)
+
+// Enum value maps for StockMovementType.
+var (
+	StockMovementType_name = map[int32]string{
+		0: "STOCK_MOVEMENT_TYPE_UNSPECIFIED",
+		1: "STOCK_MOVEMENT_TYPE_IN",
+		2: "STOCK_MOVEMENT_TYPE_OUT",
+		3: "STOCK_MOVEMENT_TYPE_TRANSFER",
+		4: "STOCK_MOVEMENT_TYPE_ADJUSTMENT",
+	}
+	StockMovementType_value = map[string]int32{
+		"STOCK_MOVEMENT_TYPE_UNSPECIFIED": 0,
+		"STOCK_MOVEMENT_TYPE_IN":          1,
+		"STOCK_MOVEMENT_TYPE_OUT":         2,
+		"STOCK_MOVEMENT_TYPE_TRANSFER":    3,
+		"STOCK_MOVEMENT_TYPE_ADJUSTMENT":  4,
+	}
+)
+
+func (x StockMovementType) Enum() *StockMovementType {
+	p := new(StockMovementType)
+	*p = x
+	return p
+}
+
+func (x StockMovementType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockMovementType) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_inventory_proto_enumTypes[0].Descriptor()
+}
+
+func (StockMovementType) Type() protoreflect.EnumType {
+	return &file_core_v1_inventory_proto_enumTypes[0]
+}
+
+func (x StockMovementType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockMovementType.Descriptor instead.
+func (StockMovementType) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+// Stock reservation status enum
+type StockReservationStatus int32
+
+const (
+	StockReservationStatus_STOCK_RESERVATION_STATUS_UNSPECIFIED StockReservationStatus = 0
+	StockReservationStatus_STOCK_RESERVATION_STATUS_ACTIVE      StockReservationStatus = 1
+	StockReservationStatus_STOCK_RESERVATION_STATUS_RELEASED    StockReservationStatus = 2
+)
+
+// Enum value maps for StockReservationStatus.
+var (
+	StockReservationStatus_name = map[int32]string{
+		0: "STOCK_RESERVATION_STATUS_UNSPECIFIED",
+		1: "STOCK_RESERVATION_STATUS_ACTIVE",
+		2: "STOCK_RESERVATION_STATUS_RELEASED",
+	}
+	StockReservationStatus_value = map[string]int32{
+		"STOCK_RESERVATION_STATUS_UNSPECIFIED": 0,
+		"STOCK_RESERVATION_STATUS_ACTIVE":      1,
+		"STOCK_RESERVATION_STATUS_RELEASED":    2,
+	}
+)
+
+func (x StockReservationStatus) Enum() *StockReservationStatus {
+	p := new(StockReservationStatus)
+	*p = x
+	return p
+}
+
+func (x StockReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_inventory_proto_enumTypes[1].Descriptor()
+}
+
+func (StockReservationStatus) Type() protoreflect.EnumType {
+	return &file_core_v1_inventory_proto_enumTypes[1]
+}
+
+func (x StockReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockReservationStatus.Descriptor instead.
+func (StockReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+// StockItem model for MongoDB core_db.inventory collection
+// The stock of a product in a warehouse, available is quantity minus reserved
+type StockItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	InventoryId   string                 `protobuf:"bytes,2,opt,name=inventory_id,json=inventoryId,proto3" json:"inventory_id" bson:"inventory_id"`
-	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	ProductId     string                 `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id"`
-	WarehouseId   string                 `protobuf:"bytes,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id" bson:"warehouse_id"`
-	Quantity      int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity" bson:"quantity"`
-	Reserved      int32                  `protobuf:"varint,7,opt,name=reserved,proto3" json:"reserved" bson:"reserved"`
-	Available     int32                  `protobuf:"varint,8,opt,name=available,proto3" json:"available" bson:"available"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	ProductId     string                 `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id" validate:"required"`
+	WarehouseId   string                 `protobuf:"bytes,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id" bson:"warehouse_id" validate:"required"`
+	Quantity      int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity" bson:"quantity" validate:"min=0"`
+	Reserved      int32                  `protobuf:"varint,7,opt,name=reserved,proto3" json:"reserved" bson:"reserved" validate:"min=0"`
+	Available     int32                  `protobuf:"varint,8,opt,name=available,proto3" json:"available" bson:"available" validate:"min=0"`
 	Location      *InventoryLocation     `protobuf:"bytes,9,opt,name=location,proto3" json:"location,omitempty" bson:"location,omitempty"`
 	BatchNumber   string                 `protobuf:"bytes,10,opt,name=batch_number,json=batchNumber,proto3" json:"batch_number,omitempty" bson:"batch_number,omitempty"`
 	SerialNumbers []string               `protobuf:"bytes,11,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty" bson:"serial_numbers,omitempty"`
@@ -41,24 +153,29 @@ type Inventory struct {
 	ReceivedDate  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=received_date,json=receivedDate,proto3" json:"received_date" bson:"received_date"`
 	Cost          float64                `protobuf:"fixed64,14,opt,name=cost,proto3" json:"cost" bson:"cost"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	// A low stock event is published when available falls to the threshold, 0 disables the events
+	LowStockThreshold int32 `protobuf:"varint,16,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold" bson:"low_stock_threshold" validate:"min=0"`
+	// Incremented by every update, stock changes are applied at the version they were computed from
+	Version       int64                  `protobuf:"varint,17,opt,name=version,proto3" json:"version" bson:"version"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Inventory) Reset() {
-	*x = Inventory{}
+func (x *StockItem) Reset() {
+	*x = StockItem{}
 	mi := &file_core_v1_inventory_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Inventory) String() string {
+func (x *StockItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Inventory) ProtoMessage() {}
+func (*StockItem) ProtoMessage() {}
 
-func (x *Inventory) ProtoReflect() protoreflect.Message {
+func (x *StockItem) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1_inventory_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -70,116 +187,130 @@ func (x *Inventory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Inventory.ProtoReflect.Descriptor instead.
-func (*Inventory) Descriptor() ([]byte, []int) {
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
 	return file_core_v1_inventory_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Inventory) GetId() string {
+func (x *StockItem) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Inventory) GetInventoryId() string {
-	if x != nil {
-		return x.InventoryId
-	}
-	return ""
-}
-
-func (x *Inventory) GetTenantId() string {
+func (x *StockItem) GetTenantId() string {
 	if x != nil {
 		return x.TenantId
 	}
 	return ""
 }
 
-func (x *Inventory) GetProductId() string {
+func (x *StockItem) GetProductId() string {
 	if x != nil {
 		return x.ProductId
 	}
 	return ""
 }
 
-func (x *Inventory) GetWarehouseId() string {
+func (x *StockItem) GetWarehouseId() string {
 	if x != nil {
 		return x.WarehouseId
 	}
 	return ""
 }
 
-func (x *Inventory) GetQuantity() int32 {
+func (x *StockItem) GetQuantity() int32 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-func (x *Inventory) GetReserved() int32 {
+func (x *StockItem) GetReserved() int32 {
 	if x != nil {
 		return x.Reserved
 	}
 	return 0
 }
 
-func (x *Inventory) GetAvailable() int32 {
+func (x *StockItem) GetAvailable() int32 {
 	if x != nil {
 		return x.Available
 	}
 	return 0
 }
 
-func (x *Inventory) GetLocation() *InventoryLocation {
+func (x *StockItem) GetLocation() *InventoryLocation {
 	if x != nil {
 		return x.Location
 	}
 	return nil
 }
 
-func (x *Inventory) GetBatchNumber() string {
+func (x *StockItem) GetBatchNumber() string {
 	if x != nil {
 		return x.BatchNumber
 	}
 	return ""
 }
 
-func (x *Inventory) GetSerialNumbers() []string {
+func (x *StockItem) GetSerialNumbers() []string {
 	if x != nil {
 		return x.SerialNumbers
 	}
 	return nil
 }
 
-func (x *Inventory) GetExpiryDate() *timestamppb.Timestamp {
+func (x *StockItem) GetExpiryDate() *timestamppb.Timestamp {
 	if x != nil {
 		return x.ExpiryDate
 	}
 	return nil
 }
 
-func (x *Inventory) GetReceivedDate() *timestamppb.Timestamp {
+func (x *StockItem) GetReceivedDate() *timestamppb.Timestamp {
 	if x != nil {
 		return x.ReceivedDate
 	}
 	return nil
 }
 
-func (x *Inventory) GetCost() float64 {
+func (x *StockItem) GetCost() float64 {
 	if x != nil {
 		return x.Cost
 	}
 	return 0
 }
 
-func (x *Inventory) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *StockItem) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.UpdatedAt
 	}
 	return nil
 }
 
+func (x *StockItem) GetLowStockThreshold() int32 {
+	if x != nil {
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
+func (x *StockItem) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *StockItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 type InventoryLocation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Aisle         string                 `protobuf:"bytes,1,opt,name=aisle,proto3" json:"aisle,omitempty" bson:"aisle,omitempty"`
@@ -240,36 +371,1631 @@ func (x *InventoryLocation) GetBin() string {
 	return ""
 }
 
-var File_core_v1_inventory_proto protoreflect.FileDescriptor
+// StockMovement model for MongoDB core_db.stock_movements collection
+// The record of a change of the stock of a product, never updated
+type StockMovement struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	TenantId  string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Type      StockMovementType      `protobuf:"varint,3,opt,name=type,proto3,enum=core.v1.StockMovementType" json:"type" bson:"type" validate:"required"`
+	ProductId string                 `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id" validate:"required"`
+	// Warehouse the stock enters for IN movements, leaves for OUT and TRANSFER movements
+	WarehouseId string `protobuf:"bytes,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id" bson:"warehouse_id" validate:"required"`
+	// Destination of TRANSFER movements
+	ToWarehouseId string `protobuf:"bytes,6,opt,name=to_warehouse_id,json=toWarehouseId,proto3" json:"to_warehouse_id,omitempty" bson:"to_warehouse_id,omitempty"`
+	// Positive, except for ADJUSTMENT movements where it is the signed difference
+	Quantity int32  `protobuf:"varint,7,opt,name=quantity,proto3" json:"quantity" bson:"quantity" validate:"required"`
+	Reason   string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty" bson:"reason,omitempty" validate:"max=500"`
+	// Document the movement belongs to, e.g. an order id
+	Reference     string                 `protobuf:"bytes,9,opt,name=reference,proto3" json:"reference,omitempty" bson:"reference,omitempty" validate:"max=100"`
+	CreatedBy     string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_core_v1_inventory_proto_rawDesc = "" +
-	"\n" +
-	"\x17core/v1/inventory.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\x83\n" +
-	"\n" +
-	"\tInventory\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12O\n" +
-	"\finventory_id\x18\x02 \x01(\tB,\x9a\x84\x9e\x03'bson:\"inventory_id\" json:\"inventory_id\"R\vinventoryId\x12C\n" +
-	"\ttenant_id\x18\x03 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12G\n" +
-	"\n" +
-	"product_id\x18\x04 \x01(\tB(\x9a\x84\x9e\x03#bson:\"product_id\" json:\"product_id\"R\tproductId\x12O\n" +
-	"\fwarehouse_id\x18\x05 \x01(\tB,\x9a\x84\x9e\x03'bson:\"warehouse_id\" json:\"warehouse_id\"R\vwarehouseId\x12@\n" +
-	"\bquantity\x18\x06 \x01(\x05B$\x9a\x84\x9e\x03\x1fbson:\"quantity\" json:\"quantity\"R\bquantity\x12@\n" +
-	"\breserved\x18\a \x01(\x05B$\x9a\x84\x9e\x03\x1fbson:\"reserved\" json:\"reserved\"R\breserved\x12D\n" +
-	"\tavailable\x18\b \x01(\x05B&\x9a\x84\x9e\x03!bson:\"available\" json:\"available\"R\tavailable\x12p\n" +
-	"\blocation\x18\t \x01(\v2\x1a.core.v1.InventoryLocationB8\x9a\x84\x9e\x033bson:\"location,omitempty\" json:\"location,omitempty\"R\blocation\x12c\n" +
-	"\fbatch_number\x18\n" +
-	" \x01(\tB@\x9a\x84\x9e\x03;bson:\"batch_number,omitempty\" json:\"batch_number,omitempty\"R\vbatchNumber\x12k\n" +
-	"\x0eserial_numbers\x18\v \x03(\tBD\x9a\x84\x9e\x03?bson:\"serial_numbers,omitempty\" json:\"serial_numbers,omitempty\"R\rserialNumbers\x12{\n" +
-	"\vexpiry_date\x18\f \x01(\v2\x1a.google.protobuf.TimestampB>\x9a\x84\x9e\x039bson:\"expiry_date,omitempty\" json:\"expiry_date,omitempty\"R\n" +
-	"expiryDate\x12o\n" +
-	"\rreceived_date\x18\r \x01(\v2\x1a.google.protobuf.TimestampB.\x9a\x84\x9e\x03)bson:\"received_date\" json:\"received_date\"R\freceivedDate\x120\n" +
-	"\x04cost\x18\x0e \x01(\x01B\x1c\x9a\x84\x9e\x03\x17bson:\"cost\" json:\"cost\"R\x04cost\x12c\n" +
-	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\"\xe9\x01\n" +
-	"\x11InventoryLocation\x12H\n" +
-	"\x05aisle\x18\x01 \x01(\tB2\x9a\x84\x9e\x03-bson:\"aisle,omitempty\" json:\"aisle,omitempty\"R\x05aisle\x12H\n" +
-	"\x05shelf\x18\x02 \x01(\tB2\x9a\x84\x9e\x03-bson:\"shelf,omitempty\" json:\"shelf,omitempty\"R\x05shelf\x12@\n" +
-	"\x03bin\x18\x03 \x01(\tB.\x9a\x84\x9e\x03)bson:\"bin,omitempty\" json:\"bin,omitempty\"R\x03binB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_core_v1_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StockMovement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockMovement) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetType() StockMovementType {
+	if x != nil {
+		return x.Type
+	}
+	return StockMovementType_STOCK_MOVEMENT_TYPE_UNSPECIFIED
+}
+
+func (x *StockMovement) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetToWarehouseId() string {
+	if x != nil {
+		return x.ToWarehouseId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockMovement) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// StockReservation model for MongoDB core_db.stock_reservations collection
+// Stock held for a document, e.g. an order, until it is released
+type StockReservation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	// Document the stock is held for, e.g. an order id
+	Reference  string                  `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference" bson:"reference" validate:"required,max=100"`
+	Lines      []*StockReservationLine `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines" bson:"lines" validate:"required,min=1,dive"`
+	Status     StockReservationStatus  `protobuf:"varint,5,opt,name=status,proto3,enum=core.v1.StockReservationStatus" json:"status" bson:"status" validate:"required"`
+	CreatedBy  string                  `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	CreatedAt  *timestamppb.Timestamp  `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	ReleasedBy string                  `protobuf:"bytes,8,opt,name=released_by,json=releasedBy,proto3" json:"released_by,omitempty" bson:"released_by,omitempty"`
+	ReleasedAt *timestamppb.Timestamp  `protobuf:"bytes,9,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty" bson:"released_at,omitempty"`
+	// Incremented by every update, a reservation is released once
+	Version       int64 `protobuf:"varint,10,opt,name=version,proto3" json:"version" bson:"version"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservation) Reset() {
+	*x = StockReservation{}
+	mi := &file_core_v1_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservation) ProtoMessage() {}
+
+func (x *StockReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservation.ProtoReflect.Descriptor instead.
+func (*StockReservation) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StockReservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockReservation) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *StockReservation) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *StockReservation) GetLines() []*StockReservationLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *StockReservation) GetStatus() StockReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StockReservationStatus_STOCK_RESERVATION_STATUS_UNSPECIFIED
+}
+
+func (x *StockReservation) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *StockReservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StockReservation) GetReleasedBy() string {
+	if x != nil {
+		return x.ReleasedBy
+	}
+	return ""
+}
+
+func (x *StockReservation) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+func (x *StockReservation) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type StockReservationLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id" validate:"required"`
+	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id" bson:"warehouse_id" validate:"required"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity" bson:"quantity" validate:"positive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservationLine) Reset() {
+	*x = StockReservationLine{}
+	mi := &file_core_v1_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservationLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservationLine) ProtoMessage() {}
+
+func (x *StockReservationLine) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservationLine.ProtoReflect.Descriptor instead.
+func (*StockReservationLine) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StockReservationLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockReservationLine) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *StockReservationLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// =============================================================================
+// Warehouses
+// =============================================================================
+type CreateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Warehouse     *Warehouse             `protobuf:"bytes,2,opt,name=warehouse,proto3" json:"warehouse,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseRequest) Reset() {
+	*x = CreateWarehouseRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseRequest) ProtoMessage() {}
+
+func (x *CreateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateWarehouseRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateWarehouseRequest) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type CreateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseResponse) Reset() {
+	*x = CreateWarehouseResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseResponse) ProtoMessage() {}
+
+func (x *CreateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type GetWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWarehouseRequest) Reset() {
+	*x = GetWarehouseRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWarehouseRequest) ProtoMessage() {}
+
+func (x *GetWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*GetWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetWarehouseRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetWarehouseRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+type GetWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWarehouseResponse) Reset() {
+	*x = GetWarehouseResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWarehouseResponse) ProtoMessage() {}
+
+func (x *GetWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*GetWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type ListWarehousesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWarehousesRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+type ListWarehousesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouses    []*Warehouse           `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*Warehouse {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+type UpdateWarehouseRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// The id of the warehouse is required
+	Warehouse *Warehouse `protobuf:"bytes,2,opt,name=warehouse,proto3" json:"warehouse,omitempty" validate:"required"`
+	// Fields to update, e.g. "name" or "address.city", every editable field is replaced when empty
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseRequest) Reset() {
+	*x = UpdateWarehouseRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseRequest) ProtoMessage() {}
+
+func (x *UpdateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateWarehouseRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateWarehouseRequest) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+func (x *UpdateWarehouseRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseResponse) Reset() {
+	*x = UpdateWarehouseResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseResponse) ProtoMessage() {}
+
+func (x *UpdateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+// =============================================================================
+// Stock
+// =============================================================================
+type GetStockItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	StockItemId   string                 `protobuf:"bytes,2,opt,name=stock_item_id,json=stockItemId,proto3" json:"stock_item_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockItemRequest) Reset() {
+	*x = GetStockItemRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockItemRequest) ProtoMessage() {}
+
+func (x *GetStockItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockItemRequest.ProtoReflect.Descriptor instead.
+func (*GetStockItemRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetStockItemRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetStockItemRequest) GetStockItemId() string {
+	if x != nil {
+		return x.StockItemId
+	}
+	return ""
+}
+
+type GetStockItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockItem     *StockItem             `protobuf:"bytes,1,opt,name=stock_item,json=stockItem,proto3" json:"stock_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockItemResponse) Reset() {
+	*x = GetStockItemResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockItemResponse) ProtoMessage() {}
+
+func (x *GetStockItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockItemResponse.ProtoReflect.Descriptor instead.
+func (*GetStockItemResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetStockItemResponse) GetStockItem() *StockItem {
+	if x != nil {
+		return x.StockItem
+	}
+	return nil
+}
+
+type ListStockItemsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Identifier  *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	WarehouseId *string                `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3,oneof" json:"warehouse_id,omitempty"`
+	ProductId   *string                `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	// Only the items at or below their low stock threshold
+	LowStockOnly  bool `protobuf:"varint,4,opt,name=low_stock_only,json=lowStockOnly,proto3" json:"low_stock_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockItemsRequest) Reset() {
+	*x = ListStockItemsRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockItemsRequest) ProtoMessage() {}
+
+func (x *ListStockItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockItemsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListStockItemsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListStockItemsRequest) GetWarehouseId() string {
+	if x != nil && x.WarehouseId != nil {
+		return *x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ListStockItemsRequest) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+func (x *ListStockItemsRequest) GetLowStockOnly() bool {
+	if x != nil {
+		return x.LowStockOnly
+	}
+	return false
+}
+
+type ListStockItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockItems    []*StockItem           `protobuf:"bytes,1,rep,name=stock_items,json=stockItems,proto3" json:"stock_items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockItemsResponse) Reset() {
+	*x = ListStockItemsResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockItemsResponse) ProtoMessage() {}
+
+func (x *ListStockItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockItemsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListStockItemsResponse) GetStockItems() []*StockItem {
+	if x != nil {
+		return x.StockItems
+	}
+	return nil
+}
+
+type UpdateStockItemRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Identifier  *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	StockItemId string                 `protobuf:"bytes,2,opt,name=stock_item_id,json=stockItemId,proto3" json:"stock_item_id,omitempty" validate:"required"`
+	// Unset fields are kept
+	LowStockThreshold *int32             `protobuf:"varint,3,opt,name=low_stock_threshold,json=lowStockThreshold,proto3,oneof" json:"low_stock_threshold,omitempty"`
+	Location          *InventoryLocation `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateStockItemRequest) Reset() {
+	*x = UpdateStockItemRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockItemRequest) ProtoMessage() {}
+
+func (x *UpdateStockItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStockItemRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateStockItemRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateStockItemRequest) GetStockItemId() string {
+	if x != nil {
+		return x.StockItemId
+	}
+	return ""
+}
+
+func (x *UpdateStockItemRequest) GetLowStockThreshold() int32 {
+	if x != nil && x.LowStockThreshold != nil {
+		return *x.LowStockThreshold
+	}
+	return 0
+}
+
+func (x *UpdateStockItemRequest) GetLocation() *InventoryLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type UpdateStockItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockItem     *StockItem             `protobuf:"bytes,1,opt,name=stock_item,json=stockItem,proto3" json:"stock_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStockItemResponse) Reset() {
+	*x = UpdateStockItemResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockItemResponse) ProtoMessage() {}
+
+func (x *UpdateStockItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStockItemResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateStockItemResponse) GetStockItem() *StockItem {
+	if x != nil {
+		return x.StockItem
+	}
+	return nil
+}
+
+type RecordStockMovementRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// The tenant and author of the movement are taken from the identifier
+	Movement      *StockMovement `protobuf:"bytes,2,opt,name=movement,proto3" json:"movement,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordStockMovementRequest) Reset() {
+	*x = RecordStockMovementRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordStockMovementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordStockMovementRequest) ProtoMessage() {}
+
+func (x *RecordStockMovementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordStockMovementRequest.ProtoReflect.Descriptor instead.
+func (*RecordStockMovementRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecordStockMovementRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *RecordStockMovementRequest) GetMovement() *StockMovement {
+	if x != nil {
+		return x.Movement
+	}
+	return nil
+}
+
+type RecordStockMovementResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Movement *StockMovement         `protobuf:"bytes,1,opt,name=movement,proto3" json:"movement,omitempty"`
+	// The stock items changed by the movement, the destination follows the source for transfers
+	StockItems    []*StockItem `protobuf:"bytes,2,rep,name=stock_items,json=stockItems,proto3" json:"stock_items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordStockMovementResponse) Reset() {
+	*x = RecordStockMovementResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordStockMovementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordStockMovementResponse) ProtoMessage() {}
+
+func (x *RecordStockMovementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordStockMovementResponse.ProtoReflect.Descriptor instead.
+func (*RecordStockMovementResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RecordStockMovementResponse) GetMovement() *StockMovement {
+	if x != nil {
+		return x.Movement
+	}
+	return nil
+}
+
+func (x *RecordStockMovementResponse) GetStockItems() []*StockItem {
+	if x != nil {
+		return x.StockItems
+	}
+	return nil
+}
+
+type ListStockMovementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	ProductId     *string                `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3,oneof" json:"product_id,omitempty"`
+	WarehouseId   *string                `protobuf:"bytes,3,opt,name=warehouse_id,json=warehouseId,proto3,oneof" json:"warehouse_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsRequest) Reset() {
+	*x = ListStockMovementsRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsRequest) ProtoMessage() {}
+
+func (x *ListStockMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListStockMovementsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListStockMovementsRequest) GetProductId() string {
+	if x != nil && x.ProductId != nil {
+		return *x.ProductId
+	}
+	return ""
+}
+
+func (x *ListStockMovementsRequest) GetWarehouseId() string {
+	if x != nil && x.WarehouseId != nil {
+		return *x.WarehouseId
+	}
+	return ""
+}
+
+type ListStockMovementsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Movements     []*StockMovement `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsResponse) Reset() {
+	*x = ListStockMovementsResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsResponse) ProtoMessage() {}
+
+func (x *ListStockMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListStockMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+type ReserveStockRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Reference  string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty" validate:"required,max=100"`
+	// Every line is reserved or none is
+	Lines         []*StockReservationLine `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty" validate:"required,min=1,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReserveStockRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ReserveStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetLines() []*StockReservationLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReserveStockResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type ReleaseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_core_v1_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReleaseStockRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ReleaseStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockResponse) Reset() {
+	*x = ReleaseStockResponse{}
+	mi := &file_core_v1_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockResponse) ProtoMessage() {}
+
+func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReleaseStockResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+var File_core_v1_inventory_proto protoreflect.FileDescriptor
+
+const file_core_v1_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x17core/v1/inventory.proto\x12\acore.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x14infra/v1/infra.proto\x1a\x17core/v1/warehouse.proto\"\xf3\f\n" +
+	"\tStockItem\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x03 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12[\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\tB<\x9a\x84\x9e\x037bson:\"product_id\" json:\"product_id\" validate:\"required\"R\tproductId\x12c\n" +
+	"\fwarehouse_id\x18\x05 \x01(\tB@\x9a\x84\x9e\x03;bson:\"warehouse_id\" json:\"warehouse_id\" validate:\"required\"R\vwarehouseId\x12Q\n" +
+	"\bquantity\x18\x06 \x01(\x05B5\x9a\x84\x9e\x030bson:\"quantity\" json:\"quantity\" validate:\"min=0\"R\bquantity\x12Q\n" +
+	"\breserved\x18\a \x01(\x05B5\x9a\x84\x9e\x030bson:\"reserved\" json:\"reserved\" validate:\"min=0\"R\breserved\x12U\n" +
+	"\tavailable\x18\b \x01(\x05B7\x9a\x84\x9e\x032bson:\"available\" json:\"available\" validate:\"min=0\"R\tavailable\x12p\n" +
+	"\blocation\x18\t \x01(\v2\x1a.core.v1.InventoryLocationB8\x9a\x84\x9e\x033bson:\"location,omitempty\" json:\"location,omitempty\"R\blocation\x12c\n" +
+	"\fbatch_number\x18\n" +
+	" \x01(\tB@\x9a\x84\x9e\x03;bson:\"batch_number,omitempty\" json:\"batch_number,omitempty\"R\vbatchNumber\x12k\n" +
+	"\x0eserial_numbers\x18\v \x03(\tBD\x9a\x84\x9e\x03?bson:\"serial_numbers,omitempty\" json:\"serial_numbers,omitempty\"R\rserialNumbers\x12{\n" +
+	"\vexpiry_date\x18\f \x01(\v2\x1a.google.protobuf.TimestampB>\x9a\x84\x9e\x039bson:\"expiry_date,omitempty\" json:\"expiry_date,omitempty\"R\n" +
+	"expiryDate\x12o\n" +
+	"\rreceived_date\x18\r \x01(\v2\x1a.google.protobuf.TimestampB.\x9a\x84\x9e\x03)bson:\"received_date\" json:\"received_date\"R\freceivedDate\x120\n" +
+	"\x04cost\x18\x0e \x01(\x01B\x1c\x9a\x84\x9e\x03\x17bson:\"cost\" json:\"cost\"R\x04cost\x12c\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12{\n" +
+	"\x13low_stock_threshold\x18\x10 \x01(\x05BK\x9a\x84\x9e\x03Fbson:\"low_stock_threshold\" json:\"low_stock_threshold\" validate:\"min=0\"R\x11lowStockThreshold\x12<\n" +
+	"\aversion\x18\x11 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\x12c\n" +
+	"\n" +
+	"created_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAtJ\x04\b\x02\x10\x03R\finventory_id\"\xe9\x01\n" +
+	"\x11InventoryLocation\x12H\n" +
+	"\x05aisle\x18\x01 \x01(\tB2\x9a\x84\x9e\x03-bson:\"aisle,omitempty\" json:\"aisle,omitempty\"R\x05aisle\x12H\n" +
+	"\x05shelf\x18\x02 \x01(\tB2\x9a\x84\x9e\x03-bson:\"shelf,omitempty\" json:\"shelf,omitempty\"R\x05shelf\x12@\n" +
+	"\x03bin\x18\x03 \x01(\tB.\x9a\x84\x9e\x03)bson:\"bin,omitempty\" json:\"bin,omitempty\"R\x03bin\"\x97\b\n" +
+	"\rStockMovement\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12`\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1a.core.v1.StockMovementTypeB0\x9a\x84\x9e\x03+bson:\"type\" json:\"type\" validate:\"required\"R\x04type\x12[\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\tB<\x9a\x84\x9e\x037bson:\"product_id\" json:\"product_id\" validate:\"required\"R\tproductId\x12c\n" +
+	"\fwarehouse_id\x18\x05 \x01(\tB@\x9a\x84\x9e\x03;bson:\"warehouse_id\" json:\"warehouse_id\" validate:\"required\"R\vwarehouseId\x12n\n" +
+	"\x0fto_warehouse_id\x18\x06 \x01(\tBF\x9a\x84\x9e\x03Abson:\"to_warehouse_id,omitempty\" json:\"to_warehouse_id,omitempty\"R\rtoWarehouseId\x12T\n" +
+	"\bquantity\x18\a \x01(\x05B8\x9a\x84\x9e\x033bson:\"quantity\" json:\"quantity\" validate:\"required\"R\bquantity\x12_\n" +
+	"\x06reason\x18\b \x01(\tBG\x9a\x84\x9e\x03Bbson:\"reason,omitempty\" json:\"reason,omitempty\" validate:\"max=500\"R\x06reason\x12k\n" +
+	"\treference\x18\t \x01(\tBM\x9a\x84\x9e\x03Hbson:\"reference,omitempty\" json:\"reference,omitempty\" validate:\"max=100\"R\treference\x12[\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12c\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\"\xe1\a\n" +
+	"\x10StockReservation\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12`\n" +
+	"\treference\x18\x03 \x01(\tBB\x9a\x84\x9e\x03=bson:\"reference\" json:\"reference\" validate:\"required,max=100\"R\treference\x12r\n" +
+	"\x05lines\x18\x04 \x03(\v2\x1d.core.v1.StockReservationLineB=\x9a\x84\x9e\x038bson:\"lines\" json:\"lines\" validate:\"required,min=1,dive\"R\x05lines\x12m\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1f.core.v1.StockReservationStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12[\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12c\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12_\n" +
+	"\vreleased_by\x18\b \x01(\tB>\x9a\x84\x9e\x039bson:\"released_by,omitempty\" json:\"released_by,omitempty\"R\n" +
+	"releasedBy\x12{\n" +
+	"\vreleased_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB>\x9a\x84\x9e\x039bson:\"released_at,omitempty\" json:\"released_at,omitempty\"R\n" +
+	"releasedAt\x12<\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\"\xae\x02\n" +
+	"\x14StockReservationLine\x12[\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB<\x9a\x84\x9e\x037bson:\"product_id\" json:\"product_id\" validate:\"required\"R\tproductId\x12c\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tB@\x9a\x84\x9e\x03;bson:\"warehouse_id\" json:\"warehouse_id\" validate:\"required\"R\vwarehouseId\x12T\n" +
+	"\bquantity\x18\x03 \x01(\x05B8\x9a\x84\x9e\x033bson:\"quantity\" json:\"quantity\" validate:\"positive\"R\bquantity\"\xbd\x01\n" +
+	"\x16CreateWarehouseRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12J\n" +
+	"\twarehouse\x18\x02 \x01(\v2\x12.core.v1.WarehouseB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\twarehouse\"K\n" +
+	"\x17CreateWarehouseResponse\x120\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x12.core.v1.WarehouseR\twarehouse\"\xab\x01\n" +
+	"\x13GetWarehouseRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12;\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\vwarehouseId\"H\n" +
+	"\x14GetWarehouseResponse\x120\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x12.core.v1.WarehouseR\twarehouse\"p\n" +
+	"\x15ListWarehousesRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\"L\n" +
+	"\x16ListWarehousesResponse\x122\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x12.core.v1.WarehouseR\n" +
+	"warehouses\"\xfa\x01\n" +
+	"\x16UpdateWarehouseRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12J\n" +
+	"\twarehouse\x18\x02 \x01(\v2\x12.core.v1.WarehouseB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\twarehouse\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"K\n" +
+	"\x17UpdateWarehouseResponse\x120\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x12.core.v1.WarehouseR\twarehouse\"\xac\x01\n" +
+	"\x13GetStockItemRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12<\n" +
+	"\rstock_item_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\vstockItemId\"I\n" +
+	"\x14GetStockItemResponse\x121\n" +
+	"\n" +
+	"stock_item\x18\x01 \x01(\v2\x12.core.v1.StockItemR\tstockItem\"\x82\x02\n" +
+	"\x15ListStockItemsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12&\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tH\x00R\vwarehouseId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tH\x01R\tproductId\x88\x01\x01\x12$\n" +
+	"\x0elow_stock_only\x18\x04 \x01(\bR\flowStockOnlyB\x0f\n" +
+	"\r_warehouse_idB\r\n" +
+	"\v_product_id\"M\n" +
+	"\x16ListStockItemsResponse\x123\n" +
+	"\vstock_items\x18\x01 \x03(\v2\x12.core.v1.StockItemR\n" +
+	"stockItems\"\xb4\x02\n" +
+	"\x16UpdateStockItemRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12<\n" +
+	"\rstock_item_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\vstockItemId\x123\n" +
+	"\x13low_stock_threshold\x18\x03 \x01(\x05H\x00R\x11lowStockThreshold\x88\x01\x01\x126\n" +
+	"\blocation\x18\x04 \x01(\v2\x1a.core.v1.InventoryLocationR\blocationB\x16\n" +
+	"\x14_low_stock_threshold\"L\n" +
+	"\x17UpdateStockItemResponse\x121\n" +
+	"\n" +
+	"stock_item\x18\x01 \x01(\v2\x12.core.v1.StockItemR\tstockItem\"\xc3\x01\n" +
+	"\x1aRecordStockMovementRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12L\n" +
+	"\bmovement\x18\x02 \x01(\v2\x16.core.v1.StockMovementB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\bmovement\"\x86\x01\n" +
+	"\x1bRecordStockMovementResponse\x122\n" +
+	"\bmovement\x18\x01 \x01(\v2\x16.core.v1.StockMovementR\bmovement\x123\n" +
+	"\vstock_items\x18\x02 \x03(\v2\x12.core.v1.StockItemR\n" +
+	"stockItems\"\xe0\x01\n" +
+	"\x19ListStockMovementsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12\"\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tH\x00R\tproductId\x88\x01\x01\x12&\n" +
+	"\fwarehouse_id\x18\x03 \x01(\tH\x01R\vwarehouseId\x88\x01\x01B\r\n" +
+	"\v_product_idB\x0f\n" +
+	"\r_warehouse_id\"R\n" +
+	"\x1aListStockMovementsResponse\x124\n" +
+	"\tmovements\x18\x01 \x03(\v2\x16.core.v1.StockMovementR\tmovements\"\x88\x02\n" +
+	"\x13ReserveStockRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12>\n" +
+	"\treference\x18\x02 \x01(\tB \x9a\x84\x9e\x03\x1bvalidate:\"required,max=100\"R\treference\x12X\n" +
+	"\x05lines\x18\x03 \x03(\v2\x1d.core.v1.StockReservationLineB#\x9a\x84\x9e\x03\x1evalidate:\"required,min=1,dive\"R\x05lines\"S\n" +
+	"\x14ReserveStockResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.core.v1.StockReservationR\vreservation\"\xaf\x01\n" +
+	"\x13ReleaseStockRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12?\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\rreservationId\"S\n" +
+	"\x14ReleaseStockResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.core.v1.StockReservationR\vreservation*\xb7\x01\n" +
+	"\x11StockMovementType\x12#\n" +
+	"\x1fSTOCK_MOVEMENT_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16STOCK_MOVEMENT_TYPE_IN\x10\x01\x12\x1b\n" +
+	"\x17STOCK_MOVEMENT_TYPE_OUT\x10\x02\x12 \n" +
+	"\x1cSTOCK_MOVEMENT_TYPE_TRANSFER\x10\x03\x12\"\n" +
+	"\x1eSTOCK_MOVEMENT_TYPE_ADJUSTMENT\x10\x04*\x8e\x01\n" +
+	"\x16StockReservationStatus\x12(\n" +
+	"$STOCK_RESERVATION_STATUS_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fSTOCK_RESERVATION_STATUS_ACTIVE\x10\x01\x12%\n" +
+	"!STOCK_RESERVATION_STATUS_RELEASED\x10\x022\xaf\a\n" +
+	"\x10InventoryService\x12T\n" +
+	"\x0fCreateWarehouse\x12\x1f.core.v1.CreateWarehouseRequest\x1a .core.v1.CreateWarehouseResponse\x12K\n" +
+	"\fGetWarehouse\x12\x1c.core.v1.GetWarehouseRequest\x1a\x1d.core.v1.GetWarehouseResponse\x12Q\n" +
+	"\x0eListWarehouses\x12\x1e.core.v1.ListWarehousesRequest\x1a\x1f.core.v1.ListWarehousesResponse\x12T\n" +
+	"\x0fUpdateWarehouse\x12\x1f.core.v1.UpdateWarehouseRequest\x1a .core.v1.UpdateWarehouseResponse\x12K\n" +
+	"\fGetStockItem\x12\x1c.core.v1.GetStockItemRequest\x1a\x1d.core.v1.GetStockItemResponse\x12Q\n" +
+	"\x0eListStockItems\x12\x1e.core.v1.ListStockItemsRequest\x1a\x1f.core.v1.ListStockItemsResponse\x12T\n" +
+	"\x0fUpdateStockItem\x12\x1f.core.v1.UpdateStockItemRequest\x1a .core.v1.UpdateStockItemResponse\x12`\n" +
+	"\x13RecordStockMovement\x12#.core.v1.RecordStockMovementRequest\x1a$.core.v1.RecordStockMovementResponse\x12]\n" +
+	"\x12ListStockMovements\x12\".core.v1.ListStockMovementsRequest\x1a#.core.v1.ListStockMovementsResponse\x12K\n" +
+	"\fReserveStock\x12\x1c.core.v1.ReserveStockRequest\x1a\x1d.core.v1.ReserveStockResponse\x12K\n" +
+	"\fReleaseStock\x12\x1c.core.v1.ReleaseStockRequest\x1a\x1d.core.v1.ReleaseStockResponseB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
 
 var (
 	file_core_v1_inventory_proto_rawDescOnce sync.Once
@@ -283,22 +2009,111 @@ func file_core_v1_inventory_proto_rawDescGZIP() []byte {
 	return file_core_v1_inventory_proto_rawDescData
 }
 
-var file_core_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_core_v1_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_core_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_core_v1_inventory_proto_goTypes = []any{
-	(*Inventory)(nil),             // 0: core.v1.Inventory
-	(*InventoryLocation)(nil),     // 1: core.v1.InventoryLocation
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(StockMovementType)(0),              // 0: core.v1.StockMovementType
+	(StockReservationStatus)(0),         // 1: core.v1.StockReservationStatus
+	(*StockItem)(nil),                   // 2: core.v1.StockItem
+	(*InventoryLocation)(nil),           // 3: core.v1.InventoryLocation
+	(*StockMovement)(nil),               // 4: core.v1.StockMovement
+	(*StockReservation)(nil),            // 5: core.v1.StockReservation
+	(*StockReservationLine)(nil),        // 6: core.v1.StockReservationLine
+	(*CreateWarehouseRequest)(nil),      // 7: core.v1.CreateWarehouseRequest
+	(*CreateWarehouseResponse)(nil),     // 8: core.v1.CreateWarehouseResponse
+	(*GetWarehouseRequest)(nil),         // 9: core.v1.GetWarehouseRequest
+	(*GetWarehouseResponse)(nil),        // 10: core.v1.GetWarehouseResponse
+	(*ListWarehousesRequest)(nil),       // 11: core.v1.ListWarehousesRequest
+	(*ListWarehousesResponse)(nil),      // 12: core.v1.ListWarehousesResponse
+	(*UpdateWarehouseRequest)(nil),      // 13: core.v1.UpdateWarehouseRequest
+	(*UpdateWarehouseResponse)(nil),     // 14: core.v1.UpdateWarehouseResponse
+	(*GetStockItemRequest)(nil),         // 15: core.v1.GetStockItemRequest
+	(*GetStockItemResponse)(nil),        // 16: core.v1.GetStockItemResponse
+	(*ListStockItemsRequest)(nil),       // 17: core.v1.ListStockItemsRequest
+	(*ListStockItemsResponse)(nil),      // 18: core.v1.ListStockItemsResponse
+	(*UpdateStockItemRequest)(nil),      // 19: core.v1.UpdateStockItemRequest
+	(*UpdateStockItemResponse)(nil),     // 20: core.v1.UpdateStockItemResponse
+	(*RecordStockMovementRequest)(nil),  // 21: core.v1.RecordStockMovementRequest
+	(*RecordStockMovementResponse)(nil), // 22: core.v1.RecordStockMovementResponse
+	(*ListStockMovementsRequest)(nil),   // 23: core.v1.ListStockMovementsRequest
+	(*ListStockMovementsResponse)(nil),  // 24: core.v1.ListStockMovementsResponse
+	(*ReserveStockRequest)(nil),         // 25: core.v1.ReserveStockRequest
+	(*ReserveStockResponse)(nil),        // 26: core.v1.ReserveStockResponse
+	(*ReleaseStockRequest)(nil),         // 27: core.v1.ReleaseStockRequest
+	(*ReleaseStockResponse)(nil),        // 28: core.v1.ReleaseStockResponse
+	(*timestamppb.Timestamp)(nil),       // 29: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),           // 30: infra.v1.UserIdentifier
+	(*Warehouse)(nil),                   // 31: core.v1.Warehouse
+	(*fieldmaskpb.FieldMask)(nil),       // 32: google.protobuf.FieldMask
 }
 var file_core_v1_inventory_proto_depIdxs = []int32{
-	1, // 0: core.v1.Inventory.location:type_name -> core.v1.InventoryLocation
-	2, // 1: core.v1.Inventory.expiry_date:type_name -> google.protobuf.Timestamp
-	2, // 2: core.v1.Inventory.received_date:type_name -> google.protobuf.Timestamp
-	2, // 3: core.v1.Inventory.updated_at:type_name -> google.protobuf.Timestamp
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3,  // 0: core.v1.StockItem.location:type_name -> core.v1.InventoryLocation
+	29, // 1: core.v1.StockItem.expiry_date:type_name -> google.protobuf.Timestamp
+	29, // 2: core.v1.StockItem.received_date:type_name -> google.protobuf.Timestamp
+	29, // 3: core.v1.StockItem.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 4: core.v1.StockItem.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: core.v1.StockMovement.type:type_name -> core.v1.StockMovementType
+	29, // 6: core.v1.StockMovement.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 7: core.v1.StockReservation.lines:type_name -> core.v1.StockReservationLine
+	1,  // 8: core.v1.StockReservation.status:type_name -> core.v1.StockReservationStatus
+	29, // 9: core.v1.StockReservation.created_at:type_name -> google.protobuf.Timestamp
+	29, // 10: core.v1.StockReservation.released_at:type_name -> google.protobuf.Timestamp
+	30, // 11: core.v1.CreateWarehouseRequest.identifier:type_name -> infra.v1.UserIdentifier
+	31, // 12: core.v1.CreateWarehouseRequest.warehouse:type_name -> core.v1.Warehouse
+	31, // 13: core.v1.CreateWarehouseResponse.warehouse:type_name -> core.v1.Warehouse
+	30, // 14: core.v1.GetWarehouseRequest.identifier:type_name -> infra.v1.UserIdentifier
+	31, // 15: core.v1.GetWarehouseResponse.warehouse:type_name -> core.v1.Warehouse
+	30, // 16: core.v1.ListWarehousesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	31, // 17: core.v1.ListWarehousesResponse.warehouses:type_name -> core.v1.Warehouse
+	30, // 18: core.v1.UpdateWarehouseRequest.identifier:type_name -> infra.v1.UserIdentifier
+	31, // 19: core.v1.UpdateWarehouseRequest.warehouse:type_name -> core.v1.Warehouse
+	32, // 20: core.v1.UpdateWarehouseRequest.update_mask:type_name -> google.protobuf.FieldMask
+	31, // 21: core.v1.UpdateWarehouseResponse.warehouse:type_name -> core.v1.Warehouse
+	30, // 22: core.v1.GetStockItemRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 23: core.v1.GetStockItemResponse.stock_item:type_name -> core.v1.StockItem
+	30, // 24: core.v1.ListStockItemsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 25: core.v1.ListStockItemsResponse.stock_items:type_name -> core.v1.StockItem
+	30, // 26: core.v1.UpdateStockItemRequest.identifier:type_name -> infra.v1.UserIdentifier
+	3,  // 27: core.v1.UpdateStockItemRequest.location:type_name -> core.v1.InventoryLocation
+	2,  // 28: core.v1.UpdateStockItemResponse.stock_item:type_name -> core.v1.StockItem
+	30, // 29: core.v1.RecordStockMovementRequest.identifier:type_name -> infra.v1.UserIdentifier
+	4,  // 30: core.v1.RecordStockMovementRequest.movement:type_name -> core.v1.StockMovement
+	4,  // 31: core.v1.RecordStockMovementResponse.movement:type_name -> core.v1.StockMovement
+	2,  // 32: core.v1.RecordStockMovementResponse.stock_items:type_name -> core.v1.StockItem
+	30, // 33: core.v1.ListStockMovementsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	4,  // 34: core.v1.ListStockMovementsResponse.movements:type_name -> core.v1.StockMovement
+	30, // 35: core.v1.ReserveStockRequest.identifier:type_name -> infra.v1.UserIdentifier
+	6,  // 36: core.v1.ReserveStockRequest.lines:type_name -> core.v1.StockReservationLine
+	5,  // 37: core.v1.ReserveStockResponse.reservation:type_name -> core.v1.StockReservation
+	30, // 38: core.v1.ReleaseStockRequest.identifier:type_name -> infra.v1.UserIdentifier
+	5,  // 39: core.v1.ReleaseStockResponse.reservation:type_name -> core.v1.StockReservation
+	7,  // 40: core.v1.InventoryService.CreateWarehouse:input_type -> core.v1.CreateWarehouseRequest
+	9,  // 41: core.v1.InventoryService.GetWarehouse:input_type -> core.v1.GetWarehouseRequest
+	11, // 42: core.v1.InventoryService.ListWarehouses:input_type -> core.v1.ListWarehousesRequest
+	13, // 43: core.v1.InventoryService.UpdateWarehouse:input_type -> core.v1.UpdateWarehouseRequest
+	15, // 44: core.v1.InventoryService.GetStockItem:input_type -> core.v1.GetStockItemRequest
+	17, // 45: core.v1.InventoryService.ListStockItems:input_type -> core.v1.ListStockItemsRequest
+	19, // 46: core.v1.InventoryService.UpdateStockItem:input_type -> core.v1.UpdateStockItemRequest
+	21, // 47: core.v1.InventoryService.RecordStockMovement:input_type -> core.v1.RecordStockMovementRequest
+	23, // 48: core.v1.InventoryService.ListStockMovements:input_type -> core.v1.ListStockMovementsRequest
+	25, // 49: core.v1.InventoryService.ReserveStock:input_type -> core.v1.ReserveStockRequest
+	27, // 50: core.v1.InventoryService.ReleaseStock:input_type -> core.v1.ReleaseStockRequest
+	8,  // 51: core.v1.InventoryService.CreateWarehouse:output_type -> core.v1.CreateWarehouseResponse
+	10, // 52: core.v1.InventoryService.GetWarehouse:output_type -> core.v1.GetWarehouseResponse
+	12, // 53: core.v1.InventoryService.ListWarehouses:output_type -> core.v1.ListWarehousesResponse
+	14, // 54: core.v1.InventoryService.UpdateWarehouse:output_type -> core.v1.UpdateWarehouseResponse
+	16, // 55: core.v1.InventoryService.GetStockItem:output_type -> core.v1.GetStockItemResponse
+	18, // 56: core.v1.InventoryService.ListStockItems:output_type -> core.v1.ListStockItemsResponse
+	20, // 57: core.v1.InventoryService.UpdateStockItem:output_type -> core.v1.UpdateStockItemResponse
+	22, // 58: core.v1.InventoryService.RecordStockMovement:output_type -> core.v1.RecordStockMovementResponse
+	24, // 59: core.v1.InventoryService.ListStockMovements:output_type -> core.v1.ListStockMovementsResponse
+	26, // 60: core.v1.InventoryService.ReserveStock:output_type -> core.v1.ReserveStockResponse
+	28, // 61: core.v1.InventoryService.ReleaseStock:output_type -> core.v1.ReleaseStockResponse
+	51, // [51:62] is the sub-list for method output_type
+	40, // [40:51] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_core_v1_inventory_proto_init() }
@@ -306,18 +2121,23 @@ func file_core_v1_inventory_proto_init() {
 	if File_core_v1_inventory_proto != nil {
 		return
 	}
+	file_core_v1_warehouse_proto_init()
+	file_core_v1_inventory_proto_msgTypes[15].OneofWrappers = []any{}
+	file_core_v1_inventory_proto_msgTypes[17].OneofWrappers = []any{}
+	file_core_v1_inventory_proto_msgTypes[21].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_inventory_proto_rawDesc), len(file_core_v1_inventory_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   27,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_core_v1_inventory_proto_goTypes,
 		DependencyIndexes: file_core_v1_inventory_proto_depIdxs,
+		EnumInfos:         file_core_v1_inventory_proto_enumTypes,
 		MessageInfos:      file_core_v1_inventory_proto_msgTypes,
 	}.Build()
 	File_core_v1_inventory_proto = out.File
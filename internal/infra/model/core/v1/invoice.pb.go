@@ -0,0 +1,1714 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: core/v1/invoice.proto
+
+package corev1
+
+import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Invoice model for MongoDB core_db.invoices collection
+// An invoice bills a confirmed sales order once, its payment status follows the payments recorded against it
+type Invoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	InvoiceNumber string                 `protobuf:"bytes,3,opt,name=invoice_number,json=invoiceNumber,proto3" json:"invoice_number" bson:"invoice_number" validate:"required,max=50"`
+	OrderId       string                 `protobuf:"bytes,4,opt,name=order_id,json=orderId,proto3" json:"order_id" bson:"order_id" validate:"required"`
+	OrderNumber   string                 `protobuf:"bytes,5,opt,name=order_number,json=orderNumber,proto3" json:"order_number" bson:"order_number"`
+	CustomerId    string                 `protobuf:"bytes,6,opt,name=customer_id,json=customerId,proto3" json:"customer_id" bson:"customer_id" validate:"required"`
+	Lines         []*InvoiceLine         `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines" bson:"lines" validate:"required,max=500,dive"`
+	// ISO 4217 code of every amount of the invoice, e.g. USD
+	Currency   string  `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency" bson:"currency" validate:"required,min=3,max=3"`
+	Subtotal   float64 `protobuf:"fixed64,9,opt,name=subtotal,proto3" json:"subtotal" bson:"subtotal"`
+	Discount   float64 `protobuf:"fixed64,10,opt,name=discount,proto3" json:"discount" bson:"discount"`
+	Tax        float64 `protobuf:"fixed64,11,opt,name=tax,proto3" json:"tax" bson:"tax"`
+	Shipping   float64 `protobuf:"fixed64,12,opt,name=shipping,proto3" json:"shipping" bson:"shipping" validate:"min=0"`
+	Total      float64 `protobuf:"fixed64,13,opt,name=total,proto3" json:"total" bson:"total" validate:"min=0"`
+	AmountPaid float64 `protobuf:"fixed64,14,opt,name=amount_paid,json=amountPaid,proto3" json:"amount_paid" bson:"amount_paid" validate:"min=0"`
+	// The total minus the amount paid
+	AmountDue      float64                `protobuf:"fixed64,15,opt,name=amount_due,json=amountDue,proto3" json:"amount_due" bson:"amount_due" validate:"min=0"`
+	PaymentStatus  PaymentStatus          `protobuf:"varint,16,opt,name=payment_status,json=paymentStatus,proto3,enum=core.v1.PaymentStatus" json:"payment_status" bson:"payment_status" validate:"required"`
+	Payments       []*InvoicePayment      `protobuf:"bytes,17,rep,name=payments,proto3" json:"payments,omitempty" bson:"payments,omitempty" validate:"max=500,dive"`
+	BillingAddress *Address               `protobuf:"bytes,18,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address,omitempty" bson:"billing_address,omitempty"`
+	IssuedAt       *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at" bson:"issued_at"`
+	// Issue date plus the net days of the customer payment terms
+	DueAt     *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=due_at,json=dueAt,proto3" json:"due_at" bson:"due_at"`
+	Notes     string                 `protobuf:"bytes,21,opt,name=notes,proto3" json:"notes,omitempty" bson:"notes,omitempty" validate:"max=2000"`
+	CreatedBy string                 `protobuf:"bytes,22,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	// Incremented by every update, an invoice is updated at the version it was read at
+	Version int64 `protobuf:"varint,25,opt,name=version,proto3" json:"version" bson:"version"`
+	// Base currency of the tenant and the units of it one unit of the currency was worth at the issue date,
+	// unset when the tenant had no rate for the currency
+	BaseCurrency string  `protobuf:"bytes,26,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty" bson:"base_currency,omitempty"`
+	ExchangeRate float64 `protobuf:"fixed64,27,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty" bson:"exchange_rate,omitempty"`
+	// The total in the base currency
+	BaseTotal     float64 `protobuf:"fixed64,28,opt,name=base_total,json=baseTotal,proto3" json:"base_total,omitempty" bson:"base_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_core_v1_invoice_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Invoice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invoice) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Invoice) GetInvoiceNumber() string {
+	if x != nil {
+		return x.InvoiceNumber
+	}
+	return ""
+}
+
+func (x *Invoice) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Invoice) GetOrderNumber() string {
+	if x != nil {
+		return x.OrderNumber
+	}
+	return ""
+}
+
+func (x *Invoice) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Invoice) GetLines() []*InvoiceLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Invoice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Invoice) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *Invoice) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *Invoice) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *Invoice) GetShipping() float64 {
+	if x != nil {
+		return x.Shipping
+	}
+	return 0
+}
+
+func (x *Invoice) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Invoice) GetAmountPaid() float64 {
+	if x != nil {
+		return x.AmountPaid
+	}
+	return 0
+}
+
+func (x *Invoice) GetAmountDue() float64 {
+	if x != nil {
+		return x.AmountDue
+	}
+	return 0
+}
+
+func (x *Invoice) GetPaymentStatus() PaymentStatus {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Invoice) GetPayments() []*InvoicePayment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *Invoice) GetBillingAddress() *Address {
+	if x != nil {
+		return x.BillingAddress
+	}
+	return nil
+}
+
+func (x *Invoice) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Invoice) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Invoice) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Invoice) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *Invoice) GetExchangeRate() float64 {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return 0
+}
+
+func (x *Invoice) GetBaseTotal() float64 {
+	if x != nil {
+		return x.BaseTotal
+	}
+	return 0
+}
+
+// InvoiceLine bills an order line, its tax is computed by the tax rules of the service
+type InvoiceLine struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OrderLineId string                 `protobuf:"bytes,1,opt,name=order_line_id,json=orderLineId,proto3" json:"order_line_id" bson:"order_line_id"`
+	ProductId   string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id" validate:"required"`
+	Sku         string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty" bson:"sku,omitempty"`
+	Name        string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty" bson:"name,omitempty"`
+	Quantity    int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity" bson:"quantity" validate:"positive"`
+	UnitPrice   float64                `protobuf:"fixed64,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price" bson:"unit_price" validate:"min=0"`
+	Discount    float64                `protobuf:"fixed64,7,opt,name=discount,proto3" json:"discount" bson:"discount" validate:"min=0"`
+	Subtotal    float64                `protobuf:"fixed64,8,opt,name=subtotal,proto3" json:"subtotal" bson:"subtotal"`
+	TaxRate     float64                `protobuf:"fixed64,9,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate" bson:"tax_rate" validate:"min=0,max=1"`
+	// Name of the tax rule the rate came from
+	TaxRule       string  `protobuf:"bytes,10,opt,name=tax_rule,json=taxRule,proto3" json:"tax_rule" bson:"tax_rule"`
+	Tax           float64 `protobuf:"fixed64,11,opt,name=tax,proto3" json:"tax" bson:"tax"`
+	Total         float64 `protobuf:"fixed64,12,opt,name=total,proto3" json:"total" bson:"total"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceLine) Reset() {
+	*x = InvoiceLine{}
+	mi := &file_core_v1_invoice_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceLine) ProtoMessage() {}
+
+func (x *InvoiceLine) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceLine.ProtoReflect.Descriptor instead.
+func (*InvoiceLine) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InvoiceLine) GetOrderLineId() string {
+	if x != nil {
+		return x.OrderLineId
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InvoiceLine) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *InvoiceLine) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *InvoiceLine) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *InvoiceLine) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *InvoiceLine) GetTaxRule() string {
+	if x != nil {
+		return x.TaxRule
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *InvoiceLine) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type InvoicePayment struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"id"`
+	Amount float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount" bson:"amount" validate:"positive"`
+	// e.g. card, bank_transfer or cash
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method" bson:"method" validate:"required,max=50"`
+	// Transaction reference of the payment provider or bank
+	Reference     string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty" bson:"reference,omitempty" validate:"max=200"`
+	PaidAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=paid_at,json=paidAt,proto3" json:"paid_at" bson:"paid_at"`
+	RecordedBy    string                 `protobuf:"bytes,6,opt,name=recorded_by,json=recordedBy,proto3" json:"recorded_by" bson:"recorded_by"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoicePayment) Reset() {
+	*x = InvoicePayment{}
+	mi := &file_core_v1_invoice_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoicePayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoicePayment) ProtoMessage() {}
+
+func (x *InvoicePayment) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoicePayment.ProtoReflect.Descriptor instead.
+func (*InvoicePayment) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InvoicePayment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InvoicePayment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *InvoicePayment) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *InvoicePayment) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *InvoicePayment) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+func (x *InvoicePayment) GetRecordedBy() string {
+	if x != nil {
+		return x.RecordedBy
+	}
+	return ""
+}
+
+// InvoiceSearch filters the invoices of a tenant, every set criterion must match
+type InvoiceSearch struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PaymentStatuses []PaymentStatus        `protobuf:"varint,1,rep,packed,name=payment_statuses,json=paymentStatuses,proto3,enum=core.v1.PaymentStatus" json:"payment_statuses,omitempty"`
+	CustomerId      *string                `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3,oneof" json:"customer_id,omitempty"`
+	OrderId         *string                `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3,oneof" json:"order_id,omitempty"`
+	// Only invoices with an amount due past their due date
+	Overdue       bool `protobuf:"varint,4,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceSearch) Reset() {
+	*x = InvoiceSearch{}
+	mi := &file_core_v1_invoice_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceSearch) ProtoMessage() {}
+
+func (x *InvoiceSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceSearch.ProtoReflect.Descriptor instead.
+func (*InvoiceSearch) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InvoiceSearch) GetPaymentStatuses() []PaymentStatus {
+	if x != nil {
+		return x.PaymentStatuses
+	}
+	return nil
+}
+
+func (x *InvoiceSearch) GetCustomerId() string {
+	if x != nil && x.CustomerId != nil {
+		return *x.CustomerId
+	}
+	return ""
+}
+
+func (x *InvoiceSearch) GetOrderId() string {
+	if x != nil && x.OrderId != nil {
+		return *x.OrderId
+	}
+	return ""
+}
+
+func (x *InvoiceSearch) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+// InvoiceDocument is the content of a printed invoice, amounts are rounded to cents
+type InvoiceDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvoiceNumber string                 `protobuf:"bytes,1,opt,name=invoice_number,json=invoiceNumber,proto3" json:"invoice_number,omitempty"`
+	OrderNumber   string                 `protobuf:"bytes,2,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"`
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	DueAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	BillTo        *InvoiceParty          `protobuf:"bytes,6,opt,name=bill_to,json=billTo,proto3" json:"bill_to,omitempty"`
+	Lines         []*InvoiceLine         `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines,omitempty"`
+	// The tax of the lines grouped by rule and rate
+	Taxes         []*InvoiceTaxSummary `protobuf:"bytes,8,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	Subtotal      float64              `protobuf:"fixed64,9,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount      float64              `protobuf:"fixed64,10,opt,name=discount,proto3" json:"discount,omitempty"`
+	Tax           float64              `protobuf:"fixed64,11,opt,name=tax,proto3" json:"tax,omitempty"`
+	Shipping      float64              `protobuf:"fixed64,12,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	Total         float64              `protobuf:"fixed64,13,opt,name=total,proto3" json:"total,omitempty"`
+	AmountPaid    float64              `protobuf:"fixed64,14,opt,name=amount_paid,json=amountPaid,proto3" json:"amount_paid,omitempty"`
+	AmountDue     float64              `protobuf:"fixed64,15,opt,name=amount_due,json=amountDue,proto3" json:"amount_due,omitempty"`
+	PaymentStatus PaymentStatus        `protobuf:"varint,16,opt,name=payment_status,json=paymentStatus,proto3,enum=core.v1.PaymentStatus" json:"payment_status,omitempty"`
+	Payments      []*InvoicePayment    `protobuf:"bytes,17,rep,name=payments,proto3" json:"payments,omitempty"`
+	// Payment terms of the customer, e.g. NET30
+	PaymentTerms  string `protobuf:"bytes,18,opt,name=payment_terms,json=paymentTerms,proto3" json:"payment_terms,omitempty"`
+	Notes         string `protobuf:"bytes,19,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceDocument) Reset() {
+	*x = InvoiceDocument{}
+	mi := &file_core_v1_invoice_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceDocument) ProtoMessage() {}
+
+func (x *InvoiceDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceDocument.ProtoReflect.Descriptor instead.
+func (*InvoiceDocument) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InvoiceDocument) GetInvoiceNumber() string {
+	if x != nil {
+		return x.InvoiceNumber
+	}
+	return ""
+}
+
+func (x *InvoiceDocument) GetOrderNumber() string {
+	if x != nil {
+		return x.OrderNumber
+	}
+	return ""
+}
+
+func (x *InvoiceDocument) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *InvoiceDocument) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *InvoiceDocument) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *InvoiceDocument) GetBillTo() *InvoiceParty {
+	if x != nil {
+		return x.BillTo
+	}
+	return nil
+}
+
+func (x *InvoiceDocument) GetLines() []*InvoiceLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *InvoiceDocument) GetTaxes() []*InvoiceTaxSummary {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+func (x *InvoiceDocument) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *InvoiceDocument) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *InvoiceDocument) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *InvoiceDocument) GetShipping() float64 {
+	if x != nil {
+		return x.Shipping
+	}
+	return 0
+}
+
+func (x *InvoiceDocument) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *InvoiceDocument) GetAmountPaid() float64 {
+	if x != nil {
+		return x.AmountPaid
+	}
+	return 0
+}
+
+func (x *InvoiceDocument) GetAmountDue() float64 {
+	if x != nil {
+		return x.AmountDue
+	}
+	return 0
+}
+
+func (x *InvoiceDocument) GetPaymentStatus() PaymentStatus {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *InvoiceDocument) GetPayments() []*InvoicePayment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *InvoiceDocument) GetPaymentTerms() string {
+	if x != nil {
+		return x.PaymentTerms
+	}
+	return ""
+}
+
+func (x *InvoiceDocument) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type InvoiceParty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	TaxId         string                 `protobuf:"bytes,4,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty"`
+	Address       *Address               `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceParty) Reset() {
+	*x = InvoiceParty{}
+	mi := &file_core_v1_invoice_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceParty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceParty) ProtoMessage() {}
+
+func (x *InvoiceParty) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceParty.ProtoReflect.Descriptor instead.
+func (*InvoiceParty) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InvoiceParty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InvoiceParty) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InvoiceParty) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *InvoiceParty) GetTaxId() string {
+	if x != nil {
+		return x.TaxId
+	}
+	return ""
+}
+
+func (x *InvoiceParty) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type InvoiceTaxSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rule  string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Rate  float64                `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Amount the rate applied to, the line subtotals minus their discounts
+	Taxable       float64 `protobuf:"fixed64,3,opt,name=taxable,proto3" json:"taxable,omitempty"`
+	Tax           float64 `protobuf:"fixed64,4,opt,name=tax,proto3" json:"tax,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceTaxSummary) Reset() {
+	*x = InvoiceTaxSummary{}
+	mi := &file_core_v1_invoice_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceTaxSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceTaxSummary) ProtoMessage() {}
+
+func (x *InvoiceTaxSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceTaxSummary.ProtoReflect.Descriptor instead.
+func (*InvoiceTaxSummary) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InvoiceTaxSummary) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *InvoiceTaxSummary) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *InvoiceTaxSummary) GetTaxable() float64 {
+	if x != nil {
+		return x.Taxable
+	}
+	return 0
+}
+
+func (x *InvoiceTaxSummary) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+// =============================================================================
+// Requests
+// =============================================================================
+type CreateInvoiceRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// A confirmed, shipped or delivered sales order without an invoice
+	OrderId       string `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty" validate:"required"`
+	Notes         string `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty" validate:"max=2000"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	mi := &file_core_v1_invoice_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateInvoiceRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type CreateInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceResponse) Reset() {
+	*x = CreateInvoiceResponse{}
+	mi := &file_core_v1_invoice_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceResponse) ProtoMessage() {}
+
+func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	InvoiceId     string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_core_v1_invoice_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetInvoiceRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetInvoiceRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+type GetInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	mi := &file_core_v1_invoice_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type SearchInvoicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Search        *InvoiceSearch         `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchInvoicesRequest) Reset() {
+	*x = SearchInvoicesRequest{}
+	mi := &file_core_v1_invoice_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchInvoicesRequest) ProtoMessage() {}
+
+func (x *SearchInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*SearchInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchInvoicesRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SearchInvoicesRequest) GetSearch() *InvoiceSearch {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *SearchInvoicesRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchInvoicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoices      []*Invoice             `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchInvoicesResponse) Reset() {
+	*x = SearchInvoicesResponse{}
+	mi := &file_core_v1_invoice_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchInvoicesResponse) ProtoMessage() {}
+
+func (x *SearchInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*SearchInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchInvoicesResponse) GetInvoices() []*Invoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+func (x *SearchInvoicesResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type RecordPaymentRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	InvoiceId  string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty" validate:"required"`
+	// At most the amount due, paid now unless paid_at is set
+	Payment       *InvoicePayment `protobuf:"bytes,3,opt,name=payment,proto3" json:"payment,omitempty" validate:"required,dive"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordPaymentRequest) Reset() {
+	*x = RecordPaymentRequest{}
+	mi := &file_core_v1_invoice_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordPaymentRequest) ProtoMessage() {}
+
+func (x *RecordPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RecordPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RecordPaymentRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *RecordPaymentRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *RecordPaymentRequest) GetPayment() *InvoicePayment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type RecordPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordPaymentResponse) Reset() {
+	*x = RecordPaymentResponse{}
+	mi := &file_core_v1_invoice_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordPaymentResponse) ProtoMessage() {}
+
+func (x *RecordPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RecordPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RecordPaymentResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type GetInvoiceDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	InvoiceId     string                 `protobuf:"bytes,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceDocumentRequest) Reset() {
+	*x = GetInvoiceDocumentRequest{}
+	mi := &file_core_v1_invoice_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceDocumentRequest) ProtoMessage() {}
+
+func (x *GetInvoiceDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceDocumentRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetInvoiceDocumentRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetInvoiceDocumentRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+type GetInvoiceDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Document      *InvoiceDocument       `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceDocumentResponse) Reset() {
+	*x = GetInvoiceDocumentResponse{}
+	mi := &file_core_v1_invoice_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceDocumentResponse) ProtoMessage() {}
+
+func (x *GetInvoiceDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_invoice_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceDocumentResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_invoice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetInvoiceDocumentResponse) GetDocument() *InvoiceDocument {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+var File_core_v1_invoice_proto protoreflect.FileDescriptor
+
+const file_core_v1_invoice_proto_rawDesc = "" +
+	"\n" +
+	"\x15core/v1/invoice.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x14infra/v1/infra.proto\x1a\x15core/v1/address.proto\x1a\x13core/v1/order.proto\"\xbd\x14\n" +
+	"\aInvoice\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12r\n" +
+	"\x0einvoice_number\x18\x03 \x01(\tBK\x9a\x84\x9e\x03Fbson:\"invoice_number\" json:\"invoice_number\" validate:\"required,max=50\"R\rinvoiceNumber\x12S\n" +
+	"\border_id\x18\x04 \x01(\tB8\x9a\x84\x9e\x033bson:\"order_id\" json:\"order_id\" validate:\"required\"R\aorderId\x12O\n" +
+	"\forder_number\x18\x05 \x01(\tB,\x9a\x84\x9e\x03'bson:\"order_number\" json:\"order_number\"R\vorderNumber\x12_\n" +
+	"\vcustomer_id\x18\x06 \x01(\tB>\x9a\x84\x9e\x039bson:\"customer_id\" json:\"customer_id\" validate:\"required\"R\n" +
+	"customerId\x12k\n" +
+	"\x05lines\x18\a \x03(\v2\x14.core.v1.InvoiceLineB?\x9a\x84\x9e\x03:bson:\"lines\" json:\"lines\" validate:\"required,max=500,dive\"R\x05lines\x12`\n" +
+	"\bcurrency\x18\b \x01(\tBD\x9a\x84\x9e\x03?bson:\"currency\" json:\"currency\" validate:\"required,min=3,max=3\"R\bcurrency\x12@\n" +
+	"\bsubtotal\x18\t \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"subtotal\" json:\"subtotal\"R\bsubtotal\x12@\n" +
+	"\bdiscount\x18\n" +
+	" \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"discount\" json:\"discount\"R\bdiscount\x12,\n" +
+	"\x03tax\x18\v \x01(\x01B\x1a\x9a\x84\x9e\x03\x15bson:\"tax\" json:\"tax\"R\x03tax\x12Q\n" +
+	"\bshipping\x18\f \x01(\x01B5\x9a\x84\x9e\x030bson:\"shipping\" json:\"shipping\" validate:\"min=0\"R\bshipping\x12E\n" +
+	"\x05total\x18\r \x01(\x01B/\x9a\x84\x9e\x03*bson:\"total\" json:\"total\" validate:\"min=0\"R\x05total\x12\\\n" +
+	"\vamount_paid\x18\x0e \x01(\x01B;\x9a\x84\x9e\x036bson:\"amount_paid\" json:\"amount_paid\" validate:\"min=0\"R\n" +
+	"amountPaid\x12X\n" +
+	"\n" +
+	"amount_due\x18\x0f \x01(\x01B9\x9a\x84\x9e\x034bson:\"amount_due\" json:\"amount_due\" validate:\"min=0\"R\tamountDue\x12\x83\x01\n" +
+	"\x0epayment_status\x18\x10 \x01(\x0e2\x16.core.v1.PaymentStatusBD\x9a\x84\x9e\x03?bson:\"payment_status\" json:\"payment_status\" validate:\"required\"R\rpaymentStatus\x12\x85\x01\n" +
+	"\bpayments\x18\x11 \x03(\v2\x17.core.v1.InvoicePaymentBP\x9a\x84\x9e\x03Kbson:\"payments,omitempty\" json:\"payments,omitempty\" validate:\"max=500,dive\"R\bpayments\x12\x81\x01\n" +
+	"\x0fbilling_address\x18\x12 \x01(\v2\x10.core.v1.AddressBF\x9a\x84\x9e\x03Abson:\"billing_address,omitempty\" json:\"billing_address,omitempty\"R\x0ebillingAddress\x12_\n" +
+	"\tissued_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampB&\x9a\x84\x9e\x03!bson:\"issued_at\" json:\"issued_at\"R\bissuedAt\x12S\n" +
+	"\x06due_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampB \x9a\x84\x9e\x03\x1bbson:\"due_at\" json:\"due_at\"R\x05dueAt\x12\\\n" +
+	"\x05notes\x18\x15 \x01(\tBF\x9a\x84\x9e\x03Abson:\"notes,omitempty\" json:\"notes,omitempty\" validate:\"max=2000\"R\x05notes\x12[\n" +
+	"\n" +
+	"created_by\x18\x16 \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12c\n" +
+	"\n" +
+	"created_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
+	"\n" +
+	"updated_at\x18\x18 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12<\n" +
+	"\aversion\x18\x19 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\x12g\n" +
+	"\rbase_currency\x18\x1a \x01(\tBB\x9a\x84\x9e\x03=bson:\"base_currency,omitempty\" json:\"base_currency,omitempty\"R\fbaseCurrency\x12g\n" +
+	"\rexchange_rate\x18\x1b \x01(\x01BB\x9a\x84\x9e\x03=bson:\"exchange_rate,omitempty\" json:\"exchange_rate,omitempty\"R\fexchangeRate\x12[\n" +
+	"\n" +
+	"base_total\x18\x1c \x01(\x01B<\x9a\x84\x9e\x037bson:\"base_total,omitempty\" json:\"base_total,omitempty\"R\tbaseTotal\"\x88\a\n" +
+	"\vInvoiceLine\x12R\n" +
+	"\rorder_line_id\x18\x01 \x01(\tB.\x9a\x84\x9e\x03)bson:\"order_line_id\" json:\"order_line_id\"R\vorderLineId\x12[\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tB<\x9a\x84\x9e\x037bson:\"product_id\" json:\"product_id\" validate:\"required\"R\tproductId\x12@\n" +
+	"\x03sku\x18\x03 \x01(\tB.\x9a\x84\x9e\x03)bson:\"sku,omitempty\" json:\"sku,omitempty\"R\x03sku\x12D\n" +
+	"\x04name\x18\x04 \x01(\tB0\x9a\x84\x9e\x03+bson:\"name,omitempty\" json:\"name,omitempty\"R\x04name\x12T\n" +
+	"\bquantity\x18\x05 \x01(\x05B8\x9a\x84\x9e\x033bson:\"quantity\" json:\"quantity\" validate:\"positive\"R\bquantity\x12X\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\x01B9\x9a\x84\x9e\x034bson:\"unit_price\" json:\"unit_price\" validate:\"min=0\"R\tunitPrice\x12Q\n" +
+	"\bdiscount\x18\a \x01(\x01B5\x9a\x84\x9e\x030bson:\"discount\" json:\"discount\" validate:\"min=0\"R\bdiscount\x12@\n" +
+	"\bsubtotal\x18\b \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"subtotal\" json:\"subtotal\"R\bsubtotal\x12V\n" +
+	"\btax_rate\x18\t \x01(\x01B;\x9a\x84\x9e\x036bson:\"tax_rate\" json:\"tax_rate\" validate:\"min=0,max=1\"R\ataxRate\x12?\n" +
+	"\btax_rule\x18\n" +
+	" \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"tax_rule\" json:\"tax_rule\"R\ataxRule\x12,\n" +
+	"\x03tax\x18\v \x01(\x01B\x1a\x9a\x84\x9e\x03\x15bson:\"tax\" json:\"tax\"R\x03tax\x124\n" +
+	"\x05total\x18\f \x01(\x01B\x1e\x9a\x84\x9e\x03\x19bson:\"total\" json:\"total\"R\x05total\"\xf0\x03\n" +
+	"\x0eInvoicePayment\x12(\n" +
+	"\x02id\x18\x01 \x01(\tB\x18\x9a\x84\x9e\x03\x13bson:\"id\" json:\"id\"R\x02id\x12L\n" +
+	"\x06amount\x18\x02 \x01(\x01B4\x9a\x84\x9e\x03/bson:\"amount\" json:\"amount\" validate:\"positive\"R\x06amount\x12S\n" +
+	"\x06method\x18\x03 \x01(\tB;\x9a\x84\x9e\x036bson:\"method\" json:\"method\" validate:\"required,max=50\"R\x06method\x12k\n" +
+	"\treference\x18\x04 \x01(\tBM\x9a\x84\x9e\x03Hbson:\"reference,omitempty\" json:\"reference,omitempty\" validate:\"max=200\"R\treference\x12W\n" +
+	"\apaid_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\"\x9a\x84\x9e\x03\x1dbson:\"paid_at\" json:\"paid_at\"R\x06paidAt\x12K\n" +
+	"\vrecorded_by\x18\x06 \x01(\tB*\x9a\x84\x9e\x03%bson:\"recorded_by\" json:\"recorded_by\"R\n" +
+	"recordedBy\"\xcf\x01\n" +
+	"\rInvoiceSearch\x12A\n" +
+	"\x10payment_statuses\x18\x01 \x03(\x0e2\x16.core.v1.PaymentStatusR\x0fpaymentStatuses\x12$\n" +
+	"\vcustomer_id\x18\x02 \x01(\tH\x00R\n" +
+	"customerId\x88\x01\x01\x12\x1e\n" +
+	"\border_id\x18\x03 \x01(\tH\x01R\aorderId\x88\x01\x01\x12\x18\n" +
+	"\aoverdue\x18\x04 \x01(\bR\aoverdueB\x0e\n" +
+	"\f_customer_idB\v\n" +
+	"\t_order_id\"\xdc\x05\n" +
+	"\x0fInvoiceDocument\x12%\n" +
+	"\x0einvoice_number\x18\x01 \x01(\tR\rinvoiceNumber\x12!\n" +
+	"\forder_number\x18\x02 \x01(\tR\vorderNumber\x127\n" +
+	"\tissued_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x121\n" +
+	"\x06due_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12.\n" +
+	"\abill_to\x18\x06 \x01(\v2\x15.core.v1.InvoicePartyR\x06billTo\x12*\n" +
+	"\x05lines\x18\a \x03(\v2\x14.core.v1.InvoiceLineR\x05lines\x120\n" +
+	"\x05taxes\x18\b \x03(\v2\x1a.core.v1.InvoiceTaxSummaryR\x05taxes\x12\x1a\n" +
+	"\bsubtotal\x18\t \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\n" +
+	" \x01(\x01R\bdiscount\x12\x10\n" +
+	"\x03tax\x18\v \x01(\x01R\x03tax\x12\x1a\n" +
+	"\bshipping\x18\f \x01(\x01R\bshipping\x12\x14\n" +
+	"\x05total\x18\r \x01(\x01R\x05total\x12\x1f\n" +
+	"\vamount_paid\x18\x0e \x01(\x01R\n" +
+	"amountPaid\x12\x1d\n" +
+	"\n" +
+	"amount_due\x18\x0f \x01(\x01R\tamountDue\x12=\n" +
+	"\x0epayment_status\x18\x10 \x01(\x0e2\x16.core.v1.PaymentStatusR\rpaymentStatus\x123\n" +
+	"\bpayments\x18\x11 \x03(\v2\x17.core.v1.InvoicePaymentR\bpayments\x12#\n" +
+	"\rpayment_terms\x18\x12 \x01(\tR\fpaymentTerms\x12\x14\n" +
+	"\x05notes\x18\x13 \x01(\tR\x05notes\"\x91\x01\n" +
+	"\fInvoiceParty\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x15\n" +
+	"\x06tax_id\x18\x04 \x01(\tR\x05taxId\x12*\n" +
+	"\aaddress\x18\x05 \x01(\v2\x10.core.v1.AddressR\aaddress\"g\n" +
+	"\x11InvoiceTaxSummary\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\x12\x18\n" +
+	"\ataxable\x18\x03 \x01(\x01R\ataxable\x12\x10\n" +
+	"\x03tax\x18\x04 \x01(\x01R\x03tax\"\xd4\x01\n" +
+	"\x14CreateInvoiceRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x123\n" +
+	"\border_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\aorderId\x12.\n" +
+	"\x05notes\x18\x03 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"max=2000\"R\x05notes\"C\n" +
+	"\x15CreateInvoiceResponse\x12*\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x10.core.v1.InvoiceR\ainvoice\"\xa5\x01\n" +
+	"\x11GetInvoiceRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x127\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\tinvoiceId\"@\n" +
+	"\x12GetInvoiceResponse\x12*\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x10.core.v1.InvoiceR\ainvoice\"\xdd\x01\n" +
+	"\x15SearchInvoicesRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12.\n" +
+	"\x06search\x18\x02 \x01(\v2\x16.core.v1.InvoiceSearchR\x06search\x12;\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"\x84\x01\n" +
+	"\x16SearchInvoicesResponse\x12,\n" +
+	"\binvoices\x18\x01 \x03(\v2\x10.core.v1.InvoiceR\binvoices\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xfa\x01\n" +
+	"\x14RecordPaymentRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x127\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\tinvoiceId\x12P\n" +
+	"\apayment\x18\x03 \x01(\v2\x17.core.v1.InvoicePaymentB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\apayment\"C\n" +
+	"\x15RecordPaymentResponse\x12*\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x10.core.v1.InvoiceR\ainvoice\"\xad\x01\n" +
+	"\x19GetInvoiceDocumentRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x127\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\tinvoiceId\"R\n" +
+	"\x1aGetInvoiceDocumentResponse\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.core.v1.InvoiceDocumentR\bdocument2\xa9\x03\n" +
+	"\x0eInvoiceService\x12N\n" +
+	"\rCreateInvoice\x12\x1d.core.v1.CreateInvoiceRequest\x1a\x1e.core.v1.CreateInvoiceResponse\x12E\n" +
+	"\n" +
+	"GetInvoice\x12\x1a.core.v1.GetInvoiceRequest\x1a\x1b.core.v1.GetInvoiceResponse\x12Q\n" +
+	"\x0eSearchInvoices\x12\x1e.core.v1.SearchInvoicesRequest\x1a\x1f.core.v1.SearchInvoicesResponse\x12N\n" +
+	"\rRecordPayment\x12\x1d.core.v1.RecordPaymentRequest\x1a\x1e.core.v1.RecordPaymentResponse\x12]\n" +
+	"\x12GetInvoiceDocument\x12\".core.v1.GetInvoiceDocumentRequest\x1a#.core.v1.GetInvoiceDocumentResponseB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_invoice_proto_rawDescOnce sync.Once
+	file_core_v1_invoice_proto_rawDescData []byte
+)
+
+func file_core_v1_invoice_proto_rawDescGZIP() []byte {
+	file_core_v1_invoice_proto_rawDescOnce.Do(func() {
+		file_core_v1_invoice_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_invoice_proto_rawDesc), len(file_core_v1_invoice_proto_rawDesc)))
+	})
+	return file_core_v1_invoice_proto_rawDescData
+}
+
+var file_core_v1_invoice_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_core_v1_invoice_proto_goTypes = []any{
+	(*Invoice)(nil),                    // 0: core.v1.Invoice
+	(*InvoiceLine)(nil),                // 1: core.v1.InvoiceLine
+	(*InvoicePayment)(nil),             // 2: core.v1.InvoicePayment
+	(*InvoiceSearch)(nil),              // 3: core.v1.InvoiceSearch
+	(*InvoiceDocument)(nil),            // 4: core.v1.InvoiceDocument
+	(*InvoiceParty)(nil),               // 5: core.v1.InvoiceParty
+	(*InvoiceTaxSummary)(nil),          // 6: core.v1.InvoiceTaxSummary
+	(*CreateInvoiceRequest)(nil),       // 7: core.v1.CreateInvoiceRequest
+	(*CreateInvoiceResponse)(nil),      // 8: core.v1.CreateInvoiceResponse
+	(*GetInvoiceRequest)(nil),          // 9: core.v1.GetInvoiceRequest
+	(*GetInvoiceResponse)(nil),         // 10: core.v1.GetInvoiceResponse
+	(*SearchInvoicesRequest)(nil),      // 11: core.v1.SearchInvoicesRequest
+	(*SearchInvoicesResponse)(nil),     // 12: core.v1.SearchInvoicesResponse
+	(*RecordPaymentRequest)(nil),       // 13: core.v1.RecordPaymentRequest
+	(*RecordPaymentResponse)(nil),      // 14: core.v1.RecordPaymentResponse
+	(*GetInvoiceDocumentRequest)(nil),  // 15: core.v1.GetInvoiceDocumentRequest
+	(*GetInvoiceDocumentResponse)(nil), // 16: core.v1.GetInvoiceDocumentResponse
+	(PaymentStatus)(0),                 // 17: core.v1.PaymentStatus
+	(*Address)(nil),                    // 18: core.v1.Address
+	(*timestamppb.Timestamp)(nil),      // 19: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),          // 20: infra.v1.UserIdentifier
+	(*v1.PaginationRequest)(nil),       // 21: infra.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),      // 22: infra.v1.PaginationResponse
+}
+var file_core_v1_invoice_proto_depIdxs = []int32{
+	1,  // 0: core.v1.Invoice.lines:type_name -> core.v1.InvoiceLine
+	17, // 1: core.v1.Invoice.payment_status:type_name -> core.v1.PaymentStatus
+	2,  // 2: core.v1.Invoice.payments:type_name -> core.v1.InvoicePayment
+	18, // 3: core.v1.Invoice.billing_address:type_name -> core.v1.Address
+	19, // 4: core.v1.Invoice.issued_at:type_name -> google.protobuf.Timestamp
+	19, // 5: core.v1.Invoice.due_at:type_name -> google.protobuf.Timestamp
+	19, // 6: core.v1.Invoice.created_at:type_name -> google.protobuf.Timestamp
+	19, // 7: core.v1.Invoice.updated_at:type_name -> google.protobuf.Timestamp
+	19, // 8: core.v1.InvoicePayment.paid_at:type_name -> google.protobuf.Timestamp
+	17, // 9: core.v1.InvoiceSearch.payment_statuses:type_name -> core.v1.PaymentStatus
+	19, // 10: core.v1.InvoiceDocument.issued_at:type_name -> google.protobuf.Timestamp
+	19, // 11: core.v1.InvoiceDocument.due_at:type_name -> google.protobuf.Timestamp
+	5,  // 12: core.v1.InvoiceDocument.bill_to:type_name -> core.v1.InvoiceParty
+	1,  // 13: core.v1.InvoiceDocument.lines:type_name -> core.v1.InvoiceLine
+	6,  // 14: core.v1.InvoiceDocument.taxes:type_name -> core.v1.InvoiceTaxSummary
+	17, // 15: core.v1.InvoiceDocument.payment_status:type_name -> core.v1.PaymentStatus
+	2,  // 16: core.v1.InvoiceDocument.payments:type_name -> core.v1.InvoicePayment
+	18, // 17: core.v1.InvoiceParty.address:type_name -> core.v1.Address
+	20, // 18: core.v1.CreateInvoiceRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 19: core.v1.CreateInvoiceResponse.invoice:type_name -> core.v1.Invoice
+	20, // 20: core.v1.GetInvoiceRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 21: core.v1.GetInvoiceResponse.invoice:type_name -> core.v1.Invoice
+	20, // 22: core.v1.SearchInvoicesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	3,  // 23: core.v1.SearchInvoicesRequest.search:type_name -> core.v1.InvoiceSearch
+	21, // 24: core.v1.SearchInvoicesRequest.pagination:type_name -> infra.v1.PaginationRequest
+	0,  // 25: core.v1.SearchInvoicesResponse.invoices:type_name -> core.v1.Invoice
+	22, // 26: core.v1.SearchInvoicesResponse.pagination:type_name -> infra.v1.PaginationResponse
+	20, // 27: core.v1.RecordPaymentRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 28: core.v1.RecordPaymentRequest.payment:type_name -> core.v1.InvoicePayment
+	0,  // 29: core.v1.RecordPaymentResponse.invoice:type_name -> core.v1.Invoice
+	20, // 30: core.v1.GetInvoiceDocumentRequest.identifier:type_name -> infra.v1.UserIdentifier
+	4,  // 31: core.v1.GetInvoiceDocumentResponse.document:type_name -> core.v1.InvoiceDocument
+	7,  // 32: core.v1.InvoiceService.CreateInvoice:input_type -> core.v1.CreateInvoiceRequest
+	9,  // 33: core.v1.InvoiceService.GetInvoice:input_type -> core.v1.GetInvoiceRequest
+	11, // 34: core.v1.InvoiceService.SearchInvoices:input_type -> core.v1.SearchInvoicesRequest
+	13, // 35: core.v1.InvoiceService.RecordPayment:input_type -> core.v1.RecordPaymentRequest
+	15, // 36: core.v1.InvoiceService.GetInvoiceDocument:input_type -> core.v1.GetInvoiceDocumentRequest
+	8,  // 37: core.v1.InvoiceService.CreateInvoice:output_type -> core.v1.CreateInvoiceResponse
+	10, // 38: core.v1.InvoiceService.GetInvoice:output_type -> core.v1.GetInvoiceResponse
+	12, // 39: core.v1.InvoiceService.SearchInvoices:output_type -> core.v1.SearchInvoicesResponse
+	14, // 40: core.v1.InvoiceService.RecordPayment:output_type -> core.v1.RecordPaymentResponse
+	16, // 41: core.v1.InvoiceService.GetInvoiceDocument:output_type -> core.v1.GetInvoiceDocumentResponse
+	37, // [37:42] is the sub-list for method output_type
+	32, // [32:37] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_invoice_proto_init() }
+func file_core_v1_invoice_proto_init() {
+	if File_core_v1_invoice_proto != nil {
+		return
+	}
+	file_core_v1_address_proto_init()
+	file_core_v1_order_proto_init()
+	file_core_v1_invoice_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_invoice_proto_rawDesc), len(file_core_v1_invoice_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_core_v1_invoice_proto_goTypes,
+		DependencyIndexes: file_core_v1_invoice_proto_depIdxs,
+		MessageInfos:      file_core_v1_invoice_proto_msgTypes,
+	}.Build()
+	File_core_v1_invoice_proto = out.File
+	file_core_v1_invoice_proto_goTypes = nil
+	file_core_v1_invoice_proto_depIdxs = nil
+}
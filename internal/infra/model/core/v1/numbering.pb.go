@@ -0,0 +1,176 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: core/v1/numbering.proto
+
+package corev1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DocumentCounter model for MongoDB core_db.document_counters collection
+// A counter holds the last number given to a document type of a tenant, in a year for the sequences reset yearly
+type DocumentCounter struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
+	// Document type of the sequence, e.g. invoice
+	Document string `protobuf:"bytes,3,opt,name=document,proto3" json:"document" bson:"document"`
+	// Year of the sequence, 0 for the sequences that are never reset
+	Period        int32                  `protobuf:"varint,4,opt,name=period,proto3" json:"period" bson:"period"`
+	Value         int64                  `protobuf:"varint,5,opt,name=value,proto3" json:"value" bson:"value"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentCounter) Reset() {
+	*x = DocumentCounter{}
+	mi := &file_core_v1_numbering_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentCounter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentCounter) ProtoMessage() {}
+
+func (x *DocumentCounter) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_numbering_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentCounter.ProtoReflect.Descriptor instead.
+func (*DocumentCounter) Descriptor() ([]byte, []int) {
+	return file_core_v1_numbering_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DocumentCounter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DocumentCounter) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DocumentCounter) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *DocumentCounter) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *DocumentCounter) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *DocumentCounter) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_core_v1_numbering_proto protoreflect.FileDescriptor
+
+const file_core_v1_numbering_proto_rawDesc = "" +
+	"\n" +
+	"\x17core/v1/numbering.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xa2\x03\n" +
+	"\x0fDocumentCounter\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
+	"\ttenant_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12@\n" +
+	"\bdocument\x18\x03 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"document\" json:\"document\"R\bdocument\x128\n" +
+	"\x06period\x18\x04 \x01(\x05B \x9a\x84\x9e\x03\x1bbson:\"period\" json:\"period\"R\x06period\x124\n" +
+	"\x05value\x18\x05 \x01(\x03B\x1e\x9a\x84\x9e\x03\x19bson:\"value\" json:\"value\"R\x05value\x12c\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAtB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_numbering_proto_rawDescOnce sync.Once
+	file_core_v1_numbering_proto_rawDescData []byte
+)
+
+func file_core_v1_numbering_proto_rawDescGZIP() []byte {
+	file_core_v1_numbering_proto_rawDescOnce.Do(func() {
+		file_core_v1_numbering_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_numbering_proto_rawDesc), len(file_core_v1_numbering_proto_rawDesc)))
+	})
+	return file_core_v1_numbering_proto_rawDescData
+}
+
+var file_core_v1_numbering_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_core_v1_numbering_proto_goTypes = []any{
+	(*DocumentCounter)(nil),       // 0: core.v1.DocumentCounter
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_core_v1_numbering_proto_depIdxs = []int32{
+	1, // 0: core.v1.DocumentCounter.updated_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_numbering_proto_init() }
+func file_core_v1_numbering_proto_init() {
+	if File_core_v1_numbering_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_numbering_proto_rawDesc), len(file_core_v1_numbering_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_core_v1_numbering_proto_goTypes,
+		DependencyIndexes: file_core_v1_numbering_proto_depIdxs,
+		MessageInfos:      file_core_v1_numbering_proto_msgTypes,
+	}.Build()
+	File_core_v1_numbering_proto = out.File
+	file_core_v1_numbering_proto_goTypes = nil
+	file_core_v1_numbering_proto_depIdxs = nil
+}
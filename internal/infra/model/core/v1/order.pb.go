@@ -7,9 +7,11 @@
 package corev1
 
 import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
 	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -142,11 +144,12 @@ func (OrderType) EnumDescriptor() ([]byte, []int) {
 type PaymentStatus int32
 
 const (
-	PaymentStatus_PAYMENT_STATUS_UNSPECIFIED PaymentStatus = 0
-	PaymentStatus_PAYMENT_STATUS_PENDING     PaymentStatus = 1
-	PaymentStatus_PAYMENT_STATUS_PAID        PaymentStatus = 2
-	PaymentStatus_PAYMENT_STATUS_REFUNDED    PaymentStatus = 3
-	PaymentStatus_PAYMENT_STATUS_FAILED      PaymentStatus = 4
+	PaymentStatus_PAYMENT_STATUS_UNSPECIFIED    PaymentStatus = 0
+	PaymentStatus_PAYMENT_STATUS_PENDING        PaymentStatus = 1
+	PaymentStatus_PAYMENT_STATUS_PAID           PaymentStatus = 2
+	PaymentStatus_PAYMENT_STATUS_REFUNDED       PaymentStatus = 3
+	PaymentStatus_PAYMENT_STATUS_FAILED         PaymentStatus = 4
+	PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID PaymentStatus = 5
 )
 
 // Enum value maps for PaymentStatus.
@@ -157,13 +160,15 @@ var (
 		2: "PAYMENT_STATUS_PAID",
 		3: "PAYMENT_STATUS_REFUNDED",
 		4: "PAYMENT_STATUS_FAILED",
+		5: "PAYMENT_STATUS_PARTIALLY_PAID",
 	}
 	PaymentStatus_value = map[string]int32{
-		"PAYMENT_STATUS_UNSPECIFIED": 0,
-		"PAYMENT_STATUS_PENDING":     1,
-		"PAYMENT_STATUS_PAID":        2,
-		"PAYMENT_STATUS_REFUNDED":    3,
-		"PAYMENT_STATUS_FAILED":      4,
+		"PAYMENT_STATUS_UNSPECIFIED":    0,
+		"PAYMENT_STATUS_PENDING":        1,
+		"PAYMENT_STATUS_PAID":           2,
+		"PAYMENT_STATUS_REFUNDED":       3,
+		"PAYMENT_STATUS_FAILED":         4,
+		"PAYMENT_STATUS_PARTIALLY_PAID": 5,
 	}
 )
 
@@ -194,86 +199,148 @@ func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
 	return file_core_v1_order_proto_rawDescGZIP(), []int{2}
 }
 
-// Order item status enum
-type OrderItemStatus int32
+// Order line status enum
+type OrderLineStatus int32
 
 const (
-	OrderItemStatus_ORDER_ITEM_STATUS_UNSPECIFIED OrderItemStatus = 0
-	OrderItemStatus_ORDER_ITEM_STATUS_PENDING     OrderItemStatus = 1
-	OrderItemStatus_ORDER_ITEM_STATUS_FULFILLED   OrderItemStatus = 2
-	OrderItemStatus_ORDER_ITEM_STATUS_CANCELLED   OrderItemStatus = 3
-	OrderItemStatus_ORDER_ITEM_STATUS_RETURNED    OrderItemStatus = 4
+	OrderLineStatus_ORDER_LINE_STATUS_UNSPECIFIED OrderLineStatus = 0
+	OrderLineStatus_ORDER_LINE_STATUS_PENDING     OrderLineStatus = 1
+	OrderLineStatus_ORDER_LINE_STATUS_FULFILLED   OrderLineStatus = 2
+	OrderLineStatus_ORDER_LINE_STATUS_CANCELLED   OrderLineStatus = 3
+	OrderLineStatus_ORDER_LINE_STATUS_RETURNED    OrderLineStatus = 4
 )
 
-// Enum value maps for OrderItemStatus.
+// Enum value maps for OrderLineStatus.
 var (
-	OrderItemStatus_name = map[int32]string{
-		0: "ORDER_ITEM_STATUS_UNSPECIFIED",
-		1: "ORDER_ITEM_STATUS_PENDING",
-		2: "ORDER_ITEM_STATUS_FULFILLED",
-		3: "ORDER_ITEM_STATUS_CANCELLED",
-		4: "ORDER_ITEM_STATUS_RETURNED",
-	}
-	OrderItemStatus_value = map[string]int32{
-		"ORDER_ITEM_STATUS_UNSPECIFIED": 0,
-		"ORDER_ITEM_STATUS_PENDING":     1,
-		"ORDER_ITEM_STATUS_FULFILLED":   2,
-		"ORDER_ITEM_STATUS_CANCELLED":   3,
-		"ORDER_ITEM_STATUS_RETURNED":    4,
+	OrderLineStatus_name = map[int32]string{
+		0: "ORDER_LINE_STATUS_UNSPECIFIED",
+		1: "ORDER_LINE_STATUS_PENDING",
+		2: "ORDER_LINE_STATUS_FULFILLED",
+		3: "ORDER_LINE_STATUS_CANCELLED",
+		4: "ORDER_LINE_STATUS_RETURNED",
+	}
+	OrderLineStatus_value = map[string]int32{
+		"ORDER_LINE_STATUS_UNSPECIFIED": 0,
+		"ORDER_LINE_STATUS_PENDING":     1,
+		"ORDER_LINE_STATUS_FULFILLED":   2,
+		"ORDER_LINE_STATUS_CANCELLED":   3,
+		"ORDER_LINE_STATUS_RETURNED":    4,
 	}
 )
 
-func (x OrderItemStatus) Enum() *OrderItemStatus {
-	p := new(OrderItemStatus)
+func (x OrderLineStatus) Enum() *OrderLineStatus {
+	p := new(OrderLineStatus)
 	*p = x
 	return p
 }
 
-func (x OrderItemStatus) String() string {
+func (x OrderLineStatus) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (OrderItemStatus) Descriptor() protoreflect.EnumDescriptor {
+func (OrderLineStatus) Descriptor() protoreflect.EnumDescriptor {
 	return file_core_v1_order_proto_enumTypes[3].Descriptor()
 }
 
-func (OrderItemStatus) Type() protoreflect.EnumType {
+func (OrderLineStatus) Type() protoreflect.EnumType {
 	return &file_core_v1_order_proto_enumTypes[3]
 }
 
-func (x OrderItemStatus) Number() protoreflect.EnumNumber {
+func (x OrderLineStatus) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use OrderItemStatus.Descriptor instead.
-func (OrderItemStatus) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use OrderLineStatus.Descriptor instead.
+func (OrderLineStatus) EnumDescriptor() ([]byte, []int) {
 	return file_core_v1_order_proto_rawDescGZIP(), []int{3}
 }
 
+// =============================================================================
+// Search
+// =============================================================================
+type OrderSortField int32
+
+const (
+	OrderSortField_ORDER_SORT_FIELD_UNSPECIFIED  OrderSortField = 0
+	OrderSortField_ORDER_SORT_FIELD_CREATED_AT   OrderSortField = 1
+	OrderSortField_ORDER_SORT_FIELD_ORDER_NUMBER OrderSortField = 2
+	OrderSortField_ORDER_SORT_FIELD_TOTAL        OrderSortField = 3
+)
+
+// Enum value maps for OrderSortField.
+var (
+	OrderSortField_name = map[int32]string{
+		0: "ORDER_SORT_FIELD_UNSPECIFIED",
+		1: "ORDER_SORT_FIELD_CREATED_AT",
+		2: "ORDER_SORT_FIELD_ORDER_NUMBER",
+		3: "ORDER_SORT_FIELD_TOTAL",
+	}
+	OrderSortField_value = map[string]int32{
+		"ORDER_SORT_FIELD_UNSPECIFIED":  0,
+		"ORDER_SORT_FIELD_CREATED_AT":   1,
+		"ORDER_SORT_FIELD_ORDER_NUMBER": 2,
+		"ORDER_SORT_FIELD_TOTAL":        3,
+	}
+)
+
+func (x OrderSortField) Enum() *OrderSortField {
+	p := new(OrderSortField)
+	*p = x
+	return p
+}
+
+func (x OrderSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_order_proto_enumTypes[4].Descriptor()
+}
+
+func (OrderSortField) Type() protoreflect.EnumType {
+	return &file_core_v1_order_proto_enumTypes[4]
+}
+
+func (x OrderSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderSortField.Descriptor instead.
+func (OrderSortField) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{4}
+}
+
 // Order model for MongoDB core_db.orders collection
+// Drafts are edited freely, the other statuses follow draft -> pending -> confirmed -> shipped -> delivered and
+// every status before shipped can be cancelled
 type Order struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	OrderId         string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id" bson:"order_id"`
-	TenantId        string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	OrderNumber     string                 `protobuf:"bytes,4,opt,name=order_number,json=orderNumber,proto3" json:"order_number" bson:"order_number"`
-	OrderType       OrderType              `protobuf:"varint,5,opt,name=order_type,json=orderType,proto3,enum=core.v1.OrderType" json:"order_type" bson:"order_type"`
-	CustomerId      string                 `protobuf:"bytes,6,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty" bson:"customer_id,omitempty"`
-	VendorId        string                 `protobuf:"bytes,7,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty" bson:"vendor_id,omitempty"`
-	Status          OrderStatus            `protobuf:"varint,8,opt,name=status,proto3,enum=core.v1.OrderStatus" json:"status" bson:"status"`
-	Items           []string               `protobuf:"bytes,9,rep,name=items,proto3" json:"items" bson:"items"`
-	Totals          *OrderTotals           `protobuf:"bytes,10,opt,name=totals,proto3" json:"totals" bson:"totals"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	OrderId     string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id" bson:"order_id"`
+	TenantId    string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	OrderNumber string                 `protobuf:"bytes,4,opt,name=order_number,json=orderNumber,proto3" json:"order_number" bson:"order_number" validate:"required,max=50"`
+	OrderType   OrderType              `protobuf:"varint,5,opt,name=order_type,json=orderType,proto3,enum=core.v1.OrderType" json:"order_type" bson:"order_type" validate:"required"`
+	// Sales orders are placed by a customer, purchase orders with a vendor
+	CustomerId string      `protobuf:"bytes,6,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty" bson:"customer_id,omitempty"`
+	VendorId   string      `protobuf:"bytes,7,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty" bson:"vendor_id,omitempty"`
+	Status     OrderStatus `protobuf:"varint,8,opt,name=status,proto3,enum=core.v1.OrderStatus" json:"status" bson:"status" validate:"required"`
+	// Computed from the lines and the shipping of the totals
+	Totals          *OrderTotals           `protobuf:"bytes,10,opt,name=totals,proto3" json:"totals" bson:"totals" validate:"required,dive"`
 	ShippingAddress *Address               `protobuf:"bytes,11,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address" bson:"shipping_address"`
 	BillingAddress  *Address               `protobuf:"bytes,12,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address" bson:"billing_address"`
 	Payment         *PaymentInfo           `protobuf:"bytes,13,opt,name=payment,proto3" json:"payment" bson:"payment"`
 	Fulfillment     *FulfillmentInfo       `protobuf:"bytes,14,opt,name=fulfillment,proto3" json:"fulfillment,omitempty" bson:"fulfillment,omitempty"`
-	Notes           string                 `protobuf:"bytes,15,opt,name=notes,proto3" json:"notes,omitempty" bson:"notes,omitempty"`
+	Notes           string                 `protobuf:"bytes,15,opt,name=notes,proto3" json:"notes,omitempty" bson:"notes,omitempty" validate:"max=2000"`
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
-	CreatedBy       string                 `protobuf:"bytes,18,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by"`
-	Timeline        []*OrderTimelineEvent  `protobuf:"bytes,19,rep,name=timeline,proto3" json:"timeline,omitempty" bson:"timeline,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	CreatedBy       string                 `protobuf:"bytes,18,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	// The status changes of the order, oldest first
+	Timeline []*OrderTimelineEvent `protobuf:"bytes,19,rep,name=timeline,proto3" json:"timeline,omitempty" bson:"timeline,omitempty"`
+	Lines    []*OrderLine          `protobuf:"bytes,20,rep,name=lines,proto3" json:"lines" bson:"lines" validate:"max=500,dive"`
+	// Incremented by every update, an order is updated at the version it was read at
+	Version       int64 `protobuf:"varint,21,opt,name=version,proto3" json:"version" bson:"version"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
@@ -362,13 +429,6 @@ func (x *Order) GetStatus() OrderStatus {
 	return OrderStatus_ORDER_STATUS_UNSPECIFIED
 }
 
-func (x *Order) GetItems() []string {
-	if x != nil {
-		return x.Items
-	}
-	return nil
-}
-
 func (x *Order) GetTotals() *OrderTotals {
 	if x != nil {
 		return x.Totals
@@ -439,14 +499,35 @@ func (x *Order) GetTimeline() []*OrderTimelineEvent {
 	return nil
 }
 
+func (x *Order) GetLines() []*OrderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Order) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type OrderTotals struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Subtotal      float64                `protobuf:"fixed64,1,opt,name=subtotal,proto3" json:"subtotal" bson:"subtotal"`
-	Tax           float64                `protobuf:"fixed64,2,opt,name=tax,proto3" json:"tax" bson:"tax"`
-	Shipping      float64                `protobuf:"fixed64,3,opt,name=shipping,proto3" json:"shipping" bson:"shipping"`
-	Discount      float64                `protobuf:"fixed64,4,opt,name=discount,proto3" json:"discount" bson:"discount"`
-	Total         float64                `protobuf:"fixed64,5,opt,name=total,proto3" json:"total" bson:"total"`
-	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency" bson:"currency"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Subtotal float64                `protobuf:"fixed64,1,opt,name=subtotal,proto3" json:"subtotal" bson:"subtotal"`
+	Tax      float64                `protobuf:"fixed64,2,opt,name=tax,proto3" json:"tax" bson:"tax"`
+	Shipping float64                `protobuf:"fixed64,3,opt,name=shipping,proto3" json:"shipping" bson:"shipping" validate:"min=0"`
+	Discount float64                `protobuf:"fixed64,4,opt,name=discount,proto3" json:"discount" bson:"discount"`
+	Total    float64                `protobuf:"fixed64,5,opt,name=total,proto3" json:"total" bson:"total"`
+	// ISO 4217 code, e.g. USD
+	Currency string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency" bson:"currency" validate:"required,min=3,max=3"`
+	// Base currency of the tenant and the units of it one unit of the currency was worth when the totals were computed,
+	// unset when the tenant had no rate for the currency
+	BaseCurrency string  `protobuf:"bytes,7,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty" bson:"base_currency,omitempty"`
+	ExchangeRate float64 `protobuf:"fixed64,8,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty" bson:"exchange_rate,omitempty"`
+	// The total in the base currency
+	BaseTotal     float64 `protobuf:"fixed64,9,opt,name=base_total,json=baseTotal,proto3" json:"base_total,omitempty" bson:"base_total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -523,6 +604,27 @@ func (x *OrderTotals) GetCurrency() string {
 	return ""
 }
 
+func (x *OrderTotals) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *OrderTotals) GetExchangeRate() float64 {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return 0
+}
+
+func (x *OrderTotals) GetBaseTotal() float64 {
+	if x != nil {
+		return x.BaseTotal
+	}
+	return 0
+}
+
 type PaymentInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method" bson:"method"`
@@ -668,7 +770,8 @@ func (x *FulfillmentInfo) GetCarrier() string {
 }
 
 type OrderTimelineEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of the order statuses of model/core, e.g. confirmed
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status" bson:"status"`
 	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp" bson:"timestamp"`
 	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id" bson:"user_id"`
@@ -735,43 +838,42 @@ func (x *OrderTimelineEvent) GetNotes() string {
 	return ""
 }
 
-// OrderItem model for MongoDB core_db.order_items collection
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
-	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id" bson:"item_id"`
-	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id" bson:"order_id"`
-	TenantId      string                 `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	ProductId     string                 `protobuf:"bytes,5,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id"`
-	Sku           string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku" bson:"sku"`
-	Name          string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name" bson:"name"`
-	Quantity      int32                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity" bson:"quantity"`
-	UnitPrice     float64                `protobuf:"fixed64,9,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price" bson:"unit_price"`
-	TaxRate       float64                `protobuf:"fixed64,10,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate" bson:"tax_rate"`
-	Discount      float64                `protobuf:"fixed64,11,opt,name=discount,proto3" json:"discount" bson:"discount"`
-	Subtotal      float64                `protobuf:"fixed64,12,opt,name=subtotal,proto3" json:"subtotal" bson:"subtotal"`
-	Tax           float64                `protobuf:"fixed64,13,opt,name=tax,proto3" json:"tax" bson:"tax"`
-	Total         float64                `protobuf:"fixed64,14,opt,name=total,proto3" json:"total" bson:"total"`
-	Status        OrderItemStatus        `protobuf:"varint,15,opt,name=status,proto3,enum=core.v1.OrderItemStatus" json:"status" bson:"status"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+// OrderLine is a product of an order, stored in the order
+// Its subtotal is quantity * unit_price, the tax applies to the subtotal minus the discount
+type OrderLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"id"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id" bson:"product_id" validate:"required"`
+	Sku       string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty" bson:"sku,omitempty" validate:"max=100"`
+	Name      string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty" bson:"name,omitempty" validate:"max=200"`
+	Quantity  int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity" bson:"quantity" validate:"positive"`
+	UnitPrice float64                `protobuf:"fixed64,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price" bson:"unit_price" validate:"min=0"`
+	// Fraction of the discounted subtotal, e.g. 0.17
+	TaxRate float64 `protobuf:"fixed64,7,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate" bson:"tax_rate" validate:"min=0,max=1"`
+	// Amount taken off the subtotal of the line
+	Discount      float64         `protobuf:"fixed64,8,opt,name=discount,proto3" json:"discount" bson:"discount" validate:"min=0"`
+	Subtotal      float64         `protobuf:"fixed64,9,opt,name=subtotal,proto3" json:"subtotal" bson:"subtotal"`
+	Tax           float64         `protobuf:"fixed64,10,opt,name=tax,proto3" json:"tax" bson:"tax"`
+	Total         float64         `protobuf:"fixed64,11,opt,name=total,proto3" json:"total" bson:"total"`
+	Status        OrderLineStatus `protobuf:"varint,12,opt,name=status,proto3,enum=core.v1.OrderLineStatus" json:"status" bson:"status"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
+func (x *OrderLine) Reset() {
+	*x = OrderLine{}
 	mi := &file_core_v1_order_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *OrderLine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*OrderLine) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
+func (x *OrderLine) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1_order_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -783,199 +885,985 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderLine.ProtoReflect.Descriptor instead.
+func (*OrderLine) Descriptor() ([]byte, []int) {
 	return file_core_v1_order_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *OrderItem) GetId() string {
+func (x *OrderLine) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *OrderItem) GetItemId() string {
-	if x != nil {
-		return x.ItemId
-	}
-	return ""
-}
-
-func (x *OrderItem) GetOrderId() string {
-	if x != nil {
-		return x.OrderId
-	}
-	return ""
-}
-
-func (x *OrderItem) GetTenantId() string {
-	if x != nil {
-		return x.TenantId
-	}
-	return ""
-}
-
-func (x *OrderItem) GetProductId() string {
+func (x *OrderLine) GetProductId() string {
 	if x != nil {
 		return x.ProductId
 	}
 	return ""
 }
 
-func (x *OrderItem) GetSku() string {
+func (x *OrderLine) GetSku() string {
 	if x != nil {
 		return x.Sku
 	}
 	return ""
 }
 
-func (x *OrderItem) GetName() string {
+func (x *OrderLine) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *OrderItem) GetQuantity() int32 {
+func (x *OrderLine) GetQuantity() int32 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-func (x *OrderItem) GetUnitPrice() float64 {
+func (x *OrderLine) GetUnitPrice() float64 {
 	if x != nil {
 		return x.UnitPrice
 	}
 	return 0
 }
 
-func (x *OrderItem) GetTaxRate() float64 {
+func (x *OrderLine) GetTaxRate() float64 {
 	if x != nil {
 		return x.TaxRate
 	}
 	return 0
 }
 
-func (x *OrderItem) GetDiscount() float64 {
+func (x *OrderLine) GetDiscount() float64 {
 	if x != nil {
 		return x.Discount
 	}
 	return 0
 }
 
-func (x *OrderItem) GetSubtotal() float64 {
+func (x *OrderLine) GetSubtotal() float64 {
 	if x != nil {
 		return x.Subtotal
 	}
 	return 0
 }
 
-func (x *OrderItem) GetTax() float64 {
+func (x *OrderLine) GetTax() float64 {
 	if x != nil {
 		return x.Tax
 	}
 	return 0
 }
 
-func (x *OrderItem) GetTotal() float64 {
+func (x *OrderLine) GetTotal() float64 {
 	if x != nil {
 		return x.Total
 	}
 	return 0
 }
 
-func (x *OrderItem) GetStatus() OrderItemStatus {
+func (x *OrderLine) GetStatus() OrderLineStatus {
 	if x != nil {
 		return x.Status
 	}
-	return OrderItemStatus_ORDER_ITEM_STATUS_UNSPECIFIED
+	return OrderLineStatus_ORDER_LINE_STATUS_UNSPECIFIED
+}
+
+// OrderSearch filters the orders of a tenant, every set criterion must match
+type OrderSearch struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Statuses   []OrderStatus          `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=core.v1.OrderStatus" json:"statuses,omitempty"`
+	OrderType  OrderType              `protobuf:"varint,2,opt,name=order_type,json=orderType,proto3,enum=core.v1.OrderType" json:"order_type,omitempty"`
+	CustomerId *string                `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3,oneof" json:"customer_id,omitempty"`
+	VendorId   *string                `protobuf:"bytes,4,opt,name=vendor_id,json=vendorId,proto3,oneof" json:"vendor_id,omitempty"`
+	// Inclusive lower and exclusive upper bound of created_at
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Case insensitive match on the order number and notes
+	Query string `protobuf:"bytes,7,opt,name=query,proto3" json:"query,omitempty"`
+	// Newest first by default
+	SortBy        OrderSortField `protobuf:"varint,8,opt,name=sort_by,json=sortBy,proto3,enum=core.v1.OrderSortField" json:"sort_by,omitempty"`
+	Ascending     bool           `protobuf:"varint,9,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderSearch) Reset() {
+	*x = OrderSearch{}
+	mi := &file_core_v1_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *OrderItem) GetCreatedAt() *timestamppb.Timestamp {
+func (*OrderSearch) ProtoMessage() {}
+
+func (x *OrderSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[6]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSearch.ProtoReflect.Descriptor instead.
+func (*OrderSearch) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderSearch) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
 	}
 	return nil
 }
 
-var File_core_v1_order_proto protoreflect.FileDescriptor
+func (x *OrderSearch) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
 
-const file_core_v1_order_proto_rawDesc = "" +
-	"\n" +
-	"\x13core/v1/order.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x15core/v1/address.proto\"\x82\r\n" +
-	"\x05Order\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12?\n" +
-	"\border_id\x18\x02 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"order_id\" json:\"order_id\"R\aorderId\x12C\n" +
-	"\ttenant_id\x18\x03 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12O\n" +
-	"\forder_number\x18\x04 \x01(\tB,\x9a\x84\x9e\x03'bson:\"order_number\" json:\"order_number\"R\vorderNumber\x12[\n" +
-	"\n" +
-	"order_type\x18\x05 \x01(\x0e2\x12.core.v1.OrderTypeB(\x9a\x84\x9e\x03#bson:\"order_type\" json:\"order_type\"R\torderType\x12_\n" +
-	"\vcustomer_id\x18\x06 \x01(\tB>\x9a\x84\x9e\x039bson:\"customer_id,omitempty\" json:\"customer_id,omitempty\"R\n" +
-	"customerId\x12W\n" +
-	"\tvendor_id\x18\a \x01(\tB:\x9a\x84\x9e\x035bson:\"vendor_id,omitempty\" json:\"vendor_id,omitempty\"R\bvendorId\x12N\n" +
-	"\x06status\x18\b \x01(\x0e2\x14.core.v1.OrderStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x124\n" +
-	"\x05items\x18\t \x03(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"items\" json:\"items\"R\x05items\x12N\n" +
-	"\x06totals\x18\n" +
-	" \x01(\v2\x14.core.v1.OrderTotalsB \x9a\x84\x9e\x03\x1bbson:\"totals\" json:\"totals\"R\x06totals\x12q\n" +
-	"\x10shipping_address\x18\v \x01(\v2\x10.core.v1.AddressB4\x9a\x84\x9e\x03/bson:\"shipping_address\" json:\"shipping_address\"R\x0fshippingAddress\x12m\n" +
-	"\x0fbilling_address\x18\f \x01(\v2\x10.core.v1.AddressB2\x9a\x84\x9e\x03-bson:\"billing_address\" json:\"billing_address\"R\x0ebillingAddress\x12R\n" +
-	"\apayment\x18\r \x01(\v2\x14.core.v1.PaymentInfoB\"\x9a\x84\x9e\x03\x1dbson:\"payment\" json:\"payment\"R\apayment\x12z\n" +
-	"\vfulfillment\x18\x0e \x01(\v2\x18.core.v1.FulfillmentInfoB>\x9a\x84\x9e\x039bson:\"fulfillment,omitempty\" json:\"fulfillment,omitempty\"R\vfulfillment\x12H\n" +
-	"\x05notes\x18\x0f \x01(\tB2\x9a\x84\x9e\x03-bson:\"notes,omitempty\" json:\"notes,omitempty\"R\x05notes\x12c\n" +
-	"\n" +
-	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
-	"\n" +
-	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12G\n" +
-	"\n" +
-	"created_by\x18\x12 \x01(\tB(\x9a\x84\x9e\x03#bson:\"created_by\" json:\"created_by\"R\tcreatedBy\x12q\n" +
-	"\btimeline\x18\x13 \x03(\v2\x1b.core.v1.OrderTimelineEventB8\x9a\x84\x9e\x033bson:\"timeline,omitempty\" json:\"timeline,omitempty\"R\btimeline\"\xf9\x02\n" +
-	"\vOrderTotals\x12@\n" +
-	"\bsubtotal\x18\x01 \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"subtotal\" json:\"subtotal\"R\bsubtotal\x12,\n" +
-	"\x03tax\x18\x02 \x01(\x01B\x1a\x9a\x84\x9e\x03\x15bson:\"tax\" json:\"tax\"R\x03tax\x12@\n" +
-	"\bshipping\x18\x03 \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"shipping\" json:\"shipping\"R\bshipping\x12@\n" +
-	"\bdiscount\x18\x04 \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"discount\" json:\"discount\"R\bdiscount\x124\n" +
-	"\x05total\x18\x05 \x01(\x01B\x1e\x9a\x84\x9e\x03\x19bson:\"total\" json:\"total\"R\x05total\x12@\n" +
-	"\bcurrency\x18\x06 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"currency\" json:\"currency\"R\bcurrency\"\xf3\x02\n" +
-	"\vPaymentInfo\x128\n" +
-	"\x06method\x18\x01 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"method\" json:\"method\"R\x06method\x12P\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x16.core.v1.PaymentStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12k\n" +
-	"\apaid_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB6\x9a\x84\x9e\x031bson:\"paid_at,omitempty\" json:\"paid_at,omitempty\"R\x06paidAt\x12k\n" +
-	"\x0etransaction_id\x18\x04 \x01(\tBD\x9a\x84\x9e\x03?bson:\"transaction_id,omitempty\" json:\"transaction_id,omitempty\"R\rtransactionId\"\xb3\x04\n" +
-	"\x0fFulfillmentInfo\x12c\n" +
-	"\fwarehouse_id\x18\x01 \x01(\tB@\x9a\x84\x9e\x03;bson:\"warehouse_id,omitempty\" json:\"warehouse_id,omitempty\"R\vwarehouseId\x12w\n" +
-	"\n" +
-	"shipped_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB<\x9a\x84\x9e\x037bson:\"shipped_at,omitempty\" json:\"shipped_at,omitempty\"R\tshippedAt\x12\x7f\n" +
-	"\fdelivered_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB@\x9a\x84\x9e\x03;bson:\"delivered_at,omitempty\" json:\"delivered_at,omitempty\"R\vdeliveredAt\x12o\n" +
-	"\x0ftracking_number\x18\x04 \x01(\tBF\x9a\x84\x9e\x03Abson:\"tracking_number,omitempty\" json:\"tracking_number,omitempty\"R\x0etrackingNumber\x12P\n" +
-	"\acarrier\x18\x05 \x01(\tB6\x9a\x84\x9e\x031bson:\"carrier,omitempty\" json:\"carrier,omitempty\"R\acarrier\"\xb7\x02\n" +
-	"\x12OrderTimelineEvent\x128\n" +
-	"\x06status\x18\x01 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12`\n" +
-	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB&\x9a\x84\x9e\x03!bson:\"timestamp\" json:\"timestamp\"R\ttimestamp\x12;\n" +
-	"\auser_id\x18\x03 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"user_id\" json:\"user_id\"R\x06userId\x12H\n" +
-	"\x05notes\x18\x04 \x01(\tB2\x9a\x84\x9e\x03-bson:\"notes,omitempty\" json:\"notes,omitempty\"R\x05notes\"\x99\b\n" +
-	"\tOrderItem\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12;\n" +
-	"\aitem_id\x18\x02 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"item_id\" json:\"item_id\"R\x06itemId\x12?\n" +
-	"\border_id\x18\x03 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"order_id\" json:\"order_id\"R\aorderId\x12C\n" +
-	"\ttenant_id\x18\x04 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x12G\n" +
+func (x *OrderSearch) GetCustomerId() string {
+	if x != nil && x.CustomerId != nil {
+		return *x.CustomerId
+	}
+	return ""
+}
+
+func (x *OrderSearch) GetVendorId() string {
+	if x != nil && x.VendorId != nil {
+		return *x.VendorId
+	}
+	return ""
+}
+
+func (x *OrderSearch) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *OrderSearch) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *OrderSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *OrderSearch) GetSortBy() OrderSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return OrderSortField_ORDER_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *OrderSearch) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+// =============================================================================
+// Orders
+// =============================================================================
+type CreateOrderRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Created as a draft, the totals are computed from the lines
+	Order         *Order `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_core_v1_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateOrderRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CreateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderResponse) Reset() {
+	*x = CreateOrderResponse{}
+	mi := &file_core_v1_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderResponse) ProtoMessage() {}
+
+func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_core_v1_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_core_v1_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type SearchOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Search        *OrderSearch           `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersRequest) Reset() {
+	*x = SearchOrdersRequest{}
+	mi := &file_core_v1_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersRequest) ProtoMessage() {}
+
+func (x *SearchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*SearchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchOrdersRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetSearch() *OrderSearch {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersResponse) Reset() {
+	*x = SearchOrdersResponse{}
+	mi := &file_core_v1_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersResponse) ProtoMessage() {}
+
+func (x *SearchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*SearchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *SearchOrdersResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UpdateOrderRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// The id and version of the order are required, only drafts are updated
+	Order *Order `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty" validate:"required"`
+	// Fields to update, e.g. "lines" or "totals.shipping", every editable field is replaced when empty
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderRequest) Reset() {
+	*x = UpdateOrderRequest{}
+	mi := &file_core_v1_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderRequest) ProtoMessage() {}
+
+func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateOrderRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *UpdateOrderRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderResponse) Reset() {
+	*x = UpdateOrderResponse{}
+	mi := &file_core_v1_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderResponse) ProtoMessage() {}
+
+func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ChangeOrderStatusRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	OrderId    string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty" validate:"required"`
+	Status     OrderStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=core.v1.OrderStatus" json:"status,omitempty" validate:"required"`
+	// Recorded in the timeline of the order
+	Notes string `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty" validate:"max=2000"`
+	// Carrier, tracking number and warehouse of a shipped order
+	Fulfillment   *FulfillmentInfo `protobuf:"bytes,5,opt,name=fulfillment,proto3" json:"fulfillment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeOrderStatusRequest) Reset() {
+	*x = ChangeOrderStatusRequest{}
+	mi := &file_core_v1_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeOrderStatusRequest) ProtoMessage() {}
+
+func (x *ChangeOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*ChangeOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChangeOrderStatusRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ChangeOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ChangeOrderStatusRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ChangeOrderStatusRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *ChangeOrderStatusRequest) GetFulfillment() *FulfillmentInfo {
+	if x != nil {
+		return x.Fulfillment
+	}
+	return nil
+}
+
+type ChangeOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeOrderStatusResponse) Reset() {
+	*x = ChangeOrderStatusResponse{}
+	mi := &file_core_v1_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeOrderStatusResponse) ProtoMessage() {}
+
+func (x *ChangeOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*ChangeOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChangeOrderStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type DeleteOrderRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Only drafts are deleted, the other orders are cancelled
+	OrderId       string `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrderRequest) Reset() {
+	*x = DeleteOrderRequest{}
+	mi := &file_core_v1_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderRequest) ProtoMessage() {}
+
+func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteOrderRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DeleteOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type DeleteOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrderResponse) Reset() {
+	*x = DeleteOrderResponse{}
+	mi := &file_core_v1_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderResponse) ProtoMessage() {}
+
+func (x *DeleteOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrderResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteOrderResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+var File_core_v1_order_proto protoreflect.FileDescriptor
+
+const file_core_v1_order_proto_rawDesc = "" +
+	"\n" +
+	"\x13core/v1/order.proto\x12\acore.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x14infra/v1/infra.proto\x1a\x15core/v1/address.proto\"\xaf\x0f\n" +
+	"\x05Order\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12?\n" +
+	"\border_id\x18\x02 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"order_id\" json:\"order_id\"R\aorderId\x12W\n" +
+	"\ttenant_id\x18\x03 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12j\n" +
+	"\forder_number\x18\x04 \x01(\tBG\x9a\x84\x9e\x03Bbson:\"order_number\" json:\"order_number\" validate:\"required,max=50\"R\vorderNumber\x12o\n" +
+	"\n" +
+	"order_type\x18\x05 \x01(\x0e2\x12.core.v1.OrderTypeB<\x9a\x84\x9e\x037bson:\"order_type\" json:\"order_type\" validate:\"required\"R\torderType\x12_\n" +
+	"\vcustomer_id\x18\x06 \x01(\tB>\x9a\x84\x9e\x039bson:\"customer_id,omitempty\" json:\"customer_id,omitempty\"R\n" +
+	"customerId\x12W\n" +
+	"\tvendor_id\x18\a \x01(\tB:\x9a\x84\x9e\x035bson:\"vendor_id,omitempty\" json:\"vendor_id,omitempty\"R\bvendorId\x12b\n" +
+	"\x06status\x18\b \x01(\x0e2\x14.core.v1.OrderStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12g\n" +
+	"\x06totals\x18\n" +
+	" \x01(\v2\x14.core.v1.OrderTotalsB9\x9a\x84\x9e\x034bson:\"totals\" json:\"totals\" validate:\"required,dive\"R\x06totals\x12q\n" +
+	"\x10shipping_address\x18\v \x01(\v2\x10.core.v1.AddressB4\x9a\x84\x9e\x03/bson:\"shipping_address\" json:\"shipping_address\"R\x0fshippingAddress\x12m\n" +
+	"\x0fbilling_address\x18\f \x01(\v2\x10.core.v1.AddressB2\x9a\x84\x9e\x03-bson:\"billing_address\" json:\"billing_address\"R\x0ebillingAddress\x12R\n" +
+	"\apayment\x18\r \x01(\v2\x14.core.v1.PaymentInfoB\"\x9a\x84\x9e\x03\x1dbson:\"payment\" json:\"payment\"R\apayment\x12z\n" +
+	"\vfulfillment\x18\x0e \x01(\v2\x18.core.v1.FulfillmentInfoB>\x9a\x84\x9e\x039bson:\"fulfillment,omitempty\" json:\"fulfillment,omitempty\"R\vfulfillment\x12\\\n" +
+	"\x05notes\x18\x0f \x01(\tBF\x9a\x84\x9e\x03Abson:\"notes,omitempty\" json:\"notes,omitempty\" validate:\"max=2000\"R\x05notes\x12c\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12[\n" +
+	"\n" +
+	"created_by\x18\x12 \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12q\n" +
+	"\btimeline\x18\x13 \x03(\v2\x1b.core.v1.OrderTimelineEventB8\x9a\x84\x9e\x033bson:\"timeline,omitempty\" json:\"timeline,omitempty\"R\btimeline\x12`\n" +
+	"\x05lines\x18\x14 \x03(\v2\x12.core.v1.OrderLineB6\x9a\x84\x9e\x031bson:\"lines\" json:\"lines\" validate:\"max=500,dive\"R\x05lines\x12<\n" +
+	"\aversion\x18\x15 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversionJ\x04\b\t\x10\n" +
+	"R\x05items\"\xd9\x05\n" +
+	"\vOrderTotals\x12@\n" +
+	"\bsubtotal\x18\x01 \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"subtotal\" json:\"subtotal\"R\bsubtotal\x12,\n" +
+	"\x03tax\x18\x02 \x01(\x01B\x1a\x9a\x84\x9e\x03\x15bson:\"tax\" json:\"tax\"R\x03tax\x12Q\n" +
+	"\bshipping\x18\x03 \x01(\x01B5\x9a\x84\x9e\x030bson:\"shipping\" json:\"shipping\" validate:\"min=0\"R\bshipping\x12@\n" +
+	"\bdiscount\x18\x04 \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"discount\" json:\"discount\"R\bdiscount\x124\n" +
+	"\x05total\x18\x05 \x01(\x01B\x1e\x9a\x84\x9e\x03\x19bson:\"total\" json:\"total\"R\x05total\x12`\n" +
+	"\bcurrency\x18\x06 \x01(\tBD\x9a\x84\x9e\x03?bson:\"currency\" json:\"currency\" validate:\"required,min=3,max=3\"R\bcurrency\x12g\n" +
+	"\rbase_currency\x18\a \x01(\tBB\x9a\x84\x9e\x03=bson:\"base_currency,omitempty\" json:\"base_currency,omitempty\"R\fbaseCurrency\x12g\n" +
+	"\rexchange_rate\x18\b \x01(\x01BB\x9a\x84\x9e\x03=bson:\"exchange_rate,omitempty\" json:\"exchange_rate,omitempty\"R\fexchangeRate\x12[\n" +
+	"\n" +
+	"base_total\x18\t \x01(\x01B<\x9a\x84\x9e\x037bson:\"base_total,omitempty\" json:\"base_total,omitempty\"R\tbaseTotal\"\xf3\x02\n" +
+	"\vPaymentInfo\x128\n" +
+	"\x06method\x18\x01 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"method\" json:\"method\"R\x06method\x12P\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.core.v1.PaymentStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12k\n" +
+	"\apaid_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB6\x9a\x84\x9e\x031bson:\"paid_at,omitempty\" json:\"paid_at,omitempty\"R\x06paidAt\x12k\n" +
+	"\x0etransaction_id\x18\x04 \x01(\tBD\x9a\x84\x9e\x03?bson:\"transaction_id,omitempty\" json:\"transaction_id,omitempty\"R\rtransactionId\"\xb3\x04\n" +
+	"\x0fFulfillmentInfo\x12c\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tB@\x9a\x84\x9e\x03;bson:\"warehouse_id,omitempty\" json:\"warehouse_id,omitempty\"R\vwarehouseId\x12w\n" +
+	"\n" +
+	"shipped_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB<\x9a\x84\x9e\x037bson:\"shipped_at,omitempty\" json:\"shipped_at,omitempty\"R\tshippedAt\x12\x7f\n" +
+	"\fdelivered_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB@\x9a\x84\x9e\x03;bson:\"delivered_at,omitempty\" json:\"delivered_at,omitempty\"R\vdeliveredAt\x12o\n" +
+	"\x0ftracking_number\x18\x04 \x01(\tBF\x9a\x84\x9e\x03Abson:\"tracking_number,omitempty\" json:\"tracking_number,omitempty\"R\x0etrackingNumber\x12P\n" +
+	"\acarrier\x18\x05 \x01(\tB6\x9a\x84\x9e\x031bson:\"carrier,omitempty\" json:\"carrier,omitempty\"R\acarrier\"\xb7\x02\n" +
+	"\x12OrderTimelineEvent\x128\n" +
+	"\x06status\x18\x01 \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12`\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB&\x9a\x84\x9e\x03!bson:\"timestamp\" json:\"timestamp\"R\ttimestamp\x12;\n" +
+	"\auser_id\x18\x03 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"user_id\" json:\"user_id\"R\x06userId\x12H\n" +
+	"\x05notes\x18\x04 \x01(\tB2\x9a\x84\x9e\x03-bson:\"notes,omitempty\" json:\"notes,omitempty\"R\x05notes\"\x95\a\n" +
+	"\tOrderLine\x12(\n" +
+	"\x02id\x18\x01 \x01(\tB\x18\x9a\x84\x9e\x03\x13bson:\"id\" json:\"id\"R\x02id\x12[\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tB<\x9a\x84\x9e\x037bson:\"product_id\" json:\"product_id\" validate:\"required\"R\tproductId\x12S\n" +
+	"\x03sku\x18\x03 \x01(\tBA\x9a\x84\x9e\x03<bson:\"sku,omitempty\" json:\"sku,omitempty\" validate:\"max=100\"R\x03sku\x12W\n" +
+	"\x04name\x18\x04 \x01(\tBC\x9a\x84\x9e\x03>bson:\"name,omitempty\" json:\"name,omitempty\" validate:\"max=200\"R\x04name\x12T\n" +
+	"\bquantity\x18\x05 \x01(\x05B8\x9a\x84\x9e\x033bson:\"quantity\" json:\"quantity\" validate:\"positive\"R\bquantity\x12X\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\x01B9\x9a\x84\x9e\x034bson:\"unit_price\" json:\"unit_price\" validate:\"min=0\"R\tunitPrice\x12V\n" +
+	"\btax_rate\x18\a \x01(\x01B;\x9a\x84\x9e\x036bson:\"tax_rate\" json:\"tax_rate\" validate:\"min=0,max=1\"R\ataxRate\x12Q\n" +
+	"\bdiscount\x18\b \x01(\x01B5\x9a\x84\x9e\x030bson:\"discount\" json:\"discount\" validate:\"min=0\"R\bdiscount\x12@\n" +
+	"\bsubtotal\x18\t \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"subtotal\" json:\"subtotal\"R\bsubtotal\x12,\n" +
+	"\x03tax\x18\n" +
+	" \x01(\x01B\x1a\x9a\x84\x9e\x03\x15bson:\"tax\" json:\"tax\"R\x03tax\x124\n" +
+	"\x05total\x18\v \x01(\x01B\x1e\x9a\x84\x9e\x03\x19bson:\"total\" json:\"total\"R\x05total\x12R\n" +
+	"\x06status\x18\f \x01(\x0e2\x18.core.v1.OrderLineStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\"\xc2\x03\n" +
+	"\vOrderSearch\x120\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x14.core.v1.OrderStatusR\bstatuses\x121\n" +
+	"\n" +
+	"order_type\x18\x02 \x01(\x0e2\x12.core.v1.OrderTypeR\torderType\x12$\n" +
+	"\vcustomer_id\x18\x03 \x01(\tH\x00R\n" +
+	"customerId\x88\x01\x01\x12 \n" +
+	"\tvendor_id\x18\x04 \x01(\tH\x01R\bvendorId\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x14\n" +
+	"\x05query\x18\a \x01(\tR\x05query\x120\n" +
+	"\asort_by\x18\b \x01(\x0e2\x17.core.v1.OrderSortFieldR\x06sortBy\x12\x1c\n" +
+	"\tascending\x18\t \x01(\bR\tascendingB\x0e\n" +
+	"\f_customer_idB\f\n" +
+	"\n" +
+	"_vendor_id\"\xad\x01\n" +
+	"\x12CreateOrderRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12>\n" +
+	"\x05order\x18\x02 \x01(\v2\x0e.core.v1.OrderB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x05order\";\n" +
+	"\x13CreateOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.core.v1.OrderR\x05order\"\x9f\x01\n" +
+	"\x0fGetOrderRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x123\n" +
+	"\border_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\aorderId\"8\n" +
+	"\x10GetOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.core.v1.OrderR\x05order\"\xd9\x01\n" +
+	"\x13SearchOrdersRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12,\n" +
+	"\x06search\x18\x02 \x01(\v2\x14.core.v1.OrderSearchR\x06search\x12;\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"|\n" +
+	"\x14SearchOrdersResponse\x12&\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0e.core.v1.OrderR\x06orders\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xea\x01\n" +
+	"\x12UpdateOrderRequest\x12W\n" +
 	"\n" +
-	"product_id\x18\x05 \x01(\tB(\x9a\x84\x9e\x03#bson:\"product_id\" json:\"product_id\"R\tproductId\x12,\n" +
-	"\x03sku\x18\x06 \x01(\tB\x1a\x9a\x84\x9e\x03\x15bson:\"sku\" json:\"sku\"R\x03sku\x120\n" +
-	"\x04name\x18\a \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x12@\n" +
-	"\bquantity\x18\b \x01(\x05B$\x9a\x84\x9e\x03\x1fbson:\"quantity\" json:\"quantity\"R\bquantity\x12G\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12>\n" +
+	"\x05order\x18\x02 \x01(\v2\x0e.core.v1.OrderB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x05order\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\";\n" +
+	"\x13UpdateOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.core.v1.OrderR\x05order\"\xdc\x02\n" +
+	"\x18ChangeOrderStatusRequest\x12W\n" +
 	"\n" +
-	"unit_price\x18\t \x01(\x01B(\x9a\x84\x9e\x03#bson:\"unit_price\" json:\"unit_price\"R\tunitPrice\x12?\n" +
-	"\btax_rate\x18\n" +
-	" \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"tax_rate\" json:\"tax_rate\"R\ataxRate\x12@\n" +
-	"\bdiscount\x18\v \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"discount\" json:\"discount\"R\bdiscount\x12@\n" +
-	"\bsubtotal\x18\f \x01(\x01B$\x9a\x84\x9e\x03\x1fbson:\"subtotal\" json:\"subtotal\"R\bsubtotal\x12,\n" +
-	"\x03tax\x18\r \x01(\x01B\x1a\x9a\x84\x9e\x03\x15bson:\"tax\" json:\"tax\"R\x03tax\x124\n" +
-	"\x05total\x18\x0e \x01(\x01B\x1e\x9a\x84\x9e\x03\x19bson:\"total\" json:\"total\"R\x05total\x12R\n" +
-	"\x06status\x18\x0f \x01(\x0e2\x18.core.v1.OrderItemStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12c\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x123\n" +
+	"\border_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\aorderId\x12F\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.core.v1.OrderStatusB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06status\x12.\n" +
+	"\x05notes\x18\x04 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"max=2000\"R\x05notes\x12:\n" +
+	"\vfulfillment\x18\x05 \x01(\v2\x18.core.v1.FulfillmentInfoR\vfulfillment\"A\n" +
+	"\x19ChangeOrderStatusResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.core.v1.OrderR\x05order\"\xa2\x01\n" +
+	"\x12DeleteOrderRequest\x12W\n" +
 	"\n" +
-	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt*\xcb\x01\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x123\n" +
+	"\border_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\aorderId\"/\n" +
+	"\x13DeleteOrderResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted*\xcb\x01\n" +
 	"\vOrderStatus\x12\x1c\n" +
 	"\x18ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12ORDER_STATUS_DRAFT\x10\x01\x12\x18\n" +
@@ -988,19 +1876,32 @@ const file_core_v1_order_proto_rawDesc = "" +
 	"\x16ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10ORDER_TYPE_SALES\x10\x01\x12\x17\n" +
 	"\x13ORDER_TYPE_PURCHASE\x10\x02\x12\x17\n" +
-	"\x13ORDER_TYPE_TRANSFER\x10\x03*\x9c\x01\n" +
+	"\x13ORDER_TYPE_TRANSFER\x10\x03*\xbf\x01\n" +
 	"\rPaymentStatus\x12\x1e\n" +
 	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x17\n" +
 	"\x13PAYMENT_STATUS_PAID\x10\x02\x12\x1b\n" +
 	"\x17PAYMENT_STATUS_REFUNDED\x10\x03\x12\x19\n" +
-	"\x15PAYMENT_STATUS_FAILED\x10\x04*\xb5\x01\n" +
-	"\x0fOrderItemStatus\x12!\n" +
-	"\x1dORDER_ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
-	"\x19ORDER_ITEM_STATUS_PENDING\x10\x01\x12\x1f\n" +
-	"\x1bORDER_ITEM_STATUS_FULFILLED\x10\x02\x12\x1f\n" +
-	"\x1bORDER_ITEM_STATUS_CANCELLED\x10\x03\x12\x1e\n" +
-	"\x1aORDER_ITEM_STATUS_RETURNED\x10\x04B3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+	"\x15PAYMENT_STATUS_FAILED\x10\x04\x12!\n" +
+	"\x1dPAYMENT_STATUS_PARTIALLY_PAID\x10\x05*\xb5\x01\n" +
+	"\x0fOrderLineStatus\x12!\n" +
+	"\x1dORDER_LINE_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ORDER_LINE_STATUS_PENDING\x10\x01\x12\x1f\n" +
+	"\x1bORDER_LINE_STATUS_FULFILLED\x10\x02\x12\x1f\n" +
+	"\x1bORDER_LINE_STATUS_CANCELLED\x10\x03\x12\x1e\n" +
+	"\x1aORDER_LINE_STATUS_RETURNED\x10\x04*\x92\x01\n" +
+	"\x0eOrderSortField\x12 \n" +
+	"\x1cORDER_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bORDER_SORT_FIELD_CREATED_AT\x10\x01\x12!\n" +
+	"\x1dORDER_SORT_FIELD_ORDER_NUMBER\x10\x02\x12\x1a\n" +
+	"\x16ORDER_SORT_FIELD_TOTAL\x10\x032\xd6\x03\n" +
+	"\fOrderService\x12H\n" +
+	"\vCreateOrder\x12\x1b.core.v1.CreateOrderRequest\x1a\x1c.core.v1.CreateOrderResponse\x12?\n" +
+	"\bGetOrder\x12\x18.core.v1.GetOrderRequest\x1a\x19.core.v1.GetOrderResponse\x12K\n" +
+	"\fSearchOrders\x12\x1c.core.v1.SearchOrdersRequest\x1a\x1d.core.v1.SearchOrdersResponse\x12H\n" +
+	"\vUpdateOrder\x12\x1b.core.v1.UpdateOrderRequest\x1a\x1c.core.v1.UpdateOrderResponse\x12Z\n" +
+	"\x11ChangeOrderStatus\x12!.core.v1.ChangeOrderStatusRequest\x1a\".core.v1.ChangeOrderStatusResponse\x12H\n" +
+	"\vDeleteOrder\x12\x1b.core.v1.DeleteOrderRequest\x1a\x1c.core.v1.DeleteOrderResponseB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
 
 var (
 	file_core_v1_order_proto_rawDescOnce sync.Once
@@ -1014,45 +1915,99 @@ func file_core_v1_order_proto_rawDescGZIP() []byte {
 	return file_core_v1_order_proto_rawDescData
 }
 
-var file_core_v1_order_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_core_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_core_v1_order_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_core_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_core_v1_order_proto_goTypes = []any{
-	(OrderStatus)(0),              // 0: core.v1.OrderStatus
-	(OrderType)(0),                // 1: core.v1.OrderType
-	(PaymentStatus)(0),            // 2: core.v1.PaymentStatus
-	(OrderItemStatus)(0),          // 3: core.v1.OrderItemStatus
-	(*Order)(nil),                 // 4: core.v1.Order
-	(*OrderTotals)(nil),           // 5: core.v1.OrderTotals
-	(*PaymentInfo)(nil),           // 6: core.v1.PaymentInfo
-	(*FulfillmentInfo)(nil),       // 7: core.v1.FulfillmentInfo
-	(*OrderTimelineEvent)(nil),    // 8: core.v1.OrderTimelineEvent
-	(*OrderItem)(nil),             // 9: core.v1.OrderItem
-	(*Address)(nil),               // 10: core.v1.Address
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(OrderStatus)(0),                  // 0: core.v1.OrderStatus
+	(OrderType)(0),                    // 1: core.v1.OrderType
+	(PaymentStatus)(0),                // 2: core.v1.PaymentStatus
+	(OrderLineStatus)(0),              // 3: core.v1.OrderLineStatus
+	(OrderSortField)(0),               // 4: core.v1.OrderSortField
+	(*Order)(nil),                     // 5: core.v1.Order
+	(*OrderTotals)(nil),               // 6: core.v1.OrderTotals
+	(*PaymentInfo)(nil),               // 7: core.v1.PaymentInfo
+	(*FulfillmentInfo)(nil),           // 8: core.v1.FulfillmentInfo
+	(*OrderTimelineEvent)(nil),        // 9: core.v1.OrderTimelineEvent
+	(*OrderLine)(nil),                 // 10: core.v1.OrderLine
+	(*OrderSearch)(nil),               // 11: core.v1.OrderSearch
+	(*CreateOrderRequest)(nil),        // 12: core.v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),       // 13: core.v1.CreateOrderResponse
+	(*GetOrderRequest)(nil),           // 14: core.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),          // 15: core.v1.GetOrderResponse
+	(*SearchOrdersRequest)(nil),       // 16: core.v1.SearchOrdersRequest
+	(*SearchOrdersResponse)(nil),      // 17: core.v1.SearchOrdersResponse
+	(*UpdateOrderRequest)(nil),        // 18: core.v1.UpdateOrderRequest
+	(*UpdateOrderResponse)(nil),       // 19: core.v1.UpdateOrderResponse
+	(*ChangeOrderStatusRequest)(nil),  // 20: core.v1.ChangeOrderStatusRequest
+	(*ChangeOrderStatusResponse)(nil), // 21: core.v1.ChangeOrderStatusResponse
+	(*DeleteOrderRequest)(nil),        // 22: core.v1.DeleteOrderRequest
+	(*DeleteOrderResponse)(nil),       // 23: core.v1.DeleteOrderResponse
+	(*Address)(nil),                   // 24: core.v1.Address
+	(*timestamppb.Timestamp)(nil),     // 25: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),         // 26: infra.v1.UserIdentifier
+	(*v1.PaginationRequest)(nil),      // 27: infra.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),     // 28: infra.v1.PaginationResponse
+	(*fieldmaskpb.FieldMask)(nil),     // 29: google.protobuf.FieldMask
 }
 var file_core_v1_order_proto_depIdxs = []int32{
 	1,  // 0: core.v1.Order.order_type:type_name -> core.v1.OrderType
 	0,  // 1: core.v1.Order.status:type_name -> core.v1.OrderStatus
-	5,  // 2: core.v1.Order.totals:type_name -> core.v1.OrderTotals
-	10, // 3: core.v1.Order.shipping_address:type_name -> core.v1.Address
-	10, // 4: core.v1.Order.billing_address:type_name -> core.v1.Address
-	6,  // 5: core.v1.Order.payment:type_name -> core.v1.PaymentInfo
-	7,  // 6: core.v1.Order.fulfillment:type_name -> core.v1.FulfillmentInfo
-	11, // 7: core.v1.Order.created_at:type_name -> google.protobuf.Timestamp
-	11, // 8: core.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
-	8,  // 9: core.v1.Order.timeline:type_name -> core.v1.OrderTimelineEvent
-	2,  // 10: core.v1.PaymentInfo.status:type_name -> core.v1.PaymentStatus
-	11, // 11: core.v1.PaymentInfo.paid_at:type_name -> google.protobuf.Timestamp
-	11, // 12: core.v1.FulfillmentInfo.shipped_at:type_name -> google.protobuf.Timestamp
-	11, // 13: core.v1.FulfillmentInfo.delivered_at:type_name -> google.protobuf.Timestamp
-	11, // 14: core.v1.OrderTimelineEvent.timestamp:type_name -> google.protobuf.Timestamp
-	3,  // 15: core.v1.OrderItem.status:type_name -> core.v1.OrderItemStatus
-	11, // 16: core.v1.OrderItem.created_at:type_name -> google.protobuf.Timestamp
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	6,  // 2: core.v1.Order.totals:type_name -> core.v1.OrderTotals
+	24, // 3: core.v1.Order.shipping_address:type_name -> core.v1.Address
+	24, // 4: core.v1.Order.billing_address:type_name -> core.v1.Address
+	7,  // 5: core.v1.Order.payment:type_name -> core.v1.PaymentInfo
+	8,  // 6: core.v1.Order.fulfillment:type_name -> core.v1.FulfillmentInfo
+	25, // 7: core.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	25, // 8: core.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 9: core.v1.Order.timeline:type_name -> core.v1.OrderTimelineEvent
+	10, // 10: core.v1.Order.lines:type_name -> core.v1.OrderLine
+	2,  // 11: core.v1.PaymentInfo.status:type_name -> core.v1.PaymentStatus
+	25, // 12: core.v1.PaymentInfo.paid_at:type_name -> google.protobuf.Timestamp
+	25, // 13: core.v1.FulfillmentInfo.shipped_at:type_name -> google.protobuf.Timestamp
+	25, // 14: core.v1.FulfillmentInfo.delivered_at:type_name -> google.protobuf.Timestamp
+	25, // 15: core.v1.OrderTimelineEvent.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 16: core.v1.OrderLine.status:type_name -> core.v1.OrderLineStatus
+	0,  // 17: core.v1.OrderSearch.statuses:type_name -> core.v1.OrderStatus
+	1,  // 18: core.v1.OrderSearch.order_type:type_name -> core.v1.OrderType
+	25, // 19: core.v1.OrderSearch.created_after:type_name -> google.protobuf.Timestamp
+	25, // 20: core.v1.OrderSearch.created_before:type_name -> google.protobuf.Timestamp
+	4,  // 21: core.v1.OrderSearch.sort_by:type_name -> core.v1.OrderSortField
+	26, // 22: core.v1.CreateOrderRequest.identifier:type_name -> infra.v1.UserIdentifier
+	5,  // 23: core.v1.CreateOrderRequest.order:type_name -> core.v1.Order
+	5,  // 24: core.v1.CreateOrderResponse.order:type_name -> core.v1.Order
+	26, // 25: core.v1.GetOrderRequest.identifier:type_name -> infra.v1.UserIdentifier
+	5,  // 26: core.v1.GetOrderResponse.order:type_name -> core.v1.Order
+	26, // 27: core.v1.SearchOrdersRequest.identifier:type_name -> infra.v1.UserIdentifier
+	11, // 28: core.v1.SearchOrdersRequest.search:type_name -> core.v1.OrderSearch
+	27, // 29: core.v1.SearchOrdersRequest.pagination:type_name -> infra.v1.PaginationRequest
+	5,  // 30: core.v1.SearchOrdersResponse.orders:type_name -> core.v1.Order
+	28, // 31: core.v1.SearchOrdersResponse.pagination:type_name -> infra.v1.PaginationResponse
+	26, // 32: core.v1.UpdateOrderRequest.identifier:type_name -> infra.v1.UserIdentifier
+	5,  // 33: core.v1.UpdateOrderRequest.order:type_name -> core.v1.Order
+	29, // 34: core.v1.UpdateOrderRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 35: core.v1.UpdateOrderResponse.order:type_name -> core.v1.Order
+	26, // 36: core.v1.ChangeOrderStatusRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 37: core.v1.ChangeOrderStatusRequest.status:type_name -> core.v1.OrderStatus
+	8,  // 38: core.v1.ChangeOrderStatusRequest.fulfillment:type_name -> core.v1.FulfillmentInfo
+	5,  // 39: core.v1.ChangeOrderStatusResponse.order:type_name -> core.v1.Order
+	26, // 40: core.v1.DeleteOrderRequest.identifier:type_name -> infra.v1.UserIdentifier
+	12, // 41: core.v1.OrderService.CreateOrder:input_type -> core.v1.CreateOrderRequest
+	14, // 42: core.v1.OrderService.GetOrder:input_type -> core.v1.GetOrderRequest
+	16, // 43: core.v1.OrderService.SearchOrders:input_type -> core.v1.SearchOrdersRequest
+	18, // 44: core.v1.OrderService.UpdateOrder:input_type -> core.v1.UpdateOrderRequest
+	20, // 45: core.v1.OrderService.ChangeOrderStatus:input_type -> core.v1.ChangeOrderStatusRequest
+	22, // 46: core.v1.OrderService.DeleteOrder:input_type -> core.v1.DeleteOrderRequest
+	13, // 47: core.v1.OrderService.CreateOrder:output_type -> core.v1.CreateOrderResponse
+	15, // 48: core.v1.OrderService.GetOrder:output_type -> core.v1.GetOrderResponse
+	17, // 49: core.v1.OrderService.SearchOrders:output_type -> core.v1.SearchOrdersResponse
+	19, // 50: core.v1.OrderService.UpdateOrder:output_type -> core.v1.UpdateOrderResponse
+	21, // 51: core.v1.OrderService.ChangeOrderStatus:output_type -> core.v1.ChangeOrderStatusResponse
+	23, // 52: core.v1.OrderService.DeleteOrder:output_type -> core.v1.DeleteOrderResponse
+	47, // [47:53] is the sub-list for method output_type
+	41, // [41:47] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_core_v1_order_proto_init() }
@@ -1061,15 +2016,16 @@ func file_core_v1_order_proto_init() {
 		return
 	}
 	file_core_v1_address_proto_init()
+	file_core_v1_order_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_order_proto_rawDesc), len(file_core_v1_order_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   6,
+			NumEnums:      5,
+			NumMessages:   19,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_core_v1_order_proto_goTypes,
 		DependencyIndexes: file_core_v1_order_proto_depIdxs,
@@ -0,0 +1,1408 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: core/v1/partner.proto
+
+package corev1
+
+import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CustomerSearch filters the customers of a tenant, every set criterion must match
+type CustomerSearch struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Statuses []CustomerStatus       `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=core.v1.CustomerStatus" json:"statuses,omitempty"`
+	Type     CustomerType           `protobuf:"varint,2,opt,name=type,proto3,enum=core.v1.CustomerType" json:"type,omitempty"`
+	// Case insensitive match on the name, email, company name and tax ID
+	Query         string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerSearch) Reset() {
+	*x = CustomerSearch{}
+	mi := &file_core_v1_partner_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerSearch) ProtoMessage() {}
+
+func (x *CustomerSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerSearch.ProtoReflect.Descriptor instead.
+func (*CustomerSearch) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CustomerSearch) GetStatuses() []CustomerStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *CustomerSearch) GetType() CustomerType {
+	if x != nil {
+		return x.Type
+	}
+	return CustomerType_CUSTOMER_TYPE_UNSPECIFIED
+}
+
+func (x *CustomerSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// VendorSearch filters the vendors of a tenant, every set criterion must match
+type VendorSearch struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Statuses []VendorStatus         `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=core.v1.VendorStatus" json:"statuses,omitempty"`
+	// Case insensitive match on the name, code, email and tax ID
+	Query         string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VendorSearch) Reset() {
+	*x = VendorSearch{}
+	mi := &file_core_v1_partner_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VendorSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VendorSearch) ProtoMessage() {}
+
+func (x *VendorSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VendorSearch.ProtoReflect.Descriptor instead.
+func (*VendorSearch) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VendorSearch) GetStatuses() []VendorStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *VendorSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// =============================================================================
+// Customers
+// =============================================================================
+type CreateCustomerRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Active unless a status is given
+	Customer      *Customer `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerRequest) Reset() {
+	*x = CreateCustomerRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerRequest) ProtoMessage() {}
+
+func (x *CreateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateCustomerRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type CreateCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerResponse) Reset() {
+	*x = CreateCustomerResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerResponse) ProtoMessage() {}
+
+func (x *CreateCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerResponse.ProtoReflect.Descriptor instead.
+func (*CreateCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateCustomerResponse) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type GetCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerRequest) Reset() {
+	*x = GetCustomerRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerRequest) ProtoMessage() {}
+
+func (x *GetCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCustomerRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type GetCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerResponse) Reset() {
+	*x = GetCustomerResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerResponse) ProtoMessage() {}
+
+func (x *GetCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerResponse.ProtoReflect.Descriptor instead.
+func (*GetCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCustomerResponse) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type SearchCustomersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Search        *CustomerSearch        `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCustomersRequest) Reset() {
+	*x = SearchCustomersRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCustomersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCustomersRequest) ProtoMessage() {}
+
+func (x *SearchCustomersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCustomersRequest.ProtoReflect.Descriptor instead.
+func (*SearchCustomersRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchCustomersRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SearchCustomersRequest) GetSearch() *CustomerSearch {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *SearchCustomersRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchCustomersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customers     []*Customer            `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCustomersResponse) Reset() {
+	*x = SearchCustomersResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCustomersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCustomersResponse) ProtoMessage() {}
+
+func (x *SearchCustomersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCustomersResponse.ProtoReflect.Descriptor instead.
+func (*SearchCustomersResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchCustomersResponse) GetCustomers() []*Customer {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+func (x *SearchCustomersResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UpdateCustomerRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// The id and version of the customer are required
+	Customer *Customer `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty" validate:"required"`
+	// Fields to update, e.g. "contacts" or "payment_terms.net_days", every editable field is replaced when empty
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomerRequest) Reset() {
+	*x = UpdateCustomerRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerRequest) ProtoMessage() {}
+
+func (x *UpdateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateCustomerRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *UpdateCustomerRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomerResponse) Reset() {
+	*x = UpdateCustomerResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerResponse) ProtoMessage() {}
+
+func (x *UpdateCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateCustomerResponse) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type DeleteCustomerRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Customers with orders are deactivated instead
+	CustomerId    string `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomerRequest) Reset() {
+	*x = DeleteCustomerRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerRequest) ProtoMessage() {}
+
+func (x *DeleteCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteCustomerRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DeleteCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type DeleteCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomerResponse) Reset() {
+	*x = DeleteCustomerResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerResponse) ProtoMessage() {}
+
+func (x *DeleteCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteCustomerResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// =============================================================================
+// Vendors
+// =============================================================================
+type CreateVendorRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Active unless a status is given
+	Vendor        *Vendor `protobuf:"bytes,2,opt,name=vendor,proto3" json:"vendor,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVendorRequest) Reset() {
+	*x = CreateVendorRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVendorRequest) ProtoMessage() {}
+
+func (x *CreateVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVendorRequest.ProtoReflect.Descriptor instead.
+func (*CreateVendorRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateVendorRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreateVendorRequest) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+type CreateVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVendorResponse) Reset() {
+	*x = CreateVendorResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVendorResponse) ProtoMessage() {}
+
+func (x *CreateVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVendorResponse.ProtoReflect.Descriptor instead.
+func (*CreateVendorResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+type GetVendorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	VendorId      string                 `protobuf:"bytes,2,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVendorRequest) Reset() {
+	*x = GetVendorRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVendorRequest) ProtoMessage() {}
+
+func (x *GetVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVendorRequest.ProtoReflect.Descriptor instead.
+func (*GetVendorRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetVendorRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetVendorRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+type GetVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVendorResponse) Reset() {
+	*x = GetVendorResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVendorResponse) ProtoMessage() {}
+
+func (x *GetVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVendorResponse.ProtoReflect.Descriptor instead.
+func (*GetVendorResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+type SearchVendorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Search        *VendorSearch          `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	Pagination    *v1.PaginationRequest  `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchVendorsRequest) Reset() {
+	*x = SearchVendorsRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchVendorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchVendorsRequest) ProtoMessage() {}
+
+func (x *SearchVendorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchVendorsRequest.ProtoReflect.Descriptor instead.
+func (*SearchVendorsRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchVendorsRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *SearchVendorsRequest) GetSearch() *VendorSearch {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *SearchVendorsRequest) GetPagination() *v1.PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type SearchVendorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendors       []*Vendor              `protobuf:"bytes,1,rep,name=vendors,proto3" json:"vendors,omitempty"`
+	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchVendorsResponse) Reset() {
+	*x = SearchVendorsResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchVendorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchVendorsResponse) ProtoMessage() {}
+
+func (x *SearchVendorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchVendorsResponse.ProtoReflect.Descriptor instead.
+func (*SearchVendorsResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchVendorsResponse) GetVendors() []*Vendor {
+	if x != nil {
+		return x.Vendors
+	}
+	return nil
+}
+
+func (x *SearchVendorsResponse) GetPagination() *v1.PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type UpdateVendorRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// The id and version of the vendor are required
+	Vendor *Vendor `protobuf:"bytes,2,opt,name=vendor,proto3" json:"vendor,omitempty" validate:"required"`
+	// Fields to update, e.g. "contact.email" or "payment_terms", every editable field is replaced when empty
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVendorRequest) Reset() {
+	*x = UpdateVendorRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVendorRequest) ProtoMessage() {}
+
+func (x *UpdateVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVendorRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVendorRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateVendorRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdateVendorRequest) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+func (x *UpdateVendorRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vendor        *Vendor                `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVendorResponse) Reset() {
+	*x = UpdateVendorResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVendorResponse) ProtoMessage() {}
+
+func (x *UpdateVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVendorResponse.ProtoReflect.Descriptor instead.
+func (*UpdateVendorResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateVendorResponse) GetVendor() *Vendor {
+	if x != nil {
+		return x.Vendor
+	}
+	return nil
+}
+
+type DeleteVendorRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Vendors with orders are deactivated instead
+	VendorId      string `protobuf:"bytes,2,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVendorRequest) Reset() {
+	*x = DeleteVendorRequest{}
+	mi := &file_core_v1_partner_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVendorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVendorRequest) ProtoMessage() {}
+
+func (x *DeleteVendorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVendorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVendorRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteVendorRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DeleteVendorRequest) GetVendorId() string {
+	if x != nil {
+		return x.VendorId
+	}
+	return ""
+}
+
+type DeleteVendorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVendorResponse) Reset() {
+	*x = DeleteVendorResponse{}
+	mi := &file_core_v1_partner_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVendorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVendorResponse) ProtoMessage() {}
+
+func (x *DeleteVendorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_partner_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVendorResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVendorResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_partner_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteVendorResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+var File_core_v1_partner_proto protoreflect.FileDescriptor
+
+const file_core_v1_partner_proto_rawDesc = "" +
+	"\n" +
+	"\x15core/v1/partner.proto\x12\acore.v1\x1a google/protobuf/field_mask.proto\x1a\x13tagger/tagger.proto\x1a\x14infra/v1/infra.proto\x1a\x16core/v1/customer.proto\x1a\x14core/v1/vendor.proto\"\x86\x01\n" +
+	"\x0eCustomerSearch\x123\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x17.core.v1.CustomerStatusR\bstatuses\x12)\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x15.core.v1.CustomerTypeR\x04type\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\"W\n" +
+	"\fVendorSearch\x121\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x15.core.v1.VendorStatusR\bstatuses\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\"\xb9\x01\n" +
+	"\x15CreateCustomerRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12G\n" +
+	"\bcustomer\x18\x02 \x01(\v2\x11.core.v1.CustomerB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\bcustomer\"G\n" +
+	"\x16CreateCustomerResponse\x12-\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x11.core.v1.CustomerR\bcustomer\"\xa8\x01\n" +
+	"\x12GetCustomerRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x129\n" +
+	"\vcustomer_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\n" +
+	"customerId\"D\n" +
+	"\x13GetCustomerResponse\x12-\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x11.core.v1.CustomerR\bcustomer\"\xdf\x01\n" +
+	"\x16SearchCustomersRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12/\n" +
+	"\x06search\x18\x02 \x01(\v2\x17.core.v1.CustomerSearchR\x06search\x12;\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"\x88\x01\n" +
+	"\x17SearchCustomersResponse\x12/\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x11.core.v1.CustomerR\tcustomers\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xf6\x01\n" +
+	"\x15UpdateCustomerRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12G\n" +
+	"\bcustomer\x18\x02 \x01(\v2\x11.core.v1.CustomerB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\bcustomer\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"G\n" +
+	"\x16UpdateCustomerResponse\x12-\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x11.core.v1.CustomerR\bcustomer\"\xab\x01\n" +
+	"\x15DeleteCustomerRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x129\n" +
+	"\vcustomer_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\n" +
+	"customerId\"2\n" +
+	"\x16DeleteCustomerResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xb1\x01\n" +
+	"\x13CreateVendorRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12A\n" +
+	"\x06vendor\x18\x02 \x01(\v2\x0f.core.v1.VendorB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06vendor\"?\n" +
+	"\x14CreateVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.core.v1.VendorR\x06vendor\"\xa2\x01\n" +
+	"\x10GetVendorRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x125\n" +
+	"\tvendor_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\bvendorId\"<\n" +
+	"\x11GetVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.core.v1.VendorR\x06vendor\"\xdb\x01\n" +
+	"\x14SearchVendorsRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12-\n" +
+	"\x06search\x18\x02 \x01(\v2\x15.core.v1.VendorSearchR\x06search\x12;\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2\x1b.infra.v1.PaginationRequestR\n" +
+	"pagination\"\x80\x01\n" +
+	"\x15SearchVendorsResponse\x12)\n" +
+	"\avendors\x18\x01 \x03(\v2\x0f.core.v1.VendorR\avendors\x12<\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1c.infra.v1.PaginationResponseR\n" +
+	"pagination\"\xee\x01\n" +
+	"\x13UpdateVendorRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12A\n" +
+	"\x06vendor\x18\x02 \x01(\v2\x0f.core.v1.VendorB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06vendor\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"?\n" +
+	"\x14UpdateVendorResponse\x12'\n" +
+	"\x06vendor\x18\x01 \x01(\v2\x0f.core.v1.VendorR\x06vendor\"\xa5\x01\n" +
+	"\x13DeleteVendorRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x125\n" +
+	"\tvendor_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\bvendorId\"0\n" +
+	"\x14DeleteVendorResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted2\xa4\x06\n" +
+	"\x0ePartnerService\x12Q\n" +
+	"\x0eCreateCustomer\x12\x1e.core.v1.CreateCustomerRequest\x1a\x1f.core.v1.CreateCustomerResponse\x12H\n" +
+	"\vGetCustomer\x12\x1b.core.v1.GetCustomerRequest\x1a\x1c.core.v1.GetCustomerResponse\x12T\n" +
+	"\x0fSearchCustomers\x12\x1f.core.v1.SearchCustomersRequest\x1a .core.v1.SearchCustomersResponse\x12Q\n" +
+	"\x0eUpdateCustomer\x12\x1e.core.v1.UpdateCustomerRequest\x1a\x1f.core.v1.UpdateCustomerResponse\x12Q\n" +
+	"\x0eDeleteCustomer\x12\x1e.core.v1.DeleteCustomerRequest\x1a\x1f.core.v1.DeleteCustomerResponse\x12K\n" +
+	"\fCreateVendor\x12\x1c.core.v1.CreateVendorRequest\x1a\x1d.core.v1.CreateVendorResponse\x12B\n" +
+	"\tGetVendor\x12\x19.core.v1.GetVendorRequest\x1a\x1a.core.v1.GetVendorResponse\x12N\n" +
+	"\rSearchVendors\x12\x1d.core.v1.SearchVendorsRequest\x1a\x1e.core.v1.SearchVendorsResponse\x12K\n" +
+	"\fUpdateVendor\x12\x1c.core.v1.UpdateVendorRequest\x1a\x1d.core.v1.UpdateVendorResponse\x12K\n" +
+	"\fDeleteVendor\x12\x1c.core.v1.DeleteVendorRequest\x1a\x1d.core.v1.DeleteVendorResponseB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_partner_proto_rawDescOnce sync.Once
+	file_core_v1_partner_proto_rawDescData []byte
+)
+
+func file_core_v1_partner_proto_rawDescGZIP() []byte {
+	file_core_v1_partner_proto_rawDescOnce.Do(func() {
+		file_core_v1_partner_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_partner_proto_rawDesc), len(file_core_v1_partner_proto_rawDesc)))
+	})
+	return file_core_v1_partner_proto_rawDescData
+}
+
+var file_core_v1_partner_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_core_v1_partner_proto_goTypes = []any{
+	(*CustomerSearch)(nil),          // 0: core.v1.CustomerSearch
+	(*VendorSearch)(nil),            // 1: core.v1.VendorSearch
+	(*CreateCustomerRequest)(nil),   // 2: core.v1.CreateCustomerRequest
+	(*CreateCustomerResponse)(nil),  // 3: core.v1.CreateCustomerResponse
+	(*GetCustomerRequest)(nil),      // 4: core.v1.GetCustomerRequest
+	(*GetCustomerResponse)(nil),     // 5: core.v1.GetCustomerResponse
+	(*SearchCustomersRequest)(nil),  // 6: core.v1.SearchCustomersRequest
+	(*SearchCustomersResponse)(nil), // 7: core.v1.SearchCustomersResponse
+	(*UpdateCustomerRequest)(nil),   // 8: core.v1.UpdateCustomerRequest
+	(*UpdateCustomerResponse)(nil),  // 9: core.v1.UpdateCustomerResponse
+	(*DeleteCustomerRequest)(nil),   // 10: core.v1.DeleteCustomerRequest
+	(*DeleteCustomerResponse)(nil),  // 11: core.v1.DeleteCustomerResponse
+	(*CreateVendorRequest)(nil),     // 12: core.v1.CreateVendorRequest
+	(*CreateVendorResponse)(nil),    // 13: core.v1.CreateVendorResponse
+	(*GetVendorRequest)(nil),        // 14: core.v1.GetVendorRequest
+	(*GetVendorResponse)(nil),       // 15: core.v1.GetVendorResponse
+	(*SearchVendorsRequest)(nil),    // 16: core.v1.SearchVendorsRequest
+	(*SearchVendorsResponse)(nil),   // 17: core.v1.SearchVendorsResponse
+	(*UpdateVendorRequest)(nil),     // 18: core.v1.UpdateVendorRequest
+	(*UpdateVendorResponse)(nil),    // 19: core.v1.UpdateVendorResponse
+	(*DeleteVendorRequest)(nil),     // 20: core.v1.DeleteVendorRequest
+	(*DeleteVendorResponse)(nil),    // 21: core.v1.DeleteVendorResponse
+	(CustomerStatus)(0),             // 22: core.v1.CustomerStatus
+	(CustomerType)(0),               // 23: core.v1.CustomerType
+	(VendorStatus)(0),               // 24: core.v1.VendorStatus
+	(*v1.UserIdentifier)(nil),       // 25: infra.v1.UserIdentifier
+	(*Customer)(nil),                // 26: core.v1.Customer
+	(*v1.PaginationRequest)(nil),    // 27: infra.v1.PaginationRequest
+	(*v1.PaginationResponse)(nil),   // 28: infra.v1.PaginationResponse
+	(*fieldmaskpb.FieldMask)(nil),   // 29: google.protobuf.FieldMask
+	(*Vendor)(nil),                  // 30: core.v1.Vendor
+}
+var file_core_v1_partner_proto_depIdxs = []int32{
+	22, // 0: core.v1.CustomerSearch.statuses:type_name -> core.v1.CustomerStatus
+	23, // 1: core.v1.CustomerSearch.type:type_name -> core.v1.CustomerType
+	24, // 2: core.v1.VendorSearch.statuses:type_name -> core.v1.VendorStatus
+	25, // 3: core.v1.CreateCustomerRequest.identifier:type_name -> infra.v1.UserIdentifier
+	26, // 4: core.v1.CreateCustomerRequest.customer:type_name -> core.v1.Customer
+	26, // 5: core.v1.CreateCustomerResponse.customer:type_name -> core.v1.Customer
+	25, // 6: core.v1.GetCustomerRequest.identifier:type_name -> infra.v1.UserIdentifier
+	26, // 7: core.v1.GetCustomerResponse.customer:type_name -> core.v1.Customer
+	25, // 8: core.v1.SearchCustomersRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 9: core.v1.SearchCustomersRequest.search:type_name -> core.v1.CustomerSearch
+	27, // 10: core.v1.SearchCustomersRequest.pagination:type_name -> infra.v1.PaginationRequest
+	26, // 11: core.v1.SearchCustomersResponse.customers:type_name -> core.v1.Customer
+	28, // 12: core.v1.SearchCustomersResponse.pagination:type_name -> infra.v1.PaginationResponse
+	25, // 13: core.v1.UpdateCustomerRequest.identifier:type_name -> infra.v1.UserIdentifier
+	26, // 14: core.v1.UpdateCustomerRequest.customer:type_name -> core.v1.Customer
+	29, // 15: core.v1.UpdateCustomerRequest.update_mask:type_name -> google.protobuf.FieldMask
+	26, // 16: core.v1.UpdateCustomerResponse.customer:type_name -> core.v1.Customer
+	25, // 17: core.v1.DeleteCustomerRequest.identifier:type_name -> infra.v1.UserIdentifier
+	25, // 18: core.v1.CreateVendorRequest.identifier:type_name -> infra.v1.UserIdentifier
+	30, // 19: core.v1.CreateVendorRequest.vendor:type_name -> core.v1.Vendor
+	30, // 20: core.v1.CreateVendorResponse.vendor:type_name -> core.v1.Vendor
+	25, // 21: core.v1.GetVendorRequest.identifier:type_name -> infra.v1.UserIdentifier
+	30, // 22: core.v1.GetVendorResponse.vendor:type_name -> core.v1.Vendor
+	25, // 23: core.v1.SearchVendorsRequest.identifier:type_name -> infra.v1.UserIdentifier
+	1,  // 24: core.v1.SearchVendorsRequest.search:type_name -> core.v1.VendorSearch
+	27, // 25: core.v1.SearchVendorsRequest.pagination:type_name -> infra.v1.PaginationRequest
+	30, // 26: core.v1.SearchVendorsResponse.vendors:type_name -> core.v1.Vendor
+	28, // 27: core.v1.SearchVendorsResponse.pagination:type_name -> infra.v1.PaginationResponse
+	25, // 28: core.v1.UpdateVendorRequest.identifier:type_name -> infra.v1.UserIdentifier
+	30, // 29: core.v1.UpdateVendorRequest.vendor:type_name -> core.v1.Vendor
+	29, // 30: core.v1.UpdateVendorRequest.update_mask:type_name -> google.protobuf.FieldMask
+	30, // 31: core.v1.UpdateVendorResponse.vendor:type_name -> core.v1.Vendor
+	25, // 32: core.v1.DeleteVendorRequest.identifier:type_name -> infra.v1.UserIdentifier
+	2,  // 33: core.v1.PartnerService.CreateCustomer:input_type -> core.v1.CreateCustomerRequest
+	4,  // 34: core.v1.PartnerService.GetCustomer:input_type -> core.v1.GetCustomerRequest
+	6,  // 35: core.v1.PartnerService.SearchCustomers:input_type -> core.v1.SearchCustomersRequest
+	8,  // 36: core.v1.PartnerService.UpdateCustomer:input_type -> core.v1.UpdateCustomerRequest
+	10, // 37: core.v1.PartnerService.DeleteCustomer:input_type -> core.v1.DeleteCustomerRequest
+	12, // 38: core.v1.PartnerService.CreateVendor:input_type -> core.v1.CreateVendorRequest
+	14, // 39: core.v1.PartnerService.GetVendor:input_type -> core.v1.GetVendorRequest
+	16, // 40: core.v1.PartnerService.SearchVendors:input_type -> core.v1.SearchVendorsRequest
+	18, // 41: core.v1.PartnerService.UpdateVendor:input_type -> core.v1.UpdateVendorRequest
+	20, // 42: core.v1.PartnerService.DeleteVendor:input_type -> core.v1.DeleteVendorRequest
+	3,  // 43: core.v1.PartnerService.CreateCustomer:output_type -> core.v1.CreateCustomerResponse
+	5,  // 44: core.v1.PartnerService.GetCustomer:output_type -> core.v1.GetCustomerResponse
+	7,  // 45: core.v1.PartnerService.SearchCustomers:output_type -> core.v1.SearchCustomersResponse
+	9,  // 46: core.v1.PartnerService.UpdateCustomer:output_type -> core.v1.UpdateCustomerResponse
+	11, // 47: core.v1.PartnerService.DeleteCustomer:output_type -> core.v1.DeleteCustomerResponse
+	13, // 48: core.v1.PartnerService.CreateVendor:output_type -> core.v1.CreateVendorResponse
+	15, // 49: core.v1.PartnerService.GetVendor:output_type -> core.v1.GetVendorResponse
+	17, // 50: core.v1.PartnerService.SearchVendors:output_type -> core.v1.SearchVendorsResponse
+	19, // 51: core.v1.PartnerService.UpdateVendor:output_type -> core.v1.UpdateVendorResponse
+	21, // 52: core.v1.PartnerService.DeleteVendor:output_type -> core.v1.DeleteVendorResponse
+	43, // [43:53] is the sub-list for method output_type
+	33, // [33:43] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_partner_proto_init() }
+func file_core_v1_partner_proto_init() {
+	if File_core_v1_partner_proto != nil {
+		return
+	}
+	file_core_v1_customer_proto_init()
+	file_core_v1_vendor_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_partner_proto_rawDesc), len(file_core_v1_partner_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_core_v1_partner_proto_goTypes,
+		DependencyIndexes: file_core_v1_partner_proto_depIdxs,
+		MessageInfos:      file_core_v1_partner_proto_msgTypes,
+	}.Build()
+	File_core_v1_partner_proto = out.File
+	file_core_v1_partner_proto_goTypes = nil
+	file_core_v1_partner_proto_depIdxs = nil
+}
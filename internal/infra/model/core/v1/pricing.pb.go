@@ -0,0 +1,1490 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: core/v1/pricing.proto
+
+package corev1
+
+import (
+	v1 "erp.localhost/internal/infra/model/infra/v1"
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Pricing rule type enum
+type PricingRuleType int32
+
+const (
+	PricingRuleType_PRICING_RULE_TYPE_UNSPECIFIED PricingRuleType = 0
+	// Sets the unit price of the products
+	PricingRuleType_PRICING_RULE_TYPE_PRICE_LIST PricingRuleType = 1
+	// Discounts the lines by their quantity
+	PricingRuleType_PRICING_RULE_TYPE_QUANTITY_TIERS PricingRuleType = 2
+	// Discounts the lines, usually for a limited time
+	PricingRuleType_PRICING_RULE_TYPE_PROMOTION PricingRuleType = 3
+)
+
+// Enum value maps for PricingRuleType.
+var (
+	PricingRuleType_name = map[int32]string{
+		0: "PRICING_RULE_TYPE_UNSPECIFIED",
+		1: "PRICING_RULE_TYPE_PRICE_LIST",
+		2: "PRICING_RULE_TYPE_QUANTITY_TIERS",
+		3: "PRICING_RULE_TYPE_PROMOTION",
+	}
+	PricingRuleType_value = map[string]int32{
+		"PRICING_RULE_TYPE_UNSPECIFIED":    0,
+		"PRICING_RULE_TYPE_PRICE_LIST":     1,
+		"PRICING_RULE_TYPE_QUANTITY_TIERS": 2,
+		"PRICING_RULE_TYPE_PROMOTION":      3,
+	}
+)
+
+func (x PricingRuleType) Enum() *PricingRuleType {
+	p := new(PricingRuleType)
+	*p = x
+	return p
+}
+
+func (x PricingRuleType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PricingRuleType) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_pricing_proto_enumTypes[0].Descriptor()
+}
+
+func (PricingRuleType) Type() protoreflect.EnumType {
+	return &file_core_v1_pricing_proto_enumTypes[0]
+}
+
+func (x PricingRuleType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PricingRuleType.Descriptor instead.
+func (PricingRuleType) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{0}
+}
+
+// PricingRule model for MongoDB core_db.pricing_rules collection
+// A rule applies to the lines of its products, or of every product when it lists none, ordered by the customers of
+// its customer group, or by every customer when it has none, from starts_at until ends_at when they are set
+type PricingRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name" bson:"name" validate:"required,max=200"`
+	Type          PricingRuleType        `protobuf:"varint,4,opt,name=type,proto3,enum=core.v1.PricingRuleType" json:"type" bson:"type" validate:"required"`
+	ProductIds    []string               `protobuf:"bytes,5,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty" bson:"product_ids,omitempty" validate:"max=500"`
+	CustomerGroup string                 `protobuf:"bytes,6,opt,name=customer_group,json=customerGroup,proto3" json:"customer_group,omitempty" bson:"customer_group,omitempty" validate:"max=50"`
+	// Rules of higher priority are evaluated first and win ties
+	Priority int32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority" bson:"priority"`
+	Active   bool  `protobuf:"varint,8,opt,name=active,proto3" json:"active" bson:"active"`
+	// Unit price of the products of a price list
+	UnitPrice float64 `protobuf:"fixed64,9,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty" bson:"unit_price,omitempty" validate:"min=0"`
+	// Discounts of quantity tiers, the tier with the highest min_quantity reached applies
+	Tiers []*PriceTier `protobuf:"bytes,10,rep,name=tiers,proto3" json:"tiers,omitempty" bson:"tiers,omitempty" validate:"max=20,dive"`
+	// Fraction of the line subtotal a promotion takes off, e.g. 0.1
+	DiscountPercent float64 `protobuf:"fixed64,11,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty" bson:"discount_percent,omitempty" validate:"min=0,max=1"`
+	// Amount a promotion takes off each unit
+	DiscountAmount float64                `protobuf:"fixed64,12,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty" bson:"discount_amount,omitempty" validate:"min=0"`
+	StartsAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty" bson:"starts_at,omitempty"`
+	// Exclusive end of the rule
+	EndsAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty" bson:"ends_at,omitempty"`
+	CreatedBy string                 `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
+	// Incremented by every update, a rule is updated at the version it was read at
+	Version       int64 `protobuf:"varint,18,opt,name=version,proto3" json:"version" bson:"version"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricingRule) Reset() {
+	*x = PricingRule{}
+	mi := &file_core_v1_pricing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricingRule) ProtoMessage() {}
+
+func (x *PricingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricingRule.ProtoReflect.Descriptor instead.
+func (*PricingRule) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PricingRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PricingRule) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *PricingRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PricingRule) GetType() PricingRuleType {
+	if x != nil {
+		return x.Type
+	}
+	return PricingRuleType_PRICING_RULE_TYPE_UNSPECIFIED
+}
+
+func (x *PricingRule) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *PricingRule) GetCustomerGroup() string {
+	if x != nil {
+		return x.CustomerGroup
+	}
+	return ""
+}
+
+func (x *PricingRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *PricingRule) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *PricingRule) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *PricingRule) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *PricingRule) GetDiscountPercent() float64 {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return 0
+}
+
+func (x *PricingRule) GetDiscountAmount() float64 {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return 0
+}
+
+func (x *PricingRule) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *PricingRule) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *PricingRule) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *PricingRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PricingRule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PricingRule) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type PriceTier struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MinQuantity int32                  `protobuf:"varint,1,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity" bson:"min_quantity" validate:"positive"`
+	// Fraction of the line subtotal taken off, e.g. 0.05
+	DiscountPercent float64 `protobuf:"fixed64,2,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent" bson:"discount_percent" validate:"min=0,max=1"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PriceTier) Reset() {
+	*x = PriceTier{}
+	mi := &file_core_v1_pricing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceTier) ProtoMessage() {}
+
+func (x *PriceTier) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceTier.ProtoReflect.Descriptor instead.
+func (*PriceTier) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PriceTier) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *PriceTier) GetDiscountPercent() float64 {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return 0
+}
+
+type PriceRequestLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty" validate:"required"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty" validate:"positive"`
+	// List price of the product, kept when no price list applies
+	UnitPrice     float64 `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty" validate:"min=0"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceRequestLine) Reset() {
+	*x = PriceRequestLine{}
+	mi := &file_core_v1_pricing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceRequestLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceRequestLine) ProtoMessage() {}
+
+func (x *PriceRequestLine) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceRequestLine.ProtoReflect.Descriptor instead.
+func (*PriceRequestLine) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PriceRequestLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceRequestLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PriceRequestLine) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+// PriceCalculation is the price of lines for a customer, amounts are before tax and rounded to cents
+type PriceCalculation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Lines    []*PricedLine          `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Subtotal float64                `protobuf:"fixed64,2,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount float64                `protobuf:"fixed64,3,opt,name=discount,proto3" json:"discount,omitempty"`
+	Total    float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Group of the customer the lines were priced for
+	CustomerGroup string `protobuf:"bytes,5,opt,name=customer_group,json=customerGroup,proto3" json:"customer_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceCalculation) Reset() {
+	*x = PriceCalculation{}
+	mi := &file_core_v1_pricing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceCalculation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceCalculation) ProtoMessage() {}
+
+func (x *PriceCalculation) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceCalculation.ProtoReflect.Descriptor instead.
+func (*PriceCalculation) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PriceCalculation) GetLines() []*PricedLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *PriceCalculation) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *PriceCalculation) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *PriceCalculation) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PriceCalculation) GetCustomerGroup() string {
+	if x != nil {
+		return x.CustomerGroup
+	}
+	return ""
+}
+
+type PricedLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Unit price of the request line
+	ListPrice float64 `protobuf:"fixed64,3,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"`
+	// Unit price after the price lists
+	UnitPrice float64 `protobuf:"fixed64,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	// Amount the discount rules take off the subtotal
+	Discount float64 `protobuf:"fixed64,5,opt,name=discount,proto3" json:"discount,omitempty"`
+	Subtotal float64 `protobuf:"fixed64,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Total    float64 `protobuf:"fixed64,7,opt,name=total,proto3" json:"total,omitempty"`
+	// Every rule evaluated for the line in order
+	Trace         []*PricingTraceStep `protobuf:"bytes,8,rep,name=trace,proto3" json:"trace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricedLine) Reset() {
+	*x = PricedLine{}
+	mi := &file_core_v1_pricing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricedLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricedLine) ProtoMessage() {}
+
+func (x *PricedLine) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricedLine.ProtoReflect.Descriptor instead.
+func (*PricedLine) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PricedLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PricedLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PricedLine) GetListPrice() float64 {
+	if x != nil {
+		return x.ListPrice
+	}
+	return 0
+}
+
+func (x *PricedLine) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *PricedLine) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *PricedLine) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *PricedLine) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PricedLine) GetTrace() []*PricingTraceStep {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+// PricingTraceStep explains why a rule did or did not change the price of a line
+type PricingTraceStep struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RuleId   string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	RuleName string                 `protobuf:"bytes,2,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	Type     PricingRuleType        `protobuf:"varint,3,opt,name=type,proto3,enum=core.v1.PricingRuleType" json:"type,omitempty"`
+	Applied  bool                   `protobuf:"varint,4,opt,name=applied,proto3" json:"applied,omitempty"`
+	// e.g. "customer group does not match" or "discount 4.50"
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricingTraceStep) Reset() {
+	*x = PricingTraceStep{}
+	mi := &file_core_v1_pricing_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricingTraceStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricingTraceStep) ProtoMessage() {}
+
+func (x *PricingTraceStep) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricingTraceStep.ProtoReflect.Descriptor instead.
+func (*PricingTraceStep) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PricingTraceStep) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *PricingTraceStep) GetRuleName() string {
+	if x != nil {
+		return x.RuleName
+	}
+	return ""
+}
+
+func (x *PricingTraceStep) GetType() PricingRuleType {
+	if x != nil {
+		return x.Type
+	}
+	return PricingRuleType_PRICING_RULE_TYPE_UNSPECIFIED
+}
+
+func (x *PricingTraceStep) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *PricingTraceStep) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// =============================================================================
+// Requests
+// =============================================================================
+type CreatePricingRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	Rule          *PricingRule           `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePricingRuleRequest) Reset() {
+	*x = CreatePricingRuleRequest{}
+	mi := &file_core_v1_pricing_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePricingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePricingRuleRequest) ProtoMessage() {}
+
+func (x *CreatePricingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePricingRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreatePricingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreatePricingRuleRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CreatePricingRuleRequest) GetRule() *PricingRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type CreatePricingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *PricingRule           `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePricingRuleResponse) Reset() {
+	*x = CreatePricingRuleResponse{}
+	mi := &file_core_v1_pricing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePricingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePricingRuleResponse) ProtoMessage() {}
+
+func (x *CreatePricingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePricingRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreatePricingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreatePricingRuleResponse) GetRule() *PricingRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type GetPricingRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	RuleId        string                 `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPricingRuleRequest) Reset() {
+	*x = GetPricingRuleRequest{}
+	mi := &file_core_v1_pricing_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPricingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricingRuleRequest) ProtoMessage() {}
+
+func (x *GetPricingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricingRuleRequest.ProtoReflect.Descriptor instead.
+func (*GetPricingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPricingRuleRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetPricingRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+type GetPricingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *PricingRule           `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPricingRuleResponse) Reset() {
+	*x = GetPricingRuleResponse{}
+	mi := &file_core_v1_pricing_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPricingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricingRuleResponse) ProtoMessage() {}
+
+func (x *GetPricingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricingRuleResponse.ProtoReflect.Descriptor instead.
+func (*GetPricingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPricingRuleResponse) GetRule() *PricingRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type ListPricingRulesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// Only rules of the type when set
+	Type          PricingRuleType `protobuf:"varint,2,opt,name=type,proto3,enum=core.v1.PricingRuleType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPricingRulesRequest) Reset() {
+	*x = ListPricingRulesRequest{}
+	mi := &file_core_v1_pricing_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPricingRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPricingRulesRequest) ProtoMessage() {}
+
+func (x *ListPricingRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPricingRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListPricingRulesRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListPricingRulesRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ListPricingRulesRequest) GetType() PricingRuleType {
+	if x != nil {
+		return x.Type
+	}
+	return PricingRuleType_PRICING_RULE_TYPE_UNSPECIFIED
+}
+
+type ListPricingRulesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Highest priority first
+	Rules         []*PricingRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPricingRulesResponse) Reset() {
+	*x = ListPricingRulesResponse{}
+	mi := &file_core_v1_pricing_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPricingRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPricingRulesResponse) ProtoMessage() {}
+
+func (x *ListPricingRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPricingRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListPricingRulesResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListPricingRulesResponse) GetRules() []*PricingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type UpdatePricingRuleRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// The id and version of the rule are required
+	Rule *PricingRule `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty" validate:"required"`
+	// Fields to update, e.g. "active" or "tiers", every editable field is replaced when empty
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePricingRuleRequest) Reset() {
+	*x = UpdatePricingRuleRequest{}
+	mi := &file_core_v1_pricing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePricingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePricingRuleRequest) ProtoMessage() {}
+
+func (x *UpdatePricingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePricingRuleRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePricingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdatePricingRuleRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *UpdatePricingRuleRequest) GetRule() *PricingRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *UpdatePricingRuleRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdatePricingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *PricingRule           `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePricingRuleResponse) Reset() {
+	*x = UpdatePricingRuleResponse{}
+	mi := &file_core_v1_pricing_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePricingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePricingRuleResponse) ProtoMessage() {}
+
+func (x *UpdatePricingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePricingRuleResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePricingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdatePricingRuleResponse) GetRule() *PricingRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type DeletePricingRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	RuleId        string                 `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty" validate:"required"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePricingRuleRequest) Reset() {
+	*x = DeletePricingRuleRequest{}
+	mi := &file_core_v1_pricing_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePricingRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePricingRuleRequest) ProtoMessage() {}
+
+func (x *DeletePricingRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePricingRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeletePricingRuleRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeletePricingRuleRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *DeletePricingRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+type DeletePricingRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePricingRuleResponse) Reset() {
+	*x = DeletePricingRuleResponse{}
+	mi := &file_core_v1_pricing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePricingRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePricingRuleResponse) ProtoMessage() {}
+
+func (x *DeletePricingRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePricingRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeletePricingRuleResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeletePricingRuleResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type CalculatePriceRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Identifier *v1.UserIdentifier     `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty" validate:"required,dive"`
+	// The lines are priced for the group of the customer, or with the rules of every customer when unset
+	CustomerId *string             `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3,oneof" json:"customer_id,omitempty"`
+	Lines      []*PriceRequestLine `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty" validate:"required,max=500,dive"`
+	// Priced now unless set
+	At            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculatePriceRequest) Reset() {
+	*x = CalculatePriceRequest{}
+	mi := &file_core_v1_pricing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculatePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculatePriceRequest) ProtoMessage() {}
+
+func (x *CalculatePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculatePriceRequest.ProtoReflect.Descriptor instead.
+func (*CalculatePriceRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CalculatePriceRequest) GetIdentifier() *v1.UserIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *CalculatePriceRequest) GetCustomerId() string {
+	if x != nil && x.CustomerId != nil {
+		return *x.CustomerId
+	}
+	return ""
+}
+
+func (x *CalculatePriceRequest) GetLines() []*PriceRequestLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CalculatePriceRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type CalculatePriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calculation   *PriceCalculation      `protobuf:"bytes,1,opt,name=calculation,proto3" json:"calculation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculatePriceResponse) Reset() {
+	*x = CalculatePriceResponse{}
+	mi := &file_core_v1_pricing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculatePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculatePriceResponse) ProtoMessage() {}
+
+func (x *CalculatePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_pricing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculatePriceResponse.ProtoReflect.Descriptor instead.
+func (*CalculatePriceResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1_pricing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CalculatePriceResponse) GetCalculation() *PriceCalculation {
+	if x != nil {
+		return x.Calculation
+	}
+	return nil
+}
+
+var File_core_v1_pricing_proto protoreflect.FileDescriptor
+
+const file_core_v1_pricing_proto_rawDesc = "" +
+	"\n" +
+	"\x15core/v1/pricing.proto\x12\acore.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x14infra/v1/infra.proto\"\x90\x0e\n" +
+	"\vPricingRule\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12W\n" +
+	"\ttenant_id\x18\x02 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12L\n" +
+	"\x04name\x18\x03 \x01(\tB8\x9a\x84\x9e\x033bson:\"name\" json:\"name\" validate:\"required,max=200\"R\x04name\x12^\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x18.core.v1.PricingRuleTypeB0\x9a\x84\x9e\x03+bson:\"type\" json:\"type\" validate:\"required\"R\x04type\x12r\n" +
+	"\vproduct_ids\x18\x05 \x03(\tBQ\x9a\x84\x9e\x03Lbson:\"product_ids,omitempty\" json:\"product_ids,omitempty\" validate:\"max=500\"R\n" +
+	"productIds\x12}\n" +
+	"\x0ecustomer_group\x18\x06 \x01(\tBV\x9a\x84\x9e\x03Qbson:\"customer_group,omitempty\" json:\"customer_group,omitempty\" validate:\"max=50\"R\rcustomerGroup\x12@\n" +
+	"\bpriority\x18\a \x01(\x05B$\x9a\x84\x9e\x03\x1fbson:\"priority\" json:\"priority\"R\bpriority\x128\n" +
+	"\x06active\x18\b \x01(\bB \x9a\x84\x9e\x03\x1bbson:\"active\" json:\"active\"R\x06active\x12l\n" +
+	"\n" +
+	"unit_price\x18\t \x01(\x01BM\x9a\x84\x9e\x03Hbson:\"unit_price,omitempty\" json:\"unit_price,omitempty\" validate:\"min=0\"R\tunitPrice\x12s\n" +
+	"\x05tiers\x18\n" +
+	" \x03(\v2\x12.core.v1.PriceTierBI\x9a\x84\x9e\x03Dbson:\"tiers,omitempty\" json:\"tiers,omitempty\" validate:\"max=20,dive\"R\x05tiers\x12\x8a\x01\n" +
+	"\x10discount_percent\x18\v \x01(\x01B_\x9a\x84\x9e\x03Zbson:\"discount_percent,omitempty\" json:\"discount_percent,omitempty\" validate:\"min=0,max=1\"R\x0fdiscountPercent\x12\x80\x01\n" +
+	"\x0fdiscount_amount\x18\f \x01(\x01BW\x9a\x84\x9e\x03Rbson:\"discount_amount,omitempty\" json:\"discount_amount,omitempty\" validate:\"min=0\"R\x0ediscountAmount\x12s\n" +
+	"\tstarts_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampB:\x9a\x84\x9e\x035bson:\"starts_at,omitempty\" json:\"starts_at,omitempty\"R\bstartsAt\x12k\n" +
+	"\aends_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampB6\x9a\x84\x9e\x031bson:\"ends_at,omitempty\" json:\"ends_at,omitempty\"R\x06endsAt\x12[\n" +
+	"\n" +
+	"created_by\x18\x0f \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12c\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12<\n" +
+	"\aversion\x18\x12 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\"\xe8\x01\n" +
+	"\tPriceTier\x12c\n" +
+	"\fmin_quantity\x18\x01 \x01(\x05B@\x9a\x84\x9e\x03;bson:\"min_quantity\" json:\"min_quantity\" validate:\"positive\"R\vminQuantity\x12v\n" +
+	"\x10discount_percent\x18\x02 \x01(\x01BK\x9a\x84\x9e\x03Fbson:\"discount_percent\" json:\"discount_percent\" validate:\"min=0,max=1\"R\x0fdiscountPercent\"\xb7\x01\n" +
+	"\x10PriceRequestLine\x127\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\tproductId\x124\n" +
+	"\bquantity\x18\x02 \x01(\x05B\x18\x9a\x84\x9e\x03\x13validate:\"positive\"R\bquantity\x124\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01B\x15\x9a\x84\x9e\x03\x10validate:\"min=0\"R\tunitPrice\"\xb2\x01\n" +
+	"\x10PriceCalculation\x12)\n" +
+	"\x05lines\x18\x01 \x03(\v2\x13.core.v1.PricedLineR\x05lines\x12\x1a\n" +
+	"\bsubtotal\x18\x02 \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\x03 \x01(\x01R\bdiscount\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x01R\x05total\x12%\n" +
+	"\x0ecustomer_group\x18\x05 \x01(\tR\rcustomerGroup\"\x84\x02\n" +
+	"\n" +
+	"PricedLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\x03 \x01(\x01R\tlistPrice\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\x01R\tunitPrice\x12\x1a\n" +
+	"\bdiscount\x18\x05 \x01(\x01R\bdiscount\x12\x1a\n" +
+	"\bsubtotal\x18\x06 \x01(\x01R\bsubtotal\x12\x14\n" +
+	"\x05total\x18\a \x01(\x01R\x05total\x12/\n" +
+	"\x05trace\x18\b \x03(\v2\x19.core.v1.PricingTraceStepR\x05trace\"\xa8\x01\n" +
+	"\x10PricingTraceStep\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x1b\n" +
+	"\trule_name\x18\x02 \x01(\tR\bruleName\x12,\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x18.core.v1.PricingRuleTypeR\x04type\x12\x18\n" +
+	"\aapplied\x18\x04 \x01(\bR\aapplied\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xb7\x01\n" +
+	"\x18CreatePricingRuleRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12B\n" +
+	"\x04rule\x18\x02 \x01(\v2\x14.core.v1.PricingRuleB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x04rule\"E\n" +
+	"\x19CreatePricingRuleResponse\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.core.v1.PricingRuleR\x04rule\"\xa3\x01\n" +
+	"\x15GetPricingRuleRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x121\n" +
+	"\arule_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06ruleId\"B\n" +
+	"\x16GetPricingRuleResponse\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.core.v1.PricingRuleR\x04rule\"\xa0\x01\n" +
+	"\x17ListPricingRulesRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12,\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x18.core.v1.PricingRuleTypeR\x04type\"F\n" +
+	"\x18ListPricingRulesResponse\x12*\n" +
+	"\x05rules\x18\x01 \x03(\v2\x14.core.v1.PricingRuleR\x05rules\"\xf4\x01\n" +
+	"\x18UpdatePricingRuleRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12B\n" +
+	"\x04rule\x18\x02 \x01(\v2\x14.core.v1.PricingRuleB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x04rule\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"E\n" +
+	"\x19UpdatePricingRuleResponse\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.core.v1.PricingRuleR\x04rule\"\xa6\x01\n" +
+	"\x18DeletePricingRuleRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x121\n" +
+	"\arule_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06ruleId\"5\n" +
+	"\x19DeletePricingRuleResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\xaa\x02\n" +
+	"\x15CalculatePriceRequest\x12W\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\v2\x18.infra.v1.UserIdentifierB\x1d\x9a\x84\x9e\x03\x18validate:\"required,dive\"R\n" +
+	"identifier\x12$\n" +
+	"\vcustomer_id\x18\x02 \x01(\tH\x00R\n" +
+	"customerId\x88\x01\x01\x12V\n" +
+	"\x05lines\x18\x03 \x03(\v2\x19.core.v1.PriceRequestLineB%\x9a\x84\x9e\x03 validate:\"required,max=500,dive\"R\x05lines\x12*\n" +
+	"\x02at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02atB\x0e\n" +
+	"\f_customer_id\"U\n" +
+	"\x16CalculatePriceResponse\x12;\n" +
+	"\vcalculation\x18\x01 \x01(\v2\x19.core.v1.PriceCalculationR\vcalculation*\x9d\x01\n" +
+	"\x0fPricingRuleType\x12!\n" +
+	"\x1dPRICING_RULE_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cPRICING_RULE_TYPE_PRICE_LIST\x10\x01\x12$\n" +
+	" PRICING_RULE_TYPE_QUANTITY_TIERS\x10\x02\x12\x1f\n" +
+	"\x1bPRICING_RULE_TYPE_PROMOTION\x10\x032\xa3\x04\n" +
+	"\x0ePricingService\x12Z\n" +
+	"\x11CreatePricingRule\x12!.core.v1.CreatePricingRuleRequest\x1a\".core.v1.CreatePricingRuleResponse\x12Q\n" +
+	"\x0eGetPricingRule\x12\x1e.core.v1.GetPricingRuleRequest\x1a\x1f.core.v1.GetPricingRuleResponse\x12W\n" +
+	"\x10ListPricingRules\x12 .core.v1.ListPricingRulesRequest\x1a!.core.v1.ListPricingRulesResponse\x12Z\n" +
+	"\x11UpdatePricingRule\x12!.core.v1.UpdatePricingRuleRequest\x1a\".core.v1.UpdatePricingRuleResponse\x12Z\n" +
+	"\x11DeletePricingRule\x12!.core.v1.DeletePricingRuleRequest\x1a\".core.v1.DeletePricingRuleResponse\x12Q\n" +
+	"\x0eCalculatePrice\x12\x1e.core.v1.CalculatePriceRequest\x1a\x1f.core.v1.CalculatePriceResponseB3Z1erp.localhost/internal/infra/model/core/v1;corev1b\x06proto3"
+
+var (
+	file_core_v1_pricing_proto_rawDescOnce sync.Once
+	file_core_v1_pricing_proto_rawDescData []byte
+)
+
+func file_core_v1_pricing_proto_rawDescGZIP() []byte {
+	file_core_v1_pricing_proto_rawDescOnce.Do(func() {
+		file_core_v1_pricing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_core_v1_pricing_proto_rawDesc), len(file_core_v1_pricing_proto_rawDesc)))
+	})
+	return file_core_v1_pricing_proto_rawDescData
+}
+
+var file_core_v1_pricing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_core_v1_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_core_v1_pricing_proto_goTypes = []any{
+	(PricingRuleType)(0),              // 0: core.v1.PricingRuleType
+	(*PricingRule)(nil),               // 1: core.v1.PricingRule
+	(*PriceTier)(nil),                 // 2: core.v1.PriceTier
+	(*PriceRequestLine)(nil),          // 3: core.v1.PriceRequestLine
+	(*PriceCalculation)(nil),          // 4: core.v1.PriceCalculation
+	(*PricedLine)(nil),                // 5: core.v1.PricedLine
+	(*PricingTraceStep)(nil),          // 6: core.v1.PricingTraceStep
+	(*CreatePricingRuleRequest)(nil),  // 7: core.v1.CreatePricingRuleRequest
+	(*CreatePricingRuleResponse)(nil), // 8: core.v1.CreatePricingRuleResponse
+	(*GetPricingRuleRequest)(nil),     // 9: core.v1.GetPricingRuleRequest
+	(*GetPricingRuleResponse)(nil),    // 10: core.v1.GetPricingRuleResponse
+	(*ListPricingRulesRequest)(nil),   // 11: core.v1.ListPricingRulesRequest
+	(*ListPricingRulesResponse)(nil),  // 12: core.v1.ListPricingRulesResponse
+	(*UpdatePricingRuleRequest)(nil),  // 13: core.v1.UpdatePricingRuleRequest
+	(*UpdatePricingRuleResponse)(nil), // 14: core.v1.UpdatePricingRuleResponse
+	(*DeletePricingRuleRequest)(nil),  // 15: core.v1.DeletePricingRuleRequest
+	(*DeletePricingRuleResponse)(nil), // 16: core.v1.DeletePricingRuleResponse
+	(*CalculatePriceRequest)(nil),     // 17: core.v1.CalculatePriceRequest
+	(*CalculatePriceResponse)(nil),    // 18: core.v1.CalculatePriceResponse
+	(*timestamppb.Timestamp)(nil),     // 19: google.protobuf.Timestamp
+	(*v1.UserIdentifier)(nil),         // 20: infra.v1.UserIdentifier
+	(*fieldmaskpb.FieldMask)(nil),     // 21: google.protobuf.FieldMask
+}
+var file_core_v1_pricing_proto_depIdxs = []int32{
+	0,  // 0: core.v1.PricingRule.type:type_name -> core.v1.PricingRuleType
+	2,  // 1: core.v1.PricingRule.tiers:type_name -> core.v1.PriceTier
+	19, // 2: core.v1.PricingRule.starts_at:type_name -> google.protobuf.Timestamp
+	19, // 3: core.v1.PricingRule.ends_at:type_name -> google.protobuf.Timestamp
+	19, // 4: core.v1.PricingRule.created_at:type_name -> google.protobuf.Timestamp
+	19, // 5: core.v1.PricingRule.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 6: core.v1.PriceCalculation.lines:type_name -> core.v1.PricedLine
+	6,  // 7: core.v1.PricedLine.trace:type_name -> core.v1.PricingTraceStep
+	0,  // 8: core.v1.PricingTraceStep.type:type_name -> core.v1.PricingRuleType
+	20, // 9: core.v1.CreatePricingRuleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	1,  // 10: core.v1.CreatePricingRuleRequest.rule:type_name -> core.v1.PricingRule
+	1,  // 11: core.v1.CreatePricingRuleResponse.rule:type_name -> core.v1.PricingRule
+	20, // 12: core.v1.GetPricingRuleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	1,  // 13: core.v1.GetPricingRuleResponse.rule:type_name -> core.v1.PricingRule
+	20, // 14: core.v1.ListPricingRulesRequest.identifier:type_name -> infra.v1.UserIdentifier
+	0,  // 15: core.v1.ListPricingRulesRequest.type:type_name -> core.v1.PricingRuleType
+	1,  // 16: core.v1.ListPricingRulesResponse.rules:type_name -> core.v1.PricingRule
+	20, // 17: core.v1.UpdatePricingRuleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	1,  // 18: core.v1.UpdatePricingRuleRequest.rule:type_name -> core.v1.PricingRule
+	21, // 19: core.v1.UpdatePricingRuleRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 20: core.v1.UpdatePricingRuleResponse.rule:type_name -> core.v1.PricingRule
+	20, // 21: core.v1.DeletePricingRuleRequest.identifier:type_name -> infra.v1.UserIdentifier
+	20, // 22: core.v1.CalculatePriceRequest.identifier:type_name -> infra.v1.UserIdentifier
+	3,  // 23: core.v1.CalculatePriceRequest.lines:type_name -> core.v1.PriceRequestLine
+	19, // 24: core.v1.CalculatePriceRequest.at:type_name -> google.protobuf.Timestamp
+	4,  // 25: core.v1.CalculatePriceResponse.calculation:type_name -> core.v1.PriceCalculation
+	7,  // 26: core.v1.PricingService.CreatePricingRule:input_type -> core.v1.CreatePricingRuleRequest
+	9,  // 27: core.v1.PricingService.GetPricingRule:input_type -> core.v1.GetPricingRuleRequest
+	11, // 28: core.v1.PricingService.ListPricingRules:input_type -> core.v1.ListPricingRulesRequest
+	13, // 29: core.v1.PricingService.UpdatePricingRule:input_type -> core.v1.UpdatePricingRuleRequest
+	15, // 30: core.v1.PricingService.DeletePricingRule:input_type -> core.v1.DeletePricingRuleRequest
+	17, // 31: core.v1.PricingService.CalculatePrice:input_type -> core.v1.CalculatePriceRequest
+	8,  // 32: core.v1.PricingService.CreatePricingRule:output_type -> core.v1.CreatePricingRuleResponse
+	10, // 33: core.v1.PricingService.GetPricingRule:output_type -> core.v1.GetPricingRuleResponse
+	12, // 34: core.v1.PricingService.ListPricingRules:output_type -> core.v1.ListPricingRulesResponse
+	14, // 35: core.v1.PricingService.UpdatePricingRule:output_type -> core.v1.UpdatePricingRuleResponse
+	16, // 36: core.v1.PricingService.DeletePricingRule:output_type -> core.v1.DeletePricingRuleResponse
+	18, // 37: core.v1.PricingService.CalculatePrice:output_type -> core.v1.CalculatePriceResponse
+	32, // [32:38] is the sub-list for method output_type
+	26, // [26:32] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_core_v1_pricing_proto_init() }
+func file_core_v1_pricing_proto_init() {
+	if File_core_v1_pricing_proto != nil {
+		return
+	}
+	file_core_v1_pricing_proto_msgTypes[16].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_pricing_proto_rawDesc), len(file_core_v1_pricing_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_core_v1_pricing_proto_goTypes,
+		DependencyIndexes: file_core_v1_pricing_proto_depIdxs,
+		EnumInfos:         file_core_v1_pricing_proto_enumTypes,
+		MessageInfos:      file_core_v1_pricing_proto_msgTypes,
+	}.Build()
+	File_core_v1_pricing_proto = out.File
+	file_core_v1_pricing_proto_goTypes = nil
+	file_core_v1_pricing_proto_depIdxs = nil
+}
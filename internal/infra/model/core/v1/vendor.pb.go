@@ -76,26 +76,30 @@ func (VendorStatus) EnumDescriptor() ([]byte, []int) {
 	return file_core_v1_vendor_proto_rawDescGZIP(), []int{0}
 }
 
-// Vendor model for MongoDB core_db.vendors collection
+// Vendor model for MongoDB core_db.vendors collection, the suppliers of the tenant
+// The code, email and tax ID of a vendor are unique within its tenant
 type Vendor struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
 	VendorId         string                 `protobuf:"bytes,2,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id" bson:"vendor_id"`
-	TenantId         string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	Name             string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name" bson:"name"`
-	Code             string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code" bson:"code"`
-	Contact          *VendorContact         `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact" bson:"contact"`
+	TenantId         string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Name             string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name" bson:"name" validate:"required,max=200"`
+	Code             string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty" bson:"code,omitempty" validate:"max=50"`
+	Contact          *VendorContact         `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact" bson:"contact" validate:"dive"`
 	Address          *Address               `protobuf:"bytes,7,opt,name=address,proto3" json:"address" bson:"address"`
-	PaymentTerms     *PaymentTerms          `protobuf:"bytes,8,opt,name=payment_terms,json=paymentTerms,proto3" json:"payment_terms" bson:"payment_terms"`
-	Rating           float64                `protobuf:"fixed64,9,opt,name=rating,proto3" json:"rating" bson:"rating"`
-	Status           VendorStatus           `protobuf:"varint,10,opt,name=status,proto3,enum=core.v1.VendorStatus" json:"status" bson:"status"`
+	PaymentTerms     *PaymentTerms          `protobuf:"bytes,8,opt,name=payment_terms,json=paymentTerms,proto3" json:"payment_terms" bson:"payment_terms" validate:"dive"`
+	Rating           float64                `protobuf:"fixed64,9,opt,name=rating,proto3" json:"rating" bson:"rating" validate:"min=0,max=5"`
+	Status           VendorStatus           `protobuf:"varint,10,opt,name=status,proto3,enum=core.v1.VendorStatus" json:"status" bson:"status" validate:"required"`
 	ProductsSupplied []string               `protobuf:"bytes,11,rep,name=products_supplied,json=productsSupplied,proto3" json:"products_supplied,omitempty" bson:"products_supplied,omitempty"`
-	Metadata         *VendorMetadata        `protobuf:"bytes,12,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty"`
+	Metadata         *VendorMetadata        `protobuf:"bytes,12,opt,name=metadata,proto3" json:"metadata,omitempty" bson:"metadata,omitempty" validate:"dive"`
 	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
 	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
-	CreatedBy        string                 `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	CreatedBy        string                 `protobuf:"bytes,15,opt,name=created_by,json=createdBy,proto3" json:"created_by" bson:"created_by" validate:"required"`
+	Contacts         []*Contact             `protobuf:"bytes,16,rep,name=contacts,proto3" json:"contacts,omitempty" bson:"contacts,omitempty" validate:"max=50,dive"`
+	// Incremented by every update, a vendor is updated at the version it was read at
+	Version       int64 `protobuf:"varint,17,opt,name=version,proto3" json:"version" bson:"version"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Vendor) Reset() {
@@ -233,10 +237,24 @@ func (x *Vendor) GetCreatedBy() string {
 	return ""
 }
 
+func (x *Vendor) GetContacts() []*Contact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *Vendor) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type VendorContact struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email" bson:"email"`
-	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone" bson:"phone"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email" bson:"email" validate:"email"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone" bson:"phone" validate:"max=50"`
 	Website       string                 `protobuf:"bytes,3,opt,name=website,proto3" json:"website,omitempty" bson:"website,omitempty"`
 	ContactPerson string                 `protobuf:"bytes,4,opt,name=contact_person,json=contactPerson,proto3" json:"contact_person,omitempty" bson:"contact_person,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -301,78 +319,18 @@ func (x *VendorContact) GetContactPerson() string {
 	return ""
 }
 
-type PaymentTerms struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Terms         string                 `protobuf:"bytes,1,opt,name=terms,proto3" json:"terms" bson:"terms"`
-	CreditLimit   float64                `protobuf:"fixed64,2,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit" bson:"credit_limit"`
-	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency" bson:"currency"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PaymentTerms) Reset() {
-	*x = PaymentTerms{}
-	mi := &file_core_v1_vendor_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *PaymentTerms) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*PaymentTerms) ProtoMessage() {}
-
-func (x *PaymentTerms) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_vendor_proto_msgTypes[2]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PaymentTerms.ProtoReflect.Descriptor instead.
-func (*PaymentTerms) Descriptor() ([]byte, []int) {
-	return file_core_v1_vendor_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *PaymentTerms) GetTerms() string {
-	if x != nil {
-		return x.Terms
-	}
-	return ""
-}
-
-func (x *PaymentTerms) GetCreditLimit() float64 {
-	if x != nil {
-		return x.CreditLimit
-	}
-	return 0
-}
-
-func (x *PaymentTerms) GetCurrency() string {
-	if x != nil {
-		return x.Currency
-	}
-	return ""
-}
-
 type VendorMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaxId         string                 `protobuf:"bytes,1,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty" bson:"tax_id,omitempty"`
+	TaxId         string                 `protobuf:"bytes,1,opt,name=tax_id,json=taxId,proto3" json:"tax_id,omitempty" bson:"tax_id,omitempty" validate:"max=50"`
 	BusinessType  string                 `protobuf:"bytes,2,opt,name=business_type,json=businessType,proto3" json:"business_type,omitempty" bson:"business_type,omitempty"`
-	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty" bson:"notes,omitempty"`
+	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty" bson:"notes,omitempty" validate:"max=2000"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *VendorMetadata) Reset() {
 	*x = VendorMetadata{}
-	mi := &file_core_v1_vendor_proto_msgTypes[3]
+	mi := &file_core_v1_vendor_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -384,7 +342,7 @@ func (x *VendorMetadata) String() string {
 func (*VendorMetadata) ProtoMessage() {}
 
 func (x *VendorMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_vendor_proto_msgTypes[3]
+	mi := &file_core_v1_vendor_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +355,7 @@ func (x *VendorMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VendorMetadata.ProtoReflect.Descriptor instead.
 func (*VendorMetadata) Descriptor() ([]byte, []int) {
-	return file_core_v1_vendor_proto_rawDescGZIP(), []int{3}
+	return file_core_v1_vendor_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *VendorMetadata) GetTaxId() string {
@@ -425,40 +383,38 @@ var File_core_v1_vendor_proto protoreflect.FileDescriptor
 
 const file_core_v1_vendor_proto_rawDesc = "" +
 	"\n" +
-	"\x14core/v1/vendor.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x15core/v1/address.proto\"\xc3\t\n" +
-	"\x06Vendor\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12C\n" +
-	"\tvendor_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"vendor_id\" json:\"vendor_id\"R\bvendorId\x12C\n" +
-	"\ttenant_id\x18\x03 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x120\n" +
-	"\x04name\x18\x04 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x120\n" +
-	"\x04code\x18\x05 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"code\" json:\"code\"R\x04code\x12T\n" +
-	"\acontact\x18\x06 \x01(\v2\x16.core.v1.VendorContactB\"\x9a\x84\x9e\x03\x1dbson:\"contact\" json:\"contact\"R\acontact\x12N\n" +
-	"\aaddress\x18\a \x01(\v2\x10.core.v1.AddressB\"\x9a\x84\x9e\x03\x1dbson:\"address\" json:\"address\"R\aaddress\x12j\n" +
-	"\rpayment_terms\x18\b \x01(\v2\x15.core.v1.PaymentTermsB.\x9a\x84\x9e\x03)bson:\"payment_terms\" json:\"payment_terms\"R\fpaymentTerms\x128\n" +
-	"\x06rating\x18\t \x01(\x01B \x9a\x84\x9e\x03\x1bbson:\"rating\" json:\"rating\"R\x06rating\x12O\n" +
+	"\x14core/v1/vendor.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x15core/v1/address.proto\x1a\x14core/v1/common.proto\"\xe3\f\n" +
+	"\x06Vendor\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12C\n" +
+	"\tvendor_id\x18\x02 \x01(\tB&\x9a\x84\x9e\x03!bson:\"vendor_id\" json:\"vendor_id\"R\bvendorId\x12W\n" +
+	"\ttenant_id\x18\x03 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12L\n" +
+	"\x04name\x18\x04 \x01(\tB8\x9a\x84\x9e\x033bson:\"name\" json:\"name\" validate:\"required,max=200\"R\x04name\x12V\n" +
+	"\x04code\x18\x05 \x01(\tBB\x9a\x84\x9e\x03=bson:\"code,omitempty\" json:\"code,omitempty\" validate:\"max=50\"R\x04code\x12d\n" +
+	"\acontact\x18\x06 \x01(\v2\x16.core.v1.VendorContactB2\x9a\x84\x9e\x03-bson:\"contact\" json:\"contact\" validate:\"dive\"R\acontact\x12N\n" +
+	"\aaddress\x18\a \x01(\v2\x10.core.v1.AddressB\"\x9a\x84\x9e\x03\x1dbson:\"address\" json:\"address\"R\aaddress\x12z\n" +
+	"\rpayment_terms\x18\b \x01(\v2\x15.core.v1.PaymentTermsB>\x9a\x84\x9e\x039bson:\"payment_terms\" json:\"payment_terms\" validate:\"dive\"R\fpaymentTerms\x12O\n" +
+	"\x06rating\x18\t \x01(\x01B7\x9a\x84\x9e\x032bson:\"rating\" json:\"rating\" validate:\"min=0,max=5\"R\x06rating\x12c\n" +
 	"\x06status\x18\n" +
-	" \x01(\x0e2\x15.core.v1.VendorStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12w\n" +
-	"\x11products_supplied\x18\v \x03(\tBJ\x9a\x84\x9e\x03Ebson:\"products_supplied,omitempty\" json:\"products_supplied,omitempty\"R\x10productsSupplied\x12m\n" +
-	"\bmetadata\x18\f \x01(\v2\x17.core.v1.VendorMetadataB8\x9a\x84\x9e\x033bson:\"metadata,omitempty\" json:\"metadata,omitempty\"R\bmetadata\x12c\n" +
+	" \x01(\x0e2\x15.core.v1.VendorStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12w\n" +
+	"\x11products_supplied\x18\v \x03(\tBJ\x9a\x84\x9e\x03Ebson:\"products_supplied,omitempty\" json:\"products_supplied,omitempty\"R\x10productsSupplied\x12}\n" +
+	"\bmetadata\x18\f \x01(\v2\x17.core.v1.VendorMetadataBH\x9a\x84\x9e\x03Cbson:\"metadata,omitempty\" json:\"metadata,omitempty\" validate:\"dive\"R\bmetadata\x12c\n" +
 	"\n" +
 	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
-	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12G\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\x12[\n" +
 	"\n" +
-	"created_by\x18\x0f \x01(\tB(\x9a\x84\x9e\x03#bson:\"created_by\" json:\"created_by\"R\tcreatedBy\"\xba\x02\n" +
-	"\rVendorContact\x124\n" +
-	"\x05email\x18\x01 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"email\" json:\"email\"R\x05email\x124\n" +
-	"\x05phone\x18\x02 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"phone\" json:\"phone\"R\x05phone\x12P\n" +
+	"created_by\x18\x0f \x01(\tB<\x9a\x84\x9e\x037bson:\"created_by\" json:\"created_by\" validate:\"required\"R\tcreatedBy\x12}\n" +
+	"\bcontacts\x18\x10 \x03(\v2\x10.core.v1.ContactBO\x9a\x84\x9e\x03Jbson:\"contacts,omitempty\" json:\"contacts,omitempty\" validate:\"max=50,dive\"R\bcontacts\x12<\n" +
+	"\aversion\x18\x11 \x01(\x03B\"\x9a\x84\x9e\x03\x1dbson:\"version\" json:\"version\"R\aversion\"\xdd\x02\n" +
+	"\rVendorContact\x12E\n" +
+	"\x05email\x18\x01 \x01(\tB/\x9a\x84\x9e\x03*bson:\"email\" json:\"email\" validate:\"email\"R\x05email\x12F\n" +
+	"\x05phone\x18\x02 \x01(\tB0\x9a\x84\x9e\x03+bson:\"phone\" json:\"phone\" validate:\"max=50\"R\x05phone\x12P\n" +
 	"\awebsite\x18\x03 \x01(\tB6\x9a\x84\x9e\x031bson:\"website,omitempty\" json:\"website,omitempty\"R\awebsite\x12k\n" +
-	"\x0econtact_person\x18\x04 \x01(\tBD\x9a\x84\x9e\x03?bson:\"contact_person,omitempty\" json:\"contact_person,omitempty\"R\rcontactPerson\"\xd7\x01\n" +
-	"\fPaymentTerms\x124\n" +
-	"\x05terms\x18\x01 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"terms\" json:\"terms\"R\x05terms\x12O\n" +
-	"\fcredit_limit\x18\x02 \x01(\x01B,\x9a\x84\x9e\x03'bson:\"credit_limit\" json:\"credit_limit\"R\vcreditLimit\x12@\n" +
-	"\bcurrency\x18\x03 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"currency\" json:\"currency\"R\bcurrency\"\x90\x02\n" +
-	"\x0eVendorMetadata\x12K\n" +
-	"\x06tax_id\x18\x01 \x01(\tB4\x9a\x84\x9e\x03/bson:\"tax_id,omitempty\" json:\"tax_id,omitempty\"R\x05taxId\x12g\n" +
-	"\rbusiness_type\x18\x02 \x01(\tBB\x9a\x84\x9e\x03=bson:\"business_type,omitempty\" json:\"business_type,omitempty\"R\fbusinessType\x12H\n" +
-	"\x05notes\x18\x03 \x01(\tB2\x9a\x84\x9e\x03-bson:\"notes,omitempty\" json:\"notes,omitempty\"R\x05notes*\x87\x01\n" +
+	"\x0econtact_person\x18\x04 \x01(\tBD\x9a\x84\x9e\x03?bson:\"contact_person,omitempty\" json:\"contact_person,omitempty\"R\rcontactPerson\"\xb6\x02\n" +
+	"\x0eVendorMetadata\x12]\n" +
+	"\x06tax_id\x18\x01 \x01(\tBF\x9a\x84\x9e\x03Abson:\"tax_id,omitempty\" json:\"tax_id,omitempty\" validate:\"max=50\"R\x05taxId\x12g\n" +
+	"\rbusiness_type\x18\x02 \x01(\tBB\x9a\x84\x9e\x03=bson:\"business_type,omitempty\" json:\"business_type,omitempty\"R\fbusinessType\x12\\\n" +
+	"\x05notes\x18\x03 \x01(\tBF\x9a\x84\x9e\x03Abson:\"notes,omitempty\" json:\"notes,omitempty\" validate:\"max=2000\"R\x05notes*\x87\x01\n" +
 	"\fVendorStatus\x12\x1d\n" +
 	"\x19VENDOR_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14VENDOR_STATUS_ACTIVE\x10\x01\x12\x1a\n" +
@@ -478,29 +434,31 @@ func file_core_v1_vendor_proto_rawDescGZIP() []byte {
 }
 
 var file_core_v1_vendor_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_core_v1_vendor_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_core_v1_vendor_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_core_v1_vendor_proto_goTypes = []any{
 	(VendorStatus)(0),             // 0: core.v1.VendorStatus
 	(*Vendor)(nil),                // 1: core.v1.Vendor
 	(*VendorContact)(nil),         // 2: core.v1.VendorContact
-	(*PaymentTerms)(nil),          // 3: core.v1.PaymentTerms
-	(*VendorMetadata)(nil),        // 4: core.v1.VendorMetadata
-	(*Address)(nil),               // 5: core.v1.Address
+	(*VendorMetadata)(nil),        // 3: core.v1.VendorMetadata
+	(*Address)(nil),               // 4: core.v1.Address
+	(*PaymentTerms)(nil),          // 5: core.v1.PaymentTerms
 	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*Contact)(nil),               // 7: core.v1.Contact
 }
 var file_core_v1_vendor_proto_depIdxs = []int32{
 	2, // 0: core.v1.Vendor.contact:type_name -> core.v1.VendorContact
-	5, // 1: core.v1.Vendor.address:type_name -> core.v1.Address
-	3, // 2: core.v1.Vendor.payment_terms:type_name -> core.v1.PaymentTerms
+	4, // 1: core.v1.Vendor.address:type_name -> core.v1.Address
+	5, // 2: core.v1.Vendor.payment_terms:type_name -> core.v1.PaymentTerms
 	0, // 3: core.v1.Vendor.status:type_name -> core.v1.VendorStatus
-	4, // 4: core.v1.Vendor.metadata:type_name -> core.v1.VendorMetadata
+	3, // 4: core.v1.Vendor.metadata:type_name -> core.v1.VendorMetadata
 	6, // 5: core.v1.Vendor.created_at:type_name -> google.protobuf.Timestamp
 	6, // 6: core.v1.Vendor.updated_at:type_name -> google.protobuf.Timestamp
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	7, // 7: core.v1.Vendor.contacts:type_name -> core.v1.Contact
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_core_v1_vendor_proto_init() }
@@ -509,13 +467,14 @@ func file_core_v1_vendor_proto_init() {
 		return
 	}
 	file_core_v1_address_proto_init()
+	file_core_v1_common_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_vendor_proto_rawDesc), len(file_core_v1_vendor_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -79,15 +79,15 @@ func (WarehouseStatus) EnumDescriptor() ([]byte, []int) {
 // Warehouse model for MongoDB core_db.warehouses collection
 type Warehouse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty" validate:"required_on_update"`
 	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id" bson:"warehouse_id"`
-	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id"`
-	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name" bson:"name"`
-	Code          string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code" bson:"code"`
+	TenantId      string                 `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id" bson:"tenant_id" validate:"required"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name" bson:"name" validate:"required,max=100"`
+	Code          string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code" bson:"code" validate:"required,max=50"`
 	Address       *Address               `protobuf:"bytes,6,opt,name=address,proto3" json:"address" bson:"address"`
-	Contact       *WarehouseContact      `protobuf:"bytes,7,opt,name=contact,proto3" json:"contact" bson:"contact"`
+	Contact       *WarehouseContact      `protobuf:"bytes,7,opt,name=contact,proto3" json:"contact" bson:"contact" validate:"dive"`
 	Capacity      *WarehouseCapacity     `protobuf:"bytes,8,opt,name=capacity,proto3" json:"capacity" bson:"capacity"`
-	Status        WarehouseStatus        `protobuf:"varint,9,opt,name=status,proto3,enum=core.v1.WarehouseStatus" json:"status" bson:"status"`
+	Status        WarehouseStatus        `protobuf:"varint,9,opt,name=status,proto3,enum=core.v1.WarehouseStatus" json:"status" bson:"status" validate:"required"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at" bson:"updated_at"`
 	unknownFields protoimpl.UnknownFields
@@ -204,7 +204,7 @@ func (x *Warehouse) GetUpdatedAt() *timestamppb.Timestamp {
 type WarehouseContact struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Manager       string                 `protobuf:"bytes,1,opt,name=manager,proto3" json:"manager" bson:"manager"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email" bson:"email"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email" bson:"email" validate:"email"`
 	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone" bson:"phone"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -325,25 +325,25 @@ var File_core_v1_warehouse_proto protoreflect.FileDescriptor
 
 const file_core_v1_warehouse_proto_rawDesc = "" +
 	"\n" +
-	"\x17core/v1/warehouse.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x15core/v1/address.proto\"\xdf\x06\n" +
-	"\tWarehouse\x123\n" +
-	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12O\n" +
-	"\fwarehouse_id\x18\x02 \x01(\tB,\x9a\x84\x9e\x03'bson:\"warehouse_id\" json:\"warehouse_id\"R\vwarehouseId\x12C\n" +
-	"\ttenant_id\x18\x03 \x01(\tB&\x9a\x84\x9e\x03!bson:\"tenant_id\" json:\"tenant_id\"R\btenantId\x120\n" +
-	"\x04name\x18\x04 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"name\" json:\"name\"R\x04name\x120\n" +
-	"\x04code\x18\x05 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"code\" json:\"code\"R\x04code\x12N\n" +
-	"\aaddress\x18\x06 \x01(\v2\x10.core.v1.AddressB\"\x9a\x84\x9e\x03\x1dbson:\"address\" json:\"address\"R\aaddress\x12W\n" +
-	"\acontact\x18\a \x01(\v2\x19.core.v1.WarehouseContactB\"\x9a\x84\x9e\x03\x1dbson:\"contact\" json:\"contact\"R\acontact\x12\\\n" +
-	"\bcapacity\x18\b \x01(\v2\x1a.core.v1.WarehouseCapacityB$\x9a\x84\x9e\x03\x1fbson:\"capacity\" json:\"capacity\"R\bcapacity\x12R\n" +
-	"\x06status\x18\t \x01(\x0e2\x18.core.v1.WarehouseStatusB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12c\n" +
+	"\x17core/v1/warehouse.proto\x12\acore.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\x1a\x15core/v1/address.proto\"\xec\a\n" +
+	"\tWarehouse\x12Q\n" +
+	"\x02id\x18\x01 \x01(\tBA\x9a\x84\x9e\x03<bson:\"_id,omitempty\" json:\"id\" validate:\"required_on_update\"R\x02id\x12O\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tB,\x9a\x84\x9e\x03'bson:\"warehouse_id\" json:\"warehouse_id\"R\vwarehouseId\x12W\n" +
+	"\ttenant_id\x18\x03 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id\" json:\"tenant_id\" validate:\"required\"R\btenantId\x12L\n" +
+	"\x04name\x18\x04 \x01(\tB8\x9a\x84\x9e\x033bson:\"name\" json:\"name\" validate:\"required,max=100\"R\x04name\x12K\n" +
+	"\x04code\x18\x05 \x01(\tB7\x9a\x84\x9e\x032bson:\"code\" json:\"code\" validate:\"required,max=50\"R\x04code\x12N\n" +
+	"\aaddress\x18\x06 \x01(\v2\x10.core.v1.AddressB\"\x9a\x84\x9e\x03\x1dbson:\"address\" json:\"address\"R\aaddress\x12g\n" +
+	"\acontact\x18\a \x01(\v2\x19.core.v1.WarehouseContactB2\x9a\x84\x9e\x03-bson:\"contact\" json:\"contact\" validate:\"dive\"R\acontact\x12\\\n" +
+	"\bcapacity\x18\b \x01(\v2\x1a.core.v1.WarehouseCapacityB$\x9a\x84\x9e\x03\x1fbson:\"capacity\" json:\"capacity\"R\bcapacity\x12f\n" +
+	"\x06status\x18\t \x01(\x0e2\x18.core.v1.WarehouseStatusB4\x9a\x84\x9e\x03/bson:\"status\" json:\"status\" validate:\"required\"R\x06status\x12c\n" +
 	"\n" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12c\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\"\xbc\x01\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"updated_at\" json:\"updated_at\"R\tupdatedAt\"\xcd\x01\n" +
 	"\x10WarehouseContact\x12<\n" +
-	"\amanager\x18\x01 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"manager\" json:\"manager\"R\amanager\x124\n" +
-	"\x05email\x18\x02 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"email\" json:\"email\"R\x05email\x124\n" +
+	"\amanager\x18\x01 \x01(\tB\"\x9a\x84\x9e\x03\x1dbson:\"manager\" json:\"manager\"R\amanager\x12E\n" +
+	"\x05email\x18\x02 \x01(\tB/\x9a\x84\x9e\x03*bson:\"email\" json:\"email\" validate:\"email\"R\x05email\x124\n" +
 	"\x05phone\x18\x03 \x01(\tB\x1e\x9a\x84\x9e\x03\x19bson:\"phone\" json:\"phone\"R\x05phone\"\xdb\x01\n" +
 	"\x11WarehouseCapacity\x12K\n" +
 	"\vtotal_space\x18\x01 \x01(\x05B*\x9a\x84\x9e\x03%bson:\"total_space\" json:\"total_space\"R\n" +
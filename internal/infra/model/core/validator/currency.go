@@ -0,0 +1,20 @@
+package validator
+
+import (
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+// ValidateExchangeRate checks the fields of an exchange rate and that it converts between two currencies
+func ValidateExchangeRate(r *corev1.ExchangeRate) error {
+	if err := validation.Struct(r, false); err != nil {
+		return err
+	}
+	if r.BaseCurrency == r.QuoteCurrency {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "QuoteCurrency").WithError(errors.New("an exchange rate converts between two currencies"))
+	}
+	return nil
+}
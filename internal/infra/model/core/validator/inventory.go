@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+func ValidateStockItem(s *corev1.StockItem, createOperation bool) error {
+	if err := validation.Struct(s, !createOperation); err != nil {
+		return err
+	}
+	if s.Reserved > s.Quantity {
+		return infra_error.Validation(infra_error.ValidationOutOfRange, "Reserved").WithError(errors.New("reserved stock exceeds the quantity"))
+	}
+	return nil
+}
+
+// ValidateStockMovement checks the fields of a movement and the rules of its type: IN, OUT and TRANSFER movements
+// move a positive quantity, TRANSFER movements move it to another warehouse
+func ValidateStockMovement(m *corev1.StockMovement) error {
+	if err := validation.Struct(m, false); err != nil {
+		return err
+	}
+	switch m.Type {
+	case corev1.StockMovementType_STOCK_MOVEMENT_TYPE_IN, corev1.StockMovementType_STOCK_MOVEMENT_TYPE_OUT:
+		if m.ToWarehouseId != "" {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "ToWarehouseId").WithError(errors.New("only transfers have a destination warehouse"))
+		}
+	case corev1.StockMovementType_STOCK_MOVEMENT_TYPE_TRANSFER:
+		if m.ToWarehouseId == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "ToWarehouseId")
+		}
+		if m.ToWarehouseId == m.WarehouseId {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "ToWarehouseId").WithError(errors.New("a transfer moves stock to another warehouse"))
+		}
+	case corev1.StockMovementType_STOCK_MOVEMENT_TYPE_ADJUSTMENT:
+		if m.ToWarehouseId != "" {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "ToWarehouseId").WithError(errors.New("only transfers have a destination warehouse"))
+		}
+		// The quantity of an adjustment is signed
+		return nil
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "Type")
+	}
+	if m.Quantity <= 0 {
+		return infra_error.Validation(infra_error.ValidationOutOfRange, "Quantity").WithError(errors.New("must be positive"))
+	}
+	return nil
+}
+
+// ValidateStockReservation checks the fields of a reservation, a product of a warehouse is held by one of its lines
+func ValidateStockReservation(r *corev1.StockReservation, createOperation bool) error {
+	if err := validation.Struct(r, !createOperation); err != nil {
+		return err
+	}
+	seen := make(map[[2]string]bool, len(r.Lines))
+	for _, line := range r.Lines {
+		key := [2]string{line.ProductId, line.WarehouseId}
+		if seen[key] {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "Lines").WithError(errors.New("duplicate line for product " + line.ProductId + " in warehouse " + line.WarehouseId))
+		}
+		seen[key] = true
+	}
+	return nil
+}
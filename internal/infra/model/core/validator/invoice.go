@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"erp.localhost/internal/infra/validation"
+	"google.golang.org/protobuf/proto"
+)
+
+// ValidateInvoice checks the fields of an invoice and that its payments do not exceed its total
+func ValidateInvoice(i *corev1.Invoice, createOperation bool) error {
+	if err := validation.Struct(i, !createOperation); err != nil {
+		return err
+	}
+	if i.AmountPaid > i.Total {
+		return infra_error.Validation(infra_error.ValidationOutOfRange, "AmountPaid").WithError(errors.New("the payments of an invoice exceed its total"))
+	}
+	return nil
+}
+
+// ValidateNewInvoice checks an invoice before it is created, an invoice without a number is given one when it is
+// stored and is checked as if it had it
+func ValidateNewInvoice(i *corev1.Invoice) error {
+	if i.InvoiceNumber != "" {
+		return ValidateInvoice(i, true)
+	}
+	numbered := proto.Clone(i).(*corev1.Invoice)
+	numbered.InvoiceNumber = unnumbered
+	return ValidateInvoice(numbered, true)
+}
+
+// ValidateInvoicePayment checks the fields of a payment recorded against an invoice
+func ValidateInvoicePayment(p *corev1.InvoicePayment) error {
+	if p == nil {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "Payment")
+	}
+	return validation.Struct(p, false)
+}
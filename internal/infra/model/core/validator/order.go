@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"erp.localhost/internal/infra/validation"
+	"google.golang.org/protobuf/proto"
+)
+
+// unnumbered stands in for the number of a document validated before it is numbered
+const unnumbered = "-"
+
+// ValidateOrder checks the fields of an order and that the discount of a line does not exceed its subtotal
+func ValidateOrder(o *corev1.Order, createOperation bool) error {
+	if err := validation.Struct(o, !createOperation); err != nil {
+		return err
+	}
+	for _, line := range o.Lines {
+		if line.Discount > float64(line.Quantity)*line.UnitPrice {
+			return infra_error.Validation(infra_error.ValidationOutOfRange, "Discount").WithError(errors.New("the discount of a line exceeds its subtotal"))
+		}
+	}
+	return nil
+}
+
+// ValidateNewOrder checks an order before it is created, an order without a number is given one when it is stored
+// and is checked as if it had it
+func ValidateNewOrder(o *corev1.Order) error {
+	if o.OrderNumber != "" {
+		return ValidateOrder(o, true)
+	}
+	numbered := proto.Clone(o).(*corev1.Order)
+	numbered.OrderNumber = unnumbered
+	return ValidateOrder(numbered, true)
+}
+
+// ValidateOrderSubmission checks that an order leaving the draft status can be fulfilled: it has lines and the
+// counterparty of its type
+func ValidateOrderSubmission(o *corev1.Order) error {
+	if len(o.Lines) == 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, "Lines")
+	}
+	switch o.OrderType {
+	case corev1.OrderType_ORDER_TYPE_SALES:
+		if o.CustomerId == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "CustomerId")
+		}
+	case corev1.OrderType_ORDER_TYPE_PURCHASE:
+		if o.VendorId == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "VendorId")
+		}
+	}
+	return nil
+}
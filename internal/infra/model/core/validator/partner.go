@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+// ValidateCustomer checks the fields of a customer, that at most one of its contacts is the primary one and that at
+// most one of its addresses of a type is the default one
+func ValidateCustomer(c *corev1.Customer, createOperation bool) error {
+	if err := validation.Struct(c, !createOperation); err != nil {
+		return err
+	}
+	if err := validatePrimaryContact(c.Contacts); err != nil {
+		return err
+	}
+	defaults := map[corev1.CustomerAddressType]bool{}
+	for _, address := range c.Addresses {
+		if !address.IsDefault {
+			continue
+		}
+		if defaults[address.Type] {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "Addresses").WithError(errors.New("more than one default address of a type"))
+		}
+		defaults[address.Type] = true
+	}
+	return nil
+}
+
+// ValidateVendor checks the fields of a vendor and that at most one of its contacts is the primary one
+func ValidateVendor(v *corev1.Vendor, createOperation bool) error {
+	if err := validation.Struct(v, !createOperation); err != nil {
+		return err
+	}
+	return validatePrimaryContact(v.Contacts)
+}
+
+func validatePrimaryContact(contacts []*corev1.Contact) error {
+	primary := false
+	for _, contact := range contacts {
+		if !contact.IsPrimary {
+			continue
+		}
+		if primary {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "Contacts").WithError(errors.New("more than one primary contact"))
+		}
+		primary = true
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"testing"
+
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func validCustomer() *corev1.Customer {
+	return &corev1.Customer{
+		TenantId:  "tenant-1",
+		Type:      corev1.CustomerType_CUSTOMER_TYPE_BUSINESS,
+		Name:      "Acme",
+		Email:     "billing@acme.com",
+		Status:    corev1.CustomerStatus_CUSTOMER_STATUS_ACTIVE,
+		CreatedBy: "user-1",
+	}
+}
+
+func TestValidateCustomer(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(customer *corev1.Customer)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*corev1.Customer) {}},
+		{name: "invalid email", modify: func(c *corev1.Customer) { c.Email = "acme" }, wantErr: true},
+		{name: "negative credit limit", modify: func(c *corev1.Customer) { c.CreditLimit = -1 }, wantErr: true},
+		{name: "invalid contact email", modify: func(c *corev1.Customer) {
+			c.Contacts = []*corev1.Contact{{Name: "Bob", Email: "bob"}}
+		}, wantErr: true},
+		{name: "two primary contacts", modify: func(c *corev1.Customer) {
+			c.Contacts = []*corev1.Contact{{Name: "Bob", IsPrimary: true}, {Name: "Alice", IsPrimary: true}}
+		}, wantErr: true},
+		{name: "default billing and shipping addresses", modify: func(c *corev1.Customer) {
+			c.Addresses = []*corev1.CustomerAddress{
+				{Type: corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_BILLING, IsDefault: true},
+				{Type: corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_SHIPPING, IsDefault: true},
+			}
+		}},
+		{name: "two default billing addresses", modify: func(c *corev1.Customer) {
+			c.Addresses = []*corev1.CustomerAddress{
+				{Type: corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_BILLING, IsDefault: true},
+				{Type: corev1.CustomerAddressType_CUSTOMER_ADDRESS_TYPE_BILLING, IsDefault: true},
+			}
+		}, wantErr: true},
+		{name: "invalid net days", modify: func(c *corev1.Customer) { c.PaymentTerms = &corev1.PaymentTerms{NetDays: 400} }, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			customer := validCustomer()
+			tc.modify(customer)
+			err := ValidateCustomer(customer, true)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateVendor(t *testing.T) {
+	vendor := &corev1.Vendor{TenantId: "tenant-1", Name: "Supplies Inc", Status: corev1.VendorStatus_VENDOR_STATUS_ACTIVE, CreatedBy: "user-1"}
+	assert.NoError(t, ValidateVendor(vendor, true))
+	assert.Error(t, ValidateVendor(vendor, false), "an update requires the id")
+
+	vendor.Rating = 6
+	assert.Error(t, ValidateVendor(vendor, true))
+}
@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"errors"
+
+	infra_error "erp.localhost/internal/infra/error"
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+// ValidatePricingRule checks the fields of a pricing rule and that it holds the prices or discounts of its type
+func ValidatePricingRule(r *corev1.PricingRule, createOperation bool) error {
+	if err := validation.Struct(r, !createOperation); err != nil {
+		return err
+	}
+	switch r.Type {
+	case corev1.PricingRuleType_PRICING_RULE_TYPE_PRICE_LIST:
+		if len(r.ProductIds) == 0 || r.UnitPrice <= 0 {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "ProductIds", "UnitPrice")
+		}
+	case corev1.PricingRuleType_PRICING_RULE_TYPE_QUANTITY_TIERS:
+		if len(r.Tiers) == 0 {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "Tiers")
+		}
+		seen := make(map[int32]bool, len(r.Tiers))
+		for _, tier := range r.Tiers {
+			if seen[tier.MinQuantity] {
+				return infra_error.Validation(infra_error.ValidationInvalidValue, "Tiers").WithError(errors.New("two tiers of a rule start at the same quantity"))
+			}
+			seen[tier.MinQuantity] = true
+		}
+	case corev1.PricingRuleType_PRICING_RULE_TYPE_PROMOTION:
+		if r.DiscountPercent == 0 && r.DiscountAmount == 0 {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "DiscountPercent", "DiscountAmount")
+		}
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "Type")
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.AsTime().After(r.StartsAt.AsTime()) {
+		return infra_error.Validation(infra_error.ValidationOutOfRange, "EndsAt").WithError(errors.New("a pricing rule ends after it starts"))
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package validator
+
+import (
+	corev1 "erp.localhost/internal/infra/model/core/v1"
+	"erp.localhost/internal/infra/validation"
+)
+
+func ValidateWarehouse(w *corev1.Warehouse, createOperation bool) error {
+	return validation.Struct(w, !createOperation)
+}
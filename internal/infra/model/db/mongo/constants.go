@@ -12,51 +12,93 @@ var (
 	EventDB  DBName = DBName(getEnvFromOS("EVENT_DB_NAME", "event_db"))
 
 	// Auth DB Collections
-	AuditLogsCollection   Collection = "audit_logs"
-	PermissionsCollection Collection = "permissions"
-	RolesCollection       Collection = "roles"
-	TenantsCollection     Collection = "tenants"
-	UsersCollection       Collection = "users"
+	APIKeysCollection           Collection = "api_keys"
+	APIUsageCollection          Collection = "api_usage"
+	AuditLogsCollection         Collection = "audit_logs"
+	DevicesCollection           Collection = "devices"
+	LoginHistoryCollection      Collection = "login_history"
+	MigrationsCollection        Collection = "migrations"
+	OutboxCollection            Collection = "outbox"
+	PermissionsCollection       Collection = "permissions"
+	PermissionSetsCollection    Collection = "permission_sets"
+	ResumeTokensCollection      Collection = "resume_tokens"
+	RolesCollection             Collection = "roles"
+	SystemReportsCollection     Collection = "system_reports"
+	TenantsCollection           Collection = "tenants"
+	UsersCollection             Collection = "users"
+	WebhooksCollection          Collection = "webhooks"
+	WebhookDeliveriesCollection Collection = "webhook_deliveries"
 
 	// Config DB Collections
 	ServiceConfigCollection Collection = "service_config"
+	ConfigEntriesCollection Collection = "config_entries"
+	ConfigHistoryCollection Collection = "config_history"
 	FeatureFlagsCollection  Collection = "feature_flags"
 	EnvironmentCollection   Collection = "environment_settings"
 
 	// Core DB Collections
-	CategoriesCollection Collection = "categories"
-	CustomerCollection   Collection = "customers"
-	InventoryCollection  Collection = "inventory"
-	OrderItemsCollection Collection = "order_items"
-	OrdersCollection     Collection = "orders"
-	ProductsCollection   Collection = "products"
-	VendorsCollection    Collection = "vendors"
-	WarehouseCollection  Collection = "warehouses"
+	CategoriesCollection        Collection = "categories"
+	CoreOutboxCollection        Collection = "core_outbox"
+	CurrencySettingsCollection  Collection = "currency_settings"
+	CustomerCollection          Collection = "customers"
+	DocumentCountersCollection  Collection = "document_counters"
+	ExchangeRatesCollection     Collection = "exchange_rates"
+	InventoryCollection         Collection = "inventory"
+	InvoicesCollection          Collection = "invoices"
+	OrderItemsCollection        Collection = "order_items"
+	OrdersCollection            Collection = "orders"
+	PricingRulesCollection      Collection = "pricing_rules"
+	ProductsCollection          Collection = "products"
+	StockMovementsCollection    Collection = "stock_movements"
+	StockReservationsCollection Collection = "stock_reservations"
+	VendorsCollection           Collection = "vendors"
+	WarehouseCollection         Collection = "warehouses"
 )
 
 var (
 	dbToCollection = map[string][]string{
-		string(AuthDB):   {string(AuditLogsCollection), string(PermissionsCollection), string(RolesCollection), string(TenantsCollection), string(UsersCollection)},
-		string(ConfigDB): {string(ServiceConfigCollection), string(FeatureFlagsCollection), string(EnvironmentCollection)},
-		string(CoreDB):   {string(CategoriesCollection), string(CustomerCollection), string(InventoryCollection), string(OrderItemsCollection), string(OrdersCollection), string(ProductsCollection), string(VendorsCollection), string(WarehouseCollection)},
+		string(AuthDB):   {string(APIKeysCollection), string(APIUsageCollection), string(AuditLogsCollection), string(DevicesCollection), string(LoginHistoryCollection), string(MigrationsCollection), string(OutboxCollection), string(PermissionsCollection), string(PermissionSetsCollection), string(ResumeTokensCollection), string(RolesCollection), string(SystemReportsCollection), string(TenantsCollection), string(UsersCollection), string(WebhooksCollection), string(WebhookDeliveriesCollection)},
+		string(ConfigDB): {string(ServiceConfigCollection), string(ConfigEntriesCollection), string(ConfigHistoryCollection), string(FeatureFlagsCollection), string(EnvironmentCollection)},
+		string(CoreDB):   {string(CategoriesCollection), string(CoreOutboxCollection), string(CurrencySettingsCollection), string(CustomerCollection), string(DocumentCountersCollection), string(ExchangeRatesCollection), string(InventoryCollection), string(InvoicesCollection), string(OrderItemsCollection), string(OrdersCollection), string(PricingRulesCollection), string(ProductsCollection), string(StockMovementsCollection), string(StockReservationsCollection), string(VendorsCollection), string(WarehouseCollection)},
 	}
 	collectionToDB = map[string]string{
-		string(AuditLogsCollection):     string(AuthDB),
-		string(PermissionsCollection):   string(AuthDB),
-		string(RolesCollection):         string(AuthDB),
-		string(TenantsCollection):       string(AuthDB),
-		string(UsersCollection):         string(AuthDB),
-		string(ServiceConfigCollection): string(ConfigDB),
-		string(FeatureFlagsCollection):  string(ConfigDB),
-		string(EnvironmentCollection):   string(ConfigDB),
-		string(CategoriesCollection):    string(CoreDB),
-		string(CustomerCollection):      string(CoreDB),
-		string(InventoryCollection):     string(CoreDB),
-		string(OrderItemsCollection):    string(CoreDB),
-		string(OrdersCollection):        string(CoreDB),
-		string(ProductsCollection):      string(CoreDB),
-		string(VendorsCollection):       string(CoreDB),
-		string(WarehouseCollection):     string(CoreDB),
+		string(APIKeysCollection):           string(AuthDB),
+		string(APIUsageCollection):          string(AuthDB),
+		string(AuditLogsCollection):         string(AuthDB),
+		string(DevicesCollection):           string(AuthDB),
+		string(LoginHistoryCollection):      string(AuthDB),
+		string(MigrationsCollection):        string(AuthDB),
+		string(OutboxCollection):            string(AuthDB),
+		string(PermissionsCollection):       string(AuthDB),
+		string(PermissionSetsCollection):    string(AuthDB),
+		string(ResumeTokensCollection):      string(AuthDB),
+		string(RolesCollection):             string(AuthDB),
+		string(SystemReportsCollection):     string(AuthDB),
+		string(TenantsCollection):           string(AuthDB),
+		string(UsersCollection):             string(AuthDB),
+		string(WebhooksCollection):          string(AuthDB),
+		string(WebhookDeliveriesCollection): string(AuthDB),
+		string(ServiceConfigCollection):     string(ConfigDB),
+		string(ConfigEntriesCollection):     string(ConfigDB),
+		string(ConfigHistoryCollection):     string(ConfigDB),
+		string(FeatureFlagsCollection):      string(ConfigDB),
+		string(EnvironmentCollection):       string(ConfigDB),
+		string(CategoriesCollection):        string(CoreDB),
+		string(CoreOutboxCollection):        string(CoreDB),
+		string(CustomerCollection):          string(CoreDB),
+		string(CurrencySettingsCollection):  string(CoreDB),
+		string(DocumentCountersCollection):  string(CoreDB),
+		string(ExchangeRatesCollection):     string(CoreDB),
+		string(InventoryCollection):         string(CoreDB),
+		string(InvoicesCollection):          string(CoreDB),
+		string(OrderItemsCollection):        string(CoreDB),
+		string(OrdersCollection):            string(CoreDB),
+		string(PricingRulesCollection):      string(CoreDB),
+		string(ProductsCollection):          string(CoreDB),
+		string(StockMovementsCollection):    string(CoreDB),
+		string(StockReservationsCollection): string(CoreDB),
+		string(VendorsCollection):           string(CoreDB),
+		string(WarehouseCollection):         string(CoreDB),
 	}
 )
 
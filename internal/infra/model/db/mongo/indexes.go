@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ManagedIndexPrefix marks indexes owned by the registry, indexes without it are never dropped by EnsureIndexes
+const ManagedIndexPrefix = "idx_"
+
+// IndexDefinition is the set of indexes kept on a collection
+type IndexDefinition struct {
+	DB         DBName
+	Collection Collection
+	Indexes    func() []mongo.IndexModel
+}
+
+var (
+	indexRegistryMu sync.RWMutex
+	indexRegistry   = []IndexDefinition{
+		{DB: AuthDB, Collection: TenantsCollection, Indexes: GetTenantsIndexes},
+		{DB: AuthDB, Collection: UsersCollection, Indexes: GetUsersIndexes},
+		{DB: AuthDB, Collection: RolesCollection, Indexes: GetRolesIndexes},
+		{DB: AuthDB, Collection: PermissionsCollection, Indexes: GetPermissionsIndexes},
+		{DB: AuthDB, Collection: PermissionSetsCollection, Indexes: GetPermissionSetsIndexes},
+		{DB: AuthDB, Collection: APIKeysCollection, Indexes: GetAPIKeysIndexes},
+		{DB: AuthDB, Collection: APIUsageCollection, Indexes: GetAPIUsageIndexes},
+		{DB: AuthDB, Collection: AuditLogsCollection, Indexes: GetAuditLogsIndexes},
+		{DB: AuthDB, Collection: DevicesCollection, Indexes: GetDevicesIndexes},
+		{DB: AuthDB, Collection: LoginHistoryCollection, Indexes: GetLoginHistoryIndexes},
+		{DB: AuthDB, Collection: MigrationsCollection, Indexes: GetMigrationsIndexes},
+		{DB: AuthDB, Collection: OutboxCollection, Indexes: GetOutboxIndexes},
+		{DB: AuthDB, Collection: SystemReportsCollection, Indexes: GetSystemReportsIndexes},
+		{DB: AuthDB, Collection: WebhooksCollection, Indexes: GetWebhooksIndexes},
+		{DB: AuthDB, Collection: WebhookDeliveriesCollection, Indexes: GetWebhookDeliveriesIndexes},
+		{DB: ConfigDB, Collection: ServiceConfigCollection, Indexes: GetServiceConfigIndexes},
+		{DB: ConfigDB, Collection: ConfigEntriesCollection, Indexes: GetConfigEntriesIndexes},
+		{DB: ConfigDB, Collection: ConfigHistoryCollection, Indexes: GetConfigHistoryIndexes},
+		{DB: ConfigDB, Collection: FeatureFlagsCollection, Indexes: GetFeatureFlagsIndexes},
+		{DB: CoreDB, Collection: WarehouseCollection, Indexes: GetWarehousesIndexes},
+		{DB: CoreDB, Collection: InventoryCollection, Indexes: GetInventoryIndexes},
+		{DB: CoreDB, Collection: StockMovementsCollection, Indexes: GetStockMovementsIndexes},
+		{DB: CoreDB, Collection: StockReservationsCollection, Indexes: GetStockReservationsIndexes},
+		{DB: CoreDB, Collection: OrdersCollection, Indexes: GetOrdersIndexes},
+		{DB: CoreDB, Collection: CustomerCollection, Indexes: GetCustomersIndexes},
+		{DB: CoreDB, Collection: VendorsCollection, Indexes: GetVendorsIndexes},
+		{DB: CoreDB, Collection: InvoicesCollection, Indexes: GetInvoicesIndexes},
+		{DB: CoreDB, Collection: DocumentCountersCollection, Indexes: GetDocumentCountersIndexes},
+		{DB: CoreDB, Collection: PricingRulesCollection, Indexes: GetPricingRulesIndexes},
+		{DB: CoreDB, Collection: CurrencySettingsCollection, Indexes: GetCurrencySettingsIndexes},
+		{DB: CoreDB, Collection: ExchangeRatesCollection, Indexes: GetExchangeRatesIndexes},
+		{DB: CoreDB, Collection: CoreOutboxCollection, Indexes: GetOutboxIndexes},
+	}
+)
+
+// RegisterIndexes adds the indexes of a collection to the registry, replacing the ones registered before for it
+func RegisterIndexes(dbName DBName, collection Collection, indexes func() []mongo.IndexModel) {
+	indexRegistryMu.Lock()
+	defer indexRegistryMu.Unlock()
+	definition := IndexDefinition{DB: dbName, Collection: collection, Indexes: indexes}
+	for i, registered := range indexRegistry {
+		if registered.DB == dbName && registered.Collection == collection {
+			indexRegistry[i] = definition
+			return
+		}
+	}
+	indexRegistry = append(indexRegistry, definition)
+}
+
+// IndexDefinitions returns the registered index definitions in registration order
+func IndexDefinitions() []IndexDefinition {
+	indexRegistryMu.RLock()
+	defer indexRegistryMu.RUnlock()
+	definitions := make([]IndexDefinition, len(indexRegistry))
+	copy(definitions, indexRegistry)
+	return definitions
+}
+
+// TenantIDIndex is the compound tenant_id and _id index of tenant scoped collections,
+// it serves tenant listings and lookups by id that are always filtered by tenant
+func TenantIDIndex() mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "tenant_id", Value: 1},
+			{Key: "_id", Value: 1},
+		},
+		Options: options.Index().SetName("idx_tenant_id_id"),
+	}
+}
+
+// TTLIndex removes documents once field, a date, is older than expireAfter. With 0 the field holds the expiry itself.
+func TTLIndex(name, field string, expireAfter time.Duration) mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: options.Index().SetName(name).SetExpireAfterSeconds(int32(expireAfter.Seconds())),
+	}
+}
@@ -0,0 +1,26 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetAPIKeysIndexes returns all index definitions for the api_keys collection
+func GetAPIKeysIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// Key lookups on every API key authenticated call
+			Keys:    bson.D{{Key: "prefix", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_prefix_unique"),
+		},
+		TenantIDIndex(),
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_created_at"),
+		},
+	}
+}
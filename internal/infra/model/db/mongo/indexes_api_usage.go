@@ -0,0 +1,23 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetAPIUsageIndexes returns all index definitions for the api_usage collection
+func GetAPIUsageIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// One rollup per tenant, day and method
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "date", Value: 1},
+				{Key: "service", Value: 1},
+				{Key: "method", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_tenant_date_method_unique"),
+		},
+	}
+}
@@ -9,6 +9,7 @@ import (
 // GetAuditLogsIndexes returns all index definitions for the audit_logs collection
 func GetAuditLogsIndexes() []mongo.IndexModel {
 	return []mongo.IndexModel{
+		TenantIDIndex(),
 		{
 			Keys: bson.D{
 				{Key: "tenant_id", Value: 1},
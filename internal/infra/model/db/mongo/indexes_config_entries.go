@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetConfigEntriesIndexes returns all index definitions for the config_entries collection
+func GetConfigEntriesIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// One entry per key and scope, also serves listings of a scope by key prefix
+			Keys: bson.D{
+				{Key: "scope", Value: 1},
+				{Key: "tenant_id", Value: 1},
+				{Key: "module", Value: 1},
+				{Key: "key", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_scope_tenant_module_key_unique"),
+		},
+	}
+}
+
+// GetConfigHistoryIndexes returns all index definitions for the config_history collection
+func GetConfigHistoryIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// History of an entry, newest first
+			Keys: bson.D{
+				{Key: "scope", Value: 1},
+				{Key: "tenant_id", Value: 1},
+				{Key: "module", Value: 1},
+				{Key: "key", Value: 1},
+				{Key: "version", Value: -1},
+			},
+			Options: options.Index().SetName("idx_scope_tenant_module_key_version"),
+		},
+	}
+}
+
+// GetFeatureFlagsIndexes returns all index definitions for the feature_flags collection
+func GetFeatureFlagsIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// Flags are addressed and evaluated by key
+			Keys:    bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_key_unique"),
+		},
+	}
+}
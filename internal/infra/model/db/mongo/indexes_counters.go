@@ -0,0 +1,22 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetDocumentCountersIndexes returns all index definitions for the document counters collection
+func GetDocumentCountersIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// One counter per sequence, the concurrent upserts of a new sequence create a single counter
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "document", Value: 1},
+				{Key: "period", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_document_period_unique").SetUnique(true),
+		},
+	}
+}
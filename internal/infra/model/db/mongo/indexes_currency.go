@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetCurrencySettingsIndexes returns all index definitions for the currency settings collection
+func GetCurrencySettingsIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// One settings document per tenant, the concurrent upserts of a tenant create a single one
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_id_unique").SetUnique(true),
+		},
+	}
+}
+
+// GetExchangeRatesIndexes returns all index definitions for the exchange rates collection
+func GetExchangeRatesIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			// The rate of a pair effective at a time is the latest before it
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "base_currency", Value: 1},
+				{Key: "quote_currency", Value: 1},
+				{Key: "effective_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_pair_effective_at"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "effective_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_effective_at"),
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetDevicesIndexes returns all index definitions for the devices collection
+func GetDevicesIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// One device per fingerprint of a user, registered on their logins
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "user_id", Value: 1},
+				{Key: "fingerprint", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_user_fingerprint_unique").SetUnique(true),
+		},
+		{
+			// Devices of a user, most recently seen first
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "user_id", Value: 1},
+				{Key: "last_seen", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_user_last_seen"),
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetWarehousesIndexes returns all index definitions for the warehouses collection
+func GetWarehousesIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "code", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_code").SetUnique(true),
+		},
+	}
+}
+
+// GetInventoryIndexes returns all index definitions for the inventory collection
+func GetInventoryIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			// A product has one stock item per warehouse, concurrent first movements cannot create two
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "product_id", Value: 1},
+				{Key: "warehouse_id", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_product_warehouse").SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "warehouse_id", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_warehouse"),
+		},
+	}
+}
+
+// GetStockMovementsIndexes returns all index definitions for the stock_movements collection
+func GetStockMovementsIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// Movement history of a product, newest first
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "product_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_product_created_at"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "warehouse_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_warehouse_created_at"),
+		},
+	}
+}
+
+// GetStockReservationsIndexes returns all index definitions for the stock_reservations collection
+func GetStockReservationsIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "reference", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_reference"),
+		},
+	}
+}
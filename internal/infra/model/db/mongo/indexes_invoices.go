@@ -0,0 +1,46 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetInvoicesIndexes returns all index definitions for the invoices collection
+func GetInvoicesIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "invoice_number", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_invoice_number").SetUnique(true),
+		},
+		{
+			// An order is invoiced once
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "order_id", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_order_id_unique").SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "customer_id", Value: 1},
+				{Key: "issued_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_customer_issued_at"),
+		},
+		{
+			// Unpaid and overdue invoice lists
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "payment_status", Value: 1},
+				{Key: "due_at", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_payment_status_due_at"),
+		},
+	}
+}
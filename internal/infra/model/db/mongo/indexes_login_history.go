@@ -0,0 +1,28 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LoginHistoryRetention is how long the login attempts are kept
+const LoginHistoryRetention = 90 * 24 * time.Hour
+
+// GetLoginHistoryIndexes returns all index definitions for the login_history collection
+func GetLoginHistoryIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// Login history of a user, newest first
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "user_id", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_user_timestamp"),
+		},
+		TTLIndex("idx_timestamp_ttl", "timestamp", LoginHistoryRetention),
+	}
+}
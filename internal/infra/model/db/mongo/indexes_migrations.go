@@ -0,0 +1,21 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetMigrationsIndexes returns all index definitions for the migrations collection
+func GetMigrationsIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// One record per applied seed manifest or migration
+			Keys: bson.D{
+				{Key: "kind", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("idx_kind_name_unique").SetUnique(true),
+		},
+	}
+}
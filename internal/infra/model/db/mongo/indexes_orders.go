@@ -0,0 +1,46 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetOrdersIndexes returns all index definitions for the orders collection
+func GetOrdersIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "order_number", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_order_number").SetUnique(true),
+		},
+		{
+			// Order lists filtered by status, newest first
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "status", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_status_created_at"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "customer_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_customer_created_at"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "vendor_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_vendor_created_at"),
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxRetention is how long published events are kept in the outbox
+const OutboxRetention = 7 * 24 * time.Hour
+
+// GetOutboxIndexes returns all index definitions for the outbox collection
+func GetOutboxIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}},
+			Options: options.Index().SetName("idx_event_id").SetUnique(true),
+		},
+		{
+			// The publisher polls the pending events due for an attempt, oldest first
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+				{Key: "created_at", Value: 1},
+			},
+			Options: options.Index().SetName("idx_status_next_attempt"),
+		},
+		// published_at is only set once an event is published, pending and failed events are kept
+		TTLIndex("idx_published_ttl", "published_at", OutboxRetention),
+	}
+}
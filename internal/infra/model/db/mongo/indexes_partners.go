@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetCustomersIndexes returns all index definitions for the customers collection
+func GetCustomersIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "email", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(nonEmpty("email")).SetName("idx_tenant_email_unique"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "company.tax_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(nonEmpty("company.tax_id")).SetName("idx_tenant_tax_id_unique"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_name"),
+		},
+	}
+}
+
+// GetVendorsIndexes returns all index definitions for the vendors collection
+func GetVendorsIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "code", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(nonEmpty("code")).SetName("idx_tenant_code_unique"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "contact.email", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(nonEmpty("contact.email")).SetName("idx_tenant_email_unique"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "metadata.tax_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(nonEmpty("metadata.tax_id")).SetName("idx_tenant_tax_id_unique"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_name"),
+		},
+	}
+}
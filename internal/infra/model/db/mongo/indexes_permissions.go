@@ -9,6 +9,7 @@ import (
 // GetPermissionsIndexes returns all index definitions for the permissions collection
 func GetPermissionsIndexes() []mongo.IndexModel {
 	return []mongo.IndexModel{
+		TenantIDIndex(),
 		{
 			Keys: bson.D{
 				{Key: "tenant_id", Value: 1},
@@ -0,0 +1,23 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetPricingRulesIndexes returns all index definitions for the pricing rules collection
+func GetPricingRulesIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			// The active rules of a tenant are loaded to price every order
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "active", Value: 1},
+				{Key: "priority", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_active_priority"),
+		},
+	}
+}
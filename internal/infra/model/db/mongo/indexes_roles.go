@@ -16,10 +16,7 @@ func GetRolesIndexes() []mongo.IndexModel {
 			},
 			Options: options.Index().SetUnique(true).SetName("idx_tenant_name_unique"),
 		},
-		{
-			Keys:    bson.D{{Key: "tenant_id", Value: 1}},
-			Options: options.Index().SetName("idx_tenant_id"),
-		},
+		TenantIDIndex(),
 		{
 			Keys: bson.D{
 				{Key: "tenant_id", Value: 1},
@@ -27,5 +24,12 @@ func GetRolesIndexes() []mongo.IndexModel {
 			},
 			Options: options.Index().SetName("idx_tenant_permissions"),
 		},
+		{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "permission_sets", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_permission_sets"),
+		},
 	}
 }
@@ -0,0 +1,23 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetServiceConfigIndexes returns all index definitions for the service_config collection
+func GetServiceConfigIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// One document per config version, concurrent writers of the same version fail
+			Keys: bson.D{
+				{Key: "service_name", Value: 1},
+				{Key: "environment", Value: 1},
+				{Key: "tenant_id", Value: 1},
+				{Key: "version", Value: -1},
+			},
+			Options: options.Index().SetUnique(true).SetName("idx_service_env_tenant_version_unique"),
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetSystemReportsIndexes returns all index definitions for the system_reports collection
+func GetSystemReportsIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// Latest report lookups
+			Keys:    bson.D{{Key: "generated_at", Value: -1}},
+			Options: options.Index().SetName("idx_generated_at"),
+		},
+	}
+}
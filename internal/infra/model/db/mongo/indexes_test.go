@@ -0,0 +1,56 @@
+package mongo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIndexDefinitions(t *testing.T) {
+	for _, definition := range IndexDefinitions() {
+		assert.Equal(t, string(definition.DB), GetDBNameFromCollection(string(definition.Collection)), definition.Collection)
+
+		names := map[string]bool{}
+		for _, index := range definition.Indexes() {
+			require.NotNil(t, index.Options, definition.Collection)
+			require.NotNil(t, index.Options.Name, definition.Collection)
+			name := *index.Options.Name
+			assert.True(t, strings.HasPrefix(name, ManagedIndexPrefix), name)
+			assert.False(t, names[name], "index %s of %s is defined twice", name, definition.Collection)
+			names[name] = true
+		}
+	}
+}
+
+func TestRegisterIndexes(t *testing.T) {
+	original := IndexDefinitions()
+	t.Cleanup(func() {
+		indexRegistryMu.Lock()
+		indexRegistry = original
+		indexRegistryMu.Unlock()
+	})
+
+	RegisterIndexes(CoreDB, ProductsCollection, func() []mongo.IndexModel { return []mongo.IndexModel{TenantIDIndex()} })
+	definitions := IndexDefinitions()
+	require.Len(t, definitions, len(original)+1)
+	assert.Equal(t, ProductsCollection, definitions[len(original)].Collection)
+
+	// Registering a collection again replaces its definition
+	RegisterIndexes(CoreDB, ProductsCollection, func() []mongo.IndexModel { return nil })
+	definitions = IndexDefinitions()
+	require.Len(t, definitions, len(original)+1)
+	assert.Empty(t, definitions[len(original)].Indexes())
+}
+
+func TestTTLIndex(t *testing.T) {
+	index := TTLIndex("idx_expires_at_ttl", "expires_at", 0)
+	assert.Equal(t, "idx_expires_at_ttl", *index.Options.Name)
+	assert.Equal(t, int32(0), *index.Options.ExpireAfterSeconds)
+
+	index = TTLIndex("idx_created_at_ttl", "created_at", 24*time.Hour)
+	assert.Equal(t, int32(86400), *index.Options.ExpireAfterSeconds)
+}
@@ -10,23 +10,21 @@ import (
 func GetUsersIndexes() []mongo.IndexModel {
 	return []mongo.IndexModel{
 		{
+			// Users may have no email or no username, both are stored as empty strings which must not collide
 			Keys: bson.D{
 				{Key: "tenant_id", Value: 1},
 				{Key: "email", Value: 1},
 			},
-			Options: options.Index().SetUnique(true).SetSparse(true).SetName("idx_tenant_email_unique"),
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(nonEmpty("email")).SetName("idx_tenant_email_unique"),
 		},
 		{
 			Keys: bson.D{
 				{Key: "tenant_id", Value: 1},
 				{Key: "username", Value: 1},
 			},
-			Options: options.Index().SetUnique(true).SetSparse(true).SetName("idx_tenant_username_unique"),
-		},
-		{
-			Keys: bson.D{{Key: "tenant_id", Value: 1}},
-			Options: options.Index().SetName("idx_tenant_id"),
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(nonEmpty("username")).SetName("idx_tenant_username_unique"),
 		},
+		TenantIDIndex(),
 		{
 			Keys: bson.D{
 				{Key: "tenant_id", Value: 1},
@@ -41,5 +39,35 @@ func GetUsersIndexes() []mongo.IndexModel {
 			},
 			Options: options.Index().SetName("idx_tenant_roles"),
 		},
+		{
+			// Default order of user searches and their created date range filter
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "created_at", Value: 1},
+				{Key: "_id", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_created_at"),
+		},
+		{
+			// OIDC logins, sparse since most users have no linked identity
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "external_identities.provider", Value: 1},
+				{Key: "external_identities.subject", Value: 1},
+			},
+			Options: options.Index().SetSparse(true).SetName("idx_tenant_external_identity"),
+		},
+		{
+			// Sweeps of the expired role assignments across the tenants
+			Keys: bson.D{
+				{Key: "roles.expires_at", Value: 1},
+			},
+			Options: options.Index().SetSparse(true).SetName("idx_roles_expires_at"),
+		},
 	}
 }
+
+// nonEmpty is a partial filter matching documents where field is a non empty string
+func nonEmpty(field string) bson.D {
+	return bson.D{{Key: field, Value: bson.D{{Key: "$gt", Value: ""}}}}
+}
@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WebhookDeliveryRetention is how long the delivery log of a webhook is kept
+const WebhookDeliveryRetention = 30 * 24 * time.Hour
+
+// GetWebhooksIndexes returns all index definitions for the webhooks collection
+func GetWebhooksIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		TenantIDIndex(),
+		{
+			// Events are matched against the active webhooks of their tenant
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "active", Value: 1},
+			},
+			Options: options.Index().SetName("idx_tenant_active"),
+		},
+	}
+}
+
+// GetWebhookDeliveriesIndexes returns all index definitions for the webhook_deliveries collection
+func GetWebhookDeliveriesIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			// Delivery logs of a webhook, newest first
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "webhook_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("idx_tenant_webhook_created_at"),
+		},
+		{
+			// The dispatcher polls the pending deliveries due for an attempt
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "next_attempt_at", Value: 1},
+			},
+			Options: options.Index().SetName("idx_status_next_attempt"),
+		},
+		TTLIndex("idx_created_ttl", "created_at", WebhookDeliveryRetention),
+	}
+}
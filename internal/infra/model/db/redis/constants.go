@@ -9,10 +9,11 @@ const (
 	RedisKeyUserSessions = "user_sessions" // user_sessions:{tenant_id}:{user_id} -> set of session_ids
 
 	// Token keys
-	RedisKeyToken          = "tokens"         // tokens:{tenant_id}:{user_id}
-	RedisKeyRefreshToken   = "refresh_tokens" // refresh_tokens:{tenant_id}:{user_id}
-	RedisKeyRevokedToken   = "revoked_tokens" // revoked_tokens:{tenant_id}:{user_id}
-	RedisKeyBlacklistToken = "blacklist"      // blacklist:{tenant_id}:{user_id}
+	RedisKeyToken              = "tokens"               // tokens:{tenant_id}:{user_id}
+	RedisKeyRefreshToken       = "refresh_tokens"       // refresh_tokens:{tenant_id}:{user_id}
+	RedisKeyRefreshTokenFamily = "refresh_token_family" // refresh_token_family:{tenant_id}:{family_id}
+	RedisKeyRevokedToken       = "revoked_tokens"       // revoked_tokens:{tenant_id}:{user_id}
+	RedisKeyBlacklistToken     = "blacklist"            // blacklist:{tenant_id}:{user_id}
 
 	RedisKeyUserAccessTokens  = "user_access_tokens"  // user_access_tokens:{tenant_id}:{user_id} -> set of token_ids
 	RedisKeyUserRefreshTokens = "user_refresh_tokens" // user_refresh_tokens:{tenant_id}:{user_id} -> set of token_ids
@@ -38,13 +39,17 @@ const (
 	RedisKeyLock = "lock" // lock:{tenant_id}:{resource_id}
 
 	// Temporary data
-	RedisKeyPasswordReset = "pwd_reset"    // pwd_reset:{tenant_id}:{token}
-	RedisKeyEmailVerify   = "email_verify" // email_verify:{tenant_id}:{token}
-	RedisKeyMFACode       = "mfa_code"     // mfa_code:{tenant_id}:{user_id}
-	RedisKeyInviteToken   = "invite"       // invite:{tenant_id}:{token}
+	RedisKeyPasswordReset = "pwd_reset"      // pwd_reset:{tenant_id}:{token}
+	RedisKeyEmailVerify   = "email_verify"   // email_verify:{tenant_id}:{token}
+	RedisKeyMFACode       = "mfa_code"       // mfa_code:{tenant_id}:{user_id}
+	RedisKeyInviteToken   = "invite"         // invite:{tenant_id}:{token}
+	RedisKeyOIDCState     = "oidc_state"     // oidc_state:{tenant_id}:{state}
+	RedisKeySAMLAssertion = "saml_assertion" // saml_assertion:{tenant_id}:{assertion_id}
+	RedisKeyLoginLink     = "login_link"     // login_link:{tenant_id}:{token}
 
 	// Analytics & Metrics
 	RedisKeyLoginAttempts = "login_attempts" // login_attempts:{tenant_id}:{user_id}
+	RedisKeyKnownDevices  = "known_devices"  // known_devices:{tenant_id}:{user_id}
 	RedisKeyActiveUsers   = "active_users"   // active_users:{tenant_id} -> set
 	RedisKeyOnlineUsers   = "online_users"   // online_users:{tenant_id} -> sorted set
 
@@ -54,4 +59,7 @@ const (
 
 	// Config cache
 	RedisKeyServiceConfig = "config" // config:{tenant_id}:{service_name}:{environment}
+
+	// Event streams
+	RedisKeyEventStream = "events" // events:{topic}
 )
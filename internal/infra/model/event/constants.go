@@ -57,6 +57,10 @@ const (
 	ActionPasswordChanged = "password_changed"
 	ActionPasswordReset   = "password_reset"
 	ActionForcedLogout    = "forced_logout"
+
+	// A user acting as another user of the tenant, see AuthAPI.Impersonate
+	ActionImpersonationStarted = "impersonation_started"
+	ActionImpersonationStopped = "impersonation_stopped"
 )
 
 // User Management Actions
@@ -79,6 +83,10 @@ const (
 	ActionRoleRevoked        = "role_revoked"
 	ActionPermissionsAdded   = "permissions_added"
 	ActionPermissionsRemoved = "permissions_removed"
+
+	// Temporary role assignments extended, and removed once expired
+	ActionRoleAssignmentExtended = "role_assignment_extended"
+	ActionRoleAssignmentExpired  = "role_assignment_expired"
 )
 
 // Order Actions
@@ -122,10 +130,12 @@ const (
 
 // Tenant Actions
 const (
-	ActionTenantCreated   = "tenant_created"
-	ActionTenantUpdated   = "tenant_updated"
-	ActionTenantSuspended = "tenant_suspended"
-	ActionTenantActivated = "tenant_activated"
+	ActionTenantCreated     = "tenant_created"
+	ActionTenantUpdated     = "tenant_updated"
+	ActionTenantSuspended   = "tenant_suspended"
+	ActionTenantActivated   = "tenant_activated"
+	ActionTenantDeactivated = "tenant_deactivated"
+	ActionTenantImported    = "tenant_imported"
 )
 
 // Security Actions
@@ -201,6 +211,8 @@ func IsValidAuditAction(action string) bool {
 		ActionTenantUpdated:       true,
 		ActionTenantSuspended:     true,
 		ActionTenantActivated:     true,
+		ActionTenantDeactivated:   true,
+		ActionTenantImported:      true,
 		ActionBruteForceDetected:  true,
 		ActionSuspiciousActivity:  true,
 		ActionUnauthorizedAccess:  true,
@@ -211,6 +223,9 @@ func IsValidAuditAction(action string) bool {
 		ActionPIIDeleted:          true,
 		ActionGDPRDataExport:      true,
 		ActionRightToBeForgotten:  true,
+
+		ActionRoleAssignmentExtended: true,
+		ActionRoleAssignmentExpired:  true,
 	}
 
 	return validActions[action]
@@ -319,3 +334,63 @@ func IsValidTargetType(targetType string) bool {
 
 	return validTargetTypes[targetType]
 }
+
+/* Domain events */
+// Event types, <module>.<aggregate>.<change>
+const (
+	EventUserCreated         = "auth.user.created"
+	EventUserUpdated         = "auth.user.updated"
+	EventUserDeleted         = "auth.user.deleted"
+	EventTenantCreated       = "auth.tenant.created"
+	EventTenantUpdated       = "auth.tenant.updated"
+	EventTenantDeleted       = "auth.tenant.deleted"
+	EventTenantStatusChanged = "auth.tenant.status_changed"
+	EventRoleCreated         = "auth.role.created"
+	EventRoleUpdated         = "auth.role.updated"
+	EventRoleDeleted         = "auth.role.deleted"
+	EventPermissionCreated   = "auth.permission.created"
+	EventPermissionUpdated   = "auth.permission.updated"
+	EventPermissionDeleted   = "auth.permission.deleted"
+	EventLoginFailed         = "auth.login.failed"
+	EventLoginSuspicious     = "auth.login.suspicious"
+	// Changes of the auth documents read from their change stream, whatever made them, see api.ChangeFeed
+	EventUserChanged       = "auth.user.changed"
+	EventTenantChanged     = "auth.tenant.changed"
+	EventRoleChanged       = "auth.role.changed"
+	EventPermissionChanged = "auth.permission.changed"
+	// The available stock of an item fell to its low stock threshold
+	EventInventoryLowStock = "core.inventory.low_stock"
+)
+
+// AggregateOf returns the <module>.<aggregate> part of an event type, the events of an aggregate share a topic
+func AggregateOf(eventType string) string {
+	if i := strings.LastIndex(eventType, "."); i > 0 {
+		return eventType[:i]
+	}
+	return eventType
+}
+
+// Outbox statuses
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+	// Failed events used up their attempts, they are kept for inspection and not retried
+	OutboxStatusFailed = "failed"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	// Failed deliveries used up their attempts, they can be replayed
+	WebhookDeliveryFailed = "failed"
+)
+
+// WebhookEvent returns the name of an event type for the webhooks of a tenant, the type without its module,
+// e.g. user.created for auth.user.created
+func WebhookEvent(eventType string) string {
+	if i := strings.Index(eventType, "."); i > 0 {
+		return eventType[i+1:]
+	}
+	return eventType
+}
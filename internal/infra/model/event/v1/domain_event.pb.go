@@ -0,0 +1,961 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: event/v1/domain_event.proto
+
+package eventv1
+
+import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DomainEvent is a change of an entity owned by a module, published to the broker.
+// Consumers drop duplicates by id, an event may be delivered more than once
+type DomainEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Type is <module>.<aggregate>.<change>, e.g. auth.user.created
+	Type     string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	TenantId string `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Actor is the user that made the change, empty for system changes
+	ActorId    string                 `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DomainEvent_User
+	//	*DomainEvent_Tenant
+	//	*DomainEvent_Role
+	//	*DomainEvent_Permission
+	//	*DomainEvent_Login
+	//	*DomainEvent_Inventory
+	Payload       isDomainEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DomainEvent) Reset() {
+	*x = DomainEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DomainEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainEvent) ProtoMessage() {}
+
+func (x *DomainEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainEvent.ProtoReflect.Descriptor instead.
+func (*DomainEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DomainEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DomainEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DomainEvent) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DomainEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *DomainEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetPayload() isDomainEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetUser() *UserEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*DomainEvent_User); ok {
+			return x.User
+		}
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetTenant() *TenantEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*DomainEvent_Tenant); ok {
+			return x.Tenant
+		}
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetRole() *RoleEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*DomainEvent_Role); ok {
+			return x.Role
+		}
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetPermission() *PermissionEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*DomainEvent_Permission); ok {
+			return x.Permission
+		}
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetLogin() *LoginEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*DomainEvent_Login); ok {
+			return x.Login
+		}
+	}
+	return nil
+}
+
+func (x *DomainEvent) GetInventory() *InventoryEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*DomainEvent_Inventory); ok {
+			return x.Inventory
+		}
+	}
+	return nil
+}
+
+type isDomainEvent_Payload interface {
+	isDomainEvent_Payload()
+}
+
+type DomainEvent_User struct {
+	User *UserEvent `protobuf:"bytes,10,opt,name=user,proto3,oneof"`
+}
+
+type DomainEvent_Tenant struct {
+	Tenant *TenantEvent `protobuf:"bytes,11,opt,name=tenant,proto3,oneof"`
+}
+
+type DomainEvent_Role struct {
+	Role *RoleEvent `protobuf:"bytes,12,opt,name=role,proto3,oneof"`
+}
+
+type DomainEvent_Permission struct {
+	Permission *PermissionEvent `protobuf:"bytes,13,opt,name=permission,proto3,oneof"`
+}
+
+type DomainEvent_Login struct {
+	Login *LoginEvent `protobuf:"bytes,14,opt,name=login,proto3,oneof"`
+}
+
+type DomainEvent_Inventory struct {
+	Inventory *InventoryEvent `protobuf:"bytes,15,opt,name=inventory,proto3,oneof"`
+}
+
+func (*DomainEvent_User) isDomainEvent_Payload() {}
+
+func (*DomainEvent_Tenant) isDomainEvent_Payload() {}
+
+func (*DomainEvent_Role) isDomainEvent_Payload() {}
+
+func (*DomainEvent_Permission) isDomainEvent_Payload() {}
+
+func (*DomainEvent_Login) isDomainEvent_Payload() {}
+
+func (*DomainEvent_Inventory) isDomainEvent_Payload() {}
+
+type UserEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	RoleIds       []string               `protobuf:"bytes,5,rep,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEvent) Reset() {
+	*x = UserEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEvent) ProtoMessage() {}
+
+func (x *UserEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEvent.ProtoReflect.Descriptor instead.
+func (*UserEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserEvent) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserEvent) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserEvent) GetRoleIds() []string {
+	if x != nil {
+		return x.RoleIds
+	}
+	return nil
+}
+
+type TenantEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantEvent) Reset() {
+	*x = TenantEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantEvent) ProtoMessage() {}
+
+func (x *TenantEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantEvent.ProtoReflect.Descriptor instead.
+func (*TenantEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TenantEvent) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *TenantEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TenantEvent) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *TenantEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type RoleEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleId        string                 `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Permissions   []string               `protobuf:"bytes,4,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleEvent) Reset() {
+	*x = RoleEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleEvent) ProtoMessage() {}
+
+func (x *RoleEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleEvent.ProtoReflect.Descriptor instead.
+func (*RoleEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RoleEvent) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *RoleEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoleEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RoleEvent) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type PermissionEvent struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PermissionId     string                 `protobuf:"bytes,1,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+	PermissionString string                 `protobuf:"bytes,2,opt,name=permission_string,json=permissionString,proto3" json:"permission_string,omitempty"`
+	Resource         string                 `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	Action           string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PermissionEvent) Reset() {
+	*x = PermissionEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PermissionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionEvent) ProtoMessage() {}
+
+func (x *PermissionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionEvent.ProtoReflect.Descriptor instead.
+func (*PermissionEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PermissionEvent) GetPermissionId() string {
+	if x != nil {
+		return x.PermissionId
+	}
+	return ""
+}
+
+func (x *PermissionEvent) GetPermissionString() string {
+	if x != nil {
+		return x.PermissionString
+	}
+	return ""
+}
+
+func (x *PermissionEvent) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *PermissionEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type LoginEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IpAddress string                 `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	// 0 to 100, see risk_reasons
+	RiskScore     int32    `protobuf:"varint,4,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	RiskReasons   []string `protobuf:"bytes,5,rep,name=risk_reasons,json=riskReasons,proto3" json:"risk_reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginEvent) Reset() {
+	*x = LoginEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginEvent) ProtoMessage() {}
+
+func (x *LoginEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginEvent.ProtoReflect.Descriptor instead.
+func (*LoginEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LoginEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *LoginEvent) GetRiskScore() int32 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *LoginEvent) GetRiskReasons() []string {
+	if x != nil {
+		return x.RiskReasons
+	}
+	return nil
+}
+
+type InventoryEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	StockItemId       string                 `protobuf:"bytes,1,opt,name=stock_item_id,json=stockItemId,proto3" json:"stock_item_id,omitempty"`
+	ProductId         string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId       string                 `protobuf:"bytes,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity          int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved          int32                  `protobuf:"varint,5,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available         int32                  `protobuf:"varint,6,opt,name=available,proto3" json:"available,omitempty"`
+	LowStockThreshold int32                  `protobuf:"varint,7,opt,name=low_stock_threshold,json=lowStockThreshold,proto3" json:"low_stock_threshold,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InventoryEvent) Reset() {
+	*x = InventoryEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryEvent) ProtoMessage() {}
+
+func (x *InventoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryEvent.ProtoReflect.Descriptor instead.
+func (*InventoryEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *InventoryEvent) GetStockItemId() string {
+	if x != nil {
+		return x.StockItemId
+	}
+	return ""
+}
+
+func (x *InventoryEvent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InventoryEvent) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *InventoryEvent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InventoryEvent) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *InventoryEvent) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *InventoryEvent) GetLowStockThreshold() int32 {
+	if x != nil {
+		return x.LowStockThreshold
+	}
+	return 0
+}
+
+// OutboxEvent is a domain event waiting to be published, written in the transaction of the change
+// Stored in the outbox collection of the module, e.g. auth_db.outbox
+type OutboxEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id" bson:"_id,omitempty"`
+	EventId  string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id" bson:"event_id"`
+	Type     string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type" bson:"type"`
+	TenantId string                 `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" bson:"tenant_id,omitempty"`
+	// Aggregate is <module>.<aggregate> of the type, the events of an aggregate id are published in order
+	Aggregate   string `protobuf:"bytes,5,opt,name=aggregate,proto3" json:"aggregate" bson:"aggregate"`
+	AggregateId string `protobuf:"bytes,6,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id" bson:"aggregate_id"`
+	// Payload is the DomainEvent in the protobuf wire format
+	Payload       []byte                 `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload" bson:"payload"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status" bson:"status"`
+	Attempts      int32                  `protobuf:"varint,9,opt,name=attempts,proto3" json:"attempts" bson:"attempts"`
+	LastError     string                 `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty" bson:"last_error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at" bson:"created_at"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at" bson:"next_attempt_at"`
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty" bson:"published_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboxEvent) Reset() {
+	*x = OutboxEvent{}
+	mi := &file_event_v1_domain_event_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxEvent) ProtoMessage() {}
+
+func (x *OutboxEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_v1_domain_event_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxEvent.ProtoReflect.Descriptor instead.
+func (*OutboxEvent) Descriptor() ([]byte, []int) {
+	return file_event_v1_domain_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OutboxEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetAggregate() string {
+	if x != nil {
+		return x.Aggregate
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *OutboxEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *OutboxEvent) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *OutboxEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OutboxEvent) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *OutboxEvent) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+var File_event_v1_domain_event_proto protoreflect.FileDescriptor
+
+const file_event_v1_domain_event_proto_rawDesc = "" +
+	"\n" +
+	"\x1bevent/v1/domain_event.proto\x12\bevent.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13tagger/tagger.proto\"\xdd\x03\n" +
+	"\vDomainEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\tR\aactorId\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12)\n" +
+	"\x04user\x18\n" +
+	" \x01(\v2\x13.event.v1.UserEventH\x00R\x04user\x12/\n" +
+	"\x06tenant\x18\v \x01(\v2\x15.event.v1.TenantEventH\x00R\x06tenant\x12)\n" +
+	"\x04role\x18\f \x01(\v2\x13.event.v1.RoleEventH\x00R\x04role\x12;\n" +
+	"\n" +
+	"permission\x18\r \x01(\v2\x19.event.v1.PermissionEventH\x00R\n" +
+	"permission\x12,\n" +
+	"\x05login\x18\x0e \x01(\v2\x14.event.v1.LoginEventH\x00R\x05login\x128\n" +
+	"\tinventory\x18\x0f \x01(\v2\x18.event.v1.InventoryEventH\x00R\tinventoryB\t\n" +
+	"\apayload\"\x89\x01\n" +
+	"\tUserEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x19\n" +
+	"\brole_ids\x18\x05 \x03(\tR\aroleIds\"j\n" +
+	"\vTenantEvent\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"r\n" +
+	"\tRoleEvent\x12\x17\n" +
+	"\arole_id\x18\x01 \x01(\tR\x06roleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12 \n" +
+	"\vpermissions\x18\x04 \x03(\tR\vpermissions\"\x97\x01\n" +
+	"\x0fPermissionEvent\x12#\n" +
+	"\rpermission_id\x18\x01 \x01(\tR\fpermissionId\x12+\n" +
+	"\x11permission_string\x18\x02 \x01(\tR\x10permissionString\x12\x1a\n" +
+	"\bresource\x18\x03 \x01(\tR\bresource\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\"\xa5\x01\n" +
+	"\n" +
+	"LoginEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x02 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"risk_score\x18\x04 \x01(\x05R\triskScore\x12!\n" +
+	"\frisk_reasons\x18\x05 \x03(\tR\vriskReasons\"\xfc\x01\n" +
+	"\x0eInventoryEvent\x12\"\n" +
+	"\rstock_item_id\x18\x01 \x01(\tR\vstockItemId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12!\n" +
+	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x05 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\x06 \x01(\x05R\tavailable\x12.\n" +
+	"\x13low_stock_threshold\x18\a \x01(\x05R\x11lowStockThreshold\"\x9a\b\n" +
+	"\vOutboxEvent\x123\n" +
+	"\x02id\x18\x01 \x01(\tB#\x9a\x84\x9e\x03\x1ebson:\"_id,omitempty\" json:\"id\"R\x02id\x12?\n" +
+	"\bevent_id\x18\x02 \x01(\tB$\x9a\x84\x9e\x03\x1fbson:\"event_id\" json:\"event_id\"R\aeventId\x120\n" +
+	"\x04type\x18\x03 \x01(\tB\x1c\x9a\x84\x9e\x03\x17bson:\"type\" json:\"type\"R\x04type\x12W\n" +
+	"\ttenant_id\x18\x04 \x01(\tB:\x9a\x84\x9e\x035bson:\"tenant_id,omitempty\" json:\"tenant_id,omitempty\"R\btenantId\x12D\n" +
+	"\taggregate\x18\x05 \x01(\tB&\x9a\x84\x9e\x03!bson:\"aggregate\" json:\"aggregate\"R\taggregate\x12O\n" +
+	"\faggregate_id\x18\x06 \x01(\tB,\x9a\x84\x9e\x03'bson:\"aggregate_id\" json:\"aggregate_id\"R\vaggregateId\x12<\n" +
+	"\apayload\x18\a \x01(\fB\"\x9a\x84\x9e\x03\x1dbson:\"payload\" json:\"payload\"R\apayload\x128\n" +
+	"\x06status\x18\b \x01(\tB \x9a\x84\x9e\x03\x1bbson:\"status\" json:\"status\"R\x06status\x12@\n" +
+	"\battempts\x18\t \x01(\x05B$\x9a\x84\x9e\x03\x1fbson:\"attempts\" json:\"attempts\"R\battempts\x12[\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tB<\x9a\x84\x9e\x037bson:\"last_error,omitempty\" json:\"last_error,omitempty\"R\tlastError\x12c\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampB(\x9a\x84\x9e\x03#bson:\"created_at\" json:\"created_at\"R\tcreatedAt\x12v\n" +
+	"\x0fnext_attempt_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampB2\x9a\x84\x9e\x03-bson:\"next_attempt_at\" json:\"next_attempt_at\"R\rnextAttemptAt\x12\x7f\n" +
+	"\fpublished_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampB@\x9a\x84\x9e\x03;bson:\"published_at,omitempty\" json:\"published_at,omitempty\"R\vpublishedAtB5Z3erp.localhost/internal/infra/model/event/v1;eventv1b\x06proto3"
+
+var (
+	file_event_v1_domain_event_proto_rawDescOnce sync.Once
+	file_event_v1_domain_event_proto_rawDescData []byte
+)
+
+func file_event_v1_domain_event_proto_rawDescGZIP() []byte {
+	file_event_v1_domain_event_proto_rawDescOnce.Do(func() {
+		file_event_v1_domain_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_v1_domain_event_proto_rawDesc), len(file_event_v1_domain_event_proto_rawDesc)))
+	})
+	return file_event_v1_domain_event_proto_rawDescData
+}
+
+var file_event_v1_domain_event_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_event_v1_domain_event_proto_goTypes = []any{
+	(*DomainEvent)(nil),           // 0: event.v1.DomainEvent
+	(*UserEvent)(nil),             // 1: event.v1.UserEvent
+	(*TenantEvent)(nil),           // 2: event.v1.TenantEvent
+	(*RoleEvent)(nil),             // 3: event.v1.RoleEvent
+	(*PermissionEvent)(nil),       // 4: event.v1.PermissionEvent
+	(*LoginEvent)(nil),            // 5: event.v1.LoginEvent
+	(*InventoryEvent)(nil),        // 6: event.v1.InventoryEvent
+	(*OutboxEvent)(nil),           // 7: event.v1.OutboxEvent
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_event_v1_domain_event_proto_depIdxs = []int32{
+	8,  // 0: event.v1.DomainEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: event.v1.DomainEvent.user:type_name -> event.v1.UserEvent
+	2,  // 2: event.v1.DomainEvent.tenant:type_name -> event.v1.TenantEvent
+	3,  // 3: event.v1.DomainEvent.role:type_name -> event.v1.RoleEvent
+	4,  // 4: event.v1.DomainEvent.permission:type_name -> event.v1.PermissionEvent
+	5,  // 5: event.v1.DomainEvent.login:type_name -> event.v1.LoginEvent
+	6,  // 6: event.v1.DomainEvent.inventory:type_name -> event.v1.InventoryEvent
+	8,  // 7: event.v1.OutboxEvent.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: event.v1.OutboxEvent.next_attempt_at:type_name -> google.protobuf.Timestamp
+	8,  // 9: event.v1.OutboxEvent.published_at:type_name -> google.protobuf.Timestamp
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_event_v1_domain_event_proto_init() }
+func file_event_v1_domain_event_proto_init() {
+	if File_event_v1_domain_event_proto != nil {
+		return
+	}
+	file_event_v1_domain_event_proto_msgTypes[0].OneofWrappers = []any{
+		(*DomainEvent_User)(nil),
+		(*DomainEvent_Tenant)(nil),
+		(*DomainEvent_Role)(nil),
+		(*DomainEvent_Permission)(nil),
+		(*DomainEvent_Login)(nil),
+		(*DomainEvent_Inventory)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_v1_domain_event_proto_rawDesc), len(file_event_v1_domain_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_event_v1_domain_event_proto_goTypes,
+		DependencyIndexes: file_event_v1_domain_event_proto_depIdxs,
+		MessageInfos:      file_event_v1_domain_event_proto_msgTypes,
+	}.Build()
+	File_event_v1_domain_event_proto = out.File
+	file_event_v1_domain_event_proto_goTypes = nil
+	file_event_v1_domain_event_proto_depIdxs = nil
+}
@@ -0,0 +1,99 @@
+// Package fieldmask applies the google.protobuf.FieldMask of partial updates to proto messages
+package fieldmask
+
+import (
+	"fmt"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// IsEmpty reports whether mask names no field, the update then replaces the whole message
+func IsEmpty(mask *fieldmaskpb.FieldMask) bool {
+	return len(mask.GetPaths()) == 0
+}
+
+// CheckPaths returns a restricted fields error naming the paths of mask that are neither one of allowed nor a
+// subfield of one, e.g. "profile.first_name" is allowed by "profile"
+func CheckPaths(mask *fieldmaskpb.FieldMask, allowed ...string) error {
+	restricted := []string{}
+	for _, path := range mask.GetPaths() {
+		if !isAllowed(path, allowed) {
+			restricted = append(restricted, path)
+		}
+	}
+	if len(restricted) > 0 {
+		return infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields, restricted...)
+	}
+	return nil
+}
+
+func isAllowed(path string, allowed []string) bool {
+	for _, field := range allowed {
+		if path == field || strings.HasPrefix(path, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply copies the fields of src named by the paths of mask to dst, the fields unset in src are cleared in dst.
+// Subfields are named with dots, e.g. "profile.first_name", repeated and map fields are replaced as a whole. dst
+// and src must be the same message type
+func Apply(dst, src proto.Message, mask *fieldmaskpb.FieldMask) error {
+	dstMessage, srcMessage := dst.ProtoReflect(), src.ProtoReflect()
+	if dstMessage.Descriptor().FullName() != srcMessage.Descriptor().FullName() {
+		return infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("cannot apply a field mask of %s to %s", srcMessage.Descriptor().FullName(), dstMessage.Descriptor().FullName()))
+	}
+	for _, path := range mask.GetPaths() {
+		if err := applyPath(dstMessage, srcMessage, strings.Split(path, ".")); err != nil {
+			return infra_error.Validation(infra_error.ValidationInvalidValue, "update_mask").WithError(fmt.Errorf("path %q: %w", path, err))
+		}
+	}
+	return nil
+}
+
+// applyPath copies the field of src at path to dst, creating the parent messages of dst on the way
+func applyPath(dst, src protoreflect.Message, path []string) error {
+	field := dst.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if field == nil {
+		return fmt.Errorf("unknown field %q", path[0])
+	}
+	if len(path) == 1 {
+		if src.Has(field) {
+			dst.Set(field, src.Get(field))
+		} else {
+			dst.Clear(field)
+		}
+		return nil
+	}
+	if field.Message() == nil || field.IsList() || field.IsMap() {
+		return fmt.Errorf("field %q has no subfields", path[0])
+	}
+	if !src.Has(field) && !dst.Has(field) {
+		// Nothing to clear
+		return checkPath(field.Message(), path[1:])
+	}
+	return applyPath(dst.Mutable(field).Message(), src.Get(field).Message(), path[1:])
+}
+
+// checkPath returns an error when path does not name a field of message
+func checkPath(message protoreflect.MessageDescriptor, path []string) error {
+	for i, name := range path {
+		field := message.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return fmt.Errorf("unknown field %q", name)
+		}
+		if i == len(path)-1 {
+			return nil
+		}
+		if field.Message() == nil || field.IsList() || field.IsMap() {
+			return fmt.Errorf("field %q has no subfields", name)
+		}
+		message = field.Message()
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package fieldmask
+
+import (
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestApply(t *testing.T) {
+	stored := &authv1.User{
+		Id:       "user-1",
+		Email:    "john@example.com",
+		Status:   authv1.UserStatus_USER_STATUS_ACTIVE,
+		Profile:  &authv1.UserProfile{FirstName: "John", LastName: "Doe", Phone: "+15550100"},
+		Roles:    []*authv1.UserRole{{RoleId: "role-1"}},
+		Version:  3,
+		Username: "john",
+	}
+	update := &authv1.User{
+		Email:   "ignored@example.com",
+		Profile: &authv1.UserProfile{FirstName: "Johnny"},
+	}
+
+	// Named fields are set, named fields unset in the update are cleared, the rest is unchanged
+	require.NoError(t, Apply(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"profile.first_name", "profile.phone", "roles"}}))
+	assert.Equal(t, "john@example.com", stored.GetEmail())
+	assert.True(t, proto.Equal(&authv1.UserProfile{FirstName: "Johnny", LastName: "Doe"}, stored.GetProfile()))
+	assert.Empty(t, stored.GetRoles())
+	assert.Equal(t, int64(3), stored.GetVersion())
+
+	// A whole message is replaced
+	require.NoError(t, Apply(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"profile"}}))
+	assert.True(t, proto.Equal(&authv1.UserProfile{FirstName: "Johnny"}, stored.GetProfile()))
+
+	// Clearing a subfield of an unset message leaves it unset
+	stored.Preferences = nil
+	require.NoError(t, Apply(stored, update, &fieldmaskpb.FieldMask{Paths: []string{"preferences.language"}}))
+	assert.Nil(t, stored.GetPreferences())
+
+	for _, path := range []string{"unknown", "email.value", "roles.role_id", "preferences.unknown"} {
+		err := Apply(stored, update, &fieldmaskpb.FieldMask{Paths: []string{path}})
+		assert.True(t, infra_error.Validation(infra_error.ValidationInvalidValue).Is(err), path)
+	}
+	assert.Error(t, Apply(stored, &authv1.Tenant{}, &fieldmaskpb.FieldMask{Paths: []string{"status"}}))
+}
+
+func TestCheckPaths(t *testing.T) {
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"profile.first_name", "roles"}}
+	assert.NoError(t, CheckPaths(mask, "profile", "roles"))
+	assert.NoError(t, CheckPaths(nil, "profile"))
+
+	err := CheckPaths(&fieldmaskpb.FieldMask{Paths: []string{"profile", "password_hash", "profiles"}}, "profile")
+	require.Error(t, err)
+	assert.True(t, infra_error.Validation(infra_error.ValidationTryToChangeRestrictedFields).Is(err))
+	assert.True(t, IsEmpty(nil))
+	assert.False(t, IsEmpty(mask))
+}
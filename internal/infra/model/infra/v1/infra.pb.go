@@ -7,6 +7,7 @@
 package infrav1
 
 import (
+	_ "github.com/srikrsna/protoc-gen-gotag/tagger"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -83,13 +84,15 @@ func (ErrorCategory) EnumDescriptor() ([]byte, []int) {
 	return file_infra_v1_infra_proto_rawDescGZIP(), []int{0}
 }
 
-// Common error response
+// Common error response, also attached to the status details of failed gRPC calls
 type Error struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                                                                                 // Error code (e.g., "AUTH_INVALID_CREDENTIALS")
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                                                           // User-friendly error message
 	Category      ErrorCategory          `protobuf:"varint,3,opt,name=category,proto3,enum=infra.v1.ErrorCategory" json:"category,omitempty"`                                            // Error category for handling
-	Details       map[string]string      `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Optional metadata/details
+	Details       map[string]string      `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Optional metadata/details, values are JSON encoded
+	ErrorCode     ErrorCode              `protobuf:"varint,5,opt,name=error_code,json=errorCode,proto3,enum=infra.v1.ErrorCode" json:"error_code,omitempty"`                             // Catalog code, UNSPECIFIED for codes outside the catalog
+	Retryable     bool                   `protobuf:"varint,6,opt,name=retryable,proto3" json:"retryable,omitempty"`                                                                      // The call may succeed when retried with backoff
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -152,6 +155,20 @@ func (x *Error) GetDetails() map[string]string {
 	return nil
 }
 
+func (x *Error) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *Error) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
 // Common response wrapper
 type Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -345,8 +362,8 @@ func (x *PaginationResponse) GetHasPrev() bool {
 
 type UserIdentifier struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty" validate:"required"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty" validate:"required"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -399,12 +416,15 @@ var File_infra_v1_infra_proto protoreflect.FileDescriptor
 
 const file_infra_v1_infra_proto_rawDesc = "" +
 	"\n" +
-	"\x14infra/v1/infra.proto\x12\binfra.v1\"\xde\x01\n" +
+	"\x14infra/v1/infra.proto\x12\binfra.v1\x1a\x1ainfra/v1/error_codes.proto\x1a\x13tagger/tagger.proto\"\xb0\x02\n" +
 	"\x05Error\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
 	"\bcategory\x18\x03 \x01(\x0e2\x17.infra.v1.ErrorCategoryR\bcategory\x126\n" +
-	"\adetails\x18\x04 \x03(\v2\x1c.infra.v1.Error.DetailsEntryR\adetails\x1a:\n" +
+	"\adetails\x18\x04 \x03(\v2\x1c.infra.v1.Error.DetailsEntryR\adetails\x122\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\x0e2\x13.infra.v1.ErrorCodeR\terrorCode\x12\x1c\n" +
+	"\tretryable\x18\x06 \x01(\bR\tretryable\x1a:\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"K\n" +
@@ -422,10 +442,10 @@ const file_infra_v1_infra_proto_rawDesc = "" +
 	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
 	"totalPages\x12\x19\n" +
 	"\bhas_next\x18\x05 \x01(\bR\ahasNext\x12\x19\n" +
-	"\bhas_prev\x18\x06 \x01(\bR\ahasPrev\"F\n" +
-	"\x0eUserIdentifier\x12\x1b\n" +
-	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId*\xdc\x01\n" +
+	"\bhas_prev\x18\x06 \x01(\bR\ahasPrev\"z\n" +
+	"\x0eUserIdentifier\x125\n" +
+	"\ttenant_id\x18\x01 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\btenantId\x121\n" +
+	"\auser_id\x18\x02 \x01(\tB\x18\x9a\x84\x9e\x03\x13validate:\"required\"R\x06userId*\xdc\x01\n" +
 	"\rErrorCategory\x12\x1e\n" +
 	"\x1aERROR_CATEGORY_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13ERROR_CATEGORY_AUTH\x10\x01\x12\x1d\n" +
@@ -457,16 +477,18 @@ var file_infra_v1_infra_proto_goTypes = []any{
 	(*PaginationResponse)(nil), // 4: infra.v1.PaginationResponse
 	(*UserIdentifier)(nil),     // 5: infra.v1.UserIdentifier
 	nil,                        // 6: infra.v1.Error.DetailsEntry
+	(ErrorCode)(0),             // 7: infra.v1.ErrorCode
 }
 var file_infra_v1_infra_proto_depIdxs = []int32{
 	0, // 0: infra.v1.Error.category:type_name -> infra.v1.ErrorCategory
 	6, // 1: infra.v1.Error.details:type_name -> infra.v1.Error.DetailsEntry
-	1, // 2: infra.v1.Response.error:type_name -> infra.v1.Error
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	7, // 2: infra.v1.Error.error_code:type_name -> infra.v1.ErrorCode
+	1, // 3: infra.v1.Response.error:type_name -> infra.v1.Error
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_infra_v1_infra_proto_init() }
@@ -474,6 +496,7 @@ func file_infra_v1_infra_proto_init() {
 	if File_infra_v1_infra_proto != nil {
 		return
 	}
+	file_infra_v1_error_codes_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
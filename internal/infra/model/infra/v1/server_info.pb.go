@@ -0,0 +1,238 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: infra/v1/server_info.proto
+
+package infrav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_infra_v1_server_info_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_infra_v1_server_info_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_infra_v1_server_info_proto_rawDescGZIP(), []int{0}
+}
+
+type GetServerInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Module of the service, e.g. Auth
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// Deployment environment, development, staging or production
+	Environment string `protobuf:"bytes,2,opt,name=environment,proto3" json:"environment,omitempty"`
+	// Version and VCS revision of the binary, see internal/infra/buildinfo
+	Version   string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Commit    string `protobuf:"bytes,4,opt,name=commit,proto3" json:"commit,omitempty"`
+	GoVersion string `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	// Debugging surfaces enabled on the server, e.g. reflection, channelz, verbose_errors
+	Features  []string               `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Build time of the binary, RFC 3339
+	BuildDate     string `protobuf:"bytes,8,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_infra_v1_server_info_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_infra_v1_server_info_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_infra_v1_server_info_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetServerInfoResponse) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *GetServerInfoResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetServerInfoResponse) GetBuildDate() string {
+	if x != nil {
+		return x.BuildDate
+	}
+	return ""
+}
+
+var File_infra_v1_server_info_proto protoreflect.FileDescriptor
+
+const file_infra_v1_server_info_proto_rawDesc = "" +
+	"\n" +
+	"\x1ainfra/v1/server_info.proto\x12\binfra.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x16\n" +
+	"\x14GetServerInfoRequest\"\x98\x02\n" +
+	"\x15GetServerInfoResponse\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12 \n" +
+	"\venvironment\x18\x02 \x01(\tR\venvironment\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x04 \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\tR\bfeatures\x129\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x1d\n" +
+	"\n" +
+	"build_date\x18\b \x01(\tR\tbuildDate2e\n" +
+	"\x11ServerInfoService\x12P\n" +
+	"\rGetServerInfo\x12\x1e.infra.v1.GetServerInfoRequest\x1a\x1f.infra.v1.GetServerInfoResponseB5Z3erp.localhost/internal/infra/model/infra/v1;infrav1b\x06proto3"
+
+var (
+	file_infra_v1_server_info_proto_rawDescOnce sync.Once
+	file_infra_v1_server_info_proto_rawDescData []byte
+)
+
+func file_infra_v1_server_info_proto_rawDescGZIP() []byte {
+	file_infra_v1_server_info_proto_rawDescOnce.Do(func() {
+		file_infra_v1_server_info_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_infra_v1_server_info_proto_rawDesc), len(file_infra_v1_server_info_proto_rawDesc)))
+	})
+	return file_infra_v1_server_info_proto_rawDescData
+}
+
+var file_infra_v1_server_info_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_infra_v1_server_info_proto_goTypes = []any{
+	(*GetServerInfoRequest)(nil),  // 0: infra.v1.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil), // 1: infra.v1.GetServerInfoResponse
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_infra_v1_server_info_proto_depIdxs = []int32{
+	2, // 0: infra.v1.GetServerInfoResponse.started_at:type_name -> google.protobuf.Timestamp
+	0, // 1: infra.v1.ServerInfoService.GetServerInfo:input_type -> infra.v1.GetServerInfoRequest
+	1, // 2: infra.v1.ServerInfoService.GetServerInfo:output_type -> infra.v1.GetServerInfoResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_infra_v1_server_info_proto_init() }
+func file_infra_v1_server_info_proto_init() {
+	if File_infra_v1_server_info_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_infra_v1_server_info_proto_rawDesc), len(file_infra_v1_server_info_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_infra_v1_server_info_proto_goTypes,
+		DependencyIndexes: file_infra_v1_server_info_proto_depIdxs,
+		MessageInfos:      file_infra_v1_server_info_proto_msgTypes,
+	}.Build()
+	File_infra_v1_server_info_proto = out.File
+	file_infra_v1_server_info_proto_goTypes = nil
+	file_infra_v1_server_info_proto_depIdxs = nil
+}
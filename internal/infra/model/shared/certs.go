@@ -19,8 +19,9 @@ type Certs struct {
 	Key    string `bson:"key" json:"key"`
 }
 
+// NewCerts returns the certificates in the resources/certs directory of the service of the caller, see the certs
+// target of the service Makefile
 func NewCerts() *Certs {
-	return nil
 	// 1. Get absolute path of the current file's directory
 	_, filename, _, ok := runtime.Caller(1) // get the file of the function who called this function ("NewCerts")
 	if !ok {
@@ -30,11 +31,15 @@ func NewCerts() *Certs {
 	if err != nil {
 		return nil
 	}
-	certsPath := fmt.Sprintf("%s/../resources/certs", relativePath)
+	return NewCertsFromDir(fmt.Sprintf("%s/../resources/certs", relativePath))
+}
+
+// NewCertsFromDir returns the certificates in dir, e.g. a mounted secret
+func NewCertsFromDir(dir string) *Certs {
 	return &Certs{
-		CACert: fmt.Sprintf("%s/%s", certsPath, CACertName),
-		Cert:   fmt.Sprintf("%s/%s", certsPath, CertName),
-		Key:    fmt.Sprintf("%s/%s", certsPath, KeyName),
+		CACert: filepath.Join(dir, CACertName),
+		Cert:   filepath.Join(dir, CertName),
+		Key:    filepath.Join(dir, KeyName),
 	}
 }
 
@@ -30,3 +30,15 @@ func IsValidModule(module string) bool {
 	}
 	return validModules[strings.ToLower(module)]
 }
+
+// ParseModule returns the module of a case insensitive name
+func ParseModule(name string) (Module, bool) {
+	for _, module := range []Module{
+		ModuleAuth, ModuleConfig, ModuleCore, ModuleDB, ModuleEvent, ModuleGateway, ModuleInit, ModuleSidecar, ModuleWebUI,
+	} {
+		if strings.EqualFold(string(module), name) {
+			return module, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,135 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// Dispatcher renders event templates and delivers them on every channel the recipient opted in to
+type Dispatcher struct {
+	logger    logger.Logger
+	templates *TemplateRegistry
+	mu        sync.RWMutex
+	notifiers map[Channel]Notifier
+	// Events delivered regardless of the recipient preferences
+	mandatory map[Event]bool
+}
+
+func NewDispatcher(templates *TemplateRegistry, logger logger.Logger) *Dispatcher {
+	if templates == nil {
+		templates = NewTemplateRegistry()
+	}
+	return &Dispatcher{
+		logger:    logger,
+		templates: templates,
+		notifiers: map[Channel]Notifier{},
+		mandatory: map[Event]bool{
+			EventEmailVerify: true,
+			EventLoginLink:   true,
+		},
+	}
+}
+
+// NewDispatcherFromEnv creates a dispatcher with the SMTP and webhook notifiers configured in the environment
+func NewDispatcherFromEnv(logger logger.Logger) *Dispatcher {
+	dispatcher := NewDispatcher(nil, logger)
+	if config := LoadSMTPConfig(); config != nil {
+		smtpNotifier, err := NewSMTPNotifier(config)
+		if err != nil {
+			logger.Warn("failed to create smtp notifier", "error", err)
+		} else {
+			dispatcher.Register(smtpNotifier)
+		}
+	}
+	for _, notifier := range LoadWebhookNotifiers() {
+		dispatcher.Register(notifier)
+	}
+	return dispatcher
+}
+
+// Register adds a notifier, replacing any notifier already registered for its channel
+func (d *Dispatcher) Register(notifier Notifier) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.notifiers[notifier.Channel()] = notifier
+	d.logger.Info("notifier registered", "channel", notifier.Channel())
+}
+
+// Notifier returns the notifier registered for a channel
+func (d *Dispatcher) Notifier(channel Channel) (Notifier, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	notifier, ok := d.notifiers[channel]
+	return notifier, ok
+}
+
+// Templates returns the template registry, used to override default templates
+func (d *Dispatcher) Templates() *TemplateRegistry {
+	return d.templates
+}
+
+// Notify delivers an event to the recipient on every channel it opted in to and that has a notifier.
+// Errors of individual channels are joined, a channel failure does not stop the others.
+func (d *Dispatcher) Notify(recipient *Recipient, event Event, data map[string]any) error {
+	if recipient == nil {
+		return errors.New("missing notification recipient")
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+	if _, ok := data["Time"]; !ok {
+		data["Time"] = time.Now().UTC().Format(time.RFC1123)
+	}
+
+	subject, body, err := d.templates.Render(event, data)
+	if err != nil {
+		d.logger.Error("failed to render notification", "event", event, "error", err)
+		return err
+	}
+
+	var errs []error
+	for _, channel := range d.channelsFor(recipient, event) {
+		notifier, ok := d.Notifier(channel)
+		if !ok {
+			continue
+		}
+		msg := &Message{
+			Channel:  channel,
+			Event:    event,
+			To:       recipient.Address(channel),
+			Subject:  subject,
+			Body:     body,
+			TenantID: recipient.TenantID,
+			UserID:   recipient.UserID,
+		}
+		if err := notifier.Send(msg); err != nil {
+			d.logger.Error("failed to send notification", "tenant_id", recipient.TenantID, "user_id", recipient.UserID, "event", event, "channel", channel, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+			continue
+		}
+		d.logger.Debug("notification sent", "tenant_id", recipient.TenantID, "user_id", recipient.UserID, "event", event, "channel", channel)
+	}
+	return errors.Join(errs...)
+}
+
+// channelsFor returns the channels an event should be delivered on for the recipient
+func (d *Dispatcher) channelsFor(recipient *Recipient, event Event) []Channel {
+	channels := []Channel{}
+	for _, channel := range []Channel{ChannelEmail, ChannelSMS, ChannelPush} {
+		if recipient.Address(channel) == "" {
+			continue
+		}
+		if d.mandatory[event] && channel == ChannelEmail {
+			channels = append(channels, channel)
+			continue
+		}
+		if recipient.Channels[channel] {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
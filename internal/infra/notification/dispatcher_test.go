@@ -0,0 +1,149 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	shared "erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	channel Channel
+	sent    []*Message
+	err     error
+}
+
+func (r *recordingNotifier) Channel() Channel {
+	return r.channel
+}
+
+func (r *recordingNotifier) Send(msg *Message) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, msg)
+	return nil
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	user := &authv1.User{
+		Id:       "user-1",
+		TenantId: "tenant-1",
+		Email:    "user@erp.localhost",
+		Profile:  &authv1.UserProfile{Phone: "+972500000000"},
+	}
+
+	testCases := []struct {
+		name          string
+		settings      *authv1.NotificationSettings
+		event         Event
+		expectedEmail int
+		expectedSMS   int
+		expectedPush  int
+	}{
+		{
+			name:     "no preferences sends nothing",
+			settings: nil,
+			event:    EventNewLogin,
+		},
+		{
+			name:          "email only",
+			settings:      &authv1.NotificationSettings{Email: true},
+			event:         EventMFAEnabled,
+			expectedEmail: 1,
+		},
+		{
+			name:          "all channels",
+			settings:      &authv1.NotificationSettings{Email: true, Sms: true, Push: true},
+			event:         EventPasswordChanged,
+			expectedEmail: 1,
+			expectedSMS:   1,
+			expectedPush:  1,
+		},
+		{
+			name:          "mandatory event ignores email preference",
+			settings:      &authv1.NotificationSettings{Sms: true},
+			event:         EventEmailVerify,
+			expectedEmail: 1,
+			expectedSMS:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			email := &recordingNotifier{channel: ChannelEmail}
+			sms := &recordingNotifier{channel: ChannelSMS}
+			push := &recordingNotifier{channel: ChannelPush}
+			dispatcher := NewDispatcher(nil, logger.NewBaseLogger(shared.ModuleAuth))
+			dispatcher.Register(email)
+			dispatcher.Register(sms)
+			dispatcher.Register(push)
+
+			u := &authv1.User{
+				Id:          user.Id,
+				TenantId:    user.TenantId,
+				Email:       user.Email,
+				Profile:     user.Profile,
+				Preferences: &authv1.UserPreferences{Notifications: tc.settings},
+			}
+			err := dispatcher.Notify(RecipientFromUser(u), tc.event, map[string]any{"Link": "https://erp.localhost/verify"})
+			require.NoError(t, err)
+
+			assert.Len(t, email.sent, tc.expectedEmail)
+			assert.Len(t, sms.sent, tc.expectedSMS)
+			assert.Len(t, push.sent, tc.expectedPush)
+			for _, msg := range email.sent {
+				assert.Equal(t, user.Email, msg.To)
+				assert.NotEmpty(t, msg.Subject)
+			}
+			for _, msg := range sms.sent {
+				assert.Equal(t, user.Profile.Phone, msg.To)
+			}
+			for _, msg := range push.sent {
+				assert.Equal(t, user.Id, msg.To)
+			}
+		})
+	}
+}
+
+func TestDispatcher_NotifyContinuesAfterChannelFailure(t *testing.T) {
+	sendErr := errors.New("smtp down")
+	email := &recordingNotifier{channel: ChannelEmail, err: sendErr}
+	sms := &recordingNotifier{channel: ChannelSMS}
+	dispatcher := NewDispatcher(nil, logger.NewBaseLogger(shared.ModuleAuth))
+	dispatcher.Register(email)
+	dispatcher.Register(sms)
+
+	recipient := &Recipient{
+		UserID:   "user-1",
+		Email:    "user@erp.localhost",
+		Phone:    "+972500000000",
+		Channels: map[Channel]bool{ChannelEmail: true, ChannelSMS: true},
+	}
+	err := dispatcher.Notify(recipient, EventNewLogin, nil)
+
+	assert.ErrorIs(t, err, sendErr)
+	assert.Len(t, sms.sent, 1)
+}
+
+func TestTemplateRegistry_Render(t *testing.T) {
+	registry := NewTemplateRegistry()
+
+	subject, body, err := registry.Render(EventNewLogin, map[string]any{"Time": "now", "IPAddress": "10.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "New sign-in to your account", subject)
+	assert.Contains(t, body, "from 10.0.0.1")
+
+	require.NoError(t, registry.Register(EventNewLogin, "Hi {{.Name}}", "custom"))
+	subject, body, err = registry.Render(EventNewLogin, map[string]any{"Name": "Dana"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hi Dana", subject)
+	assert.Equal(t, "custom", body)
+
+	_, _, err = registry.Render(Event("unknown"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+// Package notification delivers user facing notifications (email, SMS, push) through pluggable notifiers.
+package notification
+
+import (
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// Channel is a delivery channel a user can opt in or out of in NotificationSettings
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+	ChannelPush  Channel = "push"
+)
+
+// Event identifies the template used for a notification
+type Event string
+
+const (
+	EventPasswordChanged Event = "password_changed"
+	EventNewLogin        Event = "new_login"
+	EventMFAEnabled      Event = "mfa_enabled"
+	EventMFADisabled     Event = "mfa_disabled"
+	EventEmailVerify     Event = "email_verify"
+	EventSuspiciousLogin Event = "suspicious_login"
+	// EventLoginLink carries a magic link logging the user in without their password
+	EventLoginLink Event = "login_link"
+	// EventAccountDeletionScheduled is sent when a user asks to delete their account
+	EventAccountDeletionScheduled Event = "account_deletion_scheduled"
+)
+
+// Message is a rendered notification ready to be delivered
+type Message struct {
+	Channel Channel `json:"channel"`
+	Event   Event   `json:"event"`
+	// Address on the channel: email address, phone number or user id for push
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	// Tenant and user the message belongs to, used by notifiers that forward metadata
+	TenantID string `json:"tenant_id"`
+	UserID   string `json:"user_id"`
+}
+
+// Notifier delivers messages on a single channel
+type Notifier interface {
+	Channel() Channel
+	Send(msg *Message) error
+}
+
+// Recipient holds the addresses and channel preferences of a notification target
+type Recipient struct {
+	TenantID string
+	UserID   string
+	Email    string
+	Phone    string
+	// Channels the recipient opted in to
+	Channels map[Channel]bool
+}
+
+// Address returns the recipient address on the given channel
+func (r *Recipient) Address(channel Channel) string {
+	switch channel {
+	case ChannelEmail:
+		return r.Email
+	case ChannelSMS:
+		return r.Phone
+	case ChannelPush:
+		return r.UserID
+	}
+	return ""
+}
+
+// RecipientFromUser builds a recipient from a user and its notification preferences
+func RecipientFromUser(user *authv1.User) *Recipient {
+	settings := user.GetPreferences().GetNotifications()
+	return &Recipient{
+		TenantID: user.GetTenantId(),
+		UserID:   user.GetId(),
+		Email:    user.GetEmail(),
+		Phone:    user.GetProfile().GetPhone(),
+		Channels: map[Channel]bool{
+			ChannelEmail: settings.GetEmail(),
+			ChannelSMS:   settings.GetSms(),
+			ChannelPush:  settings.GetPush(),
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// LoadSMTPConfig loads SMTP configuration from environment variables, nil when SMTP_HOST is not set
+func LoadSMTPConfig() *SMTPConfig {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil
+	}
+	return &SMTPConfig{
+		Host:     host,
+		Port:     getEnv("SMTP_PORT", "587"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     getEnv("SMTP_FROM", "no-reply@erp.localhost"),
+	}
+}
+
+// SMTPNotifier delivers email notifications through an SMTP relay
+type SMTPNotifier struct {
+	config   *SMTPConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPNotifier(config *SMTPConfig) (*SMTPNotifier, error) {
+	if config == nil || config.Host == "" || config.From == "" {
+		return nil, errors.New("smtp notifier requires host and from address")
+	}
+	return &SMTPNotifier{
+		config:   config,
+		sendMail: smtp.SendMail,
+	}, nil
+}
+
+func (s *SMTPNotifier) Channel() Channel {
+	return ChannelEmail
+}
+
+func (s *SMTPNotifier) Send(msg *Message) error {
+	return s.SendEmail(msg.To, msg.Subject, msg.Body)
+}
+
+// SendEmail sends a plain text email, it also makes SMTPNotifier usable wherever a bare email sender is expected
+func (s *SMTPNotifier) SendEmail(to, subject, body string) error {
+	if to == "" {
+		return errors.New("missing email recipient")
+	}
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+	addr := net.JoinHostPort(s.config.Host, s.config.Port)
+	if err := s.sendMail(addr, auth, s.config.From, []string{to}, buildMIMEMessage(s.config.From, to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+func buildMIMEMessage(from, to, subject, body string) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + to + "\r\n")
+	sb.WriteString("Subject: " + sanitizeHeader(subject) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(sb.String())
+}
+
+// sanitizeHeader prevents header injection through templated values
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,119 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"text/template"
+)
+
+// Template renders the subject and body of an event
+type Template struct {
+	Subject *template.Template
+	Body    *template.Template
+}
+
+// NewTemplate parses a subject and body template
+func NewTemplate(event Event, subject, body string) (*Template, error) {
+	subjectTmpl, err := template.New(string(event) + "_subject").Option("missingkey=zero").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s subject template: %w", event, err)
+	}
+	bodyTmpl, err := template.New(string(event) + "_body").Option("missingkey=zero").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s body template: %w", event, err)
+	}
+	return &Template{Subject: subjectTmpl, Body: bodyTmpl}, nil
+}
+
+// Render executes the templates with data
+func (t *Template) Render(data map[string]any) (string, string, error) {
+	var subject, body bytes.Buffer
+	if err := t.Subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := t.Body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
+
+// TemplateRegistry maps events to templates
+type TemplateRegistry struct {
+	mu        sync.RWMutex
+	templates map[Event]*Template
+}
+
+// NewTemplateRegistry returns a registry loaded with the default templates
+func NewTemplateRegistry() *TemplateRegistry {
+	registry := &TemplateRegistry{
+		templates: map[Event]*Template{},
+	}
+	for event, tmpl := range defaultTemplates {
+		if err := registry.Register(event, tmpl.subject, tmpl.body); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// Register adds or replaces the template of an event
+func (r *TemplateRegistry) Register(event Event, subject, body string) error {
+	tmpl, err := NewTemplate(event, subject, body)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[event] = tmpl
+	return nil
+}
+
+// Render renders the template of an event
+func (r *TemplateRegistry) Render(event Event, data map[string]any) (string, string, error) {
+	r.mu.RLock()
+	tmpl, ok := r.templates[event]
+	r.mu.RUnlock()
+	if !ok {
+		return "", "", fmt.Errorf("no template registered for event %s", event)
+	}
+	return tmpl.Render(data)
+}
+
+var defaultTemplates = map[Event]struct {
+	subject string
+	body    string
+}{
+	EventPasswordChanged: {
+		subject: "Your password was changed",
+		body:    "The password of your account was changed at {{.Time}}. If this was not you, contact your administrator immediately.",
+	},
+	EventNewLogin: {
+		subject: "New sign-in to your account",
+		body:    "A new sign-in to your account was detected at {{.Time}}{{if .IPAddress}} from {{.IPAddress}}{{end}}. If this was not you, change your password.",
+	},
+	EventMFAEnabled: {
+		subject: "Two-factor authentication enabled",
+		body:    "Two-factor authentication was enabled on your account at {{.Time}}.",
+	},
+	EventMFADisabled: {
+		subject: "Two-factor authentication disabled",
+		body:    "Two-factor authentication was disabled on your account at {{.Time}}. If this was not you, contact your administrator immediately.",
+	},
+	EventEmailVerify: {
+		subject: "Verify your email address",
+		body:    "Please confirm your email address by opening the following link:\n\n{{.Link}}\n\nThe link expires in {{.ExpiresIn}}.",
+	},
+	EventSuspiciousLogin: {
+		subject: "Sign-in from a new device",
+		body:    "Your account was signed in to from a device or location not seen before at {{.Time}}{{if .IPAddress}} from {{.IPAddress}}{{end}}. If this was not you, change your password and contact your administrator.",
+	},
+	EventLoginLink: {
+		subject: "Your sign-in link",
+		body:    "Open the following link to sign in to your account:\n\n{{.Link}}\n\nThe link expires in {{.ExpiresIn}} and can be used once. If you did not ask for it, ignore this email.",
+	},
+	EventAccountDeletionScheduled: {
+		subject: "Your account will be deleted",
+		body:    "Your account is scheduled for deletion on {{.DeletionDate}}. Sign in before then to keep it.",
+	},
+}
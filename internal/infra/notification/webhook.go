@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookNotifier posts messages as JSON to an HTTP endpoint, typically an SMS or push provider bridge
+type WebhookNotifier struct {
+	channel Channel
+	url     string
+	client  *http.Client
+	headers map[string]string
+}
+
+func NewWebhookNotifier(channel Channel, url string, headers map[string]string) (*WebhookNotifier, error) {
+	if channel == "" || url == "" {
+		return nil, errors.New("webhook notifier requires channel and url")
+	}
+	return &WebhookNotifier{
+		channel: channel,
+		url:     url,
+		client:  &http.Client{Timeout: defaultWebhookTimeout},
+		headers: headers,
+	}, nil
+}
+
+// LoadWebhookNotifiers creates webhook notifiers from NOTIFICATION_SMS_WEBHOOK_URL and NOTIFICATION_PUSH_WEBHOOK_URL.
+// NOTIFICATION_WEBHOOK_TOKEN is sent as a bearer token when set.
+func LoadWebhookNotifiers() []Notifier {
+	headers := map[string]string{}
+	if token := os.Getenv("NOTIFICATION_WEBHOOK_TOKEN"); token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	notifiers := []Notifier{}
+	for channel, env := range map[Channel]string{
+		ChannelSMS:  "NOTIFICATION_SMS_WEBHOOK_URL",
+		ChannelPush: "NOTIFICATION_PUSH_WEBHOOK_URL",
+	} {
+		if url := os.Getenv(env); url != "" {
+			notifier, err := NewWebhookNotifier(channel, url, headers)
+			if err == nil {
+				notifiers = append(notifiers, notifier)
+			}
+		}
+	}
+	return notifiers
+}
+
+func (w *WebhookNotifier) Channel() Channel {
+	return w.channel
+}
+
+func (w *WebhookNotifier) Send(msg *Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call notification webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
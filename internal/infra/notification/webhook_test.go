@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var received Message
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(ChannelSMS, server.URL, map[string]string{"Authorization": "Bearer secret"})
+	require.NoError(t, err)
+
+	err = notifier.Send(&Message{Channel: ChannelSMS, Event: EventNewLogin, To: "+972500000000", Body: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, "+972500000000", received.To)
+	assert.Equal(t, EventNewLogin, received.Event)
+}
+
+func TestWebhookNotifier_SendFailureStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(ChannelPush, server.URL, nil)
+	require.NoError(t, err)
+
+	assert.Error(t, notifier.Send(&Message{Channel: ChannelPush, To: "user-1"}))
+}
+
+func TestSMTPNotifier_SendEmail(t *testing.T) {
+	notifier, err := NewSMTPNotifier(&SMTPConfig{Host: "smtp.erp.localhost", Port: "25", From: "no-reply@erp.localhost"})
+	require.NoError(t, err)
+
+	var addr string
+	var msg []byte
+	notifier.sendMail = func(a string, _ smtp.Auth, from string, to []string, m []byte) error {
+		addr = a
+		msg = m
+		return nil
+	}
+
+	require.NoError(t, notifier.SendEmail("user@erp.localhost", "Hello\r\nBcc: evil@erp.localhost", "line1\nline2"))
+	assert.Equal(t, "smtp.erp.localhost:25", addr)
+	assert.Contains(t, string(msg), "Subject: Hello  Bcc: evil@erp.localhost\r\n")
+	assert.Contains(t, string(msg), "line1\r\nline2")
+}
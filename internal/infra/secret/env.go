@@ -0,0 +1,30 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// EnvProvider reads secrets from the environment variables of the same name. The environment of a process does
+// not change once it started, rotation callbacks only fire for values changed with os.Setenv
+type EnvProvider struct {
+	*watcher
+}
+
+// NewEnvProvider creates a provider over the environment of the process
+func NewEnvProvider(pollInterval time.Duration, logger logger.Logger) *EnvProvider {
+	p := &EnvProvider{}
+	p.watcher = newWatcher(p.Get, pollInterval, logger)
+	return p
+}
+
+// Get returns the value of the environment variable name, empty variables are missing
+func (p *EnvProvider) Get(ctx context.Context, name string) (string, error) {
+	if value := os.Getenv(name); value != "" {
+		return value, nil
+	}
+	return "", notFound(name)
+}
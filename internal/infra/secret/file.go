@@ -0,0 +1,43 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// FileProvider reads each secret from the file of the same name in a directory, the layout of Docker and
+// Kubernetes mounted secrets. Kubernetes updates mounted secrets in place, so rotations are picked up
+type FileProvider struct {
+	*watcher
+	dir string
+}
+
+// NewFileProvider creates a provider over the files of dir
+func NewFileProvider(dir string, pollInterval time.Duration, logger logger.Logger) *FileProvider {
+	p := &FileProvider{dir: dir}
+	p.watcher = newWatcher(p.Get, pollInterval, logger)
+	return p
+}
+
+// Get returns the content of the file name, without its trailing newline
+func (p *FileProvider) Get(ctx context.Context, name string) (string, error) {
+	if name == "" || name != filepath.Base(name) {
+		return "", infra_error.Validation(infra_error.ValidationInvalidValue, "name")
+	}
+	content, err := os.ReadFile(filepath.Join(p.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", notFound(name)
+	}
+	if err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
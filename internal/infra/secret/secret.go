@@ -0,0 +1,230 @@
+// Package secret reads credentials such as the JWT signing key and database passwords from a secret store,
+// so they are not passed around as plain configuration. Providers poll the store and call the rotation
+// callbacks of a secret when its value changes, for components that can reload their credentials.
+package secret
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+//go:generate mockgen -destination=mock/mock_secret.go -package=mock erp.localhost/internal/infra/secret SecretProvider
+
+// Names of the secrets read by the services
+const (
+	JWTSecretKey           = "JWT_SECRET_KEY"
+	MongoURI               = "MONGO_URI"
+	VerificationCodeSecret = "VERIFICATION_CODE_SECRET"
+)
+
+// Kind selects the store of a provider
+type Kind string
+
+const (
+	// KindEnv reads secrets from environment variables of the same name
+	KindEnv Kind = "env"
+	// KindFile reads secrets from files named after them, e.g. Docker and Kubernetes mounted secrets
+	KindFile Kind = "file"
+	// KindVault reads secrets from the keys of a HashiCorp Vault KV v2 secret
+	KindVault Kind = "vault"
+)
+
+// DefaultPollInterval is how often rotated secrets are detected when SECRETS_POLL_INTERVAL is not set
+const DefaultPollInterval = 30 * time.Second
+
+// SecretProvider reads secrets from a secret store
+type SecretProvider interface {
+	// Get returns the current value of the secret, a not found error when the store does not have it
+	Get(ctx context.Context, name string) (string, error)
+	// OnRotate registers fn, called with the new value every time the value of the secret changes
+	OnRotate(name string, fn func(value string))
+	// Close stops the rotation polling
+	Close() error
+}
+
+// Config selects and configures the provider of a service, loaded with infra_config.Load
+type Config struct {
+	Kind         Kind          `yaml:"provider" env:"SECRETS_PROVIDER" flag:"secrets-provider" default:"env"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"SECRETS_POLL_INTERVAL" default:"30s" validate:"positive"`
+	// Directory of the secret files of the file provider
+	Dir   string      `yaml:"dir" env:"SECRETS_DIR" default:"/run/secrets"`
+	Vault VaultConfig `yaml:"vault"`
+}
+
+// Validate checks the settings of the selected provider are complete
+func (c *Config) Validate() error {
+	switch c.Kind {
+	case KindEnv:
+	case KindFile:
+		if c.Dir == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "SECRETS_DIR")
+		}
+	case KindVault:
+		if c.Vault.Address == "" || c.Vault.Token == "" {
+			return infra_error.Validation(infra_error.ValidationRequiredFields, "VAULT_ADDR", "VAULT_TOKEN")
+		}
+	default:
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "SECRETS_PROVIDER").
+			WithError(fmt.Errorf("unknown secrets provider %q", c.Kind))
+	}
+	return nil
+}
+
+// New creates the provider selected by config
+func New(config *Config, logger logger.Logger) (SecretProvider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	switch config.Kind {
+	case KindFile:
+		return NewFileProvider(config.Dir, config.PollInterval, logger), nil
+	case KindVault:
+		return NewVaultProvider(&config.Vault, config.PollInterval, logger), nil
+	default:
+		return NewEnvProvider(config.PollInterval, logger), nil
+	}
+}
+
+// Lookup returns the value of the secret and whether the store has it, other errors are logged and reported as
+// missing so callers fall back to their configured value
+func Lookup(ctx context.Context, provider SecretProvider, name string, logger logger.Logger) (string, bool) {
+	value, err := provider.Get(ctx, name)
+	if err == nil {
+		return value, true
+	}
+	if !infra_error.IsCategory(err, infra_error.CategoryNotFound) {
+		logger.Warn("failed to read secret", "secret", name, "error", err)
+	}
+	return "", false
+}
+
+// notFound is returned by the providers for secrets missing from their store
+func notFound(name string) error {
+	return infra_error.NotFound(infra_error.NotFoundResource, "secret", name)
+}
+
+// watcher polls the secrets with rotation callbacks and calls them when a value changes. Providers embed it and
+// give it their Get
+type watcher struct {
+	get      func(ctx context.Context, name string) (string, error)
+	interval time.Duration
+	logger   logger.Logger
+
+	mu        sync.Mutex
+	callbacks map[string][]func(string)
+	values    map[string]string
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func newWatcher(get func(context.Context, string) (string, error), interval time.Duration, logger logger.Logger) *watcher {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &watcher{
+		get:       get,
+		interval:  interval,
+		logger:    logger,
+		callbacks: make(map[string][]func(string)),
+		values:    make(map[string]string),
+	}
+}
+
+// OnRotate registers fn for name, the polling starts with the first callback
+func (w *watcher) OnRotate(name string, fn func(value string)) {
+	w.mu.Lock()
+	_, watched := w.values[name]
+	w.mu.Unlock()
+
+	// The value at registration is the baseline, fn is only called for later changes
+	var value string
+	if !watched {
+		ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+		current, err := w.get(ctx, name)
+		cancel()
+		if err != nil {
+			w.logger.Debug("secret not readable at registration", "secret", name, "error", err)
+		}
+		value = current
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.values[name]; !ok {
+		w.values[name] = value
+	}
+	w.callbacks[name] = append(w.callbacks[name], fn)
+	if w.stop == nil {
+		w.stop = make(chan struct{})
+		w.done = make(chan struct{})
+		go w.run(w.stop, w.done)
+	}
+}
+
+func (w *watcher) run(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll reads every watched secret and calls the callbacks of the changed ones. A secret that cannot be read keeps
+// its last value, the store may be briefly unavailable
+func (w *watcher) poll() {
+	w.mu.Lock()
+	names := make([]string, 0, len(w.values))
+	for name := range w.values {
+		names = append(names, name)
+	}
+	w.mu.Unlock()
+
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+		value, err := w.get(ctx, name)
+		cancel()
+		if err != nil {
+			if !infra_error.IsCategory(err, infra_error.CategoryNotFound) {
+				w.logger.Warn("failed to poll secret", "secret", name, "error", err)
+			}
+			continue
+		}
+
+		w.mu.Lock()
+		changed := w.values[name] != value
+		w.values[name] = value
+		callbacks := append([]func(string){}, w.callbacks[name]...)
+		w.mu.Unlock()
+
+		if changed {
+			w.logger.Info("secret rotated", "secret", name)
+			for _, fn := range callbacks {
+				fn(value)
+			}
+		}
+	}
+}
+
+// Close stops the polling and waits for a running poll to finish
+func (w *watcher) Close() error {
+	w.mu.Lock()
+	stop, done := w.stop, w.done
+	w.stop = nil
+	w.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "env", config: Config{Kind: KindEnv}},
+		{name: "file", config: Config{Kind: KindFile, Dir: "/run/secrets"}},
+		{name: "file without directory", config: Config{Kind: KindFile}, wantErr: true},
+		{name: "vault", config: Config{Kind: KindVault, Vault: VaultConfig{Address: "http://vault:8200", Token: "token"}}},
+		{name: "vault without token", config: Config{Kind: KindVault, Vault: VaultConfig{Address: "http://vault:8200"}}, wantErr: true},
+		{name: "unknown provider", config: Config{Kind: "aws"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEnvProvider_Get(t *testing.T) {
+	t.Setenv("TEST_SECRET", "value")
+	t.Setenv("TEST_EMPTY_SECRET", "")
+	p := NewEnvProvider(time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+
+	value, err := p.Get(context.Background(), "TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = p.Get(context.Background(), "TEST_EMPTY_SECRET")
+	assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+}
+
+func TestFileProvider_Get(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "JWT_SECRET_KEY"), []byte("from-file\n"), 0o600))
+	p := NewFileProvider(dir, time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+
+	testCases := []struct {
+		name         string
+		secret       string
+		want         string
+		wantNotFound bool
+		wantErr      bool
+	}{
+		{name: "trailing newline is trimmed", secret: "JWT_SECRET_KEY", want: "from-file"},
+		{name: "missing file", secret: "MONGO_URI", wantNotFound: true},
+		{name: "path outside the directory", secret: "../JWT_SECRET_KEY", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := p.Get(context.Background(), tc.secret)
+			switch {
+			case tc.wantNotFound:
+				assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+			case tc.wantErr:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, value)
+			}
+		})
+	}
+}
+
+func TestWatcher_OnRotate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "JWT_SECRET_KEY")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+	p := NewFileProvider(dir, 10*time.Millisecond, logger.NewBaseLogger(shared.ModuleAuth))
+	defer p.Close()
+
+	var mu sync.Mutex
+	var rotated []string
+	p.OnRotate("JWT_SECRET_KEY", func(value string) {
+		mu.Lock()
+		defer mu.Unlock()
+		rotated = append(rotated, value)
+	})
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), rotated...)
+	}
+
+	// The value at registration is not a rotation
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, received())
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	assert.Eventually(t, func() bool { return len(received()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"second"}, received())
+
+	// An unreadable secret keeps its last value
+	require.NoError(t, os.Remove(path))
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, received(), 1)
+
+	require.NoError(t, p.Close())
+	require.NoError(t, os.WriteFile(path, []byte("third"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, received(), 1)
+}
+
+func TestLookup(t *testing.T) {
+	t.Setenv("TEST_SECRET", "value")
+	p := NewEnvProvider(time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+
+	value, ok := Lookup(context.Background(), p, "TEST_SECRET", logger.NewBaseLogger(shared.ModuleAuth))
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+
+	_, ok = Lookup(context.Background(), p, "TEST_MISSING_SECRET", logger.NewBaseLogger(shared.ModuleAuth))
+	assert.False(t, ok)
+}
@@ -0,0 +1,96 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// VaultConfig holds the settings of the Vault provider, secrets are the keys of one KV v2 secret
+type VaultConfig struct {
+	// Address of the server, e.g. https://vault.example.com:8200
+	Address   string `yaml:"address" env:"VAULT_ADDR"`
+	Token     string `yaml:"token" env:"VAULT_TOKEN"`
+	Namespace string `yaml:"namespace" env:"VAULT_NAMESPACE"`
+	// Mount of the KV v2 engine and path of the secret holding the keys
+	Mount   string        `yaml:"mount" env:"VAULT_MOUNT" default:"secret"`
+	Path    string        `yaml:"path" env:"VAULT_PATH" default:"erp"`
+	Timeout time.Duration `yaml:"timeout" env:"VAULT_TIMEOUT" default:"5s"`
+}
+
+// VaultProvider reads secrets from the keys of a HashiCorp Vault KV v2 secret, a new version of the secret is
+// picked up as a rotation
+type VaultProvider struct {
+	*watcher
+	config *VaultConfig
+	client *http.Client
+}
+
+// NewVaultProvider creates a provider reading the secret at config.Path
+func NewVaultProvider(config *VaultConfig, pollInterval time.Duration, logger logger.Logger) *VaultProvider {
+	p := &VaultProvider{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+	p.watcher = newWatcher(p.Get, pollInterval, logger)
+	return p
+}
+
+// kvResponse is the body of a KV v2 read, the keys of the secret are under data.data
+type kvResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+// Get returns the value of the key name of the secret, non string values are returned as JSON
+func (p *VaultProvider) Get(ctx context.Context, name string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s",
+		strings.TrimRight(p.config.Address, "/"), strings.Trim(p.config.Mount, "/"), strings.Trim(p.config.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	req.Header.Set("X-Vault-Token", p.config.Token)
+	if p.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.config.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", infra_error.Internal(infra_error.InternalServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", notFound(name)
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", infra_error.Internal(infra_error.InternalServiceUnavailable,
+			fmt.Errorf("vault returned %s: %s", resp.Status, strings.TrimSpace(string(body))))
+	}
+
+	var kv kvResponse
+	if err := json.NewDecoder(resp.Body).Decode(&kv); err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("decode vault response: %w", err))
+	}
+	value, ok := kv.Data.Data[name]
+	if !ok || value == nil {
+		return "", notFound(name)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,89 @@
+package secret
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultProvider_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/erp":
+			w.Write([]byte(`{"data":{"data":{"JWT_SECRET_KEY":"from-vault","PORTS":[1,2]},"metadata":{"version":3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name         string
+		config       VaultConfig
+		secret       string
+		want         string
+		wantNotFound bool
+		wantErr      bool
+	}{
+		{
+			name:   "string value",
+			config: VaultConfig{Address: server.URL + "/", Token: "token", Mount: "secret", Path: "erp"},
+			secret: "JWT_SECRET_KEY",
+			want:   "from-vault",
+		},
+		{
+			name:   "non string value as json",
+			config: VaultConfig{Address: server.URL, Token: "token", Mount: "secret", Path: "/erp/"},
+			secret: "PORTS",
+			want:   "[1,2]",
+		},
+		{
+			name:         "missing key",
+			config:       VaultConfig{Address: server.URL, Token: "token", Mount: "secret", Path: "erp"},
+			secret:       "MONGO_URI",
+			wantNotFound: true,
+		},
+		{
+			name:         "missing secret",
+			config:       VaultConfig{Address: server.URL, Token: "token", Mount: "secret", Path: "other"},
+			secret:       "JWT_SECRET_KEY",
+			wantNotFound: true,
+		},
+		{
+			name:    "invalid token",
+			config:  VaultConfig{Address: server.URL, Token: "wrong", Mount: "secret", Path: "erp"},
+			secret:  "JWT_SECRET_KEY",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.Timeout = time.Second
+			p := NewVaultProvider(&tc.config, time.Minute, logger.NewBaseLogger(shared.ModuleAuth))
+			value, err := p.Get(context.Background(), tc.secret)
+			switch {
+			case tc.wantNotFound:
+				assert.True(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+			case tc.wantErr:
+				assert.Error(t, err)
+				assert.False(t, infra_error.IsCategory(err, infra_error.CategoryNotFound))
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Package shutdown stops the service entry points in order: the servers stop taking traffic and report NOT_SERVING,
+// the background goroutines and the in-flight calls are drained within a deadline, then the dependencies they used
+// are closed and the logger is flushed last
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"erp.localhost/internal/infra/grpc/server"
+	"erp.localhost/internal/infra/logging/logger"
+)
+
+// closeTimeout bounds the close hooks, they run after the drain deadline may have passed
+const closeTimeout = 10 * time.Second
+
+// Config holds the settings of the graceful shutdown, loaded with infra_config.Load
+type Config struct {
+	// How long the in-flight calls and the background goroutines are drained before they are cancelled
+	Timeout time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" default:"30s" validate:"positive"`
+	// How long the service reports NOT_SERVING before it stops taking calls, so the load balancers stop routing to it
+	DrainDelay time.Duration `yaml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY" flag:"shutdown-drain-delay"`
+}
+
+// process tracks the coordinators of the binary, several services may run in one process and share its clients
+var process struct {
+	mu     sync.Mutex
+	active int
+	shared []namedHook
+}
+
+// Hook is a step of the shutdown, ctx ends at the deadline of its phase
+type Hook func(ctx context.Context) error
+
+type namedHook struct {
+	name string
+	fn   Hook
+}
+
+// Coordinator runs the shutdown of a service entry point:
+//  1. the drain hooks, in the order they were added, e.g. the health service reports NOT_SERVING
+//  2. the drain delay, then Quit is closed and the goroutines started with Go are waited for until the timeout
+//  3. the close hooks, in the reverse order they were added so a dependency closes after its users, then the shared
+//     hooks when no other service of the process is running
+//  4. the logger is flushed
+type Coordinator struct {
+	config  Config
+	logger  logger.Logger
+	quit    chan struct{}
+	stop    chan struct{}
+	stopped sync.Once
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running map[string]int
+	drain   []namedHook
+	close   []namedHook
+}
+
+func New(config *Config, logger logger.Logger) *Coordinator {
+	process.mu.Lock()
+	process.active++
+	process.mu.Unlock()
+	return &Coordinator{
+		config:  *config,
+		logger:  logger,
+		quit:    make(chan struct{}),
+		stop:    make(chan struct{}),
+		running: map[string]int{},
+	}
+}
+
+// Quit is closed when the goroutines of the service have to return
+func (c *Coordinator) Quit() <-chan struct{} {
+	return c.quit
+}
+
+// Go runs fn in a goroutine the shutdown waits for, fn returns once quit is closed
+func (c *Coordinator) Go(name string, fn func(quit <-chan struct{})) {
+	c.mu.Lock()
+	c.running[name]++
+	c.mu.Unlock()
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.running[name]--; c.running[name] == 0 {
+				delete(c.running, name)
+			}
+		}()
+		fn(c.quit)
+	}()
+}
+
+// OnDrain adds a hook run first, before the goroutines are asked to return
+func (c *Coordinator) OnDrain(name string, fn Hook) {
+	c.drain = append(c.drain, namedHook{name: name, fn: fn})
+}
+
+// OnClose adds a hook run once the goroutines returned, the hooks run in the reverse order they were added
+func (c *Coordinator) OnClose(name string, fn Hook) {
+	c.close = append(c.close, namedHook{name: name, fn: fn})
+}
+
+// OnCloseShared adds a hook closing a dependency shared by the services of the process, e.g. the database clients.
+// It runs once, after the close hooks of the last coordinator of the process, and a hook of the same name is only
+// added once
+func (c *Coordinator) OnCloseShared(name string, fn Hook) {
+	process.mu.Lock()
+	defer process.mu.Unlock()
+	for _, hook := range process.shared {
+		if hook.name == name {
+			return
+		}
+	}
+	process.shared = append(process.shared, namedHook{name: name, fn: fn})
+}
+
+// Stop asks for the shutdown, e.g. when a server fails, Wait returns
+func (c *Coordinator) Stop() {
+	c.stopped.Do(func() { close(c.stop) })
+}
+
+// Wait blocks until the process receives SIGINT or SIGTERM, or Stop is called
+func (c *Coordinator) Wait() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	select {
+	case sig := <-signals:
+		c.logger.Warn("shutdown requested", "signal", sig.String())
+	case <-c.stop:
+		c.logger.Warn("shutdown requested")
+	}
+}
+
+// ServeGRPC runs srv until the shutdown is requested, see Wait. The shutdown reports srv NOT_SERVING first, then
+// drains its calls. A server that fails to serve requests the shutdown
+func (c *Coordinator) ServeGRPC(srv server.RPCServer) {
+	c.OnDrain("grpc_health", func(context.Context) error {
+		srv.Health().SetNotServing()
+		return nil
+	})
+	c.Go("grpc_server", func(quit <-chan struct{}) {
+		if err := srv.ListenAndServe(quit); err != nil {
+			c.logger.Warn("gRPC server stopped", "error", err)
+			c.Stop()
+		}
+	})
+	c.Wait()
+}
+
+// Shutdown stops the service, the errors of the hooks are joined. Goroutines still running at the deadline are
+// reported and left behind, the dependencies are closed under them
+func (c *Coordinator) Shutdown() error {
+	var errs []error
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	for _, hook := range c.drain {
+		errs = append(errs, c.run(ctx, hook))
+	}
+	if c.config.DrainDelay > 0 {
+		c.logger.Info("draining before shutdown", "delay", c.config.DrainDelay)
+		select {
+		case <-time.After(c.config.DrainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	close(c.quit)
+	if !c.waitGoroutines(ctx) {
+		c.mu.Lock()
+		running := make([]string, 0, len(c.running))
+		for name := range c.running {
+			running = append(running, name)
+		}
+		c.mu.Unlock()
+		c.logger.Warn("goroutines still running at the shutdown deadline", "timeout", c.config.Timeout, "running", running)
+		errs = append(errs, fmt.Errorf("shutdown deadline exceeded with %d goroutines running", len(running)))
+	}
+
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer closeCancel()
+	for i := len(c.close) - 1; i >= 0; i-- {
+		errs = append(errs, c.run(closeCtx, c.close[i]))
+	}
+	for _, hook := range c.release() {
+		errs = append(errs, c.run(closeCtx, hook))
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		c.logger.Warn("shutdown completed with errors", "error", err)
+	} else {
+		c.logger.Info("shutdown complete")
+	}
+	if closer, ok := c.logger.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	return err
+}
+
+// release removes the coordinator from the process and returns the shared hooks to run when it was the last one,
+// in the reverse order they were added
+func (c *Coordinator) release() []namedHook {
+	process.mu.Lock()
+	defer process.mu.Unlock()
+	if process.active--; process.active > 0 {
+		return nil
+	}
+	hooks := make([]namedHook, 0, len(process.shared))
+	for i := len(process.shared) - 1; i >= 0; i-- {
+		hooks = append(hooks, process.shared[i])
+	}
+	process.shared = nil
+	return hooks
+}
+
+// waitGoroutines returns false when ctx ends before the goroutines started with Go returned
+func (c *Coordinator) waitGoroutines(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (c *Coordinator) run(ctx context.Context, hook namedHook) error {
+	if err := hook.fn(ctx); err != nil {
+		c.logger.Error("shutdown step failed", "step", hook.name, "error", err)
+		return fmt.Errorf("%s: %w", hook.name, err)
+	}
+	c.logger.Debug("shutdown step done", "step", hook.name)
+	return nil
+}
@@ -0,0 +1,92 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinator_Shutdown(t *testing.T) {
+	c := New(&Config{Timeout: time.Second}, logger.NewBaseLogger(shared.ModuleDB))
+	var steps []string
+	step := func(name string, err error) Hook {
+		return func(ctx context.Context) error {
+			steps = append(steps, name)
+			return err
+		}
+	}
+	c.OnDrain("health", step("health", nil))
+	c.Go("server", func(quit <-chan struct{}) {
+		<-quit
+		steps = append(steps, "server")
+	})
+	c.OnClose("mongo", step("mongo", nil))
+	closeErr := errors.New("connection reset")
+	c.OnClose("broker", step("broker", closeErr))
+
+	err := c.Shutdown()
+	assert.ErrorIs(t, err, closeErr)
+	// The dependencies close in the reverse order they were added, after the goroutines returned
+	assert.Equal(t, []string{"health", "server", "broker", "mongo"}, steps)
+}
+
+func TestCoordinator_ShutdownDeadline(t *testing.T) {
+	c := New(&Config{Timeout: 50 * time.Millisecond}, logger.NewBaseLogger(shared.ModuleDB))
+	release := make(chan struct{})
+	defer close(release)
+	c.Go("stuck", func(quit <-chan struct{}) {
+		<-release
+	})
+	closed := false
+	c.OnClose("mongo", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	start := time.Now()
+	err := c.Shutdown()
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, closed)
+}
+
+func TestCoordinator_Stop(t *testing.T) {
+	c := New(&Config{Timeout: time.Second}, logger.NewBaseLogger(shared.ModuleDB))
+	c.Stop()
+	c.Stop()
+	done := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after Stop")
+	}
+	require.NoError(t, c.Shutdown())
+}
+
+func TestCoordinator_OnCloseShared(t *testing.T) {
+	auth := New(&Config{Timeout: time.Second}, logger.NewBaseLogger(shared.ModuleAuth))
+	core := New(&Config{Timeout: time.Second}, logger.NewBaseLogger(shared.ModuleCore))
+	closed := 0
+	closeMongo := func(ctx context.Context) error {
+		closed++
+		return nil
+	}
+	auth.OnCloseShared("mongo", closeMongo)
+	core.OnCloseShared("mongo", closeMongo)
+
+	// The other service still uses the client
+	require.NoError(t, auth.Shutdown())
+	assert.Equal(t, 0, closed)
+	require.NoError(t, core.Shutdown())
+	assert.Equal(t, 1, closed)
+}
@@ -0,0 +1,252 @@
+// Package status aggregates the health of the dependencies, queues, background jobs and caches of a service
+// into a single report for operators.
+package status
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	model_shared "erp.localhost/internal/infra/model/shared"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Probes slower than this report the component as degraded
+	DefaultDegradedLatency = 250 * time.Millisecond
+)
+
+// Probe round trips to a dependency and returns the latency
+type Probe func() (time.Duration, error)
+
+// QueueDepth returns the number of queued items and the queue capacity, 0 capacity means unbounded
+type QueueDepth func() (int64, int64)
+
+// JobState is the state of a background job as reported by its owner
+type JobState struct {
+	Running   bool
+	LastRun   time.Time
+	LastError error
+}
+
+// JobReporter returns the current state of a background job
+type JobReporter func() JobState
+
+// CacheStats returns the hits and misses of a cache
+type CacheStats func() (int64, int64, error)
+
+type named[T any] struct {
+	name string
+	fn   T
+}
+
+// Aggregator collects the registered sources into a SystemStatus
+type Aggregator struct {
+	logger          logger.Logger
+	degradedLatency time.Duration
+	now             func() time.Time
+
+	mu      sync.RWMutex
+	probes  []named[Probe]
+	queues  []named[QueueDepth]
+	jobs    []named[JobReporter]
+	caches  []named[CacheStats]
+	modules []model_shared.Module
+}
+
+func NewAggregator(logger logger.Logger) *Aggregator {
+	return &Aggregator{
+		logger:          logger,
+		degradedLatency: DefaultDegradedLatency,
+		now:             time.Now,
+	}
+}
+
+// SetDegradedLatency overrides the latency above which a component is degraded
+func (a *Aggregator) SetDegradedLatency(latency time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.degradedLatency = latency
+}
+
+func (a *Aggregator) AddProbe(name string, probe Probe) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.probes = append(a.probes, named[Probe]{name: name, fn: probe})
+}
+
+// EachProbe calls fn with every registered probe, e.g. to reuse them as readiness checks
+func (a *Aggregator) EachProbe(fn func(name string, probe Probe)) {
+	a.mu.RLock()
+	probes := append([]named[Probe](nil), a.probes...)
+	a.mu.RUnlock()
+	for _, probe := range probes {
+		fn(probe.name, probe.fn)
+	}
+}
+
+func (a *Aggregator) AddQueue(name string, depth QueueDepth) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.queues = append(a.queues, named[QueueDepth]{name: name, fn: depth})
+}
+
+func (a *Aggregator) AddJob(name string, reporter JobReporter) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.jobs = append(a.jobs, named[JobReporter]{name: name, fn: reporter})
+}
+
+func (a *Aggregator) AddCache(name string, stats CacheStats) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.caches = append(a.caches, named[CacheStats]{name: name, fn: stats})
+}
+
+// AddModule registers a module served by this binary, its build info is included in the report
+func (a *Aggregator) AddModule(module model_shared.Module) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.modules = append(a.modules, module)
+}
+
+// Status runs all probes concurrently and collects the state of queues, jobs and caches
+func (a *Aggregator) Status() *authv1.SystemStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	status := &authv1.SystemStatus{
+		State:      authv1.HealthState_HEALTH_STATE_HEALTHY,
+		CheckedAt:  timestamppb.New(a.now()),
+		Components: make([]*authv1.ComponentStatus, len(a.probes)),
+	}
+
+	var wg sync.WaitGroup
+	for i, probe := range a.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status.Components[i] = a.runProbe(probe)
+		}()
+	}
+	wg.Wait()
+	for _, component := range status.Components {
+		status.State = worst(status.State, component.GetState())
+	}
+
+	for _, queue := range a.queues {
+		depth, capacity := queue.fn()
+		status.Queues = append(status.Queues, &authv1.QueueStatus{
+			Name:     queue.name,
+			Depth:    depth,
+			Capacity: capacity,
+		})
+	}
+
+	for _, job := range a.jobs {
+		jobStatus := jobStatus(job.name, job.fn())
+		status.State = worst(status.State, jobStatus.GetState())
+		status.Jobs = append(status.Jobs, jobStatus)
+	}
+
+	for _, cache := range a.caches {
+		status.Caches = append(status.Caches, cacheStatus(cache.name, cache.fn))
+	}
+
+	build := buildInfo()
+	for _, module := range a.modules {
+		status.Modules = append(status.Modules, &authv1.ModuleBuildInfo{
+			Module:    string(module),
+			Version:   build.GetVersion(),
+			Revision:  build.GetRevision(),
+			BuildTime: build.GetBuildTime(),
+			GoVersion: build.GetGoVersion(),
+		})
+	}
+	return status
+}
+
+func (a *Aggregator) runProbe(probe named[Probe]) *authv1.ComponentStatus {
+	latency, err := probe.fn()
+	component := &authv1.ComponentStatus{
+		Name:      probe.name,
+		State:     authv1.HealthState_HEALTH_STATE_HEALTHY,
+		LatencyMs: latency.Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		a.logger.Warn("component probe failed", "component", probe.name, "error", err)
+		component.State = authv1.HealthState_HEALTH_STATE_UNHEALTHY
+		component.Error = err.Error()
+	case latency > a.degradedLatency:
+		component.State = authv1.HealthState_HEALTH_STATE_DEGRADED
+	}
+	return component
+}
+
+func jobStatus(name string, state JobState) *authv1.JobStatus {
+	job := &authv1.JobStatus{
+		Name:    name,
+		State:   authv1.HealthState_HEALTH_STATE_HEALTHY,
+		Running: state.Running,
+	}
+	if !state.LastRun.IsZero() {
+		job.LastRun = timestamppb.New(state.LastRun)
+	}
+	if state.LastError != nil {
+		job.LastError = state.LastError.Error()
+		job.State = authv1.HealthState_HEALTH_STATE_DEGRADED
+	}
+	if !state.Running {
+		job.State = authv1.HealthState_HEALTH_STATE_DEGRADED
+	}
+	return job
+}
+
+func cacheStatus(name string, stats CacheStats) *authv1.CacheStatus {
+	hits, misses, err := stats()
+	cache := &authv1.CacheStatus{
+		Name:   name,
+		Hits:   hits,
+		Misses: misses,
+	}
+	if err != nil {
+		cache.Error = err.Error()
+	}
+	if total := hits + misses; total > 0 {
+		cache.HitRate = float64(hits) / float64(total)
+	}
+	return cache
+}
+
+// worst returns the more severe of two states
+func worst(a, b authv1.HealthState) authv1.HealthState {
+	if b > a {
+		return b
+	}
+	return a
+}
+
+// buildInfo reads the version stamped by the go toolchain into the binary
+func buildInfo() *authv1.ModuleBuildInfo {
+	info := &authv1.ModuleBuildInfo{
+		GoVersion: runtime.Version(),
+	}
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Version = build.Main.Version
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.BuildTime = setting.Value
+		}
+	}
+	return info
+}
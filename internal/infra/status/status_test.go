@@ -0,0 +1,86 @@
+package status
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	shared "erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregator_Status(t *testing.T) {
+	testCases := []struct {
+		name      string
+		probes    map[string]Probe
+		job       JobState
+		wantState authv1.HealthState
+	}{
+		{
+			name:      "all healthy",
+			probes:    map[string]Probe{"mongo": func() (time.Duration, error) { return time.Millisecond, nil }},
+			job:       JobState{Running: true, LastRun: time.Now()},
+			wantState: authv1.HealthState_HEALTH_STATE_HEALTHY,
+		},
+		{
+			name:      "slow probe is degraded",
+			probes:    map[string]Probe{"mongo": func() (time.Duration, error) { return time.Second, nil }},
+			job:       JobState{Running: true},
+			wantState: authv1.HealthState_HEALTH_STATE_DEGRADED,
+		},
+		{
+			name:      "stopped job is degraded",
+			probes:    map[string]Probe{"mongo": func() (time.Duration, error) { return time.Millisecond, nil }},
+			job:       JobState{Running: false},
+			wantState: authv1.HealthState_HEALTH_STATE_DEGRADED,
+		},
+		{
+			name: "failed probe is unhealthy",
+			probes: map[string]Probe{
+				"mongo": func() (time.Duration, error) { return time.Second, nil },
+				"redis": func() (time.Duration, error) { return 0, errors.New("connection refused") },
+			},
+			job:       JobState{Running: true, LastError: errors.New("flush failed")},
+			wantState: authv1.HealthState_HEALTH_STATE_UNHEALTHY,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := NewAggregator(logger.NewBaseLogger(shared.ModuleAuth))
+			for name, probe := range tc.probes {
+				aggregator.AddProbe(name, probe)
+			}
+			aggregator.AddJob("usage_flush", func() JobState { return tc.job })
+
+			status := aggregator.Status()
+			require.Len(t, status.GetComponents(), len(tc.probes))
+			require.Len(t, status.GetJobs(), 1)
+			assert.Equal(t, tc.wantState, status.GetState())
+			assert.NotNil(t, status.GetCheckedAt())
+		})
+	}
+}
+
+func TestAggregator_QueuesCachesModules(t *testing.T) {
+	aggregator := NewAggregator(logger.NewBaseLogger(shared.ModuleAuth))
+	aggregator.AddQueue("usage", func() (int64, int64) { return 3, 0 })
+	aggregator.AddCache("redis", func() (int64, int64, error) { return 75, 25, nil })
+	aggregator.AddCache("broken", func() (int64, int64, error) { return 0, 0, errors.New("info failed") })
+	aggregator.AddModule(shared.ModuleAuth)
+
+	status := aggregator.Status()
+	require.Len(t, status.GetQueues(), 1)
+	assert.Equal(t, int64(3), status.GetQueues()[0].GetDepth())
+
+	require.Len(t, status.GetCaches(), 2)
+	assert.InDelta(t, 0.75, status.GetCaches()[0].GetHitRate(), 0.0001)
+	assert.Equal(t, "info failed", status.GetCaches()[1].GetError())
+
+	require.Len(t, status.GetModules(), 1)
+	assert.Equal(t, string(shared.ModuleAuth), status.GetModules()[0].GetModule())
+	assert.NotEmpty(t, status.GetModules()[0].GetGoVersion())
+	assert.Equal(t, authv1.HealthState_HEALTH_STATE_HEALTHY, status.GetState())
+}
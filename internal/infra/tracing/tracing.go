@@ -0,0 +1,99 @@
+// Package tracing configures OpenTelemetry distributed tracing for a service binary and provides helpers
+// to start spans, so calls can be followed across services down to the database.
+package tracing
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer of the spans started by this package
+const instrumentationName = "erp.localhost/internal/infra/tracing"
+
+// Config holds the exporter configuration of a service
+type Config struct {
+	// OTLP/HTTP endpoint of the collector, e.g. http://localhost:4318. Spans are not exported when empty,
+	// trace ids are still generated and propagated for log correlation
+	Endpoint string
+	// Name of the service reported on the spans
+	ServiceName string
+	// Fraction of new traces sampled, traces started by a caller follow the caller decision
+	SampleRatio float64
+}
+
+// LoadConfig reads the configuration from the standard OpenTelemetry environment variables:
+// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_SERVICE_NAME and OTEL_TRACES_SAMPLER_ARG
+func LoadConfig(module shared.Module) *Config {
+	config := &Config{
+		Endpoint:    os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		ServiceName: os.Getenv("OTEL_SERVICE_NAME"),
+		SampleRatio: 1,
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = "erp-" + strings.ToLower(string(module))
+	}
+	if ratio, err := strconv.ParseFloat(os.Getenv("OTEL_TRACES_SAMPLER_ARG"), 64); err == nil && ratio >= 0 && ratio <= 1 {
+		config.SampleRatio = ratio
+	}
+	return config
+}
+
+// Init installs the global tracer provider and W3C trace context propagation, the returned function flushes
+// and stops the exporter and must be called on shutdown
+func Init(ctx context.Context, config *Config, logger logger.Logger) (func(context.Context) error, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	}
+	if config.Endpoint != "" {
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(config.Endpoint))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+		logger.Info("exporting traces", "endpoint", config.Endpoint, "service", config.ServiceName, "sample_ratio", config.SampleRatio)
+	} else {
+		logger.Info("trace exporter disabled, OTEL_EXPORTER_OTLP_ENDPOINT is not set")
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as a child of the span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"erp.localhost/internal/infra/logging/logger"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "")
+	config := LoadConfig(shared.ModuleAuth)
+	assert.Equal(t, "erp-auth", config.ServiceName)
+	assert.Equal(t, float64(1), config.SampleRatio)
+	assert.Empty(t, config.Endpoint)
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	t.Setenv("OTEL_SERVICE_NAME", "auth")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+	config = LoadConfig(shared.ModuleAuth)
+	assert.Equal(t, "http://collector:4318", config.Endpoint)
+	assert.Equal(t, "auth", config.ServiceName)
+	assert.Equal(t, 0.25, config.SampleRatio)
+
+	// Out of range ratios keep the default
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "2")
+	assert.Equal(t, float64(1), LoadConfig(shared.ModuleAuth).SampleRatio)
+}
+
+func TestInit(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	// Without an endpoint spans still carry ids for log correlation
+	shutdown, err := Init(context.Background(), &Config{ServiceName: "erp-test", SampleRatio: 1}, logger.NewBaseLogger(shared.ModuleDB))
+	require.NoError(t, err)
+	defer shutdown(context.Background())
+
+	ctx, span := Start(context.Background(), "operation")
+	defer span.End()
+	assert.True(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.True(t, span.SpanContext().IsSampled())
+}
+
+func TestEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	End(span, nil)
+	_, span = tracer.Start(context.Background(), "failed")
+	End(span, errors.New("connection refused"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "connection refused", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+}
@@ -0,0 +1,97 @@
+package usage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// BillingLine is a single line of a billing export: calls of one method during one month
+type BillingLine struct {
+	TenantID string `json:"tenant_id"`
+	Month    string `json:"month"`
+	Service  string `json:"service"`
+	Method   string `json:"method"`
+	Calls    int64  `json:"calls"`
+	Errors   int64  `json:"errors"`
+}
+
+// BillingExport is the JSON billing export document
+type BillingExport struct {
+	TenantID   string         `json:"tenant_id"`
+	Month      string         `json:"month"`
+	TotalCalls int64          `json:"total_calls"`
+	Lines      []*BillingLine `json:"lines"`
+}
+
+// BillingLines aggregates daily rollups of a month into one line per method, sorted by service and method
+func BillingLines(tenantID, month string, rollups []*authv1.UsageRollup) []*BillingLine {
+	lines := map[string]*BillingLine{}
+	for _, rollup := range rollups {
+		key := rollup.GetService() + "/" + rollup.GetMethod()
+		line, ok := lines[key]
+		if !ok {
+			line = &BillingLine{TenantID: tenantID, Month: month, Service: rollup.GetService(), Method: rollup.GetMethod()}
+			lines[key] = line
+		}
+		line.Calls += rollup.GetCalls()
+		line.Errors += rollup.GetErrors()
+	}
+
+	result := make([]*BillingLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, line)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Service != result[j].Service {
+			return result[i].Service < result[j].Service
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
+
+// ExportCSV renders billing lines as CSV with a header row
+func ExportCSV(lines []*BillingLine) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"tenant_id", "month", "service", "method", "calls", "errors"}); err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		record := []string{
+			line.TenantID,
+			line.Month,
+			line.Service,
+			line.Method,
+			strconv.FormatInt(line.Calls, 10),
+			strconv.FormatInt(line.Errors, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write usage csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportJSON renders billing lines as a single JSON document
+func ExportJSON(tenantID, month string, lines []*BillingLine) ([]byte, error) {
+	export := &BillingExport{
+		TenantID: tenantID,
+		Month:    month,
+		Lines:    lines,
+	}
+	for _, line := range lines {
+		export.TotalCalls += line.Calls
+	}
+	return json.MarshalIndent(export, "", "  ")
+}
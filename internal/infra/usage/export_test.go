@@ -0,0 +1,39 @@
+package usage
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillingExports(t *testing.T) {
+	rollups := []*authv1.UsageRollup{
+		{Date: "2026-03-01", Service: "auth.v1.UserService", Method: "GetUser", Calls: 3, Errors: 1},
+		{Date: "2026-03-02", Service: "auth.v1.UserService", Method: "GetUser", Calls: 2},
+		{Date: "2026-03-02", Service: "auth.v1.AuthService", Method: "Login", Calls: 4},
+	}
+
+	lines := BillingLines("tenant-1", "2026-03", rollups)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Login", lines[0].Method)
+	assert.Equal(t, int64(5), lines[1].Calls)
+	assert.Equal(t, int64(1), lines[1].Errors)
+
+	csvData, err := ExportCSV(lines)
+	require.NoError(t, err)
+	rows := strings.Split(strings.TrimSpace(string(csvData)), "\n")
+	require.Len(t, rows, 3)
+	assert.Equal(t, "tenant_id,month,service,method,calls,errors", rows[0])
+	assert.Equal(t, "tenant-1,2026-03,auth.v1.AuthService,Login,4,0", rows[1])
+
+	jsonData, err := ExportJSON("tenant-1", "2026-03", lines)
+	require.NoError(t, err)
+	var export BillingExport
+	require.NoError(t, json.Unmarshal(jsonData, &export))
+	assert.Equal(t, int64(9), export.TotalCalls)
+	assert.Len(t, export.Lines, 2)
+}
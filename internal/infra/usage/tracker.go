@@ -0,0 +1,297 @@
+// Package usage counts API calls per tenant and method, rolls them up per day and enforces monthly caps.
+package usage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+const (
+	DateFormat  = "2006-01-02"
+	MonthFormat = "2006-01"
+
+	DefaultFlushInterval = time.Minute
+	// How long a tenant cap is cached before it is looked up again
+	capCacheTTL = 5 * time.Minute
+)
+
+// Store persists daily rollups
+type Store interface {
+	// Increment adds calls and errors to the rollup of a tenant, day and method, creating it when missing
+	Increment(ctx context.Context, tenantID, date, service, method string, calls, errors int64) error
+	// Rollups returns the rollups of a tenant between two days, inclusive
+	Rollups(ctx context.Context, tenantID, fromDate, toDate string) ([]*authv1.UsageRollup, error)
+}
+
+// CapProvider returns the monthly API call cap of a tenant, 0 means unlimited
+type CapProvider func(ctx context.Context, tenantID string) (int64, error)
+
+type rollupKey struct {
+	tenantID string
+	date     string
+	service  string
+	method   string
+}
+
+type counter struct {
+	calls  int64
+	errors int64
+}
+
+type monthKey struct {
+	tenantID string
+	month    string
+}
+
+type cachedCap struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// Tracker counts calls in memory and periodically flushes them to the store as daily rollups
+type Tracker struct {
+	logger      logger.Logger
+	store       Store
+	capProvider CapProvider
+	now         func() time.Time
+
+	mu      sync.Mutex
+	pending map[rollupKey]*counter
+	// Month to date totals, loaded from the store on first use and kept up to date by Record
+	monthly map[monthKey]int64
+	caps    map[string]cachedCap
+
+	// Flush loop state, reported by Status
+	running      bool
+	lastFlush    time.Time
+	lastFlushErr error
+}
+
+func NewTracker(store Store, capProvider CapProvider, logger logger.Logger) *Tracker {
+	return &Tracker{
+		logger:      logger,
+		store:       store,
+		capProvider: capProvider,
+		now:         time.Now,
+		pending:     map[rollupKey]*counter{},
+		monthly:     map[monthKey]int64{},
+		caps:        map[string]cachedCap{},
+	}
+}
+
+// SplitMethod splits a gRPC full method (/package.Service/Method) into service and method
+func SplitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	service, method, found := strings.Cut(fullMethod, "/")
+	if !found {
+		return "", service
+	}
+	return service, method
+}
+
+// Record counts a call of fullMethod for the tenant
+func (t *Tracker) Record(tenantID, fullMethod string, failed bool) {
+	if tenantID == "" {
+		return
+	}
+	now := t.now().UTC()
+	service, method := SplitMethod(fullMethod)
+	key := rollupKey{tenantID: tenantID, date: now.Format(DateFormat), service: service, method: method}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c, ok := t.pending[key]
+	if !ok {
+		c = &counter{}
+		t.pending[key] = c
+	}
+	c.calls++
+	if failed {
+		c.errors++
+	}
+	month := monthKey{tenantID: tenantID, month: now.Format(MonthFormat)}
+	if total, ok := t.monthly[month]; ok {
+		t.monthly[month] = total + 1
+	}
+}
+
+// CheckCap returns a quota error when the tenant reached its monthly cap
+func (t *Tracker) CheckCap(ctx context.Context, tenantID string) error {
+	if tenantID == "" || t.capProvider == nil {
+		return nil
+	}
+	limit, err := t.monthlyCap(ctx, tenantID)
+	if err != nil {
+		// Usage tracking must not take the API down
+		t.logger.Warn("failed to get tenant api cap", "tenant_id", tenantID, "error", err)
+		return nil
+	}
+	if limit <= 0 {
+		return nil
+	}
+	total, err := t.MonthToDate(ctx, tenantID)
+	if err != nil {
+		t.logger.Warn("failed to get tenant month to date usage", "tenant_id", tenantID, "error", err)
+		return nil
+	}
+	if total >= limit {
+		return infra_error.Business(infra_error.BusinessQuotaExceeded).
+			WithDetails("tenant_id", tenantID).
+			WithDetails("monthly_cap", limit)
+	}
+	return nil
+}
+
+// MonthlyCap returns the cap of the tenant, 0 means unlimited
+func (t *Tracker) MonthlyCap(ctx context.Context, tenantID string) (int64, error) {
+	return t.monthlyCap(ctx, tenantID)
+}
+
+func (t *Tracker) monthlyCap(ctx context.Context, tenantID string) (int64, error) {
+	if t.capProvider == nil {
+		return 0, nil
+	}
+	now := t.now()
+	t.mu.Lock()
+	cached, ok := t.caps[tenantID]
+	t.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	limit, err := t.capProvider(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	t.mu.Lock()
+	t.caps[tenantID] = cachedCap{value: limit, expiresAt: now.Add(capCacheTTL)}
+	t.mu.Unlock()
+	return limit, nil
+}
+
+// MonthToDate returns the calls of the tenant in the current month, including calls not flushed yet
+func (t *Tracker) MonthToDate(ctx context.Context, tenantID string) (int64, error) {
+	now := t.now().UTC()
+	key := monthKey{tenantID: tenantID, month: now.Format(MonthFormat)}
+
+	t.mu.Lock()
+	total, ok := t.monthly[key]
+	t.mu.Unlock()
+	if ok {
+		return total, nil
+	}
+
+	from, to := MonthRange(now)
+	rollups, err := t.store.Rollups(ctx, tenantID, from, to)
+	if err != nil {
+		return 0, err
+	}
+	for _, rollup := range rollups {
+		total += rollup.GetCalls()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for k, c := range t.pending {
+		if k.tenantID == tenantID && strings.HasPrefix(k.date, key.month) {
+			total += c.calls
+		}
+	}
+	t.monthly[key] = total
+	return total, nil
+}
+
+// Flush writes pending counters to the store, counters that failed to be written are kept for the next flush
+func (t *Tracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = map[rollupKey]*counter{}
+	// Month totals are reloaded from the store after the month changes
+	month := t.now().UTC().Format(MonthFormat)
+	for k := range t.monthly {
+		if k.month != month {
+			delete(t.monthly, k)
+		}
+	}
+	t.mu.Unlock()
+
+	var errs []error
+	for key, c := range pending {
+		if err := t.store.Increment(ctx, key.tenantID, key.date, key.service, key.method, c.calls, c.errors); err != nil {
+			errs = append(errs, err)
+			t.mu.Lock()
+			if current, ok := t.pending[key]; ok {
+				current.calls += c.calls
+				current.errors += c.errors
+			} else {
+				t.pending[key] = c
+			}
+			t.mu.Unlock()
+		}
+	}
+	err := errors.Join(errs...)
+	if err != nil {
+		t.logger.Error("failed to flush api usage", "failed_rollups", len(errs), "error", err)
+	}
+	t.mu.Lock()
+	t.lastFlush = t.now()
+	t.lastFlushErr = err
+	t.mu.Unlock()
+	return err
+}
+
+// Pending returns the number of rollups waiting to be flushed
+func (t *Tracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.pending)
+}
+
+// Status reports whether the flush loop is running and the result of the last flush
+func (t *Tracker) Status() (bool, time.Time, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.running, t.lastFlush, t.lastFlushErr
+}
+
+// Run flushes the tracker every interval until quit is closed, with a final flush on exit
+func (t *Tracker) Run(interval time.Duration, quit <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	t.setRunning(true)
+	defer t.setRunning(false)
+	// Flushes are not tied to a request, the final one must complete after quit is closed
+	ctx := context.Background()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = t.Flush(ctx)
+		case <-quit:
+			_ = t.Flush(ctx)
+			return
+		}
+	}
+}
+
+func (t *Tracker) setRunning(running bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.running = running
+}
+
+// MonthRange returns the first and last day of the month of the given time
+func MonthRange(tm time.Time) (string, string) {
+	first := time.Date(tm.Year(), tm.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	return first.Format(DateFormat), last.Format(DateFormat)
+}
@@ -0,0 +1,144 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	shared "erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	rollups map[rollupKey]*authv1.UsageRollup
+	err     error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{rollups: map[rollupKey]*authv1.UsageRollup{}}
+}
+
+func (m *memoryStore) Increment(_ context.Context, tenantID, date, service, method string, calls, errors int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	key := rollupKey{tenantID: tenantID, date: date, service: service, method: method}
+	rollup, ok := m.rollups[key]
+	if !ok {
+		rollup = &authv1.UsageRollup{TenantId: tenantID, Date: date, Service: service, Method: method}
+		m.rollups[key] = rollup
+	}
+	rollup.Calls += calls
+	rollup.Errors += errors
+	return nil
+}
+
+func (m *memoryStore) Rollups(_ context.Context, tenantID, fromDate, toDate string) ([]*authv1.UsageRollup, error) {
+	result := []*authv1.UsageRollup{}
+	for key, rollup := range m.rollups {
+		if key.tenantID == tenantID && key.date >= fromDate && key.date <= toDate {
+			result = append(result, rollup)
+		}
+	}
+	return result, nil
+}
+
+func newTestTracker(store Store, capProvider CapProvider) *Tracker {
+	tracker := NewTracker(store, capProvider, logger.NewBaseLogger(shared.ModuleAuth))
+	tracker.now = func() time.Time { return time.Date(2026, time.March, 15, 10, 0, 0, 0, time.UTC) }
+	return tracker
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method := SplitMethod("/auth.v1.UserService/GetUser")
+	assert.Equal(t, "auth.v1.UserService", service)
+	assert.Equal(t, "GetUser", method)
+
+	service, method = SplitMethod("GetUser")
+	assert.Equal(t, "", service)
+	assert.Equal(t, "GetUser", method)
+}
+
+func TestTracker_RecordAndFlush(t *testing.T) {
+	store := newMemoryStore()
+	tracker := newTestTracker(store, nil)
+
+	tracker.Record("tenant-1", "/auth.v1.UserService/GetUser", false)
+	tracker.Record("tenant-1", "/auth.v1.UserService/GetUser", true)
+	tracker.Record("tenant-2", "/auth.v1.UserService/GetUser", false)
+	tracker.Record("", "/auth.v1.UserService/GetUser", false)
+
+	require.NoError(t, tracker.Flush(context.Background()))
+	rollup := store.rollups[rollupKey{tenantID: "tenant-1", date: "2026-03-15", service: "auth.v1.UserService", method: "GetUser"}]
+	require.NotNil(t, rollup)
+	assert.Equal(t, int64(2), rollup.Calls)
+	assert.Equal(t, int64(1), rollup.Errors)
+	assert.Len(t, store.rollups, 2)
+
+	// Flushed counters are not written twice
+	require.NoError(t, tracker.Flush(context.Background()))
+	assert.Equal(t, int64(2), rollup.Calls)
+}
+
+func TestTracker_FlushKeepsCountersOnStoreFailure(t *testing.T) {
+	store := newMemoryStore()
+	store.err = errors.New("mongo down")
+	tracker := newTestTracker(store, nil)
+
+	tracker.Record("tenant-1", "/auth.v1.UserService/GetUser", false)
+	assert.Error(t, tracker.Flush(context.Background()))
+
+	store.err = nil
+	tracker.Record("tenant-1", "/auth.v1.UserService/GetUser", false)
+	require.NoError(t, tracker.Flush(context.Background()))
+	rollup := store.rollups[rollupKey{tenantID: "tenant-1", date: "2026-03-15", service: "auth.v1.UserService", method: "GetUser"}]
+	require.NotNil(t, rollup)
+	assert.Equal(t, int64(2), rollup.Calls)
+}
+
+func TestTracker_CheckCap(t *testing.T) {
+	store := newMemoryStore()
+	require.NoError(t, store.Increment(context.Background(), "tenant-1", "2026-03-01", "svc", "A", 3, 0))
+	// Previous month does not count
+	require.NoError(t, store.Increment(context.Background(), "tenant-1", "2026-02-28", "svc", "A", 100, 0))
+
+	caps := map[string]int64{"tenant-1": 5}
+	tracker := newTestTracker(store, func(_ context.Context, tenantID string) (int64, error) {
+		return caps[tenantID], nil
+	})
+
+	require.NoError(t, tracker.CheckCap(context.Background(), "tenant-1"))
+	tracker.Record("tenant-1", "/svc/A", false)
+	require.NoError(t, tracker.CheckCap(context.Background(), "tenant-1"))
+	tracker.Record("tenant-1", "/svc/A", false)
+
+	err := tracker.CheckCap(context.Background(), "tenant-1")
+	require.Error(t, err)
+	appErr, ok := infra_error.AsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, infra_error.BusinessQuotaExceeded.Code, appErr.Code)
+
+	// Tenants without a cap are unlimited
+	for i := 0; i < 10; i++ {
+		tracker.Record("tenant-2", "/svc/A", false)
+	}
+	assert.NoError(t, tracker.CheckCap(context.Background(), "tenant-2"))
+}
+
+func TestTracker_CheckCapFailsOpen(t *testing.T) {
+	tracker := newTestTracker(newMemoryStore(), func(_ context.Context, tenantID string) (int64, error) {
+		return 0, errors.New("tenant lookup failed")
+	})
+	assert.NoError(t, tracker.CheckCap(context.Background(), "tenant-1"))
+}
+
+func TestMonthRange(t *testing.T) {
+	from, to := MonthRange(time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2024-02-01", from)
+	assert.Equal(t, "2024-02-29", to)
+}
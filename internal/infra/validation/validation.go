@@ -0,0 +1,409 @@
+// Package validation checks values against the rules declared in their `validate` struct tags:
+//
+//	TenantId string   `validate:"required"`
+//	Id       string   `validate:"required_on_update,objectid"`
+//	Theme    string   `validate:"oneof=light dark auto"`
+//	Scopes   []string `validate:"required,min=1,max=50"`
+//
+// Rules are comma separated:
+//   - required: the value is not the zero value, e.g. not empty, nil or an UNSPECIFIED enum
+//   - required_on_update: required only when validating an update
+//   - email: an email address
+//   - oneof=a b c: one of the space separated values
+//   - min=n, max=n: the length of strings, slices and maps, or the value of numbers, is within the bound
+//   - objectid: a hex encoded MongoDB ObjectID
+//   - port: a port between 1 and 65535
+//   - positive: a number above 0
+//   - dive: the nested struct, or each struct of the slice, is validated with its own tags
+//
+// Except required, port and positive, rules are not checked on zero values, so optional fields only need to be valid when
+// set
+package validation
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tag is the struct tag holding the rules of a field
+const Tag = "validate"
+
+const (
+	ruleRequired         = "required"
+	ruleRequiredOnUpdate = "required_on_update"
+	ruleEmail            = "email"
+	ruleOneOf            = "oneof"
+	ruleMin              = "min"
+	ruleMax              = "max"
+	ruleObjectID         = "objectid"
+	rulePort             = "port"
+	rulePositive         = "positive"
+	ruleDive             = "dive"
+)
+
+// emailRegex is the basic RFC 5322 email validation of the models
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+// Violation is a value failing one of its rules
+type Violation struct {
+	// Field is the dotted path of the field, e.g. Profile.FirstName
+	Field string
+	Rule  string
+	// Def is the error reported for the violation
+	Def infra_error.ErrorDef
+	// Message explains the violation, e.g. "must be at most 100 characters long"
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Field + " " + v.Message
+}
+
+// Rule is a parsed rule of a field
+type Rule struct {
+	name  string
+	param string
+}
+
+// field is a struct field with rules
+type field struct {
+	index []int
+	name  string
+	// protoName is the name of the field in its proto message, name for the fields of plain structs
+	protoName string
+	rules     []Rule
+}
+
+// options of a validation
+type options struct {
+	update bool
+	// protoNames names the fields of the violations by their proto names, e.g. identifier.tenant_id
+	protoNames bool
+}
+
+// fieldCache holds the parsed fields of the validated struct types
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// Struct validates the fields of v, a struct or a pointer to one, against their tag rules. update makes the
+// required_on_update fields required. It returns a ValidationRequiredFields error naming every missing field, else
+// the error of the first violation
+func Struct(v any, update bool) error {
+	violations, err := Violations(v, update)
+	if err != nil {
+		return err
+	}
+	return Error(violations)
+}
+
+// Message validates the fields of the request message m against their tag rules, like Struct. The fields of the
+// violations are named by their proto names, e.g. identifier.tenant_id, as the clients know them
+func Message(m proto.Message) error {
+	violations, err := violations(m, options{protoNames: true})
+	if err != nil {
+		return err
+	}
+	return Error(violations)
+}
+
+// Violations returns the violations of the tag rules of the fields of v, a struct or a pointer to one. Unknown rules
+// are an internal error
+func Violations(v any, update bool) ([]Violation, error) {
+	return violations(v, options{update: update})
+}
+
+func violations(v any, opts options) ([]Violation, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, infra_error.Validation(infra_error.ValidationRequiredFields, typeName(reflect.TypeOf(v)))
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("cannot validate %T, not a struct", v))
+	}
+	return structViolations(value, "", opts)
+}
+
+// Error returns the error of violations: a ValidationRequiredFields error naming every missing field, else the
+// error of the first violation, nil without violations
+func Error(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	missing := []string{}
+	for _, violation := range violations {
+		if violation.Def.Code == infra_error.ValidationRequiredFields.Code {
+			missing = append(missing, violation.Field)
+		}
+	}
+	if len(missing) > 0 {
+		return infra_error.Validation(infra_error.ValidationRequiredFields, missing...)
+	}
+	first := violations[0]
+	return infra_error.Validation(first.Def, first.Field).WithError(errors.New(first.String()))
+}
+
+func structViolations(value reflect.Value, prefix string, opts options) ([]Violation, error) {
+	fields, err := structFields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+	violations := []Violation{}
+	for _, f := range fields {
+		name := f.name
+		if opts.protoNames {
+			name = f.protoName
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		fieldValue := value.FieldByIndex(f.index)
+		for _, r := range f.rules {
+			if r.name == ruleDive {
+				nested, err := diveViolations(fieldValue, name, opts)
+				if err != nil {
+					return nil, err
+				}
+				violations = append(violations, nested...)
+				continue
+			}
+			if violation := check(fieldValue, r, opts.update); violation != nil {
+				violation.Field = name
+				violations = append(violations, *violation)
+				// A missing value fails its other rules too, only its first violation is reported
+				break
+			}
+		}
+	}
+	return violations, nil
+}
+
+// diveViolations validates the struct of value, or each struct of the slice value, nil values are skipped
+func diveViolations(value reflect.Value, name string, opts options) ([]Violation, error) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return diveViolations(value.Elem(), name, opts)
+	case reflect.Struct:
+		return structViolations(value, name, opts)
+	case reflect.Slice, reflect.Array:
+		violations := []Violation{}
+		for i := 0; i < value.Len(); i++ {
+			nested, err := diveViolations(value.Index(i), fmt.Sprintf("%s[%d]", name, i), opts)
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, nested...)
+		}
+		return violations, nil
+	default:
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("cannot dive into %s of kind %s", name, value.Kind()))
+	}
+}
+
+// structFields returns the fields of t with rules, parsed once per type
+func structFields(t reflect.Type) ([]field, error) {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field), nil
+	}
+	fields := []field{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(Tag)
+		if !sf.IsExported() || tag == "" || tag == "-" {
+			continue
+		}
+		rules, err := ParseRules(tag)
+		if err != nil {
+			return nil, infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("%s.%s: %w", t.Name(), sf.Name, err))
+		}
+		fields = append(fields, field{index: sf.Index, name: sf.Name, protoName: protoName(sf), rules: rules})
+	}
+	fieldCache.Store(t, fields)
+	return fields, nil
+}
+
+// protoName returns the name of sf in the protobuf tag of the generated messages, e.g.
+// `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3"`, its Go name for the other fields
+func protoName(sf reflect.StructField) string {
+	for _, option := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+		if name, ok := strings.CutPrefix(option, "name="); ok {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+// ParseRules parses the comma separated rules of a tag, unknown rules are an error
+func ParseRules(tag string) ([]Rule, error) {
+	rules := []Rule{}
+	for _, raw := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(raw), "=")
+		r := Rule{name: name, param: param}
+		switch name {
+		case ruleRequired, ruleRequiredOnUpdate, ruleEmail, ruleObjectID, rulePort, rulePositive, ruleDive:
+		case ruleOneOf:
+			if param == "" {
+				return nil, fmt.Errorf("rule %s needs values", name)
+			}
+		case ruleMin, ruleMax:
+			if _, err := strconv.ParseFloat(param, 64); err != nil {
+				return nil, fmt.Errorf("rule %s needs a number: %w", name, err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown rule %q", raw)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// Check returns the violations of value against rules, parsed with ParseRules. dive is not supported and the
+// Field of the violations is left empty
+func Check(value reflect.Value, rules []Rule, update bool) []Violation {
+	violations := []Violation{}
+	for _, r := range rules {
+		if violation := check(value, r, update); violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+	return violations
+}
+
+// check returns the violation of r by value, nil when it holds
+func check(value reflect.Value, r Rule, update bool) *Violation {
+	violation := func(def infra_error.ErrorDef, format string, args ...any) *Violation {
+		return &Violation{Rule: r.name, Def: def, Message: fmt.Sprintf(format, args...)}
+	}
+	switch r.name {
+	case ruleRequired:
+		if value.IsZero() {
+			return violation(infra_error.ValidationRequiredFields, "is required")
+		}
+		return nil
+	case ruleRequiredOnUpdate:
+		if update && value.IsZero() {
+			return violation(infra_error.ValidationRequiredFields, "is required")
+		}
+		return nil
+	case rulePositive:
+		if !isPositive(value) {
+			return violation(infra_error.ValidationOutOfRange, "must be positive")
+		}
+		return nil
+	case rulePort:
+		if port, ok := number(value); !ok || port < 1 || port > 65535 {
+			return violation(infra_error.ValidationOutOfRange, "must be a port between 1 and 65535, got %v", value.Interface())
+		}
+		return nil
+	}
+	if value.IsZero() {
+		return nil
+	}
+	switch r.name {
+	case ruleEmail:
+		if value.Kind() != reflect.String || !IsEmail(value.String()) {
+			return violation(infra_error.ValidationInvalidEmail, "must be an email address")
+		}
+	case ruleOneOf:
+		allowed := strings.Fields(r.param)
+		current := fmt.Sprint(value.Interface())
+		for _, option := range allowed {
+			if current == option {
+				return nil
+			}
+		}
+		return violation(infra_error.ValidationInvalidValue, "must be one of %s, got %q", strings.Join(allowed, ", "), current)
+	case ruleMin, ruleMax:
+		return checkBound(value, r, violation)
+	case ruleObjectID:
+		if value.Kind() != reflect.String || !IsObjectID(value.String()) {
+			return violation(infra_error.ValidationInvalidID, "must be an ObjectID")
+		}
+	}
+	return nil
+}
+
+// checkBound checks the min and max rules, on lengths for strings, slices and maps and on values for numbers
+func checkBound(value reflect.Value, r Rule, violation func(infra_error.ErrorDef, string, ...any) *Violation) *Violation {
+	bound, _ := strconv.ParseFloat(r.param, 64)
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		length := float64(value.Len())
+		unit := "items"
+		if value.Kind() == reflect.String {
+			length = float64(len([]rune(value.String())))
+			unit = "characters long"
+		}
+		if r.name == ruleMin && length < bound {
+			return violation(infra_error.ValidationTooShort, "must be at least %s %s", r.param, unit)
+		}
+		if r.name == ruleMax && length > bound {
+			return violation(infra_error.ValidationTooLong, "must be at most %s %s", r.param, unit)
+		}
+		return nil
+	}
+	n, ok := number(value)
+	if !ok {
+		return violation(infra_error.ValidationInvalidType, "cannot be bounded, it is a %s", value.Kind())
+	}
+	if r.name == ruleMin && n < bound {
+		return violation(infra_error.ValidationOutOfRange, "must be at least %s, got %v", r.param, value.Interface())
+	}
+	if r.name == ruleMax && n > bound {
+		return violation(infra_error.ValidationOutOfRange, "must be at most %s, got %v", r.param, value.Interface())
+	}
+	return nil
+}
+
+// IsEmail reports whether email is an email address of at most 254 characters (RFC 5321)
+func IsEmail(email string) bool {
+	email = strings.TrimSpace(email)
+	return len(email) <= 254 && emailRegex.MatchString(email)
+}
+
+// IsObjectID reports whether s is a hex encoded MongoDB ObjectID
+func IsObjectID(s string) bool {
+	if len(s) != 24 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func number(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func isPositive(v reflect.Value) bool {
+	n, ok := number(v)
+	return ok && n > 0
+}
+
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	infra_error "erp.localhost/internal/infra/error"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	City string `validate:"required"`
+}
+
+type testModel struct {
+	Id        string         `validate:"required_on_update,objectid"`
+	TenantId  string         `validate:"required"`
+	Email     string         `validate:"email"`
+	Theme     string         `validate:"oneof=light dark auto"`
+	Name      string         `validate:"min=2,max=5"`
+	Tags      []string       `validate:"max=2"`
+	Priority  int32          `validate:"min=1,max=10"`
+	Address   *testAddress   `validate:"dive"`
+	Addresses []*testAddress `validate:"dive"`
+	Ignored   string
+}
+
+func validModel() *testModel {
+	return &testModel{Id: "65a1f0c2e4b0a1b2c3d4e5f6", TenantId: "tenant-1", Email: "john@example.com", Theme: "dark", Name: "john"}
+}
+
+func TestStruct(t *testing.T) {
+	require.NoError(t, Struct(validModel(), true))
+	// Optional fields are only checked when set
+	require.NoError(t, Struct(&testModel{TenantId: "tenant-1"}, false))
+
+	testCases := []struct {
+		name   string
+		update bool
+		modify func(m *testModel)
+		def    infra_error.ErrorDef
+	}{
+		{name: "missing", modify: func(m *testModel) { m.TenantId = "" }, def: infra_error.ValidationRequiredFields},
+		{name: "missing on update", update: true, modify: func(m *testModel) { m.Id = "" }, def: infra_error.ValidationRequiredFields},
+		{name: "email", modify: func(m *testModel) { m.Email = "john" }, def: infra_error.ValidationInvalidEmail},
+		{name: "oneof", modify: func(m *testModel) { m.Theme = "blue" }, def: infra_error.ValidationInvalidValue},
+		{name: "too short", modify: func(m *testModel) { m.Name = "j" }, def: infra_error.ValidationTooShort},
+		{name: "too long", modify: func(m *testModel) { m.Name = "johnny" }, def: infra_error.ValidationTooLong},
+		{name: "too many", modify: func(m *testModel) { m.Tags = []string{"a", "b", "c"} }, def: infra_error.ValidationTooLong},
+		{name: "out of range", modify: func(m *testModel) { m.Priority = 11 }, def: infra_error.ValidationOutOfRange},
+		{name: "objectid", modify: func(m *testModel) { m.Id = "not-an-object-id" }, def: infra_error.ValidationInvalidID},
+		{name: "nested", modify: func(m *testModel) { m.Address = &testAddress{} }, def: infra_error.ValidationRequiredFields},
+		{name: "nested slice", modify: func(m *testModel) { m.Addresses = []*testAddress{{City: "Paris"}, {}} }, def: infra_error.ValidationRequiredFields},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			model := validModel()
+			tc.modify(model)
+			err := Struct(model, tc.update)
+			assert.True(t, infra_error.Validation(tc.def).Is(err), err)
+		})
+	}
+
+	// Every missing field is reported
+	violations, err := Violations(&testModel{Addresses: []*testAddress{{}}}, true)
+	require.NoError(t, err)
+	fields := []string{}
+	for _, violation := range violations {
+		fields = append(fields, violation.Field)
+	}
+	assert.Equal(t, []string{"Id", "TenantId", "Addresses[0].City"}, fields)
+
+	assert.True(t, infra_error.Validation(infra_error.ValidationRequiredFields).Is(Struct((*testModel)(nil), false)))
+	assert.Error(t, Struct("not a struct", false))
+	assert.Error(t, Struct(&struct {
+		Name string `validate:"unknown"`
+	}{}, false))
+}
+
+func TestMessage(t *testing.T) {
+	require.NoError(t, Message(&authv1.GetRoleRequest{
+		Identifier:     &infrav1.UserIdentifier{TenantId: "tenant-1", UserId: "user-1"},
+		RoleId:         "role-1",
+		TargetTenantId: "tenant-1",
+	}))
+
+	// The violations are named by the proto fields
+	err := Message(&authv1.GetRoleRequest{Identifier: &infrav1.UserIdentifier{UserId: "user-1"}, RoleId: "role-1"})
+	var appErr *infra_error.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, infra_error.ValidationRequiredFields.Code, appErr.Code)
+	assert.Equal(t, []string{"identifier.tenant_id", "target_tenant_id"}, appErr.Details["fields"])
+
+	err = Message(&authv1.GetRoleRequest{RoleId: "role-1", TargetTenantId: "tenant-1"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, []string{"identifier"}, appErr.Details["fields"])
+}
+
+func TestCheck(t *testing.T) {
+	rules, err := ParseRules("port")
+	require.NoError(t, err)
+	assert.Empty(t, Check(reflect.ValueOf(8080), rules, false))
+	violations := Check(reflect.ValueOf(0), rules, false)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "must be a port between 1 and 65535, got 0", violations[0].Message)
+
+	rules, err = ParseRules("positive")
+	require.NoError(t, err)
+	assert.Len(t, Check(reflect.ValueOf(-1), rules, false), 1)
+
+	_, err = ParseRules("min=abc")
+	assert.Error(t, err)
+	_, err = ParseRules("oneof")
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	infra_config "erp.localhost/internal/infra/config"
+	"erp.localhost/internal/infra/secret"
+)
+
+// Config holds the settings of the init job, see infra_config.Load for how they are resolved
+type Config struct {
+	Mongo   infra_config.Mongo `yaml:"mongo"`
+	Secrets secret.Config      `yaml:"secrets"`
+	// Disabled skips the seeding, e.g. for deployments seeded by a migration
+	Disabled bool `yaml:"disabled" env:"DISABLE_INIT" flag:"disable-init"`
+	// ManifestDir holds the seed manifests, the manifests embedded in the binary are applied when it is empty
+	ManifestDir string `yaml:"manifest_dir" env:"SEED_MANIFEST_DIR" flag:"seed-manifest-dir"`
+	// CredentialsFile receives the generated passwords of the seeded users, e.g. of the system admin, they are
+	// printed to stdout when it is empty
+	CredentialsFile string `yaml:"credentials_file" env:"SEED_CREDENTIALS_FILE" flag:"seed-credentials-file"`
+}
+
+// loadConfig loads the settings of the job from its defaults, CONFIG_FILE, the environment and args
+func loadConfig(args []string) (*Config, error) {
+	config := &Config{}
+	if err := infra_config.Load(config, infra_config.WithArgs(args)); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
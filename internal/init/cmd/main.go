@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
 
+	"erp.localhost/internal/infra/buildinfo"
+	db_mongo "erp.localhost/internal/infra/db/mongo"
 	"erp.localhost/internal/infra/logging/logger"
 	shared "erp.localhost/internal/infra/model/shared"
+	"erp.localhost/internal/infra/secret"
 	"erp.localhost/internal/init/seeder"
 )
 
@@ -13,21 +18,45 @@ func Main() {
 	logger := logger.NewBaseLogger(shared.ModuleInit)
 	defer logger.Close()
 
-	disableInit := getEnv("DISABLE_INIT", "")
-	if disableInit != "" {
+	config, err := loadConfig(os.Args[1:])
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	if config.Disabled {
 		logger.Info("ERP System - Init Service disabled")
 		return
 	}
 	logger.Info("ERP System - Init Service Started")
+	// The manifests are checked before connecting to the database
+	manifests, err := seeder.LoadManifests(config.ManifestDir)
+	if err != nil {
+		logger.Error("invalid seed manifests", "error", err)
+		os.Exit(1)
+	}
+	secrets, closeMongo, err := connectMongo(config, logger)
+	if err != nil {
+		logger.Error("failed to create secret provider", "error", err)
+		os.Exit(1)
+	}
+	// Disconnects the clients of the seeder on exit
+	defer closeMongo()
 
 	// Run seeding
-	logger.Info("Starting system data seeding")
+	logger.Info("Starting system data seeding", buildinfo.Get().LogArgs()...)
 	s, err := seeder.NewSeeder(logger)
 	if err != nil {
 		logger.Fatal("failed to init seeder", "error", err)
 		os.Exit(1)
 	}
-	if err := s.SeedSystemData(); err != nil {
+	// The passwords of the seeded users are read from the secret store, the generated ones are written once
+	s.SetSecretLookup(func(name string) (string, bool) {
+		return secret.Lookup(context.Background(), secrets, name, logger)
+	})
+	if config.CredentialsFile != "" {
+		s.SetCredentialsSink(credentialsFile(config.CredentialsFile))
+	}
+	if err := s.SeedSystemData(context.Background(), manifests); err != nil {
 		logger.Error("Seeding failed", "error", err)
 		os.Exit(1)
 	}
@@ -36,10 +65,34 @@ func Main() {
 	logger.Info("Init Service - Exiting")
 }
 
-// getEnv gets an environment variable or returns a default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// connectMongo sets the URI of the Mongo clients, read from the secret store selected by SECRETS_PROVIDER when it
+// holds one, and returns the store. The returned function disconnects the clients and closes the store
+func connectMongo(config *Config, logger logger.Logger) (secret.SecretProvider, func(), error) {
+	secrets, err := secret.New(&config.Secrets, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	if uri, ok := secret.Lookup(context.Background(), secrets, secret.MongoURI, logger); ok {
+		config.Mongo.URI = uri
+	}
+	db_mongo.SetURI(config.Mongo.URI)
+	return secrets, func() {
+		db_mongo.CloseClients(context.Background())
+		secrets.Close()
+	}, nil
+}
+
+// credentialsFile appends the generated passwords to path, readable by its owner only
+func credentialsFile(path string) seeder.CredentialsSink {
+	return func(tenant, email, password string) error {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(file, "%s %s %s\n", tenant, email, password); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
 	}
-	return defaultValue
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"erp.localhost/internal/infra/logging/logger"
+	shared "erp.localhost/internal/infra/model/shared"
+	"erp.localhost/internal/init/migrate"
+)
+
+// Migrate upgrades the stored documents to the latest schema versions and exits, see the migrate package. With the
+// status argument it logs the recorded and latest versions of the collections instead
+func Migrate(args []string) {
+	logger := logger.NewBaseLogger(shared.ModuleInit)
+	defer logger.Close()
+
+	config, err := loadConfig(args)
+	if err != nil {
+		logger.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	_, closeMongo, err := connectMongo(config, logger)
+	if err != nil {
+		logger.Error("failed to create secret provider", "error", err)
+		os.Exit(1)
+	}
+	defer closeMongo()
+
+	runner, err := migrate.NewRunner(logger)
+	if err != nil {
+		logger.Error("failed to init migrations", "error", err)
+		os.Exit(1)
+	}
+	defer runner.Close()
+
+	if len(args) > 0 && args[0] == "status" {
+		results, err := runner.Status(context.Background())
+		if err != nil {
+			logger.Error("failed to read migration status", "error", err)
+			os.Exit(1)
+		}
+		for _, result := range results {
+			logger.Info("Migration status", "collection", result.Collection, "applied_version", result.From, "latest_version", result.To)
+		}
+		return
+	}
+
+	logger.Info("Migrating documents")
+	results, err := runner.Run(context.Background())
+	if err != nil {
+		logger.Error("Migration failed", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("Documents migrated", "collections", len(results))
+}
@@ -0,0 +1,235 @@
+// Package migrate upgrades the stored documents of the collections with migrations to their latest schema version,
+// see migration.Register. It is run by `erp migrate`, before or after deploying a release with new migrations: the
+// services upgrade the documents they read meanwhile
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/db/memory"
+	mongo_db "erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/db/mongo/migration"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
+)
+
+// recordKind is the kind of the migrations records of the schema versions applied to the collections
+const recordKind = "schema"
+
+// Record is the schema version applied to a collection, stored in the migrations collection
+type Record struct {
+	ID        string    `bson:"_id,omitempty"`
+	Kind      string    `bson:"kind"`
+	Name      string    `bson:"name"`
+	Version   int       `bson:"version"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// Result is the outcome of the migration of a collection
+type Result struct {
+	Collection model_mongo.Collection
+	// From is the version recorded before the run, 0 for a collection never migrated
+	From int
+	To   int
+	// Upgraded is the number of documents stored at To
+	Upgraded int
+	// Skipped is the number of documents written by a service during the run, they are upgraded when read
+	Skipped int
+}
+
+// Runner upgrades the documents of the collections with migrations
+type Runner struct {
+	logger  logger.Logger
+	records *collection.BaseCollectionHandler[Record]
+	// handlers are the database handlers of the databases of the collections, by database name
+	handlers map[model_mongo.DBName]db.DBHandler
+}
+
+// NewRunner creates a runner recording the applied versions in the migrations collection
+func NewRunner(logger logger.Logger) (*Runner, error) {
+	records, err := collection.NewBaseCollectionHandler[Record](model_mongo.AuthDB, model_mongo.MigrationsCollection, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{
+		logger:   logger,
+		records:  records,
+		handlers: map[model_mongo.DBName]db.DBHandler{},
+	}, nil
+}
+
+// Close closes the database handlers of the runner
+func (r *Runner) Close() {
+	for _, handler := range r.handlers {
+		handler.Close()
+	}
+}
+
+// Run upgrades the documents of every collection with migrations and records the versions applied. It stops at the
+// first document a migration fails on, the documents upgraded before stay upgraded
+func (r *Runner) Run(ctx context.Context) ([]Result, error) {
+	results := []Result{}
+	for _, name := range migration.Collections() {
+		result, err := r.migrate(ctx, name)
+		if err != nil {
+			return results, fmt.Errorf("failed to migrate %s: %w", name, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Status returns the versions recorded for the collections with migrations and their latest versions
+func (r *Runner) Status(ctx context.Context) ([]Result, error) {
+	results := []Result{}
+	for _, name := range migration.Collections() {
+		from, err := r.applied(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, Result{Collection: name, From: from, To: migration.Latest(name)})
+	}
+	return results, nil
+}
+
+func (r *Runner) migrate(ctx context.Context, name model_mongo.Collection) (Result, error) {
+	result := Result{Collection: name, To: migration.Latest(name)}
+	from, err := r.applied(ctx, name)
+	if err != nil {
+		return result, err
+	}
+	result.From = from
+	if from > result.To {
+		r.logger.Warn("collection migrated by a newer release", "collection", name, "applied_version", from, "latest_version", result.To)
+	}
+
+	handler, err := r.handler(name)
+	if err != nil {
+		return result, err
+	}
+	// The documents at an older version, those stored before the first migration have no version
+	filter := map[string]any{"$or": []map[string]any{
+		{migration.VersionField: map[string]any{"$lt": result.To}},
+		{migration.VersionField: map[string]any{"$exists": false}},
+	}}
+	docs := []bson.M{}
+	if err := handler.FindAll(ctx, string(name), filter, &docs); err != nil {
+		return result, infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+
+	for _, doc := range docs {
+		upgraded, err := r.upgrade(ctx, handler, name, doc)
+		if err != nil {
+			return result, err
+		}
+		if upgraded {
+			result.Upgraded++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	if result.To > from {
+		if err := r.record(ctx, name, result.To); err != nil {
+			return result, err
+		}
+	}
+	r.logger.Info("Collection migrated", "collection", name, "from", from, "to", result.To,
+		"upgraded", result.Upgraded, "skipped", result.Skipped)
+	return result, nil
+}
+
+// upgrade stores doc upgraded, unless it was written since it was read
+func (r *Runner) upgrade(ctx context.Context, handler db.DBHandler, name model_mongo.Collection, doc bson.M) (bool, error) {
+	id := doc["_id"]
+	version := migration.Version(doc)
+	fields := make([]string, 0, len(doc))
+	for field := range doc {
+		fields = append(fields, field)
+	}
+	if _, err := migration.Upgrade(name, doc); err != nil {
+		return false, infra_error.Internal(infra_error.InternalUnexpectedError, fmt.Errorf("document %v: %w", id, err))
+	}
+	delete(doc, "_id")
+
+	// The fields the migrations removed, e.g. the old names of renamed fields
+	unset := []string{}
+	for _, field := range fields {
+		if _, ok := doc[field]; !ok && field != "_id" {
+			unset = append(unset, field)
+		}
+	}
+	slices.Sort(unset)
+	opts := map[string]any{mongo_db.UpdateOptionRequireMatch: true}
+	if len(unset) > 0 {
+		opts[mongo_db.UpdateOptionUnset] = unset
+	}
+
+	filter := map[string]any{"_id": id, migration.VersionField: version}
+	if version == 0 {
+		filter[migration.VersionField] = map[string]any{"$in": bson.A{int32(0), nil}}
+	}
+	err := handler.Update(ctx, string(name), filter, doc, opts)
+	if errors.Is(err, mongo_db.ErrNoMatch) {
+		r.logger.Debug("document written during the migration, skipped", "collection", name, "id", id)
+		return false, nil
+	}
+	if err != nil {
+		return false, infra_error.Internal(infra_error.InternalDatabaseError, err)
+	}
+	return true, nil
+}
+
+// applied returns the version recorded for the collection, 0 when it was never migrated
+func (r *Runner) applied(ctx context.Context, name model_mongo.Collection) (int, error) {
+	record, err := r.records.FindOne(ctx, map[string]any{"kind": recordKind, "name": string(name)})
+	if errors.Is(err, driver_mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return record.Version, nil
+}
+
+func (r *Runner) record(ctx context.Context, name model_mongo.Collection, version int) error {
+	filter := map[string]any{"kind": recordKind, "name": string(name)}
+	update := map[string]any{"$set": map[string]any{"version": version, "applied_at": time.Now().UTC()}}
+	if _, err := r.records.FindOneAndUpdate(ctx, filter, update, true); err != nil {
+		return fmt.Errorf("failed to record the version: %w", err)
+	}
+	return nil
+}
+
+// handler returns the database handler of the database of the collection
+func (r *Runner) handler(name model_mongo.Collection) (db.DBHandler, error) {
+	dbName := model_mongo.DBName(model_mongo.GetDBNameFromCollection(string(name)))
+	if dbName == "" {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "collection").
+			WithError(fmt.Errorf("%s is not a collection of a known database", name))
+	}
+	if handler, ok := r.handlers[dbName]; ok {
+		return handler, nil
+	}
+	var handler db.DBHandler
+	if memory.Enabled() {
+		handler = memory.SharedDocuments(string(dbName))
+	} else {
+		manager, err := mongo_db.NewMongoDBManager(dbName, r.logger)
+		if err != nil {
+			return nil, err
+		}
+		handler = manager
+	}
+	r.handlers[dbName] = handler
+	return handler, nil
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/db/mongo/migration"
+	"erp.localhost/internal/infra/logging/logger"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRunner_Run(t *testing.T) {
+	memory.Enable()
+	ctx := context.Background()
+	documents := memory.SharedDocuments(string(model_mongo.CoreDB))
+	t.Cleanup(func() {
+		documents.Reset()
+		memory.SharedDocuments(string(model_mongo.AuthDB)).Reset()
+		migration.Reset(model_mongo.ProductsCollection)
+	})
+
+	products := string(model_mongo.ProductsCollection)
+	oldID, err := documents.Create(ctx, products, bson.M{"name": "Widget", "qty": int64(5)})
+	require.NoError(t, err)
+	currentID, err := documents.Create(ctx, products, bson.M{"name": "Gadget", "quantity": int64(2), migration.VersionField: int32(1)})
+	require.NoError(t, err)
+	migration.Register(migration.Migration{
+		Collection:  model_mongo.ProductsCollection,
+		Version:     1,
+		Description: "rename qty to quantity",
+		Up: func(doc bson.M) error {
+			doc["quantity"] = doc["qty"]
+			delete(doc, "qty")
+			return nil
+		},
+	})
+
+	runner, err := NewRunner(logger.NewBaseLogger(shared.ModuleInit))
+	require.NoError(t, err)
+	defer runner.Close()
+
+	results, err := runner.Run(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, Result{Collection: model_mongo.ProductsCollection, From: 0, To: 1, Upgraded: 1}, results[0])
+
+	stored := bson.M{}
+	require.NoError(t, documents.FindOne(ctx, products, map[string]any{"_id": oldID}, &stored))
+	assert.Equal(t, int64(5), stored["quantity"])
+	assert.NotContains(t, stored, "qty")
+	assert.Equal(t, 1, migration.Version(stored))
+	require.NoError(t, documents.FindOne(ctx, products, map[string]any{"_id": currentID}, &stored))
+	assert.Equal(t, int64(2), stored["quantity"])
+
+	// The collection is recorded at its latest version, a second run finds nothing to upgrade
+	status, err := runner.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Result{{Collection: model_mongo.ProductsCollection, From: 1, To: 1}}, status)
+	results, err = runner.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, results[0].Upgraded)
+}
@@ -0,0 +1,256 @@
+package seeder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultManifests are the manifests applied when no manifest directory is configured
+//
+//go:embed manifests/*.yaml
+var defaultManifests embed.FS
+
+// Manifest declares the data a seed stores, see Seeder.Apply. Items are found by their well-known keys in their
+// tenant: the name of a tenant, the permission string of a permission, the name of a role and the email of a user.
+// The missing items are created and the existing ones are left as they are, so a manifest is applied on every run
+type Manifest struct {
+	// Name identifies the manifest in the migrations collection
+	Name string `yaml:"name"`
+	// Version is recorded once the manifest is applied, it is raised when the manifest changes
+	Version     int              `yaml:"version"`
+	Tenants     []TenantSeed     `yaml:"tenants"`
+	Permissions []PermissionSeed `yaml:"permissions"`
+	Roles       []RoleSeed       `yaml:"roles"`
+	Users       []UserSeed       `yaml:"users"`
+
+	// checksum is the SHA-256 of the manifest file
+	checksum string
+}
+
+// TenantSeed is a tenant, keyed by its name
+type TenantSeed struct {
+	Name   string `yaml:"name"`
+	Domain string `yaml:"domain"`
+}
+
+// PermissionSeed is a permission of a tenant, keyed by the permission string of its resource and action
+type PermissionSeed struct {
+	Tenant      string `yaml:"tenant"`
+	Resource    string `yaml:"resource"`
+	Action      string `yaml:"action"`
+	DisplayName string `yaml:"display_name"`
+	Description string `yaml:"description"`
+	IsDangerous bool   `yaml:"is_dangerous"`
+}
+
+// PermissionString returns the key of the permission, e.g. "user:read". The wildcard of all the resources and
+// actions is allowed, it is the permission of the administrators
+func (p PermissionSeed) PermissionString() (string, error) {
+	if p.Resource == auth.ResourceTypeAll && p.Action == auth.PermissionActionAll {
+		return permissions.Wildcard, nil
+	}
+	return auth.CreatePermissionString(p.Resource, p.Action)
+}
+
+// RoleSeed is a role of a tenant, keyed by its name. Permissions are the permission strings of permissions of the
+// manifest in the same tenant, they are added to the role when it already exists
+type RoleSeed struct {
+	Tenant      string   `yaml:"tenant"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// UserSeed is a user of a tenant, keyed by its email. Roles are the names of roles of the manifest in the same
+// tenant. The password is read from the PasswordEnv environment variable when it is set, Password otherwise.
+// Without either, GeneratePassword creates the user with a random password the user must change on the first login
+type UserSeed struct {
+	Tenant           string   `yaml:"tenant"`
+	Username         string   `yaml:"username"`
+	Email            string   `yaml:"email"`
+	Password         string   `yaml:"password"`
+	PasswordEnv      string   `yaml:"password_env"`
+	GeneratePassword bool     `yaml:"generate_password"`
+	Roles            []string `yaml:"roles"`
+}
+
+// password returns the password of the user, from its environment variable when it is set
+func (u UserSeed) password() string {
+	if u.PasswordEnv != "" {
+		if value := os.Getenv(u.PasswordEnv); value != "" {
+			return value
+		}
+	}
+	return u.Password
+}
+
+// LoadManifests returns the manifests of dir, the .yaml, .yml and .json files in name order. The manifests
+// embedded in the binary are returned when dir is empty
+func LoadManifests(dir string) ([]*Manifest, error) {
+	if dir == "" {
+		sub, err := fs.Sub(defaultManifests, "manifests")
+		if err != nil {
+			return nil, err
+		}
+		return loadManifests(sub)
+	}
+	return loadManifests(os.DirFS(dir))
+}
+
+func loadManifests(fsys fs.FS) ([]*Manifest, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "manifests").WithError(err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		switch path.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+
+	manifests := make([]*Manifest, 0, len(names))
+	seen := map[string]string{}
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "manifests").WithError(err)
+		}
+		manifest, err := ParseManifest(data)
+		if err != nil {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidFormat, "manifests").WithError(fmt.Errorf("%s: %w", name, err))
+		}
+		if other, ok := seen[manifest.Name]; ok {
+			return nil, infra_error.Validation(infra_error.ValidationInvalidValue, "manifests").
+				WithError(fmt.Errorf("%s and %s are both named %q", other, name, manifest.Name))
+		}
+		seen[manifest.Name] = name
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+// ParseManifest decodes and validates a YAML or JSON manifest, unknown keys are rejected so typos are caught
+func ParseManifest(data []byte) (*Manifest, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	manifest := &Manifest{}
+	if err := decoder.Decode(manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	manifest.checksum = hex.EncodeToString(sum[:])
+	return manifest, nil
+}
+
+// Validate checks the manifest has a name and a version, and its items keys and references to the other items of
+// the manifest
+func (m *Manifest) Validate() error {
+	var problems []string
+	if m.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if m.Version < 1 {
+		problems = append(problems, "version must be positive")
+	}
+
+	tenants := map[string]bool{}
+	for _, tenant := range m.Tenants {
+		if tenant.Name == "" {
+			problems = append(problems, "tenant name is required")
+			continue
+		}
+		if tenants[tenant.Name] {
+			problems = append(problems, fmt.Sprintf("tenant %q is declared twice", tenant.Name))
+		}
+		tenants[tenant.Name] = true
+	}
+	checkTenant := func(kind, key, tenant string) {
+		if !tenants[tenant] {
+			problems = append(problems, fmt.Sprintf("%s %q is in tenant %q which the manifest does not declare", kind, key, tenant))
+		}
+	}
+
+	permissionKeys := map[string]bool{}
+	for _, permission := range m.Permissions {
+		permissionString, err := permission.PermissionString()
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("permission %s:%s is invalid: %v", permission.Resource, permission.Action, err))
+			continue
+		}
+		checkTenant("permission", permissionString, permission.Tenant)
+		key := permission.Tenant + "/" + permissionString
+		if permissionKeys[key] {
+			problems = append(problems, fmt.Sprintf("permission %q is declared twice in tenant %q", permissionString, permission.Tenant))
+		}
+		permissionKeys[key] = true
+	}
+
+	roles := map[string]bool{}
+	for _, role := range m.Roles {
+		if role.Name == "" {
+			problems = append(problems, "role name is required")
+			continue
+		}
+		checkTenant("role", role.Name, role.Tenant)
+		key := role.Tenant + "/" + role.Name
+		if roles[key] {
+			problems = append(problems, fmt.Sprintf("role %q is declared twice in tenant %q", role.Name, role.Tenant))
+		}
+		roles[key] = true
+		for _, permission := range role.Permissions {
+			if !permissionKeys[role.Tenant+"/"+strings.ToLower(permission)] {
+				problems = append(problems, fmt.Sprintf("role %q references permission %q which its tenant does not declare", role.Name, permission))
+			}
+		}
+	}
+
+	users := map[string]bool{}
+	for _, user := range m.Users {
+		if user.Email == "" {
+			problems = append(problems, "user email is required")
+			continue
+		}
+		checkTenant("user", user.Email, user.Tenant)
+		key := user.Tenant + "/" + user.Email
+		if users[key] {
+			problems = append(problems, fmt.Sprintf("user %q is declared twice in tenant %q", user.Email, user.Tenant))
+		}
+		users[key] = true
+		if user.Password == "" && user.PasswordEnv == "" && !user.GeneratePassword {
+			problems = append(problems, fmt.Sprintf("user %q needs a password, a password_env or generate_password", user.Email))
+		}
+		for _, role := range user.Roles {
+			if !roles[user.Tenant+"/"+role] {
+				problems = append(problems, fmt.Sprintf("user %q references role %q which its tenant does not declare", user.Email, role))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return infra_error.Validation(infra_error.ValidationInvalidValue, "manifest").WithError(errors.New(strings.Join(problems, "; ")))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package seeder
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadManifests_Embedded(t *testing.T) {
+	manifests, err := LoadManifests("")
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	assert.Equal(t, "system", manifests[0].Name)
+	assert.NotEmpty(t, manifests[0].checksum)
+}
+
+func TestLoadManifests(t *testing.T) {
+	fsys := fstest.MapFS{
+		"02-demo.json":  {Data: []byte(`{"name": "demo", "version": 2, "tenants": [{"name": "demo"}]}`)},
+		"01-system.yml": {Data: []byte("name: system\nversion: 1\n")},
+		"README.md":     {Data: []byte("# Manifests")},
+	}
+	manifests, err := loadManifests(fsys)
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+	assert.Equal(t, "system", manifests[0].Name)
+	assert.Equal(t, "demo", manifests[1].Name)
+	assert.Equal(t, 2, manifests[1].Version)
+
+	fsys["03-system.yaml"] = &fstest.MapFile{Data: []byte("name: system\nversion: 3\n")}
+	_, err = loadManifests(fsys)
+	assert.ErrorContains(t, err, "both named")
+}
+
+func TestParseManifest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		manifest string
+		problem  string
+	}{
+		{
+			name:     "unknown key",
+			manifest: "name: demo\nversion: 1\nroless: []\n",
+			problem:  "roless",
+		},
+		{
+			name:     "no version",
+			manifest: "name: demo\n",
+			problem:  "version must be positive",
+		},
+		{
+			name:     "undeclared tenant",
+			manifest: "name: demo\nversion: 1\nroles:\n  - {tenant: demo, name: admin}\n",
+			problem:  `role "admin" is in tenant "demo" which the manifest does not declare`,
+		},
+		{
+			name: "undeclared permission",
+			manifest: "name: demo\nversion: 1\ntenants: [{name: demo}]\n" +
+				"roles:\n  - {tenant: demo, name: admin, permissions: [\"user:read\"]}\n",
+			problem: `role "admin" references permission "user:read"`,
+		},
+		{
+			name: "user without password",
+			manifest: "name: demo\nversion: 1\ntenants: [{name: demo}]\n" +
+				"users:\n  - {tenant: demo, email: admin@demo.com}\n",
+			problem: "needs a password",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tc.manifest))
+			assert.ErrorContains(t, err, tc.problem)
+		})
+	}
+}
+
+func TestUserSeed_Password(t *testing.T) {
+	user := UserSeed{Password: "default", PasswordEnv: "SEED_TEST_PASSWORD"}
+	assert.Equal(t, "default", user.password())
+	t.Setenv("SEED_TEST_PASSWORD", "from-env")
+	assert.Equal(t, "from-env", user.password())
+}
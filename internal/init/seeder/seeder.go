@@ -1,21 +1,42 @@
 package seeder
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	collection_auth "erp.localhost/internal/auth/collection"
 	"erp.localhost/internal/auth/hash"
 	"erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/db/memory"
 	mongo_db "erp.localhost/internal/infra/db/mongo"
+	"erp.localhost/internal/infra/db/mongo/codec"
+	"erp.localhost/internal/infra/db/mongo/collection"
 	infra_error "erp.localhost/internal/infra/error"
 	"erp.localhost/internal/infra/logging/logger"
-	"erp.localhost/internal/infra/model/auth"
 	authv1 "erp.localhost/internal/infra/model/auth/v1"
 	model_mongo "erp.localhost/internal/infra/model/db/mongo"
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	driver_mongo "go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// seedRecordKind is the kind of the migrations records of applied manifests
+const seedRecordKind = "seed"
+
+// seedRecord is the record of an applied manifest in the migrations collection
+type seedRecord struct {
+	ID        string    `bson:"_id,omitempty"`
+	Kind      string    `bson:"kind"`
+	Name      string    `bson:"name"`
+	Version   int       `bson:"version"`
+	Checksum  string    `bson:"checksum"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
 type Seeder struct {
 	logger logger.Logger
 
@@ -24,8 +45,18 @@ type Seeder struct {
 	userHandler       *collection_auth.UserCollection
 	permissionHandler *collection_auth.PermissionCollection
 	roleHandler       *collection_auth.RoleCollection
+	migrationHandler  *collection.BaseCollectionHandler[seedRecord]
+
+	// Reads the password_env secrets of the users, the environment by default
+	lookupSecret func(name string) (string, bool)
+	// Receives the generated passwords of the inserted users, printed by default
+	credentials CredentialsSink
 }
 
+// CredentialsSink receives the password generated for a new user, it is given out only once. An error undoes the
+// insert of the user, the next run generates a new password
+type CredentialsSink func(tenant, email, password string) error
+
 func NewSeeder(logger logger.Logger) (*Seeder, error) {
 	th, err := collection_auth.NewTenantCollection(logger)
 	if err != nil {
@@ -47,256 +78,344 @@ func NewSeeder(logger logger.Logger) (*Seeder, error) {
 		logger.Fatal("failed to create role collection", "error", err)
 		return nil, err
 	}
+	mh, err := collection.NewBaseCollectionHandler[seedRecord](model_mongo.AuthDB, model_mongo.MigrationsCollection, logger)
+	if err != nil {
+		logger.Fatal("failed to create migrations collection", "error", err)
+		return nil, err
+	}
 	return &Seeder{
 		logger:            logger,
 		tenantHandler:     th,
 		userHandler:       uh,
 		permissionHandler: ph,
 		roleHandler:       rh,
+		migrationHandler:  mh,
+		lookupSecret:      lookupEnv,
+		credentials:       printCredentials,
 	}, nil
 }
 
-func (s *Seeder) SeedSystemData() error {
-	s.logger.Info("Seeding system data")
-
-	// Step 0: Create indexes BEFORE seeding data
-	if err := s.SeedIndexes(); err != nil {
-		return fmt.Errorf("failed to seed indexes: %w", err)
-	}
+// SetSecretLookup reads the password_env secrets of the users with lookup, e.g. from the secret store
+func (s *Seeder) SetSecretLookup(lookup func(name string) (string, bool)) {
+	s.lookupSecret = lookup
+}
 
-	// Step 1: Create system tenant
-	if err := s.seedSystemTenant(); err != nil {
-		return fmt.Errorf("failed to seed system tenant: %w", err)
-	}
-	s.logger.Info("System tenant seeded", "tenant_id", db.SystemTenantID)
+// SetCredentialsSink gives the generated passwords to sink instead of printing them
+func (s *Seeder) SetCredentialsSink(sink CredentialsSink) {
+	s.credentials = sink
+}
 
-	// Step 2: Create system permission
-	if err := s.seedSystemPermission(); err != nil {
-		return fmt.Errorf("failed to seed system permission: %w", err)
-	}
-	s.logger.Info("System permission seeded", "permission_id", db.SystemAdminPermissionID)
+// SeedSystemData ensures the indexes and applies manifests, see LoadManifests. The seeding is idempotent, it is run
+// on every start of the init job
+func (s *Seeder) SeedSystemData(ctx context.Context, manifests []*Manifest) error {
+	s.logger.Info("Seeding system data")
 
-	// Step 3: Create system role
-	if err := s.seedSystemRole(); err != nil {
-		return fmt.Errorf("failed to seed system role: %w", err)
+	// Step 0: Create indexes BEFORE seeding data, the unique ones make the upserts of concurrent jobs safe
+	if err := s.SeedIndexes(ctx); err != nil {
+		return fmt.Errorf("failed to seed indexes: %w", err)
 	}
-	s.logger.Info("System role seeded", "role_id", db.SystemAdminRoleID)
 
-	// Step 4: Create system admin user
-	if err := s.seedSystemAdminUser(); err != nil {
-		return fmt.Errorf("failed to seed system admin user: %w", err)
+	// Step 1: Apply the manifests
+	for _, manifest := range manifests {
+		if err := s.Apply(ctx, manifest); err != nil {
+			return fmt.Errorf("failed to apply manifest %s: %w", manifest.Name, err)
+		}
 	}
-	s.logger.Info("System admin user seeded", "user_id", db.SystemAdminUserID)
+	s.logger.Info("System data seeded",
+		"tenant_id", db.SystemTenantID,
+		"permission_id", db.SystemAdminPermissionID,
+		"role_id", db.SystemAdminRoleID,
+		"user_id", db.SystemAdminUserID,
+	)
 
 	return nil
 }
 
-// SeedIndexes ensures all indexes are created for system collections
-func (s *Seeder) SeedIndexes() error {
-	s.logger.Info("Creating indexes for system collections")
-
-	// Define collection-index mappings
-	indexMappings := []struct {
-		dbName     model_mongo.DBName
-		collection model_mongo.Collection
-		indexes    []mongo.IndexModel
-	}{
-		{
-			dbName:     model_mongo.AuthDB,
-			collection: model_mongo.TenantsCollection,
-			indexes:    model_mongo.GetTenantsIndexes(),
-		},
-		{
-			dbName:     model_mongo.AuthDB,
-			collection: model_mongo.UsersCollection,
-			indexes:    model_mongo.GetUsersIndexes(),
-		},
-		{
-			dbName:     model_mongo.AuthDB,
-			collection: model_mongo.RolesCollection,
-			indexes:    model_mongo.GetRolesIndexes(),
-		},
-		{
-			dbName:     model_mongo.AuthDB,
-			collection: model_mongo.PermissionsCollection,
-			indexes:    model_mongo.GetPermissionsIndexes(),
-		},
-		// {
-		// 	dbName:     model_mongo.EventDB,
-		// 	collection: model_mongo.AuditLogsCollection,
-		// 	indexes:    model_mongo.GetAuditLogsIndexes(),
-		// },
+// SeedIndexes ensures the indexes of every collection in the index registry
+func (s *Seeder) SeedIndexes(ctx context.Context) error {
+	if memory.Enabled() {
+		s.logger.Info("In-memory mode, indexes are not ensured")
+		return nil
 	}
+	s.logger.Info("Ensuring indexes of registered collections")
 
-	// Create indexes for each collection
-	for _, mapping := range indexMappings {
-		dbManager, err := mongo_db.NewMongoDBManager(mapping.dbName, s.logger)
-		if err != nil {
-			s.logger.Error(fmt.Sprintf("failed to create DB manager for %s", mapping.dbName), "error", err)
-			return err
+	managers := map[model_mongo.DBName]*mongo_db.MongoDBManager{}
+	defer func() {
+		for _, dbManager := range managers {
+			dbManager.Close()
+		}
+	}()
+
+	for _, definition := range model_mongo.IndexDefinitions() {
+		dbManager, ok := managers[definition.DB]
+		if !ok {
+			var err error
+			dbManager, err = mongo_db.NewMongoDBManager(definition.DB, s.logger)
+			if err != nil {
+				s.logger.Error(fmt.Sprintf("failed to create DB manager for %s", definition.DB), "error", err)
+				return err
+			}
+			managers[definition.DB] = dbManager
 		}
-		defer dbManager.Close()
 
-		if err := dbManager.EnsureIndexes(string(mapping.collection), mapping.indexes); err != nil {
-			s.logger.Error(fmt.Sprintf("failed to create indexes for %s.%s", mapping.dbName, mapping.collection), "error", err)
+		if err := dbManager.EnsureIndexes(ctx, string(definition.Collection), definition.Indexes()); err != nil {
+			s.logger.Error(fmt.Sprintf("failed to ensure indexes for %s.%s", definition.DB, definition.Collection), "error", err)
 			return err
 		}
 	}
 
-	s.logger.Info("All indexes created successfully")
+	s.logger.Info("All indexes ensured successfully")
 	return nil
 }
 
-func (s *Seeder) seedSystemTenant() error {
-	s.logger.Debug("Checking for existing system tenant")
-	// Check if system tenant already exists
-	filter := map[string]any{"name": db.SystemTenant}
-	existing, err := s.tenantHandler.FindOne(filter)
-	if err == nil && existing != nil {
-		s.logger.Info("System tenant already exists, skipping creation")
-		db.SystemTenantID = existing.Id
-		return nil
+// Apply stores the items of manifest missing from the database and records the applied version in the migrations
+// collection. The existing items are left as they are, except for the permissions of the manifest added to its roles
+func (s *Seeder) Apply(ctx context.Context, manifest *Manifest) error {
+	s.checkRecord(ctx, manifest)
+
+	tenantIDs := map[string]string{}
+	for _, seed := range manifest.Tenants {
+		tenant, err := upsert(ctx, s.tenantHandler.BaseCollectionHandler, map[string]any{"name": seed.Name}, &authv1.Tenant{
+			Name:      seed.Name,
+			Domain:    seed.Domain,
+			Status:    authv1.TenantStatus_TENANT_STATUS_ACTIVE,
+			CreatedBy: "System",
+			CreatedAt: timestamppb.Now(),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("tenant %s: %w", seed.Name, err)
+		}
+		tenantIDs[seed.Name] = tenant.Id
 	}
 
-	s.logger.Debug("Creating system tenant")
-
-	// Create new tenant
-	tenant := &authv1.Tenant{
-		Name:      db.SystemTenant,
-		Status:    authv1.TenantStatus_TENANT_STATUS_ACTIVE,
-		CreatedBy: "System",
+	permissionIDs := map[string]string{}
+	for _, seed := range manifest.Permissions {
+		tenantID := tenantIDs[seed.Tenant]
+		permissionString, err := seed.PermissionString()
+		if err != nil {
+			return err
+		}
+		filter := map[string]any{"tenant_id": tenantID, "permission_string": permissionString}
+		permission, err := upsert(ctx, s.permissionHandler.BaseCollectionHandler, filter, &authv1.Permission{
+			TenantId:         tenantID,
+			Resource:         seed.Resource,
+			Action:           seed.Action,
+			CreatedBy:        "System",
+			DisplayName:      seed.DisplayName,
+			Description:      seed.Description,
+			PermissionString: permissionString,
+			Status:           authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE,
+			IsDangerous:      seed.IsDangerous,
+			CreatedAt:        timestamppb.Now(),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("permission %s: %w", permissionString, err)
+		}
+		permissionIDs[seed.Tenant+"/"+permissionString] = permission.Id
 	}
 
-	tenantID, err := s.tenantHandler.Create(tenant)
-	if err != nil {
-		return infra_error.Internal(infra_error.InternalDatabaseError, err)
+	roleIDs := map[string]string{}
+	for _, seed := range manifest.Roles {
+		tenantID := tenantIDs[seed.Tenant]
+		permissions := make([]string, 0, len(seed.Permissions))
+		for _, permission := range seed.Permissions {
+			permissions = append(permissions, permissionIDs[seed.Tenant+"/"+strings.ToLower(permission)])
+		}
+		filter := map[string]any{"tenant_id": tenantID, "name": seed.Name}
+		role, err := upsert(ctx, s.roleHandler.BaseCollectionHandler, filter, &authv1.Role{
+			TenantId:    tenantID,
+			Name:        seed.Name,
+			Description: seed.Description,
+			Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
+			CreatedBy:   "System",
+			CreatedAt:   timestamppb.Now(),
+		}, map[string]any{"$addToSet": map[string]any{"permissions": map[string]any{"$each": permissions}}})
+		if err != nil {
+			return fmt.Errorf("role %s: %w", seed.Name, err)
+		}
+		roleIDs[seed.Tenant+"/"+seed.Name] = role.Id
 	}
 
-	db.SystemTenantID = tenantID
-	return nil
-}
-
-func (s *Seeder) seedSystemPermission() error {
-	s.logger.Debug("Checking for existing system permission")
+	userIDs := map[string]string{}
+	for _, seed := range manifest.Users {
+		tenantID := tenantIDs[seed.Tenant]
+		password, generated, err := s.password(seed)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", seed.Email, err)
+		}
+		passwordHash, err := hash.HashPassword(password)
+		if err != nil {
+			return infra_error.Internal(infra_error.InternalUnexpectedError, err)
+		}
+		roles := make([]*authv1.UserRole, 0, len(seed.Roles))
+		for _, role := range seed.Roles {
+			roles = append(roles, &authv1.UserRole{
+				TenantId:   tenantID,
+				RoleId:     roleIDs[seed.Tenant+"/"+role],
+				AssignedAt: timestamppb.Now(),
+				AssignedBy: "System",
+			})
+		}
+		filter := map[string]any{"tenant_id": tenantID, "email": seed.Email}
+		// The password and the roles are only set on the insert, the user may have changed them since
+		user, err := upsert(ctx, s.userHandler.BaseCollectionHandler, filter, &authv1.User{
+			TenantId:           tenantID,
+			Username:           seed.Username,
+			Email:              seed.Email,
+			PasswordHash:       passwordHash,
+			MustChangePassword: generated,
+			Status:             authv1.UserStatus_USER_STATUS_ACTIVE,
+			CreatedBy:          "System",
+			Roles:              roles,
+			CreatedAt:          timestamppb.Now(),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", seed.Email, err)
+		}
+		// The hashes are salted, the user has the generated password only when this run inserted it
+		if generated && user.PasswordHash == passwordHash {
+			if err := s.giveOutPassword(ctx, seed, user, password); err != nil {
+				return fmt.Errorf("user %s: %w", seed.Email, err)
+			}
+		}
+		userIDs[seed.Tenant+"/"+seed.Email] = user.Id
+	}
 
-	// Check if permission already exists
-	filter := map[string]any{
-		"tenant_id":         db.SystemTenantID,
-		"permission_string": db.TenantAdminPermission,
+	// The services find the system items by these ids
+	if id, ok := tenantIDs[db.SystemTenant]; ok {
+		db.SystemTenantID = id
 	}
-	existing, err := s.permissionHandler.FindOne(filter)
-	if err == nil && existing != nil {
-		s.logger.Info("System permission already exists, skipping creation")
-		db.SystemAdminPermissionID = existing.Id
-		return nil
+	if id, ok := permissionIDs[db.SystemTenant+"/"+db.TenantAdminPermission]; ok {
+		db.SystemAdminPermissionID = id
 	}
-
-	s.logger.Debug("Creating system permission")
-
-	// Create permission
-	permission := &authv1.Permission{
-		TenantId:         db.SystemTenantID,
-		Resource:         auth.ResourceTypeAll,
-		Action:           auth.PermissionActionAll,
-		CreatedBy:        "System",
-		DisplayName:      "System Controller",
-		Description:      "Full system access - all resources and actions",
-		PermissionString: db.TenantAdminPermission,
-		Status:           authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE,
-		IsDangerous:      true,
+	if id, ok := roleIDs[db.SystemTenant+"/"+db.SystemAdminUser]; ok {
+		db.SystemAdminRoleID = id
 	}
-
-	permissionID, err := s.permissionHandler.Create(permission)
-	if err != nil {
-		return infra_error.Internal(infra_error.InternalDatabaseError, err)
+	if id, ok := userIDs[db.SystemTenant+"/"+db.SystemAdminEmail]; ok {
+		db.SystemAdminUserID = id
 	}
 
-	db.SystemAdminPermissionID = permissionID
-	return nil
+	return s.record(ctx, manifest)
 }
 
-func (s *Seeder) seedSystemRole() error {
-	s.logger.Debug("Checking for existing system role")
-
-	// Check if role already exists
-	filter := map[string]any{
-		"tenant_id": db.SystemTenantID,
-		"name":      db.SystemAdminUser,
+// password returns the password of the user of seed, its secret when the secret store holds it. The generated
+// passwords are reported
+func (s *Seeder) password(seed UserSeed) (string, bool, error) {
+	if seed.PasswordEnv != "" {
+		if value, ok := s.lookupSecret(seed.PasswordEnv); ok && value != "" {
+			return value, false, nil
+		}
 	}
-	existing, err := s.roleHandler.FindOne(filter)
-	if err == nil && existing != nil {
-		s.logger.Info("System role already exists, skipping creation")
-		db.SystemAdminRoleID = existing.Id
-		return nil
+	if password := seed.password(); password != "" {
+		return password, false, nil
 	}
-
-	s.logger.Debug("Creating system role")
-
-	// Create role
-	role := &authv1.Role{
-		TenantId:    db.SystemTenantID,
-		Name:        db.SystemAdminUser,
-		Description: "System administrator role with full access to all resources",
-		Permissions: []string{db.SystemAdminPermissionID},
-		Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
-		CreatedBy:   "System",
+	if !seed.GeneratePassword {
+		return "", false, infra_error.Validation(infra_error.ValidationRequiredFields, "password")
 	}
+	password, err := hash.GeneratePassword()
+	return password, err == nil, err
+}
 
-	roleID, err := s.roleHandler.Create(role)
-	if err != nil {
-		return infra_error.Internal(infra_error.InternalDatabaseError, err)
+// giveOutPassword hands the generated password of the new user to the credentials sink, the user is deleted when it
+// fails so the password is not lost
+func (s *Seeder) giveOutPassword(ctx context.Context, seed UserSeed, user *authv1.User, password string) error {
+	if err := s.credentials(seed.Tenant, seed.Email, password); err != nil {
+		if deleteErr := s.userHandler.Delete(ctx, map[string]any{"_id": user.Id}); deleteErr != nil {
+			s.logger.Error("failed to delete user without credentials", "email", seed.Email, "error", deleteErr)
+		}
+		return fmt.Errorf("failed to give out the generated password: %w", err)
 	}
-
-	db.SystemAdminRoleID = roleID
+	s.logger.Warn("Generated the password of a new user, it must be changed on the first login", "tenant", seed.Tenant, "email", seed.Email)
 	return nil
 }
 
-func (s *Seeder) seedSystemAdminUser() error {
-	s.logger.Debug("Checking for existing system admin user")
+// lookupEnv reads the secrets of the users from the environment
+func lookupEnv(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+// printCredentials prints the generated passwords to stdout, apart from the logs that may be shipped elsewhere
+func printCredentials(tenant, email, password string) error {
+	_, err := fmt.Fprintf(os.Stdout, "Generated password of %s in tenant %s, shown once: %s\n", email, tenant, password)
+	return err
+}
 
-	// Check if user already exists
-	filter := map[string]any{
-		"tenant_id": db.SystemTenantID,
-		"email":     db.SystemAdminEmail,
+// checkRecord warns when manifest is older than the applied one, or changed without raising its version
+func (s *Seeder) checkRecord(ctx context.Context, manifest *Manifest) {
+	applied, err := s.migrationHandler.FindOne(ctx, map[string]any{"kind": seedRecordKind, "name": manifest.Name})
+	if err != nil {
+		if !errors.Is(err, driver_mongo.ErrNoDocuments) {
+			s.logger.Warn("failed to read the applied version of manifest", "manifest", manifest.Name, "error", err)
+		}
+		return
 	}
-	existing, err := s.userHandler.FindOne(filter)
-	if err == nil && existing != nil {
-		s.logger.Info("System admin user already exists, skipping creation")
-		db.SystemAdminUserID = existing.Id
-		return nil
+	switch {
+	case applied.Version > manifest.Version:
+		s.logger.Warn("manifest is older than the applied one",
+			"manifest", manifest.Name, "version", manifest.Version, "applied_version", applied.Version)
+	case applied.Version == manifest.Version && applied.Checksum != manifest.checksum:
+		s.logger.Warn("manifest changed without raising its version", "manifest", manifest.Name, "version", manifest.Version)
+	case applied.Version == manifest.Version:
+		s.logger.Info("Manifest already applied, ensuring its items", "manifest", manifest.Name, "version", manifest.Version)
 	}
+}
 
-	s.logger.Debug("Creating system admin user")
+// record stores the applied version of manifest in the migrations collection
+func (s *Seeder) record(ctx context.Context, manifest *Manifest) error {
+	filter := map[string]any{"kind": seedRecordKind, "name": manifest.Name}
+	update := map[string]any{"$set": map[string]any{
+		"version":    manifest.Version,
+		"checksum":   manifest.checksum,
+		"applied_at": time.Now().UTC(),
+	}}
+	if _, err := s.migrationHandler.FindOneAndUpdate(ctx, filter, update, true); err != nil {
+		return fmt.Errorf("failed to record manifest: %w", err)
+	}
+	s.logger.Info("Manifest applied", "manifest", manifest.Name, "version", manifest.Version)
+	return nil
+}
 
-	// Hash password
-	hash, err := hash.HashPassword(db.SystemAdminPassword)
+// upsert returns the item matching filter, inserting item when none does. The fields of update are applied to the
+// existing item as well. An upsert racing another on a unique index fails with a conflict, it is retried once to
+// return the item inserted by the other one
+func upsert[T any](ctx context.Context, handler *collection.BaseCollectionHandler[T], filter map[string]any, item any, update map[string]any) (*T, error) {
+	insert, err := insertFields(item, filter, update)
 	if err != nil {
-		return infra_error.Internal(infra_error.InternalUnexpectedError, err)
+		return nil, infra_error.Internal(infra_error.InternalUnexpectedError, err)
+	}
+	changes := map[string]any{"$setOnInsert": insert}
+	for operator, fields := range update {
+		changes[operator] = fields
 	}
 
-	// Create user with system admin role
-	user := &authv1.User{
-		TenantId:     db.SystemTenantID,
-		Username:     db.SystemAdminUser,
-		Email:        db.SystemAdminEmail,
-		PasswordHash: hash,
-		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
-		CreatedBy:    "System",
-		Roles: []*authv1.UserRole{
-			{
-				TenantId:   db.SystemTenantID,
-				RoleId:     db.SystemAdminRoleID,
-				AssignedAt: timestamppb.Now(),
-				AssignedBy: "System",
-			},
-		},
+	result, err := handler.FindOneAndUpdate(ctx, filter, changes, true)
+	if infra_error.IsCategory(err, infra_error.CategoryConflict) {
+		result, err = handler.FindOneAndUpdate(ctx, filter, changes, true)
 	}
+	return result, err
+}
 
-	userID, err := s.userHandler.Create(user)
+// insertFields returns the fields of item set on the insert, without its id and the fields of filter and update
+// that the insert already sets
+func insertFields(item any, filter map[string]any, update map[string]any) (bson.M, error) {
+	raw, err := bson.MarshalWithRegistry(codec.GetRegistry(), item)
 	if err != nil {
-		return infra_error.Internal(infra_error.InternalDatabaseError, err)
+		return nil, err
 	}
-
-	db.SystemAdminUserID = userID
-	return nil
+	fields := bson.M{}
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "_id")
+	for field := range filter {
+		delete(fields, field)
+	}
+	for _, operatorFields := range update {
+		if operatorFields, ok := operatorFields.(map[string]any); ok {
+			for field := range operatorFields {
+				delete(fields, field)
+			}
+		}
+	}
+	return fields, nil
 }
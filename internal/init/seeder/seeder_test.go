@@ -0,0 +1,187 @@
+package seeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/db/memory"
+	"erp.localhost/internal/infra/logging/logger"
+	model_mongo "erp.localhost/internal/infra/model/db/mongo"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedSystemData_Idempotent(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	s, err := NewSeeder(logger.NewBaseLogger(shared.ModuleInit))
+	require.NoError(t, err)
+	s.SetSecretLookup(func(string) (string, bool) { return "", false })
+	generated := map[string]string{}
+	s.SetCredentialsSink(func(tenant, email, password string) error {
+		generated[tenant+"/"+email] = password
+		return nil
+	})
+	manifests, err := LoadManifests("")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, s.SeedSystemData(ctx, manifests))
+	tenantID, roleID, userID := db.SystemTenantID, db.SystemAdminRoleID, db.SystemAdminUserID
+	require.NotEmpty(t, tenantID)
+
+	// Restarting the job finds the items it created
+	require.NoError(t, s.SeedSystemData(ctx, manifests))
+	assert.Equal(t, tenantID, db.SystemTenantID)
+	assert.Equal(t, roleID, db.SystemAdminRoleID)
+	assert.Equal(t, userID, db.SystemAdminUserID)
+
+	tenants, err := s.tenantHandler.FindAll(ctx, map[string]any{})
+	require.NoError(t, err)
+	assert.Len(t, tenants, 1)
+	roles, err := s.roleHandler.FindAll(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, []string{db.SystemAdminPermissionID}, roles[0].Permissions)
+	users, err := s.userHandler.FindAll(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Len(t, users[0].Roles, 1)
+	assert.Equal(t, roleID, users[0].Roles[0].RoleId)
+
+	// The password of the system admin is generated once and must be changed
+	require.Len(t, generated, 1)
+	password := generated[db.SystemTenant+"/"+db.SystemAdminEmail]
+	valid, err := hash.Verify(ctx, password, users[0].PasswordHash)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.True(t, users[0].MustChangePassword)
+
+	records, err := s.migrationHandler.FindAll(ctx, map[string]any{"kind": seedRecordKind})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "system", records[0].Name)
+	assert.Equal(t, 2, records[0].Version)
+}
+
+func TestApply_UserPasswords(t *testing.T) {
+	manifest, err := ParseManifest([]byte(`
+name: demo
+version: 1
+tenants: [{name: demo}]
+users:
+  - {tenant: demo, email: admin@demo.com, password_env: DEMO_ADMIN_PASSWORD, generate_password: true}
+`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		secret       string
+		sinkErr      error
+		wantErr      bool
+		wantUser     bool
+		wantGenerate bool
+	}{
+		{name: "from the secret store", secret: "Secret-Admin-Password-1", wantUser: true},
+		{name: "generated", wantUser: true, wantGenerate: true},
+		{name: "generated password not given out", sinkErr: errors.New("disk full"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory.Enable()
+			documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+			t.Cleanup(documents.Reset)
+
+			s, err := NewSeeder(logger.NewBaseLogger(shared.ModuleInit))
+			require.NoError(t, err)
+			s.SetSecretLookup(func(name string) (string, bool) {
+				return tc.secret, name == "DEMO_ADMIN_PASSWORD" && tc.secret != ""
+			})
+			var generated []string
+			s.SetCredentialsSink(func(_, _, password string) error {
+				generated = append(generated, password)
+				return tc.sinkErr
+			})
+
+			err = s.Apply(context.Background(), manifest)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			users, err := s.userHandler.FindAll(context.Background(), map[string]any{})
+			require.NoError(t, err)
+			if !tc.wantUser {
+				// The user is not kept with a password nobody knows
+				assert.Empty(t, users)
+				return
+			}
+			require.Len(t, users, 1)
+			assert.Equal(t, tc.wantGenerate, users[0].MustChangePassword)
+			password := tc.secret
+			if tc.wantGenerate {
+				require.Len(t, generated, 1)
+				password = generated[0]
+			} else {
+				assert.Empty(t, generated)
+			}
+			valid, err := hash.Verify(context.Background(), password, users[0].PasswordHash)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+}
+
+func TestApply_AddsPermissionsToExistingRole(t *testing.T) {
+	memory.Enable()
+	documents := memory.SharedDocuments(string(model_mongo.AuthDB))
+	t.Cleanup(documents.Reset)
+
+	s, err := NewSeeder(logger.NewBaseLogger(shared.ModuleInit))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	v1, err := ParseManifest([]byte(`
+name: demo
+version: 1
+tenants: [{name: demo}]
+permissions:
+  - {tenant: demo, resource: user, action: read}
+roles:
+  - {tenant: demo, name: viewer, permissions: ["user:read"]}
+`))
+	require.NoError(t, err)
+	require.NoError(t, s.Apply(ctx, v1))
+
+	v2, err := ParseManifest([]byte(`
+name: demo
+version: 2
+tenants: [{name: demo}]
+permissions:
+  - {tenant: demo, resource: user, action: read}
+  - {tenant: demo, resource: role, action: read}
+roles:
+  - {tenant: demo, name: viewer, description: changed, permissions: ["user:read", "role:read"]}
+`))
+	require.NoError(t, err)
+	require.NoError(t, s.Apply(ctx, v2))
+
+	roles, err := s.roleHandler.FindAll(ctx, map[string]any{})
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Len(t, roles[0].Permissions, 2)
+	// The existing role keeps its fields
+	assert.Empty(t, roles[0].Description)
+
+	records, err := s.migrationHandler.FindAll(ctx, map[string]any{"kind": seedRecordKind, "name": "demo"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, 2, records[0].Version)
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	"erp.localhost/internal/infra/db/mongo/collection"
+	"erp.localhost/internal/infra/db/mongo/query"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRoleCollection(t *testing.T) *collection_auth.RoleCollection {
+	t.Helper()
+	roles, err := collection_auth.NewRoleCollection(logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+	return roles
+}
+
+func TestCollection_CRUD(t *testing.T) {
+	ctx := context.Background()
+	tenant := SeedTenant(t)
+	roles := newRoleCollection(t)
+
+	role := &authv1.Role{TenantId: tenant.Tenant.Id, Name: "viewer", Status: authv1.RoleStatus_ROLE_STATUS_ACTIVE}
+	id, err := roles.Create(ctx, role)
+	require.NoError(t, err)
+
+	filter, err := query.New[authv1.Role]().Tenant(tenant.Tenant.Id).ID(id).Build()
+	require.NoError(t, err)
+	stored, err := roles.FindOne(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, "viewer", stored.Name)
+
+	stored.Description = "Reads everything"
+	require.NoError(t, roles.Update(ctx, filter, stored))
+	stored, err = roles.FindOne(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, "Reads everything", stored.Description)
+
+	all, err := roles.FindAll(ctx, map[string]any{"tenant_id": tenant.Tenant.Id})
+	require.NoError(t, err)
+	assert.Len(t, all, 2, "the seeded TenantAdmin role and the new role")
+
+	require.NoError(t, roles.Delete(ctx, filter))
+	_, err = roles.FindOne(ctx, filter)
+	assert.Error(t, err)
+}
+
+func TestCollection_UpdateVersioned(t *testing.T) {
+	ctx := context.Background()
+	tenant := SeedTenant(t)
+	users, err := collection_auth.NewUserCollection(logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+
+	filter, err := query.New[authv1.User]().Tenant(tenant.Tenant.Id).ID(tenant.Admin.Id).Build()
+	require.NoError(t, err)
+	first, err := users.FindOne(ctx, filter)
+	require.NoError(t, err)
+	second, err := users.FindOne(ctx, filter)
+	require.NoError(t, err)
+
+	first.Email = "first@example.test"
+	require.NoError(t, users.UpdateVersioned(ctx, filter, first, first.Version))
+
+	// The second copy was read at the version the first update replaced
+	second.Email = "second@example.test"
+	err = users.UpdateVersioned(ctx, filter, second, second.Version)
+	assert.ErrorIs(t, err, infra_error.Conflict(infra_error.ConflictResourceModified))
+}
+
+func TestCollection_Transaction(t *testing.T) {
+	ctx := context.Background()
+	tenant := SeedTenant(t)
+	roles := newRoleCollection(t)
+
+	// The roles created in an aborted transaction are not stored
+	errAbort := errors.New("abort")
+	err := roles.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := roles.Create(ctx, &authv1.Role{TenantId: tenant.Tenant.Id, Name: "aborted"}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	filter, err := query.New[authv1.Role]().Tenant(tenant.Tenant.Id).Eq("name", "aborted").Build()
+	require.NoError(t, err)
+	found, err := roles.FindAll(ctx, filter)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestTenantScopedCollectionHandler(t *testing.T) {
+	ctx := context.Background()
+	tenant, other := SeedTenant(t), SeedTenant(t)
+	roles, err := collection.NewTenantScopedCollectionHandler[authv1.Role](newRoleCollection(t), tenant.Tenant.Id)
+	require.NoError(t, err)
+
+	found, err := roles.FindAll(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, tenant.RoleID, found[0].Id)
+
+	_, err = roles.FindOne(ctx, map[string]any{"tenant_id": other.Tenant.Id, "_id": other.RoleID})
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTenantAccessDenied))
+	// Without the tenant in the filter the role of the other tenant is not found
+	_, err = roles.FindOne(ctx, map[string]any{"_id": other.RoleID})
+	assert.Error(t, err)
+}
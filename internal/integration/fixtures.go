@@ -0,0 +1,107 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	collection_auth "erp.localhost/internal/auth/collection"
+	"erp.localhost/internal/auth/hash"
+	"erp.localhost/internal/infra/db"
+	"erp.localhost/internal/infra/logging/logger"
+	model_auth "erp.localhost/internal/infra/model/auth"
+	"erp.localhost/internal/infra/model/auth/permissions"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Tenant is a tenant seeded like a new tenant of the auth service, with the wildcard permission, the TenantAdmin
+// role and the admin user
+type Tenant struct {
+	Tenant       *authv1.Tenant
+	PermissionID string
+	RoleID       string
+	Admin        *authv1.User
+	// AdminPassword is the plain password of Admin
+	AdminPassword string
+}
+
+// SeedTenant stores a new tenant with its admin in the auth database, every call seeds a tenant of its own so the
+// tests do not see each other's data
+func SeedTenant(t *testing.T) *Tenant {
+	t.Helper()
+	ctx := context.Background()
+	log := logger.NewBaseLogger(shared.ModuleAuth)
+
+	tenants, err := collection_auth.NewTenantCollection(log)
+	require.NoError(t, err)
+	permissionCollection, err := collection_auth.NewPermissionCollection(log)
+	require.NoError(t, err)
+	roles, err := collection_auth.NewRoleCollection(log)
+	require.NoError(t, err)
+	users, err := collection_auth.NewUserCollection(log)
+	require.NoError(t, err)
+
+	name := "integration-" + uuid.NewString()
+	seeded := &Tenant{
+		Tenant: &authv1.Tenant{
+			Name:      name,
+			Status:    authv1.TenantStatus_TENANT_STATUS_ACTIVE,
+			Contact:   &authv1.ContactInfo{Email: "admin@" + name + ".test"},
+			CreatedBy: db.SystemAdminUser,
+			CreatedAt: timestamppb.Now(),
+			UpdatedAt: timestamppb.Now(),
+		},
+		AdminPassword: db.TenantAdminPassword,
+	}
+	seeded.Tenant.Id, err = tenants.Create(ctx, seeded.Tenant)
+	require.NoError(t, err)
+	tenantID := seeded.Tenant.Id
+
+	seeded.PermissionID, err = permissionCollection.Create(ctx, &authv1.Permission{
+		TenantId:         tenantID,
+		DisplayName:      "Full Access",
+		PermissionString: permissions.Wildcard,
+		Resource:         model_auth.ResourceTypeAll,
+		Action:           model_auth.PermissionActionAll,
+		Status:           authv1.PermissionStatus_PERMISSION_STATUS_ACTIVE,
+		CreatedBy:        db.SystemAdminUser,
+		IsDangerous:      true,
+		Protected:        true,
+	})
+	require.NoError(t, err)
+
+	seeded.RoleID, err = roles.Create(ctx, &authv1.Role{
+		TenantId:    tenantID,
+		Name:        model_auth.RoleTenantAdmin,
+		Type:        authv1.RoleType_ROLE_TYPE_SYSTEM,
+		Permissions: []string{seeded.PermissionID},
+		Protected:   true,
+		Status:      authv1.RoleStatus_ROLE_STATUS_ACTIVE,
+		CreatedBy:   db.SystemAdminUser,
+	})
+	require.NoError(t, err)
+
+	passwordHash, err := hash.HashPassword(seeded.AdminPassword)
+	require.NoError(t, err)
+	seeded.Admin = &authv1.User{
+		TenantId:     tenantID,
+		Username:     db.TenantAdminUser,
+		PasswordHash: passwordHash,
+		Status:       authv1.UserStatus_USER_STATUS_ACTIVE,
+		CreatedBy:    db.SystemAdminUser,
+		Roles: []*authv1.UserRole{{
+			TenantId:   tenantID,
+			RoleId:     seeded.RoleID,
+			AssignedAt: timestamppb.Now(),
+			AssignedBy: db.SystemAdminUser,
+		}},
+	}
+	seeded.Admin.Id, err = users.Create(ctx, seeded.Admin)
+	require.NoError(t, err)
+	return seeded
+}
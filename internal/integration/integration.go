@@ -0,0 +1,96 @@
+//go:build integration
+
+// Package integration runs the handlers against real MongoDB and Redis servers started in Docker with
+// testcontainers, where the unit tests mock them. The tests are built with the integration tag and need a Docker
+// daemon:
+//
+//	go test -tags integration -count=1 ./internal/integration/...
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	db_mongo "erp.localhost/internal/infra/db/mongo"
+	"github.com/testcontainers/testcontainers-go"
+	tc_mongo "github.com/testcontainers/testcontainers-go/modules/mongodb"
+	tc_redis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+const (
+	// MongoImage runs as a single member replica set, so the transactions and change streams work
+	MongoImage = "mongo:7.0"
+	RedisImage = "redis:7.4-alpine"
+
+	// startTimeout bounds the start of the containers, the images may be pulled first
+	startTimeout = 5 * time.Minute
+)
+
+// Env is the MongoDB and Redis servers of the tests, the handlers created after Start connect to them
+type Env struct {
+	MongoURI string
+	RedisURL string
+
+	containers []testcontainers.Container
+}
+
+// Start starts the containers and points the Mongo managers and the Redis handlers at them
+func Start(ctx context.Context) (*Env, error) {
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	env := &Env{}
+	mongoContainer, err := tc_mongo.Run(ctx, MongoImage, tc_mongo.WithReplicaSet("rs0"))
+	if err != nil {
+		return nil, fmt.Errorf("starting mongo: %w", err)
+	}
+	env.containers = append(env.containers, mongoContainer)
+	if env.MongoURI, err = mongoContainer.ConnectionString(ctx); err != nil {
+		env.Close(context.Background())
+		return nil, fmt.Errorf("reading the mongo uri: %w", err)
+	}
+
+	redisContainer, err := tc_redis.Run(ctx, RedisImage)
+	if err != nil {
+		env.Close(context.Background())
+		return nil, fmt.Errorf("starting redis: %w", err)
+	}
+	env.containers = append(env.containers, redisContainer)
+	if env.RedisURL, err = redisContainer.ConnectionString(ctx); err != nil {
+		env.Close(context.Background())
+		return nil, fmt.Errorf("reading the redis url: %w", err)
+	}
+
+	db_mongo.SetURI(env.MongoURI)
+	// The Redis handlers load their configuration from the environment, see redis.LoadRedisConfig
+	if err := os.Setenv("REDIS_URL", env.RedisURL); err != nil {
+		env.Close(context.Background())
+		return nil, err
+	}
+	return env, nil
+}
+
+// Close terminates the containers, the data of the tests goes with them
+func (e *Env) Close(ctx context.Context) {
+	for i := len(e.containers) - 1; i >= 0; i-- {
+		_ = e.containers[i].Terminate(ctx)
+	}
+	e.containers = nil
+}
+
+// Main runs the tests of a package against a new Env, it is called by the TestMain of the package:
+//
+//	func TestMain(m *testing.M) { integration.Main(m) }
+func Main(m *testing.M) {
+	env, err := Start(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "integration: %v\n", err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	env.Close(context.Background())
+	os.Exit(code)
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auth_handler "erp.localhost/internal/auth/handler"
+	"erp.localhost/internal/infra/db/redis"
+	infra_error "erp.localhost/internal/infra/error"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1_cache "erp.localhost/internal/infra/model/auth/v1/cache"
+	"erp.localhost/internal/infra/model/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAccessTokenHandler(t *testing.T) {
+	ctx := context.Background()
+	tenant := SeedTenant(t)
+	tenantID, userID := tenant.Tenant.Id, tenant.Admin.Id
+	handler, err := auth_handler.NewAccessTokenHandler(redis.LoadRedisConfig(), logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+
+	metadata := &authv1_cache.TokenMetadata{
+		Jti:       uuid.NewString(),
+		TenantId:  tenantID,
+		UserId:    userID,
+		IssuedAt:  timestamppb.Now(),
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}
+	require.NoError(t, handler.Store(ctx, tenantID, userID, metadata))
+
+	stored, err := handler.Validate(ctx, tenantID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, metadata.Jti, stored.Jti)
+
+	keys, err := handler.ScanKeys(ctx, tenantID)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	require.NoError(t, handler.Revoke(ctx, tenantID, userID, userID))
+	_, err = handler.Validate(ctx, tenantID, userID)
+	assert.ErrorIs(t, err, infra_error.Auth(infra_error.AuthTokenRevoked))
+
+	require.NoError(t, handler.Delete(ctx, tenantID, userID))
+	_, err = handler.GetOne(ctx, tenantID, userID)
+	assert.Error(t, err)
+}
+
+func TestAccessTokenHandler_Expires(t *testing.T) {
+	ctx := context.Background()
+	tenant := SeedTenant(t)
+	tenantID, userID := tenant.Tenant.Id, tenant.Admin.Id
+	handler, err := auth_handler.NewAccessTokenHandler(redis.LoadRedisConfig(), logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Store(ctx, tenantID, userID, &authv1_cache.TokenMetadata{
+		Jti:       uuid.NewString(),
+		TenantId:  tenantID,
+		UserId:    userID,
+		IssuedAt:  timestamppb.Now(),
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Second)),
+	}))
+	// The key is stored with the TTL of the token, Redis drops it once the token expired
+	require.Eventually(t, func() bool {
+		_, err := handler.GetOne(ctx, tenantID, userID)
+		return err != nil
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestRefreshTokenHandler(t *testing.T) {
+	ctx := context.Background()
+	tenant := SeedTenant(t)
+	tenantID, userID := tenant.Tenant.Id, tenant.Admin.Id
+	handler, err := auth_handler.NewRefreshTokenHandler(redis.LoadRedisConfig(), logger.NewBaseLogger(shared.ModuleAuth))
+	require.NoError(t, err)
+
+	refreshToken := &authv1_cache.RefreshToken{
+		TokenHash: uuid.NewString(),
+		TenantId:  tenantID,
+		UserId:    userID,
+		CreatedAt: timestamppb.Now(),
+		ExpiresAt: timestamppb.New(time.Now().Add(24 * time.Hour)),
+	}
+	require.NoError(t, handler.Store(ctx, tenantID, userID, refreshToken))
+
+	stored, err := handler.Validate(ctx, tenantID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, refreshToken.TokenHash, stored.TokenHash)
+
+	// Storing a token for another tenant is rejected
+	assert.Error(t, handler.Store(ctx, "another-tenant", userID, refreshToken))
+
+	require.NoError(t, handler.Revoke(ctx, tenantID, userID, userID))
+	_, err = handler.Validate(ctx, tenantID, userID)
+	assert.Error(t, err)
+}
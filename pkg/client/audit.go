@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	eventv1 "erp.localhost/internal/infra/model/event/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditLog is an action recorded by the audit log of a tenant
+type AuditLog = eventv1.AuditLog
+
+// AuditFilter selects the events of AuditClient.Tail, the empty fields match every event
+type AuditFilter struct {
+	// TargetTenantID is the tenant whose events are streamed, the tenant of the session when empty
+	TargetTenantID string
+	// ActorID is the user or API key that did the action
+	ActorID string
+	// ResourceType is the type of the resource the action was on, e.g. user, role or token
+	ResourceType string
+	// Since skips the events stored before it, ignored when Cursor is set
+	Since time.Time
+	// Cursor resumes the stream right after the event it was returned with
+	Cursor string
+}
+
+// AuditClient streams the audit log of a tenant
+type AuditClient struct {
+	client *Client
+}
+
+// Tail calls fn with the matching events oldest first, then with the new ones as they are stored, until ctx is
+// done or fn fails. The cursor passed with every event resumes the stream after it, see AuditFilter.Cursor
+func (a *AuditClient) Tail(ctx context.Context, filter AuditFilter, fn func(log *AuditLog, cursor string) error) error {
+	identity, err := a.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	req := &authv1.StreamAuditEventsRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: filter.TargetTenantID,
+		Filter: &authv1.AuditEventFilter{
+			ActorId:      filter.ActorID,
+			ResourceType: filter.ResourceType,
+		},
+		Cursor: filter.Cursor,
+	}
+	if !filter.Since.IsZero() {
+		req.Filter.Since = timestamppb.New(filter.Since)
+	}
+	stream, err := a.client.audit.StreamAuditEvents(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(event.GetLog(), event.GetCursor()); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+)
+
+// LoginRequest is the password login of a user
+type LoginRequest struct {
+	TenantID string
+	// Account is the email of the user when it contains an @, its username otherwise
+	Account  string
+	Password string
+	// MFACode is the TOTP or recovery code of the users with MFA enabled
+	MFACode string
+}
+
+// AuthClient logs the user of the Client in and out and manages its tokens
+type AuthClient struct {
+	client *Client
+}
+
+// Login authenticates the user and starts the session of the client with the tokens returned
+func (a *AuthClient) Login(ctx context.Context, req *LoginRequest) (*Tokens, error) {
+	loginReq := &authv1.LoginRequest{
+		TenantId: req.TenantID,
+		Password: req.Password,
+		MfaCode:  req.MFACode,
+	}
+	if strings.Contains(req.Account, "@") {
+		loginReq.AccountId = &authv1.LoginRequest_Email{Email: req.Account}
+	} else {
+		loginReq.AccountId = &authv1.LoginRequest_Username{Username: req.Account}
+	}
+	res, err := a.client.auth.Login(ctx, loginReq)
+	if err != nil {
+		return nil, err
+	}
+	tokens := tokensFromResponse(res)
+	if err := a.client.session.set(tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Logout revokes the tokens of the session and ends it, the session ends even when the revocation fails
+func (a *AuthClient) Logout(ctx context.Context) error {
+	identity, err := a.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	tokens := a.client.session.current()
+	defer a.client.session.clear()
+	_, err = a.client.auth.Logout(ctx, &authv1.LogoutRequest{
+		Identifier: identity.identifier(),
+		Tokens:     &authv1.Tokens{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken},
+	})
+	return err
+}
+
+// VerifyToken reports whether the auth service still accepts the access token of the session
+func (a *AuthClient) VerifyToken(ctx context.Context) (bool, error) {
+	tokens := a.client.session.current()
+	if tokens == nil {
+		return false, ErrNoSession
+	}
+	res, err := a.client.auth.VerifyToken(ctx, &authv1.VerifyTokenRequest{Token: tokens.AccessToken})
+	if err != nil {
+		return false, err
+	}
+	return res.GetValid(), nil
+}
+
+// RevokeTenantSessions revokes every access and refresh token of the users of targetTenantID, the tenant of the
+// session when empty, and returns how many were revoked
+func (a *AuthClient) RevokeTenantSessions(ctx context.Context, targetTenantID string) (accessTokens, refreshTokens int32, err error) {
+	identity, err := a.client.session.identityOf()
+	if err != nil {
+		return 0, 0, err
+	}
+	if targetTenantID == "" {
+		targetTenantID = identity.TenantID
+	}
+	res, err := a.client.auth.RevokeAllTenantTokens(ctx, &authv1.RevokeAllTenantTokensRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: targetTenantID,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return res.GetAccessTokensRevoked(), res.GetRefreshTokensRevoked(), nil
+}
+
+// Refresh exchanges the refresh token of the session for new tokens before the access token expires, e.g. after
+// the roles of the user changed
+func (a *AuthClient) Refresh(ctx context.Context) (*Tokens, error) {
+	tokens := a.client.session.current()
+	if tokens == nil {
+		return nil, ErrNoSession
+	}
+	if _, err := a.client.session.refreshRejected(ctx, tokens.AccessToken); err != nil {
+		return nil, err
+	}
+	return a.client.session.current(), nil
+}
@@ -0,0 +1,252 @@
+// Package client is the SDK of the auth services for the Go services embedding this repository. A Client holds the
+// session of one user: it attaches the access token to every call, refreshes it before it expires and exposes typed
+// clients of the auth, user, RBAC, tenant and audit services.
+//
+//	c, err := client.New(ctx, "auth:5000", client.WithCertsDir("/etc/erp/certs"))
+//	if err != nil { ... }
+//	defer c.Close()
+//	if _, err := c.Login(ctx, tenantID, "jane@example.com", password); err != nil { ... }
+//	allowed, err := c.HasPermission(ctx, "orders:create")
+//
+// Failed calls return the gRPC status errors of the services, see status.Code
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"erp.localhost/internal/infra/grpc/certs"
+	internal_client "erp.localhost/internal/infra/grpc/client"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	"erp.localhost/internal/infra/logging/logger"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"erp.localhost/internal/infra/model/shared"
+	"google.golang.org/grpc"
+)
+
+// Module names the SDK in its logs
+const Module shared.Module = "SDK"
+
+// DefaultRefreshBefore is how long before its expiry the access token is refreshed
+const DefaultRefreshBefore = 30 * time.Second
+
+// ErrNoSession is returned by the calls needing the identity of the user before Login or SetTokens
+var ErrNoSession = errors.New("client has no session, call Login or SetTokens first")
+
+// Logger is the logger of the SDK, see WithLogger
+type Logger = logger.Logger
+
+// RetryPolicy configures the retries of the idempotent calls, see WithRetryPolicy
+type RetryPolicy = interceptor.RetryPolicy
+
+// Options of New, set with the Option functions
+type Options struct {
+	insecure       bool
+	certs          *shared.Certs
+	connectTimeout time.Duration
+	requestTimeout time.Duration
+	retry          *RetryPolicy
+	refreshBefore  time.Duration
+	logger         Logger
+}
+
+// Option customizes New and NewFromConn
+type Option func(*Options)
+
+// WithInsecure connects without TLS, for local development
+func WithInsecure() Option {
+	return func(o *Options) {
+		o.insecure = true
+	}
+}
+
+// WithCertsDir reads the CA, certificate and key of the mTLS connection from dir, see shared.NewCertsFromDir
+func WithCertsDir(dir string) Option {
+	return func(o *Options) {
+		o.certs = shared.NewCertsFromDir(dir)
+	}
+}
+
+// WithConnectTimeout sets the minimum time given to a connection attempt
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.connectTimeout = timeout
+	}
+}
+
+// WithRequestTimeout sets the deadline of the calls made without one, retries included
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.requestTimeout = timeout
+	}
+}
+
+// WithRetryPolicy replaces the default retries of the idempotent calls, no retries when nil
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *Options) {
+		o.retry = policy
+	}
+}
+
+// WithRefreshBefore sets how long before its expiry the access token is refreshed, DefaultRefreshBefore otherwise
+func WithRefreshBefore(d time.Duration) Option {
+	return func(o *Options) {
+		o.refreshBefore = d
+	}
+}
+
+// WithLogger replaces the logger of the SDK
+func WithLogger(l Logger) Option {
+	return func(o *Options) {
+		o.logger = l
+	}
+}
+
+func newOptions(opts []Option) *Options {
+	options := &Options{
+		retry:         interceptor.DefaultRetryPolicy(),
+		refreshBefore: DefaultRefreshBefore,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.logger == nil {
+		options.logger = logger.NewBaseLogger(Module)
+	}
+	return options
+}
+
+// Client is the session of a user with the auth services, safe for concurrent use
+type Client struct {
+	session *session
+	logger  Logger
+	closer  func() error
+
+	auth         authv1.AuthServiceClient
+	users        authv1.UserServiceClient
+	tenants      authv1.TenantServiceClient
+	roles        authv1.RoleServiceClient
+	permissions  authv1.PermissionServiceClient
+	verification authv1.VerificationServiceClient
+	audit        authv1.AuditServiceClient
+}
+
+// New connects to the auth service at address, over mTLS unless WithInsecure is set. Calls of idempotent methods
+// are retried while the service is unavailable, see WithRetryPolicy
+func New(ctx context.Context, address string, opts ...Option) (*Client, error) {
+	options := newOptions(opts)
+	certificates := options.certs
+	if certificates == nil && !options.insecure {
+		certificates = shared.NewCerts()
+	}
+	grpcClient, err := internal_client.NewGRPCClient(ctx, &internal_client.Config{
+		Address:        address,
+		Certs:          certificates,
+		Module:         Module,
+		Insecure:       options.insecure,
+		ConnectTimeout: options.connectTimeout,
+		RequestTimeout: options.requestTimeout,
+		Retry:          options.retry,
+		ServerIdentity: certs.Identity(shared.ModuleAuth),
+	}, options.logger)
+	if err != nil {
+		return nil, err
+	}
+	c := newClient(grpcClient.Conn(), options)
+	c.closer = grpcClient.Close
+	return c, nil
+}
+
+// NewFromConn creates a Client over a connection to the auth service managed by the caller, Close does not close
+// it. The connection options of opts are ignored
+func NewFromConn(conn grpc.ClientConnInterface, opts ...Option) *Client {
+	return newClient(conn, newOptions(opts))
+}
+
+func newClient(conn grpc.ClientConnInterface, options *Options) *Client {
+	// Refreshes go over the bare connection, the session lock is held while they run
+	auth := authv1.NewAuthServiceClient(conn)
+	session := newSession(options.refreshBefore, func(ctx context.Context, identity Identity, refreshToken string) (*authv1.TokensResponse, error) {
+		return auth.RefreshToken(ctx, &authv1.RefreshTokenRequest{
+			Identifier:   identity.identifier(),
+			RefreshToken: refreshToken,
+		})
+	})
+	authenticated := &authConn{conn: conn, session: session, logger: options.logger}
+	return &Client{
+		session:      session,
+		logger:       options.logger,
+		auth:         authv1.NewAuthServiceClient(authenticated),
+		users:        authv1.NewUserServiceClient(authenticated),
+		tenants:      authv1.NewTenantServiceClient(authenticated),
+		roles:        authv1.NewRoleServiceClient(authenticated),
+		permissions:  authv1.NewPermissionServiceClient(authenticated),
+		verification: authv1.NewVerificationServiceClient(authenticated),
+		audit:        authv1.NewAuditServiceClient(authenticated),
+	}
+}
+
+// Close closes the connection opened by New
+func (c *Client) Close() error {
+	if c.closer != nil {
+		return c.closer()
+	}
+	return nil
+}
+
+// Auth returns the client of the login, logout and token calls
+func (c *Client) Auth() *AuthClient {
+	return &AuthClient{client: c}
+}
+
+// Users returns the client of the user service
+func (c *Client) Users() *UsersClient {
+	return &UsersClient{client: c}
+}
+
+// RBAC returns the client of the role and permission checks
+func (c *Client) RBAC() *RBACClient {
+	return &RBACClient{client: c}
+}
+
+// Audit returns the client of the audit log stream
+func (c *Client) Audit() *AuditClient {
+	return &AuditClient{client: c}
+}
+
+// Tenants returns the client of the tenant service
+func (c *Client) Tenants() *TenantsClient {
+	return &TenantsClient{client: c}
+}
+
+// Login authenticates with the password of the user of the tenant, account is an email when it contains an @ and a
+// username otherwise. The tokens returned start the session of the client
+func (c *Client) Login(ctx context.Context, tenantID, account, password string) (*Tokens, error) {
+	return c.Auth().Login(ctx, &LoginRequest{TenantID: tenantID, Account: account, Password: password})
+}
+
+// SetTokens starts the session of the client with tokens obtained elsewhere, e.g. relayed by a caller
+func (c *Client) SetTokens(tokens *Tokens) error {
+	return c.session.set(tokens)
+}
+
+// Tokens returns the current tokens of the session, nil without one
+func (c *Client) Tokens() *Tokens {
+	return c.session.current()
+}
+
+// Identity returns the user of the session
+func (c *Client) Identity() (Identity, error) {
+	return c.session.identityOf()
+}
+
+// Logout revokes the tokens of the session and ends it
+func (c *Client) Logout(ctx context.Context) error {
+	return c.Auth().Logout(ctx)
+}
+
+// HasPermission reports whether the user of the session holds the "resource:action" permission on its tenant
+func (c *Client) HasPermission(ctx context.Context, permission string) (bool, error) {
+	return c.RBAC().HasPermission(ctx, permission)
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeConn answers the calls of the client with handlers by method and records their authorization headers
+type fakeConn struct {
+	mu       sync.Mutex
+	handlers map[string]func(ctx context.Context, req any) (proto.Message, error)
+	headers  map[string][]string
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		handlers: map[string]func(ctx context.Context, req any) (proto.Message, error){},
+		headers:  map[string][]string{},
+	}
+}
+
+func (f *fakeConn) Invoke(ctx context.Context, method string, args, reply any, _ ...grpc.CallOption) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.mu.Lock()
+	f.headers[method] = append(f.headers[method], md.Get(interceptor.AuthorizationHeader)...)
+	handler := f.handlers[method]
+	f.mu.Unlock()
+	if handler == nil {
+		return status.Error(codes.Unimplemented, method)
+	}
+	res, err := handler(ctx, args)
+	if err != nil {
+		return err
+	}
+	proto.Merge(reply.(proto.Message), res)
+	return nil
+}
+
+func (f *fakeConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streams")
+}
+
+func accessToken(t *testing.T, tenantID, userID string) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &token.JWTAccessClaims{
+		TenantID: tenantID,
+		UserID:   userID,
+		Username: "jane",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	return signed
+}
+
+func tokensResponse(accessToken, refreshToken string, expiresIn time.Duration) *authv1.TokensResponse {
+	return &authv1.TokensResponse{
+		Tokens: &authv1.Tokens{Token: accessToken, RefreshToken: refreshToken},
+		ExpiresIn: &authv1.ExpiresIn{
+			Token:        time.Now().Add(expiresIn).Unix(),
+			RefreshToken: time.Now().Add(24 * time.Hour).Unix(),
+		},
+	}
+}
+
+func TestClient_LoginAndHasPermission(t *testing.T) {
+	conn := newFakeConn()
+	access := accessToken(t, "tenant-1", "user-1")
+	conn.handlers[authv1.AuthService_Login_FullMethodName] = func(_ context.Context, req any) (proto.Message, error) {
+		login := req.(*authv1.LoginRequest)
+		assert.Equal(t, "tenant-1", login.GetTenantId())
+		assert.Equal(t, "jane@example.com", login.GetEmail())
+		return tokensResponse(access, "refresh-1", time.Hour), nil
+	}
+	conn.handlers[authv1.VerificationService_HasPermission_FullMethodName] = func(_ context.Context, req any) (proto.Message, error) {
+		check := req.(*authv1.HasPermissionRequest)
+		assert.Equal(t, "tenant-1", check.GetIdentifier().GetTenantId())
+		assert.Equal(t, "user-1", check.GetIdentifier().GetUserId())
+		assert.Equal(t, "tenant-1", check.GetTargetTenantId())
+		return &authv1.HasPermissionResponse{HasPermission: check.GetPermission() == "orders:create"}, nil
+	}
+	c := NewFromConn(conn, WithLogger(nopLogger{}))
+
+	_, err := c.HasPermission(context.Background(), "orders:create")
+	require.ErrorIs(t, err, ErrNoSession)
+
+	_, err = c.Login(context.Background(), "tenant-1", "jane@example.com", "password")
+	require.NoError(t, err)
+	identity, err := c.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", identity.UserID)
+	assert.Equal(t, "jane", identity.Username)
+
+	allowed, err := c.HasPermission(context.Background(), "orders:create")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = c.HasPermission(context.Background(), "orders:delete")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, []string{"Bearer " + access, "Bearer " + access}, conn.headers[authv1.VerificationService_HasPermission_FullMethodName])
+
+	_, err = c.HasPermission(context.Background(), "orders")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestClient_RefreshesBeforeExpiry(t *testing.T) {
+	conn := newFakeConn()
+	expiring := accessToken(t, "tenant-1", "user-1")
+	refreshed := accessToken(t, "tenant-1", "user-1") + "x"
+	refreshes := 0
+	conn.handlers[authv1.AuthService_RefreshToken_FullMethodName] = func(_ context.Context, req any) (proto.Message, error) {
+		refreshes++
+		assert.Equal(t, "refresh-1", req.(*authv1.RefreshTokenRequest).GetRefreshToken())
+		assert.Equal(t, "user-1", req.(*authv1.RefreshTokenRequest).GetIdentifier().GetUserId())
+		return tokensResponse(refreshed, "refresh-2", time.Hour), nil
+	}
+	conn.handlers[authv1.VerificationService_GetUserRoles_FullMethodName] = func(context.Context, any) (proto.Message, error) {
+		return &authv1.GetUserRolesResponse{RoleIds: []string{"admin"}}, nil
+	}
+	c := NewFromConn(conn, WithLogger(nopLogger{}), WithRefreshBefore(time.Minute))
+	require.NoError(t, c.SetTokens(&Tokens{
+		AccessToken:        expiring,
+		AccessTokenExpiry:  time.Now().Add(10 * time.Second),
+		RefreshToken:       "refresh-1",
+		RefreshTokenExpiry: time.Now().Add(time.Hour),
+	}))
+
+	for range 2 {
+		roles, err := c.RBAC().RoleIDs(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin"}, roles)
+	}
+	assert.Equal(t, 1, refreshes)
+	assert.Equal(t, "refresh-2", c.Tokens().RefreshToken)
+	assert.Equal(t, []string{"Bearer " + refreshed, "Bearer " + refreshed}, conn.headers[authv1.VerificationService_GetUserRoles_FullMethodName])
+}
+
+func TestClient_RetriesRejectedToken(t *testing.T) {
+	conn := newFakeConn()
+	revoked := accessToken(t, "tenant-1", "user-1")
+	refreshed := revoked + "x"
+	conn.handlers[authv1.AuthService_RefreshToken_FullMethodName] = func(context.Context, any) (proto.Message, error) {
+		return tokensResponse(refreshed, "refresh-2", time.Hour), nil
+	}
+	conn.handlers[authv1.UserService_GetUser_FullMethodName] = func(ctx context.Context, _ any) (proto.Message, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if md.Get(interceptor.AuthorizationHeader)[0] != "Bearer "+refreshed {
+			return nil, status.Error(codes.Unauthenticated, "token revoked")
+		}
+		return &authv1.User{Id: "user-1"}, nil
+	}
+	c := NewFromConn(conn, WithLogger(nopLogger{}))
+	require.NoError(t, c.SetTokens(&Tokens{AccessToken: revoked, AccessTokenExpiry: time.Now().Add(time.Hour), RefreshToken: "refresh-1"}))
+
+	user, err := c.Users().Me(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.GetId())
+	assert.Len(t, conn.headers[authv1.UserService_GetUser_FullMethodName], 2)
+
+	// A failed refresh returns the rejection of the call
+	conn.handlers[authv1.AuthService_RefreshToken_FullMethodName] = func(context.Context, any) (proto.Message, error) {
+		return nil, status.Error(codes.Unauthenticated, "refresh token revoked")
+	}
+	conn.handlers[authv1.UserService_GetUser_FullMethodName] = func(context.Context, any) (proto.Message, error) {
+		return nil, status.Error(codes.Unauthenticated, "token revoked")
+	}
+	_, err = c.Users().Me(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestClient_Logout(t *testing.T) {
+	conn := newFakeConn()
+	access := accessToken(t, "tenant-1", "user-1")
+	conn.handlers[authv1.AuthService_Logout_FullMethodName] = func(_ context.Context, req any) (proto.Message, error) {
+		logout := req.(*authv1.LogoutRequest)
+		assert.Equal(t, access, logout.GetTokens().GetToken())
+		assert.Equal(t, "refresh-1", logout.GetTokens().GetRefreshToken())
+		return &authv1.LogoutResponse{}, nil
+	}
+	c := NewFromConn(conn, WithLogger(nopLogger{}))
+	require.NoError(t, c.SetTokens(&Tokens{AccessToken: access, AccessTokenExpiry: time.Now().Add(time.Hour), RefreshToken: "refresh-1"}))
+
+	require.NoError(t, c.Logout(context.Background()))
+	assert.Nil(t, c.Tokens())
+	assert.ErrorIs(t, c.Logout(context.Background()), ErrNoSession)
+}
+
+func TestClient_SetTokensReadsExpiry(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &token.JWTAccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiry)},
+		TenantID:         "tenant-1",
+		UserID:           "user-1",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	c := NewFromConn(newFakeConn(), WithLogger(nopLogger{}))
+
+	require.NoError(t, c.SetTokens(&Tokens{AccessToken: signed}))
+	assert.True(t, expiry.Equal(c.Tokens().AccessTokenExpiry))
+
+	// An expired token without a refresh token is not sent
+	require.NoError(t, c.SetTokens(&Tokens{AccessToken: signed, AccessTokenExpiry: time.Now().Add(-time.Minute)}))
+	_, err = c.RBAC().RoleIDs(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestClient_SetTokensRejectsMalformedToken(t *testing.T) {
+	c := NewFromConn(newFakeConn(), WithLogger(nopLogger{}))
+	assert.Error(t, c.SetTokens(&Tokens{AccessToken: "not-a-jwt"}))
+	assert.Nil(t, c.Tokens())
+}
+
+// nopLogger discards the logs of the client
+type nopLogger struct{}
+
+func (nopLogger) Trace(string, ...any)                 {}
+func (nopLogger) Debug(string, ...any)                 {}
+func (nopLogger) Info(string, ...any)                  {}
+func (nopLogger) Warn(string, ...any)                  {}
+func (nopLogger) Error(string, ...any)                 {}
+func (nopLogger) Fatal(string, ...any)                 {}
+func (l nopLogger) WithContext(context.Context) Logger { return l }
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Role is a named set of permissions of a tenant
+type Role = authv1.Role
+
+// Permission is a "resource:action" permission defined by a tenant
+type Permission = authv1.Permission
+
+// RBACClient checks the roles and permissions of the user of the session
+type RBACClient struct {
+	client *Client
+}
+
+// HasPermission reports whether the user holds the "resource:action" permission, e.g. "orders:create", on its
+// tenant
+func (r *RBACClient) HasPermission(ctx context.Context, permission string) (bool, error) {
+	return r.HasPermissionOn(ctx, permission, "", nil)
+}
+
+// HasPermissionOn reports whether the user holds the "resource:action" permission on targetTenantID, its own tenant
+// when empty. The conditions of the permission are evaluated against attributes, e.g. the owner of the resource
+func (r *RBACClient) HasPermissionOn(ctx context.Context, permission, targetTenantID string, attributes map[string]string) (bool, error) {
+	if _, _, ok := strings.Cut(permission, ":"); !ok {
+		return false, status.Errorf(codes.InvalidArgument, "permission %q is not resource:action", permission)
+	}
+	identity, err := r.client.session.identityOf()
+	if err != nil {
+		return false, err
+	}
+	if targetTenantID == "" {
+		targetTenantID = identity.TenantID
+	}
+	res, err := r.client.verification.HasPermission(ctx, &authv1.HasPermissionRequest{
+		Identifier:     identity.identifier(),
+		Permission:     permission,
+		TargetTenantId: targetTenantID,
+		Attributes:     attributes,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.GetHasPermission(), nil
+}
+
+// Permissions returns every permission of the user
+func (r *RBACClient) Permissions(ctx context.Context) (map[string]bool, error) {
+	identity, err := r.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.verification.GetUserPermissions(ctx, &authv1.GetUserPermissionsRequest{Identifier: identity.identifier()})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetPermissions(), nil
+}
+
+// RoleIDs returns the ids of the roles of the user
+func (r *RBACClient) RoleIDs(ctx context.Context) ([]string, error) {
+	identity, err := r.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.verification.GetUserRoles(ctx, &authv1.GetUserRolesRequest{Identifier: identity.identifier()})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetRoleIds(), nil
+}
+
+// ListPermissions returns the permissions defined by targetTenantID, the tenant of the user when empty
+func (r *RBACClient) ListPermissions(ctx context.Context, targetTenantID string) ([]*Permission, error) {
+	identity, err := r.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	if targetTenantID == "" {
+		targetTenantID = identity.TenantID
+	}
+	res, err := r.client.permissions.ListPermissions(ctx, &authv1.ListPermissionsRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: targetTenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetPermissions(), nil
+}
+
+// Roles returns the roles of the tenant of the user
+func (r *RBACClient) Roles(ctx context.Context) ([]*Role, error) {
+	identity, err := r.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.roles.ListRoles(ctx, &authv1.ListRolesRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: identity.TenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetRoles(), nil
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"erp.localhost/internal/auth/token"
+	"erp.localhost/internal/infra/grpc/interceptor"
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	infrav1 "erp.localhost/internal/infra/model/infra/v1"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Tokens are the access and refresh tokens of a session
+type Tokens struct {
+	AccessToken        string
+	AccessTokenExpiry  time.Time
+	RefreshToken       string
+	RefreshTokenExpiry time.Time
+}
+
+// tokensFromResponse converts the tokens returned by the auth service, the expiries are unix seconds
+func tokensFromResponse(res *authv1.TokensResponse) *Tokens {
+	return &Tokens{
+		AccessToken:        res.GetTokens().GetToken(),
+		AccessTokenExpiry:  time.Unix(res.GetExpiresIn().GetToken(), 0),
+		RefreshToken:       res.GetTokens().GetRefreshToken(),
+		RefreshTokenExpiry: time.Unix(res.GetExpiresIn().GetRefreshToken(), 0),
+	}
+}
+
+// Identity is the user the access token of the session was issued to
+type Identity struct {
+	TenantID string
+	UserID   string
+	Username string
+	Email    string
+	Roles    []string
+}
+
+func (i Identity) identifier() *infrav1.UserIdentifier {
+	return &infrav1.UserIdentifier{TenantId: i.TenantID, UserId: i.UserID}
+}
+
+// parseAccessToken reads the identity and the expiry of the claims of accessToken, the expiry is zero when it has
+// none. The signature is checked by the services, not by the SDK which does not hold the key
+func parseAccessToken(accessToken string) (Identity, time.Time, error) {
+	claims := &token.JWTAccessClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, claims); err != nil {
+		return Identity{}, time.Time{}, err
+	}
+	var expiry time.Time
+	if claims.ExpiresAt != nil {
+		expiry = claims.ExpiresAt.Time
+	}
+	return Identity{
+		TenantID: claims.TenantID,
+		UserID:   claims.UserID,
+		Username: claims.Username,
+		Email:    claims.Email,
+		Roles:    claims.Roles,
+	}, expiry, nil
+}
+
+// refreshFunc exchanges the refresh token of identity for new tokens
+type refreshFunc func(ctx context.Context, identity Identity, refreshToken string) (*authv1.TokensResponse, error)
+
+// session holds the tokens of the user, refreshed under its lock so concurrent calls share one refresh
+type session struct {
+	mu            sync.Mutex
+	tokens        *Tokens
+	identity      Identity
+	refreshBefore time.Duration
+	refresh       refreshFunc
+	now           func() time.Time
+}
+
+func newSession(refreshBefore time.Duration, refresh refreshFunc) *session {
+	return &session{
+		refreshBefore: refreshBefore,
+		refresh:       refresh,
+		now:           time.Now,
+	}
+}
+
+// set replaces the tokens of the session, the identity is read from the access token and so is its expiry when
+// tokens has none
+func (s *session) set(tokens *Tokens) error {
+	identity, expiry, err := parseAccessToken(tokens.AccessToken)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *tokens
+	if copied.AccessTokenExpiry.IsZero() {
+		copied.AccessTokenExpiry = expiry
+	}
+	s.tokens = &copied
+	s.identity = identity
+	return nil
+}
+
+// clear ends the session
+func (s *session) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = nil
+	s.identity = Identity{}
+}
+
+// current returns a copy of the tokens of the session, nil without one
+func (s *session) current() *Tokens {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		return nil
+	}
+	copied := *s.tokens
+	return &copied
+}
+
+// identityOf returns the user of the session, ErrNoSession without one
+func (s *session) identityOf() (Identity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		return Identity{}, ErrNoSession
+	}
+	return s.identity, nil
+}
+
+// accessToken returns the access token of the session, refreshed first when it expires within refreshBefore. The
+// tokens without an expiry are never refreshed. Empty without a session
+func (s *session) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		return "", nil
+	}
+	if s.tokens.AccessTokenExpiry.IsZero() || s.now().Add(s.refreshBefore).Before(s.tokens.AccessTokenExpiry) {
+		return s.tokens.AccessToken, nil
+	}
+	return s.refreshLocked(ctx)
+}
+
+// refreshRejected refreshes the tokens after the services rejected rejected, unless another call already did
+func (s *session) refreshRejected(ctx context.Context, rejected string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		return "", ErrNoSession
+	}
+	if s.tokens.AccessToken != rejected {
+		return s.tokens.AccessToken, nil
+	}
+	return s.refreshLocked(ctx)
+}
+
+func (s *session) refreshLocked(ctx context.Context) (string, error) {
+	if s.tokens.RefreshToken == "" {
+		return "", status.Error(codes.Unauthenticated, "access token expired and there is no refresh token, log in again")
+	}
+	if !s.tokens.RefreshTokenExpiry.IsZero() && !s.now().Before(s.tokens.RefreshTokenExpiry) {
+		return "", status.Error(codes.Unauthenticated, "refresh token expired, log in again")
+	}
+	res, err := s.refresh(ctx, s.identity, s.tokens.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	tokens := tokensFromResponse(res)
+	identity, _, err := parseAccessToken(tokens.AccessToken)
+	if err != nil {
+		return "", err
+	}
+	s.tokens = tokens
+	s.identity = identity
+	return tokens.AccessToken, nil
+}
+
+// withBearer returns ctx sending accessToken in the authorization header of the outgoing call
+func withBearer(ctx context.Context, accessToken string) context.Context {
+	if accessToken == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, interceptor.AuthorizationHeader, "Bearer "+accessToken)
+}
+
+// authConn attaches the access token of the session to the calls made over conn. A call rejected as
+// unauthenticated is retried once with refreshed tokens, the token may have been revoked before it expired
+type authConn struct {
+	conn    grpc.ClientConnInterface
+	session *session
+	logger  Logger
+}
+
+func (a *authConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	accessToken, err := a.session.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	err = a.conn.Invoke(withBearer(ctx, accessToken), method, args, reply, opts...)
+	if accessToken == "" || status.Code(err) != codes.Unauthenticated || method == authv1.AuthService_Logout_FullMethodName {
+		return err
+	}
+	refreshed, refreshErr := a.session.refreshRejected(ctx, accessToken)
+	if refreshErr != nil {
+		a.logger.WithContext(ctx).Warn("failed to refresh the rejected access token", "method", method, "error", refreshErr)
+		return err
+	}
+	return a.conn.Invoke(withBearer(ctx, refreshed), method, args, reply, opts...)
+}
+
+func (a *authConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	accessToken, err := a.session.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.conn.NewStream(withBearer(ctx, accessToken), desc, method, opts...)
+}
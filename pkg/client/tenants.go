@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Tenant is an organization using the ERP
+type Tenant = authv1.Tenant
+
+// TenantStatus is the lifecycle status of a tenant, see TenantsClient.ChangeStatus
+type TenantStatus = authv1.TenantStatus
+
+// TenantsClient manages the tenants as the user of the session, the tenants other than its own need the
+// permissions of the system tenant
+type TenantsClient struct {
+	client *Client
+}
+
+// Create stores a new tenant and returns its id
+func (t *TenantsClient) Create(ctx context.Context, tenant *Tenant) (string, error) {
+	identity, err := t.client.session.identityOf()
+	if err != nil {
+		return "", err
+	}
+	res, err := t.client.tenants.CreateTenant(ctx, &authv1.CreateTenantRequest{
+		Identifier: identity.identifier(),
+		Tenant:     tenant,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.GetTenantId(), nil
+}
+
+// Get returns the tenant of tenantID
+func (t *TenantsClient) Get(ctx context.Context, tenantID string) (*Tenant, error) {
+	identity, err := t.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	return t.client.tenants.GetTenant(ctx, &authv1.GetTenantRequest{
+		Identifier: identity.identifier(),
+		Tenant:     &authv1.GetTenantRequest_TenantId{TenantId: tenantID},
+	})
+}
+
+// Current returns the tenant of the session
+func (t *TenantsClient) Current(ctx context.Context) (*Tenant, error) {
+	identity, err := t.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	return t.Get(ctx, identity.TenantID)
+}
+
+// List returns the tenants, the tenants of status when it is set
+func (t *TenantsClient) List(ctx context.Context, status string) ([]*Tenant, error) {
+	identity, err := t.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	req := &authv1.ListTenantsRequest{Identifier: identity.identifier()}
+	if status != "" {
+		req.Status = &status
+	}
+	res, err := t.client.tenants.ListTenants(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetTenants(), nil
+}
+
+// Update changes the fields of tenant, named by their proto names, e.g. "name". The whole tenant is replaced when
+// fields is empty
+func (t *TenantsClient) Update(ctx context.Context, tenant *Tenant, fields ...string) error {
+	identity, err := t.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	req := &authv1.UpdateTenantRequest{
+		Identifier: identity.identifier(),
+		Tenant:     tenant,
+	}
+	if len(fields) > 0 {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: fields}
+	}
+	_, err = t.client.tenants.UpdateTenant(ctx, req)
+	return err
+}
+
+// ChangeStatus moves the tenant of tenantID to status, e.g. to suspend it
+func (t *TenantsClient) ChangeStatus(ctx context.Context, tenantID string, status TenantStatus, reason string) error {
+	identity, err := t.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.tenants.ChangeTenantStatus(ctx, &authv1.ChangeTenantStatusRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: tenantID,
+		Status:         status,
+		Reason:         reason,
+	})
+	return err
+}
+
+// Delete removes the tenant of tenantID
+func (t *TenantsClient) Delete(ctx context.Context, tenantID string) error {
+	identity, err := t.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.tenants.DeleteTenant(ctx, &authv1.DeleteTenantRequest{
+		Identifier: identity.identifier(),
+		TenantId:   tenantID,
+	})
+	return err
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	authv1 "erp.localhost/internal/infra/model/auth/v1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rolesUpdateMask names the roles of a user, see UsersClient.AssignRole
+var rolesUpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"roles"}}
+
+// User is a user of a tenant
+type User = authv1.User
+
+// UsersClient manages the users of the tenant of the session, as the user of the session
+type UsersClient struct {
+	client *Client
+}
+
+// Create stores a new user with password, validated against the password policy of the tenant, and returns its id
+func (u *UsersClient) Create(ctx context.Context, user *User, password string) (string, error) {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return "", err
+	}
+	res, err := u.client.users.CreateUser(ctx, &authv1.CreateUserRequest{
+		Identifier: identity.identifier(),
+		User:       user,
+		Password:   password,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.GetUserId(), nil
+}
+
+// Get returns the user of userID
+func (u *UsersClient) Get(ctx context.Context, userID string) (*User, error) {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	return u.client.users.GetUser(ctx, &authv1.GetUserRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: identity.TenantID,
+		AccountId:      userID,
+	})
+}
+
+// Me returns the user of the session
+func (u *UsersClient) Me(ctx context.Context) (*User, error) {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	return u.Get(ctx, identity.UserID)
+}
+
+// List returns the users of the tenant, the users holding roleID when it is set
+func (u *UsersClient) List(ctx context.Context, roleID string) ([]*User, error) {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return nil, err
+	}
+	req := &authv1.ListUsersRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: identity.TenantID,
+	}
+	if roleID != "" {
+		req.RoleId = &roleID
+	}
+	res, err := u.client.users.ListUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetUsers(), nil
+}
+
+// Update changes the fields of user, named by their proto names, e.g. "first_name". The whole user is replaced
+// when fields is empty
+func (u *UsersClient) Update(ctx context.Context, user *User, fields ...string) error {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	req := &authv1.UpdateUserRequest{
+		Identifier: identity.identifier(),
+		User:       user,
+	}
+	if len(fields) > 0 {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: fields}
+	}
+	_, err = u.client.users.UpdateUser(ctx, req)
+	return err
+}
+
+// AssignRole grants roleID of the tenant to the user of userID, the users holding it already are left as they are.
+// The role is held until expiresAt, for good when it is zero
+func (u *UsersClient) AssignRole(ctx context.Context, userID, roleID string, expiresAt time.Time) error {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	user, err := u.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, role := range user.GetRoles() {
+		if role.GetRoleId() == roleID && role.GetTenantId() == user.GetTenantId() {
+			return nil
+		}
+	}
+	role := &authv1.UserRole{
+		RoleId:     roleID,
+		TenantId:   user.GetTenantId(),
+		AssignedAt: timestamppb.Now(),
+		AssignedBy: identity.UserID,
+	}
+	if !expiresAt.IsZero() {
+		role.ExpiresAt = timestamppb.New(expiresAt)
+	}
+	_, err = u.client.users.UpdateUser(ctx, &authv1.UpdateUserRequest{
+		Identifier: identity.identifier(),
+		User: &authv1.User{
+			Id:       user.GetId(),
+			TenantId: user.GetTenantId(),
+			Version:  user.GetVersion(),
+			Roles:    append(user.GetRoles(), role),
+		},
+		UpdateMask: rolesUpdateMask,
+	})
+	return err
+}
+
+// RevokeDevice forgets the device of deviceID of the user of userID and revokes the session issued on it, it
+// reports whether there was one
+func (u *UsersClient) RevokeDevice(ctx context.Context, userID, deviceID string) (bool, error) {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return false, err
+	}
+	res, err := u.client.users.RevokeDevice(ctx, &authv1.RevokeDeviceRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: identity.TenantID,
+		AccountId:      &userID,
+		DeviceId:       deviceID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.GetTokensRevoked(), nil
+}
+
+// Delete removes the user of userID
+func (u *UsersClient) Delete(ctx context.Context, userID string) error {
+	identity, err := u.client.session.identityOf()
+	if err != nil {
+		return err
+	}
+	_, err = u.client.users.DeleteUser(ctx, &authv1.DeleteUserRequest{
+		Identifier:     identity.identifier(),
+		TargetTenantId: identity.TenantID,
+		AccountId:      &userID,
+	})
+	return err
+}